@@ -0,0 +1,124 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// RefWildcard matches any value in one component of a RefPattern
+const RefWildcard = "*"
+
+// RefPattern matches refs by component, where any component may be
+// RefWildcard. It uses the same module:type:id format as Ref:
+//
+//	dnd5e:conditions:*   every dnd5e condition
+//	*:spells:fireball    fireball from any module
+//	dnd5e:*:*            everything dnd5e defines
+type RefPattern struct {
+	Module string
+	Type   string
+	ID     string
+}
+
+// ParseRefPattern parses a pattern string. Each component must be RefWildcard
+// or a valid ref component; a wildcard cannot be mixed into a component ("fire*").
+func ParseRefPattern(s string) (*RefPattern, error) {
+	if s == "" {
+		return nil, NewParseError(s, "", 0, ErrEmptyString)
+	}
+
+	segments := strings.Split(s, separatorChar)
+	if len(segments) < expectedParts {
+		return nil, NewParseError(s, "", 0,
+			fmt.Errorf("%w: expected %d segments, got %d", ErrTooFewSegments, expectedParts, len(segments)))
+	}
+	if len(segments) > expectedParts {
+		return nil, NewParseError(s, "", 0,
+			fmt.Errorf("%w: expected %d segments, got %d", ErrTooManySegments, expectedParts, len(segments)))
+	}
+
+	pattern := &RefPattern{
+		Module: segments[0],
+		Type:   segments[1],
+		ID:     segments[2],
+	}
+	if err := pattern.validate(); err != nil {
+		return nil, err
+	}
+
+	return pattern, nil
+}
+
+// PatternFromRef returns a pattern that matches exactly ref
+func PatternFromRef(ref *Ref) *RefPattern {
+	return &RefPattern{Module: ref.Module, Type: ref.Type, ID: ref.ID}
+}
+
+// String returns the pattern as module:type:id
+func (p *RefPattern) String() string {
+	return fmt.Sprintf("%s:%s:%s", p.Module, p.Type, p.ID)
+}
+
+// Matches reports whether ref matches every non-wildcard component of the pattern
+func (p *RefPattern) Matches(ref *Ref) bool {
+	if p == nil || ref == nil {
+		return false
+	}
+	return matchComponent(p.Module, ref.Module) &&
+		matchComponent(p.Type, ref.Type) &&
+		matchComponent(p.ID, ref.ID)
+}
+
+// IsExact reports whether the pattern has no wildcards
+func (p *RefPattern) IsExact() bool {
+	return p.Module != RefWildcard && p.Type != RefWildcard && p.ID != RefWildcard
+}
+
+// MarshalJSON implements json.Marshaler
+func (p *RefPattern) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (p *RefPattern) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+
+	parsed, err := ParseRefPattern(str)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal ref pattern: %w", err)
+	}
+
+	*p = *parsed
+	return nil
+}
+
+// validate checks each component is a wildcard or a valid ref component
+func (p *RefPattern) validate() error {
+	for _, component := range []struct {
+		field string
+		value string
+	}{
+		{"module", p.Module},
+		{"type", p.Type},
+		{"id", p.ID},
+	} {
+		if component.value == "" {
+			return NewValidationError(component.field, component.value, "cannot be empty", ErrEmptyComponent)
+		}
+		if component.value != RefWildcard && !isValidIdentifierPart(component.value) {
+			return NewValidationError(component.field, component.value,
+				"must be * or contain only letters, digits, underscore, and dash",
+				ErrInvalidCharacters)
+		}
+	}
+	return nil
+}
+
+// matchComponent matches one pattern component against a ref component
+func matchComponent(pattern, value string) bool {
+	return pattern == RefWildcard || pattern == value
+}
@@ -0,0 +1,98 @@
+package core_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRefPattern(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{name: "exact", input: "dnd5e:conditions:raging"},
+		{name: "wildcard id", input: "dnd5e:conditions:*"},
+		{name: "all wildcards", input: "*:*:*"},
+		{name: "empty", input: "", wantErr: core.ErrEmptyString},
+		{name: "too few", input: "dnd5e:*", wantErr: core.ErrTooFewSegments},
+		{name: "too many", input: "dnd5e:*:*:*", wantErr: core.ErrTooManySegments},
+		{name: "empty component", input: "dnd5e::*", wantErr: core.ErrEmptyComponent},
+		{name: "partial wildcard", input: "dnd5e:spells:fire*", wantErr: core.ErrInvalidCharacters},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern, err := core.ParseRefPattern(tt.input)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.input, pattern.String())
+		})
+	}
+}
+
+func TestRefPattern_Matches(t *testing.T) {
+	raging := core.MustNewRef(core.RefInput{Module: "dnd5e", Type: "conditions", ID: "raging"})
+	fireball := core.MustNewRef(core.RefInput{Module: "dnd5e", Type: "spells", ID: "fireball"})
+	homebrew := core.MustNewRef(core.RefInput{Module: "homebrew", Type: "spells", ID: "fireball"})
+
+	tests := []struct {
+		pattern string
+		ref     *core.Ref
+		want    bool
+	}{
+		{"dnd5e:conditions:*", raging, true},
+		{"dnd5e:conditions:*", fireball, false},
+		{"*:spells:fireball", fireball, true},
+		{"*:spells:fireball", homebrew, true},
+		{"dnd5e:*:*", homebrew, false},
+		{"dnd5e:conditions:raging", raging, true},
+		{"*:*:*", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			pattern, err := core.ParseRefPattern(tt.pattern)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, pattern.Matches(tt.ref))
+		})
+	}
+}
+
+func TestRefPattern_FromRef(t *testing.T) {
+	raging := core.MustNewRef(core.RefInput{Module: "dnd5e", Type: "conditions", ID: "raging"})
+
+	pattern := core.PatternFromRef(raging)
+	assert.True(t, pattern.IsExact())
+	assert.True(t, pattern.Matches(raging))
+	assert.Equal(t, raging.String(), pattern.String())
+
+	wild, err := core.ParseRefPattern("dnd5e:conditions:*")
+	require.NoError(t, err)
+	assert.False(t, wild.IsExact())
+}
+
+func TestRefPattern_JSON(t *testing.T) {
+	type grant struct {
+		Immune *core.RefPattern `json:"immune"`
+	}
+
+	var g grant
+	require.NoError(t, json.Unmarshal([]byte(`{"immune":"dnd5e:conditions:*"}`), &g))
+	assert.Equal(t, "*", g.Immune.ID)
+
+	data, err := json.Marshal(g)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"immune":"dnd5e:conditions:*"}`, string(data))
+
+	err = json.Unmarshal([]byte(`{"immune":"dnd5e:conditions"}`), &g)
+	require.ErrorIs(t, err, core.ErrTooFewSegments)
+}
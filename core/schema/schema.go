@@ -0,0 +1,301 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package schema versions the data toolkit types save (the ToData outputs of
+// characters, rooms, environments and conditions) so a toolkit upgrade can
+// migrate old saves instead of failing to load them.
+//
+// Each data shape registers a kind and its current version. Saved data is
+// wrapped in an Envelope recording both:
+//
+//	{"kind":"dnd5e.character","version":2,"data":{...}}
+//
+// When a shape changes, its owner bumps the version and registers a migration
+// from the previous one. Unmarshal runs every migration between the saved
+// version and the current one before decoding. Data saved before envelopes
+// existed (the bare ToData JSON) is read as LegacyVersion.
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// LegacyVersion is the version of data saved without an envelope, and the
+// first version of every kind
+const LegacyVersion = 1
+
+var (
+	// ErrUnknownKind is returned for a kind that was never registered
+	ErrUnknownKind = errors.New("unknown schema kind")
+
+	// ErrAlreadyRegistered is returned when registering a kind or a migration twice
+	ErrAlreadyRegistered = errors.New("schema already registered")
+
+	// ErrInvalidVersion is returned for a version below LegacyVersion, or a
+	// migration that doesn't start below the kind's current version
+	ErrInvalidVersion = errors.New("invalid schema version")
+
+	// ErrKindMismatch is returned when unmarshaling an envelope of another kind
+	ErrKindMismatch = errors.New("schema kind mismatch")
+
+	// ErrNewerVersion is returned for data saved by a newer toolkit than this one
+	ErrNewerVersion = errors.New("schema version is newer than supported")
+
+	// ErrMissingMigration is returned when no migration exists for a step
+	// between the saved version and the current one
+	ErrMissingMigration = errors.New("missing schema migration")
+)
+
+// Envelope wraps saved data with the kind and version it was saved as
+type Envelope struct {
+	Kind    string          `json:"kind"`
+	Version int             `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// Migration transforms data of one version into the next version
+type Migration func(data json.RawMessage) (json.RawMessage, error)
+
+// Registry holds the current version and migrations of each kind.
+// Most callers use the package-level functions, which share a default registry.
+type Registry struct {
+	mu         sync.RWMutex
+	versions   map[string]int
+	migrations map[string]map[int]Migration
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{
+		versions:   make(map[string]int),
+		migrations: make(map[string]map[int]Migration),
+	}
+}
+
+// Register records the current version of a kind
+func (r *Registry) Register(kind string, version int) error {
+	if kind == "" {
+		return fmt.Errorf("%w: kind is required", ErrUnknownKind)
+	}
+	if version < LegacyVersion {
+		return fmt.Errorf("%w: %s version %d", ErrInvalidVersion, kind, version)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.versions[kind]; exists {
+		return fmt.Errorf("%w: %s", ErrAlreadyRegistered, kind)
+	}
+	r.versions[kind] = version
+	return nil
+}
+
+// RegisterMigration records the migration of a kind from version from to from+1
+func (r *Registry) RegisterMigration(kind string, from int, migrate Migration) error {
+	if migrate == nil {
+		return fmt.Errorf("%w: %s migration from %d is nil", ErrMissingMigration, kind, from)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.versions[kind]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownKind, kind)
+	}
+	if from < LegacyVersion || from >= current {
+		return fmt.Errorf("%w: %s migration from %d (current %d)", ErrInvalidVersion, kind, from, current)
+	}
+	if _, exists := r.migrations[kind][from]; exists {
+		return fmt.Errorf("%w: %s migration from %d", ErrAlreadyRegistered, kind, from)
+	}
+
+	if r.migrations[kind] == nil {
+		r.migrations[kind] = make(map[int]Migration)
+	}
+	r.migrations[kind][from] = migrate
+	return nil
+}
+
+// CurrentVersion returns the current version of a kind
+func (r *Registry) CurrentVersion(kind string) (int, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	version, ok := r.versions[kind]
+	return version, ok
+}
+
+// Wrap marshals v and wraps it in an envelope at the kind's current version
+func (r *Registry) Wrap(kind string, v any) (*Envelope, error) {
+	version, ok := r.CurrentVersion(kind)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKind, kind)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("schema: failed to marshal %s: %w", kind, err)
+	}
+	return &Envelope{Kind: kind, Version: version, Data: data}, nil
+}
+
+// Marshal marshals v into envelope JSON at the kind's current version
+func (r *Registry) Marshal(kind string, v any) ([]byte, error) {
+	envelope, err := r.Wrap(kind, v)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(envelope)
+}
+
+// Migrate returns the envelope migrated to its kind's current version.
+// Data already at the current version is not touched.
+func (r *Registry) Migrate(envelope *Envelope) (*Envelope, error) {
+	steps, current, err := r.migrationSteps(envelope.Kind, envelope.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	data := envelope.Data
+	for i, migrate := range steps {
+		version := envelope.Version + i
+		migrated, err := migrate(data)
+		if err != nil {
+			return nil, fmt.Errorf("schema: failed to migrate %s from version %d: %w", envelope.Kind, version, err)
+		}
+		data = migrated
+	}
+
+	return &Envelope{Kind: envelope.Kind, Version: current, Data: data}, nil
+}
+
+// migrationSteps returns the migrations from version to the kind's current
+// version, so they run without holding the lock
+func (r *Registry) migrationSteps(kind string, version int) ([]Migration, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	current, ok := r.versions[kind]
+	if !ok {
+		return nil, 0, fmt.Errorf("%w: %s", ErrUnknownKind, kind)
+	}
+	if version < LegacyVersion {
+		return nil, 0, fmt.Errorf("%w: %s version %d", ErrInvalidVersion, kind, version)
+	}
+	if version > current {
+		return nil, 0, fmt.Errorf("%w: %s version %d (current %d)", ErrNewerVersion, kind, version, current)
+	}
+
+	steps := make([]Migration, 0, current-version)
+	for from := version; from < current; from++ {
+		migrate, ok := r.migrations[kind][from]
+		if !ok {
+			return nil, 0, fmt.Errorf("%w: %s from version %d", ErrMissingMigration, kind, from)
+		}
+		steps = append(steps, migrate)
+	}
+	return steps, current, nil
+}
+
+// Unmarshal decodes saved data of a kind into v, migrating it to the current
+// version first. data may be envelope JSON or bare data saved before
+// envelopes, which is read as LegacyVersion.
+func (r *Registry) Unmarshal(data []byte, kind string, v any) error {
+	envelope, err := Decode(data, kind)
+	if err != nil {
+		return err
+	}
+	if envelope.Kind != kind {
+		return fmt.Errorf("%w: expected %s, got %s", ErrKindMismatch, kind, envelope.Kind)
+	}
+
+	migrated, err := r.Migrate(envelope)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(migrated.Data, v); err != nil {
+		return fmt.Errorf("schema: failed to unmarshal %s: %w", kind, err)
+	}
+	return nil
+}
+
+// Decode reads envelope JSON, or wraps bare data as legacyKind at LegacyVersion.
+// Data is an envelope only if its sole fields are kind, version and data.
+func Decode(data []byte, legacyKind string) (*Envelope, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err == nil && isEnvelope(fields) {
+		var envelope Envelope
+		if err := json.Unmarshal(data, &envelope); err != nil {
+			return nil, fmt.Errorf("schema: invalid envelope: %w", err)
+		}
+		return &envelope, nil
+	}
+
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("schema: invalid %s data", legacyKind)
+	}
+	return &Envelope{Kind: legacyKind, Version: LegacyVersion, Data: data}, nil
+}
+
+// isEnvelope reports whether decoded object fields are exactly an envelope's
+func isEnvelope(fields map[string]json.RawMessage) bool {
+	if len(fields) != 3 {
+		return false
+	}
+	for _, name := range []string{"kind", "version", "data"} {
+		if _, ok := fields[name]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// defaultRegistry backs the package-level functions
+var defaultRegistry = NewRegistry()
+
+// Register records the current version of a kind in the default registry
+func Register(kind string, version int) error {
+	return defaultRegistry.Register(kind, version)
+}
+
+// MustRegister is Register for package initialization; it panics on error
+func MustRegister(kind string, version int) {
+	if err := Register(kind, version); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterMigration records a migration from version from to from+1 in the default registry
+func RegisterMigration(kind string, from int, migrate Migration) error {
+	return defaultRegistry.RegisterMigration(kind, from, migrate)
+}
+
+// CurrentVersion returns the current version of a kind in the default registry
+func CurrentVersion(kind string) (int, bool) {
+	return defaultRegistry.CurrentVersion(kind)
+}
+
+// Wrap wraps v in an envelope at the kind's current version in the default registry
+func Wrap(kind string, v any) (*Envelope, error) {
+	return defaultRegistry.Wrap(kind, v)
+}
+
+// Marshal marshals v into envelope JSON using the default registry
+func Marshal(kind string, v any) ([]byte, error) {
+	return defaultRegistry.Marshal(kind, v)
+}
+
+// Migrate migrates an envelope to its current version using the default registry
+func Migrate(envelope *Envelope) (*Envelope, error) {
+	return defaultRegistry.Migrate(envelope)
+}
+
+// Unmarshal decodes saved data of a kind into v using the default registry
+func Unmarshal(data []byte, kind string, v any) error {
+	return defaultRegistry.Unmarshal(data, kind, v)
+}
@@ -0,0 +1,159 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package schema_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KirkDiggler/rpg-toolkit/core/schema"
+)
+
+const heroKind = "test.hero"
+
+// heroV3 is the current shape: v2 renamed "hp" to "hit_points", v3 split "name"
+type heroV3 struct {
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	HitPoints int    `json:"hit_points"`
+}
+
+func newHeroRegistry(t *testing.T) *schema.Registry {
+	t.Helper()
+	registry := schema.NewRegistry()
+	require.NoError(t, registry.Register(heroKind, 3))
+
+	require.NoError(t, registry.RegisterMigration(heroKind, 1, func(data json.RawMessage) (json.RawMessage, error) {
+		var fields map[string]any
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return nil, err
+		}
+		fields["hit_points"] = fields["hp"]
+		delete(fields, "hp")
+		return json.Marshal(fields)
+	}))
+	require.NoError(t, registry.RegisterMigration(heroKind, 2, func(data json.RawMessage) (json.RawMessage, error) {
+		var fields map[string]any
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return nil, err
+		}
+		fields["first_name"], fields["last_name"] = "Aria", "Vale"
+		delete(fields, "name")
+		return json.Marshal(fields)
+	}))
+	return registry
+}
+
+func TestRegistry_RoundTrip(t *testing.T) {
+	registry := newHeroRegistry(t)
+	hero := heroV3{FirstName: "Aria", LastName: "Vale", HitPoints: 12}
+
+	data, err := registry.Marshal(heroKind, hero)
+	require.NoError(t, err)
+	assert.JSONEq(t,
+		`{"kind":"test.hero","version":3,"data":{"first_name":"Aria","last_name":"Vale","hit_points":12}}`,
+		string(data))
+
+	var loaded heroV3
+	require.NoError(t, registry.Unmarshal(data, heroKind, &loaded))
+	assert.Equal(t, hero, loaded)
+}
+
+func TestRegistry_MigratesOldVersions(t *testing.T) {
+	registry := newHeroRegistry(t)
+	want := heroV3{FirstName: "Aria", LastName: "Vale", HitPoints: 12}
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "bare legacy data", data: `{"name":"Aria Vale","hp":12}`},
+		{name: "version 1 envelope", data: `{"kind":"test.hero","version":1,"data":{"name":"Aria Vale","hp":12}}`},
+		{name: "version 2 envelope", data: `{"kind":"test.hero","version":2,"data":{"name":"Aria Vale","hit_points":12}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var loaded heroV3
+			require.NoError(t, registry.Unmarshal([]byte(tt.data), heroKind, &loaded))
+			assert.Equal(t, want, loaded)
+		})
+	}
+}
+
+func TestRegistry_UnmarshalErrors(t *testing.T) {
+	registry := newHeroRegistry(t)
+	require.NoError(t, registry.Register("test.gapped", 3))
+	require.NoError(t, registry.RegisterMigration("test.gapped", 1, func(data json.RawMessage) (json.RawMessage, error) {
+		return data, nil
+	}))
+	require.NoError(t, registry.Register("test.failing", 2))
+	require.NoError(t, registry.RegisterMigration("test.failing", 1, func(json.RawMessage) (json.RawMessage, error) {
+		return nil, errors.New("corrupt")
+	}))
+
+	tests := []struct {
+		name string
+		kind string
+		data string
+		want error
+	}{
+		{name: "newer version", kind: heroKind, data: `{"kind":"test.hero","version":4,"data":{}}`, want: schema.ErrNewerVersion},
+		{name: "kind mismatch", kind: heroKind, data: `{"kind":"test.room","version":1,"data":{}}`, want: schema.ErrKindMismatch},
+		{name: "unknown kind", kind: "test.unknown", data: `{}`, want: schema.ErrUnknownKind},
+		{name: "version zero", kind: heroKind, data: `{"kind":"test.hero","version":0,"data":{}}`, want: schema.ErrInvalidVersion},
+		{name: "missing step", kind: "test.gapped", data: `{}`, want: schema.ErrMissingMigration},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var loaded heroV3
+			err := registry.Unmarshal([]byte(tt.data), tt.kind, &loaded)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+
+	t.Run("failing migration", func(t *testing.T) {
+		var loaded map[string]any
+		err := registry.Unmarshal([]byte(`{}`), "test.failing", &loaded)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "corrupt")
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		var loaded heroV3
+		assert.Error(t, registry.Unmarshal([]byte(`{nope`), heroKind, &loaded))
+	})
+}
+
+func TestRegistry_RegistrationErrors(t *testing.T) {
+	registry := schema.NewRegistry()
+	noop := func(data json.RawMessage) (json.RawMessage, error) { return data, nil }
+
+	assert.ErrorIs(t, registry.Register(heroKind, 0), schema.ErrInvalidVersion)
+	require.NoError(t, registry.Register(heroKind, 2))
+	assert.ErrorIs(t, registry.Register(heroKind, 3), schema.ErrAlreadyRegistered)
+
+	assert.ErrorIs(t, registry.RegisterMigration("test.unknown", 1, noop), schema.ErrUnknownKind)
+	assert.ErrorIs(t, registry.RegisterMigration(heroKind, 2, noop), schema.ErrInvalidVersion)
+	assert.ErrorIs(t, registry.RegisterMigration(heroKind, 1, nil), schema.ErrMissingMigration)
+	require.NoError(t, registry.RegisterMigration(heroKind, 1, noop))
+	assert.ErrorIs(t, registry.RegisterMigration(heroKind, 1, noop), schema.ErrAlreadyRegistered)
+
+	version, ok := registry.CurrentVersion(heroKind)
+	assert.True(t, ok)
+	assert.Equal(t, 2, version)
+}
+
+func TestDecode_OnlyExactEnvelopes(t *testing.T) {
+	// Bare data that happens to have a version field is not an envelope
+	envelope, err := schema.Decode([]byte(`{"kind":"x","version":"2.0","data":{},"name":"cave"}`), heroKind)
+	require.NoError(t, err)
+	assert.Equal(t, heroKind, envelope.Kind)
+	assert.Equal(t, schema.LegacyVersion, envelope.Version)
+}
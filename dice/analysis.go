@@ -0,0 +1,333 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package dice
+
+import (
+	"fmt"
+	"math"
+)
+
+// negligible is the probability below which a chain of explosions is cut
+// short when computing a distribution. Exploding dice can in principle roll
+// forever; the chains dropped here change no probability a game can notice.
+const negligible = 1e-15
+
+// Distribution is the exact probability distribution of a roll's total,
+// computed without rolling. Encounter balancing uses it to answer questions
+// like "what does 2d6+4 with Great Weapon Fighting deal on average?"
+type Distribution struct {
+	minimum int       // the lowest total
+	probs   []float64 // probs[i] is the probability of totaling minimum+i
+}
+
+// Outcome is one possible total and its probability
+type Outcome struct {
+	Total       int
+	Probability float64
+}
+
+// Distribution returns the distribution of the roll's total, honoring its
+// keep rule, rerolls and explosions. It does not roll the dice or change a
+// roll that has already rolled. Returns the roll's configuration error, if any.
+func (r *Roll) Distribution() (*Distribution, error) {
+	if r.badCfg != nil {
+		return nil, r.badCfg
+	}
+	if r.count == 0 {
+		return constant(0), nil
+	}
+
+	absCount := r.count
+	if absCount < 0 {
+		absCount = -absCount
+	}
+	die := r.dieDistribution()
+
+	var total *Distribution
+	if r.keep == 0 || r.kept >= absCount {
+		total = constant(0)
+		for i := 0; i < absCount; i++ {
+			total = total.Add(die)
+		}
+	} else {
+		total = keepDistribution(die, absCount, r.kept, r.keep)
+	}
+
+	if r.count < 0 {
+		return total.negate(), nil
+	}
+	return total, nil
+}
+
+// Distribution returns the distribution of the pool's total, including its
+// modifier. Returns an error for a spec with a negative count or a die size
+// below 1.
+func (p *Pool) Distribution() (*Distribution, error) {
+	total := constant(p.modifier)
+	for _, spec := range p.dice {
+		if spec.Size <= 0 {
+			return nil, fmt.Errorf("%w: d%d", ErrInvalidDieSize, spec.Size)
+		}
+		if spec.Count < 0 {
+			return nil, fmt.Errorf("%w: %d", ErrInvalidDieCount, spec.Count)
+		}
+		die := uniform(spec.Size)
+		for i := 0; i < spec.Count; i++ {
+			total = total.Add(die)
+		}
+	}
+	return total, nil
+}
+
+// AnalyzeNotation parses dice notation like "2d6+4" and returns the
+// distribution of its total.
+func AnalyzeNotation(notation string) (*Distribution, error) {
+	pool, err := ParseNotation(notation)
+	if err != nil {
+		return nil, err
+	}
+	return pool.Distribution()
+}
+
+// Min returns the lowest possible total
+func (d *Distribution) Min() int {
+	return d.minimum
+}
+
+// Max returns the highest possible total
+func (d *Distribution) Max() int {
+	return d.minimum + len(d.probs) - 1
+}
+
+// Probability returns the chance of rolling exactly total
+func (d *Distribution) Probability(total int) float64 {
+	i := total - d.minimum
+	if i < 0 || i >= len(d.probs) {
+		return 0
+	}
+	return d.probs[i]
+}
+
+// AtLeast returns the chance of rolling total or more, e.g. the chance an
+// attack roll meets an AC
+func (d *Distribution) AtLeast(total int) float64 {
+	chance := 0.0
+	for i := max(total-d.minimum, 0); i < len(d.probs); i++ {
+		chance += d.probs[i]
+	}
+	return math.Min(chance, 1)
+}
+
+// AtMost returns the chance of rolling total or less
+func (d *Distribution) AtMost(total int) float64 {
+	chance := 0.0
+	for i := 0; i < len(d.probs) && i <= total-d.minimum; i++ {
+		chance += d.probs[i]
+	}
+	return math.Min(chance, 1)
+}
+
+// Mean returns the expected total
+func (d *Distribution) Mean() float64 {
+	mean := 0.0
+	for i, p := range d.probs {
+		mean += float64(d.minimum+i) * p
+	}
+	return mean
+}
+
+// Variance returns the variance of the total
+func (d *Distribution) Variance() float64 {
+	mean := d.Mean()
+	variance := 0.0
+	for i, p := range d.probs {
+		diff := float64(d.minimum+i) - mean
+		variance += diff * diff * p
+	}
+	return variance
+}
+
+// StdDev returns the standard deviation of the total
+func (d *Distribution) StdDev() float64 {
+	return math.Sqrt(d.Variance())
+}
+
+// Outcomes returns every possible total with its probability, lowest first
+func (d *Distribution) Outcomes() []Outcome {
+	outcomes := make([]Outcome, 0, len(d.probs))
+	for i, p := range d.probs {
+		if p > 0 {
+			outcomes = append(outcomes, Outcome{Total: d.minimum + i, Probability: p})
+		}
+	}
+	return outcomes
+}
+
+// Add returns the distribution of the sum of two independent totals, e.g.
+// weapon damage plus sneak attack
+func (d *Distribution) Add(other *Distribution) *Distribution {
+	probs := make([]float64, len(d.probs)+len(other.probs)-1)
+	for i, p := range d.probs {
+		if p == 0 {
+			continue
+		}
+		for j, q := range other.probs {
+			probs[i+j] += p * q
+		}
+	}
+	return &Distribution{minimum: d.minimum + other.minimum, probs: probs}
+}
+
+// Shift returns the distribution with n added to every total, e.g. a
+// damage modifier
+func (d *Distribution) Shift(n int) *Distribution {
+	return &Distribution{minimum: d.minimum + n, probs: d.probs}
+}
+
+// negate returns the distribution of the negated total
+func (d *Distribution) negate() *Distribution {
+	probs := make([]float64, len(d.probs))
+	for i, p := range d.probs {
+		probs[len(probs)-1-i] = p
+	}
+	return &Distribution{minimum: -d.Max(), probs: probs}
+}
+
+// trim drops impossible totals below the lowest possible one, such as the
+// faces a reroll-until roll never ends on
+func (d *Distribution) trim() *Distribution {
+	lowest := 0
+	for lowest < len(d.probs)-1 && d.probs[lowest] == 0 {
+		lowest++
+	}
+	return &Distribution{minimum: d.minimum + lowest, probs: d.probs[lowest:]}
+}
+
+// constant returns a distribution that always totals n
+func constant(n int) *Distribution {
+	return &Distribution{minimum: n, probs: []float64{1}}
+}
+
+// uniform returns the distribution of one fair die
+func uniform(size int) *Distribution {
+	probs := make([]float64, size)
+	for i := range probs {
+		probs[i] = 1 / float64(size)
+	}
+	return &Distribution{minimum: 1, probs: probs}
+}
+
+// dieDistribution returns the distribution of one die's value after the
+// roll's rerolls and explosions
+func (r *Roll) dieDistribution() *Distribution {
+	size := float64(r.size)
+	atMost := min(r.reroll.atMost, r.size)
+
+	// faces[f-1] is the chance the die ends on face f
+	faces := make([]float64, r.size)
+	for f := 1; f <= r.size; f++ {
+		switch {
+		case atMost == 0:
+			faces[f-1] = 1 / size
+		case r.reroll.repeat:
+			if f > atMost {
+				faces[f-1] = 1 / float64(r.size-atMost)
+			}
+		default:
+			if f > atMost {
+				faces[f-1] = 1 / size
+			}
+			faces[f-1] += float64(atMost) / size / size
+		}
+	}
+	die := (&Distribution{minimum: 1, probs: faces}).trim()
+	if !r.reroll.explode {
+		return die
+	}
+
+	// Explosion rolls aren't rerolled; each one that shows the maximum
+	// explodes again, up to maxExplosions
+	depth := 1
+	for chain := 1 / size; depth < maxExplosions && chain >= negligible; depth++ {
+		chain /= size
+	}
+	explosion := uniform(r.size)
+	for ; depth > 1; depth-- {
+		explosion = explodeOnce(uniform(r.size), explosion)
+	}
+	return explodeOnce(die, explosion)
+}
+
+// explodeOnce returns die with its maximum face replaced by the maximum plus
+// an explosion
+func explodeOnce(die, explosion *Distribution) *Distribution {
+	top := die.Max()
+	chance := die.probs[len(die.probs)-1]
+	exploded := explosion.Shift(top)
+
+	probs := make([]float64, exploded.Max()-die.minimum+1)
+	copy(probs, die.probs[:len(die.probs)-1])
+	for i, p := range exploded.probs {
+		probs[exploded.minimum-die.minimum+i] += chance * p
+	}
+	return &Distribution{minimum: die.minimum, probs: probs}
+}
+
+// keepDistribution returns the distribution of the sum of the kept dice
+// when count dice with the die distribution roll and kept of them are kept.
+// It assigns dice to values from the first kept to the last: c of the dice
+// left show each value in C(left, c) ways.
+func keepDistribution(die *Distribution, count, kept int, keep keepMode) *Distribution {
+	// sums[j][s] is the chance j dice are assigned with kept sum s
+	maxSum := kept * die.Max()
+	sums := make([][]float64, count+1)
+	for j := range sums {
+		sums[j] = make([]float64, maxSum+1)
+	}
+	sums[0][0] = 1
+
+	for step := range die.probs {
+		i := step
+		if keep == keepHighest {
+			i = len(die.probs) - 1 - step
+		}
+		p := die.probs[i]
+		if p == 0 {
+			continue
+		}
+		value := die.minimum + i
+
+		next := make([][]float64, count+1)
+		for j := range next {
+			next[j] = make([]float64, maxSum+1)
+		}
+		for j := 0; j <= count; j++ {
+			keptSoFar := min(j, kept)
+			for c := 0; j+c <= count; c++ {
+				weight := binomial(count-j, c) * math.Pow(p, float64(c))
+				add := min(c, kept-keptSoFar) * value
+				for s, q := range sums[j] {
+					if q != 0 {
+						next[j+c][s+add] += q * weight
+					}
+				}
+			}
+		}
+		sums = next
+	}
+
+	// Trim the impossible low sums
+	probs := sums[count]
+	lowest := kept * die.minimum
+	return &Distribution{minimum: lowest, probs: probs[lowest:]}
+}
+
+// binomial returns n choose k
+func binomial(n, k int) float64 {
+	result := 1.0
+	for i := 1; i <= k; i++ {
+		result = result * float64(n-k+i) / float64(i)
+	}
+	return result
+}
@@ -0,0 +1,228 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package dice
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+const tolerance = 1e-9
+
+func TestRoll_Distribution(t *testing.T) {
+	tests := []struct {
+		name         string
+		roll         *Roll
+		wantMin      int
+		wantMax      int
+		wantMean     float64
+		wantVariance float64
+	}{
+		{
+			name:         "2d6",
+			roll:         D6(2),
+			wantMin:      2,
+			wantMax:      12,
+			wantMean:     7,
+			wantVariance: 35.0 / 6,
+		},
+		{
+			name:         "negative dice",
+			roll:         D4(-1),
+			wantMin:      -4,
+			wantMax:      -1,
+			wantMean:     -2.5,
+			wantVariance: 1.25,
+		},
+		{
+			name:         "no dice",
+			roll:         D6(0),
+			wantMin:      0,
+			wantMax:      0,
+			wantMean:     0,
+			wantVariance: 0,
+		},
+		{
+			// Each die: faces 3-6 at 1/6, plus 1/3 of a fresh d6
+			name:         "great weapon fighting",
+			roll:         D6(2).RerollOnce(2),
+			wantMin:      2,
+			wantMax:      12,
+			wantMean:     25.0 / 3,
+			wantVariance: 2 * (349.0/18 - (25.0/6)*(25.0/6)),
+		},
+		{
+			name:         "reroll until above",
+			roll:         D6(1).RerollUntilAbove(2),
+			wantMin:      3,
+			wantMax:      6,
+			wantMean:     4.5,
+			wantVariance: 1.25,
+		},
+		{
+			name:         "advantage",
+			roll:         D20Advantage(),
+			wantMin:      1,
+			wantMax:      20,
+			wantMean:     13.825,
+			wantVariance: 22.194375,
+		},
+		{
+			name:         "disadvantage",
+			roll:         D20Disadvantage(),
+			wantMin:      1,
+			wantMax:      20,
+			wantMean:     7.175,
+			wantVariance: 22.194375,
+		},
+		{
+			name:         "4d6 drop lowest",
+			roll:         D6(4).KeepHighest(3),
+			wantMin:      3,
+			wantMax:      18,
+			wantMean:     15869.0 / 1296,
+			wantVariance: 8.1045233,
+		},
+		{
+			name:         "keep more than rolled",
+			roll:         D6(2).KeepHighest(5),
+			wantMin:      2,
+			wantMax:      12,
+			wantMean:     7,
+			wantVariance: 35.0 / 6,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dist, err := tt.roll.Distribution()
+			if err != nil {
+				t.Fatalf("Distribution() error = %v", err)
+			}
+			if dist.Min() != tt.wantMin || dist.Max() != tt.wantMax {
+				t.Errorf("range = [%d, %d], want [%d, %d]", dist.Min(), dist.Max(), tt.wantMin, tt.wantMax)
+			}
+			if math.Abs(dist.Mean()-tt.wantMean) > tolerance {
+				t.Errorf("Mean() = %v, want %v", dist.Mean(), tt.wantMean)
+			}
+			if math.Abs(dist.Variance()-tt.wantVariance) > 1e-6 {
+				t.Errorf("Variance() = %v, want %v", dist.Variance(), tt.wantVariance)
+			}
+			if total := dist.AtLeast(dist.Min()); math.Abs(total-1) > tolerance {
+				t.Errorf("probabilities sum to %v, want 1", total)
+			}
+			if tt.roll.rolled {
+				t.Error("Distribution() rolled the dice")
+			}
+		})
+	}
+}
+
+func TestRoll_DistributionExplode(t *testing.T) {
+	dist, err := D6(1).Explode().Distribution()
+	if err != nil {
+		t.Fatalf("Distribution() error = %v", err)
+	}
+
+	// An exploding d6 averages 3.5 * 6/5 and can never total exactly 6
+	if math.Abs(dist.Mean()-4.2) > tolerance {
+		t.Errorf("Mean() = %v, want 4.2", dist.Mean())
+	}
+	if dist.Probability(6) != 0 {
+		t.Errorf("Probability(6) = %v, want 0", dist.Probability(6))
+	}
+	if math.Abs(dist.Probability(9)-1.0/36) > tolerance {
+		t.Errorf("Probability(9) = %v, want 1/36", dist.Probability(9))
+	}
+	if math.Abs(dist.AtLeast(1)-1) > tolerance {
+		t.Errorf("probabilities sum to %v, want 1", dist.AtLeast(1))
+	}
+}
+
+func TestRoll_DistributionInvalid(t *testing.T) {
+	if _, err := D6(1).KeepHighest(-1).Distribution(); err == nil {
+		t.Error("expected error for a negative keep count")
+	}
+	if _, err := D6(1).RerollUntilAbove(6).Distribution(); err == nil {
+		t.Error("expected error for rerolling every face")
+	}
+}
+
+func TestAnalyzeNotation(t *testing.T) {
+	tests := []struct {
+		notation string
+		wantMin  int
+		wantMax  int
+		wantMean float64
+		total    int
+		wantProb float64
+	}{
+		{notation: "2d6", wantMin: 2, wantMax: 12, wantMean: 7, total: 7, wantProb: 6.0 / 36},
+		{notation: "2d6+4", wantMin: 6, wantMax: 16, wantMean: 11, total: 16, wantProb: 1.0 / 36},
+		{notation: "2d6+1d4+3", wantMin: 6, wantMax: 19, wantMean: 12.5, total: 6, wantProb: 1.0 / 144},
+		{notation: "d20-1", wantMin: 0, wantMax: 19, wantMean: 9.5, total: 20, wantProb: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.notation, func(t *testing.T) {
+			dist, err := AnalyzeNotation(tt.notation)
+			if err != nil {
+				t.Fatalf("AnalyzeNotation() error = %v", err)
+			}
+			if dist.Min() != tt.wantMin || dist.Max() != tt.wantMax {
+				t.Errorf("range = [%d, %d], want [%d, %d]", dist.Min(), dist.Max(), tt.wantMin, tt.wantMax)
+			}
+			if math.Abs(dist.Mean()-tt.wantMean) > tolerance {
+				t.Errorf("Mean() = %v, want %v", dist.Mean(), tt.wantMean)
+			}
+			if math.Abs(dist.Probability(tt.total)-tt.wantProb) > tolerance {
+				t.Errorf("Probability(%d) = %v, want %v", tt.total, dist.Probability(tt.total), tt.wantProb)
+			}
+		})
+	}
+
+	if _, err := AnalyzeNotation("2x6"); !errors.Is(err, ErrInvalidNotation) {
+		t.Errorf("AnalyzeNotation(\"2x6\") error = %v, want ErrInvalidNotation", err)
+	}
+}
+
+func TestDistribution_Combine(t *testing.T) {
+	gwf, err := D6(2).RerollOnce(2).Distribution()
+	if err != nil {
+		t.Fatalf("Distribution() error = %v", err)
+	}
+
+	// Greatsword with Great Weapon Fighting and +4 Strength
+	greatsword := gwf.Shift(4)
+	if math.Abs(greatsword.Mean()-(25.0/3+4)) > tolerance {
+		t.Errorf("Mean() = %v, want %v", greatsword.Mean(), 25.0/3+4)
+	}
+	if math.Abs(greatsword.Variance()-gwf.Variance()) > tolerance {
+		t.Error("Shift() changed the variance")
+	}
+
+	// Rapier plus two sneak attack dice
+	rapier, _ := D8(1).Distribution()
+	sneak, _ := D6(2).Distribution()
+	hit := rapier.Add(sneak)
+	if hit.Min() != 3 || hit.Max() != 20 {
+		t.Errorf("range = [%d, %d], want [3, 20]", hit.Min(), hit.Max())
+	}
+	if math.Abs(hit.Mean()-11.5) > tolerance {
+		t.Errorf("Mean() = %v, want 11.5", hit.Mean())
+	}
+
+	// Advantage hits AC 11 three times in four
+	advantage, _ := D20Advantage().Distribution()
+	if math.Abs(advantage.AtLeast(11)-0.75) > tolerance {
+		t.Errorf("AtLeast(11) = %v, want 0.75", advantage.AtLeast(11))
+	}
+	if math.Abs(advantage.AtMost(10)-0.25) > tolerance {
+		t.Errorf("AtMost(10) = %v, want 0.25", advantage.AtMost(10))
+	}
+	if len(advantage.Outcomes()) != 20 {
+		t.Errorf("Outcomes() has %d totals, want 20", len(advantage.Outcomes()))
+	}
+}
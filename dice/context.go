@@ -0,0 +1,49 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package dice
+
+import "context"
+
+type rollerKey struct{}
+
+// WithRoller returns a context that carries the roller. Rolls made with the
+// default constructors (D20, NewRoll, Fate, NewLazy, ...) and pools rolled
+// with a nil roller use it when they roll with this context, so a test can
+// make a whole call tree deterministic without threading a Roller through it.
+// Rolls given an explicit roller (NewRollWithRoller, ...) keep their own.
+func WithRoller(ctx context.Context, roller Roller) context.Context {
+	return context.WithValue(ctx, rollerKey{}, roller)
+}
+
+// FromContext returns the roller carried by the context, or a new
+// CryptoRoller when there is none.
+func FromContext(ctx context.Context) Roller {
+	if roller, ok := rollerFromContext(ctx); ok {
+		return roller
+	}
+	return NewRoller()
+}
+
+// rollerFromContext returns the context's roller, if it carries one
+func rollerFromContext(ctx context.Context) (Roller, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	roller, ok := ctx.Value(rollerKey{}).(Roller)
+	return roller, ok && roller != nil
+}
+
+// pickRoller returns the roller a roll should use: its own when it was given
+// one explicitly, otherwise the context's, falling back to its default.
+func pickRoller(ctx context.Context, own Roller, explicit bool) Roller {
+	if !explicit {
+		if roller, ok := rollerFromContext(ctx); ok {
+			return roller
+		}
+	}
+	if own == nil {
+		return NewRoller()
+	}
+	return own
+}
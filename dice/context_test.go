@@ -0,0 +1,114 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package dice
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+)
+
+func TestFromContext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRoller := mock_dice.NewMockRoller(ctrl)
+
+	if got := FromContext(WithRoller(context.Background(), mockRoller)); got != mockRoller {
+		t.Errorf("FromContext() = %v, want the context roller", got)
+	}
+	if _, ok := FromContext(context.Background()).(*CryptoRoller); !ok {
+		t.Error("FromContext() without a roller should return a CryptoRoller")
+	}
+	if _, ok := FromContext(WithRoller(context.Background(), nil)).(*CryptoRoller); !ok {
+		t.Error("FromContext() with a nil roller should return a CryptoRoller")
+	}
+}
+
+func TestWithRoller_DefaultRollsUseContext(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect func(m *mock_dice.MockRoller)
+		roll   func(ctx context.Context) int
+		want   int
+	}{
+		{
+			name:   "D20",
+			expect: func(m *mock_dice.MockRoller) { m.EXPECT().RollN(gomock.Any(), 1, 20).Return([]int{17}, nil) },
+			roll:   func(ctx context.Context) int { return D20(1).GetValueWithContext(ctx) },
+			want:   17,
+		},
+		{
+			name: "reroll uses the context roller too",
+			expect: func(m *mock_dice.MockRoller) {
+				gomock.InOrder(
+					m.EXPECT().RollN(gomock.Any(), 1, 6).Return([]int{1}, nil),
+					m.EXPECT().Roll(gomock.Any(), 6).Return(5, nil),
+				)
+			},
+			roll: func(ctx context.Context) int { return D6(1).RerollOnce(1).GetValueWithContext(ctx) },
+			want: 5,
+		},
+		{
+			name:   "advantage",
+			expect: func(m *mock_dice.MockRoller) { m.EXPECT().RollN(gomock.Any(), 2, 20).Return([]int{4, 15}, nil) },
+			roll:   func(ctx context.Context) int { return D20Advantage().GetValueWithContext(ctx) },
+			want:   15,
+		},
+		{
+			name:   "fate",
+			expect: func(m *mock_dice.MockRoller) { m.EXPECT().RollN(gomock.Any(), 2, 3).Return([]int{3, 3}, nil) },
+			roll:   func(ctx context.Context) int { return Fate(2).GetValueWithContext(ctx) },
+			want:   2,
+		},
+		{
+			name:   "lazy",
+			expect: func(m *mock_dice.MockRoller) { m.EXPECT().RollN(gomock.Any(), 2, 6).Return([]int{3, 4}, nil) },
+			roll:   func(ctx context.Context) int { return NewLazy(SimplePool(2, 6, 1)).GetValueWithContext(ctx) },
+			want:   8,
+		},
+		{
+			name:   "pool with nil roller",
+			expect: func(m *mock_dice.MockRoller) { m.EXPECT().RollN(gomock.Any(), 1, 8).Return([]int{6}, nil) },
+			roll:   func(ctx context.Context) int { return SimplePool(1, 8, 2).RollContext(ctx, nil).Total() },
+			want:   8,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRoller := mock_dice.NewMockRoller(ctrl)
+			tt.expect(mockRoller)
+
+			ctx := WithRoller(context.Background(), mockRoller)
+			if got := tt.roll(ctx); got != tt.want {
+				t.Errorf("value = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithRoller_ExplicitRollerWins(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	own := mock_dice.NewMockRoller(ctrl)
+	own.EXPECT().RollN(gomock.Any(), 1, 20).Return([]int{3}, nil)
+	contextRoller := mock_dice.NewMockRoller(ctrl) // never called
+
+	roll, err := NewRollWithRoller(1, 20, own)
+	if err != nil {
+		t.Fatalf("NewRollWithRoller() error = %v", err)
+	}
+	ctx := WithRoller(context.Background(), contextRoller)
+	if got := roll.KeepHighest(1).GetValueWithContext(ctx); got != 3 {
+		t.Errorf("GetValueWithContext() = %d, want 3", got)
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package dice
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Keep values for RollData.Keep
+const (
+	KeepHighest = "highest"
+	KeepLowest  = "lowest"
+)
+
+// RollData is the serializable form of a Roll. It records the roll's
+// configuration and, once rolled, every die it rolled, so a roll made
+// mid-turn can be saved and restored with the same values and description.
+type RollData struct {
+	Count int    `json:"count"`
+	Size  int    `json:"size"`
+	Keep  string `json:"keep,omitempty"` // KeepHighest, KeepLowest, or empty to sum every die
+	Kept  int    `json:"kept,omitempty"`
+	Label string `json:"label,omitempty"`
+
+	RerollAtMost int  `json:"reroll_at_most,omitempty"`
+	RerollRepeat bool `json:"reroll_repeat,omitempty"`
+	Explode      bool `json:"explode,omitempty"`
+
+	// Results, only present once the roll has been made
+	Rolled bool      `json:"rolled"`
+	Result int       `json:"result,omitempty"`
+	Dice   []DieData `json:"dice,omitempty"`
+
+	// Error is the roll's error message, or its invalid option if not yet rolled
+	Error string `json:"error,omitempty"`
+}
+
+// DieData is one rolled die. The last face counts, plus any explosions.
+type DieData struct {
+	Faces    []int `json:"faces"`
+	Exploded []int `json:"exploded,omitempty"`
+	Dropped  bool  `json:"dropped,omitempty"`
+}
+
+// ToData converts the roll to its serializable form.
+// An unrolled roll stays unrolled; ToData never rolls.
+func (r *Roll) ToData() *RollData {
+	data := &RollData{
+		Count:        r.count,
+		Size:         r.size,
+		Kept:         r.kept,
+		Label:        r.label,
+		RerollAtMost: r.reroll.atMost,
+		RerollRepeat: r.reroll.repeat,
+		Explode:      r.reroll.explode,
+		Rolled:       r.rolled,
+	}
+	switch r.keep {
+	case keepHighest:
+		data.Keep = KeepHighest
+	case keepLowest:
+		data.Keep = KeepLowest
+	}
+
+	switch {
+	case r.rolled && r.err != nil:
+		data.Error = r.err.Error()
+	case r.rolled:
+		data.Result = r.result
+		data.Dice = make([]DieData, len(r.rolls))
+		for i, history := range r.histories {
+			data.Dice[i] = DieData{
+				Faces:    append([]int(nil), history.faces...),
+				Exploded: append([]int(nil), history.exploded...),
+				Dropped:  r.dropped[i],
+			}
+		}
+	case r.badCfg != nil:
+		data.Error = r.badCfg.Error()
+	}
+	return data
+}
+
+// FromData restores a roll saved with ToData using a new CryptoRoller.
+// A rolled roll keeps its values; an unrolled one rolls when first read,
+// preferring a roller carried by the context.
+// Returns an error if the data is inconsistent.
+func FromData(data *RollData) (*Roll, error) {
+	roll, err := FromDataWithRoller(data, NewRoller())
+	if err != nil {
+		return nil, err
+	}
+	roll.pinned = false
+	return roll, nil
+}
+
+// FromDataWithRoller restores a roll saved with ToData using a specific roller.
+// Returns an error if roller is nil or the data is inconsistent.
+func FromDataWithRoller(data *RollData, roller Roller) (*Roll, error) {
+	if roller == nil {
+		return nil, ErrNilRoller
+	}
+	if data == nil {
+		return nil, fmt.Errorf("%w: no data", ErrInvalidRollData)
+	}
+	if data.Size <= 0 {
+		return nil, fmt.Errorf("%w %d", ErrInvalidDieSize, data.Size)
+	}
+
+	r := &Roll{
+		count:  data.Count,
+		size:   data.Size,
+		roller: roller,
+		pinned: true,
+		kept:   data.Kept,
+		label:  data.Label,
+		reroll: rerollPolicy{
+			atMost:  data.RerollAtMost,
+			repeat:  data.RerollRepeat,
+			explode: data.Explode,
+		},
+	}
+	switch data.Keep {
+	case "":
+	case KeepHighest:
+		r.keep = keepHighest
+	case KeepLowest:
+		r.keep = keepLowest
+	default:
+		return nil, fmt.Errorf("%w: unknown keep %q", ErrInvalidRollData, data.Keep)
+	}
+
+	if !data.Rolled {
+		if data.Error != "" {
+			r.badCfg = errors.New(data.Error)
+		}
+		return r, nil
+	}
+
+	r.rolled = true
+	if data.Error != "" {
+		r.err = errors.New(data.Error)
+		return r, nil
+	}
+	if err := r.restoreDice(data); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// restoreDice fills in a rolled roll's dice, checking they add up to its result
+func (r *Roll) restoreDice(data *RollData) error {
+	absCount := r.count
+	if absCount < 0 {
+		absCount = -absCount
+	}
+	if len(data.Dice) != absCount {
+		return fmt.Errorf("%w: %d dice recorded for %d rolled", ErrInvalidRollData, len(data.Dice), absCount)
+	}
+
+	r.rolls = make([]int, len(data.Dice))
+	r.histories = make([]dieHistory, len(data.Dice))
+	r.dropped = make([]bool, len(data.Dice))
+	total := 0
+	for i, die := range data.Dice {
+		if len(die.Faces) == 0 {
+			return fmt.Errorf("%w: die %d has no faces", ErrInvalidRollData, i)
+		}
+		history := dieHistory{
+			faces:    append([]int(nil), die.Faces...),
+			exploded: append([]int(nil), die.Exploded...),
+		}
+		r.histories[i] = history
+		r.rolls[i] = history.value()
+		r.dropped[i] = die.Dropped
+		if !die.Dropped {
+			total += r.rolls[i]
+		}
+	}
+	if r.count < 0 {
+		total = -total
+	}
+	if total != data.Result {
+		return fmt.Errorf("%w: dice total %d but result %d", ErrInvalidRollData, total, data.Result)
+	}
+	r.result = total
+	return nil
+}
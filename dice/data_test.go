@@ -0,0 +1,198 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package dice
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+)
+
+func TestRoll_DataRoundTrip(t *testing.T) {
+	tests := []struct {
+		name      string
+		count     int
+		size      int
+		policy    func(*Roll) *Roll
+		rolls     []int
+		rerolls   []int
+		wantValue int
+		wantDesc  string
+	}{
+		{
+			name:      "attack roll",
+			count:     1,
+			size:      20,
+			policy:    func(r *Roll) *Roll { return r },
+			rolls:     []int{17},
+			wantValue: 17,
+			wantDesc:  "+d20[17]=17",
+		},
+		{
+			name:      "negative count",
+			count:     -2,
+			size:      4,
+			policy:    func(r *Roll) *Roll { return r },
+			rolls:     []int{3, 1},
+			wantValue: -4,
+			wantDesc:  "-2d4[3,1]=-4",
+		},
+		{
+			name:      "keep highest",
+			count:     4,
+			size:      6,
+			policy:    func(r *Roll) *Roll { return r.KeepHighest(3) },
+			rolls:     []int{6, 1, 4, 5},
+			wantValue: 15,
+			wantDesc:  "+4d6kh3[6,(1),4,5]=15",
+		},
+		{
+			name:      "rerolled and exploded",
+			count:     2,
+			size:      6,
+			policy:    func(r *Roll) *Roll { return r.RerollOnce(1).Explode() },
+			rolls:     []int{1, 3},
+			rerolls:   []int{6, 2},
+			wantValue: 11,
+			wantDesc:  "+2d6ro1![1>6!+2,3]=11",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			absCount := max(tt.count, -tt.count)
+			mockRoller := mock_dice.NewMockRoller(ctrl)
+			calls := []any{mockRoller.EXPECT().RollN(gomock.Any(), absCount, tt.size).Return(tt.rolls, nil)}
+			for _, reroll := range tt.rerolls {
+				calls = append(calls, mockRoller.EXPECT().Roll(gomock.Any(), tt.size).Return(reroll, nil))
+			}
+			gomock.InOrder(calls...)
+
+			base, err := NewRollWithRoller(tt.count, tt.size, mockRoller)
+			if err != nil {
+				t.Fatalf("NewRollWithRoller() error = %v", err)
+			}
+			roll := tt.policy(base)
+			roll.GetValue()
+
+			raw, err := json.Marshal(roll.ToData())
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			var data RollData
+			if err := json.Unmarshal(raw, &data); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+
+			// A restored roll never rolls again; the controller fails on any call
+			restored, err := FromDataWithRoller(&data, mock_dice.NewMockRoller(ctrl))
+			if err != nil {
+				t.Fatalf("FromDataWithRoller() error = %v", err)
+			}
+			if value := restored.GetValue(); value != tt.wantValue {
+				t.Errorf("GetValue() = %d, want %d", value, tt.wantValue)
+			}
+			if desc := restored.GetDescription(); desc != tt.wantDesc {
+				t.Errorf("GetDescription() = %q, want %q", desc, tt.wantDesc)
+			}
+		})
+	}
+}
+
+func TestRoll_DataUnrolled(t *testing.T) {
+	t.Run("unrolled roll rolls after restore", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		data := D20Advantage().ToData()
+		if data.Rolled || len(data.Dice) != 0 {
+			t.Fatalf("ToData() rolled an unrolled roll: %+v", data)
+		}
+
+		mockRoller := mock_dice.NewMockRoller(ctrl)
+		mockRoller.EXPECT().RollN(gomock.Any(), 2, 20).Return([]int{4, 17}, nil)
+
+		restored, err := FromDataWithRoller(data, mockRoller)
+		if err != nil {
+			t.Fatalf("FromDataWithRoller() error = %v", err)
+		}
+		if desc := restored.GetDescription(); desc != "adv[4,17]=17" {
+			t.Errorf("GetDescription() = %q, want %q", desc, "adv[4,17]=17")
+		}
+	})
+
+	t.Run("invalid option survives", func(t *testing.T) {
+		restored, err := FromData(D6(1).RerollUntilAbove(6).ToData())
+		if err != nil {
+			t.Fatalf("FromData() error = %v", err)
+		}
+		if restored.Err() == nil {
+			t.Error("Err() expected the saved invalid option")
+		}
+	})
+
+	t.Run("roll error survives", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRoller := mock_dice.NewMockRoller(ctrl)
+		mockRoller.EXPECT().RollN(gomock.Any(), 1, 6).Return(nil, errors.New("roller broke"))
+		roll, _ := NewRollWithRoller(1, 6, mockRoller)
+		_ = roll.Err()
+
+		restored, err := FromData(roll.ToData())
+		if err != nil {
+			t.Fatalf("FromData() error = %v", err)
+		}
+		if err := restored.Err(); err == nil || err.Error() != "roller broke" {
+			t.Errorf("Err() = %v, want roller broke", err)
+		}
+	})
+}
+
+func TestFromData_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    *RollData
+		wantErr error
+	}{
+		{name: "nil data", data: nil, wantErr: ErrInvalidRollData},
+		{name: "bad size", data: &RollData{Count: 1, Size: 0}, wantErr: ErrInvalidDieSize},
+		{name: "unknown keep", data: &RollData{Count: 2, Size: 20, Keep: "middle"}, wantErr: ErrInvalidRollData},
+		{
+			name:    "missing dice",
+			data:    &RollData{Count: 2, Size: 6, Rolled: true, Result: 4, Dice: []DieData{{Faces: []int{4}}}},
+			wantErr: ErrInvalidRollData,
+		},
+		{
+			name:    "die without faces",
+			data:    &RollData{Count: 1, Size: 6, Rolled: true, Dice: []DieData{{}}},
+			wantErr: ErrInvalidRollData,
+		},
+		{
+			name:    "result doesn't match dice",
+			data:    &RollData{Count: 1, Size: 6, Rolled: true, Result: 6, Dice: []DieData{{Faces: []int{2}}}},
+			wantErr: ErrInvalidRollData,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := FromData(tt.data); !errors.Is(err, tt.wantErr) {
+				t.Errorf("FromData() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := FromDataWithRoller(&RollData{Count: 1, Size: 6}, nil); !errors.Is(err, ErrNilRoller) {
+		t.Errorf("FromDataWithRoller(nil roller) error = %v, want %v", err, ErrNilRoller)
+	}
+}
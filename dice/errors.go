@@ -21,4 +21,7 @@ var (
 
 	// ErrNilRoller indicates a nil roller was provided
 	ErrNilRoller = errors.New("dice: roller cannot be nil")
+
+	// ErrInvalidRollData indicates saved roll data can't be restored
+	ErrInvalidRollData = errors.New("dice: invalid roll data")
 )
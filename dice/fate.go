@@ -0,0 +1,145 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package dice
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// FateRoll represents Fate (Fudge) dice to be rolled as a modifier. Each die
+// has two blank, two plus and two minus faces, counting 0, +1 and -1, so 4dF
+// totals -4 to +4. Like Roll, it rolls lazily and caches the result.
+type FateRoll struct {
+	count  int
+	roller Roller
+	pinned bool // roller was given explicitly; otherwise a context roller wins
+
+	// Cache the result after first roll
+	rolled bool
+	result int
+	faces  []int // -1, 0 or +1 per die
+	err    error
+}
+
+// Fate creates a roll of count Fate dice, e.g. Fate(4) for the standard 4dF.
+func Fate(count int) *FateRoll {
+	return &FateRoll{count: count, roller: NewRoller()}
+}
+
+// NewFateWithRoller creates a roll of count Fate dice with a specific roller.
+// Each die is a d3 on the roller: 1 is minus, 2 blank and 3 plus.
+// Returns an error if roller is nil.
+func NewFateWithRoller(count int, roller Roller) (*FateRoll, error) {
+	if roller == nil {
+		return nil, ErrNilRoller
+	}
+	return &FateRoll{count: count, roller: roller, pinned: true}, nil
+}
+
+// GetValue rolls the dice (if not already rolled) and returns the total.
+// Subsequent calls return the same value.
+// If an error occurred during rolling, returns 0.
+func (r *FateRoll) GetValue() int {
+	return r.GetValueWithContext(context.Background())
+}
+
+// GetValueWithContext rolls the dice (if not already rolled) and returns the total.
+// Subsequent calls return the same value.
+// If an error occurred during rolling, returns 0.
+func (r *FateRoll) GetValueWithContext(ctx context.Context) int {
+	if !r.rolled {
+		r.roll(ctx)
+	}
+	if r.err != nil {
+		return 0
+	}
+	return r.result
+}
+
+// Err returns any error that occurred during rolling.
+func (r *FateRoll) Err() error {
+	return r.ErrWithContext(context.Background())
+}
+
+// ErrWithContext returns any error that occurred during rolling.
+func (r *FateRoll) ErrWithContext(ctx context.Context) error {
+	if !r.rolled {
+		r.roll(ctx)
+	}
+	return r.err
+}
+
+// Faces returns each die's face: -1, 0 or +1.
+func (r *FateRoll) Faces() []int {
+	if !r.rolled {
+		r.roll(context.Background())
+	}
+	return r.faces
+}
+
+// GetDescription returns a description of the roll in the format
+// "4dF[+--0]=-1", one symbol per die.
+// If an error occurred during rolling, returns an error description.
+func (r *FateRoll) GetDescription() string {
+	return r.GetDescriptionWithContext(context.Background())
+}
+
+// GetDescriptionWithContext returns a description of the roll in the format
+// "4dF[+--0]=-1", one symbol per die.
+// If an error occurred during rolling, returns an error description.
+func (r *FateRoll) GetDescriptionWithContext(ctx context.Context) string {
+	if !r.rolled {
+		r.roll(ctx)
+	}
+
+	if r.err != nil {
+		return fmt.Sprintf("ERROR: %v", r.err)
+	}
+
+	var symbols strings.Builder
+	for _, face := range r.faces {
+		switch face {
+		case 1:
+			symbols.WriteByte('+')
+		case -1:
+			symbols.WriteByte('-')
+		default:
+			symbols.WriteByte('0')
+		}
+	}
+
+	notation := "dF"
+	if r.count != 1 {
+		notation = fmt.Sprintf("%ddF", r.count)
+	}
+	return fmt.Sprintf("%s[%s]=%d", notation, symbols.String(), r.result)
+}
+
+// String implements Stringer interface
+func (r *FateRoll) String() string {
+	return r.GetDescription()
+}
+
+// roll performs the actual dice rolling.
+func (r *FateRoll) roll(ctx context.Context) {
+	r.rolled = true
+	if r.count < 0 {
+		r.err = fmt.Errorf("%w: %d", ErrInvalidDieCount, r.count)
+		return
+	}
+
+	rolls, err := pickRoller(ctx, r.roller, r.pinned).RollN(ctx, r.count, 3)
+	if err != nil {
+		r.err = err
+		return
+	}
+
+	r.faces = make([]int, len(rolls))
+	for i, roll := range rolls {
+		r.faces[i] = roll - 2
+		r.result += r.faces[i]
+	}
+}
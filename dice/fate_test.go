@@ -0,0 +1,118 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package dice
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+)
+
+func TestFateRoll(t *testing.T) {
+	tests := []struct {
+		name      string
+		count     int
+		rolls     []int
+		wantValue int
+		wantFaces []int
+		wantDesc  string
+	}{
+		{
+			name:      "standard 4dF",
+			count:     4,
+			rolls:     []int{3, 1, 1, 2},
+			wantValue: -1,
+			wantFaces: []int{1, -1, -1, 0},
+			wantDesc:  "4dF[+--0]=-1",
+		},
+		{
+			name:      "all plus",
+			count:     4,
+			rolls:     []int{3, 3, 3, 3},
+			wantValue: 4,
+			wantFaces: []int{1, 1, 1, 1},
+			wantDesc:  "4dF[++++]=4",
+		},
+		{
+			name:      "single die",
+			count:     1,
+			rolls:     []int{2},
+			wantValue: 0,
+			wantFaces: []int{0},
+			wantDesc:  "dF[0]=0",
+		},
+		{
+			name:      "no dice",
+			count:     0,
+			rolls:     []int{},
+			wantValue: 0,
+			wantFaces: []int{},
+			wantDesc:  "0dF[]=0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			roller := mock_dice.NewMockRoller(ctrl)
+			roller.EXPECT().RollN(gomock.Any(), tt.count, 3).Return(tt.rolls, nil)
+
+			roll, err := NewFateWithRoller(tt.count, roller)
+			if err != nil {
+				t.Fatalf("NewFateWithRoller() error = %v", err)
+			}
+			if got := roll.GetValue(); got != tt.wantValue {
+				t.Errorf("GetValue() = %d, want %d", got, tt.wantValue)
+			}
+			if got := roll.Faces(); !slices.Equal(got, tt.wantFaces) {
+				t.Errorf("Faces() = %v, want %v", got, tt.wantFaces)
+			}
+			if got := roll.GetDescription(); got != tt.wantDesc {
+				t.Errorf("GetDescription() = %q, want %q", got, tt.wantDesc)
+			}
+			// Cached: a second call doesn't roll again
+			if got := roll.GetValue(); got != tt.wantValue {
+				t.Errorf("second GetValue() = %d, want %d", got, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestFateRoll_Errors(t *testing.T) {
+	if _, err := NewFateWithRoller(4, nil); !errors.Is(err, ErrNilRoller) {
+		t.Errorf("NewFateWithRoller(nil) error = %v, want %v", err, ErrNilRoller)
+	}
+
+	negative := Fate(-1)
+	if !errors.Is(negative.Err(), ErrInvalidDieCount) {
+		t.Errorf("Err() = %v, want %v", negative.Err(), ErrInvalidDieCount)
+	}
+	if negative.GetValue() != 0 {
+		t.Errorf("GetValue() = %d, want 0", negative.GetValue())
+	}
+}
+
+func TestFate_Range(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		roll := Fate(4)
+		value := roll.GetValue()
+		if roll.Err() != nil {
+			t.Fatalf("Err() = %v", roll.Err())
+		}
+		if value < -4 || value > 4 {
+			t.Fatalf("GetValue() = %d, want -4..4", value)
+		}
+		for _, face := range roll.Faces() {
+			if face < -1 || face > 1 {
+				t.Fatalf("face %d, want -1..1", face)
+			}
+		}
+	}
+}
@@ -13,6 +13,7 @@ import (
 type Lazy struct {
 	pool   *Pool
 	roller Roller
+	pinned bool // roller was given explicitly; otherwise a context roller wins
 }
 
 // NewLazy creates a new lazy dice roll from a Pool.
@@ -26,11 +27,12 @@ func NewLazy(pool *Pool) *Lazy {
 // NewLazyWithRoller creates a new lazy dice roll with a specific roller.
 func NewLazyWithRoller(pool *Pool, roller Roller) *Lazy {
 	if roller == nil {
-		roller = NewRoller()
+		return NewLazy(pool)
 	}
 	return &Lazy{
 		pool:   pool,
 		roller: roller,
+		pinned: true,
 	}
 }
 
@@ -51,7 +53,7 @@ func (l *Lazy) GetValue() int {
 
 // GetValueWithContext rolls the dice fresh with context support.
 func (l *Lazy) GetValueWithContext(ctx context.Context) int {
-	result := l.pool.RollContext(ctx, l.roller)
+	result := l.pool.RollContext(ctx, pickRoller(ctx, l.roller, l.pinned))
 	if result.Error() != nil {
 		return 0
 	}
@@ -65,7 +67,7 @@ func (l *Lazy) GetDescription() string {
 
 // GetDescriptionWithContext returns a description with context support.
 func (l *Lazy) GetDescriptionWithContext(ctx context.Context) string {
-	result := l.pool.RollContext(ctx, l.roller)
+	result := l.pool.RollContext(ctx, pickRoller(ctx, l.roller, l.pinned))
 	if result.Error() != nil {
 		return "ERROR: " + result.Error().Error()
 	}
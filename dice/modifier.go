@@ -8,21 +8,39 @@ package dice
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 )
 
+// keepMode selects which rolled dice count toward a roll's result.
+// The zero value sums every die.
+type keepMode int
+
+const (
+	keepHighest keepMode = iota + 1 // keep the highest dice (4d6 drop lowest, advantage)
+	keepLowest                      // keep the lowest dice (disadvantage)
+)
+
 // Roll represents dice to be rolled as a modifier.
 // It implements events.ModifierValue by rolling dice when GetValue() is called.
 type Roll struct {
 	count  int
 	size   int
 	roller Roller
+	pinned bool     // roller was given explicitly; otherwise a context roller wins
+	keep   keepMode // which dice count toward the result
+	kept   int      // how many dice keep keeps
+	label  string   // replaces the notation in descriptions, e.g. "adv"
+	reroll rerollPolicy
+	badCfg error // an invalid option, reported instead of rolling
 
 	// Cache the result after first roll
-	rolled bool
-	result int
-	rolls  []int
-	err    error // Store any error that occurred during rolling
+	rolled    bool
+	result    int
+	rolls     []int
+	histories []dieHistory // histories[i] is how rolls[i] was reached
+	dropped   []bool       // dropped[i] is true when rolls[i] doesn't count
+	err       error        // Store any error that occurred during rolling
 }
 
 // NewRoll creates a new dice roll modifier using a new CryptoRoller.
@@ -52,6 +70,7 @@ func NewRollWithRoller(count, size int, roller Roller) (*Roll, error) {
 		count:  count,
 		size:   size,
 		roller: roller,
+		pinned: true,
 	}, nil
 }
 
@@ -94,6 +113,7 @@ func (r *Roll) ErrWithContext(ctx context.Context) error {
 
 // GetDescription returns a description of the roll in the format:
 // "+2d6[4,2]=6" for positive counts or "-2d6[4,2]=-6" for negative counts.
+// Dropped dice appear in parentheses: "+4d6kh3[6,(1),4,5]=15".
 // If an error occurred during rolling, returns an error description.
 func (r *Roll) GetDescription() string {
 	return r.GetDescriptionWithContext(context.Background())
@@ -101,6 +121,7 @@ func (r *Roll) GetDescription() string {
 
 // GetDescriptionWithContext returns a description of the roll in the format:
 // "+2d6[4,2]=6" for positive counts or "-2d6[4,2]=-6" for negative counts.
+// Dropped dice appear in parentheses: "+4d6kh3[6,(1),4,5]=15".
 // If an error occurred during rolling, returns an error description.
 // The context parameter allows for cancellation during the rolling process.
 func (r *Roll) GetDescriptionWithContext(ctx context.Context) string {
@@ -112,6 +133,20 @@ func (r *Roll) GetDescriptionWithContext(ctx context.Context) string {
 		return fmt.Sprintf("ERROR: %v", r.err)
 	}
 
+	// Build roll list. Advantage and disadvantage show both dice and the one
+	// kept; other rolls mark dropped dice with parentheses.
+	rollStrs := make([]string, len(r.rolls))
+	for i := range r.rolls {
+		if r.dropped[i] && r.label == "" {
+			rollStrs[i] = fmt.Sprintf("(%s)", r.histories[i].String())
+		} else {
+			rollStrs[i] = r.histories[i].String()
+		}
+	}
+	if r.label != "" {
+		return fmt.Sprintf("%s[%s]=%d", r.label, strings.Join(rollStrs, ","), r.result)
+	}
+
 	// Build notation
 	var notation string
 	switch r.count {
@@ -122,11 +157,12 @@ func (r *Roll) GetDescriptionWithContext(ctx context.Context) string {
 	default:
 		notation = fmt.Sprintf("%dd%d", r.count, r.size)
 	}
-
-	// Build roll list
-	rollStrs := make([]string, len(r.rolls))
-	for i, roll := range r.rolls {
-		rollStrs[i] = fmt.Sprintf("%d", roll)
+	notation += r.reroll.notation()
+	switch r.keep {
+	case keepHighest:
+		notation += fmt.Sprintf("kh%d", r.kept)
+	case keepLowest:
+		notation += fmt.Sprintf("kl%d", r.kept)
 	}
 
 	// Format based on positive/negative
@@ -136,12 +172,60 @@ func (r *Roll) GetDescriptionWithContext(ctx context.Context) string {
 	return fmt.Sprintf("%s[%s]=%d", notation, strings.Join(rollStrs, ","), r.result)
 }
 
+// KeepHighest returns a copy of the roll that keeps only the highest n dice,
+// e.g. D6(4).KeepHighest(3) rolls 4d6 and drops the lowest. The dropped dice
+// still appear in the description: "+4d6kh3[6,(1),4,5]=15".
+// If n is negative the roll fails with an error; n >= count keeps every die.
+func (r *Roll) KeepHighest(n int) *Roll {
+	return r.withKeep(keepHighest, n)
+}
+
+// KeepLowest returns a copy of the roll that keeps only the lowest n dice,
+// e.g. D20(2).KeepLowest(1) rolls with disadvantage.
+// If n is negative the roll fails with an error; n >= count keeps every die.
+func (r *Roll) KeepLowest(n int) *Roll {
+	return r.withKeep(keepLowest, n)
+}
+
+// withKeep returns an unrolled copy of the roll with a keep rule
+func (r *Roll) withKeep(keep keepMode, n int) *Roll {
+	roll := r.clone()
+	roll.keep = keep
+	roll.kept = n
+	if n < 0 {
+		roll.badCfg = fmt.Errorf("dice: invalid keep count %d", n)
+	}
+	return roll
+}
+
+// clone returns an unrolled copy of the roll's configuration
+func (r *Roll) clone() *Roll {
+	return &Roll{
+		count:  r.count,
+		size:   r.size,
+		roller: r.roller,
+		pinned: r.pinned,
+		keep:   r.keep,
+		kept:   r.kept,
+		label:  r.label,
+		reroll: r.reroll,
+		badCfg: r.badCfg,
+	}
+}
+
 // roll performs the actual dice rolling.
 func (r *Roll) roll(ctx context.Context) {
+	if r.badCfg != nil {
+		r.err = r.badCfg
+		r.rolled = true
+		return
+	}
 	if r.count == 0 {
 		r.rolled = true
 		r.result = 0
 		r.rolls = []int{}
+		r.histories = []dieHistory{}
+		r.dropped = []bool{}
 		return
 	}
 
@@ -151,18 +235,34 @@ func (r *Roll) roll(ctx context.Context) {
 		absCount = -absCount
 	}
 
-	rolls, err := r.roller.RollN(ctx, absCount, r.size)
+	roller := pickRoller(ctx, r.roller, r.pinned)
+	rolls, err := roller.RollN(ctx, absCount, r.size)
 	if err != nil {
 		r.err = err
 		r.rolled = true
 		return
 	}
+	rolls = slices.Clone(rolls)
+	r.histories = make([]dieHistory, len(rolls))
+	for i, roll := range rolls {
+		history, err := r.reroll.apply(ctx, roller, r.size, roll)
+		if err != nil {
+			r.err = err
+			r.rolled = true
+			return
+		}
+		r.histories[i] = history
+		rolls[i] = history.value()
+	}
 	r.rolls = rolls
+	r.dropped = r.drop(rolls)
 
 	// Calculate total
 	total := 0
-	for _, roll := range r.rolls {
-		total += roll
+	for i, roll := range r.rolls {
+		if !r.dropped[i] {
+			total += roll
+		}
 	}
 
 	// Apply sign
@@ -175,6 +275,31 @@ func (r *Roll) roll(ctx context.Context) {
 	r.rolled = true
 }
 
+// drop marks the dice the keep rule drops. Among equal dice, the first rolled
+// is dropped first.
+func (r *Roll) drop(rolls []int) []bool {
+	dropped := make([]bool, len(rolls))
+	if r.keep == 0 || r.kept >= len(rolls) {
+		return dropped
+	}
+
+	// Order dice from first to drop to last
+	order := make([]int, len(rolls))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		if r.keep == keepHighest {
+			return rolls[a] - rolls[b]
+		}
+		return rolls[b] - rolls[a]
+	})
+	for _, i := range order[:len(rolls)-r.kept] {
+		dropped[i] = true
+	}
+	return dropped
+}
+
 // Helper functions for common dice
 
 // D4 creates a d4 roll modifier.
@@ -225,3 +350,33 @@ func D100(count int) *Roll {
 	roll, _ := NewRoll(count, 100)
 	return roll
 }
+
+// D20Advantage creates a d20 roll with advantage: two d20s, keeping the higher.
+// Its description records both dice, e.g. "adv[17,4]=17".
+func D20Advantage() *Roll {
+	return &Roll{count: 2, size: 20, roller: NewRoller(), keep: keepHighest, kept: 1, label: "adv"}
+}
+
+// D20Disadvantage creates a d20 roll with disadvantage: two d20s, keeping the
+// lower. Its description records both dice, e.g. "dis[17,4]=4".
+func D20Disadvantage() *Roll {
+	return &Roll{count: 2, size: 20, roller: NewRoller(), keep: keepLowest, kept: 1, label: "dis"}
+}
+
+// NewD20AdvantageWithRoller creates a d20 roll with advantage using a specific roller.
+// Returns an error if roller is nil.
+func NewD20AdvantageWithRoller(roller Roller) (*Roll, error) {
+	if roller == nil {
+		return nil, fmt.Errorf("dice: roller cannot be nil")
+	}
+	return &Roll{count: 2, size: 20, roller: roller, pinned: true, keep: keepHighest, kept: 1, label: "adv"}, nil
+}
+
+// NewD20DisadvantageWithRoller creates a d20 roll with disadvantage using a specific roller.
+// Returns an error if roller is nil.
+func NewD20DisadvantageWithRoller(roller Roller) (*Roll, error) {
+	if roller == nil {
+		return nil, fmt.Errorf("dice: roller cannot be nil")
+	}
+	return &Roll{count: 2, size: 20, roller: roller, pinned: true, keep: keepLowest, kept: 1, label: "dis"}, nil
+}
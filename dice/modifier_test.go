@@ -418,3 +418,156 @@ func TestRoll_Err_BeforeRoll(t *testing.T) {
 		t.Error("roll.Err() should have triggered a roll")
 	}
 }
+
+func TestRoll_AdvantageDisadvantage(t *testing.T) {
+	tests := []struct {
+		name         string
+		newRoll      func(Roller) (*Roll, error)
+		rolls        []int
+		wantValue    int
+		expectedDesc string
+	}{
+		{"advantage keeps higher", NewD20AdvantageWithRoller, []int{17, 4}, 17, "adv[17,4]=17"},
+		{"advantage second die higher", NewD20AdvantageWithRoller, []int{3, 12}, 12, "adv[3,12]=12"},
+		{"disadvantage keeps lower", NewD20DisadvantageWithRoller, []int{17, 4}, 4, "dis[17,4]=4"},
+		{"disadvantage ties", NewD20DisadvantageWithRoller, []int{9, 9}, 9, "dis[9,9]=9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRoller := mock_dice.NewMockRoller(ctrl)
+			mockRoller.EXPECT().RollN(gomock.Any(), 2, 20).Return(tt.rolls, nil).Times(1)
+
+			roll, err := tt.newRoll(mockRoller)
+			if err != nil {
+				t.Fatalf("constructor error = %v", err)
+			}
+
+			if value := roll.GetValue(); value != tt.wantValue {
+				t.Errorf("GetValue() = %d, want %d", value, tt.wantValue)
+			}
+			if desc := roll.GetDescription(); desc != tt.expectedDesc {
+				t.Errorf("GetDescription() = %q, want %q", desc, tt.expectedDesc)
+			}
+		})
+	}
+
+	t.Run("nil roller", func(t *testing.T) {
+		if _, err := NewD20AdvantageWithRoller(nil); err == nil {
+			t.Error("NewD20AdvantageWithRoller(nil) expected error")
+		}
+		if _, err := NewD20DisadvantageWithRoller(nil); err == nil {
+			t.Error("NewD20DisadvantageWithRoller(nil) expected error")
+		}
+	})
+
+	t.Run("helpers roll real dice", func(t *testing.T) {
+		for _, roll := range []*Roll{D20Advantage(), D20Disadvantage()} {
+			if value := roll.GetValue(); value < 1 || value > 20 {
+				t.Errorf("GetValue() = %d, want 1-20", value)
+			}
+			if err := roll.Err(); err != nil {
+				t.Errorf("Err() = %v", err)
+			}
+		}
+	})
+}
+
+func TestRoll_KeepDrop(t *testing.T) {
+	tests := []struct {
+		name      string
+		count     int
+		keep      func(*Roll) *Roll
+		rolls     []int
+		wantValue int
+		wantDesc  string
+	}{
+		{
+			name:      "4d6 drop lowest",
+			count:     4,
+			keep:      func(r *Roll) *Roll { return r.KeepHighest(3) },
+			rolls:     []int{6, 1, 4, 5},
+			wantValue: 15,
+			wantDesc:  "+4d6kh3[6,(1),4,5]=15",
+		},
+		{
+			name:      "ties drop the first rolled",
+			count:     4,
+			keep:      func(r *Roll) *Roll { return r.KeepHighest(2) },
+			rolls:     []int{3, 3, 5, 3},
+			wantValue: 8,
+			wantDesc:  "+4d6kh2[(3),(3),5,3]=8",
+		},
+		{
+			name:      "keep lowest",
+			count:     3,
+			keep:      func(r *Roll) *Roll { return r.KeepLowest(1) },
+			rolls:     []int{4, 2, 6},
+			wantValue: 2,
+			wantDesc:  "+3d6kl1[(4),2,(6)]=2",
+		},
+		{
+			name:      "keep more than rolled keeps every die",
+			count:     2,
+			keep:      func(r *Roll) *Roll { return r.KeepHighest(5) },
+			rolls:     []int{4, 2},
+			wantValue: 6,
+			wantDesc:  "+2d6kh5[4,2]=6",
+		},
+		{
+			name:      "negative count",
+			count:     -2,
+			keep:      func(r *Roll) *Roll { return r.KeepHighest(1) },
+			rolls:     []int{4, 2},
+			wantValue: -4,
+			wantDesc:  "-2d6kh1[4,(2)]=-4",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRoller := mock_dice.NewMockRoller(ctrl)
+			abs := tt.count
+			if abs < 0 {
+				abs = -abs
+			}
+			mockRoller.EXPECT().RollN(gomock.Any(), abs, 6).Return(tt.rolls, nil).Times(1)
+
+			base, err := NewRollWithRoller(tt.count, 6, mockRoller)
+			if err != nil {
+				t.Fatalf("NewRollWithRoller() error = %v", err)
+			}
+			roll := tt.keep(base)
+
+			if value := roll.GetValue(); value != tt.wantValue {
+				t.Errorf("GetValue() = %d, want %d", value, tt.wantValue)
+			}
+			if desc := roll.GetDescription(); desc != tt.wantDesc {
+				t.Errorf("GetDescription() = %q, want %q", desc, tt.wantDesc)
+			}
+		})
+	}
+
+	t.Run("negative keep count", func(t *testing.T) {
+		roll := D6(4).KeepHighest(-1)
+		if roll.Err() == nil {
+			t.Error("KeepHighest(-1) expected error")
+		}
+		if value := roll.GetValue(); value != 0 {
+			t.Errorf("GetValue() = %d, want 0", value)
+		}
+	})
+
+	t.Run("helpers keep real dice", func(t *testing.T) {
+		roll := D6(4).KeepHighest(3)
+		if value := roll.GetValue(); value < 3 || value > 18 {
+			t.Errorf("GetValue() = %d, want 3-18", value)
+		}
+	})
+}
@@ -64,10 +64,11 @@ func (p *Pool) Roll(roller Roller) *Result {
 	return p.RollContext(context.Background(), roller)
 }
 
-// RollContext performs a fresh roll with context support
+// RollContext performs a fresh roll with context support.
+// A nil roller uses the context's roller (see WithRoller), or a CryptoRoller.
 func (p *Pool) RollContext(ctx context.Context, roller Roller) *Result {
 	if roller == nil {
-		roller = NewRoller()
+		roller = FromContext(ctx)
 	}
 
 	result := &Result{
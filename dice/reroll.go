@@ -0,0 +1,131 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package dice
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxExplosions caps how many times one die explodes, so a roller that
+// always rolls the maximum can't loop forever
+const maxExplosions = 100
+
+// rerollPolicy is how a Roll rerolls and explodes its dice. The zero value
+// keeps every die as rolled.
+type rerollPolicy struct {
+	atMost  int  // reroll dice showing this or less; 0 rerolls nothing
+	repeat  bool // keep rerolling until the die shows more than atMost
+	explode bool // roll another die, and add it, whenever a die shows its maximum
+}
+
+// dieHistory is how one die reached its value
+type dieHistory struct {
+	faces    []int // every face rolled before explosions; the last one counts
+	exploded []int // extra dice added by explosions
+}
+
+// value returns the die's value: its last face plus any explosions
+func (h dieHistory) value() int {
+	value := h.faces[len(h.faces)-1]
+	for _, extra := range h.exploded {
+		value += extra
+	}
+	return value
+}
+
+// String renders the history: rerolled faces lead to the kept one ("1>5")
+// and explosions follow a "!" ("6!+6!+2")
+func (h dieHistory) String() string {
+	parts := make([]string, len(h.faces))
+	for i, face := range h.faces {
+		parts[i] = strconv.Itoa(face)
+	}
+	result := strings.Join(parts, ">")
+	for _, extra := range h.exploded {
+		result += "!+" + strconv.Itoa(extra)
+	}
+	return result
+}
+
+// notation renders the policy as a notation suffix: "ro2" rerolls 2 or less
+// once, "r2" rerolls until above 2, "!" explodes
+func (p rerollPolicy) notation() string {
+	var notation string
+	switch {
+	case p.atMost > 0 && p.repeat:
+		notation = fmt.Sprintf("r%d", p.atMost)
+	case p.atMost > 0:
+		notation = fmt.Sprintf("ro%d", p.atMost)
+	}
+	if p.explode {
+		notation += "!"
+	}
+	return notation
+}
+
+// apply rerolls and explodes one die
+func (p rerollPolicy) apply(ctx context.Context, roller Roller, size, face int) (dieHistory, error) {
+	history := dieHistory{faces: []int{face}}
+
+	for face <= p.atMost {
+		next, err := roller.Roll(ctx, size)
+		if err != nil {
+			return history, err
+		}
+		face = next
+		history.faces = append(history.faces, face)
+		if !p.repeat {
+			break
+		}
+	}
+
+	for last := face; p.explode && last == size && len(history.exploded) < maxExplosions; {
+		next, err := roller.Roll(ctx, size)
+		if err != nil {
+			return history, err
+		}
+		history.exploded = append(history.exploded, next)
+		last = next
+	}
+	return history, nil
+}
+
+// RerollOnce returns a copy of the roll that rerolls each die showing n or
+// less once, keeping the new roll even if it's lower. Great Weapon Fighting
+// is D6(2).RerollOnce(2); its description shows the rerolls: "+2d6ro2[1>5,4]=9".
+func (r *Roll) RerollOnce(n int) *Roll {
+	roll := r.clone()
+	roll.reroll.atMost = max(n, 0)
+	roll.reroll.repeat = false
+	return roll
+}
+
+// RerollUntilAbove returns a copy of the roll that rerolls each die until it
+// shows more than n. The roll fails with an error if n leaves no face to stop
+// on (n >= die size).
+func (r *Roll) RerollUntilAbove(n int) *Roll {
+	roll := r.clone()
+	roll.reroll.atMost = max(n, 0)
+	roll.reroll.repeat = true
+	if n >= r.size {
+		roll.badCfg = fmt.Errorf("dice: cannot reroll every face of a d%d", r.size)
+	}
+	return roll
+}
+
+// Explode returns a copy of the roll that rolls another die, and adds it,
+// whenever a die shows its maximum: "+1d6![6!+6!+2]=14". Explosions apply
+// after rerolls, and the exploded total is one die for KeepHighest and
+// KeepLowest. The roll fails with an error on a d1, which would always explode.
+func (r *Roll) Explode() *Roll {
+	roll := r.clone()
+	roll.reroll.explode = true
+	if r.size <= 1 {
+		roll.badCfg = fmt.Errorf("dice: cannot explode a d%d", r.size)
+	}
+	return roll
+}
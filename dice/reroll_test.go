@@ -0,0 +1,177 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package dice
+
+import (
+	"errors"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+)
+
+func TestRoll_RerollAndExplode(t *testing.T) {
+	tests := []struct {
+		name      string
+		count     int
+		size      int
+		policy    func(*Roll) *Roll
+		rolls     []int // initial RollN result
+		rerolls   []int // later single Roll results, in order
+		wantValue int
+		wantDesc  string
+	}{
+		{
+			name:      "great weapon fighting rerolls once",
+			count:     2,
+			size:      6,
+			policy:    func(r *Roll) *Roll { return r.RerollOnce(2) },
+			rolls:     []int{1, 4},
+			rerolls:   []int{5},
+			wantValue: 9,
+			wantDesc:  "+2d6ro2[1>5,4]=9",
+		},
+		{
+			name:      "reroll once keeps a low reroll",
+			count:     1,
+			size:      6,
+			policy:    func(r *Roll) *Roll { return r.RerollOnce(2) },
+			rolls:     []int{2},
+			rerolls:   []int{1},
+			wantValue: 1,
+			wantDesc:  "+d6ro2[2>1]=1",
+		},
+		{
+			name:      "reroll until above",
+			count:     1,
+			size:      6,
+			policy:    func(r *Roll) *Roll { return r.RerollUntilAbove(2) },
+			rolls:     []int{1},
+			rerolls:   []int{2, 1, 3},
+			wantValue: 3,
+			wantDesc:  "+d6r2[1>2>1>3]=3",
+		},
+		{
+			name:      "explode on max",
+			count:     2,
+			size:      6,
+			policy:    func(r *Roll) *Roll { return r.Explode() },
+			rolls:     []int{6, 3},
+			rerolls:   []int{6, 2},
+			wantValue: 17,
+			wantDesc:  "+2d6![6!+6!+2,3]=17",
+		},
+		{
+			name:      "reroll then explode",
+			count:     1,
+			size:      6,
+			policy:    func(r *Roll) *Roll { return r.RerollOnce(1).Explode() },
+			rolls:     []int{1},
+			rerolls:   []int{6, 4},
+			wantValue: 10,
+			wantDesc:  "+d6ro1![1>6!+4]=10",
+		},
+		{
+			name:      "exploded total is one die for keep",
+			count:     2,
+			size:      4,
+			policy:    func(r *Roll) *Roll { return r.Explode().KeepHighest(1) },
+			rolls:     []int{4, 3},
+			rerolls:   []int{1},
+			wantValue: 5,
+			wantDesc:  "+2d4!kh1[4!+1,(3)]=5",
+		},
+		{
+			name:      "no rerolls needed",
+			count:     2,
+			size:      6,
+			policy:    func(r *Roll) *Roll { return r.RerollOnce(2) },
+			rolls:     []int{3, 4},
+			wantValue: 7,
+			wantDesc:  "+2d6ro2[3,4]=7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRoller := mock_dice.NewMockRoller(ctrl)
+			calls := []any{mockRoller.EXPECT().RollN(gomock.Any(), tt.count, tt.size).Return(tt.rolls, nil)}
+			for _, reroll := range tt.rerolls {
+				calls = append(calls, mockRoller.EXPECT().Roll(gomock.Any(), tt.size).Return(reroll, nil))
+			}
+			gomock.InOrder(calls...)
+
+			base, err := NewRollWithRoller(tt.count, tt.size, mockRoller)
+			if err != nil {
+				t.Fatalf("NewRollWithRoller() error = %v", err)
+			}
+			roll := tt.policy(base)
+
+			if value := roll.GetValue(); value != tt.wantValue {
+				t.Errorf("GetValue() = %d, want %d", value, tt.wantValue)
+			}
+			if desc := roll.GetDescription(); desc != tt.wantDesc {
+				t.Errorf("GetDescription() = %q, want %q", desc, tt.wantDesc)
+			}
+		})
+	}
+}
+
+func TestRoll_RerollPolicyErrors(t *testing.T) {
+	t.Run("reroll every face", func(t *testing.T) {
+		if err := D6(1).RerollUntilAbove(6).Err(); err == nil {
+			t.Error("RerollUntilAbove(6) on a d6 expected error")
+		}
+	})
+
+	t.Run("explode a d1", func(t *testing.T) {
+		roll, err := NewRoll(1, 1)
+		if err != nil {
+			t.Fatalf("NewRoll() error = %v", err)
+		}
+		if err := roll.Explode().Err(); err == nil {
+			t.Error("Explode() on a d1 expected error")
+		}
+	})
+
+	t.Run("explosions are capped", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRoller := mock_dice.NewMockRoller(ctrl)
+		mockRoller.EXPECT().RollN(gomock.Any(), 1, 6).Return([]int{6}, nil)
+		mockRoller.EXPECT().Roll(gomock.Any(), 6).Return(6, nil).Times(maxExplosions)
+
+		roll, _ := NewRollWithRoller(1, 6, mockRoller)
+		if value := roll.Explode().GetValue(); value != 6*(maxExplosions+1) {
+			t.Errorf("GetValue() = %d, want %d", value, 6*(maxExplosions+1))
+		}
+	})
+
+	t.Run("reroll error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRoller := mock_dice.NewMockRoller(ctrl)
+		mockRoller.EXPECT().RollN(gomock.Any(), 1, 6).Return([]int{1}, nil)
+		mockRoller.EXPECT().Roll(gomock.Any(), 6).Return(0, errors.New("roller broke"))
+
+		roll, _ := NewRollWithRoller(1, 6, mockRoller)
+		if err := roll.RerollOnce(1).Err(); err == nil || err.Error() != "roller broke" {
+			t.Errorf("Err() = %v, want roller broke", err)
+		}
+	})
+
+	t.Run("policies don't touch the original", func(t *testing.T) {
+		base := D6(2)
+		_ = base.RerollOnce(2).Explode()
+		if notation := base.reroll.notation(); notation != "" {
+			t.Errorf("base roll notation = %q, want none", notation)
+		}
+	})
+}
@@ -6,6 +6,7 @@ package dice
 import (
 	"context"
 	"crypto/rand"
+	"encoding/binary"
 	"fmt"
 	"math/big"
 )
@@ -45,7 +46,13 @@ func (c *CryptoRoller) Roll(_ context.Context, size int) (int, error) {
 	return int(n.Int64()) + 1, nil
 }
 
-// RollN rolls multiple dice using crypto/rand.
+// rollBatchSize caps how many dice one crypto/rand read covers, bounding the
+// buffer for huge pools while keeping reads to one per batch
+const rollBatchSize = 512
+
+// RollN rolls multiple dice using crypto/rand. Instead of one crypto/rand call
+// per die, it reads the entropy for up to rollBatchSize dice at once and maps
+// each 8 bytes to a face without modulo bias.
 func (c *CryptoRoller) RollN(ctx context.Context, count, size int) ([]int, error) {
 	if size <= 0 {
 		return nil, fmt.Errorf("dice: invalid die size %d", size)
@@ -55,16 +62,40 @@ func (c *CryptoRoller) RollN(ctx context.Context, count, size int) ([]int, error
 	}
 
 	results := make([]int, count)
-	for i := 0; i < count; i++ {
+	buf := make([]byte, 8*min(count, rollBatchSize))
+	for start := 0; start < count; start += rollBatchSize {
 		// Check for cancellation
 		if err := ctx.Err(); err != nil {
 			return nil, fmt.Errorf("dice: rolling cancelled: %w", err)
 		}
-		roll, err := c.Roll(ctx, size)
-		if err != nil {
-			return nil, err
+
+		n := min(rollBatchSize, count-start)
+		entropy := buf[:8*n]
+		if _, err := rand.Read(entropy); err != nil {
+			return nil, fmt.Errorf("dice: crypto/rand error: %w", err)
+		}
+		for i := range n {
+			face, ok := uniformFace(binary.LittleEndian.Uint64(entropy[8*i:]), size)
+			if !ok {
+				// Rejected to avoid bias (odds under size in 2^64); roll this die alone
+				roll, err := c.Roll(ctx, size)
+				if err != nil {
+					return nil, err
+				}
+				face = roll
+			}
+			results[start+i] = face
 		}
-		results[i] = roll
 	}
 	return results, nil
 }
+
+// uniformFace maps a random 64-bit value to a face from 1 to size. Values
+// below 2^64 mod size are rejected so every face is equally likely.
+func uniformFace(value uint64, size int) (int, bool) {
+	faces := uint64(size)
+	if value < -faces%faces {
+		return 0, false
+	}
+	return int(value%faces) + 1, true
+}
@@ -191,3 +191,102 @@ func TestNewMockableRoller(t *testing.T) {
 		t.Fatal("NewMockableRoller(nil) returned nil")
 	}
 }
+
+func TestUniformFace(t *testing.T) {
+	// 2^64 mod 6 is 4, so 0-3 are rejected to keep every face equally likely
+	tests := []struct {
+		name   string
+		value  uint64
+		size   int
+		want   int
+		wantOK bool
+	}{
+		{name: "rejected below threshold", value: 3, size: 6, wantOK: false},
+		{name: "first accepted value", value: 4, size: 6, want: 5, wantOK: true},
+		{name: "wraps around faces", value: 6, size: 6, want: 1, wantOK: true},
+		{name: "max value", value: ^uint64(0), size: 6, want: 4, wantOK: true},
+		{name: "power of two never rejects", value: 0, size: 8, want: 1, wantOK: true},
+		{name: "d1", value: 12345, size: 1, want: 1, wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := uniformFace(tt.value, tt.size)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("uniformFace(%d, %d) = %d, %v, want %d, %v", tt.value, tt.size, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestCryptoRoller_RollNBatches(t *testing.T) {
+	roller := &CryptoRoller{}
+
+	t.Run("spans several batches", func(t *testing.T) {
+		count := rollBatchSize*3 + 7
+		results, err := roller.RollN(context.Background(), count, 6)
+		if err != nil {
+			t.Fatalf("RollN(%d, 6) error = %v", count, err)
+		}
+		if len(results) != count {
+			t.Fatalf("RollN(%d, 6) returned %d results", count, len(results))
+		}
+
+		// Every face should show up roughly count/6 times
+		faces := make(map[int]int)
+		for _, result := range results {
+			if result < 1 || result > 6 {
+				t.Fatalf("RollN result %d out of range", result)
+			}
+			faces[result]++
+		}
+		for face := 1; face <= 6; face++ {
+			if faces[face] < count/12 {
+				t.Errorf("face %d rolled %d times in %d dice", face, faces[face], count)
+			}
+		}
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := roller.RollN(ctx, 20, 6); err == nil {
+			t.Error("RollN with a cancelled context expected error")
+		}
+	})
+}
+
+// rollEach is the unbatched baseline: one crypto/rand call per die
+func rollEach(ctx context.Context, roller *CryptoRoller, count, size int) ([]int, error) {
+	results := make([]int, count)
+	for i := range results {
+		roll, err := roller.Roll(ctx, size)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = roll
+	}
+	return results, nil
+}
+
+func BenchmarkCryptoRoller_RollN(b *testing.B) {
+	roller := &CryptoRoller{}
+	ctx := context.Background()
+
+	for _, count := range []int{1, 20, 100, 1000} {
+		b.Run(fmt.Sprintf("batched/%dd6", count), func(b *testing.B) {
+			for b.Loop() {
+				if _, err := roller.RollN(ctx, count, 6); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("per-die/%dd6", count), func(b *testing.B) {
+			for b.Loop() {
+				if _, err := rollEach(ctx, roller, count, 6); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
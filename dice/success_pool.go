@@ -0,0 +1,195 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package dice
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BotchRule decides when a success-counting roll goes badly wrong
+type BotchRule int
+
+const (
+	// BotchNone counts successes and never botches
+	BotchNone BotchRule = iota
+
+	// BotchOnesCancel is the classic World of Darkness rule: each 1 cancels a
+	// success, and a roll with no successes and at least one 1 botches.
+	BotchOnesCancel
+
+	// BotchGlitch is the Shadowrun rule: the roll glitches when more than
+	// half the dice show 1, and botches (a critical glitch) when it glitches
+	// with no successes. 1s don't cancel successes.
+	BotchGlitch
+)
+
+// SuccessPool rolls a number of dice against a target number and counts the
+// dice that meet it, as World of Darkness and Shadowrun do. Like Pool, it
+// doesn't cache: each Roll produces fresh results.
+type SuccessPool struct {
+	count  int
+	size   int
+	target int
+	botch  BotchRule
+}
+
+// NewSuccessPool creates a pool of count dice of a size. Only the die's
+// maximum succeeds until Target sets a target number.
+func NewSuccessPool(count, size int) *SuccessPool {
+	return &SuccessPool{count: count, size: size, target: size}
+}
+
+// Target returns a copy of the pool where a die showing n or more is a
+// success, e.g. NewSuccessPool(7, 10).Target(8)
+func (p *SuccessPool) Target(n int) *SuccessPool {
+	pool := *p
+	pool.target = n
+	return &pool
+}
+
+// Botch returns a copy of the pool that applies a botch rule
+func (p *SuccessPool) Botch(rule BotchRule) *SuccessPool {
+	pool := *p
+	pool.botch = rule
+	return &pool
+}
+
+// Notation returns the pool's notation, e.g. "7d10>=8"
+func (p *SuccessPool) Notation() string {
+	return fmt.Sprintf("%dd%d>=%d", p.count, p.size, p.target)
+}
+
+// Roll performs a fresh roll of the pool using the provided roller
+func (p *SuccessPool) Roll(roller Roller) *SuccessResult {
+	return p.RollContext(context.Background(), roller)
+}
+
+// RollContext performs a fresh roll with context support.
+// A nil roller uses the context's roller (see WithRoller), or a CryptoRoller.
+func (p *SuccessPool) RollContext(ctx context.Context, roller Roller) *SuccessResult {
+	if roller == nil {
+		roller = FromContext(ctx)
+	}
+
+	result := &SuccessResult{pool: p}
+	if p.size <= 0 {
+		result.err = fmt.Errorf("%w: d%d", ErrInvalidDieSize, p.size)
+		return result
+	}
+	if p.count < 0 {
+		result.err = fmt.Errorf("%w: %d", ErrInvalidDieCount, p.count)
+		return result
+	}
+	if p.target < 1 || p.target > p.size {
+		result.err = fmt.Errorf("dice: target %d is not on a d%d", p.target, p.size)
+		return result
+	}
+
+	rolls, err := roller.RollN(ctx, p.count, p.size)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	result.rolls = rolls
+
+	successes, ones := 0, 0
+	for _, roll := range rolls {
+		if roll >= p.target {
+			successes++
+		}
+		if roll == 1 {
+			ones++
+		}
+	}
+	result.ones = ones
+
+	switch p.botch {
+	case BotchOnesCancel:
+		result.botched = successes == 0 && ones > 0
+		successes = max(successes-ones, 0)
+	case BotchGlitch:
+		result.glitched = ones*2 > len(rolls)
+		result.botched = result.glitched && successes == 0
+	}
+	result.successes = successes
+	return result
+}
+
+// SuccessResult is the outcome of rolling a success pool
+type SuccessResult struct {
+	pool      *SuccessPool
+	rolls     []int
+	successes int
+	ones      int
+	botched   bool
+	glitched  bool
+	err       error
+}
+
+// Successes returns the number of successes, after any 1s cancel them
+func (r *SuccessResult) Successes() int {
+	return r.successes
+}
+
+// Rolls returns the individual dice rolls
+func (r *SuccessResult) Rolls() []int {
+	return r.rolls
+}
+
+// Ones returns how many dice showed 1
+func (r *SuccessResult) Ones() int {
+	return r.ones
+}
+
+// Botched returns true when the roll botched: a classic World of Darkness
+// botch or a Shadowrun critical glitch
+func (r *SuccessResult) Botched() bool {
+	return r.botched
+}
+
+// Glitched returns true when more than half the dice showed 1 under
+// BotchGlitch, with or without successes
+func (r *SuccessResult) Glitched() bool {
+	return r.glitched
+}
+
+// Error returns any error that occurred during rolling
+func (r *SuccessResult) Error() error {
+	return r.err
+}
+
+// Description returns a formatted description of the roll
+// Format: "7d10>=8: [10,9,3,1,8,2,5] = 3 successes" or "... = botch"
+func (r *SuccessResult) Description() string {
+	if r.err != nil {
+		return fmt.Sprintf("ERROR: %v", r.err)
+	}
+
+	rollStrs := make([]string, len(r.rolls))
+	for i, roll := range r.rolls {
+		rollStrs[i] = strconv.Itoa(roll)
+	}
+
+	var outcome string
+	switch {
+	case r.botched:
+		outcome = "botch"
+	case r.successes == 1:
+		outcome = "1 success"
+	default:
+		outcome = fmt.Sprintf("%d successes", r.successes)
+	}
+	if r.glitched && !r.botched {
+		outcome += ", glitch"
+	}
+	return fmt.Sprintf("%s: [%s] = %s", r.pool.Notation(), strings.Join(rollStrs, ","), outcome)
+}
+
+// String implements Stringer interface
+func (r *SuccessResult) String() string {
+	return r.Description()
+}
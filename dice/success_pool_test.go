@@ -0,0 +1,167 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package dice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+)
+
+func TestSuccessPool_Roll(t *testing.T) {
+	tests := []struct {
+		name          string
+		pool          *SuccessPool
+		rolls         []int
+		wantSuccesses int
+		wantBotched   bool
+		wantGlitched  bool
+		wantDesc      string
+	}{
+		{
+			name:          "counts dice meeting the target",
+			pool:          NewSuccessPool(7, 10).Target(8),
+			rolls:         []int{10, 9, 3, 1, 8, 2, 5},
+			wantSuccesses: 3,
+			wantDesc:      "7d10>=8: [10,9,3,1,8,2,5] = 3 successes",
+		},
+		{
+			name:          "only the maximum succeeds without a target",
+			pool:          NewSuccessPool(3, 6),
+			rolls:         []int{6, 5, 6},
+			wantSuccesses: 2,
+			wantDesc:      "3d6>=6: [6,5,6] = 2 successes",
+		},
+		{
+			name:          "ones cancel successes",
+			pool:          NewSuccessPool(5, 10).Target(6).Botch(BotchOnesCancel),
+			rolls:         []int{7, 9, 1, 4, 3},
+			wantSuccesses: 1,
+			wantDesc:      "5d10>=6: [7,9,1,4,3] = 1 success",
+		},
+		{
+			name:          "ones cancelling every success is not a botch",
+			pool:          NewSuccessPool(3, 10).Target(6).Botch(BotchOnesCancel),
+			rolls:         []int{7, 1, 1},
+			wantSuccesses: 0,
+			wantDesc:      "3d10>=6: [7,1,1] = 0 successes",
+		},
+		{
+			name:          "no successes and a one botches",
+			pool:          NewSuccessPool(4, 10).Target(6).Botch(BotchOnesCancel),
+			rolls:         []int{2, 1, 5, 3},
+			wantSuccesses: 0,
+			wantBotched:   true,
+			wantDesc:      "4d10>=6: [2,1,5,3] = botch",
+		},
+		{
+			name:          "glitch with successes",
+			pool:          NewSuccessPool(5, 6).Target(5).Botch(BotchGlitch),
+			rolls:         []int{1, 1, 1, 5, 6},
+			wantSuccesses: 2,
+			wantGlitched:  true,
+			wantDesc:      "5d6>=5: [1,1,1,5,6] = 2 successes, glitch",
+		},
+		{
+			name:          "critical glitch",
+			pool:          NewSuccessPool(4, 6).Target(5).Botch(BotchGlitch),
+			rolls:         []int{1, 1, 1, 4},
+			wantSuccesses: 0,
+			wantBotched:   true,
+			wantGlitched:  true,
+			wantDesc:      "4d6>=5: [1,1,1,4] = botch",
+		},
+		{
+			name:          "half ones is not a glitch",
+			pool:          NewSuccessPool(4, 6).Target(5).Botch(BotchGlitch),
+			rolls:         []int{1, 1, 3, 4},
+			wantSuccesses: 0,
+			wantDesc:      "4d6>=5: [1,1,3,4] = 0 successes",
+		},
+		{
+			name:          "ones don't botch without a rule",
+			pool:          NewSuccessPool(2, 10).Target(8),
+			rolls:         []int{1, 1},
+			wantSuccesses: 0,
+			wantDesc:      "2d10>=8: [1,1] = 0 successes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			roller := mock_dice.NewMockRoller(ctrl)
+			roller.EXPECT().RollN(gomock.Any(), len(tt.rolls), tt.pool.size).Return(tt.rolls, nil)
+
+			result := tt.pool.RollContext(context.Background(), roller)
+			if result.Error() != nil {
+				t.Fatalf("RollContext() error = %v", result.Error())
+			}
+			if result.Successes() != tt.wantSuccesses {
+				t.Errorf("Successes() = %d, want %d", result.Successes(), tt.wantSuccesses)
+			}
+			if result.Botched() != tt.wantBotched {
+				t.Errorf("Botched() = %v, want %v", result.Botched(), tt.wantBotched)
+			}
+			if result.Glitched() != tt.wantGlitched {
+				t.Errorf("Glitched() = %v, want %v", result.Glitched(), tt.wantGlitched)
+			}
+			if result.Description() != tt.wantDesc {
+				t.Errorf("Description() = %q, want %q", result.Description(), tt.wantDesc)
+			}
+		})
+	}
+}
+
+func TestSuccessPool_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		pool    *SuccessPool
+		wantErr error
+	}{
+		{name: "die size", pool: NewSuccessPool(3, 0), wantErr: ErrInvalidDieSize},
+		{name: "die count", pool: NewSuccessPool(-1, 10), wantErr: ErrInvalidDieCount},
+		{name: "target above the die", pool: NewSuccessPool(3, 10).Target(11)},
+		{name: "target below one", pool: NewSuccessPool(3, 10).Target(0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.pool.Roll(NewRoller())
+			if result.Error() == nil {
+				t.Fatal("expected an error")
+			}
+			if tt.wantErr != nil && !errors.Is(result.Error(), tt.wantErr) {
+				t.Errorf("Error() = %v, want %v", result.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSuccessPool_OptionsCopy(t *testing.T) {
+	base := NewSuccessPool(7, 10)
+	targeted := base.Target(8)
+	botching := targeted.Botch(BotchOnesCancel)
+
+	if base.Notation() != "7d10>=10" || targeted.Notation() != "7d10>=8" {
+		t.Errorf("Target() changed the original pool: %q, %q", base.Notation(), targeted.Notation())
+	}
+	if targeted.botch != BotchNone || botching.botch != BotchOnesCancel {
+		t.Error("Botch() changed the original pool")
+	}
+
+	result := botching.Roll(NewRoller())
+	if result.Error() != nil {
+		t.Fatalf("Roll() error = %v", result.Error())
+	}
+	if len(result.Rolls()) != 7 {
+		t.Errorf("Rolls() has %d dice, want 7", len(result.Rolls()))
+	}
+}
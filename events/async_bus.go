@@ -0,0 +1,250 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package events
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"sync"
+)
+
+// Default sizing for an AsyncEventBus
+const (
+	DefaultAsyncWorkers   = 4
+	DefaultAsyncQueueSize = 256
+)
+
+var (
+	// ErrBusClosed is returned when publishing to an AsyncEventBus after Close
+	ErrBusClosed = errors.New("event bus is closed")
+
+	// ErrQueueFull is returned when an AsyncEventBus can't accept another event
+	// without blocking (see AsyncBusConfig.BlockWhenFull)
+	ErrQueueFull = errors.New("event bus queue is full")
+)
+
+// AsyncBusConfig configures an asynchronous event bus.
+type AsyncBusConfig struct {
+	// Workers is the number of delivery goroutines (default DefaultAsyncWorkers)
+	Workers int
+
+	// QueueSize is how many events each worker can hold before publishing
+	// backs up (default DefaultAsyncQueueSize)
+	QueueSize int
+
+	// BlockWhenFull makes Publish wait for room in a full queue (or for ctx to
+	// end) instead of returning ErrQueueFull. A handler that publishes while
+	// its own worker's queue is full will deadlock, so leave this off when
+	// handlers publish.
+	BlockWhenFull bool
+
+	// OnError receives errors returned by handlers. Publish has already
+	// returned by the time a handler runs, so this is the only place they
+	// surface. Errors are dropped if nil.
+	OnError func(topic Topic, event any, err error)
+
+	// Metrics receives queue depth and rejected events. Use Instrument for
+	// published and handled events. Optional.
+	Metrics Metrics
+}
+
+// AsyncEventBus is an opt-in EventBus that delivers events on a pool of
+// worker goroutines so publishers never wait on handlers.
+//
+// Every topic is pinned to one worker, so events on the same topic reach
+// handlers in the order they were published. Events on different topics
+// may interleave.
+//
+// Chained events (ChainedTopic.PublishWithChain) are still delivered
+// synchronously: the publisher needs the chain its subscribers built.
+//
+// Close stops accepting events and drains the ones already queued.
+type AsyncEventBus struct {
+	registry *simpleEventBus
+	queues   []chan asyncDelivery
+	block    bool
+	onError  func(topic Topic, event any, err error)
+	metrics  Metrics
+
+	mu      sync.RWMutex // guards closed against sends on closed queues
+	closed  bool
+	workers sync.WaitGroup
+}
+
+// Ensure AsyncEventBus implements EventBus and supports pattern subscriptions and middleware
+var (
+	_ EventBus          = (*AsyncEventBus)(nil)
+	_ PatternSubscriber = (*AsyncEventBus)(nil)
+	_ MiddlewareUser    = (*AsyncEventBus)(nil)
+)
+
+// asyncDelivery is a queued event awaiting its handlers
+type asyncDelivery struct {
+	ctx   context.Context
+	topic Topic
+	event any
+}
+
+// synchronousEvent marks payloads that must be delivered before Publish returns
+type synchronousEvent interface {
+	deliverSynchronously()
+}
+
+// NewAsyncEventBus creates an asynchronous event bus and starts its workers.
+// Call Close to stop them.
+func NewAsyncEventBus(config AsyncBusConfig) *AsyncEventBus {
+	workers := config.Workers
+	if workers <= 0 {
+		workers = DefaultAsyncWorkers
+	}
+	queueSize := config.QueueSize
+	if queueSize <= 0 {
+		queueSize = DefaultAsyncQueueSize
+	}
+
+	metrics := config.Metrics
+	if metrics == nil {
+		metrics = NopMetrics{}
+	}
+
+	b := &AsyncEventBus{
+		registry: NewEventBus().(*simpleEventBus),
+		queues:   make([]chan asyncDelivery, workers),
+		block:    config.BlockWhenFull,
+		onError:  config.OnError,
+		metrics:  metrics,
+	}
+
+	for i := range b.queues {
+		b.queues[i] = make(chan asyncDelivery, queueSize)
+		b.workers.Add(1)
+		go b.run(i)
+	}
+
+	return b
+}
+
+// Subscribe registers a handler for a specific topic
+func (b *AsyncEventBus) Subscribe(ctx context.Context, topic Topic, handler any) (string, error) {
+	return b.registry.Subscribe(ctx, topic, handler)
+}
+
+// SubscribePattern registers a handler for every topic matching pattern.
+// Pattern handlers run on the worker of the topic they match.
+func (b *AsyncEventBus) SubscribePattern(ctx context.Context, pattern string, handler PatternHandler) (string, error) {
+	return b.registry.SubscribePattern(ctx, pattern, handler)
+}
+
+// Use adds middleware to the bus. Publish middleware wraps delivery on the
+// topic's worker, not the queueing Publish call.
+func (b *AsyncEventBus) Use(middleware ...Middleware) {
+	b.registry.Use(middleware...)
+}
+
+// Unsubscribe removes a subscription by ID. Events already queued for the
+// topic may still reach the handler.
+func (b *AsyncEventBus) Unsubscribe(ctx context.Context, id string) error {
+	return b.registry.Unsubscribe(ctx, id)
+}
+
+// Publish queues the event for its topic's worker and returns without waiting
+// for handlers.
+//
+// Returns ErrBusClosed after Close, and ErrQueueFull if the worker's queue is
+// full (or ctx's error while waiting, with BlockWhenFull).
+func (b *AsyncEventBus) Publish(ctx context.Context, topic Topic, event any) error {
+	if _, ok := event.(synchronousEvent); ok {
+		return b.registry.Publish(ctx, topic, event)
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return ErrBusClosed
+	}
+
+	delivery := asyncDelivery{ctx: ctx, topic: topic, event: event}
+	worker := b.workerFor(topic)
+	queue := b.queues[worker]
+
+	if !b.block {
+		select {
+		case queue <- delivery:
+			b.metrics.QueueDepth(worker, len(queue))
+			return nil
+		default:
+			b.metrics.EventRejected(topic, ErrQueueFull)
+			return ErrQueueFull
+		}
+	}
+
+	select {
+	case queue <- delivery:
+		b.metrics.QueueDepth(worker, len(queue))
+		return nil
+	case <-ctx.Done():
+		b.metrics.EventRejected(topic, ctx.Err())
+		return ctx.Err()
+	}
+}
+
+// QueueDepths returns how many events wait in each worker's queue, indexed
+// by worker. Poll it for a queue depth gauge, or set AsyncBusConfig.Metrics.
+func (b *AsyncEventBus) QueueDepths() []int {
+	depths := make([]int, len(b.queues))
+	for i, queue := range b.queues {
+		depths[i] = len(queue)
+	}
+	return depths
+}
+
+// Close stops accepting events and waits for queued events to be delivered.
+// Returns ctx's error if it ends before the queues drain; the workers keep
+// draining in the background. Closing twice is a no-op.
+func (b *AsyncEventBus) Close(ctx context.Context) error {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		for _, queue := range b.queues {
+			close(queue)
+		}
+	}
+	b.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		b.workers.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// workerFor pins a topic to a worker so its events stay in order
+func (b *AsyncEventBus) workerFor(topic Topic) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(topic))
+	return int(h.Sum32() % uint32(len(b.queues)))
+}
+
+// run delivers a worker's queued events until its queue is closed and empty
+func (b *AsyncEventBus) run(worker int) {
+	defer b.workers.Done()
+
+	queue := b.queues[worker]
+	for delivery := range queue {
+		b.metrics.QueueDepth(worker, len(queue))
+		err := b.registry.Publish(delivery.ctx, delivery.topic, delivery.event)
+		if err != nil && b.onError != nil {
+			b.onError(delivery.topic, delivery.event, err)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package events_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+)
+
+// AsyncEventBusTestSuite tests asynchronous delivery
+type AsyncEventBusTestSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func TestAsyncEventBusSuite(t *testing.T) {
+	suite.Run(t, new(AsyncEventBusTestSuite))
+}
+
+func (s *AsyncEventBusTestSuite) SetupTest() {
+	s.ctx = context.Background()
+}
+
+func (s *AsyncEventBusTestSuite) TestPublishDoesNotWaitForHandlers() {
+	bus := events.NewAsyncEventBus(events.AsyncBusConfig{})
+	release := make(chan struct{})
+	delivered := make(chan TestNotificationEvent, 1)
+
+	_, err := NotificationTopic.On(bus).Subscribe(s.ctx, func(_ context.Context, e TestNotificationEvent) error {
+		<-release
+		delivered <- e
+		return nil
+	})
+	s.Require().NoError(err)
+
+	s.Require().NoError(NotificationTopic.On(bus).Publish(s.ctx, TestNotificationEvent{ID: testHero}))
+	s.Empty(delivered, "handler should still be blocked")
+
+	close(release)
+	s.Require().NoError(bus.Close(s.ctx))
+	s.Equal(testHero, (<-delivered).ID)
+}
+
+func (s *AsyncEventBusTestSuite) TestTopicOrderPreserved() {
+	bus := events.NewAsyncEventBus(events.AsyncBusConfig{Workers: 8})
+	var mu sync.Mutex
+	var values []int
+
+	_, err := NotificationTopic.On(bus).Subscribe(s.ctx, func(_ context.Context, e TestNotificationEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+		values = append(values, e.Value)
+		return nil
+	})
+	s.Require().NoError(err)
+
+	notifications := NotificationTopic.On(bus)
+	for i := 0; i < 100; i++ {
+		s.Require().NoError(notifications.Publish(s.ctx, TestNotificationEvent{Value: i}))
+	}
+	s.Require().NoError(bus.Close(s.ctx))
+
+	s.Require().Len(values, 100)
+	for i, value := range values {
+		s.Equal(i, value)
+	}
+}
+
+func (s *AsyncEventBusTestSuite) TestCloseDrainsAndRejectsNewEvents() {
+	bus := events.NewAsyncEventBus(events.AsyncBusConfig{Workers: 1})
+	var mu sync.Mutex
+	count := 0
+
+	_, err := ActionTopic.On(bus).Subscribe(s.ctx, func(_ context.Context, _ TestActionEvent) error {
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		defer mu.Unlock()
+		count++
+		return nil
+	})
+	s.Require().NoError(err)
+
+	for i := 0; i < 10; i++ {
+		s.Require().NoError(ActionTopic.On(bus).Publish(s.ctx, TestActionEvent{ActorID: testBarbarian}))
+	}
+	s.Require().NoError(bus.Close(s.ctx))
+	s.Equal(10, count)
+
+	err = ActionTopic.On(bus).Publish(s.ctx, TestActionEvent{ActorID: testBarbarian})
+	s.ErrorIs(err, events.ErrBusClosed)
+	s.NoError(bus.Close(s.ctx), "closing twice is a no-op")
+}
+
+func (s *AsyncEventBusTestSuite) TestCloseStopsWaitingWhenContextEnds() {
+	bus := events.NewAsyncEventBus(events.AsyncBusConfig{Workers: 1})
+	release := make(chan struct{})
+
+	_, err := NotificationTopic.On(bus).Subscribe(s.ctx, func(_ context.Context, _ TestNotificationEvent) error {
+		<-release
+		return nil
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(NotificationTopic.On(bus).Publish(s.ctx, TestNotificationEvent{}))
+
+	ctx, cancel := context.WithTimeout(s.ctx, 10*time.Millisecond)
+	defer cancel()
+	s.ErrorIs(bus.Close(ctx), context.DeadlineExceeded)
+
+	close(release)
+	s.NoError(bus.Close(s.ctx))
+}
+
+func (s *AsyncEventBusTestSuite) TestFullQueue() {
+	s.Run("returns ErrQueueFull by default", func() {
+		bus := events.NewAsyncEventBus(events.AsyncBusConfig{Workers: 1, QueueSize: 1})
+		release := make(chan struct{})
+		started := make(chan struct{}, 1)
+
+		_, err := NotificationTopic.On(bus).Subscribe(s.ctx, func(_ context.Context, _ TestNotificationEvent) error {
+			started <- struct{}{}
+			<-release
+			return nil
+		})
+		s.Require().NoError(err)
+
+		notifications := NotificationTopic.On(bus)
+		s.Require().NoError(notifications.Publish(s.ctx, TestNotificationEvent{Value: 1}))
+		<-started // worker holds the first event, queue is empty again
+		s.Require().NoError(notifications.Publish(s.ctx, TestNotificationEvent{Value: 2}))
+		s.ErrorIs(notifications.Publish(s.ctx, TestNotificationEvent{Value: 3}), events.ErrQueueFull)
+
+		close(release)
+		s.Require().NoError(bus.Close(s.ctx))
+	})
+
+	s.Run("waits for room with BlockWhenFull", func() {
+		bus := events.NewAsyncEventBus(events.AsyncBusConfig{Workers: 1, QueueSize: 1, BlockWhenFull: true})
+		release := make(chan struct{})
+		started := make(chan struct{}, 1)
+
+		_, err := NotificationTopic.On(bus).Subscribe(s.ctx, func(_ context.Context, e TestNotificationEvent) error {
+			if e.Value == 1 {
+				started <- struct{}{}
+				<-release
+			}
+			return nil
+		})
+		s.Require().NoError(err)
+
+		notifications := NotificationTopic.On(bus)
+		s.Require().NoError(notifications.Publish(s.ctx, TestNotificationEvent{Value: 1}))
+		<-started
+		s.Require().NoError(notifications.Publish(s.ctx, TestNotificationEvent{Value: 2}))
+
+		ctx, cancel := context.WithTimeout(s.ctx, 10*time.Millisecond)
+		defer cancel()
+		s.ErrorIs(notifications.Publish(ctx, TestNotificationEvent{Value: 3}), context.DeadlineExceeded)
+
+		close(release)
+		s.Require().NoError(notifications.Publish(s.ctx, TestNotificationEvent{Value: 4}))
+		s.Require().NoError(bus.Close(s.ctx))
+	})
+}
+
+func (s *AsyncEventBusTestSuite) TestHandlerErrorsReported() {
+	handlerErr := errors.New("handler failed")
+	var mu sync.Mutex
+	var reported []error
+
+	bus := events.NewAsyncEventBus(events.AsyncBusConfig{
+		OnError: func(topic events.Topic, _ any, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			s.Equal(TopicNotification, topic)
+			reported = append(reported, err)
+		},
+	})
+
+	_, err := NotificationTopic.On(bus).Subscribe(s.ctx, func(_ context.Context, _ TestNotificationEvent) error {
+		return handlerErr
+	})
+	s.Require().NoError(err)
+
+	s.Require().NoError(NotificationTopic.On(bus).Publish(s.ctx, TestNotificationEvent{}))
+	s.Require().NoError(bus.Close(s.ctx))
+	s.Require().Len(reported, 1)
+	s.ErrorIs(reported[0], handlerErr)
+}
+
+func (s *AsyncEventBusTestSuite) TestChainedTopicsDeliverSynchronously() {
+	bus := events.NewAsyncEventBus(events.AsyncBusConfig{})
+	defer func() { s.Require().NoError(bus.Close(s.ctx)) }()
+
+	attacks := TestAttackChain.On(bus)
+	_, err := attacks.SubscribeWithChain(s.ctx, func(
+		_ context.Context, _ TestAttackEvent, c chain.Chain[TestAttackEvent],
+	) (chain.Chain[TestAttackEvent], error) {
+		err := c.Add(TestStageConditions, "rage", func(_ context.Context, e TestAttackEvent) (TestAttackEvent, error) {
+			e.Damage += 2
+			return e, nil
+		})
+		return c, err
+	})
+	s.Require().NoError(err)
+
+	attack := TestAttackEvent{AttackerID: testBarbarian, Damage: 10}
+	stages := []chain.Stage{TestStageBase, TestStageConditions, TestStageFinal}
+	modified, err := attacks.PublishWithChain(s.ctx, attack, events.NewStagedChain[TestAttackEvent](stages))
+	s.Require().NoError(err)
+	result, err := modified.Execute(s.ctx, attack)
+	s.Require().NoError(err)
+	s.Equal(12, result.Damage)
+}
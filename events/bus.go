@@ -22,6 +22,7 @@ package events
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
 )
 
@@ -54,15 +55,41 @@ type simpleEventBus struct {
 	mu          sync.RWMutex
 	subscribers map[Topic][]subscription
 	idToTopic   map[string]Topic
+	patterns    []patternSubscription
+	middleware  []Middleware
 	nextID      int
+
+	// Scopes (see Scope) deliver their parent's handlers too
+	parent    *simpleEventBus
+	prefix    string // prefixes a scope's subscription IDs so they can't clash with its parent's
+	scopes    []*simpleEventBus
+	nextScope int
+	closed    bool
+}
+
+type patternSubscription struct {
+	id      string
+	pattern topicPattern
+	handler PatternHandler
 }
 
+// Ensure simpleEventBus supports pattern subscriptions, middleware and scopes
+var (
+	_ PatternSubscriber = (*simpleEventBus)(nil)
+	_ MiddlewareUser    = (*simpleEventBus)(nil)
+	_ Scoper            = (*simpleEventBus)(nil)
+)
+
 func (b *simpleEventBus) Subscribe(_ context.Context, topic Topic, handler any) (string, error) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
+	if b.closed {
+		return "", ErrBusClosed
+	}
+
 	b.nextID++
-	id := fmt.Sprintf("%s-%d", topic, b.nextID)
+	id := fmt.Sprintf("%s%s-%d", b.prefix, topic, b.nextID)
 
 	sub := subscription{
 		id:      id,
@@ -75,12 +102,45 @@ func (b *simpleEventBus) Subscribe(_ context.Context, topic Topic, handler any)
 	return id, nil
 }
 
-func (b *simpleEventBus) Unsubscribe(_ context.Context, id string) error {
+// SubscribePattern registers a handler for every topic matching pattern
+func (b *simpleEventBus) SubscribePattern(_ context.Context, pattern string, handler PatternHandler) (string, error) {
+	parsed, err := parseTopicPattern(pattern)
+	if err != nil {
+		return "", err
+	}
+
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
+	if b.closed {
+		return "", ErrBusClosed
+	}
+
+	b.nextID++
+	id := fmt.Sprintf("%spattern:%s-%d", b.prefix, pattern, b.nextID)
+	b.patterns = append(b.patterns, patternSubscription{id: id, pattern: parsed, handler: handler})
+
+	return id, nil
+}
+
+func (b *simpleEventBus) Unsubscribe(ctx context.Context, id string) error {
+	b.mu.Lock()
+
 	topic, exists := b.idToTopic[id]
 	if !exists {
+		for i, sub := range b.patterns {
+			if sub.id == id {
+				b.patterns = append(b.patterns[:i:i], b.patterns[i+1:]...)
+				b.mu.Unlock()
+				return nil
+			}
+		}
+		b.mu.Unlock()
+
+		// A scope can remove its parent's subscriptions, like any holder of the ID
+		if b.parent != nil {
+			return b.parent.Unsubscribe(ctx, id)
+		}
 		return nil // Already unsubscribed
 	}
 
@@ -92,28 +152,88 @@ func (b *simpleEventBus) Unsubscribe(_ context.Context, id string) error {
 			break
 		}
 	}
+	b.mu.Unlock()
 
 	return nil
 }
 
-func (b *simpleEventBus) Publish(_ context.Context, topic Topic, event any) error {
-	b.mu.RLock()
-	subs := b.subscribers[topic]
-	handlers := make([]any, len(subs))
-	for i, sub := range subs {
-		handlers[i] = sub.handler
+func (b *simpleEventBus) Publish(ctx context.Context, topic Topic, event any) error {
+	// A scope delivers to its ancestors' handlers first, through their middleware
+	var lineage []*simpleEventBus
+	for bus := b; bus != nil; bus = bus.parent {
+		lineage = append(lineage, bus)
+	}
+
+	var subs []subscription
+	var observers []patternSubscription
+	var middleware []Middleware
+	for _, bus := range slices.Backward(lineage) {
+		bus.mu.RLock()
+		if bus.closed {
+			bus.mu.RUnlock()
+			return ErrBusClosed
+		}
+		subs = append(subs, bus.subscribers[topic]...)
+		for _, sub := range bus.patterns {
+			if sub.pattern.matches(topic) {
+				observers = append(observers, sub)
+			}
+		}
+		middleware = append(middleware, bus.middleware...)
+		bus.mu.RUnlock()
+	}
+
+	// Handlers receive the event's meta, so events they publish become its children
+	ctx, meta := publishMeta(ctx, topic)
+
+	observed := event
+	wrapped, chained := event.(observedEvent)
+	if chained {
+		observed = wrapped.observedValue()
 	}
-	b.mu.RUnlock()
 
 	// Call handlers outside lock to avoid deadlock
 	// The handlers are wrapped functions that know how to handle the event
-	for _, handler := range handlers {
-		if fn, ok := handler.(func(any) error); ok {
-			if err := fn(event); err != nil {
+	deliver := func(ctx context.Context, delivery Delivery) error {
+		for _, sub := range subs {
+			handler := sub.handler
+			invoke := func(ctx context.Context, _ Delivery) error {
+				switch fn := handler.(type) {
+				case func(context.Context, any) error:
+					return fn(ctx, event)
+				case func(any) error:
+					return fn(event)
+				}
+				return nil
+			}
+			delivery.SubscriptionID = sub.id
+			if err := wrapDelivery(middleware, handlerHook, invoke)(ctx, delivery); err != nil {
 				return err
 			}
 		}
+
+		for _, sub := range observers {
+			observer := sub.handler
+			invoke := func(ctx context.Context, delivery Delivery) error {
+				return observer(ctx, delivery.Topic, delivery.Event)
+			}
+			delivery.SubscriptionID = sub.id
+			if err := wrapDelivery(middleware, handlerHook, invoke)(ctx, delivery); err != nil {
+				return err
+			}
+		}
+
+		return nil
 	}
 
-	return nil
+	return wrapDelivery(middleware, publishHook, deliver)(ctx, Delivery{Topic: topic, Event: observed, Chained: chained, Meta: meta})
+}
+
+// Use adds middleware around every Publish and handler invocation.
+// Middleware added first is outermost.
+func (b *simpleEventBus) Use(middleware ...Middleware) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.middleware = append(slices.Clip(b.middleware), middleware...)
 }
@@ -0,0 +1,243 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// EventMeta identifies one published event and what caused it. The bus
+// assigns it on Publish; handlers read it with EventMetaFromContext.
+type EventMeta struct {
+	// ID uniquely identifies the event within the process
+	ID string `json:"id"`
+
+	// ParentID is the event that caused this one, or "" for a root event
+	ParentID string `json:"parent_id,omitempty"`
+
+	// CorrelationID groups every event caused by the same root event. It is
+	// the context's correlation ID (see WithCorrelationID) if it has one,
+	// otherwise the root event's ID.
+	CorrelationID string `json:"correlation_id"`
+
+	// Topic the event was published on
+	Topic Topic `json:"topic"`
+}
+
+// eventSequence numbers events for their IDs
+var eventSequence atomic.Uint64
+
+// causalityKey is the context key for causality
+type causalityKey struct{}
+
+// causality is the causal state a context carries
+type causality struct {
+	// cause is the parent of events published under the context: the event
+	// being handled, or one set with WithCause
+	cause *EventMeta
+
+	// reserved is the meta BeginEvent reserved for the next publish
+	reserved *reservedMeta
+}
+
+// reservedMeta is meta reserved by BeginEvent, used by the first publish
+type reservedMeta struct {
+	meta EventMeta
+	used atomic.Bool
+}
+
+// EventMetaFromContext returns the meta of the event a handler is handling.
+// Returns false outside a handler.
+func EventMetaFromContext(ctx context.Context) (EventMeta, bool) {
+	state, _ := ctx.Value(causalityKey{}).(causality)
+	if state.cause == nil {
+		return EventMeta{}, false
+	}
+	return *state.cause, true
+}
+
+// WithCause returns a context whose publishes are children of cause. Handlers
+// don't need it - events they publish with their context are linked to the
+// event they handle. Use it for steps run one after another, e.g. to make a
+// DamageReceivedEvent a child of the damage chain that computed it.
+func WithCause(ctx context.Context, cause EventMeta) context.Context {
+	return context.WithValue(ctx, causalityKey{}, causality{cause: &cause})
+}
+
+// BeginEvent reserves the meta of the next event published under the
+// returned context, so the publisher knows the event's ID before publishing:
+//
+//	chainCtx, chainMeta := events.BeginEvent(ctx)
+//	chain, _ := DamageChain.On(bus).PublishWithChain(chainCtx, event, chain)
+//	ctx = events.WithCause(ctx, chainMeta) // what follows was caused by the chain
+//
+// Only the first publish under the context uses the reserved meta.
+func BeginEvent(ctx context.Context) (context.Context, EventMeta) {
+	meta := newEventMeta(ctx, "")
+	state, _ := ctx.Value(causalityKey{}).(causality)
+	state.reserved = &reservedMeta{meta: meta}
+	return context.WithValue(ctx, causalityKey{}, state), meta
+}
+
+// publishMeta returns the meta of an event being published under ctx, and
+// the context its handlers receive
+func publishMeta(ctx context.Context, topic Topic) (context.Context, EventMeta) {
+	state, _ := ctx.Value(causalityKey{}).(causality)
+
+	var meta EventMeta
+	if state.reserved != nil && state.reserved.used.CompareAndSwap(false, true) {
+		meta = state.reserved.meta
+	} else {
+		meta = newEventMeta(ctx, topic)
+	}
+	meta.Topic = topic
+
+	if CorrelationIDFromContext(ctx) == "" {
+		ctx = WithCorrelationID(ctx, meta.CorrelationID)
+	}
+	return WithCause(ctx, meta), meta
+}
+
+// newEventMeta creates meta for an event published under ctx
+func newEventMeta(ctx context.Context, topic Topic) EventMeta {
+	meta := EventMeta{
+		ID:            fmt.Sprintf("evt-%d", eventSequence.Add(1)),
+		CorrelationID: CorrelationIDFromContext(ctx),
+		Topic:         topic,
+	}
+	if state, _ := ctx.Value(causalityKey{}).(causality); state.cause != nil {
+		meta.ParentID = state.cause.ID
+		if meta.CorrelationID == "" {
+			meta.CorrelationID = state.cause.CorrelationID
+		}
+	}
+	if meta.CorrelationID == "" {
+		meta.CorrelationID = meta.ID
+	}
+	return meta
+}
+
+// CausalRecord is one event seen by a CausalityTracker
+type CausalRecord struct {
+	Meta EventMeta `json:"meta"`
+
+	// Event is the published payload. Chained events are recorded as their
+	// event value, without the chain.
+	Event any `json:"event"`
+}
+
+// CausalityTracker records the events published on a bus with their meta,
+// so any event can be traced back to what caused it - e.g. to show a player
+// that 47 damage came from a damage chain run for a Strike.
+//
+//	tracker := events.NewCausalityTracker()
+//	events.Use(bus, tracker.Middleware())
+//	...
+//	for _, record := range tracker.Lineage(damageID) { ... } // damage, chain, strike
+//
+// Records are kept until Reset; call it between encounters.
+type CausalityTracker struct {
+	mu      sync.RWMutex
+	records []CausalRecord
+	byID    map[string]int
+}
+
+// NewCausalityTracker creates an empty causality tracker
+func NewCausalityTracker() *CausalityTracker {
+	return &CausalityTracker{byID: make(map[string]int)}
+}
+
+// Middleware returns middleware that records every published event,
+// chained events included
+func (t *CausalityTracker) Middleware() Middleware {
+	return Middleware{
+		Publish: func(next DeliveryFunc) DeliveryFunc {
+			return func(ctx context.Context, delivery Delivery) error {
+				t.Record(delivery.Meta, delivery.Event)
+				return next(ctx, delivery)
+			}
+		},
+	}
+}
+
+// Record adds an event to the tracker. The middleware calls it; call it
+// directly for events the bus doesn't see.
+func (t *CausalityTracker) Record(meta EventMeta, event any) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.byID[meta.ID] = len(t.records)
+	t.records = append(t.records, CausalRecord{Meta: meta, Event: event})
+}
+
+// Get returns the record of an event by ID
+func (t *CausalityTracker) Get(eventID string) (CausalRecord, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	i, ok := t.byID[eventID]
+	if !ok {
+		return CausalRecord{}, false
+	}
+	return t.records[i], true
+}
+
+// Lineage returns an event followed by its ancestors, nearest first, ending
+// at the root or at the first ancestor that wasn't recorded
+func (t *CausalityTracker) Lineage(eventID string) []CausalRecord {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var lineage []CausalRecord
+	for id := eventID; id != ""; {
+		i, ok := t.byID[id]
+		if !ok || len(lineage) > len(t.records) { // missing, or a cycle
+			break
+		}
+		lineage = append(lineage, t.records[i])
+		id = t.records[i].Meta.ParentID
+	}
+	return lineage
+}
+
+// Children returns the events an event directly caused, in publish order
+func (t *CausalityTracker) Children(eventID string) []CausalRecord {
+	return t.filter(func(record CausalRecord) bool { return record.Meta.ParentID == eventID })
+}
+
+// Correlated returns every event with a correlation ID, in publish order
+func (t *CausalityTracker) Correlated(correlationID string) []CausalRecord {
+	return t.filter(func(record CausalRecord) bool { return record.Meta.CorrelationID == correlationID })
+}
+
+// Records returns every recorded event in publish order
+func (t *CausalityTracker) Records() []CausalRecord {
+	return t.filter(func(CausalRecord) bool { return true })
+}
+
+// Reset discards the recorded events
+func (t *CausalityTracker) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.records = nil
+	t.byID = make(map[string]int)
+}
+
+// filter returns the records accepted by keep
+func (t *CausalityTracker) filter(keep func(CausalRecord) bool) []CausalRecord {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var result []CausalRecord
+	for _, record := range t.records {
+		if keep(record) {
+			result = append(result, record)
+		}
+	}
+	return result
+}
@@ -0,0 +1,124 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package events_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+)
+
+// CausalityTestSuite tests event IDs, parent links and the causality tracker
+type CausalityTestSuite struct {
+	suite.Suite
+	ctx     context.Context
+	bus     events.EventBus
+	tracker *events.CausalityTracker
+}
+
+func TestCausalitySuite(t *testing.T) {
+	suite.Run(t, new(CausalityTestSuite))
+}
+
+func (s *CausalityTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.tracker = events.NewCausalityTracker()
+	s.Require().NoError(events.Use(s.bus, s.tracker.Middleware()))
+}
+
+// topics returns the topics of records, in order
+func topics(records []events.CausalRecord) []events.Topic {
+	result := make([]events.Topic, len(records))
+	for i, record := range records {
+		result[i] = record.Meta.Topic
+	}
+	return result
+}
+
+func (s *CausalityTestSuite) TestHandlerPublishesAreChildren() {
+	var actionMeta events.EventMeta
+	_, err := ActionTopic.On(s.bus).Subscribe(s.ctx, func(ctx context.Context, e TestActionEvent) error {
+		var ok bool
+		actionMeta, ok = events.EventMetaFromContext(ctx)
+		s.True(ok)
+		return NotificationTopic.On(s.bus).Publish(ctx, TestNotificationEvent{ID: e.ActorID})
+	})
+	s.Require().NoError(err)
+	var notificationID string
+	_, err = NotificationTopic.On(s.bus).Subscribe(s.ctx, func(ctx context.Context, _ TestNotificationEvent) error {
+		meta, _ := events.EventMetaFromContext(ctx)
+		notificationID = meta.ID
+		s.Equal(meta.CorrelationID, events.CorrelationIDFromContext(ctx))
+		return nil
+	})
+	s.Require().NoError(err)
+
+	s.Require().NoError(ActionTopic.On(s.bus).Publish(s.ctx, TestActionEvent{ActorID: testHero}))
+
+	s.Empty(actionMeta.ParentID, "root event")
+	s.Equal(actionMeta.ID, actionMeta.CorrelationID, "root starts the correlation")
+	lineage := s.tracker.Lineage(notificationID)
+	s.Equal([]events.Topic{TopicNotification, TopicAction}, topics(lineage))
+	s.Equal(actionMeta.ID, lineage[0].Meta.ParentID)
+	s.Equal(actionMeta.ID, lineage[0].Meta.CorrelationID)
+	s.Equal(TestActionEvent{ActorID: testHero}, lineage[1].Event)
+	s.Equal([]events.Topic{TopicNotification}, topics(s.tracker.Children(actionMeta.ID)))
+
+	_, ok := events.EventMetaFromContext(s.ctx)
+	s.False(ok, "outside a handler")
+}
+
+func (s *CausalityTestSuite) TestBeginEventLinksSequentialSteps() {
+	var chainMetaSeen events.EventMeta
+	_, err := TestAttackChain.On(s.bus).SubscribeWithChain(s.ctx,
+		func(ctx context.Context, _ TestAttackEvent, c chain.Chain[TestAttackEvent]) (chain.Chain[TestAttackEvent], error) {
+			chainMetaSeen, _ = events.EventMetaFromContext(ctx)
+			return c, nil
+		})
+	s.Require().NoError(err)
+
+	ctx := events.WithCorrelationID(s.ctx, "turn-3")
+	chainCtx, chainMeta := events.BeginEvent(ctx)
+	stages := []chain.Stage{TestStageBase, TestStageFinal}
+	_, err = TestAttackChain.On(s.bus).PublishWithChain(chainCtx, TestAttackEvent{}, events.NewStagedChain[TestAttackEvent](stages))
+	s.Require().NoError(err)
+	s.Equal(chainMeta.ID, chainMetaSeen.ID, "the publish used the reserved meta")
+
+	s.Require().NoError(NotificationTopic.On(s.bus).Publish(events.WithCause(ctx, chainMeta), TestNotificationEvent{}))
+
+	// A second publish under the reserved context gets its own ID
+	s.Require().NoError(ActionTopic.On(s.bus).Publish(chainCtx, TestActionEvent{}))
+
+	records := s.tracker.Correlated("turn-3")
+	s.Require().Len(records, 3)
+	s.Equal(chainMetaSeen, records[0].Meta)
+	s.Equal(chainMeta.ID, records[1].Meta.ParentID)
+	s.NotEqual(chainMeta.ID, records[2].Meta.ID)
+	s.Equal([]events.Topic{TopicNotification, TopicTestAttack}, topics(s.tracker.Lineage(records[1].Meta.ID)))
+}
+
+func (s *CausalityTestSuite) TestLineageStopsAtUnrecordedAncestor() {
+	untracked := events.EventMeta{ID: "evt-elsewhere", CorrelationID: "evt-elsewhere"}
+	s.Require().NoError(ActionTopic.On(s.bus).Publish(events.WithCause(s.ctx, untracked), TestActionEvent{}))
+
+	records := s.tracker.Records()
+	s.Require().Len(records, 1)
+	s.Equal("evt-elsewhere", records[0].Meta.ParentID)
+	s.Equal("evt-elsewhere", records[0].Meta.CorrelationID, "correlation follows the cause")
+	s.Len(s.tracker.Lineage(records[0].Meta.ID), 1)
+
+	record, ok := s.tracker.Get(records[0].Meta.ID)
+	s.True(ok)
+	s.Equal(records[0], record)
+
+	s.tracker.Reset()
+	s.Empty(s.tracker.Records())
+	_, ok = s.tracker.Get(records[0].Meta.ID)
+	s.False(ok)
+}
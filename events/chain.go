@@ -7,7 +7,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/KirkDiggler/rpg-toolkit/core/chain"
 )
@@ -16,40 +20,84 @@ import (
 var (
 	ErrDuplicateID = errors.New("modifier ID already exists")
 	ErrIDNotFound  = errors.New("modifier ID not found")
+
+	// ErrAmbiguousOrder is returned by a strict chain when a modifier would
+	// tie with another on stage and priority (see StagedChainConfig.Strict)
+	ErrAmbiguousOrder = errors.New("modifier order is ambiguous")
 )
 
+// DefaultPriority is the priority of modifiers added with Add
+const DefaultPriority = 0
+
+// StagedChainConfig configures how a staged chain orders modifiers that share
+// a stage. Modifiers in a stage run by ascending priority; ties run in the
+// order they were added unless OrderByID is set.
+type StagedChainConfig struct {
+	// OrderByID breaks priority ties by modifier ID instead of the order the
+	// modifiers were added, so the result doesn't depend on which subscriber
+	// happened to register first (e.g. whether Bless or Bane was cast first).
+	OrderByID bool
+
+	// Strict makes adding a modifier that ties with another on stage and
+	// priority fail with ErrAmbiguousOrder, so every tie has to be resolved
+	// with an explicit priority. Ties resolved by OrderByID aren't ambiguous.
+	Strict bool
+}
+
 // StagedChain implements chain.Chain[T] with ordered stage execution.
 // It processes data through stages in the order they were defined.
 type StagedChain[T any] struct {
 	mu        sync.RWMutex
+	config    StagedChainConfig
 	stages    []chain.Stage
 	modifiers map[chain.Stage][]modifier[T]
 	idToStage map[string]chain.Stage // Track which stage an ID belongs to
 }
 
-// modifier wraps a handler with its ID
+// modifier wraps a handler with its ID and priority
 type modifier[T any] struct {
-	id      string
-	handler func(context.Context, T) (T, error)
+	id       string
+	priority int
+	handler  func(context.Context, T) (T, error)
 }
 
 // NewStagedChain creates a new chain with the specified stage order.
-// Modifiers will be executed in the order stages are provided.
+// Modifiers will be executed in the order stages are provided, and within a
+// stage in the order they were added.
 func NewStagedChain[T any](stages []chain.Stage) *StagedChain[T] {
+	return NewStagedChainWithConfig[T](stages, StagedChainConfig{})
+}
+
+// NewStagedChainWithConfig creates a new chain with the specified stage order
+// and tie-breaking rules for modifiers that share a stage.
+func NewStagedChainWithConfig[T any](stages []chain.Stage, config StagedChainConfig) *StagedChain[T] {
 	modifiers := make(map[chain.Stage][]modifier[T])
 	for _, stage := range stages {
 		modifiers[stage] = make([]modifier[T], 0)
 	}
 
 	return &StagedChain[T]{
+		config:    config,
 		stages:    stages,
 		modifiers: modifiers,
 		idToStage: make(map[string]chain.Stage),
 	}
 }
 
-// Add implements chain.Chain[T]
+// Add implements chain.Chain[T]. The modifier gets DefaultPriority.
 func (c *StagedChain[T]) Add(stage chain.Stage, id string, handler func(context.Context, T) (T, error)) error {
+	return c.AddWithPriority(stage, id, DefaultPriority, handler)
+}
+
+// AddWithPriority registers a modifier at a stage with a priority. Modifiers
+// in a stage run by ascending priority, so a priority below DefaultPriority
+// runs before modifiers added with Add.
+func (c *StagedChain[T]) AddWithPriority(
+	stage chain.Stage,
+	id string,
+	priority int,
+	handler func(context.Context, T) (T, error),
+) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -58,11 +106,25 @@ func (c *StagedChain[T]) Add(stage chain.Stage, id string, handler func(context.
 		return ErrDuplicateID
 	}
 
-	// Add modifier to stage
-	c.modifiers[stage] = append(c.modifiers[stage], modifier[T]{
-		id:      id,
-		handler: handler,
+	mods := c.modifiers[stage]
+	if c.config.Strict && !c.config.OrderByID {
+		for _, mod := range mods {
+			if mod.priority == priority {
+				return fmt.Errorf("%w: %s and %s share stage %s at priority %d",
+					ErrAmbiguousOrder, mod.id, id, stage, priority)
+			}
+		}
+	}
+
+	// Insert modifier before the first one that runs after it
+	added := modifier[T]{id: id, priority: priority, handler: handler}
+	at := slices.IndexFunc(mods, func(mod modifier[T]) bool {
+		return c.runsBefore(added, mod)
 	})
+	if at < 0 {
+		at = len(mods)
+	}
+	c.modifiers[stage] = slices.Insert(mods, at, added)
 
 	// Track ID to stage mapping
 	c.idToStage[id] = stage
@@ -95,13 +157,33 @@ func (c *StagedChain[T]) Remove(id string) error {
 	return ErrIDNotFound
 }
 
+// runsBefore reports whether modifier a runs before b in the same stage, when
+// b was added first
+func (c *StagedChain[T]) runsBefore(a, b modifier[T]) bool {
+	if a.priority != b.priority {
+		return a.priority < b.priority
+	}
+	return c.config.OrderByID && strings.Compare(a.id, b.id) < 0
+}
+
 // Execute implements chain.Chain[T]
 func (c *StagedChain[T]) Execute(ctx context.Context, data T) (T, error) {
+	return c.execute(ctx, data, nil)
+}
+
+// execute runs the modifiers in stage order, recording each in report when
+// it isn't nil
+func (c *StagedChain[T]) execute(ctx context.Context, data T, report *ChainReport) (T, error) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
 	result := data
 
+	var before reflect.Value
+	if report != nil {
+		before = snapshot(result)
+	}
+
 	// Process each stage in order
 	for _, stage := range c.stages {
 		mods := c.modifiers[stage]
@@ -109,7 +191,22 @@ func (c *StagedChain[T]) Execute(ctx context.Context, data T) (T, error) {
 		// Execute all modifiers in this stage
 		for _, mod := range mods {
 			var err error
+			start := time.Now()
 			result, err = mod.handler(ctx, result)
+
+			if report != nil {
+				after := snapshot(result)
+				report.Steps = append(report.Steps, ChainStep{
+					Stage:      stage,
+					ModifierID: mod.id,
+					Priority:   mod.priority,
+					Changes:    diffSnapshots(before, after),
+					Duration:   time.Since(start),
+					Err:        err,
+				})
+				before = after
+			}
+
 			if err != nil {
 				return result, fmt.Errorf("stage %s, modifier %s: %w", stage, mod.id, err)
 			}
@@ -118,3 +215,31 @@ func (c *StagedChain[T]) Execute(ctx context.Context, data T) (T, error) {
 
 	return result, nil
 }
+
+// PrioritizedChain is a chain that orders modifiers within a stage by
+// priority. StagedChain implements it.
+type PrioritizedChain[T any] interface {
+	chain.Chain[T]
+
+	// AddWithPriority registers a modifier at a stage with a priority
+	AddWithPriority(stage chain.Stage, id string, priority int, handler func(context.Context, T) (T, error)) error
+}
+
+// AddWithPriority adds a modifier with a priority within its stage, for
+// subscribers that only see a chain.Chain:
+//
+//	events.AddWithPriority(c, StageConditions, "bane", -1, baneModifier)
+//
+// Chains that don't implement PrioritizedChain get the modifier with Add.
+func AddWithPriority[T any](
+	c chain.Chain[T],
+	stage chain.Stage,
+	id string,
+	priority int,
+	handler func(context.Context, T) (T, error),
+) error {
+	if prioritized, ok := c.(PrioritizedChain[T]); ok {
+		return prioritized.AddWithPriority(stage, id, priority, handler)
+	}
+	return c.Add(stage, id, handler)
+}
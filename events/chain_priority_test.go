@@ -0,0 +1,113 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package events_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+)
+
+// ChainPriorityTestSuite tests ordering modifiers that share a stage
+type ChainPriorityTestSuite struct {
+	suite.Suite
+	ctx    context.Context
+	stages []chain.Stage
+}
+
+func TestChainPrioritySuite(t *testing.T) {
+	suite.Run(t, new(ChainPriorityTestSuite))
+}
+
+func (s *ChainPriorityTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.stages = []chain.Stage{TestStageBase, TestStageModifiers, TestStageConditions, TestStageFinal}
+}
+
+// record returns a modifier that appends its name to the event's order
+func record(name string) func(context.Context, []string) ([]string, error) {
+	return func(_ context.Context, order []string) ([]string, error) {
+		return append(order, name), nil
+	}
+}
+
+// run adds modifiers to a chain in the given order and returns the order they ran in
+func (s *ChainPriorityTestSuite) run(c *events.StagedChain[[]string], names ...string) []string {
+	for _, name := range names {
+		s.Require().NoError(c.Add(TestStageConditions, name, record(name)))
+	}
+	order, err := c.Execute(s.ctx, nil)
+	s.Require().NoError(err)
+	return order
+}
+
+func (s *ChainPriorityTestSuite) TestTiesRunInRegistrationOrder() {
+	s.Equal([]string{"bless", "bane"}, s.run(events.NewStagedChain[[]string](s.stages), "bless", "bane"))
+	s.Equal([]string{"bane", "bless"}, s.run(events.NewStagedChain[[]string](s.stages), "bane", "bless"))
+}
+
+func (s *ChainPriorityTestSuite) TestOrderByIDIsReproducible() {
+	config := events.StagedChainConfig{OrderByID: true}
+	s.Equal([]string{"bane", "bless"}, s.run(events.NewStagedChainWithConfig[[]string](s.stages, config), "bless", "bane"))
+	s.Equal([]string{"bane", "bless"}, s.run(events.NewStagedChainWithConfig[[]string](s.stages, config), "bane", "bless"))
+}
+
+func (s *ChainPriorityTestSuite) TestPriorityOrdersWithinStage() {
+	c := events.NewStagedChainWithConfig[[]string](s.stages, events.StagedChainConfig{OrderByID: true})
+	s.Require().NoError(c.AddWithPriority(TestStageConditions, "cap", 10, record("cap")))
+	s.Require().NoError(c.AddWithPriority(TestStageConditions, "zealot", -5, record("zealot")))
+	s.Require().NoError(c.Add(TestStageModifiers, "mark", record("mark")))
+
+	s.Equal([]string{"mark", "zealot", "bane", "bless", "cap"}, s.run(c, "bless", "bane"),
+		"stages still run in order, and priorities order within a stage")
+}
+
+func (s *ChainPriorityTestSuite) TestStrictRejectsTies() {
+	c := events.NewStagedChainWithConfig[[]string](s.stages, events.StagedChainConfig{Strict: true})
+	s.Require().NoError(c.Add(TestStageConditions, "bless", record("bless")))
+	s.Require().NoError(c.Add(TestStageModifiers, "mark", record("mark")), "ties are per stage")
+
+	err := c.Add(TestStageConditions, "bane", record("bane"))
+	s.ErrorIs(err, events.ErrAmbiguousOrder)
+	s.Contains(err.Error(), "bless and bane")
+
+	s.Require().NoError(c.AddWithPriority(TestStageConditions, "bane", 1, record("bane")))
+	order, err := c.Execute(s.ctx, nil)
+	s.Require().NoError(err)
+	s.Equal([]string{"mark", "bless", "bane"}, order)
+
+	s.Require().NoError(c.Remove("bless"))
+	s.NoError(c.Add(TestStageConditions, "bless-again", record("bless")), "removed modifiers no longer tie")
+}
+
+func (s *ChainPriorityTestSuite) TestStrictWithOrderByIDAllowsTies() {
+	c := events.NewStagedChainWithConfig[[]string](s.stages, events.StagedChainConfig{Strict: true, OrderByID: true})
+	s.Equal([]string{"bane", "bless"}, s.run(c, "bless", "bane"))
+}
+
+func (s *ChainPriorityTestSuite) TestAddWithPriorityThroughBus() {
+	bus := events.NewEventBus()
+	topic := events.DefineChainedTopic[[]string]("test.priority").On(bus)
+	for _, sub := range []struct {
+		name     string
+		priority int
+	}{{"late", 1}, {"early", -1}} {
+		_, err := topic.SubscribeWithChain(s.ctx,
+			func(_ context.Context, _ []string, c chain.Chain[[]string]) (chain.Chain[[]string], error) {
+				return c, events.AddWithPriority(c, TestStageConditions, sub.name, sub.priority, record(sub.name))
+			})
+		s.Require().NoError(err)
+	}
+
+	modified, err := topic.PublishWithChain(s.ctx, nil, events.NewStagedChain[[]string](s.stages))
+	s.Require().NoError(err)
+	order, report, err := events.TraceChain(s.ctx, modified, nil)
+	s.Require().NoError(err)
+	s.Equal([]string{"early", "late"}, order)
+	s.Equal(-1, report.Steps[0].Priority)
+}
@@ -0,0 +1,267 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+)
+
+// ChainReport describes one traced execution of a chain: every modifier that
+// ran, in order, with what it changed. Use it to debug how modifiers
+// interact, e.g. why a damage roll came out lower than expected.
+type ChainReport struct {
+	// Steps lists the modifiers that ran, in execution order. A modifier
+	// that returned an error is the last step.
+	Steps []ChainStep
+
+	// Duration is how long the whole execution took
+	Duration time.Duration
+}
+
+// ChainStep records one modifier's execution
+type ChainStep struct {
+	// Stage is the stage the modifier was added at
+	Stage chain.Stage
+
+	// ModifierID is the ID the subscriber added the modifier with
+	ModifierID string
+
+	// Priority is the modifier's priority within its stage
+	Priority int
+
+	// Changes lists what the modifier changed in the chain event. Empty when
+	// it changed nothing.
+	Changes []FieldChange
+
+	// Duration is how long the modifier ran
+	Duration time.Duration
+
+	// Err is the error the modifier returned
+	Err error
+}
+
+// FieldChange is one value a modifier changed in the chain event
+type FieldChange struct {
+	// Field is the dotted path of the changed exported field, e.g.
+	// "Damage" or "Roll.Bonus". Empty when the event isn't a struct and
+	// changed as a whole.
+	Field string
+
+	// Before and After are the field's values around the modifier
+	Before any
+	After  any
+}
+
+// StepsChanging returns the steps whose modifier changed a field, in order
+func (r *ChainReport) StepsChanging(field string) []ChainStep {
+	var steps []ChainStep
+	for _, step := range r.Steps {
+		for _, change := range step.Changes {
+			if change.Field == field {
+				steps = append(steps, step)
+				break
+			}
+		}
+	}
+	return steps
+}
+
+// String renders the report one modifier per line:
+//
+//	chain executed in 41µs
+//	  conditions/rage (12µs): Damage 10 -> 12
+//	  final/resistance (3µs): Damage 12 -> 6
+func (r *ChainReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "chain executed in %v", r.Duration)
+	for _, step := range r.Steps {
+		fmt.Fprintf(&b, "\n  %s/%s (%v): ", step.Stage, step.ModifierID, step.Duration)
+
+		changes := make([]string, len(step.Changes))
+		for i, change := range step.Changes {
+			changes[i] = strings.TrimSpace(fmt.Sprintf("%s %v -> %v", change.Field, change.Before, change.After))
+		}
+		if len(changes) == 0 {
+			changes = append(changes, "no changes")
+		}
+		if step.Err != nil {
+			changes = append(changes, "error: "+step.Err.Error())
+		}
+		b.WriteString(strings.Join(changes, ", "))
+	}
+	return b.String()
+}
+
+// TracingChain is a chain that can report on its execution. StagedChain
+// implements it.
+type TracingChain[T any] interface {
+	chain.Chain[T]
+
+	// Trace runs the chain like Execute, returning a report of its execution
+	// alongside the result
+	Trace(ctx context.Context, data T) (T, *ChainReport, error)
+}
+
+// Trace runs the chain like Execute, and also returns a report of each
+// modifier that ran: its stage, what it changed in the event, and how long
+// it took. The report is returned even when a modifier fails.
+//
+// Tracing snapshots the event after every modifier, so use it to debug
+// rather than on every execution. Changes are found by comparing exported
+// fields; a pointer event is compared by the struct it points to.
+func (c *StagedChain[T]) Trace(ctx context.Context, data T) (T, *ChainReport, error) {
+	report := &ChainReport{}
+	start := time.Now()
+	result, err := c.execute(ctx, data, report)
+	report.Duration = time.Since(start)
+	return result, report, err
+}
+
+// TraceChain traces the execution of a chain, such as the one returned by
+// PublishWithChain:
+//
+//	modifiedChain, _ := attacks.PublishWithChain(ctx, attack, chain)
+//	result, report, err := events.TraceChain(ctx, modifiedChain, attack)
+//	log.Println(report)
+//
+// Chains that don't implement TracingChain are executed without steps in
+// the report.
+func TraceChain[T any](ctx context.Context, c chain.Chain[T], data T) (T, *ChainReport, error) {
+	if tracing, ok := c.(TracingChain[T]); ok {
+		return tracing.Trace(ctx, data)
+	}
+
+	report := &ChainReport{}
+	start := time.Now()
+	result, err := c.Execute(ctx, data)
+	report.Duration = time.Since(start)
+	return result, report, err
+}
+
+// snapshot returns a deep copy of an event, so changes a modifier makes in
+// place, through a pointer event or a slice it shares, show up when compared.
+// Unexported fields are copied shallowly.
+func snapshot[T any](data T) reflect.Value {
+	return cloneValue(reflect.ValueOf(&data).Elem(), make(map[clonedPointer]reflect.Value))
+}
+
+// clonedPointer identifies a pointer cloneValue has copied. A pointer to a
+// struct and to its first field share an address, so the type is included.
+type clonedPointer struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+// cloneValue deep copies a value's exported data. seen maps pointers already
+// copied to their copies, so cyclic data terminates.
+func cloneValue(v reflect.Value, seen map[clonedPointer]reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		key := clonedPointer{addr: v.Pointer(), typ: v.Type()}
+		if clone, ok := seen[key]; ok {
+			return clone
+		}
+		clone := reflect.New(v.Type().Elem())
+		seen[key] = clone
+		clone.Elem().Set(cloneValue(v.Elem(), seen))
+		return clone
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		clone := reflect.New(v.Type()).Elem()
+		clone.Set(cloneValue(v.Elem(), seen))
+		return clone
+	case reflect.Struct:
+		clone := reflect.New(v.Type()).Elem()
+		clone.Set(v)
+		for i := range v.NumField() {
+			if clone.Field(i).CanSet() {
+				clone.Field(i).Set(cloneValue(v.Field(i), seen))
+			}
+		}
+		return clone
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		clone := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			clone.Index(i).Set(cloneValue(v.Index(i), seen))
+		}
+		return clone
+	case reflect.Array:
+		clone := reflect.New(v.Type()).Elem()
+		for i := range v.Len() {
+			clone.Index(i).Set(cloneValue(v.Index(i), seen))
+		}
+		return clone
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		clone := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			clone.SetMapIndex(iter.Key(), cloneValue(iter.Value(), seen))
+		}
+		return clone
+	default:
+		return v
+	}
+}
+
+// diffSnapshots returns the changes between two snapshots of an event. The
+// event itself is dereferenced if it's a pointer or interface; struct fields
+// are compared field by field, recursing into nested structs.
+func diffSnapshots(before, after reflect.Value) []FieldChange {
+	for (before.Kind() == reflect.Pointer || before.Kind() == reflect.Interface) &&
+		before.Kind() == after.Kind() && !before.IsNil() && !after.IsNil() {
+		before, after = before.Elem(), after.Elem()
+	}
+
+	var changes []FieldChange
+	diffValues("", before, after, &changes)
+	return changes
+}
+
+// diffValues appends the changes between two values at a field path
+func diffValues(path string, before, after reflect.Value, changes *[]FieldChange) {
+	if before.Type() == after.Type() && before.Kind() == reflect.Struct {
+		for i := range before.NumField() {
+			field := before.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := field.Name
+			if path != "" {
+				name = path + "." + name
+			}
+			diffValues(name, before.Field(i), after.Field(i), changes)
+		}
+		return
+	}
+
+	beforeValue, afterValue := valueOf(before), valueOf(after)
+	if !reflect.DeepEqual(beforeValue, afterValue) {
+		*changes = append(*changes, FieldChange{Field: path, Before: beforeValue, After: afterValue})
+	}
+}
+
+// valueOf returns a reflected value as an interface, or nil for an invalid value
+func valueOf(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
@@ -0,0 +1,139 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package events_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+)
+
+// traceDamageEvent is a pointer chain event whose modifiers change it in place
+type traceDamageEvent struct {
+	Damage     int
+	Components []int
+	Roll       traceRoll
+}
+
+type traceRoll struct {
+	Dice  string
+	Bonus int
+}
+
+// ChainTraceTestSuite tests tracing staged chain execution
+type ChainTraceTestSuite struct {
+	suite.Suite
+	ctx    context.Context
+	stages []chain.Stage
+}
+
+func TestChainTraceSuite(t *testing.T) {
+	suite.Run(t, new(ChainTraceTestSuite))
+}
+
+func (s *ChainTraceTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.stages = []chain.Stage{TestStageBase, TestStageModifiers, TestStageConditions, TestStageFinal}
+}
+
+func (s *ChainTraceTestSuite) TestTraceRecordsEachModifier() {
+	bus := events.NewEventBus()
+	attacks := TestAttackChain.On(bus)
+	_, err := attacks.SubscribeWithChain(s.ctx,
+		func(_ context.Context, _ TestAttackEvent, c chain.Chain[TestAttackEvent]) (chain.Chain[TestAttackEvent], error) {
+			s.Require().NoError(c.Add(TestStageFinal, "resistance", func(_ context.Context, e TestAttackEvent) (TestAttackEvent, error) {
+				e.Damage /= 2
+				return e, nil
+			}))
+			s.Require().NoError(c.Add(TestStageConditions, "rage", func(_ context.Context, e TestAttackEvent) (TestAttackEvent, error) {
+				e.Damage += 2
+				return e, nil
+			}))
+			s.Require().NoError(c.Add(TestStageModifiers, "mark", func(_ context.Context, e TestAttackEvent) (TestAttackEvent, error) {
+				return e, nil
+			}))
+			return c, nil
+		})
+	s.Require().NoError(err)
+
+	attack := TestAttackEvent{AttackerID: "barbarian", Damage: 10}
+	modified, err := attacks.PublishWithChain(s.ctx, attack, events.NewStagedChain[TestAttackEvent](s.stages))
+	s.Require().NoError(err)
+
+	result, report, err := events.TraceChain(s.ctx, modified, attack)
+	s.Require().NoError(err)
+	s.Equal(6, result.Damage)
+
+	s.Require().Len(report.Steps, 3)
+	s.Equal(TestStageModifiers, report.Steps[0].Stage)
+	s.Equal("mark", report.Steps[0].ModifierID)
+	s.Empty(report.Steps[0].Changes)
+
+	s.Equal("rage", report.Steps[1].ModifierID)
+	s.Equal([]events.FieldChange{{Field: "Damage", Before: 10, After: 12}}, report.Steps[1].Changes)
+	s.Equal([]events.FieldChange{{Field: "Damage", Before: 12, After: 6}}, report.Steps[2].Changes)
+
+	s.Len(report.StepsChanging("Damage"), 2)
+	s.Empty(report.StepsChanging("TargetID"))
+	s.Contains(report.String(), "conditions/rage")
+	s.Contains(report.String(), "Damage 10 -> 12")
+}
+
+func (s *ChainTraceTestSuite) TestTracePointerEventChangedInPlace() {
+	c := events.NewStagedChain[*traceDamageEvent](s.stages)
+	s.Require().NoError(c.Add(TestStageModifiers, "sneak-attack", func(_ context.Context, e *traceDamageEvent) (*traceDamageEvent, error) {
+		e.Components = append(e.Components, 7)
+		e.Roll.Bonus += 3
+		return e, nil
+	}))
+
+	event := &traceDamageEvent{Damage: 5, Components: []int{5}, Roll: traceRoll{Dice: "1d8"}}
+	_, report, err := c.Trace(s.ctx, event)
+	s.Require().NoError(err)
+
+	s.Require().Len(report.Steps, 1)
+	s.Equal([]events.FieldChange{
+		{Field: "Components", Before: []int{5}, After: []int{5, 7}},
+		{Field: "Roll.Bonus", Before: 0, After: 3},
+	}, report.Steps[0].Changes)
+}
+
+func (s *ChainTraceTestSuite) TestTraceReportsFailingModifier() {
+	errBroken := errors.New("broken")
+	c := events.NewStagedChain[TestAttackEvent](s.stages)
+	s.Require().NoError(c.Add(TestStageBase, "base", func(_ context.Context, e TestAttackEvent) (TestAttackEvent, error) {
+		e.Damage = 4
+		return e, nil
+	}))
+	s.Require().NoError(c.Add(TestStageFinal, "broken", func(_ context.Context, e TestAttackEvent) (TestAttackEvent, error) {
+		return e, errBroken
+	}))
+
+	_, report, err := c.Trace(s.ctx, TestAttackEvent{})
+	s.ErrorIs(err, errBroken)
+	s.Require().Len(report.Steps, 2)
+	s.Equal("broken", report.Steps[1].ModifierID)
+	s.ErrorIs(report.Steps[1].Err, errBroken)
+	s.Contains(report.String(), "error: broken")
+}
+
+func (s *ChainTraceTestSuite) TestExecuteMatchesTrace() {
+	c := events.NewStagedChain[int](s.stages)
+	s.Require().NoError(c.Add(TestStageBase, "double", func(_ context.Context, n int) (int, error) {
+		return n * 2, nil
+	}))
+
+	executed, err := c.Execute(s.ctx, 4)
+	s.Require().NoError(err)
+	traced, report, err := c.Trace(s.ctx, 4)
+	s.Require().NoError(err)
+
+	s.Equal(executed, traced)
+	s.Equal([]events.FieldChange{{Before: 4, After: 8}}, report.Steps[0].Changes, "non-struct events change as a whole")
+}
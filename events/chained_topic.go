@@ -89,19 +89,22 @@ type chainedTopic[T any] struct {
 
 // chainedEvent wraps an event with its chain for passing through the bus
 type chainedEvent[T any] struct {
-	ctx   context.Context
 	event T
 	chain chain.Chain[T]
 }
 
+// deliverSynchronously marks chained events for synchronous delivery on an
+// AsyncEventBus; PublishWithChain reads the chain back once Publish returns
+func (*chainedEvent[T]) deliverSynchronously() {}
+
 // SubscribeWithChain implements ChainedTopic[T]
 func (t *chainedTopic[T]) SubscribeWithChain(ctx context.Context,
 	handler func(context.Context, T, chain.Chain[T]) (chain.Chain[T], error)) (string, error) {
 	// Wrap the handler to work with our chainedEvent wrapper
-	wrappedHandler := func(payload any) error {
+	wrappedHandler := func(ctx context.Context, payload any) error {
 		if ce, ok := payload.(*chainedEvent[T]); ok {
 			// Call the actual handler with the chain
-			newChain, err := handler(ce.ctx, ce.event, ce.chain)
+			newChain, err := handler(ctx, ce.event, ce.chain)
 			if err != nil {
 				return err
 			}
@@ -118,7 +121,6 @@ func (t *chainedTopic[T]) SubscribeWithChain(ctx context.Context,
 func (t *chainedTopic[T]) PublishWithChain(ctx context.Context, event T, chain chain.Chain[T]) (chain.Chain[T], error) {
 	// Create wrapper that carries both event and chain
 	ce := &chainedEvent[T]{
-		ctx:   ctx,
 		event: event,
 		chain: chain,
 	}
@@ -0,0 +1,248 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Payload content types
+const (
+	// ContentTypeJSON is the content type of payloads encoded by JSONCodec
+	ContentTypeJSON = "application/json"
+	// ContentTypeProtobuf is the content type for protobuf payload codecs
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+var (
+	// ErrNoCodec is returned when encoding or decoding an event whose topic
+	// has no registered codec
+	ErrNoCodec = errors.New("no codec registered for topic")
+
+	// ErrInvalidEnvelope is returned for an envelope that can't be decoded:
+	// malformed wire data, or a payload whose content type doesn't match the
+	// topic's codec
+	ErrInvalidEnvelope = errors.New("invalid event envelope")
+)
+
+// Codec encodes the payloads of one topic for transport to another process
+type Codec interface {
+	// ContentType names the payload encoding, e.g. ContentTypeJSON
+	ContentType() string
+
+	// Marshal encodes an event published on the topic
+	Marshal(event any) ([]byte, error)
+
+	// Unmarshal decodes a payload into an event of the topic's Go type, so
+	// typed subscribers on the receiving bus get it
+	Unmarshal(data []byte) (any, error)
+}
+
+// JSONCodec returns a codec encoding T payloads as JSON. Payloads with
+// interface fields (e.g. a core.Entity target) marshal but can't be
+// unmarshaled; give those topics their own codec.
+func JSONCodec[T any]() Codec {
+	return jsonCodec{payload: payloadType[T]()}
+}
+
+// jsonCodec encodes payloads of one Go type as JSON
+type jsonCodec struct {
+	payload reflect.Type
+}
+
+// ContentType returns ContentTypeJSON
+func (c jsonCodec) ContentType() string {
+	return ContentTypeJSON
+}
+
+// Marshal encodes the event as JSON
+func (c jsonCodec) Marshal(event any) ([]byte, error) {
+	if event == nil || !reflect.TypeOf(event).AssignableTo(c.payload) {
+		return nil, fmt.Errorf("json codec: event %T is not a %v", event, c.payload)
+	}
+	return json.Marshal(event)
+}
+
+// Unmarshal decodes JSON into a new payload value
+func (c jsonCodec) Unmarshal(data []byte) (any, error) {
+	payload := reflect.New(c.payload)
+	if err := json.Unmarshal(data, payload.Interface()); err != nil {
+		return nil, fmt.Errorf("json codec: %w", err)
+	}
+	return payload.Elem().Interface(), nil
+}
+
+// NewCodec returns a codec built from functions, for payload encodings other
+// than JSON. For protobuf, convert to and from the generated message:
+//
+//	codec := events.NewCodec(events.ContentTypeProtobuf,
+//	    func(e dnd5eEvents.DamageReceivedEvent) ([]byte, error) { return proto.Marshal(toProto(e)) },
+//	    func(data []byte) (dnd5eEvents.DamageReceivedEvent, error) { return fromProto(data) },
+//	)
+func NewCodec[T any](contentType string, marshal func(T) ([]byte, error), unmarshal func([]byte) (T, error)) Codec {
+	return &funcCodec[T]{contentType: contentType, marshal: marshal, unmarshal: unmarshal}
+}
+
+// funcCodec is a Codec built from functions
+type funcCodec[T any] struct {
+	contentType string
+	marshal     func(T) ([]byte, error)
+	unmarshal   func([]byte) (T, error)
+}
+
+// ContentType returns the codec's content type
+func (c *funcCodec[T]) ContentType() string {
+	return c.contentType
+}
+
+// Marshal encodes the event with the marshal function
+func (c *funcCodec[T]) Marshal(event any) ([]byte, error) {
+	typed, ok := event.(T)
+	if !ok {
+		return nil, fmt.Errorf("%s codec: event %T is not a %v", c.contentType, event, payloadType[T]())
+	}
+	return c.marshal(typed)
+}
+
+// Unmarshal decodes the payload with the unmarshal function
+func (c *funcCodec[T]) Unmarshal(data []byte) (any, error) {
+	return c.unmarshal(data)
+}
+
+// CodecRegistry holds the codec for each topic that crosses a process
+// boundary. Both sides register the same codecs: the sender encodes
+// published events into Envelopes, the receiver decodes them and publishes
+// them on its own bus.
+//
+//	codecs := events.NewCodecRegistry()
+//	codecs.RegisterDefined("dnd5e.combat.#") // JSON codecs for every combat topic
+//
+//	// Game server: forward combat events
+//	events.SubscribePattern(ctx, bus, "dnd5e.combat.#", codecs.Forwarder(send))
+//
+//	// Spectator service: publish them locally
+//	codecs.Publish(ctx, spectatorBus, envelope)
+type CodecRegistry struct {
+	mu     sync.RWMutex
+	codecs map[Topic]Codec
+}
+
+// NewCodecRegistry creates an empty codec registry
+func NewCodecRegistry() *CodecRegistry {
+	return &CodecRegistry{codecs: make(map[Topic]Codec)}
+}
+
+// Register sets the codec for a topic, replacing any codec it had
+func (r *CodecRegistry) Register(topic Topic, codec Codec) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.codecs[topic] = codec
+}
+
+// RegisterDefined registers a JSON codec for every typed topic defined with
+// DefineTypedTopic that matches pattern (see SubscribePattern) and has no
+// codec yet, so imported rulebooks' events need no hand-written marshalers.
+// Chained topics are skipped: they gather modifiers in-process. Returns the
+// topics registered, or ErrInvalidPattern for a malformed pattern.
+func (r *CodecRegistry) RegisterDefined(pattern string) ([]Topic, error) {
+	infos, err := ListTopicsMatching(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var registered []Topic
+	for _, info := range infos {
+		if info.Kind != TopicKindTyped {
+			continue
+		}
+		if _, ok := r.codecs[info.Topic]; ok {
+			continue
+		}
+		r.codecs[info.Topic] = jsonCodec{payload: info.PayloadType}
+		registered = append(registered, info.Topic)
+	}
+	return registered, nil
+}
+
+// Codec returns the codec registered for a topic
+func (r *CodecRegistry) Codec(topic Topic) (Codec, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	codec, ok := r.codecs[topic]
+	return codec, ok
+}
+
+// Encode wraps an event and its meta in an Envelope, encoding the event with
+// its topic's codec. Returns ErrNoCodec if the topic has none.
+func (r *CodecRegistry) Encode(meta EventMeta, event any) (*Envelope, error) {
+	codec, ok := r.Codec(meta.Topic)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNoCodec, meta.Topic)
+	}
+	payload, err := codec.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("encode %s: %w", meta.Topic, err)
+	}
+	return &Envelope{Meta: meta, ContentType: codec.ContentType(), Payload: payload}, nil
+}
+
+// Decode returns the event an Envelope carries, decoded with its topic's
+// codec. Returns ErrNoCodec if the topic has none, and ErrInvalidEnvelope if
+// the payload's content type isn't the codec's.
+func (r *CodecRegistry) Decode(env *Envelope) (any, error) {
+	codec, ok := r.Codec(env.Meta.Topic)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNoCodec, env.Meta.Topic)
+	}
+	if env.ContentType != codec.ContentType() {
+		return nil, fmt.Errorf("%w: %s payload is %q, codec expects %q",
+			ErrInvalidEnvelope, env.Meta.Topic, env.ContentType, codec.ContentType())
+	}
+	event, err := codec.Unmarshal(env.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("decode %s: %w", env.Meta.Topic, err)
+	}
+	return event, nil
+}
+
+// Publish decodes an Envelope and publishes its event on bus. The event is
+// published as a child of the original (see WithCause), so it keeps the
+// original's correlation ID and its ParentID is the original's ID.
+func (r *CodecRegistry) Publish(ctx context.Context, bus EventBus, env *Envelope) error {
+	event, err := r.Decode(env)
+	if err != nil {
+		return err
+	}
+	ctx = WithCorrelationID(WithCause(ctx, env.Meta), env.Meta.CorrelationID)
+	return bus.Publish(ctx, env.Meta.Topic, event)
+}
+
+// Forwarder returns a pattern handler that encodes each event and passes the
+// Envelope to send, e.g. a gRPC stream's Send. Events on topics with no codec
+// are skipped, so the pattern may also match chained topics. A send error is
+// returned to the publisher; wrap send to drop or queue failures instead.
+func (r *CodecRegistry) Forwarder(send func(ctx context.Context, env *Envelope) error) PatternHandler {
+	return func(ctx context.Context, topic Topic, event any) error {
+		if _, ok := r.Codec(topic); !ok {
+			return nil
+		}
+		meta, ok := EventMetaFromContext(ctx)
+		if !ok {
+			meta = EventMeta{Topic: topic}
+		}
+		env, err := r.Encode(meta, event)
+		if err != nil {
+			return err
+		}
+		return send(ctx, env)
+	}
+}
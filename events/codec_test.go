@@ -0,0 +1,219 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package events_test
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/events"
+)
+
+// codecAttackEvent is a payload forwarded between processes
+type codecAttackEvent struct {
+	AttackerID string `json:"attacker_id"`
+	Damage     int    `json:"damage"`
+}
+
+var (
+	codecAttackTopic   = events.DefineTypedTopic[codecAttackEvent]("codec.combat.attack")
+	codecModifierChain = events.DefineChainedTopic[codecAttackEvent]("codec.combat.modifier")
+	codecUnlistedTopic = events.DefineTypedTopic[TestActionEvent]("codec.unlisted")
+)
+
+// CodecTestSuite tests event envelopes and the codec registry
+type CodecTestSuite struct {
+	suite.Suite
+	ctx    context.Context
+	codecs *events.CodecRegistry
+}
+
+func TestCodecSuite(t *testing.T) {
+	suite.Run(t, new(CodecTestSuite))
+}
+
+func (s *CodecTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.codecs = events.NewCodecRegistry()
+	_, err := s.codecs.RegisterDefined("codec.combat.#")
+	s.Require().NoError(err)
+}
+
+// forward publishes event on a sending bus and returns the envelopes its forwarder sent
+func (s *CodecTestSuite) forward(publish func(bus events.EventBus) error) []*events.Envelope {
+	bus := events.NewEventBus()
+	var sent []*events.Envelope
+	_, err := events.SubscribePattern(s.ctx, bus, "codec.#", s.codecs.Forwarder(
+		func(_ context.Context, env *events.Envelope) error {
+			sent = append(sent, env)
+			return nil
+		}))
+	s.Require().NoError(err)
+	s.Require().NoError(publish(bus))
+	return sent
+}
+
+func (s *CodecTestSuite) TestRegisterDefinedSkipsChainedTopics() {
+	codecs := events.NewCodecRegistry()
+	registered, err := codecs.RegisterDefined("codec.#")
+	s.Require().NoError(err)
+	s.ElementsMatch([]events.Topic{"codec.combat.attack", "codec.unlisted"}, registered)
+
+	_, ok := codecs.Codec("codec.combat.modifier")
+	s.False(ok, "chained topics stay in-process")
+
+	_, err = codecs.RegisterDefined("codec.*x")
+	s.ErrorIs(err, events.ErrInvalidPattern)
+}
+
+func (s *CodecTestSuite) TestRoundTrip() {
+	wireFormats := []struct {
+		name      string
+		marshal   func(env *events.Envelope) ([]byte, error)
+		unmarshal func(data []byte, env *events.Envelope) error
+	}{
+		{
+			"json",
+			func(env *events.Envelope) ([]byte, error) { return json.Marshal(env) },
+			func(data []byte, env *events.Envelope) error { return json.Unmarshal(data, env) },
+		},
+		{"protobuf", (*events.Envelope).MarshalBinary, func(data []byte, env *events.Envelope) error {
+			return env.UnmarshalBinary(data)
+		}},
+	}
+
+	for _, format := range wireFormats {
+		s.Run(format.name, func() {
+			sent := s.forward(func(bus events.EventBus) error {
+				ctx := events.WithCorrelationID(s.ctx, "turn-3")
+				return codecAttackTopic.On(bus).Publish(ctx, codecAttackEvent{AttackerID: "hero", Damage: 12})
+			})
+			s.Require().Len(sent, 1)
+			original := sent[0].Meta
+
+			data, err := format.marshal(sent[0])
+			s.Require().NoError(err)
+			var env events.Envelope
+			s.Require().NoError(format.unmarshal(data, &env))
+			s.Equal(*sent[0], env)
+
+			receiver := events.NewEventBus()
+			var received []codecAttackEvent
+			var meta events.EventMeta
+			_, err = codecAttackTopic.On(receiver).Subscribe(s.ctx, func(ctx context.Context, e codecAttackEvent) error {
+				received = append(received, e)
+				meta, _ = events.EventMetaFromContext(ctx)
+				return nil
+			})
+			s.Require().NoError(err)
+
+			s.Require().NoError(s.codecs.Publish(s.ctx, receiver, &env))
+			s.Equal([]codecAttackEvent{{AttackerID: "hero", Damage: 12}}, received)
+			s.Equal(original.ID, meta.ParentID, "the local event is a child of the forwarded one")
+			s.Equal("turn-3", meta.CorrelationID)
+		})
+	}
+}
+
+func (s *CodecTestSuite) TestJSONEnvelopeInlinesJSONPayload() {
+	env := &events.Envelope{
+		Meta:        events.EventMeta{ID: "evt-1", CorrelationID: "evt-1", Topic: "codec.combat.attack"},
+		ContentType: events.ContentTypeJSON,
+		Payload:     []byte(`{"attacker_id":"hero","damage":12}`),
+	}
+	data, err := json.Marshal(env)
+	s.Require().NoError(err)
+	s.Contains(string(data), `"payload":{"attacker_id":"hero","damage":12}`)
+
+	env.Payload = []byte("not json")
+	_, err = json.Marshal(env)
+	s.ErrorIs(err, events.ErrInvalidEnvelope)
+}
+
+func (s *CodecTestSuite) TestCustomCodec() {
+	s.codecs.Register("codec.combat.attack", events.NewCodec(events.ContentTypeProtobuf,
+		func(e codecAttackEvent) ([]byte, error) { return []byte(e.AttackerID), nil },
+		func(data []byte) (codecAttackEvent, error) { return codecAttackEvent{AttackerID: string(data)}, nil },
+	))
+
+	env, err := s.codecs.Encode(events.EventMeta{Topic: "codec.combat.attack"}, codecAttackEvent{AttackerID: "hero"})
+	s.Require().NoError(err)
+	s.Equal(events.ContentTypeProtobuf, env.ContentType)
+
+	// Non-JSON payloads are base64-encoded in the JSON envelope
+	data, err := json.Marshal(env)
+	s.Require().NoError(err)
+	var decoded events.Envelope
+	s.Require().NoError(json.Unmarshal(data, &decoded))
+
+	event, err := s.codecs.Decode(&decoded)
+	s.Require().NoError(err)
+	s.Equal(codecAttackEvent{AttackerID: "hero"}, event)
+
+	_, err = s.codecs.Encode(events.EventMeta{Topic: "codec.combat.attack"}, TestActionEvent{})
+	s.Error(err, "events of another type are rejected")
+}
+
+func (s *CodecTestSuite) TestDecodeErrors() {
+	_, err := s.codecs.Decode(&events.Envelope{Meta: events.EventMeta{Topic: "codec.unlisted"}})
+	s.ErrorIs(err, events.ErrNoCodec)
+
+	_, err = s.codecs.Decode(&events.Envelope{
+		Meta:        events.EventMeta{Topic: "codec.combat.attack"},
+		ContentType: events.ContentTypeProtobuf,
+	})
+	s.ErrorIs(err, events.ErrInvalidEnvelope)
+
+	var env events.Envelope
+	s.ErrorIs(env.UnmarshalBinary([]byte{0x0a, 0x05, 'a'}), events.ErrInvalidEnvelope, "truncated")
+}
+
+func (s *CodecTestSuite) TestUnmarshalBinarySkipsUnknownFields() {
+	env := &events.Envelope{
+		Meta:        events.EventMeta{ID: "evt-1", Topic: "codec.combat.attack"},
+		ContentType: events.ContentTypeJSON,
+		Payload:     []byte(`{}`),
+	}
+	data, err := env.MarshalBinary()
+	s.Require().NoError(err)
+
+	// field 15 varint, field 16 length-delimited, as a newer sender might add
+	data = binary.AppendUvarint(data, 15<<3|0)
+	data = binary.AppendUvarint(data, 300)
+	data = binary.AppendUvarint(data, 16<<3|2)
+	data = binary.AppendUvarint(data, 3)
+	data = append(data, "new"...)
+
+	var decoded events.Envelope
+	s.Require().NoError(decoded.UnmarshalBinary(data))
+	s.Equal(*env, decoded)
+}
+
+func (s *CodecTestSuite) TestForwarderSkipsTopicsWithoutCodecs() {
+	sent := s.forward(func(bus events.EventBus) error {
+		if err := codecUnlistedTopic.On(bus).Publish(s.ctx, TestActionEvent{}); err != nil {
+			return err
+		}
+		chain := events.NewStagedChain[codecAttackEvent](nil)
+		_, err := codecModifierChain.On(bus).PublishWithChain(s.ctx, codecAttackEvent{}, chain)
+		return err
+	})
+	s.Empty(sent)
+}
+
+func (s *CodecTestSuite) TestForwarderReturnsSendErrors() {
+	bus := events.NewEventBus()
+	_, err := events.SubscribePattern(s.ctx, bus, "codec.#", s.codecs.Forwarder(
+		func(context.Context, *events.Envelope) error {
+			return context.DeadlineExceeded
+		}))
+	s.Require().NoError(err)
+
+	err = codecAttackTopic.On(bus).Publish(s.ctx, codecAttackEvent{})
+	s.ErrorIs(err, context.DeadlineExceeded)
+}
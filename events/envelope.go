@@ -0,0 +1,185 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package events
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+)
+
+// Envelope is the wire form of one event: its meta and its payload, encoded
+// by the topic's Codec (see CodecRegistry). It has two stable encodings.
+//
+// JSON (MarshalJSON), with a JSON payload inline and any other payload
+// base64-encoded:
+//
+//	{"meta":{"id":"evt-7","correlation_id":"evt-1","topic":"dnd5e.combat.damage"},
+//	 "content_type":"application/json","payload":{"damage":12}}
+//
+// Protobuf (MarshalBinary), the wire format of this message, so a gRPC
+// service can declare it and carry envelopes without a marshaler per event:
+//
+//	message EventEnvelope {
+//	  string topic = 1;
+//	  string id = 2;
+//	  string parent_id = 3;
+//	  string correlation_id = 4;
+//	  string content_type = 5;
+//	  bytes payload = 6;
+//	}
+//
+// New fields will only be added with new field numbers, and decoding skips
+// fields it doesn't know.
+type Envelope struct {
+	Meta        EventMeta
+	ContentType string
+	Payload     []byte
+}
+
+// Envelope protobuf field numbers
+const (
+	envelopeFieldTopic         = 1
+	envelopeFieldID            = 2
+	envelopeFieldParentID      = 3
+	envelopeFieldCorrelationID = 4
+	envelopeFieldContentType   = 5
+	envelopeFieldPayload       = 6
+)
+
+// Protobuf wire types
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// envelopeJSON is the JSON form of an Envelope
+type envelopeJSON struct {
+	Meta        EventMeta       `json:"meta"`
+	ContentType string          `json:"content_type"`
+	Payload     json.RawMessage `json:"payload,omitempty"`
+}
+
+// MarshalJSON encodes the envelope as JSON, with a JSON payload inline
+func (e Envelope) MarshalJSON() ([]byte, error) {
+	wire := envelopeJSON{Meta: e.Meta, ContentType: e.ContentType}
+	switch {
+	case len(e.Payload) == 0:
+	case e.ContentType == ContentTypeJSON:
+		if !json.Valid(e.Payload) {
+			return nil, fmt.Errorf("%w: %s payload is not valid JSON", ErrInvalidEnvelope, e.Meta.Topic)
+		}
+		wire.Payload = e.Payload
+	default:
+		encoded, err := json.Marshal(e.Payload) // base64
+		if err != nil {
+			return nil, err
+		}
+		wire.Payload = encoded
+	}
+	return json.Marshal(wire)
+}
+
+// UnmarshalJSON decodes an envelope encoded by MarshalJSON
+func (e *Envelope) UnmarshalJSON(data []byte) error {
+	var wire envelopeJSON
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidEnvelope, err)
+	}
+
+	*e = Envelope{Meta: wire.Meta, ContentType: wire.ContentType}
+	switch {
+	case len(wire.Payload) == 0:
+	case wire.ContentType == ContentTypeJSON:
+		e.Payload = wire.Payload
+	default:
+		if err := json.Unmarshal(wire.Payload, &e.Payload); err != nil {
+			return fmt.Errorf("%w: payload: %w", ErrInvalidEnvelope, err)
+		}
+	}
+	return nil
+}
+
+// MarshalBinary encodes the envelope in protobuf wire format
+func (e *Envelope) MarshalBinary() ([]byte, error) {
+	var buf []byte
+	buf = appendProtoBytes(buf, envelopeFieldTopic, []byte(e.Meta.Topic))
+	buf = appendProtoBytes(buf, envelopeFieldID, []byte(e.Meta.ID))
+	buf = appendProtoBytes(buf, envelopeFieldParentID, []byte(e.Meta.ParentID))
+	buf = appendProtoBytes(buf, envelopeFieldCorrelationID, []byte(e.Meta.CorrelationID))
+	buf = appendProtoBytes(buf, envelopeFieldContentType, []byte(e.ContentType))
+	buf = appendProtoBytes(buf, envelopeFieldPayload, e.Payload)
+	return buf, nil
+}
+
+// UnmarshalBinary decodes an envelope in protobuf wire format
+func (e *Envelope) UnmarshalBinary(data []byte) error {
+	*e = Envelope{}
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return fmt.Errorf("%w: malformed field key", ErrInvalidEnvelope)
+		}
+		data = data[n:]
+
+		field, wireType := key>>3, key&7
+		var value []byte
+		switch wireType {
+		case wireVarint:
+			if _, n = binary.Uvarint(data); n <= 0 {
+				return fmt.Errorf("%w: malformed varint in field %d", ErrInvalidEnvelope, field)
+			}
+			data = data[n:]
+			continue
+		case wireFixed64, wireFixed32:
+			size := 8
+			if wireType == wireFixed32 {
+				size = 4
+			}
+			if len(data) < size {
+				return fmt.Errorf("%w: truncated field %d", ErrInvalidEnvelope, field)
+			}
+			data = data[size:]
+			continue
+		case wireBytes:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || length > uint64(len(data)-n) {
+				return fmt.Errorf("%w: truncated field %d", ErrInvalidEnvelope, field)
+			}
+			value = data[n : n+int(length)]
+			data = data[n+int(length):]
+		default:
+			return fmt.Errorf("%w: unsupported wire type %d in field %d", ErrInvalidEnvelope, wireType, field)
+		}
+
+		switch field {
+		case envelopeFieldTopic:
+			e.Meta.Topic = Topic(value)
+		case envelopeFieldID:
+			e.Meta.ID = string(value)
+		case envelopeFieldParentID:
+			e.Meta.ParentID = string(value)
+		case envelopeFieldCorrelationID:
+			e.Meta.CorrelationID = string(value)
+		case envelopeFieldContentType:
+			e.ContentType = string(value)
+		case envelopeFieldPayload:
+			e.Payload = append([]byte(nil), value...)
+		}
+	}
+	return nil
+}
+
+// appendProtoBytes appends a length-delimited field, omitting it when empty
+// as proto3 does
+func appendProtoBytes(buf []byte, field int, value []byte) []byte {
+	if len(value) == 0 {
+		return buf
+	}
+	buf = binary.AppendUvarint(buf, uint64(field)<<3|wireBytes)
+	buf = binary.AppendUvarint(buf, uint64(len(value)))
+	return append(buf, value...)
+}
@@ -0,0 +1,249 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// DefaultExportBuffer is how many messages Serve holds for a client that
+// hasn't received them yet
+const DefaultExportBuffer = 64
+
+var (
+	// ErrNotExported is returned by Exporter.Convert for an event whose topic has
+	// no converter and no envelope fallback
+	ErrNotExported = errors.New("topic is not exported")
+
+	// ErrClientTooSlow is returned by Serve when a client falls a full buffer
+	// behind (see Exporter.SetClientBuffer)
+	ErrClientTooSlow = errors.New("export client is too slow")
+)
+
+// Stream is the sending half of a stream of messages. A gRPC server stream
+// (e.g. pb.CombatService_WatchServer) satisfies it for its generated message.
+type Stream[M any] interface {
+	Send(M) error
+}
+
+// Exporter maps events to the messages of a service's stream, typically a
+// generated protobuf message such as *pb.CombatEvent, and streams them to
+// clients. Rulebooks or servers register a converter per topic:
+//
+//	exporter := events.NewExporter[*pb.CombatEvent]()
+//	events.RegisterExport(exporter, dnd5eEvents.DamageReceivedTopic,
+//	    func(meta events.EventMeta, e dnd5eEvents.DamageReceivedEvent) (*pb.CombatEvent, error) {
+//	        return &pb.CombatEvent{Id: meta.ID, Damage: &pb.Damage{Target: e.TargetID, Amount: int32(e.Amount)}}, nil
+//	    })
+//
+//	// In the Watch RPC handler: stream until the client disconnects
+//	return exporter.Serve(stream.Context(), bus, "dnd5e.combat.#", stream)
+//
+// Topics without a converter can still be exported as Envelopes, see
+// ExportEnvelopes.
+type Exporter[M any] struct {
+	mu         sync.RWMutex
+	converters map[Topic]func(EventMeta, any) (M, error)
+	codecs     *CodecRegistry
+	fromEnv    func(*Envelope) (M, error)
+	buffer     int
+}
+
+// NewExporter creates an exporter with no converters
+func NewExporter[M any]() *Exporter[M] {
+	return &Exporter[M]{
+		converters: make(map[Topic]func(EventMeta, any) (M, error)),
+		buffer:     DefaultExportBuffer,
+	}
+}
+
+// SetClientBuffer sets how many messages Serve holds for each client before
+// disconnecting it with ErrClientTooSlow (default DefaultExportBuffer). It
+// applies to Serve calls that start afterwards.
+func (x *Exporter[M]) SetClientBuffer(size int) {
+	if size < 1 {
+		size = 1
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.buffer = size
+}
+
+// RegisterExport sets the converter for a typed topic's events, replacing any
+// converter it had
+func RegisterExport[T, M any](x *Exporter[M], topic *TypedTopicDef[T], convert func(EventMeta, T) (M, error)) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.converters[topic.Topic()] = func(meta EventMeta, event any) (M, error) {
+		typed, ok := event.(T)
+		if !ok {
+			var zero M
+			return zero, fmt.Errorf("export %s: event %T is not a %v", meta.Topic, event, payloadType[T]())
+		}
+		return convert(meta, typed)
+	}
+}
+
+// ExportEnvelopes exports topics without a converter that have a codec in
+// codecs: the event is encoded into an Envelope and fromEnv wraps it in a
+// message, e.g. a oneof field carrying the raw envelope.
+func (x *Exporter[M]) ExportEnvelopes(codecs *CodecRegistry, fromEnv func(*Envelope) (M, error)) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.codecs = codecs
+	x.fromEnv = fromEnv
+}
+
+// Exports reports whether events on topic are exported
+func (x *Exporter[M]) Exports(topic Topic) bool {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	if _, ok := x.converters[topic]; ok {
+		return true
+	}
+	if x.codecs == nil {
+		return false
+	}
+	_, ok := x.codecs.Codec(topic)
+	return ok
+}
+
+// Convert maps one event to a message. Returns ErrNotExported if the topic
+// has no converter and no envelope fallback.
+func (x *Exporter[M]) Convert(meta EventMeta, event any) (M, error) {
+	x.mu.RLock()
+	convert, ok := x.converters[meta.Topic]
+	codecs, fromEnv := x.codecs, x.fromEnv
+	x.mu.RUnlock()
+
+	if ok {
+		return convert(meta, event)
+	}
+
+	var zero M
+	if codecs == nil {
+		return zero, fmt.Errorf("%w: %s", ErrNotExported, meta.Topic)
+	}
+	env, err := codecs.Encode(meta, event)
+	if errors.Is(err, ErrNoCodec) {
+		return zero, fmt.Errorf("%w: %s", ErrNotExported, meta.Topic)
+	}
+	if err != nil {
+		return zero, err
+	}
+	return fromEnv(env)
+}
+
+// Serve streams every exported event matching pattern (see SubscribePattern)
+// to stream until ctx is done or a send fails, then unsubscribes. It returns
+// nil when ctx ends and the send error otherwise.
+//
+// Publishers never wait on the client: converted messages go into a bounded
+// buffer that one goroutine drains into stream, so sends stay serialized, as
+// gRPC streams require. A client that falls a full buffer behind is
+// disconnected with ErrClientTooSlow rather than skipped, so a client never
+// sees a stream with gaps. A failed send or conversion never fails the
+// publisher: game logic doesn't stop because a client went away.
+//
+// Serve doesn't wait for a send already in progress when it returns; stream's
+// Send must return once the client's RPC ends, as a gRPC stream's does.
+func (x *Exporter[M]) Serve(ctx context.Context, bus EventBus, pattern string, stream Stream[M]) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	x.mu.RLock()
+	queue := make(chan M, x.buffer)
+	x.mu.RUnlock()
+
+	var (
+		mu     sync.Mutex
+		served error
+	)
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if served == nil {
+			served = err
+			cancel()
+		}
+	}
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-queue:
+				if ctx.Err() != nil {
+					return
+				}
+				if err := stream.Send(msg); err != nil {
+					fail(err)
+					return
+				}
+			}
+		}
+	}()
+
+	id, err := SubscribePattern(ctx, bus, pattern, func(eventCtx context.Context, topic Topic, event any) error {
+		if ctx.Err() != nil || !x.Exports(topic) {
+			return nil
+		}
+		meta, ok := EventMetaFromContext(eventCtx)
+		if !ok {
+			meta = EventMeta{Topic: topic}
+		}
+		msg, err := x.Convert(meta, event)
+		if err != nil {
+			fail(err)
+			return nil
+		}
+		select {
+		case queue <- msg:
+		default:
+			fail(ErrClientTooSlow)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	<-ctx.Done()
+	// Use a fresh context: ctx is already done
+	unsubscribeErr := bus.Unsubscribe(context.Background(), id)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if served != nil {
+		return served
+	}
+	return unsubscribeErr
+}
+
+// ChannelStream returns a Stream that sends messages on ch, for servers that
+// fan events out themselves or for tests. Send blocks until the message is
+// received or ctx is done, and then returns ctx's error.
+func ChannelStream[M any](ctx context.Context, ch chan<- M) Stream[M] {
+	return channelStream[M]{ctx: ctx, ch: ch}
+}
+
+// channelStream is a Stream backed by a channel
+type channelStream[M any] struct {
+	ctx context.Context
+	ch  chan<- M
+}
+
+// Send sends msg on the channel
+func (s channelStream[M]) Send(msg M) error {
+	select {
+	case s.ch <- msg:
+		return nil
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package events_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/events"
+)
+
+// exportMessage stands in for a generated protobuf message
+type exportMessage struct {
+	EventID  string
+	Summary  string
+	Envelope *events.Envelope
+}
+
+var (
+	exportAttackTopic = events.DefineTypedTopic[codecAttackEvent]("export.combat.attack")
+	exportHealTopic   = events.DefineTypedTopic[codecAttackEvent]("export.combat.heal")
+	exportChatTopic   = events.DefineTypedTopic[TestActionEvent]("export.chat")
+)
+
+// failingStream rejects every message, like a client that disconnected
+type failingStream struct{}
+
+func (failingStream) Send(*exportMessage) error { return errors.New("client gone") }
+
+// blockingStream never finishes a send until release is closed, like a client
+// on a stalled connection
+type blockingStream struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingStream) Send(*exportMessage) error {
+	select {
+	case b.started <- struct{}{}:
+	default:
+	}
+	<-b.release
+	return nil
+}
+
+// ExportTestSuite tests exporting events to message streams
+type ExportTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	bus      events.EventBus
+	exporter *events.Exporter[*exportMessage]
+}
+
+func TestExportSuite(t *testing.T) {
+	suite.Run(t, new(ExportTestSuite))
+}
+
+func (s *ExportTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.exporter = events.NewExporter[*exportMessage]()
+	events.RegisterExport(s.exporter, exportAttackTopic,
+		func(meta events.EventMeta, e codecAttackEvent) (*exportMessage, error) {
+			return &exportMessage{EventID: meta.ID, Summary: fmt.Sprintf("%s hit for %d", e.AttackerID, e.Damage)}, nil
+		})
+}
+
+// publishAttack publishes an attack on the suite's bus
+func (s *ExportTestSuite) publishAttack() {
+	s.Require().NoError(exportAttackTopic.On(s.bus).Publish(s.ctx, codecAttackEvent{AttackerID: "goblin", Damage: 4}))
+}
+
+func (s *ExportTestSuite) TestServeStreamsConvertedEvents() {
+	ctx, cancel := context.WithCancel(s.ctx)
+	received := make(chan *exportMessage, 100)
+	done := make(chan error, 1)
+	go func() {
+		done <- s.exporter.Serve(ctx, s.bus, "export.#", events.ChannelStream(ctx, received))
+	}()
+
+	// Serve subscribes on its own goroutine; publish until it is listening
+	var msg *exportMessage
+	s.Require().Eventually(func() bool {
+		s.publishAttack()
+		select {
+		case msg = <-received:
+			return true
+		default:
+			return false
+		}
+	}, time.Second, time.Millisecond)
+
+	s.Equal("goblin hit for 4", msg.Summary)
+	s.NotEmpty(msg.EventID, "converters get the event's meta")
+
+	// Topics without a converter are skipped
+	s.Require().NoError(exportChatTopic.On(s.bus).Publish(s.ctx, TestActionEvent{}))
+
+	cancel()
+	s.Require().NoError(<-done)
+
+	// After Serve returns nothing more is sent
+	for len(received) > 0 {
+		<-received
+	}
+	s.publishAttack()
+	s.Empty(received)
+}
+
+func (s *ExportTestSuite) TestServeReturnsSendErrorWithoutFailingPublisher() {
+	done := make(chan error, 1)
+	go func() {
+		done <- s.exporter.Serve(s.ctx, s.bus, "export.#", failingStream{})
+	}()
+
+	s.Require().Eventually(func() bool {
+		// The publisher never sees the stream's error
+		s.publishAttack()
+		select {
+		case err := <-done:
+			s.EqualError(err, "client gone")
+			return true
+		default:
+			return false
+		}
+	}, time.Second, time.Millisecond)
+}
+
+func (s *ExportTestSuite) TestServeDisconnectsBlockedClientWithoutBlockingPublisher() {
+	s.exporter.SetClientBuffer(2)
+	stream := &blockingStream{started: make(chan struct{}, 1), release: make(chan struct{})}
+	defer close(stream.release)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.exporter.Serve(s.ctx, s.bus, "export.#", stream)
+	}()
+
+	// Publish until the client is stuck on its first message
+	s.Require().Eventually(func() bool {
+		s.publishAttack()
+		select {
+		case <-stream.started:
+			return true
+		default:
+			return false
+		}
+	}, time.Second, time.Millisecond)
+
+	// The buffer fills and the client is dropped; publishing never waits on it
+	published := make(chan struct{})
+	go func() {
+		defer close(published)
+		for range 10 {
+			s.publishAttack()
+		}
+	}()
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		s.FailNow("publishing blocked on a stalled client")
+	}
+
+	select {
+	case err := <-done:
+		s.ErrorIs(err, events.ErrClientTooSlow)
+	case <-time.After(time.Second):
+		s.FailNow("Serve kept a stalled client")
+	}
+}
+
+func (s *ExportTestSuite) TestConvertFallsBackToEnvelopes() {
+	codecs := events.NewCodecRegistry()
+	_, err := codecs.RegisterDefined("export.combat.#")
+	s.Require().NoError(err)
+	s.exporter.ExportEnvelopes(codecs, func(env *events.Envelope) (*exportMessage, error) {
+		return &exportMessage{Envelope: env}, nil
+	})
+
+	s.True(s.exporter.Exports(exportHealTopic.Topic()))
+	s.False(s.exporter.Exports(exportChatTopic.Topic()))
+
+	msg, err := s.exporter.Convert(events.EventMeta{ID: "evt-1", Topic: exportHealTopic.Topic()},
+		codecAttackEvent{AttackerID: "cleric", Damage: 7})
+	s.Require().NoError(err)
+	s.Require().NotNil(msg.Envelope)
+	s.Equal("evt-1", msg.Envelope.Meta.ID)
+	s.JSONEq(`{"attacker_id":"cleric","damage":7}`, string(msg.Envelope.Payload))
+
+	// Registered converters win over the fallback
+	msg, err = s.exporter.Convert(events.EventMeta{Topic: exportAttackTopic.Topic()}, codecAttackEvent{AttackerID: "orc", Damage: 9})
+	s.Require().NoError(err)
+	s.Nil(msg.Envelope)
+	s.Equal("orc hit for 9", msg.Summary)
+
+	_, err = s.exporter.Convert(events.EventMeta{Topic: exportChatTopic.Topic()}, TestActionEvent{})
+	s.ErrorIs(err, events.ErrNotExported)
+}
+
+func (s *ExportTestSuite) TestConvertRejectsWrongPayload() {
+	_, err := s.exporter.Convert(events.EventMeta{Topic: exportAttackTopic.Topic()}, TestActionEvent{})
+	s.Error(err)
+}
@@ -0,0 +1,227 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// correlationIDKey is the context key for a publish's correlation ID
+type correlationIDKey struct{}
+
+// WithCorrelationID returns a context carrying a correlation ID. An
+// EventJournal records it with every event published under that context, so
+// events caused by one player action can be grouped together.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationIDFromContext returns the context's correlation ID, or "" if it has none
+func CorrelationIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+	return id
+}
+
+// JournalEntry is one event recorded by an EventJournal
+type JournalEntry struct {
+	// Sequence numbers entries in publish order, starting at 1
+	Sequence uint64 `json:"sequence"`
+
+	// Topic the event was published on
+	Topic Topic `json:"topic"`
+
+	// Event is the published payload
+	Event any `json:"event"`
+
+	// Timestamp is when the event was published
+	Timestamp time.Time `json:"timestamp"`
+
+	// CorrelationID is the publish context's correlation ID (see WithCorrelationID)
+	CorrelationID string `json:"correlation_id,omitempty"`
+
+	// Nested is true for events published by a handler while another event
+	// was being delivered. Replaying onto a bus with the same handlers
+	// publishes them again, so ReplayOptions.SkipNested leaves them out.
+	Nested bool `json:"nested,omitempty"`
+}
+
+// JournalConfig configures an event journal.
+type JournalConfig struct {
+	// Bus delivers the journaled events (default NewEventBus())
+	Bus EventBus
+
+	// Now stamps entries (default time.Now)
+	Now func() time.Time
+
+	// OnRecord receives each entry as it is recorded, e.g. to persist it.
+	// It runs before the event is delivered.
+	OnRecord func(entry JournalEntry)
+}
+
+// ReplayOptions controls which entries a replay publishes.
+type ReplayOptions struct {
+	// SkipNested leaves out events published by handlers. Set it when the
+	// target bus has the same handlers as the original, which publish those
+	// events again.
+	SkipNested bool
+
+	// Filter, if set, replays only the entries it returns true for
+	Filter func(entry JournalEntry) bool
+}
+
+// EventJournal is an EventBus that records every event published through it
+// before delivering it on the bus it wraps. The recorded entries can be
+// replayed onto another bus to reproduce a combat or rebuild state.
+//
+// Chained events (ChainedTopic.PublishWithChain) are not recorded: they ask
+// subscribers for modifiers rather than report that something happened, and
+// the publisher makes them again when it runs.
+//
+// Nested is only reliable when one goroutine publishes at a time and
+// handlers run in the publisher's goroutine (NewEventBus). Behind an
+// AsyncEventBus every event looks top-level.
+type EventJournal struct {
+	bus      EventBus
+	now      func() time.Time
+	onRecord func(entry JournalEntry)
+
+	mu       sync.Mutex
+	entries  []JournalEntry
+	sequence uint64
+	depth    int // publishes in progress, for Nested
+}
+
+// Ensure EventJournal implements EventBus and supports middleware
+var (
+	_ EventBus       = (*EventJournal)(nil)
+	_ MiddlewareUser = (*EventJournal)(nil)
+)
+
+// NewEventJournal creates an event journal wrapping config.Bus
+func NewEventJournal(config JournalConfig) *EventJournal {
+	bus := config.Bus
+	if bus == nil {
+		bus = NewEventBus()
+	}
+	now := config.Now
+	if now == nil {
+		now = time.Now
+	}
+
+	return &EventJournal{
+		bus:      bus,
+		now:      now,
+		onRecord: config.OnRecord,
+	}
+}
+
+// Subscribe registers a handler on the wrapped bus
+func (j *EventJournal) Subscribe(ctx context.Context, topic Topic, handler any) (string, error) {
+	return j.bus.Subscribe(ctx, topic, handler)
+}
+
+// SubscribePattern registers a pattern handler on the wrapped bus. Returns
+// ErrPatternsUnsupported if the wrapped bus doesn't support patterns.
+func (j *EventJournal) SubscribePattern(ctx context.Context, pattern string, handler PatternHandler) (string, error) {
+	return SubscribePattern(ctx, j.bus, pattern, handler)
+}
+
+// Use adds middleware to the wrapped bus. Events are recorded before the
+// middleware runs, so events it stops are still journaled. Does nothing if
+// the wrapped bus doesn't support middleware.
+func (j *EventJournal) Use(middleware ...Middleware) {
+	_ = Use(j.bus, middleware...)
+}
+
+// Unsubscribe removes a subscription from the wrapped bus
+func (j *EventJournal) Unsubscribe(ctx context.Context, id string) error {
+	return j.bus.Unsubscribe(ctx, id)
+}
+
+// Publish records the event, then delivers it on the wrapped bus. The event
+// is recorded even if delivery fails.
+func (j *EventJournal) Publish(ctx context.Context, topic Topic, event any) error {
+	if _, ok := event.(synchronousEvent); ok {
+		return j.bus.Publish(ctx, topic, event)
+	}
+
+	j.mu.Lock()
+	j.sequence++
+	entry := JournalEntry{
+		Sequence:      j.sequence,
+		Topic:         topic,
+		Event:         event,
+		Timestamp:     j.now(),
+		CorrelationID: CorrelationIDFromContext(ctx),
+		Nested:        j.depth > 0,
+	}
+	j.entries = append(j.entries, entry)
+	j.depth++
+	j.mu.Unlock()
+
+	defer func() {
+		j.mu.Lock()
+		j.depth--
+		j.mu.Unlock()
+	}()
+
+	if j.onRecord != nil {
+		j.onRecord(entry)
+	}
+
+	return j.bus.Publish(ctx, topic, event)
+}
+
+// Entries returns a copy of the recorded entries in publish order
+func (j *EventJournal) Entries() []JournalEntry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entries := make([]JournalEntry, len(j.entries))
+	copy(entries, j.entries)
+	return entries
+}
+
+// Reset discards the recorded entries. Sequence numbers keep counting up.
+func (j *EventJournal) Reset() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.entries = nil
+}
+
+// Replay publishes the recorded entries onto target in order
+func (j *EventJournal) Replay(ctx context.Context, target EventBus, opts ReplayOptions) error {
+	return ReplayEntries(ctx, target, j.Entries(), opts)
+}
+
+// ReplayEntries publishes entries onto target in the order given. Each event
+// is published under its recorded correlation ID.
+//
+// Entries loaded from storage must hold the same Go types that were
+// published: typed subscribers ignore payloads of any other type.
+//
+// Stops at the first publish error, returning it with the entry's sequence.
+func ReplayEntries(ctx context.Context, target EventBus, entries []JournalEntry, opts ReplayOptions) error {
+	for _, entry := range entries {
+		if opts.SkipNested && entry.Nested {
+			continue
+		}
+		if opts.Filter != nil && !opts.Filter(entry) {
+			continue
+		}
+
+		replayCtx := ctx
+		if entry.CorrelationID != "" {
+			replayCtx = WithCorrelationID(ctx, entry.CorrelationID)
+		}
+		if err := target.Publish(replayCtx, entry.Topic, entry.Event); err != nil {
+			return fmt.Errorf("replay entry %d (%s): %w", entry.Sequence, entry.Topic, err)
+		}
+	}
+	return nil
+}
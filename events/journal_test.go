@@ -0,0 +1,232 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package events_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+)
+
+// EventJournalTestSuite tests event recording and replay
+type EventJournalTestSuite struct {
+	suite.Suite
+	ctx     context.Context
+	clock   time.Time
+	journal *events.EventJournal
+}
+
+func TestEventJournalSuite(t *testing.T) {
+	suite.Run(t, new(EventJournalTestSuite))
+}
+
+func (s *EventJournalTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.clock = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.journal = events.NewEventJournal(events.JournalConfig{
+		Now: func() time.Time {
+			s.clock = s.clock.Add(time.Second)
+			return s.clock
+		},
+	})
+}
+
+func (s *EventJournalTestSuite) TestRecordsPublishedEvents() {
+	var delivered []TestNotificationEvent
+	_, err := NotificationTopic.On(s.journal).Subscribe(s.ctx, func(_ context.Context, e TestNotificationEvent) error {
+		delivered = append(delivered, e)
+		return nil
+	})
+	s.Require().NoError(err)
+
+	ctx := events.WithCorrelationID(s.ctx, "turn-1")
+	s.Require().NoError(NotificationTopic.On(s.journal).Publish(ctx, TestNotificationEvent{ID: testHero}))
+	s.Require().NoError(ActionTopic.On(s.journal).Publish(s.ctx, TestActionEvent{ActorID: testGoblin}))
+
+	s.Len(delivered, 1, "events still reach subscribers")
+
+	entries := s.journal.Entries()
+	s.Require().Len(entries, 2)
+
+	s.Equal(uint64(1), entries[0].Sequence)
+	s.Equal(TopicNotification, entries[0].Topic)
+	s.Equal(TestNotificationEvent{ID: testHero}, entries[0].Event)
+	s.Equal("turn-1", entries[0].CorrelationID)
+	s.Equal(time.Date(2024, 1, 1, 12, 0, 1, 0, time.UTC), entries[0].Timestamp)
+
+	s.Equal(uint64(2), entries[1].Sequence)
+	s.Equal(TopicAction, entries[1].Topic)
+	s.Empty(entries[1].CorrelationID)
+}
+
+func (s *EventJournalTestSuite) TestMarksEventsPublishedByHandlers() {
+	_, err := ActionTopic.On(s.journal).Subscribe(s.ctx, func(ctx context.Context, e TestActionEvent) error {
+		return NotificationTopic.On(s.journal).Publish(ctx, TestNotificationEvent{ID: e.ActorID})
+	})
+	s.Require().NoError(err)
+
+	s.Require().NoError(ActionTopic.On(s.journal).Publish(s.ctx, TestActionEvent{ActorID: testHero}))
+	s.Require().NoError(NotificationTopic.On(s.journal).Publish(s.ctx, TestNotificationEvent{ID: testGoblin}))
+
+	entries := s.journal.Entries()
+	s.Require().Len(entries, 3)
+	s.False(entries[0].Nested)
+	s.True(entries[1].Nested, "published by the action handler")
+	s.False(entries[2].Nested)
+}
+
+func (s *EventJournalTestSuite) TestChainedEventsNotRecorded() {
+	attacks := TestAttackChain.On(s.journal)
+	_, err := attacks.SubscribeWithChain(s.ctx, func(
+		_ context.Context, _ TestAttackEvent, c chain.Chain[TestAttackEvent],
+	) (chain.Chain[TestAttackEvent], error) {
+		err := c.Add(TestStageConditions, "rage", func(_ context.Context, e TestAttackEvent) (TestAttackEvent, error) {
+			e.Damage += 2
+			return e, nil
+		})
+		return c, err
+	})
+	s.Require().NoError(err)
+
+	attack := TestAttackEvent{AttackerID: testBarbarian, Damage: 10}
+	stages := []chain.Stage{TestStageBase, TestStageConditions, TestStageFinal}
+	modified, err := attacks.PublishWithChain(s.ctx, attack, events.NewStagedChain[TestAttackEvent](stages))
+	s.Require().NoError(err)
+	result, err := modified.Execute(s.ctx, attack)
+	s.Require().NoError(err)
+
+	s.Equal(12, result.Damage, "chain still collects modifiers")
+	s.Empty(s.journal.Entries())
+}
+
+func (s *EventJournalTestSuite) TestReplayOntoFreshBus() {
+	notifications := NotificationTopic.On(s.journal)
+	s.Require().NoError(notifications.Publish(events.WithCorrelationID(s.ctx, "turn-1"), TestNotificationEvent{Value: 1}))
+	s.Require().NoError(notifications.Publish(s.ctx, TestNotificationEvent{Value: 2}))
+	s.Require().NoError(ActionTopic.On(s.journal).Publish(s.ctx, TestActionEvent{ActorID: testDragon}))
+
+	fresh := events.NewEventBus()
+	var values []int
+	var actors []string
+	_, err := NotificationTopic.On(fresh).Subscribe(s.ctx, func(_ context.Context, e TestNotificationEvent) error {
+		values = append(values, e.Value)
+		return nil
+	})
+	s.Require().NoError(err)
+	_, err = ActionTopic.On(fresh).Subscribe(s.ctx, func(_ context.Context, e TestActionEvent) error {
+		actors = append(actors, e.ActorID)
+		return nil
+	})
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.journal.Replay(s.ctx, fresh, events.ReplayOptions{}))
+	s.Equal([]int{1, 2}, values)
+	s.Equal([]string{testDragon}, actors)
+}
+
+func (s *EventJournalTestSuite) TestReplayCarriesCorrelationID() {
+	s.Require().NoError(NotificationTopic.On(s.journal).Publish(
+		events.WithCorrelationID(s.ctx, "turn-7"), TestNotificationEvent{}))
+
+	replayed := events.NewEventJournal(events.JournalConfig{})
+	s.Require().NoError(s.journal.Replay(s.ctx, replayed, events.ReplayOptions{}))
+
+	entries := replayed.Entries()
+	s.Require().Len(entries, 1)
+	s.Equal("turn-7", entries[0].CorrelationID)
+}
+
+func (s *EventJournalTestSuite) TestReplayOptions() {
+	_, err := ActionTopic.On(s.journal).Subscribe(s.ctx, func(ctx context.Context, e TestActionEvent) error {
+		return NotificationTopic.On(s.journal).Publish(ctx, TestNotificationEvent{ID: e.ActorID})
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(ActionTopic.On(s.journal).Publish(s.ctx, TestActionEvent{ActorID: testHero}))
+	s.Require().NoError(ActionTopic.On(s.journal).Publish(s.ctx, TestActionEvent{ActorID: testGoblin}))
+
+	s.Run("skip nested", func() {
+		target := events.NewEventJournal(events.JournalConfig{})
+		s.Require().NoError(s.journal.Replay(s.ctx, target, events.ReplayOptions{SkipNested: true}))
+
+		entries := target.Entries()
+		s.Require().Len(entries, 2)
+		s.Equal(TopicAction, entries[0].Topic)
+		s.Equal(TopicAction, entries[1].Topic)
+	})
+
+	s.Run("filter", func() {
+		target := events.NewEventJournal(events.JournalConfig{})
+		err := s.journal.Replay(s.ctx, target, events.ReplayOptions{
+			Filter: func(entry events.JournalEntry) bool { return entry.Topic == TopicNotification },
+		})
+		s.Require().NoError(err)
+		s.Len(target.Entries(), 2)
+	})
+}
+
+func (s *EventJournalTestSuite) TestReplayStopsOnError() {
+	notifications := NotificationTopic.On(s.journal)
+	for i := 1; i <= 3; i++ {
+		s.Require().NoError(notifications.Publish(s.ctx, TestNotificationEvent{Value: i}))
+	}
+
+	handlerErr := errors.New("handler failed")
+	fresh := events.NewEventBus()
+	var values []int
+	_, err := NotificationTopic.On(fresh).Subscribe(s.ctx, func(_ context.Context, e TestNotificationEvent) error {
+		values = append(values, e.Value)
+		if e.Value == 2 {
+			return handlerErr
+		}
+		return nil
+	})
+	s.Require().NoError(err)
+
+	err = s.journal.Replay(s.ctx, fresh, events.ReplayOptions{})
+	s.ErrorIs(err, handlerErr)
+	s.Equal([]int{1, 2}, values)
+}
+
+func (s *EventJournalTestSuite) TestOnRecordAndReset() {
+	var recorded []events.JournalEntry
+	journal := events.NewEventJournal(events.JournalConfig{
+		OnRecord: func(entry events.JournalEntry) { recorded = append(recorded, entry) },
+	})
+
+	s.Require().NoError(NotificationTopic.On(journal).Publish(s.ctx, TestNotificationEvent{Value: 1}))
+	s.Require().Len(recorded, 1)
+	s.Equal(journal.Entries(), recorded)
+
+	journal.Reset()
+	s.Empty(journal.Entries())
+
+	s.Require().NoError(NotificationTopic.On(journal).Publish(s.ctx, TestNotificationEvent{Value: 2}))
+	entries := journal.Entries()
+	s.Require().Len(entries, 1)
+	s.Equal(uint64(2), entries[0].Sequence, "sequence keeps counting after reset")
+}
+
+func (s *EventJournalTestSuite) TestWrapsAnotherBus() {
+	async := events.NewAsyncEventBus(events.AsyncBusConfig{})
+	journal := events.NewEventJournal(events.JournalConfig{Bus: async})
+
+	delivered := make(chan TestNotificationEvent, 1)
+	_, err := NotificationTopic.On(journal).Subscribe(s.ctx, func(_ context.Context, e TestNotificationEvent) error {
+		delivered <- e
+		return nil
+	})
+	s.Require().NoError(err)
+
+	s.Require().NoError(NotificationTopic.On(journal).Publish(s.ctx, TestNotificationEvent{ID: testHero}))
+	s.Require().NoError(async.Close(s.ctx))
+
+	s.Equal(testHero, (<-delivered).ID)
+	s.Len(journal.Entries(), 1)
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package events
+
+import (
+	"context"
+	"time"
+)
+
+// Metrics receives event bus instrumentation, for export to a metrics system
+// such as Prometheus: counters of published and handled events per topic, a
+// histogram of handler latency, and a gauge of AsyncEventBus queue depth.
+//
+// Implementations must be safe for concurrent use. Embed NopMetrics to
+// implement only the methods you need.
+type Metrics interface {
+	// EventPublished is called once per published event, before its
+	// handlers run. On an AsyncEventBus that is when a worker takes the
+	// event off its queue.
+	EventPublished(topic Topic)
+
+	// EventHandled is called after each handler returns, with how long it
+	// ran and the error it returned. Chained topic handlers are included, so
+	// slow modifiers on a damage chain show up here. Subscription IDs are
+	// unique per subscription; label by topic alone to keep cardinality low.
+	EventHandled(topic Topic, subscriptionID string, duration time.Duration, err error)
+
+	// QueueDepth reports how many events wait in an AsyncEventBus worker's
+	// queue, after an event is queued or taken off it
+	QueueDepth(worker int, depth int)
+
+	// EventRejected is called when an AsyncEventBus turns an event away
+	// because its worker's queue is full (ErrQueueFull), or because ctx ended
+	// while waiting for room
+	EventRejected(topic Topic, err error)
+}
+
+// NopMetrics ignores every measurement. Embed it in a Metrics implementation
+// that only records some of them.
+type NopMetrics struct{}
+
+// EventPublished does nothing
+func (NopMetrics) EventPublished(Topic) {}
+
+// EventHandled does nothing
+func (NopMetrics) EventHandled(Topic, string, time.Duration, error) {}
+
+// QueueDepth does nothing
+func (NopMetrics) QueueDepth(int, int) {}
+
+// EventRejected does nothing
+func (NopMetrics) EventRejected(Topic, error) {}
+
+// Instrument returns middleware that reports published events and handler
+// latency to metrics:
+//
+//	events.Use(bus, events.Instrument(promMetrics))
+//
+// Add it first so its timing includes the other middleware's work, such as
+// Retry's attempts. For queue depth, also set AsyncBusConfig.Metrics.
+func Instrument(metrics Metrics) Middleware {
+	return Middleware{
+		Publish: func(next DeliveryFunc) DeliveryFunc {
+			return func(ctx context.Context, delivery Delivery) error {
+				metrics.EventPublished(delivery.Topic)
+				return next(ctx, delivery)
+			}
+		},
+		Handler: func(next DeliveryFunc) DeliveryFunc {
+			return func(ctx context.Context, delivery Delivery) error {
+				start := time.Now()
+				err := next(ctx, delivery)
+				metrics.EventHandled(delivery.Topic, delivery.SubscriptionID, time.Since(start), err)
+				return err
+			}
+		},
+	}
+}
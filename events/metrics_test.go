@@ -0,0 +1,151 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package events_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+)
+
+// handledMetric is one EventHandled call
+type handledMetric struct {
+	topic          events.Topic
+	subscriptionID string
+	duration       time.Duration
+	err            error
+}
+
+// recordingMetrics records every measurement for assertions
+type recordingMetrics struct {
+	mu        sync.Mutex
+	published []events.Topic
+	handled   []handledMetric
+	depths    map[int][]int
+	rejected  []error
+}
+
+func newRecordingMetrics() *recordingMetrics {
+	return &recordingMetrics{depths: make(map[int][]int)}
+}
+
+func (m *recordingMetrics) EventPublished(topic events.Topic) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.published = append(m.published, topic)
+}
+
+func (m *recordingMetrics) EventHandled(topic events.Topic, subscriptionID string, duration time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handled = append(m.handled, handledMetric{topic, subscriptionID, duration, err})
+}
+
+func (m *recordingMetrics) QueueDepth(worker int, depth int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.depths[worker] = append(m.depths[worker], depth)
+}
+
+func (m *recordingMetrics) EventRejected(_ events.Topic, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rejected = append(m.rejected, err)
+}
+
+// MetricsTestSuite tests bus instrumentation
+type MetricsTestSuite struct {
+	suite.Suite
+	ctx     context.Context
+	metrics *recordingMetrics
+}
+
+func TestMetricsSuite(t *testing.T) {
+	suite.Run(t, new(MetricsTestSuite))
+}
+
+func (s *MetricsTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.metrics = newRecordingMetrics()
+}
+
+func (s *MetricsTestSuite) TestInstrumentCountsAndTimesHandlers() {
+	bus := events.NewEventBus()
+	s.Require().NoError(events.Use(bus, events.Instrument(s.metrics)))
+
+	handlerErr := errors.New("too slow")
+	slowID, err := ActionTopic.On(bus).Subscribe(s.ctx, func(_ context.Context, _ TestActionEvent) error {
+		time.Sleep(5 * time.Millisecond)
+		return handlerErr
+	})
+	s.Require().NoError(err)
+	_, err = TestAttackChain.On(bus).SubscribeWithChain(s.ctx,
+		func(_ context.Context, _ TestAttackEvent, c chain.Chain[TestAttackEvent]) (chain.Chain[TestAttackEvent], error) {
+			return c, nil
+		})
+	s.Require().NoError(err)
+
+	s.ErrorIs(ActionTopic.On(bus).Publish(s.ctx, TestActionEvent{ActorID: testHero}), handlerErr)
+	stages := []chain.Stage{TestStageBase, TestStageFinal}
+	_, err = TestAttackChain.On(bus).PublishWithChain(s.ctx, TestAttackEvent{}, events.NewStagedChain[TestAttackEvent](stages))
+	s.Require().NoError(err)
+
+	s.Equal([]events.Topic{TopicAction, TopicTestAttack}, s.metrics.published)
+	s.Require().Len(s.metrics.handled, 2)
+	slow := s.metrics.handled[0]
+	s.Equal(TopicAction, slow.topic)
+	s.Equal(slowID, slow.subscriptionID)
+	s.GreaterOrEqual(slow.duration, 5*time.Millisecond)
+	s.ErrorIs(slow.err, handlerErr)
+	s.Equal(TopicTestAttack, s.metrics.handled[1].topic, "chained handlers are timed")
+	s.NoError(s.metrics.handled[1].err)
+}
+
+func (s *MetricsTestSuite) TestAsyncBusReportsQueueDepth() {
+	bus := events.NewAsyncEventBus(events.AsyncBusConfig{Workers: 1, QueueSize: 2, Metrics: s.metrics})
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	_, err := NotificationTopic.On(bus).Subscribe(s.ctx, func(_ context.Context, e TestNotificationEvent) error {
+		if e.Value == 1 {
+			started <- struct{}{}
+			<-release
+		}
+		return nil
+	})
+	s.Require().NoError(err)
+
+	notifications := NotificationTopic.On(bus)
+	s.Require().NoError(notifications.Publish(s.ctx, TestNotificationEvent{Value: 1}))
+	<-started // worker holds the first event, queue is empty again
+	s.Require().NoError(notifications.Publish(s.ctx, TestNotificationEvent{Value: 2}))
+	s.Require().NoError(notifications.Publish(s.ctx, TestNotificationEvent{Value: 3}))
+	s.Equal([]int{2}, bus.QueueDepths())
+	s.ErrorIs(notifications.Publish(s.ctx, TestNotificationEvent{Value: 4}), events.ErrQueueFull)
+
+	close(release)
+	s.Require().NoError(bus.Close(s.ctx))
+	s.Equal([]int{0}, bus.QueueDepths())
+
+	s.metrics.mu.Lock()
+	defer s.metrics.mu.Unlock()
+	depths := s.metrics.depths[0]
+	s.Contains(depths, 2, "depth reported as events are queued")
+	s.Equal(0, depths[len(depths)-1], "and as the worker drains them")
+	s.Equal([]error{events.ErrQueueFull}, s.metrics.rejected)
+}
+
+func (s *MetricsTestSuite) TestNopMetrics() {
+	bus := events.NewEventBus()
+	s.Require().NoError(events.Use(bus, events.Instrument(events.NopMetrics{})))
+	_, err := ActionTopic.On(bus).Subscribe(s.ctx, func(_ context.Context, _ TestActionEvent) error { return nil })
+	s.Require().NoError(err)
+	s.NoError(ActionTopic.On(bus).Publish(s.ctx, TestActionEvent{}))
+}
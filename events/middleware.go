@@ -0,0 +1,118 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrMiddlewareUnsupported is returned by Use for a bus that doesn't
+	// implement MiddlewareUser
+	ErrMiddlewareUnsupported = errors.New("event bus does not support middleware")
+
+	// ErrHandlerPanic is returned in place of a handler panic caught by Recover
+	ErrHandlerPanic = errors.New("event handler panicked")
+)
+
+// Delivery describes one step of event delivery seen by middleware: a whole
+// Publish, or one subscriber's handler.
+type Delivery struct {
+	// Topic is the topic the event was published on
+	Topic Topic
+
+	// SubscriptionID identifies the subscriber being invoked. Empty when
+	// wrapping Publish.
+	SubscriptionID string
+
+	// Event is the published event. Chained events arrive as their event
+	// value, without the chain.
+	Event any
+
+	// Chained is true for ChainedTopic events, whose handlers add modifiers
+	// to a chain rather than act on the event
+	Chained bool
+
+	// Meta identifies the event and the event that caused it
+	Meta EventMeta
+}
+
+// DeliveryFunc performs a delivery step. Middleware calls next to continue
+// delivery, or returns without calling it to stop the step.
+type DeliveryFunc func(ctx context.Context, delivery Delivery) error
+
+// Middleware wraps event delivery for cross-cutting concerns such as
+// tracing, panic recovery, rate limiting and audit logging. Either hook may
+// be nil.
+//
+// Middleware observes and guards delivery; it can't replace the event. The
+// context it passes to next reaches the handlers.
+type Middleware struct {
+	// Publish wraps each Publish call, around every handler of the event
+	Publish func(next DeliveryFunc) DeliveryFunc
+
+	// Handler wraps each subscriber's handler, pattern handlers included
+	Handler func(next DeliveryFunc) DeliveryFunc
+}
+
+// MiddlewareUser is implemented by buses that accept middleware.
+type MiddlewareUser interface {
+	Use(middleware ...Middleware)
+}
+
+// Use adds middleware to the bus, so every publish and handler invocation
+// runs through it without wrapping handlers one by one:
+//
+//	events.Use(bus, events.Recover(), tracing, audit)
+//
+// Middleware added first is outermost. Returns ErrMiddlewareUnsupported if
+// the bus doesn't implement MiddlewareUser.
+func Use(bus EventBus, middleware ...Middleware) error {
+	user, ok := bus.(MiddlewareUser)
+	if !ok {
+		return ErrMiddlewareUnsupported
+	}
+	user.Use(middleware...)
+	return nil
+}
+
+// Recover returns middleware that turns a handler panic into an error
+// wrapping ErrHandlerPanic, so one faulty feature can't crash the game loop.
+func Recover() Middleware {
+	return Middleware{
+		Handler: func(next DeliveryFunc) DeliveryFunc {
+			return func(ctx context.Context, delivery Delivery) (err error) {
+				defer func() {
+					if r := recover(); r != nil {
+						err = fmt.Errorf("%w: %s handler %s: %v", ErrHandlerPanic, delivery.Topic, delivery.SubscriptionID, r)
+					}
+				}()
+				return next(ctx, delivery)
+			}
+		},
+	}
+}
+
+// wrapDelivery applies the hooks selected by hook around base, the first
+// middleware outermost
+func wrapDelivery(
+	middleware []Middleware,
+	hook func(Middleware) func(DeliveryFunc) DeliveryFunc,
+	base DeliveryFunc,
+) DeliveryFunc {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		if wrap := hook(middleware[i]); wrap != nil {
+			base = wrap(base)
+		}
+	}
+	return base
+}
+
+// publishHook selects a middleware's Publish hook
+func publishHook(m Middleware) func(DeliveryFunc) DeliveryFunc { return m.Publish }
+
+// handlerHook selects a middleware's Handler hook
+func handlerHook(m Middleware) func(DeliveryFunc) DeliveryFunc { return m.Handler }
@@ -0,0 +1,254 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package events_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+)
+
+// traceKey carries a trace ID added by middleware
+type traceKey struct{}
+
+// MiddlewareTestSuite tests bus middleware
+type MiddlewareTestSuite struct {
+	suite.Suite
+	ctx context.Context
+	bus events.EventBus
+	log []string
+}
+
+func TestMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(MiddlewareTestSuite))
+}
+
+func (s *MiddlewareTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.log = nil
+}
+
+// logging returns middleware that logs around both hooks under name
+func (s *MiddlewareTestSuite) logging(name string) events.Middleware {
+	wrap := func(kind string) func(events.DeliveryFunc) events.DeliveryFunc {
+		return func(next events.DeliveryFunc) events.DeliveryFunc {
+			return func(ctx context.Context, delivery events.Delivery) error {
+				s.log = append(s.log, fmt.Sprintf("%s:%s:%s", name, kind, delivery.Topic))
+				return next(ctx, delivery)
+			}
+		}
+	}
+	return events.Middleware{Publish: wrap("publish"), Handler: wrap("handler")}
+}
+
+// subscribeAction subscribes a handler that logs under name
+func (s *MiddlewareTestSuite) subscribeAction(name string) string {
+	id, err := ActionTopic.On(s.bus).Subscribe(s.ctx, func(_ context.Context, _ TestActionEvent) error {
+		s.log = append(s.log, name)
+		return nil
+	})
+	s.Require().NoError(err)
+	return id
+}
+
+func (s *MiddlewareTestSuite) TestWrapsPublishAndEachHandler() {
+	s.subscribeAction("first")
+	s.subscribeAction("second")
+	s.Require().NoError(events.Use(s.bus, s.logging("outer"), s.logging("inner")))
+
+	s.Require().NoError(ActionTopic.On(s.bus).Publish(s.ctx, TestActionEvent{ActorID: testHero}))
+
+	s.Equal([]string{
+		"outer:publish:test.action",
+		"inner:publish:test.action",
+		"outer:handler:test.action",
+		"inner:handler:test.action",
+		"first",
+		"outer:handler:test.action",
+		"inner:handler:test.action",
+		"second",
+	}, s.log)
+}
+
+func (s *MiddlewareTestSuite) TestDeliveryDescribesStep() {
+	id := s.subscribeAction("handler")
+	var deliveries []events.Delivery
+	record := func(next events.DeliveryFunc) events.DeliveryFunc {
+		return func(ctx context.Context, delivery events.Delivery) error {
+			deliveries = append(deliveries, delivery)
+			return next(ctx, delivery)
+		}
+	}
+	s.Require().NoError(events.Use(s.bus, events.Middleware{Publish: record, Handler: record}))
+
+	event := TestActionEvent{ActorID: testHero, Action: "dash"}
+	s.Require().NoError(ActionTopic.On(s.bus).Publish(s.ctx, event))
+
+	s.Require().Len(deliveries, 2)
+	meta := deliveries[0].Meta
+	s.NotEmpty(meta.ID)
+	s.Equal(TopicAction, meta.Topic)
+	s.Equal([]events.Delivery{
+		{Topic: TopicAction, Event: event, Meta: meta},
+		{Topic: TopicAction, SubscriptionID: id, Event: event, Meta: meta},
+	}, deliveries)
+}
+
+func (s *MiddlewareTestSuite) TestContextReachesHandlers() {
+	var typedTrace, chainedTrace any
+	_, err := ActionTopic.On(s.bus).Subscribe(s.ctx, func(ctx context.Context, _ TestActionEvent) error {
+		typedTrace = ctx.Value(traceKey{})
+		return nil
+	})
+	s.Require().NoError(err)
+	_, err = TestAttackChain.On(s.bus).SubscribeWithChain(s.ctx,
+		func(ctx context.Context, _ TestAttackEvent, c chain.Chain[TestAttackEvent]) (chain.Chain[TestAttackEvent], error) {
+			chainedTrace = ctx.Value(traceKey{})
+			return c, nil
+		})
+	s.Require().NoError(err)
+
+	s.Require().NoError(events.Use(s.bus, events.Middleware{
+		Publish: func(next events.DeliveryFunc) events.DeliveryFunc {
+			return func(ctx context.Context, delivery events.Delivery) error {
+				return next(context.WithValue(ctx, traceKey{}, "trace-1"), delivery)
+			}
+		},
+	}))
+
+	s.Require().NoError(ActionTopic.On(s.bus).Publish(s.ctx, TestActionEvent{}))
+	stages := []chain.Stage{TestStageBase, TestStageFinal}
+	_, err = TestAttackChain.On(s.bus).PublishWithChain(s.ctx, TestAttackEvent{}, events.NewStagedChain[TestAttackEvent](stages))
+	s.Require().NoError(err)
+
+	s.Equal("trace-1", typedTrace)
+	s.Equal("trace-1", chainedTrace)
+}
+
+func (s *MiddlewareTestSuite) TestChainedEventsStillCollectModifiers() {
+	_, err := TestAttackChain.On(s.bus).SubscribeWithChain(s.ctx,
+		func(_ context.Context, _ TestAttackEvent, c chain.Chain[TestAttackEvent]) (chain.Chain[TestAttackEvent], error) {
+			return c, c.Add(TestStageConditions, "rage", func(_ context.Context, e TestAttackEvent) (TestAttackEvent, error) {
+				e.Damage += 2
+				return e, nil
+			})
+		})
+	s.Require().NoError(err)
+
+	var observed []any
+	s.Require().NoError(events.Use(s.bus, events.Middleware{
+		Handler: func(next events.DeliveryFunc) events.DeliveryFunc {
+			return func(ctx context.Context, delivery events.Delivery) error {
+				observed = append(observed, delivery.Event)
+				return next(ctx, delivery)
+			}
+		},
+	}))
+
+	attack := TestAttackEvent{AttackerID: testBarbarian, Damage: 10}
+	stages := []chain.Stage{TestStageBase, TestStageConditions, TestStageFinal}
+	modified, err := TestAttackChain.On(s.bus).PublishWithChain(s.ctx, attack, events.NewStagedChain[TestAttackEvent](stages))
+	s.Require().NoError(err)
+	result, err := modified.Execute(s.ctx, attack)
+	s.Require().NoError(err)
+
+	s.Equal(12, result.Damage)
+	s.Equal([]any{attack}, observed)
+}
+
+func (s *MiddlewareTestSuite) TestStoppingDelivery() {
+	s.subscribeAction("handler")
+	s.Require().NoError(events.Use(s.bus, events.Middleware{
+		Publish: func(_ events.DeliveryFunc) events.DeliveryFunc {
+			return func(context.Context, events.Delivery) error {
+				return nil // drop the event, as a rate limiter would
+			}
+		},
+	}))
+
+	s.Require().NoError(ActionTopic.On(s.bus).Publish(s.ctx, TestActionEvent{}))
+	s.Empty(s.log)
+}
+
+func (s *MiddlewareTestSuite) TestPatternHandlersWrapped() {
+	s.Require().NoError(events.Use(s.bus, s.logging("mw")))
+	_, err := events.SubscribePattern(s.ctx, s.bus, "test.#", func(_ context.Context, _ events.Topic, _ any) error {
+		s.log = append(s.log, "pattern")
+		return nil
+	})
+	s.Require().NoError(err)
+
+	s.Require().NoError(ActionTopic.On(s.bus).Publish(s.ctx, TestActionEvent{}))
+	s.Equal([]string{"mw:publish:test.action", "mw:handler:test.action", "pattern"}, s.log)
+}
+
+func (s *MiddlewareTestSuite) TestRecover() {
+	_, err := ActionTopic.On(s.bus).Subscribe(s.ctx, func(context.Context, TestActionEvent) error {
+		panic("bad feature")
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(events.Use(s.bus, events.Recover()))
+
+	err = ActionTopic.On(s.bus).Publish(s.ctx, TestActionEvent{})
+	s.ErrorIs(err, events.ErrHandlerPanic)
+	s.Contains(err.Error(), "bad feature")
+}
+
+func (s *MiddlewareTestSuite) TestHandlerErrorPassesThrough() {
+	handlerErr := errors.New("handler failed")
+	_, err := ActionTopic.On(s.bus).Subscribe(s.ctx, func(context.Context, TestActionEvent) error {
+		return handlerErr
+	})
+	s.Require().NoError(err)
+
+	var seen error
+	s.Require().NoError(events.Use(s.bus, events.Middleware{
+		Handler: func(next events.DeliveryFunc) events.DeliveryFunc {
+			return func(ctx context.Context, delivery events.Delivery) error {
+				seen = next(ctx, delivery)
+				return seen
+			}
+		},
+	}))
+
+	s.ErrorIs(ActionTopic.On(s.bus).Publish(s.ctx, TestActionEvent{}), handlerErr)
+	s.ErrorIs(seen, handlerErr)
+}
+
+func (s *MiddlewareTestSuite) TestOtherBuses() {
+	s.Run("async bus", func() {
+		s.SetupTest()
+		bus := events.NewAsyncEventBus(events.AsyncBusConfig{})
+		s.bus = bus
+		s.subscribeAction("handler")
+		s.Require().NoError(events.Use(bus, s.logging("mw")))
+
+		s.Require().NoError(ActionTopic.On(bus).Publish(s.ctx, TestActionEvent{}))
+		s.Require().NoError(bus.Close(s.ctx))
+		s.Equal([]string{"mw:publish:test.action", "mw:handler:test.action", "handler"}, s.log)
+	})
+
+	s.Run("journal", func() {
+		s.SetupTest()
+		journal := events.NewEventJournal(events.JournalConfig{})
+		s.bus = journal
+		s.subscribeAction("handler")
+		s.Require().NoError(events.Use(journal, s.logging("mw")))
+
+		s.Require().NoError(ActionTopic.On(journal).Publish(s.ctx, TestActionEvent{}))
+		s.Equal([]string{"mw:publish:test.action", "mw:handler:test.action", "handler"}, s.log)
+		s.Len(journal.Entries(), 1)
+	})
+
+	s.Run("unsupported bus", func() {
+		s.ErrorIs(events.Use(exactOnlyBus{events.NewEventBus()}, events.Recover()), events.ErrMiddlewareUnsupported)
+	})
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Pattern wildcards. Topics are dot-separated segments ("dnd5e.combat.attack");
+// a wildcard must be a whole segment.
+const (
+	// WildcardSegment matches exactly one segment: "dnd5e.combat.*"
+	WildcardSegment = "*"
+	// WildcardTail matches zero or more segments: "dnd5e.condition.#"
+	WildcardTail = "#"
+)
+
+var (
+	// ErrInvalidPattern is returned for a pattern with an empty segment or a
+	// wildcard mixed into a segment ("attack*")
+	ErrInvalidPattern = errors.New("invalid topic pattern")
+
+	// ErrPatternsUnsupported is returned by SubscribePattern for a bus that
+	// doesn't implement PatternSubscriber
+	ErrPatternsUnsupported = errors.New("event bus does not support pattern subscriptions")
+)
+
+// PatternHandler receives every event whose topic matches a pattern. Chained
+// events arrive as the event value, without their chain: pattern handlers
+// observe, they don't add modifiers.
+type PatternHandler func(ctx context.Context, topic Topic, event any) error
+
+// PatternSubscriber is implemented by buses that accept pattern subscriptions.
+// The subscription ID is removed with the bus's Unsubscribe.
+type PatternSubscriber interface {
+	SubscribePattern(ctx context.Context, pattern string, handler PatternHandler) (string, error)
+}
+
+// SubscribePattern subscribes a handler to every topic matching pattern, for
+// observers such as logging and metrics that watch families of events:
+//
+//	events.SubscribePattern(ctx, bus, "dnd5e.combat.*", logEvent)     // one level
+//	events.SubscribePattern(ctx, bus, "dnd5e.condition.#", logEvent)  // any depth
+//	events.SubscribePattern(ctx, bus, "#", logEvent)                  // everything
+//
+// Pattern handlers run after the topic's own subscribers. Returns
+// ErrPatternsUnsupported if the bus doesn't implement PatternSubscriber, and
+// ErrInvalidPattern for a malformed pattern.
+func SubscribePattern(ctx context.Context, bus EventBus, pattern string, handler PatternHandler) (string, error) {
+	subscriber, ok := bus.(PatternSubscriber)
+	if !ok {
+		return "", ErrPatternsUnsupported
+	}
+	return subscriber.SubscribePattern(ctx, pattern, handler)
+}
+
+// topicPattern is a parsed subscription pattern
+type topicPattern []string
+
+// parseTopicPattern splits and validates a pattern
+func parseTopicPattern(pattern string) (topicPattern, error) {
+	segments := strings.Split(pattern, ".")
+	for _, segment := range segments {
+		if segment == "" {
+			return nil, fmt.Errorf("%w %q: empty segment", ErrInvalidPattern, pattern)
+		}
+		if segment != WildcardSegment && segment != WildcardTail && strings.ContainsAny(segment, "*#") {
+			return nil, fmt.Errorf("%w %q: wildcards must be whole segments", ErrInvalidPattern, pattern)
+		}
+	}
+	return segments, nil
+}
+
+// matches reports whether topic matches the pattern
+func (p topicPattern) matches(topic Topic) bool {
+	return matchSegments(p, strings.Split(string(topic), "."))
+}
+
+// matchSegments matches pattern segments against topic segments, letting "#"
+// absorb any number of topic segments
+func matchSegments(pattern, topic []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == WildcardTail {
+			for skip := 0; skip <= len(topic); skip++ {
+				if matchSegments(pattern[1:], topic[skip:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(topic) == 0 || (pattern[0] != WildcardSegment && pattern[0] != topic[0]) {
+			return false
+		}
+		pattern, topic = pattern[1:], topic[1:]
+	}
+	return len(topic) == 0
+}
+
+// observedEvent is implemented by bus payloads that wrap the event pattern
+// handlers should see (chained events wrap theirs with the chain)
+type observedEvent interface {
+	observedValue() any
+}
+
+// observedValue returns the event value without its chain for pattern handlers
+func (ce *chainedEvent[T]) observedValue() any {
+	return ce.event
+}
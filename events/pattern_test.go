@@ -0,0 +1,207 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package events_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+)
+
+// Topics shaped like a rulebook's, for pattern matching
+var (
+	patternAttackTopic  = events.DefineTypedTopic[TestActionEvent]("dnd5e.combat.attack")
+	patternDamageTopic  = events.DefineTypedTopic[TestActionEvent]("dnd5e.combat.damage.received")
+	patternAppliedTopic = events.DefineTypedTopic[TestNotificationEvent]("dnd5e.condition.applied")
+	patternExhaustTopic = events.DefineTypedTopic[TestNotificationEvent]("dnd5e.condition.exhaustion.changed")
+	patternSpellTopic   = events.DefineTypedTopic[TestNotificationEvent]("dnd5e.spell.cast")
+)
+
+// PatternSubscriptionTestSuite tests wildcard subscriptions
+type PatternSubscriptionTestSuite struct {
+	suite.Suite
+	ctx context.Context
+	bus events.EventBus
+}
+
+func TestPatternSubscriptionSuite(t *testing.T) {
+	suite.Run(t, new(PatternSubscriptionTestSuite))
+}
+
+func (s *PatternSubscriptionTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+}
+
+// publishAll publishes one event on each test topic
+func (s *PatternSubscriptionTestSuite) publishAll() {
+	s.Require().NoError(patternAttackTopic.On(s.bus).Publish(s.ctx, TestActionEvent{ActorID: testHero}))
+	s.Require().NoError(patternDamageTopic.On(s.bus).Publish(s.ctx, TestActionEvent{ActorID: testGoblin}))
+	s.Require().NoError(patternAppliedTopic.On(s.bus).Publish(s.ctx, TestNotificationEvent{ID: testHero}))
+	s.Require().NoError(patternExhaustTopic.On(s.bus).Publish(s.ctx, TestNotificationEvent{ID: testHero}))
+	s.Require().NoError(patternSpellTopic.On(s.bus).Publish(s.ctx, TestNotificationEvent{ID: testDragon}))
+}
+
+// record subscribes to pattern and returns the topics it sees
+func (s *PatternSubscriptionTestSuite) record(pattern string) *[]events.Topic {
+	var topics []events.Topic
+	_, err := events.SubscribePattern(s.ctx, s.bus, pattern, func(_ context.Context, topic events.Topic, _ any) error {
+		topics = append(topics, topic)
+		return nil
+	})
+	s.Require().NoError(err)
+	return &topics
+}
+
+func (s *PatternSubscriptionTestSuite) TestMatching() {
+	testCases := []struct {
+		pattern  string
+		expected []events.Topic
+	}{
+		{"dnd5e.combat.*", []events.Topic{"dnd5e.combat.attack"}},
+		{"dnd5e.combat.#", []events.Topic{"dnd5e.combat.attack", "dnd5e.combat.damage.received"}},
+		{"dnd5e.condition.#", []events.Topic{"dnd5e.condition.applied", "dnd5e.condition.exhaustion.changed"}},
+		{"dnd5e.*.cast", []events.Topic{"dnd5e.spell.cast"}},
+		{"#.changed", []events.Topic{"dnd5e.condition.exhaustion.changed"}},
+		{"dnd5e.spell.cast", []events.Topic{"dnd5e.spell.cast"}},
+		{"dnd5e.spell.cast.#", []events.Topic{"dnd5e.spell.cast"}},
+		{"dnd5e.*", nil},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.pattern, func() {
+			s.SetupTest()
+			topics := s.record(tc.pattern)
+			s.publishAll()
+			s.Equal(tc.expected, *topics)
+		})
+	}
+}
+
+func (s *PatternSubscriptionTestSuite) TestCatchAllReceivesEvents() {
+	var received []any
+	_, err := events.SubscribePattern(s.ctx, s.bus, "#", func(_ context.Context, _ events.Topic, event any) error {
+		received = append(received, event)
+		return nil
+	})
+	s.Require().NoError(err)
+
+	s.publishAll()
+	s.Require().Len(received, 5)
+	s.Equal(TestActionEvent{ActorID: testHero}, received[0])
+}
+
+func (s *PatternSubscriptionTestSuite) TestTopicSubscribersStillCalled() {
+	var order []string
+	_, err := patternAttackTopic.On(s.bus).Subscribe(s.ctx, func(_ context.Context, _ TestActionEvent) error {
+		order = append(order, "topic")
+		return nil
+	})
+	s.Require().NoError(err)
+	_, err = events.SubscribePattern(s.ctx, s.bus, "dnd5e.#", func(_ context.Context, _ events.Topic, _ any) error {
+		order = append(order, "pattern")
+		return nil
+	})
+	s.Require().NoError(err)
+
+	s.Require().NoError(patternAttackTopic.On(s.bus).Publish(s.ctx, TestActionEvent{}))
+	s.Equal([]string{"topic", "pattern"}, order)
+}
+
+func (s *PatternSubscriptionTestSuite) TestChainedEventsObservedWithoutChain() {
+	var observed []any
+	_, err := events.SubscribePattern(s.ctx, s.bus, "test.*", func(_ context.Context, _ events.Topic, event any) error {
+		observed = append(observed, event)
+		return nil
+	})
+	s.Require().NoError(err)
+
+	attack := TestAttackEvent{AttackerID: testBarbarian, Damage: 10}
+	stages := []chain.Stage{TestStageBase, TestStageConditions, TestStageFinal}
+	_, err = TestAttackChain.On(s.bus).PublishWithChain(s.ctx, attack, events.NewStagedChain[TestAttackEvent](stages))
+	s.Require().NoError(err)
+
+	s.Equal([]any{attack}, observed)
+}
+
+func (s *PatternSubscriptionTestSuite) TestUnsubscribe() {
+	var count int
+	id, err := events.SubscribePattern(s.ctx, s.bus, "#", func(_ context.Context, _ events.Topic, _ any) error {
+		count++
+		return nil
+	})
+	s.Require().NoError(err)
+
+	s.Require().NoError(patternAttackTopic.On(s.bus).Publish(s.ctx, TestActionEvent{}))
+	s.Require().NoError(s.bus.Unsubscribe(s.ctx, id))
+	s.Require().NoError(patternAttackTopic.On(s.bus).Publish(s.ctx, TestActionEvent{}))
+
+	s.Equal(1, count)
+}
+
+func (s *PatternSubscriptionTestSuite) TestHandlerErrorReturned() {
+	handlerErr := errors.New("metrics down")
+	_, err := events.SubscribePattern(s.ctx, s.bus, "#", func(_ context.Context, _ events.Topic, _ any) error {
+		return handlerErr
+	})
+	s.Require().NoError(err)
+
+	err = patternAttackTopic.On(s.bus).Publish(s.ctx, TestActionEvent{})
+	s.ErrorIs(err, handlerErr)
+}
+
+func (s *PatternSubscriptionTestSuite) TestInvalidPatterns() {
+	for _, pattern := range []string{"", "dnd5e..attack", "attack*", "dnd5e.#x"} {
+		_, err := events.SubscribePattern(s.ctx, s.bus, pattern, func(context.Context, events.Topic, any) error {
+			return nil
+		})
+		s.ErrorIs(err, events.ErrInvalidPattern, pattern)
+	}
+}
+
+func (s *PatternSubscriptionTestSuite) TestOtherBuses() {
+	s.Run("async bus", func() {
+		bus := events.NewAsyncEventBus(events.AsyncBusConfig{})
+		var topics []events.Topic
+		_, err := events.SubscribePattern(s.ctx, bus, "dnd5e.combat.*", func(_ context.Context, topic events.Topic, _ any) error {
+			topics = append(topics, topic)
+			return nil
+		})
+		s.Require().NoError(err)
+
+		s.Require().NoError(patternAttackTopic.On(bus).Publish(s.ctx, TestActionEvent{}))
+		s.Require().NoError(bus.Close(s.ctx))
+		s.Equal([]events.Topic{"dnd5e.combat.attack"}, topics)
+	})
+
+	s.Run("journal", func() {
+		journal := events.NewEventJournal(events.JournalConfig{})
+		var count int
+		_, err := events.SubscribePattern(s.ctx, journal, "#", func(_ context.Context, _ events.Topic, _ any) error {
+			count++
+			return nil
+		})
+		s.Require().NoError(err)
+
+		s.Require().NoError(patternAttackTopic.On(journal).Publish(s.ctx, TestActionEvent{}))
+		s.Equal(1, count)
+	})
+
+	s.Run("unsupported bus", func() {
+		_, err := events.SubscribePattern(s.ctx, exactOnlyBus{s.bus}, "#", func(context.Context, events.Topic, any) error {
+			return nil
+		})
+		s.ErrorIs(err, events.ErrPatternsUnsupported)
+	})
+}
+
+// exactOnlyBus hides the wrapped bus's pattern support
+type exactOnlyBus struct {
+	events.EventBus
+}
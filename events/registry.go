@@ -0,0 +1,160 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package events
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// TopicKind distinguishes notification topics from chained topics
+type TopicKind string
+
+const (
+	// TopicKindTyped is a TypedTopic: subscribers are notified of the event
+	TopicKindTyped TopicKind = "typed"
+	// TopicKindChained is a ChainedTopic: subscribers add modifiers to a chain
+	TopicKindChained TopicKind = "chained"
+)
+
+// TopicField describes one exported field of a topic's payload
+type TopicField struct {
+	// Name is the Go field name
+	Name string
+	// JSONName is the field's key when the payload is marshaled to JSON
+	JSONName string
+	// Type is the Go type of the field, e.g. "int" or "*core.Ref"
+	Type string
+}
+
+// TopicInfo describes a defined topic for discovery
+type TopicInfo struct {
+	// Topic is the routing key
+	Topic Topic
+	// Kind says whether the topic is typed or chained
+	Kind TopicKind
+	// PayloadType is the event type published on the topic
+	PayloadType reflect.Type
+	// Fields are the payload's exported fields, for struct payloads
+	Fields []TopicField
+}
+
+// NewPayload returns a pointer to a new zero payload, ready to unmarshal a
+// stored event into (e.g. journal entries loaded for ReplayEntries)
+func (i TopicInfo) NewPayload() any {
+	return reflect.New(i.PayloadType).Interface()
+}
+
+// topicRegistry records every topic defined with DefineTypedTopic or
+// DefineChainedTopic. Topics are package-level variables, so a module's
+// topics are registered as soon as it is imported.
+type topicRegistry struct {
+	mu     sync.RWMutex
+	topics []TopicInfo
+}
+
+// topics is the process-wide topic registry
+var topics = &topicRegistry{}
+
+// register records a topic definition. Defining the same topic, kind and
+// payload again is a no-op.
+func (r *topicRegistry) register(topic Topic, kind TopicKind, payload reflect.Type) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, info := range r.topics {
+		if info.Topic == topic && info.Kind == kind && info.PayloadType == payload {
+			return
+		}
+	}
+	r.topics = append(r.topics, TopicInfo{
+		Topic:       topic,
+		Kind:        kind,
+		PayloadType: payload,
+		Fields:      payloadFields(payload),
+	})
+}
+
+// list returns the registered topics accepted by keep, sorted by topic then kind
+func (r *topicRegistry) list(keep func(TopicInfo) bool) []TopicInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []TopicInfo
+	for _, info := range r.topics {
+		if keep(info) {
+			result = append(result, info)
+		}
+	}
+	slices.SortStableFunc(result, func(a, b TopicInfo) int {
+		if c := strings.Compare(string(a.Topic), string(b.Topic)); c != 0 {
+			return c
+		}
+		return strings.Compare(string(a.Kind), string(b.Kind))
+	})
+	return result
+}
+
+// ListTopics returns every topic defined in the process, sorted by topic.
+// Game servers use it to see which events the imported rulebooks publish and
+// wire handlers programmatically.
+//
+// A topic defined with two payload types (usually a mistake) is listed once
+// per type.
+func ListTopics() []TopicInfo {
+	return topics.list(func(TopicInfo) bool { return true })
+}
+
+// ListTopicsMatching returns the defined topics matching a subscription
+// pattern (see SubscribePattern), e.g. "dnd5e.combat.#". Returns
+// ErrInvalidPattern for a malformed pattern.
+func ListTopicsMatching(pattern string) ([]TopicInfo, error) {
+	parsed, err := parseTopicPattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return topics.list(func(info TopicInfo) bool { return parsed.matches(info.Topic) }), nil
+}
+
+// LookupTopic returns the definitions of a topic, or nil if it isn't defined
+func LookupTopic(topic Topic) []TopicInfo {
+	return topics.list(func(info TopicInfo) bool { return info.Topic == topic })
+}
+
+// payloadFields lists the exported fields of a struct payload (or pointer to one)
+func payloadFields(payload reflect.Type) []TopicField {
+	if payload.Kind() == reflect.Pointer {
+		payload = payload.Elem()
+	}
+	if payload.Kind() != reflect.Struct {
+		return nil
+	}
+
+	fields := make([]TopicField, 0, payload.NumField())
+	for i := range payload.NumField() {
+		field := payload.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		jsonName := field.Name
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			name, _, _ := strings.Cut(tag, ",")
+			if name == "-" {
+				continue
+			}
+			if name != "" {
+				jsonName = name
+			}
+		}
+		fields = append(fields, TopicField{Name: field.Name, JSONName: jsonName, Type: field.Type.String()})
+	}
+	return fields
+}
+
+// payloadType returns the reflect.Type of T, including interface types
+func payloadType[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package events_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/events"
+)
+
+// registryDamageEvent is a payload with JSON tags for field discovery
+type registryDamageEvent struct {
+	TargetID string `json:"target_id"`
+	Amount   int    `json:"amount,omitempty"`
+	Internal string `json:"-"`
+	Source   string
+	secret   string
+}
+
+var (
+	registryDamageTopic = events.DefineTypedTopic[registryDamageEvent]("registry.combat.damage")
+	registryDamageChain = events.DefineChainedTopic[*registryDamageEvent]("registry.combat.damage")
+	registryTurnTopic   = events.DefineTypedTopic[TestNotificationEvent]("registry.turn.start")
+)
+
+// TopicRegistryTestSuite tests topic discovery
+type TopicRegistryTestSuite struct {
+	suite.Suite
+}
+
+func TestTopicRegistrySuite(t *testing.T) {
+	suite.Run(t, new(TopicRegistryTestSuite))
+}
+
+func (s *TopicRegistryTestSuite) TestDefinedTopicsAreListed() {
+	s.Require().NotNil(registryDamageTopic)
+	s.Require().NotNil(registryDamageChain)
+	s.Require().NotNil(registryTurnTopic)
+
+	var listed []events.Topic
+	for _, info := range events.ListTopics() {
+		listed = append(listed, info.Topic)
+	}
+	s.Contains(listed, events.Topic("registry.combat.damage"))
+	s.Contains(listed, events.Topic("registry.turn.start"))
+	s.Contains(listed, events.Topic("events.dead_letter"))
+	s.True(slices.IsSorted(stringsOf(listed)), "topics are sorted")
+}
+
+func (s *TopicRegistryTestSuite) TestLookupTopic() {
+	infos := events.LookupTopic("registry.combat.damage")
+	s.Require().Len(infos, 2)
+
+	chained, typed := infos[0], infos[1]
+	s.Equal(events.TopicKindChained, chained.Kind)
+	s.Equal(reflect.TypeOf(&registryDamageEvent{}), chained.PayloadType)
+	s.Equal(events.TopicKindTyped, typed.Kind)
+	s.Equal(reflect.TypeOf(registryDamageEvent{}), typed.PayloadType)
+
+	expectedFields := []events.TopicField{
+		{Name: "TargetID", JSONName: "target_id", Type: "string"},
+		{Name: "Amount", JSONName: "amount", Type: "int"},
+		{Name: "Source", JSONName: "Source", Type: "string"},
+	}
+	s.Equal(expectedFields, typed.Fields)
+	s.Equal(expectedFields, chained.Fields, "pointer payloads describe the struct")
+
+	s.Nil(events.LookupTopic("registry.unknown"))
+}
+
+func (s *TopicRegistryTestSuite) TestListTopicsMatching() {
+	infos, err := events.ListTopicsMatching("registry.*.start")
+	s.Require().NoError(err)
+	s.Require().Len(infos, 1)
+	s.Equal(events.Topic("registry.turn.start"), infos[0].Topic)
+
+	infos, err = events.ListTopicsMatching("registry.#")
+	s.Require().NoError(err)
+	s.Len(infos, 3)
+
+	_, err = events.ListTopicsMatching("registry..start")
+	s.ErrorIs(err, events.ErrInvalidPattern)
+}
+
+func (s *TopicRegistryTestSuite) TestRedefiningIsNoOp() {
+	events.DefineTypedTopic[TestNotificationEvent]("registry.turn.start")
+	s.Len(events.LookupTopic("registry.turn.start"), 1)
+}
+
+func (s *TopicRegistryTestSuite) TestNewPayloadDecodesStoredEvents() {
+	infos := events.LookupTopic("registry.turn.start")
+	s.Require().Len(infos, 1)
+
+	payload := infos[0].NewPayload()
+	s.Require().NoError(json.Unmarshal([]byte(`{"ID":"hero","Value":3}`), payload))
+	s.Equal(&TestNotificationEvent{ID: "hero", Value: 3}, payload)
+}
+
+// stringsOf converts topics to strings for ordering assertions
+func stringsOf(topics []events.Topic) []string {
+	result := make([]string, len(topics))
+	for i, topic := range topics {
+		result[i] = string(topic)
+	}
+	return result
+}
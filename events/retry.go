@@ -0,0 +1,153 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DeadLetterTopic receives handler deliveries that still failed after their
+// retries (see Retry). Subscribe to it to log, inspect or reprocess failures.
+var DeadLetterTopic = DefineTypedTopic[DeadLetterEvent]("events.dead_letter")
+
+// DeadLetterEvent is a handler delivery that failed every attempt.
+type DeadLetterEvent struct {
+	// Topic is the topic the event was published on
+	Topic Topic
+
+	// SubscriptionID identifies the subscriber whose handler failed
+	SubscriptionID string
+
+	// Event is the event the handler failed on
+	Event any
+
+	// Err is the error from the last attempt
+	Err error
+
+	// Attempts is how many times the handler was called
+	Attempts int
+
+	// FailedAt is when the last attempt failed
+	FailedAt time.Time
+
+	// retry calls the failed subscriber's handler again
+	retry DeliveryFunc
+}
+
+// Reprocess delivers the event to the failed subscriber's handler once more,
+// e.g. after fixing whatever made it fail. Other subscribers aren't called.
+func (e DeadLetterEvent) Reprocess(ctx context.Context) error {
+	if e.retry == nil {
+		return fmt.Errorf("dead letter for %s handler %s can't be reprocessed", e.Topic, e.SubscriptionID)
+	}
+	return e.retry(ctx, Delivery{Topic: e.Topic, SubscriptionID: e.SubscriptionID, Event: e.Event})
+}
+
+// RetryConfig configures the Retry middleware.
+type RetryConfig struct {
+	// MaxAttempts is how many times a handler is called before giving up,
+	// including the first call. Values below 1 mean a single call.
+	MaxAttempts int
+
+	// Backoff returns how long to wait before the given retry (1 for the
+	// first retry). Retries are immediate if nil. Waiting stops early if the
+	// context ends.
+	Backoff func(retry int) time.Duration
+
+	// Retryable reports whether an error is worth retrying. Every error is
+	// retried if nil.
+	Retryable func(err error) bool
+
+	// DeadLetters is the bus that receives DeadLetterTopic events for
+	// deliveries that still fail. Without it the last error is returned to
+	// the publisher as before.
+	DeadLetters EventBus
+
+	// Now returns the current time for FailedAt. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// Retry returns middleware that calls a failing handler again under the
+// config's policy, then hands the failure to the dead-letter topic.
+//
+// A dead-lettered failure doesn't fail the publish: the remaining subscribers
+// still run and Publish returns nil, since the dead-letter subscribers now own
+// the failure. Failures on DeadLetterTopic itself are returned, not
+// dead-lettered again.
+//
+// Chained events are never retried. Their handlers add modifiers as they go,
+// so calling one again could add its modifiers twice.
+func Retry(config RetryConfig) Middleware {
+	attempts := max(config.MaxAttempts, 1)
+	now := config.Now
+	if now == nil {
+		now = time.Now
+	}
+
+	return Middleware{
+		Handler: func(next DeliveryFunc) DeliveryFunc {
+			return func(ctx context.Context, delivery Delivery) error {
+				if delivery.Chained {
+					return next(ctx, delivery)
+				}
+
+				err := next(ctx, delivery)
+				attempt := 1
+				for err != nil && attempt < attempts && retryable(config.Retryable, err) {
+					if waitErr := waitForRetry(ctx, config.Backoff, attempt); waitErr != nil {
+						return errors.Join(err, waitErr)
+					}
+					attempt++
+					err = next(ctx, delivery)
+				}
+
+				if err == nil || config.DeadLetters == nil || delivery.Topic == DeadLetterTopic.topic {
+					return err
+				}
+
+				deadLetter := DeadLetterEvent{
+					Topic:          delivery.Topic,
+					SubscriptionID: delivery.SubscriptionID,
+					Event:          delivery.Event,
+					Err:            err,
+					Attempts:       attempt,
+					FailedAt:       now(),
+					retry:          next,
+				}
+				if publishErr := DeadLetterTopic.On(config.DeadLetters).Publish(ctx, deadLetter); publishErr != nil {
+					return errors.Join(err, fmt.Errorf("publish dead letter: %w", publishErr))
+				}
+				return nil
+			}
+		},
+	}
+}
+
+// retryable applies the optional Retryable filter
+func retryable(filter func(error) bool, err error) bool {
+	return filter == nil || filter(err)
+}
+
+// waitForRetry sleeps for the backoff before the given retry, or until ctx ends
+func waitForRetry(ctx context.Context, backoff func(int) time.Duration, retry int) error {
+	if backoff == nil {
+		return ctx.Err()
+	}
+	delay := backoff(retry)
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package events_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+)
+
+var (
+	errConcentration = errors.New("concentration check failed to roll")
+	errPermanent     = errors.New("unknown spell")
+)
+
+// RetryTestSuite tests the retry middleware and dead-letter topic
+type RetryTestSuite struct {
+	suite.Suite
+	ctx         context.Context
+	bus         events.EventBus
+	deadLetters []events.DeadLetterEvent
+}
+
+func TestRetrySuite(t *testing.T) {
+	suite.Run(t, new(RetryTestSuite))
+}
+
+func (s *RetryTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.deadLetters = nil
+
+	_, err := events.DeadLetterTopic.On(s.bus).Subscribe(s.ctx, func(_ context.Context, e events.DeadLetterEvent) error {
+		s.deadLetters = append(s.deadLetters, e)
+		return nil
+	})
+	s.Require().NoError(err)
+}
+
+// failing subscribes a handler that fails the first failures calls and
+// returns a pointer to its call count
+func (s *RetryTestSuite) failing(failures int, err error) (*int, string) {
+	calls := 0
+	id, subErr := ActionTopic.On(s.bus).Subscribe(s.ctx, func(context.Context, TestActionEvent) error {
+		calls++
+		if calls <= failures {
+			return err
+		}
+		return nil
+	})
+	s.Require().NoError(subErr)
+	return &calls, id
+}
+
+func (s *RetryTestSuite) TestRetriesUntilSuccess() {
+	calls, _ := s.failing(2, errConcentration)
+	s.Require().NoError(events.Use(s.bus, events.Retry(events.RetryConfig{MaxAttempts: 3, DeadLetters: s.bus})))
+
+	s.Require().NoError(ActionTopic.On(s.bus).Publish(s.ctx, TestActionEvent{ActorID: testHero}))
+	s.Equal(3, *calls)
+	s.Empty(s.deadLetters)
+}
+
+func (s *RetryTestSuite) TestDeadLettersAfterLastAttempt() {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	calls, id := s.failing(5, errConcentration)
+	laterCalls := 0
+	_, err := ActionTopic.On(s.bus).Subscribe(s.ctx, func(context.Context, TestActionEvent) error {
+		laterCalls++
+		return nil
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(events.Use(s.bus, events.Retry(events.RetryConfig{
+		MaxAttempts: 2,
+		DeadLetters: s.bus,
+		Now:         func() time.Time { return now },
+	})))
+
+	event := TestActionEvent{ActorID: testHero, Action: "concentrate"}
+	s.Require().NoError(ActionTopic.On(s.bus).Publish(s.ctx, event))
+
+	s.Equal(2, *calls)
+	s.Equal(1, laterCalls, "later subscribers still run")
+	s.Require().Len(s.deadLetters, 1)
+	deadLetter := s.deadLetters[0]
+	s.Equal(TopicAction, deadLetter.Topic)
+	s.Equal(id, deadLetter.SubscriptionID)
+	s.Equal(event, deadLetter.Event)
+	s.ErrorIs(deadLetter.Err, errConcentration)
+	s.Equal(2, deadLetter.Attempts)
+	s.Equal(now, deadLetter.FailedAt)
+}
+
+func (s *RetryTestSuite) TestReprocess() {
+	calls, _ := s.failing(1, errConcentration)
+	s.Require().NoError(events.Use(s.bus, events.Retry(events.RetryConfig{DeadLetters: s.bus})))
+
+	s.Require().NoError(ActionTopic.On(s.bus).Publish(s.ctx, TestActionEvent{}))
+	s.Require().Len(s.deadLetters, 1)
+
+	s.Require().NoError(s.deadLetters[0].Reprocess(s.ctx))
+	s.Equal(2, *calls)
+
+	s.Error(events.DeadLetterEvent{}.Reprocess(s.ctx))
+}
+
+func (s *RetryTestSuite) TestWithoutDeadLettersReturnsError() {
+	calls, _ := s.failing(5, errConcentration)
+	s.Require().NoError(events.Use(s.bus, events.Retry(events.RetryConfig{MaxAttempts: 3})))
+
+	s.ErrorIs(ActionTopic.On(s.bus).Publish(s.ctx, TestActionEvent{}), errConcentration)
+	s.Equal(3, *calls)
+}
+
+func (s *RetryTestSuite) TestRetryableFilter() {
+	calls, _ := s.failing(5, errPermanent)
+	s.Require().NoError(events.Use(s.bus, events.Retry(events.RetryConfig{
+		MaxAttempts: 3,
+		Retryable:   func(err error) bool { return !errors.Is(err, errPermanent) },
+		DeadLetters: s.bus,
+	})))
+
+	s.Require().NoError(ActionTopic.On(s.bus).Publish(s.ctx, TestActionEvent{}))
+	s.Equal(1, *calls)
+	s.Require().Len(s.deadLetters, 1)
+	s.Equal(1, s.deadLetters[0].Attempts)
+}
+
+func (s *RetryTestSuite) TestBackoffStopsWithContext() {
+	calls, _ := s.failing(5, errConcentration)
+	var waits []int
+	s.Require().NoError(events.Use(s.bus, events.Retry(events.RetryConfig{
+		MaxAttempts: 3,
+		Backoff: func(retry int) time.Duration {
+			waits = append(waits, retry)
+			return time.Hour
+		},
+	})))
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	cancel()
+	err := ActionTopic.On(s.bus).Publish(ctx, TestActionEvent{})
+	s.ErrorIs(err, errConcentration)
+	s.ErrorIs(err, context.Canceled)
+	s.Equal(1, *calls)
+	s.Equal([]int{1}, waits)
+}
+
+func (s *RetryTestSuite) TestChainedEventsNotRetried() {
+	calls := 0
+	_, err := TestAttackChain.On(s.bus).SubscribeWithChain(s.ctx,
+		func(_ context.Context, _ TestAttackEvent, c chain.Chain[TestAttackEvent]) (chain.Chain[TestAttackEvent], error) {
+			calls++
+			return c, errConcentration
+		})
+	s.Require().NoError(err)
+	s.Require().NoError(events.Use(s.bus, events.Retry(events.RetryConfig{MaxAttempts: 3, DeadLetters: s.bus})))
+
+	stages := []chain.Stage{TestStageBase, TestStageFinal}
+	_, err = TestAttackChain.On(s.bus).PublishWithChain(s.ctx, TestAttackEvent{}, events.NewStagedChain[TestAttackEvent](stages))
+	s.ErrorIs(err, errConcentration)
+	s.Equal(1, calls)
+	s.Empty(s.deadLetters)
+}
+
+func (s *RetryTestSuite) TestFailingDeadLetterHandlerNotDeadLettered() {
+	bus := events.NewEventBus()
+	deadLetterCalls := 0
+	_, err := events.DeadLetterTopic.On(bus).Subscribe(s.ctx, func(context.Context, events.DeadLetterEvent) error {
+		deadLetterCalls++
+		return errPermanent
+	})
+	s.Require().NoError(err)
+	_, err = ActionTopic.On(bus).Subscribe(s.ctx, func(context.Context, TestActionEvent) error {
+		return errConcentration
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(events.Use(bus, events.Retry(events.RetryConfig{MaxAttempts: 2, DeadLetters: bus})))
+
+	err = ActionTopic.On(bus).Publish(s.ctx, TestActionEvent{})
+	s.ErrorIs(err, errConcentration)
+	s.ErrorIs(err, errPermanent)
+	s.Equal(2, deadLetterCalls, "dead-letter handler retried but not dead-lettered")
+}
@@ -0,0 +1,138 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrScopeUnsupported is returned by NewScope for a bus that doesn't implement Scoper
+var ErrScopeUnsupported = errors.New("event bus does not support scopes")
+
+// Scoper is implemented by buses that can create child scopes.
+type Scoper interface {
+	NewScope() *Scope
+}
+
+// NewScope creates a child scope of bus (see Scope). Returns
+// ErrScopeUnsupported if the bus doesn't implement Scoper.
+func NewScope(bus EventBus) (*Scope, error) {
+	scoper, ok := bus.(Scoper)
+	if !ok {
+		return nil, ErrScopeUnsupported
+	}
+	return scoper.NewScope(), nil
+}
+
+// Scope is a child bus for one encounter (or any other span of play). Events
+// published on the scope reach the parent's handlers, then the scope's own;
+// events published on the parent don't reach the scope's handlers, so
+// concurrent encounters sharing a parent stay isolated.
+//
+// Close removes every handler subscribed to the scope at once, so conditions
+// and features applied during the encounter can't leak into the next one:
+//
+//	scope, _ := events.NewScope(bus)
+//	defer scope.Close()
+//	condition.Apply(ctx, scope) // subscribes on the scope
+//	AttackTopic.On(scope).Publish(ctx, attack)
+//
+// Publish encounter events on the scope: a parent handler that publishes on
+// the parent bus it captured won't reach the scope's handlers. Scopes can be
+// nested; closing a scope closes its children.
+type Scope struct {
+	bus *simpleEventBus
+}
+
+// Ensure Scope implements EventBus and supports pattern subscriptions, middleware and scopes
+var (
+	_ EventBus          = (*Scope)(nil)
+	_ PatternSubscriber = (*Scope)(nil)
+	_ MiddlewareUser    = (*Scope)(nil)
+	_ Scoper            = (*Scope)(nil)
+)
+
+// NewScope creates a child scope of the bus
+func (b *simpleEventBus) NewScope() *Scope {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextScope++
+	child := NewEventBus().(*simpleEventBus)
+	child.parent = b
+	child.prefix = fmt.Sprintf("%sscope-%d/", b.prefix, b.nextScope)
+	child.closed = b.closed
+	if !b.closed {
+		b.scopes = append(b.scopes, child)
+	}
+	return &Scope{bus: child}
+}
+
+// Subscribe registers a handler that lives until the scope is closed.
+// Returns ErrBusClosed after Close.
+func (s *Scope) Subscribe(ctx context.Context, topic Topic, handler any) (string, error) {
+	return s.bus.Subscribe(ctx, topic, handler)
+}
+
+// SubscribePattern registers a handler for every topic matching pattern,
+// until the scope is closed. Returns ErrBusClosed after Close.
+func (s *Scope) SubscribePattern(ctx context.Context, pattern string, handler PatternHandler) (string, error) {
+	return s.bus.SubscribePattern(ctx, pattern, handler)
+}
+
+// Unsubscribe removes a subscription by ID, the scope's or its parent's
+func (s *Scope) Unsubscribe(ctx context.Context, id string) error {
+	return s.bus.Unsubscribe(ctx, id)
+}
+
+// Publish sends an event to the parent's subscribers, then the scope's.
+// Returns ErrBusClosed after Close.
+func (s *Scope) Publish(ctx context.Context, topic Topic, event any) error {
+	return s.bus.Publish(ctx, topic, event)
+}
+
+// Use adds middleware to the scope's events, inside the parent's middleware
+func (s *Scope) Use(middleware ...Middleware) {
+	s.bus.Use(middleware...)
+}
+
+// NewScope creates a nested scope, closed along with this one
+func (s *Scope) NewScope() *Scope {
+	return s.bus.NewScope()
+}
+
+// Close removes every subscription made on the scope and its nested scopes
+// in one step. A Publish already delivering may still reach them. Afterwards
+// Subscribe and Publish return ErrBusClosed. Closing twice is a no-op.
+func (s *Scope) Close() {
+	s.bus.close()
+
+	parent := s.bus.parent
+	parent.mu.Lock()
+	defer parent.mu.Unlock()
+	parent.scopes = slices.DeleteFunc(parent.scopes, func(child *simpleEventBus) bool { return child == s.bus })
+}
+
+// close drops the bus's subscriptions and closes its scopes
+func (b *simpleEventBus) close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	b.subscribers = make(map[Topic][]subscription)
+	b.idToTopic = make(map[string]Topic)
+	b.patterns = nil
+	scopes := b.scopes
+	b.scopes = nil
+	b.mu.Unlock()
+
+	for _, scope := range scopes {
+		scope.close()
+	}
+}
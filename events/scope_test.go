@@ -0,0 +1,162 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package events_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+)
+
+// ScopeTestSuite tests scoped child buses
+type ScopeTestSuite struct {
+	suite.Suite
+	ctx       context.Context
+	bus       events.EventBus
+	scope     *events.Scope
+	delivered []string
+}
+
+func TestScopeSuite(t *testing.T) {
+	suite.Run(t, new(ScopeTestSuite))
+}
+
+func (s *ScopeTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.delivered = nil
+
+	scope, err := events.NewScope(s.bus)
+	s.Require().NoError(err)
+	s.scope = scope
+}
+
+// subscribe records deliveries of actions to bus under name
+func (s *ScopeTestSuite) subscribe(bus events.EventBus, name string) string {
+	id, err := ActionTopic.On(bus).Subscribe(s.ctx, func(_ context.Context, _ TestActionEvent) error {
+		s.delivered = append(s.delivered, name)
+		return nil
+	})
+	s.Require().NoError(err)
+	return id
+}
+
+func (s *ScopeTestSuite) TestScopeInheritsParentHandlers() {
+	s.subscribe(s.bus, "parent")
+	s.subscribe(s.scope, "scope")
+
+	s.Require().NoError(ActionTopic.On(s.scope).Publish(s.ctx, TestActionEvent{}))
+	s.Equal([]string{"parent", "scope"}, s.delivered)
+
+	s.delivered = nil
+	s.Require().NoError(ActionTopic.On(s.bus).Publish(s.ctx, TestActionEvent{}))
+	s.Equal([]string{"parent"}, s.delivered, "the parent doesn't see scope handlers")
+}
+
+func (s *ScopeTestSuite) TestSiblingScopesAreIsolated() {
+	other, err := events.NewScope(s.bus)
+	s.Require().NoError(err)
+	s.subscribe(s.scope, "encounter-1")
+	s.subscribe(other, "encounter-2")
+
+	s.Require().NoError(ActionTopic.On(other).Publish(s.ctx, TestActionEvent{}))
+	s.Equal([]string{"encounter-2"}, s.delivered)
+}
+
+func (s *ScopeTestSuite) TestCloseTearsDownScopeHandlers() {
+	s.subscribe(s.bus, "parent")
+	s.subscribe(s.scope, "condition")
+	_, err := s.scope.SubscribePattern(s.ctx, "test.#", func(_ context.Context, _ events.Topic, _ any) error {
+		s.delivered = append(s.delivered, "pattern")
+		return nil
+	})
+	s.Require().NoError(err)
+	nested := s.scope.NewScope()
+	s.subscribe(nested, "nested")
+
+	s.scope.Close()
+	s.scope.Close() // no-op
+
+	s.ErrorIs(ActionTopic.On(s.scope).Publish(s.ctx, TestActionEvent{}), events.ErrBusClosed)
+	s.ErrorIs(ActionTopic.On(nested).Publish(s.ctx, TestActionEvent{}), events.ErrBusClosed)
+	_, err = s.scope.Subscribe(s.ctx, TopicAction, func(context.Context, any) error { return nil })
+	s.ErrorIs(err, events.ErrBusClosed)
+
+	s.Require().NoError(ActionTopic.On(s.bus).Publish(s.ctx, TestActionEvent{}))
+	s.Equal([]string{"parent"}, s.delivered, "parent handlers survive")
+
+	fresh, err := events.NewScope(s.bus)
+	s.Require().NoError(err)
+	s.delivered = nil
+	s.Require().NoError(ActionTopic.On(fresh).Publish(s.ctx, TestActionEvent{}))
+	s.Equal([]string{"parent"}, s.delivered, "nothing leaks into the next encounter")
+}
+
+func (s *ScopeTestSuite) TestUnsubscribe() {
+	parentID := s.subscribe(s.bus, "parent")
+	scopeID := s.subscribe(s.scope, "scope")
+	s.NotEqual(parentID, scopeID, "scope IDs can't clash with the parent's")
+
+	s.Require().NoError(s.scope.Unsubscribe(s.ctx, scopeID))
+	s.Require().NoError(ActionTopic.On(s.scope).Publish(s.ctx, TestActionEvent{}))
+	s.Equal([]string{"parent"}, s.delivered)
+
+	s.Require().NoError(s.scope.Unsubscribe(s.ctx, parentID))
+	s.delivered = nil
+	s.Require().NoError(ActionTopic.On(s.bus).Publish(s.ctx, TestActionEvent{}))
+	s.Empty(s.delivered, "a scope can remove a parent subscription by ID")
+}
+
+func (s *ScopeTestSuite) TestChainedEventsCollectParentAndScopeModifiers() {
+	var added []string
+	type attackModifier = func(context.Context, TestAttackEvent, chain.Chain[TestAttackEvent]) (chain.Chain[TestAttackEvent], error)
+	modifier := func(name string) attackModifier {
+		return func(_ context.Context, _ TestAttackEvent, c chain.Chain[TestAttackEvent]) (chain.Chain[TestAttackEvent], error) {
+			added = append(added, name)
+			return c, nil
+		}
+	}
+	_, err := TestAttackChain.On(s.bus).SubscribeWithChain(s.ctx, modifier("feature"))
+	s.Require().NoError(err)
+	_, err = TestAttackChain.On(s.scope).SubscribeWithChain(s.ctx, modifier("condition"))
+	s.Require().NoError(err)
+
+	stages := []chain.Stage{TestStageBase, TestStageFinal}
+	_, err = TestAttackChain.On(s.scope).PublishWithChain(s.ctx, TestAttackEvent{}, events.NewStagedChain[TestAttackEvent](stages))
+	s.Require().NoError(err)
+	s.Equal([]string{"feature", "condition"}, added)
+}
+
+func (s *ScopeTestSuite) TestMiddlewareNests() {
+	var order []string
+	trace := func(name string) events.Middleware {
+		return events.Middleware{Publish: func(next events.DeliveryFunc) events.DeliveryFunc {
+			return func(ctx context.Context, delivery events.Delivery) error {
+				order = append(order, name)
+				return next(ctx, delivery)
+			}
+		}}
+	}
+	s.Require().NoError(events.Use(s.bus, trace("parent")))
+	s.Require().NoError(events.Use(s.scope, trace("scope")))
+
+	s.Require().NoError(ActionTopic.On(s.scope).Publish(s.ctx, TestActionEvent{}))
+	s.Equal([]string{"parent", "scope"}, order)
+
+	order = nil
+	s.Require().NoError(ActionTopic.On(s.bus).Publish(s.ctx, TestActionEvent{}))
+	s.Equal([]string{"parent"}, order)
+}
+
+func (s *ScopeTestSuite) TestUnsupportedBus() {
+	bus := events.NewAsyncEventBus(events.AsyncBusConfig{})
+	defer func() { s.Require().NoError(bus.Close(s.ctx)) }()
+
+	_, err := events.NewScope(bus)
+	s.ErrorIs(err, events.ErrScopeUnsupported)
+}
@@ -27,6 +27,11 @@ func (d *TypedTopicDef[T]) On(bus EventBus) TypedTopic[T] {
 	}
 }
 
+// Topic returns the topic ID this definition publishes on
+func (d *TypedTopicDef[T]) Topic() Topic {
+	return d.topic
+}
+
 // ChainedTopicDef defines a typed topic that supports chain processing.
 // This is created once at package level and used to get chained topics.
 //
@@ -58,7 +63,10 @@ func (d *ChainedTopicDef[T]) On(bus EventBus) ChainedTopic[T] {
 // Example:
 //
 //	var AttackTopic = events.DefineTypedTopic[AttackEvent]("combat.attack")
+//
+// The topic is registered for discovery with ListTopics.
 func DefineTypedTopic[T any](topic Topic) *TypedTopicDef[T] {
+	topics.register(topic, TopicKindTyped, payloadType[T]())
 	return &TypedTopicDef[T]{
 		topic: topic,
 	}
@@ -70,7 +78,10 @@ func DefineTypedTopic[T any](topic Topic) *TypedTopicDef[T] {
 // Example:
 //
 //	var AttackChain = events.DefineChainedTopic[AttackEvent]("combat.attack")
+//
+// The topic is registered for discovery with ListTopics.
 func DefineChainedTopic[T any](topic Topic) *ChainedTopicDef[T] {
+	topics.register(topic, TopicKindChained, payloadType[T]())
 	return &ChainedTopicDef[T]{
 		topic: topic,
 	}
@@ -16,6 +16,7 @@ import (
 type TypedTopic[T any] interface {
 	// Subscribe registers a handler for events of type T.
 	// This is for pure notifications - the handler processes but doesn't transform the event.
+	// The handler receives the context passed to Publish.
 	// Returns a subscription ID that can be used to unsubscribe.
 	Subscribe(ctx context.Context, handler func(context.Context, T) error) (string, error)
 
@@ -38,7 +39,7 @@ type typedTopic[T any] struct {
 // Subscribe implements TypedTopic[T]
 func (t *typedTopic[T]) Subscribe(ctx context.Context, handler func(context.Context, T) error) (string, error) {
 	// Wrap handler to match bus signature
-	wrappedHandler := func(event any) error {
+	wrappedHandler := func(ctx context.Context, event any) error {
 		typedEvent, ok := event.(T)
 		if !ok {
 			return nil // Ignore events of wrong type
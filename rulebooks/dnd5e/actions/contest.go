@@ -0,0 +1,107 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package actions
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/checks"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
+)
+
+// targetCombatant returns the input's target as a combatant, looking it up in
+// the context when the target entity isn't one.
+func targetCombatant(ctx context.Context, input ActionInput) (combat.Combatant, error) {
+	if combatant, ok := input.Target.(combat.Combatant); ok {
+		return combatant, nil
+	}
+	combatant, err := combat.GetCombatantFromContext(ctx, input.Target.GetID())
+	if err != nil {
+		return nil, rpgerr.Wrapf(err, "failed to find target %s", input.Target.GetID())
+	}
+	return combatant, nil
+}
+
+// athleticsModifier returns the owner's Strength (Athletics) modifier.
+func athleticsModifier(owner core.Entity) int {
+	switch o := owner.(type) {
+	case combat.Combatant:
+		return combat.SkillModifier(o, skills.Athletics)
+	case combat.SkillModifierProvider:
+		return o.GetSkillModifier(skills.Athletics)
+	}
+	return 0
+}
+
+// resolveAthleticsContest pits the owner's Strength (Athletics) check against
+// the target's Strength (Athletics) or Dexterity (Acrobatics) check, the
+// target choosing whichever is better (PHB p.195).
+func resolveAthleticsContest(
+	ctx context.Context,
+	owner core.Entity,
+	target combat.Combatant,
+	input ActionInput,
+	source *core.Ref,
+) (*checks.ContestResult, error) {
+	defenseSkill := skills.Athletics
+	defenseModifier := combat.SkillModifier(target, skills.Athletics)
+	if acrobatics := combat.SkillModifier(target, skills.Acrobatics); acrobatics > defenseModifier {
+		defenseSkill = skills.Acrobatics
+		defenseModifier = acrobatics
+	}
+
+	cause := dnd5eEvents.CheckCause{EffectRef: source, InstigatorID: owner.GetID()}
+	return checks.MakeContest(ctx, &checks.ContestInput{
+		Initiator: &checks.AbilityCheckInput{
+			Roller:    input.Roller,
+			EventBus:  input.Bus,
+			CheckerID: owner.GetID(),
+			Cause:     cause,
+			Skill:     skills.Athletics,
+			Modifier:  athleticsModifier(owner),
+		},
+		Defender: &checks.AbilityCheckInput{
+			Roller:    input.Roller,
+			EventBus:  input.Bus,
+			CheckerID: target.GetID(),
+			Cause:     cause,
+			Skill:     defenseSkill,
+			Modifier:  defenseModifier,
+		},
+	})
+}
+
+// applyToTarget applies a condition won by a grapple or shove to the target.
+// Monsters hold their own conditions; characters pick it up from the applied event.
+func applyToTarget(
+	ctx context.Context,
+	input ActionInput,
+	conditionType dnd5eEvents.ConditionType,
+	condition dnd5eEvents.ConditionBehavior,
+) error {
+	if holder, ok := input.Target.(interface {
+		AddCondition(condition dnd5eEvents.ConditionBehavior)
+	}); ok {
+		if err := condition.Apply(ctx, input.Bus); err != nil {
+			return rpgerr.Wrapf(err, "failed to apply %s to %s", conditionType, input.Target.GetID())
+		}
+		holder.AddCondition(condition)
+		return nil
+	}
+
+	err := dnd5eEvents.ConditionAppliedTopic.On(input.Bus).Publish(ctx, dnd5eEvents.ConditionAppliedEvent{
+		Target:    input.Target,
+		Type:      conditionType,
+		Source:    dnd5eEvents.ConditionSourceAction,
+		Condition: condition,
+	})
+	if err != nil {
+		return rpgerr.Wrapf(err, "failed to apply %s to %s", conditionType, input.Target.GetID())
+	}
+	return nil
+}
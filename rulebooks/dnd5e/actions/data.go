@@ -68,6 +68,18 @@ func LoadFromData(data ActionData) (Action, error) {
 			WeaponID: "", // No weapon for unarmed
 		}), nil
 
+	case refs.Actions.Grapple().ID:
+		return NewGrapple(GrappleConfig{
+			ID:      data.ID,
+			OwnerID: data.OwnerID,
+		}), nil
+
+	case refs.Actions.Shove().ID:
+		return NewShove(ShoveConfig{
+			ID:      data.ID,
+			OwnerID: data.OwnerID,
+		}), nil
+
 	default:
 		return nil, fmt.Errorf("unknown action type: %s", data.Ref.ID)
 	}
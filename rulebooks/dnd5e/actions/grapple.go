@@ -0,0 +1,176 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	coreCombat "github.com/KirkDiggler/rpg-toolkit/core/combat"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/conditions"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// Grapple represents grappling a creature in place of one attack from the
+// Attack action (PHB p.195). It consumes one AttacksRemaining and resolves a
+// Strength (Athletics) check contested by the target's Athletics or
+// Acrobatics. If the grappler wins, the target is Grappled until it escapes.
+//
+// Creature size and free hands aren't tracked, so the one-size-larger and
+// free-hand limits are left to the caller.
+type Grapple struct {
+	id      string
+	ownerID string
+}
+
+// GrappleConfig contains configuration for creating a Grapple action
+type GrappleConfig struct {
+	ID      string
+	OwnerID string
+}
+
+// NewGrapple creates a new Grapple action
+func NewGrapple(config GrappleConfig) *Grapple {
+	return &Grapple{
+		id:      config.ID,
+		ownerID: config.OwnerID,
+	}
+}
+
+// GetID implements core.Entity
+func (g *Grapple) GetID() string {
+	return g.id
+}
+
+// GetType implements core.Entity
+func (g *Grapple) GetType() core.EntityType {
+	return EntityTypeAction
+}
+
+// CanActivate implements core.Action[ActionInput]
+// Grapple can be activated when there are attacks remaining and a target.
+func (g *Grapple) CanActivate(_ context.Context, _ core.Entity, input ActionInput) error {
+	if input.ActionEconomy == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "action economy required")
+	}
+
+	if input.Bus == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "event bus required for grapple")
+	}
+
+	if !input.ActionEconomy.CanUseAttack() {
+		return rpgerr.New(rpgerr.CodeResourceExhausted, "no attacks remaining")
+	}
+
+	if input.Target == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "grapple requires a target")
+	}
+
+	return nil
+}
+
+// Activate implements core.Action[ActionInput]
+// Grapple consumes one attack, resolves the contest, applies the Grappled
+// condition to the target on a win, and publishes a GrappleResolvedEvent.
+func (g *Grapple) Activate(ctx context.Context, owner core.Entity, input ActionInput) error {
+	if err := g.CanActivate(ctx, owner, input); err != nil {
+		return err
+	}
+
+	target, err := targetCombatant(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	if err := input.ActionEconomy.UseAttack(); err != nil {
+		return rpgerr.Wrapf(err, "failed to use attack")
+	}
+
+	contest, err := resolveAthleticsContest(ctx, owner, target, input, refs.Actions.Grapple())
+	if err != nil {
+		return rpgerr.Wrapf(err, "failed to resolve grapple contest")
+	}
+
+	if contest.InitiatorWins {
+		grappled := conditions.NewGrappledCondition(conditions.GrappledConditionConfig{
+			CharacterID: input.Target.GetID(),
+			GrapplerID:  owner.GetID(),
+		})
+		if err := applyToTarget(ctx, input, dnd5eEvents.ConditionGrappled, grappled); err != nil {
+			return err
+		}
+	}
+
+	topic := dnd5eEvents.GrappleResolvedTopic.On(input.Bus)
+	err = topic.Publish(ctx, dnd5eEvents.GrappleResolvedEvent{
+		AttackerID:    owner.GetID(),
+		TargetID:      input.Target.GetID(),
+		AttackerTotal: contest.Initiator.Total,
+		TargetTotal:   contest.Defender.Total,
+		Success:       contest.InitiatorWins,
+		ActionID:      g.id,
+	})
+	if err != nil {
+		return rpgerr.Wrapf(err, "failed to publish grapple resolved event")
+	}
+
+	return nil
+}
+
+// Apply implements Action - Grapple is a permanent action and does not need
+// to subscribe to any events.
+func (g *Grapple) Apply(_ context.Context, _ events.EventBus) error {
+	return nil
+}
+
+// Remove implements Action - Grapple is a permanent action and does not need
+// to unsubscribe from any events.
+func (g *Grapple) Remove(_ context.Context, _ events.EventBus) error {
+	return nil
+}
+
+// IsTemporary returns false - Grapple is a permanent action
+func (g *Grapple) IsTemporary() bool {
+	return false
+}
+
+// UsesRemaining returns UnlimitedUses - Grapple can be used as long as attacks remain
+func (g *Grapple) UsesRemaining() int {
+	return UnlimitedUses
+}
+
+// ToJSON converts the action to JSON for persistence
+func (g *Grapple) ToJSON() (json.RawMessage, error) {
+	data := map[string]interface{}{
+		"id":       g.id,
+		"owner_id": g.ownerID,
+		"type":     "grapple",
+	}
+
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal grapple: %w", err)
+	}
+
+	return bytes, nil
+}
+
+// ActionType returns the action economy cost (free - uses come from Attack ability)
+func (g *Grapple) ActionType() coreCombat.ActionType {
+	return coreCombat.ActionFree
+}
+
+// CapacityType returns that Grapple consumes attack capacity
+func (g *Grapple) CapacityType() combat.CapacityType {
+	return combat.CapacityAttack
+}
+
+// Compile-time check that Grapple implements Action
+var _ Action = (*Grapple)(nil)
@@ -0,0 +1,240 @@
+package actions_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/actions"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	mock_combat "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat/mock"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/conditions"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+)
+
+// combatantTarget is a combatant that can act as an owner or target entity
+type combatantTarget struct {
+	*mock_combat.MockCombatant
+}
+
+func (c *combatantTarget) GetType() core.EntityType {
+	return "monster"
+}
+
+// conditionHolder is a combatant that keeps its own conditions, like a monster
+type conditionHolder struct {
+	*combatantTarget
+	conditions []dnd5eEvents.ConditionBehavior
+}
+
+func (c *conditionHolder) AddCondition(condition dnd5eEvents.ConditionBehavior) {
+	c.conditions = append(c.conditions, condition)
+}
+
+type GrappleShoveTestSuite struct {
+	suite.Suite
+	ctrl          *gomock.Controller
+	ctx           context.Context
+	bus           events.EventBus
+	roller        *mock_dice.MockRoller
+	attacker      *combatantTarget
+	target        *combatantTarget
+	actionEconomy *combat.ActionEconomy
+	grapple       *actions.Grapple
+	shove         *actions.Shove
+}
+
+func TestGrappleShoveTestSuite(t *testing.T) {
+	suite.Run(t, new(GrappleShoveTestSuite))
+}
+
+func (s *GrappleShoveTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.roller = mock_dice.NewMockRoller(s.ctrl)
+
+	// Attacker: STR 16 (+3), no skill proficiency
+	s.attacker = &combatantTarget{MockCombatant: s.newCombatant("fighter-1", 16, 10)}
+	// Target: STR 10 (+0), DEX 14 (+2) - defends with Acrobatics
+	s.target = &combatantTarget{MockCombatant: s.newCombatant("goblin-1", 10, 14)}
+
+	s.actionEconomy = combat.NewActionEconomy()
+	s.actionEconomy.SetAttacks(2)
+
+	s.grapple = actions.NewGrapple(actions.GrappleConfig{ID: "fighter-1-grapple", OwnerID: "fighter-1"})
+	s.shove = actions.NewShove(actions.ShoveConfig{ID: "fighter-1-shove", OwnerID: "fighter-1"})
+}
+
+func (s *GrappleShoveTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *GrappleShoveTestSuite) newCombatant(id string, str, dex int) *mock_combat.MockCombatant {
+	c := mock_combat.NewMockCombatant(s.ctrl)
+	c.EXPECT().GetID().Return(id).AnyTimes()
+	c.EXPECT().AbilityScores().Return(shared.AbilityScores{
+		abilities.STR: str,
+		abilities.DEX: dex,
+	}).AnyTimes()
+	return c
+}
+
+// expectRolls sets the attacker's d20 and then the target's d20
+func (s *GrappleShoveTestSuite) expectRolls(attacker, target int) {
+	gomock.InOrder(
+		s.roller.EXPECT().Roll(gomock.Any(), 20).Return(attacker, nil),
+		s.roller.EXPECT().Roll(gomock.Any(), 20).Return(target, nil),
+	)
+}
+
+func (s *GrappleShoveTestSuite) input() actions.ActionInput {
+	return actions.ActionInput{
+		Bus:           s.bus,
+		ActionEconomy: s.actionEconomy,
+		Target:        s.target,
+		Roller:        s.roller,
+	}
+}
+
+func (s *GrappleShoveTestSuite) TestGrapple_NoAttacksRemaining() {
+	s.actionEconomy.SetAttacks(0)
+
+	err := s.grapple.CanActivate(s.ctx, s.attacker, s.input())
+	s.Require().Error(err)
+	var rpgErr *rpgerr.Error
+	s.Require().True(errors.As(err, &rpgErr))
+	s.Assert().Equal(rpgerr.CodeResourceExhausted, rpgErr.Code)
+}
+
+func (s *GrappleShoveTestSuite) TestGrapple_WinAppliesGrappledViaEvent() {
+	s.expectRolls(12, 10) // 15 vs 12
+
+	var applied *dnd5eEvents.ConditionAppliedEvent
+	_, err := dnd5eEvents.ConditionAppliedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, e dnd5eEvents.ConditionAppliedEvent) error {
+			applied = &e
+			return nil
+		})
+	s.Require().NoError(err)
+
+	var resolved *dnd5eEvents.GrappleResolvedEvent
+	_, err = dnd5eEvents.GrappleResolvedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, e dnd5eEvents.GrappleResolvedEvent) error {
+			resolved = &e
+			return nil
+		})
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.grapple.Activate(s.ctx, s.attacker, s.input()))
+
+	s.Assert().Equal(1, s.actionEconomy.AttacksRemaining, "grapple replaces one attack")
+
+	s.Require().NotNil(applied)
+	s.Assert().Equal(dnd5eEvents.ConditionGrappled, applied.Type)
+	s.Assert().Equal(dnd5eEvents.ConditionSourceAction, applied.Source)
+	grappled, ok := applied.Condition.(*conditions.GrappledCondition)
+	s.Require().True(ok)
+	s.Assert().Equal("goblin-1", grappled.CharacterID)
+	s.Assert().Equal("fighter-1", grappled.GrapplerID)
+
+	s.Require().NotNil(resolved)
+	s.Assert().True(resolved.Success)
+	s.Assert().Equal(15, resolved.AttackerTotal)
+	s.Assert().Equal(12, resolved.TargetTotal)
+	s.Assert().Equal("fighter-1-grapple", resolved.ActionID)
+}
+
+func (s *GrappleShoveTestSuite) TestGrapple_TieAppliesNothing() {
+	s.expectRolls(9, 10) // 12 vs 12 - a tie leaves the situation unchanged
+
+	applied := false
+	_, err := dnd5eEvents.ConditionAppliedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, _ dnd5eEvents.ConditionAppliedEvent) error {
+			applied = true
+			return nil
+		})
+	s.Require().NoError(err)
+
+	var resolved *dnd5eEvents.GrappleResolvedEvent
+	_, err = dnd5eEvents.GrappleResolvedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, e dnd5eEvents.GrappleResolvedEvent) error {
+			resolved = &e
+			return nil
+		})
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.grapple.Activate(s.ctx, s.attacker, s.input()))
+
+	s.Assert().False(applied)
+	s.Require().NotNil(resolved)
+	s.Assert().False(resolved.Success)
+	s.Assert().Equal(1, s.actionEconomy.AttacksRemaining, "a failed grapple still uses the attack")
+}
+
+func (s *GrappleShoveTestSuite) TestShove_RequiresEffect() {
+	err := s.shove.CanActivate(s.ctx, s.attacker, s.input())
+	s.Require().Error(err)
+	var rpgErr *rpgerr.Error
+	s.Require().True(errors.As(err, &rpgErr))
+	s.Assert().Equal(rpgerr.CodeInvalidArgument, rpgErr.Code)
+}
+
+func (s *GrappleShoveTestSuite) TestShove_ProneAddsConditionToHolder() {
+	s.expectRolls(15, 3) // 18 vs 5
+
+	holder := &conditionHolder{combatantTarget: s.target}
+	input := s.input()
+	input.Target = holder
+	input.ShoveEffect = dnd5eEvents.ShoveProne
+
+	s.Require().NoError(s.shove.Activate(s.ctx, s.attacker, input))
+
+	s.Require().Len(holder.conditions, 1)
+	prone, ok := holder.conditions[0].(*conditions.ProneCondition)
+	s.Require().True(ok)
+	s.Assert().Equal("goblin-1", prone.CharacterID)
+	s.Assert().Equal(1, s.actionEconomy.AttacksRemaining)
+}
+
+func (s *GrappleShoveTestSuite) TestShove_PushReportsDistance() {
+	s.expectRolls(15, 3)
+
+	var resolved *dnd5eEvents.ShoveResolvedEvent
+	_, err := dnd5eEvents.ShoveResolvedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, e dnd5eEvents.ShoveResolvedEvent) error {
+			resolved = &e
+			return nil
+		})
+	s.Require().NoError(err)
+
+	input := s.input()
+	input.ShoveEffect = dnd5eEvents.ShovePush
+	s.Require().NoError(s.shove.Activate(s.ctx, s.attacker, input))
+
+	s.Require().NotNil(resolved)
+	s.Assert().True(resolved.Success)
+	s.Assert().Equal(dnd5eEvents.ShovePush, resolved.Effect)
+	s.Assert().Equal(5, resolved.PushFeet)
+}
+
+func (s *GrappleShoveTestSuite) TestShove_ConsumesEachAttack() {
+	s.roller.EXPECT().Roll(gomock.Any(), 20).Return(1, nil).Times(4)
+
+	input := s.input()
+	input.ShoveEffect = dnd5eEvents.ShovePush
+	s.Require().NoError(s.shove.Activate(s.ctx, s.attacker, input))
+	s.Require().NoError(s.shove.Activate(s.ctx, s.attacker, input))
+
+	s.Assert().Equal(0, s.actionEconomy.AttacksRemaining)
+	s.Assert().Error(s.shove.Activate(s.ctx, s.attacker, input))
+}
@@ -0,0 +1,188 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	coreCombat "github.com/KirkDiggler/rpg-toolkit/core/combat"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/conditions"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// shovePushFeet is how far a successful shove pushes its target
+const shovePushFeet = 5
+
+// Shove represents shoving a creature in place of one attack from the Attack
+// action (PHB p.195). It consumes one AttacksRemaining and resolves a Strength
+// (Athletics) check contested by the target's Athletics or Acrobatics. If the
+// attacker wins, the target is knocked Prone or pushed 5 feet away, as chosen
+// through input.ShoveEffect. The game moves a pushed target.
+//
+// Creature size isn't tracked, so the one-size-larger limit is left to the caller.
+type Shove struct {
+	id      string
+	ownerID string
+}
+
+// ShoveConfig contains configuration for creating a Shove action
+type ShoveConfig struct {
+	ID      string
+	OwnerID string
+}
+
+// NewShove creates a new Shove action
+func NewShove(config ShoveConfig) *Shove {
+	return &Shove{
+		id:      config.ID,
+		ownerID: config.OwnerID,
+	}
+}
+
+// GetID implements core.Entity
+func (s *Shove) GetID() string {
+	return s.id
+}
+
+// GetType implements core.Entity
+func (s *Shove) GetType() core.EntityType {
+	return EntityTypeAction
+}
+
+// CanActivate implements core.Action[ActionInput]
+// Shove can be activated when there are attacks remaining, a target, and a chosen effect.
+func (s *Shove) CanActivate(_ context.Context, _ core.Entity, input ActionInput) error {
+	if input.ActionEconomy == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "action economy required")
+	}
+
+	if input.Bus == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "event bus required for shove")
+	}
+
+	if !input.ActionEconomy.CanUseAttack() {
+		return rpgerr.New(rpgerr.CodeResourceExhausted, "no attacks remaining")
+	}
+
+	if input.Target == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "shove requires a target")
+	}
+
+	switch input.ShoveEffect {
+	case dnd5eEvents.ShoveProne, dnd5eEvents.ShovePush:
+		return nil
+	default:
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument,
+			"invalid shove effect: %q (must be 'prone' or 'push')", input.ShoveEffect)
+	}
+}
+
+// Activate implements core.Action[ActionInput]
+// Shove consumes one attack, resolves the contest, knocks the target prone on
+// a winning ShoveProne, and publishes a ShoveResolvedEvent.
+func (s *Shove) Activate(ctx context.Context, owner core.Entity, input ActionInput) error {
+	if err := s.CanActivate(ctx, owner, input); err != nil {
+		return err
+	}
+
+	target, err := targetCombatant(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	if err := input.ActionEconomy.UseAttack(); err != nil {
+		return rpgerr.Wrapf(err, "failed to use attack")
+	}
+
+	contest, err := resolveAthleticsContest(ctx, owner, target, input, refs.Actions.Shove())
+	if err != nil {
+		return rpgerr.Wrapf(err, "failed to resolve shove contest")
+	}
+
+	event := dnd5eEvents.ShoveResolvedEvent{
+		AttackerID:    owner.GetID(),
+		TargetID:      input.Target.GetID(),
+		Effect:        input.ShoveEffect,
+		AttackerTotal: contest.Initiator.Total,
+		TargetTotal:   contest.Defender.Total,
+		Success:       contest.InitiatorWins,
+		ActionID:      s.id,
+	}
+
+	if contest.InitiatorWins {
+		switch input.ShoveEffect {
+		case dnd5eEvents.ShoveProne:
+			prone := conditions.NewProneCondition(input.Target.GetID())
+			if err := applyToTarget(ctx, input, dnd5eEvents.ConditionProne, prone); err != nil {
+				return err
+			}
+		case dnd5eEvents.ShovePush:
+			event.PushFeet = shovePushFeet
+		}
+	}
+
+	if err := dnd5eEvents.ShoveResolvedTopic.On(input.Bus).Publish(ctx, event); err != nil {
+		return rpgerr.Wrapf(err, "failed to publish shove resolved event")
+	}
+
+	return nil
+}
+
+// Apply implements Action - Shove is a permanent action and does not need
+// to subscribe to any events.
+func (s *Shove) Apply(_ context.Context, _ events.EventBus) error {
+	return nil
+}
+
+// Remove implements Action - Shove is a permanent action and does not need
+// to unsubscribe from any events.
+func (s *Shove) Remove(_ context.Context, _ events.EventBus) error {
+	return nil
+}
+
+// IsTemporary returns false - Shove is a permanent action
+func (s *Shove) IsTemporary() bool {
+	return false
+}
+
+// UsesRemaining returns UnlimitedUses - Shove can be used as long as attacks remain
+func (s *Shove) UsesRemaining() int {
+	return UnlimitedUses
+}
+
+// ToJSON converts the action to JSON for persistence
+func (s *Shove) ToJSON() (json.RawMessage, error) {
+	data := map[string]interface{}{
+		"id":       s.id,
+		"owner_id": s.ownerID,
+		"type":     "shove",
+	}
+
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal shove: %w", err)
+	}
+
+	return bytes, nil
+}
+
+// ActionType returns the action economy cost (free - uses come from Attack ability)
+func (s *Shove) ActionType() coreCombat.ActionType {
+	return coreCombat.ActionFree
+}
+
+// CapacityType returns that Shove consumes attack capacity
+func (s *Shove) CapacityType() combat.CapacityType {
+	return combat.CapacityAttack
+}
+
+// Compile-time check that Shove implements Action
+var _ Action = (*Shove)(nil)
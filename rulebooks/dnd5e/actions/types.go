@@ -3,8 +3,10 @@ package actions
 
 import (
 	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/dice"
 	"github.com/KirkDiggler/rpg-toolkit/events"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
 	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
 )
 
@@ -38,4 +40,12 @@ type ActionInput struct {
 	// MovementCostFt is the movement cost in feet to reach the destination
 	// This should be calculated by the caller based on grid/terrain rules
 	MovementCostFt int `json:"-"`
+
+	// ShoveEffect is whether a Shove knocks the target prone or pushes it
+	// (required for Shove)
+	ShoveEffect dnd5eEvents.ShoveEffect `json:"-"`
+
+	// Roller rolls the contested checks for Grapple and Shove.
+	// If nil, a default roller is used.
+	Roller dice.Roller `json:"-"`
 }
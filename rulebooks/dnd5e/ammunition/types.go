@@ -69,9 +69,8 @@ func (a *Ammunition) EquipmentWeight() float32 {
 
 // EquipmentValue returns the value in copper pieces
 func (a *Ammunition) EquipmentValue() int {
-	// TODO: Parse cost string and convert to copper
-	// For now, return a placeholder
-	return 0
+	value, _ := shared.ParseCost(a.Cost)
+	return value
 }
 
 // EquipmentDescription returns a description of the item
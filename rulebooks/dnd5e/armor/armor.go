@@ -93,9 +93,8 @@ func (a *Armor) EquipmentWeight() float32 {
 
 // EquipmentValue returns the value in copper pieces
 func (a *Armor) EquipmentValue() int {
-	// TODO: Parse cost string (e.g., "5 gp") and convert to copper
-	// For now, return a placeholder
-	return 0
+	value, _ := shared.ParseCost(a.Cost)
+	return value
 }
 
 // EquipmentDescription returns a description of the armor
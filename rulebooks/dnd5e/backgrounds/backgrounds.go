@@ -45,6 +45,11 @@ const (
 	GuildMerchant Background = "guild-merchant" // Variant of Guild Artisan
 )
 
+// Customized background (PHB "Customizing a Background")
+const (
+	Custom Background = "custom" // Any two skills and two tools or languages
+)
+
 // All provides map lookup for backgrounds.
 //
 // Deprecated: Use BackgroundData directly - it now contains ID field and Name()/Description() methods.
@@ -67,6 +72,8 @@ var All = map[string]Background{
 	"pirate":         Pirate,
 	"knight":         Knight,
 	"guild-merchant": GuildMerchant,
+	// Customized
+	"custom": Custom,
 }
 
 // GetByID returns a background by its ID
@@ -122,6 +129,8 @@ func (b Background) Name() string {
 		return "Knight"
 	case GuildMerchant:
 		return "Guild Merchant"
+	case Custom:
+		return "Custom Background"
 	default:
 		return string(b)
 	}
@@ -165,6 +174,8 @@ func (b Background) Description() string {
 		return "You are a noble warrior sworn to a code of honor"
 	case GuildMerchant:
 		return "You are a member of a merchant's guild"
+	case Custom:
+		return "You shaped your own past from the customization rules"
 	default:
 		return ""
 	}
@@ -180,6 +191,12 @@ func (b Background) IsVariant() bool {
 	}
 }
 
+// IsCustom returns true if this background is built from the customization
+// rules instead of a fixed list of proficiencies
+func (b Background) IsCustom() bool {
+	return b == Custom
+}
+
 // BaseBackground returns the base background for variants
 func (b Background) BaseBackground() Background {
 	switch b {
@@ -1,6 +1,7 @@
 package backgrounds
 
 import (
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/proficiencies"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
 )
 
@@ -15,6 +16,9 @@ type Data struct {
 	// Languages
 	LanguageCount int // Number of languages to choose
 
+	// Tools or languages, in any mix (customized backgrounds)
+	ToolOrLanguageCount int
+
 	// Equipment
 	// TODO: Add equipment grants when equipment system is ready
 
@@ -158,6 +162,65 @@ var BackgroundData = map[Background]*Data{
 		LanguageCount: 1,
 		Feature:       "Guild Membership", // Same as Guild Artisan
 	},
+
+	// Customized background: any two skills, and two tools or languages
+	// from the sample backgrounds. The feature is taken from any background
+	// and has no mechanics yet.
+	Custom: {
+		ID:                  Custom,
+		SkillCount:          2,
+		Skills:              nil, // Any skill
+		ToolOrLanguageCount: 2,
+	},
+}
+
+// CustomizationTools returns the tool proficiencies the sample backgrounds
+// offer, which a customized background may choose from
+func CustomizationTools() []proficiencies.Tool {
+	return []proficiencies.Tool{
+		// Artisan's tools
+		proficiencies.ToolAlchemist,
+		proficiencies.ToolBrewer,
+		proficiencies.ToolCalligrapher,
+		proficiencies.ToolCarpenter,
+		proficiencies.ToolCartographer,
+		proficiencies.ToolCobbler,
+		proficiencies.ToolCook,
+		proficiencies.ToolGlassblower,
+		proficiencies.ToolJeweler,
+		proficiencies.ToolLeatherworker,
+		proficiencies.ToolMason,
+		proficiencies.ToolPainter,
+		proficiencies.ToolPotter,
+		proficiencies.ToolSmith,
+		proficiencies.ToolTinker,
+		proficiencies.ToolWeaver,
+		proficiencies.ToolWoodcarver,
+		// Gaming sets
+		proficiencies.ToolDiceSet,
+		proficiencies.ToolPlayingCardSet,
+		proficiencies.ToolDragonchessSet,
+		proficiencies.ToolThreeDragonAnte,
+		// Musical instruments
+		proficiencies.ToolBagpipes,
+		proficiencies.ToolDrum,
+		proficiencies.ToolDulcimer,
+		proficiencies.ToolFlute,
+		proficiencies.ToolLute,
+		proficiencies.ToolLyre,
+		proficiencies.ToolHorn,
+		proficiencies.ToolPanFlute,
+		proficiencies.ToolShawm,
+		proficiencies.ToolViol,
+		// Other tools
+		proficiencies.ToolDisguiseKit,
+		proficiencies.ToolForgeryKit,
+		proficiencies.ToolHerbalism,
+		proficiencies.ToolNavigator,
+		proficiencies.ToolThieves,
+		proficiencies.ToolVehicleLand,
+		proficiencies.ToolVehicleWater,
+	}
 }
 
 // GetData returns the mechanical data for a background
@@ -166,7 +166,7 @@ func GetGrants(bg Background) *Grant {
 		}
 
 	default:
-		// Unknown background or custom
+		// Unknown background, or Custom where every proficiency is a choice
 		return nil
 	}
 }
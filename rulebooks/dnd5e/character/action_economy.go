@@ -389,7 +389,9 @@ func (c *Character) activateCombatAbility(ca combatabilities.CombatAbility, _ *A
 
 // activateFeature directly manages action economy for feature activation.
 // Features manage their own resources (charges) but the Character manages action economy consumption.
-func (c *Character) activateFeature(f features.Feature, _ *ActivateAbilityInput) (*ActivateAbilityOutput, error) {
+// Features that grant capacity (Action Surge, Flurry of Blows, Dash movement) do so through
+// the bridged toolkit ActionEconomy, which is synced back after activation.
+func (c *Character) activateFeature(f features.Feature, input *ActivateAbilityInput) (*ActivateAbilityOutput, error) {
 	// Check action economy
 	if !c.canUseAbilityByActionType(f.ActionType()) {
 		reason := c.actionTypeExhaustedReason(f.ActionType())
@@ -416,7 +418,14 @@ func (c *Character) activateFeature(f features.Feature, _ *ActivateAbilityInput)
 	c.consumeActionType(f.ActionType())
 
 	// Activate the feature
-	if err := f.Activate(ctx, c, features.FeatureInput{Bus: c.bus}); err != nil {
+	ae := c.toToolkitActionEconomy()
+	featureInput := features.FeatureInput{
+		Bus:           c.bus,
+		ActionEconomy: ae,
+		Action:        input.Action,
+		Speed:         c.GetSpeed(),
+	}
+	if err := f.Activate(ctx, c, featureInput); err != nil {
 		// Rollback action economy on failure
 		c.restoreActionType(f.ActionType())
 		return &ActivateAbilityOutput{
@@ -427,6 +436,9 @@ func (c *Character) activateFeature(f features.Feature, _ *ActivateAbilityInput)
 		}, nil
 	}
 
+	// Sync capacity granted by the feature back to our data
+	c.fromToolkitActionEconomy(ae)
+
 	return &ActivateAbilityOutput{
 		Success:   true,
 		Abilities: c.buildAvailableAbilities(),
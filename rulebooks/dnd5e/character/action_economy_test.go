@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/KirkDiggler/rpg-toolkit/core"
 	coreResources "github.com/KirkDiggler/rpg-toolkit/core/resources"
 	"github.com/KirkDiggler/rpg-toolkit/events"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
@@ -884,3 +885,75 @@ func (s *ActionEconomyTestSuite) TestHasGranted_NotInCombat() {
 	char := createTestFighterCharacter(s.T(), s.bus)
 	s.False(char.HasGranted(GrantedAttacks))
 }
+
+// createTestKiMonkCharacter creates a level 3 Monk with 3 Ki and the Ki features.
+func createTestKiMonkCharacter(t *testing.T, bus events.EventBus) *Character {
+	t.Helper()
+
+	char := createTestMonkCharacter(t, bus)
+	char.resources[resources.Ki] = combat.NewRecoverableResource(combat.RecoverableResourceConfig{
+		ID:          string(resources.Ki),
+		Maximum:     3,
+		CharacterID: char.id,
+		ResetType:   coreResources.ResetShortRest,
+	})
+
+	for _, ref := range []*core.Ref{refs.Features.FlurryOfBlows(), refs.Features.StepOfTheWind()} {
+		output, err := features.CreateFromRef(&features.CreateFromRefInput{
+			Ref:         ref.String(),
+			CharacterID: char.id,
+		})
+		if err != nil {
+			t.Fatalf("failed to create %s: %v", ref.ID, err)
+		}
+		char.features = append(char.features, output.Feature)
+	}
+
+	return char
+}
+
+func (s *ActionEconomyTestSuite) TestActivateAbility_FlurryOfBlowsGrantsStrikes() {
+	char := createTestKiMonkCharacter(s.T(), s.bus)
+
+	_, err := char.StartTurn(s.ctx, &StartTurnInput{Speed: 30})
+	s.Require().NoError(err)
+
+	output, err := char.ActivateAbility(s.ctx, &ActivateAbilityInput{
+		AbilityRef: refs.Features.FlurryOfBlows(),
+	})
+	s.Require().NoError(err)
+	s.Require().True(output.Success, output.Error)
+
+	// Ki and the bonus action are spent; two strikes are granted
+	s.Equal(2, char.GetResource(resources.Ki).Current())
+	s.Equal(0, char.actionEconomy.BonusActionsRemaining)
+	s.Equal(2, char.actionEconomy.Granted[GrantedFlurryStrikes])
+
+	for range 2 {
+		executed, err := char.ExecuteAction(s.ctx, &ExecuteActionInput{ActionRef: refs.Actions.FlurryStrike()})
+		s.Require().NoError(err)
+		s.True(executed.Success, executed.Error)
+	}
+
+	executed, err := char.ExecuteAction(s.ctx, &ExecuteActionInput{ActionRef: refs.Actions.FlurryStrike()})
+	s.Require().NoError(err)
+	s.False(executed.Success)
+}
+
+func (s *ActionEconomyTestSuite) TestActivateAbility_StepOfTheWindDash() {
+	char := createTestKiMonkCharacter(s.T(), s.bus)
+
+	_, err := char.StartTurn(s.ctx, &StartTurnInput{Speed: 30})
+	s.Require().NoError(err)
+
+	output, err := char.ActivateAbility(s.ctx, &ActivateAbilityInput{
+		AbilityRef: refs.Features.StepOfTheWind(),
+		Action:     "dash",
+	})
+	s.Require().NoError(err)
+	s.Require().True(output.Success, output.Error)
+
+	s.Equal(60, char.actionEconomy.MovementRemaining)
+	s.Equal(2, char.GetResource(resources.Ki).Current())
+	s.Equal(0, char.actionEconomy.BonusActionsRemaining)
+}
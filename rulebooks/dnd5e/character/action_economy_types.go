@@ -115,6 +115,7 @@ type StartTurnOutput struct {
 // ActivateAbilityInput provides input for activating a combat ability or feature.
 type ActivateAbilityInput struct {
 	AbilityRef *core.Ref // which ability to activate
+	Action     string    // choice for features with options (e.g., Step of the Wind: "dash" or "disengage")
 }
 
 // ActivateAbilityOutput contains the result of activating an ability.
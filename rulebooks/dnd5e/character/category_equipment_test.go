@@ -116,6 +116,25 @@ func (s *CategoryBasedEquipmentTestSuite) TestBarbarianSecondaryWeaponCategoryCh
 		"Should have the selected spear in equipment selection")
 }
 
+// TestCategorySelectionOutsideCategories rejects a pick the option's categories don't offer
+func (s *CategoryBasedEquipmentTestSuite) TestCategorySelectionOutsideCategories() {
+	err := s.draft.SetClass(&character.SetClassInput{
+		ClassID: classes.Barbarian,
+		Choices: character.ClassChoices{
+			Skills: []skills.Skill{skills.Athletics, skills.Intimidation},
+			Equipment: []character.EquipmentChoiceSelection{
+				{
+					ChoiceID:           choices.BarbarianWeaponsSecondary,
+					OptionID:           choices.BarbarianSecondarySimple,
+					CategorySelections: []shared.EquipmentID{weapons.Longsword}, // Martial, not simple
+				},
+			},
+		},
+	})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "is not one of the choices")
+}
+
 func TestCategoryBasedEquipmentTestSuite(t *testing.T) {
 	suite.Run(t, new(CategoryBasedEquipmentTestSuite))
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/classes"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combatabilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/conditions"
 	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/features"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/languages"
@@ -28,6 +29,7 @@ import (
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/saves"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/spells"
 )
 
 const (
@@ -127,15 +129,22 @@ func (c *Character) GetLevel() int {
 	return c.level
 }
 
-// GetSpeed returns the character's base walking speed in feet from their race.
+// GetSpeed returns the character's base walking speed in feet from their race,
+// or their beast form's walking speed while wild shaped, reduced by exhaustion.
 // This is the base speed before condition modifiers (e.g., Unarmored Movement).
 // Condition-based speed modifiers are applied through the MovementChain.
 func (c *Character) GetSpeed() int {
-	raceData := races.GetData(c.raceID)
-	if raceData == nil {
-		return 30 // Default speed if race data not found
+	speed := 30 // Default speed if race data not found
+	if shaped := c.wildShape(); shaped != nil {
+		speed = shaped.Speed()
+	} else if raceData := races.GetData(c.raceID); raceData != nil {
+		speed = raceData.Speed
 	}
-	return raceData.Speed
+
+	if exhaustion := c.exhaustion(); exhaustion != nil {
+		return exhaustion.Speed(speed)
+	}
+	return speed
 }
 
 // GetExtraAttacksCount returns the number of extra attacks granted by class features.
@@ -162,16 +171,20 @@ func (c *Character) GetExtraAttacksCount() int {
 
 // GetAbilityScore returns the character's ability score (including racial modifiers)
 func (c *Character) GetAbilityScore(ability abilities.Ability) int {
-	return c.abilityScores[ability]
+	return c.AbilityScores()[ability]
 }
 
 // GetAbilityModifier returns the modifier for an ability score
 func (c *Character) GetAbilityModifier(ability abilities.Ability) int {
-	return c.abilityScores.Modifier(ability)
+	return c.AbilityScores().Modifier(ability)
 }
 
-// AbilityScores returns all ability scores (implements Combatant interface)
+// AbilityScores returns all ability scores (implements Combatant interface).
+// While wild shaped, Strength, Dexterity and Constitution come from the beast form.
 func (c *Character) AbilityScores() shared.AbilityScores {
+	if shaped := c.wildShape(); shaped != nil {
+		return shaped.AbilityScores(c.abilityScores)
+	}
 	return c.abilityScores
 }
 
@@ -180,6 +193,56 @@ func (c *Character) ProficiencyBonus() int {
 	return c.proficiencyBonus
 }
 
+// SpellcastingAbility returns the ability the character's class casts with,
+// or an empty ability when the class doesn't cast spells.
+func (c *Character) SpellcastingAbility() abilities.Ability {
+	classData := classes.GetData(c.classID)
+	if classData == nil {
+		return ""
+	}
+	return classData.SpellcastingAbility
+}
+
+// SpellcastingStats returns the character's spell attack bonus and spell save
+// DC, derived from the class spellcasting ability and proficiency bonus and run
+// through the SpellcastingChain so items like a Rod of the Pact Keeper apply.
+// Returns nil for characters whose class doesn't cast spells.
+func (c *Character) SpellcastingStats(ctx context.Context) (*dnd5eEvents.SpellcastingChainEvent, error) {
+	ability := c.SpellcastingAbility()
+	if ability == "" {
+		return nil, nil
+	}
+
+	modifier := c.GetAbilityModifier(ability)
+	return spells.ResolveStats(ctx, &spells.StatsInput{
+		CasterID:    c.id,
+		Ability:     ability,
+		AttackBonus: spells.BaseAttackBonus(modifier, c.proficiencyBonus),
+		SaveDC:      spells.BaseSaveDC(modifier, c.proficiencyBonus),
+		EventBus:    c.bus,
+	})
+}
+
+// SpellAttackBonus returns the character's spell attack bonus, or 0 when the
+// character doesn't cast spells.
+func (c *Character) SpellAttackBonus(ctx context.Context) int {
+	stats, err := c.SpellcastingStats(ctx)
+	if err != nil || stats == nil {
+		return 0
+	}
+	return stats.AttackBonus()
+}
+
+// SpellSaveDC returns the character's spell save DC, or 0 when the character
+// doesn't cast spells.
+func (c *Character) SpellSaveDC(ctx context.Context) int {
+	stats, err := c.SpellcastingStats(ctx)
+	if err != nil || stats == nil {
+		return 0
+	}
+	return stats.SaveDC()
+}
+
 // GetSkillModifier returns the total modifier for a skill check
 func (c *Character) GetSkillModifier(skill skills.Skill) int {
 	ability := skills.Ability(skill)
@@ -596,6 +659,9 @@ func initStandardCombatAbilities(char *Character) {
 	_ = char.AddCombatAbility(combatabilities.NewDisengage(char.id + "-disengage"))
 	_ = char.AddCombatAbility(combatabilities.NewHelp(char.id + "-help"))
 	_ = char.AddCombatAbility(combatabilities.NewHide(char.id + "-hide"))
+	_ = char.AddCombatAbility(combatabilities.NewSearch(char.id + "-search"))
+	_ = char.AddCombatAbility(combatabilities.NewUseObject(char.id + "-use-object"))
+	_ = char.AddCombatAbility(combatabilities.NewReady(char.id + "-ready"))
 }
 
 // GetCombatAbility returns a specific combat ability by ID, or nil if not found.
@@ -643,6 +709,9 @@ func (c *Character) ActivateCombatAbility(ctx context.Context, input *combat.Act
 		ActionHolder:  c,
 		Speed:         input.Speed,
 		ExtraAttacks:  input.ExtraAttacks,
+		TargetID:      input.TargetID,
+		HelpAgainstID: input.HelpAgainstID,
+		Roller:        input.Roller,
 	}
 
 	if err := ability.CanActivate(ctx, c, abilityInput); err != nil {
@@ -686,34 +755,68 @@ func (c *Character) GetConditions() []dnd5eEvents.ConditionBehavior {
 	return c.conditions
 }
 
-// GetHitPoints returns the character's current hit points
+// GetHitPoints returns the character's current hit points,
+// or their beast form's hit points while wild shaped
 func (c *Character) GetHitPoints() int {
+	if shaped := c.wildShape(); shaped != nil {
+		return shaped.HitPoints()
+	}
 	return c.hitPoints
 }
 
-// GetMaxHitPoints returns the character's maximum hit points
+// GetMaxHitPoints returns the character's maximum hit points (halved by
+// exhaustion level 4), or their beast form's maximum while wild shaped
 func (c *Character) GetMaxHitPoints() int {
+	if shaped := c.wildShape(); shaped != nil {
+		return shaped.MaxHitPoints()
+	}
+	if exhaustion := c.exhaustion(); exhaustion != nil {
+		return exhaustion.MaxHitPoints(c.maxHitPoints)
+	}
 	return c.maxHitPoints
 }
 
+// exhaustion returns the character's exhaustion, or nil if they aren't exhausted
+func (c *Character) exhaustion() *conditions.ExhaustionCondition {
+	for _, condition := range c.conditions {
+		if exhausted, ok := condition.(*conditions.ExhaustionCondition); ok && exhausted.IsApplied() {
+			return exhausted
+		}
+	}
+	return nil
+}
+
+// wildShape returns the character's active Wild Shape, or nil if they're in their normal form
+func (c *Character) wildShape() *conditions.WildShapedCondition {
+	for _, condition := range c.conditions {
+		if shaped, ok := condition.(*conditions.WildShapedCondition); ok && shaped.IsApplied() {
+			return shaped
+		}
+	}
+	return nil
+}
+
 // ApplyDamage reduces the character's HP by the damage amount(s).
 // HP cannot go below 0. Returns the result of the damage application.
 //
+// While wild shaped, the beast form's hit points absorb the damage first. If the
+// form drops to 0 the character reverts and the excess damage carries over to
+// their normal hit points.
+//
 // This method directly mutates the character's HP. The caller is responsible
 // for persisting the updated character state.
 //
 // Implements combat.Combatant interface.
-//
-//nolint:revive // ctx is unused but kept for interface consistency and future use
-func (c *Character) ApplyDamage(_ context.Context, input *combat.ApplyDamageInput) *combat.ApplyDamageResult {
+func (c *Character) ApplyDamage(ctx context.Context, input *combat.ApplyDamageInput) *combat.ApplyDamageResult {
 	if input == nil {
 		return &combat.ApplyDamageResult{
-			CurrentHP:  c.hitPoints,
-			PreviousHP: c.hitPoints,
+			CurrentHP:  c.GetHitPoints(),
+			PreviousHP: c.GetHitPoints(),
 		}
 	}
 
-	previousHP := c.hitPoints
+	previousHP := c.GetHitPoints()
+	previousNormalHP := c.hitPoints
 	totalDamage := 0
 
 	// Sum all damage instances
@@ -721,8 +824,19 @@ func (c *Character) ApplyDamage(_ context.Context, input *combat.ApplyDamageInpu
 		totalDamage += instance.Amount
 	}
 
+	// A beast form takes the damage first; only the carryover reaches the character
+	remaining := totalDamage
+	if shaped := c.wildShape(); shaped != nil {
+		carryover, err := shaped.AbsorbDamage(ctx, totalDamage)
+		if err != nil {
+			// The form is at 0 even if its removal couldn't be published; revert anyway
+			_ = shaped.Remove(ctx, c.bus)
+		}
+		remaining = carryover
+	}
+
 	// Apply damage (minimum HP is 0)
-	c.hitPoints -= totalDamage
+	c.hitPoints -= remaining
 	if c.hitPoints < 0 {
 		c.hitPoints = 0
 	}
@@ -731,15 +845,18 @@ func (c *Character) ApplyDamage(_ context.Context, input *combat.ApplyDamageInpu
 
 	return &combat.ApplyDamageResult{
 		TotalDamage:   totalDamage,
-		CurrentHP:     c.hitPoints,
-		DroppedToZero: c.hitPoints == 0 && previousHP > 0,
+		CurrentHP:     c.GetHitPoints(),
+		DroppedToZero: c.hitPoints == 0 && previousNormalHP > 0,
 		PreviousHP:    previousHP,
 	}
 }
 
-// AC returns the character's armor class.
+// AC returns the character's armor class, or their beast form's while wild shaped.
 // Implements combat.Combatant interface.
 func (c *Character) AC() int {
+	if shaped := c.wildShape(); shaped != nil {
+		return shaped.ArmorClass()
+	}
 	return c.armorClass
 }
 
@@ -955,6 +1072,12 @@ func (c *Character) ToData() *Data {
 	// Copy spell slots map directly since SpellSlotData is already the data type
 	data.SpellSlots = maps.Clone(c.spellSlots)
 
+	// Spell attack bonus and save DC are derived for character sheets
+	if stats, err := c.SpellcastingStats(context.Background()); err == nil && stats != nil {
+		data.SpellAttackBonus = stats.AttackBonus()
+		data.SpellSaveDC = stats.SaveDC()
+	}
+
 	// Copy class resources map directly since ResourceData is already the data type
 	data.ClassResources = maps.Clone(c.classResources)
 
@@ -1031,6 +1154,14 @@ func (c *Character) subscribeToEvents(ctx context.Context) error {
 	}
 	c.subscriptionIDs = append(c.subscriptionIDs, subID)
 
+	// Subscribe to exhaustion changes (hit point maximum may drop)
+	exhaustionTopic := dnd5eEvents.ExhaustionChangedTopic.On(c.bus)
+	subID, err = exhaustionTopic.Subscribe(ctx, c.onExhaustionChanged)
+	if err != nil {
+		return rpgerr.Wrapf(err, "failed to subscribe to exhaustion changed")
+	}
+	c.subscriptionIDs = append(c.subscriptionIDs, subID)
+
 	// Subscribe to healing received events
 	healingTopic := dnd5eEvents.HealingReceivedTopic.On(c.bus)
 	subID, err = healingTopic.Subscribe(ctx, c.onHealingReceived)
@@ -1065,6 +1196,13 @@ func (c *Character) onConditionApplied(ctx context.Context, event dnd5eEvents.Co
 		return nil
 	}
 
+	// Exhaustion stacks: further exhaustion raises the existing level
+	if incoming, ok := event.Condition.(*conditions.ExhaustionCondition); ok {
+		if existing := c.exhaustion(); existing != nil {
+			return existing.AddLevels(ctx, incoming.Level, string(event.Source))
+		}
+	}
+
 	// Apply the condition (subscribes to events)
 	if err := event.Condition.Apply(ctx, c.bus); err != nil {
 		// Clean up any partial subscriptions to avoid resource leaks
@@ -1074,6 +1212,7 @@ func (c *Character) onConditionApplied(ctx context.Context, event dnd5eEvents.Co
 
 	// Store the condition
 	c.conditions = append(c.conditions, event.Condition)
+	c.capHitPoints()
 
 	return nil
 }
@@ -1112,6 +1251,27 @@ func (c *Character) onConditionRemoved(_ context.Context, event dnd5eEvents.Cond
 	return nil
 }
 
+// onExhaustionChanged handles ExhaustionChangedEvent
+func (c *Character) onExhaustionChanged(_ context.Context, event dnd5eEvents.ExhaustionChangedEvent) error {
+	// Only process events for this character
+	if event.CharacterID != c.id {
+		return nil
+	}
+
+	c.capHitPoints()
+	return nil
+}
+
+// capHitPoints lowers hit points to a reduced maximum (exhaustion level 4)
+func (c *Character) capHitPoints() {
+	if c.wildShape() != nil {
+		return
+	}
+	if maxHP := c.GetMaxHitPoints(); c.hitPoints > maxHP {
+		c.hitPoints = maxHP
+	}
+}
+
 // onHealingReceived handles HealingReceivedEvent
 func (c *Character) onHealingReceived(_ context.Context, event dnd5eEvents.HealingReceivedEvent) error {
 	// Only process events for this character
@@ -1119,10 +1279,16 @@ func (c *Character) onHealingReceived(_ context.Context, event dnd5eEvents.Heali
 		return nil
 	}
 
-	// Apply healing: add Amount to hitPoints, cap at maxHitPoints
+	// A beast form heals in place of the character's normal form
+	if shaped := c.wildShape(); shaped != nil {
+		shaped.Heal(event.Amount)
+		return nil
+	}
+
+	// Apply healing: add Amount to hitPoints, cap at maximum hit points
 	c.hitPoints += event.Amount
-	if c.hitPoints > c.maxHitPoints {
-		c.hitPoints = c.maxHitPoints
+	if maxHP := c.GetMaxHitPoints(); c.hitPoints > maxHP {
+		c.hitPoints = maxHP
 	}
 
 	return nil
@@ -1264,7 +1430,15 @@ func (c *Character) EffectiveAC(ctx context.Context) *combat.ACBreakdown {
 	shieldItem := equippedShield.AsArmor()
 
 	// Calculate base AC
-	if armorItem != nil {
+	if shaped := c.wildShape(); shaped != nil {
+		// A beast form's AC replaces armor, shield and DEX; worn gear merges into the form
+		breakdown.AddComponent(combat.ACComponent{
+			Type:   combat.ACSourceBase,
+			Source: refs.Conditions.WildShaped(),
+			Value:  shaped.ArmorClass(),
+		})
+		armorItem, shieldItem = nil, nil
+	} else if armorItem != nil {
 		// Wearing armor: use armor's AC
 		breakdown.AddComponent(calculateArmorAC(armorItem))
 
@@ -0,0 +1,445 @@
+package choices
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/backgrounds"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/classes"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/equipment"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/fightingstyles"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/languages"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/races"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/spells"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/terrains"
+)
+
+// CatalogInput selects what a Catalog covers
+type CatalogInput struct {
+	ClassID    classes.Class          // Required
+	Subclass   classes.Subclass       // Optional: applies the subclass's extra choices
+	RaceID     races.Race             // Optional: a race or subrace
+	Background backgrounds.Background // Optional
+	Level      int                    // Class level (default 1)
+}
+
+// Catalog is every choice a character builder presents for a class, race and
+// background at a level, with display names for each option, so UIs can be
+// generated from toolkit data instead of hardcoding options. Submit a choice
+// as a Submission with the choice's ID, Source and Category.
+type Catalog struct {
+	ClassID    classes.Class          `json:"class_id"`
+	Subclass   classes.Subclass       `json:"subclass,omitempty"`
+	RaceID     races.Race             `json:"race_id,omitempty"`
+	Background backgrounds.Background `json:"background,omitempty"`
+	Level      int                    `json:"level"`
+	Choices    []CatalogChoice        `json:"choices"`
+}
+
+// CatalogChoice is one choice the player makes
+type CatalogChoice struct {
+	ID       ChoiceID              `json:"id"`
+	Source   shared.ChoiceSource   `json:"source"`
+	Category shared.ChoiceCategory `json:"category"`
+	Label    string                `json:"label"`
+	Count    int                   `json:"count"` // How many options to choose
+
+	// Options to choose from. Empty when they depend on the character's other
+	// choices (expertise picks from proficient skills).
+	Options []CatalogOption `json:"options,omitempty"`
+}
+
+// CatalogOption is one option of a choice
+type CatalogOption struct {
+	ID          OptionID `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+
+	// Category to submit the option under, when it differs from the choice's
+	// (tools or languages chosen from one pool)
+	Category shared.ChoiceCategory `json:"category,omitempty"`
+
+	// Items an equipment bundle grants
+	Items []EquipmentItem `json:"items,omitempty"`
+
+	// Selections the option requires, e.g. "choose a martial weapon" within
+	// an equipment bundle, or the abilities an Ability Score Improvement raises
+	Selections []CatalogSelection `json:"selections,omitempty"`
+}
+
+// CatalogSelection is a nested pick within an option
+type CatalogSelection struct {
+	Label   string          `json:"label"`
+	Count   int             `json:"count"`
+	Options []CatalogOption `json:"options,omitempty"` // Empty for free-form picks (feats)
+}
+
+// BuildCatalog renders the requirements for a class (and optionally subclass,
+// race and background) at a level into a serializable catalog. Class choices
+// are cumulative from level 1, as in GetClassRequirementsAtLevel.
+//
+// Returns CodeNotFound for an unknown class, race or background.
+func BuildCatalog(input *CatalogInput) (*Catalog, error) {
+	if input == nil {
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "input is required")
+	}
+	if classes.GetData(input.ClassID) == nil {
+		return nil, rpgerr.New(rpgerr.CodeNotFound, "unknown class",
+			rpgerr.WithMeta("class", input.ClassID))
+	}
+	if _, ok := races.AllIncludingSubraces[string(input.RaceID)]; input.RaceID != "" && !ok {
+		return nil, rpgerr.New(rpgerr.CodeNotFound, "unknown race",
+			rpgerr.WithMeta("race", input.RaceID))
+	}
+	if input.Background != "" && backgrounds.GetData(input.Background) == nil {
+		return nil, rpgerr.New(rpgerr.CodeNotFound, "unknown background",
+			rpgerr.WithMeta("background", input.Background))
+	}
+
+	level := input.Level
+	if level <= 0 {
+		level = 1
+	}
+
+	catalog := &Catalog{
+		ClassID:    input.ClassID,
+		Subclass:   input.Subclass,
+		RaceID:     input.RaceID,
+		Background: input.Background,
+		Level:      level,
+		Choices:    []CatalogChoice{},
+	}
+
+	classReqs := GetClassRequirementsAtLevel(input.ClassID, level)
+	if input.Subclass != "" {
+		classReqs = GetClassRequirementsWithSubclass(input.ClassID, level, input.Subclass)
+	}
+	catalog.add(shared.SourceClass, classReqs)
+
+	if input.RaceID != "" {
+		catalog.add(shared.SourceRace, GetRaceRequirements(input.RaceID))
+	}
+	if input.Background != "" {
+		catalog.add(shared.SourceBackground, GetBackgroundRequirements(input.Background))
+	}
+
+	return catalog, nil
+}
+
+// add appends the choices of reqs in Requirements field order
+func (c *Catalog) add(source shared.ChoiceSource, reqs *Requirements) {
+	if reqs == nil {
+		return
+	}
+
+	choice := func(id ChoiceID, category shared.ChoiceCategory, label string, count int, options []CatalogOption) {
+		c.Choices = append(c.Choices, CatalogChoice{
+			ID:       id,
+			Source:   source,
+			Category: category,
+			Label:    label,
+			Count:    count,
+			Options:  options,
+		})
+	}
+
+	for _, req := range append([]*SkillRequirement{reqs.Skills}, reqs.AdditionalSkills...) {
+		if req != nil {
+			choice(req.ID, shared.ChoiceSkills, req.Label, req.Count, skillOptions(req.Options))
+		}
+	}
+
+	for _, req := range reqs.Equipment {
+		options := make([]CatalogOption, len(req.Options))
+		for i, opt := range req.Options {
+			options[i] = CatalogOption{ID: opt.ID, Name: opt.Label, Items: opt.Items}
+			for _, category := range opt.CategoryChoices {
+				options[i].Selections = append(options[i].Selections, CatalogSelection{
+					Label:   category.Label,
+					Count:   category.Choose,
+					Options: equipmentCategoryOptions(category.Type, category.Categories),
+				})
+			}
+		}
+		choice(req.ID, shared.ChoiceEquipment, req.Label, req.Choose, options)
+	}
+
+	for _, req := range reqs.EquipmentCategories {
+		choice(req.ID, shared.ChoiceEquipment, req.Label, req.Choose, equipmentCategoryOptions(req.Type, req.Categories))
+	}
+
+	for _, req := range reqs.Languages {
+		choice(req.ID, shared.ChoiceLanguages, req.Label, req.Count, languageOptions(req.Options))
+	}
+
+	if req := reqs.Tools; req != nil {
+		choice(req.ID, shared.ChoiceToolProficiency, req.Label, req.Count, toolOptions(req.Options))
+	}
+
+	if req := reqs.ToolsOrLanguages; req != nil {
+		tools := make([]shared.SelectionID, len(req.ToolOptions))
+		for i, tool := range req.ToolOptions {
+			tools[i] = shared.SelectionID(tool)
+		}
+		options := toolOptions(tools)
+		for i := range options {
+			options[i].Category = shared.ChoiceToolProficiency
+		}
+		for _, option := range languageOptions(req.LanguageOptions) {
+			option.Category = shared.ChoiceLanguages
+			options = append(options, option)
+		}
+		choice(req.ID, shared.ChoiceToolProficiency, req.Label, req.Count, options)
+	}
+
+	if req := reqs.FightingStyle; req != nil {
+		options := make([]CatalogOption, len(req.Options))
+		for i, style := range req.Options {
+			options[i] = CatalogOption{ID: style, Name: fightingstyles.Name(style), Description: fightingstyles.Description(style)}
+		}
+		choice(req.ID, shared.ChoiceFightingStyle, req.Label, 1, options)
+	}
+
+	for _, req := range append([]*ExpertiseRequirement{reqs.Expertise}, reqs.AdditionalExpertise...) {
+		if req != nil {
+			choice(req.ID, shared.ChoiceExpertise, req.Label, req.Count, nil)
+		}
+	}
+
+	for _, req := range reqs.AbilityScoreImprovements {
+		choice(req.ID, shared.ChoiceAbilityScores, req.Label, 1, abilityScoreImprovementOptions(req.Points))
+	}
+
+	if req := reqs.FavoredEnemy; req != nil {
+		options := make([]CatalogOption, len(req.Options))
+		for i, creatureType := range req.Options {
+			options[i] = CatalogOption{ID: string(creatureType), Name: displayName(string(creatureType))}
+		}
+		choice(req.ID, shared.ChoiceFavoredEnemy, req.Label, req.Count, options)
+	}
+
+	if req := reqs.FavoredTerrain; req != nil {
+		options := make([]CatalogOption, len(req.Options))
+		for i, terrain := range req.Options {
+			options[i] = CatalogOption{ID: terrain, Name: terrains.Name(terrain)}
+		}
+		choice(req.ID, shared.ChoiceFavoredTerrain, req.Label, req.Count, options)
+	}
+
+	if req := reqs.Traits; req != nil {
+		choice(req.ID, shared.ChoiceTraits, req.Label, req.Count, traitOptions(req.Options))
+	}
+
+	if req := reqs.AbilityIncreases; req != nil {
+		choice(req.ID, shared.ChoiceAbilityScores, req.Label, req.Count, abilityOptions(req.Options))
+	}
+
+	if req := reqs.Feats; req != nil {
+		choice(req.ID, shared.ChoiceFeats, req.Label, req.Count, nil)
+	}
+
+	if req := reqs.VariableTrait; req != nil {
+		choice(req.ID, shared.ChoiceTraits, req.Label, 1, variableTraitOptions())
+	}
+
+	if req := reqs.Subclass; req != nil {
+		options := make([]CatalogOption, len(req.Options))
+		for i, subclass := range req.Options {
+			options[i] = CatalogOption{
+				ID:          subclass,
+				Name:        classes.SubClassName(subclass),
+				Description: classes.SubClassDescription(subclass),
+			}
+		}
+		choice(req.ID, shared.ChoiceClass, req.Label, 1, options)
+	}
+
+	for _, req := range append([]*CantripRequirement{reqs.Cantrips}, reqs.AdditionalCantrips...) {
+		if req != nil {
+			choice(req.ID, shared.ChoiceCantrips, req.Label, req.Count, spellOptions(req.Class, req.Subclass, req.Options, 0))
+		}
+	}
+
+	for _, req := range append([]*SpellbookRequirement{reqs.Spellbook}, reqs.AdditionalSpells...) {
+		if req != nil {
+			choice(req.ID, shared.ChoiceSpells, req.Label, req.Count, spellOptions(req.Class, req.Subclass, req.Options, req.SpellLevel))
+		}
+	}
+}
+
+// skillOptions lists skills, or every skill when the requirement allows any
+func skillOptions(allowed []skills.Skill) []CatalogOption {
+	if len(allowed) == 0 {
+		allowed = skills.List()
+	}
+	options := make([]CatalogOption, len(allowed))
+	for i, skill := range allowed {
+		options[i] = CatalogOption{ID: skill, Name: skills.Display(skill), Description: skills.Ability(skill).Display()}
+	}
+	return options
+}
+
+// languageOptions lists languages, or every language when the requirement allows any
+func languageOptions(allowed []languages.Language) []CatalogOption {
+	if len(allowed) == 0 {
+		allowed = append(languages.StandardLanguages(), languages.ExoticLanguages()...)
+	}
+	options := make([]CatalogOption, len(allowed))
+	for i, language := range allowed {
+		options[i] = CatalogOption{ID: language, Name: languages.Display(language)}
+	}
+	return options
+}
+
+// toolOptions lists tools, named after their equipment when there is one
+func toolOptions(allowed []shared.SelectionID) []CatalogOption {
+	options := make([]CatalogOption, len(allowed))
+	for i, tool := range allowed {
+		options[i] = equipmentOption(tool)
+	}
+	return options
+}
+
+// equipmentOption describes an equipment ID
+func equipmentOption(id shared.SelectionID) CatalogOption {
+	item, err := equipment.GetByID(id)
+	if err != nil {
+		return CatalogOption{ID: id, Name: displayName(id)}
+	}
+	return CatalogOption{ID: id, Name: item.EquipmentName(), Description: item.EquipmentDescription()}
+}
+
+// equipmentCategoryOptions lists the equipment in categories
+func equipmentCategoryOptions(equipType shared.EquipmentType, categories []shared.EquipmentCategory) []CatalogOption {
+	items, err := ExpandEquipmentCategories(equipType, categories)
+	if err != nil {
+		return nil
+	}
+	options := make([]CatalogOption, len(items))
+	for i, item := range items {
+		options[i] = CatalogOption{
+			ID:          item.EquipmentID(),
+			Name:        item.EquipmentName(),
+			Description: item.EquipmentDescription(),
+		}
+	}
+	return options
+}
+
+// abilityOptions lists abilities, or every ability when the requirement allows any
+func abilityOptions(allowed []abilities.Ability) []CatalogOption {
+	if len(allowed) == 0 {
+		allowed = abilities.List()
+	}
+	options := make([]CatalogOption, len(allowed))
+	for i, ability := range allowed {
+		options[i] = CatalogOption{ID: string(ability), Name: ability.Display()}
+	}
+	return options
+}
+
+// abilityScoreImprovementOptions offers ability increases or a feat
+func abilityScoreImprovementOptions(points int) []CatalogOption {
+	return []CatalogOption{
+		{
+			ID:   AbilityScoreImprovementIncrease,
+			Name: "Ability Score Increase",
+			Selections: []CatalogSelection{{
+				Label:   fmt.Sprintf("Choose %d ability increases (the same ability twice for +2)", points),
+				Count:   points,
+				Options: abilityOptions(nil),
+			}},
+		},
+		{
+			ID:         AbilityScoreImprovementFeat,
+			Name:       "Feat",
+			Selections: []CatalogSelection{{Label: "Choose a feat", Count: 1}},
+		},
+	}
+}
+
+// variableTraitOptions offers custom lineage's darkvision or a skill proficiency
+func variableTraitOptions() []CatalogOption {
+	return []CatalogOption{
+		{
+			ID:          CustomLineageDarkvision,
+			Name:        "Darkvision",
+			Description: "You can see in dim light within 60 feet as if it were bright light",
+		},
+		{
+			ID:         CustomLineageSkillProficiency,
+			Name:       "Skill Proficiency",
+			Selections: []CatalogSelection{{Label: "Choose a skill", Count: 1, Options: skillOptions(nil)}},
+		},
+	}
+}
+
+// traitOptions describes racial trait options such as draconic ancestries
+func traitOptions(allowed []shared.SelectionID) []CatalogOption {
+	options := make([]CatalogOption, len(allowed))
+	for i, trait := range allowed {
+		options[i] = CatalogOption{ID: trait, Name: displayName(trait)}
+		if ancestry := races.GetDraconicAncestry(races.DraconicAncestry(trait)); ancestry != nil {
+			shape := "15 ft. cone"
+			if ancestry.Line {
+				shape = "5 by 30 ft. line"
+			}
+			options[i].Description = fmt.Sprintf("%s breath weapon (%s, %s save)",
+				displayName(string(ancestry.DamageType)), shape, ancestry.SaveAbility.Abbreviation())
+		}
+	}
+	return options
+}
+
+// spellOptions describes spells. With no allowed spells, any spell of 1st
+// level up to maxLevel on the class's spell list is accepted, so all of them
+// are listed; with no class either, every spell of those levels is.
+func spellOptions(
+	classID classes.Class, subclass classes.Subclass, allowed []spells.Spell, maxLevel int,
+) []CatalogOption {
+	if len(allowed) == 0 {
+		for level := 1; level <= maxLevel; level++ {
+			if classID == "" {
+				for _, data := range spells.GetSpellsByLevel(level) {
+					allowed = append(allowed, data.ID)
+				}
+				continue
+			}
+			allowed = append(allowed, spells.ClassSpells(classID, level)...)
+		}
+		if classID == classes.Warlock {
+			allowed = append(allowed, spells.PatronSpells(subclass, maxLevel)...)
+		}
+		sort.Strings(allowed)
+		allowed = slices.Compact(allowed)
+	}
+	options := make([]CatalogOption, len(allowed))
+	for i, spell := range allowed {
+		options[i] = CatalogOption{ID: spell, Name: spellDisplayName(spell), Description: spells.Description(spell)}
+	}
+	return options
+}
+
+// spellDisplayName returns a spell's name, or one made from its ID for spells
+// without a name
+func spellDisplayName(spell spells.Spell) string {
+	if name := spells.Name(spell); name != "" {
+		return name
+	}
+	return displayName(spell)
+}
+
+// displayName turns an identifier like "smiths-tools" into "Smiths Tools"
+func displayName(id string) string {
+	words := strings.FieldsFunc(id, func(r rune) bool { return r == '_' || r == '-' })
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,169 @@
+package choices_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/backgrounds"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/character/choices"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/classes"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/fightingstyles"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/languages"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/races"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/weapons"
+)
+
+// CatalogTestSuite tests exporting requirements as a UI catalog
+type CatalogTestSuite struct {
+	suite.Suite
+}
+
+func TestCatalogSuite(t *testing.T) {
+	suite.Run(t, new(CatalogTestSuite))
+}
+
+// findChoice returns the catalog choice with an ID
+func (s *CatalogTestSuite) findChoice(catalog *choices.Catalog, id choices.ChoiceID) choices.CatalogChoice {
+	for _, choice := range catalog.Choices {
+		if choice.ID == id {
+			return choice
+		}
+	}
+	s.FailNow("choice not in catalog", id)
+	return choices.CatalogChoice{}
+}
+
+// optionIDs returns the IDs of options
+func optionIDs(options []choices.CatalogOption) []choices.OptionID {
+	ids := make([]choices.OptionID, len(options))
+	for i, option := range options {
+		ids[i] = option.ID
+	}
+	return ids
+}
+
+func (s *CatalogTestSuite) TestFighterCatalog() {
+	catalog, err := choices.BuildCatalog(&choices.CatalogInput{
+		ClassID:    classes.Fighter,
+		RaceID:     races.HalfElf,
+		Background: backgrounds.Soldier,
+	})
+	s.Require().NoError(err)
+	s.Equal(1, catalog.Level)
+
+	skillChoice := s.findChoice(catalog, choices.FighterSkills)
+	s.Equal(shared.SourceClass, skillChoice.Source)
+	s.Equal(shared.ChoiceSkills, skillChoice.Category)
+	s.Equal(2, skillChoice.Count)
+	s.Contains(optionIDs(skillChoice.Options), skills.Athletics)
+
+	style := s.findChoice(catalog, choices.FighterFightingStyle)
+	s.Equal(shared.ChoiceFightingStyle, style.Category)
+	for _, option := range style.Options {
+		s.Equal(fightingstyles.Name(option.ID), option.Name)
+		s.NotEmpty(option.Description)
+	}
+
+	weaponChoice := s.findChoice(catalog, choices.FighterWeaponsPrimary)
+	s.Equal(shared.ChoiceEquipment, weaponChoice.Category)
+	var martialShield *choices.CatalogOption
+	for i := range weaponChoice.Options {
+		if weaponChoice.Options[i].ID == choices.FighterWeaponMartialShield {
+			martialShield = &weaponChoice.Options[i]
+		}
+	}
+	s.Require().NotNil(martialShield)
+	s.Require().NotEmpty(martialShield.Selections, "category pick within the bundle")
+	s.Contains(optionIDs(martialShield.Selections[0].Options), weapons.Longsword)
+
+	raceSkills := s.findChoice(catalog, choices.HalfElfSkills)
+	s.Equal(shared.SourceRace, raceSkills.Source)
+	s.Len(raceSkills.Options, len(skills.List()), "any skill is listed")
+}
+
+func (s *CatalogTestSuite) TestLevelAndSubclass() {
+	catalog, err := choices.BuildCatalog(&choices.CatalogInput{ClassID: classes.Bard, Level: 4})
+	s.Require().NoError(err)
+
+	college := s.findChoice(catalog, choices.BardCollege)
+	s.Equal(shared.ChoiceClass, college.Category)
+	for _, option := range college.Options {
+		s.NotEmpty(option.Name)
+	}
+
+	asi := s.findChoice(catalog, "bard-asi-4")
+	s.Equal(shared.ChoiceAbilityScores, asi.Category)
+	s.Equal([]choices.OptionID{choices.AbilityScoreImprovementIncrease, choices.AbilityScoreImprovementFeat},
+		optionIDs(asi.Options))
+	s.Equal(2, asi.Options[0].Selections[0].Count)
+	s.Len(asi.Options[0].Selections[0].Options, 6)
+
+	expertise := s.findChoice(catalog, choices.BardExpertise3)
+	s.Empty(expertise.Options, "picked from proficient skills")
+
+	spells3 := s.findChoice(catalog, "bard-spells-3")
+	s.NotEmpty(spells3.Options, "any spell up to 2nd level is listed")
+	s.findChoice(catalog, "bard-cantrips-4")
+}
+
+func (s *CatalogTestSuite) TestCustomBackgroundMixesToolsAndLanguages() {
+	catalog, err := choices.BuildCatalog(&choices.CatalogInput{ClassID: classes.Rogue, Background: backgrounds.Custom})
+	s.Require().NoError(err)
+
+	mixed := s.findChoice(catalog, choices.CustomBackgroundProficiencies)
+	s.Equal(shared.SourceBackground, mixed.Source)
+	categories := map[shared.ChoiceCategory]bool{}
+	for _, option := range mixed.Options {
+		categories[option.Category] = true
+		s.NotEmpty(option.Name)
+	}
+	s.True(categories[shared.ChoiceToolProficiency])
+	s.True(categories[shared.ChoiceLanguages])
+	s.Contains(optionIDs(mixed.Options), languages.Elvish)
+}
+
+func (s *CatalogTestSuite) TestDragonbornAncestryDescribed() {
+	catalog, err := choices.BuildCatalog(&choices.CatalogInput{ClassID: classes.Sorcerer, RaceID: races.Dragonborn})
+	s.Require().NoError(err)
+
+	ancestry := s.findChoice(catalog, choices.DragonbornAncestry)
+	s.Require().NotEmpty(ancestry.Options)
+	s.Equal("Black", ancestry.Options[0].Name)
+	s.Equal("Acid breath weapon (5 by 30 ft. line, DEX save)", ancestry.Options[0].Description)
+}
+
+func (s *CatalogTestSuite) TestSerializes() {
+	catalog, err := choices.BuildCatalog(&choices.CatalogInput{ClassID: classes.Wizard, RaceID: races.HighElf})
+	s.Require().NoError(err)
+
+	data, err := json.Marshal(catalog)
+	s.Require().NoError(err)
+	var decoded choices.Catalog
+	s.Require().NoError(json.Unmarshal(data, &decoded))
+	s.Equal(catalog.Choices, decoded.Choices)
+}
+
+func (s *CatalogTestSuite) TestUnknownContent() {
+	testCases := []struct {
+		name  string
+		input *choices.CatalogInput
+		code  rpgerr.Code
+	}{
+		{"nil input", nil, rpgerr.CodeInvalidArgument},
+		{"unknown class", &choices.CatalogInput{ClassID: "pastry-chef"}, rpgerr.CodeNotFound},
+		{"unknown race", &choices.CatalogInput{ClassID: classes.Fighter, RaceID: "gnoll"}, rpgerr.CodeNotFound},
+		{"unknown background", &choices.CatalogInput{ClassID: classes.Fighter, Background: "chef"}, rpgerr.CodeNotFound},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			_, err := choices.BuildCatalog(tc.input)
+			s.Equal(tc.code, rpgerr.GetCode(err))
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package choices
 
-import "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+import (
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/classes"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+)
 
 // ChoiceID represents a unique identifier for a choice requirement
 type ChoiceID string
@@ -39,6 +44,12 @@ const (
 	BardExpertise10 ChoiceID = "bard-expertise-10" // Level 10
 )
 
+// Ranger choice IDs
+const (
+	RangerFavoredEnemy   ChoiceID = "ranger-favored-enemy"   // Level 1
+	RangerFavoredTerrain ChoiceID = "ranger-favored-terrain" // Level 1
+)
+
 // Fighter equipment choice IDs
 const (
 	FighterArmor            ChoiceID = "fighter-armor"
@@ -130,7 +141,8 @@ const (
 
 // Race skill choice IDs
 const (
-	HalfElfSkills ChoiceID = "half-elf-skills"
+	HalfElfSkills     ChoiceID = "half-elf-skills"
+	VariantHumanSkill ChoiceID = "variant-human-skill"
 )
 
 // Race language choice IDs
@@ -138,6 +150,9 @@ const (
 	HumanLanguage   ChoiceID = "human-language"
 	HalfElfLanguage ChoiceID = "half-elf-language"
 	HighElfLanguage ChoiceID = "high-elf-language"
+
+	VariantHumanLanguage  ChoiceID = "variant-human-language"
+	CustomLineageLanguage ChoiceID = "custom-lineage-language"
 )
 
 // Race cantrip choice IDs
@@ -154,6 +169,39 @@ const (
 	DwarfToolProficiency ChoiceID = "dwarf-tool-proficiency"
 )
 
+// Racial trait choice IDs
+const (
+	DragonbornAncestry         ChoiceID = "dragonborn-ancestry"
+	CustomLineageSize          ChoiceID = "custom-lineage-size"
+	CustomLineageVariableTrait ChoiceID = "custom-lineage-variable-trait"
+)
+
+// Race ability score increase choice IDs
+const (
+	VariantHumanAbilities ChoiceID = "variant-human-abilities" // +1 to two abilities
+	CustomLineageAbility  ChoiceID = "custom-lineage-ability"  // +2 to one ability
+)
+
+// Race feat choice IDs
+const (
+	VariantHumanFeat  ChoiceID = "variant-human-feat"
+	CustomLineageFeat ChoiceID = "custom-lineage-feat"
+)
+
+// Custom lineage size option IDs
+const (
+	CustomLineageSmall  OptionID = "small"
+	CustomLineageMedium OptionID = "medium"
+)
+
+// Custom lineage variable trait option IDs
+const (
+	// CustomLineageDarkvision grants darkvision out to 60 feet
+	CustomLineageDarkvision OptionID = "darkvision"
+	// CustomLineageSkillProficiency grants proficiency in one skill
+	CustomLineageSkillProficiency OptionID = "skill-proficiency"
+)
+
 // Subclass choice IDs
 const (
 	FighterArchetype ChoiceID = "fighter-archetype" // Level 3
@@ -182,6 +230,34 @@ const (
 	SorcererSpells1   ChoiceID = "sorcerer-spells-1"
 	WarlockCantrips1  ChoiceID = "warlock-cantrips-1"
 	WarlockSpells1    ChoiceID = "warlock-spells-1"
+	RangerSpells2     ChoiceID = "ranger-spells-2"
+)
+
+// Level-up choice IDs follow the "<class>-<choice>-<level>" form of the
+// constants above, e.g. SpellsChoiceID(classes.Bard, 1) is BardSpells1.
+
+// SpellsChoiceID returns the ID of the spells a class learns at a level
+func SpellsChoiceID(classID classes.Class, level int) ChoiceID {
+	return ChoiceID(fmt.Sprintf("%s-spells-%d", classID, level))
+}
+
+// CantripsChoiceID returns the ID of the cantrips a class learns at a level
+func CantripsChoiceID(classID classes.Class, level int) ChoiceID {
+	return ChoiceID(fmt.Sprintf("%s-cantrips-%d", classID, level))
+}
+
+// AbilityScoreImprovementChoiceID returns the ID of a class's Ability Score
+// Improvement at a level, e.g. "fighter-asi-4"
+func AbilityScoreImprovementChoiceID(classID classes.Class, level int) ChoiceID {
+	return ChoiceID(fmt.Sprintf("%s-asi-%d", classID, level))
+}
+
+// Ability Score Improvement option IDs
+const (
+	// AbilityScoreImprovementIncrease raises ability scores: two +1s, or +2 to one
+	AbilityScoreImprovementIncrease OptionID = "ability-score-increase"
+	// AbilityScoreImprovementFeat takes a feat instead
+	AbilityScoreImprovementFeat OptionID = "feat"
 )
 
 // BackgroundData choice IDs
@@ -198,6 +274,12 @@ const (
 	SoldierTools         ChoiceID = "soldier-tools"
 )
 
+// Customized background choice IDs
+const (
+	CustomBackgroundSkills        ChoiceID = "custom-background-skills"
+	CustomBackgroundProficiencies ChoiceID = "custom-background-proficiencies" // Tools or languages
+)
+
 // Equipment option IDs - Fighter
 const (
 	FighterArmorChainMail      OptionID = "fighter-armor-a"
@@ -12,9 +12,11 @@ import (
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/classes"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/fightingstyles"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/languages"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/spells"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/terrains"
 )
 
 // ClassTestData holds all test data for a specific class
@@ -1507,17 +1509,25 @@ func (s *ClassComprehensiveSuite) createRangerValidBase() *choices.Submissions {
 		},
 	})
 
-	// TODO(#306): Rangers should NOT have fighting style at level 1 (comes at level 2)
-	// But the requirements currently expect it, so we add it to make the test pass
+	// Favored enemy and terrain
 	subs.Add(choices.Submission{
-		Category: shared.ChoiceFightingStyle,
+		Category: shared.ChoiceFavoredEnemy,
 		Source:   shared.SourceClass,
-		ChoiceID: choices.RangerFightingStyle,
+		ChoiceID: choices.RangerFavoredEnemy,
 		Values: []shared.SelectionID{
-			fightingstyles.Archery,
+			shared.SelectionID(monster.CreatureTypeUndead),
+		},
+	})
+	subs.Add(choices.Submission{
+		Category: shared.ChoiceFavoredTerrain,
+		Source:   shared.SourceClass,
+		ChoiceID: choices.RangerFavoredTerrain,
+		Values: []shared.SelectionID{
+			terrains.Forest,
 		},
 	})
 
+	// No fighting style at level 1 (comes at level 2)
 	// No subclass at level 1 (Archetype comes at level 3)
 	// No spells at level 1 (spellcasting starts at level 2)
 
@@ -0,0 +1,151 @@
+package choices
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/equipment"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/items"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/packs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/tools"
+)
+
+// Equipment category placeholders for instruments and spellcasting focuses.
+// Weapon and armor choices use the weapons and armor category constants; tool
+// choices may also use a tools.ToolCategory (e.g., "artisan").
+const (
+	// EquipmentCategoryMusicalInstruments is "any musical instrument"
+	EquipmentCategoryMusicalInstruments shared.EquipmentCategory = "musical-instruments"
+
+	// EquipmentCategoryDruidicFoci is "a druidic focus"
+	EquipmentCategoryDruidicFoci shared.EquipmentCategory = "druidic-foci"
+
+	// EquipmentCategoryArcaneFoci is "an arcane focus"
+	EquipmentCategoryArcaneFoci shared.EquipmentCategory = "arcane-foci"
+
+	// EquipmentCategoryHolySymbols is "a holy symbol"
+	EquipmentCategoryHolySymbols shared.EquipmentCategory = "holy-symbols"
+)
+
+// ExpandEquipmentCategories returns the concrete equipment a category
+// placeholder such as "any martial weapon" stands for, sorted by ID. It is the
+// one source of truth for both validating category picks and listing them in
+// a builder's drill-down.
+func ExpandEquipmentCategories(
+	equipType shared.EquipmentType,
+	categories []shared.EquipmentCategory,
+) ([]equipment.Equipment, error) {
+	if len(categories) == 0 {
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "no categories specified")
+	}
+
+	var result []equipment.Equipment
+	for _, category := range categories {
+		expanded, err := expandEquipmentCategory(equipType, category)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, expanded...)
+	}
+
+	slices.SortFunc(result, func(a, b equipment.Equipment) int {
+		return strings.Compare(a.EquipmentID(), b.EquipmentID())
+	})
+	return slices.CompactFunc(result, func(a, b equipment.Equipment) bool {
+		return a.EquipmentID() == b.EquipmentID()
+	}), nil
+}
+
+// expandEquipmentCategory returns the equipment in one category
+func expandEquipmentCategory(
+	equipType shared.EquipmentType,
+	category shared.EquipmentCategory,
+) ([]equipment.Equipment, error) {
+	var ids []shared.EquipmentID
+	switch {
+	case category == EquipmentCategoryMusicalInstruments:
+		ids = toolIDs(tools.CategoryMusical)
+	case category == EquipmentCategoryDruidicFoci:
+		ids = []shared.EquipmentID{items.DruidicFocus}
+	case category == EquipmentCategoryArcaneFoci:
+		ids = []shared.EquipmentID{items.ArcaneFocus}
+	case category == EquipmentCategoryHolySymbols:
+		ids = []shared.EquipmentID{items.HolySymbol}
+	case equipType == shared.EquipmentTypeTool:
+		ids = toolIDs(tools.ToolCategory(category))
+	default:
+		expanded, err := equipment.GetByCategory(equipType, []shared.EquipmentCategory{category})
+		if err != nil {
+			return nil, err
+		}
+		if len(expanded) == 0 {
+			return nil, rpgerr.Newf(rpgerr.CodeInvalidArgument, "unknown %s category '%s'", equipType, category)
+		}
+		return expanded, nil
+	}
+
+	if len(ids) == 0 {
+		return nil, rpgerr.Newf(rpgerr.CodeInvalidArgument, "unknown %s category '%s'", equipType, category)
+	}
+
+	expanded := make([]equipment.Equipment, 0, len(ids))
+	for _, id := range ids {
+		item, err := equipment.GetByID(id)
+		if err != nil {
+			return nil, err
+		}
+		expanded = append(expanded, item)
+	}
+	return expanded, nil
+}
+
+// toolIDs returns the IDs of the tools in a category
+func toolIDs(category tools.ToolCategory) []shared.EquipmentID {
+	var ids []shared.EquipmentID
+	for _, tool := range tools.GetByCategory(category) {
+		ids = append(ids, tool.ID)
+	}
+	return ids
+}
+
+// InEquipmentCategories reports whether an equipment ID is one of the
+// concrete items a category placeholder stands for
+func InEquipmentCategories(
+	equipType shared.EquipmentType,
+	categories []shared.EquipmentCategory,
+	id shared.EquipmentID,
+) bool {
+	expanded, err := ExpandEquipmentCategories(equipType, categories)
+	if err != nil {
+		return false
+	}
+	return slices.ContainsFunc(expanded, func(item equipment.Equipment) bool {
+		return item.EquipmentID() == id
+	})
+}
+
+// ExpandEquipmentBundle returns the concrete items a bundle ID such as
+// "explorer-pack" stands for, with resolved details where the item is in the
+// equipment registry. An ID that isn't a bundle expands to itself.
+func ExpandEquipmentBundle(id shared.EquipmentID) ([]EquipmentItem, error) {
+	pack, ok := packs.All[id]
+	if !ok {
+		detail := equipment.ResolveEquipmentDetail(id)
+		if detail == nil {
+			return nil, rpgerr.Newf(rpgerr.CodeNotFound, "unknown equipment '%s'", id)
+		}
+		return []EquipmentItem{{ID: id, Quantity: 1, Detail: detail}}, nil
+	}
+
+	contents := make([]EquipmentItem, len(pack.Contents))
+	for i, item := range pack.Contents {
+		contents[i] = EquipmentItem{
+			ID:       item.ItemID,
+			Quantity: item.Quantity,
+			Detail:   equipment.ResolveEquipmentDetail(item.ItemID),
+		}
+	}
+	return contents, nil
+}
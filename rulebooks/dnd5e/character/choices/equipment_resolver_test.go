@@ -0,0 +1,144 @@
+package choices
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/armor"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/classes"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/equipment"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/items"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/packs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/tools"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/weapons"
+)
+
+// EquipmentResolverTestSuite tests expanding equipment placeholders and bundles
+type EquipmentResolverTestSuite struct {
+	suite.Suite
+}
+
+func TestEquipmentResolverSuite(t *testing.T) {
+	suite.Run(t, new(EquipmentResolverTestSuite))
+}
+
+// ids returns the IDs of equipment
+func (s *EquipmentResolverTestSuite) ids(expanded []equipment.Equipment) []shared.EquipmentID {
+	ids := make([]shared.EquipmentID, len(expanded))
+	for i, item := range expanded {
+		ids[i] = item.EquipmentID()
+	}
+	return ids
+}
+
+func (s *EquipmentResolverTestSuite) TestMartialWeapons() {
+	expanded, err := ExpandEquipmentCategories(shared.EquipmentTypeWeapon,
+		[]shared.EquipmentCategory{weapons.CategoryMartialMelee, weapons.CategoryMartialRanged})
+	s.Require().NoError(err)
+
+	ids := s.ids(expanded)
+	s.Len(ids, len(weapons.GetMartialWeapons()))
+	s.Contains(ids, weapons.Longsword)
+	s.Contains(ids, weapons.Longbow)
+	s.NotContains(ids, weapons.Dagger)
+	s.IsIncreasing(ids, "sorted by ID")
+}
+
+func (s *EquipmentResolverTestSuite) TestPlaceholders() {
+	testCases := []struct {
+		name      string
+		equipType shared.EquipmentType
+		category  shared.EquipmentCategory
+		contains  shared.EquipmentID
+		count     int
+	}{
+		{"musical instruments", shared.EquipmentTypeTool, EquipmentCategoryMusicalInstruments, tools.Lute, 10},
+		{"artisan's tools", shared.EquipmentTypeTool, shared.EquipmentCategory(tools.CategoryArtisan), tools.SmithTools, 17},
+		{"druidic foci", shared.EquipmentTypeTool, EquipmentCategoryDruidicFoci, items.DruidicFocus, 1},
+		{"holy symbols", shared.EquipmentTypeTool, EquipmentCategoryHolySymbols, items.HolySymbol, 1},
+		{"light armor", shared.EquipmentTypeArmor, armor.CategoryLight, armor.Leather, 3},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			expanded, err := ExpandEquipmentCategories(tc.equipType, []shared.EquipmentCategory{tc.category})
+			s.Require().NoError(err)
+			s.Len(expanded, tc.count)
+			s.Contains(s.ids(expanded), tc.contains)
+		})
+	}
+}
+
+func (s *EquipmentResolverTestSuite) TestUnknownCategory() {
+	_, err := ExpandEquipmentCategories(shared.EquipmentTypeWeapon, []shared.EquipmentCategory{"exotic"})
+	s.Error(err)
+
+	_, err = ExpandEquipmentCategories(shared.EquipmentTypeWeapon, nil)
+	s.Error(err)
+}
+
+func (s *EquipmentResolverTestSuite) TestInEquipmentCategories() {
+	simple := []shared.EquipmentCategory{weapons.CategorySimpleMelee, weapons.CategorySimpleRanged}
+	s.True(InEquipmentCategories(shared.EquipmentTypeWeapon, simple, weapons.Spear))
+	s.False(InEquipmentCategories(shared.EquipmentTypeWeapon, simple, weapons.Longsword))
+}
+
+func (s *EquipmentResolverTestSuite) TestExpandBundle() {
+	contents, err := ExpandEquipmentBundle(packs.ExplorerPack)
+	s.Require().NoError(err)
+	s.Len(contents, len(packs.All[packs.ExplorerPack].Contents))
+	for _, item := range contents {
+		if item.ID == items.Torch {
+			s.Equal(10, item.Quantity)
+		}
+	}
+
+	single, err := ExpandEquipmentBundle(weapons.Longsword)
+	s.Require().NoError(err)
+	s.Require().Len(single, 1)
+	s.Equal(weapons.Longsword, single[0].ID)
+	s.Require().NotNil(single[0].Detail)
+	s.Equal("Longsword", single[0].Detail.Name)
+
+	_, err = ExpandEquipmentBundle("bag-of-holding")
+	s.Error(err)
+}
+
+func (s *EquipmentResolverTestSuite) TestRequirementsListBundleContents() {
+	reqs := GetClassRequirements(classes.Fighter)
+	for _, req := range reqs.Equipment {
+		for _, option := range req.Options {
+			if option.ID != FighterPackExplorer {
+				continue
+			}
+			s.Require().Len(option.Items, 1)
+			s.NotEmpty(option.Items[0].Contents)
+			return
+		}
+	}
+	s.Fail("fighter requirements have no explorer's pack option")
+}
+
+func (s *EquipmentResolverTestSuite) TestEveryCategoryChoiceExpands() {
+	allClasses := []classes.Class{
+		classes.Fighter, classes.Barbarian, classes.Wizard, classes.Rogue, classes.Cleric, classes.Bard,
+		classes.Druid, classes.Monk, classes.Paladin, classes.Ranger, classes.Sorcerer, classes.Warlock,
+	}
+	for _, classID := range allClasses {
+		reqs := GetClassRequirements(classID)
+		if reqs == nil {
+			continue
+		}
+		for _, req := range reqs.Equipment {
+			for _, option := range req.Options {
+				for _, choice := range option.CategoryChoices {
+					expanded, err := ExpandEquipmentCategories(choice.Type, choice.Categories)
+					s.NoError(err, "%s option %s", classID, option.ID)
+					s.NotEmpty(expanded, "%s option %s", classID, option.ID)
+				}
+			}
+		}
+	}
+}
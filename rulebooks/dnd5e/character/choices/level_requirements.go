@@ -0,0 +1,231 @@
+package choices
+
+import (
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/classes"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/fightingstyles"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/spells"
+)
+
+// GetClassLevelUpRequirements returns the choices a class gains on reaching a level,
+// so a level-up flow can validate the player's new choices with the same Validator
+// used at character creation:
+//
+//	reqs := choices.GetClassLevelUpRequirements(classes.Bard, 3)
+//	result := choices.NewValidator().Validate(reqs, submissions)
+//
+// Level 1 returns the character creation requirements. Covers subclasses, fighting
+// styles, Ability Score Improvements, expertise, cantrips and spells known; other
+// level-up choices (e.g., metamagic, eldritch invocations) are not modeled yet.
+func GetClassLevelUpRequirements(classID classes.Class, level int) *Requirements {
+	if level <= 1 {
+		return GetClassRequirementsAtLevel(classID, 1)
+	}
+
+	reqs := &Requirements{}
+	addLevelRequirements(reqs, classID, level)
+	return reqs
+}
+
+// addLevelRequirements adds the choices a class gains on reaching a level. The base
+// requirements hold the other level 1 choices. A spell, cantrip or expertise choice
+// that reqs already has from an earlier level is added to the Additional fields.
+func addLevelRequirements(reqs *Requirements, classID classes.Class, level int) {
+	classData := classes.ClassData[classID]
+	if classData == nil {
+		return
+	}
+
+	if classData.SubclassLevel > 0 && level == classData.SubclassLevel {
+		reqs.Subclass = &SubclassRequirement{
+			ID:      ChoiceID(classData.SubclassChoiceID),
+			Options: classData.Subclasses,
+			Label:   classData.SubclassLabel,
+		}
+	}
+
+	if style := getLevelFightingStyle(classID, level); style != nil {
+		reqs.FightingStyle = style
+	}
+
+	if expertise := getLevelExpertise(classID, level); expertise != nil {
+		if reqs.Expertise == nil {
+			reqs.Expertise = expertise
+		} else {
+			reqs.AdditionalExpertise = append(reqs.AdditionalExpertise, expertise)
+		}
+	}
+
+	if classes.HasAbilityScoreImprovement(classID, level) {
+		reqs.AbilityScoreImprovements = append(reqs.AbilityScoreImprovements, &AbilityScoreImprovementRequirement{
+			ID:     AbilityScoreImprovementChoiceID(classID, level),
+			Points: 2,
+			Label:  "Increase your ability scores or choose a feat",
+		})
+	}
+
+	if level < 2 {
+		return
+	}
+
+	if cantrips := getLevelCantrips(classID, level); cantrips != nil {
+		if reqs.Cantrips == nil {
+			reqs.Cantrips = cantrips
+		} else {
+			reqs.AdditionalCantrips = append(reqs.AdditionalCantrips, cantrips)
+		}
+	}
+
+	if spellChoice := getLevelSpells(classID, level); spellChoice != nil {
+		if reqs.Spellbook == nil {
+			reqs.Spellbook = spellChoice
+		} else {
+			reqs.AdditionalSpells = append(reqs.AdditionalSpells, spellChoice)
+		}
+	}
+}
+
+// getLevelFightingStyle returns the fighting style paladins and rangers choose at level 2
+func getLevelFightingStyle(classID classes.Class, level int) *FightingStyleRequirement {
+	if level != 2 {
+		return nil
+	}
+
+	switch classID {
+	case classes.Paladin:
+		return &FightingStyleRequirement{
+			ID: PaladinFightingStyle,
+			Options: []fightingstyles.FightingStyle{
+				fightingstyles.Defense,
+				fightingstyles.Dueling,
+				fightingstyles.GreatWeaponFighting,
+				fightingstyles.Protection,
+			},
+			Label: "Choose a fighting style",
+		}
+	case classes.Ranger:
+		return &FightingStyleRequirement{
+			ID: RangerFightingStyle,
+			Options: []fightingstyles.FightingStyle{
+				fightingstyles.Archery,
+				fightingstyles.Defense,
+				fightingstyles.Dueling,
+				fightingstyles.TwoWeaponFighting,
+			},
+			Label: "Choose a fighting style",
+		}
+	default:
+		return nil
+	}
+}
+
+// getLevelExpertise returns the expertise bards gain at levels 3 and 10 and rogues at level 6.
+// Rogue level 1 expertise is part of the base requirements.
+func getLevelExpertise(classID classes.Class, level int) *ExpertiseRequirement {
+	switch {
+	case classID == classes.Bard && level == 3:
+		return &ExpertiseRequirement{ID: BardExpertise3, Count: 2, Label: "Choose 2 skills for expertise"}
+	case classID == classes.Bard && level == 10:
+		return &ExpertiseRequirement{ID: BardExpertise10, Count: 2, Label: "Choose 2 skills for expertise"}
+	case classID == classes.Rogue && level == 6:
+		return &ExpertiseRequirement{
+			ID:    RogueExpertise6,
+			Count: 2,
+			Label: "Choose 2 skills or thieves' tools for expertise",
+		}
+	default:
+		return nil
+	}
+}
+
+// getLevelCantrips returns the cantrips a class learns on reaching a level
+func getLevelCantrips(classID classes.Class, level int) *CantripRequirement {
+	count := classes.CantripsKnownAtLevel(classID, level) - classes.CantripsKnownAtLevel(classID, level-1)
+	if count <= 0 {
+		return nil
+	}
+
+	var options []spells.Spell
+	if base := getBaseClassRequirements(classID).Cantrips; base != nil {
+		options = base.Options
+	}
+
+	return &CantripRequirement{
+		ID:      CantripsChoiceID(classID, level),
+		Class:   classID,
+		Count:   count,
+		Options: options,
+		Label:   fmt.Sprintf("Choose %d new %s", count, plural(count, "cantrip")),
+	}
+}
+
+// getLevelSpells returns the spells a class learns on reaching a level. Wizards copy
+// two spells into their spellbook; clerics, druids and paladins prepare spells from
+// their whole list and don't choose any.
+//
+// SpellLevel is the highest spell level the class can learn. Options only lists
+// 1st-level spells, so once higher levels open up Options is empty and the
+// validator checks each spell against the class spell list (see spells.OnSpellList).
+func getLevelSpells(classID classes.Class, level int) *SpellbookRequirement {
+	count := 2
+	if classID != classes.Wizard {
+		count = classes.SpellsKnownAtLevel(classID, level) - classes.SpellsKnownAtLevel(classID, level-1)
+	}
+	if count <= 0 {
+		return nil
+	}
+
+	spellLevel := maxSpellLevel(classID, level)
+	var options []spells.Spell
+	if spellLevel == 1 {
+		if classID == classes.Ranger {
+			options = rangerSpellOptions()
+		} else if base := getBaseClassRequirements(classID).Spellbook; base != nil {
+			options = base.Options
+		}
+	}
+
+	return &SpellbookRequirement{
+		ID:         SpellsChoiceID(classID, level),
+		Class:      classID,
+		Count:      count,
+		SpellLevel: spellLevel,
+		Options:    options,
+		Label:      fmt.Sprintf("Choose %d %s of up to %s level", count, plural(count, "spell"), ordinal(spellLevel)),
+	}
+}
+
+// maxSpellLevel returns the highest level of spell a class can cast at a class level
+func maxSpellLevel(classID classes.Class, level int) int {
+	switch classID {
+	case classes.Warlock:
+		return spells.PactSlots(level).Level
+	case classes.Paladin, classes.Ranger:
+		return len(spells.HalfCasterSlots(level))
+	default:
+		return min((level+1)/2, spells.MaxSpellLevel)
+	}
+}
+
+// plural returns noun with an "s" unless count is 1
+func plural(count int, noun string) string {
+	if count == 1 {
+		return noun
+	}
+	return noun + "s"
+}
+
+// ordinal returns a spell level as "1st", "2nd", "3rd", "4th", ...
+func ordinal(n int) string {
+	switch n {
+	case 1:
+		return "1st"
+	case 2:
+		return "2nd"
+	case 3:
+		return "3rd"
+	default:
+		return fmt.Sprintf("%dth", n)
+	}
+}
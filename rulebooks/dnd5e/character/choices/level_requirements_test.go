@@ -0,0 +1,231 @@
+package choices
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/classes"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/spells"
+)
+
+// LevelRequirementsTestSuite tests choice requirements for levels 2-5
+type LevelRequirementsTestSuite struct {
+	suite.Suite
+	validator *Validator
+}
+
+func TestLevelRequirementsSuite(t *testing.T) {
+	suite.Run(t, new(LevelRequirementsTestSuite))
+}
+
+func (s *LevelRequirementsTestSuite) SetupTest() {
+	s.validator = NewValidator()
+}
+
+func (s *LevelRequirementsTestSuite) TestLevelUpChoices() {
+	testCases := []struct {
+		name      string
+		class     classes.Class
+		level     int
+		subclass  ChoiceID
+		expertise ChoiceID
+		asi       ChoiceID
+		cantrips  int
+		spells    int
+	}{
+		{name: "wizard 2", class: classes.Wizard, level: 2, subclass: "wizard-tradition", spells: 2},
+		{name: "bard 3", class: classes.Bard, level: 3, subclass: BardCollege, expertise: BardExpertise3, spells: 1},
+		{name: "rogue 3", class: classes.Rogue, level: 3, subclass: RogueArchetype},
+		{name: "fighter 4", class: classes.Fighter, level: 4, asi: "fighter-asi-4"},
+		{name: "sorcerer 4", class: classes.Sorcerer, level: 4, asi: "sorcerer-asi-4", cantrips: 1, spells: 1},
+		{name: "cleric 4", class: classes.Cleric, level: 4, asi: "cleric-asi-4", cantrips: 1},
+		{name: "ranger 4", class: classes.Ranger, level: 4, asi: "ranger-asi-4"},
+		{name: "warlock 5", class: classes.Warlock, level: 5, spells: 1},
+		{name: "paladin 5", class: classes.Paladin, level: 5},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			reqs := GetClassLevelUpRequirements(tc.class, tc.level)
+			s.Require().NotNil(reqs)
+			s.Nil(reqs.Skills, "level 1 choices aren't repeated")
+			s.Empty(reqs.Equipment)
+
+			if tc.subclass != "" {
+				s.Require().NotNil(reqs.Subclass)
+				s.Equal(tc.subclass, reqs.Subclass.ID)
+			} else {
+				s.Nil(reqs.Subclass)
+			}
+
+			if tc.expertise != "" {
+				s.Require().NotNil(reqs.Expertise)
+				s.Equal(tc.expertise, reqs.Expertise.ID)
+				s.Equal(2, reqs.Expertise.Count)
+			} else {
+				s.Nil(reqs.Expertise)
+			}
+
+			if tc.asi != "" {
+				s.Require().Len(reqs.AbilityScoreImprovements, 1)
+				s.Equal(tc.asi, reqs.AbilityScoreImprovements[0].ID)
+				s.Equal(2, reqs.AbilityScoreImprovements[0].Points)
+			} else {
+				s.Empty(reqs.AbilityScoreImprovements)
+			}
+
+			if tc.cantrips > 0 {
+				s.Require().NotNil(reqs.Cantrips)
+				s.Equal(CantripsChoiceID(tc.class, tc.level), reqs.Cantrips.ID)
+				s.Equal(tc.cantrips, reqs.Cantrips.Count)
+				s.NotEmpty(reqs.Cantrips.Options)
+			} else {
+				s.Nil(reqs.Cantrips)
+			}
+
+			if tc.spells > 0 {
+				s.Require().NotNil(reqs.Spellbook)
+				s.Equal(SpellsChoiceID(tc.class, tc.level), reqs.Spellbook.ID)
+				s.Equal(tc.spells, reqs.Spellbook.Count)
+			} else {
+				s.Nil(reqs.Spellbook)
+			}
+		})
+	}
+}
+
+func (s *LevelRequirementsTestSuite) TestSpellLevelGrowsWithClassLevel() {
+	wizard2 := GetClassLevelUpRequirements(classes.Wizard, 2).Spellbook
+	s.Require().NotNil(wizard2)
+	s.Equal(1, wizard2.SpellLevel)
+	s.Contains(wizard2.Options, spells.MagicMissile, "1st-level options come from the class list")
+
+	wizard3 := GetClassLevelUpRequirements(classes.Wizard, 3).Spellbook
+	s.Require().NotNil(wizard3)
+	s.Equal(2, wizard3.SpellLevel)
+	s.Empty(wizard3.Options, "no 2nd-level class lists yet")
+
+	ranger5 := GetClassLevelUpRequirements(classes.Ranger, 5).Spellbook
+	s.Require().NotNil(ranger5)
+	s.Equal(RangerSpells2, GetClassLevelUpRequirements(classes.Ranger, 2).Spellbook.ID)
+	s.Equal(2, ranger5.SpellLevel)
+
+	warlock5 := GetClassLevelUpRequirements(classes.Warlock, 5).Spellbook
+	s.Require().NotNil(warlock5)
+	s.Equal(3, warlock5.SpellLevel)
+}
+
+func (s *LevelRequirementsTestSuite) TestCumulativeRequirements() {
+	reqs := GetClassRequirementsAtLevel(classes.Bard, 4)
+
+	s.Require().NotNil(reqs.Skills, "level 1 choices are kept")
+	s.Require().NotNil(reqs.Spellbook)
+	s.Equal(BardSpells1, reqs.Spellbook.ID)
+	s.Require().NotNil(reqs.Cantrips)
+	s.Equal(BardCantrips1, reqs.Cantrips.ID)
+
+	var spellIDs []ChoiceID
+	for _, spellReq := range reqs.AdditionalSpells {
+		spellIDs = append(spellIDs, spellReq.ID)
+	}
+	s.Equal([]ChoiceID{"bard-spells-2", "bard-spells-3", "bard-spells-4"}, spellIDs)
+	s.Require().Len(reqs.AdditionalCantrips, 1)
+	s.Equal(ChoiceID("bard-cantrips-4"), reqs.AdditionalCantrips[0].ID)
+
+	s.Require().NotNil(reqs.Subclass)
+	s.Require().NotNil(reqs.Expertise)
+	s.Equal(BardExpertise3, reqs.Expertise.ID)
+	s.Len(reqs.AbilityScoreImprovements, 1)
+
+	rogue := GetClassRequirementsAtLevel(classes.Rogue, 6)
+	s.Require().NotNil(rogue.Expertise)
+	s.Equal(RogueExpertise1, rogue.Expertise.ID)
+	s.Require().Len(rogue.AdditionalExpertise, 1)
+	s.Equal(RogueExpertise6, rogue.AdditionalExpertise[0].ID)
+}
+
+func (s *LevelRequirementsTestSuite) TestValidateLevelUpChoices() {
+	reqs := GetClassLevelUpRequirements(classes.Bard, 4)
+
+	submissions := NewSubmissions()
+	submissions.Add(Submission{
+		Category: shared.ChoiceAbilityScores,
+		Source:   shared.SourceClass,
+		ChoiceID: "bard-asi-4",
+		OptionID: AbilityScoreImprovementIncrease,
+		Values:   []shared.SelectionID{string(abilities.CHA), string(abilities.CHA)},
+	})
+	submissions.Add(Submission{
+		Category: shared.ChoiceCantrips,
+		Source:   shared.SourceClass,
+		ChoiceID: "bard-cantrips-4",
+		Values:   []shared.SelectionID{spells.ViciousMockery},
+	})
+	submissions.Add(Submission{
+		Category: shared.ChoiceSpells,
+		Source:   shared.SourceClass,
+		ChoiceID: "bard-spells-4",
+		Values:   []shared.SelectionID{spells.Thunderwave},
+	})
+
+	result := s.validator.Validate(reqs, submissions)
+	s.True(result.Valid, "errors: %v", result.Errors)
+
+	s.Run("missing choices fail", func() {
+		result := s.validator.Validate(GetClassLevelUpRequirements(classes.Bard, 3), submissions)
+		s.False(result.Valid)
+	})
+}
+
+func (s *LevelRequirementsTestSuite) TestValidateAbilityScoreImprovement() {
+	req := &AbilityScoreImprovementRequirement{ID: "fighter-asi-4", Points: 2, Label: "ASI"}
+
+	testCases := []struct {
+		name   string
+		option OptionID
+		values []shared.SelectionID
+		valid  bool
+	}{
+		{"two abilities", AbilityScoreImprovementIncrease, []shared.SelectionID{string(abilities.STR), string(abilities.CON)}, true},
+		{"one ability twice", AbilityScoreImprovementIncrease, []shared.SelectionID{string(abilities.STR), string(abilities.STR)}, true},
+		{"too few increases", AbilityScoreImprovementIncrease, []shared.SelectionID{string(abilities.STR)}, false},
+		{"unknown ability", AbilityScoreImprovementIncrease, []shared.SelectionID{string(abilities.STR), "luck"}, false},
+		{"feat", AbilityScoreImprovementFeat, []shared.SelectionID{"alert"}, true},
+		{"two feats", AbilityScoreImprovementFeat, []shared.SelectionID{"alert", "lucky"}, false},
+		{"no option", "", []shared.SelectionID{string(abilities.STR), string(abilities.CON)}, false},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			submissions := NewSubmissions()
+			submissions.Add(Submission{
+				Category: shared.ChoiceAbilityScores,
+				Source:   shared.SourceClass,
+				ChoiceID: req.ID,
+				OptionID: tc.option,
+				Values:   tc.values,
+			})
+			err := s.validator.validateAbilityScoreImprovement(req, submissions)
+			s.Equal(tc.valid, err == nil, "error: %v", err)
+		})
+	}
+
+	s.NotNil(s.validator.validateAbilityScoreImprovement(req, NewSubmissions()), "missing choice")
+}
+
+func (s *LevelRequirementsTestSuite) TestExpertiseSubmission() {
+	submissions := NewSubmissions()
+	submissions.Add(Submission{
+		Category: shared.ChoiceExpertise,
+		Source:   shared.SourceClass,
+		ChoiceID: BardExpertise3,
+		Values:   []shared.SelectionID{skills.Persuasion, skills.Performance},
+	})
+
+	expertise := GetClassLevelUpRequirements(classes.Bard, 3).Expertise
+	s.Nil(s.validator.validateExpertise(expertise, submissions))
+}
@@ -210,6 +210,16 @@ func (s *RaceAPIValidationSuite) TestHalflingAPIValidation() {
 	}
 }
 
+// TestDragonbornAncestryRequirement validates the draconic ancestry choice
+func (s *RaceAPIValidationSuite) TestDragonbornAncestryRequirement() {
+	reqs := choices.GetRaceRequirements(races.Dragonborn)
+	s.Require().NotNil(reqs)
+	s.Require().NotNil(reqs.Traits, "Dragonborn should choose a draconic ancestry")
+	s.Equal(choices.DragonbornAncestry, reqs.Traits.ID)
+	s.Equal(1, reqs.Traits.Count)
+	s.Len(reqs.Traits.Options, 10, "Dragonborn has 10 draconic ancestries")
+}
+
 // TestAllRacesHaveAPIData ensures we have API data for all races
 func (s *RaceAPIValidationSuite) TestAllRacesHaveAPIData() {
 	raceNames := []string{
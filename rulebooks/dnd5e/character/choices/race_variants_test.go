@@ -0,0 +1,162 @@
+package choices_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/character/choices"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/classes"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/races"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
+)
+
+// RaceVariantsTestSuite tests the Variant Human and Custom Lineage requirements
+type RaceVariantsTestSuite struct {
+	suite.Suite
+	validator *choices.Validator
+}
+
+func TestRaceVariantsTestSuite(t *testing.T) {
+	suite.Run(t, new(RaceVariantsTestSuite))
+}
+
+func (s *RaceVariantsTestSuite) SetupTest() {
+	s.validator = choices.NewValidator()
+}
+
+func (s *RaceVariantsTestSuite) variantHumanSubmissions(increases ...shared.SelectionID) *choices.Submissions {
+	subs := choices.NewSubmissions()
+	subs.Add(choices.Submission{
+		Category: shared.ChoiceSkills,
+		Source:   shared.SourceRace,
+		ChoiceID: choices.VariantHumanSkill,
+		Values:   []shared.SelectionID{skills.Stealth},
+	})
+	subs.Add(choices.Submission{
+		Category: shared.ChoiceLanguages,
+		Source:   shared.SourceRace,
+		ChoiceID: choices.VariantHumanLanguage,
+		Values:   []shared.SelectionID{"elvish"},
+	})
+	subs.Add(choices.Submission{
+		Category: shared.ChoiceAbilityScores,
+		Source:   shared.SourceRace,
+		ChoiceID: choices.VariantHumanAbilities,
+		Values:   increases,
+	})
+	subs.Add(choices.Submission{
+		Category: shared.ChoiceFeats,
+		Source:   shared.SourceRace,
+		ChoiceID: choices.VariantHumanFeat,
+		Values:   []shared.SelectionID{"alert"},
+	})
+	return subs
+}
+
+func (s *RaceVariantsTestSuite) TestVariantHumanRequirements() {
+	reqs := choices.GetRaceRequirements(races.VariantHuman)
+	s.Require().Len(reqs.AdditionalSkills, 1)
+	s.Equal(1, reqs.AdditionalSkills[0].Count)
+	s.Require().Len(reqs.Languages, 1)
+	s.Require().NotNil(reqs.AbilityIncreases)
+	s.Equal(2, reqs.AbilityIncreases.Count)
+	s.Equal(1, reqs.AbilityIncreases.Amount)
+	s.Require().NotNil(reqs.Feats)
+	s.Equal(1, reqs.Feats.Count)
+}
+
+func (s *RaceVariantsTestSuite) TestVariantHumanValidation() {
+	testCases := []struct {
+		name      string
+		increases []shared.SelectionID
+		valid     bool
+	}{
+		{"two different abilities", []shared.SelectionID{string(abilities.STR), string(abilities.CON)}, true},
+		{"same ability twice", []shared.SelectionID{string(abilities.STR), string(abilities.STR)}, false},
+		{"one ability", []shared.SelectionID{string(abilities.STR)}, false},
+		{"unknown ability", []shared.SelectionID{string(abilities.STR), "luck"}, false},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			result := s.validator.Validate(
+				choices.GetRaceRequirements(races.VariantHuman),
+				s.variantHumanSubmissions(tc.increases...),
+			)
+			s.Equal(tc.valid, result.Valid, "errors: %v", result.Errors)
+		})
+	}
+}
+
+func (s *RaceVariantsTestSuite) TestVariantHumanSkillKeptAlongsideClassSkills() {
+	// Without the race skill, creation validation reports it next to the missing fighter choices
+	subs := choices.NewSubmissions()
+	result := s.validator.ValidateCharacterCreation(classes.Fighter, races.VariantHuman, subs)
+	s.Require().False(result.Valid)
+
+	var choiceIDs []choices.ChoiceID
+	for _, err := range result.Errors {
+		choiceIDs = append(choiceIDs, err.ChoiceID)
+	}
+	s.Contains(choiceIDs, choices.FighterSkills)
+	s.Contains(choiceIDs, choices.VariantHumanSkill)
+	s.Contains(choiceIDs, choices.VariantHumanFeat)
+}
+
+func (s *RaceVariantsTestSuite) TestCustomLineageVariableTrait() {
+	testCases := []struct {
+		name   string
+		option shared.SelectionID
+		values []shared.SelectionID
+		valid  bool
+	}{
+		{"darkvision", choices.CustomLineageDarkvision, nil, true},
+		{"skill proficiency", choices.CustomLineageSkillProficiency, []shared.SelectionID{skills.Insight}, true},
+		{"skill proficiency without a skill", choices.CustomLineageSkillProficiency, nil, false},
+		{"unknown skill", choices.CustomLineageSkillProficiency, []shared.SelectionID{"juggling"}, false},
+		{"unknown option", "wings", nil, false},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			subs := choices.NewSubmissions()
+			subs.Add(choices.Submission{
+				Category: shared.ChoiceLanguages,
+				Source:   shared.SourceRace,
+				ChoiceID: choices.CustomLineageLanguage,
+				Values:   []shared.SelectionID{"sylvan"},
+			})
+			subs.Add(choices.Submission{
+				Category: shared.ChoiceAbilityScores,
+				Source:   shared.SourceRace,
+				ChoiceID: choices.CustomLineageAbility,
+				Values:   []shared.SelectionID{string(abilities.WIS)},
+			})
+			subs.Add(choices.Submission{
+				Category: shared.ChoiceFeats,
+				Source:   shared.SourceRace,
+				ChoiceID: choices.CustomLineageFeat,
+				Values:   []shared.SelectionID{"lucky"},
+			})
+			subs.Add(choices.Submission{
+				Category: shared.ChoiceTraits,
+				Source:   shared.SourceRace,
+				ChoiceID: choices.CustomLineageSize,
+				Values:   []shared.SelectionID{choices.CustomLineageSmall},
+			})
+			subs.Add(choices.Submission{
+				Category: shared.ChoiceTraits,
+				Source:   shared.SourceRace,
+				ChoiceID: choices.CustomLineageVariableTrait,
+				OptionID: tc.option,
+				Values:   tc.values,
+			})
+
+			result := s.validator.Validate(choices.GetRaceRequirements(races.CustomLineage), subs)
+			s.Equal(tc.valid, result.Valid, "errors: %v", result.Errors)
+		})
+	}
+}
@@ -5,18 +5,23 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/ammunition"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/armor"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/backgrounds"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/classes"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/equipment"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/fightingstyles"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/items"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/languages"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/packs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/proficiencies"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/races"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/spells"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/terrains"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/weapons"
 )
 
@@ -24,7 +29,7 @@ import (
 type Requirements struct {
 	// Skills that need to be chosen
 	Skills           *SkillRequirement   `json:"skills,omitempty"`
-	AdditionalSkills []*SkillRequirement `json:"additional_skills,omitempty"` // For subclass-granted skills
+	AdditionalSkills []*SkillRequirement `json:"additional_skills,omitempty"` // For subclass- and race-granted skills
 
 	// Equipment choices
 	Equipment []*EquipmentRequirement `json:"equipment,omitempty"`
@@ -36,9 +41,28 @@ type Requirements struct {
 	Languages []*LanguageRequirement `json:"languages,omitempty"` // Changed to array for multiple language choices
 	Tools     *ToolRequirement       `json:"tools,omitempty"`
 
+	// Tools and languages chosen from one pool (e.g., a customized background)
+	ToolsOrLanguages *ToolOrLanguageRequirement `json:"tools_or_languages,omitempty"`
+
 	// Class-specific choices
-	FightingStyle *FightingStyleRequirement `json:"fighting_style,omitempty"`
-	Expertise     *ExpertiseRequirement     `json:"expertise,omitempty"`
+	FightingStyle       *FightingStyleRequirement `json:"fighting_style,omitempty"`
+	Expertise           *ExpertiseRequirement     `json:"expertise,omitempty"`
+	AdditionalExpertise []*ExpertiseRequirement   `json:"additional_expertise,omitempty"` // Expertise gained on later levels
+
+	// Ability Score Improvements (or feats) gained on level-up
+	AbilityScoreImprovements []*AbilityScoreImprovementRequirement `json:"ability_score_improvements,omitempty"`
+
+	// Ranger choices
+	FavoredEnemy   *FavoredEnemyRequirement   `json:"favored_enemy,omitempty"`
+	FavoredTerrain *FavoredTerrainRequirement `json:"favored_terrain,omitempty"`
+
+	// Racial trait choices (e.g., draconic ancestry)
+	Traits *TraitRequirement `json:"traits,omitempty"`
+
+	// Variant human and custom lineage choices
+	AbilityIncreases *AbilityIncreaseRequirement `json:"ability_increases,omitempty"`
+	Feats            *FeatRequirement            `json:"feats,omitempty"`
+	VariableTrait    *VariableTraitRequirement   `json:"variable_trait,omitempty"`
 
 	// Subclass choice (required at specific levels)
 	Subclass *SubclassRequirement `json:"subclass,omitempty"`
@@ -46,6 +70,10 @@ type Requirements struct {
 	// Spell choices
 	Cantrips  *CantripRequirement   `json:"cantrips,omitempty"`
 	Spellbook *SpellbookRequirement `json:"spellbook,omitempty"`
+
+	// Cantrips and spells learned on later levels
+	AdditionalCantrips []*CantripRequirement   `json:"additional_cantrips,omitempty"`
+	AdditionalSpells   []*SpellbookRequirement `json:"additional_spells,omitempty"`
 }
 
 // SkillRequirement defines skill choice requirements
@@ -74,18 +102,24 @@ type EquipmentOption struct {
 
 // EquipmentItem represents an item in an equipment option
 type EquipmentItem struct {
-	ID       shared.EquipmentID         `json:"id"`               // Equipment ID
-	Quantity int                        `json:"quantity"`         // How many (default 1)
-	Detail   *equipment.EquipmentDetail `json:"detail,omitempty"` // Resolved equipment stats
+	ID       shared.EquipmentID         `json:"id"`                 // Equipment ID
+	Quantity int                        `json:"quantity"`           // How many (default 1)
+	Detail   *equipment.EquipmentDetail `json:"detail,omitempty"`   // Resolved equipment stats
+	Contents []EquipmentItem            `json:"contents,omitempty"` // What a bundle (e.g., explorer's pack) holds
 }
 
 // enrichEquipmentRequirements populates the Detail field for each equipment item
-// across all options in the given requirements using the equipment registry.
+// across all options in the given requirements using the equipment registry,
+// and the Contents of each bundle.
 func enrichEquipmentRequirements(reqs []*EquipmentRequirement) []*EquipmentRequirement {
 	for _, req := range reqs {
 		for i := range req.Options {
 			for j := range req.Options[i].Items {
-				req.Options[i].Items[j].Detail = equipment.ResolveEquipmentDetail(req.Options[i].Items[j].ID)
+				item := &req.Options[i].Items[j]
+				item.Detail = equipment.ResolveEquipmentDetail(item.ID)
+				if item.Detail != nil && item.Detail.Type == shared.EquipmentTypePack {
+					item.Contents, _ = ExpandEquipmentBundle(item.ID)
+				}
 			}
 		}
 	}
@@ -125,6 +159,16 @@ type ToolRequirement struct {
 	Label   string   `json:"label"`
 }
 
+// ToolOrLanguageRequirement defines a choice filled by any mix of tool
+// proficiencies and languages, e.g., "choose two tools or languages"
+type ToolOrLanguageRequirement struct {
+	ID              ChoiceID             `json:"id"` // Unique identifier
+	Count           int                  `json:"count"`
+	ToolOptions     []proficiencies.Tool `json:"tool_options"`     // Tools to choose from
+	LanguageOptions []languages.Language `json:"language_options"` // Languages to choose from
+	Label           string               `json:"label"`            // e.g., "Choose 2 tools or languages"
+}
+
 // FightingStyleRequirement defines fighting style choice requirements
 type FightingStyleRequirement struct {
 	ID      ChoiceID                       `json:"id"`      // Unique identifier
@@ -139,6 +183,67 @@ type ExpertiseRequirement struct {
 	Label string   `json:"label"` // e.g., "Choose 2 skills or thieves' tools for expertise"
 }
 
+// AbilityScoreImprovementRequirement defines an Ability Score Improvement.
+// Submit it with OptionID AbilityScoreImprovementIncrease and Points abilities
+// as values (the same ability twice for +2), or AbilityScoreImprovementFeat
+// and the feat as the only value.
+type AbilityScoreImprovementRequirement struct {
+	ID     ChoiceID `json:"id"`     // Unique identifier
+	Points int      `json:"points"` // Ability score points to distribute
+	Label  string   `json:"label"`  // e.g., "Ability Score Improvement (level 4)"
+}
+
+// AbilityIncreaseRequirement defines racial ability score increases the player
+// places, such as variant human's +1 to two abilities. Submit Count different
+// abilities as values.
+type AbilityIncreaseRequirement struct {
+	ID      ChoiceID            `json:"id"`                // Unique identifier
+	Count   int                 `json:"count"`             // Number of different abilities to increase
+	Amount  int                 `json:"amount"`            // Increase to each chosen ability
+	Options []abilities.Ability `json:"options,omitempty"` // nil means any ability
+	Label   string              `json:"label"`             // e.g., "Increase two ability scores by 1"
+}
+
+// FeatRequirement defines a feat choice. Feats aren't modeled yet, so any
+// feat ID is accepted.
+type FeatRequirement struct {
+	ID    ChoiceID `json:"id"` // Unique identifier
+	Count int      `json:"count"`
+	Label string   `json:"label"` // e.g., "Choose a feat"
+}
+
+// VariableTraitRequirement defines custom lineage's variable trait. Submit it
+// with OptionID CustomLineageDarkvision and no values, or
+// CustomLineageSkillProficiency and the skill as the only value.
+type VariableTraitRequirement struct {
+	ID    ChoiceID `json:"id"`    // Unique identifier
+	Label string   `json:"label"` // e.g., "Choose darkvision or a skill proficiency"
+}
+
+// FavoredEnemyRequirement defines a ranger's favored enemy choice requirements
+type FavoredEnemyRequirement struct {
+	ID      ChoiceID               `json:"id"` // Unique identifier
+	Count   int                    `json:"count"`
+	Options []monster.CreatureType `json:"options"` // Creature types to choose from
+	Label   string                 `json:"label"`   // e.g., "Choose a favored enemy"
+}
+
+// FavoredTerrainRequirement defines a ranger's Natural Explorer terrain choice requirements
+type FavoredTerrainRequirement struct {
+	ID      ChoiceID           `json:"id"` // Unique identifier
+	Count   int                `json:"count"`
+	Options []terrains.Terrain `json:"options"` // Terrains to choose from
+	Label   string             `json:"label"`   // e.g., "Choose a favored terrain"
+}
+
+// TraitRequirement defines a racial trait choice requirement (e.g., draconic ancestry)
+type TraitRequirement struct {
+	ID      ChoiceID             `json:"id"` // Unique identifier
+	Count   int                  `json:"count"`
+	Options []shared.SelectionID `json:"options"` // Trait options to choose from
+	Label   string               `json:"label"`   // e.g., "Choose your draconic ancestry"
+}
+
 // SubclassRequirement defines subclass choice requirements
 type SubclassRequirement struct {
 	ID      ChoiceID           `json:"id"`      // Unique identifier
@@ -148,19 +253,23 @@ type SubclassRequirement struct {
 
 // CantripRequirement defines cantrip choice requirements
 type CantripRequirement struct {
-	ID      ChoiceID       `json:"id"`      // Unique identifier
-	Count   int            `json:"count"`   // How many cantrips to choose
-	Options []spells.Spell `json:"options"` // Available cantrips
-	Label   string         `json:"label"`   // e.g., "Choose 3 cantrips"
+	ID       ChoiceID         `json:"id"`                 // Unique identifier
+	Class    classes.Class    `json:"class,omitempty"`    // Spell list the cantrips are chosen from
+	Subclass classes.Subclass `json:"subclass,omitempty"` // Subclass adding to the spell list, if known
+	Count    int              `json:"count"`              // How many cantrips to choose
+	Options  []spells.Spell   `json:"options"`            // Available cantrips
+	Label    string           `json:"label"`              // e.g., "Choose 3 cantrips"
 }
 
 // SpellbookRequirement defines spellbook choice requirements
 type SpellbookRequirement struct {
-	ID         ChoiceID       `json:"id"`          // Unique identifier
-	Count      int            `json:"count"`       // How many spells to choose
-	SpellLevel int            `json:"spell_level"` // Level of spells to choose (1 for 1st level)
-	Options    []spells.Spell `json:"options"`     // Available spells
-	Label      string         `json:"label"`       // e.g., "Choose 6 1st-level spells for your spellbook"
+	ID         ChoiceID         `json:"id"`                 // Unique identifier
+	Class      classes.Class    `json:"class,omitempty"`    // Spell list the spells are chosen from
+	Subclass   classes.Subclass `json:"subclass,omitempty"` // Subclass adding to the spell list, if known
+	Count      int              `json:"count"`              // How many spells to choose
+	SpellLevel int              `json:"spell_level"`        // Level of spells to choose (1 for 1st level)
+	Options    []spells.Spell   `json:"options"`            // Available spells
+	Label      string           `json:"label"`              // e.g., "Choose 6 1st-level spells for your spellbook"
 }
 
 // GetClassRequirements returns the requirements for a specific class at level 1
@@ -168,20 +277,13 @@ func GetClassRequirements(classID classes.Class) *Requirements {
 	return GetClassRequirementsAtLevel(classID, 1)
 }
 
-// GetClassRequirementsAtLevel returns the requirements for a specific class at a given level
+// GetClassRequirementsAtLevel returns the requirements for a specific class at a given level:
+// every choice made from level 1 up to it (see GetClassLevelUpRequirements)
 func GetClassRequirementsAtLevel(classID classes.Class, level int) *Requirements {
 	reqs := getBaseClassRequirements(classID)
-
-	// Add subclass requirement if needed at this level
-	classData := classes.ClassData[classID]
-	if classData != nil && classData.SubclassLevel > 0 && level >= classData.SubclassLevel {
-		reqs.Subclass = &SubclassRequirement{
-			ID:      ChoiceID(classData.SubclassChoiceID),
-			Options: classData.Subclasses,
-			Label:   classData.SubclassLabel,
-		}
+	for classLevel := 1; classLevel <= level; classLevel++ {
+		addLevelRequirements(reqs, classID, classLevel)
 	}
-
 	return reqs
 }
 
@@ -282,6 +384,7 @@ func getWizardRequirements() *Requirements {
 		Equipment: enrichEquipmentRequirements(getWizardEquipmentRequirements()),
 		Cantrips: &CantripRequirement{
 			ID:    WizardCantrips1,
+			Class: classes.Wizard,
 			Count: 3,
 			Options: []spells.Spell{
 				// Damage cantrips
@@ -301,6 +404,7 @@ func getWizardRequirements() *Requirements {
 		},
 		Spellbook: &SpellbookRequirement{
 			ID:         WizardSpells1,
+			Class:      classes.Wizard,
 			Count:      6,
 			SpellLevel: 1,
 			Options: []spells.Spell{
@@ -371,6 +475,7 @@ func getBardRequirements() *Requirements {
 		},
 		Cantrips: &CantripRequirement{
 			ID:    BardCantrips1,
+			Class: classes.Bard,
 			Count: 2,
 			Options: []spells.Spell{
 				spells.BladeWard,
@@ -389,6 +494,7 @@ func getBardRequirements() *Requirements {
 		},
 		Spellbook: &SpellbookRequirement{
 			ID:         BardSpells1,
+			Class:      classes.Bard,
 			Count:      4,
 			SpellLevel: 1,
 			Options: []spells.Spell{
@@ -431,6 +537,7 @@ func getDruidRequirements() *Requirements {
 		Equipment: enrichEquipmentRequirements(getDruidEquipmentRequirements()),
 		Cantrips: &CantripRequirement{
 			ID:    DruidCantrips1,
+			Class: classes.Druid,
 			Count: 2,
 			Options: []spells.Spell{
 				// Damage cantrips
@@ -530,17 +637,48 @@ func getRangerRequirements() *Requirements {
 			Label:   "Choose 3 skills",
 		},
 		Equipment: enrichEquipmentRequirements(getRangerEquipmentRequirements()),
-		FightingStyle: &FightingStyleRequirement{
-			ID: RangerFightingStyle,
-			Options: []fightingstyles.FightingStyle{
-				fightingstyles.Archery,
-				fightingstyles.Defense,
-				fightingstyles.Dueling,
-				fightingstyles.TwoWeaponFighting,
+		FavoredEnemy: &FavoredEnemyRequirement{
+			ID:    RangerFavoredEnemy,
+			Count: 1,
+			Options: []monster.CreatureType{
+				monster.CreatureTypeAberration,
+				monster.CreatureTypeBeast,
+				monster.CreatureTypeCelestial,
+				monster.CreatureTypeConstruct,
+				monster.CreatureTypeDragon,
+				monster.CreatureTypeElemental,
+				monster.CreatureTypeFey,
+				monster.CreatureTypeFiend,
+				monster.CreatureTypeGiant,
+				monster.CreatureTypeHumanoid,
+				monster.CreatureTypeMonstrosity,
+				monster.CreatureTypeOoze,
+				monster.CreatureTypePlant,
+				monster.CreatureTypeUndead,
 			},
-			Label: "Choose a fighting style",
+			Label: "Choose a favored enemy",
+		},
+		FavoredTerrain: &FavoredTerrainRequirement{
+			ID:      RangerFavoredTerrain,
+			Count:   1,
+			Options: terrains.All(),
+			Label:   "Choose a favored terrain",
 		},
-		// Note: Rangers get spells at level 2, not level 1
+		// Note: Fighting style and spells come at level 2 (see addLevelRequirements)
+	}
+}
+
+// rangerSpellOptions returns the 1st-level ranger spells
+func rangerSpellOptions() []spells.Spell {
+	return []spells.Spell{
+		spells.AnimalFriendship,
+		spells.CureWounds,
+		spells.DetectMagic,
+		spells.EnsnaringStrike,
+		spells.FogCloud,
+		spells.HailOfThorns,
+		spells.Longstrider,
+		spells.SpeakWithAnimals,
 	}
 }
 
@@ -556,6 +694,7 @@ func getSorcererRequirements() *Requirements {
 		Equipment: enrichEquipmentRequirements(getSorcererEquipmentRequirements()),
 		Cantrips: &CantripRequirement{
 			ID:    SorcererCantrips1,
+			Class: classes.Sorcerer,
 			Count: 4,
 			Options: []spells.Spell{
 				// Damage cantrips
@@ -585,6 +724,7 @@ func getSorcererRequirements() *Requirements {
 		},
 		Spellbook: &SpellbookRequirement{
 			ID:         SorcererSpells1,
+			Class:      classes.Sorcerer,
 			Count:      2,
 			SpellLevel: 1,
 			Options: []spells.Spell{
@@ -622,6 +762,7 @@ func getWarlockRequirements() *Requirements {
 		Equipment: enrichEquipmentRequirements(getWarlockEquipmentRequirements()),
 		Cantrips: &CantripRequirement{
 			ID:    WarlockCantrips1,
+			Class: classes.Warlock,
 			Count: 2,
 			Options: []spells.Spell{
 				// Damage cantrips
@@ -643,6 +784,7 @@ func getWarlockRequirements() *Requirements {
 		},
 		Spellbook: &SpellbookRequirement{
 			ID:         WarlockSpells1,
+			Class:      classes.Warlock,
 			Count:      2,
 			SpellLevel: 1,
 			Options: []spells.Spell{
@@ -672,6 +814,7 @@ func getClericRequirements() *Requirements {
 		Equipment: enrichEquipmentRequirements(getClericEquipmentRequirements()),
 		Cantrips: &CantripRequirement{
 			ID:    ClericCantrips1,
+			Class: classes.Cleric,
 			Count: 3,
 			Options: []spells.Spell{
 				// Damage cantrips
@@ -724,9 +867,51 @@ func getSkillChoiceID(classID classes.Class) ChoiceID {
 	}
 }
 
+// GetBackgroundRequirements returns the requirements for a background.
+// Fixed backgrounds grant their proficiencies (see backgrounds.GetGrants) and
+// return nil; a customized background chooses any two skills and two tools or
+// languages.
+func GetBackgroundRequirements(bg backgrounds.Background) *Requirements {
+	if !bg.IsCustom() {
+		return nil
+	}
+
+	data := backgrounds.GetData(bg)
+	langOptions := append(languages.StandardLanguages(), languages.ExoticLanguages()...)
+	return &Requirements{
+		Skills: &SkillRequirement{
+			ID:      CustomBackgroundSkills,
+			Count:   data.SkillCount,
+			Options: skills.List(),
+			Label:   fmt.Sprintf("Choose %d skills", data.SkillCount),
+		},
+		ToolsOrLanguages: &ToolOrLanguageRequirement{
+			ID:              CustomBackgroundProficiencies,
+			Count:           data.ToolOrLanguageCount,
+			ToolOptions:     backgrounds.CustomizationTools(),
+			LanguageOptions: langOptions,
+			Label:           fmt.Sprintf("Choose %d tools or languages", data.ToolOrLanguageCount),
+		},
+	}
+}
+
 // GetRaceRequirements returns the requirements for a specific race
 func GetRaceRequirements(raceID races.Race) *Requirements {
 	switch raceID {
+	case races.Dragonborn:
+		ancestries := races.DraconicAncestries()
+		options := make([]shared.SelectionID, len(ancestries))
+		for i, ancestry := range ancestries {
+			options[i] = shared.SelectionID(ancestry)
+		}
+		return &Requirements{
+			Traits: &TraitRequirement{
+				ID:      DragonbornAncestry,
+				Count:   1,
+				Options: options,
+				Label:   "Choose your draconic ancestry",
+			},
+		}
 	case races.Dwarf:
 		return &Requirements{
 			Tools: &ToolRequirement{
@@ -767,26 +952,10 @@ func GetRaceRequirements(raceID races.Race) *Requirements {
 		return &Requirements{
 			Languages: []*LanguageRequirement{
 				{
-					ID:    HumanLanguage,
-					Count: 1,
-					Options: []languages.Language{
-						languages.Dwarvish,
-						languages.Elvish,
-						languages.Giant,
-						languages.Gnomish,
-						languages.Goblin,
-						languages.Halfling,
-						languages.Orc,
-						languages.Abyssal,
-						languages.Celestial,
-						languages.Draconic,
-						languages.DeepSpeech,
-						languages.Infernal,
-						languages.Primordial,
-						languages.Sylvan,
-						languages.Undercommon,
-					},
-					Label: "Choose 1 language",
+					ID:      HumanLanguage,
+					Count:   1,
+					Options: humanLanguageOptions(),
+					Label:   "Choose 1 language",
 				},
 			},
 		}
@@ -801,11 +970,95 @@ func GetRaceRequirements(raceID races.Race) *Requirements {
 				},
 			},
 		}
+	case races.VariantHuman:
+		return &Requirements{
+			// Race skills go in AdditionalSkills so merging with class skills keeps both
+			AdditionalSkills: []*SkillRequirement{
+				{
+					ID:      VariantHumanSkill,
+					Count:   1,
+					Options: nil, // Any skill
+					Label:   "Choose 1 skill",
+				},
+			},
+			Languages: []*LanguageRequirement{
+				{
+					ID:      VariantHumanLanguage,
+					Count:   1,
+					Options: humanLanguageOptions(),
+					Label:   "Choose 1 language",
+				},
+			},
+			AbilityIncreases: &AbilityIncreaseRequirement{
+				ID:     VariantHumanAbilities,
+				Count:  2,
+				Amount: 1,
+				Label:  "Increase two different ability scores by 1",
+			},
+			Feats: &FeatRequirement{
+				ID:    VariantHumanFeat,
+				Count: 1,
+				Label: "Choose a feat",
+			},
+		}
+	case races.CustomLineage:
+		return &Requirements{
+			Languages: []*LanguageRequirement{
+				{
+					ID:      CustomLineageLanguage,
+					Count:   1,
+					Options: nil, // Any language
+					Label:   "Choose 1 language",
+				},
+			},
+			AbilityIncreases: &AbilityIncreaseRequirement{
+				ID:     CustomLineageAbility,
+				Count:  1,
+				Amount: 2,
+				Label:  "Increase one ability score by 2",
+			},
+			Feats: &FeatRequirement{
+				ID:    CustomLineageFeat,
+				Count: 1,
+				Label: "Choose a feat",
+			},
+			Traits: &TraitRequirement{
+				ID:      CustomLineageSize,
+				Count:   1,
+				Options: []shared.SelectionID{CustomLineageSmall, CustomLineageMedium},
+				Label:   "Choose your size",
+			},
+			VariableTrait: &VariableTraitRequirement{
+				ID:    CustomLineageVariableTrait,
+				Label: "Choose darkvision or a skill proficiency",
+			},
+		}
 	default:
 		return &Requirements{}
 	}
 }
 
+// humanLanguageOptions returns the languages a human can learn besides Common
+func humanLanguageOptions() []languages.Language {
+	return []languages.Language{
+		languages.Dwarvish,
+		languages.Elvish,
+		languages.Giant,
+		languages.Gnomish,
+		languages.Goblin,
+		languages.Halfling,
+		languages.Orc,
+		languages.Abyssal,
+		languages.Celestial,
+		languages.Draconic,
+		languages.DeepSpeech,
+		languages.Infernal,
+		languages.Primordial,
+		languages.Sylvan,
+		languages.Undercommon,
+	}
+}
+
 func getBardEquipmentRequirements() []*EquipmentRequirement {
 	return []*EquipmentRequirement{
 		{
@@ -870,7 +1123,7 @@ func getBardEquipmentRequirements() []*EquipmentRequirement {
 						{
 							Choose:     1,
 							Type:       "tool",
-							Categories: []shared.EquipmentCategory{shared.EquipmentCategory("musical-instruments")},
+							Categories: []shared.EquipmentCategory{EquipmentCategoryMusicalInstruments},
 							Label:      "Choose a musical instrument",
 						},
 					},
@@ -1396,7 +1649,7 @@ func getDruidEquipmentRequirements() []*EquipmentRequirement {
 						{
 							Choose:     1,
 							Type:       "tool",
-							Categories: []shared.EquipmentCategory{shared.EquipmentCategory("druidic-foci")},
+							Categories: []shared.EquipmentCategory{EquipmentCategoryDruidicFoci},
 							Label:      "Choose a druidic focus",
 						},
 					},
@@ -1539,7 +1792,7 @@ func getPaladinEquipmentRequirements() []*EquipmentRequirement {
 						{
 							Choose:     1,
 							Type:       "tool",
-							Categories: []shared.EquipmentCategory{shared.EquipmentCategory("holy-symbols")},
+							Categories: []shared.EquipmentCategory{EquipmentCategoryHolySymbols},
 							Label:      "Choose a holy symbol",
 						},
 					},
@@ -1658,7 +1911,7 @@ func getSorcererEquipmentRequirements() []*EquipmentRequirement {
 						{
 							Choose:     1,
 							Type:       "tool",
-							Categories: []shared.EquipmentCategory{shared.EquipmentCategory("arcane-foci")},
+							Categories: []shared.EquipmentCategory{EquipmentCategoryArcaneFoci},
 							Label:      "Choose an arcane focus",
 						},
 					},
@@ -1731,7 +1984,7 @@ func getWarlockEquipmentRequirements() []*EquipmentRequirement {
 						{
 							Choose:     1,
 							Type:       "tool",
-							Categories: []shared.EquipmentCategory{shared.EquipmentCategory("arcane-foci")},
+							Categories: []shared.EquipmentCategory{EquipmentCategoryArcaneFoci},
 							Label:      "Choose an arcane focus",
 						},
 					},
@@ -1792,5 +2045,17 @@ func GetClassRequirementsWithSubclass(class classes.Class, level int, subclass c
 	// Apply the modifications
 	ApplySubclassModifications(reqs, mods)
 
+	// Validate spells against the subclass's additions to the spell list
+	for _, cantripReq := range append([]*CantripRequirement{reqs.Cantrips}, reqs.AdditionalCantrips...) {
+		if cantripReq != nil {
+			cantripReq.Subclass = subclass
+		}
+	}
+	for _, spellReq := range append([]*SpellbookRequirement{reqs.Spellbook}, reqs.AdditionalSpells...) {
+		if spellReq != nil {
+			spellReq.Subclass = subclass
+		}
+	}
+
 	return reqs
 }
@@ -85,3 +85,22 @@ func (s *RequirementsDetailTestSuite) TestAllClassesEquipmentItemsHaveDetails()
 		})
 	}
 }
+
+func (s *RequirementsDetailTestSuite) TestRangerLevelRequirements() {
+	level1 := GetClassRequirementsAtLevel(classes.Ranger, 1)
+	s.Nil(level1.FightingStyle, "rangers choose a fighting style at level 2")
+	s.Nil(level1.Spellbook, "rangers learn spells at level 2")
+	s.Require().NotNil(level1.FavoredEnemy)
+	s.Equal(RangerFavoredEnemy, level1.FavoredEnemy.ID)
+	s.Equal(1, level1.FavoredEnemy.Count)
+	s.Require().NotNil(level1.FavoredTerrain)
+	s.Equal(RangerFavoredTerrain, level1.FavoredTerrain.ID)
+
+	level2 := GetClassRequirementsAtLevel(classes.Ranger, 2)
+	s.Require().NotNil(level2.FightingStyle)
+	s.Equal(RangerFightingStyle, level2.FightingStyle.ID)
+	s.Require().NotNil(level2.Spellbook)
+	s.Equal(RangerSpells2, level2.Spellbook.ID)
+	s.Equal(2, level2.Spellbook.Count)
+	s.Equal(1, level2.Spellbook.SpellLevel)
+}
@@ -0,0 +1,166 @@
+package choices
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/classes"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/spells"
+)
+
+// SpellListValidationTestSuite tests validating cantrips and spells against class spell lists
+type SpellListValidationTestSuite struct {
+	suite.Suite
+	validator *Validator
+}
+
+func TestSpellListValidationSuite(t *testing.T) {
+	suite.Run(t, new(SpellListValidationTestSuite))
+}
+
+func (s *SpellListValidationTestSuite) SetupTest() {
+	s.validator = NewValidator()
+}
+
+// messages returns the messages of the errors for a choice
+func (s *SpellListValidationTestSuite) messages(result *ValidationResult, choiceID ChoiceID) []string {
+	var messages []string
+	for _, err := range result.Errors {
+		if err.ChoiceID == choiceID {
+			messages = append(messages, err.Message)
+		}
+	}
+	return messages
+}
+
+func (s *SpellListValidationTestSuite) TestErrorPerIllegalSelection() {
+	submissions := NewSubmissions()
+	submissions.Add(Submission{
+		Category: shared.ChoiceSpells,
+		Source:   shared.SourceClass,
+		ChoiceID: "wizard-spells-5",
+		Values:   []shared.SelectionID{spells.CureWounds, spells.Fireball},
+	})
+
+	result := s.validator.Validate(GetClassLevelUpRequirements(classes.Wizard, 5), submissions)
+	s.Equal([]string{"Cure Wounds is not on the wizard spell list"}, s.messages(result, "wizard-spells-5"))
+
+	submissions = NewSubmissions()
+	submissions.Add(Submission{
+		Category: shared.ChoiceSpells,
+		Source:   shared.SourceClass,
+		ChoiceID: "wizard-spells-3",
+		Values:   []shared.SelectionID{spells.CureWounds, spells.Fireball},
+	})
+
+	result = s.validator.Validate(GetClassLevelUpRequirements(classes.Wizard, 3), submissions)
+	s.Equal([]string{
+		"Cure Wounds is not on the wizard spell list",
+		"Fireball is a 3rd-level spell, above 2nd level",
+	}, s.messages(result, "wizard-spells-3"))
+}
+
+func (s *SpellListValidationTestSuite) TestSpellSelections() {
+	testCases := []struct {
+		name    string
+		class   classes.Class
+		level   int
+		spell   spells.Spell
+		message string
+	}{
+		{"spell on the list", classes.Sorcerer, 5, spells.LightningBolt, ""},
+		{"spell from another list", classes.Sorcerer, 5, spells.SpiritGuardians, "Spirit Guardians is not on the sorcerer spell list"},
+		{"cantrip", classes.Bard, 3, spells.ViciousMockery, "Vicious Mockery is a cantrip, not a spell of up to 2nd level"},
+		{"unknown spell", classes.Warlock, 5, "wish", "Wish is not on the warlock spell list"},
+		{"not in the 1st-level options", classes.Wizard, 2, spells.FeatherFall, "Feather Fall is not in the allowed options"},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			choiceID := SpellsChoiceID(tc.class, tc.level)
+			submissions := NewSubmissions()
+			submissions.Add(Submission{
+				Category: shared.ChoiceSpells,
+				Source:   shared.SourceClass,
+				ChoiceID: choiceID,
+				Values:   []shared.SelectionID{tc.spell},
+			})
+
+			reqs := &Requirements{Spellbook: getLevelSpells(tc.class, tc.level)}
+			reqs.Spellbook.Count = 1
+			result := s.validator.Validate(reqs, submissions)
+			if tc.message == "" {
+				s.True(result.Valid, "errors: %v", result.Errors)
+				return
+			}
+			s.Equal([]string{tc.message}, s.messages(result, choiceID))
+		})
+	}
+}
+
+func (s *SpellListValidationTestSuite) TestCantripSelections() {
+	submissions := NewSubmissions()
+	submissions.Add(Submission{
+		Category: shared.ChoiceCantrips,
+		Source:   shared.SourceClass,
+		ChoiceID: WizardCantrips1,
+		Values:   []shared.SelectionID{spells.FireBolt, spells.SacredFlame, spells.MagicMissile},
+	})
+
+	result := s.validator.Validate(GetClassRequirements(classes.Wizard), submissions)
+	s.Equal([]string{
+		"Sacred Flame is not on the wizard spell list",
+		"Magic Missile is not a cantrip",
+	}, s.messages(result, WizardCantrips1))
+}
+
+func (s *SpellListValidationTestSuite) TestPatronExpandedSpells() {
+	reqs := GetClassLevelUpRequirements(classes.Warlock, 5)
+	spellsAt5 := func() *Submissions {
+		submissions := NewSubmissions()
+		submissions.Add(Submission{
+			Category: shared.ChoiceSpells,
+			Source:   shared.SourceClass,
+			ChoiceID: reqs.Spellbook.ID,
+			Values:   []shared.SelectionID{spells.Fireball},
+		})
+		return submissions
+	}
+
+	result := s.validator.Validate(reqs, spellsAt5())
+	s.Equal([]string{"Fireball is not on the warlock spell list"}, s.messages(result, reqs.Spellbook.ID))
+
+	s.Run("patron on the requirement", func() {
+		result := s.validator.Validate(GetClassRequirementsWithSubclass(classes.Warlock, 5, classes.Fiend), spellsAt5())
+		s.Empty(s.messages(result, reqs.Spellbook.ID))
+	})
+
+	s.Run("patron submitted", func() {
+		submissions := spellsAt5()
+		submissions.Add(Submission{
+			Category: shared.ChoiceClass,
+			Source:   shared.SourceClass,
+			ChoiceID: WarlockPatron,
+			Values:   []shared.SelectionID{classes.Fiend},
+		})
+		result := s.validator.Validate(reqs, submissions)
+		s.Empty(s.messages(result, reqs.Spellbook.ID))
+	})
+}
+
+func (s *SpellListValidationTestSuite) TestSubclassOptionsFromAnotherList() {
+	reqs := GetClassRequirementsWithSubclass(classes.Cleric, 1, classes.NatureDomain)
+
+	submissions := NewSubmissions()
+	submissions.Add(Submission{
+		Category: shared.ChoiceCantrips,
+		Source:   shared.SourceClass,
+		ChoiceID: ClericCantrips1,
+		Values:   []shared.SelectionID{spells.Thornwhip, spells.Guidance, spells.Light, spells.Thaumaturgy},
+	})
+
+	result := s.validator.Validate(reqs, submissions)
+	s.Empty(s.messages(result, ClericCantrips1), "the Nature Domain's druid cantrip is allowed")
+}
@@ -2,10 +2,11 @@ package choices
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/classes"
-	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/equipment"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/races"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
@@ -93,6 +94,14 @@ func (v *Validator) Validate(requirements *Requirements, submissions *Submission
 		}
 	}
 
+	// Validate tools or languages (for customized backgrounds)
+	if requirements.ToolsOrLanguages != nil {
+		if err := v.validateToolsOrLanguages(requirements.ToolsOrLanguages, submissions); err != nil {
+			result.Valid = false
+			result.Errors = append(result.Errors, *err)
+		}
+	}
+
 	// Validate subclass
 	if requirements.Subclass != nil {
 		if err := v.validateSubclass(requirements.Subclass, submissions); err != nil {
@@ -125,6 +134,67 @@ func (v *Validator) Validate(requirements *Requirements, submissions *Submission
 		}
 	}
 
+	// Validate expertise gained on later levels
+	for _, expertiseReq := range requirements.AdditionalExpertise {
+		if err := v.validateExpertise(expertiseReq, submissions); err != nil {
+			result.Valid = false
+			result.Errors = append(result.Errors, *err)
+		}
+	}
+
+	// Validate ability score improvements
+	for _, asiReq := range requirements.AbilityScoreImprovements {
+		if err := v.validateAbilityScoreImprovement(asiReq, submissions); err != nil {
+			result.Valid = false
+			result.Errors = append(result.Errors, *err)
+		}
+	}
+
+	// Validate favored enemy and terrain (for rangers)
+	if requirements.FavoredEnemy != nil {
+		if err := v.validateFavoredEnemy(requirements.FavoredEnemy, submissions); err != nil {
+			result.Valid = false
+			result.Errors = append(result.Errors, *err)
+		}
+	}
+
+	if requirements.FavoredTerrain != nil {
+		if err := v.validateFavoredTerrain(requirements.FavoredTerrain, submissions); err != nil {
+			result.Valid = false
+			result.Errors = append(result.Errors, *err)
+		}
+	}
+
+	// Validate racial trait choices (e.g., draconic ancestry)
+	if requirements.Traits != nil {
+		if err := v.validateTraits(requirements.Traits, submissions); err != nil {
+			result.Valid = false
+			result.Errors = append(result.Errors, *err)
+		}
+	}
+
+	// Validate variant human and custom lineage choices
+	if requirements.AbilityIncreases != nil {
+		if err := v.validateAbilityIncreases(requirements.AbilityIncreases, submissions); err != nil {
+			result.Valid = false
+			result.Errors = append(result.Errors, *err)
+		}
+	}
+
+	if requirements.Feats != nil {
+		if err := v.validateFeats(requirements.Feats, submissions); err != nil {
+			result.Valid = false
+			result.Errors = append(result.Errors, *err)
+		}
+	}
+
+	if requirements.VariableTrait != nil {
+		if err := v.validateVariableTrait(requirements.VariableTrait, submissions); err != nil {
+			result.Valid = false
+			result.Errors = append(result.Errors, *err)
+		}
+	}
+
 	// Validate spellbook (for wizards)
 	if requirements.Spellbook != nil {
 		if err := v.validateSpellbook(requirements.Spellbook, submissions); err != nil {
@@ -133,6 +203,26 @@ func (v *Validator) Validate(requirements *Requirements, submissions *Submission
 		}
 	}
 
+	// Validate cantrips and spells learned on later levels
+	for _, cantripReq := range requirements.AdditionalCantrips {
+		if err := v.validateCantrips(cantripReq, submissions); err != nil {
+			result.Valid = false
+			result.Errors = append(result.Errors, *err)
+		}
+	}
+	for _, spellReq := range requirements.AdditionalSpells {
+		if err := v.validateSpellbook(spellReq, submissions); err != nil {
+			result.Valid = false
+			result.Errors = append(result.Errors, *err)
+		}
+	}
+
+	// Validate each chosen cantrip and spell against its spell list
+	if errs := v.validateSpellLists(requirements, submissions); len(errs) > 0 {
+		result.Valid = false
+		result.Errors = append(result.Errors, errs...)
+	}
+
 	return result
 }
 
@@ -167,6 +257,13 @@ func (v *Validator) validateSkills(req *SkillRequirement, submissions *Submissio
 			found = true
 			totalChosen += len(sub.Values)
 			for _, skillID := range sub.Values {
+				if chosenSkills[skillID] {
+					return &ValidationError{
+						Category: shared.ChoiceSkills,
+						ChoiceID: req.ID,
+						Message:  fmt.Sprintf("Skill '%s' chosen more than once", skillID),
+					}
+				}
 				chosenSkills[skillID] = true
 			}
 		}
@@ -281,7 +378,7 @@ func (v *Validator) validateEquipmentCategory(
 			}
 
 			// Get all valid equipment IDs for the categories
-			validEquipment, err := equipment.GetByCategory(req.Type, req.Categories)
+			validEquipment, err := ExpandEquipmentCategories(req.Type, req.Categories)
 			if err != nil {
 				return &ValidationError{
 					Category: shared.ChoiceEquipment,
@@ -387,6 +484,56 @@ func (v *Validator) validateTools(req *ToolRequirement, submissions *Submissions
 	return nil
 }
 
+func (v *Validator) validateToolsOrLanguages(
+	req *ToolOrLanguageRequirement,
+	submissions *Submissions,
+) *ValidationError {
+	allowed := make(map[shared.SelectionID]bool)
+	for _, tool := range req.ToolOptions {
+		allowed[shared.SelectionID(tool)] = true
+	}
+	for _, lang := range req.LanguageOptions {
+		allowed[lang] = true
+	}
+
+	// Tools and languages are submitted separately under the same choice ID
+	chosen := make(map[shared.SelectionID]bool)
+	for _, category := range []shared.ChoiceCategory{shared.ChoiceToolProficiency, shared.ChoiceLanguages} {
+		for _, sub := range submissions.GetByCategory(category) {
+			if sub.ChoiceID != req.ID {
+				continue
+			}
+			for _, value := range sub.Values {
+				if !allowed[value] {
+					return &ValidationError{
+						Category: category,
+						ChoiceID: req.ID,
+						Message:  fmt.Sprintf("Invalid tool or language choice '%s'", value),
+					}
+				}
+				if chosen[value] {
+					return &ValidationError{
+						Category: category,
+						ChoiceID: req.ID,
+						Message:  fmt.Sprintf("'%s' chosen more than once", value),
+					}
+				}
+				chosen[value] = true
+			}
+		}
+	}
+
+	if len(chosen) != req.Count {
+		return &ValidationError{
+			Category: shared.ChoiceToolProficiency,
+			ChoiceID: req.ID,
+			Message:  fmt.Sprintf("%s: Must choose exactly %d tools or languages, got %d", req.Label, req.Count, len(chosen)),
+		}
+	}
+
+	return nil
+}
+
 type validateChoiceInput struct {
 	Submissions []Submission
 	ChoiceID    ChoiceID
@@ -467,11 +614,12 @@ func (v *Validator) validateSubclass(req *SubclassRequirement, submissions *Subm
 	})
 }
 
+// validateCantrips validates the number of cantrips chosen. The cantrips
+// themselves are checked by validateSpellLists.
 func (v *Validator) validateCantrips(req *CantripRequirement, submissions *Submissions) *ValidationError {
 	return v.validateChoice(validateChoiceInput{
 		Submissions: submissions.GetByCategory(shared.ChoiceCantrips),
 		ChoiceID:    req.ID,
-		Options:     req.Options,
 		Label:       req.Label,
 		Category:    shared.ChoiceCantrips,
 		ItemName:    "cantrip",
@@ -491,6 +639,47 @@ func (v *Validator) validateFightingStyle(req *FightingStyleRequirement, submiss
 	})
 }
 
+func (v *Validator) validateFavoredEnemy(req *FavoredEnemyRequirement, submissions *Submissions) *ValidationError {
+	options := make([]shared.SelectionID, len(req.Options))
+	for i, creatureType := range req.Options {
+		options[i] = shared.SelectionID(creatureType)
+	}
+
+	return v.validateChoice(validateChoiceInput{
+		Submissions: submissions.GetByCategory(shared.ChoiceFavoredEnemy),
+		ChoiceID:    req.ID,
+		Options:     options,
+		Label:       req.Label,
+		Category:    shared.ChoiceFavoredEnemy,
+		ItemName:    "favored enemy",
+		Count:       req.Count,
+	})
+}
+
+func (v *Validator) validateFavoredTerrain(req *FavoredTerrainRequirement, submissions *Submissions) *ValidationError {
+	return v.validateChoice(validateChoiceInput{
+		Submissions: submissions.GetByCategory(shared.ChoiceFavoredTerrain),
+		ChoiceID:    req.ID,
+		Options:     req.Options,
+		Label:       req.Label,
+		Category:    shared.ChoiceFavoredTerrain,
+		ItemName:    "favored terrain",
+		Count:       req.Count,
+	})
+}
+
+func (v *Validator) validateTraits(req *TraitRequirement, submissions *Submissions) *ValidationError {
+	return v.validateChoice(validateChoiceInput{
+		Submissions: submissions.GetByCategory(shared.ChoiceTraits),
+		ChoiceID:    req.ID,
+		Options:     req.Options,
+		Label:       req.Label,
+		Category:    shared.ChoiceTraits,
+		ItemName:    "trait",
+		Count:       req.Count,
+	})
+}
+
 func (v *Validator) validateExpertise(req *ExpertiseRequirement, submissions *Submissions) *ValidationError {
 	// Find expertise submissions
 	expertiseSubs := submissions.GetByCategory(shared.ChoiceExpertise)
@@ -523,6 +712,8 @@ func (v *Validator) validateExpertise(req *ExpertiseRequirement, submissions *Su
 	return nil
 }
 
+// validateSpellbook validates the number of spells chosen. The spells
+// themselves are checked by validateSpellLists.
 func (v *Validator) validateSpellbook(req *SpellbookRequirement, submissions *Submissions) *ValidationError {
 	// Find spellbook submissions
 	spellSubs := submissions.GetByCategory(shared.ChoiceSpells)
@@ -533,24 +724,6 @@ func (v *Validator) validateSpellbook(req *SpellbookRequirement, submissions *Su
 		if sub.ChoiceID == req.ID {
 			found = true
 			totalChosen += len(sub.Values)
-
-			// Validate chosen spells are in the allowed options
-			if len(req.Options) > 0 {
-				allowedSpells := make(map[spells.Spell]bool)
-				for _, spell := range req.Options {
-					allowedSpells[spell] = true
-				}
-
-				for _, chosenSpell := range sub.Values {
-					if !allowedSpells[chosenSpell] {
-						return &ValidationError{
-							Category: shared.ChoiceSpells,
-							ChoiceID: req.ID,
-							Message:  fmt.Sprintf("Spell '%s' is not in the allowed options", chosenSpell),
-						}
-					}
-				}
-			}
 		}
 	}
 
@@ -573,6 +746,259 @@ func (v *Validator) validateSpellbook(req *SpellbookRequirement, submissions *Su
 	return nil
 }
 
+// spellListInput describes one cantrip or spell choice for validateSpellSelections
+type spellListInput struct {
+	Submissions []Submission
+	ChoiceID    ChoiceID
+	Category    shared.ChoiceCategory
+	Class       classes.Class
+	Subclass    classes.Subclass
+	Options     []spells.Spell
+	MaxLevel    int // 0 for cantrips
+}
+
+// validateSpellLists checks every chosen cantrip and spell against its
+// requirement, returning an error per illegal selection
+func (v *Validator) validateSpellLists(requirements *Requirements, submissions *Submissions) []ValidationError {
+	var errs []ValidationError
+
+	for _, req := range append([]*CantripRequirement{requirements.Cantrips}, requirements.AdditionalCantrips...) {
+		if req == nil {
+			continue
+		}
+		errs = append(errs, v.validateSpellSelections(spellListInput{
+			Submissions: submissions.GetByCategory(shared.ChoiceCantrips),
+			ChoiceID:    req.ID,
+			Category:    shared.ChoiceCantrips,
+			Class:       req.Class,
+			Subclass:    submittedSubclass(req.Class, req.Subclass, submissions),
+			Options:     req.Options,
+		})...)
+	}
+
+	for _, req := range append([]*SpellbookRequirement{requirements.Spellbook}, requirements.AdditionalSpells...) {
+		if req == nil {
+			continue
+		}
+		errs = append(errs, v.validateSpellSelections(spellListInput{
+			Submissions: submissions.GetByCategory(shared.ChoiceSpells),
+			ChoiceID:    req.ID,
+			Category:    shared.ChoiceSpells,
+			Class:       req.Class,
+			Subclass:    submittedSubclass(req.Class, req.Subclass, submissions),
+			Options:     req.Options,
+			MaxLevel:    req.SpellLevel,
+		})...)
+	}
+
+	return errs
+}
+
+// validateSpellSelections validates each spell chosen for one requirement.
+// A spell in Options is always allowed, so subclasses can offer spells from
+// other lists (e.g., the Nature Domain's druid cantrip). Any other spell must
+// be on the class's spell list, or its subclass's additions to it, and be of
+// the right level. With neither Options nor a Class any spell is allowed.
+func (v *Validator) validateSpellSelections(input spellListInput) []ValidationError {
+	var errs []ValidationError
+	for _, sub := range input.Submissions {
+		if sub.ChoiceID != input.ChoiceID {
+			continue
+		}
+		for _, chosen := range sub.Values {
+			if message := spellSelectionError(input, chosen); message != "" {
+				errs = append(errs, ValidationError{
+					Category: input.Category,
+					ChoiceID: input.ChoiceID,
+					Message:  message,
+				})
+			}
+		}
+	}
+	return errs
+}
+
+// spellSelectionError returns why a spell can't be chosen, or "" if it can
+func spellSelectionError(input spellListInput, chosen spells.Spell) string {
+	if slices.Contains(input.Options, chosen) {
+		return ""
+	}
+
+	name := spellDisplayName(chosen)
+	level, known := spells.Level(chosen)
+
+	switch {
+	case input.Class != "" && !spells.OnSpellList(input.Class, input.Subclass, chosen):
+		return fmt.Sprintf("%s is not on the %s spell list", name, input.Class)
+	case known && input.MaxLevel == 0 && level > 0:
+		return fmt.Sprintf("%s is not a cantrip", name)
+	case known && input.MaxLevel > 0 && level == 0:
+		return fmt.Sprintf("%s is a cantrip, not a spell of up to %s level", name, ordinal(input.MaxLevel))
+	case known && level > input.MaxLevel:
+		return fmt.Sprintf("%s is a %s-level spell, above %s level", name, ordinal(level), ordinal(input.MaxLevel))
+	case len(input.Options) > 0:
+		return fmt.Sprintf("%s is not in the allowed options", name)
+	default:
+		return ""
+	}
+}
+
+// submittedSubclass returns subclass, or if it's empty the subclass submitted
+// for the class, e.g., a warlock's patron chosen at level 1
+func submittedSubclass(classID classes.Class, subclass classes.Subclass, submissions *Submissions) classes.Subclass {
+	if subclass != "" || classID == "" {
+		return subclass
+	}
+
+	for _, sub := range submissions.GetByCategory(shared.ChoiceClass) {
+		for _, value := range sub.Values {
+			if classes.SubclassParent(value) == classID {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+func (v *Validator) validateAbilityScoreImprovement(
+	req *AbilityScoreImprovementRequirement,
+	submissions *Submissions,
+) *ValidationError {
+	fail := func(message string) *ValidationError {
+		return &ValidationError{
+			Category: shared.ChoiceAbilityScores,
+			ChoiceID: req.ID,
+			Message:  fmt.Sprintf("%s: %s", req.Label, message),
+		}
+	}
+
+	var chosen *Submission
+	for _, sub := range submissions.GetByCategory(shared.ChoiceAbilityScores) {
+		if sub.ChoiceID == req.ID {
+			chosen = &sub
+			break
+		}
+	}
+	if chosen == nil {
+		return fail("Must increase ability scores or choose a feat")
+	}
+
+	switch chosen.OptionID {
+	case AbilityScoreImprovementIncrease:
+		if len(chosen.Values) != req.Points {
+			return fail(fmt.Sprintf("Must choose exactly %d ability increases, got %d", req.Points, len(chosen.Values)))
+		}
+		for _, value := range chosen.Values {
+			if _, err := abilities.GetByID(string(value)); err != nil {
+				return fail(fmt.Sprintf("Invalid ability '%s'", value))
+			}
+		}
+	case AbilityScoreImprovementFeat:
+		if len(chosen.Values) != 1 || chosen.Values[0] == "" {
+			return fail("Must choose exactly 1 feat")
+		}
+	default:
+		return fail(fmt.Sprintf("Invalid option '%s'", chosen.OptionID))
+	}
+
+	return nil
+}
+
+func (v *Validator) validateAbilityIncreases(
+	req *AbilityIncreaseRequirement,
+	submissions *Submissions,
+) *ValidationError {
+	fail := func(message string) *ValidationError {
+		return &ValidationError{
+			Category: shared.ChoiceAbilityScores,
+			ChoiceID: req.ID,
+			Message:  fmt.Sprintf("%s: %s", req.Label, message),
+		}
+	}
+
+	var chosen *Submission
+	for _, sub := range submissions.GetByCategory(shared.ChoiceAbilityScores) {
+		if sub.ChoiceID == req.ID {
+			chosen = &sub
+			break
+		}
+	}
+	if chosen == nil {
+		return fail(fmt.Sprintf("Must choose %d abilities", req.Count))
+	}
+
+	if len(chosen.Values) != req.Count {
+		return fail(fmt.Sprintf("Must choose exactly %d abilities, got %d", req.Count, len(chosen.Values)))
+	}
+
+	seen := make(map[shared.SelectionID]bool)
+	for _, value := range chosen.Values {
+		ability, err := abilities.GetByID(string(value))
+		if err != nil {
+			return fail(fmt.Sprintf("Invalid ability '%s'", value))
+		}
+		if len(req.Options) > 0 && !slices.Contains(req.Options, ability) {
+			return fail(fmt.Sprintf("Ability '%s' cannot be increased", value))
+		}
+		if seen[value] {
+			return fail(fmt.Sprintf("Ability '%s' chosen more than once", value))
+		}
+		seen[value] = true
+	}
+
+	return nil
+}
+
+func (v *Validator) validateFeats(req *FeatRequirement, submissions *Submissions) *ValidationError {
+	return v.validateChoice(validateChoiceInput{
+		Submissions: submissions.GetByCategory(shared.ChoiceFeats),
+		ChoiceID:    req.ID,
+		Label:       req.Label,
+		Category:    shared.ChoiceFeats,
+		ItemName:    "feat",
+		Count:       req.Count,
+	})
+}
+
+func (v *Validator) validateVariableTrait(req *VariableTraitRequirement, submissions *Submissions) *ValidationError {
+	fail := func(message string) *ValidationError {
+		return &ValidationError{
+			Category: shared.ChoiceTraits,
+			ChoiceID: req.ID,
+			Message:  fmt.Sprintf("%s: %s", req.Label, message),
+		}
+	}
+
+	var chosen *Submission
+	for _, sub := range submissions.GetByCategory(shared.ChoiceTraits) {
+		if sub.ChoiceID == req.ID {
+			chosen = &sub
+			break
+		}
+	}
+	if chosen == nil {
+		return fail("Must choose darkvision or a skill proficiency")
+	}
+
+	switch chosen.OptionID {
+	case CustomLineageDarkvision:
+		if len(chosen.Values) != 0 {
+			return fail("Darkvision takes no selections")
+		}
+	case CustomLineageSkillProficiency:
+		if len(chosen.Values) != 1 {
+			return fail("Must choose exactly 1 skill")
+		}
+		if _, err := skills.GetByID(string(chosen.Values[0])); err != nil {
+			return fail(fmt.Sprintf("Invalid skill '%s'", chosen.Values[0]))
+		}
+	default:
+		return fail(fmt.Sprintf("Invalid option '%s'", chosen.OptionID))
+	}
+
+	return nil
+}
+
 // mergeRequirements merges multiple requirement sets
 func mergeRequirements(reqs ...*Requirements) *Requirements {
 	merged := &Requirements{}
@@ -589,6 +1015,8 @@ func mergeRequirements(reqs ...*Requirements) *Requirements {
 			}
 		}
 
+		merged.AdditionalSkills = append(merged.AdditionalSkills, req.AdditionalSkills...)
+
 		// Merge equipment (append all)
 		merged.Equipment = append(merged.Equipment, req.Equipment...)
 
@@ -609,6 +1037,11 @@ func mergeRequirements(reqs ...*Requirements) *Requirements {
 			}
 		}
 
+		// Take first tools-or-languages requirement
+		if req.ToolsOrLanguages != nil && merged.ToolsOrLanguages == nil {
+			merged.ToolsOrLanguages = req.ToolsOrLanguages
+		}
+
 		// Take first fighting style requirement
 		if req.FightingStyle != nil && merged.FightingStyle == nil {
 			merged.FightingStyle = req.FightingStyle
@@ -618,6 +1051,34 @@ func mergeRequirements(reqs ...*Requirements) *Requirements {
 		if req.Expertise != nil && merged.Expertise == nil {
 			merged.Expertise = req.Expertise
 		}
+		merged.AdditionalExpertise = append(merged.AdditionalExpertise, req.AdditionalExpertise...)
+
+		// Merge ability score improvements (append all)
+		merged.AbilityScoreImprovements = append(merged.AbilityScoreImprovements, req.AbilityScoreImprovements...)
+
+		// Take first favored enemy and terrain requirements
+		if req.FavoredEnemy != nil && merged.FavoredEnemy == nil {
+			merged.FavoredEnemy = req.FavoredEnemy
+		}
+		if req.FavoredTerrain != nil && merged.FavoredTerrain == nil {
+			merged.FavoredTerrain = req.FavoredTerrain
+		}
+
+		// Take first trait requirement
+		if req.Traits != nil && merged.Traits == nil {
+			merged.Traits = req.Traits
+		}
+
+		// Take first variant human and custom lineage requirements
+		if req.AbilityIncreases != nil && merged.AbilityIncreases == nil {
+			merged.AbilityIncreases = req.AbilityIncreases
+		}
+		if req.Feats != nil && merged.Feats == nil {
+			merged.Feats = req.Feats
+		}
+		if req.VariableTrait != nil && merged.VariableTrait == nil {
+			merged.VariableTrait = req.VariableTrait
+		}
 
 		// Take first subclass requirement
 		if req.Subclass != nil && merged.Subclass == nil {
@@ -160,9 +160,9 @@ func (s *CombatAbilitiesTestSuite) TestCharacterGetsStandardCombatAbilities() {
 		s.Require().NoError(err)
 
 		abilities := char.GetCombatAbilities()
-		s.Assert().Len(abilities, 6, "character should have exactly 6 standard combat abilities")
+		s.Assert().Len(abilities, 9, "character should have exactly 9 standard combat abilities")
 
-		// Verify all six are present
+		// Verify all nine are present
 		abilityNames := make(map[string]bool)
 		for _, ability := range abilities {
 			abilityNames[ability.Name()] = true
@@ -174,6 +174,9 @@ func (s *CombatAbilitiesTestSuite) TestCharacterGetsStandardCombatAbilities() {
 		s.Assert().True(abilityNames["Disengage"], "should have Disengage")
 		s.Assert().True(abilityNames["Help"], "should have Help")
 		s.Assert().True(abilityNames["Hide"], "should have Hide")
+		s.Assert().True(abilityNames["Search"], "should have Search")
+		s.Assert().True(abilityNames["Use Object"], "should have Use Object")
+		s.Assert().True(abilityNames["Ready"], "should have Ready")
 	})
 }
 
@@ -23,10 +23,12 @@ import (
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/features"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/fightingstyles"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/languages"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/races"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/resources"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/terrains"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/weapons"
 )
 
@@ -492,3 +494,145 @@ func (d *DummyEntity) GetID() string {
 func (d *DummyEntity) GetType() core.EntityType {
 	return "test"
 }
+
+func (s *CharacterConditionsTestSuite) TestRangerGetsFavoredEnemyAndNaturalExplorer() {
+	draft := LoadDraftFromData(&DraftData{
+		ID:       "test-ranger",
+		PlayerID: "player1",
+	})
+
+	s.Require().NoError(draft.SetName(&SetNameInput{Name: "Minsc"}))
+	s.Require().NoError(draft.SetRace(&SetRaceInput{
+		RaceID:  races.Human,
+		Choices: RaceChoices{Languages: []languages.Language{languages.Elvish}},
+	}))
+	s.Require().NoError(draft.SetClass(&SetClassInput{
+		ClassID: classes.Ranger,
+		Choices: ClassChoices{
+			Skills: []skills.Skill{skills.AnimalHandling, skills.Stealth, skills.Survival},
+			Equipment: []EquipmentChoiceSelection{
+				{ChoiceID: choices.RangerArmor, OptionID: choices.RangerArmorScale},
+				{ChoiceID: choices.RangerWeaponsPrimary, OptionID: choices.RangerWeaponShortswords},
+				{ChoiceID: choices.RangerPack, OptionID: choices.RangerPackDungeoneer},
+			},
+			FavoredEnemies:  []monster.CreatureType{monster.CreatureTypeUndead},
+			FavoredTerrains: []terrains.Terrain{terrains.Forest},
+		},
+	}))
+	s.Require().NoError(draft.SetBackground(&SetBackgroundInput{
+		BackgroundID: backgrounds.Soldier,
+		Choices:      BackgroundChoices{},
+	}))
+	s.Require().NoError(draft.SetAbilityScores(&SetAbilityScoresInput{
+		Scores: shared.AbilityScores{
+			abilities.STR: 12, abilities.DEX: 16, abilities.CON: 14,
+			abilities.INT: 10, abilities.WIS: 14, abilities.CHA: 8,
+		},
+	}))
+
+	char, err := draft.ToCharacter(s.ctx, "ranger-1", s.bus)
+	s.Require().NoError(err)
+
+	var favored *conditions.FavoredEnemyCondition
+	var explorer *conditions.NaturalExplorerCondition
+	for _, cond := range char.GetConditions() {
+		switch c := cond.(type) {
+		case *conditions.FavoredEnemyCondition:
+			favored = c
+		case *conditions.NaturalExplorerCondition:
+			explorer = c
+		}
+	}
+
+	s.Require().NotNil(favored, "ranger should have favored enemy")
+	s.True(favored.IsApplied())
+	s.True(favored.IsFavoredEnemy(monster.CreatureTypeUndead))
+	s.False(favored.IsFavoredEnemy(monster.CreatureTypeBeast))
+
+	s.Require().NotNil(explorer, "ranger should have natural explorer")
+	s.True(explorer.IsFavoredTerrain(terrains.Forest))
+	s.False(explorer.IsFavoredTerrain(terrains.Desert))
+
+	s.Empty(char.ToData().SpellSlots, "rangers have no spell slots at level 1")
+}
+
+// rangerDraft builds a level 1 ranger draft of the given race for racial trait tests
+func (s *CharacterConditionsTestSuite) rangerDraft(id string, race *SetRaceInput) *Draft {
+	draft := LoadDraftFromData(&DraftData{
+		ID:       id,
+		PlayerID: "player1",
+	})
+
+	s.Require().NoError(draft.SetName(&SetNameInput{Name: "Ranger"}))
+	s.Require().NoError(draft.SetRace(race))
+	s.Require().NoError(draft.SetClass(&SetClassInput{
+		ClassID: classes.Ranger,
+		Choices: ClassChoices{
+			Skills: []skills.Skill{skills.AnimalHandling, skills.Stealth, skills.Survival},
+			Equipment: []EquipmentChoiceSelection{
+				{ChoiceID: choices.RangerArmor, OptionID: choices.RangerArmorScale},
+				{ChoiceID: choices.RangerWeaponsPrimary, OptionID: choices.RangerWeaponShortswords},
+				{ChoiceID: choices.RangerPack, OptionID: choices.RangerPackDungeoneer},
+			},
+			FavoredEnemies:  []monster.CreatureType{monster.CreatureTypeUndead},
+			FavoredTerrains: []terrains.Terrain{terrains.Forest},
+		},
+	}))
+	s.Require().NoError(draft.SetBackground(&SetBackgroundInput{
+		BackgroundID: backgrounds.Soldier,
+		Choices:      BackgroundChoices{},
+	}))
+	s.Require().NoError(draft.SetAbilityScores(&SetAbilityScoresInput{
+		Scores: shared.AbilityScores{
+			abilities.STR: 12, abilities.DEX: 16, abilities.CON: 14,
+			abilities.INT: 10, abilities.WIS: 14, abilities.CHA: 8,
+		},
+	}))
+
+	return draft
+}
+
+func (s *CharacterConditionsTestSuite) TestHalflingGetsLucky() {
+	draft := s.rangerDraft("test-halfling", &SetRaceInput{RaceID: races.Halfling})
+
+	char, err := draft.ToCharacter(s.ctx, "halfling-1", s.bus)
+	s.Require().NoError(err)
+
+	var lucky *conditions.HalflingLuckyCondition
+	for _, cond := range char.GetConditions() {
+		if c, ok := cond.(*conditions.HalflingLuckyCondition); ok {
+			lucky = c
+		}
+	}
+	s.Require().NotNil(lucky, "halfling should have lucky")
+	s.True(lucky.IsApplied())
+	s.Equal("halfling-1", lucky.CharacterID)
+}
+
+func (s *CharacterConditionsTestSuite) TestDragonbornGetsBreathWeapon() {
+	draft := s.rangerDraft("test-dragonborn", &SetRaceInput{
+		RaceID:  races.Dragonborn,
+		Choices: RaceChoices{DraconicAncestry: races.AncestryGreen},
+	})
+
+	char, err := draft.ToCharacter(s.ctx, "dragonborn-1", s.bus)
+	s.Require().NoError(err)
+
+	var breath *features.BreathWeapon
+	for _, feature := range char.GetFeatures() {
+		if b, ok := feature.(*features.BreathWeapon); ok {
+			breath = b
+		}
+	}
+	s.Require().NotNil(breath, "dragonborn should have a breath weapon")
+
+	data, err := breath.ToJSON()
+	s.Require().NoError(err)
+
+	var breathData features.BreathWeaponData
+	s.Require().NoError(json.Unmarshal(data, &breathData))
+	s.Equal(races.AncestryGreen, breathData.Ancestry)
+	// 8 + CON 14 (+2) + proficiency 2
+	s.Equal(12, breathData.SaveDC)
+	s.Equal(1, breathData.Uses)
+}
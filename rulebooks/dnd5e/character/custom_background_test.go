@@ -0,0 +1,212 @@
+package character
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/backgrounds"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/character/choices"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/classes"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/fightingstyles"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/languages"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/proficiencies"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/races"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/weapons"
+)
+
+// CustomBackgroundSuite tests customized backgrounds from choice to character
+type CustomBackgroundSuite struct {
+	suite.Suite
+	eventBus events.EventBus
+	draft    *Draft
+}
+
+func TestCustomBackgroundSuite(t *testing.T) {
+	suite.Run(t, new(CustomBackgroundSuite))
+}
+
+// SetupTest builds a Dwarf Fighter draft with everything but the background
+func (s *CustomBackgroundSuite) SetupTest() {
+	s.eventBus = events.NewEventBus()
+
+	draft, err := NewDraft(&DraftConfig{ID: "custom-bg", PlayerID: "player-1"})
+	s.Require().NoError(err)
+	s.draft = draft
+
+	s.Require().NoError(draft.SetName(&SetNameInput{Name: "Brenna"}))
+	s.Require().NoError(draft.SetRace(&SetRaceInput{
+		RaceID:  races.Dwarf,
+		Choices: RaceChoices{Tools: []shared.SelectionID{string(proficiencies.ToolSmith)}},
+	}))
+	s.Require().NoError(draft.SetClass(&SetClassInput{
+		ClassID: classes.Fighter,
+		Choices: ClassChoices{
+			Skills: []skills.Skill{skills.Athletics, skills.Perception},
+			Equipment: []EquipmentChoiceSelection{
+				{ChoiceID: choices.FighterArmor, OptionID: choices.FighterArmorChainMail},
+				{
+					ChoiceID:           choices.FighterWeaponsPrimary,
+					OptionID:           choices.FighterWeaponMartialShield,
+					CategorySelections: []shared.EquipmentID{weapons.Longsword},
+				},
+				{ChoiceID: choices.FighterWeaponsSecondary, OptionID: choices.FighterRangedCrossbow},
+				{ChoiceID: choices.FighterPack, OptionID: choices.FighterPackExplorer},
+			},
+			FightingStyle: fightingstyles.Defense,
+		},
+	}))
+	s.Require().NoError(draft.SetAbilityScores(&SetAbilityScoresInput{
+		Scores: shared.AbilityScores{
+			abilities.STR: 15,
+			abilities.DEX: 14,
+			abilities.CON: 13,
+			abilities.INT: 12,
+			abilities.WIS: 10,
+			abilities.CHA: 8,
+		},
+		Method: "standard-array",
+	}))
+}
+
+func (s *CustomBackgroundSuite) TestResolvesChosenProficiencies() {
+	s.Require().NoError(s.draft.SetBackground(&SetBackgroundInput{
+		BackgroundID: backgrounds.Custom,
+		Choices: BackgroundChoices{
+			Skills:    []skills.Skill{skills.Arcana, skills.History},
+			Tools:     []shared.SelectionID{string(proficiencies.ToolThieves)},
+			Languages: []languages.Language{languages.Draconic},
+		},
+	}))
+	s.True(s.draft.IsBackgroundComplete())
+	s.True(s.draft.Progress().Has(ProgressBackground))
+
+	char, err := s.draft.ToCharacter(context.Background(), "brenna", s.eventBus)
+	s.Require().NoError(err)
+	data := char.ToData()
+
+	s.Equal(shared.Proficient, data.Skills[skills.Arcana])
+	s.Equal(shared.Proficient, data.Skills[skills.History])
+	s.Equal(shared.Proficient, data.Skills[skills.Athletics], "class skills are kept")
+	s.Contains(data.ToolProficiencies, proficiencies.ToolThieves)
+	s.Contains(data.Languages, languages.Draconic)
+}
+
+func (s *CustomBackgroundSuite) TestAllowsAnyMixOfToolsAndLanguages() {
+	testCases := []struct {
+		name      string
+		tools     []shared.SelectionID
+		languages []languages.Language
+	}{
+		{"two tools", []shared.SelectionID{string(proficiencies.ToolLute), string(proficiencies.ToolDiceSet)}, nil},
+		{"two languages", nil, []languages.Language{languages.Elvish, languages.Infernal}},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.Require().NoError(s.draft.SetBackground(&SetBackgroundInput{
+				BackgroundID: backgrounds.Custom,
+				Choices: BackgroundChoices{
+					Skills:    []skills.Skill{skills.Arcana, skills.History},
+					Tools:     tc.tools,
+					Languages: tc.languages,
+				},
+			}))
+			s.True(s.draft.IsBackgroundComplete())
+			s.NoError(s.draft.ValidateChoices())
+		})
+	}
+}
+
+func (s *CustomBackgroundSuite) TestRejectsInvalidChoices() {
+	testCases := []struct {
+		name    string
+		choices BackgroundChoices
+	}{
+		{"missing tools or languages", BackgroundChoices{
+			Skills: []skills.Skill{skills.Arcana, skills.History},
+		}},
+		{"too many tools and languages", BackgroundChoices{
+			Skills:    []skills.Skill{skills.Arcana, skills.History},
+			Tools:     []shared.SelectionID{string(proficiencies.ToolLute), string(proficiencies.ToolDiceSet)},
+			Languages: []languages.Language{languages.Elvish},
+		}},
+		{"one skill", BackgroundChoices{
+			Skills:    []skills.Skill{skills.Arcana},
+			Languages: []languages.Language{languages.Elvish, languages.Infernal},
+		}},
+		{"duplicate skill", BackgroundChoices{
+			Skills:    []skills.Skill{skills.Arcana, skills.Arcana},
+			Languages: []languages.Language{languages.Elvish, languages.Infernal},
+		}},
+		{"unknown skill", BackgroundChoices{
+			Skills:    []skills.Skill{skills.Arcana, "basket-weaving"},
+			Languages: []languages.Language{languages.Elvish, languages.Infernal},
+		}},
+		{"tool no background offers", BackgroundChoices{
+			Skills:    []skills.Skill{skills.Arcana, skills.History},
+			Tools:     []shared.SelectionID{string(proficiencies.ToolPoisoner)},
+			Languages: []languages.Language{languages.Elvish},
+		}},
+		{"duplicate language", BackgroundChoices{
+			Skills:    []skills.Skill{skills.Arcana, skills.History},
+			Languages: []languages.Language{languages.Elvish, languages.Elvish},
+		}},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.Require().NoError(s.draft.SetBackground(&SetBackgroundInput{
+				BackgroundID: backgrounds.Custom,
+				Choices:      tc.choices,
+			}))
+			s.False(s.draft.IsBackgroundComplete())
+
+			_, err := s.draft.ToCharacter(context.Background(), "brenna", s.eventBus)
+			s.Error(err)
+		})
+	}
+}
+
+func (s *CustomBackgroundSuite) TestSwitchingToFixedBackgroundClearsChoices() {
+	s.Require().NoError(s.draft.SetBackground(&SetBackgroundInput{
+		BackgroundID: backgrounds.Custom,
+		Choices: BackgroundChoices{
+			Skills: []skills.Skill{skills.Arcana, skills.History},
+			Tools:  []shared.SelectionID{string(proficiencies.ToolThieves), string(proficiencies.ToolLute)},
+		},
+	}))
+	s.Require().NoError(s.draft.SetBackground(&SetBackgroundInput{BackgroundID: backgrounds.Soldier}))
+	s.True(s.draft.IsBackgroundComplete())
+
+	char, err := s.draft.ToCharacter(context.Background(), "brenna", s.eventBus)
+	s.Require().NoError(err)
+	data := char.ToData()
+	s.NotContains(data.Skills, skills.Arcana)
+	s.NotContains(data.ToolProficiencies, proficiencies.ToolThieves)
+}
+
+func (s *CustomBackgroundSuite) TestStandardBackgroundGrantsProficiencies() {
+	s.Require().NoError(s.draft.SetBackground(&SetBackgroundInput{BackgroundID: backgrounds.Hermit}))
+	s.True(s.draft.IsBackgroundComplete())
+
+	char, err := s.draft.ToCharacter(context.Background(), "brenna", s.eventBus)
+	s.Require().NoError(err)
+	data := char.ToData()
+
+	s.Equal(shared.Proficient, data.Skills[skills.Medicine])
+	s.Equal(shared.Proficient, data.Skills[skills.Religion])
+	s.Equal(shared.Proficient, data.Skills[skills.Athletics], "class skills are kept")
+	s.Contains(data.ToolProficiencies, proficiencies.ToolHerbalism)
+}
+
+func (s *CustomBackgroundSuite) TestUnknownBackground() {
+	err := s.draft.SetBackground(&SetBackgroundInput{BackgroundID: "pastry-chef"})
+	s.Error(err)
+	s.Empty(s.draft.Background())
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/KirkDiggler/rpg-toolkit/core"
 	coreResources "github.com/KirkDiggler/rpg-toolkit/core/resources"
+	"github.com/KirkDiggler/rpg-toolkit/core/schema"
 	"github.com/KirkDiggler/rpg-toolkit/events"
 	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
@@ -26,6 +27,17 @@ import (
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
 )
 
+// DataKind identifies character Data in a schema.Envelope
+const DataKind = "dnd5e.character"
+
+// DataVersion is the current version of Data. Bump it and register a
+// schema migration from the previous version when Data changes shape.
+const DataVersion = 1
+
+func init() {
+	schema.MustRegister(DataKind, DataVersion)
+}
+
 // Data represents the serializable form of a character
 // This is what gets stored in the database
 type Data struct {
@@ -73,6 +85,11 @@ type Data struct {
 	ClassResources map[shared.ClassResourceType]ResourceData             `json:"class_resources,omitempty"`
 	Resources      map[coreResources.ResourceKey]RecoverableResourceData `json:"resources,omitempty"`
 
+	// Spellcasting stats, derived by ToData for character sheets.
+	// LoadFromData recomputes them from the class, so they are never read back.
+	SpellAttackBonus int `json:"spell_attack_bonus,omitempty"`
+	SpellSaveDC      int `json:"spell_save_dc,omitempty"`
+
 	// Features (rage, second wind, etc)
 	Features []json.RawMessage `json:"features,omitempty"`
 
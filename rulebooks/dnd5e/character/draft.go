@@ -2,7 +2,9 @@ package character
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
 	"time"
 
 	coreResources "github.com/KirkDiggler/rpg-toolkit/core/resources"
@@ -21,8 +23,10 @@ import (
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/features"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/fightingstyles"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/languages"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/proficiencies"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/races"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/resources"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
@@ -231,6 +235,10 @@ func (d *Draft) SetRace(input *SetRaceInput) error {
 			if d.subrace == races.HighElf {
 				choiceID = choices.HighElfLanguage
 			}
+		case races.VariantHuman:
+			choiceID = choices.VariantHumanLanguage
+		case races.CustomLineage:
+			choiceID = choices.CustomLineageLanguage
 		}
 		d.recordChoice(choices.ChoiceData{
 			Category:          shared.ChoiceLanguages,
@@ -240,11 +248,20 @@ func (d *Draft) SetRace(input *SetRaceInput) error {
 		})
 	}
 
-	// Record skill choices (for Half-Elf, etc.)
-	if len(input.Choices.Skills) > 0 {
+	// Record skill choices (for Half-Elf, Variant Human, etc.)
+	// Custom Lineage's skill is recorded with its variable trait below
+	if len(input.Choices.Skills) > 0 && d.race != races.CustomLineage {
+		var choiceID choices.ChoiceID
+		switch d.race {
+		case races.HalfElf:
+			choiceID = choices.HalfElfSkills
+		case races.VariantHuman:
+			choiceID = choices.VariantHumanSkill
+		}
 		d.recordChoice(choices.ChoiceData{
 			Category:       shared.ChoiceSkills,
 			Source:         shared.SourceRace,
+			ChoiceID:       choiceID,
 			SkillSelection: input.Choices.Skills,
 		})
 	}
@@ -277,6 +294,20 @@ func (d *Draft) SetRace(input *SetRaceInput) error {
 		})
 	}
 
+	// Record draconic ancestry (for Dragonborn)
+	if input.Choices.DraconicAncestry != "" {
+		d.recordChoice(choices.ChoiceData{
+			Category:       shared.ChoiceTraits,
+			Source:         shared.SourceRace,
+			ChoiceID:       choices.DragonbornAncestry,
+			TraitSelection: []string{string(input.Choices.DraconicAncestry)},
+		})
+	}
+
+	if err := d.recordLineageChoices(input.Choices); err != nil {
+		return err
+	}
+
 	d.updatedAt = time.Now()
 
 	// Update progress if race choices are complete
@@ -287,6 +318,65 @@ func (d *Draft) SetRace(input *SetRaceInput) error {
 	return nil
 }
 
+// recordLineageChoices records the Variant Human and Custom Lineage choices:
+// ability score increases, a feat, and Custom Lineage's size and variable trait
+func (d *Draft) recordLineageChoices(raceChoices RaceChoices) error {
+	reqs := choices.GetRaceRequirements(d.race)
+
+	if len(raceChoices.AbilityIncreases) > 0 {
+		if reqs.AbilityIncreases == nil {
+			return rpgerr.Newf(rpgerr.CodeInvalidArgument, "race %s has no ability score choices", d.race)
+		}
+		increases := make(shared.AbilityScores)
+		for _, ability := range raceChoices.AbilityIncreases {
+			increases[ability] = reqs.AbilityIncreases.Amount
+		}
+		d.recordChoice(choices.ChoiceData{
+			Category:              shared.ChoiceAbilityScores,
+			Source:                shared.SourceRace,
+			ChoiceID:              reqs.AbilityIncreases.ID,
+			AbilityScoreSelection: increases,
+		})
+	}
+
+	if raceChoices.Feat != "" {
+		if reqs.Feats == nil {
+			return rpgerr.Newf(rpgerr.CodeInvalidArgument, "race %s has no feat choice", d.race)
+		}
+		d.recordChoice(choices.ChoiceData{
+			Category:      shared.ChoiceFeats,
+			Source:        shared.SourceRace,
+			ChoiceID:      reqs.Feats.ID,
+			FeatSelection: []string{raceChoices.Feat},
+		})
+	}
+
+	if d.race != races.CustomLineage {
+		return nil
+	}
+
+	if raceChoices.Size != "" {
+		d.recordChoice(choices.ChoiceData{
+			Category:       shared.ChoiceTraits,
+			Source:         shared.SourceRace,
+			ChoiceID:       choices.CustomLineageSize,
+			TraitSelection: []string{raceChoices.Size},
+		})
+	}
+
+	if raceChoices.VariableTrait != "" {
+		d.recordChoice(choices.ChoiceData{
+			Category:       shared.ChoiceTraits,
+			Source:         shared.SourceRace,
+			ChoiceID:       choices.CustomLineageVariableTrait,
+			OptionID:       raceChoices.VariableTrait,
+			SkillSelection: raceChoices.Skills,
+		})
+	}
+
+	return nil
+}
+
 // SetClass sets the character's class and subclass
 func (d *Draft) SetClass(input *SetClassInput) error {
 	if input == nil {
@@ -306,6 +396,11 @@ func (d *Draft) SetClass(input *SetClassInput) error {
 			classData.SkillCount, len(input.Choices.Skills))
 	}
 
+	// Characters are created at level 1, so only level 1 subclasses can be chosen here
+	if err := classes.ValidateSubclass(input.ClassID, input.SubclassID, 1); err != nil {
+		return err
+	}
+
 	// Clear all existing class choices before recording new ones
 	// This prevents accumulation when changing classes (e.g., Fighter to Barbarian)
 	d.clearChoicesBySource(shared.SourceClass)
@@ -421,7 +516,12 @@ func (d *Draft) SetClass(input *SetClassInput) error {
 			}
 		}
 
-		// TODO: Add background skills when background data is implemented
+		// Add skills granted by a standard background
+		if grant := backgrounds.GetGrants(d.background); grant != nil {
+			for _, skill := range grant.SkillProficiencies {
+				proficientSkills[skill] = true
+			}
+		}
 
 		for _, expertiseSkill := range input.Choices.Expertise {
 			if !proficientSkills[expertiseSkill] {
@@ -442,6 +542,33 @@ func (d *Draft) SetClass(input *SetClassInput) error {
 		})
 	}
 
+	// Record favored enemy and terrain choices (for Rangers)
+	if len(input.Choices.FavoredEnemies) > 0 {
+		var choiceID choices.ChoiceID
+		if requirements.FavoredEnemy != nil {
+			choiceID = requirements.FavoredEnemy.ID
+		}
+		d.recordChoice(choices.ChoiceData{
+			Category:              shared.ChoiceFavoredEnemy,
+			Source:                shared.SourceClass,
+			ChoiceID:              choiceID,
+			FavoredEnemySelection: input.Choices.FavoredEnemies,
+		})
+	}
+
+	if len(input.Choices.FavoredTerrains) > 0 {
+		var choiceID choices.ChoiceID
+		if requirements.FavoredTerrain != nil {
+			choiceID = requirements.FavoredTerrain.ID
+		}
+		d.recordChoice(choices.ChoiceData{
+			Category:                shared.ChoiceFavoredTerrain,
+			Source:                  shared.SourceClass,
+			ChoiceID:                choiceID,
+			FavoredTerrainSelection: input.Choices.FavoredTerrains,
+		})
+	}
+
 	// Record equipment choices
 	if err := d.recordEquipmentChoices(input.Choices.Equipment, requirements); err != nil {
 		return err
@@ -467,18 +594,54 @@ func (d *Draft) SetBackground(input *SetBackgroundInput) error {
 	// This prevents accumulation when changing backgrounds
 	d.clearChoicesBySource(shared.SourceBackground)
 
-	// TODO: Validate background when we have internal background data
+	// Validate background exists
+	if backgrounds.GetData(input.BackgroundID) == nil {
+		return rpgerr.Newf(rpgerr.CodeNotFound, "unknown background: %s", input.BackgroundID)
+	}
+
 	d.background = input.BackgroundID
 
+	// Customized backgrounds choose skills and a pool of tools or languages
+	var skillChoiceID, proficiencyChoiceID choices.ChoiceID
+	if requirements := choices.GetBackgroundRequirements(d.background); requirements != nil {
+		skillChoiceID = requirements.Skills.ID
+		proficiencyChoiceID = requirements.ToolsOrLanguages.ID
+	}
+
 	// Record language choices
 	if len(input.Choices.Languages) > 0 {
 		d.recordChoice(choices.ChoiceData{
 			Category:          shared.ChoiceLanguages,
 			Source:            shared.SourceBackground,
+			ChoiceID:          proficiencyChoiceID,
 			LanguageSelection: input.Choices.Languages,
 		})
 	}
 
+	// Record skill choices (customized background)
+	if len(input.Choices.Skills) > 0 {
+		d.recordChoice(choices.ChoiceData{
+			Category:       shared.ChoiceSkills,
+			Source:         shared.SourceBackground,
+			ChoiceID:       skillChoiceID,
+			SkillSelection: input.Choices.Skills,
+		})
+	}
+
+	// Record tool proficiency choices (customized background)
+	if len(input.Choices.Tools) > 0 {
+		toolSelection := make([]proficiencies.Tool, 0, len(input.Choices.Tools))
+		for _, t := range input.Choices.Tools {
+			toolSelection = append(toolSelection, proficiencies.Tool(t))
+		}
+		d.recordChoice(choices.ChoiceData{
+			Category:      shared.ChoiceToolProficiency,
+			Source:        shared.SourceBackground,
+			ChoiceID:      proficiencyChoiceID,
+			ToolSelection: toolSelection,
+		})
+	}
+
 	d.updatedAt = time.Now()
 
 	// Update progress if background choices are complete
@@ -584,6 +747,15 @@ func (d *Draft) ToCharacter(ctx context.Context, characterID string, bus events.
 		finalScores[ability] += bonus
 	}
 
+	// Apply chosen racial increases (Variant Human, Custom Lineage)
+	for _, choice := range d.choices {
+		if choice.Source == shared.SourceRace && choice.Category == shared.ChoiceAbilityScores {
+			for ability, bonus := range choice.AbilityScoreSelection {
+				finalScores[ability] += bonus
+			}
+		}
+	}
+
 	// Calculate starting HP
 	maxHP := classData.HitDice + finalScores.Modifier(abilities.CON)
 
@@ -593,7 +765,7 @@ func (d *Draft) ToCharacter(ctx context.Context, characterID string, bus events.
 	armorProfs, weaponProfs, toolProfs := d.compileProficiencies()
 
 	// Compile features (can fail)
-	charFeatures, err := d.compileFeatures(characterID)
+	charFeatures, err := d.compileFeatures(characterID, finalScores)
 	if err != nil {
 		return nil, rpgerr.Wrapf(err, "failed to compile features")
 	}
@@ -674,6 +846,22 @@ func (d *Draft) ToCharacter(ctx context.Context, characterID string, bus events.
 		}
 	}
 
+	// Apply passive racial traits (e.g., Dwarven Resilience, Halfling Lucky)
+	raceConditions, err := d.compileRaceConditions(characterID)
+	if err != nil {
+		return nil, rpgerr.Wrapf(err, "failed to compile racial conditions")
+	}
+	for _, cond := range raceConditions {
+		if err := conditionTopic.Publish(ctx, dnd5eEvents.ConditionAppliedEvent{
+			Target:    char,
+			Type:      dnd5eEvents.ConditionRacialTrait,
+			Source:    dnd5eEvents.ConditionSourceRace,
+			Condition: cond,
+		}); err != nil {
+			return nil, rpgerr.Wrapf(err, "failed to apply racial condition")
+		}
+	}
+
 	return char, nil
 }
 
@@ -690,6 +878,10 @@ func (d *Draft) ValidateChoices() error {
 
 	// Process stored choices into submissions
 	for _, choice := range d.choices {
+		// Background choices are validated against the background's requirements below
+		if choice.Source == shared.SourceBackground {
+			continue
+		}
 		switch choice.Category {
 		case shared.ChoiceSkills:
 			if len(choice.SkillSelection) > 0 {
@@ -772,12 +964,57 @@ func (d *Draft) ValidateChoices() error {
 					Values:   expertiseValues,
 				})
 			}
+		case shared.ChoiceFavoredEnemy:
+			if len(choice.FavoredEnemySelection) > 0 {
+				submissions.Add(choices.Submission{
+					Category: shared.ChoiceFavoredEnemy,
+					Source:   choice.Source,
+					ChoiceID: choice.ChoiceID,
+					Values:   favoredEnemyValues(choice.FavoredEnemySelection),
+				})
+			}
+		case shared.ChoiceFavoredTerrain:
+			if len(choice.FavoredTerrainSelection) > 0 {
+				submissions.Add(choices.Submission{
+					Category: shared.ChoiceFavoredTerrain,
+					Source:   choice.Source,
+					ChoiceID: choice.ChoiceID,
+					Values:   choice.FavoredTerrainSelection,
+				})
+			}
+		case shared.ChoiceTraits:
+			if submission, ok := traitSubmission(choice); ok {
+				submissions.Add(submission)
+			}
+		case shared.ChoiceAbilityScores:
+			// Base ability scores are checked by SetAbilityScores; only racial increases have a ChoiceID
+			if choice.ChoiceID != "" {
+				submissions.Add(abilityIncreaseSubmission(choice))
+			}
+		case shared.ChoiceFeats:
+			if len(choice.FeatSelection) > 0 {
+				submissions.Add(choices.Submission{
+					Category: shared.ChoiceFeats,
+					Source:   choice.Source,
+					ChoiceID: choice.ChoiceID,
+					Values:   choice.FeatSelection,
+				})
+			}
 		}
 	}
 
 	// Validate choices
 	result := validator.ValidateCharacterCreation(d.class, d.race, submissions)
 
+	// Validate background choices (customized backgrounds)
+	if reqs := choices.GetBackgroundRequirements(d.background); reqs != nil {
+		backgroundResult := validator.Validate(reqs, d.getBackgroundSubmissions())
+		if !backgroundResult.Valid {
+			result.Valid = false
+			result.Errors = append(result.Errors, backgroundResult.Errors...)
+		}
+	}
+
 	if !result.Valid {
 		// Return first error as rpgerr
 		if len(result.Errors) > 0 {
@@ -842,6 +1079,9 @@ func (d *Draft) recordChoice(choice choices.ChoiceData) {
 			if c.ChoiceID != choice.ChoiceID {
 				filtered = append(filtered, c)
 			}
+		} else if c.ChoiceID != "" && choice.ChoiceID != "" && c.ChoiceID != choice.ChoiceID {
+			// Distinct choices in one category (e.g., Custom Lineage's size and variable trait)
+			filtered = append(filtered, c)
 		} else {
 			// For non-equipment choices, check category and source as before
 			if c.Category != choice.Category || c.Source != choice.Source {
@@ -864,9 +1104,16 @@ func (d *Draft) compileSkills(raceData *races.Data) map[skills.Skill]shared.Prof
 		skillMap[skill] = shared.Proficient
 	}
 
-	// Add chosen skills from choices
+	// Add skills granted by a standard background (customized backgrounds grant none)
+	if grant := backgrounds.GetGrants(d.background); grant != nil {
+		for _, skill := range grant.SkillProficiencies {
+			skillMap[skill] = shared.Proficient
+		}
+	}
+
+	// Add chosen skills from choices, including Custom Lineage's variable trait
 	for _, choice := range d.choices {
-		if choice.Category == shared.ChoiceSkills {
+		if choice.Category == shared.ChoiceSkills || choice.ChoiceID == choices.CustomLineageVariableTrait {
 			for _, skill := range choice.SkillSelection {
 				skillMap[skill] = shared.Proficient
 			}
@@ -885,8 +1132,6 @@ func (d *Draft) compileSkills(raceData *races.Data) map[skills.Skill]shared.Prof
 		}
 	}
 
-	// TODO: Add background skills when we have internal background data
-
 	return skillMap
 }
 
@@ -901,7 +1146,8 @@ func (d *Draft) compileSavingThrows(classData *classes.Data) map[abilities.Abili
 	return saves
 }
 
-// compileProficiencies collects armor, weapon, and tool proficiencies from class and race grants
+// compileProficiencies collects armor, weapon, and tool proficiencies from class, race, and
+// background grants, plus tools chosen for a customized background
 func (d *Draft) compileProficiencies() ([]proficiencies.Armor, []proficiencies.Weapon, []proficiencies.Tool) {
 	armorProfs := make([]proficiencies.Armor, 0)
 	weaponProfs := make([]proficiencies.Weapon, 0)
@@ -926,7 +1172,17 @@ func (d *Draft) compileProficiencies() ([]proficiencies.Armor, []proficiencies.W
 		}
 	}
 
-	// TODO: Collect from background grants when implemented
+	// Collect tools granted by a standard background
+	if grant := backgrounds.GetGrants(d.background); grant != nil {
+		toolProfs = append(toolProfs, grant.ToolProficiencies...)
+	}
+
+	// Collect tools chosen for a customized background
+	for _, choice := range d.choices {
+		if choice.Source == shared.SourceBackground && choice.Category == shared.ChoiceToolProficiency {
+			toolProfs = append(toolProfs, choice.ToolSelection...)
+		}
+	}
 
 	return armorProfs, weaponProfs, toolProfs
 }
@@ -1012,16 +1268,13 @@ func (d *Draft) compileSpellSlots(classData *classes.Data) map[int]SpellSlotData
 	return slots
 }
 
-// compileFeatures returns the character's class features using the unified grant system.
-// Features are created from FeatureRef grants defined in classes/grant.go.
-func (d *Draft) compileFeatures(characterID string) ([]features.Feature, error) {
+// compileFeatures returns the character's class and racial features using the unified grant system.
+// Features are created from FeatureRef grants defined in classes/grant.go and races/grants.go.
+func (d *Draft) compileFeatures(characterID string, scores shared.AbilityScores) ([]features.Feature, error) {
 	featureList := make([]features.Feature, 0)
 
 	// Get grants for the class at level 1 (character creation)
 	grants := classes.GetGrantsForLevel(d.class, 1)
-	if grants == nil {
-		return featureList, nil
-	}
 
 	// Create features from each grant's FeatureRefs
 	for _, grant := range grants {
@@ -1038,13 +1291,55 @@ func (d *Draft) compileFeatures(characterID string) ([]features.Feature, error)
 		}
 	}
 
+	if raceGrant := races.GetGrants(d.race); raceGrant != nil {
+		for _, featureRef := range raceGrant.Features {
+			output, err := features.CreateFromRef(&features.CreateFromRefInput{
+				Ref:         featureRef.Ref,
+				Config:      featureRef.Config,
+				CharacterID: characterID,
+			})
+			if err != nil {
+				return nil, rpgerr.Wrapf(err, "failed to create feature from ref %s", featureRef.Ref)
+			}
+			featureList = append(featureList, output.Feature)
+		}
+	}
+
+	// Dragonborn Breath Weapon depends on the chosen ancestry and CON (DC 8 + CON + proficiency)
+	if ancestry := d.getDraconicAncestry(); ancestry != "" {
+		saveDC := 8 + scores.Modifier(abilities.CON) + 2
+		config := json.RawMessage(fmt.Sprintf(`{"ancestry": %q, "save_dc": %d, "level": 1}`, ancestry, saveDC))
+		output, err := features.CreateFromRef(&features.CreateFromRefInput{
+			Ref:         refs.Features.BreathWeapon().String(),
+			Config:      config,
+			CharacterID: characterID,
+		})
+		if err != nil {
+			return nil, rpgerr.Wrap(err, "failed to create breath weapon")
+		}
+		featureList = append(featureList, output.Feature)
+	}
+
 	return featureList, nil
 }
 
+// getDraconicAncestry returns the Dragonborn's chosen ancestry, or empty if none was chosen
+func (d *Draft) getDraconicAncestry() races.DraconicAncestry {
+	if d.race != races.Dragonborn {
+		return ""
+	}
+	for _, choice := range d.choices {
+		if choice.ChoiceID == choices.DragonbornAncestry && len(choice.TraitSelection) > 0 {
+			return races.DraconicAncestry(choice.TraitSelection[0])
+		}
+	}
+	return ""
+}
+
 // compileConditions creates conditions from grants and draft choices (e.g., fighting styles).
 // Conditions can come from two sources:
 // 1. Class grants (e.g., Barbarian's Unarmored Defense)
-// 2. Player choices (e.g., Fighter's chosen Fighting Style)
+// 2. Player choices (e.g., Fighter's chosen Fighting Style, Ranger's favored enemies)
 func (d *Draft) compileConditions(characterID string) ([]dnd5eEvents.ConditionBehavior, error) {
 	conditionList := make([]dnd5eEvents.ConditionBehavior, 0)
 
@@ -1066,6 +1361,13 @@ func (d *Draft) compileConditions(characterID string) ([]dnd5eEvents.ConditionBe
 		}
 	}
 
+	// Classes with cantrips scale their damage cantrips with character level
+	if classData := classes.GetData(d.class); classData != nil && classData.CantripsKnown > 0 {
+		conditionList = append(conditionList, conditions.NewCantripScalingCondition(conditions.CantripScalingInput{
+			CharacterID: characterID,
+		}))
+	}
+
 	// Add conditions from player choices (e.g., fighting styles)
 	// Fighting styles are CHOICES, not grants, so they're handled separately
 	// Each fighting style maps to its corresponding condition
@@ -1077,9 +1379,61 @@ func (d *Draft) compileConditions(characterID string) ([]dnd5eEvents.ConditionBe
 		conditionList = append(conditionList, fsCondition)
 	}
 
+	// Ranger Favored Enemy and Natural Explorer carry the chosen creature types and terrains
+	for _, choice := range d.choices {
+		switch {
+		case choice.Category == shared.ChoiceFavoredEnemy && len(choice.FavoredEnemySelection) > 0:
+			conditionList = append(conditionList, conditions.NewFavoredEnemyCondition(conditions.FavoredEnemyInput{
+				CharacterID: characterID,
+				Enemies:     choice.FavoredEnemySelection,
+			}))
+		case choice.Category == shared.ChoiceFavoredTerrain && len(choice.FavoredTerrainSelection) > 0:
+			conditionList = append(conditionList, conditions.NewNaturalExplorerCondition(conditions.NaturalExplorerInput{
+				CharacterID: characterID,
+				Terrains:    choice.FavoredTerrainSelection,
+			}))
+		}
+	}
+
+	return conditionList, nil
+}
+
+// compileRaceConditions creates conditions for passive racial traits (e.g., Fey Ancestry)
+// from the race's grants.
+func (d *Draft) compileRaceConditions(characterID string) ([]dnd5eEvents.ConditionBehavior, error) {
+	conditionList := make([]dnd5eEvents.ConditionBehavior, 0)
+
+	grant := races.GetGrants(d.race)
+	if grant == nil {
+		return conditionList, nil
+	}
+
+	raceSourceRef := "dnd5e:races:" + string(d.race)
+	for _, condRef := range grant.Conditions {
+		output, err := conditions.CreateFromRef(&conditions.CreateFromRefInput{
+			Ref:         condRef.Ref,
+			Config:      condRef.Config,
+			CharacterID: characterID,
+			SourceRef:   raceSourceRef,
+		})
+		if err != nil {
+			return nil, rpgerr.Wrapf(err, "failed to create condition from ref %s", condRef.Ref)
+		}
+		conditionList = append(conditionList, output.Condition)
+	}
+
 	return conditionList, nil
 }
 
+// favoredEnemyValues converts favored enemy creature types to submission values
+func favoredEnemyValues(enemies []monster.CreatureType) []shared.SelectionID {
+	values := make([]shared.SelectionID, len(enemies))
+	for i, enemy := range enemies {
+		values[i] = shared.SelectionID(enemy)
+	}
+	return values
+}
+
 // createFightingStyleCondition creates the appropriate condition for a fighting style.
 // Each fighting style maps to its own dedicated condition type.
 func createFightingStyleCondition(
@@ -1160,9 +1514,17 @@ func (d *Draft) IsBackgroundComplete() bool {
 		return false
 	}
 
-	// TODO: Get background requirements when we have background data
-	// For now, just check that background is set
-	return true
+	// Get background requirements
+	reqs := choices.GetBackgroundRequirements(d.background)
+	if reqs == nil {
+		return true // No choices required
+	}
+
+	// Validate
+	validator := choices.NewValidator()
+	result := validator.Validate(reqs, d.getBackgroundSubmissions())
+
+	return result.Valid
 }
 
 // Helper to check if class needs subclass at level 1
@@ -1180,13 +1542,17 @@ func (d *Draft) getRaceSubmissions() *choices.Submissions {
 			// Convert ChoiceData to Submission
 			// This would need proper mapping of choice data to submission format
 			// For now, simplified version
-			if len(choice.SkillSelection) > 0 {
+			if len(choice.SkillSelection) > 0 && choice.Category == shared.ChoiceSkills {
 				skillValues := make([]shared.SelectionID, 0, len(choice.SkillSelection))
 				skillValues = append(skillValues, choice.SkillSelection...)
+				choiceID := choices.HalfElfSkills
+				if d.race == races.VariantHuman {
+					choiceID = choices.VariantHumanSkill
+				}
 				subs.Add(choices.Submission{
 					Category: shared.ChoiceSkills,
 					Source:   shared.SourceRace,
-					ChoiceID: choices.HalfElfSkills, // Would need to map based on race
+					ChoiceID: choiceID,
 					Values:   skillValues,
 				})
 			}
@@ -1240,12 +1606,65 @@ func (d *Draft) getRaceSubmissions() *choices.Submissions {
 					Values:   toolValues,
 				})
 			}
+
+			// Handle racial trait choices (Dragonborn ancestry, Custom Lineage)
+			if choice.Category == shared.ChoiceTraits {
+				if submission, ok := traitSubmission(choice); ok {
+					subs.Add(submission)
+				}
+			}
+
+			// Handle Variant Human and Custom Lineage ability increases and feats
+			if choice.Category == shared.ChoiceAbilityScores {
+				subs.Add(abilityIncreaseSubmission(choice))
+			}
+			if len(choice.FeatSelection) > 0 {
+				subs.Add(choices.Submission{
+					Category: shared.ChoiceFeats,
+					Source:   shared.SourceRace,
+					ChoiceID: choice.ChoiceID,
+					Values:   choice.FeatSelection,
+				})
+			}
 		}
 	}
 
 	return subs
 }
 
+// traitSubmission converts a trait choice to a submission. A choice with an
+// option (Custom Lineage's variable trait) submits its skill, if any, as the value.
+func traitSubmission(choice choices.ChoiceData) (choices.Submission, bool) {
+	submission := choices.Submission{
+		Category: shared.ChoiceTraits,
+		Source:   choice.Source,
+		ChoiceID: choice.ChoiceID,
+		OptionID: choice.OptionID,
+		Values:   choice.TraitSelection,
+	}
+	if choice.OptionID != "" {
+		submission.Values = choice.SkillSelection
+		return submission, true
+	}
+	return submission, len(choice.TraitSelection) > 0
+}
+
+// abilityIncreaseSubmission converts a racial ability increase choice to a
+// submission of the chosen abilities, in a stable order
+func abilityIncreaseSubmission(choice choices.ChoiceData) choices.Submission {
+	values := make([]shared.SelectionID, 0, len(choice.AbilityScoreSelection))
+	for ability := range choice.AbilityScoreSelection {
+		values = append(values, string(ability))
+	}
+	slices.Sort(values)
+	return choices.Submission{
+		Category: shared.ChoiceAbilityScores,
+		Source:   choice.Source,
+		ChoiceID: choice.ChoiceID,
+		Values:   values,
+	}
+}
+
 // getClassSubmissions extracts class-related submissions from draft choices
 func (d *Draft) getClassSubmissions() *choices.Submissions {
 	subs := choices.NewSubmissions()
@@ -1323,6 +1742,73 @@ func (d *Draft) getClassSubmissions() *choices.Submissions {
 					Values:   expertiseValues,
 				})
 			}
+
+			// Handle favored enemy and terrain choices (Ranger L1)
+			if len(choice.FavoredEnemySelection) > 0 {
+				subs.Add(choices.Submission{
+					Category: shared.ChoiceFavoredEnemy,
+					Source:   shared.SourceClass,
+					ChoiceID: choice.ChoiceID,
+					Values:   favoredEnemyValues(choice.FavoredEnemySelection),
+				})
+			}
+
+			if len(choice.FavoredTerrainSelection) > 0 {
+				subs.Add(choices.Submission{
+					Category: shared.ChoiceFavoredTerrain,
+					Source:   shared.SourceClass,
+					ChoiceID: choice.ChoiceID,
+					Values:   choice.FavoredTerrainSelection,
+				})
+			}
+		}
+	}
+
+	return subs
+}
+
+// getBackgroundSubmissions extracts background-related submissions from draft choices
+func (d *Draft) getBackgroundSubmissions() *choices.Submissions {
+	subs := choices.NewSubmissions()
+
+	for _, choice := range d.choices {
+		if choice.Source != shared.SourceBackground {
+			continue
+		}
+
+		if len(choice.SkillSelection) > 0 {
+			skillValues := make([]shared.SelectionID, 0, len(choice.SkillSelection))
+			skillValues = append(skillValues, choice.SkillSelection...)
+			subs.Add(choices.Submission{
+				Category: shared.ChoiceSkills,
+				Source:   shared.SourceBackground,
+				ChoiceID: choice.ChoiceID,
+				Values:   skillValues,
+			})
+		}
+
+		if len(choice.LanguageSelection) > 0 {
+			langValues := make([]shared.SelectionID, 0, len(choice.LanguageSelection))
+			langValues = append(langValues, choice.LanguageSelection...)
+			subs.Add(choices.Submission{
+				Category: shared.ChoiceLanguages,
+				Source:   shared.SourceBackground,
+				ChoiceID: choice.ChoiceID,
+				Values:   langValues,
+			})
+		}
+
+		if len(choice.ToolSelection) > 0 {
+			toolValues := make([]shared.SelectionID, 0, len(choice.ToolSelection))
+			for _, t := range choice.ToolSelection {
+				toolValues = append(toolValues, shared.SelectionID(t))
+			}
+			subs.Add(choices.Submission{
+				Category: shared.ChoiceToolProficiency,
+				Source:   shared.SourceBackground,
+				ChoiceID: choice.ChoiceID,
+				Values:   toolValues,
+			})
 		}
 	}
 
@@ -1354,7 +1840,7 @@ func (d *Draft) recordEquipmentChoice(
 
 	// Try to find as a category requirement
 	if catReq := d.findCategoryRequirement(selection.ChoiceID, requirements); catReq != nil {
-		return d.recordCategoryEquipment(selection)
+		return d.recordCategoryEquipment(selection, catReq)
 	}
 
 	return rpgerr.Newf(rpgerr.CodeNotFound, "unknown equipment choice '%s'", selection.ChoiceID)
@@ -1478,28 +1964,46 @@ func (d *Draft) validateCategorySelections(
 			selection.OptionID, totalRequired, len(selection.CategorySelections))
 	}
 
-	// Validate each equipment ID exists
+	// Validate each equipment ID exists and is one the option's categories offer
 	for _, equipID := range selection.CategorySelections {
 		if _, err := equipment.GetByID(equipID); err != nil {
 			return nil, rpgerr.Newf(rpgerr.CodeNotFound, "invalid equipment ID '%s'", equipID)
 		}
+		offered := false
+		for _, catChoice := range option.CategoryChoices {
+			if choices.InEquipmentCategories(catChoice.Type, catChoice.Categories, equipID) {
+				offered = true
+				break
+			}
+		}
+		if !offered {
+			return nil, rpgerr.Newf(rpgerr.CodeInvalidArgument,
+				"equipment '%s' is not one of the choices for option '%s'", equipID, selection.OptionID)
+		}
 	}
 
 	return selection.CategorySelections, nil
 }
 
 // recordCategoryEquipment processes a top-level category equipment choice
-func (d *Draft) recordCategoryEquipment(selection EquipmentChoiceSelection) error {
+func (d *Draft) recordCategoryEquipment(
+	selection EquipmentChoiceSelection,
+	catReq *choices.EquipmentCategoryRequirement,
+) error {
 	if len(selection.CategorySelections) == 0 {
 		return rpgerr.Newf(rpgerr.CodeInvalidArgument,
 			"category choice '%s' requires category selections", selection.ChoiceID)
 	}
 
-	// Validate equipment IDs
+	// Validate equipment IDs are in the requirement's categories
 	for _, equipID := range selection.CategorySelections {
 		if _, err := equipment.GetByID(equipID); err != nil {
 			return rpgerr.Newf(rpgerr.CodeNotFound, "invalid equipment ID '%s'", equipID)
 		}
+		if !choices.InEquipmentCategories(catReq.Type, catReq.Categories, equipID) {
+			return rpgerr.Newf(rpgerr.CodeInvalidArgument,
+				"equipment '%s' is not one of the choices for '%s'", equipID, selection.ChoiceID)
+		}
 	}
 
 	d.recordChoice(choices.ChoiceData{
@@ -1532,6 +2036,21 @@ func calculateBarbarianRageUses(level int) int {
 	}
 }
 
+// calculateChannelDivinityUses determines Channel Divinity uses per rest based on cleric level.
+// Clerics gain Channel Divinity at level 2.
+func calculateChannelDivinityUses(level int) int {
+	switch {
+	case level < 2:
+		return 0
+	case level < 6:
+		return 1
+	case level < 18:
+		return 2
+	default:
+		return 3
+	}
+}
+
 // initializeClassResources adds class-specific resources to the character.
 // Called during ToCharacter after the character struct is created.
 func (d *Draft) initializeClassResources(char *Character) {
@@ -1562,6 +2081,63 @@ func (d *Draft) initializeClassResources(char *Character) {
 			ResetType:   coreResources.ResetShortRest,
 		})
 		char.resources[resources.Ki] = kiResource
+
+	case classes.Cleric:
+		// Channel Divinity - recovered on short or long rest
+		uses := calculateChannelDivinityUses(level)
+		if uses > 0 {
+			channelDivinity := combat.NewRecoverableResource(combat.RecoverableResourceConfig{
+				ID:          string(resources.ChannelDivinity),
+				Maximum:     uses,
+				CharacterID: char.id,
+				ResetType:   coreResources.ResetShortRest,
+			})
+			char.resources[resources.ChannelDivinity] = channelDivinity
+		}
+
+	case classes.Paladin:
+		// Lay on Hands pool - 5 points per paladin level, recovered on long rest
+		layOnHands := combat.NewRecoverableResource(combat.RecoverableResourceConfig{
+			ID:          string(resources.LayOnHands),
+			Maximum:     5 * level,
+			CharacterID: char.id,
+			ResetType:   coreResources.ResetLongRest,
+		})
+		char.resources[resources.LayOnHands] = layOnHands
+
+	case classes.Sorcerer:
+		// Sorcery points - equal to sorcerer level from level 2, recovered on long rest
+		if level >= 2 {
+			char.resources[resources.SorceryPoints] = resources.NewSorceryPointsResource(resources.SorceryPointsResourceConfig{
+				CharacterID: char.id,
+				Level:       level,
+			})
+		}
+
+	case classes.Druid:
+		// Wild Shape - 2 uses from druid level 2, recovered on short or long rest
+		if level >= 2 {
+			char.resources[resources.WildShape] = combat.NewRecoverableResource(combat.RecoverableResourceConfig{
+				ID:          string(resources.WildShape),
+				Maximum:     2,
+				CharacterID: char.id,
+				ResetType:   coreResources.ResetShortRest,
+			})
+		}
+
+	case classes.Bard:
+		// Bardic Inspiration - CHA modifier uses (minimum 1), recovered on long rest,
+		// or on short rest once Font of Inspiration arrives at bard level 5
+		resetType := coreResources.ResetLongRest
+		if level >= 5 {
+			resetType = coreResources.ResetShortRest
+		}
+		char.resources[resources.BardicInspiration] = combat.NewRecoverableResource(combat.RecoverableResourceConfig{
+			ID:          string(resources.BardicInspiration),
+			Maximum:     max(char.abilityScores.Modifier(abilities.CHA), 1),
+			CharacterID: char.id,
+			ResetType:   resetType,
+		})
 	}
 
 	// Hit dice - all classes get hit dice for short rest healing
@@ -1600,10 +2176,23 @@ func (d *Draft) initializeStandardCombatAbilities(char *Character) {
 	// Hide - consumes action economy to attempt a Stealth check (become hidden)
 	hideAbility := combatabilities.NewHide(char.id + "-hide")
 	_ = char.AddCombatAbility(hideAbility)
+
+	// Search - consumes action economy to make a Perception check
+	searchAbility := combatabilities.NewSearch(char.id + "-search")
+	_ = char.AddCombatAbility(searchAbility)
+
+	// Use Object - consumes action economy to interact with an object
+	useObjectAbility := combatabilities.NewUseObject(char.id + "-use-object")
+	_ = char.AddCombatAbility(useObjectAbility)
+
+	// Ready - consumes action economy to hold an action until a declared trigger
+	readyAbility := combatabilities.NewReady(char.id + "-ready")
+	_ = char.AddCombatAbility(readyAbility)
 }
 
 // initializeStandardActions adds standard permanent actions to the character.
-// These are always available: Strike (uses attacks from Attack ability) and Move.
+// These are always available: Strike, Grapple and Shove (which use attacks from
+// the Attack ability) and Move.
 // Called during ToCharacter after the character struct is created.
 func (d *Draft) initializeStandardActions(char *Character) {
 	// Strike - consumes AttacksRemaining to make weapon attacks
@@ -1614,6 +2203,10 @@ func (d *Draft) initializeStandardActions(char *Character) {
 	})
 	_ = char.AddAction(strikeAction)
 
+	// Grapple and Shove - each replaces one attack with an Athletics contest
+	_ = char.AddAction(actions.NewGrapple(actions.GrappleConfig{ID: char.id + "-grapple", OwnerID: char.id}))
+	_ = char.AddAction(actions.NewShove(actions.ShoveConfig{ID: char.id + "-shove", OwnerID: char.id}))
+
 	// Move - consumes MovementRemaining to change position
 	moveAction := actions.NewMove(actions.MoveConfig{
 		ID:      char.id + "-move",
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/ammunition"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/backgrounds"
@@ -467,6 +468,22 @@ func (s *DraftTestSuite) TestCompileInventory_EquipmentChoices() {
 	})
 }
 
+// Test: Fighter subclasses are chosen at level 3, not at character creation
+func (s *DraftTestSuite) TestSetClass_RejectsSubclassBeforeSubclassLevel() {
+	draft := s.createFighterDraft()
+
+	err := draft.SetClass(&character.SetClassInput{
+		ClassID:    classes.Fighter,
+		SubclassID: classes.Champion,
+		Choices: character.ClassChoices{
+			Skills: []skills.Skill{skills.Athletics, skills.Intimidation},
+		},
+	})
+	s.Require().Error(err)
+	s.Equal(rpgerr.CodeNotAllowed, rpgerr.GetCode(err))
+	s.Equal(classes.Fighter, draft.Class(), "the previous class choice is kept")
+}
+
 // Test: Multiple same items (no merging)
 func (s *DraftTestSuite) TestCompileInventory_NoMerging() {
 	// Create a fresh draft for this test
@@ -1150,7 +1167,7 @@ func (s *ClassChangeTestSuite) TestMultipleClassChanges_OnlyLatestChoicesRemain(
 	err := s.baseDraft.SetClass(&character.SetClassInput{
 		ClassID: classes.Fighter,
 		Choices: character.ClassChoices{
-			Skills: []skills.Skill{skills.Athletics, skills.Intimidation},
+			Skills: []skills.Skill{skills.Athletics, skills.History},
 			Equipment: []character.EquipmentChoiceSelection{
 				{
 					ChoiceID:           choices.FighterWeaponsPrimary,
@@ -1204,10 +1221,10 @@ func (s *ClassChangeTestSuite) TestMultipleClassChanges_OnlyLatestChoicesRemain(
 	rogueSkills := char.ToData().Skills
 
 	// Should NOT have Fighter or Barbarian skills
-	_, hasIntimidation := rogueSkills[skills.Intimidation]
+	_, hasHistory := rogueSkills[skills.History]
 	_, hasNature := rogueSkills[skills.Nature]
 	_, hasSurvival := rogueSkills[skills.Survival]
-	s.Assert().False(hasIntimidation, "Should NOT have Fighter's Intimidation")
+	s.Assert().False(hasHistory, "Should NOT have Fighter's History")
 	s.Assert().False(hasNature, "Should NOT have Barbarian's Nature")
 	s.Assert().False(hasSurvival, "Should NOT have Barbarian's Survival")
 
@@ -0,0 +1,105 @@
+package character
+
+import (
+	"context"
+	"testing"
+
+	coreResources "github.com/KirkDiggler/rpg-toolkit/core/resources"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/conditions"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/stretchr/testify/suite"
+)
+
+// ExhaustionTestSuite tests how exhaustion changes a character's speed and hit points
+type ExhaustionTestSuite struct {
+	suite.Suite
+	ctx       context.Context
+	bus       events.EventBus
+	character *Character
+}
+
+func TestExhaustionSuite(t *testing.T) {
+	suite.Run(t, new(ExhaustionTestSuite))
+}
+
+func (s *ExhaustionTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.character = &Character{
+		id:           "test-ranger",
+		level:        3,
+		hitPoints:    30,
+		maxHitPoints: 30,
+		bus:          s.bus,
+		resources:    make(map[coreResources.ResourceKey]*combat.RecoverableResource),
+	}
+	s.Require().NoError(s.character.subscribeToEvents(s.ctx))
+}
+
+func (s *ExhaustionTestSuite) TearDownTest() {
+	_ = s.character.Cleanup(s.ctx)
+}
+
+func (s *ExhaustionTestSuite) exhaust(level int) {
+	err := dnd5eEvents.ConditionAppliedTopic.On(s.bus).Publish(s.ctx, dnd5eEvents.ConditionAppliedEvent{
+		Target:    s.character,
+		Type:      dnd5eEvents.ConditionExhaustion1,
+		Condition: conditions.NewExhaustionCondition(conditions.ExhaustionInput{CharacterID: s.character.id, Level: level}),
+	})
+	s.Require().NoError(err)
+}
+
+func (s *ExhaustionTestSuite) TestSpeedHalvedThenZero() {
+	s.Equal(30, s.character.GetSpeed())
+
+	s.exhaust(1)
+	s.Equal(30, s.character.GetSpeed())
+
+	s.exhaust(1)
+	s.Equal(15, s.character.GetSpeed())
+
+	s.exhaust(3)
+	s.Equal(0, s.character.GetSpeed())
+}
+
+func (s *ExhaustionTestSuite) TestFurtherExhaustionStacks() {
+	s.exhaust(2)
+	s.exhaust(1)
+
+	exhaustion := s.character.exhaustion()
+	s.Require().NotNil(exhaustion)
+	s.Equal(3, exhaustion.Level)
+	s.Len(s.character.GetConditions(), 1)
+}
+
+func (s *ExhaustionTestSuite) TestHitPointMaximumHalvedAtLevelFour() {
+	s.exhaust(4)
+	s.Equal(15, s.character.GetMaxHitPoints())
+	s.Equal(15, s.character.GetHitPoints())
+
+	s.Require().NoError(dnd5eEvents.HealingReceivedTopic.On(s.bus).Publish(s.ctx, dnd5eEvents.HealingReceivedEvent{
+		TargetID: s.character.id,
+		Amount:   10,
+	}))
+	s.Equal(15, s.character.GetHitPoints())
+}
+
+func (s *ExhaustionTestSuite) TestLongRestRemovesOneLevel() {
+	s.exhaust(4)
+
+	s.Require().NoError(s.character.LongRest(s.ctx))
+	s.Require().NotNil(s.character.exhaustion())
+	s.Equal(3, s.character.exhaustion().Level)
+	s.Equal(30, s.character.GetMaxHitPoints())
+	s.Equal(30, s.character.GetHitPoints())
+}
+
+func (s *ExhaustionTestSuite) TestLastLevelRecovered() {
+	s.exhaust(1)
+
+	s.Require().NoError(s.character.LongRest(s.ctx))
+	s.Nil(s.character.exhaustion())
+	s.Empty(s.character.GetConditions())
+}
@@ -1,15 +1,18 @@
 package character
 
 import (
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/backgrounds"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/character/choices"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/classes"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/fightingstyles"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/languages"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/races"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/spells"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/terrains"
 )
 
 // SetNameInput contains the input for setting a character's name
@@ -27,9 +30,17 @@ type SetRaceInput struct {
 // RaceChoices contains optional choices when selecting a race
 type RaceChoices struct {
 	Languages []languages.Language `json:"languages,omitempty"`
-	Skills    []skills.Skill       `json:"skills,omitempty"`
+	Skills    []skills.Skill       `json:"skills,omitempty"` // Custom Lineage: the skill-proficiency variable trait
 	Cantrips  []spells.Spell       `json:"cantrips,omitempty"`
 	Tools     []shared.SelectionID `json:"tools,omitempty"` // Tool proficiency choices (Dwarf)
+
+	DraconicAncestry races.DraconicAncestry `json:"draconic_ancestry,omitempty"` // Dragonborn ancestry
+
+	// Variant Human and Custom Lineage
+	AbilityIncreases []abilities.Ability `json:"ability_increases,omitempty"`
+	Feat             shared.SelectionID  `json:"feat,omitempty"`
+	Size             choices.OptionID    `json:"size,omitempty"`           // Custom Lineage: small or medium
+	VariableTrait    choices.OptionID    `json:"variable_trait,omitempty"` // Custom Lineage: darkvision or skill-proficiency
 }
 
 // SetClassInput contains the input for setting a character's class
@@ -48,6 +59,9 @@ type ClassChoices struct {
 	Equipment     []EquipmentChoiceSelection   `json:"equipment,omitempty"`
 	Tools         []shared.SelectionID         `json:"tools,omitempty"`     // Tool proficiency choices (Monk, Bard)
 	Expertise     []skills.Skill               `json:"expertise,omitempty"` // Expertise choices (Rogue L1/L6, Bard L3/L10)
+
+	FavoredEnemies  []monster.CreatureType `json:"favored_enemies,omitempty"`  // Ranger Favored Enemy
+	FavoredTerrains []terrains.Terrain     `json:"favored_terrains,omitempty"` // Ranger Natural Explorer
 }
 
 // EquipmentChoiceSelection represents a player's choice for an equipment requirement
@@ -66,9 +80,12 @@ type SetBackgroundInput struct {
 	Choices      BackgroundChoices      `json:"choices,omitempty"`
 }
 
-// BackgroundChoices contains optional choices when selecting a background
+// BackgroundChoices contains optional choices when selecting a background.
+// Skills and Tools are only chosen for a customized background.
 type BackgroundChoices struct {
 	Languages []languages.Language `json:"languages,omitempty"`
+	Skills    []skills.Skill       `json:"skills,omitempty"`
+	Tools     []shared.SelectionID `json:"tools,omitempty"`
 }
 
 // SetAbilityScoresInput contains the input for setting ability scores
@@ -15,6 +15,11 @@ func (i InventoryItem) GetTotalWeight() float32 {
 	return i.Equipment.EquipmentWeight() * float32(i.Quantity)
 }
 
+// GetTotalValue returns the total value in copper pieces of this stack of items
+func (i InventoryItem) GetTotalValue() int {
+	return i.Equipment.EquipmentValue() * i.Quantity
+}
+
 // ToData converts the inventory item to its persistent form
 func (i InventoryItem) ToData() InventoryItemData {
 	return InventoryItemData{
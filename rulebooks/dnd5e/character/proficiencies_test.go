@@ -120,8 +120,12 @@ func (s *ProficienciesSuite) TestFighterProficiencies() {
 		"Fighter should have simple and martial weapon proficiencies",
 	)
 
-	// Fighters have no tool proficiencies by default
-	s.Empty(data.ToolProficiencies, "Fighter should have no tool proficiencies")
+	// Fighters have no tool proficiencies; the Soldier background grants land vehicles
+	s.Equal(
+		[]proficiencies.Tool{proficiencies.ToolVehicleLand},
+		data.ToolProficiencies,
+		"Fighter should only have the Soldier background's tool proficiency",
+	)
 }
 
 // TestBarbarianProficiencies verifies Barbarian gets light/medium/shields armor
@@ -299,8 +303,12 @@ func (s *ProficienciesSuite) TestMonkProficiencies() {
 	)
 
 	// Monks get artisan's tools OR musical instrument - not tested here as it's a choice
-	// For now, verify tool proficiencies is empty (choice system not exercised)
-	s.Empty(data.ToolProficiencies, "Monk should have no tool proficiencies (choice not made)")
+	// so the only tool is the Hermit background's herbalism kit
+	s.Equal(
+		[]proficiencies.Tool{proficiencies.ToolHerbalism},
+		data.ToolProficiencies,
+		"Monk should only have the Hermit background's tool proficiency (choice not made)",
+	)
 }
 
 // TestProficienciesRoundTrip verifies proficiencies survive serialization/deserialization
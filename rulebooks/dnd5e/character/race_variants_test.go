@@ -0,0 +1,189 @@
+package character
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/backgrounds"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/character/choices"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/classes"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/fightingstyles"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/languages"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/races"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/weapons"
+)
+
+// RaceVariantsSuite tests Variant Human and Custom Lineage from choice to character
+type RaceVariantsSuite struct {
+	suite.Suite
+	eventBus events.EventBus
+	draft    *Draft
+}
+
+func TestRaceVariantsSuite(t *testing.T) {
+	suite.Run(t, new(RaceVariantsSuite))
+}
+
+// SetupTest builds a Soldier Fighter draft with everything but the race
+func (s *RaceVariantsSuite) SetupTest() {
+	s.eventBus = events.NewEventBus()
+
+	draft, err := NewDraft(&DraftConfig{ID: "race-variant", PlayerID: "player-1"})
+	s.Require().NoError(err)
+	s.draft = draft
+
+	s.Require().NoError(draft.SetName(&SetNameInput{Name: "Tamsin"}))
+	s.Require().NoError(draft.SetClass(&SetClassInput{
+		ClassID: classes.Fighter,
+		Choices: ClassChoices{
+			Skills: []skills.Skill{skills.Athletics, skills.Perception},
+			Equipment: []EquipmentChoiceSelection{
+				{ChoiceID: choices.FighterArmor, OptionID: choices.FighterArmorChainMail},
+				{
+					ChoiceID:           choices.FighterWeaponsPrimary,
+					OptionID:           choices.FighterWeaponMartialShield,
+					CategorySelections: []shared.EquipmentID{weapons.Longsword},
+				},
+				{ChoiceID: choices.FighterWeaponsSecondary, OptionID: choices.FighterRangedCrossbow},
+				{ChoiceID: choices.FighterPack, OptionID: choices.FighterPackExplorer},
+			},
+			FightingStyle: fightingstyles.Defense,
+		},
+	}))
+	s.Require().NoError(draft.SetBackground(&SetBackgroundInput{
+		BackgroundID: backgrounds.Soldier,
+		Choices:      BackgroundChoices{},
+	}))
+	s.Require().NoError(draft.SetAbilityScores(&SetAbilityScoresInput{
+		Scores: shared.AbilityScores{
+			abilities.STR: 15,
+			abilities.DEX: 14,
+			abilities.CON: 13,
+			abilities.INT: 12,
+			abilities.WIS: 10,
+			abilities.CHA: 8,
+		},
+		Method: "standard-array",
+	}))
+}
+
+func (s *RaceVariantsSuite) TestVariantHuman() {
+	s.Require().NoError(s.draft.SetRace(&SetRaceInput{
+		RaceID: races.VariantHuman,
+		Choices: RaceChoices{
+			Languages:        []languages.Language{languages.Elvish},
+			Skills:           []skills.Skill{skills.Stealth},
+			AbilityIncreases: []abilities.Ability{abilities.STR, abilities.CON},
+			Feat:             "alert",
+		},
+	}))
+	s.True(s.draft.IsRaceComplete())
+
+	char, err := s.draft.ToCharacter(context.Background(), "tamsin", s.eventBus)
+	s.Require().NoError(err)
+	data := char.ToData()
+
+	s.Equal(16, data.AbilityScores[abilities.STR])
+	s.Equal(14, data.AbilityScores[abilities.CON])
+	s.Equal(14, data.AbilityScores[abilities.DEX], "variant humans don't get +1 to every ability")
+	s.Equal(shared.Proficient, data.Skills[skills.Stealth])
+	s.Equal(shared.Proficient, data.Skills[skills.Athletics], "class skills are kept")
+	s.Contains(data.Languages, languages.Elvish)
+}
+
+func (s *RaceVariantsSuite) TestVariantHumanRequiresFeat() {
+	s.Require().NoError(s.draft.SetRace(&SetRaceInput{
+		RaceID: races.VariantHuman,
+		Choices: RaceChoices{
+			Languages:        []languages.Language{languages.Elvish},
+			Skills:           []skills.Skill{skills.Stealth},
+			AbilityIncreases: []abilities.Ability{abilities.STR, abilities.CON},
+		},
+	}))
+	s.False(s.draft.IsRaceComplete())
+
+	_, err := s.draft.ToCharacter(context.Background(), "tamsin", s.eventBus)
+	s.Error(err)
+}
+
+func (s *RaceVariantsSuite) TestVariantHumanRejectsSameAbilityTwice() {
+	s.Require().NoError(s.draft.SetRace(&SetRaceInput{
+		RaceID: races.VariantHuman,
+		Choices: RaceChoices{
+			Languages:        []languages.Language{languages.Elvish},
+			Skills:           []skills.Skill{skills.Stealth},
+			AbilityIncreases: []abilities.Ability{abilities.STR, abilities.STR},
+			Feat:             "alert",
+		},
+	}))
+	s.False(s.draft.IsRaceComplete())
+}
+
+func (s *RaceVariantsSuite) TestCustomLineageWithSkill() {
+	s.Require().NoError(s.draft.SetRace(&SetRaceInput{
+		RaceID: races.CustomLineage,
+		Choices: RaceChoices{
+			Languages:        []languages.Language{languages.Sylvan},
+			AbilityIncreases: []abilities.Ability{abilities.DEX},
+			Feat:             "lucky",
+			Size:             choices.CustomLineageSmall,
+			VariableTrait:    choices.CustomLineageSkillProficiency,
+			Skills:           []skills.Skill{skills.Stealth},
+		},
+	}))
+	s.True(s.draft.IsRaceComplete())
+
+	char, err := s.draft.ToCharacter(context.Background(), "tamsin", s.eventBus)
+	s.Require().NoError(err)
+	data := char.ToData()
+
+	s.Equal(16, data.AbilityScores[abilities.DEX])
+	s.Equal(15, data.AbilityScores[abilities.STR])
+	s.Equal(shared.Proficient, data.Skills[skills.Stealth])
+	s.Contains(data.Languages, languages.Sylvan)
+}
+
+func (s *RaceVariantsSuite) TestCustomLineageWithDarkvision() {
+	s.Require().NoError(s.draft.SetRace(&SetRaceInput{
+		RaceID: races.CustomLineage,
+		Choices: RaceChoices{
+			Languages:        []languages.Language{languages.Sylvan},
+			AbilityIncreases: []abilities.Ability{abilities.CON},
+			Feat:             "tough",
+			Size:             choices.CustomLineageMedium,
+			VariableTrait:    choices.CustomLineageDarkvision,
+		},
+	}))
+	s.True(s.draft.IsRaceComplete())
+}
+
+func (s *RaceVariantsSuite) TestCustomLineageRequiresVariableTraitSkill() {
+	s.Require().NoError(s.draft.SetRace(&SetRaceInput{
+		RaceID: races.CustomLineage,
+		Choices: RaceChoices{
+			Languages:        []languages.Language{languages.Sylvan},
+			AbilityIncreases: []abilities.Ability{abilities.DEX},
+			Feat:             "lucky",
+			Size:             choices.CustomLineageSmall,
+			VariableTrait:    choices.CustomLineageSkillProficiency,
+		},
+	}))
+	s.False(s.draft.IsRaceComplete())
+}
+
+func (s *RaceVariantsSuite) TestRejectsIncreasesForFixedRace() {
+	err := s.draft.SetRace(&SetRaceInput{
+		RaceID: races.Human,
+		Choices: RaceChoices{
+			Languages:        []languages.Language{languages.Elvish},
+			AbilityIncreases: []abilities.Ability{abilities.STR},
+		},
+	})
+	s.Error(err)
+}
@@ -0,0 +1,105 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package character
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/classes"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+)
+
+type SpellcastingStatsTestSuite struct {
+	suite.Suite
+	ctx context.Context
+	bus events.EventBus
+}
+
+func TestSpellcastingStatsSuite(t *testing.T) {
+	suite.Run(t, new(SpellcastingStatsTestSuite))
+}
+
+func (s *SpellcastingStatsTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+}
+
+func (s *SpellcastingStatsTestSuite) newCharacter(classID classes.Class) *Character {
+	return &Character{
+		id:      "hero-1",
+		classID: classID,
+		abilityScores: shared.AbilityScores{
+			abilities.STR: 10,
+			abilities.DEX: 10,
+			abilities.CON: 10,
+			abilities.INT: 16, // +3
+			abilities.WIS: 12, // +1
+			abilities.CHA: 18, // +4
+		},
+		proficiencyBonus: 3,
+		bus:              s.bus,
+	}
+}
+
+func (s *SpellcastingStatsTestSuite) TestDerivedFromClassAbility() {
+	wizard := s.newCharacter(classes.Wizard)
+	s.Equal(abilities.INT, wizard.SpellcastingAbility())
+	s.Equal(6, wizard.SpellAttackBonus(s.ctx))
+	s.Equal(14, wizard.SpellSaveDC(s.ctx))
+
+	warlock := s.newCharacter(classes.Warlock)
+	s.Equal(7, warlock.SpellAttackBonus(s.ctx))
+	s.Equal(15, warlock.SpellSaveDC(s.ctx))
+}
+
+func (s *SpellcastingStatsTestSuite) TestNonCaster() {
+	fighter := s.newCharacter(classes.Fighter)
+
+	stats, err := fighter.SpellcastingStats(s.ctx)
+	s.Require().NoError(err)
+	s.Nil(stats)
+	s.Equal(0, fighter.SpellAttackBonus(s.ctx))
+	s.Equal(0, fighter.SpellSaveDC(s.ctx))
+}
+
+func (s *SpellcastingStatsTestSuite) TestItemBonusThroughChain() {
+	warlock := s.newCharacter(classes.Warlock)
+
+	// Rod of the Pact Keeper, +2
+	_, err := dnd5eEvents.SpellcastingChain.On(s.bus).SubscribeWithChain(s.ctx, func(
+		_ context.Context,
+		_ *dnd5eEvents.SpellcastingChainEvent,
+		c chain.Chain[*dnd5eEvents.SpellcastingChainEvent],
+	) (chain.Chain[*dnd5eEvents.SpellcastingChainEvent], error) {
+		rod := func(_ context.Context, e *dnd5eEvents.SpellcastingChainEvent) (*dnd5eEvents.SpellcastingChainEvent, error) {
+			e.BonusSources = append(e.BonusSources, dnd5eEvents.SpellcastingBonusSource{
+				SourceRef:   &core.Ref{Module: "dnd5e", Type: "items", ID: "rod_of_the_pact_keeper_2"},
+				AttackBonus: 2,
+				SaveDCBonus: 2,
+			})
+			return e, nil
+		}
+		return c, c.Add(combat.StageEquipment, "rod_of_the_pact_keeper", rod)
+	})
+	s.Require().NoError(err)
+
+	stats, err := warlock.SpellcastingStats(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(7, stats.BaseAttackBonus)
+	s.Equal(9, stats.AttackBonus())
+	s.Equal(17, stats.SaveDC())
+
+	data := warlock.ToData()
+	s.Equal(9, data.SpellAttackBonus)
+	s.Equal(17, data.SpellSaveDC)
+}
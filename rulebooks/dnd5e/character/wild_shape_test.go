@@ -0,0 +1,160 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package character
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	coreResources "github.com/KirkDiggler/rpg-toolkit/core/resources"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/classes"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/features"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster/monsters"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/resources"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+)
+
+// CharacterWildShapeTestSuite exercises a druid taking a monster stat block as a
+// beast form through the Wild Shape feature and condition.
+type CharacterWildShapeTestSuite struct {
+	suite.Suite
+	ctx   context.Context
+	bus   events.EventBus
+	druid *Character
+}
+
+func TestCharacterWildShapeSuite(t *testing.T) {
+	suite.Run(t, new(CharacterWildShapeTestSuite))
+}
+
+// SetupTest loads a level 2 druid (20 HP, AC 12) and shapes them into a wolf (11 HP, AC 13)
+func (s *CharacterWildShapeTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+
+	druid, err := LoadFromData(s.ctx, &Data{
+		ID:               "druid-1",
+		Name:             "Willow",
+		ClassID:          classes.Druid,
+		Level:            2,
+		ProficiencyBonus: 2,
+		HitPoints:        20,
+		MaxHitPoints:     20,
+		ArmorClass:       12,
+		AbilityScores: shared.AbilityScores{
+			abilities.STR: 10,
+			abilities.DEX: 14,
+			abilities.CON: 14,
+			abilities.INT: 12,
+			abilities.WIS: 16,
+			abilities.CHA: 8,
+		},
+	}, s.bus)
+	s.Require().NoError(err)
+	s.druid = druid
+
+	s.druid.AddResource(resources.WildShape, combat.NewRecoverableResource(combat.RecoverableResourceConfig{
+		ID:          string(resources.WildShape),
+		Maximum:     2,
+		CharacterID: s.druid.GetID(),
+		ResetType:   coreResources.ResetShortRest,
+	}))
+
+	output, err := features.CreateFromRef(&features.CreateFromRefInput{
+		Ref:         refs.Features.WildShape().String(),
+		CharacterID: s.druid.GetID(),
+	})
+	s.Require().NoError(err)
+
+	err = output.Feature.Activate(s.ctx, s.druid, features.FeatureInput{
+		Bus:  s.bus,
+		Form: monsters.NewWolf("wolf-form").ToData(),
+	})
+	s.Require().NoError(err)
+}
+
+// damage applies a single slashing damage instance to the druid
+func (s *CharacterWildShapeTestSuite) damage(amount int) *combat.ApplyDamageResult {
+	return s.druid.ApplyDamage(s.ctx, &combat.ApplyDamageInput{
+		Instances: []combat.DamageInstance{{Amount: amount, Type: string(damage.Slashing)}},
+	})
+}
+
+func (s *CharacterWildShapeTestSuite) TestTakesFormStatistics() {
+	s.Equal(11, s.druid.GetHitPoints())
+	s.Equal(11, s.druid.GetMaxHitPoints())
+	s.Equal(13, s.druid.AC())
+	s.Equal(13, s.druid.EffectiveAC(s.ctx).Total)
+
+	scores := s.druid.AbilityScores()
+	s.Equal(12, scores[abilities.STR], "strength from the wolf")
+	s.Equal(15, scores[abilities.DEX], "dexterity from the wolf")
+	s.Equal(16, scores[abilities.WIS], "wisdom kept from the druid")
+	s.Equal(2, s.druid.GetAbilityModifier(abilities.DEX))
+	s.Equal(1, s.druid.GetResource(resources.WildShape).Current())
+}
+
+func (s *CharacterWildShapeTestSuite) TestFormAbsorbsDamage() {
+	result := s.damage(6)
+
+	s.Equal(6, result.TotalDamage)
+	s.Equal(11, result.PreviousHP)
+	s.Equal(5, result.CurrentHP)
+	s.False(result.DroppedToZero)
+	s.Equal(20, s.druid.ToData().HitPoints, "normal form untouched")
+}
+
+func (s *CharacterWildShapeTestSuite) TestRevertsAtZeroWithCarryover() {
+	result := s.damage(15)
+
+	s.Equal(16, result.CurrentHP, "4 damage carried over to the normal form")
+	s.False(result.DroppedToZero)
+	s.Equal(20, s.druid.GetMaxHitPoints())
+	s.Equal(12, s.druid.AC())
+	s.Equal(10, s.druid.AbilityScores()[abilities.STR])
+	s.Empty(s.druid.GetConditions())
+}
+
+func (s *CharacterWildShapeTestSuite) TestHealingHealsForm() {
+	s.damage(8)
+
+	err := dnd5eEvents.HealingReceivedTopic.On(s.bus).Publish(s.ctx, dnd5eEvents.HealingReceivedEvent{
+		TargetID: s.druid.GetID(),
+		Amount:   4,
+	})
+	s.Require().NoError(err)
+
+	s.Equal(7, s.druid.GetHitPoints())
+	s.Equal(20, s.druid.ToData().HitPoints)
+}
+
+func (s *CharacterWildShapeTestSuite) TestCannotCastSpellsWhileShaped() {
+	economy := combat.NewActionEconomy()
+	economy.ApplyConditions(combat.ConditionRefs(s.druid.GetConditions()))
+
+	err := economy.CheckSpellcasting()
+	s.Require().Error(err)
+	s.Equal(rpgerr.CodeNotAllowed, rpgerr.GetCode(err))
+	s.NoError(economy.CheckAction(), "the form can still act")
+}
+
+func (s *CharacterWildShapeTestSuite) TestFormSurvivesReload() {
+	s.damage(3)
+
+	loaded, err := LoadFromData(s.ctx, s.druid.ToData(), events.NewEventBus())
+	s.Require().NoError(err)
+
+	s.Equal(8, loaded.GetHitPoints())
+	s.Equal(13, loaded.AC())
+	s.Equal(20, loaded.ToData().HitPoints)
+}
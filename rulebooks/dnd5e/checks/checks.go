@@ -0,0 +1,209 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package checks implements D&D 5e ability check and contest mechanics
+package checks
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/rpg-toolkit/dice"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
+)
+
+// AbilityCheckInput contains all parameters needed to make an ability check
+type AbilityCheckInput struct {
+	// Roller is the dice roller to use. If nil, defaults to dice.NewRoller().
+	// Pass a mock roller here for testing.
+	Roller dice.Roller
+
+	// EventBus is the event bus for chain modifiers. If nil, no chain events are fired.
+	// This allows conditions and features to grant advantage or bonuses on checks.
+	EventBus events.EventBus
+
+	// CheckerID is the ID of the entity making the check.
+	// Required when EventBus is provided.
+	CheckerID string
+
+	// Cause provides context about what prompted this check.
+	Cause dnd5eEvents.CheckCause
+
+	// Ability is the ability score being tested.
+	// If empty and Skill is set, the skill's ability is used.
+	Ability abilities.Ability
+
+	// Skill is the skill applied to the check, if any (e.g. skills.Athletics)
+	Skill skills.Skill
+
+	// DC is the Difficulty Class that must be met or exceeded.
+	// Leave 0 for a contest, where the totals are compared instead.
+	DC int
+
+	// Modifier is the total bonus/penalty to add to the roll
+	// (typically ability modifier + proficiency bonus if proficient in the skill)
+	Modifier int
+
+	// ProficiencyBonus is the proficiency bonus included in Modifier, if any.
+	// It is reported on the AbilityCheckChain so Jack of All Trades can tell a
+	// proficient check from a non-proficient one.
+	ProficiencyBonus int
+
+	// HasAdvantage indicates rolling two d20s and taking the higher result
+	HasAdvantage bool
+
+	// HasDisadvantage indicates rolling two d20s and taking the lower result.
+	// If both HasAdvantage and HasDisadvantage are true, they cancel out.
+	HasDisadvantage bool
+
+	// RequiresSight marks a check that relies on sight (e.g. spotting a hidden
+	// creature). Blinded creatures fail it automatically.
+	RequiresSight bool
+
+	// RequiresHearing marks a check that relies on hearing (e.g. listening at
+	// a door). Deafened creatures fail it automatically.
+	RequiresHearing bool
+}
+
+// AbilityCheckResult contains the outcome of an ability check
+type AbilityCheckResult struct {
+	// Roll is the actual d20 roll result used (highest/lowest if advantage/disadvantage)
+	Roll int
+
+	// Total is the final value (Roll + Modifier + ChainBonuses)
+	Total int
+
+	// DC is the Difficulty Class that was tested against (0 for a contest)
+	DC int
+
+	// Success indicates whether the check met the DC (always false for a contest)
+	Success bool
+
+	// AdvantageSources contains the sources that granted advantage on this check
+	AdvantageSources []dnd5eEvents.CheckModifierSource
+
+	// DisadvantageSources contains the sources that imposed disadvantage on this check
+	DisadvantageSources []dnd5eEvents.CheckModifierSource
+
+	// BonusSources contains the sources that added bonuses to this check
+	BonusSources []dnd5eEvents.CheckBonusSource
+
+	// AutoFailSources contains the sources that made this check fail automatically.
+	// A check that fails automatically never succeeds, whatever its total.
+	AutoFailSources []dnd5eEvents.CheckModifierSource
+}
+
+// MakeAbilityCheck executes an ability check using the input parameters.
+//
+// If input.EventBus is provided, the AbilityCheckChain is fired before the roll
+// so conditions and features can add advantage, disadvantage, or bonuses, or
+// make the check fail automatically. Unlike attack rolls, natural 1s and 20s have no special effect on checks.
+func MakeAbilityCheck(ctx context.Context, input *AbilityCheckInput) (*AbilityCheckResult, error) {
+	if input == nil {
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "input cannot be nil")
+	}
+
+	ability := input.Ability
+	if ability == "" && input.Skill != "" {
+		ability = skills.Ability(input.Skill)
+	}
+	if ability == "" {
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "ability or skill is required")
+	}
+
+	roller := input.Roller
+	if roller == nil {
+		roller = dice.NewRoller()
+	}
+
+	hasAdvantage := input.HasAdvantage
+	hasDisadvantage := input.HasDisadvantage
+	bonusFromChain := 0
+	rerollOnes := false
+	var advantageSources []dnd5eEvents.CheckModifierSource
+	var disadvantageSources []dnd5eEvents.CheckModifierSource
+	var bonusSources []dnd5eEvents.CheckBonusSource
+	var autoFailSources []dnd5eEvents.CheckModifierSource
+
+	// Track input-provided advantage/disadvantage as sources for auditability
+	if input.HasAdvantage {
+		advantageSources = append(advantageSources, dnd5eEvents.CheckModifierSource{
+			Name:       "Input",
+			SourceType: "input",
+		})
+	}
+	if input.HasDisadvantage {
+		disadvantageSources = append(disadvantageSources, dnd5eEvents.CheckModifierSource{
+			Name:       "Input",
+			SourceType: "input",
+		})
+	}
+
+	if input.EventBus != nil {
+		chainEvent := &dnd5eEvents.AbilityCheckChainEvent{
+			CheckerID: input.CheckerID,
+			Ability:   ability,
+			Skill:     input.Skill,
+			DC:        input.DC,
+			Cause:     input.Cause,
+
+			ProficiencyBonus: input.ProficiencyBonus,
+			RequiresSight:    input.RequiresSight,
+			RequiresHearing:  input.RequiresHearing,
+		}
+
+		checkChain := events.NewStagedChain[*dnd5eEvents.AbilityCheckChainEvent](combat.ModifierStages)
+		chainTopic := dnd5eEvents.AbilityCheckChain.On(input.EventBus)
+
+		modifiedChain, err := chainTopic.PublishWithChain(ctx, chainEvent, checkChain)
+		if err != nil {
+			return nil, rpgerr.Wrap(err, "failed to publish ability check chain event")
+		}
+
+		result, err := modifiedChain.Execute(ctx, chainEvent)
+		if err != nil {
+			return nil, rpgerr.Wrap(err, "failed to execute ability check chain")
+		}
+
+		if result.HasAdvantage() {
+			hasAdvantage = true
+			advantageSources = append(advantageSources, result.AdvantageSources...)
+		}
+		if result.HasDisadvantage() {
+			hasDisadvantage = true
+			disadvantageSources = append(disadvantageSources, result.DisadvantageSources...)
+		}
+		bonusFromChain = result.TotalBonus()
+		bonusSources = append(bonusSources, result.BonusSources...)
+		rerollOnes = len(result.RerollOneSources) > 0
+		autoFailSources = result.AutoFailSources
+	}
+
+	// D&D 5e Rule: Advantage and Disadvantage cancel each other out
+	d20, err := combat.RollD20(ctx, roller, combat.D20Input{
+		Advantage:    hasAdvantage,
+		Disadvantage: hasDisadvantage,
+		RerollOnes:   rerollOnes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	roll := d20.Roll
+
+	total := roll + input.Modifier + bonusFromChain
+
+	return &AbilityCheckResult{
+		Roll:                roll,
+		Total:               total,
+		DC:                  input.DC,
+		Success:             input.DC > 0 && total >= input.DC && len(autoFailSources) == 0,
+		AdvantageSources:    advantageSources,
+		DisadvantageSources: disadvantageSources,
+		BonusSources:        bonusSources,
+		AutoFailSources:     autoFailSources,
+	}, nil
+}
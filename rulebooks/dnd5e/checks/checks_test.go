@@ -0,0 +1,244 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package checks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
+)
+
+type ChecksTestSuite struct {
+	suite.Suite
+	ctx        context.Context
+	ctrl       *gomock.Controller
+	mockRoller *mock_dice.MockRoller
+	bus        events.EventBus
+}
+
+func TestChecksSuite(t *testing.T) {
+	suite.Run(t, new(ChecksTestSuite))
+}
+
+func (s *ChecksTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRoller = mock_dice.NewMockRoller(s.ctrl)
+	s.bus = events.NewEventBus()
+}
+
+func (s *ChecksTestSuite) SetupSubTest() {
+	s.SetupTest()
+}
+
+func (s *ChecksTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// subscribe adds a chain modifier for checks made by checkerID.
+func (s *ChecksTestSuite) subscribe(checkerID string, modify func(*dnd5eEvents.AbilityCheckChainEvent)) {
+	_, err := dnd5eEvents.AbilityCheckChain.On(s.bus).SubscribeWithChain(s.ctx,
+		func(
+			_ context.Context,
+			event *dnd5eEvents.AbilityCheckChainEvent,
+			c chain.Chain[*dnd5eEvents.AbilityCheckChainEvent],
+		) (chain.Chain[*dnd5eEvents.AbilityCheckChainEvent], error) {
+			if event.CheckerID != checkerID {
+				return c, nil
+			}
+			err := c.Add(combat.StageConditions, "test_modifier",
+				func(_ context.Context, e *dnd5eEvents.AbilityCheckChainEvent) (*dnd5eEvents.AbilityCheckChainEvent, error) {
+					modify(e)
+					return e, nil
+				})
+			return c, err
+		})
+	s.Require().NoError(err)
+}
+
+func (s *ChecksTestSuite) TestMakeAbilityCheck() {
+	s.Run("meets DC", func() {
+		s.mockRoller.EXPECT().Roll(s.ctx, 20).Return(12, nil)
+
+		result, err := MakeAbilityCheck(s.ctx, &AbilityCheckInput{
+			Roller:   s.mockRoller,
+			Ability:  abilities.STR,
+			DC:       15,
+			Modifier: 3,
+		})
+		s.Require().NoError(err)
+		s.Equal(12, result.Roll)
+		s.Equal(15, result.Total)
+		s.True(result.Success)
+	})
+
+	s.Run("contest check never succeeds on its own", func() {
+		s.mockRoller.EXPECT().Roll(s.ctx, 20).Return(20, nil)
+
+		result, err := MakeAbilityCheck(s.ctx, &AbilityCheckInput{
+			Roller: s.mockRoller,
+			Skill:  skills.Athletics,
+		})
+		s.Require().NoError(err)
+		s.Equal(20, result.Total)
+		s.False(result.Success)
+	})
+
+	s.Run("requires ability or skill", func() {
+		_, err := MakeAbilityCheck(s.ctx, &AbilityCheckInput{Roller: s.mockRoller, DC: 10})
+		s.Error(err)
+	})
+
+	s.Run("nil input", func() {
+		_, err := MakeAbilityCheck(s.ctx, nil)
+		s.Error(err)
+	})
+}
+
+func (s *ChecksTestSuite) TestChainModifiers() {
+	s.Run("skill sets the ability on the chain event", func() {
+		var seen *dnd5eEvents.AbilityCheckChainEvent
+		s.subscribe("rogue", func(e *dnd5eEvents.AbilityCheckChainEvent) { seen = e })
+		s.mockRoller.EXPECT().Roll(s.ctx, 20).Return(10, nil)
+
+		_, err := MakeAbilityCheck(s.ctx, &AbilityCheckInput{
+			Roller:    s.mockRoller,
+			EventBus:  s.bus,
+			CheckerID: "rogue",
+			Skill:     skills.Acrobatics,
+			DC:        12,
+		})
+		s.Require().NoError(err)
+		s.Require().NotNil(seen)
+		s.Equal(abilities.DEX, seen.Ability)
+		s.Equal(skills.Acrobatics, seen.Skill)
+	})
+
+	s.Run("advantage and bonus from chain", func() {
+		s.subscribe("rogue", func(e *dnd5eEvents.AbilityCheckChainEvent) {
+			e.AdvantageSources = append(e.AdvantageSources, dnd5eEvents.CheckModifierSource{Name: "Help"})
+			e.BonusSources = append(e.BonusSources, dnd5eEvents.CheckBonusSource{
+				CheckModifierSource: dnd5eEvents.CheckModifierSource{Name: "Guidance"},
+				Bonus:               2,
+			})
+		})
+		s.mockRoller.EXPECT().RollN(s.ctx, 2, 20).Return([]int{4, 11}, nil)
+
+		result, err := MakeAbilityCheck(s.ctx, &AbilityCheckInput{
+			Roller:    s.mockRoller,
+			EventBus:  s.bus,
+			CheckerID: "rogue",
+			Ability:   abilities.DEX,
+			DC:        15,
+			Modifier:  2,
+		})
+		s.Require().NoError(err)
+		s.Equal(11, result.Roll)
+		s.Equal(15, result.Total)
+		s.True(result.Success)
+		s.Len(result.AdvantageSources, 1)
+		s.Len(result.BonusSources, 1)
+	})
+
+	s.Run("advantage and disadvantage cancel", func() {
+		s.subscribe("rogue", func(e *dnd5eEvents.AbilityCheckChainEvent) {
+			e.DisadvantageSources = append(e.DisadvantageSources, dnd5eEvents.CheckModifierSource{Name: "Poisoned"})
+		})
+		s.mockRoller.EXPECT().Roll(s.ctx, 20).Return(9, nil)
+
+		result, err := MakeAbilityCheck(s.ctx, &AbilityCheckInput{
+			Roller:       s.mockRoller,
+			EventBus:     s.bus,
+			CheckerID:    "rogue",
+			Ability:      abilities.DEX,
+			DC:           10,
+			HasAdvantage: true,
+		})
+		s.Require().NoError(err)
+		s.Equal(9, result.Roll)
+		s.False(result.Success)
+	})
+
+	s.Run("auto-fail from chain fails a high roll", func() {
+		var seen *dnd5eEvents.AbilityCheckChainEvent
+		s.subscribe("rogue", func(e *dnd5eEvents.AbilityCheckChainEvent) {
+			seen = e
+			e.AutoFailSources = append(e.AutoFailSources, dnd5eEvents.CheckModifierSource{Name: "Blinded"})
+		})
+		s.mockRoller.EXPECT().Roll(s.ctx, 20).Return(20, nil)
+
+		result, err := MakeAbilityCheck(s.ctx, &AbilityCheckInput{
+			Roller:        s.mockRoller,
+			EventBus:      s.bus,
+			CheckerID:     "rogue",
+			Skill:         skills.Perception,
+			DC:            10,
+			RequiresSight: true,
+		})
+		s.Require().NoError(err)
+		s.True(seen.RequiresSight)
+		s.False(result.Success)
+		s.Len(result.AutoFailSources, 1)
+	})
+
+	s.Run("other checkers are unaffected", func() {
+		s.subscribe("fighter", func(e *dnd5eEvents.AbilityCheckChainEvent) {
+			e.DisadvantageSources = append(e.DisadvantageSources, dnd5eEvents.CheckModifierSource{Name: "Poisoned"})
+		})
+		s.mockRoller.EXPECT().Roll(s.ctx, 20).Return(9, nil)
+
+		result, err := MakeAbilityCheck(s.ctx, &AbilityCheckInput{
+			Roller:    s.mockRoller,
+			EventBus:  s.bus,
+			CheckerID: "rogue",
+			Ability:   abilities.DEX,
+		})
+		s.Require().NoError(err)
+		s.Empty(result.DisadvantageSources)
+	})
+}
+
+func (s *ChecksTestSuite) TestMakeContest() {
+	contest := func(initiatorRoll, defenderRoll int) *ContestResult {
+		gomock.InOrder(
+			s.mockRoller.EXPECT().Roll(s.ctx, 20).Return(initiatorRoll, nil),
+			s.mockRoller.EXPECT().Roll(s.ctx, 20).Return(defenderRoll, nil),
+		)
+
+		result, err := MakeContest(s.ctx, &ContestInput{
+			Initiator: &AbilityCheckInput{Roller: s.mockRoller, Skill: skills.Acrobatics, Modifier: 4},
+			Defender:  &AbilityCheckInput{Roller: s.mockRoller, Skill: skills.Athletics, Modifier: 5},
+		})
+		s.Require().NoError(err)
+		return result
+	}
+
+	s.Run("initiator wins with a higher total", func() {
+		result := contest(12, 10)
+		s.Equal(16, result.Initiator.Total)
+		s.Equal(15, result.Defender.Total)
+		s.True(result.InitiatorWins)
+	})
+
+	s.Run("tie keeps the status quo", func() {
+		result := contest(11, 10)
+		s.Equal(result.Initiator.Total, result.Defender.Total)
+		s.False(result.InitiatorWins)
+	})
+
+	s.Run("missing defender", func() {
+		_, err := MakeContest(s.ctx, &ContestInput{Initiator: &AbilityCheckInput{Skill: skills.Athletics}})
+		s.Error(err)
+	})
+}
@@ -0,0 +1,86 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package checks
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+)
+
+// ContestInput contains the two checks that make up a contest
+type ContestInput struct {
+	// Initiator is the check of the creature trying to change the situation
+	// (e.g. the creature trying to escape a grapple)
+	Initiator *AbilityCheckInput
+
+	// Defender is the check of the creature resisting the change
+	// (e.g. the grappler)
+	Defender *AbilityCheckInput
+}
+
+// Validate validates the input.
+func (c *ContestInput) Validate() error {
+	if c == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "ContestInput is nil")
+	}
+	if c.Initiator == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "Initiator is required")
+	}
+	if c.Defender == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "Defender is required")
+	}
+	return nil
+}
+
+// ContestResult contains the outcome of a contest
+type ContestResult struct {
+	// Initiator is the initiating creature's check result
+	Initiator *AbilityCheckResult
+
+	// Defender is the defending creature's check result
+	Defender *AbilityCheckResult
+
+	// InitiatorWins is true if the initiator's total beat the defender's
+	InitiatorWins bool
+}
+
+// MakeContest resolves a contest between two ability checks.
+//
+// Both checks run through the AbilityCheckChain. Per D&D 5e, a tie leaves the
+// situation as it was, so the initiator wins only by strictly exceeding the
+// defender's total. A check that fails automatically loses the contest; if
+// both do, the situation is unchanged.
+func MakeContest(ctx context.Context, input *ContestInput) (*ContestResult, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	initiator, err := MakeAbilityCheck(ctx, input.Initiator)
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to make initiator check")
+	}
+
+	defender, err := MakeAbilityCheck(ctx, input.Defender)
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to make defender check")
+	}
+
+	return &ContestResult{
+		Initiator:     initiator,
+		Defender:      defender,
+		InitiatorWins: initiatorWins(initiator, defender),
+	}, nil
+}
+
+// initiatorWins decides a contest, counting automatic failures as losses
+func initiatorWins(initiator, defender *AbilityCheckResult) bool {
+	if len(initiator.AutoFailSources) > 0 {
+		return false
+	}
+	if len(defender.AutoFailSources) > 0 {
+		return true
+	}
+	return initiator.Total > defender.Total
+}
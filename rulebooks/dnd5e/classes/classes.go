@@ -433,6 +433,32 @@ func SubclassParent(s Subclass) Class {
 	return Invalid
 }
 
+// ValidateSubclass checks that a subclass belongs to the class and that the character
+// has reached the class level the subclass is chosen at (e.g., level 3 for fighters).
+// An empty subclass or SubclassNone is always valid.
+func ValidateSubclass(classID Class, subclass Subclass, level int) error {
+	if subclass == "" || subclass == SubclassNone {
+		return nil
+	}
+
+	if parent := SubclassParent(subclass); parent != classID {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "subclass does not belong to class",
+			rpgerr.WithMeta("class", classID),
+			rpgerr.WithMeta("subclass", subclass))
+	}
+
+	data := GetData(classID)
+	if data == nil {
+		return rpgerr.Newf(rpgerr.CodeNotFound, "unknown class: %s", classID)
+	}
+	if level < data.SubclassLevel {
+		return rpgerr.Newf(rpgerr.CodeNotAllowed, "%s subclasses are chosen at level %d, not level %d",
+			classID, data.SubclassLevel, level)
+	}
+
+	return nil
+}
+
 // SubClassName returns the display name of the subclass
 func SubClassName(s Subclass) string {
 	if name, ok := subclassNames[s]; ok {
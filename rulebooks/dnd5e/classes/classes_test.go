@@ -0,0 +1,122 @@
+package classes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+)
+
+type ClassesTestSuite struct {
+	suite.Suite
+}
+
+func TestClassesSuite(t *testing.T) {
+	suite.Run(t, new(ClassesTestSuite))
+}
+
+func (s *ClassesTestSuite) TestValidateSubclass() {
+	testCases := []struct {
+		name     string
+		class    Class
+		subclass Subclass
+		level    int
+		wantCode rpgerr.Code
+	}{
+		{name: "fighter picks champion at level 3", class: Fighter, subclass: Champion, level: 3},
+		{name: "fighter keeps battle master past level 3", class: Fighter, subclass: BattleMaster, level: 10},
+		{name: "cleric picks a domain at level 1", class: Cleric, subclass: LifeDomain, level: 1},
+		{name: "no subclass yet", class: Fighter, subclass: "", level: 1},
+		{name: "explicit none", class: Fighter, subclass: SubclassNone, level: 1},
+		{
+			name: "fighter too low for champion", class: Fighter, subclass: Champion, level: 2,
+			wantCode: rpgerr.CodeNotAllowed,
+		},
+		{
+			name: "subclass from another class", class: Fighter, subclass: Thief, level: 3,
+			wantCode: rpgerr.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			err := ValidateSubclass(tc.class, tc.subclass, tc.level)
+			if tc.wantCode == "" {
+				s.NoError(err)
+				return
+			}
+			s.Require().Error(err)
+			s.Equal(tc.wantCode, rpgerr.GetCode(err))
+		})
+	}
+}
+
+func (s *ClassesTestSuite) TestRangerProgression() {
+	testCases := []struct {
+		level          int
+		favoredEnemies int
+		terrains       int
+		spellsKnown    int
+	}{
+		{level: 1, favoredEnemies: 1, terrains: 1, spellsKnown: 0},
+		{level: 2, favoredEnemies: 1, terrains: 1, spellsKnown: 2},
+		{level: 3, favoredEnemies: 1, terrains: 1, spellsKnown: 3},
+		{level: 4, favoredEnemies: 1, terrains: 1, spellsKnown: 3},
+		{level: 6, favoredEnemies: 2, terrains: 2, spellsKnown: 4},
+		{level: 10, favoredEnemies: 2, terrains: 3, spellsKnown: 6},
+		{level: 14, favoredEnemies: 3, terrains: 3, spellsKnown: 8},
+		{level: 20, favoredEnemies: 3, terrains: 3, spellsKnown: 11},
+	}
+
+	for _, tc := range testCases {
+		s.Equal(tc.favoredEnemies, FavoredEnemyCount(tc.level), "favored enemies at level %d", tc.level)
+		s.Equal(tc.terrains, FavoredTerrainCount(tc.level), "favored terrains at level %d", tc.level)
+		s.Equal(tc.spellsKnown, RangerSpellsKnown(tc.level), "spells known at level %d", tc.level)
+	}
+}
+
+func (s *ClassesTestSuite) TestSpellcastingProgression() {
+	testCases := []struct {
+		class    Class
+		level    int
+		spells   int
+		cantrips int
+	}{
+		{class: Bard, level: 1, spells: 4, cantrips: 2},
+		{class: Bard, level: 4, spells: 7, cantrips: 3},
+		{class: Bard, level: 10, spells: 14, cantrips: 4},
+		{class: Sorcerer, level: 3, spells: 4, cantrips: 4},
+		{class: Warlock, level: 5, spells: 6, cantrips: 3},
+		{class: Warlock, level: 25, spells: 15, cantrips: 4},
+		{class: Ranger, level: 5, spells: 4, cantrips: 0},
+		{class: Wizard, level: 4, spells: 0, cantrips: 4},
+		{class: Fighter, level: 4, spells: 0, cantrips: 0},
+		{class: Bard, level: 0, spells: 0, cantrips: 0},
+	}
+
+	for _, tc := range testCases {
+		s.Equal(tc.spells, SpellsKnownAtLevel(tc.class, tc.level), "%s spells known at level %d", tc.class, tc.level)
+		s.Equal(tc.cantrips, CantripsKnownAtLevel(tc.class, tc.level), "%s cantrips at level %d", tc.class, tc.level)
+	}
+}
+
+func (s *ClassesTestSuite) TestAbilityScoreImprovementLevels() {
+	var fighter, rogue, wizard []int
+	for level := 1; level <= 20; level++ {
+		if HasAbilityScoreImprovement(Fighter, level) {
+			fighter = append(fighter, level)
+		}
+		if HasAbilityScoreImprovement(Rogue, level) {
+			rogue = append(rogue, level)
+		}
+		if HasAbilityScoreImprovement(Wizard, level) {
+			wizard = append(wizard, level)
+		}
+	}
+
+	s.Equal([]int{4, 6, 8, 12, 14, 16, 19}, fighter)
+	s.Equal([]int{4, 8, 10, 12, 16, 19}, rogue)
+	s.Equal([]int{4, 8, 12, 16, 19}, wizard)
+	s.False(HasAbilityScoreImprovement("pastry-chef", 4))
+}
@@ -214,6 +214,15 @@ func getRogueGrants() []Grant {
 			},
 			// Note: Expertise is a CHOICE, not a grant - handled separately
 		},
+		{
+			Level: 2,
+			// Cunning Action - Dash, Disengage, or Hide as a bonus action
+			Features: []FeatureRef{
+				{
+					Ref: refs.Features.CunningAction().String(),
+				},
+			},
+		},
 	}
 }
 
@@ -25,7 +25,7 @@ func (s *GrantTestSuite) TestGetGrants_Rogue_ReturnsGrants() {
 	grants := GetGrants(Rogue)
 
 	s.Require().NotNil(grants, "GetGrants(Rogue) should not return nil")
-	s.Require().Len(grants, 1, "Rogue should have 1 grant at level 1")
+	s.Require().Len(grants, 2, "Rogue should have grants at levels 1 and 2")
 
 	level1 := grants[0]
 	s.Equal(1, level1.Level, "First grant should be at level 1")
@@ -34,7 +34,7 @@ func (s *GrantTestSuite) TestGetGrants_Rogue_ReturnsGrants() {
 func (s *GrantTestSuite) TestGetGrants_Rogue_Level1Proficiencies() {
 	grants := GetGrants(Rogue)
 	s.Require().NotNil(grants)
-	s.Require().Len(grants, 1)
+	s.Require().Len(grants, 2)
 
 	level1 := grants[0]
 
@@ -64,7 +64,7 @@ func (s *GrantTestSuite) TestGetGrants_Rogue_Level1Proficiencies() {
 func (s *GrantTestSuite) TestGetGrants_Rogue_Level1SneakAttack() {
 	grants := GetGrants(Rogue)
 	s.Require().NotNil(grants)
-	s.Require().Len(grants, 1)
+	s.Require().Len(grants, 2)
 
 	level1 := grants[0]
 
@@ -79,7 +79,7 @@ func (s *GrantTestSuite) TestGetGrants_Rogue_Level1SneakAttack() {
 func (s *GrantTestSuite) TestGetGrants_Rogue_Level1ThievesCant() {
 	grants := GetGrants(Rogue)
 	s.Require().NotNil(grants)
-	s.Require().Len(grants, 1)
+	s.Require().Len(grants, 2)
 
 	level1 := grants[0]
 
@@ -95,7 +95,7 @@ func (s *GrantTestSuite) TestGetGrants_Rogue_EndToEndConditionCreation() {
 
 	grants := GetGrants(Rogue)
 	s.Require().NotNil(grants)
-	s.Require().Len(grants, 1)
+	s.Require().Len(grants, 2)
 
 	level1 := grants[0]
 	s.Require().Len(level1.Conditions, 1)
@@ -110,6 +110,19 @@ func (s *GrantTestSuite) TestGetGrants_Rogue_EndToEndConditionCreation() {
 	s.Equal("dnd5e:conditions:sneak_attack", condRef.Ref)
 }
 
+func (s *GrantTestSuite) TestGetGrants_Rogue_Level2CunningAction() {
+	grants := GetGrantsForLevel(Rogue, 2)
+	s.Require().Len(grants, 2)
+
+	level2 := grants[1]
+	s.Equal(2, level2.Level)
+	s.Require().Len(level2.Features, 1, "Rogue should have 1 feature at level 2")
+	s.Equal(refs.Features.CunningAction().String(), level2.Features[0].Ref,
+		"Rogue should have cunning action feature")
+
+	s.Len(GetGrantsForLevel(Rogue, 1), 1, "Cunning Action is not granted at level 1")
+}
+
 // =============================================================================
 // Fighter Tests
 // =============================================================================
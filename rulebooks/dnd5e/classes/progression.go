@@ -0,0 +1,55 @@
+package classes
+
+// spellsKnownByLevel is how many spells a class that learns spells knows,
+// indexed by class level starting at 1. Rangers use RangerSpellsKnown.
+var spellsKnownByLevel = map[Class][]int{
+	Bard:     {4, 5, 6, 7, 8, 9, 10, 11, 12, 14, 15, 15, 16, 18, 19, 19, 20, 22, 22, 22},
+	Sorcerer: {2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 12, 13, 13, 14, 14, 15, 15, 15, 15},
+	Warlock:  {2, 3, 4, 5, 6, 7, 8, 9, 10, 10, 11, 11, 12, 12, 13, 13, 14, 14, 15, 15},
+}
+
+// SpellsKnownAtLevel returns how many spells a bard, ranger, sorcerer or warlock
+// of the given level knows. Returns 0 for classes that prepare spells instead.
+func SpellsKnownAtLevel(classID Class, level int) int {
+	if classID == Ranger {
+		return RangerSpellsKnown(level)
+	}
+	table := spellsKnownByLevel[classID]
+	if level < 1 || len(table) == 0 {
+		return 0
+	}
+	return table[min(level, len(table))-1]
+}
+
+// CantripsKnownAtLevel returns how many cantrips a class of the given level knows:
+// its level 1 cantrips, plus one more at levels 4 and 10.
+func CantripsKnownAtLevel(classID Class, level int) int {
+	data := ClassData[classID]
+	if data == nil || data.CantripsKnown == 0 || level < 1 {
+		return 0
+	}
+	known := data.CantripsKnown
+	if level >= 4 {
+		known++
+	}
+	if level >= 10 {
+		known++
+	}
+	return known
+}
+
+// HasAbilityScoreImprovement reports whether a class gains an Ability Score
+// Improvement at the given level. Every class gains one at levels 4, 8, 12, 16
+// and 19; fighters also at 6 and 14, and rogues at 10.
+func HasAbilityScoreImprovement(classID Class, level int) bool {
+	switch level {
+	case 4, 8, 12, 16, 19:
+		return ClassData[classID] != nil
+	case 6, 14:
+		return classID == Fighter
+	case 10:
+		return classID == Rogue
+	default:
+		return false
+	}
+}
@@ -0,0 +1,40 @@
+package classes
+
+// FavoredEnemyCount returns how many favored enemy types a ranger of the given level
+// has chosen: one at level 1, plus one more at levels 6 and 14.
+func FavoredEnemyCount(rangerLevel int) int {
+	switch {
+	case rangerLevel < 1:
+		return 0
+	case rangerLevel < 6:
+		return 1
+	case rangerLevel < 14:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// FavoredTerrainCount returns how many favored terrains a ranger of the given level
+// has chosen with Natural Explorer: one at level 1, plus one more at levels 6 and 10.
+func FavoredTerrainCount(rangerLevel int) int {
+	switch {
+	case rangerLevel < 1:
+		return 0
+	case rangerLevel < 6:
+		return 1
+	case rangerLevel < 10:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// RangerSpellsKnown returns how many spells a ranger of the given level knows.
+// Rangers learn their first two spells at level 2 and one more at every odd level after.
+func RangerSpellsKnown(rangerLevel int) int {
+	if rangerLevel < 2 {
+		return 0
+	}
+	return (min(rangerLevel, 20)+1)/2 + 1
+}
@@ -3,7 +3,12 @@
 
 package combat
 
-import "github.com/KirkDiggler/rpg-toolkit/rpgerr"
+import (
+	"slices"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+)
 
 // ActionEconomy tracks the available actions, bonus actions, and reactions for a combatant
 // Purpose: Manages the action economy system for D&D 5e combat, ensuring combatants can only
@@ -17,10 +22,18 @@ type ActionEconomy struct {
 	// Capacity (set when specific abilities are used)
 	AttacksRemaining  int // Set when Attack ability is taken (stays 0 until then)
 	MovementRemaining int // Set at turn start from character speed
+	MovementUsed      int // Feet spent since SetMovement, including extra costs
 
 	// Additional capacity for granted actions
 	OffHandAttacksRemaining int // Set by TwoWeaponGranter after main-hand attack
 	FlurryStrikesRemaining  int // Set by FlurryOfBlows feature (usually 2)
+
+	// Legendary actions (legendary creatures only, 0 for everyone else)
+	LegendaryActionsPerRound  int // Set via SetLegendaryActions (usually 3)
+	LegendaryActionsRemaining int // Refreshed by Reset at the start of the creature's turn
+
+	// Restriction is imposed by active conditions (set via ApplyConditions)
+	Restriction EconomyRestriction
 }
 
 // NewActionEconomy creates a new ActionEconomy with default values (1/1/1)
@@ -36,48 +49,101 @@ func NewActionEconomy() *ActionEconomy {
 // CanUseAction returns whether an action is available
 // Purpose: Allows checking action availability without consuming it
 func (ae *ActionEconomy) CanUseAction() bool {
-	return ae.ActionsRemaining > 0
+	return !ae.Restriction.NoActions && ae.ActionsRemaining > 0
 }
 
 // CanUseBonusAction returns whether a bonus action is available
 // Purpose: Allows checking bonus action availability without consuming it
 func (ae *ActionEconomy) CanUseBonusAction() bool {
-	return ae.BonusActionsRemaining > 0
+	return !ae.Restriction.NoActions && ae.BonusActionsRemaining > 0
 }
 
 // CanUseReaction returns whether a reaction is available
 // Purpose: Allows checking reaction availability without consuming it
 func (ae *ActionEconomy) CanUseReaction() bool {
-	return ae.ReactionsRemaining > 0
+	return !ae.Restriction.NoReactions && ae.ReactionsRemaining > 0
 }
 
 // UseAction consumes an action if available
-// Returns CodeResourceExhausted if no actions remain
+// Returns CodeNotAllowed if a condition prevents actions,
+// or CodeResourceExhausted if no actions remain
 func (ae *ActionEconomy) UseAction() error {
+	if err := ae.CheckAction(); err != nil {
+		return err
+	}
+	ae.ActionsRemaining--
+	return nil
+}
+
+// CheckAction returns why an action can't be used, or nil if one is available
+// Purpose: Error-returning counterpart to CanUseAction for validation before consuming.
+// Returns CodeNotAllowed if a condition prevents it, or CodeResourceExhausted if none remain
+func (ae *ActionEconomy) CheckAction() error {
+	if ae.Restriction.NoActions {
+		return ae.restrictedError("action")
+	}
 	if ae.ActionsRemaining <= 0 {
 		return rpgerr.ResourceExhausted("action")
 	}
-	ae.ActionsRemaining--
 	return nil
 }
 
 // UseBonusAction consumes a bonus action if available
-// Returns CodeResourceExhausted if no bonus actions remain
+// Returns CodeNotAllowed if a condition prevents actions,
+// or CodeResourceExhausted if no bonus actions remain
 func (ae *ActionEconomy) UseBonusAction() error {
+	if err := ae.CheckBonusAction(); err != nil {
+		return err
+	}
+	ae.BonusActionsRemaining--
+	return nil
+}
+
+// CheckBonusAction returns why a bonus action can't be used, or nil if one is available
+// Purpose: Error-returning counterpart to CanUseBonusAction for validation before consuming.
+// Returns CodeNotAllowed if a condition prevents it, or CodeResourceExhausted if none remain
+func (ae *ActionEconomy) CheckBonusAction() error {
+	if ae.Restriction.NoActions {
+		return ae.restrictedError("bonus action")
+	}
 	if ae.BonusActionsRemaining <= 0 {
 		return rpgerr.ResourceExhausted("bonus action")
 	}
-	ae.BonusActionsRemaining--
 	return nil
 }
 
 // UseReaction consumes a reaction if available
-// Returns CodeResourceExhausted if no reactions remain
+// Returns CodeNotAllowed if a condition prevents reactions,
+// or CodeResourceExhausted if no reactions remain
 func (ae *ActionEconomy) UseReaction() error {
+	if err := ae.CheckReaction(); err != nil {
+		return err
+	}
+	ae.ReactionsRemaining--
+	return nil
+}
+
+// CheckReaction returns why a reaction can't be used, or nil if one is available
+// Purpose: Error-returning counterpart to CanUseReaction for validation before consuming.
+// Returns CodeNotAllowed if a condition prevents it, or CodeResourceExhausted if none remain
+func (ae *ActionEconomy) CheckReaction() error {
+	if ae.Restriction.NoReactions {
+		return ae.restrictedError("reaction")
+	}
 	if ae.ReactionsRemaining <= 0 {
 		return rpgerr.ResourceExhausted("reaction")
 	}
-	ae.ReactionsRemaining--
+	return nil
+}
+
+// CheckSpellcasting returns why spells can't be cast, or nil if nothing prevents it
+// Purpose: Lets spell casting reject casters whose conditions forbid spells (Wild Shape)
+// before checking the casting time's action.
+// Returns CodeNotAllowed if a condition prevents spellcasting
+func (ae *ActionEconomy) CheckSpellcasting() error {
+	if ae.Restriction.NoSpellcasting {
+		return ae.restrictedError("spellcasting")
+	}
 	return nil
 }
 
@@ -86,6 +152,8 @@ func (ae *ActionEconomy) UseReaction() error {
 // Note: Does NOT reset AttacksRemaining (stays 0 until Attack ability is used) or
 // MovementRemaining (should be set separately via SetMovement at turn start).
 // Resets turn-granted capacity (OffHandAttacks, FlurryStrikes) to 0.
+// Refreshes legendary actions to LegendaryActionsPerRound.
+// Any condition Restriction stays in force.
 func (ae *ActionEconomy) Reset() {
 	ae.ActionsRemaining = 1
 	ae.BonusActionsRemaining = 1
@@ -94,17 +162,62 @@ func (ae *ActionEconomy) Reset() {
 	// They are set separately by abilities (Attack) and at turn start (SetMovement)
 	ae.OffHandAttacksRemaining = 0
 	ae.FlurryStrikesRemaining = 0
+	ae.LegendaryActionsRemaining = ae.LegendaryActionsPerRound
+	ae.enforceRestriction()
+}
+
+// ApplyConditions restricts the economy according to the given active conditions
+// Purpose: Lets Stunned/Incapacitated/Paralyzed zero out actions and reactions, and
+// Restrained/Grappled zero movement, without game servers enforcing each case.
+// Replaces any previous restriction. Resources already zeroed by an earlier
+// restriction are not restored until the next Reset/SetMovement.
+// Returns the combined restriction now in force.
+func (ae *ActionEconomy) ApplyConditions(conditionRefs []*core.Ref) EconomyRestriction {
+	ae.Restriction = CombineConditionRestrictions(conditionRefs)
+	ae.enforceRestriction()
+	return ae.Restriction
+}
+
+// enforceRestriction zeroes the resources the current restriction removes
+func (ae *ActionEconomy) enforceRestriction() {
+	if ae.Restriction.NoActions {
+		ae.ActionsRemaining = 0
+		ae.BonusActionsRemaining = 0
+		ae.AttacksRemaining = 0
+		ae.OffHandAttacksRemaining = 0
+		ae.FlurryStrikesRemaining = 0
+		ae.LegendaryActionsRemaining = 0
+	}
+	if ae.Restriction.NoReactions {
+		ae.ReactionsRemaining = 0
+	}
+	if ae.Restriction.NoMovement {
+		ae.MovementRemaining = 0
+	}
+}
+
+// restrictedError builds the error returned when a condition prevents using a resource
+func (ae *ActionEconomy) restrictedError(resource string) error {
+	return rpgerr.Newf(rpgerr.CodeNotAllowed, "%s not allowed while %s", resource, ae.Restriction.describe())
 }
 
 // GrantExtraAction grants an additional action
 // Purpose: Used by features like Action Surge that provide extra actions beyond the normal limit
+// Has no effect while a condition prevents actions.
 func (ae *ActionEconomy) GrantExtraAction() {
+	if ae.Restriction.NoActions {
+		return
+	}
 	ae.ActionsRemaining++
 }
 
 // GrantExtraBonusAction grants an additional bonus action
 // Purpose: Future-proofing for potential features that grant extra bonus actions
+// Has no effect while a condition prevents actions.
 func (ae *ActionEconomy) GrantExtraBonusAction() {
+	if ae.Restriction.NoActions {
+		return
+	}
 	ae.BonusActionsRemaining++
 }
 
@@ -142,27 +255,74 @@ func (ae *ActionEconomy) CanUseMovement(cost int) bool {
 
 // UseMovement consumes the specified amount of movement if available
 // Purpose: Called by Move actions to consume movement when moving on the battlefield.
-// Returns CodeResourceExhausted if insufficient movement remains.
+// Movement left over stays available, so a creature can split its movement
+// around its action and bonus action. The cost is added to MovementUsed.
+// Returns CodeNotAllowed if a condition reduces speed to 0,
+// or CodeResourceExhausted if insufficient movement remains.
 // Does not consume partial movement - it's all or nothing.
 func (ae *ActionEconomy) UseMovement(cost int) error {
+	if ae.Restriction.NoMovement && cost > 0 {
+		return ae.restrictedError("movement")
+	}
 	if ae.MovementRemaining < cost {
 		return rpgerr.ResourceExhausted("movement")
 	}
 	ae.MovementRemaining -= cost
+	ae.MovementUsed += cost
 	return nil
 }
 
+// RefundMovement returns movement spent on a step that didn't happen
+// Purpose: Called when movement is paid for and then interrupted before the
+// creature leaves its square. Never refunds more than MovementUsed.
+func (ae *ActionEconomy) RefundMovement(amount int) {
+	amount = min(amount, ae.MovementUsed)
+	if amount <= 0 {
+		return
+	}
+	ae.MovementUsed -= amount
+	if !ae.Restriction.NoMovement {
+		ae.MovementRemaining += amount
+	}
+}
+
+// StopMovement ends the creature's movement for the turn
+// Purpose: Called when an effect reduces speed to 0 mid-move (e.g. Sentinel).
+// Unlike SetMovement(0), the movement already used this turn is kept.
+func (ae *ActionEconomy) StopMovement() {
+	ae.MovementRemaining = 0
+}
+
 // SetMovement sets the movement remaining to the specified amount
 // Purpose: Called at turn start to set movement from character speed.
-// Overwrites any existing movement value.
+// Overwrites any existing movement value and starts MovementUsed over.
+// Conditions that reduce speed to 0 (see ApplyConditions) keep movement at 0.
 func (ae *ActionEconomy) SetMovement(amount int) {
+	if ae.Restriction.NoMovement {
+		amount = 0
+	}
 	ae.MovementRemaining = amount
+	ae.MovementUsed = 0
+}
+
+// GrantReadiedMovement sets up the movement for a readied Move being released
+// Purpose: Called when a Move held with the Ready action (refs.Actions.Move())
+// is released by its trigger. Per D&D 5e the creature moves up to its speed;
+// movement left over from its own turn can't be used, so this replaces
+// MovementRemaining like SetMovement. The reaction is consumed by the
+// readied action condition, not here.
+func (ae *ActionEconomy) GrantReadiedMovement(speed int) {
+	ae.SetMovement(speed)
 }
 
 // AddMovement adds the specified amount to movement remaining
 // Purpose: Called by the Dash ability to add the character's speed again.
 // Can be called multiple times (e.g., Rogue's Cunning Action Dash).
+// Has no effect while a condition reduces speed to 0.
 func (ae *ActionEconomy) AddMovement(amount int) {
+	if ae.Restriction.NoMovement {
+		return
+	}
 	ae.MovementRemaining += amount
 }
 
@@ -215,3 +375,98 @@ func (ae *ActionEconomy) UseFlurryStrike() error {
 func (ae *ActionEconomy) SetFlurryStrikes(count int) {
 	ae.FlurryStrikesRemaining = count
 }
+
+// CanUseLegendaryAction returns whether enough legendary actions remain for the given cost
+// Purpose: Allows checking legendary action availability without consuming it.
+// Returns false for creatures without legendary actions.
+func (ae *ActionEconomy) CanUseLegendaryAction(cost int) bool {
+	return ae.CheckLegendaryAction(cost) == nil
+}
+
+// UseLegendaryAction consumes legendary actions for the given cost if available
+// Purpose: Called by LegendaryActions.Take at the end of another creature's turn.
+// Returns CodeNotAllowed if a condition prevents actions,
+// or CodeResourceExhausted if too few legendary actions remain.
+func (ae *ActionEconomy) UseLegendaryAction(cost int) error {
+	if err := ae.CheckLegendaryAction(cost); err != nil {
+		return err
+	}
+	ae.LegendaryActionsRemaining -= cost
+	return nil
+}
+
+// CheckLegendaryAction returns why legendary actions of the given cost can't be used, or nil
+// Purpose: Error-returning counterpart to CanUseLegendaryAction for validation before consuming.
+// Returns CodeNotAllowed if a condition prevents it, or CodeResourceExhausted if too few remain
+func (ae *ActionEconomy) CheckLegendaryAction(cost int) error {
+	if ae.Restriction.NoActions {
+		return ae.restrictedError("legendary action")
+	}
+	if ae.LegendaryActionsRemaining < cost {
+		return rpgerr.ResourceExhausted("legendary action")
+	}
+	return nil
+}
+
+// SetLegendaryActions sets the legendary actions per round and refreshes the pool
+// Purpose: Called when a legendary creature joins combat. Reset refreshes the pool
+// to this amount at the start of each of the creature's turns.
+func (ae *ActionEconomy) SetLegendaryActions(perRound int) {
+	ae.LegendaryActionsPerRound = perRound
+	ae.LegendaryActionsRemaining = perRound
+	ae.enforceRestriction()
+}
+
+// ActionEconomyData is the persistent form of an ActionEconomy, for saving a
+// fight mid-turn
+type ActionEconomyData struct {
+	ActionsRemaining          int                `json:"actions_remaining"`
+	BonusActionsRemaining     int                `json:"bonus_actions_remaining"`
+	ReactionsRemaining        int                `json:"reactions_remaining"`
+	AttacksRemaining          int                `json:"attacks_remaining,omitempty"`
+	MovementRemaining         int                `json:"movement_remaining,omitempty"`
+	MovementUsed              int                `json:"movement_used,omitempty"`
+	OffHandAttacksRemaining   int                `json:"off_hand_attacks_remaining,omitempty"`
+	FlurryStrikesRemaining    int                `json:"flurry_strikes_remaining,omitempty"`
+	LegendaryActionsPerRound  int                `json:"legendary_actions_per_round,omitempty"`
+	LegendaryActionsRemaining int                `json:"legendary_actions_remaining,omitempty"`
+	Restriction               EconomyRestriction `json:"restriction"`
+}
+
+// ToData converts the action economy to its persistent form
+func (ae *ActionEconomy) ToData() ActionEconomyData {
+	restriction := ae.Restriction
+	restriction.Sources = slices.Clone(ae.Restriction.Sources)
+	return ActionEconomyData{
+		ActionsRemaining:          ae.ActionsRemaining,
+		BonusActionsRemaining:     ae.BonusActionsRemaining,
+		ReactionsRemaining:        ae.ReactionsRemaining,
+		AttacksRemaining:          ae.AttacksRemaining,
+		MovementRemaining:         ae.MovementRemaining,
+		MovementUsed:              ae.MovementUsed,
+		OffHandAttacksRemaining:   ae.OffHandAttacksRemaining,
+		FlurryStrikesRemaining:    ae.FlurryStrikesRemaining,
+		LegendaryActionsPerRound:  ae.LegendaryActionsPerRound,
+		LegendaryActionsRemaining: ae.LegendaryActionsRemaining,
+		Restriction:               restriction,
+	}
+}
+
+// LoadActionEconomyData restores an action economy from its persistent form
+func LoadActionEconomyData(data ActionEconomyData) *ActionEconomy {
+	restriction := data.Restriction
+	restriction.Sources = slices.Clone(data.Restriction.Sources)
+	return &ActionEconomy{
+		ActionsRemaining:          data.ActionsRemaining,
+		BonusActionsRemaining:     data.BonusActionsRemaining,
+		ReactionsRemaining:        data.ReactionsRemaining,
+		AttacksRemaining:          data.AttacksRemaining,
+		MovementRemaining:         data.MovementRemaining,
+		MovementUsed:              data.MovementUsed,
+		OffHandAttacksRemaining:   data.OffHandAttacksRemaining,
+		FlurryStrikesRemaining:    data.FlurryStrikesRemaining,
+		LegendaryActionsPerRound:  data.LegendaryActionsPerRound,
+		LegendaryActionsRemaining: data.LegendaryActionsRemaining,
+		Restriction:               restriction,
+	}
+}
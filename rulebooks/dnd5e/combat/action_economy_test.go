@@ -3,7 +3,9 @@ package combat
 import (
 	"testing"
 
+	"github.com/KirkDiggler/rpg-toolkit/core"
 	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -563,6 +565,80 @@ func (s *ActionEconomyTestSuite) TestAddMovement() {
 	})
 }
 
+func (s *ActionEconomyTestSuite) TestMovementUsed() {
+	s.Run("tracks movement split around an action", func() {
+		s.economy.SetMovement(30)
+		s.Require().NoError(s.economy.UseMovement(10))
+		s.Require().NoError(s.economy.UseAction())
+		s.Require().NoError(s.economy.UseMovement(15))
+
+		s.Equal(25, s.economy.MovementUsed)
+		s.Equal(5, s.economy.MovementRemaining)
+	})
+
+	s.Run("failed use does not count", func() {
+		s.economy.SetMovement(10)
+		s.Error(s.economy.UseMovement(15))
+		s.Equal(0, s.economy.MovementUsed)
+	})
+
+	s.Run("set movement starts over", func() {
+		s.economy.SetMovement(30)
+		s.Require().NoError(s.economy.UseMovement(20))
+		s.economy.SetMovement(30)
+		s.Equal(0, s.economy.MovementUsed)
+		s.Equal(30, s.economy.MovementRemaining)
+	})
+
+	s.Run("refund returns spent movement", func() {
+		s.economy.SetMovement(30)
+		s.Require().NoError(s.economy.UseMovement(10))
+		s.economy.RefundMovement(5)
+		s.Equal(5, s.economy.MovementUsed)
+		s.Equal(25, s.economy.MovementRemaining)
+	})
+
+	s.Run("refund never exceeds movement used", func() {
+		s.economy.SetMovement(30)
+		s.Require().NoError(s.economy.UseMovement(5))
+		s.economy.RefundMovement(20)
+		s.Equal(0, s.economy.MovementUsed)
+		s.Equal(30, s.economy.MovementRemaining)
+	})
+
+	s.Run("stop keeps movement used", func() {
+		s.economy.SetMovement(30)
+		s.Require().NoError(s.economy.UseMovement(10))
+		s.economy.StopMovement()
+		s.Equal(10, s.economy.MovementUsed)
+		s.Equal(0, s.economy.MovementRemaining)
+	})
+
+	s.Run("round trips through data", func() {
+		s.economy.SetMovement(30)
+		s.Require().NoError(s.economy.UseMovement(10))
+		loaded := LoadActionEconomyData(s.economy.ToData())
+		s.Equal(10, loaded.MovementUsed)
+		s.Equal(20, loaded.MovementRemaining)
+	})
+}
+
+func (s *ActionEconomyTestSuite) TestGrantReadiedMovement() {
+	s.Run("replaces movement left from the creature's turn", func() {
+		s.economy.SetMovement(30)
+		s.Require().NoError(s.economy.UseMovement(25))
+		s.economy.GrantReadiedMovement(30)
+		s.Equal(30, s.economy.MovementRemaining)
+		s.Equal(0, s.economy.MovementUsed)
+	})
+
+	s.Run("grants nothing while speed is 0", func() {
+		s.economy.ApplyConditions([]*core.Ref{refs.Conditions.Grappled()})
+		s.economy.GrantReadiedMovement(30)
+		s.Equal(0, s.economy.MovementRemaining)
+	})
+}
+
 func (s *ActionEconomyTestSuite) TestResetDoesNotAffectSubResources() {
 	s.Run("reset does not clear attacks remaining", func() {
 		s.economy.SetAttacks(2)
@@ -829,3 +905,138 @@ func (s *ActionEconomyTestSuite) TestResetClearsGrantedCapacity() {
 		s.Equal(0, s.economy.FlurryStrikesRemaining)
 	})
 }
+
+func (s *ActionEconomyTestSuite) TestApplyConditions() {
+	s.Run("stunned removes actions, reactions, and movement", func() {
+		s.economy.SetMovement(30)
+		s.economy.SetAttacks(2)
+
+		restriction := s.economy.ApplyConditions([]*core.Ref{refs.Conditions.Stunned()})
+
+		s.True(restriction.NoActions)
+		s.True(restriction.NoReactions)
+		s.True(restriction.NoMovement)
+		s.Equal(0, s.economy.ActionsRemaining)
+		s.Equal(0, s.economy.BonusActionsRemaining)
+		s.Equal(0, s.economy.ReactionsRemaining)
+		s.Equal(0, s.economy.MovementRemaining)
+		s.Equal(0, s.economy.AttacksRemaining)
+	})
+
+	s.Run("incapacitated leaves movement", func() {
+		s.economy.SetMovement(30)
+
+		s.economy.ApplyConditions([]*core.Ref{refs.Conditions.Incapacitated()})
+
+		s.False(s.economy.CanUseAction())
+		s.False(s.economy.CanUseReaction())
+		s.Equal(30, s.economy.MovementRemaining)
+	})
+
+	s.Run("restrained and grappled only remove movement", func() {
+		for _, ref := range []*core.Ref{refs.Conditions.Restrained(), refs.Conditions.Grappled()} {
+			economy := NewActionEconomy()
+			economy.SetMovement(30)
+
+			economy.ApplyConditions([]*core.Ref{ref})
+
+			s.True(economy.CanUseAction(), ref.ID)
+			s.True(economy.CanUseReaction(), ref.ID)
+			s.Equal(0, economy.MovementRemaining, ref.ID)
+		}
+	})
+
+	s.Run("turned only removes reactions", func() {
+		s.economy.SetMovement(30)
+
+		s.economy.ApplyConditions([]*core.Ref{refs.Conditions.Turned()})
+
+		s.True(s.economy.CanUseAction())
+		s.False(s.economy.CanUseReaction())
+		s.Equal(30, s.economy.MovementRemaining)
+	})
+
+	s.Run("wild shaped only removes spellcasting", func() {
+		s.economy.SetMovement(30)
+
+		s.economy.ApplyConditions([]*core.Ref{refs.Conditions.WildShaped()})
+
+		s.True(s.economy.CanUseAction())
+		s.True(s.economy.CanUseReaction())
+		s.Equal(30, s.economy.MovementRemaining)
+
+		err := s.economy.CheckSpellcasting()
+		s.Require().Error(err)
+		s.Equal(rpgerr.CodeNotAllowed, rpgerr.GetCode(err))
+		s.Contains(err.Error(), "wild_shaped")
+	})
+
+	s.Run("conditions without economy effects are ignored", func() {
+		restriction := s.economy.ApplyConditions([]*core.Ref{refs.Conditions.Poisoned(), refs.Conditions.Dodging()})
+
+		s.False(restriction.IsRestricted())
+		s.Empty(restriction.Sources)
+		s.True(s.economy.CanUseAction())
+	})
+
+	s.Run("combines sources from every restricting condition", func() {
+		restriction := s.economy.ApplyConditions([]*core.Ref{
+			refs.Conditions.Grappled(),
+			refs.Conditions.Poisoned(),
+			refs.Conditions.Incapacitated(),
+		})
+
+		s.True(restriction.NoActions)
+		s.True(restriction.NoMovement)
+		s.Equal([]*core.Ref{refs.Conditions.Grappled(), refs.Conditions.Incapacitated()}, restriction.Sources)
+	})
+
+	s.Run("replacing conditions lifts the restriction", func() {
+		s.economy.ApplyConditions([]*core.Ref{refs.Conditions.Stunned()})
+		s.economy.ApplyConditions(nil)
+
+		s.False(s.economy.Restriction.IsRestricted())
+		s.Require().NoError(s.economy.UseMovement(0))
+
+		// Resources spent to the restriction come back at the next turn
+		s.economy.Reset()
+		s.True(s.economy.CanUseAction())
+	})
+}
+
+func (s *ActionEconomyTestSuite) TestRestrictedEconomyRejectsUse() {
+	s.Run("use returns not allowed naming the condition", func() {
+		s.economy.ApplyConditions([]*core.Ref{refs.Conditions.Paralyzed()})
+
+		err := s.economy.UseAction()
+		s.Require().Error(err)
+		s.True(rpgerr.IsNotAllowed(err))
+		s.Contains(err.Error(), "paralyzed")
+
+		s.True(rpgerr.IsNotAllowed(s.economy.UseBonusAction()))
+		s.True(rpgerr.IsNotAllowed(s.economy.UseReaction()))
+		s.True(rpgerr.IsNotAllowed(s.economy.UseMovement(5)))
+	})
+
+	s.Run("grants have no effect while restricted", func() {
+		s.economy.ApplyConditions([]*core.Ref{refs.Conditions.Stunned()})
+
+		s.economy.GrantExtraAction()
+		s.economy.GrantExtraBonusAction()
+		s.economy.AddMovement(30)
+		s.economy.SetMovement(30)
+
+		s.Equal(0, s.economy.ActionsRemaining)
+		s.Equal(0, s.economy.BonusActionsRemaining)
+		s.Equal(0, s.economy.MovementRemaining)
+	})
+
+	s.Run("reset keeps the restriction in force", func() {
+		s.economy.ApplyConditions([]*core.Ref{refs.Conditions.Unconscious()})
+
+		s.economy.Reset()
+
+		s.Equal(0, s.economy.ActionsRemaining)
+		s.Equal(0, s.economy.ReactionsRemaining)
+	})
+}
@@ -0,0 +1,673 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package combat
+
+import (
+	"context"
+	"math"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/dice"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/weapons"
+	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
+)
+
+// AreaShape is the shape of an area of effect template.
+type AreaShape string
+
+const (
+	// AreaShapeSphere covers every square within Size feet of the origin.
+	AreaShapeSphere AreaShape = "sphere"
+
+	// AreaShapeCube covers a Size-foot cube with the origin at one corner,
+	// extending toward Direction (or +X/+Y when Direction is nil).
+	AreaShapeCube AreaShape = "cube"
+
+	// AreaShapeCone spreads from the origin toward Direction for Size feet.
+	// Its width at any point equals its distance from the origin.
+	AreaShapeCone AreaShape = "cone"
+
+	// AreaShapeLine extends from the origin toward Direction for Size feet
+	// and is one square (5 feet) wide.
+	AreaShapeLine AreaShape = "line"
+)
+
+// coneHalfAngle is the half-angle of a 5e cone, whose width equals its length.
+var coneHalfAngle = math.Atan(0.5)
+
+// AreaTemplate describes the shape and extent of an area effect.
+type AreaTemplate struct {
+	// Shape is the template shape.
+	Shape AreaShape
+
+	// Size is the template's extent in feet: radius for spheres, side length
+	// for cubes, and length for cones and lines.
+	Size int
+
+	// Direction is a point the template is aimed at. Required for cones and
+	// lines; optional for cubes.
+	Direction *spatial.Position
+}
+
+// Validate validates the template fields.
+func (t *AreaTemplate) Validate() error {
+	if t.Size <= 0 {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "template Size must be positive")
+	}
+	switch t.Shape {
+	case AreaShapeSphere, AreaShapeCube:
+		return nil
+	case AreaShapeCone, AreaShapeLine:
+		if t.Direction == nil {
+			return rpgerr.Newf(rpgerr.CodeInvalidArgument, "%s template requires a Direction", t.Shape)
+		}
+		return nil
+	default:
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument, "unknown template shape: %s", t.Shape)
+	}
+}
+
+// Positions returns the grid positions covered by the template from origin.
+// The origin square itself is excluded for cones and lines, which emanate from it.
+func (t *AreaTemplate) Positions(grid spatial.Grid, origin spatial.Position) ([]spatial.Position, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+
+	length := float64(t.Size) / FeetPerGridUnit
+
+	var dirX, dirY float64
+	if t.Direction != nil {
+		dirX, dirY = t.Direction.X-origin.X, t.Direction.Y-origin.Y
+	}
+	dirLength := math.Hypot(dirX, dirY)
+	if (t.Shape == AreaShapeCone || t.Shape == AreaShapeLine) && dirLength == 0 {
+		return nil, rpgerr.Newf(rpgerr.CodeInvalidArgument, "%s template Direction must differ from origin", t.Shape)
+	}
+	if dirLength > 0 {
+		dirX, dirY = dirX/dirLength, dirY/dirLength
+	}
+
+	var positions []spatial.Position
+	for _, pos := range grid.GetPositionsInRange(origin, length) {
+		dx, dy := pos.X-origin.X, pos.Y-origin.Y
+
+		switch t.Shape {
+		case AreaShapeSphere:
+			positions = append(positions, pos)
+
+		case AreaShapeCube:
+			// Squares covered run from the origin corner to Size-1 squares away
+			// along each axis, in the sign of the direction (default positive).
+			if withinCubeAxis(dx, dirX, length) && withinCubeAxis(dy, dirY, length) {
+				positions = append(positions, pos)
+			}
+
+		case AreaShapeCone:
+			dist := math.Hypot(dx, dy)
+			if dist == 0 {
+				continue
+			}
+			cos := (dx*dirX + dy*dirY) / dist
+			if math.Acos(math.Max(-1, math.Min(1, cos))) <= coneHalfAngle+1e-9 {
+				positions = append(positions, pos)
+			}
+
+		case AreaShapeLine:
+			along := dx*dirX + dy*dirY
+			across := math.Abs(dx*dirY - dy*dirX)
+			if along > 0 && along <= length && across <= 0.5 {
+				positions = append(positions, pos)
+			}
+		}
+	}
+
+	return positions, nil
+}
+
+// withinCubeAxis reports whether an offset along one axis falls inside a cube
+// of the given side length anchored at the origin.
+func withinCubeAxis(offset, dir, side float64) bool {
+	if dir < 0 {
+		offset = -offset
+	}
+	return offset >= 0 && offset < side
+}
+
+// AreaSave configures a save-based area effect (Fireball, Burning Hands).
+type AreaSave struct {
+	// Ability is the ability used for the saving throw.
+	Ability abilities.Ability
+
+	// DC is the Difficulty Class each creature must meet or exceed.
+	DC int
+
+	// HalfOnSuccess halves the damage for creatures that succeed.
+	// When false, a successful save negates the damage entirely.
+	HalfOnSuccess bool
+
+	// Trigger identifies what is causing the save (defaults to spell).
+	Trigger dnd5eEvents.SaveTrigger
+
+	// Modifiers overrides the save modifier by creature ID, e.g. to include
+	// a situational bonus. Creatures not listed use their saving throw modifier
+	// (see SaveModifierProvider), or their ability modifier.
+	Modifiers map[string]int
+}
+
+// AreaAttack configures an attack-based area effect, where the attacker makes
+// a separate attack roll against every creature in the area.
+type AreaAttack struct {
+	// Weapon is the weapon used for each attack. Damage comes from the weapon.
+	Weapon *weapons.Weapon
+}
+
+// AreaDamage is the damage dealt by a save-based area effect.
+// It is rolled once and shared by every creature in the area.
+type AreaDamage struct {
+	// Dice is the damage notation (e.g. "8d6").
+	Dice string
+
+	// Type is the damage type.
+	Type damage.Type
+}
+
+// AreaInput provides all information needed to resolve an area effect.
+// Exactly one of Save or Attack must be provided.
+type AreaInput struct {
+	// SourceID is the caster or attacker. Required for attack-based effects.
+	// The source never provides cover against its own effect.
+	SourceID string
+
+	// EffectRef identifies the spell, feature, or trap creating the area.
+	EffectRef *core.Ref
+
+	// Template is the shape and size of the area.
+	Template AreaTemplate
+
+	// Origin is the template's point of origin.
+	Origin spatial.Position
+
+	// Save configures a save-based effect.
+	Save *AreaSave
+
+	// Attack configures an attack-based effect.
+	Attack *AreaAttack
+
+	// Damage is the damage dealt by a save-based effect.
+	// Save-only effects (Turn Undead) leave it nil: creatures only save, and
+	// callers apply the consequence of a failed save.
+	// Attack-based effects roll their weapon's damage instead.
+	Damage *AreaDamage
+
+	// ExcludeIDs lists creatures in the area that the effect skips
+	// (e.g. allies protected by Sculpt Spells).
+	ExcludeIDs []string
+
+	// Filter limits the effect to creatures it returns true for
+	// (e.g. Turn Undead affects only undead). Nil affects every creature.
+	Filter func(Combatant) bool
+
+	// EventBus is required for publishing chain and summary events.
+	EventBus events.EventBus
+
+	// Roller is the dice roller. If nil, a default roller is used.
+	Roller dice.Roller
+}
+
+// Validate validates the input fields.
+func (a *AreaInput) Validate() error {
+	if a == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "AreaInput is nil")
+	}
+	if a.EventBus == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "EventBus is required")
+	}
+	if err := a.Template.Validate(); err != nil {
+		return err
+	}
+	if (a.Save == nil) == (a.Attack == nil) {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "exactly one of Save or Attack is required")
+	}
+	if a.Damage != nil && a.Damage.Dice == "" {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "Damage.Dice is required")
+	}
+	if a.Attack != nil {
+		if a.Attack.Weapon == nil {
+			return rpgerr.New(rpgerr.CodeInvalidArgument, "Attack.Weapon is required")
+		}
+		if a.SourceID == "" {
+			return rpgerr.New(rpgerr.CodeInvalidArgument, "SourceID is required for attack-based effects")
+		}
+		if a.Damage != nil {
+			return rpgerr.New(rpgerr.CodeInvalidArgument, "Damage is not used by attack-based effects")
+		}
+	}
+	return nil
+}
+
+// AreaSaveOutcome is one creature's saving throw against an area effect.
+type AreaSaveOutcome struct {
+	// Roll is the d20 result used (after advantage/disadvantage).
+	Roll int
+
+	// Total is Roll plus the save modifier and all bonuses.
+	Total int
+
+	// Success indicates Total met or exceeded the DC.
+	Success bool
+
+	// AdvantageSources contains the sources that granted advantage.
+	AdvantageSources []dnd5eEvents.SaveModifierSource
+
+	// DisadvantageSources contains the sources that imposed disadvantage.
+	DisadvantageSources []dnd5eEvents.SaveModifierSource
+
+	// BonusSources contains the sources that added bonuses, including cover.
+	BonusSources []dnd5eEvents.SaveBonusSource
+
+	// AutoFailSources contains the sources that made the save fail automatically.
+	AutoFailSources []dnd5eEvents.SaveModifierSource
+}
+
+// AreaTargetResult is the per-creature breakdown of an area effect.
+type AreaTargetResult struct {
+	// TargetID is the affected creature.
+	TargetID string
+
+	// Cover is the cover the creature had from the origin.
+	Cover *CoverResult
+
+	// Save is the creature's saving throw (save-based effects only).
+	Save *AreaSaveOutcome
+
+	// Attack is the attack against the creature (attack-based effects only).
+	Attack *AttackResult
+
+	// Damage is the damage dealt after chain modifiers, resistance, and halving.
+	// Damage is resolved but not applied; callers apply it to the creature.
+	Damage int
+
+	// DamageComponents are the final damage components after the chain.
+	DamageComponents []dnd5eEvents.DamageComponent
+}
+
+// AreaResult contains the complete outcome of an area effect.
+type AreaResult struct {
+	// Positions are the grid squares covered by the template.
+	Positions []spatial.Position
+
+	// Targets holds a breakdown for each affected creature, in grid order.
+	Targets []*AreaTargetResult
+
+	// DamageRolls are the shared damage dice (save-based effects only).
+	DamageRolls []int
+
+	// BaseDamage is the shared damage before saves and modifiers (save-based effects only).
+	BaseDamage int
+
+	// TotalDamage is the sum of damage across all targets.
+	TotalDamage int
+}
+
+// ResolveAreaEffect resolves an area of effect against every creature inside it.
+//
+// The flow is:
+//   - Find the squares covered by the template using the room in context
+//   - Collect creatures in those squares (via the CombatantLookup in context),
+//     skipping excluded creatures and those with full cover from the origin
+//   - Save-based: roll damage once, then each creature saves through the
+//     SavingThrowChain with cover added to DEX saves; damage is halved or
+//     negated on success and resolved per creature through the DamageChain.
+//     Save-only effects stop after the saves.
+//   - Attack-based: resolve a separate attack from SourceID against each creature
+//   - Publish one AreaEffectResolvedEvent summarizing every outcome
+//
+// Like ResolveAttack, damage is resolved but not applied to hit points.
+// Room entities that aren't combatants (walls, obstacles) are ignored.
+func ResolveAreaEffect(ctx context.Context, input *AreaInput) (*AreaResult, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	room, err := getRoomFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	roller := input.Roller
+	if roller == nil {
+		roller = dice.NewRoller()
+	}
+
+	positions, err := input.Template.Positions(room.GetGrid(), input.Origin)
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := collectAreaTargets(ctx, room, positions, input)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &AreaResult{Positions: positions}
+
+	var baseComponent dnd5eEvents.DamageComponent
+	if input.Save != nil && input.Damage != nil {
+		pool, err := dice.ParseNotation(input.Damage.Dice)
+		if err != nil {
+			return nil, rpgerr.Wrapf(err, "invalid damage dice %s", input.Damage.Dice)
+		}
+		rolled := pool.RollContext(ctx, roller)
+		if rolled.Error() != nil {
+			return nil, rpgerr.Wrap(rolled.Error(), "failed to roll area damage")
+		}
+		for _, group := range rolled.Rolls() {
+			result.DamageRolls = append(result.DamageRolls, group...)
+		}
+		baseComponent = dnd5eEvents.DamageComponent{
+			Source:            dnd5eEvents.DamageSourceSpell,
+			SourceRef:         input.EffectRef,
+			OriginalDiceRolls: result.DamageRolls,
+			FinalDiceRolls:    result.DamageRolls,
+			FlatBonus:         rolled.Modifier(),
+			DamageType:        input.Damage.Type,
+		}
+		result.BaseDamage = baseComponent.Total()
+	}
+
+	for _, target := range targets {
+		var targetResult *AreaTargetResult
+		if input.Save != nil {
+			targetResult, err = resolveAreaSaveTarget(ctx, input, target, baseComponent, roller)
+		} else {
+			targetResult, err = resolveAreaAttackTarget(ctx, input, target, roller)
+		}
+		if err != nil {
+			return nil, rpgerr.Wrapf(err, "failed to resolve area effect against %s", target.combatant.GetID())
+		}
+		result.Targets = append(result.Targets, targetResult)
+		result.TotalDamage += targetResult.Damage
+	}
+
+	if err := publishAreaEffectResolved(ctx, input, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// areaTarget is a creature inside an area along with its cover from the origin.
+type areaTarget struct {
+	combatant Combatant
+	cover     *CoverResult
+}
+
+// collectAreaTargets finds the creatures occupying the given positions.
+func collectAreaTargets(
+	ctx context.Context,
+	room spatial.Room,
+	positions []spatial.Position,
+	input *AreaInput,
+) ([]areaTarget, error) {
+	excluded := make(map[string]bool, len(input.ExcludeIDs))
+	for _, id := range input.ExcludeIDs {
+		excluded[id] = true
+	}
+
+	seen := make(map[string]bool)
+	var targets []areaTarget
+	for _, pos := range positions {
+		for _, entity := range room.GetEntitiesAt(pos) {
+			id := entity.GetID()
+			if seen[id] || excluded[id] {
+				continue
+			}
+			seen[id] = true
+
+			combatant, err := GetCombatantFromContext(ctx, id)
+			if err != nil {
+				// Not a combatant (wall, obstacle, item) - unaffected
+				continue
+			}
+			if input.Filter != nil && !input.Filter(combatant) {
+				continue
+			}
+
+			origin := input.Origin
+			cover, err := CalculateCover(ctx, &CalculateCoverInput{
+				SourceID: input.SourceID,
+				Origin:   &origin,
+				TargetID: id,
+			})
+			if err != nil {
+				return nil, rpgerr.Wrapf(err, "failed to calculate cover for %s", id)
+			}
+			if cover.Level == CoverFull {
+				continue
+			}
+
+			targets = append(targets, areaTarget{combatant: combatant, cover: cover})
+		}
+	}
+
+	return targets, nil
+}
+
+// resolveAreaSaveTarget runs one creature's saving throw and resolves its share of the damage.
+func resolveAreaSaveTarget(
+	ctx context.Context,
+	input *AreaInput,
+	target areaTarget,
+	baseComponent dnd5eEvents.DamageComponent,
+	roller dice.Roller,
+) (*AreaTargetResult, error) {
+	targetID := target.combatant.GetID()
+
+	save, err := rollAreaSave(ctx, input, target, roller)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &AreaTargetResult{
+		TargetID: targetID,
+		Cover:    target.cover,
+		Save:     save,
+	}
+	if input.Damage == nil {
+		return result, nil
+	}
+
+	component := baseComponent
+	if save.Success {
+		if !input.Save.HalfOnSuccess {
+			return result, nil
+		}
+		// Halve before resistance: the halved amount becomes a flat value so
+		// multipliers from the chain still apply to it.
+		component.FinalDiceRolls = nil
+		component.FlatBonus = baseComponent.Total() / 2
+	}
+
+	resolved, err := ResolveDamage(ctx, &ResolveDamageInput{
+		AttackerID: input.SourceID,
+		TargetID:   targetID,
+		Components: []dnd5eEvents.DamageComponent{component},
+		EventBus:   input.EventBus,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Damage = resolved.TotalDamage
+	result.DamageComponents = resolved.FinalComponents
+	return result, nil
+}
+
+// SaveModifierProvider is implemented by combatants that know their total
+// saving throw modifier, including proficiency (characters and monsters).
+type SaveModifierProvider interface {
+	GetSavingThrowModifier(ability abilities.Ability) int
+}
+
+// saveModifier returns the combatant's saving throw modifier, falling back to
+// the ability modifier for combatants without save proficiencies.
+func saveModifier(combatant Combatant, ability abilities.Ability) int {
+	if provider, ok := combatant.(SaveModifierProvider); ok {
+		return provider.GetSavingThrowModifier(ability)
+	}
+	return combatant.AbilityScores().Modifier(ability)
+}
+
+// rollAreaSave rolls a saving throw through the SavingThrowChain.
+// Cover bonuses are added for DEX saves.
+func rollAreaSave(
+	ctx context.Context,
+	input *AreaInput,
+	target areaTarget,
+	roller dice.Roller,
+) (*AreaSaveOutcome, error) {
+	targetID := target.combatant.GetID()
+
+	trigger := input.Save.Trigger
+	if trigger == "" {
+		trigger = dnd5eEvents.SaveTriggerSpell
+	}
+
+	chainEvent := &dnd5eEvents.SavingThrowChainEvent{
+		SaverID: targetID,
+		Ability: input.Save.Ability,
+		DC:      input.Save.DC,
+		Cause: dnd5eEvents.SaveCause{
+			Trigger:      trigger,
+			EffectRef:    input.EffectRef,
+			InstigatorID: input.SourceID,
+		},
+	}
+	if input.Damage != nil {
+		chainEvent.Cause.DamageType = input.Damage.Type
+	}
+	if coverBonus := target.cover.SaveBonusSource(targetID, input.Save.Ability); coverBonus != nil {
+		chainEvent.BonusSources = append(chainEvent.BonusSources, *coverBonus)
+	}
+
+	modifier, ok := input.Save.Modifiers[targetID]
+	if !ok {
+		modifier = saveModifier(target.combatant, input.Save.Ability)
+	}
+
+	return rollSavingThrow(ctx, input.EventBus, roller, chainEvent, modifier)
+}
+
+// rollSavingThrow publishes a save through the SavingThrowChain and rolls it
+// with the chain's advantage, bonuses and automatic failures applied.
+func rollSavingThrow(
+	ctx context.Context,
+	bus events.EventBus,
+	roller dice.Roller,
+	chainEvent *dnd5eEvents.SavingThrowChainEvent,
+	modifier int,
+) (*AreaSaveOutcome, error) {
+	dc := chainEvent.DC
+
+	saveChain := events.NewStagedChain[*dnd5eEvents.SavingThrowChainEvent](ModifierStages)
+	modifiedChain, err := dnd5eEvents.SavingThrowChain.On(bus).PublishWithChain(ctx, chainEvent, saveChain)
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to publish saving throw chain event")
+	}
+	final, err := modifiedChain.Execute(ctx, chainEvent)
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to execute saving throw chain")
+	}
+
+	d20, err := RollD20(ctx, roller, D20Input{
+		Advantage:    final.HasAdvantage(),
+		Disadvantage: final.HasDisadvantage(),
+		RerollOnes:   len(final.RerollOneSources) > 0,
+	})
+	if err != nil {
+		return nil, err
+	}
+	roll := d20.Roll
+
+	total := roll + modifier + final.TotalBonus()
+	return &AreaSaveOutcome{
+		Roll:                roll,
+		Total:               total,
+		Success:             total >= dc && !final.AutoFails(),
+		AdvantageSources:    final.AdvantageSources,
+		DisadvantageSources: final.DisadvantageSources,
+		BonusSources:        final.BonusSources,
+		AutoFailSources:     final.AutoFailSources,
+	}, nil
+}
+
+// resolveAreaAttackTarget resolves one attack from the source against a creature.
+func resolveAreaAttackTarget(
+	ctx context.Context,
+	input *AreaInput,
+	target areaTarget,
+	roller dice.Roller,
+) (*AreaTargetResult, error) {
+	targetID := target.combatant.GetID()
+
+	attack, err := ResolveAttack(ctx, &AttackInput{
+		AttackerID: input.SourceID,
+		TargetID:   targetID,
+		Weapon:     input.Attack.Weapon,
+		EventBus:   input.EventBus,
+		Roller:     roller,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &AreaTargetResult{
+		TargetID: targetID,
+		Cover:    target.cover,
+		Attack:   attack,
+		Damage:   attack.TotalDamage,
+	}
+	if attack.Breakdown != nil {
+		result.DamageComponents = attack.Breakdown.Components
+	}
+	return result, nil
+}
+
+// publishAreaEffectResolved publishes the summary event for a resolved area effect.
+func publishAreaEffectResolved(ctx context.Context, input *AreaInput, result *AreaResult) error {
+	outcomes := make([]dnd5eEvents.AreaEffectTargetOutcome, 0, len(result.Targets))
+	for _, target := range result.Targets {
+		outcome := dnd5eEvents.AreaEffectTargetOutcome{
+			TargetID: target.TargetID,
+			Cover:    string(target.Cover.Level),
+			Damage:   target.Damage,
+		}
+		if target.Save != nil {
+			outcome.Saved = target.Save.Success
+		}
+		if target.Attack != nil {
+			outcome.Hit = target.Attack.Hit
+			outcome.Critical = target.Attack.Critical
+		}
+		outcomes = append(outcomes, outcome)
+	}
+
+	err := dnd5eEvents.AreaEffectResolvedTopic.On(input.EventBus).Publish(ctx, dnd5eEvents.AreaEffectResolvedEvent{
+		SourceID:    input.SourceID,
+		EffectRef:   input.EffectRef,
+		Shape:       string(input.Template.Shape),
+		Targets:     outcomes,
+		TotalDamage: result.TotalDamage,
+	})
+	if err != nil {
+		return rpgerr.Wrap(err, "failed to publish area effect resolved event")
+	}
+	return nil
+}
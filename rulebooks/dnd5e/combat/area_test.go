@@ -0,0 +1,371 @@
+package combat_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	mock_combat "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat/mock"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/weapons"
+	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
+)
+
+// combatantMap resolves combatants by ID; anything else in the room is an obstacle
+type combatantMap map[string]combat.Combatant
+
+func (m combatantMap) Get(id string) (combat.Combatant, error) {
+	if c, ok := m[id]; ok {
+		return c, nil
+	}
+	return nil, rpgerr.Newf(rpgerr.CodeNotFound, "combatant %s not found", id)
+}
+
+type AreaEffectTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	ctx      context.Context
+	eventBus events.EventBus
+	lookup   combatantMap
+	roller   *mock_dice.MockRoller
+	room     *spatial.BasicRoom
+	grid     *spatial.SquareGrid
+}
+
+func TestAreaEffectSuite(t *testing.T) {
+	suite.Run(t, new(AreaEffectTestSuite))
+}
+
+func (s *AreaEffectTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.eventBus = events.NewEventBus()
+	s.lookup = combatantMap{}
+	s.roller = mock_dice.NewMockRoller(s.ctrl)
+
+	s.grid = spatial.NewSquareGrid(spatial.SquareGridConfig{
+		Width:  10,
+		Height: 10,
+	})
+	s.room = spatial.NewBasicRoom(spatial.BasicRoomConfig{
+		ID:   "area-room",
+		Type: "combat",
+		Grid: s.grid,
+	})
+
+	s.ctx = combat.WithRoom(context.Background(), s.room)
+	s.ctx = combat.WithCombatantLookup(s.ctx, s.lookup)
+}
+
+func (s *AreaEffectTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// placeCreature places a combatant in the room with the given DEX score
+func (s *AreaEffectTestSuite) placeCreature(id string, pos spatial.Position, dex int) {
+	creature := mock_combat.NewMockCombatant(s.ctrl)
+	creature.EXPECT().GetID().Return(id).AnyTimes()
+	creature.EXPECT().AbilityScores().Return(shared.AbilityScores{
+		abilities.STR: 10,
+		abilities.DEX: dex,
+	}).AnyTimes()
+	creature.EXPECT().ProficiencyBonus().Return(2).AnyTimes()
+	creature.EXPECT().AC().Return(13).AnyTimes()
+
+	s.lookup[id] = creature
+	s.Require().NoError(s.room.PlaceEntity(&testCombatant{id: id, entityType: "monster"}, pos))
+}
+
+func (s *AreaEffectTestSuite) fireball() *combat.AreaInput {
+	return &combat.AreaInput{
+		SourceID:  "wizard",
+		EffectRef: refs.Spells.Fireball(),
+		Template:  combat.AreaTemplate{Shape: combat.AreaShapeSphere, Size: 10},
+		Origin:    spatial.Position{X: 5, Y: 5},
+		Save: &combat.AreaSave{
+			Ability:       abilities.DEX,
+			DC:            13,
+			HalfOnSuccess: true,
+		},
+		Damage:   &combat.AreaDamage{Dice: "2d6", Type: damage.Fire},
+		EventBus: s.eventBus,
+		Roller:   s.roller,
+	}
+}
+
+func (s *AreaEffectTestSuite) TestSaveEffectRollsDamageOnceAndHalvesOnSuccess() {
+	s.placeCreature("goblin-1", spatial.Position{X: 5, Y: 5}, 10)
+	s.placeCreature("goblin-2", spatial.Position{X: 6, Y: 6}, 10)
+	s.placeCreature("orc", spatial.Position{X: 9, Y: 9}, 10) // outside the sphere
+
+	var summary *dnd5eEvents.AreaEffectResolvedEvent
+	_, err := dnd5eEvents.AreaEffectResolvedTopic.On(s.eventBus).Subscribe(s.ctx,
+		func(_ context.Context, e dnd5eEvents.AreaEffectResolvedEvent) error {
+			summary = &e
+			return nil
+		})
+	s.Require().NoError(err)
+
+	gomock.InOrder(
+		s.roller.EXPECT().RollN(gomock.Any(), 2, 6).Return([]int{3, 4}, nil),
+		s.roller.EXPECT().Roll(gomock.Any(), 20).Return(5, nil),  // goblin-1 fails
+		s.roller.EXPECT().Roll(gomock.Any(), 20).Return(15, nil), // goblin-2 succeeds
+	)
+
+	result, err := combat.ResolveAreaEffect(s.ctx, s.fireball())
+	s.Require().NoError(err)
+
+	s.Equal([]int{3, 4}, result.DamageRolls)
+	s.Equal(7, result.BaseDamage)
+	s.Require().Len(result.Targets, 2)
+
+	s.Equal("goblin-1", result.Targets[0].TargetID)
+	s.False(result.Targets[0].Save.Success)
+	s.Equal(7, result.Targets[0].Damage)
+
+	s.Equal("goblin-2", result.Targets[1].TargetID)
+	s.True(result.Targets[1].Save.Success)
+	s.Equal(3, result.Targets[1].Damage, "half of 7 rounds down")
+
+	s.Equal(10, result.TotalDamage)
+
+	s.Require().NotNil(summary, "one summary event is published")
+	s.Equal("wizard", summary.SourceID)
+	s.Equal(refs.Spells.Fireball(), summary.EffectRef)
+	s.Equal("sphere", summary.Shape)
+	s.Len(summary.Targets, 2)
+	s.True(summary.Targets[1].Saved)
+	s.Equal(10, summary.TotalDamage)
+}
+
+func (s *AreaEffectTestSuite) TestSuccessfulSaveNegatesWithoutHalfOnSuccess() {
+	s.placeCreature("goblin", spatial.Position{X: 5, Y: 5}, 10)
+
+	s.roller.EXPECT().RollN(gomock.Any(), 2, 6).Return([]int{6, 6}, nil)
+	s.roller.EXPECT().Roll(gomock.Any(), 20).Return(18, nil)
+
+	input := s.fireball()
+	input.Save.HalfOnSuccess = false
+
+	result, err := combat.ResolveAreaEffect(s.ctx, input)
+	s.Require().NoError(err)
+
+	s.Require().Len(result.Targets, 1)
+	s.Equal(0, result.Targets[0].Damage)
+	s.Equal(0, result.TotalDamage)
+}
+
+func (s *AreaEffectTestSuite) TestCoverAddsToDexteritySave() {
+	// A crate between the origin and the goblin grants half cover (+2 DEX)
+	s.Require().NoError(s.room.PlaceEntity(&testCombatant{id: "crate", entityType: "object"},
+		spatial.Position{X: 6, Y: 5}))
+	s.placeCreature("goblin", spatial.Position{X: 7, Y: 5}, 10)
+
+	s.roller.EXPECT().RollN(gomock.Any(), 2, 6).Return([]int{2, 2}, nil)
+	s.roller.EXPECT().Roll(gomock.Any(), 20).Return(11, nil) // 11 + 2 cover = 13
+
+	result, err := combat.ResolveAreaEffect(s.ctx, s.fireball())
+	s.Require().NoError(err)
+
+	s.Require().Len(result.Targets, 1)
+	target := result.Targets[0]
+	s.Equal(combat.CoverHalf, target.Cover.Level)
+	s.Equal(13, target.Save.Total)
+	s.True(target.Save.Success)
+	s.Require().Len(target.Save.BonusSources, 1)
+	s.Equal(refs.Conditions.HalfCover(), target.Save.BonusSources[0].SourceRef)
+}
+
+func (s *AreaEffectTestSuite) TestFullCoverExcludesTarget() {
+	s.Require().NoError(s.room.PlaceEntity(&wallSegment{id: "wall"}, spatial.Position{X: 6, Y: 5}))
+	s.placeCreature("goblin", spatial.Position{X: 7, Y: 5}, 10)
+
+	s.roller.EXPECT().RollN(gomock.Any(), 2, 6).Return([]int{2, 2}, nil)
+
+	result, err := combat.ResolveAreaEffect(s.ctx, s.fireball())
+	s.Require().NoError(err)
+	s.Empty(result.Targets)
+}
+
+func (s *AreaEffectTestSuite) TestSaveModifierOverride() {
+	s.placeCreature("goblin", spatial.Position{X: 5, Y: 5}, 10)
+
+	s.roller.EXPECT().RollN(gomock.Any(), 2, 6).Return([]int{2, 2}, nil)
+	s.roller.EXPECT().Roll(gomock.Any(), 20).Return(8, nil)
+
+	input := s.fireball()
+	input.Save.Modifiers = map[string]int{"goblin": 5}
+
+	result, err := combat.ResolveAreaEffect(s.ctx, input)
+	s.Require().NoError(err)
+	s.Equal(13, result.Targets[0].Save.Total)
+	s.True(result.Targets[0].Save.Success)
+}
+
+func (s *AreaEffectTestSuite) TestExcludeIDsSkipsCreatures() {
+	s.placeCreature("goblin", spatial.Position{X: 5, Y: 5}, 10)
+	s.placeCreature("ally", spatial.Position{X: 4, Y: 4}, 10)
+
+	s.roller.EXPECT().RollN(gomock.Any(), 2, 6).Return([]int{2, 2}, nil)
+	s.roller.EXPECT().Roll(gomock.Any(), 20).Return(8, nil)
+
+	input := s.fireball()
+	input.ExcludeIDs = []string{"ally"}
+
+	result, err := combat.ResolveAreaEffect(s.ctx, input)
+	s.Require().NoError(err)
+	s.Require().Len(result.Targets, 1)
+	s.Equal("goblin", result.Targets[0].TargetID)
+}
+
+func (s *AreaEffectTestSuite) TestSaveOnlyEffectWithFilter() {
+	s.placeCreature("zombie", spatial.Position{X: 5, Y: 5}, 6)
+	s.placeCreature("goblin", spatial.Position{X: 4, Y: 4}, 10)
+
+	// Only the zombie saves and no damage is rolled
+	s.roller.EXPECT().Roll(gomock.Any(), 20).Return(8, nil)
+
+	input := s.fireball()
+	input.Damage = nil
+	input.Save = &combat.AreaSave{Ability: abilities.DEX, DC: 13}
+	input.Filter = func(c combat.Combatant) bool { return c.GetID() == "zombie" }
+
+	result, err := combat.ResolveAreaEffect(s.ctx, input)
+	s.Require().NoError(err)
+	s.Require().Len(result.Targets, 1)
+	s.Equal("zombie", result.Targets[0].TargetID)
+	s.Equal(6, result.Targets[0].Save.Total)
+	s.False(result.Targets[0].Save.Success)
+	s.Zero(result.Targets[0].Damage)
+	s.Zero(result.TotalDamage)
+	s.Empty(result.DamageRolls)
+}
+
+func (s *AreaEffectTestSuite) TestAttackEffectResolvesAttackPerTarget() {
+	archer := mock_combat.NewMockCombatant(s.ctrl)
+	archer.EXPECT().GetID().Return("archer").AnyTimes()
+	archer.EXPECT().AbilityScores().Return(shared.AbilityScores{
+		abilities.STR: 10,
+		abilities.DEX: 16, // +3
+	}).AnyTimes()
+	archer.EXPECT().ProficiencyBonus().Return(2).AnyTimes()
+	s.lookup["archer"] = archer
+	s.Require().NoError(s.room.PlaceEntity(&testCombatant{id: "archer", entityType: "character"},
+		spatial.Position{X: 0, Y: 5}))
+
+	s.placeCreature("goblin", spatial.Position{X: 3, Y: 5}, 10)
+
+	gomock.InOrder(
+		s.roller.EXPECT().Roll(gomock.Any(), 20).Return(12, nil), // 12 + 5 = 17 vs AC 13
+		s.roller.EXPECT().RollN(gomock.Any(), 1, 6).Return([]int{4}, nil),
+	)
+
+	result, err := combat.ResolveAreaEffect(s.ctx, &combat.AreaInput{
+		SourceID: "archer",
+		Template: combat.AreaTemplate{
+			Shape:     combat.AreaShapeLine,
+			Size:      30,
+			Direction: &spatial.Position{X: 9, Y: 5},
+		},
+		Origin: spatial.Position{X: 0, Y: 5},
+		Attack: &combat.AreaAttack{Weapon: &weapons.Weapon{
+			ID:         weapons.Shortbow,
+			Name:       "Shortbow",
+			Category:   weapons.CategorySimpleRanged,
+			Damage:     "1d6",
+			DamageType: damage.Piercing,
+			Range:      &weapons.Range{Normal: 80, Long: 320},
+		}},
+		EventBus: s.eventBus,
+		Roller:   s.roller,
+	})
+	s.Require().NoError(err)
+
+	s.Require().Len(result.Targets, 1, "the archer is at the line's origin and is not included")
+	target := result.Targets[0]
+	s.Equal("goblin", target.TargetID)
+	s.Require().NotNil(target.Attack)
+	s.True(target.Attack.Hit)
+	s.Equal(7, target.Damage)
+	s.Nil(target.Save)
+}
+
+func (s *AreaEffectTestSuite) TestValidation() {
+	testCases := []struct {
+		name   string
+		mutate func(*combat.AreaInput)
+	}{
+		{"no save or attack", func(in *combat.AreaInput) { in.Save = nil }},
+		{"both save and attack", func(in *combat.AreaInput) { in.Attack = &combat.AreaAttack{} }},
+		{"damage without dice", func(in *combat.AreaInput) { in.Damage = &combat.AreaDamage{} }},
+		{"no event bus", func(in *combat.AreaInput) { in.EventBus = nil }},
+		{"zero size", func(in *combat.AreaInput) { in.Template.Size = 0 }},
+		{"cone without direction", func(in *combat.AreaInput) { in.Template.Shape = combat.AreaShapeCone }},
+		{"unknown shape", func(in *combat.AreaInput) { in.Template.Shape = "torus" }},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			input := s.fireball()
+			tc.mutate(input)
+
+			_, err := combat.ResolveAreaEffect(s.ctx, input)
+			s.Require().Error(err)
+			s.Equal(rpgerr.CodeInvalidArgument, rpgerr.GetCode(err))
+		})
+	}
+}
+
+func (s *AreaEffectTestSuite) TestRequiresRoom() {
+	_, err := combat.ResolveAreaEffect(context.Background(), s.fireball())
+	s.Require().Error(err)
+	s.Equal(rpgerr.CodeNotFound, rpgerr.GetCode(err))
+}
+
+func (s *AreaEffectTestSuite) TestTemplatePositions() {
+	origin := spatial.Position{X: 0, Y: 5}
+	east := &spatial.Position{X: 9, Y: 5}
+
+	s.Run("sphere", func() {
+		template := &combat.AreaTemplate{Shape: combat.AreaShapeSphere, Size: 5}
+		positions, err := template.Positions(s.grid, spatial.Position{X: 5, Y: 5})
+		s.Require().NoError(err)
+		s.Len(positions, 9, "a 5-foot sphere covers the origin and its 8 neighbors")
+	})
+
+	s.Run("cube", func() {
+		template := &combat.AreaTemplate{Shape: combat.AreaShapeCube, Size: 10}
+		positions, err := template.Positions(s.grid, spatial.Position{X: 2, Y: 2})
+		s.Require().NoError(err)
+		s.ElementsMatch([]spatial.Position{
+			{X: 2, Y: 2}, {X: 3, Y: 2},
+			{X: 2, Y: 3}, {X: 3, Y: 3},
+		}, positions)
+	})
+
+	s.Run("line", func() {
+		template := &combat.AreaTemplate{Shape: combat.AreaShapeLine, Size: 15, Direction: east}
+		positions, err := template.Positions(s.grid, origin)
+		s.Require().NoError(err)
+		s.ElementsMatch([]spatial.Position{{X: 1, Y: 5}, {X: 2, Y: 5}, {X: 3, Y: 5}}, positions)
+	})
+
+	s.Run("cone", func() {
+		template := &combat.AreaTemplate{Shape: combat.AreaShapeCone, Size: 15, Direction: east}
+		positions, err := template.Positions(s.grid, origin)
+		s.Require().NoError(err)
+		s.Contains(positions, spatial.Position{X: 3, Y: 5})
+		s.Contains(positions, spatial.Position{X: 2, Y: 6}, "a cone widens as it travels")
+		s.NotContains(positions, spatial.Position{X: 1, Y: 6}, "too close to the origin to be that wide")
+		s.NotContains(positions, origin)
+	})
+}
@@ -73,6 +73,7 @@ type AttackInput struct {
 	TargetID string
 
 	// Weapon is the weapon being used for the attack.
+	// Optional for off-hand attacks, which default to the weapon in the off-hand slot.
 	Weapon *weapons.Weapon
 
 	// EventBus is required for publishing attack/damage events.
@@ -85,12 +86,19 @@ type AttackInput struct {
 	// AttackHand indicates which hand is making the attack.
 	// Default (empty or AttackHandMain) is a main hand attack.
 	// AttackHandOff triggers two-weapon fighting validation and consumes a bonus action.
+	// Off-hand attacks don't add a positive ability modifier to damage unless
+	// the attacker has the Two-Weapon Fighting style.
 	AttackHand AttackHand
 
 	// AttackType indicates whether this is a standard attack or an opportunity attack.
 	// Default (empty) is treated as AttackTypeStandard.
 	// Set to AttackTypeOpportunity when triggering opportunity attacks.
 	AttackType dnd5eEvents.AttackType
+
+	// Reactions prompts reactors for the triggers the attack raises (e.g.
+	// Shield), and the attack waits for their decisions before applying its
+	// outcome. Nil applies the outcome with no reactions.
+	Reactions *ReactionPrompter
 }
 
 // Validate validates the input.
@@ -109,7 +117,7 @@ func (ai *AttackInput) Validate() error {
 		return rpgerr.New(rpgerr.CodeInvalidArgument, "TargetID is required")
 	}
 
-	if ai.Weapon == nil {
+	if ai.Weapon == nil && ai.AttackHand != AttackHandOff {
 		return rpgerr.New(rpgerr.CodeInvalidArgument, "Weapon is nil")
 	}
 
@@ -142,6 +150,15 @@ type AttackResult struct {
 	HasAdvantage    bool  // True if rolled with advantage
 	HasDisadvantage bool  // True if rolled with disadvantage
 
+	// Cover the target had from the attacker (nil when resolved without a room).
+	// Its AC bonus is already included in TargetAC; use Cover.ACComponent()
+	// to attribute it in an AC breakdown.
+	Cover *CoverResult
+
+	// Range of a ranged attack (nil for melee or when resolved without a room).
+	// Long-range and hostile-adjacent disadvantage are already in the roll.
+	Range *AttackRangeResult
+
 	// Damage details
 	DamageRolls []int       // Individual damage dice rolls (flattened)
 	DamageBonus int         // Total damage bonus
@@ -150,6 +167,14 @@ type AttackResult struct {
 
 	// Detailed breakdown
 	Breakdown *DamageBreakdown // Detailed damage breakdown (nil if attack missed)
+
+	// Trace is the serializable replay of the attack for combat logs.
+	// Render it with FormatAttackLog.
+	Trace *AttackTrace
+
+	// ReactionDecisions are the prompted reactions, taken or declined, when
+	// AttackInput.Reactions is set.
+	ReactionDecisions []ReactionDecision
 }
 
 // ResolveAttack performs a complete attack resolution using the event chain system.
@@ -158,8 +183,9 @@ type AttackResult struct {
 // need reaction windows between the hit and damage phases. New code that needs
 // to support player reactions (Shield, Opportunity Attack prompts, etc.) should
 // call ResolveAttackHit followed by ApplyAttackOutcome with the player's
-// reaction decisions. ResolveAttack will not be removed; it delegates to both
-// discrete phases with an empty reaction set.
+// reaction decisions, or set AttackInput.Reactions to prompt reactors in
+// process. ResolveAttack will not be removed; it delegates to both discrete
+// phases, with the reactions AttackInput.Reactions takes.
 //
 //nolint:gocyclo // Attack resolution requires orchestrating multiple game rules stages
 func ResolveAttack(ctx context.Context, input *AttackInput) (*AttackResult, error) {
@@ -168,26 +194,50 @@ func ResolveAttack(ctx context.Context, input *AttackInput) (*AttackResult, erro
 	}
 
 	// Phase 1: run the attack chain and determine hit against original AC
-	hitResult, err := ResolveAttackHit(ctx, &ResolveAttackHitInput{
-		AttackerID: input.AttackerID,
-		TargetID:   input.TargetID,
-		Weapon:     input.Weapon,
-		EventBus:   input.EventBus,
-		Roller:     input.Roller,
-		AttackHand: input.AttackHand,
-		AttackType: input.AttackType,
-	})
-	if err != nil {
-		return nil, err
+	var hitResult *AttackContext
+	resolveHit := func() error {
+		var err error
+		hitResult, err = ResolveAttackHit(ctx, &ResolveAttackHitInput{
+			AttackerID: input.AttackerID,
+			TargetID:   input.TargetID,
+			Weapon:     input.Weapon,
+			EventBus:   input.EventBus,
+			Roller:     input.Roller,
+			AttackHand: input.AttackHand,
+			AttackType: input.AttackType,
+		})
+		return err
 	}
 
-	// Phase 2: apply outcome with no reaction modifiers
-	return ApplyAttackOutcome(ctx, &ApplyAttackOutcomeInput{
+	// Reaction window: prompt reactors for the triggers phase 1 raised
+	var decisions []ReactionDecision
+	if input.Reactions == nil {
+		if err := resolveHit(); err != nil {
+			return nil, err
+		}
+	} else {
+		triggers, err := CollectReactionTriggers(ctx, input.EventBus, resolveHit)
+		if err != nil {
+			return nil, err
+		}
+		decisions, err = input.Reactions.Prompt(ctx, input.EventBus, triggers)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Phase 2: apply outcome with the reactions taken
+	result, err := ApplyAttackOutcome(ctx, &ApplyAttackOutcomeInput{
 		HitResult: hitResult,
-		Reactions: nil,
+		Reactions: ReactionModifiers(decisions),
 		EventBus:  input.EventBus,
 		Roller:    input.Roller,
 	})
+	if err != nil {
+		return nil, err
+	}
+	result.ReactionDecisions = decisions
+	return result, nil
 }
 
 // rollDamageDice rolls the damage pool the specified number of times and combines results
@@ -206,6 +256,45 @@ func rollDamageDice(ctx context.Context, pool *dice.Pool, roller dice.Roller, ti
 	return allRolls, nil
 }
 
+// rollMaxPlusDamage rolls the damage pool once and adds the pool's maximum
+// dice, for the CriticalMaxPlusRoll house rule. The maximum dice come first.
+func rollMaxPlusDamage(ctx context.Context, pool *dice.Pool, roller dice.Roller) ([]int, error) {
+	maximum, err := rollDamageDice(ctx, pool, maxRoller{}, 1)
+	if err != nil {
+		return nil, err
+	}
+	rolled, err := rollDamageDice(ctx, pool, roller, 1)
+	if err != nil {
+		return nil, err
+	}
+	return append(maximum, rolled...), nil
+}
+
+// maxRoller is a dice.Roller that always rolls the highest face.
+type maxRoller struct{}
+
+func (maxRoller) Roll(_ context.Context, size int) (int, error) {
+	if size <= 0 {
+		return 0, rpgerr.Newf(rpgerr.CodeInvalidArgument, "invalid die size: %d", size)
+	}
+	return size, nil
+}
+
+func (m maxRoller) RollN(ctx context.Context, count, size int) ([]int, error) {
+	if count < 0 {
+		return nil, rpgerr.Newf(rpgerr.CodeInvalidArgument, "invalid die count: %d", count)
+	}
+	rolls := make([]int, count)
+	for i := range rolls {
+		roll, err := m.Roll(ctx, size)
+		if err != nil {
+			return nil, err
+		}
+		rolls[i] = roll
+	}
+	return rolls, nil
+}
+
 // determineAbilityUsed determines which ability is used for the attack
 func determineAbilityUsed(weapon *weapons.Weapon, scores shared.AbilityScores) abilities.Ability {
 	// Finesse weapons can use STR or DEX (use whichever is higher)
@@ -285,58 +374,79 @@ func abilityToRef(ability abilities.Ability) *core.Ref {
 	}
 }
 
-// validateOffHandAttack validates two-weapon fighting requirements for off-hand attacks.
-// Returns an error if requirements are not met.
-func validateOffHandAttack(ctx context.Context, input *AttackInput) error {
+// offHandDamageBonus returns the ability bonus an off-hand attack actually dealt:
+// a negative modifier always applies, a positive one only via the Two-Weapon
+// Fighting style's damage component.
+func offHandDamageBonus(abilityMod int, components []dnd5eEvents.DamageComponent) int {
+	bonus := min(abilityMod, 0)
+	for _, component := range components {
+		if component.SourceRef != nil &&
+			component.SourceRef.ID == refs.Conditions.FightingStyleTwoWeaponFighting().ID {
+			bonus += component.FlatBonus
+		}
+	}
+	return bonus
+}
+
+// validateOffHandAttack validates two-weapon fighting requirements for off-hand attacks
+// and returns the weapon to attack with. A nil weapon selects the off-hand slot's weapon;
+// a non-nil weapon must match it. Consumes the bonus action on success.
+func validateOffHandAttack(ctx context.Context, characterID string, weapon *weapons.Weapon) (*weapons.Weapon, error) {
 	// Get two-weapon context
 	twc, ok := GetTwoWeaponContext(ctx)
 	if !ok {
-		return rpgerr.New(rpgerr.CodeInvalidArgument, "two-weapon context not available for off-hand attack validation")
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument,
+			"two-weapon context not available for off-hand attack validation")
 	}
 
-	characterID := input.AttackerID
-
 	// Check main hand weapon
 	mainHand := twc.GetMainHandWeapon(characterID)
 	if mainHand == nil {
-		return rpgerr.New(rpgerr.CodeInvalidArgument, "no weapon in main hand")
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "no weapon in main hand")
 	}
 
 	// Look up main hand weapon properties
 	mainWeapon, err := weapons.GetByID(mainHand.WeaponID)
 	if err != nil {
-		return rpgerr.Wrapf(err, "unknown main hand weapon: %s", mainHand.WeaponID)
+		return nil, rpgerr.Wrapf(err, "unknown main hand weapon: %s", mainHand.WeaponID)
 	}
 
 	if !mainWeapon.HasProperty(weapons.PropertyLight) {
-		return rpgerr.New(rpgerr.CodeInvalidArgument, "main hand weapon must be light for two-weapon fighting")
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "main hand weapon must be light for two-weapon fighting")
 	}
 
 	// Check off hand weapon
 	offHand := twc.GetOffHandWeapon(characterID)
 	if offHand == nil {
-		return rpgerr.New(rpgerr.CodeInvalidArgument, "no weapon in off hand")
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "no weapon in off hand")
 	}
 
 	// Look up off hand weapon properties
 	offWeapon, err := weapons.GetByID(offHand.WeaponID)
 	if err != nil {
-		return rpgerr.Wrapf(err, "unknown off hand weapon: %s", offHand.WeaponID)
+		return nil, rpgerr.Wrapf(err, "unknown off hand weapon: %s", offHand.WeaponID)
 	}
 
 	if !offWeapon.HasProperty(weapons.PropertyLight) {
-		return rpgerr.New(rpgerr.CodeInvalidArgument, "off hand weapon must be light for two-weapon fighting")
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "off hand weapon must be light for two-weapon fighting")
+	}
+
+	if weapon == nil {
+		weapon = &offWeapon
+	} else if weapon.ID != offWeapon.ID {
+		return nil, rpgerr.Newf(rpgerr.CodeInvalidArgument,
+			"weapon %s is not in the off hand (holding %s)", weapon.ID, offWeapon.ID)
 	}
 
 	// Check and consume bonus action
 	actionEconomy := twc.GetActionEconomy(characterID)
 	if actionEconomy == nil {
-		return rpgerr.New(rpgerr.CodeInvalidArgument, "action economy not available for off-hand attack")
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "action economy not available for off-hand attack")
 	}
 
 	if err := actionEconomy.UseBonusAction(); err != nil {
-		return err // Already returns CodeResourceExhausted with "bonus action"
+		return nil, err // Already returns CodeResourceExhausted with "bonus action"
 	}
 
-	return nil
+	return weapon, nil
 }
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/KirkDiggler/rpg-toolkit/core"
 	"github.com/KirkDiggler/rpg-toolkit/dice"
 	"github.com/KirkDiggler/rpg-toolkit/events"
 	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
@@ -35,9 +36,15 @@ type AttackContext struct {
 	Weapon     *weapons.Weapon
 
 	// Original state (before any reactions)
-	OriginalAC int  // Target AC before any reaction modifiers
+	OriginalAC int  // Target AC before any reaction modifiers (includes cover)
 	WouldHit   bool // Whether roll hits against originalAC
 
+	// Cover the target had from the attacker, nil when resolved without a room
+	Cover *CoverResult
+
+	// Range of a ranged attack, nil for melee or when resolved without a room
+	Range *AttackRangeResult
+
 	// Roll details (needed by phase 2 to re-evaluate hit)
 	AttackRoll      int   // The d20 result
 	AttackBonus     int   // Total bonus applied
@@ -62,6 +69,10 @@ type AttackContext struct {
 	AbilityMod      int
 	AbilityUsed     abilities.Ability
 	IsOffHandAttack bool
+
+	// Trace is the base bonuses plus every attack chain change, carried to
+	// phase 2 for AttackResult.Trace
+	Trace []dnd5eEvents.ChainTraceStep
 }
 
 // ReactionModifier represents an AC or roll modification chosen by a player
@@ -91,6 +102,7 @@ type ResolveAttackHitInput struct {
 	TargetID string
 
 	// Weapon is the weapon being used for the attack.
+	// Optional for off-hand attacks, which default to the weapon in the off-hand slot.
 	Weapon *weapons.Weapon
 
 	// EventBus is required for publishing attack/damage events.
@@ -118,7 +130,7 @@ func (r *ResolveAttackHitInput) Validate() error {
 	if r.TargetID == "" {
 		return rpgerr.New(rpgerr.CodeInvalidArgument, "TargetID is required")
 	}
-	if r.Weapon == nil {
+	if r.Weapon == nil && r.AttackHand != AttackHandOff {
 		return rpgerr.New(rpgerr.CodeInvalidArgument, "Weapon is nil")
 	}
 	if r.EventBus == nil {
@@ -180,7 +192,9 @@ func (a *ApplyAttackOutcomeInput) Validate() error {
 // whether to push reaction prompts to players.
 //
 // The chain itself always runs to completion — there is no in-process pause.
-// The "reaction window" lives at the RPC boundary between phase 1 and phase 2.
+// The "reaction window" lives at the RPC boundary between phase 1 and phase 2,
+// or, for callers that can answer prompts in process, in ResolveAttack with
+// AttackInput.Reactions set.
 //
 //nolint:gocyclo // Attack resolution requires orchestrating multiple game rules stages
 func ResolveAttackHit(ctx context.Context, input *ResolveAttackHitInput) (*AttackContext, error) {
@@ -204,46 +218,81 @@ func ResolveAttackHit(ctx context.Context, input *ResolveAttackHitInput) (*Attac
 	proficiencyBonus := attacker.ProficiencyBonus()
 	defenderAC := GetEffectiveAC(ctx, defender)
 
+	// Cover from room geometry raises the AC the attack roll must beat.
+	// A target behind full cover can't be targeted directly at all.
+	cover := resolveAttackCover(ctx, input.AttackerID, input.TargetID)
+	if cover != nil && cover.Level == CoverFull {
+		return nil, rpgerr.Newf(rpgerr.CodeInvalidTarget,
+			"target %s has full cover from %s", input.TargetID, input.AttackerID)
+	}
+	if component := cover.ACComponent(); component != nil {
+		defenderAC += component.Value
+	}
+
+	weapon := input.Weapon
 	isOffHandAttack := input.AttackHand == AttackHandOff
 	if isOffHandAttack {
-		if err := validateOffHandAttack(ctx, &AttackInput{
-			AttackerID: input.AttackerID,
-			TargetID:   input.TargetID,
-			Weapon:     input.Weapon,
-			EventBus:   input.EventBus,
-			Roller:     input.Roller,
-			AttackHand: input.AttackHand,
-			AttackType: input.AttackType,
-		}); err != nil {
+		weapon, err = validateOffHandAttack(ctx, input.AttackerID, input.Weapon)
+		if err != nil {
 			return nil, err
 		}
 	}
 
+	// Distance to the target decides whether a ranged attack is possible and
+	// whether it suffers long-range or hostile-adjacent disadvantage.
+	attackRange, err := resolveAttackRange(ctx, input.AttackerID, input.TargetID, weapon)
+	if err != nil {
+		return nil, err
+	}
+
 	roller := input.Roller
 	if roller == nil {
 		roller = dice.NewRoller()
 	}
 
-	abilityMod := calculateAttackAbilityModifier(input.Weapon, attackerScores)
+	abilityMod := calculateAttackAbilityModifier(weapon, attackerScores)
+	abilityUsed := determineAbilityUsed(weapon, attackerScores)
 	baseBonus := abilityMod + proficiencyBonus
 
 	attackEvent := dnd5eEvents.AttackChainEvent{
 		AttackerID:          input.AttackerID,
 		TargetID:            input.TargetID,
-		WeaponRef:           weaponToRef(input.Weapon),
-		IsMelee:             !input.Weapon.IsRanged(),
+		WeaponRef:           weaponToRef(weapon),
+		IsMelee:             !weapon.IsRanged() && (attackRange == nil || !attackRange.IsRanged),
 		AttackType:          resolveAttackType(input.AttackType),
 		AdvantageSources:    nil,
-		DisadvantageSources: nil,
+		DisadvantageSources: attackRange.DisadvantageSources(),
 		CancellationSources: nil,
 		AttackBonus:         baseBonus,
 		TargetAC:            defenderAC,
 		CriticalThreshold:   20,
 		ReactionsConsumed:   nil,
+		Trace:               baseAttackTrace(abilityUsed, abilityMod, proficiencyBonus),
+	}
+	for _, source := range attackEvent.DisadvantageSources {
+		attackEvent.Trace = append(attackEvent.Trace, dnd5eEvents.ChainTraceStep{
+			Stage:     StageBase,
+			Kind:      dnd5eEvents.ChainTraceDisadvantage,
+			SourceRef: source.SourceRef,
+			SourceID:  source.SourceID,
+			Reason:    source.Reason,
+		})
+	}
+
+	// Optional flanking rule: an ally on the opposite side grants melee advantage
+	if source := flankingAdvantage(ctx, input.AttackerID, input.TargetID, attackEvent.IsMelee); source != nil {
+		attackEvent.AdvantageSources = append(attackEvent.AdvantageSources, *source)
+		attackEvent.Trace = append(attackEvent.Trace, dnd5eEvents.ChainTraceStep{
+			Stage:     StageBase,
+			Kind:      dnd5eEvents.ChainTraceAdvantage,
+			SourceRef: source.SourceRef,
+			SourceID:  source.SourceID,
+			Reason:    source.Reason,
+		})
 	}
 
 	// Build and execute attack chain (phase 1 chain runs end-to-end)
-	attackChain := events.NewStagedChain[dnd5eEvents.AttackChainEvent](ModifierStages)
+	attackChain := newTracedAttackChain(events.NewStagedChain[dnd5eEvents.AttackChainEvent](ModifierStages))
 	attacks := dnd5eEvents.AttackChain.On(input.EventBus)
 
 	modifiedAttackChain, err := attacks.PublishWithChain(ctx, attackEvent, attackChain)
@@ -260,38 +309,22 @@ func ResolveAttackHit(ctx context.Context, input *ResolveAttackHitInput) (*Attac
 	hasAdvantage := len(finalAttackEvent.AdvantageSources) > 0
 	hasDisadvantage := len(finalAttackEvent.DisadvantageSources) > 0
 
-	var attackRoll int
-	var allRolls []int
-
-	switch {
-	case hasAdvantage && hasDisadvantage:
-		attackRoll, err = roller.Roll(ctx, 20)
-		if err != nil {
-			return nil, rpgerr.Wrap(err, "failed to roll attack")
-		}
-		allRolls = []int{attackRoll}
+	if hasAdvantage && hasDisadvantage {
 		hasAdvantage = false
 		hasDisadvantage = false
-	case hasAdvantage:
-		allRolls, err = roller.RollN(ctx, 2, 20)
-		if err != nil {
-			return nil, rpgerr.Wrap(err, "failed to roll attack with advantage")
-		}
-		attackRoll = max(allRolls[0], allRolls[1])
-	case hasDisadvantage:
-		allRolls, err = roller.RollN(ctx, 2, 20)
-		if err != nil {
-			return nil, rpgerr.Wrap(err, "failed to roll attack with disadvantage")
-		}
-		attackRoll = min(allRolls[0], allRolls[1])
-	default:
-		attackRoll, err = roller.Roll(ctx, 20)
-		if err != nil {
-			return nil, rpgerr.Wrap(err, "failed to roll attack")
-		}
-		allRolls = []int{attackRoll}
 	}
 
+	d20, err := RollD20(ctx, roller, D20Input{
+		Advantage:    hasAdvantage,
+		Disadvantage: hasDisadvantage,
+		RerollOnes:   len(finalAttackEvent.RerollOneSources) > 0,
+	})
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to roll attack")
+	}
+	attackRoll := d20.Roll
+	allRolls := d20.Rolls
+
 	totalAttack := attackRoll + finalAttackEvent.AttackBonus
 	isNatural20 := attackRoll == 20
 	isNatural1 := attackRoll == 1
@@ -347,9 +380,11 @@ func ResolveAttackHit(ctx context.Context, input *ResolveAttackHitInput) (*Attac
 	return &AttackContext{
 		AttackerID:        input.AttackerID,
 		TargetID:          input.TargetID,
-		Weapon:            input.Weapon,
+		Weapon:            weapon,
 		OriginalAC:        defenderAC,
 		WouldHit:          wouldHit,
+		Cover:             cover,
+		Range:             attackRange,
 		AttackRoll:        attackRoll,
 		AttackBonus:       finalAttackEvent.AttackBonus,
 		TotalAttack:       totalAttack,
@@ -361,8 +396,9 @@ func ResolveAttackHit(ctx context.Context, input *ResolveAttackHitInput) (*Attac
 		CriticalThreshold: finalAttackEvent.CriticalThreshold,
 		ReactionsConsumed: finalAttackEvent.ReactionsConsumed,
 		AbilityMod:        abilityMod,
-		AbilityUsed:       determineAbilityUsed(input.Weapon, attackerScores),
+		AbilityUsed:       abilityUsed,
 		IsOffHandAttack:   isOffHandAttack,
+		Trace:             finalAttackEvent.Trace,
 	}, nil
 }
 
@@ -376,6 +412,7 @@ func ResolveAttackHit(ctx context.Context, input *ResolveAttackHitInput) (*Attac
 //     DamageReceivedEvent
 //  4. If the attack misses (e.g., Shield turned a hit into a miss), returns a
 //     result with Hit=false and no damage
+//  5. Publishes the attack_resolved entry to CombatLogTopic
 //
 // Passing an empty Reactions slice produces identical output to the original
 // monolithic ResolveAttack for the same roll + AC combination.
@@ -393,9 +430,30 @@ func ApplyAttackOutcome(ctx context.Context, input *ApplyAttackOutcomeInput) (*A
 	}
 
 	// Recompute effective AC with any reaction modifiers
+	var acSteps []dnd5eEvents.ChainTraceStep
+	if component := ac.Cover.ACComponent(); component != nil {
+		acSteps = append(acSteps, dnd5eEvents.ChainTraceStep{
+			Kind:      dnd5eEvents.ChainTraceCover,
+			SourceRef: component.Source,
+			Value:     component.Value,
+		})
+	}
 	effectiveAC := ac.OriginalAC
 	for _, mod := range input.Reactions {
 		effectiveAC += mod.ACBonus
+		if mod.ACBonus != 0 {
+			step := dnd5eEvents.ChainTraceStep{
+				Kind:     dnd5eEvents.ChainTraceReaction,
+				SourceID: ac.TargetID,
+				Value:    mod.ACBonus,
+			}
+			if ref, err := core.ParseString(mod.ConditionRef); err == nil {
+				step.SourceRef = ref
+			} else {
+				step.Reason = mod.ConditionRef
+			}
+			acSteps = append(acSteps, step)
+		}
 	}
 
 	// Re-evaluate hit with effective AC
@@ -426,10 +484,28 @@ func ApplyAttackOutcome(ctx context.Context, input *ApplyAttackOutcomeInput) (*A
 		AllRolls:        ac.AllRolls,
 		HasAdvantage:    ac.HasAdvantage,
 		HasDisadvantage: ac.HasDisadvantage,
+		Cover:           ac.Cover,
+		Range:           ac.Range,
 		DamageType:      ac.Weapon.DamageType,
+		Trace: &AttackTrace{
+			Rolls:           ac.AllRolls,
+			Roll:            ac.AttackRoll,
+			HasAdvantage:    ac.HasAdvantage,
+			HasDisadvantage: ac.HasDisadvantage,
+			Steps:           ac.Trace,
+			AttackBonus:     ac.AttackBonus,
+			TotalAttack:     ac.TotalAttack,
+			ACSteps:         acSteps,
+			TargetAC:        effectiveAC,
+			Hit:             hit,
+			Critical:        isCritical,
+		},
 	}
 
 	if !hit {
+		if err := publishAttackLog(ctx, input.EventBus, ac, result); err != nil {
+			return nil, err
+		}
 		return result, nil
 	}
 
@@ -440,9 +516,12 @@ func ApplyAttackOutcome(ctx context.Context, input *ApplyAttackOutcomeInput) (*A
 	}
 
 	var damageRolls []int
-	if isCritical {
+	switch {
+	case isCritical && GetCombatRules(ctx).CriticalHit == CriticalMaxPlusRoll:
+		damageRolls, err = rollMaxPlusDamage(ctx, damagePool, roller)
+	case isCritical:
 		damageRolls, err = rollDamageDice(ctx, damagePool, roller, 2)
-	} else {
+	default:
 		damageRolls, err = rollDamageDice(ctx, damagePool, roller, 1)
 	}
 	if err != nil {
@@ -457,14 +536,23 @@ func ApplyAttackOutcome(ctx context.Context, input *ApplyAttackOutcomeInput) (*A
 		FinalDiceRolls:    damageRolls,
 		DamageType:        ac.Weapon.DamageType,
 		IsCritical:        isCritical,
+		Annotations:       ac.Weapon.Annotations,
+	}
+
+	// Off-hand attacks don't add the ability modifier to damage unless it's
+	// negative; the Two-Weapon Fighting style adds it back in the damage chain.
+	abilityDamage := ac.AbilityMod
+	if ac.IsOffHandAttack {
+		abilityDamage = min(ac.AbilityMod, 0)
 	}
 
 	abilityComponent := dnd5eEvents.DamageComponent{
-		Source:     dnd5eEvents.DamageSourceAbility,
-		SourceRef:  abilityToRef(ac.AbilityUsed),
-		FlatBonus:  ac.AbilityMod,
-		DamageType: ac.Weapon.DamageType,
-		IsCritical: isCritical,
+		Source:      dnd5eEvents.DamageSourceAbility,
+		SourceRef:   abilityToRef(ac.AbilityUsed),
+		FlatBonus:   abilityDamage,
+		DamageType:  ac.Weapon.DamageType,
+		IsCritical:  isCritical,
+		Annotations: ac.Weapon.Annotations,
 	}
 
 	resolveOutput, err := ResolveDamage(ctx, &ResolveDamageInput{
@@ -497,11 +585,20 @@ func ApplyAttackOutcome(ctx context.Context, input *ApplyAttackOutcomeInput) (*A
 		return nil, rpgerr.Wrapf(err, "failed to look up attacker %s for damage bonus", ac.AttackerID)
 	}
 	result.DamageBonus = attacker.AbilityScores().Modifier(finalAbilityUsed)
+	if ac.IsOffHandAttack {
+		result.DamageBonus = offHandDamageBonus(result.DamageBonus, resolveOutput.FinalComponents)
+	}
 
 	if result.TotalDamage < 0 {
 		result.TotalDamage = 0
 	}
 
+	result.Trace.Damage = &DamageTrace{
+		Steps:      resolveOutput.Trace,
+		Components: resolveOutput.FinalComponents,
+		Total:      result.TotalDamage,
+	}
+
 	result.Breakdown = &DamageBreakdown{
 		Components:  resolveOutput.FinalComponents,
 		AbilityUsed: finalAbilityUsed,
@@ -509,7 +606,7 @@ func ApplyAttackOutcome(ctx context.Context, input *ApplyAttackOutcomeInput) (*A
 	}
 
 	damageTopic := dnd5eEvents.DamageReceivedTopic.On(input.EventBus)
-	if err := damageTopic.Publish(ctx, dnd5eEvents.DamageReceivedEvent{
+	if err := damageTopic.Publish(events.WithCause(ctx, resolveOutput.ChainEvent), dnd5eEvents.DamageReceivedEvent{
 		TargetID:   ac.TargetID,
 		SourceID:   ac.AttackerID,
 		SourceRef:  weaponToRef(ac.Weapon),
@@ -519,5 +616,9 @@ func ApplyAttackOutcome(ctx context.Context, input *ApplyAttackOutcomeInput) (*A
 		return nil, rpgerr.Wrap(err, "failed to publish damage received event")
 	}
 
+	if err := publishAttackLog(ctx, input.EventBus, ac, result); err != nil {
+		return nil, err
+	}
+
 	return result, nil
 }
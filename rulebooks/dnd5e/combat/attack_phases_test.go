@@ -18,6 +18,7 @@ import (
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
 	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/gamectx"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monstertraits"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/weapons"
 )
@@ -231,6 +232,38 @@ func (s *AttackPhasesTestSuite) TestApplyAttackOutcome_NoReactions_Hit() {
 	s.Equal(9, result.TotalDamage)
 }
 
+// TestResolveAttack_WeaponAnnotationsReachResistance verifies a magical weapon's
+// damage carries the annotation and gets past "from nonmagical attacks".
+func (s *AttackPhasesTestSuite) TestResolveAttack_WeaponAnnotationsReachResistance() {
+	resistance := monstertraits.Resistance("goblin-1", damage.Slashing, damage.AnnotationMagical)
+	s.Require().NoError(resistance.Apply(s.ctx, s.eventBus))
+
+	attack := func(weapon *weapons.Weapon) *combat.AttackResult {
+		s.mockRoller.EXPECT().Roll(s.ctx, 20).Return(15, nil)
+		s.mockRoller.EXPECT().RollN(s.ctx, 1, 8).Return([]int{7}, nil)
+		result, err := combat.ResolveAttack(s.ctx, &combat.AttackInput{
+			AttackerID: "fighter-1",
+			TargetID:   "goblin-1",
+			Weapon:     weapon,
+			EventBus:   s.eventBus,
+			Roller:     s.mockRoller,
+		})
+		s.Require().NoError(err)
+		return result
+	}
+
+	//nolint:gocritic // math explanation: (7 + 3) halved = 5
+	s.Equal(5, attack(s.longsword).TotalDamage, "a mundane longsword is resisted")
+
+	plusOne := s.longsword.WithAnnotations(damage.AnnotationMagical)
+	result := attack(&plusOne)
+	s.Equal(10, result.TotalDamage, "a magical longsword isn't")
+	s.Require().NotNil(result.Breakdown)
+	for _, component := range result.Breakdown.Components {
+		s.True(component.HasAnnotation(damage.AnnotationMagical), "%s component", component.Source)
+	}
+}
+
 // TestApplyAttackOutcome_NoReactions_Miss verifies the miss path returns no damage.
 func (s *AttackPhasesTestSuite) TestApplyAttackOutcome_NoReactions_Miss() {
 	// Roll 5 → total 10 → miss vs AC 15
@@ -0,0 +1,186 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package combat
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/weapons"
+	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
+)
+
+// AttackRangeResult describes the distance of an attack and the range penalties it carries.
+type AttackRangeResult struct {
+	// DistanceFeet is the distance from attacker to target in feet
+	DistanceFeet int
+
+	// IsRanged is true for ranged weapons and for thrown weapons beyond 5 feet
+	IsRanged bool
+
+	// LongRange is true when the target is beyond the weapon's normal range
+	// but within its long range (disadvantage)
+	LongRange bool
+
+	// HostileNearbyID is a hostile creature within 5 feet of the attacker
+	// (disadvantage on ranged attacks). Empty when there is none.
+	HostileNearbyID string
+}
+
+// DisadvantageSources returns the disadvantage the range imposes on the attack roll.
+func (r *AttackRangeResult) DisadvantageSources() []dnd5eEvents.AttackModifierSource {
+	if r == nil || !r.IsRanged {
+		return nil
+	}
+
+	var sources []dnd5eEvents.AttackModifierSource
+	if r.LongRange {
+		sources = append(sources, dnd5eEvents.AttackModifierSource{
+			SourceRef: refs.Conditions.LongRange(),
+			Reason:    "long range",
+		})
+	}
+	if r.HostileNearbyID != "" {
+		sources = append(sources, dnd5eEvents.AttackModifierSource{
+			SourceRef: refs.Conditions.HostileAdjacent(),
+			SourceID:  r.HostileNearbyID,
+			Reason:    "hostile within 5 feet",
+		})
+	}
+	return sources
+}
+
+// HostilityChecker decides whether one entity is hostile to another.
+// Games with factions or allegiances provide one via WithHostilityChecker.
+type HostilityChecker interface {
+	// IsHostile returns true if otherID is hostile to sourceID
+	IsHostile(ctx context.Context, sourceID, otherID string) bool
+}
+
+type hostilityCheckerKey struct{}
+
+// WithHostilityChecker adds a hostility checker to the context.
+func WithHostilityChecker(ctx context.Context, checker HostilityChecker) context.Context {
+	return context.WithValue(ctx, hostilityCheckerKey{}, checker)
+}
+
+// GetHostilityChecker retrieves the hostility checker from the context.
+func GetHostilityChecker(ctx context.Context) (HostilityChecker, bool) {
+	checker, ok := ctx.Value(hostilityCheckerKey{}).(HostilityChecker)
+	return checker, ok
+}
+
+// isHostile reports whether other is hostile to source. Without a checker in
+// the context, entities of a different type are treated as hostile
+// (characters vs monsters).
+func isHostile(ctx context.Context, source, other core.Entity) bool {
+	if checker, ok := GetHostilityChecker(ctx); ok {
+		return checker.IsHostile(ctx, source.GetID(), other.GetID())
+	}
+	return source.GetType() != other.GetType()
+}
+
+// isIncapacitated reports whether a combatant's conditions leave it unable to act.
+func isIncapacitated(ctx context.Context, entityID string) bool {
+	combatant, err := GetCombatantFromContext(ctx, entityID)
+	if err != nil || combatant == nil {
+		return false
+	}
+	holder, ok := combatant.(ConditionHolder)
+	if !ok {
+		return false
+	}
+	return CombineConditionRestrictions(ConditionRefs(holder.GetConditions())).NoActions
+}
+
+// resolveAttackRange measures the attack against the weapon's range.
+//
+// Per D&D 5e:
+//   - A ranged attack beyond the weapon's long range is impossible
+//   - Beyond normal range, the attack roll has disadvantage
+//   - A ranged attack has disadvantage while a hostile creature that can see
+//     the attacker and isn't incapacitated is within 5 feet
+//
+// Returns nil when there is no room in the context, either combatant isn't
+// placed, or the attack is a melee attack.
+func resolveAttackRange(
+	ctx context.Context, attackerID, targetID string, weapon *weapons.Weapon,
+) (*AttackRangeResult, error) {
+	room, err := getRoomFromContext(ctx)
+	if err != nil {
+		return nil, nil
+	}
+	attackerPos, found := room.GetEntityPosition(attackerID)
+	if !found {
+		return nil, nil
+	}
+	targetPos, found := room.GetEntityPosition(targetID)
+	if !found {
+		return nil, nil
+	}
+
+	distance := room.GetGrid().Distance(attackerPos, targetPos)
+	result := &AttackRangeResult{
+		DistanceFeet: GetCombatRules(ctx).GridDistanceFeet(room.GetGrid(), attackerPos, targetPos),
+		IsRanged:     weapon.IsRanged(),
+	}
+
+	// A thrown melee weapon becomes a ranged attack once the target is out of reach
+	if !result.IsRanged && weapon.HasProperty(weapons.PropertyThrown) && distance > DefaultMeleeReach {
+		result.IsRanged = true
+	}
+	if !result.IsRanged {
+		return nil, nil
+	}
+
+	if weapon.Range != nil {
+		if weapon.Range.Long > 0 && result.DistanceFeet > weapon.Range.Long {
+			return nil, rpgerr.Newf(rpgerr.CodeOutOfRange,
+				"target %s is %d ft away, beyond the %d ft long range of %s",
+				targetID, result.DistanceFeet, weapon.Range.Long, weapon.ID)
+		}
+		result.LongRange = result.DistanceFeet > weapon.Range.Normal
+	}
+
+	result.HostileNearbyID = findHostileNearby(ctx, room, attackerID, attackerPos)
+	return result, nil
+}
+
+// findHostileNearby returns the hostile, non-incapacitated creature within
+// 5 feet of the attacker with the lowest ID, or an empty string. Vision is
+// not tracked, so every such creature is assumed to see the attacker.
+func findHostileNearby(ctx context.Context, room spatial.Room, attackerID string, attackerPos spatial.Position) string {
+	nearby := room.GetEntitiesInRange(attackerPos, DefaultMeleeReach)
+
+	var attacker core.Entity
+	for _, entity := range nearby {
+		if entity.GetID() == attackerID {
+			attacker = entity
+			break
+		}
+	}
+	if attacker == nil {
+		return ""
+	}
+
+	hostileID := ""
+	for _, entity := range nearby {
+		id := entity.GetID()
+		if id == attackerID || (hostileID != "" && id >= hostileID) {
+			continue
+		}
+		// Only combatants threaten - walls and obstacles share the room
+		if combatant, err := GetCombatantFromContext(ctx, id); err != nil || combatant == nil {
+			continue
+		}
+		if !isHostile(ctx, attacker, entity) || isIncapacitated(ctx, id) {
+			continue
+		}
+		hostileID = id
+	}
+	return hostileID
+}
@@ -0,0 +1,346 @@
+package combat_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/weapons"
+	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
+)
+
+// conditionedEntity is a combatant that reports its active conditions
+type conditionedEntity struct {
+	*mockEntity
+	conditions []dnd5eEvents.ConditionBehavior
+}
+
+func (c *conditionedEntity) GetConditions() []dnd5eEvents.ConditionBehavior { return c.conditions }
+
+// hostilityFunc adapts a function to combat.HostilityChecker
+type hostilityFunc func(sourceID, otherID string) bool
+
+func (f hostilityFunc) IsHostile(_ context.Context, sourceID, otherID string) bool {
+	return f(sourceID, otherID)
+}
+
+type AttackRangeTestSuite struct {
+	suite.Suite
+	ctrl       *gomock.Controller
+	ctx        context.Context
+	eventBus   events.EventBus
+	lookup     *integrationLookup
+	room       *spatial.BasicRoom
+	mockRoller *mock_dice.MockRoller
+}
+
+func TestAttackRangeSuite(t *testing.T) {
+	suite.Run(t, new(AttackRangeTestSuite))
+}
+
+func (s *AttackRangeTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.eventBus = events.NewEventBus()
+	s.lookup = newIntegrationLookup()
+	s.mockRoller = mock_dice.NewMockRoller(s.ctrl)
+
+	s.room = spatial.NewBasicRoom(spatial.BasicRoomConfig{
+		ID:   "range-room",
+		Type: "combat",
+		Grid: spatial.NewSquareGrid(spatial.SquareGridConfig{Width: 80, Height: 10}),
+	})
+
+	s.ctx = combat.WithRoom(context.Background(), s.room)
+	s.ctx = combat.WithCombatantLookup(s.ctx, s.lookup)
+
+	// Archer: DEX 16 (+3), proficiency +2, at the west edge of the room
+	s.place("archer", "character", 0, nil)
+}
+
+func (s *AttackRangeTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// place adds a combatant at (x, 5) in the room and to the lookup
+func (s *AttackRangeTestSuite) place(id string, entityType core.EntityType, x int, conditions []dnd5eEvents.ConditionBehavior) {
+	s.lookup.Add(&conditionedEntity{
+		mockEntity: &mockEntity{
+			id:               id,
+			hitPoints:        20,
+			maxHitPoints:     20,
+			ac:               15,
+			abilityScores:    shared.AbilityScores{abilities.STR: 10, abilities.DEX: 16},
+			proficiencyBonus: 2,
+		},
+		conditions: conditions,
+	})
+	s.Require().NoError(s.room.PlaceEntity(&testCombatant{id: id, entityType: entityType},
+		spatial.Position{X: float64(x), Y: 5}))
+}
+
+func (s *AttackRangeTestSuite) attackHit(weaponID weapons.WeaponID) (*combat.AttackContext, error) {
+	weapon, err := weapons.GetByID(weaponID)
+	s.Require().NoError(err)
+
+	return combat.ResolveAttackHit(s.ctx, &combat.ResolveAttackHitInput{
+		AttackerID: "archer",
+		TargetID:   "goblin",
+		Weapon:     &weapon,
+		EventBus:   s.eventBus,
+		Roller:     s.mockRoller,
+	})
+}
+
+// disadvantageSteps returns the disadvantage steps recorded in an attack trace
+func disadvantageSteps(trace []dnd5eEvents.ChainTraceStep) []dnd5eEvents.ChainTraceStep {
+	var steps []dnd5eEvents.ChainTraceStep
+	for _, step := range trace {
+		if step.Kind == dnd5eEvents.ChainTraceDisadvantage {
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
+
+func (s *AttackRangeTestSuite) TestWithinNormalRange() {
+	s.place("goblin", "monster", 10, nil)
+	s.mockRoller.EXPECT().Roll(gomock.Any(), 20).Return(12, nil)
+
+	result, err := s.attackHit(weapons.Shortbow)
+	s.Require().NoError(err)
+
+	s.Require().NotNil(result.Range)
+	s.Equal(50, result.Range.DistanceFeet)
+	s.True(result.Range.IsRanged)
+	s.False(result.Range.LongRange)
+	s.Empty(result.Range.HostileNearbyID)
+	s.False(result.HasDisadvantage)
+}
+
+func (s *AttackRangeTestSuite) TestLongRangeImposesDisadvantage() {
+	s.place("goblin", "monster", 20, nil)
+	s.mockRoller.EXPECT().RollN(gomock.Any(), 2, 20).Return([]int{15, 4}, nil)
+
+	result, err := s.attackHit(weapons.Shortbow)
+	s.Require().NoError(err)
+
+	s.Require().NotNil(result.Range)
+	s.Equal(100, result.Range.DistanceFeet)
+	s.True(result.Range.LongRange)
+	s.True(result.HasDisadvantage)
+	s.Equal(4, result.AttackRoll)
+
+	steps := disadvantageSteps(result.Trace)
+	s.Require().Len(steps, 1)
+	s.Equal(combat.StageBase, steps[0].Stage)
+	s.Equal(refs.Conditions.LongRange(), steps[0].SourceRef)
+}
+
+func (s *AttackRangeTestSuite) TestBeyondLongRangeFails() {
+	s.place("goblin", "monster", 70, nil)
+
+	_, err := s.attackHit(weapons.Shortbow)
+	s.Require().Error(err)
+	s.Equal(rpgerr.CodeOutOfRange, rpgerr.GetCode(err))
+	s.Contains(err.Error(), "350 ft")
+}
+
+func (s *AttackRangeTestSuite) TestHostileWithinFiveFeetImposesDisadvantage() {
+	s.place("goblin", "monster", 10, nil)
+	s.place("orc", "monster", 1, nil)
+	s.mockRoller.EXPECT().RollN(gomock.Any(), 2, 20).Return([]int{15, 4}, nil)
+
+	result, err := s.attackHit(weapons.Shortbow)
+	s.Require().NoError(err)
+
+	s.Require().NotNil(result.Range)
+	s.Equal("orc", result.Range.HostileNearbyID)
+	s.True(result.HasDisadvantage)
+	steps := disadvantageSteps(result.Trace)
+	s.Require().Len(steps, 1)
+	s.Equal(refs.Conditions.HostileAdjacent(), steps[0].SourceRef)
+	s.Equal("orc", steps[0].SourceID)
+}
+
+func (s *AttackRangeTestSuite) TestAllyWithinFiveFeetIgnored() {
+	s.place("goblin", "monster", 10, nil)
+	s.place("cleric", "character", 1, nil)
+	s.mockRoller.EXPECT().Roll(gomock.Any(), 20).Return(12, nil)
+
+	result, err := s.attackHit(weapons.Shortbow)
+	s.Require().NoError(err)
+
+	s.Empty(result.Range.HostileNearbyID)
+	s.False(result.HasDisadvantage)
+}
+
+func (s *AttackRangeTestSuite) TestIncapacitatedHostileIgnored() {
+	s.place("goblin", "monster", 10, nil)
+	s.place("orc", "monster", 1, []dnd5eEvents.ConditionBehavior{
+		&staticCondition{ref: refs.Conditions.Stunned()},
+	})
+	s.mockRoller.EXPECT().Roll(gomock.Any(), 20).Return(12, nil)
+
+	result, err := s.attackHit(weapons.Shortbow)
+	s.Require().NoError(err)
+
+	s.Empty(result.Range.HostileNearbyID)
+	s.False(result.HasDisadvantage)
+}
+
+func (s *AttackRangeTestSuite) TestHostilityCheckerOverridesDefault() {
+	s.place("goblin", "monster", 10, nil)
+	s.place("charmed-cleric", "character", 1, nil)
+	s.ctx = combat.WithHostilityChecker(s.ctx, hostilityFunc(func(_, otherID string) bool {
+		return otherID == "charmed-cleric"
+	}))
+	s.mockRoller.EXPECT().RollN(gomock.Any(), 2, 20).Return([]int{15, 4}, nil)
+
+	result, err := s.attackHit(weapons.Shortbow)
+	s.Require().NoError(err)
+
+	s.Equal("charmed-cleric", result.Range.HostileNearbyID)
+	s.True(result.HasDisadvantage)
+}
+
+func (s *AttackRangeTestSuite) TestMeleeAttackUnaffected() {
+	s.place("goblin", "monster", 1, nil)
+	s.mockRoller.EXPECT().Roll(gomock.Any(), 20).Return(12, nil)
+
+	result, err := s.attackHit(weapons.Dagger)
+	s.Require().NoError(err)
+
+	s.Nil(result.Range)
+	s.False(result.HasDisadvantage)
+}
+
+func (s *AttackRangeTestSuite) TestThrownWeaponUsesRange() {
+	s.Run("within normal range", func() {
+		s.SetupTest()
+		s.place("goblin", "monster", 3, nil)
+		s.mockRoller.EXPECT().Roll(gomock.Any(), 20).Return(12, nil)
+
+		result, err := s.attackHit(weapons.Dagger)
+		s.Require().NoError(err)
+
+		s.Require().NotNil(result.Range)
+		s.True(result.Range.IsRanged)
+		s.Equal(15, result.Range.DistanceFeet)
+		s.False(result.HasDisadvantage)
+	})
+
+	s.Run("long range", func() {
+		s.SetupTest()
+		s.place("goblin", "monster", 8, nil)
+		s.mockRoller.EXPECT().RollN(gomock.Any(), 2, 20).Return([]int{15, 4}, nil)
+
+		result, err := s.attackHit(weapons.Dagger)
+		s.Require().NoError(err)
+
+		s.True(result.Range.LongRange)
+		s.True(result.HasDisadvantage)
+	})
+
+	s.Run("beyond long range", func() {
+		s.SetupTest()
+		s.place("goblin", "monster", 13, nil)
+
+		_, err := s.attackHit(weapons.Dagger)
+		s.Equal(rpgerr.CodeOutOfRange, rpgerr.GetCode(err))
+	})
+}
+
+func (s *AttackRangeTestSuite) TestNoRoomSkipsRangeCheck() {
+	s.place("goblin", "monster", 70, nil)
+	s.ctx = combat.WithCombatantLookup(context.Background(), s.lookup)
+	s.mockRoller.EXPECT().Roll(gomock.Any(), 20).Return(12, nil)
+
+	result, err := s.attackHit(weapons.Shortbow)
+	s.Require().NoError(err)
+
+	s.Nil(result.Range)
+	s.False(result.HasDisadvantage)
+}
+
+func (s *AttackRangeTestSuite) scorchingRay() *combat.SpellAttackInput {
+	return &combat.SpellAttackInput{
+		AttackerID:  "archer",
+		TargetID:    "goblin",
+		SpellRef:    refs.Spells.ScorchingRay(),
+		AttackBonus: 5,
+		Ranged:      true,
+		Range:       120,
+		Damage:      "2d6",
+		DamageType:  damage.Fire,
+		EventBus:    s.eventBus,
+		Roller:      s.mockRoller,
+	}
+}
+
+func (s *AttackRangeTestSuite) TestSpellAttackHitPublishesDamage() {
+	s.place("goblin", "monster", 10, nil)
+	var received *dnd5eEvents.DamageReceivedEvent
+	_, err := dnd5eEvents.DamageReceivedTopic.On(s.eventBus).Subscribe(s.ctx,
+		func(_ context.Context, e dnd5eEvents.DamageReceivedEvent) error {
+			received = &e
+			return nil
+		})
+	s.Require().NoError(err)
+
+	s.mockRoller.EXPECT().Roll(gomock.Any(), 20).Return(11, nil)
+	s.mockRoller.EXPECT().RollN(gomock.Any(), 2, 6).Return([]int{2, 5}, nil)
+
+	result, err := combat.ResolveSpellAttack(s.ctx, s.scorchingRay())
+	s.Require().NoError(err)
+
+	s.True(result.Hit)
+	s.Equal(16, result.TotalAttack)
+	s.Equal(7, result.TotalDamage)
+	s.Require().NotNil(received)
+	s.Equal(7, received.Amount)
+	s.Equal(refs.Spells.ScorchingRay(), received.SourceRef)
+}
+
+func (s *AttackRangeTestSuite) TestSpellAttackCriticalDoublesDice() {
+	s.place("goblin", "monster", 10, nil)
+	s.mockRoller.EXPECT().Roll(gomock.Any(), 20).Return(20, nil)
+	s.mockRoller.EXPECT().RollN(gomock.Any(), 2, 6).Return([]int{1, 1}, nil).Times(2)
+
+	result, err := combat.ResolveSpellAttack(s.ctx, s.scorchingRay())
+	s.Require().NoError(err)
+
+	s.True(result.Critical)
+	s.Equal(4, result.TotalDamage)
+}
+
+func (s *AttackRangeTestSuite) TestSpellAttackBeyondRangeFails() {
+	s.place("goblin", "monster", 30, nil)
+
+	_, err := combat.ResolveSpellAttack(s.ctx, s.scorchingRay())
+	s.Require().Error(err)
+	s.Equal(rpgerr.CodeOutOfRange, rpgerr.GetCode(err))
+}
+
+func (s *AttackRangeTestSuite) TestRangedSpellAttackHostileWithinFiveFeet() {
+	s.place("goblin", "monster", 10, nil)
+	s.place("orc", "monster", 1, nil)
+	s.mockRoller.EXPECT().RollN(gomock.Any(), 2, 20).Return([]int{15, 2}, nil)
+
+	result, err := combat.ResolveSpellAttack(s.ctx, s.scorchingRay())
+	s.Require().NoError(err)
+
+	s.True(result.HasDisadvantage)
+	s.False(result.Hit)
+}
@@ -0,0 +1,193 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package combat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+)
+
+// FormatAttackLog renders an attack trace as one line of combat-log text, e.g.
+//
+//	20 (d20 13, +3 DEX, +2 Proficiency, +2 Archery) vs AC 15: hit for 9 damage (Longbow 6 piercing, +3 DEX)
+//
+// Every value comes from the trace, so clients never re-derive a result. The
+// text is CombatLogEvent.Text for the trace's attack_resolved payload, without
+// the actor and target.
+func FormatAttackLog(trace *AttackTrace) string {
+	if trace == nil {
+		return ""
+	}
+
+	attack := &dnd5eEvents.AttackResolvedLog{
+		Rolls:        trace.Rolls,
+		Roll:         trace.Roll,
+		Total:        trace.TotalAttack,
+		TargetAC:     trace.TargetAC,
+		Hit:          trace.Hit,
+		Critical:     trace.Critical,
+		Advantage:    trace.HasAdvantage,
+		Disadvantage: trace.HasDisadvantage,
+	}
+	addTraceSources(attack, trace)
+	if trace.Damage != nil {
+		attack.Damage = trace.Damage.Total
+	}
+
+	entry := dnd5eEvents.CombatLogEvent{
+		Version: dnd5eEvents.CombatLogVersion,
+		Kind:    dnd5eEvents.CombatLogAttackResolved,
+		Attack:  attack,
+	}
+	return entry.Text()
+}
+
+// FormatDamageLog renders a damage trace as combat-log text, e.g.
+//
+//	12 damage (Greatsword 9 slashing (rerolled 1 to 4, Great Weapon Fighting), +3 STR)
+func FormatDamageLog(trace *DamageTrace) string {
+	if trace == nil {
+		return ""
+	}
+	return dnd5eEvents.DamageLogText(trace.Total, "", dnd5eEvents.CombatLogSourcesFromDamage(trace.Components))
+}
+
+// addTraceSources copies the trace's attack, AC and damage breakdown onto a
+// combat log payload.
+func addTraceSources(attack *dnd5eEvents.AttackResolvedLog, trace *AttackTrace) {
+	if trace == nil {
+		return
+	}
+	attack.Sources = dnd5eEvents.CombatLogSourcesFromTrace(trace.Steps)
+	attack.ACSources = dnd5eEvents.CombatLogSourcesFromTrace(trace.ACSteps)
+	if trace.Damage != nil {
+		attack.DamageSources = dnd5eEvents.CombatLogSourcesFromDamage(trace.Damage.Components)
+	}
+}
+
+// attackResolvedLog builds the canonical combat log entry for a resolved attack.
+func attackResolvedLog(ac *AttackContext, result *AttackResult) dnd5eEvents.CombatLogEvent {
+	attack := &dnd5eEvents.AttackResolvedLog{
+		WeaponRef:    weaponToRef(ac.Weapon),
+		Rolls:        result.AllRolls,
+		Roll:         result.AttackRoll,
+		Total:        result.TotalAttack,
+		TargetAC:     result.TargetAC,
+		Hit:          result.Hit,
+		Critical:     result.Critical,
+		Advantage:    result.HasAdvantage,
+		Disadvantage: result.HasDisadvantage,
+		Damage:       result.TotalDamage,
+		DamageType:   result.DamageType,
+	}
+	addTraceSources(attack, result.Trace)
+
+	return dnd5eEvents.CombatLogEvent{
+		Version:  dnd5eEvents.CombatLogVersion,
+		Kind:     dnd5eEvents.CombatLogAttackResolved,
+		ActorID:  ac.AttackerID,
+		TargetID: ac.TargetID,
+		Attack:   attack,
+	}
+}
+
+// publishAttackLog publishes the attack_resolved entry for a finished attack.
+func publishAttackLog(ctx context.Context, bus events.EventBus, ac *AttackContext, result *AttackResult) error {
+	if err := dnd5eEvents.CombatLogTopic.On(bus).Publish(ctx, attackResolvedLog(ac, result)); err != nil {
+		return rpgerr.Wrap(err, "failed to publish attack combat log")
+	}
+	return nil
+}
+
+// CombatLogBridge republishes rule-level notifications as canonical combat
+// log entries on CombatLogTopic, so consumers follow one stream:
+//   - DamageReceivedTopic becomes damage_applied
+//   - ConditionAppliedTopic becomes condition_applied
+//   - ResourceConsumedTopic becomes resource_spent
+//
+// Attack and save entries are published directly by ApplyAttackOutcome and
+// saves.MakeSavingThrow. Apply the bridge once per bus.
+type CombatLogBridge struct {
+	bus             events.EventBus
+	subscriptionIDs []string
+}
+
+// NewCombatLogBridge creates a combat log bridge.
+func NewCombatLogBridge() *CombatLogBridge {
+	return &CombatLogBridge{}
+}
+
+// IsApplied returns true if the bridge is subscribed to a bus.
+func (b *CombatLogBridge) IsApplied() bool {
+	return b.bus != nil
+}
+
+// Apply subscribes the bridge to the notification topics it translates.
+func (b *CombatLogBridge) Apply(ctx context.Context, bus events.EventBus) error {
+	if b.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "combat log bridge already applied")
+	}
+	b.bus = bus
+	log := dnd5eEvents.CombatLogTopic.On(bus)
+
+	damageSubID, err := dnd5eEvents.DamageReceivedTopic.On(bus).Subscribe(ctx,
+		func(ctx context.Context, e dnd5eEvents.DamageReceivedEvent) error {
+			return log.Publish(ctx, dnd5eEvents.DamageAppliedLogFrom(e))
+		})
+	if err != nil {
+		b.bus = nil
+		return rpgerr.Wrap(err, "failed to subscribe to damage received topic")
+	}
+	b.subscriptionIDs = append(b.subscriptionIDs, damageSubID)
+
+	conditionSubID, err := dnd5eEvents.ConditionAppliedTopic.On(bus).Subscribe(ctx,
+		func(ctx context.Context, e dnd5eEvents.ConditionAppliedEvent) error {
+			return log.Publish(ctx, dnd5eEvents.ConditionAppliedLogFrom(e))
+		})
+	if err != nil {
+		_ = b.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to condition applied topic")
+	}
+	b.subscriptionIDs = append(b.subscriptionIDs, conditionSubID)
+
+	resourceSubID, err := dnd5eEvents.ResourceConsumedTopic.On(bus).Subscribe(ctx,
+		func(ctx context.Context, e dnd5eEvents.ResourceConsumedEvent) error {
+			return log.Publish(ctx, dnd5eEvents.ResourceSpentLogFrom(e))
+		})
+	if err != nil {
+		_ = b.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to resource consumed topic")
+	}
+	b.subscriptionIDs = append(b.subscriptionIDs, resourceSubID)
+
+	return nil
+}
+
+// Remove unsubscribes the bridge from all topics.
+func (b *CombatLogBridge) Remove(ctx context.Context, bus events.EventBus) error {
+	if b.bus == nil {
+		return nil // Not applied, nothing to remove
+	}
+
+	total := len(b.subscriptionIDs)
+	var errs []error
+	for _, subID := range b.subscriptionIDs {
+		if err := bus.Unsubscribe(ctx, subID); err != nil {
+			errs = append(errs, fmt.Errorf("unsubscribe %s: %w", subID, err))
+		}
+	}
+
+	b.subscriptionIDs = nil
+	b.bus = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unsubscribe %d/%d subscriptions: %w", len(errs), total, errors.Join(errs...))
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package combat_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/core/resources"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+type CombatLogTestSuite struct {
+	suite.Suite
+	ctx     context.Context
+	bus     events.EventBus
+	bridge  *combat.CombatLogBridge
+	entries []dnd5eEvents.CombatLogEvent
+}
+
+func TestCombatLogSuite(t *testing.T) {
+	suite.Run(t, new(CombatLogTestSuite))
+}
+
+func (s *CombatLogTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.entries = nil
+
+	s.bridge = combat.NewCombatLogBridge()
+	s.Require().NoError(s.bridge.Apply(s.ctx, s.bus))
+
+	_, err := dnd5eEvents.CombatLogTopic.On(s.bus).Subscribe(s.ctx, func(_ context.Context, e dnd5eEvents.CombatLogEvent) error {
+		s.entries = append(s.entries, e)
+		return nil
+	})
+	s.Require().NoError(err)
+}
+
+func (s *CombatLogTestSuite) TestBridgeTranslatesNotifications() {
+	s.Require().NoError(dnd5eEvents.DamageReceivedTopic.On(s.bus).Publish(s.ctx, dnd5eEvents.DamageReceivedEvent{
+		TargetID:   "goblin",
+		SourceID:   "fighter",
+		SourceRef:  refs.Weapons.Longsword(),
+		Amount:     9,
+		DamageType: damage.Slashing,
+	}))
+	s.Require().NoError(dnd5eEvents.ConditionAppliedTopic.On(s.bus).Publish(s.ctx, dnd5eEvents.ConditionAppliedEvent{
+		Target: &testCombatant{id: "goblin", entityType: "monster"},
+		Type:   dnd5eEvents.ConditionFrightened,
+		Source: dnd5eEvents.ConditionSourceSpell,
+	}))
+	s.Require().NoError(dnd5eEvents.ResourceConsumedTopic.On(s.bus).Publish(s.ctx, dnd5eEvents.ResourceConsumedEvent{
+		CharacterID: "fighter",
+		ResourceKey: resources.ResourceKey("second_wind"),
+		Amount:      1,
+		Remaining:   0,
+	}))
+
+	s.Require().Len(s.entries, 3)
+	var texts []string
+	for _, entry := range s.entries {
+		s.Require().NoError(entry.Validate())
+		s.Equal(dnd5eEvents.CombatLogVersion, entry.Version)
+		texts = append(texts, entry.Text())
+	}
+	s.Equal([]string{
+		"goblin takes 9 slashing damage from fighter",
+		"goblin is frightened",
+		"fighter spends 1 Second Wind (0 left)",
+	}, texts)
+	s.Equal(refs.Weapons.Longsword(), s.entries[0].Damage.SourceRef)
+}
+
+func (s *CombatLogTestSuite) TestRemove() {
+	s.Require().NoError(s.bridge.Remove(s.ctx, s.bus))
+	s.False(s.bridge.IsApplied())
+
+	s.Require().NoError(dnd5eEvents.DamageReceivedTopic.On(s.bus).Publish(s.ctx, dnd5eEvents.DamageReceivedEvent{
+		TargetID: "goblin",
+		Amount:   3,
+	}))
+	s.Empty(s.entries)
+}
+
+func (s *CombatLogTestSuite) TestJSONSchema() {
+	entry := dnd5eEvents.DamageAppliedLogFrom(dnd5eEvents.DamageReceivedEvent{
+		TargetID:   "goblin",
+		SourceID:   "wizard",
+		Amount:     7,
+		DamageType: damage.Fire,
+		IsCritical: true,
+	})
+
+	data, err := json.Marshal(entry)
+	s.Require().NoError(err)
+	s.JSONEq(`{
+		"version": 1,
+		"kind": "damage_applied",
+		"actor_id": "wizard",
+		"target_id": "goblin",
+		"damage": {"amount": 7, "damage_type": "fire", "critical": true}
+	}`, string(data))
+
+	var decoded dnd5eEvents.CombatLogEvent
+	s.Require().NoError(json.Unmarshal(data, &decoded))
+	s.Equal(entry, decoded)
+	s.Equal("goblin takes 7 fire damage from wizard (critical)", decoded.Text())
+}
+
+func (s *CombatLogTestSuite) TestValidate() {
+	testCases := []struct {
+		name  string
+		entry dnd5eEvents.CombatLogEvent
+	}{
+		{"future version", dnd5eEvents.CombatLogEvent{
+			Version: dnd5eEvents.CombatLogVersion + 1,
+			Kind:    dnd5eEvents.CombatLogDamageApplied,
+			Damage:  &dnd5eEvents.DamageAppliedLog{},
+		}},
+		{"unknown kind", dnd5eEvents.CombatLogEvent{Version: 1, Kind: "cheered"}},
+		{"missing payload", dnd5eEvents.CombatLogEvent{Version: 1, Kind: dnd5eEvents.CombatLogSaveResolved}},
+		{"extra payload", dnd5eEvents.CombatLogEvent{
+			Version: 1,
+			Kind:    dnd5eEvents.CombatLogDamageApplied,
+			Damage:  &dnd5eEvents.DamageAppliedLog{},
+			Save:    &dnd5eEvents.SaveResolvedLog{},
+		}},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.Error(tc.entry.Validate())
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
 )
 
 // ApplyDamageInput contains parameters for applying damage to a combatant.
@@ -101,6 +102,21 @@ func GetEffectiveAC(ctx context.Context, c Combatant) int {
 	return c.AC()
 }
 
+// SkillModifierProvider is implemented by combatants that know their total
+// skill modifier, including proficiency and expertise (characters and monsters).
+type SkillModifierProvider interface {
+	GetSkillModifier(skill skills.Skill) int
+}
+
+// SkillModifier returns the combatant's modifier for a skill check, falling
+// back to the skill's ability modifier for combatants without skills.
+func SkillModifier(c Combatant, skill skills.Skill) int {
+	if provider, ok := c.(SkillModifierProvider); ok {
+		return provider.GetSkillModifier(skill)
+	}
+	return c.AbilityScores().Modifier(skills.Ability(skill))
+}
+
 // CombatantLookup provides combatant lookup from context.
 // This interface is satisfied by gamectx.CombatantRegistry.
 type CombatantLookup interface {
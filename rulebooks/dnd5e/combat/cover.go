@@ -0,0 +1,264 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package combat
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
+)
+
+// CoverLevel classifies how much of a target is protected by obstacles.
+// In D&D 5e cover never stacks - only the most protective degree applies.
+type CoverLevel string
+
+const (
+	// CoverNone means nothing meaningful stands between source and target.
+	CoverNone CoverLevel = "none"
+
+	// CoverHalf grants +2 to AC and DEX saving throws (low wall, another creature).
+	CoverHalf CoverLevel = "half"
+
+	// CoverThreeQuarters grants +5 to AC and DEX saving throws (arrow slit, thick tree trunk).
+	CoverThreeQuarters CoverLevel = "three_quarters"
+
+	// CoverFull means the target can't be targeted directly by an attack or spell.
+	CoverFull CoverLevel = "full"
+)
+
+// rank orders cover levels so the most protective one can be selected.
+func (c CoverLevel) rank() int {
+	switch c {
+	case CoverHalf:
+		return 1
+	case CoverThreeQuarters:
+		return 2
+	case CoverFull:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// ACBonus returns the armor class bonus granted by this cover level.
+// Full cover returns 0 because the target cannot be attacked at all.
+func (c CoverLevel) ACBonus() int {
+	switch c {
+	case CoverHalf:
+		return 2
+	case CoverThreeQuarters:
+		return 5
+	default:
+		return 0
+	}
+}
+
+// SaveBonus returns the saving throw bonus granted by this cover level for the
+// given ability. Cover only protects against DEX saving throws.
+func (c CoverLevel) SaveBonus(ability abilities.Ability) int {
+	if ability != abilities.DEX {
+		return 0
+	}
+	return c.ACBonus()
+}
+
+// Ref returns the ref used to attribute this cover level in breakdowns, or nil for CoverNone.
+func (c CoverLevel) Ref() *core.Ref {
+	switch c {
+	case CoverHalf:
+		return refs.Conditions.HalfCover()
+	case CoverThreeQuarters:
+		return refs.Conditions.ThreeQuartersCover()
+	case CoverFull:
+		return refs.Conditions.FullCover()
+	default:
+		return nil
+	}
+}
+
+// CoverProvider is implemented by room entities that grant a specific degree of
+// cover, such as an arrow slit (three-quarters) or a low wall (half).
+// Entities that don't implement it fall back to their spatial.Placeable
+// line-of-sight flag: sight-blocking obstacles give full cover, anything else
+// (typically a creature) gives half cover.
+type CoverProvider interface {
+	// ProvidesCover returns the degree of cover this entity grants when it lies
+	// between a source and a target.
+	ProvidesCover() CoverLevel
+}
+
+// CoverSource records one obstacle that contributed to a cover calculation.
+type CoverSource struct {
+	// EntityID is the obstacle occupying the intervening square.
+	EntityID string
+
+	// Position is where the obstacle stands.
+	Position spatial.Position
+
+	// Level is the cover this obstacle grants on its own.
+	Level CoverLevel
+}
+
+// CoverResult contains the outcome of a cover calculation.
+type CoverResult struct {
+	// Level is the most protective cover among all sources.
+	Level CoverLevel
+
+	// Sources lists every obstacle found between the source and the target.
+	Sources []CoverSource
+}
+
+// ACComponent returns the AC breakdown entry for this cover, or nil if the
+// cover grants no AC bonus.
+func (r *CoverResult) ACComponent() *ACComponent {
+	if r == nil || r.Level.ACBonus() == 0 {
+		return nil
+	}
+	return &ACComponent{
+		Type:   ACSourceCondition,
+		Source: r.Level.Ref(),
+		Value:  r.Level.ACBonus(),
+	}
+}
+
+// SaveBonusSource returns the saving throw breakdown entry for this cover, or
+// nil if the cover grants no bonus to the given ability.
+func (r *CoverResult) SaveBonusSource(targetID string, ability abilities.Ability) *dnd5eEvents.SaveBonusSource {
+	if r == nil || r.Level.SaveBonus(ability) == 0 {
+		return nil
+	}
+	return &dnd5eEvents.SaveBonusSource{
+		SaveModifierSource: dnd5eEvents.SaveModifierSource{
+			Name:       "Cover",
+			SourceType: "cover",
+			SourceRef:  r.Level.Ref(),
+			EntityID:   targetID,
+		},
+		Bonus: r.Level.SaveBonus(ability),
+	}
+}
+
+// CalculateCoverInput identifies the source and target of an attack or effect.
+type CalculateCoverInput struct {
+	// SourceID is the attacker or caster. Ignored when Origin is set.
+	SourceID string
+
+	// Origin is the point an effect originates from (e.g. the center of a
+	// Fireball). When nil, the position of SourceID is used.
+	Origin *spatial.Position
+
+	// TargetID is the creature that may benefit from cover.
+	TargetID string
+}
+
+// Validate validates the input fields.
+func (i *CalculateCoverInput) Validate() error {
+	if i == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "CalculateCoverInput is nil")
+	}
+	if i.SourceID == "" && i.Origin == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "SourceID or Origin is required")
+	}
+	if i.TargetID == "" {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "TargetID is required")
+	}
+	return nil
+}
+
+// CalculateCover classifies the cover a target has against a source using the
+// room in context (see WithRoom).
+//
+// Every square on the line between source and target (exclusive of both ends)
+// is inspected. Each entity found there contributes cover: its CoverProvider
+// level if implemented, full cover if it blocks line of sight, otherwise half
+// cover. The most protective contribution wins.
+//
+// Returns CodeNotFound if no room is in context or either endpoint is not placed.
+func CalculateCover(ctx context.Context, input *CalculateCoverInput) (*CoverResult, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	room, err := getRoomFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var from spatial.Position
+	if input.Origin != nil {
+		from = *input.Origin
+	} else {
+		pos, found := room.GetEntityPosition(input.SourceID)
+		if !found {
+			return nil, rpgerr.Newf(rpgerr.CodeNotFound, "source %s not found in room", input.SourceID)
+		}
+		from = pos
+	}
+
+	to, found := room.GetEntityPosition(input.TargetID)
+	if !found {
+		return nil, rpgerr.Newf(rpgerr.CodeNotFound, "target %s not found in room", input.TargetID)
+	}
+
+	result := &CoverResult{Level: CoverNone}
+
+	line := room.GetLineOfSight(from, to)
+	for i := 1; i < len(line)-1; i++ {
+		for _, entity := range room.GetEntitiesAt(line[i]) {
+			if entity.GetID() == input.SourceID || entity.GetID() == input.TargetID {
+				continue
+			}
+
+			level := coverFromEntity(entity)
+			result.Sources = append(result.Sources, CoverSource{
+				EntityID: entity.GetID(),
+				Position: line[i],
+				Level:    level,
+			})
+			if level.rank() > result.Level.rank() {
+				result.Level = level
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// coverFromEntity determines the cover a single obstacle grants.
+func coverFromEntity(entity core.Entity) CoverLevel {
+	if provider, ok := entity.(CoverProvider); ok {
+		return provider.ProvidesCover()
+	}
+	if placeable, ok := entity.(spatial.Placeable); ok && placeable.BlocksLineOfSight() {
+		return CoverFull
+	}
+	return CoverHalf
+}
+
+// resolveAttackCover computes target cover for an attack when the context
+// carries a room with both combatants placed. Attacks resolved without spatial
+// state (no room, or unplaced combatants) simply have no cover.
+func resolveAttackCover(ctx context.Context, attackerID, targetID string) *CoverResult {
+	room, err := getRoomFromContext(ctx)
+	if err != nil {
+		return nil
+	}
+	if _, found := room.GetEntityPosition(attackerID); !found {
+		return nil
+	}
+	if _, found := room.GetEntityPosition(targetID); !found {
+		return nil
+	}
+
+	cover, err := CalculateCover(ctx, &CalculateCoverInput{SourceID: attackerID, TargetID: targetID})
+	if err != nil {
+		return nil
+	}
+	return cover
+}
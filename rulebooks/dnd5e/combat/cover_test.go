@@ -0,0 +1,250 @@
+package combat_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	mock_combat "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat/mock"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/weapons"
+	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
+)
+
+// wallSegment is an obstacle that blocks line of sight (full cover)
+type wallSegment struct {
+	id string
+}
+
+func (w *wallSegment) GetID() string            { return w.id }
+func (w *wallSegment) GetType() core.EntityType { return "wall" }
+func (w *wallSegment) GetSize() int             { return 1 }
+func (w *wallSegment) BlocksMovement() bool     { return true }
+func (w *wallSegment) BlocksLineOfSight() bool  { return true }
+
+// arrowSlit is an obstacle that declares its own cover level
+type arrowSlit struct {
+	id string
+}
+
+func (a *arrowSlit) GetID() string                    { return a.id }
+func (a *arrowSlit) GetType() core.EntityType         { return "obstacle" }
+func (a *arrowSlit) ProvidesCover() combat.CoverLevel { return combat.CoverThreeQuarters }
+
+type CoverTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	ctx      context.Context
+	eventBus events.EventBus
+	lookup   *mock_combat.MockCombatantLookup
+	room     *spatial.BasicRoom
+}
+
+func TestCoverSuite(t *testing.T) {
+	suite.Run(t, new(CoverTestSuite))
+}
+
+func (s *CoverTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.eventBus = events.NewEventBus()
+	s.lookup = mock_combat.NewMockCombatantLookup(s.ctrl)
+
+	grid := spatial.NewSquareGrid(spatial.SquareGridConfig{
+		Width:  10,
+		Height: 10,
+	})
+	s.room = spatial.NewBasicRoom(spatial.BasicRoomConfig{
+		ID:   "cover-room",
+		Type: "combat",
+		Grid: grid,
+	})
+
+	s.ctx = combat.WithRoom(context.Background(), s.room)
+	s.ctx = combat.WithCombatantLookup(s.ctx, s.lookup)
+
+	// Archer at (0,5), goblin at (6,5) - a straight line along Y=5
+	s.Require().NoError(s.room.PlaceEntity(&testCombatant{id: "archer", entityType: "character"},
+		spatial.Position{X: 0, Y: 5}))
+	s.Require().NoError(s.room.PlaceEntity(&testCombatant{id: "goblin", entityType: "monster"},
+		spatial.Position{X: 6, Y: 5}))
+}
+
+func (s *CoverTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *CoverTestSuite) calculate() *combat.CoverResult {
+	result, err := combat.CalculateCover(s.ctx, &combat.CalculateCoverInput{
+		SourceID: "archer",
+		TargetID: "goblin",
+	})
+	s.Require().NoError(err)
+	return result
+}
+
+func (s *CoverTestSuite) TestNoObstacles() {
+	result := s.calculate()
+
+	s.Equal(combat.CoverNone, result.Level)
+	s.Empty(result.Sources)
+	s.Nil(result.ACComponent())
+}
+
+func (s *CoverTestSuite) TestCreatureGrantsHalfCover() {
+	s.Require().NoError(s.room.PlaceEntity(&testCombatant{id: "ally", entityType: "character"},
+		spatial.Position{X: 3, Y: 5}))
+
+	result := s.calculate()
+
+	s.Equal(combat.CoverHalf, result.Level)
+	s.Require().Len(result.Sources, 1)
+	s.Equal("ally", result.Sources[0].EntityID)
+
+	component := result.ACComponent()
+	s.Require().NotNil(component)
+	s.Equal(2, component.Value)
+	s.Equal(combat.ACSourceCondition, component.Type)
+	s.Equal(refs.Conditions.HalfCover(), component.Source)
+}
+
+func (s *CoverTestSuite) TestCoverProviderDeclaresLevel() {
+	s.Require().NoError(s.room.PlaceEntity(&arrowSlit{id: "slit"}, spatial.Position{X: 5, Y: 5}))
+
+	result := s.calculate()
+
+	s.Equal(combat.CoverThreeQuarters, result.Level)
+	s.Equal(5, result.ACComponent().Value)
+}
+
+func (s *CoverTestSuite) TestMostProtectiveSourceWins() {
+	s.Require().NoError(s.room.PlaceEntity(&testCombatant{id: "ally", entityType: "character"},
+		spatial.Position{X: 2, Y: 5}))
+	s.Require().NoError(s.room.PlaceEntity(&arrowSlit{id: "slit"}, spatial.Position{X: 4, Y: 5}))
+
+	result := s.calculate()
+
+	s.Equal(combat.CoverThreeQuarters, result.Level)
+	s.Len(result.Sources, 2, "every obstacle is recorded even though cover doesn't stack")
+}
+
+func (s *CoverTestSuite) TestSightBlockingObstacleGrantsFullCover() {
+	s.Require().NoError(s.room.PlaceEntity(&wallSegment{id: "wall"}, spatial.Position{X: 3, Y: 5}))
+
+	result := s.calculate()
+
+	s.Equal(combat.CoverFull, result.Level)
+	s.Nil(result.ACComponent(), "full cover has no AC bonus - the target can't be attacked")
+}
+
+func (s *CoverTestSuite) TestOriginOverridesSourcePosition() {
+	s.Require().NoError(s.room.PlaceEntity(&testCombatant{id: "ally", entityType: "character"},
+		spatial.Position{X: 3, Y: 5}))
+
+	// An effect centered at (6,2) approaches the goblin from above, clear of the ally
+	origin := spatial.Position{X: 6, Y: 2}
+	result, err := combat.CalculateCover(s.ctx, &combat.CalculateCoverInput{
+		Origin:   &origin,
+		TargetID: "goblin",
+	})
+	s.Require().NoError(err)
+	s.Equal(combat.CoverNone, result.Level)
+}
+
+func (s *CoverTestSuite) TestSaveBonusOnlyForDexterity() {
+	s.Require().NoError(s.room.PlaceEntity(&testCombatant{id: "ally", entityType: "character"},
+		spatial.Position{X: 3, Y: 5}))
+
+	result := s.calculate()
+
+	dexSource := result.SaveBonusSource("goblin", abilities.DEX)
+	s.Require().NotNil(dexSource)
+	s.Equal(2, dexSource.Bonus)
+	s.Equal(refs.Conditions.HalfCover(), dexSource.SourceRef)
+
+	s.Nil(result.SaveBonusSource("goblin", abilities.WIS))
+}
+
+func (s *CoverTestSuite) TestRequiresRoom() {
+	_, err := combat.CalculateCover(context.Background(), &combat.CalculateCoverInput{
+		SourceID: "archer",
+		TargetID: "goblin",
+	})
+	s.Require().Error(err)
+	s.Equal(rpgerr.CodeNotFound, rpgerr.GetCode(err))
+}
+
+func (s *CoverTestSuite) setupAttack() (*weapons.Weapon, *mock_dice.MockRoller) {
+	archer := mock_combat.NewMockCombatant(s.ctrl)
+	archer.EXPECT().GetID().Return("archer").AnyTimes()
+	archer.EXPECT().AbilityScores().Return(shared.AbilityScores{
+		abilities.STR: 10,
+		abilities.DEX: 16, // +3
+	}).AnyTimes()
+	archer.EXPECT().ProficiencyBonus().Return(2).AnyTimes()
+
+	goblin := mock_combat.NewMockCombatant(s.ctrl)
+	goblin.EXPECT().GetID().Return("goblin").AnyTimes()
+	goblin.EXPECT().AC().Return(15).AnyTimes()
+
+	s.lookup.EXPECT().Get("archer").Return(archer, nil).AnyTimes()
+	s.lookup.EXPECT().Get("goblin").Return(goblin, nil).AnyTimes()
+
+	shortbow := &weapons.Weapon{
+		ID:         weapons.Shortbow,
+		Name:       "Shortbow",
+		Category:   weapons.CategorySimpleRanged,
+		Damage:     "1d6",
+		DamageType: damage.Piercing,
+		Range:      &weapons.Range{Normal: 80, Long: 320},
+	}
+
+	return shortbow, mock_dice.NewMockRoller(s.ctrl)
+}
+
+func (s *CoverTestSuite) TestAttackIncludesCoverInTargetAC() {
+	s.Require().NoError(s.room.PlaceEntity(&testCombatant{id: "ally", entityType: "character"},
+		spatial.Position{X: 3, Y: 5}))
+	shortbow, roller := s.setupAttack()
+
+	// 11 + DEX 3 + prof 2 = 16: hits AC 15 but not AC 17 with half cover
+	roller.EXPECT().Roll(gomock.Any(), 20).Return(11, nil)
+
+	result, err := combat.ResolveAttack(s.ctx, &combat.AttackInput{
+		AttackerID: "archer",
+		TargetID:   "goblin",
+		Weapon:     shortbow,
+		EventBus:   s.eventBus,
+		Roller:     roller,
+	})
+	s.Require().NoError(err)
+
+	s.Equal(17, result.TargetAC)
+	s.False(result.Hit)
+	s.Require().NotNil(result.Cover)
+	s.Equal(combat.CoverHalf, result.Cover.Level)
+}
+
+func (s *CoverTestSuite) TestAttackAgainstFullCoverIsRejected() {
+	s.Require().NoError(s.room.PlaceEntity(&wallSegment{id: "wall"}, spatial.Position{X: 3, Y: 5}))
+	shortbow, roller := s.setupAttack()
+
+	_, err := combat.ResolveAttack(s.ctx, &combat.AttackInput{
+		AttackerID: "archer",
+		TargetID:   "goblin",
+		Weapon:     shortbow,
+		EventBus:   s.eventBus,
+		Roller:     roller,
+	})
+	s.Require().Error(err)
+	s.Equal(rpgerr.CodeInvalidTarget, rpgerr.GetCode(err))
+}
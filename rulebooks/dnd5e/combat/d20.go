@@ -0,0 +1,86 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package combat
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/rpg-toolkit/dice"
+)
+
+// D20Input configures a d20 roll for an attack roll, ability check, or saving throw.
+type D20Input struct {
+	// Advantage rolls two d20s and keeps the higher.
+	Advantage bool
+
+	// Disadvantage rolls two d20s and keeps the lower.
+	// Advantage and disadvantage cancel out, leaving a single d20.
+	Disadvantage bool
+
+	// RerollOnes rerolls a die that comes up 1, once, and the new roll must be
+	// used (Halfling Lucky). With advantage or disadvantage only one die is rerolled.
+	RerollOnes bool
+}
+
+// D20Result is the outcome of a d20 roll.
+type D20Result struct {
+	// Roll is the d20 result used.
+	Roll int
+
+	// Rolls are the dice the result was chosen from, after any reroll.
+	Rolls []int
+
+	// Rerolled is true when a natural 1 was rerolled.
+	Rerolled bool
+}
+
+// RollD20 rolls the d20 for an attack roll, ability check, or saving throw.
+func RollD20(ctx context.Context, roller dice.Roller, input D20Input) (*D20Result, error) {
+	count := 1
+	if input.Advantage != input.Disadvantage {
+		count = 2
+	}
+
+	var rolls []int
+	if count == 1 {
+		roll, err := roller.Roll(ctx, 20)
+		if err != nil {
+			return nil, err
+		}
+		rolls = []int{roll}
+	} else {
+		var err error
+		rolls, err = roller.RollN(ctx, count, 20)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &D20Result{Rolls: rolls}
+	if input.RerollOnes {
+		for i, roll := range rolls {
+			if roll != 1 {
+				continue
+			}
+			reroll, err := roller.Roll(ctx, 20)
+			if err != nil {
+				return nil, err
+			}
+			rolls[i] = reroll
+			result.Rerolled = true
+			break
+		}
+	}
+
+	result.Roll = rolls[0]
+	if count == 2 {
+		if input.Advantage {
+			result.Roll = max(rolls[0], rolls[1])
+		} else {
+			result.Roll = min(rolls[0], rolls[1])
+		}
+	}
+
+	return result, nil
+}
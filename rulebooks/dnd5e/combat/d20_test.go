@@ -0,0 +1,80 @@
+package combat_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+)
+
+type D20TestSuite struct {
+	suite.Suite
+	ctrl   *gomock.Controller
+	ctx    context.Context
+	roller *mock_dice.MockRoller
+}
+
+func TestD20Suite(t *testing.T) {
+	suite.Run(t, new(D20TestSuite))
+}
+
+func (s *D20TestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.ctx = context.Background()
+	s.roller = mock_dice.NewMockRoller(s.ctrl)
+}
+
+func (s *D20TestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *D20TestSuite) TestStraightRoll() {
+	s.roller.EXPECT().Roll(gomock.Any(), 20).Return(1, nil)
+
+	result, err := combat.RollD20(s.ctx, s.roller, combat.D20Input{})
+	s.Require().NoError(err)
+	s.Equal(1, result.Roll)
+	s.False(result.Rerolled)
+}
+
+func (s *D20TestSuite) TestAdvantageAndDisadvantage() {
+	s.roller.EXPECT().RollN(gomock.Any(), 2, 20).Return([]int{6, 15}, nil)
+	result, err := combat.RollD20(s.ctx, s.roller, combat.D20Input{Advantage: true})
+	s.Require().NoError(err)
+	s.Equal(15, result.Roll)
+
+	s.roller.EXPECT().RollN(gomock.Any(), 2, 20).Return([]int{6, 15}, nil)
+	result, err = combat.RollD20(s.ctx, s.roller, combat.D20Input{Disadvantage: true})
+	s.Require().NoError(err)
+	s.Equal(6, result.Roll)
+
+	s.roller.EXPECT().Roll(gomock.Any(), 20).Return(9, nil)
+	result, err = combat.RollD20(s.ctx, s.roller, combat.D20Input{Advantage: true, Disadvantage: true})
+	s.Require().NoError(err)
+	s.Equal(9, result.Roll, "advantage and disadvantage cancel")
+}
+
+func (s *D20TestSuite) TestRerollOnes() {
+	gomock.InOrder(
+		s.roller.EXPECT().Roll(gomock.Any(), 20).Return(1, nil),
+		s.roller.EXPECT().Roll(gomock.Any(), 20).Return(1, nil),
+	)
+	result, err := combat.RollD20(s.ctx, s.roller, combat.D20Input{RerollOnes: true})
+	s.Require().NoError(err)
+	s.Equal(1, result.Roll, "the new roll must be used")
+	s.True(result.Rerolled)
+
+	// Only one die is rerolled even when both show 1
+	gomock.InOrder(
+		s.roller.EXPECT().RollN(gomock.Any(), 2, 20).Return([]int{1, 1}, nil),
+		s.roller.EXPECT().Roll(gomock.Any(), 20).Return(17, nil),
+	)
+	result, err = combat.RollD20(s.ctx, s.roller, combat.D20Input{Advantage: true, RerollOnes: true})
+	s.Require().NoError(err)
+	s.Equal(17, result.Roll)
+	s.Equal([]int{17, 1}, result.Rolls)
+}
@@ -176,9 +176,9 @@ func DealDamage(ctx context.Context, input *DealDamageInput) (*DealDamageOutput,
 		IsCritical: input.IsCritical,
 	})
 
-	// NOTIFY: publish DamageReceivedEvent for reactions
+	// NOTIFY: publish DamageReceivedEvent for reactions, caused by the damage chain
 	damageTopic := dnd5eEvents.DamageReceivedTopic.On(input.EventBus)
-	err = damageTopic.Publish(ctx, dnd5eEvents.DamageReceivedEvent{
+	err = damageTopic.Publish(events.WithCause(ctx, resolveOutput.ChainEvent), dnd5eEvents.DamageReceivedEvent{
 		TargetID:   targetID,
 		SourceID:   input.AttackerID,
 		Amount:     applyResult.TotalDamage,
@@ -252,6 +252,13 @@ type ResolveDamageOutput struct {
 	// AbilityUsed is the ability that was used for the attack after chain modifiers.
 	// Conditions like Martial Arts may change this (e.g., STR -> DEX).
 	AbilityUsed abilities.Ability
+
+	// Trace records every change damage chain modifiers made, in order
+	Trace []dnd5eEvents.ChainTraceStep
+
+	// ChainEvent identifies the published damage chain event. Events that
+	// follow from the damage are linked to it with events.WithCause.
+	ChainEvent events.EventMeta
 }
 
 // ResolveDamage processes damage through the chain without applying HP changes.
@@ -285,10 +292,11 @@ func ResolveDamage(ctx context.Context, input *ResolveDamageInput) (*ResolveDama
 		WeaponRef:    input.WeaponRef,
 	}
 
-	damageChain := events.NewStagedChain[*dnd5eEvents.DamageChainEvent](ModifierStages)
+	damageChain := newTracedDamageChain(events.NewStagedChain[*dnd5eEvents.DamageChainEvent](ModifierStages))
 	damages := dnd5eEvents.DamageChain.On(input.EventBus)
 
-	modifiedChain, err := damages.PublishWithChain(ctx, damageEvent, damageChain)
+	chainCtx, chainMeta := events.BeginEvent(ctx)
+	modifiedChain, err := damages.PublishWithChain(chainCtx, damageEvent, damageChain)
 	if err != nil {
 		return nil, rpgerr.Wrap(err, "failed to publish damage chain")
 	}
@@ -312,6 +320,8 @@ func ResolveDamage(ctx context.Context, input *ResolveDamageInput) (*ResolveDama
 		FinalInstances:  finalInstances,
 		FinalComponents: finalEvent.Components,
 		AbilityUsed:     finalEvent.AbilityUsed,
+		Trace:           finalEvent.Trace,
+		ChainEvent:      chainMeta,
 	}, nil
 }
 
@@ -168,6 +168,50 @@ func (s *DealDamageTestSuite) TestDealDamageBasic() {
 	s.Equal(damage.Slashing, receivedEvent.DamageType)
 }
 
+func (s *DealDamageTestSuite) TestDamageTracesBackToStrike() {
+	tracker := events.NewCausalityTracker()
+	s.Require().NoError(events.Use(s.eventBus, tracker.Middleware()))
+
+	target := &mockCombatant{id: "hero-1", hitPoints: 60, maxHitPoints: 60}
+
+	// The game resolves a strike in its handler, under the handler's context
+	_, err := dnd5eEvents.StrikeExecutedTopic.On(s.eventBus).Subscribe(s.ctx,
+		func(ctx context.Context, e dnd5eEvents.StrikeExecutedEvent) error {
+			_, err := combat.DealDamage(ctx, &combat.DealDamageInput{
+				Target:     target,
+				AttackerID: e.AttackerID,
+				Source:     combat.DamageSourceAttack,
+				Instances:  []combat.DamageInstanceInput{{Amount: 47, Type: damage.Slashing}},
+				EventBus:   s.eventBus,
+			})
+			return err
+		})
+	s.Require().NoError(err)
+
+	var damageID string
+	_, err = dnd5eEvents.DamageReceivedTopic.On(s.eventBus).Subscribe(s.ctx,
+		func(ctx context.Context, _ dnd5eEvents.DamageReceivedEvent) error {
+			meta, _ := events.EventMetaFromContext(ctx)
+			damageID = meta.ID
+			return nil
+		})
+	s.Require().NoError(err)
+
+	s.Require().NoError(dnd5eEvents.StrikeExecutedTopic.On(s.eventBus).Publish(s.ctx, dnd5eEvents.StrikeExecutedEvent{
+		AttackerID: "ogre-1",
+		TargetID:   "hero-1",
+	}))
+
+	lineage := tracker.Lineage(damageID)
+	s.Require().Len(lineage, 3)
+	s.Equal(events.Topic("dnd5e.combat.damage.received"), lineage[0].Meta.Topic)
+	s.Equal(events.Topic("dnd5e.combat.damage.chain"), lineage[1].Meta.Topic)
+	s.Equal(events.Topic("dnd5e.action.strike.executed"), lineage[2].Meta.Topic)
+	s.Equal(47, lineage[0].Event.(dnd5eEvents.DamageReceivedEvent).Amount)
+	s.Equal("ogre-1", lineage[2].Event.(dnd5eEvents.StrikeExecutedEvent).AttackerID)
+	s.Equal(lineage[2].Meta.ID, lineage[0].Meta.CorrelationID)
+}
+
 func (s *DealDamageTestSuite) TestDealDamageMultipleInstances() {
 	target := &mockCombatant{
 		id:           "hero-1",
@@ -0,0 +1,135 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package combat
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// EconomyRestriction describes what active conditions take away from a
+// combatant's action economy.
+type EconomyRestriction struct {
+	// NoActions prevents actions and bonus actions (incapacitated).
+	NoActions bool `json:"no_actions,omitempty"`
+
+	// NoReactions prevents reactions (incapacitated).
+	NoReactions bool `json:"no_reactions,omitempty"`
+
+	// NoMovement reduces speed to 0 (grappled, restrained, stunned, etc.).
+	NoMovement bool `json:"no_movement,omitempty"`
+
+	// NoSpellcasting prevents casting spells (Wild Shape).
+	NoSpellcasting bool `json:"no_spellcasting,omitempty"`
+
+	// Sources are the conditions imposing the restriction.
+	Sources []*core.Ref `json:"sources,omitempty"`
+}
+
+// IsRestricted returns true if the restriction removes anything.
+func (r EconomyRestriction) IsRestricted() bool {
+	return r.NoActions || r.NoReactions || r.NoMovement || r.NoSpellcasting
+}
+
+// describe names the conditions imposing the restriction for error messages.
+func (r EconomyRestriction) describe() string {
+	names := make([]string, 0, len(r.Sources))
+	for _, source := range r.Sources {
+		names = append(names, source.ID)
+	}
+	return strings.Join(names, ", ")
+}
+
+// ConditionEconomyRestriction returns the restriction a single condition imposes.
+//
+// Per D&D 5e:
+//   - Incapacitated: no actions or reactions
+//   - Paralyzed (including by Hold Person), Petrified, Stunned, Unconscious:
+//     incapacitated and can't move
+//   - Grappled, Restrained: speed becomes 0
+//   - Turned: can't take reactions (Turn Undead)
+//   - Wild Shaped: can't cast spells (Wild Shape)
+//
+// Conditions with no action economy effect return an empty restriction.
+func ConditionEconomyRestriction(ref *core.Ref) EconomyRestriction {
+	if ref == nil {
+		return EconomyRestriction{}
+	}
+
+	var r EconomyRestriction
+	switch ref.ID {
+	case refs.Conditions.Incapacitated().ID:
+		r.NoActions = true
+		r.NoReactions = true
+	case refs.Conditions.Paralyzed().ID,
+		refs.Conditions.HoldPerson().ID,
+		refs.Conditions.Petrified().ID,
+		refs.Conditions.Stunned().ID,
+		refs.Conditions.Unconscious().ID:
+		r.NoActions = true
+		r.NoReactions = true
+		r.NoMovement = true
+	case refs.Conditions.Grappled().ID,
+		refs.Conditions.Restrained().ID:
+		r.NoMovement = true
+	case refs.Conditions.Turned().ID:
+		r.NoReactions = true
+	case refs.Conditions.WildShaped().ID:
+		r.NoSpellcasting = true
+	default:
+		return EconomyRestriction{}
+	}
+
+	r.Sources = []*core.Ref{ref}
+	return r
+}
+
+// CombineConditionRestrictions merges the restrictions of every condition.
+func CombineConditionRestrictions(conditionRefs []*core.Ref) EconomyRestriction {
+	var combined EconomyRestriction
+	for _, ref := range conditionRefs {
+		r := ConditionEconomyRestriction(ref)
+		if !r.IsRestricted() {
+			continue
+		}
+		combined.NoActions = combined.NoActions || r.NoActions
+		combined.NoReactions = combined.NoReactions || r.NoReactions
+		combined.NoMovement = combined.NoMovement || r.NoMovement
+		combined.NoSpellcasting = combined.NoSpellcasting || r.NoSpellcasting
+		combined.Sources = append(combined.Sources, r.Sources...)
+	}
+	return combined
+}
+
+// ConditionHolder is implemented by combatants that track their active conditions.
+// Character and Monster both implement it.
+type ConditionHolder interface {
+	// GetConditions returns all active conditions
+	GetConditions() []dnd5eEvents.ConditionBehavior
+}
+
+// ConditionRefs extracts the ref of each condition from its JSON form.
+// Conditions that can't be serialized or carry no ref are skipped.
+func ConditionRefs(conditions []dnd5eEvents.ConditionBehavior) []*core.Ref {
+	result := make([]*core.Ref, 0, len(conditions))
+	for _, condition := range conditions {
+		jsonData, err := condition.ToJSON()
+		if err != nil {
+			continue
+		}
+
+		var refData struct {
+			Ref *core.Ref `json:"ref"`
+		}
+		if err := json.Unmarshal(jsonData, &refData); err != nil || refData.Ref == nil {
+			continue
+		}
+		result = append(result, refData.Ref)
+	}
+	return result
+}
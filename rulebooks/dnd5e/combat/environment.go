@@ -0,0 +1,400 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package combat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
+)
+
+// EnvironmentTag is a flag describing the environment at a location.
+// Tags use the same strings game servers store on room and zone data.
+type EnvironmentTag string
+
+const (
+	// EnvironmentUnderwater marks a location fully submerged in water
+	EnvironmentUnderwater EnvironmentTag = "underwater"
+
+	// EnvironmentDarkness marks a heavily obscured location with no light
+	EnvironmentDarkness EnvironmentTag = "darkness"
+
+	// EnvironmentDifficultTerrain marks rubble, undergrowth, steep stairs and the
+	// like, where every foot of movement costs 1 extra foot
+	EnvironmentDifficultTerrain EnvironmentTag = "difficult_terrain"
+)
+
+// EnvironmentProvider reports the environment tags at an entity's location.
+type EnvironmentProvider interface {
+	// TagsFor returns the environment tags where the entity currently is
+	TagsFor(ctx context.Context, entityID string) []EnvironmentTag
+}
+
+// PositionEnvironmentProvider is implemented by providers that can also report
+// the tags at a position, for rules about where a creature is moving to.
+// Difficult terrain is only applied by providers that implement it.
+type PositionEnvironmentProvider interface {
+	// TagsAt returns the environment tags at the position
+	TagsAt(ctx context.Context, pos spatial.Position) []EnvironmentTag
+}
+
+// EnvironmentZone is an area of a room with its own environment tags
+// (e.g. a flooded pit or an unlit alcove).
+type EnvironmentZone struct {
+	// ID identifies the zone
+	ID string
+
+	// Center is the middle of the zone in room coordinates
+	Center spatial.Position
+
+	// Radius is the zone's extent from Center in grid units
+	Radius float64
+
+	// Tags apply to entities inside the zone
+	Tags []EnvironmentTag
+}
+
+// RoomEnvironment is an EnvironmentProvider toggled per room and zone.
+// Tags apply to every entity in the room; zone tags apply only to entities
+// inside the zone, located through the Room in the context (see WithRoom).
+type RoomEnvironment struct {
+	// Tags apply throughout the room
+	Tags []EnvironmentTag
+
+	// Zones add tags to parts of the room
+	Zones []EnvironmentZone
+}
+
+// Ensure RoomEnvironment implements EnvironmentProvider and PositionEnvironmentProvider
+var (
+	_ EnvironmentProvider         = (*RoomEnvironment)(nil)
+	_ PositionEnvironmentProvider = (*RoomEnvironment)(nil)
+)
+
+// TagsFor returns the room's tags plus the tags of every zone containing the entity.
+// Zone tags are skipped when there is no room in the context or the entity isn't placed.
+func (r *RoomEnvironment) TagsFor(ctx context.Context, entityID string) []EnvironmentTag {
+	if len(r.Zones) == 0 {
+		return append([]EnvironmentTag(nil), r.Tags...)
+	}
+
+	room, err := getRoomFromContext(ctx)
+	if err != nil {
+		return append([]EnvironmentTag(nil), r.Tags...)
+	}
+	pos, found := room.GetEntityPosition(entityID)
+	if !found {
+		return append([]EnvironmentTag(nil), r.Tags...)
+	}
+	return r.tagsAt(room, pos)
+}
+
+// TagsAt returns the room's tags plus the tags of every zone containing the position.
+// Zone tags are skipped when there is no room in the context.
+func (r *RoomEnvironment) TagsAt(ctx context.Context, pos spatial.Position) []EnvironmentTag {
+	room, err := getRoomFromContext(ctx)
+	if err != nil {
+		return append([]EnvironmentTag(nil), r.Tags...)
+	}
+	return r.tagsAt(room, pos)
+}
+
+// tagsAt returns the room's tags plus the tags of every zone containing pos
+func (r *RoomEnvironment) tagsAt(room spatial.Room, pos spatial.Position) []EnvironmentTag {
+	tags := append([]EnvironmentTag(nil), r.Tags...)
+	for _, zone := range r.Zones {
+		if room.GetGrid().Distance(zone.Center, pos) <= zone.Radius {
+			tags = append(tags, zone.Tags...)
+		}
+	}
+	return tags
+}
+
+// underwaterMeleeWeapons are the melee weapons that don't suffer disadvantage underwater
+var underwaterMeleeWeapons = []*core.Ref{
+	refs.Weapons.Dagger(),
+	refs.Weapons.Javelin(),
+	refs.Weapons.Shortsword(),
+	refs.Weapons.Spear(),
+	refs.Weapons.Trident(),
+}
+
+// underwaterRangedWeapons are the ranged weapons that don't suffer disadvantage underwater
+var underwaterRangedWeapons = []*core.Ref{
+	refs.Weapons.LightCrossbow(),
+	refs.Weapons.HeavyCrossbow(),
+	refs.Weapons.HandCrossbow(),
+	refs.Weapons.Net(),
+	refs.Weapons.Javelin(),
+	refs.Weapons.Spear(),
+	refs.Weapons.Trident(),
+	refs.Weapons.Dart(),
+}
+
+// EnvironmentalModifiersConfig configures the environmental modifiers layer.
+type EnvironmentalModifiersConfig struct {
+	// Provider reports the environment tags at each entity's location
+	Provider EnvironmentProvider
+
+	// HasSwimSpeed reports whether a creature has a swimming speed (optional).
+	// Swimmers ignore the underwater melee penalty.
+	HasSwimSpeed func(entityID string) bool
+
+	// HasDarkvision reports whether a creature can see in darkness (optional)
+	HasDarkvision func(entityID string) bool
+}
+
+// Validate validates the config.
+func (c *EnvironmentalModifiersConfig) Validate() error {
+	if c == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "EnvironmentalModifiersConfig is nil")
+	}
+	if c.Provider == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "Provider is required")
+	}
+	return nil
+}
+
+// EnvironmentalModifiers applies underwater and darkness rules through the
+// attack and damage chains:
+//   - Underwater melee attacks have disadvantage unless the attacker has a swim
+//     speed or uses a dagger, javelin, shortsword, spear, or trident
+//   - Underwater ranged attacks have disadvantage unless made with a crossbow,
+//     a net, or a thrown javelin, spear, trident, or dart
+//   - Creatures underwater have resistance to fire damage
+//   - Attacks against a target in darkness have disadvantage, and attacks from
+//     an attacker in darkness have advantage, unless the other creature has darkvision
+//   - Moving into difficult terrain costs 1 extra foot per foot moved, when the
+//     provider implements PositionEnvironmentProvider
+//
+// Apply it once per bus; it reads each entity's tags from the provider per event.
+// For graded light, darkvision ranges and hidden creatures, use VisionModifiers
+// instead of the darkness tag.
+type EnvironmentalModifiers struct {
+	provider        EnvironmentProvider
+	hasSwimSpeed    func(entityID string) bool
+	hasDarkvision   func(entityID string) bool
+	bus             events.EventBus
+	subscriptionIDs []string
+}
+
+// NewEnvironmentalModifiers creates the environmental modifiers layer from config.
+func NewEnvironmentalModifiers(config *EnvironmentalModifiersConfig) (*EnvironmentalModifiers, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &EnvironmentalModifiers{
+		provider:      config.Provider,
+		hasSwimSpeed:  config.HasSwimSpeed,
+		hasDarkvision: config.HasDarkvision,
+	}, nil
+}
+
+// IsApplied returns true if the modifiers are subscribed to a bus.
+func (m *EnvironmentalModifiers) IsApplied() bool {
+	return m.bus != nil
+}
+
+// Apply subscribes the modifiers to AttackChain, DamageChain and MovementChain.
+func (m *EnvironmentalModifiers) Apply(ctx context.Context, bus events.EventBus) error {
+	if m.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "environmental modifiers already applied")
+	}
+	m.bus = bus
+
+	attackSubID, err := dnd5eEvents.AttackChain.On(bus).SubscribeWithChain(ctx, m.onAttackChain)
+	if err != nil {
+		m.bus = nil
+		return rpgerr.Wrap(err, "failed to subscribe to attack chain")
+	}
+	m.subscriptionIDs = append(m.subscriptionIDs, attackSubID)
+
+	damageSubID, err := dnd5eEvents.DamageChain.On(bus).SubscribeWithChain(ctx, m.onDamageChain)
+	if err != nil {
+		_ = m.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to damage chain")
+	}
+	m.subscriptionIDs = append(m.subscriptionIDs, damageSubID)
+
+	movementSubID, err := dnd5eEvents.MovementChain.On(bus).SubscribeWithChain(ctx, m.onMovementChain)
+	if err != nil {
+		_ = m.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to movement chain")
+	}
+	m.subscriptionIDs = append(m.subscriptionIDs, movementSubID)
+
+	return nil
+}
+
+// Remove unsubscribes the modifiers from all events.
+func (m *EnvironmentalModifiers) Remove(ctx context.Context, bus events.EventBus) error {
+	if m.bus == nil {
+		return nil // Not applied, nothing to remove
+	}
+
+	total := len(m.subscriptionIDs)
+	var errs []error
+	for _, subID := range m.subscriptionIDs {
+		if err := bus.Unsubscribe(ctx, subID); err != nil {
+			errs = append(errs, fmt.Errorf("unsubscribe %s: %w", subID, err))
+		}
+	}
+
+	m.subscriptionIDs = nil
+	m.bus = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unsubscribe %d/%d subscriptions: %w", len(errs), total, errors.Join(errs...))
+	}
+	return nil
+}
+
+// hasTag reports whether the entity's location carries the tag.
+func (m *EnvironmentalModifiers) hasTag(ctx context.Context, entityID string, tag EnvironmentTag) bool {
+	return slices.Contains(m.provider.TagsFor(ctx, entityID), tag)
+}
+
+// canSeeInDarkness reports whether the creature has darkvision.
+func (m *EnvironmentalModifiers) canSeeInDarkness(entityID string) bool {
+	return m.hasDarkvision != nil && m.hasDarkvision(entityID)
+}
+
+// underwaterPenalty reports whether an underwater attack suffers disadvantage.
+func (m *EnvironmentalModifiers) underwaterPenalty(event dnd5eEvents.AttackChainEvent) bool {
+	if event.IsMelee {
+		if m.hasSwimSpeed != nil && m.hasSwimSpeed(event.AttackerID) {
+			return false
+		}
+		return !containsRef(underwaterMeleeWeapons, event.WeaponRef)
+	}
+	return !containsRef(underwaterRangedWeapons, event.WeaponRef)
+}
+
+// onAttackChain adds underwater and darkness advantage/disadvantage.
+func (m *EnvironmentalModifiers) onAttackChain(
+	ctx context.Context,
+	event dnd5eEvents.AttackChainEvent,
+	c chain.Chain[dnd5eEvents.AttackChainEvent],
+) (chain.Chain[dnd5eEvents.AttackChainEvent], error) {
+	var advantage, disadvantage []dnd5eEvents.AttackModifierSource
+
+	if m.hasTag(ctx, event.AttackerID, EnvironmentUnderwater) && m.underwaterPenalty(event) {
+		disadvantage = append(disadvantage, dnd5eEvents.AttackModifierSource{
+			SourceRef: refs.Conditions.Underwater(),
+			SourceID:  event.AttackerID,
+			Reason:    "underwater",
+		})
+	}
+	if m.hasTag(ctx, event.TargetID, EnvironmentDarkness) && !m.canSeeInDarkness(event.AttackerID) {
+		disadvantage = append(disadvantage, dnd5eEvents.AttackModifierSource{
+			SourceRef: refs.Conditions.Darkness(),
+			SourceID:  event.TargetID,
+			Reason:    "target unseen in darkness",
+		})
+	}
+	if m.hasTag(ctx, event.AttackerID, EnvironmentDarkness) && !m.canSeeInDarkness(event.TargetID) {
+		advantage = append(advantage, dnd5eEvents.AttackModifierSource{
+			SourceRef: refs.Conditions.Darkness(),
+			SourceID:  event.AttackerID,
+			Reason:    "attacker unseen in darkness",
+		})
+	}
+
+	if len(advantage) == 0 && len(disadvantage) == 0 {
+		return c, nil
+	}
+
+	modifyAttack := func(_ context.Context, e dnd5eEvents.AttackChainEvent) (dnd5eEvents.AttackChainEvent, error) {
+		e.AdvantageSources = append(e.AdvantageSources, advantage...)
+		e.DisadvantageSources = append(e.DisadvantageSources, disadvantage...)
+		return e, nil
+	}
+	if err := c.Add(StageConditions, "environment", modifyAttack); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to add environmental modifiers for attacker %s", event.AttackerID)
+	}
+
+	return c, nil
+}
+
+// onDamageChain adds fire resistance for targets underwater.
+func (m *EnvironmentalModifiers) onDamageChain(
+	ctx context.Context,
+	event *dnd5eEvents.DamageChainEvent,
+	c chain.Chain[*dnd5eEvents.DamageChainEvent],
+) (chain.Chain[*dnd5eEvents.DamageChainEvent], error) {
+	hasFire := slices.ContainsFunc(event.Components, func(component dnd5eEvents.DamageComponent) bool {
+		return component.DamageType == damage.Fire && component.Multiplier == 0
+	})
+	if !hasFire || !m.hasTag(ctx, event.TargetID, EnvironmentUnderwater) {
+		return c, nil
+	}
+
+	addResistance := func(_ context.Context, e *dnd5eEvents.DamageChainEvent) (*dnd5eEvents.DamageChainEvent, error) {
+		e.Components = append(e.Components, dnd5eEvents.DamageComponent{
+			Source:     dnd5eEvents.DamageSourceCondition,
+			SourceRef:  refs.Conditions.Underwater(),
+			DamageType: damage.Fire,
+			Multiplier: 0.5,
+		})
+		return e, nil
+	}
+	if err := c.Add(StageFinal, "underwater_fire_resistance", addResistance); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to add underwater fire resistance for target %s", event.TargetID)
+	}
+
+	return c, nil
+}
+
+// onMovementChain adds the difficult terrain cost to steps into difficult terrain.
+// Per D&D 5e the cost stacks with crawling and squeezing.
+func (m *EnvironmentalModifiers) onMovementChain(
+	ctx context.Context,
+	event *dnd5eEvents.MovementChainEvent,
+	c chain.Chain[*dnd5eEvents.MovementChainEvent],
+) (chain.Chain[*dnd5eEvents.MovementChainEvent], error) {
+	provider, ok := m.provider.(PositionEnvironmentProvider)
+	if !ok {
+		return c, nil
+	}
+	to := spatial.Position{X: event.ToPosition.X, Y: event.ToPosition.Y}
+	if !slices.Contains(provider.TagsAt(ctx, to), EnvironmentDifficultTerrain) {
+		return c, nil
+	}
+
+	addCost := func(_ context.Context, e *dnd5eEvents.MovementChainEvent) (*dnd5eEvents.MovementChainEvent, error) {
+		e.ExtraCostSources = append(e.ExtraCostSources, dnd5eEvents.MovementCostSource{
+			MovementModifierSource: dnd5eEvents.MovementModifierSource{
+				Name:       "Difficult Terrain",
+				SourceType: "environment",
+				SourceRef:  refs.Conditions.DifficultTerrain(),
+			},
+			ExtraFeetPerFoot: 1,
+		})
+		return e, nil
+	}
+	if err := c.Add(StageConditions, "difficult_terrain", addCost); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to add difficult terrain cost for entity %s", event.EntityID)
+	}
+
+	return c, nil
+}
+
+// containsRef reports whether ref matches one of the refs by ID.
+func containsRef(list []*core.Ref, ref *core.Ref) bool {
+	if ref == nil {
+		return false
+	}
+	return slices.ContainsFunc(list, func(r *core.Ref) bool { return r.ID == ref.ID })
+}
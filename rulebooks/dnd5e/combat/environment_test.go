@@ -0,0 +1,280 @@
+package combat_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
+)
+
+// tagsByEntity is an EnvironmentProvider with fixed tags per entity
+type tagsByEntity map[string][]combat.EnvironmentTag
+
+func (t tagsByEntity) TagsFor(_ context.Context, entityID string) []combat.EnvironmentTag {
+	return t[entityID]
+}
+
+type EnvironmentTestSuite struct {
+	suite.Suite
+	ctx       context.Context
+	eventBus  events.EventBus
+	tags      tagsByEntity
+	modifiers *combat.EnvironmentalModifiers
+}
+
+func TestEnvironmentSuite(t *testing.T) {
+	suite.Run(t, new(EnvironmentTestSuite))
+}
+
+func (s *EnvironmentTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.eventBus = events.NewEventBus()
+	s.tags = tagsByEntity{}
+
+	var err error
+	s.modifiers, err = combat.NewEnvironmentalModifiers(&combat.EnvironmentalModifiersConfig{
+		Provider:      s.tags,
+		HasSwimSpeed:  func(entityID string) bool { return entityID == "sahuagin" },
+		HasDarkvision: func(entityID string) bool { return entityID == "drow" },
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(s.modifiers.Apply(s.ctx, s.eventBus))
+}
+
+func (s *EnvironmentTestSuite) SetupSubTest() {
+	s.SetupTest()
+}
+
+func (s *EnvironmentTestSuite) attack(event dnd5eEvents.AttackChainEvent) dnd5eEvents.AttackChainEvent {
+	attackChain := events.NewStagedChain[dnd5eEvents.AttackChainEvent](combat.ModifierStages)
+	modified, err := dnd5eEvents.AttackChain.On(s.eventBus).PublishWithChain(s.ctx, event, attackChain)
+	s.Require().NoError(err)
+	result, err := modified.Execute(s.ctx, event)
+	s.Require().NoError(err)
+	return result
+}
+
+func (s *EnvironmentTestSuite) melee(attackerID string, weapon *core.Ref) dnd5eEvents.AttackChainEvent {
+	return s.attack(dnd5eEvents.AttackChainEvent{
+		AttackerID: attackerID,
+		TargetID:   "shark",
+		WeaponRef:  weapon,
+		IsMelee:    true,
+	})
+}
+
+func (s *EnvironmentTestSuite) TestConfigValidation() {
+	_, err := combat.NewEnvironmentalModifiers(&combat.EnvironmentalModifiersConfig{})
+	s.Error(err)
+
+	_, err = combat.NewEnvironmentalModifiers(nil)
+	s.Error(err)
+}
+
+func (s *EnvironmentTestSuite) TestUnderwaterMelee() {
+	s.Run("longsword has disadvantage", func() {
+		s.tags["fighter"] = []combat.EnvironmentTag{combat.EnvironmentUnderwater}
+
+		result := s.melee("fighter", refs.Weapons.Longsword())
+		s.Require().Len(result.DisadvantageSources, 1)
+		s.Equal(refs.Conditions.Underwater(), result.DisadvantageSources[0].SourceRef)
+	})
+
+	s.Run("thrusting weapons are exempt", func() {
+		s.tags["fighter"] = []combat.EnvironmentTag{combat.EnvironmentUnderwater}
+
+		for _, weapon := range []*core.Ref{refs.Weapons.Dagger(), refs.Weapons.Spear(), refs.Weapons.Trident()} {
+			s.Empty(s.melee("fighter", weapon).DisadvantageSources, weapon.ID)
+		}
+	})
+
+	s.Run("swimmers are exempt", func() {
+		s.tags["sahuagin"] = []combat.EnvironmentTag{combat.EnvironmentUnderwater}
+
+		s.Empty(s.melee("sahuagin", refs.Weapons.Longsword()).DisadvantageSources)
+	})
+
+	s.Run("dry land has no penalty", func() {
+		s.Empty(s.melee("fighter", refs.Weapons.Longsword()).DisadvantageSources)
+	})
+}
+
+func (s *EnvironmentTestSuite) TestUnderwaterRanged() {
+	s.tags["archer"] = []combat.EnvironmentTag{combat.EnvironmentUnderwater}
+
+	ranged := func(weapon *core.Ref) dnd5eEvents.AttackChainEvent {
+		return s.attack(dnd5eEvents.AttackChainEvent{AttackerID: "archer", TargetID: "shark", WeaponRef: weapon})
+	}
+
+	s.Len(ranged(refs.Weapons.Longbow()).DisadvantageSources, 1)
+	s.Empty(ranged(refs.Weapons.LightCrossbow()).DisadvantageSources)
+	s.Empty(ranged(refs.Weapons.Javelin()).DisadvantageSources)
+}
+
+func (s *EnvironmentTestSuite) TestDarkness() {
+	s.Run("target in darkness imposes disadvantage", func() {
+		s.tags["shark"] = []combat.EnvironmentTag{combat.EnvironmentDarkness}
+
+		result := s.melee("fighter", refs.Weapons.Longsword())
+		s.Require().Len(result.DisadvantageSources, 1)
+		s.Equal(refs.Conditions.Darkness(), result.DisadvantageSources[0].SourceRef)
+		s.Empty(result.AdvantageSources)
+	})
+
+	s.Run("attacker in darkness gains advantage", func() {
+		s.tags["fighter"] = []combat.EnvironmentTag{combat.EnvironmentDarkness}
+
+		result := s.melee("fighter", refs.Weapons.Longsword())
+		s.Len(result.AdvantageSources, 1)
+		s.Empty(result.DisadvantageSources)
+	})
+
+	s.Run("darkvision sees the target", func() {
+		s.tags["shark"] = []combat.EnvironmentTag{combat.EnvironmentDarkness}
+
+		s.Empty(s.melee("drow", refs.Weapons.Shortsword()).DisadvantageSources)
+	})
+}
+
+func (s *EnvironmentTestSuite) TestUnderwaterFireResistance() {
+	damageChain := func(targetID string) *dnd5eEvents.DamageChainEvent {
+		event := &dnd5eEvents.DamageChainEvent{
+			AttackerID: "mage",
+			TargetID:   targetID,
+			Components: []dnd5eEvents.DamageComponent{
+				{Source: dnd5eEvents.DamageSourceSpell, FinalDiceRolls: []int{6, 4}, DamageType: damage.Fire},
+			},
+		}
+		chain := events.NewStagedChain[*dnd5eEvents.DamageChainEvent](combat.ModifierStages)
+		modified, err := dnd5eEvents.DamageChain.On(s.eventBus).PublishWithChain(s.ctx, event, chain)
+		s.Require().NoError(err)
+		result, err := modified.Execute(s.ctx, event)
+		s.Require().NoError(err)
+		return result
+	}
+
+	s.tags["shark"] = []combat.EnvironmentTag{combat.EnvironmentUnderwater}
+
+	result := damageChain("shark")
+	s.Require().Len(result.Components, 2)
+	s.Equal(0.5, result.Components[1].Multiplier)
+	s.Equal(refs.Conditions.Underwater(), result.Components[1].SourceRef)
+
+	s.Len(damageChain("fighter").Components, 1)
+}
+
+func (s *EnvironmentTestSuite) TestRemove() {
+	s.tags["fighter"] = []combat.EnvironmentTag{combat.EnvironmentUnderwater}
+	s.Require().NoError(s.modifiers.Remove(s.ctx, s.eventBus))
+	s.False(s.modifiers.IsApplied())
+
+	s.Empty(s.melee("fighter", refs.Weapons.Longsword()).DisadvantageSources)
+}
+
+func (s *EnvironmentTestSuite) TestRoomEnvironmentZones() {
+	room := spatial.NewBasicRoom(spatial.BasicRoomConfig{
+		ID:   "grotto",
+		Type: "combat",
+		Grid: spatial.NewSquareGrid(spatial.SquareGridConfig{Width: 20, Height: 20}),
+	})
+	s.Require().NoError(room.PlaceEntity(&testCombatant{id: "diver", entityType: "character"},
+		spatial.Position{X: 3, Y: 3}))
+	s.Require().NoError(room.PlaceEntity(&testCombatant{id: "guard", entityType: "character"},
+		spatial.Position{X: 15, Y: 15}))
+	ctx := combat.WithRoom(s.ctx, room)
+
+	environment := &combat.RoomEnvironment{
+		Tags: []combat.EnvironmentTag{combat.EnvironmentDarkness},
+		Zones: []combat.EnvironmentZone{
+			{ID: "pool", Center: spatial.Position{X: 2, Y: 2}, Radius: 2, Tags: []combat.EnvironmentTag{combat.EnvironmentUnderwater}},
+		},
+	}
+
+	s.Equal([]combat.EnvironmentTag{combat.EnvironmentDarkness, combat.EnvironmentUnderwater},
+		environment.TagsFor(ctx, "diver"))
+	s.Equal([]combat.EnvironmentTag{combat.EnvironmentDarkness}, environment.TagsFor(ctx, "guard"))
+
+	// Without a room only the room-wide tags apply
+	s.Equal([]combat.EnvironmentTag{combat.EnvironmentDarkness}, environment.TagsFor(s.ctx, "diver"))
+}
+
+// rubble is a zone of difficult terrain covering (4..6, 4..6)
+func (s *EnvironmentTestSuite) rubble() combat.EnvironmentZone {
+	return combat.EnvironmentZone{
+		ID:     "rubble",
+		Center: spatial.Position{X: 5, Y: 5},
+		Radius: 1,
+		Tags:   []combat.EnvironmentTag{combat.EnvironmentDifficultTerrain},
+	}
+}
+
+func (s *EnvironmentTestSuite) TestRoomEnvironmentTagsAt() {
+	room := spatial.NewBasicRoom(spatial.BasicRoomConfig{
+		ID:   "ruins",
+		Type: "combat",
+		Grid: spatial.NewSquareGrid(spatial.SquareGridConfig{Width: 20, Height: 20}),
+	})
+	ctx := combat.WithRoom(s.ctx, room)
+
+	environment := &combat.RoomEnvironment{Zones: []combat.EnvironmentZone{s.rubble()}}
+
+	s.Equal([]combat.EnvironmentTag{combat.EnvironmentDifficultTerrain}, environment.TagsAt(ctx, spatial.Position{X: 6, Y: 5}))
+	s.Empty(environment.TagsAt(ctx, spatial.Position{X: 8, Y: 5}))
+	s.Empty(environment.TagsAt(s.ctx, spatial.Position{X: 5, Y: 5}))
+}
+
+func (s *EnvironmentTestSuite) TestDifficultTerrainMovementCost() {
+	room := spatial.NewBasicRoom(spatial.BasicRoomConfig{
+		ID:   "ruins",
+		Type: "combat",
+		Grid: spatial.NewSquareGrid(spatial.SquareGridConfig{Width: 20, Height: 20}),
+	})
+	s.Require().NoError(room.PlaceEntity(&testCombatant{id: "fighter", entityType: "character"},
+		spatial.Position{X: 2, Y: 5}))
+	ctx := combat.WithRoom(s.ctx, room)
+
+	bus := events.NewEventBus()
+	modifiers, err := combat.NewEnvironmentalModifiers(&combat.EnvironmentalModifiersConfig{
+		Provider: &combat.RoomEnvironment{Zones: []combat.EnvironmentZone{s.rubble()}},
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(modifiers.Apply(ctx, bus))
+
+	economy := combat.NewActionEconomy()
+	economy.SetMovement(30)
+
+	// One normal step, then two steps into rubble at double cost
+	result, err := combat.MoveEntity(ctx, &combat.MoveEntityInput{
+		EntityID:   "fighter",
+		EntityType: "character",
+		Path:       []spatial.Position{{X: 3, Y: 5}, {X: 4, Y: 5}, {X: 5, Y: 5}},
+		EventBus:   bus,
+		Economy:    economy,
+	})
+	s.Require().NoError(err)
+	s.Equal(3, result.StepsCompleted)
+	s.Equal(25, result.MovementUsed)
+	s.Equal(25, economy.MovementUsed)
+	s.Equal(5, economy.MovementRemaining)
+}
+
+func (s *EnvironmentTestSuite) TestDifficultTerrainNeedsPositionProvider() {
+	// tagsByEntity only reports tags per entity, so no terrain cost is added
+	s.tags["fighter"] = []combat.EnvironmentTag{combat.EnvironmentDifficultTerrain}
+
+	event := &dnd5eEvents.MovementChainEvent{EntityID: "fighter"}
+	movementChain := events.NewStagedChain[*dnd5eEvents.MovementChainEvent](combat.ModifierStages)
+	modified, err := dnd5eEvents.MovementChain.On(s.eventBus).PublishWithChain(s.ctx, event, movementChain)
+	s.Require().NoError(err)
+	result, err := modified.Execute(s.ctx, event)
+	s.Require().NoError(err)
+	s.Equal(1, result.CostMultiplier())
+}
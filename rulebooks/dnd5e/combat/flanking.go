@@ -0,0 +1,110 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package combat
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
+)
+
+// findFlankingAlly returns the ally of the attacker that flanks the target
+// with it, or an empty string.
+//
+// Per the DMG flanking variant, a creature and its ally flank an enemy when
+// both are adjacent to it on opposite sides or corners of its space. The ally
+// can't be incapacitated. Square and hex grids are supported; gridless rooms
+// have no opposite space, so nothing flanks there.
+//
+// Returns an empty string when there is no room in the context or either
+// combatant isn't placed.
+func findFlankingAlly(ctx context.Context, attackerID, targetID string) string {
+	room, err := getRoomFromContext(ctx)
+	if err != nil {
+		return ""
+	}
+	attackerPos, found := room.GetEntityPosition(attackerID)
+	if !found {
+		return ""
+	}
+	targetPos, found := room.GetEntityPosition(targetID)
+	if !found {
+		return ""
+	}
+	if room.GetGrid().Distance(attackerPos, targetPos) > DefaultMeleeReach {
+		return ""
+	}
+
+	entities := room.GetAllEntities()
+	attacker, ok := entities[attackerID]
+	if !ok {
+		return ""
+	}
+
+	opposite, ok := oppositePosition(room.GetGrid(), attackerPos, targetPos)
+	if !ok {
+		return ""
+	}
+	for _, entity := range room.GetEntitiesAt(opposite) {
+		if isFlankingAlly(ctx, attacker, entity) {
+			return entity.GetID()
+		}
+	}
+	return ""
+}
+
+// oppositePosition returns the space on the far side of the target from the
+// attacker. Offset hex grids mirror through cube coordinates, since offset
+// rows don't line up; square and axial hex coordinates mirror directly.
+func oppositePosition(grid spatial.Grid, attackerPos, targetPos spatial.Position) (spatial.Position, bool) {
+	switch grid.GetShape() {
+	case spatial.GridShapeSquare:
+	case spatial.GridShapeHex:
+		if hex, ok := grid.(*spatial.HexGrid); ok {
+			attacker := hex.OffsetToCube(attackerPos)
+			target := hex.OffsetToCube(targetPos)
+			return hex.CubeToOffset(target.Add(target.Subtract(attacker))), true
+		}
+	default:
+		return spatial.Position{}, false
+	}
+	return spatial.Position{
+		X: 2*targetPos.X - attackerPos.X,
+		Y: 2*targetPos.Y - attackerPos.Y,
+	}, true
+}
+
+// isFlankingAlly reports whether the entity is a combatant allied with the
+// attacker that is able to act.
+func isFlankingAlly(ctx context.Context, attacker, entity core.Entity) bool {
+	id := entity.GetID()
+	if id == attacker.GetID() {
+		return false
+	}
+	// Only combatants flank - walls and obstacles share the room
+	if combatant, err := GetCombatantFromContext(ctx, id); err != nil || combatant == nil {
+		return false
+	}
+	return !isHostile(ctx, attacker, entity) && !isIncapacitated(ctx, id)
+}
+
+// flankingAdvantage returns the advantage source for a flanked melee attack,
+// or nil when the rule is off or no ally flanks the target.
+func flankingAdvantage(ctx context.Context, attackerID, targetID string, isMelee bool) *dnd5eEvents.AttackModifierSource {
+	if !isMelee || !GetCombatRules(ctx).FlankingAdvantage {
+		return nil
+	}
+	allyID := findFlankingAlly(ctx, attackerID, targetID)
+	if allyID == "" {
+		return nil
+	}
+	return &dnd5eEvents.AttackModifierSource{
+		SourceRef: refs.Conditions.Flanking(),
+		SourceID:  allyID,
+		Reason:    "flanking with " + allyID,
+	}
+}
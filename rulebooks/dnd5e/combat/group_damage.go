@@ -0,0 +1,198 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package combat
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+)
+
+// DamageTarget is one creature receiving damage from ApplyDamageToMany.
+type DamageTarget struct {
+	// Target is the combatant receiving damage.
+	Target Combatant
+
+	// Halved halves the damage before resistance (e.g. a successful save
+	// against Fireball).
+	Halved bool
+}
+
+// ApplyDamageToManyInput contains parameters for applying one set of damage
+// to several creatures (Fireball against a pack of goblins).
+type ApplyDamageToManyInput struct {
+	// Targets are the creatures receiving damage. Each may appear only once.
+	Targets []DamageTarget
+
+	// AttackerID is the ID of the entity dealing damage (optional)
+	AttackerID string
+
+	// Source identifies where the damage comes from
+	Source DamageSource
+
+	// SourceRef identifies the spell, feature, or hazard dealing the damage (optional)
+	SourceRef *core.Ref
+
+	// Instances are the damage amounts shared by every target, before resistance
+	Instances []DamageInstanceInput
+
+	// IsCritical indicates if this damage is from a critical hit
+	IsCritical bool
+
+	// EventBus is the event bus for publishing chain and notification events
+	EventBus events.EventBus
+}
+
+// Validate validates the input.
+func (a *ApplyDamageToManyInput) Validate() error {
+	if a == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "ApplyDamageToManyInput is nil")
+	}
+	if len(a.Targets) == 0 {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "at least one target is required")
+	}
+	if len(a.Instances) == 0 {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "Instances is required")
+	}
+	if a.EventBus == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "EventBus is required")
+	}
+
+	seen := make(map[string]bool, len(a.Targets))
+	for i, target := range a.Targets {
+		if target.Target == nil {
+			return rpgerr.Newf(rpgerr.CodeInvalidArgument, "target %d is nil", i)
+		}
+		id := target.Target.GetID()
+		if seen[id] {
+			return rpgerr.Newf(rpgerr.CodeInvalidArgument, "target %s is listed more than once", id)
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// TargetDamageResult is the outcome of group damage for one creature.
+type TargetDamageResult struct {
+	// TargetID is the creature that received damage
+	TargetID string
+
+	// Halved is true if the damage was halved before resistance
+	Halved bool
+
+	// TotalDamage is the damage applied after halving, modifiers, and resistance
+	TotalDamage int
+
+	// CurrentHP is the creature's HP after damage
+	CurrentHP int
+
+	// DroppedToZero is true if this damage reduced the creature to 0 HP
+	DroppedToZero bool
+
+	// FinalInstances are the damage instances after chain modifiers
+	FinalInstances []DamageInstanceInput
+
+	// FinalComponents are the full damage components after chain modifiers
+	FinalComponents []dnd5eEvents.DamageComponent
+}
+
+// ApplyDamageToManyOutput contains the result of applying group damage.
+type ApplyDamageToManyOutput struct {
+	// Results holds one entry per target, in input order
+	Results []*TargetDamageResult
+
+	// TotalDamage is the sum of damage applied to all targets
+	TotalDamage int
+}
+
+// ApplyDamageToMany applies one set of damage instances to several creatures.
+//
+// The damage components are built once and copied for each target, so every
+// creature runs its own pass through the DamageChain - resistance, immunity,
+// and vulnerability are resolved per creature. Each target then goes through
+// the same APPLY and NOTIFY steps as DealDamage, including its own
+// DamageReceivedEvent. One GroupDamageAppliedEvent summarizing every target
+// is published after all damage has been applied.
+func ApplyDamageToMany(ctx context.Context, input *ApplyDamageToManyInput) (*ApplyDamageToManyOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	base := make([]dnd5eEvents.DamageComponent, 0, len(input.Instances))
+	halved := make([]dnd5eEvents.DamageComponent, 0, len(input.Instances))
+	for _, inst := range input.Instances {
+		component := dnd5eEvents.DamageComponent{
+			Source:     dnd5eEvents.DamageSourceType(input.Source),
+			SourceRef:  input.SourceRef,
+			FlatBonus:  inst.Amount,
+			DamageType: inst.Type,
+			IsCritical: input.IsCritical,
+		}
+		base = append(base, component)
+
+		component.FlatBonus = inst.Amount / 2
+		halved = append(halved, component)
+	}
+
+	output := &ApplyDamageToManyOutput{
+		Results: make([]*TargetDamageResult, 0, len(input.Targets)),
+	}
+	outcomes := make([]dnd5eEvents.GroupDamageTargetOutcome, 0, len(input.Targets))
+
+	for _, target := range input.Targets {
+		components := base
+		if target.Halved {
+			components = halved
+		}
+
+		// Chain modifiers mutate components in place, so each target gets its own copy
+		dealt, err := DealDamage(ctx, &DealDamageInput{
+			Target:     target.Target,
+			AttackerID: input.AttackerID,
+			Source:     input.Source,
+			Components: append([]dnd5eEvents.DamageComponent(nil), components...),
+			IsCritical: input.IsCritical,
+			EventBus:   input.EventBus,
+		})
+		if err != nil {
+			return nil, rpgerr.Wrapf(err, "failed to apply damage to %s", target.Target.GetID())
+		}
+
+		result := &TargetDamageResult{
+			TargetID:        target.Target.GetID(),
+			Halved:          target.Halved,
+			TotalDamage:     dealt.TotalDamage,
+			CurrentHP:       dealt.CurrentHP,
+			DroppedToZero:   dealt.DroppedToZero,
+			FinalInstances:  dealt.FinalInstances,
+			FinalComponents: dealt.FinalComponents,
+		}
+		output.Results = append(output.Results, result)
+		output.TotalDamage += result.TotalDamage
+
+		outcomes = append(outcomes, dnd5eEvents.GroupDamageTargetOutcome{
+			TargetID:      result.TargetID,
+			Damage:        result.TotalDamage,
+			Halved:        result.Halved,
+			CurrentHP:     result.CurrentHP,
+			DroppedToZero: result.DroppedToZero,
+		})
+	}
+
+	err := dnd5eEvents.GroupDamageAppliedTopic.On(input.EventBus).Publish(ctx, dnd5eEvents.GroupDamageAppliedEvent{
+		SourceID:    input.AttackerID,
+		SourceRef:   input.SourceRef,
+		DamageType:  input.Instances[0].Type,
+		Targets:     outcomes,
+		TotalDamage: output.TotalDamage,
+	})
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to publish group damage applied event")
+	}
+
+	return output, nil
+}
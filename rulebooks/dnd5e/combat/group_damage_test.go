@@ -0,0 +1,211 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package combat_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+type GroupDamageTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	eventBus events.EventBus
+	goblins  []*mockCombatant
+}
+
+func TestGroupDamageSuite(t *testing.T) {
+	suite.Run(t, new(GroupDamageTestSuite))
+}
+
+func (s *GroupDamageTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.eventBus = events.NewEventBus()
+	s.goblins = []*mockCombatant{
+		{id: "goblin-1", hitPoints: 7, maxHitPoints: 7},
+		{id: "goblin-2", hitPoints: 20, maxHitPoints: 20},
+		{id: "goblin-3", hitPoints: 20, maxHitPoints: 20},
+	}
+}
+
+// fireball builds input dealing 14 fire damage to the goblins
+func (s *GroupDamageTestSuite) fireball(halved ...string) *combat.ApplyDamageToManyInput {
+	targets := make([]combat.DamageTarget, 0, len(s.goblins))
+	for _, goblin := range s.goblins {
+		target := combat.DamageTarget{Target: goblin}
+		for _, id := range halved {
+			target.Halved = target.Halved || id == goblin.id
+		}
+		targets = append(targets, target)
+	}
+	return &combat.ApplyDamageToManyInput{
+		Targets:    targets,
+		AttackerID: "wizard",
+		Source:     combat.DamageSourceSpell,
+		SourceRef:  refs.Spells.Fireball(),
+		Instances:  []combat.DamageInstanceInput{{Amount: 14, Type: damage.Fire}},
+		EventBus:   s.eventBus,
+	}
+}
+
+// subscribeMultiplier adds a fire multiplier to damage against one target
+func (s *GroupDamageTestSuite) subscribeMultiplier(targetID string, multiplier float64) {
+	_, err := dnd5eEvents.DamageChain.On(s.eventBus).SubscribeWithChain(s.ctx,
+		func(_ context.Context, e *dnd5eEvents.DamageChainEvent, c chain.Chain[*dnd5eEvents.DamageChainEvent],
+		) (chain.Chain[*dnd5eEvents.DamageChainEvent], error) {
+			if e.TargetID != targetID {
+				return c, nil
+			}
+			return c, c.Add(combat.StageFinal, "fire_"+targetID,
+				func(_ context.Context, e *dnd5eEvents.DamageChainEvent) (*dnd5eEvents.DamageChainEvent, error) {
+					e.Components = append(e.Components, dnd5eEvents.DamageComponent{
+						Source:     dnd5eEvents.DamageSourceCondition,
+						DamageType: damage.Fire,
+						Multiplier: multiplier,
+					})
+					return e, nil
+				})
+		})
+	s.Require().NoError(err)
+}
+
+func (s *GroupDamageTestSuite) TestValidate() {
+	s.Run("nil input", func() {
+		s.Error((*combat.ApplyDamageToManyInput)(nil).Validate())
+	})
+
+	s.Run("no targets", func() {
+		input := s.fireball()
+		input.Targets = nil
+		s.Error(input.Validate())
+	})
+
+	s.Run("nil target", func() {
+		input := s.fireball()
+		input.Targets = append(input.Targets, combat.DamageTarget{})
+		s.Error(input.Validate())
+	})
+
+	s.Run("duplicate target", func() {
+		input := s.fireball()
+		input.Targets = append(input.Targets, combat.DamageTarget{Target: s.goblins[0]})
+		err := input.Validate()
+		s.Require().Error(err)
+		s.Contains(err.Error(), "goblin-1")
+	})
+
+	s.Run("no instances", func() {
+		input := s.fireball()
+		input.Instances = nil
+		s.Error(input.Validate())
+	})
+
+	s.Run("no event bus", func() {
+		input := s.fireball()
+		input.EventBus = nil
+		s.Error(input.Validate())
+	})
+}
+
+func (s *GroupDamageTestSuite) TestAppliesDamageToEveryTarget() {
+	output, err := combat.ApplyDamageToMany(s.ctx, s.fireball())
+	s.Require().NoError(err)
+
+	s.Require().Len(output.Results, 3)
+	for i, result := range output.Results {
+		s.Equal(s.goblins[i].id, result.TargetID)
+		s.Equal(14, result.TotalDamage)
+	}
+	s.Equal(42, output.TotalDamage)
+
+	s.True(output.Results[0].DroppedToZero)
+	s.Equal(0, s.goblins[0].hitPoints)
+	s.Equal(6, s.goblins[1].hitPoints)
+	s.Equal(6, s.goblins[2].hitPoints)
+}
+
+func (s *GroupDamageTestSuite) TestResolvesResistancePerTarget() {
+	s.subscribeMultiplier("goblin-2", 0.5)
+	s.subscribeMultiplier("goblin-3", 2.0)
+
+	output, err := combat.ApplyDamageToMany(s.ctx, s.fireball())
+	s.Require().NoError(err)
+
+	s.Equal(14, output.Results[0].TotalDamage)
+	s.Equal(7, output.Results[1].TotalDamage)
+	s.Equal(28, output.Results[2].TotalDamage)
+	s.Equal(49, output.TotalDamage)
+
+	// Each target's chain saw only the shared base component plus its own modifiers
+	s.Len(output.Results[0].FinalComponents, 1)
+	s.Len(output.Results[1].FinalComponents, 2)
+	s.Len(output.Results[2].FinalComponents, 2)
+}
+
+func (s *GroupDamageTestSuite) TestHalvesBeforeResistance() {
+	s.subscribeMultiplier("goblin-3", 0.5)
+
+	output, err := combat.ApplyDamageToMany(s.ctx, s.fireball("goblin-2", "goblin-3"))
+	s.Require().NoError(err)
+
+	s.False(output.Results[0].Halved)
+	s.Equal(14, output.Results[0].TotalDamage)
+	s.True(output.Results[1].Halved)
+	s.Equal(7, output.Results[1].TotalDamage)
+	s.True(output.Results[2].Halved)
+	s.Equal(3, output.Results[2].TotalDamage)
+}
+
+func (s *GroupDamageTestSuite) TestPublishesPerTargetAndBatchedEvents() {
+	s.subscribeMultiplier("goblin-2", 0.5)
+
+	var received []dnd5eEvents.DamageReceivedEvent
+	_, err := dnd5eEvents.DamageReceivedTopic.On(s.eventBus).Subscribe(s.ctx,
+		func(_ context.Context, e dnd5eEvents.DamageReceivedEvent) error {
+			received = append(received, e)
+			return nil
+		})
+	s.Require().NoError(err)
+
+	var batches []dnd5eEvents.GroupDamageAppliedEvent
+	_, err = dnd5eEvents.GroupDamageAppliedTopic.On(s.eventBus).Subscribe(s.ctx,
+		func(_ context.Context, e dnd5eEvents.GroupDamageAppliedEvent) error {
+			batches = append(batches, e)
+			return nil
+		})
+	s.Require().NoError(err)
+
+	_, err = combat.ApplyDamageToMany(s.ctx, s.fireball("goblin-3"))
+	s.Require().NoError(err)
+
+	s.Require().Len(received, 3)
+	s.Equal("goblin-2", received[1].TargetID)
+	s.Equal(7, received[1].Amount)
+
+	s.Require().Len(batches, 1)
+	batch := batches[0]
+	s.Equal("wizard", batch.SourceID)
+	s.Equal(refs.Spells.Fireball(), batch.SourceRef)
+	s.Equal(damage.Fire, batch.DamageType)
+	s.Equal(28, batch.TotalDamage)
+	s.Require().Len(batch.Targets, 3)
+	s.Equal(dnd5eEvents.GroupDamageTargetOutcome{
+		TargetID: "goblin-1", Damage: 14, CurrentHP: 0, DroppedToZero: true,
+	}, batch.Targets[0])
+	s.Equal(dnd5eEvents.GroupDamageTargetOutcome{
+		TargetID: "goblin-2", Damage: 7, CurrentHP: 13,
+	}, batch.Targets[1])
+	s.Equal(dnd5eEvents.GroupDamageTargetOutcome{
+		TargetID: "goblin-3", Damage: 7, Halved: true, CurrentHP: 13,
+	}, batch.Targets[2])
+}
@@ -0,0 +1,197 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package combat
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+)
+
+// LairAction is one effect a creature can trigger in its lair.
+type LairAction struct {
+	// ID identifies the action (e.g. "grasping_tide")
+	ID string
+
+	// Name is the display name (e.g. "Grasping Tide")
+	Name string
+}
+
+// LairActionsConfig configures a creature's lair actions.
+type LairActionsConfig struct {
+	// OwnerID is the creature whose lair it is
+	OwnerID string
+
+	// Actions are the lair actions the creature can choose from
+	Actions []LairAction
+}
+
+// Validate validates the config.
+func (c *LairActionsConfig) Validate() error {
+	if c == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "LairActionsConfig is nil")
+	}
+	if c.OwnerID == "" {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "OwnerID is required")
+	}
+	if len(c.Actions) == 0 {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "at least one lair action is required")
+	}
+
+	seen := make(map[string]bool, len(c.Actions))
+	for _, action := range c.Actions {
+		if action.ID == "" {
+			return rpgerr.New(rpgerr.CodeInvalidArgument, "lair action ID is required")
+		}
+		if seen[action.ID] {
+			return rpgerr.Newf(rpgerr.CodeInvalidArgument, "duplicate lair action %s", action.ID)
+		}
+		seen[action.ID] = true
+	}
+	return nil
+}
+
+// LairActions tracks a creature's lair actions across rounds.
+//
+// Per D&D 5e, on initiative count 20 (losing all ties) the creature takes one
+// lair action. It can't take lair actions while incapacitated, and it can't
+// use the same lair action two rounds in a row. Use initiative.OrderWithLair
+// to place the lair's turn in the initiative order.
+//
+// The tracking fields are exported so game servers can persist them between rounds.
+type LairActions struct {
+	// OwnerID is the creature whose lair it is
+	OwnerID string
+
+	// Actions are the lair actions the creature can choose from
+	Actions []LairAction
+
+	// LastActionID is the lair action taken most recently
+	LastActionID string
+
+	// LastRound is the round LastActionID was taken in (0 if none yet)
+	LastRound int
+}
+
+// NewLairActions creates a lair action tracker from config.
+func NewLairActions(config *LairActionsConfig) (*LairActions, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &LairActions{
+		OwnerID: config.OwnerID,
+		Actions: append([]LairAction(nil), config.Actions...),
+	}, nil
+}
+
+// Available returns the lair actions the creature may take in the given round.
+// Returns nil if a lair action was already taken this round.
+func (l *LairActions) Available(round int) []LairAction {
+	if l.LastRound >= round {
+		return nil
+	}
+
+	var available []LairAction
+	for _, action := range l.Actions {
+		if l.repeats(action.ID, round) {
+			continue
+		}
+		available = append(available, action)
+	}
+	return available
+}
+
+// repeats reports whether taking the action in round would repeat last round's action.
+func (l *LairActions) repeats(actionID string, round int) bool {
+	return actionID == l.LastActionID && l.LastRound == round-1
+}
+
+// TakeLairActionInput provides the parameters for taking a lair action.
+type TakeLairActionInput struct {
+	// ActionID is the lair action to take
+	ActionID string
+
+	// Round is the current round number
+	Round int
+
+	// Economy is the lair owner's action economy (optional).
+	// When provided, conditions that prevent actions also prevent lair actions.
+	Economy *ActionEconomy
+
+	// EventBus is used to publish the LairActionTakenEvent
+	EventBus events.EventBus
+}
+
+// Validate validates the input.
+func (t *TakeLairActionInput) Validate() error {
+	if t == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "TakeLairActionInput is nil")
+	}
+	if t.ActionID == "" {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "ActionID is required")
+	}
+	if t.Round < 1 {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "Round must be at least 1")
+	}
+	if t.EventBus == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "EventBus is required")
+	}
+	return nil
+}
+
+// Take records the chosen lair action and publishes a LairActionTakenEvent.
+// The action's effect is resolved by the caller (e.g. via ResolveAreaEffect).
+//
+// Returns CodeNotFound for an unknown action, CodeResourceExhausted if a lair
+// action was already taken this round, CodeNotAllowed if the action was used
+// last round or a condition prevents the owner from acting.
+func (l *LairActions) Take(ctx context.Context, input *TakeLairActionInput) (*LairAction, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	action, found := l.find(input.ActionID)
+	if !found {
+		return nil, rpgerr.Newf(rpgerr.CodeNotFound, "%s has no lair action %s", l.OwnerID, input.ActionID)
+	}
+
+	if input.Economy != nil && input.Economy.Restriction.NoActions {
+		return nil, input.Economy.restrictedError("lair action")
+	}
+	if l.LastRound >= input.Round {
+		return nil, rpgerr.Newf(rpgerr.CodeResourceExhausted,
+			"%s already took a lair action in round %d", l.OwnerID, l.LastRound)
+	}
+	if l.repeats(action.ID, input.Round) {
+		return nil, rpgerr.Newf(rpgerr.CodeNotAllowed,
+			"%s can't use lair action %s two rounds in a row", l.OwnerID, action.ID)
+	}
+
+	l.LastActionID = action.ID
+	l.LastRound = input.Round
+
+	err := dnd5eEvents.LairActionTakenTopic.On(input.EventBus).Publish(ctx, dnd5eEvents.LairActionTakenEvent{
+		OwnerID:  l.OwnerID,
+		ActionID: action.ID,
+		Round:    input.Round,
+	})
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to publish lair action taken event")
+	}
+
+	return &action, nil
+}
+
+// find returns the lair action with the given ID.
+func (l *LairActions) find(actionID string) (LairAction, bool) {
+	for _, action := range l.Actions {
+		if action.ID == actionID {
+			return action, true
+		}
+	}
+	return LairAction{}, false
+}
@@ -0,0 +1,147 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package combat_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+type LairActionsTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	eventBus events.EventBus
+	lair     *combat.LairActions
+	taken    []dnd5eEvents.LairActionTakenEvent
+}
+
+func TestLairActionsSuite(t *testing.T) {
+	suite.Run(t, new(LairActionsTestSuite))
+}
+
+func (s *LairActionsTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.eventBus = events.NewEventBus()
+
+	var err error
+	s.lair, err = combat.NewLairActions(&combat.LairActionsConfig{
+		OwnerID: "dragon",
+		Actions: []combat.LairAction{
+			{ID: "grasping_tide", Name: "Grasping Tide"},
+			{ID: "tremor", Name: "Tremor"},
+		},
+	})
+	s.Require().NoError(err)
+
+	s.taken = nil
+	_, err = dnd5eEvents.LairActionTakenTopic.On(s.eventBus).Subscribe(s.ctx,
+		func(_ context.Context, e dnd5eEvents.LairActionTakenEvent) error {
+			s.taken = append(s.taken, e)
+			return nil
+		})
+	s.Require().NoError(err)
+}
+
+func (s *LairActionsTestSuite) SetupSubTest() {
+	s.SetupTest()
+}
+
+func (s *LairActionsTestSuite) take(actionID string, round int) error {
+	_, err := s.lair.Take(s.ctx, &combat.TakeLairActionInput{
+		ActionID: actionID,
+		Round:    round,
+		EventBus: s.eventBus,
+	})
+	return err
+}
+
+func (s *LairActionsTestSuite) TestConfigValidation() {
+	s.Run("nil config", func() {
+		_, err := combat.NewLairActions(nil)
+		s.Error(err)
+	})
+
+	s.Run("no actions", func() {
+		_, err := combat.NewLairActions(&combat.LairActionsConfig{OwnerID: "dragon"})
+		s.Error(err)
+	})
+
+	s.Run("duplicate action", func() {
+		_, err := combat.NewLairActions(&combat.LairActionsConfig{
+			OwnerID: "dragon",
+			Actions: []combat.LairAction{{ID: "tremor"}, {ID: "tremor"}},
+		})
+		s.Error(err)
+	})
+}
+
+func (s *LairActionsTestSuite) TestTakePublishesEvent() {
+	s.Require().NoError(s.take("tremor", 1))
+
+	s.Equal("tremor", s.lair.LastActionID)
+	s.Equal(1, s.lair.LastRound)
+	s.Require().Len(s.taken, 1)
+	s.Equal(dnd5eEvents.LairActionTakenEvent{OwnerID: "dragon", ActionID: "tremor", Round: 1}, s.taken[0])
+}
+
+func (s *LairActionsTestSuite) TestOncePerRound() {
+	s.Require().NoError(s.take("tremor", 1))
+
+	err := s.take("grasping_tide", 1)
+	s.Equal(rpgerr.CodeResourceExhausted, rpgerr.GetCode(err))
+	s.Nil(s.lair.Available(1))
+}
+
+func (s *LairActionsTestSuite) TestNoRepeatInConsecutiveRounds() {
+	s.Require().NoError(s.take("tremor", 1))
+
+	err := s.take("tremor", 2)
+	s.Equal(rpgerr.CodeNotAllowed, rpgerr.GetCode(err))
+
+	available := s.lair.Available(2)
+	s.Require().Len(available, 1)
+	s.Equal("grasping_tide", available[0].ID)
+
+	s.Require().NoError(s.take("grasping_tide", 2))
+
+	// Skipping a round makes the action available again
+	s.Require().NoError(s.take("grasping_tide", 4))
+	s.Len(s.taken, 3)
+}
+
+func (s *LairActionsTestSuite) TestRejections() {
+	s.Run("unknown action", func() {
+		err := s.take("earthquake", 1)
+		s.Equal(rpgerr.CodeNotFound, rpgerr.GetCode(err))
+	})
+
+	s.Run("owner incapacitated", func() {
+		economy := combat.NewActionEconomy()
+		economy.ApplyConditions([]*core.Ref{refs.Conditions.Paralyzed()})
+
+		_, err := s.lair.Take(s.ctx, &combat.TakeLairActionInput{
+			ActionID: "tremor",
+			Round:    1,
+			Economy:  economy,
+			EventBus: s.eventBus,
+		})
+		s.Equal(rpgerr.CodeNotAllowed, rpgerr.GetCode(err))
+		s.Empty(s.taken)
+		s.Equal(0, s.lair.LastRound)
+	})
+
+	s.Run("invalid round", func() {
+		err := s.take("tremor", 0)
+		s.Equal(rpgerr.CodeInvalidArgument, rpgerr.GetCode(err))
+	})
+}
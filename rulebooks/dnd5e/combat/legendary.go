@@ -0,0 +1,235 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package combat
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+)
+
+// DefaultLegendaryActionsPerRound is the number of legendary actions most legendary creatures get.
+const DefaultLegendaryActionsPerRound = 3
+
+// LegendaryAction is one option a legendary creature can take at the end of
+// another creature's turn.
+type LegendaryAction struct {
+	// ID identifies the action (e.g. "tail_attack")
+	ID string
+
+	// Name is the display name (e.g. "Tail Attack")
+	Name string
+
+	// Cost is how many legendary actions it spends. 0 is treated as 1.
+	Cost int
+}
+
+// cost returns the legendary actions this action spends.
+func (a LegendaryAction) cost() int {
+	if a.Cost < 1 {
+		return 1
+	}
+	return a.Cost
+}
+
+// LegendaryActionsConfig configures a legendary creature's legendary actions.
+type LegendaryActionsConfig struct {
+	// OwnerID is the legendary creature
+	OwnerID string
+
+	// PerRound is the size of the pool. Defaults to DefaultLegendaryActionsPerRound.
+	PerRound int
+
+	// Actions are the legendary actions the creature can choose from
+	Actions []LegendaryAction
+}
+
+// Validate validates the config.
+func (c *LegendaryActionsConfig) Validate() error {
+	if c == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "LegendaryActionsConfig is nil")
+	}
+	if c.OwnerID == "" {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "OwnerID is required")
+	}
+	if c.PerRound < 0 {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "PerRound cannot be negative")
+	}
+	if len(c.Actions) == 0 {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "at least one legendary action is required")
+	}
+
+	seen := make(map[string]bool, len(c.Actions))
+	for _, action := range c.Actions {
+		if action.ID == "" {
+			return rpgerr.New(rpgerr.CodeInvalidArgument, "legendary action ID is required")
+		}
+		if seen[action.ID] {
+			return rpgerr.Newf(rpgerr.CodeInvalidArgument, "duplicate legendary action %s", action.ID)
+		}
+		seen[action.ID] = true
+	}
+	return nil
+}
+
+// LegendaryActions is a legendary creature's set of legendary actions.
+//
+// Per D&D 5e, a legendary creature can take a limited number of legendary
+// actions per round, one at a time and only at the end of another creature's
+// turn. Spent legendary actions are regained at the start of the creature's
+// own turn.
+//
+// The pool itself lives on the creature's ActionEconomy
+// (LegendaryActionsRemaining), so Reset at turn start refreshes it and
+// incapacitating conditions take it away like any other action.
+type LegendaryActions struct {
+	ownerID  string
+	perRound int
+	actions  []LegendaryAction
+}
+
+// NewLegendaryActions creates a legendary action set from config.
+func NewLegendaryActions(config *LegendaryActionsConfig) (*LegendaryActions, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	perRound := config.PerRound
+	if perRound == 0 {
+		perRound = DefaultLegendaryActionsPerRound
+	}
+
+	return &LegendaryActions{
+		ownerID:  config.OwnerID,
+		perRound: perRound,
+		actions:  append([]LegendaryAction(nil), config.Actions...),
+	}, nil
+}
+
+// OwnerID returns the legendary creature's ID.
+func (l *LegendaryActions) OwnerID() string {
+	return l.ownerID
+}
+
+// PerRound returns the number of legendary actions per round.
+func (l *LegendaryActions) PerRound() int {
+	return l.perRound
+}
+
+// Actions returns every legendary action the creature can choose from.
+func (l *LegendaryActions) Actions() []LegendaryAction {
+	return append([]LegendaryAction(nil), l.actions...)
+}
+
+// InitEconomy sets the creature's legendary action pool on its action economy.
+// Call once when the creature joins combat.
+func (l *LegendaryActions) InitEconomy(economy *ActionEconomy) {
+	economy.SetLegendaryActions(l.perRound)
+}
+
+// Available returns the legendary actions the creature can afford after the
+// given creature's turn ends. Returns nil on the creature's own turn.
+func (l *LegendaryActions) Available(economy *ActionEconomy, turnEndedID string) []LegendaryAction {
+	if turnEndedID == l.ownerID {
+		return nil
+	}
+
+	var available []LegendaryAction
+	for _, action := range l.actions {
+		if economy.CanUseLegendaryAction(action.cost()) {
+			available = append(available, action)
+		}
+	}
+	return available
+}
+
+// TakeLegendaryActionInput provides the parameters for taking a legendary action.
+type TakeLegendaryActionInput struct {
+	// ActionID is the legendary action to take
+	ActionID string
+
+	// TurnEndedID is the creature whose turn just ended
+	TurnEndedID string
+
+	// Round is the current round number
+	Round int
+
+	// Economy is the legendary creature's action economy
+	Economy *ActionEconomy
+
+	// EventBus is used to publish the LegendaryActionTakenEvent
+	EventBus events.EventBus
+}
+
+// Validate validates the input.
+func (t *TakeLegendaryActionInput) Validate() error {
+	if t == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "TakeLegendaryActionInput is nil")
+	}
+	if t.ActionID == "" {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "ActionID is required")
+	}
+	if t.TurnEndedID == "" {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "TurnEndedID is required")
+	}
+	if t.Economy == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "Economy is required")
+	}
+	if t.EventBus == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "EventBus is required")
+	}
+	return nil
+}
+
+// Take spends legendary actions for the chosen action and publishes a
+// LegendaryActionTakenEvent. The action's effect is resolved by the caller
+// (an attack via ResolveAttack, movement via MoveEntity, etc.).
+//
+// Returns CodeTimingRestriction on the creature's own turn, CodeNotFound for
+// an unknown action, and the economy's error when the pool can't pay the cost.
+func (l *LegendaryActions) Take(ctx context.Context, input *TakeLegendaryActionInput) (*LegendaryAction, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	if input.TurnEndedID == l.ownerID {
+		return nil, rpgerr.Newf(rpgerr.CodeTimingRestriction,
+			"%s can only take legendary actions at the end of another creature's turn", l.ownerID)
+	}
+
+	action, found := l.find(input.ActionID)
+	if !found {
+		return nil, rpgerr.Newf(rpgerr.CodeNotFound, "%s has no legendary action %s", l.ownerID, input.ActionID)
+	}
+
+	if err := input.Economy.UseLegendaryAction(action.cost()); err != nil {
+		return nil, err
+	}
+
+	err := dnd5eEvents.LegendaryActionTakenTopic.On(input.EventBus).Publish(ctx, dnd5eEvents.LegendaryActionTakenEvent{
+		OwnerID:     l.ownerID,
+		ActionID:    action.ID,
+		Cost:        action.cost(),
+		Remaining:   input.Economy.LegendaryActionsRemaining,
+		TurnEndedID: input.TurnEndedID,
+		Round:       input.Round,
+	})
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to publish legendary action taken event")
+	}
+
+	return &action, nil
+}
+
+// find returns the legendary action with the given ID.
+func (l *LegendaryActions) find(actionID string) (LegendaryAction, bool) {
+	for _, action := range l.actions {
+		if action.ID == actionID {
+			return action, true
+		}
+	}
+	return LegendaryAction{}, false
+}
@@ -0,0 +1,205 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package combat_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/initiative"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+type LegendaryActionsTestSuite struct {
+	suite.Suite
+	ctx       context.Context
+	eventBus  events.EventBus
+	legendary *combat.LegendaryActions
+	economy   *combat.ActionEconomy
+	taken     []dnd5eEvents.LegendaryActionTakenEvent
+}
+
+func TestLegendaryActionsSuite(t *testing.T) {
+	suite.Run(t, new(LegendaryActionsTestSuite))
+}
+
+func (s *LegendaryActionsTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.eventBus = events.NewEventBus()
+
+	var err error
+	s.legendary, err = combat.NewLegendaryActions(&combat.LegendaryActionsConfig{
+		OwnerID: "dragon",
+		Actions: []combat.LegendaryAction{
+			{ID: "detect", Name: "Detect"},
+			{ID: "tail_attack", Name: "Tail Attack"},
+			{ID: "wing_attack", Name: "Wing Attack", Cost: 2},
+		},
+	})
+	s.Require().NoError(err)
+
+	s.economy = combat.NewActionEconomy()
+	s.legendary.InitEconomy(s.economy)
+
+	s.taken = nil
+	_, err = dnd5eEvents.LegendaryActionTakenTopic.On(s.eventBus).Subscribe(s.ctx,
+		func(_ context.Context, e dnd5eEvents.LegendaryActionTakenEvent) error {
+			s.taken = append(s.taken, e)
+			return nil
+		})
+	s.Require().NoError(err)
+}
+
+func (s *LegendaryActionsTestSuite) SetupSubTest() {
+	s.SetupTest()
+}
+
+func (s *LegendaryActionsTestSuite) take(actionID, turnEndedID string) (*combat.LegendaryAction, error) {
+	return s.legendary.Take(s.ctx, &combat.TakeLegendaryActionInput{
+		ActionID:    actionID,
+		TurnEndedID: turnEndedID,
+		Round:       1,
+		Economy:     s.economy,
+		EventBus:    s.eventBus,
+	})
+}
+
+func (s *LegendaryActionsTestSuite) TestConfigValidation() {
+	s.Run("nil config", func() {
+		_, err := combat.NewLegendaryActions(nil)
+		s.Error(err)
+	})
+
+	s.Run("missing owner", func() {
+		_, err := combat.NewLegendaryActions(&combat.LegendaryActionsConfig{
+			Actions: []combat.LegendaryAction{{ID: "detect"}},
+		})
+		s.Error(err)
+	})
+
+	s.Run("no actions", func() {
+		_, err := combat.NewLegendaryActions(&combat.LegendaryActionsConfig{OwnerID: "dragon"})
+		s.Error(err)
+	})
+
+	s.Run("duplicate action", func() {
+		_, err := combat.NewLegendaryActions(&combat.LegendaryActionsConfig{
+			OwnerID: "dragon",
+			Actions: []combat.LegendaryAction{{ID: "detect"}, {ID: "detect"}},
+		})
+		s.Error(err)
+	})
+
+	s.Run("defaults to three per round", func() {
+		s.Equal(combat.DefaultLegendaryActionsPerRound, s.legendary.PerRound())
+		s.Equal(3, s.economy.LegendaryActionsRemaining)
+	})
+}
+
+func (s *LegendaryActionsTestSuite) TestTakeSpendsCost() {
+	action, err := s.take("wing_attack", "fighter")
+	s.Require().NoError(err)
+	s.Equal("wing_attack", action.ID)
+	s.Equal(1, s.economy.LegendaryActionsRemaining)
+
+	_, err = s.take("tail_attack", "rogue")
+	s.Require().NoError(err)
+	s.Equal(0, s.economy.LegendaryActionsRemaining)
+
+	// Legendary actions don't touch the creature's own action economy
+	s.True(s.economy.CanUseAction())
+	s.True(s.economy.CanUseReaction())
+
+	s.Require().Len(s.taken, 2)
+	s.Equal(dnd5eEvents.LegendaryActionTakenEvent{
+		OwnerID:     "dragon",
+		ActionID:    "wing_attack",
+		Cost:        2,
+		Remaining:   1,
+		TurnEndedID: "fighter",
+		Round:       1,
+	}, s.taken[0])
+}
+
+func (s *LegendaryActionsTestSuite) TestTakeRejections() {
+	s.Run("on own turn", func() {
+		_, err := s.take("detect", "dragon")
+		s.Equal(rpgerr.CodeTimingRestriction, rpgerr.GetCode(err))
+		s.Equal(3, s.economy.LegendaryActionsRemaining)
+	})
+
+	s.Run("unknown action", func() {
+		_, err := s.take("breath_weapon", "fighter")
+		s.Equal(rpgerr.CodeNotFound, rpgerr.GetCode(err))
+	})
+
+	s.Run("pool too small for cost", func() {
+		_, err := s.take("wing_attack", "fighter")
+		s.Require().NoError(err)
+		_, err = s.take("wing_attack", "rogue")
+		s.Equal(rpgerr.CodeResourceExhausted, rpgerr.GetCode(err))
+		s.Equal(1, s.economy.LegendaryActionsRemaining)
+		s.Len(s.taken, 1)
+	})
+
+	s.Run("incapacitated", func() {
+		s.economy.ApplyConditions([]*core.Ref{refs.Conditions.Stunned()})
+		_, err := s.take("detect", "fighter")
+		s.Equal(rpgerr.CodeNotAllowed, rpgerr.GetCode(err))
+	})
+
+	s.Run("invalid input", func() {
+		_, err := s.legendary.Take(s.ctx, &combat.TakeLegendaryActionInput{ActionID: "detect"})
+		s.Equal(rpgerr.CodeInvalidArgument, rpgerr.GetCode(err))
+	})
+}
+
+func (s *LegendaryActionsTestSuite) TestAvailable() {
+	s.Len(s.legendary.Available(s.economy, "fighter"), 3)
+	s.Nil(s.legendary.Available(s.economy, "dragon"))
+
+	_, err := s.take("wing_attack", "fighter")
+	s.Require().NoError(err)
+
+	available := s.legendary.Available(s.economy, "rogue")
+	s.Require().Len(available, 2)
+	s.Equal("detect", available[0].ID)
+	s.Equal("tail_attack", available[1].ID)
+}
+
+func (s *LegendaryActionsTestSuite) TestPoolRefreshesAtStartOfOwnersTurn() {
+	tracker := initiative.New([]core.Entity{
+		initiative.NewParticipant("fighter", dnd5e.EntityTypeCharacter),
+		initiative.NewParticipant("dragon", dnd5e.EntityTypeMonster),
+		initiative.NewParticipant("rogue", dnd5e.EntityTypeCharacter),
+	})
+
+	for tracker.Round() <= 2 {
+		current := tracker.Current()
+		if current.GetID() == "dragon" {
+			s.economy.Reset()
+			s.Equal(3, s.economy.LegendaryActionsRemaining)
+		}
+
+		// End of turn: the dragon spends what it can after everyone else
+		if _, err := s.take("tail_attack", current.GetID()); err != nil {
+			s.Equal(rpgerr.CodeTimingRestriction, rpgerr.GetCode(err))
+			s.Equal("dragon", current.GetID())
+		}
+		tracker.Next()
+	}
+
+	// One after each of fighter and rogue per round; the dragon's turn in
+	// between refreshes the pool, so only the rogue's spend is left outstanding
+	s.Len(s.taken, 4)
+	s.Equal(2, s.economy.LegendaryActionsRemaining)
+}
@@ -5,6 +5,7 @@ package combat
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/KirkDiggler/rpg-toolkit/dice"
 	"github.com/KirkDiggler/rpg-toolkit/events"
@@ -34,6 +35,92 @@ func getRoomFromContext(ctx context.Context) (spatial.Room, error) {
 	return room, nil
 }
 
+// DistanceFeet returns the distance in feet between two entities in the room
+// carried by the context. Returns false if there is no room or either entity
+// isn't placed.
+func DistanceFeet(ctx context.Context, fromID, toID string) (int, bool) {
+	room, err := getRoomFromContext(ctx)
+	if err != nil {
+		return 0, false
+	}
+	fromPos, found := room.GetEntityPosition(fromID)
+	if !found {
+		return 0, false
+	}
+	toPos, found := room.GetEntityPosition(toID)
+	if !found {
+		return 0, false
+	}
+	return GetCombatRules(ctx).GridDistanceFeet(room.GetGrid(), fromPos, toPos), true
+}
+
+// DistanceToPositionFeet returns the distance in feet from an entity to a
+// position in the room carried by the context. Returns false if there is no
+// room or the entity isn't placed.
+func DistanceToPositionFeet(ctx context.Context, entityID string, pos spatial.Position) (int, bool) {
+	room, err := getRoomFromContext(ctx)
+	if err != nil {
+		return 0, false
+	}
+	entityPos, found := room.GetEntityPosition(entityID)
+	if !found {
+		return 0, false
+	}
+	return GetCombatRules(ctx).GridDistanceFeet(room.GetGrid(), entityPos, pos), true
+}
+
+// EntityPosition returns the position of an entity in the room carried by the
+// context. Returns false if there is no room or the entity isn't placed.
+func EntityPosition(ctx context.Context, entityID string) (spatial.Position, bool) {
+	room, err := getRoomFromContext(ctx)
+	if err != nil {
+		return spatial.Position{}, false
+	}
+	return room.GetEntityPosition(entityID)
+}
+
+// CheckTeleport returns an error if an entity can't teleport to a position in
+// the room carried by the context: the destination must be on the grid,
+// unoccupied, and within maxFeet of the entity.
+func CheckTeleport(ctx context.Context, entityID string, to spatial.Position, maxFeet int) error {
+	room, err := getRoomFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	from, found := room.GetEntityPosition(entityID)
+	if !found {
+		return rpgerr.Newf(rpgerr.CodeNotFound, "%s is not in the room", entityID)
+	}
+	if !room.GetGrid().IsValidPosition(to) {
+		return rpgerr.Newf(rpgerr.CodeInvalidTarget, "destination %v is off the grid", to)
+	}
+	if distance := GetCombatRules(ctx).GridDistanceFeet(room.GetGrid(), from, to); distance > maxFeet {
+		return rpgerr.Newf(rpgerr.CodeOutOfRange, "destination is %d ft away, beyond %d ft", distance, maxFeet)
+	}
+	if room.IsPositionOccupied(to) {
+		return rpgerr.Newf(rpgerr.CodeInvalidTarget, "destination %v is occupied", to)
+	}
+	return nil
+}
+
+// TeleportEntity moves an entity straight to a position in the room carried by
+// the context (Misty Step). It crosses no squares in between, so it spends no
+// movement and provokes no opportunity attacks. See CheckTeleport for the
+// destination requirements.
+func TeleportEntity(ctx context.Context, entityID string, to spatial.Position, maxFeet int) error {
+	if err := CheckTeleport(ctx, entityID, to, maxFeet); err != nil {
+		return err
+	}
+	room, err := getRoomFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if err := room.MoveEntity(entityID, to); err != nil {
+		return rpgerr.WrapWithCode(err, rpgerr.CodeInvalidTarget, "destination is not free for "+entityID)
+	}
+	return nil
+}
+
 // DefaultMeleeReach is the default melee reach for most combatants in grid units.
 // In D&D 5e with 5ft squares, this is 1 unit (5 feet).
 // Reach weapons extend this to 2 units (10 feet).
@@ -62,6 +149,24 @@ type MoveEntityInput struct {
 	// Roller is the dice roller for opportunity attack rolls.
 	// If nil, a default roller is used.
 	Roller dice.Roller
+
+	// Economy is the mover's action economy (optional).
+	// When provided, each step's cost (including extra costs such as crawling)
+	// is deducted from MovementRemaining, and movement stops when it runs out.
+	Economy *ActionEconomy
+
+	// DecideOpportunityAttack is asked whether a threatener takes an
+	// opportunity attack against the mover (optional). When nil, attackers
+	// implementing OpportunityAttackDecider decide; all others always attack.
+	DecideOpportunityAttack func(ctx context.Context, attackerID, targetID string) bool
+}
+
+// OpportunityAttackDecider is implemented by combatants that choose whether to
+// spend their reaction on an opportunity attack (a monster's behavior, or a
+// player's standing choice). Combatants that don't implement it always attack.
+type OpportunityAttackDecider interface {
+	// TakeOpportunityAttack returns true to make the opportunity attack
+	TakeOpportunityAttack(ctx context.Context, targetID string) bool
 }
 
 // Validate validates the input fields.
@@ -110,6 +215,9 @@ type MoveEntityResult struct {
 	// OAsTriggered contains all opportunity attacks that were triggered during movement.
 	OAsTriggered []OpportunityAttackResult
 
+	// OAsDeclined lists the threateners that chose not to make an opportunity attack.
+	OAsDeclined []string
+
 	// OAErrors contains any errors that occurred while processing opportunity attacks.
 	// These are non-fatal errors that didn't stop movement but should be logged for debugging.
 	OAErrors []string
@@ -119,21 +227,30 @@ type MoveEntityResult struct {
 
 	// StopReason explains why movement was stopped, if applicable.
 	StopReason string
+
+	// MovementUsed is the movement spent in feet, including extra costs
+	MovementUsed int
 }
 
 // MoveEntity executes movement step by step, checking for opportunity attacks at each step.
 // The function fires a MovementChain event before each step to allow conditions like
-// Disengaging to prevent opportunity attacks, or features like Sentinel to stop movement.
+// Disengaging to prevent opportunity attacks, or features like Mobile to prevent
+// them from particular creatures.
 //
 // For each step in the path:
 //  1. Determine which entities threaten the current position
 //  2. Fire MovementChain event to collect modifiers
 //  3. If movement is not prevented:
 //     a. For each threatening entity that the mover is LEAVING threat range of:
-//     - Trigger opportunity attack (unless OA is prevented)
+//     - Skip it if OAs from it are prevented, or if it declines (see
+//     MoveEntityInput.DecideOpportunityAttack)
+//     - Trigger the opportunity attack, then fire OpportunityAttackChain;
+//     a hit that reduces the mover's speed to 0 (Sentinel) stops movement
 //     b. Move to next position
 //  4. If movement is blocked, stop and return current state
 //
+// Step costs measure diagonals by the CombatRules in the context (see WithCombatRules).
+//
 //nolint:gocyclo // Movement resolution requires coordinating multiple game systems
 func MoveEntity(ctx context.Context, input *MoveEntityInput) (*MoveEntityResult, error) {
 	if err := input.Validate(); err != nil {
@@ -168,6 +285,10 @@ func MoveEntity(ctx context.Context, input *MoveEntityInput) (*MoveEntityResult,
 	// Track actual steps taken (separate from loop index to handle skipped positions)
 	actualSteps := 0
 
+	// Diagonals moved so far, for the alternating diagonal metric
+	rules := GetCombatRules(ctx)
+	diagonals := 0
+
 	// Process each step in the path
 	for _, nextPos := range input.Path {
 		// Skip if this is the current position (first position in path might be starting point)
@@ -211,26 +332,57 @@ func MoveEntity(ctx context.Context, input *MoveEntityInput) (*MoveEntityResult,
 			return result, nil
 		}
 
-		// Process opportunity attacks if not prevented
-		if !finalEvent.IsOAPrevented() {
-			for _, threatenerID := range threateningEntities {
-				// Check if mover is leaving this threatener's threat range
-				if isLeavingThreatRange(ctx, room, input.EntityID, threatenerID, currentPos, nextPos) {
-					// Trigger opportunity attack
-					oaResult, err := triggerOpportunityAttack(ctx, threatenerID, input.EntityID, input.EventBus, roller)
-					if err != nil {
-						// Record error for debugging but continue - OA failure shouldn't stop movement
-						result.OAErrors = append(result.OAErrors, err.Error())
-						continue
-					}
-
-					if oaResult != nil {
-						result.OAsTriggered = append(result.OAsTriggered, *oaResult)
-
-						// Future: Check for Sentinel-style effects that stop movement on hit
-						// For now, OAs don't stop movement unless the target is incapacitated
-					}
+		// Pay for the step before leaving the square
+		stepFeet, stepDiagonals := rules.moveFeet(room.GetGrid(), currentPos, nextPos, diagonals)
+		stepCost := stepFeet * finalEvent.CostMultiplier()
+		if input.Economy != nil {
+			if err := input.Economy.UseMovement(stepCost); err != nil {
+				result.MovementStopped = true
+				result.StopReason = err.Error()
+				return result, nil
+			}
+		}
+		result.MovementUsed += stepCost
+		diagonals = stepDiagonals
+
+		// Process opportunity attacks from threateners the mover is leaving
+		for _, threatenerID := range threateningEntities {
+			if finalEvent.IsOAPreventedFrom(threatenerID) ||
+				!isLeavingThreatRange(ctx, room, input.EntityID, threatenerID, currentPos, nextPos) {
+				continue
+			}
+
+			if !takesOpportunityAttack(ctx, input, threatenerID) {
+				result.OAsDeclined = append(result.OAsDeclined, threatenerID)
+				continue
+			}
+
+			oaResult, err := triggerOpportunityAttack(ctx, threatenerID, input.EntityID, input.EventBus, roller)
+			if err != nil {
+				// Record error for debugging but continue - OA failure shouldn't stop movement
+				result.OAErrors = append(result.OAErrors, err.Error())
+				continue
+			}
+			if oaResult == nil {
+				continue
+			}
+			result.OAsTriggered = append(result.OAsTriggered, *oaResult)
+
+			oaEvent, err := publishOpportunityAttackChain(ctx, input.EventBus, input.EntityID, oaResult)
+			if err != nil {
+				result.OAErrors = append(result.OAErrors, err.Error())
+				continue
+			}
+			if oaEvent.StopsMovement() {
+				// The mover never leaves the square: refund the step, then drop speed to 0
+				result.MovementUsed -= stepCost
+				if input.Economy != nil {
+					input.Economy.RefundMovement(stepCost)
+					input.Economy.StopMovement()
 				}
+				result.MovementStopped = true
+				result.StopReason = fmt.Sprintf("speed reduced to 0 by %s", oaEvent.SpeedZeroSources[0].Name)
+				return result, nil
 			}
 		}
 
@@ -329,6 +481,51 @@ func canMakeOpportunityAttack(_ context.Context, _ string) bool {
 	return true
 }
 
+// takesOpportunityAttack asks whether the threatener makes its opportunity
+// attack: the input's callback decides first, then an OpportunityAttackDecider
+// combatant. Anyone else always attacks.
+func takesOpportunityAttack(ctx context.Context, input *MoveEntityInput, threatenerID string) bool {
+	if input.DecideOpportunityAttack != nil {
+		return input.DecideOpportunityAttack(ctx, threatenerID, input.EntityID)
+	}
+	attacker, err := GetCombatantFromContext(ctx, threatenerID)
+	if err != nil {
+		return true // triggerOpportunityAttack reports the missing combatant
+	}
+	if decider, ok := attacker.(OpportunityAttackDecider); ok {
+		return decider.TakeOpportunityAttack(ctx, input.EntityID)
+	}
+	return true
+}
+
+// publishOpportunityAttackChain lets features react to an opportunity attack's outcome
+func publishOpportunityAttackChain(
+	ctx context.Context,
+	bus events.EventBus,
+	targetID string,
+	oaResult *OpportunityAttackResult,
+) (*dnd5eEvents.OpportunityAttackChainEvent, error) {
+	oaEvent := &dnd5eEvents.OpportunityAttackChainEvent{
+		AttackerID: oaResult.AttackerID,
+		TargetID:   targetID,
+		Hit:        oaResult.Hit,
+		Damage:     oaResult.Damage,
+		Critical:   oaResult.Critical,
+	}
+
+	oaChain := events.NewStagedChain[*dnd5eEvents.OpportunityAttackChainEvent](ModifierStages)
+	modifiedChain, err := dnd5eEvents.OpportunityAttackChain.On(bus).PublishWithChain(ctx, oaEvent, oaChain)
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to publish opportunity attack chain")
+	}
+
+	finalEvent, err := modifiedChain.Execute(ctx, oaEvent)
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to execute opportunity attack chain")
+	}
+	return finalEvent, nil
+}
+
 // triggerOpportunityAttack resolves an opportunity attack from attacker against target.
 // Returns nil if the attacker cannot make an attack (no weapon, etc.).
 func triggerOpportunityAttack(
@@ -545,3 +545,276 @@ func (s *MovementTestSuite) TestMoveEntity_OAMissDoesNotStopMovement() {
 	s.False(result.OAsTriggered[0].Hit, "OA should miss")
 	s.Equal(0, result.OAsTriggered[0].Damage)
 }
+
+func (s *MovementTestSuite) TestMoveEntity_DeductsEconomyMovement() {
+	fighter := &testCombatant{id: "fighter-1", entityType: "character"}
+	s.Require().NoError(s.room.PlaceEntity(fighter, spatial.Position{X: 2, Y: 2}))
+
+	economy := combat.NewActionEconomy()
+	economy.SetMovement(30)
+
+	result, err := combat.MoveEntity(s.ctx, &combat.MoveEntityInput{
+		EntityID:   "fighter-1",
+		EntityType: "character",
+		Path:       []spatial.Position{{X: 3, Y: 2}, {X: 4, Y: 2}},
+		EventBus:   s.eventBus,
+		Economy:    economy,
+	})
+	s.Require().NoError(err)
+	s.Equal(10, result.MovementUsed)
+	s.Equal(20, economy.MovementRemaining)
+}
+
+func (s *MovementTestSuite) TestMoveEntity_SplitsMovementAroundAction() {
+	fighter := &testCombatant{id: "fighter-1", entityType: "character"}
+	s.Require().NoError(s.room.PlaceEntity(fighter, spatial.Position{X: 2, Y: 2}))
+
+	economy := combat.NewActionEconomy()
+	economy.SetMovement(30)
+
+	_, err := combat.MoveEntity(s.ctx, &combat.MoveEntityInput{
+		EntityID:   "fighter-1",
+		EntityType: "character",
+		Path:       []spatial.Position{{X: 3, Y: 2}},
+		EventBus:   s.eventBus,
+		Economy:    economy,
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(economy.UseAction())
+
+	result, err := combat.MoveEntity(s.ctx, &combat.MoveEntityInput{
+		EntityID:   "fighter-1",
+		EntityType: "character",
+		Path:       []spatial.Position{{X: 4, Y: 2}, {X: 5, Y: 2}},
+		EventBus:   s.eventBus,
+		Economy:    economy,
+	})
+	s.Require().NoError(err)
+	s.Equal(10, result.MovementUsed)
+	s.Equal(15, economy.MovementUsed)
+	s.Equal(15, economy.MovementRemaining)
+}
+
+func (s *MovementTestSuite) TestMoveEntity_ExtraCostSources() {
+	fighter := &testCombatant{id: "fighter-1", entityType: "character"}
+	s.Require().NoError(s.room.PlaceEntity(fighter, spatial.Position{X: 2, Y: 2}))
+
+	// Simulate crawling: 1 extra foot per foot moved
+	_, err := dnd5eEvents.MovementChain.On(s.eventBus).SubscribeWithChain(s.ctx, func(
+		_ context.Context,
+		_ *dnd5eEvents.MovementChainEvent,
+		c chain.Chain[*dnd5eEvents.MovementChainEvent],
+	) (chain.Chain[*dnd5eEvents.MovementChainEvent], error) {
+		addCost := func(_ context.Context, e *dnd5eEvents.MovementChainEvent) (*dnd5eEvents.MovementChainEvent, error) {
+			e.ExtraCostSources = append(e.ExtraCostSources, dnd5eEvents.MovementCostSource{
+				MovementModifierSource: dnd5eEvents.MovementModifierSource{Name: "Crawling"},
+				ExtraFeetPerFoot:       1,
+			})
+			return e, nil
+		}
+		return c, c.Add(combat.StageConditions, "crawling", addCost)
+	})
+	s.Require().NoError(err)
+
+	economy := combat.NewActionEconomy()
+	economy.SetMovement(15)
+
+	result, err := combat.MoveEntity(s.ctx, &combat.MoveEntityInput{
+		EntityID:   "fighter-1",
+		EntityType: "character",
+		Path:       []spatial.Position{{X: 3, Y: 2}, {X: 4, Y: 2}},
+		EventBus:   s.eventBus,
+		Economy:    economy,
+	})
+	s.Require().NoError(err)
+
+	// The first step costs 10 ft; the second would cost 10 more with only 5 left
+	s.Equal(1, result.StepsCompleted)
+	s.Equal(10, result.MovementUsed)
+	s.True(result.MovementStopped)
+	s.Equal("insufficient movement", result.StopReason)
+	s.Equal(5, economy.MovementRemaining)
+	s.Equal(spatial.Position{X: 3, Y: 2}, result.FinalPosition)
+}
+
+// decidingCombatant is a combatant that chooses whether to take opportunity attacks
+type decidingCombatant struct {
+	*mock_combat.MockCombatant
+	takes bool
+}
+
+func (d *decidingCombatant) TakeOpportunityAttack(_ context.Context, _ string) bool { return d.takes }
+
+// placeFighterNextToGoblin sets up the fighter at (2,2) with a goblin at (2,3),
+// so moving the fighter to (2,1) leaves the goblin's reach
+func (s *MovementTestSuite) placeFighterNextToGoblin(goblin combat.Combatant) {
+	s.Require().NoError(s.room.PlaceEntity(&testCombatant{id: "fighter-1", entityType: "character"},
+		spatial.Position{X: 2, Y: 2}))
+	s.Require().NoError(s.room.PlaceEntity(&testCombatant{id: "goblin-1", entityType: "monster"},
+		spatial.Position{X: 2, Y: 3}))
+
+	mockFighter := mock_combat.NewMockCombatant(s.ctrl)
+	mockFighter.EXPECT().GetID().Return("fighter-1").AnyTimes()
+	mockFighter.EXPECT().AC().Return(16).AnyTimes()
+
+	s.lookup.EXPECT().Get("fighter-1").Return(mockFighter, nil).AnyTimes()
+	s.lookup.EXPECT().Get("goblin-1").Return(goblin, nil).AnyTimes()
+}
+
+// newMockGoblin returns a goblin combatant able to resolve an attack
+func (s *MovementTestSuite) newMockGoblin() *mock_combat.MockCombatant {
+	goblin := mock_combat.NewMockCombatant(s.ctrl)
+	goblin.EXPECT().GetID().Return("goblin-1").AnyTimes()
+	goblin.EXPECT().AbilityScores().Return(shared.AbilityScores{
+		abilities.STR: 8,
+		abilities.DEX: 14,
+	}).AnyTimes()
+	goblin.EXPECT().ProficiencyBonus().Return(2).AnyTimes()
+	return goblin
+}
+
+func (s *MovementTestSuite) TestMoveEntity_DecisionCallbackDeclinesOA() {
+	s.placeFighterNextToGoblin(s.newMockGoblin())
+
+	var asked []string
+	result, err := combat.MoveEntity(s.ctx, &combat.MoveEntityInput{
+		EntityID:   "fighter-1",
+		EntityType: "character",
+		Path:       []spatial.Position{{X: 2, Y: 1}},
+		EventBus:   s.eventBus,
+		DecideOpportunityAttack: func(_ context.Context, attackerID, targetID string) bool {
+			asked = append(asked, attackerID+"->"+targetID)
+			return false
+		},
+	})
+	s.Require().NoError(err)
+
+	s.Equal([]string{"goblin-1->fighter-1"}, asked)
+	s.Empty(result.OAsTriggered)
+	s.Equal([]string{"goblin-1"}, result.OAsDeclined)
+	s.Equal(spatial.Position{X: 2, Y: 1}, result.FinalPosition)
+}
+
+func (s *MovementTestSuite) TestMoveEntity_CombatantDecidesOA() {
+	s.Run("declines", func() {
+		s.SetupTest()
+		s.placeFighterNextToGoblin(&decidingCombatant{MockCombatant: s.newMockGoblin(), takes: false})
+
+		result, err := combat.MoveEntity(s.ctx, &combat.MoveEntityInput{
+			EntityID:   "fighter-1",
+			EntityType: "character",
+			Path:       []spatial.Position{{X: 2, Y: 1}},
+			EventBus:   s.eventBus,
+		})
+		s.Require().NoError(err)
+		s.Empty(result.OAsTriggered)
+		s.Equal([]string{"goblin-1"}, result.OAsDeclined)
+	})
+
+	s.Run("takes", func() {
+		s.SetupTest()
+		s.placeFighterNextToGoblin(&decidingCombatant{MockCombatant: s.newMockGoblin(), takes: true})
+
+		mockRoller := mock_dice.NewMockRoller(s.ctrl)
+		mockRoller.EXPECT().Roll(gomock.Any(), 20).Return(5, nil)
+
+		result, err := combat.MoveEntity(s.ctx, &combat.MoveEntityInput{
+			EntityID:   "fighter-1",
+			EntityType: "character",
+			Path:       []spatial.Position{{X: 2, Y: 1}},
+			EventBus:   s.eventBus,
+			Roller:     mockRoller,
+		})
+		s.Require().NoError(err)
+		s.Len(result.OAsTriggered, 1)
+		s.Empty(result.OAsDeclined)
+	})
+}
+
+func (s *MovementTestSuite) TestMoveEntity_OAExemptionSkipsThreatener() {
+	s.placeFighterNextToGoblin(s.newMockGoblin())
+
+	_, err := dnd5eEvents.MovementChain.On(s.eventBus).SubscribeWithChain(s.ctx, func(
+		_ context.Context,
+		_ *dnd5eEvents.MovementChainEvent,
+		c chain.Chain[*dnd5eEvents.MovementChainEvent],
+	) (chain.Chain[*dnd5eEvents.MovementChainEvent], error) {
+		exempt := func(_ context.Context, e *dnd5eEvents.MovementChainEvent) (*dnd5eEvents.MovementChainEvent, error) {
+			e.OAExemptions = append(e.OAExemptions, dnd5eEvents.OAExemption{
+				MovementModifierSource: dnd5eEvents.MovementModifierSource{Name: "Mobile"},
+				ThreatenerID:           "goblin-1",
+			})
+			return e, nil
+		}
+		return c, c.Add(combat.StageFeatures, "mobile", exempt)
+	})
+	s.Require().NoError(err)
+
+	result, err := combat.MoveEntity(s.ctx, &combat.MoveEntityInput{
+		EntityID:   "fighter-1",
+		EntityType: "character",
+		Path:       []spatial.Position{{X: 2, Y: 1}},
+		EventBus:   s.eventBus,
+	})
+	s.Require().NoError(err)
+	s.Empty(result.OAsTriggered)
+	s.Empty(result.OAsDeclined)
+	s.Equal(1, result.StepsCompleted)
+}
+
+func (s *MovementTestSuite) TestMoveEntity_OAChainStopsMovementOnHit() {
+	s.placeFighterNextToGoblin(s.newMockGoblin())
+
+	var seen *dnd5eEvents.OpportunityAttackChainEvent
+	_, err := dnd5eEvents.OpportunityAttackChain.On(s.eventBus).SubscribeWithChain(s.ctx, func(
+		_ context.Context,
+		event *dnd5eEvents.OpportunityAttackChainEvent,
+		c chain.Chain[*dnd5eEvents.OpportunityAttackChainEvent],
+	) (chain.Chain[*dnd5eEvents.OpportunityAttackChainEvent], error) {
+		seen = event
+		if !event.Hit {
+			return c, nil
+		}
+		stop := func(
+			_ context.Context, e *dnd5eEvents.OpportunityAttackChainEvent,
+		) (*dnd5eEvents.OpportunityAttackChainEvent, error) {
+			e.SpeedZeroSources = append(e.SpeedZeroSources, dnd5eEvents.MovementModifierSource{Name: "Sentinel"})
+			return e, nil
+		}
+		return c, c.Add(combat.StageFeatures, "sentinel", stop)
+	})
+	s.Require().NoError(err)
+
+	mockRoller := mock_dice.NewMockRoller(s.ctrl)
+	mockRoller.EXPECT().Roll(gomock.Any(), 20).Return(18, nil)
+	mockRoller.EXPECT().RollN(gomock.Any(), 1, 1).Return([]int{1}, nil)
+
+	economy := combat.NewActionEconomy()
+	economy.SetMovement(30)
+
+	result, err := combat.MoveEntity(s.ctx, &combat.MoveEntityInput{
+		EntityID:   "fighter-1",
+		EntityType: "character",
+		Path:       []spatial.Position{{X: 2, Y: 1}, {X: 2, Y: 0}},
+		EventBus:   s.eventBus,
+		Roller:     mockRoller,
+		Economy:    economy,
+	})
+	s.Require().NoError(err)
+
+	s.Require().NotNil(seen)
+	s.Equal("goblin-1", seen.AttackerID)
+	s.Equal("fighter-1", seen.TargetID)
+
+	s.Len(result.OAsTriggered, 1)
+	s.True(result.MovementStopped)
+	s.Equal("speed reduced to 0 by Sentinel", result.StopReason)
+	s.Equal(0, result.StepsCompleted)
+	s.Equal(0, result.MovementUsed)
+	s.Equal(0, economy.MovementRemaining)
+	s.Equal(0, economy.MovementUsed)
+	s.Equal(spatial.Position{X: 2, Y: 2}, result.FinalPosition)
+
+	pos, _ := s.room.GetEntityPosition("fighter-1")
+	s.Equal(spatial.Position{X: 2, Y: 2}, pos, "the fighter never leaves the square")
+}
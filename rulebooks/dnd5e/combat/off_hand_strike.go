@@ -0,0 +1,106 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package combat
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/rpg-toolkit/dice"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/weapons"
+)
+
+// ResolveOffHandStrikeInput provides parameters for resolving a requested off-hand strike.
+type ResolveOffHandStrikeInput struct {
+	// Request is the event published by the OffHandStrike action.
+	// An empty WeaponID attacks with the weapon in the off-hand slot.
+	Request dnd5eEvents.OffHandStrikeRequestedEvent
+
+	// EventBus is required for publishing attack/damage events.
+	EventBus events.EventBus
+
+	// Roller is the dice roller for attack and damage rolls.
+	// If nil, a default roller is used.
+	Roller dice.Roller
+}
+
+// Validate validates the input.
+func (r *ResolveOffHandStrikeInput) Validate() error {
+	if r == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "ResolveOffHandStrikeInput is nil")
+	}
+	if r.Request.AttackerID == "" {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "AttackerID is required")
+	}
+	if r.Request.TargetID == "" {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "TargetID is required")
+	}
+	if r.EventBus == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "EventBus is nil")
+	}
+	return nil
+}
+
+// ResolveOffHandStrike resolves an OffHandStrikeRequestedEvent into an off-hand attack
+// and publishes an OffHandStrikeResolvedEvent.
+//
+// The attack goes through ResolveAttack with AttackHandOff, so the two-weapon
+// context in ctx validates the weapons and pays the bonus action, and damage
+// follows the off-hand rules. The off-hand attack itself was already consumed
+// by the action that published the request.
+func ResolveOffHandStrike(ctx context.Context, input *ResolveOffHandStrikeInput) (*AttackResult, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	request := input.Request
+
+	var weapon *weapons.Weapon
+	if request.WeaponID != "" {
+		w, err := weapons.GetByID(weapons.WeaponID(request.WeaponID))
+		if err != nil {
+			return nil, rpgerr.Wrapf(err, "unknown off-hand weapon: %s", request.WeaponID)
+		}
+		weapon = &w
+	}
+
+	result, err := ResolveAttack(ctx, &AttackInput{
+		AttackerID: request.AttackerID,
+		TargetID:   request.TargetID,
+		Weapon:     weapon,
+		EventBus:   input.EventBus,
+		Roller:     input.Roller,
+		AttackHand: AttackHandOff,
+	})
+	if err != nil {
+		return nil, rpgerr.Wrapf(err, "failed to resolve off-hand strike for %s", request.AttackerID)
+	}
+
+	weaponID := request.WeaponID
+	if weaponID == "" {
+		if twc, ok := GetTwoWeaponContext(ctx); ok {
+			if offHand := twc.GetOffHandWeapon(request.AttackerID); offHand != nil {
+				weaponID = string(offHand.WeaponID)
+			}
+		}
+	}
+
+	resolved := dnd5eEvents.OffHandStrikeResolvedTopic.On(input.EventBus)
+	if err := resolved.Publish(ctx, dnd5eEvents.OffHandStrikeResolvedEvent{
+		AttackerID:  request.AttackerID,
+		TargetID:    request.TargetID,
+		WeaponID:    weaponID,
+		ActionID:    request.ActionID,
+		Hit:         result.Hit,
+		Critical:    result.Critical,
+		TotalDamage: result.TotalDamage,
+		DamageType:  result.DamageType,
+	}); err != nil {
+		return nil, rpgerr.Wrap(err, "failed to publish off-hand strike resolved event")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,478 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package combat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/dice"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+)
+
+// TurnPhase is a point in a creature's turn when ongoing effects resolve.
+type TurnPhase string
+
+const (
+	// TurnPhaseStart resolves when the creature's turn starts.
+	TurnPhaseStart TurnPhase = "turn_start"
+
+	// TurnPhaseEnd resolves when the creature's turn ends.
+	TurnPhaseEnd TurnPhase = "turn_end"
+)
+
+// Reasons ongoing damage can end.
+const (
+	OngoingDamageEndedSaved            = "saved"
+	OngoingDamageEndedConditionRemoved = "condition_removed"
+	OngoingDamageEndedConcentration    = "concentration_ended"
+	OngoingDamageEndedDurationExpired  = "duration_expired"
+	OngoingDamageEndedRemoved          = "removed"
+)
+
+// OngoingDamageSave is the saving throw a creature repeats to end ongoing damage.
+type OngoingDamageSave struct {
+	// Ability is the ability used for the saving throw.
+	Ability abilities.Ability
+
+	// DC is the Difficulty Class the creature must meet or exceed.
+	DC int
+
+	// Phase is when the creature repeats the save. Defaults to the damage's phase,
+	// in which case the save comes first and a success ends the effect before
+	// any damage (Immolation: damage on a failed save, ends on a successful one).
+	Phase TurnPhase
+}
+
+// OngoingDamage is damage a creature takes each turn from a spell or condition,
+// such as Immolation's fire or a bleeding wound.
+//
+// The fields are exported so game servers can persist effects between sessions
+// and restore them with OngoingDamageScheduler.Add.
+type OngoingDamage struct {
+	// ID uniquely identifies the effect within the scheduler.
+	ID string
+
+	// TargetID is the creature taking the damage.
+	TargetID string
+
+	// SourceID is the creature causing the damage (optional).
+	SourceID string
+
+	// EffectRef identifies the spell, condition or feature causing the damage.
+	EffectRef *core.Ref
+
+	// Dice is the damage rolled each tick (e.g. "3d6" or "1d4+1").
+	Dice string
+
+	// DamageType is the type of the damage.
+	DamageType damage.Type
+
+	// Phase is when the damage is dealt in the target's turn.
+	Phase TurnPhase
+
+	// Save lets the target end the effect with a saving throw (optional).
+	Save *OngoingDamageSave
+
+	// EndsWithCondition ends the effect when this condition is removed from
+	// the target (optional).
+	EndsWithCondition *core.Ref
+
+	// Concentration ends the effect when SourceID stops concentrating on the
+	// spell identified by EffectRef.
+	Concentration bool
+
+	// MaxTicks ends the effect after it has dealt damage this many times.
+	// Zero means it lasts until it is otherwise ended.
+	MaxTicks int
+
+	// Ticks is how many times the effect has dealt damage.
+	Ticks int
+}
+
+// Validate validates the effect fields.
+func (o *OngoingDamage) Validate() error {
+	if o == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "OngoingDamage is nil")
+	}
+	if o.ID == "" {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "ID is required")
+	}
+	if o.TargetID == "" {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "TargetID is required")
+	}
+	if _, err := dice.ParseNotation(o.Dice); err != nil {
+		return rpgerr.Wrapf(err, "invalid damage dice %q", o.Dice)
+	}
+	if err := o.Phase.validate(); err != nil {
+		return err
+	}
+	if o.Save != nil && o.Save.Phase != "" {
+		if err := o.Save.Phase.validate(); err != nil {
+			return err
+		}
+	}
+	if o.Concentration && (o.SourceID == "" || o.EffectRef == nil) {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "Concentration requires SourceID and EffectRef")
+	}
+	if o.MaxTicks < 0 {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "MaxTicks cannot be negative")
+	}
+	return nil
+}
+
+// validate reports whether the phase is known.
+func (p TurnPhase) validate() error {
+	switch p {
+	case TurnPhaseStart, TurnPhaseEnd:
+		return nil
+	default:
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument, "unknown turn phase: %q", p)
+	}
+}
+
+// savePhase returns when the target repeats its save.
+func (o *OngoingDamage) savePhase() TurnPhase {
+	if o.Save.Phase != "" {
+		return o.Save.Phase
+	}
+	return o.Phase
+}
+
+// OngoingDamageSchedulerConfig configures the ongoing damage scheduler.
+type OngoingDamageSchedulerConfig struct {
+	// Combatants looks up the creatures taking damage
+	Combatants CombatantLookup
+
+	// Roller is the dice roller for damage and saves. If nil, a default roller is used.
+	Roller dice.Roller
+}
+
+// Validate validates the config.
+func (c *OngoingDamageSchedulerConfig) Validate() error {
+	if c == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "OngoingDamageSchedulerConfig is nil")
+	}
+	if c.Combatants == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "Combatants is required")
+	}
+	return nil
+}
+
+// OngoingDamageScheduler deals ongoing damage at the turn phases each effect
+// names, using the turn start and end events as the combat clock:
+//   - At the effect's save phase, the target repeats its save; a success ends the effect
+//   - At the effect's damage phase, the damage is rolled and dealt through DealDamage,
+//     so resistances and other DamageChain modifiers apply
+//   - An effect ends when its condition is removed from the target, when its
+//     source stops concentrating on it, or after MaxTicks
+//
+// Every ended effect publishes an OngoingDamageEndedEvent, so games can remove
+// the spell or condition that caused it. Apply it once per bus.
+type OngoingDamageScheduler struct {
+	combatants      CombatantLookup
+	roller          dice.Roller
+	effects         map[string]*OngoingDamage
+	bus             events.EventBus
+	subscriptionIDs []string
+}
+
+// NewOngoingDamageScheduler creates an ongoing damage scheduler from config.
+func NewOngoingDamageScheduler(config *OngoingDamageSchedulerConfig) (*OngoingDamageScheduler, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	roller := config.Roller
+	if roller == nil {
+		roller = dice.NewRoller()
+	}
+
+	return &OngoingDamageScheduler{
+		combatants: config.Combatants,
+		roller:     roller,
+		effects:    make(map[string]*OngoingDamage),
+	}, nil
+}
+
+// Add schedules an ongoing damage effect.
+// Returns CodeAlreadyExists if an effect with the same ID is scheduled.
+func (s *OngoingDamageScheduler) Add(effect *OngoingDamage) error {
+	if err := effect.Validate(); err != nil {
+		return err
+	}
+	if _, exists := s.effects[effect.ID]; exists {
+		return rpgerr.Newf(rpgerr.CodeAlreadyExists, "ongoing damage %s already scheduled", effect.ID)
+	}
+	s.effects[effect.ID] = effect
+	return nil
+}
+
+// End stops an ongoing damage effect early, e.g. when a creature stanches a
+// bleeding wound with a Medicine check. Returns CodeNotFound for an unknown effect.
+func (s *OngoingDamageScheduler) End(ctx context.Context, effectID string) error {
+	effect, ok := s.effects[effectID]
+	if !ok {
+		return rpgerr.Newf(rpgerr.CodeNotFound, "ongoing damage %s not found", effectID)
+	}
+	return s.end(ctx, effect, OngoingDamageEndedRemoved)
+}
+
+// Effects returns the scheduled effects, ordered by ID.
+func (s *OngoingDamageScheduler) Effects() []*OngoingDamage {
+	effects := make([]*OngoingDamage, 0, len(s.effects))
+	for _, effect := range s.effects {
+		effects = append(effects, effect)
+	}
+	sort.Slice(effects, func(i, j int) bool {
+		return effects[i].ID < effects[j].ID
+	})
+	return effects
+}
+
+// IsApplied returns true if the scheduler is subscribed to a bus.
+func (s *OngoingDamageScheduler) IsApplied() bool {
+	return s.bus != nil
+}
+
+// Apply subscribes the scheduler to turn start and end, condition removed, and
+// concentration ended events.
+func (s *OngoingDamageScheduler) Apply(ctx context.Context, bus events.EventBus) error {
+	if s.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "ongoing damage scheduler already applied")
+	}
+	s.bus = bus
+
+	turnStartSubID, err := dnd5eEvents.TurnStartTopic.On(bus).Subscribe(ctx, s.onTurnStart)
+	if err != nil {
+		s.bus = nil
+		return rpgerr.Wrap(err, "failed to subscribe to turn start")
+	}
+	s.subscriptionIDs = append(s.subscriptionIDs, turnStartSubID)
+
+	turnEndSubID, err := dnd5eEvents.TurnEndTopic.On(bus).Subscribe(ctx, s.onTurnEnd)
+	if err != nil {
+		_ = s.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to turn end")
+	}
+	s.subscriptionIDs = append(s.subscriptionIDs, turnEndSubID)
+
+	removedSubID, err := dnd5eEvents.ConditionRemovedTopic.On(bus).Subscribe(ctx, s.onConditionRemoved)
+	if err != nil {
+		_ = s.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to condition removed")
+	}
+	s.subscriptionIDs = append(s.subscriptionIDs, removedSubID)
+
+	concentrationSubID, err := dnd5eEvents.ConcentrationEndedTopic.On(bus).Subscribe(ctx, s.onConcentrationEnded)
+	if err != nil {
+		_ = s.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to concentration ended")
+	}
+	s.subscriptionIDs = append(s.subscriptionIDs, concentrationSubID)
+
+	return nil
+}
+
+// Remove unsubscribes the scheduler from all events. Scheduled effects are kept.
+func (s *OngoingDamageScheduler) Remove(ctx context.Context, bus events.EventBus) error {
+	if s.bus == nil {
+		return nil // Not applied, nothing to remove
+	}
+
+	total := len(s.subscriptionIDs)
+	var errs []error
+	for _, subID := range s.subscriptionIDs {
+		if err := bus.Unsubscribe(ctx, subID); err != nil {
+			errs = append(errs, fmt.Errorf("unsubscribe %s: %w", subID, err))
+		}
+	}
+
+	s.subscriptionIDs = nil
+	s.bus = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unsubscribe %d/%d subscriptions: %w", len(errs), total, errors.Join(errs...))
+	}
+	return nil
+}
+
+// onTurnStart resolves the effects on the creature whose turn is starting.
+func (s *OngoingDamageScheduler) onTurnStart(ctx context.Context, event dnd5eEvents.TurnStartEvent) error {
+	return s.resolvePhase(ctx, event.CharacterID, TurnPhaseStart)
+}
+
+// onTurnEnd resolves the effects on the creature whose turn is ending.
+func (s *OngoingDamageScheduler) onTurnEnd(ctx context.Context, event dnd5eEvents.TurnEndEvent) error {
+	return s.resolvePhase(ctx, event.CharacterID, TurnPhaseEnd)
+}
+
+// resolvePhase repeats saves and deals damage for each of the creature's
+// effects that resolve in the phase, in ID order.
+func (s *OngoingDamageScheduler) resolvePhase(ctx context.Context, targetID string, phase TurnPhase) error {
+	ctx = WithCombatantLookup(ctx, s.combatants)
+
+	for _, effect := range s.Effects() {
+		if effect.TargetID != targetID {
+			continue
+		}
+
+		if effect.Save != nil && effect.savePhase() == phase {
+			saved, err := s.repeatSave(ctx, effect)
+			if err != nil {
+				return err
+			}
+			if saved {
+				if err := s.end(ctx, effect, OngoingDamageEndedSaved); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		if effect.Phase != phase {
+			continue
+		}
+		if err := s.tick(ctx, effect); err != nil {
+			return err
+		}
+		if effect.MaxTicks > 0 && effect.Ticks >= effect.MaxTicks {
+			if err := s.end(ctx, effect, OngoingDamageEndedDurationExpired); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// repeatSave rolls the target's save against the effect through the SavingThrowChain.
+func (s *OngoingDamageScheduler) repeatSave(ctx context.Context, effect *OngoingDamage) (bool, error) {
+	target, err := s.combatants.Get(effect.TargetID)
+	if err != nil {
+		return false, rpgerr.Wrapf(err, "failed to find ongoing damage target %s", effect.TargetID)
+	}
+
+	trigger := dnd5eEvents.SaveTriggerCondition
+	if effect.Concentration {
+		trigger = dnd5eEvents.SaveTriggerSpell
+	}
+
+	outcome, err := rollSavingThrow(ctx, s.bus, s.roller, &dnd5eEvents.SavingThrowChainEvent{
+		SaverID: effect.TargetID,
+		Ability: effect.Save.Ability,
+		DC:      effect.Save.DC,
+		Cause: dnd5eEvents.SaveCause{
+			Trigger:      trigger,
+			EffectRef:    effect.EffectRef,
+			InstigatorID: effect.SourceID,
+			DamageType:   effect.DamageType,
+		},
+	}, saveModifier(target, effect.Save.Ability))
+	if err != nil {
+		return false, rpgerr.Wrapf(err, "failed to repeat save against ongoing damage %s", effect.ID)
+	}
+	return outcome.Success, nil
+}
+
+// tick rolls the effect's damage and deals it to the target.
+func (s *OngoingDamageScheduler) tick(ctx context.Context, effect *OngoingDamage) error {
+	target, err := s.combatants.Get(effect.TargetID)
+	if err != nil {
+		return rpgerr.Wrapf(err, "failed to find ongoing damage target %s", effect.TargetID)
+	}
+
+	pool, err := dice.ParseNotation(effect.Dice)
+	if err != nil {
+		return rpgerr.Wrapf(err, "invalid damage dice %s", effect.Dice)
+	}
+	rolled := pool.RollContext(ctx, s.roller)
+	if rolled.Error() != nil {
+		return rpgerr.Wrapf(rolled.Error(), "failed to roll ongoing damage %s", effect.ID)
+	}
+	var rolls []int
+	for _, group := range rolled.Rolls() {
+		rolls = append(rolls, group...)
+	}
+
+	_, err = DealDamage(ctx, &DealDamageInput{
+		Target:     target,
+		AttackerID: effect.SourceID,
+		Source:     DamageSourceCondition,
+		Components: []dnd5eEvents.DamageComponent{{
+			Source:            dnd5eEvents.DamageSourceCondition,
+			SourceRef:         effect.EffectRef,
+			OriginalDiceRolls: rolls,
+			FinalDiceRolls:    rolls,
+			FlatBonus:         rolled.Modifier(),
+			DamageType:        effect.DamageType,
+		}},
+		EventBus: s.bus,
+	})
+	if err != nil {
+		return rpgerr.Wrapf(err, "failed to deal ongoing damage %s", effect.ID)
+	}
+
+	effect.Ticks++
+	return nil
+}
+
+// onConditionRemoved ends effects tied to a condition removed from their target.
+func (s *OngoingDamageScheduler) onConditionRemoved(ctx context.Context, event dnd5eEvents.ConditionRemovedEvent) error {
+	for _, effect := range s.Effects() {
+		if effect.EndsWithCondition == nil || effect.TargetID != event.CharacterID {
+			continue
+		}
+		if effect.EndsWithCondition.String() != event.ConditionRef {
+			continue
+		}
+		if err := s.end(ctx, effect, OngoingDamageEndedConditionRemoved); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// onConcentrationEnded ends effects from a spell its caster stopped concentrating on.
+func (s *OngoingDamageScheduler) onConcentrationEnded(
+	ctx context.Context,
+	event dnd5eEvents.ConcentrationEndedEvent,
+) error {
+	for _, effect := range s.Effects() {
+		if !effect.Concentration || effect.SourceID != event.CasterID || effect.EffectRef.ID != event.SpellID {
+			continue
+		}
+		if err := s.end(ctx, effect, OngoingDamageEndedConcentration); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// end unschedules the effect and publishes an OngoingDamageEndedEvent when applied.
+func (s *OngoingDamageScheduler) end(ctx context.Context, effect *OngoingDamage, reason string) error {
+	delete(s.effects, effect.ID)
+	if s.bus == nil {
+		return nil
+	}
+
+	err := dnd5eEvents.OngoingDamageEndedTopic.On(s.bus).Publish(ctx, dnd5eEvents.OngoingDamageEndedEvent{
+		EffectID:  effect.ID,
+		TargetID:  effect.TargetID,
+		SourceID:  effect.SourceID,
+		EffectRef: effect.EffectRef,
+		Reason:    reason,
+	})
+	if err != nil {
+		return rpgerr.Wrapf(err, "failed to publish ongoing damage ended for %s", effect.ID)
+	}
+	return nil
+}
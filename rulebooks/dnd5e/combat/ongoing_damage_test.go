@@ -0,0 +1,311 @@
+package combat_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+)
+
+var immolation = &core.Ref{Module: "dnd5e", Type: "spells", ID: "immolation"}
+
+type OngoingDamageTestSuite struct {
+	suite.Suite
+	ctrl      *gomock.Controller
+	ctx       context.Context
+	eventBus  events.EventBus
+	roller    *mock_dice.MockRoller
+	orc       *mockCombatant
+	goblin    *mockCombatant
+	scheduler *combat.OngoingDamageScheduler
+	ended     []dnd5eEvents.OngoingDamageEndedEvent
+	damage    []dnd5eEvents.DamageReceivedEvent
+}
+
+func TestOngoingDamageSuite(t *testing.T) {
+	suite.Run(t, new(OngoingDamageTestSuite))
+}
+
+func (s *OngoingDamageTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.ctx = context.Background()
+	s.eventBus = events.NewEventBus()
+	s.roller = mock_dice.NewMockRoller(s.ctrl)
+	s.orc = &mockCombatant{
+		id: "orc", hitPoints: 30, maxHitPoints: 30,
+		abilityScores: shared.AbilityScores{abilities.DEX: 12, abilities.CON: 16},
+	}
+	s.goblin = &mockCombatant{id: "goblin", hitPoints: 7, maxHitPoints: 7}
+
+	var err error
+	s.scheduler, err = combat.NewOngoingDamageScheduler(&combat.OngoingDamageSchedulerConfig{
+		Combatants: combatantMap{"orc": s.orc, "goblin": s.goblin},
+		Roller:     s.roller,
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(s.scheduler.Apply(s.ctx, s.eventBus))
+
+	s.ended = nil
+	_, err = dnd5eEvents.OngoingDamageEndedTopic.On(s.eventBus).Subscribe(s.ctx,
+		func(_ context.Context, e dnd5eEvents.OngoingDamageEndedEvent) error {
+			s.ended = append(s.ended, e)
+			return nil
+		})
+	s.Require().NoError(err)
+
+	s.damage = nil
+	_, err = dnd5eEvents.DamageReceivedTopic.On(s.eventBus).Subscribe(s.ctx,
+		func(_ context.Context, e dnd5eEvents.DamageReceivedEvent) error {
+			s.damage = append(s.damage, e)
+			return nil
+		})
+	s.Require().NoError(err)
+}
+
+func (s *OngoingDamageTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// immolation builds the Immolation effect on the orc: 3d6 fire at the end of
+// each of its turns until it makes a DC 15 DEX save
+func (s *OngoingDamageTestSuite) immolation() *combat.OngoingDamage {
+	return &combat.OngoingDamage{
+		ID:            "immolation-orc",
+		TargetID:      "orc",
+		SourceID:      "wizard",
+		EffectRef:     immolation,
+		Dice:          "3d6",
+		DamageType:    damage.Fire,
+		Phase:         combat.TurnPhaseEnd,
+		Save:          &combat.OngoingDamageSave{Ability: abilities.DEX, DC: 15},
+		Concentration: true,
+	}
+}
+
+// poison builds a poison effect on the orc dealing 1d4 at the start of its turns
+func (s *OngoingDamageTestSuite) poison() *combat.OngoingDamage {
+	return &combat.OngoingDamage{
+		ID:                "poison-orc",
+		TargetID:          "orc",
+		EffectRef:         refs.Conditions.Poisoned(),
+		Dice:              "1d4",
+		DamageType:        damage.Poison,
+		Phase:             combat.TurnPhaseStart,
+		EndsWithCondition: refs.Conditions.Poisoned(),
+	}
+}
+
+func (s *OngoingDamageTestSuite) startTurn(id string) {
+	s.Require().NoError(dnd5eEvents.TurnStartTopic.On(s.eventBus).Publish(s.ctx,
+		dnd5eEvents.TurnStartEvent{CharacterID: id}))
+}
+
+func (s *OngoingDamageTestSuite) endTurn(id string) {
+	s.Require().NoError(dnd5eEvents.TurnEndTopic.On(s.eventBus).Publish(s.ctx,
+		dnd5eEvents.TurnEndEvent{CharacterID: id}))
+}
+
+func (s *OngoingDamageTestSuite) TestValidate() {
+	testCases := []struct {
+		name   string
+		modify func(*combat.OngoingDamage)
+	}{
+		{"missing ID", func(o *combat.OngoingDamage) { o.ID = "" }},
+		{"missing target", func(o *combat.OngoingDamage) { o.TargetID = "" }},
+		{"bad dice", func(o *combat.OngoingDamage) { o.Dice = "lots" }},
+		{"unknown phase", func(o *combat.OngoingDamage) { o.Phase = "midnight" }},
+		{"unknown save phase", func(o *combat.OngoingDamage) { o.Save.Phase = "midnight" }},
+		{"concentration without source", func(o *combat.OngoingDamage) { o.SourceID = "" }},
+		{"negative max ticks", func(o *combat.OngoingDamage) { o.MaxTicks = -1 }},
+	}
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			effect := s.immolation()
+			tc.modify(effect)
+			s.Error(s.scheduler.Add(effect))
+		})
+	}
+
+	s.Run("nil config", func() {
+		_, err := combat.NewOngoingDamageScheduler(nil)
+		s.Error(err)
+	})
+	s.Run("missing combatants", func() {
+		_, err := combat.NewOngoingDamageScheduler(&combat.OngoingDamageSchedulerConfig{})
+		s.Error(err)
+	})
+}
+
+func (s *OngoingDamageTestSuite) TestAdd() {
+	s.Require().NoError(s.scheduler.Add(s.poison()))
+	s.Require().NoError(s.scheduler.Add(s.immolation()))
+
+	err := s.scheduler.Add(s.poison())
+	s.Equal(rpgerr.CodeAlreadyExists, rpgerr.GetCode(err))
+
+	effects := s.scheduler.Effects()
+	s.Require().Len(effects, 2)
+	s.Equal("immolation-orc", effects[0].ID)
+	s.Equal("poison-orc", effects[1].ID)
+
+	s.Equal(rpgerr.CodeAlreadyExists, rpgerr.GetCode(s.scheduler.Apply(s.ctx, s.eventBus)))
+}
+
+func (s *OngoingDamageTestSuite) TestSaveFirstAtTheDamagePhase() {
+	s.Require().NoError(s.scheduler.Add(s.immolation()))
+
+	// The turn starting and other creatures' turns ending don't trigger it
+	s.startTurn("orc")
+	s.endTurn("goblin")
+	s.Empty(s.damage)
+
+	// Failed save (8 + 1 < 15): 3d6 fire
+	gomock.InOrder(
+		s.roller.EXPECT().Roll(gomock.Any(), 20).Return(8, nil),
+		s.roller.EXPECT().RollN(gomock.Any(), 3, 6).Return([]int{4, 5, 6}, nil),
+	)
+	s.endTurn("orc")
+	s.Require().Len(s.damage, 1)
+	s.Equal(dnd5eEvents.DamageReceivedEvent{
+		TargetID: "orc", SourceID: "wizard", Amount: 15, DamageType: damage.Fire,
+	}, s.damage[0])
+	s.Equal(15, s.orc.hitPoints)
+	s.Equal(1, s.scheduler.Effects()[0].Ticks)
+
+	// Successful save (14 + 1 >= 15) ends it with no damage
+	s.roller.EXPECT().Roll(gomock.Any(), 20).Return(14, nil)
+	s.endTurn("orc")
+	s.Len(s.damage, 1)
+	s.Empty(s.scheduler.Effects())
+	s.Require().Len(s.ended, 1)
+	s.Equal(dnd5eEvents.OngoingDamageEndedEvent{
+		EffectID: "immolation-orc", TargetID: "orc", SourceID: "wizard",
+		EffectRef: immolation, Reason: combat.OngoingDamageEndedSaved,
+	}, s.ended[0])
+}
+
+func (s *OngoingDamageTestSuite) TestSaveInAnotherPhase() {
+	effect := s.poison()
+	effect.Save = &combat.OngoingDamageSave{Ability: abilities.CON, DC: 12, Phase: combat.TurnPhaseEnd}
+	s.Require().NoError(s.scheduler.Add(effect))
+
+	s.roller.EXPECT().RollN(gomock.Any(), 1, 4).Return([]int{3}, nil)
+	s.startTurn("orc")
+	s.Equal(27, s.orc.hitPoints)
+
+	// 9 + 3 meets DC 12
+	s.roller.EXPECT().Roll(gomock.Any(), 20).Return(9, nil)
+	s.endTurn("orc")
+	s.Empty(s.scheduler.Effects())
+	s.Require().Len(s.ended, 1)
+	s.Equal(combat.OngoingDamageEndedSaved, s.ended[0].Reason)
+}
+
+func (s *OngoingDamageTestSuite) TestDamageGoesThroughTheDamageChain() {
+	s.Require().NoError(s.scheduler.Add(s.poison()))
+
+	// The orc resists poison
+	_, err := dnd5eEvents.DamageChain.On(s.eventBus).SubscribeWithChain(s.ctx,
+		func(_ context.Context, e *dnd5eEvents.DamageChainEvent, c chain.Chain[*dnd5eEvents.DamageChainEvent],
+		) (chain.Chain[*dnd5eEvents.DamageChainEvent], error) {
+			if e.TargetID != "orc" {
+				return c, nil
+			}
+			return c, c.Add(combat.StageFinal, "poison_resistance",
+				func(_ context.Context, e *dnd5eEvents.DamageChainEvent) (*dnd5eEvents.DamageChainEvent, error) {
+					e.Components = append(e.Components, dnd5eEvents.DamageComponent{
+						Source:     dnd5eEvents.DamageSourceCondition,
+						DamageType: damage.Poison,
+						Multiplier: 0.5,
+					})
+					return e, nil
+				})
+		})
+	s.Require().NoError(err)
+
+	s.roller.EXPECT().RollN(gomock.Any(), 1, 4).Return([]int{4}, nil)
+	s.startTurn("orc")
+	s.Equal(28, s.orc.hitPoints)
+}
+
+func (s *OngoingDamageTestSuite) TestMaxTicks() {
+	effect := s.poison()
+	effect.MaxTicks = 2
+	s.Require().NoError(s.scheduler.Add(effect))
+
+	s.roller.EXPECT().RollN(gomock.Any(), 1, 4).Return([]int{1}, nil).Times(2)
+	s.startTurn("orc")
+	s.Empty(s.ended)
+	s.startTurn("orc")
+	s.Require().Len(s.ended, 1)
+	s.Equal(combat.OngoingDamageEndedDurationExpired, s.ended[0].Reason)
+
+	s.startTurn("orc")
+	s.Equal(28, s.orc.hitPoints)
+}
+
+func (s *OngoingDamageTestSuite) TestEndsWithCondition() {
+	s.Require().NoError(s.scheduler.Add(s.poison()))
+
+	// Removing the condition from another creature leaves it in place
+	s.Require().NoError(dnd5eEvents.ConditionRemovedTopic.On(s.eventBus).Publish(s.ctx,
+		dnd5eEvents.ConditionRemovedEvent{CharacterID: "goblin", ConditionRef: refs.Conditions.Poisoned().String()}))
+	s.Len(s.scheduler.Effects(), 1)
+
+	s.Require().NoError(dnd5eEvents.ConditionRemovedTopic.On(s.eventBus).Publish(s.ctx,
+		dnd5eEvents.ConditionRemovedEvent{CharacterID: "orc", ConditionRef: refs.Conditions.Poisoned().String()}))
+	s.Empty(s.scheduler.Effects())
+	s.Require().Len(s.ended, 1)
+	s.Equal(combat.OngoingDamageEndedConditionRemoved, s.ended[0].Reason)
+
+	s.startTurn("orc")
+	s.Empty(s.damage)
+}
+
+func (s *OngoingDamageTestSuite) TestEndsWithConcentration() {
+	s.Require().NoError(s.scheduler.Add(s.immolation()))
+
+	s.Require().NoError(dnd5eEvents.ConcentrationEndedTopic.On(s.eventBus).Publish(s.ctx,
+		dnd5eEvents.ConcentrationEndedEvent{CasterID: "cleric", SpellID: "immolation"}))
+	s.Len(s.scheduler.Effects(), 1)
+
+	s.Require().NoError(dnd5eEvents.ConcentrationEndedTopic.On(s.eventBus).Publish(s.ctx,
+		dnd5eEvents.ConcentrationEndedEvent{CasterID: "wizard", SpellID: "immolation", Reason: "failed_save"}))
+	s.Empty(s.scheduler.Effects())
+	s.Require().Len(s.ended, 1)
+	s.Equal(combat.OngoingDamageEndedConcentration, s.ended[0].Reason)
+}
+
+func (s *OngoingDamageTestSuite) TestEnd() {
+	s.Require().NoError(s.scheduler.Add(s.poison()))
+
+	s.Equal(rpgerr.CodeNotFound, rpgerr.GetCode(s.scheduler.End(s.ctx, "bleeding-goblin")))
+
+	s.Require().NoError(s.scheduler.End(s.ctx, "poison-orc"))
+	s.Empty(s.scheduler.Effects())
+	s.Require().Len(s.ended, 1)
+	s.Equal(combat.OngoingDamageEndedRemoved, s.ended[0].Reason)
+}
+
+func (s *OngoingDamageTestSuite) TestRemoveStopsTheClock() {
+	s.Require().NoError(s.scheduler.Add(s.poison()))
+	s.Require().NoError(s.scheduler.Remove(s.ctx, s.eventBus))
+	s.False(s.scheduler.IsApplied())
+
+	s.startTurn("orc")
+	s.Empty(s.damage)
+	s.Len(s.scheduler.Effects(), 1)
+}
@@ -0,0 +1,255 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package combat
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+)
+
+// ReactionDecider decides whether a reactor takes a reaction. A player client
+// asks the player; an AI decides on its own. Deciders run inside the flow that
+// raised the trigger, which waits for the answer.
+type ReactionDecider interface {
+	// DecideReaction answers a prompt. An error declines the reaction.
+	DecideReaction(ctx context.Context, prompt *dnd5eEvents.ReactionPrompt) (*dnd5eEvents.ReactionResponse, error)
+}
+
+// ReactionDeciderFunc adapts a function to a ReactionDecider.
+type ReactionDeciderFunc func(ctx context.Context, prompt *dnd5eEvents.ReactionPrompt) (*dnd5eEvents.ReactionResponse, error)
+
+// DecideReaction calls f.
+func (f ReactionDeciderFunc) DecideReaction(
+	ctx context.Context,
+	prompt *dnd5eEvents.ReactionPrompt,
+) (*dnd5eEvents.ReactionResponse, error) {
+	return f(ctx, prompt)
+}
+
+// DeclineReason says why a prompted reaction wasn't taken.
+type DeclineReason string
+
+const (
+	// DeclineReasonDecided means the decider chose not to react.
+	DeclineReasonDecided DeclineReason = "declined"
+
+	// DeclineReasonNoDecider means no decider is registered for the reactor.
+	DeclineReasonNoDecider DeclineReason = "no_decider"
+
+	// DeclineReasonTimeout means the decider didn't answer before the deadline.
+	DeclineReasonTimeout DeclineReason = "timeout"
+
+	// DeclineReasonError means the decider returned an error.
+	DeclineReasonError DeclineReason = "error"
+
+	// DeclineReasonReactionUsed means the reactor already took a reaction in
+	// this flow. A creature has one reaction per round.
+	DeclineReasonReactionUsed DeclineReason = "reaction_used"
+)
+
+// ReactionDecision is the outcome of prompting one trigger.
+type ReactionDecision struct {
+	// Trigger is the reaction opportunity that was offered.
+	Trigger dnd5eEvents.ReactionTriggerEvent
+
+	// Taken is true when the reactor took the reaction.
+	Taken bool
+
+	// DeclineReason says why the reaction wasn't taken. Empty when Taken.
+	DeclineReason DeclineReason
+
+	// Err is the decider's error when DeclineReason is DeclineReasonError.
+	Err error
+}
+
+// ReactionPrompterConfig configures a ReactionPrompter.
+type ReactionPrompterConfig struct {
+	// Timeout bounds each decision. Zero waits as long as the context allows.
+	Timeout time.Duration
+
+	// Fallback decides for reactors with no registered decider, e.g. an AI
+	// for every monster. Nil declines.
+	Fallback ReactionDecider
+}
+
+// ReactionPrompter turns reaction triggers into decisions. The policy is
+// default-decline: a reactor with no decider, a decider that errors, and a
+// decider that misses the deadline all decline, so a missing or slow client
+// never stalls combat or spends a reaction the player didn't choose.
+type ReactionPrompter struct {
+	mu       sync.RWMutex
+	deciders map[string]ReactionDecider
+	timeout  time.Duration
+	fallback ReactionDecider
+}
+
+// NewReactionPrompter creates a reaction prompter.
+func NewReactionPrompter(config *ReactionPrompterConfig) *ReactionPrompter {
+	p := &ReactionPrompter{deciders: make(map[string]ReactionDecider)}
+	if config != nil {
+		p.timeout = config.Timeout
+		p.fallback = config.Fallback
+	}
+	return p
+}
+
+// Register sets the decider for a reactor, replacing any previous one.
+func (p *ReactionPrompter) Register(reactorID string, decider ReactionDecider) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.deciders[reactorID] = decider
+}
+
+// Unregister removes a reactor's decider.
+func (p *ReactionPrompter) Unregister(reactorID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.deciders, reactorID)
+}
+
+// Prompt asks each trigger's reactor whether to react, in trigger order, and
+// publishes a ReactionUsedEvent on the bus for each reaction taken. A reactor
+// who takes one reaction declines the rest: they have one reaction per round.
+func (p *ReactionPrompter) Prompt(
+	ctx context.Context,
+	bus events.EventBus,
+	triggers []dnd5eEvents.ReactionTriggerEvent,
+) ([]ReactionDecision, error) {
+	if bus == nil {
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "EventBus is required")
+	}
+
+	decisions := make([]ReactionDecision, 0, len(triggers))
+	reacted := make(map[string]bool)
+	for _, trigger := range triggers {
+		var decision ReactionDecision
+		if reacted[trigger.ReactorID] {
+			decision = ReactionDecision{Trigger: trigger, DeclineReason: DeclineReasonReactionUsed}
+		} else {
+			decision = p.decide(ctx, trigger)
+		}
+
+		if decision.Taken {
+			reacted[trigger.ReactorID] = true
+			used := dnd5eEvents.ReactionUsedEvent{
+				CharacterID: trigger.ReactorID,
+				Reason:      string(trigger.TriggerKind),
+			}
+			if ref, err := core.ParseString(trigger.ConditionRef); err == nil {
+				used.FeatureRef = ref
+			}
+			if err := dnd5eEvents.ReactionUsedTopic.On(bus).Publish(ctx, used); err != nil {
+				return nil, rpgerr.Wrap(err, "failed to publish reaction used event")
+			}
+		}
+		decisions = append(decisions, decision)
+	}
+	return decisions, nil
+}
+
+// decide asks one trigger's decider, declining on no decider, error or timeout.
+func (p *ReactionPrompter) decide(ctx context.Context, trigger dnd5eEvents.ReactionTriggerEvent) ReactionDecision {
+	decision := ReactionDecision{Trigger: trigger}
+
+	p.mu.RLock()
+	decider, ok := p.deciders[trigger.ReactorID]
+	p.mu.RUnlock()
+	if !ok {
+		decider = p.fallback
+	}
+	if decider == nil {
+		decision.DeclineReason = DeclineReasonNoDecider
+		return decision
+	}
+
+	prompt := &dnd5eEvents.ReactionPrompt{Trigger: trigger}
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		prompt.Deadline = deadline
+	}
+
+	// Run the decider on its own goroutine so a decider that ignores its
+	// context still can't hold the flow past the deadline
+	type answer struct {
+		response *dnd5eEvents.ReactionResponse
+		err      error
+	}
+	answered := make(chan answer, 1)
+	go func() {
+		response, err := decider.DecideReaction(ctx, prompt)
+		answered <- answer{response: response, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		decision.DeclineReason = DeclineReasonTimeout
+	case a := <-answered:
+		switch {
+		case a.err != nil:
+			decision.DeclineReason = DeclineReasonError
+			decision.Err = a.err
+		case a.response == nil || !a.response.Take:
+			decision.DeclineReason = DeclineReasonDecided
+		default:
+			decision.Taken = true
+		}
+	}
+	return decision
+}
+
+// ReactionModifiers returns the modifiers of the reactions taken, for
+// ApplyAttackOutcome.
+func ReactionModifiers(decisions []ReactionDecision) []ReactionModifier {
+	var modifiers []ReactionModifier
+	for _, decision := range decisions {
+		if decision.Taken {
+			modifiers = append(modifiers, ReactionModifier{
+				ConditionRef: decision.Trigger.ConditionRef,
+				ACBonus:      decision.Trigger.ACBonus,
+			})
+		}
+	}
+	return modifiers
+}
+
+// CollectReactionTriggers runs fn and returns the reaction triggers published
+// on the bus while it ran.
+func CollectReactionTriggers(
+	ctx context.Context,
+	bus events.EventBus,
+	fn func() error,
+) ([]dnd5eEvents.ReactionTriggerEvent, error) {
+	var mu sync.Mutex
+	var triggers []dnd5eEvents.ReactionTriggerEvent
+
+	topic := dnd5eEvents.ReactionTriggerTopic.On(bus)
+	subID, err := topic.Subscribe(ctx, func(_ context.Context, trigger dnd5eEvents.ReactionTriggerEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+		triggers = append(triggers, trigger)
+		return nil
+	})
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to subscribe to reaction triggers")
+	}
+	defer func() { _ = topic.Unsubscribe(ctx, subID) }()
+
+	if err := fn(); err != nil {
+		return nil, err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	return triggers, nil
+}
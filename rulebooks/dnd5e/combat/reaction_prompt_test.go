@@ -0,0 +1,233 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package combat_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	mock_combat "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat/mock"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/weapons"
+)
+
+const shieldRef = "dnd5e:spells:shield"
+
+// ReactionPromptTestSuite tests prompting reactors in process
+type ReactionPromptTestSuite struct {
+	suite.Suite
+	ctrl       *gomock.Controller
+	ctx        context.Context
+	bus        events.EventBus
+	mockRoller *mock_dice.MockRoller
+	used       []dnd5eEvents.ReactionUsedEvent
+}
+
+func TestReactionPromptSuite(t *testing.T) {
+	suite.Run(t, new(ReactionPromptTestSuite))
+}
+
+func (s *ReactionPromptTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.bus = events.NewEventBus()
+	s.mockRoller = mock_dice.NewMockRoller(s.ctrl)
+	s.used = nil
+	_, err := dnd5eEvents.ReactionUsedTopic.On(s.bus).Subscribe(context.Background(),
+		func(_ context.Context, e dnd5eEvents.ReactionUsedEvent) error {
+			s.used = append(s.used, e)
+			return nil
+		})
+	s.Require().NoError(err)
+
+	// Fighter (+5 to hit) attacks a wizard with AC 15
+	lookup := mock_combat.NewMockCombatantLookup(s.ctrl)
+	s.ctx = combat.WithCombatantLookup(context.Background(), lookup)
+	attacker := mock_combat.NewMockCombatant(s.ctrl)
+	attacker.EXPECT().GetID().Return("fighter-1").AnyTimes()
+	attacker.EXPECT().AbilityScores().Return(shared.AbilityScores{abilities.STR: 16}).AnyTimes()
+	attacker.EXPECT().ProficiencyBonus().Return(2).AnyTimes()
+	wizard := mock_combat.NewMockCombatant(s.ctrl)
+	wizard.EXPECT().GetID().Return("wizard-1").AnyTimes()
+	wizard.EXPECT().AC().Return(15).AnyTimes()
+	lookup.EXPECT().Get("fighter-1").Return(attacker, nil).AnyTimes()
+	lookup.EXPECT().Get("wizard-1").Return(wizard, nil).AnyTimes()
+}
+
+func (s *ReactionPromptTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// trigger builds a Shield trigger for a reactor
+func trigger(reactorID string) dnd5eEvents.ReactionTriggerEvent {
+	return dnd5eEvents.ReactionTriggerEvent{
+		ReactorID:    reactorID,
+		ConditionRef: shieldRef,
+		TriggerKind:  dnd5eEvents.TriggerKindPostHit,
+		SourceEntity: "fighter-1",
+		ACBonus:      5,
+	}
+}
+
+// take returns a decider that always answers take
+func take(take bool) combat.ReactionDecider {
+	return combat.ReactionDeciderFunc(func(_ context.Context, _ *dnd5eEvents.ReactionPrompt) (
+		*dnd5eEvents.ReactionResponse, error,
+	) {
+		return &dnd5eEvents.ReactionResponse{Take: take}, nil
+	})
+}
+
+func (s *ReactionPromptTestSuite) TestDefaultDecline() {
+	prompter := combat.NewReactionPrompter(&combat.ReactionPrompterConfig{Timeout: 20 * time.Millisecond})
+	prompter.Register("declines", take(false))
+	prompter.Register("errors", combat.ReactionDeciderFunc(
+		func(_ context.Context, _ *dnd5eEvents.ReactionPrompt) (*dnd5eEvents.ReactionResponse, error) {
+			return nil, errors.New("client disconnected")
+		}))
+	prompter.Register("stalls", combat.ReactionDeciderFunc(
+		func(_ context.Context, _ *dnd5eEvents.ReactionPrompt) (*dnd5eEvents.ReactionResponse, error) {
+			time.Sleep(time.Second) // ignores its context
+			return &dnd5eEvents.ReactionResponse{Take: true}, nil
+		}))
+
+	decisions, err := prompter.Prompt(s.ctx, s.bus, []dnd5eEvents.ReactionTriggerEvent{
+		trigger("unregistered"), trigger("declines"), trigger("errors"), trigger("stalls"),
+	})
+	s.Require().NoError(err)
+	s.Require().Len(decisions, 4)
+
+	var reasons []combat.DeclineReason
+	for _, decision := range decisions {
+		s.False(decision.Taken)
+		reasons = append(reasons, decision.DeclineReason)
+	}
+	s.Equal([]combat.DeclineReason{
+		combat.DeclineReasonNoDecider,
+		combat.DeclineReasonDecided,
+		combat.DeclineReasonError,
+		combat.DeclineReasonTimeout,
+	}, reasons)
+	s.EqualError(decisions[2].Err, "client disconnected")
+	s.Empty(s.used, "declined reactions spend nothing")
+	s.Empty(combat.ReactionModifiers(decisions))
+}
+
+func (s *ReactionPromptTestSuite) TestTakenReactionSpendsReaction() {
+	var prompt *dnd5eEvents.ReactionPrompt
+	prompter := combat.NewReactionPrompter(&combat.ReactionPrompterConfig{Timeout: time.Minute})
+	prompter.Register("wizard-1", combat.ReactionDeciderFunc(
+		func(_ context.Context, p *dnd5eEvents.ReactionPrompt) (*dnd5eEvents.ReactionResponse, error) {
+			prompt = p
+			return &dnd5eEvents.ReactionResponse{Take: true}, nil
+		}))
+
+	decisions, err := prompter.Prompt(s.ctx, s.bus, []dnd5eEvents.ReactionTriggerEvent{
+		trigger("wizard-1"), trigger("wizard-1"),
+	})
+	s.Require().NoError(err)
+
+	s.Require().NotNil(prompt)
+	s.False(prompt.Deadline.IsZero(), "the timeout sets the deadline")
+	s.True(decisions[0].Taken)
+	s.Equal(combat.DeclineReasonReactionUsed, decisions[1].DeclineReason, "one reaction per round")
+
+	s.Require().Len(s.used, 1)
+	s.Equal("wizard-1", s.used[0].CharacterID)
+	s.Require().NotNil(s.used[0].FeatureRef)
+	s.Equal(shieldRef, s.used[0].FeatureRef.String())
+	s.Equal([]combat.ReactionModifier{{ConditionRef: shieldRef, ACBonus: 5}}, combat.ReactionModifiers(decisions))
+}
+
+func (s *ReactionPromptTestSuite) TestFallbackDecidesForUnregistered() {
+	prompter := combat.NewReactionPrompter(&combat.ReactionPrompterConfig{Fallback: take(true)})
+	prompter.Register("player-1", take(false))
+
+	decisions, err := prompter.Prompt(s.ctx, s.bus, []dnd5eEvents.ReactionTriggerEvent{
+		trigger("player-1"), trigger("goblin-1"),
+	})
+	s.Require().NoError(err)
+	s.False(decisions[0].Taken, "a registered decider overrides the fallback")
+	s.True(decisions[1].Taken)
+
+	prompter.Unregister("player-1")
+	decisions, err = prompter.Prompt(s.ctx, s.bus, []dnd5eEvents.ReactionTriggerEvent{trigger("player-1")})
+	s.Require().NoError(err)
+	s.True(decisions[0].Taken)
+}
+
+// publishShieldTrigger stands in for the Shield condition: it offers the
+// wizard a +5 AC reaction when an attack would hit by less than 5
+func (s *ReactionPromptTestSuite) publishShieldTrigger() {
+	_, err := dnd5eEvents.PostAttackRollChain.On(s.bus).SubscribeWithChain(context.Background(),
+		func(ctx context.Context, e *dnd5eEvents.PostAttackRollEvent, c chain.Chain[*dnd5eEvents.PostAttackRollEvent]) (
+			chain.Chain[*dnd5eEvents.PostAttackRollEvent], error,
+		) {
+			if e.WouldHit && e.TotalAttack < e.OriginalAC+5 {
+				t := trigger(e.TargetID)
+				t.Payload = *e
+				return c, dnd5eEvents.ReactionTriggerTopic.On(s.bus).Publish(ctx, t)
+			}
+			return c, nil
+		})
+	s.Require().NoError(err)
+}
+
+// attack resolves the fighter's longsword attack on the wizard
+func (s *ReactionPromptTestSuite) attack(prompter *combat.ReactionPrompter) *combat.AttackResult {
+	result, err := combat.ResolveAttack(s.ctx, &combat.AttackInput{
+		AttackerID: "fighter-1",
+		TargetID:   "wizard-1",
+		Weapon: &weapons.Weapon{
+			ID: weapons.Longsword, Name: "Longsword", Category: weapons.CategoryMartialMelee,
+			Damage: "1d8", DamageType: damage.Slashing,
+		},
+		EventBus:  s.bus,
+		Roller:    s.mockRoller,
+		Reactions: prompter,
+	})
+	s.Require().NoError(err)
+	return result
+}
+
+func (s *ReactionPromptTestSuite) TestResolveAttackWaitsForShield() {
+	s.publishShieldTrigger()
+	prompter := combat.NewReactionPrompter(nil)
+	prompter.Register("wizard-1", take(true))
+
+	// 12 + 5 = 17 hits AC 15, but not AC 20
+	s.mockRoller.EXPECT().Roll(s.ctx, 20).Return(12, nil)
+
+	result := s.attack(prompter)
+	s.False(result.Hit, "Shield turned the hit into a miss")
+	s.Equal(20, result.TargetAC)
+	s.Require().Len(result.ReactionDecisions, 1)
+	s.True(result.ReactionDecisions[0].Taken)
+	s.Len(s.used, 1)
+}
+
+func (s *ReactionPromptTestSuite) TestResolveAttackWithoutDecider() {
+	s.publishShieldTrigger()
+
+	s.mockRoller.EXPECT().Roll(s.ctx, 20).Return(12, nil)
+	s.mockRoller.EXPECT().RollN(s.ctx, 1, 8).Return([]int{6}, nil)
+
+	result := s.attack(combat.NewReactionPrompter(nil))
+	s.True(result.Hit, "no decider declines Shield")
+	s.Equal(15, result.TargetAC)
+	s.Require().Len(result.ReactionDecisions, 1)
+	s.Equal(combat.DeclineReasonNoDecider, result.ReactionDecisions[0].DeclineReason)
+	s.Empty(s.used)
+}
@@ -0,0 +1,138 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package combat
+
+import (
+	"context"
+	"math"
+
+	coreCombat "github.com/KirkDiggler/rpg-toolkit/core/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
+)
+
+// DiagonalMetric selects how diagonal movement is measured on a square grid.
+type DiagonalMetric string
+
+const (
+	// DiagonalStandard counts every diagonal square as 5 feet (PHB default)
+	DiagonalStandard DiagonalMetric = "standard"
+
+	// DiagonalAlternating counts the first diagonal as 5 feet, the second as
+	// 10 feet, and so on (DMG variant)
+	DiagonalAlternating DiagonalMetric = "alternating"
+)
+
+// CriticalHitVariant selects how critical hit damage is rolled.
+type CriticalHitVariant string
+
+const (
+	// CriticalDoubleDice rolls the weapon's damage dice twice (PHB default)
+	CriticalDoubleDice CriticalHitVariant = "double_dice"
+
+	// CriticalMaxPlusRoll takes the maximum of the damage dice and adds one roll
+	CriticalMaxPlusRoll CriticalHitVariant = "max_plus_roll"
+)
+
+// CombatRules holds the optional and house rules a game runs with.
+// The zero value is the standard PHB ruleset. Game servers store it as
+// configuration and add it to the context with WithCombatRules; ResolveAttack,
+// MoveEntity, and the TurnManager read it from there.
+type CombatRules struct {
+	// FlankingAdvantage grants advantage on melee attacks against a target
+	// when an ally of the attacker is on the opposite side of it (DMG variant)
+	FlankingAdvantage bool `json:"flanking_advantage"`
+
+	// DiagonalMetric measures diagonal movement and distances on square grids.
+	// Empty means DiagonalStandard.
+	DiagonalMetric DiagonalMetric `json:"diagonal_metric,omitempty"`
+
+	// HealingPotionBonusAction lets a creature drink a healing potion as a
+	// bonus action instead of an action
+	HealingPotionBonusAction bool `json:"healing_potion_bonus_action"`
+
+	// CriticalHit selects how critical damage is rolled. Empty means CriticalDoubleDice.
+	CriticalHit CriticalHitVariant `json:"critical_hit,omitempty"`
+}
+
+// Validate validates the rules.
+func (r *CombatRules) Validate() error {
+	if r == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "CombatRules is nil")
+	}
+	switch r.DiagonalMetric {
+	case "", DiagonalStandard, DiagonalAlternating:
+	default:
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument, "unknown diagonal metric: %s", r.DiagonalMetric)
+	}
+	switch r.CriticalHit {
+	case "", CriticalDoubleDice, CriticalMaxPlusRoll:
+	default:
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument, "unknown critical hit variant: %s", r.CriticalHit)
+	}
+	return nil
+}
+
+type combatRulesKey struct{}
+
+// WithCombatRules adds the combat rules to the context.
+func WithCombatRules(ctx context.Context, rules *CombatRules) context.Context {
+	return context.WithValue(ctx, combatRulesKey{}, rules)
+}
+
+// GetCombatRules retrieves the combat rules from the context.
+// Returns the standard rules when none are set.
+func GetCombatRules(ctx context.Context) *CombatRules {
+	if rules, ok := ctx.Value(combatRulesKey{}).(*CombatRules); ok && rules != nil {
+		return rules
+	}
+	return &CombatRules{}
+}
+
+// PotionActionType returns the action economy cost of drinking a healing potion.
+func (r *CombatRules) PotionActionType() coreCombat.ActionType {
+	if r.HealingPotionBonusAction {
+		return coreCombat.ActionBonus
+	}
+	return coreCombat.ActionStandard
+}
+
+// GridDistanceFeet returns the distance between two positions in feet,
+// measuring diagonals by the rules' DiagonalMetric on square grids.
+func (r *CombatRules) GridDistanceFeet(grid spatial.Grid, from, to spatial.Position) int {
+	feet, _ := r.moveFeet(grid, from, to, 0)
+	return feet
+}
+
+// PathFeet returns the cost in feet of moving along the path, ignoring
+// extra costs such as difficult terrain or crawling.
+func (r *CombatRules) PathFeet(grid spatial.Grid, path []spatial.Position) int {
+	total := 0
+	diagonals := 0
+	for i := 1; i < len(path); i++ {
+		var feet int
+		feet, diagonals = r.moveFeet(grid, path[i-1], path[i], diagonals)
+		total += feet
+	}
+	return total
+}
+
+// moveFeet returns the feet between two positions and the running diagonal
+// count, given the diagonals already moved this turn. Only the alternating
+// metric on a square grid depends on the count.
+func (r *CombatRules) moveFeet(grid spatial.Grid, from, to spatial.Position, diagonals int) (int, int) {
+	if r.DiagonalMetric != DiagonalAlternating || grid.GetShape() != spatial.GridShapeSquare {
+		return int(grid.Distance(from, to) * FeetPerGridUnit), diagonals
+	}
+
+	dx := int(math.Abs(to.X - from.X))
+	dy := int(math.Abs(to.Y - from.Y))
+	diagonal := min(dx, dy)
+	straight := max(dx, dy) - diagonal
+
+	// Every second diagonal costs an extra 5 feet
+	extra := (diagonals+diagonal)/2 - diagonals/2
+	feet := (straight + diagonal + extra) * int(FeetPerGridUnit)
+	return feet, diagonals + diagonal
+}
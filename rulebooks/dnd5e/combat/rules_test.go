@@ -0,0 +1,296 @@
+package combat_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	coreCombat "github.com/KirkDiggler/rpg-toolkit/core/combat"
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/weapons"
+	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
+)
+
+type CombatRulesTestSuite struct {
+	suite.Suite
+	ctrl       *gomock.Controller
+	ctx        context.Context
+	eventBus   events.EventBus
+	lookup     *integrationLookup
+	room       *spatial.BasicRoom
+	mockRoller *mock_dice.MockRoller
+}
+
+func TestCombatRulesSuite(t *testing.T) {
+	suite.Run(t, new(CombatRulesTestSuite))
+}
+
+func (s *CombatRulesTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.eventBus = events.NewEventBus()
+	s.lookup = newIntegrationLookup()
+	s.mockRoller = mock_dice.NewMockRoller(s.ctrl)
+
+	s.room = spatial.NewBasicRoom(spatial.BasicRoomConfig{
+		ID:   "rules-room",
+		Type: "combat",
+		Grid: spatial.NewSquareGrid(spatial.SquareGridConfig{Width: 10, Height: 10}),
+	})
+
+	s.ctx = combat.WithRoom(context.Background(), s.room)
+	s.ctx = combat.WithCombatantLookup(s.ctx, s.lookup)
+}
+
+func (s *CombatRulesTestSuite) SetupSubTest() {
+	s.SetupTest()
+}
+
+func (s *CombatRulesTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// place adds a combatant at (x, y) in the room and to the lookup
+func (s *CombatRulesTestSuite) place(id string, entityType core.EntityType, x, y float64) {
+	s.lookup.Add(&mockEntity{
+		id:               id,
+		hitPoints:        20,
+		maxHitPoints:     20,
+		ac:               12,
+		abilityScores:    shared.AbilityScores{abilities.STR: 14, abilities.DEX: 10},
+		proficiencyBonus: 2,
+	})
+	s.Require().NoError(s.room.PlaceEntity(&testCombatant{id: id, entityType: entityType},
+		spatial.Position{X: x, Y: y}))
+}
+
+func (s *CombatRulesTestSuite) strike(ctx context.Context) *combat.AttackContext {
+	weapon, err := weapons.GetByID(weapons.Longsword)
+	s.Require().NoError(err)
+
+	result, err := combat.ResolveAttackHit(ctx, &combat.ResolveAttackHitInput{
+		AttackerID: "fighter",
+		TargetID:   "goblin",
+		Weapon:     &weapon,
+		EventBus:   s.eventBus,
+		Roller:     s.mockRoller,
+	})
+	s.Require().NoError(err)
+	return result
+}
+
+func (s *CombatRulesTestSuite) TestValidate() {
+	s.NoError((&combat.CombatRules{}).Validate())
+	s.NoError((&combat.CombatRules{
+		DiagonalMetric: combat.DiagonalAlternating,
+		CriticalHit:    combat.CriticalMaxPlusRoll,
+	}).Validate())
+
+	s.Error((&combat.CombatRules{DiagonalMetric: "hex"}).Validate())
+	s.Error((&combat.CombatRules{CriticalHit: "triple"}).Validate())
+
+	var nilRules *combat.CombatRules
+	s.Error(nilRules.Validate())
+}
+
+func (s *CombatRulesTestSuite) TestDefaults() {
+	rules := combat.GetCombatRules(context.Background())
+	s.Equal(combat.CombatRules{}, *rules)
+	s.Equal(coreCombat.ActionStandard, rules.PotionActionType())
+
+	potions := &combat.CombatRules{HealingPotionBonusAction: true}
+	s.Equal(coreCombat.ActionBonus, combat.GetCombatRules(combat.WithCombatRules(s.ctx, potions)).PotionActionType())
+}
+
+func (s *CombatRulesTestSuite) TestDiagonalMetric() {
+	grid := s.room.GetGrid()
+	path := []spatial.Position{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 2}, {X: 3, Y: 3}, {X: 4, Y: 3}}
+
+	standard := &combat.CombatRules{}
+	s.Equal(20, standard.PathFeet(grid, path))
+	s.Equal(15, standard.GridDistanceFeet(grid, spatial.Position{X: 0, Y: 0}, spatial.Position{X: 3, Y: 3}))
+
+	// 5 + 10 + 5 for the diagonals, 5 for the straight step
+	alternating := &combat.CombatRules{DiagonalMetric: combat.DiagonalAlternating}
+	s.Equal(25, alternating.PathFeet(grid, path))
+	s.Equal(20, alternating.GridDistanceFeet(grid, spatial.Position{X: 0, Y: 0}, spatial.Position{X: 3, Y: 3}))
+	s.Equal(30, alternating.GridDistanceFeet(grid, spatial.Position{X: 0, Y: 0}, spatial.Position{X: 4, Y: 4}))
+}
+
+func (s *CombatRulesTestSuite) TestMoveEntityAlternatingDiagonals() {
+	s.place("fighter", "character", 0, 0)
+
+	economy := combat.NewActionEconomy()
+	economy.SetMovement(30)
+
+	ctx := combat.WithCombatRules(s.ctx, &combat.CombatRules{DiagonalMetric: combat.DiagonalAlternating})
+	result, err := combat.MoveEntity(ctx, &combat.MoveEntityInput{
+		EntityID:   "fighter",
+		EntityType: "character",
+		Path:       []spatial.Position{{X: 1, Y: 1}, {X: 2, Y: 2}, {X: 3, Y: 3}},
+		EventBus:   s.eventBus,
+		Economy:    economy,
+	})
+	s.Require().NoError(err)
+	s.Equal(3, result.StepsCompleted)
+	s.Equal(20, result.MovementUsed)
+	s.Equal(10, economy.MovementRemaining)
+}
+
+func (s *CombatRulesTestSuite) TestFlanking() {
+	flanking := &combat.CombatRules{FlankingAdvantage: true}
+
+	s.Run("ally opposite grants advantage", func() {
+		s.place("fighter", "character", 2, 5)
+		s.place("goblin", "monster", 3, 5)
+		s.place("rogue", "character", 4, 5)
+
+		s.mockRoller.EXPECT().RollN(gomock.Any(), 2, 20).Return([]int{4, 15}, nil)
+		result := s.strike(combat.WithCombatRules(s.ctx, flanking))
+		s.True(result.HasAdvantage)
+		s.Equal(15, result.AttackRoll)
+
+		var flankStep bool
+		for _, step := range result.Trace {
+			if step.SourceRef == refs.Conditions.Flanking() {
+				flankStep = true
+				s.Equal("rogue", step.SourceID)
+			}
+		}
+		s.True(flankStep)
+	})
+
+	s.Run("opposite corners flank", func() {
+		s.place("fighter", "character", 2, 4)
+		s.place("goblin", "monster", 3, 5)
+		s.place("rogue", "character", 4, 6)
+
+		s.mockRoller.EXPECT().RollN(gomock.Any(), 2, 20).Return([]int{4, 15}, nil)
+		s.True(s.strike(combat.WithCombatRules(s.ctx, flanking)).HasAdvantage)
+	})
+
+	s.Run("ally not opposite", func() {
+		s.place("fighter", "character", 2, 5)
+		s.place("goblin", "monster", 3, 5)
+		s.place("rogue", "character", 3, 6)
+
+		s.mockRoller.EXPECT().Roll(gomock.Any(), 20).Return(12, nil)
+		s.False(s.strike(combat.WithCombatRules(s.ctx, flanking)).HasAdvantage)
+	})
+
+	s.Run("enemy opposite doesn't flank", func() {
+		s.place("fighter", "character", 2, 5)
+		s.place("goblin", "monster", 3, 5)
+		s.place("orc", "monster", 4, 5)
+
+		s.mockRoller.EXPECT().Roll(gomock.Any(), 20).Return(12, nil)
+		s.False(s.strike(combat.WithCombatRules(s.ctx, flanking)).HasAdvantage)
+	})
+
+	s.Run("rule off by default", func() {
+		s.place("fighter", "character", 2, 5)
+		s.place("goblin", "monster", 3, 5)
+		s.place("rogue", "character", 4, 5)
+
+		s.mockRoller.EXPECT().Roll(gomock.Any(), 20).Return(12, nil)
+		s.False(s.strike(s.ctx).HasAdvantage)
+	})
+
+	s.Run("offset hex opposite flanks", func() {
+		s.useGrid(spatial.NewHexGrid(spatial.HexGridConfig{Width: 10, Height: 10}))
+		// Offset rows don't line up, so (2, 5) is opposite (4, 6) around (3, 5)
+		s.place("fighter", "character", 4, 6)
+		s.place("goblin", "monster", 3, 5)
+		s.place("rogue", "character", 2, 5)
+
+		s.mockRoller.EXPECT().RollN(gomock.Any(), 2, 20).Return([]int{4, 15}, nil)
+		s.True(s.strike(combat.WithCombatRules(s.ctx, flanking)).HasAdvantage)
+	})
+
+	s.Run("offset hex neighbor doesn't flank", func() {
+		s.useGrid(spatial.NewHexGrid(spatial.HexGridConfig{Width: 10, Height: 10}))
+		s.place("fighter", "character", 4, 6)
+		s.place("goblin", "monster", 3, 5)
+		s.place("rogue", "character", 3, 4)
+
+		s.mockRoller.EXPECT().Roll(gomock.Any(), 20).Return(12, nil)
+		s.False(s.strike(combat.WithCombatRules(s.ctx, flanking)).HasAdvantage)
+	})
+
+	s.Run("axial hex opposite flanks", func() {
+		s.useGrid(spatial.NewAxialHexGrid(spatial.AxialHexGridConfig{SpanWidth: 20, SpanHeight: 20}))
+		s.place("fighter", "character", 2, -1)
+		s.place("goblin", "monster", 3, -1)
+		s.place("rogue", "character", 4, -1)
+
+		s.mockRoller.EXPECT().RollN(gomock.Any(), 2, 20).Return([]int{4, 15}, nil)
+		s.True(s.strike(combat.WithCombatRules(s.ctx, flanking)).HasAdvantage)
+	})
+}
+
+// useGrid replaces the room with an empty one on the given grid
+func (s *CombatRulesTestSuite) useGrid(grid spatial.Grid) {
+	s.room = spatial.NewBasicRoom(spatial.BasicRoomConfig{
+		ID:   "rules-room",
+		Type: "combat",
+		Grid: grid,
+	})
+	s.ctx = combat.WithRoom(context.Background(), s.room)
+	s.ctx = combat.WithCombatantLookup(s.ctx, s.lookup)
+}
+
+func (s *CombatRulesTestSuite) TestCriticalHitVariants() {
+	critical := func(ctx context.Context) *combat.AttackResult {
+		s.place("fighter", "character", 2, 5)
+		s.place("goblin", "monster", 3, 5)
+
+		weapon, err := weapons.GetByID(weapons.Longsword)
+		s.Require().NoError(err)
+
+		result, err := combat.ApplyAttackOutcome(ctx, &combat.ApplyAttackOutcomeInput{
+			HitResult: &combat.AttackContext{
+				AttackerID:        "fighter",
+				TargetID:          "goblin",
+				Weapon:            &weapon,
+				OriginalAC:        12,
+				WouldHit:          true,
+				AttackRoll:        20,
+				TotalAttack:       24,
+				IsNaturalTwenty:   true,
+				AllRolls:          []int{20},
+				CriticalThreshold: 20,
+				AbilityMod:        2,
+				AbilityUsed:       abilities.STR,
+			},
+			EventBus: s.eventBus,
+			Roller:   s.mockRoller,
+		})
+		s.Require().NoError(err)
+		s.Require().True(result.Critical)
+		return result
+	}
+
+	s.Run("double dice by default", func() {
+		s.mockRoller.EXPECT().RollN(gomock.Any(), 1, 8).Return([]int{3}, nil).Times(2)
+
+		result := critical(s.ctx)
+		s.Equal([]int{3, 3}, result.DamageRolls)
+		s.Equal(8, result.TotalDamage)
+	})
+
+	s.Run("max plus roll", func() {
+		s.mockRoller.EXPECT().RollN(gomock.Any(), 1, 8).Return([]int{3}, nil)
+
+		ctx := combat.WithCombatRules(s.ctx, &combat.CombatRules{CriticalHit: combat.CriticalMaxPlusRoll})
+		result := critical(ctx)
+		s.Equal([]int{8, 3}, result.DamageRolls)
+		s.Equal(13, result.TotalDamage)
+	})
+}
@@ -0,0 +1,318 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package combat
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/dice"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+)
+
+// SpellAttackInput provides all information needed to resolve a spell attack
+// (Scorching Ray, Spiritual Weapon, Fire Bolt).
+type SpellAttackInput struct {
+	// AttackerID is the caster making the attack.
+	AttackerID string
+
+	// TargetID is the creature being attacked.
+	TargetID string
+
+	// SpellRef identifies the spell. It is the attack chain's WeaponRef and
+	// the damage component's SourceRef.
+	SpellRef *core.Ref
+
+	// AttackBonus is the caster's spell attack bonus (ability modifier plus
+	// proficiency), from spells.CastResult.
+	AttackBonus int
+
+	// Ranged makes this a ranged spell attack. Ranged spell attacks have
+	// disadvantage while a hostile creature is within 5 feet of the caster.
+	Ranged bool
+
+	// Range is the spell's range in feet. A target farther away can't be
+	// attacked. Zero skips the check.
+	Range int
+
+	// Damage is the damage dice notation (e.g. "2d6"). Doubled on a critical hit.
+	Damage string
+
+	// DamageBonus is a flat bonus added to the damage (e.g. the spellcasting
+	// ability modifier for Spiritual Weapon).
+	DamageBonus int
+
+	// DamageType is the type of damage dealt.
+	DamageType damage.Type
+
+	// EventBus is required for publishing attack/damage events.
+	EventBus events.EventBus
+
+	// Roller is the dice roller. If nil, a default roller is used.
+	Roller dice.Roller
+}
+
+// Validate validates the input fields.
+func (s *SpellAttackInput) Validate() error {
+	if s == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "SpellAttackInput is nil")
+	}
+	if s.AttackerID == "" {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "AttackerID is required")
+	}
+	if s.TargetID == "" {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "TargetID is required")
+	}
+	if s.SpellRef == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "SpellRef is required")
+	}
+	if s.Damage == "" {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "Damage is required")
+	}
+	if s.EventBus == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "EventBus is nil")
+	}
+	return nil
+}
+
+// ResolveSpellAttack resolves a spell attack roll and its damage.
+//
+// It follows the weapon attack flow: cover raises the target's AC, the
+// AttackChain collects advantage, bonuses, and critical thresholds (with the
+// spell as WeaponRef), a natural 1 misses and a natural 20 hits, and a hit's
+// damage runs through the DamageChain before a DamageReceivedEvent is
+// published. The DamageChain carries the spell as WeaponRef and its dice as
+// WeaponDamage, so cantrip scaling and Agonizing Blast can recognize it.
+// Like ResolveAttack, there is no reaction window between the roll and the
+// damage, and damage is resolved but not applied to hit points.
+//
+//nolint:gocyclo // Attack resolution requires orchestrating multiple game rules stages
+func ResolveSpellAttack(ctx context.Context, input *SpellAttackInput) (*AttackResult, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	defender, err := GetCombatantFromContext(ctx, input.TargetID)
+	if err != nil {
+		return nil, rpgerr.Wrapf(err, "failed to look up defender %s", input.TargetID)
+	}
+	defenderAC := GetEffectiveAC(ctx, defender)
+
+	cover := resolveAttackCover(ctx, input.AttackerID, input.TargetID)
+	if cover != nil && cover.Level == CoverFull {
+		return nil, rpgerr.Newf(rpgerr.CodeInvalidTarget,
+			"target %s has full cover from %s", input.TargetID, input.AttackerID)
+	}
+	var acSteps []dnd5eEvents.ChainTraceStep
+	if component := cover.ACComponent(); component != nil {
+		defenderAC += component.Value
+		acSteps = append(acSteps, dnd5eEvents.ChainTraceStep{
+			Kind:      dnd5eEvents.ChainTraceCover,
+			SourceRef: component.Source,
+			Value:     component.Value,
+		})
+	}
+
+	attackRange, err := resolveSpellAttackRange(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	roller := input.Roller
+	if roller == nil {
+		roller = dice.NewRoller()
+	}
+
+	attackEvent := dnd5eEvents.AttackChainEvent{
+		AttackerID:          input.AttackerID,
+		TargetID:            input.TargetID,
+		WeaponRef:           input.SpellRef,
+		IsMelee:             !input.Ranged,
+		AttackType:          dnd5eEvents.AttackTypeStandard,
+		DisadvantageSources: attackRange.DisadvantageSources(),
+		AttackBonus:         input.AttackBonus,
+		TargetAC:            defenderAC,
+		CriticalThreshold:   20,
+		Trace: []dnd5eEvents.ChainTraceStep{{
+			Stage:     StageBase,
+			Kind:      dnd5eEvents.ChainTraceBase,
+			SourceRef: input.SpellRef,
+			Value:     input.AttackBonus,
+			Reason:    "spell attack bonus",
+		}},
+	}
+
+	attackChain := newTracedAttackChain(events.NewStagedChain[dnd5eEvents.AttackChainEvent](ModifierStages))
+	modifiedChain, err := dnd5eEvents.AttackChain.On(input.EventBus).PublishWithChain(ctx, attackEvent, attackChain)
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to publish attack chain")
+	}
+	final, err := modifiedChain.Execute(ctx, attackEvent)
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to execute attack chain")
+	}
+
+	hasAdvantage := len(final.AdvantageSources) > 0
+	hasDisadvantage := len(final.DisadvantageSources) > 0
+	if hasAdvantage && hasDisadvantage {
+		hasAdvantage = false
+		hasDisadvantage = false
+	}
+
+	d20, err := RollD20(ctx, roller, D20Input{
+		Advantage:    hasAdvantage,
+		Disadvantage: hasDisadvantage,
+		RerollOnes:   len(final.RerollOneSources) > 0,
+	})
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to roll spell attack")
+	}
+
+	totalAttack := d20.Roll + final.AttackBonus
+	var hit bool
+	switch {
+	case d20.Roll == 1:
+		hit = false
+	case d20.Roll == 20:
+		hit = true
+	default:
+		hit = totalAttack >= defenderAC
+	}
+	isCritical := hit && d20.Roll >= final.CriticalThreshold
+
+	result := &AttackResult{
+		AttackRoll:      d20.Roll,
+		AttackBonus:     final.AttackBonus,
+		TotalAttack:     totalAttack,
+		TargetAC:        defenderAC,
+		Hit:             hit,
+		Critical:        isCritical,
+		IsNaturalTwenty: d20.Roll == 20,
+		IsNaturalOne:    d20.Roll == 1,
+		AllRolls:        d20.Rolls,
+		HasAdvantage:    hasAdvantage,
+		HasDisadvantage: hasDisadvantage,
+		Cover:           cover,
+		Range:           attackRange,
+		DamageBonus:     input.DamageBonus,
+		DamageType:      input.DamageType,
+		Trace: &AttackTrace{
+			Rolls:           d20.Rolls,
+			Roll:            d20.Roll,
+			HasAdvantage:    hasAdvantage,
+			HasDisadvantage: hasDisadvantage,
+			Steps:           final.Trace,
+			AttackBonus:     final.AttackBonus,
+			TotalAttack:     totalAttack,
+			ACSteps:         acSteps,
+			TargetAC:        defenderAC,
+			Hit:             hit,
+			Critical:        isCritical,
+		},
+	}
+	if !hit {
+		return result, nil
+	}
+
+	damagePool, err := dice.ParseNotation(input.Damage)
+	if err != nil {
+		return nil, rpgerr.Wrapf(err, "invalid spell damage %s", input.Damage)
+	}
+	var damageRolls []int
+	switch {
+	case isCritical && GetCombatRules(ctx).CriticalHit == CriticalMaxPlusRoll:
+		damageRolls, err = rollMaxPlusDamage(ctx, damagePool, roller)
+	case isCritical:
+		damageRolls, err = rollDamageDice(ctx, damagePool, roller, 2)
+	default:
+		damageRolls, err = rollDamageDice(ctx, damagePool, roller, 1)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.DamageRolls = damageRolls
+
+	resolved, err := ResolveDamage(ctx, &ResolveDamageInput{
+		AttackerID: input.AttackerID,
+		TargetID:   input.TargetID,
+		Components: []dnd5eEvents.DamageComponent{{
+			Source:            dnd5eEvents.DamageSourceSpell,
+			SourceRef:         input.SpellRef,
+			OriginalDiceRolls: damageRolls,
+			FinalDiceRolls:    damageRolls,
+			FlatBonus:         input.DamageBonus,
+			DamageType:        input.DamageType,
+			IsCritical:        isCritical,
+		}},
+		IsCritical:   isCritical,
+		HasAdvantage: hasAdvantage,
+		EventBus:     input.EventBus,
+		WeaponDamage: input.Damage,
+		WeaponRef:    input.SpellRef,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.TotalDamage = max(resolved.TotalDamage, 0)
+	result.Trace.Damage = &DamageTrace{
+		Steps:      resolved.Trace,
+		Components: resolved.FinalComponents,
+		Total:      result.TotalDamage,
+	}
+	result.Breakdown = &DamageBreakdown{
+		Components:  resolved.FinalComponents,
+		TotalDamage: resolved.TotalDamage,
+	}
+
+	damageCtx := events.WithCause(ctx, resolved.ChainEvent)
+	err = dnd5eEvents.DamageReceivedTopic.On(input.EventBus).Publish(damageCtx, dnd5eEvents.DamageReceivedEvent{
+		TargetID:   input.TargetID,
+		SourceID:   input.AttackerID,
+		SourceRef:  input.SpellRef,
+		Amount:     result.TotalDamage,
+		DamageType: input.DamageType,
+	})
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to publish damage received event")
+	}
+
+	return result, nil
+}
+
+// resolveSpellAttackRange measures a spell attack against the spell's range.
+// Returns nil when there is no room in the context, either combatant isn't
+// placed, or the attack is a melee spell attack.
+func resolveSpellAttackRange(ctx context.Context, input *SpellAttackInput) (*AttackRangeResult, error) {
+	if !input.Ranged {
+		return nil, nil
+	}
+	room, err := getRoomFromContext(ctx)
+	if err != nil {
+		return nil, nil
+	}
+	attackerPos, found := room.GetEntityPosition(input.AttackerID)
+	if !found {
+		return nil, nil
+	}
+	targetPos, found := room.GetEntityPosition(input.TargetID)
+	if !found {
+		return nil, nil
+	}
+
+	result := &AttackRangeResult{
+		DistanceFeet: GetCombatRules(ctx).GridDistanceFeet(room.GetGrid(), attackerPos, targetPos),
+		IsRanged:     true,
+	}
+	if input.Range > 0 && result.DistanceFeet > input.Range {
+		return nil, rpgerr.Newf(rpgerr.CodeOutOfRange, "target %s is %d ft away, beyond the %d ft range of %s",
+			input.TargetID, result.DistanceFeet, input.Range, input.SpellRef.ID)
+	}
+
+	result.HostileNearbyID = findHostileNearby(ctx, room, input.AttackerID, attackerPos)
+	return result, nil
+}
@@ -0,0 +1,234 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package combat
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+)
+
+// AttackTrace is the serializable replay of an attack: every roll, every
+// modifier with its stage and source, and the damage that followed.
+// Render it with FormatAttackLog.
+type AttackTrace struct {
+	// Rolls are all d20s rolled (2 with advantage or disadvantage)
+	Rolls []int
+	// Roll is the d20 that was kept
+	Roll int
+	// HasAdvantage and HasDisadvantage are the final roll mode after cancellation
+	HasAdvantage    bool
+	HasDisadvantage bool
+
+	// Steps are the base bonuses and every attack chain change, in order
+	Steps []dnd5eEvents.ChainTraceStep
+
+	// Outcome
+	AttackBonus int
+	TotalAttack int
+	// ACSteps are the cover and reaction changes to the target's AC
+	ACSteps  []dnd5eEvents.ChainTraceStep
+	TargetAC int
+	Hit      bool
+	Critical bool
+
+	// Damage is nil when the attack missed
+	Damage *DamageTrace
+}
+
+// DamageTrace is the serializable replay of a damage roll through the chain.
+type DamageTrace struct {
+	// Steps are every damage chain change, in order
+	Steps []dnd5eEvents.ChainTraceStep
+	// Components are the final damage components, including dice and rerolls
+	Components []dnd5eEvents.DamageComponent
+	// Total is the damage after multipliers
+	Total int
+}
+
+// tracedChain wraps a chain so each modifier's changes are recorded as trace
+// steps on the event. Subscribers add modifiers exactly as before.
+type tracedChain[T any] struct {
+	chain.Chain[T]
+	snapshot func(T) T
+	diff     func(before, after T, stage chain.Stage, id string) []dnd5eEvents.ChainTraceStep
+	record   func(T, []dnd5eEvents.ChainTraceStep) T
+}
+
+// Add wraps the modifier so its changes are traced.
+func (c *tracedChain[T]) Add(stage chain.Stage, id string, modifier func(context.Context, T) (T, error)) error {
+	return c.Chain.Add(stage, id, func(ctx context.Context, data T) (T, error) {
+		before := c.snapshot(data)
+		after, err := modifier(ctx, data)
+		if err != nil {
+			return after, err
+		}
+		steps := c.diff(before, after, stage, id)
+		if len(steps) == 0 {
+			return after, nil
+		}
+		return c.record(after, steps), nil
+	})
+}
+
+// newTracedAttackChain returns an attack chain that records modifier changes in Trace.
+func newTracedAttackChain(
+	inner chain.Chain[dnd5eEvents.AttackChainEvent],
+) chain.Chain[dnd5eEvents.AttackChainEvent] {
+	return &tracedChain[dnd5eEvents.AttackChainEvent]{
+		Chain: inner,
+		// AttackChainEvent is a value; modifiers only append to its slices
+		snapshot: func(e dnd5eEvents.AttackChainEvent) dnd5eEvents.AttackChainEvent { return e },
+		diff:     diffAttackChainEvent,
+		record: func(e dnd5eEvents.AttackChainEvent, steps []dnd5eEvents.ChainTraceStep) dnd5eEvents.AttackChainEvent {
+			e.Trace = append(e.Trace, steps...)
+			return e
+		},
+	}
+}
+
+// newTracedDamageChain returns a damage chain that records modifier changes in Trace.
+func newTracedDamageChain(inner chain.Chain[*dnd5eEvents.DamageChainEvent]) chain.Chain[*dnd5eEvents.DamageChainEvent] {
+	return &tracedChain[*dnd5eEvents.DamageChainEvent]{
+		Chain: inner,
+		// Modifiers mutate the event and its components in place, so copy both
+		snapshot: func(e *dnd5eEvents.DamageChainEvent) *dnd5eEvents.DamageChainEvent {
+			snapshot := *e
+			snapshot.Components = append([]dnd5eEvents.DamageComponent(nil), e.Components...)
+			return &snapshot
+		},
+		diff: diffDamageChainEvent,
+		record: func(e *dnd5eEvents.DamageChainEvent, steps []dnd5eEvents.ChainTraceStep) *dnd5eEvents.DamageChainEvent {
+			e.Trace = append(e.Trace, steps...)
+			return e
+		},
+	}
+}
+
+// diffAttackChainEvent describes what one modifier changed on an attack.
+func diffAttackChainEvent(
+	before, after dnd5eEvents.AttackChainEvent, stage chain.Stage, id string,
+) []dnd5eEvents.ChainTraceStep {
+	var steps []dnd5eEvents.ChainTraceStep
+
+	if delta := after.AttackBonus - before.AttackBonus; delta != 0 {
+		steps = append(steps, dnd5eEvents.ChainTraceStep{
+			Stage:      stage,
+			ModifierID: id,
+			Kind:       dnd5eEvents.ChainTraceBonus,
+			Value:      delta,
+		})
+	}
+
+	sourceSteps := func(kind dnd5eEvents.ChainTraceKind, sources []dnd5eEvents.AttackModifierSource, from int) {
+		for _, source := range sources[min(from, len(sources)):] {
+			steps = append(steps, dnd5eEvents.ChainTraceStep{
+				Stage:      stage,
+				ModifierID: id,
+				Kind:       kind,
+				SourceRef:  source.SourceRef,
+				SourceID:   source.SourceID,
+				Reason:     source.Reason,
+			})
+		}
+	}
+	sourceSteps(dnd5eEvents.ChainTraceAdvantage, after.AdvantageSources, len(before.AdvantageSources))
+	sourceSteps(dnd5eEvents.ChainTraceDisadvantage, after.DisadvantageSources, len(before.DisadvantageSources))
+	sourceSteps(dnd5eEvents.ChainTraceCancellation, after.CancellationSources, len(before.CancellationSources))
+
+	if after.CriticalThreshold != before.CriticalThreshold {
+		steps = append(steps, dnd5eEvents.ChainTraceStep{
+			Stage:      stage,
+			ModifierID: id,
+			Kind:       dnd5eEvents.ChainTraceCriticalThreshold,
+			Value:      after.CriticalThreshold,
+		})
+	}
+
+	return steps
+}
+
+// diffDamageChainEvent describes what one modifier changed on a damage roll.
+func diffDamageChainEvent(
+	before, after *dnd5eEvents.DamageChainEvent, stage chain.Stage, id string,
+) []dnd5eEvents.ChainTraceStep {
+	var steps []dnd5eEvents.ChainTraceStep
+
+	for i := range after.Components {
+		component := &after.Components[i]
+
+		if i >= len(before.Components) {
+			step := dnd5eEvents.ChainTraceStep{
+				Stage:      stage,
+				ModifierID: id,
+				Kind:       dnd5eEvents.ChainTraceDamage,
+				SourceRef:  component.SourceRef,
+				Value:      component.Total(),
+				DamageType: component.DamageType,
+			}
+			if component.Multiplier != 0 {
+				step.Kind = dnd5eEvents.ChainTraceMultiplier
+				step.Value = 0
+				step.Multiplier = component.Multiplier
+			}
+			steps = append(steps, step)
+			continue
+		}
+
+		previous := before.Components[i]
+		if delta := component.FlatBonus - previous.FlatBonus; delta != 0 {
+			steps = append(steps, dnd5eEvents.ChainTraceStep{
+				Stage:      stage,
+				ModifierID: id,
+				Kind:       dnd5eEvents.ChainTraceBonus,
+				SourceRef:  component.SourceRef,
+				Value:      delta,
+				DamageType: component.DamageType,
+			})
+		}
+		for _, reroll := range component.Rerolls[min(len(previous.Rerolls), len(component.Rerolls)):] {
+			steps = append(steps, dnd5eEvents.ChainTraceStep{
+				Stage:      stage,
+				ModifierID: id,
+				Kind:       dnd5eEvents.ChainTraceReroll,
+				SourceRef:  component.SourceRef,
+				Reason:     reroll.Reason,
+				Value:      reroll.After - reroll.Before,
+				DamageType: component.DamageType,
+			})
+		}
+	}
+
+	if after.AbilityUsed != before.AbilityUsed {
+		steps = append(steps, dnd5eEvents.ChainTraceStep{
+			Stage:      stage,
+			ModifierID: id,
+			Kind:       dnd5eEvents.ChainTraceAbility,
+			Ability:    after.AbilityUsed,
+		})
+	}
+
+	return steps
+}
+
+// baseAttackTrace records the ability modifier and proficiency bonus an attack starts with.
+func baseAttackTrace(ability abilities.Ability, abilityMod, proficiencyBonus int) []dnd5eEvents.ChainTraceStep {
+	return []dnd5eEvents.ChainTraceStep{
+		{
+			Stage:     StageBase,
+			Kind:      dnd5eEvents.ChainTraceBase,
+			SourceRef: abilityToRef(ability),
+			Value:     abilityMod,
+			Ability:   ability,
+		},
+		{
+			Stage:  StageBase,
+			Kind:   dnd5eEvents.ChainTraceBase,
+			Reason: "proficiency",
+			Value:  proficiencyBonus,
+		},
+	}
+}
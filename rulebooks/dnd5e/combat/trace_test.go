@@ -0,0 +1,334 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package combat_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	mock_combat "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat/mock"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/weapons"
+)
+
+type AttackTraceTestSuite struct {
+	suite.Suite
+	ctrl       *gomock.Controller
+	ctx        context.Context
+	eventBus   events.EventBus
+	lookup     *mock_combat.MockCombatantLookup
+	longbow    *weapons.Weapon
+	mockRoller *mock_dice.MockRoller
+}
+
+func TestAttackTraceSuite(t *testing.T) {
+	suite.Run(t, new(AttackTraceTestSuite))
+}
+
+func (s *AttackTraceTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.eventBus = events.NewEventBus()
+	s.lookup = mock_combat.NewMockCombatantLookup(s.ctrl)
+	s.ctx = combat.WithCombatantLookup(context.Background(), s.lookup)
+
+	// Archer: DEX 16 (+3), proficiency +2
+	archer := mock_combat.NewMockCombatant(s.ctrl)
+	archer.EXPECT().GetID().Return("archer").AnyTimes()
+	archer.EXPECT().AbilityScores().Return(shared.AbilityScores{
+		abilities.STR: 10,
+		abilities.DEX: 16,
+	}).AnyTimes()
+	archer.EXPECT().ProficiencyBonus().Return(2).AnyTimes()
+
+	goblin := mock_combat.NewMockCombatant(s.ctrl)
+	goblin.EXPECT().GetID().Return("goblin").AnyTimes()
+	goblin.EXPECT().AC().Return(15).AnyTimes()
+
+	s.lookup.EXPECT().Get("archer").Return(archer, nil).AnyTimes()
+	s.lookup.EXPECT().Get("goblin").Return(goblin, nil).AnyTimes()
+
+	s.longbow = &weapons.Weapon{
+		ID:         weapons.Longbow,
+		Name:       "Longbow",
+		Category:   weapons.CategoryMartialRanged,
+		Damage:     "1d8",
+		DamageType: damage.Piercing,
+		Properties: []weapons.WeaponProperty{weapons.PropertyAmmunition},
+	}
+
+	s.mockRoller = mock_dice.NewMockRoller(s.ctrl)
+}
+
+func (s *AttackTraceTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// subscribeAttack registers a modifier on every attack chain.
+func (s *AttackTraceTestSuite) subscribeAttack(
+	stage chain.Stage, id string, modify func(dnd5eEvents.AttackChainEvent) dnd5eEvents.AttackChainEvent,
+) {
+	_, err := dnd5eEvents.AttackChain.On(s.eventBus).SubscribeWithChain(s.ctx,
+		func(_ context.Context, _ dnd5eEvents.AttackChainEvent, c chain.Chain[dnd5eEvents.AttackChainEvent],
+		) (chain.Chain[dnd5eEvents.AttackChainEvent], error) {
+			return c, c.Add(stage, id, func(_ context.Context, e dnd5eEvents.AttackChainEvent) (dnd5eEvents.AttackChainEvent, error) {
+				return modify(e), nil
+			})
+		})
+	s.Require().NoError(err)
+}
+
+// subscribeDamage registers a modifier on every damage chain.
+func (s *AttackTraceTestSuite) subscribeDamage(
+	stage chain.Stage, id string, modify func(*dnd5eEvents.DamageChainEvent),
+) {
+	_, err := dnd5eEvents.DamageChain.On(s.eventBus).SubscribeWithChain(s.ctx,
+		func(_ context.Context, _ *dnd5eEvents.DamageChainEvent, c chain.Chain[*dnd5eEvents.DamageChainEvent],
+		) (chain.Chain[*dnd5eEvents.DamageChainEvent], error) {
+			return c, c.Add(stage, id, func(_ context.Context, e *dnd5eEvents.DamageChainEvent) (*dnd5eEvents.DamageChainEvent, error) {
+				modify(e)
+				return e, nil
+			})
+		})
+	s.Require().NoError(err)
+}
+
+func (s *AttackTraceTestSuite) attack() *combat.AttackResult {
+	result, err := combat.ResolveAttack(s.ctx, &combat.AttackInput{
+		AttackerID: "archer",
+		TargetID:   "goblin",
+		Weapon:     s.longbow,
+		EventBus:   s.eventBus,
+		Roller:     s.mockRoller,
+	})
+	s.Require().NoError(err)
+	s.Require().NotNil(result.Trace)
+	return result
+}
+
+func (s *AttackTraceTestSuite) TestTraceRecordsBaseBonusesAndChainSteps() {
+	s.subscribeAttack(combat.StageFeatures, "archery", func(e dnd5eEvents.AttackChainEvent) dnd5eEvents.AttackChainEvent {
+		e.AttackBonus += 2
+		return e
+	})
+	s.subscribeAttack(combat.StageConditions, "dodging", func(e dnd5eEvents.AttackChainEvent) dnd5eEvents.AttackChainEvent {
+		e.DisadvantageSources = append(e.DisadvantageSources, dnd5eEvents.AttackModifierSource{
+			SourceRef: refs.Conditions.Dodging(),
+			SourceID:  "goblin",
+			Reason:    "Dodging",
+		})
+		return e
+	})
+
+	s.mockRoller.EXPECT().RollN(gomock.Any(), 2, 20).Return([]int{17, 11}, nil)
+	s.mockRoller.EXPECT().RollN(gomock.Any(), 1, 8).Return([]int{6}, nil)
+
+	result := s.attack()
+	trace := result.Trace
+
+	s.Equal([]int{17, 11}, trace.Rolls)
+	s.Equal(11, trace.Roll)
+	s.True(trace.HasDisadvantage)
+	s.Equal(18, trace.TotalAttack)
+	s.Equal(15, trace.TargetAC)
+	s.True(trace.Hit)
+
+	s.Require().Len(trace.Steps, 4)
+	s.Equal(dnd5eEvents.ChainTraceBase, trace.Steps[0].Kind)
+	s.Equal(refs.Abilities.Dexterity(), trace.Steps[0].SourceRef)
+	s.Equal(3, trace.Steps[0].Value)
+	s.Equal(dnd5eEvents.ChainTraceBase, trace.Steps[1].Kind)
+	s.Equal(2, trace.Steps[1].Value)
+
+	s.Equal(dnd5eEvents.ChainTraceBonus, trace.Steps[2].Kind)
+	s.Equal(combat.StageFeatures, trace.Steps[2].Stage)
+	s.Equal("archery", trace.Steps[2].ModifierID)
+	s.Equal(2, trace.Steps[2].Value)
+
+	s.Equal(dnd5eEvents.ChainTraceDisadvantage, trace.Steps[3].Kind)
+	s.Equal(combat.StageConditions, trace.Steps[3].Stage)
+	s.Equal(refs.Conditions.Dodging(), trace.Steps[3].SourceRef)
+	s.Equal("goblin", trace.Steps[3].SourceID)
+
+	s.Require().NotNil(trace.Damage)
+	s.Equal(9, trace.Damage.Total)
+
+	s.Equal("18 (d20 11 of 17/11, +3 DEX, +2 Proficiency, +2 Archery, disadvantage from Dodging) vs AC 15: "+
+		"hit for 9 damage (Longbow 6 piercing, +3 DEX)", combat.FormatAttackLog(trace))
+}
+
+func (s *AttackTraceTestSuite) TestTraceRecordsDamageChainSteps() {
+	s.subscribeDamage(combat.StageFeatures, "lucky_reroll", func(e *dnd5eEvents.DamageChainEvent) {
+		weapon := &e.Components[0]
+		weapon.Rerolls = append(weapon.Rerolls, dnd5eEvents.RerollEvent{
+			DieIndex: 0, Before: 1, After: 7, Reason: "lucky_reroll",
+		})
+		weapon.FinalDiceRolls = []int{7}
+	})
+	s.subscribeDamage(combat.StageConditions, "hex", func(e *dnd5eEvents.DamageChainEvent) {
+		e.Components = append(e.Components, dnd5eEvents.DamageComponent{
+			Source:            dnd5eEvents.DamageSourceSpell,
+			OriginalDiceRolls: []int{4},
+			FinalDiceRolls:    []int{4},
+			DamageType:        damage.Necrotic,
+		})
+	})
+	s.subscribeDamage(combat.StageFinal, "resistance", func(e *dnd5eEvents.DamageChainEvent) {
+		e.Components = append(e.Components, dnd5eEvents.DamageComponent{
+			Source:     dnd5eEvents.DamageSourceCondition,
+			DamageType: damage.Necrotic,
+			Multiplier: 0.5,
+		})
+	})
+
+	s.mockRoller.EXPECT().Roll(gomock.Any(), 20).Return(14, nil)
+	s.mockRoller.EXPECT().RollN(gomock.Any(), 1, 8).Return([]int{1}, nil)
+
+	result := s.attack()
+	damageTrace := result.Trace.Damage
+	s.Require().NotNil(damageTrace)
+
+	s.Require().Len(damageTrace.Steps, 3)
+	s.Equal(dnd5eEvents.ChainTraceReroll, damageTrace.Steps[0].Kind)
+	s.Equal(combat.StageFeatures, damageTrace.Steps[0].Stage)
+	s.Equal("lucky_reroll", damageTrace.Steps[0].Reason)
+	s.Equal(6, damageTrace.Steps[0].Value)
+
+	s.Equal(dnd5eEvents.ChainTraceDamage, damageTrace.Steps[1].Kind)
+	s.Equal("hex", damageTrace.Steps[1].ModifierID)
+	s.Equal(4, damageTrace.Steps[1].Value)
+
+	s.Equal(dnd5eEvents.ChainTraceMultiplier, damageTrace.Steps[2].Kind)
+	s.Equal(combat.StageFinal, damageTrace.Steps[2].Stage)
+	s.Equal(0.5, damageTrace.Steps[2].Multiplier)
+
+	// 7 piercing + 3 DEX + 4 necrotic halved to 2
+	s.Equal(12, damageTrace.Total)
+	s.Equal("12 damage (Longbow 7 piercing (rerolled 1 to 7, Lucky Reroll), +3 DEX, Spell 4 necrotic, "+
+		"Condition x0.5 necrotic)", combat.FormatDamageLog(damageTrace))
+}
+
+func (s *AttackTraceTestSuite) TestTraceRecordsReactionACAndMiss() {
+	s.mockRoller.EXPECT().Roll(gomock.Any(), 20).Return(12, nil)
+
+	hit, err := combat.ResolveAttackHit(s.ctx, &combat.ResolveAttackHitInput{
+		AttackerID: "archer",
+		TargetID:   "goblin",
+		Weapon:     s.longbow,
+		EventBus:   s.eventBus,
+		Roller:     s.mockRoller,
+	})
+	s.Require().NoError(err)
+	s.Require().True(hit.WouldHit)
+
+	result, err := combat.ApplyAttackOutcome(s.ctx, &combat.ApplyAttackOutcomeInput{
+		HitResult: hit,
+		Reactions: []combat.ReactionModifier{{ConditionRef: "dnd5e:conditions:shield", ACBonus: 5}},
+		EventBus:  s.eventBus,
+		Roller:    s.mockRoller,
+	})
+	s.Require().NoError(err)
+
+	trace := result.Trace
+	s.Require().NotNil(trace)
+	s.False(trace.Hit)
+	s.Nil(trace.Damage)
+	s.Require().Len(trace.ACSteps, 1)
+	s.Equal(dnd5eEvents.ChainTraceReaction, trace.ACSteps[0].Kind)
+	s.Equal("shield", trace.ACSteps[0].SourceRef.ID)
+	s.Equal(5, trace.ACSteps[0].Value)
+
+	s.Equal("17 (d20 12, +3 DEX, +2 Proficiency) vs AC 20 (+5 Shield): miss", combat.FormatAttackLog(trace))
+}
+
+func (s *AttackTraceTestSuite) TestTraceSurvivesJSONRoundTrip() {
+	s.subscribeAttack(combat.StageFeatures, "archery", func(e dnd5eEvents.AttackChainEvent) dnd5eEvents.AttackChainEvent {
+		e.AttackBonus += 2
+		return e
+	})
+	s.mockRoller.EXPECT().Roll(gomock.Any(), 20).Return(20, nil)
+	s.mockRoller.EXPECT().RollN(gomock.Any(), 1, 8).Return([]int{3}, nil)
+	s.mockRoller.EXPECT().RollN(gomock.Any(), 1, 8).Return([]int{5}, nil)
+
+	result := s.attack()
+
+	data, err := json.Marshal(result.Trace)
+	s.Require().NoError(err)
+
+	var loaded combat.AttackTrace
+	s.Require().NoError(json.Unmarshal(data, &loaded))
+
+	s.Equal(*result.Trace, loaded)
+	s.Equal(combat.FormatAttackLog(result.Trace), combat.FormatAttackLog(&loaded))
+	s.Contains(combat.FormatAttackLog(&loaded), "critical hit for 11 damage")
+}
+
+func (s *AttackTraceTestSuite) TestFormatAttackLog_Nil() {
+	s.Empty(combat.FormatAttackLog(nil))
+	s.Empty(combat.FormatDamageLog(nil))
+}
+
+func (s *AttackTraceTestSuite) TestAttackPublishesCombatLog() {
+	var entries []dnd5eEvents.CombatLogEvent
+	_, err := dnd5eEvents.CombatLogTopic.On(s.eventBus).Subscribe(s.ctx,
+		func(_ context.Context, e dnd5eEvents.CombatLogEvent) error {
+			entries = append(entries, e)
+			return nil
+		})
+	s.Require().NoError(err)
+
+	s.mockRoller.EXPECT().Roll(gomock.Any(), 20).Return(14, nil)
+	s.mockRoller.EXPECT().RollN(gomock.Any(), 1, 8).Return([]int{6}, nil)
+	s.attack()
+
+	s.mockRoller.EXPECT().Roll(gomock.Any(), 20).Return(2, nil)
+	missed := s.attack()
+
+	s.Require().Len(entries, 2)
+	s.Require().NoError(entries[0].Validate())
+	s.Equal(dnd5eEvents.CombatLogAttackResolved, entries[0].Kind)
+	s.Equal("archer", entries[0].ActorID)
+	s.Equal("goblin", entries[0].TargetID)
+	attack := entries[0].Attack
+	s.Require().NotNil(attack)
+	s.Equal(refs.Weapons.Longbow(), attack.WeaponRef)
+	s.Equal([]int{14}, attack.Rolls)
+	s.Equal(14, attack.Roll)
+	s.Equal(19, attack.Total)
+	s.Equal(15, attack.TargetAC)
+	s.True(attack.Hit)
+	s.Equal(9, attack.Damage)
+	s.Equal(damage.Piercing, attack.DamageType)
+
+	// The trace's sources travel with the entry
+	s.Require().Len(attack.Sources, 2)
+	s.Equal(dnd5eEvents.CombatLogSource{
+		Kind: dnd5eEvents.ChainTraceBase, Label: "DEX", Value: 3, SourceRef: attack.Sources[0].SourceRef,
+	}, attack.Sources[0])
+	s.Equal(dnd5eEvents.CombatLogSource{
+		Kind: dnd5eEvents.ChainTraceBase, Label: "Proficiency", Value: 2,
+	}, attack.Sources[1])
+	s.Empty(attack.ACSources)
+	s.Require().Len(attack.DamageSources, 2)
+	s.Equal("Longbow", attack.DamageSources[0].Label)
+	s.Equal(6, attack.DamageSources[0].Value)
+
+	s.Equal("archer attacks goblin with Longbow: 19 (d20 14, +3 DEX, +2 Proficiency) vs AC 15: "+
+		"hit for 9 damage (Longbow 6 piercing, +3 DEX)", entries[0].Text())
+	s.Equal("archer attacks goblin with Longbow: 7 (d20 2, +3 DEX, +2 Proficiency) vs AC 15: miss", entries[1].Text())
+
+	// FormatAttackLog is the same text without the actor and target
+	s.Equal("7 (d20 2, +3 DEX, +2 Proficiency) vs AC 15: miss", combat.FormatAttackLog(missed.Trace))
+}
@@ -33,6 +33,15 @@ type ActivateAbilityInput struct {
 
 	// ExtraAttacks is the number of additional attacks from features like Extra Attack.
 	ExtraAttacks int
+
+	// TargetID is the ally helped by Help, or the object used by Use Object.
+	TargetID string
+
+	// HelpAgainstID is the foe a helped ally's attack gains advantage against.
+	HelpAgainstID string
+
+	// Roller rolls the checks made by Hide and Search (optional).
+	Roller dice.Roller
 }
 
 // AbilityInfo provides metadata about an available combat ability.
@@ -119,6 +128,14 @@ type NewTurnManagerInput struct {
 
 	// OffHandWeapon provides off-hand weapon info for two-weapon fighting validation.
 	OffHandWeapon *EquippedWeaponInfo
+
+	// Rules are the optional and house rules for this combat.
+	// If nil, the standard rules are used.
+	Rules *CombatRules
+
+	// Economy is the character's action economy. If nil, a fresh one is
+	// created. Pass a restored economy with ResumeTurn to continue a saved turn.
+	Economy *ActionEconomy
 }
 
 // StartTurnResult contains the outcome of starting a turn.
@@ -146,8 +163,12 @@ type TurnManager struct {
 	roller         dice.Roller
 	mainHandWeapon *EquippedWeaponInfo
 	offHandWeapon  *EquippedWeaponInfo
+	rules          *CombatRules
 	turnStarted    bool
 	turnEnded      bool
+
+	// offHandSubscriptionID resolves OffHandStrikeRequestedEvents during the turn
+	offHandSubscriptionID string
 }
 
 // NewTurnManager creates a TurnManager for managing a combatant's turn.
@@ -168,29 +189,43 @@ func NewTurnManager(input *NewTurnManagerInput) (*TurnManager, error) {
 	if input.EventBus == nil {
 		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "EventBus is required")
 	}
+	rules := input.Rules
+	if rules == nil {
+		rules = &CombatRules{}
+	}
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
 
 	roller := input.Roller
 	if roller == nil {
 		roller = dice.NewRoller()
 	}
 
+	economy := input.Economy
+	if economy == nil {
+		economy = NewActionEconomy()
+	}
+
 	return &TurnManager{
 		character:      input.Character,
-		economy:        NewActionEconomy(),
+		economy:        economy,
 		combatants:     input.Combatants,
 		room:           input.Room,
 		bus:            input.EventBus,
 		roller:         roller,
 		mainHandWeapon: input.MainHandWeapon,
 		offHandWeapon:  input.OffHandWeapon,
+		rules:          rules,
 	}, nil
 }
 
 // buildContext creates an operation context with combat dependencies.
-// Wraps the caller's context with CombatantLookup, Room, and TwoWeaponContext.
+// Wraps the caller's context with CombatantLookup, Room, CombatRules, and TwoWeaponContext.
 func (tm *TurnManager) buildContext(ctx context.Context) context.Context {
 	ctx = WithCombatantLookup(ctx, tm.combatants)
 	ctx = WithRoom(ctx, tm.room)
+	ctx = WithCombatRules(ctx, tm.rules)
 	ctx = WithTwoWeaponContext(ctx, &turnManagerTwoWeaponContext{
 		characterID:    tm.character.GetID(),
 		mainHandWeapon: tm.mainHandWeapon,
@@ -230,6 +265,9 @@ func (t *turnManagerTwoWeaponContext) GetActionEconomy(characterID string) *Acti
 }
 
 // StartTurn initializes the action economy and publishes a TurnStartEvent.
+// Active conditions then restrict the economy (see ActionEconomy.ApplyConditions).
+// Until EndTurn, the character's OffHandStrikeRequestedEvents are resolved as
+// off-hand attacks (see ResolveOffHandStrike).
 // Must be called before any other turn actions.
 func (tm *TurnManager) StartTurn(ctx context.Context) (*StartTurnResult, error) {
 	if tm.turnEnded {
@@ -250,6 +288,38 @@ func (tm *TurnManager) StartTurn(ctx context.Context) (*StartTurnResult, error)
 		return nil, fmt.Errorf("failed to publish turn start event: %w", err)
 	}
 
+	return tm.beginTurn(ctx)
+}
+
+// ResumeTurn continues a turn that was saved after StartTurn, e.g. when an
+// encounter is loaded mid-turn. It keeps the economy passed in
+// NewTurnManagerInput, so movement and actions already spent stay spent, and
+// doesn't publish a TurnStartEvent, so turn start effects don't run twice.
+// Use it instead of StartTurn.
+func (tm *TurnManager) ResumeTurn(ctx context.Context) (*StartTurnResult, error) {
+	if tm.turnEnded {
+		return nil, rpgerr.New(rpgerr.CodeInvalidState, "turn manager cannot be reused after EndTurn")
+	}
+	if tm.turnStarted {
+		return nil, rpgerr.New(rpgerr.CodeInvalidState, "turn already started")
+	}
+	tm.turnStarted = true
+
+	return tm.beginTurn(ctx)
+}
+
+// beginTurn restricts the economy by active conditions and starts resolving
+// off-hand strikes, once the turn has started or resumed
+func (tm *TurnManager) beginTurn(ctx context.Context) (*StartTurnResult, error) {
+	// Turn start effects have resolved; restrict the economy by what remains active
+	tm.applyConditionRestrictions()
+
+	subID, err := dnd5eEvents.OffHandStrikeRequestedTopic.On(tm.bus).Subscribe(ctx, tm.onOffHandStrikeRequested)
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to subscribe to off-hand strike requests")
+	}
+	tm.offHandSubscriptionID = subID
+
 	return &StartTurnResult{
 		Economy: tm.economy,
 	}, nil
@@ -273,6 +343,13 @@ func (tm *TurnManager) EndTurn(ctx context.Context) (*EndTurnResult, error) {
 		return nil, fmt.Errorf("failed to publish turn end event: %w", err)
 	}
 
+	if tm.offHandSubscriptionID != "" {
+		if err := tm.bus.Unsubscribe(ctx, tm.offHandSubscriptionID); err != nil {
+			return nil, rpgerr.Wrap(err, "failed to unsubscribe from off-hand strike requests")
+		}
+		tm.offHandSubscriptionID = ""
+	}
+
 	// Cleanup temporary actions/conditions
 	if err := tm.character.Cleanup(ctx); err != nil {
 		return nil, err
@@ -284,3 +361,31 @@ func (tm *TurnManager) EndTurn(ctx context.Context) (*EndTurnResult, error) {
 		CharacterID: tm.character.GetID(),
 	}, nil
 }
+
+// onOffHandStrikeRequested resolves an OffHandStrike action activated by this
+// turn's character. The action already consumed the off-hand attack.
+func (tm *TurnManager) onOffHandStrikeRequested(ctx context.Context, event dnd5eEvents.OffHandStrikeRequestedEvent) error {
+	if event.AttackerID != tm.character.GetID() {
+		return nil
+	}
+
+	tm.applyConditionRestrictions()
+	_, err := ResolveOffHandStrike(tm.buildContext(ctx), &ResolveOffHandStrikeInput{
+		Request:  event,
+		EventBus: tm.bus,
+		Roller:   tm.roller,
+	})
+	return err
+}
+
+// applyConditionRestrictions restricts the economy by the character's active conditions.
+// It runs at turn start and before each action so conditions gained mid-turn
+// (e.g. Stunned by a reaction) take effect immediately.
+// Characters that don't implement ConditionHolder are never restricted.
+func (tm *TurnManager) applyConditionRestrictions() {
+	holder, ok := tm.character.(ConditionHolder)
+	if !ok {
+		return
+	}
+	tm.economy.ApplyConditions(ConditionRefs(holder.GetConditions()))
+}
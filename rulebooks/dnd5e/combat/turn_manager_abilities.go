@@ -38,6 +38,8 @@ func (tm *TurnManager) UseAbility(ctx context.Context, input *UseAbilityInput) (
 		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "AbilityRef is required")
 	}
 
+	tm.applyConditionRestrictions()
+
 	err := tm.character.ActivateCombatAbility(ctx, &ActivateAbilityInput{
 		AbilityRef:   input.AbilityRef,
 		Bus:          tm.bus,
@@ -34,6 +34,7 @@ type OffHandStrikeInput struct {
 	TargetID string
 
 	// Weapon is the off-hand weapon used for the attack.
+	// If nil, the weapon in the off-hand slot is used.
 	Weapon *weapons.Weapon
 }
 
@@ -65,6 +66,7 @@ func (tm *TurnManager) Strike(ctx context.Context, input *StrikeInput) (*AttackR
 		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "Weapon is required")
 	}
 
+	tm.applyConditionRestrictions()
 	if err := tm.economy.UseAttack(); err != nil {
 		return nil, err
 	}
@@ -87,8 +89,8 @@ func (tm *TurnManager) Strike(ctx context.Context, input *StrikeInput) (*AttackR
 
 // Move executes movement along a path, consuming movement from the economy.
 // Path[0] must be the entity's current position.
-// Movement cost is (len(path) - 1) * 5 feet. If stopped early by an opportunity attack,
-// unused movement is refunded.
+// Movement cost is 5 feet per step, measuring diagonals by the CombatRules diagonal
+// metric. If stopped early by an opportunity attack, unused movement is refunded.
 func (tm *TurnManager) Move(ctx context.Context, input *MoveInput) (*MoveEntityResult, error) {
 	if tm.turnEnded {
 		return nil, rpgerr.New(rpgerr.CodeInvalidState, "turn already ended")
@@ -114,10 +116,12 @@ func (tm *TurnManager) Move(ctx context.Context, input *MoveInput) (*MoveEntityR
 			int(currentPos.X), int(currentPos.Y), int(input.Path[0].X), int(input.Path[0].Y))
 	}
 
-	// Calculate movement cost: each step between positions is 5 feet
-	steps := len(input.Path) - 1
-	cost := steps * int(FeetPerGridUnit)
+	// Calculate movement cost: each step between positions is 5 feet,
+	// or 10 feet for every second diagonal under the alternating metric
+	grid := tm.room.GetGrid()
+	cost := tm.rules.PathFeet(grid, input.Path)
 
+	tm.applyConditionRestrictions()
 	if err := tm.economy.UseMovement(cost); err != nil {
 		return nil, err
 	}
@@ -136,9 +140,8 @@ func (tm *TurnManager) Move(ctx context.Context, input *MoveInput) (*MoveEntityR
 
 	// Refund unused movement if stopped early by OA
 	if result.MovementStopped {
-		unusedSteps := steps - result.StepsCompleted
-		if unusedSteps > 0 {
-			refund := unusedSteps * int(FeetPerGridUnit)
+		refund := cost - tm.rules.PathFeet(grid, input.Path[:result.StepsCompleted+1])
+		if refund > 0 {
 			tm.economy.AddMovement(refund)
 		}
 	}
@@ -161,10 +164,8 @@ func (tm *TurnManager) OffHandStrike(ctx context.Context, input *OffHandStrikeIn
 	if input.TargetID == "" {
 		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "TargetID is required")
 	}
-	if input.Weapon == nil {
-		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "Weapon is required")
-	}
 
+	tm.applyConditionRestrictions()
 	if err := tm.economy.UseOffHandAttack(); err != nil {
 		return nil, err
 	}
@@ -204,6 +205,7 @@ func (tm *TurnManager) FlurryStrike(ctx context.Context, input *FlurryStrikeInpu
 		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "Weapon is required")
 	}
 
+	tm.applyConditionRestrictions()
 	if err := tm.economy.UseFlurryStrike(); err != nil {
 		return nil, err
 	}
@@ -34,8 +34,9 @@ type AvailableAction struct {
 }
 
 // GetAvailableAbilities returns all combat abilities with their current availability.
-// Checks the action economy to determine if each ability can be activated.
+// Checks the action economy, including condition restrictions, to determine if each ability can be activated.
 func (tm *TurnManager) GetAvailableAbilities(_ context.Context) []AvailableAbility {
+	tm.applyConditionRestrictions()
 	infos := tm.character.GetAbilityInfos()
 	result := make([]AvailableAbility, 0, len(infos))
 
@@ -52,8 +53,9 @@ func (tm *TurnManager) GetAvailableAbilities(_ context.Context) []AvailableAbili
 }
 
 // GetAvailableActions returns all actions with their current availability.
-// Checks the action economy to determine if each action can be taken.
+// Checks the action economy, including condition restrictions, to determine if each action can be taken.
 func (tm *TurnManager) GetAvailableActions(_ context.Context) []AvailableAction {
+	tm.applyConditionRestrictions()
 	infos := tm.character.GetActionInfos()
 	result := make([]AvailableAction, 0, len(infos))
 
@@ -76,6 +78,18 @@ func (tm *TurnManager) GetEconomy() *ActionEconomy {
 
 // canUseAbility checks if an ability can be activated based on its action type cost.
 func (tm *TurnManager) canUseAbility(info AbilityInfo) (bool, string) {
+	restriction := tm.economy.Restriction
+	switch info.ActionType {
+	case coreCombat.ActionStandard, coreCombat.ActionBonus:
+		if restriction.NoActions {
+			return false, "prevented by " + restriction.describe()
+		}
+	case coreCombat.ActionReaction:
+		if restriction.NoReactions {
+			return false, "prevented by " + restriction.describe()
+		}
+	}
+
 	switch info.ActionType {
 	case coreCombat.ActionStandard:
 		if !tm.economy.CanUseAction() {
@@ -96,6 +110,11 @@ func (tm *TurnManager) canUseAbility(info AbilityInfo) (bool, string) {
 // canUseAction checks if an action can be taken based on its capacity requirements.
 // Actions consume capacity (attacks, movement, etc.) rather than action economy directly.
 func (tm *TurnManager) canUseAction(info ActionInfo) (bool, string) {
+	restriction := tm.economy.Restriction
+	if info.CapacityType == CapacityMovement && restriction.NoMovement {
+		return false, "prevented by " + restriction.describe()
+	}
+
 	// Check capacity requirements
 	switch info.CapacityType {
 	case CapacityAttack:
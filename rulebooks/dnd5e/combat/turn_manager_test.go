@@ -2,14 +2,17 @@ package combat_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
 
+	"github.com/KirkDiggler/rpg-toolkit/core"
 	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
 	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/character"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/classes"
@@ -231,6 +234,18 @@ func (s *TurnManagerTestSuite) TestNewTurnManager_MissingRoom() {
 	})
 }
 
+func (s *TurnManagerTestSuite) TestNewTurnManager_InvalidRules() {
+	_, err := combat.NewTurnManager(&combat.NewTurnManagerInput{
+		Character:  s.fighter,
+		Combatants: s.lookup,
+		Room:       s.room,
+		EventBus:   s.bus,
+		Rules:      &combat.CombatRules{DiagonalMetric: "euclidean"},
+	})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "diagonal metric")
+}
+
 func (s *TurnManagerTestSuite) TestNewTurnManager_MissingEventBus() {
 	s.Run("missing event bus", func() {
 		_, err := combat.NewTurnManager(&combat.NewTurnManagerInput{
@@ -530,7 +545,7 @@ func (s *TurnManagerTestSuite) TestGetAvailableAbilities() {
 		s.Require().NoError(err)
 
 		available := tm.GetAvailableAbilities(s.ctx)
-		s.Require().Len(available, 6) // Attack, Dash, Disengage, Dodge, Help, Hide
+		s.Require().Len(available, 9) // Attack, Dash, Disengage, Dodge, Help, Hide, Search, Use Object, Ready
 
 		// All should be usable initially
 		for _, a := range available {
@@ -593,6 +608,162 @@ func (s *TurnManagerTestSuite) TestActionsBeforeTurnStarted() {
 	})
 }
 
+// --- Condition Restrictions ---
+
+// staticCondition is a condition with no behavior beyond its ref, like a
+// Stunned or Restrained condition applied by a game server
+type staticCondition struct {
+	ref *core.Ref
+	bus events.EventBus
+}
+
+func (c *staticCondition) IsApplied() bool { return c.bus != nil }
+
+func (c *staticCondition) Apply(_ context.Context, bus events.EventBus) error {
+	c.bus = bus
+	return nil
+}
+
+func (c *staticCondition) Remove(_ context.Context, _ events.EventBus) error {
+	c.bus = nil
+	return nil
+}
+
+func (c *staticCondition) ToJSON() (json.RawMessage, error) {
+	return json.Marshal(map[string]any{"ref": c.ref})
+}
+
+func (s *TurnManagerTestSuite) applyCondition(ref *core.Ref) {
+	err := dnd5eEvents.ConditionAppliedTopic.On(s.bus).Publish(s.ctx, dnd5eEvents.ConditionAppliedEvent{
+		Target:    s.fighter,
+		Condition: &staticCondition{ref: ref},
+	})
+	s.Require().NoError(err)
+}
+
+func (s *TurnManagerTestSuite) TestStunnedCharacterCannotAct() {
+	s.Run("stunned zeroes the economy at turn start", func() {
+		s.applyCondition(refs.Conditions.Stunned())
+		tm := s.createTurnManager()
+
+		result, err := tm.StartTurn(s.ctx)
+		s.Require().NoError(err)
+
+		s.Equal(0, result.Economy.ActionsRemaining)
+		s.Equal(0, result.Economy.BonusActionsRemaining)
+		s.Equal(0, result.Economy.ReactionsRemaining)
+		s.Equal(0, result.Economy.MovementRemaining)
+
+		_, err = tm.UseAbility(s.ctx, &combat.UseAbilityInput{
+			AbilityRef: refs.CombatAbilities.Attack(),
+		})
+		s.Require().Error(err)
+		s.True(rpgerr.IsNotAllowed(err))
+
+		for _, a := range tm.GetAvailableAbilities(s.ctx) {
+			s.False(a.CanUse, "ability %s should not be usable while stunned", a.Info.Name)
+			s.Contains(a.Reason, "stunned")
+		}
+	})
+}
+
+func (s *TurnManagerTestSuite) TestRestrainedCharacterCannotMove() {
+	s.Run("restrained blocks movement but not actions", func() {
+		s.applyCondition(refs.Conditions.Restrained())
+		tm := s.createTurnManager()
+
+		_, err := tm.StartTurn(s.ctx)
+		s.Require().NoError(err)
+
+		_, err = tm.Move(s.ctx, &combat.MoveInput{
+			Path: []spatial.Position{{X: 2, Y: 2}, {X: 2, Y: 3}},
+		})
+		s.Require().Error(err)
+		s.True(rpgerr.IsNotAllowed(err))
+
+		// Dash doesn't help when speed is 0
+		_, err = tm.UseAbility(s.ctx, &combat.UseAbilityInput{
+			AbilityRef: refs.CombatAbilities.Dash(),
+		})
+		s.Require().NoError(err)
+		s.Equal(0, tm.GetEconomy().MovementRemaining)
+	})
+}
+
+func (s *TurnManagerTestSuite) TestConditionAppliedMidTurn() {
+	s.Run("incapacitation during the turn blocks further strikes", func() {
+		tm := s.createTurnManager()
+		_, err := tm.StartTurn(s.ctx)
+		s.Require().NoError(err)
+
+		_, err = tm.UseAbility(s.ctx, &combat.UseAbilityInput{
+			AbilityRef: refs.CombatAbilities.Attack(),
+		})
+		s.Require().NoError(err)
+		s.Require().True(tm.GetEconomy().CanUseAttack())
+
+		s.applyCondition(refs.Conditions.Incapacitated())
+
+		_, err = tm.Strike(s.ctx, &combat.StrikeInput{
+			TargetID: "goblin-1",
+			Weapon:   s.weapon,
+		})
+		s.Require().Error(err)
+		s.Equal(0, tm.GetEconomy().AttacksRemaining)
+	})
+}
+
+func (s *TurnManagerTestSuite) TestOffHandStrikeRequestResolvesDuringTurn() {
+	s.Run("requested off-hand strike resolves with the off-hand weapon", func() {
+		tm, err := combat.NewTurnManager(&combat.NewTurnManagerInput{
+			Character:      s.fighter,
+			Combatants:     s.lookup,
+			Room:           s.room,
+			EventBus:       s.bus,
+			Roller:         s.mockRoller,
+			MainHandWeapon: &combat.EquippedWeaponInfo{WeaponID: weapons.Shortsword},
+			OffHandWeapon:  &combat.EquippedWeaponInfo{WeaponID: weapons.Dagger},
+		})
+		s.Require().NoError(err)
+		_, err = tm.StartTurn(s.ctx)
+		s.Require().NoError(err)
+
+		var resolved []dnd5eEvents.OffHandStrikeResolvedEvent
+		_, err = dnd5eEvents.OffHandStrikeResolvedTopic.On(s.bus).Subscribe(s.ctx,
+			func(_ context.Context, e dnd5eEvents.OffHandStrikeResolvedEvent) error {
+				resolved = append(resolved, e)
+				return nil
+			})
+		s.Require().NoError(err)
+
+		// Attack: 15 + 4 STR + 3 prof = 22; damage is the d4 alone
+		s.mockRoller.EXPECT().Roll(gomock.Any(), 20).Return(15, nil)
+		s.mockRoller.EXPECT().RollN(gomock.Any(), 1, 4).Return([]int{3}, nil)
+
+		request := dnd5eEvents.OffHandStrikeRequestedEvent{
+			AttackerID: "fighter-1",
+			TargetID:   "goblin-1",
+			ActionID:   "fighter-1-off-hand-strike",
+		}
+		err = dnd5eEvents.OffHandStrikeRequestedTopic.On(s.bus).Publish(s.ctx, request)
+		s.Require().NoError(err)
+
+		s.Require().Len(resolved, 1)
+		s.True(resolved[0].Hit)
+		s.Equal(3, resolved[0].TotalDamage)
+		s.Equal(string(weapons.Dagger), resolved[0].WeaponID)
+		s.Equal("fighter-1-off-hand-strike", resolved[0].ActionID)
+		s.False(tm.GetEconomy().CanUseBonusAction())
+
+		// After the turn ends, requests are no longer resolved
+		_, err = tm.EndTurn(s.ctx)
+		s.Require().NoError(err)
+		err = dnd5eEvents.OffHandStrikeRequestedTopic.On(s.bus).Publish(s.ctx, request)
+		s.Require().NoError(err)
+		s.Len(resolved, 1)
+	})
+}
+
 func TestTurnManagerSuite(t *testing.T) {
 	suite.Run(t, new(TurnManagerTestSuite))
 }
@@ -14,6 +14,9 @@ import (
 	"github.com/KirkDiggler/rpg-toolkit/events"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/conditions"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/weapons"
 )
@@ -504,6 +507,165 @@ func (s *TwoWeaponFightingTestSuite) TestMainHandAttackDoesNotRequireContext() {
 	s.True(result.Hit, "Main hand attack should work without TwoWeaponContext")
 }
 
+// addDualWielder registers a shortsword/dagger fighter and a goblin, returning
+// a context carrying the fighter's two-weapon context.
+func (s *TwoWeaponFightingTestSuite) addDualWielder(scores shared.AbilityScores) (context.Context, *mockTwoWeaponContext) {
+	twc := &mockTwoWeaponContext{
+		mainHand:      &combat.EquippedWeaponInfo{WeaponID: weapons.Shortsword},
+		offHand:       &combat.EquippedWeaponInfo{WeaponID: weapons.Dagger},
+		actionEconomy: combat.NewActionEconomy(),
+	}
+	s.lookup.Add(&mockEntity{
+		id:               "fighter-1",
+		name:             "Fighter",
+		abilityScores:    scores,
+		proficiencyBonus: 2,
+		ac:               16,
+		hitPoints:        20,
+		maxHitPoints:     20,
+	})
+	s.lookup.Add(&mockEntity{
+		id:           "goblin-1",
+		name:         "Goblin",
+		ac:           12,
+		hitPoints:    7,
+		maxHitPoints: 7,
+	})
+	return combat.WithTwoWeaponContext(s.ctx, twc), twc
+}
+
+func (s *TwoWeaponFightingTestSuite) offHandAttack(ctx context.Context, weapon *weapons.Weapon) (*combat.AttackResult, error) {
+	return combat.ResolveAttack(ctx, &combat.AttackInput{
+		AttackerID: "fighter-1",
+		TargetID:   "goblin-1",
+		Weapon:     weapon,
+		EventBus:   s.bus,
+		Roller:     s.mockRoller,
+		AttackHand: combat.AttackHandOff,
+	})
+}
+
+func (s *TwoWeaponFightingTestSuite) TestOffHandDamageOmitsPositiveModifier() {
+	ctx, _ := s.addDualWielder(shared.AbilityScores{abilities.STR: 10, abilities.DEX: 16})
+
+	s.mockRoller.EXPECT().Roll(gomock.Any(), 20).Return(15, nil)
+	s.mockRoller.EXPECT().RollN(gomock.Any(), 1, 4).Return([]int{4}, nil)
+
+	result, err := s.offHandAttack(ctx, nil)
+
+	s.Require().NoError(err)
+	s.Equal(5, result.AttackBonus, "ability modifier still applies to the attack roll")
+	s.Equal(4, result.TotalDamage, "off-hand damage doesn't add a positive modifier")
+	s.Equal(0, result.DamageBonus)
+}
+
+func (s *TwoWeaponFightingTestSuite) TestOffHandDamageKeepsNegativeModifier() {
+	ctx, _ := s.addDualWielder(shared.AbilityScores{abilities.STR: 8, abilities.DEX: 8})
+
+	s.mockRoller.EXPECT().Roll(gomock.Any(), 20).Return(18, nil)
+	s.mockRoller.EXPECT().RollN(gomock.Any(), 1, 4).Return([]int{4}, nil)
+
+	result, err := s.offHandAttack(ctx, nil)
+
+	s.Require().NoError(err)
+	s.Equal(3, result.TotalDamage, "a negative modifier always applies")
+	s.Equal(-1, result.DamageBonus)
+}
+
+func (s *TwoWeaponFightingTestSuite) TestOffHandDamageWithTwoWeaponFightingStyle() {
+	ctx, _ := s.addDualWielder(shared.AbilityScores{abilities.STR: 10, abilities.DEX: 16})
+
+	style := conditions.NewFightingStyleTwoWeaponFightingCondition("fighter-1")
+	s.Require().NoError(style.Apply(s.ctx, s.bus))
+	defer func() { _ = style.Remove(s.ctx, s.bus) }()
+
+	s.mockRoller.EXPECT().Roll(gomock.Any(), 20).Return(15, nil)
+	s.mockRoller.EXPECT().RollN(gomock.Any(), 1, 4).Return([]int{4}, nil)
+
+	result, err := s.offHandAttack(ctx, nil)
+
+	s.Require().NoError(err)
+	s.Equal(7, result.TotalDamage, "the style adds the modifier back")
+	s.Equal(3, result.DamageBonus)
+}
+
+func (s *TwoWeaponFightingTestSuite) TestOffHandAttackSelectsOffHandWeapon() {
+	ctx, twc := s.addDualWielder(shared.AbilityScores{abilities.STR: 10, abilities.DEX: 16})
+
+	s.mockRoller.EXPECT().Roll(gomock.Any(), 20).Return(15, nil)
+	s.mockRoller.EXPECT().RollN(gomock.Any(), 1, 4).Return([]int{2}, nil) // dagger, not shortsword
+
+	result, err := s.offHandAttack(ctx, nil)
+
+	s.Require().NoError(err)
+	s.Equal(damage.Piercing, result.DamageType)
+	s.Equal([]int{2}, result.DamageRolls)
+	s.False(twc.actionEconomy.CanUseBonusAction())
+}
+
+func (s *TwoWeaponFightingTestSuite) TestOffHandAttackRejectsWeaponNotInOffHand() {
+	ctx, twc := s.addDualWielder(shared.AbilityScores{abilities.STR: 10, abilities.DEX: 16})
+
+	shortsword, err := weapons.GetByID(weapons.Shortsword)
+	s.Require().NoError(err)
+
+	_, err = s.offHandAttack(ctx, &shortsword)
+
+	s.Require().Error(err)
+	s.Contains(err.Error(), "not in the off hand")
+	s.True(twc.actionEconomy.CanUseBonusAction(), "bonus action should not be consumed")
+}
+
+func (s *TwoWeaponFightingTestSuite) TestResolveOffHandStrike() {
+	ctx, _ := s.addDualWielder(shared.AbilityScores{abilities.STR: 10, abilities.DEX: 16})
+
+	var resolved []dnd5eEvents.OffHandStrikeResolvedEvent
+	_, err := dnd5eEvents.OffHandStrikeResolvedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, e dnd5eEvents.OffHandStrikeResolvedEvent) error {
+			resolved = append(resolved, e)
+			return nil
+		})
+	s.Require().NoError(err)
+
+	s.mockRoller.EXPECT().Roll(gomock.Any(), 20).Return(15, nil)
+	s.mockRoller.EXPECT().RollN(gomock.Any(), 1, 4).Return([]int{3}, nil)
+
+	result, err := combat.ResolveOffHandStrike(ctx, &combat.ResolveOffHandStrikeInput{
+		Request: dnd5eEvents.OffHandStrikeRequestedEvent{
+			AttackerID: "fighter-1",
+			TargetID:   "goblin-1",
+			WeaponID:   string(weapons.Dagger),
+			ActionID:   "fighter-1-off-hand-strike",
+		},
+		EventBus: s.bus,
+		Roller:   s.mockRoller,
+	})
+
+	s.Require().NoError(err)
+	s.True(result.Hit)
+	s.Require().Len(resolved, 1)
+	s.Equal(dnd5eEvents.OffHandStrikeResolvedEvent{
+		AttackerID:  "fighter-1",
+		TargetID:    "goblin-1",
+		WeaponID:    string(weapons.Dagger),
+		ActionID:    "fighter-1-off-hand-strike",
+		Hit:         true,
+		TotalDamage: 3,
+		DamageType:  damage.Piercing,
+	}, resolved[0])
+}
+
+func (s *TwoWeaponFightingTestSuite) TestResolveOffHandStrike_Validation() {
+	_, err := combat.ResolveOffHandStrike(s.ctx, nil)
+	s.Require().Error(err)
+
+	_, err = combat.ResolveOffHandStrike(s.ctx, &combat.ResolveOffHandStrikeInput{
+		Request:  dnd5eEvents.OffHandStrikeRequestedEvent{AttackerID: "fighter-1"},
+		EventBus: s.bus,
+	})
+	s.Require().Error(err)
+}
+
 func TestTwoWeaponFightingSuite(t *testing.T) {
 	suite.Run(t, new(TwoWeaponFightingTestSuite))
 }
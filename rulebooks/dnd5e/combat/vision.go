@@ -0,0 +1,340 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package combat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
+)
+
+// LightLevel is how well lit a location is.
+type LightLevel string
+
+const (
+	// LightBright is normal light; creatures see normally
+	LightBright LightLevel = "bright"
+
+	// LightDim is lightly obscured; it hampers Perception but not attacks
+	LightDim LightLevel = "dim"
+
+	// LightDarkness is heavily obscured; only darkvision, blindsight or truesight see into it
+	LightDarkness LightLevel = "darkness"
+
+	// LightMagicalDarkness is darkness that darkvision can't penetrate (e.g. the darkness spell)
+	LightMagicalDarkness LightLevel = "magical_darkness"
+)
+
+// rank orders light levels from darkest to brightest.
+func (l LightLevel) rank() int {
+	switch l {
+	case LightMagicalDarkness:
+		return 0
+	case LightDarkness:
+		return 1
+	case LightDim:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// LightingProvider reports the light level at an entity's location.
+type LightingProvider interface {
+	// LightAt returns the light level where the entity currently is
+	LightAt(ctx context.Context, entityID string) LightLevel
+}
+
+// LightZone is an area of a room with its own light level
+// (e.g. a torch's radius or a darkness spell).
+type LightZone struct {
+	// ID identifies the zone
+	ID string
+
+	// Center is the middle of the zone in room coordinates
+	Center spatial.Position
+
+	// Radius is the zone's extent from Center in grid units
+	Radius float64
+
+	// Level is the light inside the zone
+	Level LightLevel
+}
+
+// RoomLighting is a LightingProvider with an ambient level and lit or darkened zones.
+// Zones override the ambient light for entities inside them, located through the
+// Room in the context (see WithRoom). Where zones overlap, magical darkness wins
+// because it suppresses mundane light; otherwise the brightest zone wins.
+type RoomLighting struct {
+	// Ambient is the light throughout the room; empty means bright
+	Ambient LightLevel
+
+	// Zones set the light in parts of the room
+	Zones []LightZone
+}
+
+// Ensure RoomLighting implements LightingProvider
+var _ LightingProvider = (*RoomLighting)(nil)
+
+// LightAt returns the light level at the entity's position.
+// Zones are skipped when there is no room in the context or the entity isn't placed.
+func (r *RoomLighting) LightAt(ctx context.Context, entityID string) LightLevel {
+	ambient := r.Ambient
+	if ambient == "" {
+		ambient = LightBright
+	}
+	if len(r.Zones) == 0 {
+		return ambient
+	}
+
+	room, err := getRoomFromContext(ctx)
+	if err != nil {
+		return ambient
+	}
+	pos, found := room.GetEntityPosition(entityID)
+	if !found {
+		return ambient
+	}
+
+	var level LightLevel
+	for _, zone := range r.Zones {
+		if room.GetGrid().Distance(zone.Center, pos) > zone.Radius {
+			continue
+		}
+		if zone.Level == LightMagicalDarkness {
+			return LightMagicalDarkness
+		}
+		if level == "" || zone.Level.rank() > level.rank() {
+			level = zone.Level
+		}
+	}
+	if level == "" {
+		return ambient
+	}
+	return level
+}
+
+// Senses are a creature's special senses, as ranges in feet (0 means none).
+type Senses struct {
+	// Darkvision sees in darkness as if it were dim light
+	Darkvision int
+
+	// Blindsight perceives surroundings without relying on sight
+	Blindsight int
+
+	// Truesight sees in normal and magical darkness
+	Truesight int
+}
+
+// VisionModifiersConfig configures the vision modifiers layer.
+type VisionModifiersConfig struct {
+	// Lighting reports the light level at each entity's location
+	Lighting LightingProvider
+
+	// SensesFor returns a creature's special senses (optional; none if nil)
+	SensesFor func(entityID string) Senses
+
+	// IsHidden reports whether a creature is hidden, e.g. after a successful
+	// Hide action (optional; nobody is hidden if nil)
+	IsHidden func(entityID string) bool
+
+	// OnReveal is called when a hidden creature gives away its location by
+	// attacking, so the game can clear its hidden state (optional)
+	OnReveal func(entityID string)
+}
+
+// Validate validates the config.
+func (c *VisionModifiersConfig) Validate() error {
+	if c == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "VisionModifiersConfig is nil")
+	}
+	if c.Lighting == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "Lighting is required")
+	}
+	return nil
+}
+
+// VisionModifiers applies the unseen attacker and target rules through the attack chain:
+//   - A creature sees into bright and dim light, into darkness within its darkvision,
+//     and into magical darkness only within its truesight
+//   - Blindsight perceives any creature within range, lit or hidden
+//   - Nobody sees through total cover (see CalculateCover), nor a hidden creature
+//   - Attacks against a target the attacker can't see have disadvantage; attacks
+//     from an attacker the target can't see have advantage
+//   - A hidden attacker gives away its location when it attacks (see OnReveal)
+//
+// Distances come from the Room in the context (see WithRoom); without one, senses
+// apply at any range and total cover is ignored. Being unseen in darkness is
+// attributed to refs.Conditions.Darkness, so Devil's Sight strips it as it does
+// for EnvironmentalModifiers.
+//
+// Apply it once per bus; it reads light, senses and hidden state per event.
+type VisionModifiers struct {
+	lighting        LightingProvider
+	sensesFor       func(entityID string) Senses
+	isHidden        func(entityID string) bool
+	onReveal        func(entityID string)
+	bus             events.EventBus
+	subscriptionIDs []string
+}
+
+// NewVisionModifiers creates the vision modifiers layer from config.
+func NewVisionModifiers(config *VisionModifiersConfig) (*VisionModifiers, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &VisionModifiers{
+		lighting:  config.Lighting,
+		sensesFor: config.SensesFor,
+		isHidden:  config.IsHidden,
+		onReveal:  config.OnReveal,
+	}, nil
+}
+
+// IsApplied returns true if the modifiers are subscribed to a bus.
+func (m *VisionModifiers) IsApplied() bool {
+	return m.bus != nil
+}
+
+// Apply subscribes the modifiers to AttackChain.
+func (m *VisionModifiers) Apply(ctx context.Context, bus events.EventBus) error {
+	if m.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "vision modifiers already applied")
+	}
+	m.bus = bus
+
+	attackSubID, err := dnd5eEvents.AttackChain.On(bus).SubscribeWithChain(ctx, m.onAttackChain)
+	if err != nil {
+		m.bus = nil
+		return rpgerr.Wrap(err, "failed to subscribe to attack chain")
+	}
+	m.subscriptionIDs = append(m.subscriptionIDs, attackSubID)
+
+	return nil
+}
+
+// Remove unsubscribes the modifiers from all events.
+func (m *VisionModifiers) Remove(ctx context.Context, bus events.EventBus) error {
+	if m.bus == nil {
+		return nil // Not applied, nothing to remove
+	}
+
+	total := len(m.subscriptionIDs)
+	var errs []error
+	for _, subID := range m.subscriptionIDs {
+		if err := bus.Unsubscribe(ctx, subID); err != nil {
+			errs = append(errs, fmt.Errorf("unsubscribe %s: %w", subID, err))
+		}
+	}
+
+	m.subscriptionIDs = nil
+	m.bus = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unsubscribe %d/%d subscriptions: %w", len(errs), total, errors.Join(errs...))
+	}
+	return nil
+}
+
+// CanSee reports whether the observer can see the target under the layer's
+// light, senses and hidden state.
+func (m *VisionModifiers) CanSee(ctx context.Context, observerID, targetID string) bool {
+	return m.unseenBecause(ctx, observerID, targetID) == nil
+}
+
+// unseenBecause returns the ref attributing why the observer can't see the
+// target, or nil if it can.
+func (m *VisionModifiers) unseenBecause(ctx context.Context, observerID, targetID string) *core.Ref {
+	senses := m.senses(observerID)
+	distance, placed := DistanceFeet(ctx, observerID, targetID)
+	inRange := func(feet int) bool {
+		return feet > 0 && (!placed || distance <= feet)
+	}
+
+	if placed {
+		cover, err := CalculateCover(ctx, &CalculateCoverInput{SourceID: observerID, TargetID: targetID})
+		if err == nil && cover.Level == CoverFull {
+			return refs.Conditions.FullCover()
+		}
+	}
+	if inRange(senses.Blindsight) {
+		return nil
+	}
+	if m.isHidden != nil && m.isHidden(targetID) {
+		return refs.Conditions.Hidden()
+	}
+
+	switch m.lighting.LightAt(ctx, targetID) {
+	case LightMagicalDarkness:
+		if !inRange(senses.Truesight) {
+			return refs.Conditions.Darkness()
+		}
+	case LightDarkness:
+		if !inRange(senses.Darkvision) && !inRange(senses.Truesight) {
+			return refs.Conditions.Darkness()
+		}
+	}
+	return nil
+}
+
+// senses returns the creature's special senses, or none.
+func (m *VisionModifiers) senses(entityID string) Senses {
+	if m.sensesFor == nil {
+		return Senses{}
+	}
+	return m.sensesFor(entityID)
+}
+
+// onAttackChain adds advantage or disadvantage for unseen combatants and
+// reveals a hidden attacker.
+func (m *VisionModifiers) onAttackChain(
+	ctx context.Context,
+	event dnd5eEvents.AttackChainEvent,
+	c chain.Chain[dnd5eEvents.AttackChainEvent],
+) (chain.Chain[dnd5eEvents.AttackChainEvent], error) {
+	var advantage, disadvantage []dnd5eEvents.AttackModifierSource
+
+	if ref := m.unseenBecause(ctx, event.AttackerID, event.TargetID); ref != nil {
+		disadvantage = append(disadvantage, dnd5eEvents.AttackModifierSource{
+			SourceRef: ref,
+			SourceID:  event.TargetID,
+			Reason:    "attacker can't see target",
+		})
+	}
+	if ref := m.unseenBecause(ctx, event.TargetID, event.AttackerID); ref != nil {
+		advantage = append(advantage, dnd5eEvents.AttackModifierSource{
+			SourceRef: ref,
+			SourceID:  event.AttackerID,
+			Reason:    "target can't see attacker",
+		})
+	}
+
+	if m.onReveal != nil && m.isHidden != nil && m.isHidden(event.AttackerID) {
+		m.onReveal(event.AttackerID)
+	}
+
+	if len(advantage) == 0 && len(disadvantage) == 0 {
+		return c, nil
+	}
+
+	modifyAttack := func(_ context.Context, e dnd5eEvents.AttackChainEvent) (dnd5eEvents.AttackChainEvent, error) {
+		e.AdvantageSources = append(e.AdvantageSources, advantage...)
+		e.DisadvantageSources = append(e.DisadvantageSources, disadvantage...)
+		return e, nil
+	}
+	if err := c.Add(StageConditions, "vision", modifyAttack); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to add vision modifiers for attacker %s", event.AttackerID)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,229 @@
+package combat_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
+)
+
+// lightByEntity is a LightingProvider with a fixed light level per entity
+type lightByEntity map[string]combat.LightLevel
+
+func (l lightByEntity) LightAt(_ context.Context, entityID string) combat.LightLevel {
+	if level, ok := l[entityID]; ok {
+		return level
+	}
+	return combat.LightBright
+}
+
+type VisionTestSuite struct {
+	suite.Suite
+	ctx       context.Context
+	eventBus  events.EventBus
+	light     lightByEntity
+	senses    map[string]combat.Senses
+	hidden    map[string]bool
+	revealed  []string
+	modifiers *combat.VisionModifiers
+}
+
+func TestVisionSuite(t *testing.T) {
+	suite.Run(t, new(VisionTestSuite))
+}
+
+func (s *VisionTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.eventBus = events.NewEventBus()
+	s.light = lightByEntity{}
+	s.senses = map[string]combat.Senses{}
+	s.hidden = map[string]bool{}
+	s.revealed = nil
+
+	var err error
+	s.modifiers, err = combat.NewVisionModifiers(&combat.VisionModifiersConfig{
+		Lighting:  s.light,
+		SensesFor: func(entityID string) combat.Senses { return s.senses[entityID] },
+		IsHidden:  func(entityID string) bool { return s.hidden[entityID] },
+		OnReveal: func(entityID string) {
+			s.hidden[entityID] = false
+			s.revealed = append(s.revealed, entityID)
+		},
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(s.modifiers.Apply(s.ctx, s.eventBus))
+}
+
+func (s *VisionTestSuite) SetupSubTest() {
+	s.SetupTest()
+}
+
+func (s *VisionTestSuite) attack(ctx context.Context) dnd5eEvents.AttackChainEvent {
+	event := dnd5eEvents.AttackChainEvent{AttackerID: "rogue", TargetID: "orc", IsMelee: true}
+	attackChain := events.NewStagedChain[dnd5eEvents.AttackChainEvent](combat.ModifierStages)
+	modified, err := dnd5eEvents.AttackChain.On(s.eventBus).PublishWithChain(ctx, event, attackChain)
+	s.Require().NoError(err)
+	result, err := modified.Execute(ctx, event)
+	s.Require().NoError(err)
+	return result
+}
+
+// placeApart places the rogue and orc in a room the given number of squares apart
+func (s *VisionTestSuite) placeApart(squares int) (context.Context, spatial.Room) {
+	room := spatial.NewBasicRoom(spatial.BasicRoomConfig{
+		ID:   "cavern",
+		Type: "combat",
+		Grid: spatial.NewSquareGrid(spatial.SquareGridConfig{Width: 30, Height: 5}),
+	})
+	s.Require().NoError(room.PlaceEntity(&testCombatant{id: "rogue", entityType: "character"}, spatial.Position{X: 0, Y: 2}))
+	s.Require().NoError(room.PlaceEntity(&testCombatant{id: "orc", entityType: "monster"},
+		spatial.Position{X: float64(squares), Y: 2}))
+	return combat.WithRoom(s.ctx, room), room
+}
+
+func (s *VisionTestSuite) TestConfigValidation() {
+	_, err := combat.NewVisionModifiers(&combat.VisionModifiersConfig{})
+	s.Error(err)
+
+	_, err = combat.NewVisionModifiers(nil)
+	s.Error(err)
+}
+
+func (s *VisionTestSuite) TestLight() {
+	s.Run("bright and dim light have no effect", func() {
+		s.light["orc"] = combat.LightDim
+
+		result := s.attack(s.ctx)
+		s.Empty(result.AdvantageSources)
+		s.Empty(result.DisadvantageSources)
+	})
+
+	s.Run("target in darkness imposes disadvantage", func() {
+		s.light["orc"] = combat.LightDarkness
+
+		result := s.attack(s.ctx)
+		s.Require().Len(result.DisadvantageSources, 1)
+		s.Equal(refs.Conditions.Darkness(), result.DisadvantageSources[0].SourceRef)
+		s.Empty(result.AdvantageSources)
+	})
+
+	s.Run("attacker in darkness gains advantage", func() {
+		s.light["rogue"] = combat.LightDarkness
+
+		result := s.attack(s.ctx)
+		s.Require().Len(result.AdvantageSources, 1)
+		s.Equal(refs.Conditions.Darkness(), result.AdvantageSources[0].SourceRef)
+		s.Empty(result.DisadvantageSources)
+	})
+}
+
+func (s *VisionTestSuite) TestDarkvision() {
+	s.Run("sees into darkness", func() {
+		s.light["orc"] = combat.LightDarkness
+		s.senses["rogue"] = combat.Senses{Darkvision: 60}
+
+		s.Empty(s.attack(s.ctx).DisadvantageSources)
+	})
+
+	s.Run("limited by range", func() {
+		s.light["orc"] = combat.LightDarkness
+		s.senses["rogue"] = combat.Senses{Darkvision: 60}
+
+		ctx, _ := s.placeApart(12) // 60 feet
+		s.Empty(s.attack(ctx).DisadvantageSources)
+
+		ctx, _ = s.placeApart(13) // 65 feet
+		s.Len(s.attack(ctx).DisadvantageSources, 1)
+	})
+
+	s.Run("can't see into magical darkness", func() {
+		s.light["orc"] = combat.LightMagicalDarkness
+		s.senses["rogue"] = combat.Senses{Darkvision: 120}
+
+		s.Len(s.attack(s.ctx).DisadvantageSources, 1)
+	})
+
+	s.Run("truesight sees into magical darkness", func() {
+		s.light["orc"] = combat.LightMagicalDarkness
+		s.senses["rogue"] = combat.Senses{Truesight: 30}
+
+		s.Empty(s.attack(s.ctx).DisadvantageSources)
+	})
+}
+
+func (s *VisionTestSuite) TestHidden() {
+	s.Run("hidden attacker gains advantage and is revealed", func() {
+		s.hidden["rogue"] = true
+
+		result := s.attack(s.ctx)
+		s.Require().Len(result.AdvantageSources, 1)
+		s.Equal(refs.Conditions.Hidden(), result.AdvantageSources[0].SourceRef)
+		s.Equal([]string{"rogue"}, s.revealed)
+
+		s.Empty(s.attack(s.ctx).AdvantageSources)
+	})
+
+	s.Run("hidden target imposes disadvantage", func() {
+		s.hidden["orc"] = true
+
+		result := s.attack(s.ctx)
+		s.Require().Len(result.DisadvantageSources, 1)
+		s.Equal(refs.Conditions.Hidden(), result.DisadvantageSources[0].SourceRef)
+		s.Empty(s.revealed)
+	})
+
+	s.Run("blindsight perceives hidden creatures in darkness", func() {
+		s.hidden["rogue"] = true
+		s.light["rogue"] = combat.LightDarkness
+		s.senses["orc"] = combat.Senses{Blindsight: 10}
+
+		ctx, _ := s.placeApart(1)
+		s.Empty(s.attack(ctx).AdvantageSources)
+	})
+}
+
+func (s *VisionTestSuite) TestTotalCoverBlocksSight() {
+	ctx, room := s.placeApart(4)
+	s.True(s.modifiers.CanSee(ctx, "rogue", "orc"))
+
+	s.Require().NoError(room.PlaceEntity(&wallSegment{id: "pillar"}, spatial.Position{X: 2, Y: 2}))
+	s.False(s.modifiers.CanSee(ctx, "rogue", "orc"))
+	s.False(s.modifiers.CanSee(ctx, "orc", "rogue"))
+}
+
+func (s *VisionTestSuite) TestRemove() {
+	s.light["orc"] = combat.LightDarkness
+	s.Require().NoError(s.modifiers.Remove(s.ctx, s.eventBus))
+	s.False(s.modifiers.IsApplied())
+
+	s.Empty(s.attack(s.ctx).DisadvantageSources)
+}
+
+func (s *VisionTestSuite) TestRoomLightingZones() {
+	ctx, _ := s.placeApart(10)
+
+	lighting := &combat.RoomLighting{
+		Ambient: combat.LightDarkness,
+		Zones: []combat.LightZone{
+			{ID: "torch", Center: spatial.Position{X: 0, Y: 2}, Radius: 4, Level: combat.LightBright},
+			{ID: "torch_edge", Center: spatial.Position{X: 0, Y: 2}, Radius: 8, Level: combat.LightDim},
+		},
+	}
+	s.Equal(combat.LightBright, lighting.LightAt(ctx, "rogue"))
+	s.Equal(combat.LightDarkness, lighting.LightAt(ctx, "orc"))
+
+	lighting.Zones = append(lighting.Zones, combat.LightZone{
+		ID: "darkness_spell", Center: spatial.Position{X: 1, Y: 2}, Radius: 3, Level: combat.LightMagicalDarkness,
+	})
+	s.Equal(combat.LightMagicalDarkness, lighting.LightAt(ctx, "rogue"))
+
+	// Without a room only the ambient light applies; unset ambient is bright
+	s.Equal(combat.LightDarkness, lighting.LightAt(s.ctx, "rogue"))
+	s.Equal(combat.LightBright, (&combat.RoomLighting{}).LightAt(s.ctx, "rogue"))
+}
@@ -89,16 +89,16 @@ func (b *BaseCombatAbility) CanActivate(_ context.Context, _ core.Entity, input
 	// Check action economy based on action type
 	switch b.actionType {
 	case coreCombat.ActionStandard:
-		if !input.ActionEconomy.CanUseAction() {
-			return rpgerr.ResourceExhausted("action")
+		if err := input.ActionEconomy.CheckAction(); err != nil {
+			return err
 		}
 	case coreCombat.ActionBonus:
-		if !input.ActionEconomy.CanUseBonusAction() {
-			return rpgerr.ResourceExhausted("bonus action")
+		if err := input.ActionEconomy.CheckBonusAction(); err != nil {
+			return err
 		}
 	case coreCombat.ActionReaction:
-		if !input.ActionEconomy.CanUseReaction() {
-			return rpgerr.ResourceExhausted("reaction")
+		if err := input.ActionEconomy.CheckReaction(); err != nil {
+			return err
 		}
 	case coreCombat.ActionFree:
 		// Free actions don't consume resources
@@ -229,9 +229,26 @@ func LoadJSON(data json.RawMessage) (CombatAbility, error) {
 		}
 		return hide, nil
 
+	case refs.CombatAbilities.Search().ID:
+		search := &Search{}
+		if err := search.loadJSON(data); err != nil {
+			return nil, fmt.Errorf("failed to load search ability: %w", err)
+		}
+		return search, nil
+
+	case refs.CombatAbilities.UseObject().ID:
+		useObject := &UseObject{}
+		if err := useObject.loadJSON(data); err != nil {
+			return nil, fmt.Errorf("failed to load use object ability: %w", err)
+		}
+		return useObject, nil
+
 	case refs.CombatAbilities.Ready().ID:
-		// Ready ability will be implemented in a future phase
-		return nil, fmt.Errorf("ready ability not yet implemented")
+		ready := &Ready{}
+		if err := ready.loadJSON(data); err != nil {
+			return nil, fmt.Errorf("failed to load ready ability: %w", err)
+		}
+		return ready, nil
 
 	default:
 		return nil, fmt.Errorf("unknown combat ability type: %s", metadata.Ref.ID)
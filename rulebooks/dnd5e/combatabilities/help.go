@@ -11,21 +11,21 @@ import (
 	"github.com/KirkDiggler/rpg-toolkit/core"
 	coreCombat "github.com/KirkDiggler/rpg-toolkit/core/combat"
 	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/conditions"
 	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
 )
 
 // Help represents the Help combat ability (PHB p.192).
-// When activated, it consumes 1 action and publishes a HelpActivatedEvent.
+// When activated, it consumes 1 action, applies the Helped condition to the
+// ally named by input.TargetID, and publishes a HelpActivatedEvent.
 // The helped ally gains advantage on their next ability check, or (when helping
-// against a creature within 5 feet) advantage on their next attack roll against
-// that creature before the start of the helper's next turn.
+// against a creature within 5 feet, input.HelpAgainstID) advantage on their next
+// attack roll against that creature before the start of the helper's next turn.
 //
-// Mirrors the Dodge/Disengage bar: this consumes the action and emits the
-// activation signal. Applying the advantage to the ally (and threading which
-// ally is helped) is a later beat — the ally target is not yet carried through
-// the character ActivateAbility path, so HelpActivatedEvent.AllyID is empty for
-// now (documented gap).
+// Without a TargetID only the activation signal is published, leaving the game
+// to decide who is helped. Checking that the foe is within 5 feet of the helper
+// is left to the caller.
 type Help struct {
 	*BaseCombatAbility
 }
@@ -62,8 +62,8 @@ func (h *Help) CanActivate(ctx context.Context, owner core.Entity, input CombatA
 	return nil
 }
 
-// Activate consumes 1 action and publishes a HelpActivatedEvent.
-// A subscriber in a later beat applies the advantage to the helped ally.
+// Activate consumes 1 action, applies the Helped condition to the ally,
+// and publishes a HelpActivatedEvent.
 func (h *Help) Activate(ctx context.Context, owner core.Entity, input CombatAbilityInput) error {
 	if input.Bus == nil {
 		return rpgerr.New(rpgerr.CodeInvalidArgument, "event bus required for Help")
@@ -71,8 +71,21 @@ func (h *Help) Activate(ctx context.Context, owner core.Entity, input CombatAbil
 	if err := h.BaseCombatAbility.Activate(ctx, owner, input); err != nil {
 		return err
 	}
+	if input.TargetID != "" {
+		condition := conditions.NewHelpedCondition(conditions.HelpedInput{
+			CharacterID: input.TargetID,
+			HelperID:    owner.GetID(),
+			AgainstID:   input.HelpAgainstID,
+		})
+		if err := condition.Apply(ctx, input.Bus); err != nil {
+			return fmt.Errorf("failed to apply helped condition: %w", err)
+		}
+	}
+
 	if err := dnd5eEvents.HelpActivatedTopic.On(input.Bus).Publish(ctx, dnd5eEvents.HelpActivatedEvent{
 		CharacterID: owner.GetID(),
+		AllyID:      input.TargetID,
+		TargetID:    input.HelpAgainstID,
 	}); err != nil {
 		return fmt.Errorf("failed to publish help activated event: %w", err)
 	}
@@ -5,20 +5,24 @@ import (
 	"encoding/json"
 	"testing"
 
+	"go.uber.org/mock/gomock"
+
 	"github.com/KirkDiggler/rpg-toolkit/core"
 	coreCombat "github.com/KirkDiggler/rpg-toolkit/core/combat"
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
 	"github.com/KirkDiggler/rpg-toolkit/events"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combatabilities"
 	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
 	"github.com/stretchr/testify/suite"
 )
 
-// HelpAbilityTestSuite covers the Help combat ability (#697 Beat-1): it consumes
-// the standard action and publishes HelpActivatedEvent. The mechanical effect
-// (advantage to the ally) is a later beat — these tests cover the constructor,
-// economy spend, activation signal, and persistence round-trip.
+// HelpAbilityTestSuite covers the Help combat ability: it consumes the standard
+// action, applies the Helped condition to the ally, and publishes
+// HelpActivatedEvent. These tests cover the constructor, economy spend,
+// activation signal, the ally's advantage, and persistence round-trip.
 type HelpAbilityTestSuite struct {
 	suite.Suite
 	ctx           context.Context
@@ -92,6 +96,35 @@ func (s *HelpAbilityTestSuite) TestActivate_ConsumesActionAndPublishes() {
 	s.Equal(s.owner.GetID(), got.CharacterID)
 }
 
+func (s *HelpAbilityTestSuite) TestActivate_AppliesHelpedToAlly() {
+	var got dnd5eEvents.HelpActivatedEvent
+	_, err := dnd5eEvents.HelpActivatedTopic.On(s.bus).Subscribe(
+		s.ctx,
+		func(_ context.Context, e dnd5eEvents.HelpActivatedEvent) error {
+			got = e
+			return nil
+		},
+	)
+	s.Require().NoError(err)
+
+	err = s.help.Activate(s.ctx, s.owner, combatabilities.CombatAbilityInput{
+		ActionEconomy: s.actionEconomy, Bus: s.bus, TargetID: "ally", HelpAgainstID: "orc",
+	})
+	s.Require().NoError(err)
+	s.Equal("ally", got.AllyID)
+	s.Equal("orc", got.TargetID)
+
+	attackEvent := dnd5eEvents.AttackChainEvent{AttackerID: "ally", TargetID: "orc", IsMelee: true}
+	attackChain := events.NewStagedChain[dnd5eEvents.AttackChainEvent](combat.ModifierStages)
+	modifiedChain, err := dnd5eEvents.AttackChain.On(s.bus).PublishWithChain(s.ctx, attackEvent, attackChain)
+	s.Require().NoError(err)
+	finalEvent, err := modifiedChain.Execute(s.ctx, attackEvent)
+	s.Require().NoError(err)
+	s.Require().Len(finalEvent.AdvantageSources, 1)
+	s.Equal(refs.Conditions.Helped(), finalEvent.AdvantageSources[0].SourceRef)
+	s.Equal(s.owner.GetID(), finalEvent.AdvantageSources[0].SourceID)
+}
+
 func (s *HelpAbilityTestSuite) TestActivate_NoEventBus() {
 	err := s.help.Activate(s.ctx, s.owner, combatabilities.CombatAbilityInput{
 		ActionEconomy: s.actionEconomy,
@@ -114,9 +147,8 @@ func (s *HelpAbilityTestSuite) TestToJSON_AndLoadRoundTrip() {
 	s.Equal(refs.CombatAbilities.Help().ID, loaded.Ref().ID)
 }
 
-// HideAbilityTestSuite covers the Hide combat ability (#697 Beat-1): it consumes
-// the standard action and publishes HideActivatedEvent. The Stealth check + the
-// Hidden condition are a later beat.
+// HideAbilityTestSuite covers the Hide combat ability: it consumes the standard
+// action, makes the Stealth check, and publishes HideActivatedEvent with its total.
 type HideAbilityTestSuite struct {
 	suite.Suite
 	ctx           context.Context
@@ -168,6 +200,30 @@ func (s *HideAbilityTestSuite) TestActivate_ConsumesActionAndPublishes() {
 	s.Equal(s.owner.GetID(), got.CharacterID)
 }
 
+func (s *HideAbilityTestSuite) TestActivate_ReportsStealthTotal() {
+	ctrl := gomock.NewController(s.T())
+	roller := mock_dice.NewMockRoller(ctrl)
+	roller.EXPECT().Roll(gomock.Any(), 20).Return(13, nil)
+
+	var got dnd5eEvents.HideActivatedEvent
+	_, err := dnd5eEvents.HideActivatedTopic.On(s.bus).Subscribe(
+		s.ctx,
+		func(_ context.Context, e dnd5eEvents.HideActivatedEvent) error {
+			got = e
+			return nil
+		},
+	)
+	s.Require().NoError(err)
+
+	owner := &skilledOwner{mockOwner: mockOwner{id: "rogue"}, modifiers: map[skills.Skill]int{skills.Stealth: 7}}
+	err = s.hide.Activate(s.ctx, owner, combatabilities.CombatAbilityInput{
+		ActionEconomy: s.actionEconomy, Bus: s.bus, Roller: roller,
+	})
+	s.Require().NoError(err)
+	s.Equal("rogue", got.CharacterID)
+	s.Equal(20, got.StealthTotal, "d20 13 + Stealth 7")
+}
+
 func (s *HideAbilityTestSuite) TestCanActivate_RequiresEventBus() {
 	err := s.hide.CanActivate(s.ctx, s.owner, combatabilities.CombatAbilityInput{
 		ActionEconomy: s.actionEconomy, Bus: nil,
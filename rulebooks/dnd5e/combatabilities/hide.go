@@ -13,18 +13,16 @@ import (
 	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
 	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
 )
 
 // Hide represents the Hide combat ability (PHB p.192).
-// When activated, it consumes 1 action and publishes a HideActivatedEvent.
-// The character attempts to become hidden — a Dexterity (Stealth) check against
-// observers' passive Perception. On success the character is Hidden (unseen and
-// unheard) until they are discovered, make noise, attack, or move into the open.
-//
-// Mirrors the Dodge/Disengage bar: this consumes the action and emits the
-// activation signal. Resolving the Stealth check and applying the Hidden
-// condition is a later beat (the toolkit has the proficiency/check machinery;
-// nothing yet wires HideActivated → a check → the Hidden condition).
+// When activated, it consumes 1 action, makes a Dexterity (Stealth) check and
+// publishes the total in a HideActivatedEvent. The game compares it with
+// observers' passive Perception: those who don't beat it lose track of the
+// character, who stays hidden until discovered, making noise, attacking, or
+// moving into the open. Hidden state feeds combat.VisionModifiers through
+// its IsHidden callback.
 type Hide struct {
 	*BaseCombatAbility
 }
@@ -61,8 +59,8 @@ func (h *Hide) CanActivate(ctx context.Context, owner core.Entity, input CombatA
 	return nil
 }
 
-// Activate consumes 1 action and publishes a HideActivatedEvent.
-// A subscriber in a later beat resolves the Stealth check and applies Hidden.
+// Activate consumes 1 action, rolls the Stealth check (through the
+// AbilityCheckChain, so conditions and features apply), and publishes a HideActivatedEvent.
 func (h *Hide) Activate(ctx context.Context, owner core.Entity, input CombatAbilityInput) error {
 	if input.Bus == nil {
 		return rpgerr.New(rpgerr.CodeInvalidArgument, "event bus required for Hide")
@@ -70,8 +68,13 @@ func (h *Hide) Activate(ctx context.Context, owner core.Entity, input CombatAbil
 	if err := h.BaseCombatAbility.Activate(ctx, owner, input); err != nil {
 		return err
 	}
+	check, err := rollSkillCheck(ctx, owner, input, skills.Stealth, refs.CombatAbilities.Hide())
+	if err != nil {
+		return fmt.Errorf("failed to make stealth check: %w", err)
+	}
 	if err := dnd5eEvents.HideActivatedTopic.On(input.Bus).Publish(ctx, dnd5eEvents.HideActivatedEvent{
-		CharacterID: owner.GetID(),
+		CharacterID:  owner.GetID(),
+		StealthTotal: check.Total,
 	}); err != nil {
 		return fmt.Errorf("failed to publish hide activated event: %w", err)
 	}
@@ -1,9 +1,12 @@
 package combatabilities
 
 import (
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/dice"
 	"github.com/KirkDiggler/rpg-toolkit/events"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/actions"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
 )
 
 // CombatAbilityInput provides input data for combat ability activation.
@@ -34,4 +37,27 @@ type CombatAbilityInput struct {
 	// 0 = normal (1 attack), 1 = Extra Attack (2 attacks), etc.
 	// Required for the Attack ability to set correct attack capacity.
 	ExtraAttacks int `json:"-"`
+
+	// ReadiedActionRef identifies the action held by the Ready ability
+	// (e.g. refs.Actions.Strike()). Required for Ready.
+	ReadiedActionRef *core.Ref `json:"-"`
+
+	// ReadyTargetID is the intended target of the readied action, if any.
+	ReadyTargetID string `json:"-"`
+
+	// ReadyTrigger is the circumstance that releases the readied action.
+	// Required for Ready.
+	ReadyTrigger *dnd5eEvents.ReadyTrigger `json:"-"`
+
+	// TargetID is the ally helped by Help, or the object used by Use Object.
+	// Required for Use Object; Help applies the Helped condition only when set.
+	TargetID string `json:"-"`
+
+	// HelpAgainstID is the creature the helped ally's next attack gains
+	// advantage against. Empty means Help aids a task: the ally's next check.
+	HelpAgainstID string `json:"-"`
+
+	// Roller rolls the checks made by Hide (Stealth) and Search (Perception).
+	// If nil, a default roller is used.
+	Roller dice.Roller `json:"-"`
 }
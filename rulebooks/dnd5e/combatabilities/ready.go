@@ -0,0 +1,134 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package combatabilities
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	coreCombat "github.com/KirkDiggler/rpg-toolkit/core/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/conditions"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// Ready represents the Ready combat ability.
+// When activated, it consumes 1 action and holds another action until a
+// declared trigger occurs. The held action is released with the character's
+// reaction, and is lost if the trigger hasn't occurred by the start of the
+// character's next turn.
+type Ready struct {
+	*BaseCombatAbility
+}
+
+// ReadyData is the JSON structure for persisting Ready ability state
+type ReadyData struct {
+	Ref *core.Ref `json:"ref"`
+	ID  string    `json:"id"`
+}
+
+// NewReady creates a new Ready combat ability that uses a standard action.
+// This is the default Ready action available to all characters.
+func NewReady(id string) *Ready {
+	return &Ready{
+		BaseCombatAbility: NewBaseCombatAbility(BaseCombatAbilityConfig{
+			ID:          id,
+			Name:        "Ready",
+			Description: "Hold an action and take it with your reaction when a chosen trigger occurs.",
+			ActionType:  coreCombat.ActionStandard,
+			Ref:         refs.CombatAbilities.Ready(),
+		}),
+	}
+}
+
+// CanActivate checks if the Ready ability can be activated.
+// Requires an available action, an event bus, the action to hold, and a trigger.
+func (r *Ready) CanActivate(ctx context.Context, owner core.Entity, input CombatAbilityInput) error {
+	if err := r.BaseCombatAbility.CanActivate(ctx, owner, input); err != nil {
+		return err
+	}
+
+	return validateReadyInput(input)
+}
+
+// Activate consumes 1 action, applies the ReadiedActionCondition to the owner
+// on input.Bus, and publishes a ReadyActivatedEvent for game-server telemetry.
+//
+// The condition watches the bus for the trigger, consumes the owner's reaction
+// when it fires, and removes itself at the start of the owner's next turn.
+func (r *Ready) Activate(ctx context.Context, owner core.Entity, input CombatAbilityInput) error {
+	// Validate before consuming the action
+	if err := validateReadyInput(input); err != nil {
+		return err
+	}
+
+	if err := r.BaseCombatAbility.Activate(ctx, owner, input); err != nil {
+		return err
+	}
+
+	condition := conditions.NewReadiedActionCondition(conditions.ReadiedActionConfig{
+		CharacterID: owner.GetID(),
+		ActionRef:   input.ReadiedActionRef,
+		TargetID:    input.ReadyTargetID,
+		Trigger:     *input.ReadyTrigger,
+	})
+	if err := condition.Apply(ctx, input.Bus); err != nil {
+		return fmt.Errorf("failed to apply readied action condition: %w", err)
+	}
+
+	if err := dnd5eEvents.ReadyActivatedTopic.On(input.Bus).Publish(ctx, dnd5eEvents.ReadyActivatedEvent{
+		CharacterID: owner.GetID(),
+		ActionRef:   input.ReadiedActionRef,
+		TargetID:    input.ReadyTargetID,
+		Trigger:     *input.ReadyTrigger,
+	}); err != nil {
+		return fmt.Errorf("failed to publish ready activated event: %w", err)
+	}
+
+	return nil
+}
+
+// validateReadyInput checks the Ready-specific input fields.
+func validateReadyInput(input CombatAbilityInput) error {
+	if input.Bus == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "event bus required for Ready")
+	}
+	if input.ReadiedActionRef == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "readied action ref required for Ready")
+	}
+	if input.ReadyTrigger == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "trigger required for Ready")
+	}
+	return nil
+}
+
+// ToJSON converts the Ready ability to JSON for persistence
+func (r *Ready) ToJSON() (json.RawMessage, error) {
+	data := ReadyData{
+		Ref: r.Ref(),
+		ID:  r.GetID(),
+	}
+
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal ready ability data: %w", err)
+	}
+
+	return bytes, nil
+}
+
+// loadJSON deserializes a Ready ability from JSON
+func (r *Ready) loadJSON(data json.RawMessage) error {
+	var readyData ReadyData
+	if err := json.Unmarshal(data, &readyData); err != nil {
+		return fmt.Errorf("failed to unmarshal ready ability data: %w", err)
+	}
+
+	r.BaseCombatAbility = NewReady(readyData.ID).BaseCombatAbility
+
+	return nil
+}
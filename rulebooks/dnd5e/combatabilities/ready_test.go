@@ -0,0 +1,180 @@
+package combatabilities_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	coreCombat "github.com/KirkDiggler/rpg-toolkit/core/combat"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combatabilities"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/gamectx"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReadyAbilityTestSuite struct {
+	suite.Suite
+	ctx           context.Context
+	bus           events.EventBus
+	owner         *mockOwner
+	actionEconomy *combat.ActionEconomy
+	ready         *combatabilities.Ready
+	trigger       *dnd5eEvents.ReadyTrigger
+}
+
+func TestReadyAbilityTestSuite(t *testing.T) {
+	suite.Run(t, new(ReadyAbilityTestSuite))
+}
+
+func (s *ReadyAbilityTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.owner = &mockOwner{id: "test-character"}
+	s.actionEconomy = combat.NewActionEconomy()
+	s.ready = combatabilities.NewReady("test-ready")
+	s.trigger = &dnd5eEvents.ReadyTrigger{
+		Kind:        dnd5eEvents.ReadyTriggerCreatureMoves,
+		CreatureID:  "goblin",
+		Description: "when the goblin comes around the corner",
+	}
+}
+
+func (s *ReadyAbilityTestSuite) input() combatabilities.CombatAbilityInput {
+	return combatabilities.CombatAbilityInput{
+		ActionEconomy:    s.actionEconomy,
+		Bus:              s.bus,
+		ReadiedActionRef: refs.Actions.Strike(),
+		ReadyTargetID:    "goblin",
+		ReadyTrigger:     s.trigger,
+	}
+}
+
+func (s *ReadyAbilityTestSuite) TestNewReady_Properties() {
+	// Assert
+	s.Assert().Equal("test-ready", s.ready.GetID())
+	s.Assert().Equal("Ready", s.ready.Name())
+	s.Assert().Contains(s.ready.Description(), "reaction")
+	s.Assert().Equal(coreCombat.ActionStandard, s.ready.ActionType())
+	s.Assert().Equal(refs.CombatAbilities.Ready(), s.ready.Ref())
+}
+
+func (s *ReadyAbilityTestSuite) TestCanActivate_Success() {
+	// Act
+	err := s.ready.CanActivate(s.ctx, s.owner, s.input())
+
+	// Assert
+	s.Require().NoError(err)
+}
+
+func (s *ReadyAbilityTestSuite) TestCanActivate_RequiresTrigger() {
+	// Arrange
+	input := s.input()
+	input.ReadyTrigger = nil
+
+	// Act
+	err := s.ready.CanActivate(s.ctx, s.owner, input)
+
+	// Assert
+	s.Require().Error(err)
+}
+
+func (s *ReadyAbilityTestSuite) TestCanActivate_RequiresActionRef() {
+	// Arrange
+	input := s.input()
+	input.ReadiedActionRef = nil
+
+	// Act
+	err := s.ready.CanActivate(s.ctx, s.owner, input)
+
+	// Assert
+	s.Require().Error(err)
+}
+
+func (s *ReadyAbilityTestSuite) TestActivate_ConsumesActionAndPublishesEvent() {
+	// Arrange
+	var received *dnd5eEvents.ReadyActivatedEvent
+	_, err := dnd5eEvents.ReadyActivatedTopic.On(s.bus).Subscribe(
+		s.ctx,
+		func(_ context.Context, event dnd5eEvents.ReadyActivatedEvent) error {
+			received = &event
+			return nil
+		},
+	)
+	s.Require().NoError(err)
+
+	// Act
+	err = s.ready.Activate(s.ctx, s.owner, s.input())
+
+	// Assert
+	s.Require().NoError(err)
+	s.Assert().Equal(0, s.actionEconomy.ActionsRemaining)
+	s.Require().NotNil(received)
+	s.Assert().Equal("test-character", received.CharacterID)
+	s.Assert().Equal(refs.Actions.Strike(), received.ActionRef)
+	s.Assert().Equal("goblin", received.TargetID)
+	s.Assert().Equal(*s.trigger, received.Trigger)
+}
+
+func (s *ReadyAbilityTestSuite) TestActivate_HeldActionFiresOnTrigger() {
+	// Arrange
+	var triggered []dnd5eEvents.ReactionTriggerEvent
+	_, err := dnd5eEvents.ReactionTriggerTopic.On(s.bus).Subscribe(
+		s.ctx,
+		func(_ context.Context, event dnd5eEvents.ReactionTriggerEvent) error {
+			triggered = append(triggered, event)
+			return nil
+		},
+	)
+	s.Require().NoError(err)
+
+	err = s.ready.Activate(s.ctx, s.owner, s.input())
+	s.Require().NoError(err)
+
+	// Act - the goblin moves while the holder's reaction is ready
+	ctx := gamectx.WithReactionReadiness(s.ctx, gamectx.ReactionReadinessMap{
+		"test-character": {refs.Conditions.ReadiedAction().String(): true},
+	})
+	movementChain := events.NewStagedChain[*dnd5eEvents.MovementChainEvent](combat.ModifierStages)
+	_, err = dnd5eEvents.MovementChain.On(s.bus).PublishWithChain(ctx,
+		&dnd5eEvents.MovementChainEvent{EntityID: "goblin"}, movementChain)
+	s.Require().NoError(err)
+
+	// Assert
+	s.Require().Len(triggered, 1)
+	s.Assert().Equal(dnd5eEvents.TriggerKindReadiedAction, triggered[0].TriggerKind)
+	s.Assert().Equal("test-character", triggered[0].ReactorID)
+}
+
+func (s *ReadyAbilityTestSuite) TestActivate_InvalidInputDoesNotConsumeAction() {
+	// Arrange
+	input := s.input()
+	input.ReadyTrigger = nil
+
+	// Act
+	err := s.ready.Activate(s.ctx, s.owner, input)
+
+	// Assert
+	s.Require().Error(err)
+	s.Assert().Equal(1, s.actionEconomy.ActionsRemaining)
+}
+
+func (s *ReadyAbilityTestSuite) TestJSONRoundTrip() {
+	// Act
+	jsonData, err := s.ready.ToJSON()
+	s.Require().NoError(err)
+
+	var data combatabilities.ReadyData
+	s.Require().NoError(json.Unmarshal(jsonData, &data))
+	s.Assert().Equal("test-ready", data.ID)
+	s.Assert().Equal(refs.CombatAbilities.Ready(), data.Ref)
+
+	loaded, err := combatabilities.LoadJSON(jsonData)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Assert().Equal("test-ready", loaded.GetID())
+	s.Assert().Equal("Ready", loaded.Name())
+}
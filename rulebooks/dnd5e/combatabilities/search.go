@@ -0,0 +1,104 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package combatabilities
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	coreCombat "github.com/KirkDiggler/rpg-toolkit/core/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
+)
+
+// Search represents the Search combat ability (PHB p.193).
+// When activated, it consumes 1 action, makes a Wisdom (Perception) check and
+// publishes the total in a SearchActivatedEvent. The game compares it with
+// hidden creatures' Stealth totals, or the DC of whatever is being looked for.
+type Search struct {
+	*BaseCombatAbility
+}
+
+// SearchData is the JSON structure for persisting Search ability state.
+type SearchData struct {
+	Ref *core.Ref `json:"ref"`
+	ID  string    `json:"id"`
+}
+
+// NewSearch creates a new Search combat ability that uses a standard action.
+// This is the default Search action available to all characters.
+func NewSearch(id string) *Search {
+	return &Search{
+		BaseCombatAbility: NewBaseCombatAbility(BaseCombatAbilityConfig{
+			ID:          id,
+			Name:        "Search",
+			Description: "Make a Perception check to find hidden creatures or objects.",
+			ActionType:  coreCombat.ActionStandard,
+			Ref:         refs.CombatAbilities.Search(),
+		}),
+	}
+}
+
+// CanActivate checks if the Search ability can be activated.
+// Requires an available action and an event bus.
+func (s *Search) CanActivate(ctx context.Context, owner core.Entity, input CombatAbilityInput) error {
+	if err := s.BaseCombatAbility.CanActivate(ctx, owner, input); err != nil {
+		return err
+	}
+	if input.Bus == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "event bus required for Search")
+	}
+	return nil
+}
+
+// Activate consumes 1 action, rolls the Perception check, and publishes a SearchActivatedEvent.
+func (s *Search) Activate(ctx context.Context, owner core.Entity, input CombatAbilityInput) error {
+	if input.Bus == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "event bus required for Search")
+	}
+	if err := s.BaseCombatAbility.Activate(ctx, owner, input); err != nil {
+		return err
+	}
+	check, err := rollSkillCheck(ctx, owner, input, skills.Perception, refs.CombatAbilities.Search())
+	if err != nil {
+		return fmt.Errorf("failed to make perception check: %w", err)
+	}
+	if err := dnd5eEvents.SearchActivatedTopic.On(input.Bus).Publish(ctx, dnd5eEvents.SearchActivatedEvent{
+		CharacterID:     owner.GetID(),
+		PerceptionTotal: check.Total,
+	}); err != nil {
+		return fmt.Errorf("failed to publish search activated event: %w", err)
+	}
+	return nil
+}
+
+// ToJSON converts the Search ability to JSON for persistence.
+func (s *Search) ToJSON() (json.RawMessage, error) {
+	data := SearchData{Ref: s.Ref(), ID: s.GetID()}
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal search ability data: %w", err)
+	}
+	return bytes, nil
+}
+
+// loadJSON deserializes a Search ability from JSON.
+func (s *Search) loadJSON(data json.RawMessage) error {
+	var searchData SearchData
+	if err := json.Unmarshal(data, &searchData); err != nil {
+		return fmt.Errorf("failed to unmarshal search ability data: %w", err)
+	}
+	s.BaseCombatAbility = NewBaseCombatAbility(BaseCombatAbilityConfig{
+		ID:          searchData.ID,
+		Name:        "Search",
+		Description: "Make a Perception check to find hidden creatures or objects.",
+		ActionType:  coreCombat.ActionStandard,
+		Ref:         refs.CombatAbilities.Search(),
+	})
+	return nil
+}
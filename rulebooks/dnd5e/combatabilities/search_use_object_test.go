@@ -0,0 +1,182 @@
+package combatabilities_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	coreCombat "github.com/KirkDiggler/rpg-toolkit/core/combat"
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combatabilities"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
+)
+
+// skilledOwner is an owner that knows its skill modifiers, as a character does
+type skilledOwner struct {
+	mockOwner
+	modifiers map[skills.Skill]int
+}
+
+func (o *skilledOwner) GetSkillModifier(skill skills.Skill) int {
+	return o.modifiers[skill]
+}
+
+// SearchAbilityTestSuite covers the Search combat ability: it consumes the
+// standard action, makes the Perception check, and publishes SearchActivatedEvent.
+type SearchAbilityTestSuite struct {
+	suite.Suite
+	ctx           context.Context
+	bus           events.EventBus
+	actionEconomy *combat.ActionEconomy
+	search        *combatabilities.Search
+}
+
+func TestSearchAbilityTestSuite(t *testing.T) {
+	suite.Run(t, new(SearchAbilityTestSuite))
+}
+
+func (s *SearchAbilityTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.actionEconomy = combat.NewActionEconomy()
+	s.search = combatabilities.NewSearch("test-search")
+}
+
+func (s *SearchAbilityTestSuite) TestNewSearch_Properties() {
+	s.Equal("test-search", s.search.GetID())
+	s.Equal("Search", s.search.Name())
+	s.Equal(coreCombat.ActionStandard, s.search.ActionType())
+	s.Equal(refs.CombatAbilities.Search(), s.search.Ref())
+}
+
+func (s *SearchAbilityTestSuite) TestActivate_ReportsPerceptionTotal() {
+	ctrl := gomock.NewController(s.T())
+	roller := mock_dice.NewMockRoller(ctrl)
+	roller.EXPECT().Roll(gomock.Any(), 20).Return(9, nil)
+
+	var got dnd5eEvents.SearchActivatedEvent
+	_, err := dnd5eEvents.SearchActivatedTopic.On(s.bus).Subscribe(
+		s.ctx,
+		func(_ context.Context, e dnd5eEvents.SearchActivatedEvent) error {
+			got = e
+			return nil
+		},
+	)
+	s.Require().NoError(err)
+
+	owner := &skilledOwner{mockOwner: mockOwner{id: "ranger"}, modifiers: map[skills.Skill]int{skills.Perception: 5}}
+	err = s.search.Activate(s.ctx, owner, combatabilities.CombatAbilityInput{
+		ActionEconomy: s.actionEconomy, Bus: s.bus, Roller: roller,
+	})
+	s.Require().NoError(err)
+	s.Equal(0, s.actionEconomy.ActionsRemaining, "Search consumes the standard action")
+	s.Equal("ranger", got.CharacterID)
+	s.Equal(14, got.PerceptionTotal, "d20 9 + Perception 5")
+}
+
+func (s *SearchAbilityTestSuite) TestCanActivate() {
+	s.Run("requires an event bus", func() {
+		err := s.search.CanActivate(s.ctx, &mockOwner{id: "ranger"}, combatabilities.CombatAbilityInput{
+			ActionEconomy: s.actionEconomy,
+		})
+		s.Require().Error(err)
+	})
+
+	s.Run("requires an action", func() {
+		economy := combat.NewActionEconomy()
+		s.Require().NoError(economy.UseAction())
+		err := s.search.CanActivate(s.ctx, &mockOwner{id: "ranger"}, combatabilities.CombatAbilityInput{
+			ActionEconomy: economy, Bus: s.bus,
+		})
+		s.Require().Error(err)
+	})
+}
+
+func (s *SearchAbilityTestSuite) TestToJSON_AndLoadRoundTrip() {
+	jsonData, err := s.search.ToJSON()
+	s.Require().NoError(err)
+
+	var data combatabilities.SearchData
+	s.Require().NoError(json.Unmarshal(jsonData, &data))
+	s.Equal("test-search", data.ID)
+
+	loaded, err := combatabilities.LoadJSON(jsonData)
+	s.Require().NoError(err)
+	s.Equal("Search", loaded.Name())
+	s.Equal(refs.CombatAbilities.Search().ID, loaded.Ref().ID)
+}
+
+// UseObjectAbilityTestSuite covers the Use Object combat ability: it consumes
+// the standard action and publishes UseObjectActivatedEvent naming the object.
+type UseObjectAbilityTestSuite struct {
+	suite.Suite
+	ctx           context.Context
+	bus           events.EventBus
+	owner         *mockOwner
+	actionEconomy *combat.ActionEconomy
+	useObject     *combatabilities.UseObject
+}
+
+func TestUseObjectAbilityTestSuite(t *testing.T) {
+	suite.Run(t, new(UseObjectAbilityTestSuite))
+}
+
+func (s *UseObjectAbilityTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.owner = &mockOwner{id: "test-user"}
+	s.actionEconomy = combat.NewActionEconomy()
+	s.useObject = combatabilities.NewUseObject("test-use-object")
+}
+
+func (s *UseObjectAbilityTestSuite) TestNewUseObject_Properties() {
+	s.Equal("test-use-object", s.useObject.GetID())
+	s.Equal("Use Object", s.useObject.Name())
+	s.Equal(coreCombat.ActionStandard, s.useObject.ActionType())
+	s.Equal(refs.CombatAbilities.UseObject(), s.useObject.Ref())
+}
+
+func (s *UseObjectAbilityTestSuite) TestActivate_ConsumesActionAndPublishes() {
+	var got dnd5eEvents.UseObjectActivatedEvent
+	_, err := dnd5eEvents.UseObjectActivatedTopic.On(s.bus).Subscribe(
+		s.ctx,
+		func(_ context.Context, e dnd5eEvents.UseObjectActivatedEvent) error {
+			got = e
+			return nil
+		},
+	)
+	s.Require().NoError(err)
+
+	err = s.useObject.Activate(s.ctx, s.owner, combatabilities.CombatAbilityInput{
+		ActionEconomy: s.actionEconomy, Bus: s.bus, TargetID: "potion-of-healing",
+	})
+	s.Require().NoError(err)
+	s.Equal(0, s.actionEconomy.ActionsRemaining, "Use Object consumes the standard action")
+	s.Equal(s.owner.GetID(), got.CharacterID)
+	s.Equal("potion-of-healing", got.ObjectID)
+}
+
+func (s *UseObjectAbilityTestSuite) TestActivate_RequiresObject() {
+	err := s.useObject.Activate(s.ctx, s.owner, combatabilities.CombatAbilityInput{
+		ActionEconomy: s.actionEconomy, Bus: s.bus,
+	})
+	s.Require().Error(err)
+	s.Equal(1, s.actionEconomy.ActionsRemaining, "the action isn't spent without an object")
+}
+
+func (s *UseObjectAbilityTestSuite) TestToJSON_AndLoadRoundTrip() {
+	jsonData, err := s.useObject.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := combatabilities.LoadJSON(jsonData)
+	s.Require().NoError(err)
+	s.Equal("Use Object", loaded.Name())
+	s.Equal("test-use-object", loaded.GetID())
+}
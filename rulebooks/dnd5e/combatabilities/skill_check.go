@@ -0,0 +1,44 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package combatabilities
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/checks"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
+)
+
+// skillModifierSource is implemented by owners that know their skill
+// modifiers, such as characters
+type skillModifierSource interface {
+	GetSkillModifier(skill skills.Skill) int
+}
+
+// rollSkillCheck makes the owner's check for an ability like Hide or Search.
+// The modifier comes from the owner when it knows its skills, and already
+// includes proficiency and expertise.
+func rollSkillCheck(
+	ctx context.Context,
+	owner core.Entity,
+	input CombatAbilityInput,
+	skill skills.Skill,
+	source *core.Ref,
+) (*checks.AbilityCheckResult, error) {
+	modifier := 0
+	if s, ok := owner.(skillModifierSource); ok {
+		modifier = s.GetSkillModifier(skill)
+	}
+
+	return checks.MakeAbilityCheck(ctx, &checks.AbilityCheckInput{
+		Roller:    input.Roller,
+		EventBus:  input.Bus,
+		CheckerID: owner.GetID(),
+		Cause:     dnd5eEvents.CheckCause{EffectRef: source},
+		Skill:     skill,
+		Modifier:  modifier,
+	})
+}
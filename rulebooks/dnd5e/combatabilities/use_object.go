@@ -0,0 +1,108 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package combatabilities
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	coreCombat "github.com/KirkDiggler/rpg-toolkit/core/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// UseObject represents the Use Object combat ability (PHB p.193).
+// Most object interactions are free during a move or action, but an object that
+// needs more (drinking a potion, pulling a stuck lever, a second interaction in
+// a turn) takes the action. When activated, it consumes 1 action and publishes
+// a UseObjectActivatedEvent naming the object; the game resolves what it does.
+type UseObject struct {
+	*BaseCombatAbility
+}
+
+// UseObjectData is the JSON structure for persisting Use Object ability state.
+type UseObjectData struct {
+	Ref *core.Ref `json:"ref"`
+	ID  string    `json:"id"`
+}
+
+// NewUseObject creates a new Use Object combat ability that uses a standard action.
+// This is the default Use Object action available to all characters.
+func NewUseObject(id string) *UseObject {
+	return &UseObject{
+		BaseCombatAbility: NewBaseCombatAbility(BaseCombatAbilityConfig{
+			ID:          id,
+			Name:        "Use Object",
+			Description: "Interact with an object that requires your action, such as drinking a potion.",
+			ActionType:  coreCombat.ActionStandard,
+			Ref:         refs.CombatAbilities.UseObject(),
+		}),
+	}
+}
+
+// CanActivate checks if the Use Object ability can be activated.
+// Requires an available action, an event bus, and the object in input.TargetID.
+func (u *UseObject) CanActivate(ctx context.Context, owner core.Entity, input CombatAbilityInput) error {
+	if err := u.BaseCombatAbility.CanActivate(ctx, owner, input); err != nil {
+		return err
+	}
+	return u.validate(input)
+}
+
+// Activate consumes 1 action and publishes a UseObjectActivatedEvent.
+func (u *UseObject) Activate(ctx context.Context, owner core.Entity, input CombatAbilityInput) error {
+	if err := u.validate(input); err != nil {
+		return err
+	}
+	if err := u.BaseCombatAbility.Activate(ctx, owner, input); err != nil {
+		return err
+	}
+	if err := dnd5eEvents.UseObjectActivatedTopic.On(input.Bus).Publish(ctx, dnd5eEvents.UseObjectActivatedEvent{
+		CharacterID: owner.GetID(),
+		ObjectID:    input.TargetID,
+	}); err != nil {
+		return fmt.Errorf("failed to publish use object activated event: %w", err)
+	}
+	return nil
+}
+
+// validate checks the input Use Object needs beyond the action itself.
+func (u *UseObject) validate(input CombatAbilityInput) error {
+	if input.Bus == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "event bus required for Use Object")
+	}
+	if input.TargetID == "" {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "object required for Use Object")
+	}
+	return nil
+}
+
+// ToJSON converts the Use Object ability to JSON for persistence.
+func (u *UseObject) ToJSON() (json.RawMessage, error) {
+	data := UseObjectData{Ref: u.Ref(), ID: u.GetID()}
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal use object ability data: %w", err)
+	}
+	return bytes, nil
+}
+
+// loadJSON deserializes a Use Object ability from JSON.
+func (u *UseObject) loadJSON(data json.RawMessage) error {
+	var useObjectData UseObjectData
+	if err := json.Unmarshal(data, &useObjectData); err != nil {
+		return fmt.Errorf("failed to unmarshal use object ability data: %w", err)
+	}
+	u.BaseCombatAbility = NewBaseCombatAbility(BaseCombatAbilityConfig{
+		ID:          useObjectData.ID,
+		Name:        "Use Object",
+		Description: "Interact with an object that requires your action, such as drinking a potion.",
+		ActionType:  coreCombat.ActionStandard,
+		Ref:         refs.CombatAbilities.UseObject(),
+	})
+	return nil
+}
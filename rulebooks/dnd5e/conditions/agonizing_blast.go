@@ -0,0 +1,148 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/gamectx"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// AgonizingBlastData is the JSON structure for persisting agonizing blast condition state
+type AgonizingBlastData struct {
+	Ref         *core.Ref `json:"ref"`
+	CharacterID string    `json:"character_id"`
+}
+
+// AgonizingBlastCondition adds the warlock's Charisma modifier to each eldritch blast beam that hits.
+// Eldritch blast damage is recognized by a WeaponRef of refs.Spells.EldritchBlast() on the damage chain.
+type AgonizingBlastCondition struct {
+	CharacterID     string
+	subscriptionIDs []string
+	bus             events.EventBus
+}
+
+// Ensure AgonizingBlastCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*AgonizingBlastCondition)(nil)
+
+// NewAgonizingBlastCondition creates a new Agonizing Blast invocation condition.
+func NewAgonizingBlastCondition(characterID string) *AgonizingBlastCondition {
+	return &AgonizingBlastCondition{
+		CharacterID: characterID,
+	}
+}
+
+// IsApplied returns true if this condition is currently applied.
+func (a *AgonizingBlastCondition) IsApplied() bool {
+	return a.bus != nil
+}
+
+// Apply subscribes this condition to damage chain events.
+func (a *AgonizingBlastCondition) Apply(ctx context.Context, bus events.EventBus) error {
+	if a.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "agonizing blast already applied")
+	}
+	a.bus = bus
+
+	damageChain := dnd5eEvents.DamageChain.On(bus)
+	subID, err := damageChain.SubscribeWithChain(ctx, a.onDamageChain)
+	if err != nil {
+		return rpgerr.Wrap(err, "failed to subscribe to damage chain")
+	}
+	a.subscriptionIDs = append(a.subscriptionIDs, subID)
+
+	return nil
+}
+
+// Remove unsubscribes this condition from events.
+func (a *AgonizingBlastCondition) Remove(ctx context.Context, bus events.EventBus) error {
+	if a.bus == nil {
+		return nil
+	}
+
+	total := len(a.subscriptionIDs)
+	var errs []error
+	for _, subID := range a.subscriptionIDs {
+		if err := bus.Unsubscribe(ctx, subID); err != nil {
+			errs = append(errs, fmt.Errorf("unsubscribe %s: %w", subID, err))
+		}
+	}
+
+	a.subscriptionIDs = nil
+	a.bus = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unsubscribe %d/%d subscriptions: %w", len(errs), total, errors.Join(errs...))
+	}
+	return nil
+}
+
+// ToJSON converts the condition to JSON for persistence.
+func (a *AgonizingBlastCondition) ToJSON() (json.RawMessage, error) {
+	data := AgonizingBlastData{
+		Ref:         refs.Conditions.AgonizingBlast(),
+		CharacterID: a.CharacterID,
+	}
+	return json.Marshal(data)
+}
+
+// loadJSON loads agonizing blast condition state from JSON.
+func (a *AgonizingBlastCondition) loadJSON(data json.RawMessage) error {
+	var blastData AgonizingBlastData
+	if err := json.Unmarshal(data, &blastData); err != nil {
+		return rpgerr.Wrap(err, "failed to unmarshal agonizing blast data")
+	}
+
+	a.CharacterID = blastData.CharacterID
+	return nil
+}
+
+// onDamageChain adds the Charisma modifier to eldritch blast damage by this character.
+func (a *AgonizingBlastCondition) onDamageChain(
+	ctx context.Context,
+	event *dnd5eEvents.DamageChainEvent,
+	c chain.Chain[*dnd5eEvents.DamageChainEvent],
+) (chain.Chain[*dnd5eEvents.DamageChainEvent], error) {
+	if event.AttackerID != a.CharacterID || event.WeaponRef != refs.Spells.EldritchBlast() {
+		return c, nil
+	}
+
+	registry, err := gamectx.RequireCharacters(ctx)
+	if err != nil {
+		return c, err
+	}
+
+	abilityScores := registry.GetCharacterAbilityScores(a.CharacterID)
+	if abilityScores == nil || abilityScores.CharismaMod() == 0 {
+		return c, nil
+	}
+	charismaMod := abilityScores.CharismaMod()
+
+	modifyDamage := func(_ context.Context, e *dnd5eEvents.DamageChainEvent) (*dnd5eEvents.DamageChainEvent, error) {
+		e.Components = append(e.Components, dnd5eEvents.DamageComponent{
+			Source:     dnd5eEvents.DamageSourceFeature,
+			SourceRef:  refs.Conditions.AgonizingBlast(),
+			FlatBonus:  charismaMod,
+			DamageType: e.DamageType,
+			IsCritical: e.IsCritical,
+		})
+		return e, nil
+	}
+
+	if err := c.Add(combat.StageFeatures, "agonizing_blast", modifyDamage); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to apply agonizing blast for character %s", a.CharacterID)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/gamectx"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/invocations"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+type AgonizingBlastTestSuite struct {
+	suite.Suite
+	ctx       context.Context
+	bus       events.EventBus
+	agonizing *AgonizingBlastCondition
+}
+
+func TestAgonizingBlastSuite(t *testing.T) {
+	suite.Run(t, new(AgonizingBlastTestSuite))
+}
+
+// SetupTest applies Agonizing Blast for a warlock with 16 Charisma (+3)
+func (s *AgonizingBlastTestSuite) SetupTest() {
+	s.bus = events.NewEventBus()
+
+	registry := gamectx.NewBasicCharacterRegistry()
+	registry.AddAbilityScores("warlock-1", &gamectx.AbilityScores{Charisma: 16})
+	gameCtx := gamectx.NewGameContext(gamectx.GameContextConfig{CharacterRegistry: registry})
+	s.ctx = gamectx.WithGameContext(context.Background(), gameCtx)
+
+	condition, err := NewInvocationCondition(invocations.AgonizingBlast, "warlock-1")
+	s.Require().NoError(err)
+	s.agonizing = condition.(*AgonizingBlastCondition)
+	s.Require().NoError(s.agonizing.Apply(s.ctx, s.bus))
+}
+
+// hit runs the damage chain for one beam and returns the final components
+func (s *AgonizingBlastTestSuite) hit(attackerID string, source *core.Ref) []dnd5eEvents.DamageComponent {
+	event := &dnd5eEvents.DamageChainEvent{
+		AttackerID: attackerID,
+		TargetID:   "goblin-1",
+		Components: []dnd5eEvents.DamageComponent{{
+			Source:            dnd5eEvents.DamageSourceSpell,
+			OriginalDiceRolls: []int{7},
+			FinalDiceRolls:    []int{7},
+			DamageType:        damage.Force,
+		}},
+		DamageType: damage.Force,
+		WeaponRef:  source,
+	}
+
+	damageChain := events.NewStagedChain[*dnd5eEvents.DamageChainEvent](combat.ModifierStages)
+	modified, err := dnd5eEvents.DamageChain.On(s.bus).PublishWithChain(s.ctx, event, damageChain)
+	s.Require().NoError(err)
+
+	final, err := modified.Execute(s.ctx, event)
+	s.Require().NoError(err)
+	return final.Components
+}
+
+func (s *AgonizingBlastTestSuite) TestAddsCharismaToEldritchBlast() {
+	components := s.hit("warlock-1", refs.Spells.EldritchBlast())
+	s.Require().Len(components, 2)
+	s.Equal(dnd5eEvents.DamageComponent{
+		Source:     dnd5eEvents.DamageSourceFeature,
+		SourceRef:  refs.Conditions.AgonizingBlast(),
+		FlatBonus:  3,
+		DamageType: damage.Force,
+	}, components[1])
+}
+
+func (s *AgonizingBlastTestSuite) TestIgnoresOtherDamage() {
+	s.Len(s.hit("warlock-1", refs.Spells.FireBolt()), 1, "other spell")
+	s.Len(s.hit("warlock-1", refs.Weapons.Dagger()), 1, "weapon attack")
+	s.Len(s.hit("warlock-2", refs.Spells.EldritchBlast()), 1, "another warlock's blast")
+}
+
+func (s *AgonizingBlastTestSuite) TestRoundTrip() {
+	data, err := s.agonizing.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := LoadJSON(data)
+	s.Require().NoError(err)
+	s.Equal(NewAgonizingBlastCondition("warlock-1"), loaded)
+
+	_, err = NewInvocationCondition(invocations.RepellingBlast, "warlock-1")
+	s.Error(err, "repelling blast is not implemented")
+}
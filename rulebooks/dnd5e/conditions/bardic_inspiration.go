@@ -0,0 +1,291 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/dice"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// BardicInspirationData is the JSON structure for persisting a held inspiration die.
+// The die can be held for up to 10 minutes, so it must survive reloads between encounters.
+type BardicInspirationData struct {
+	Ref         *core.Ref `json:"ref"`
+	CharacterID string    `json:"character_id"`
+	SourceID    string    `json:"source_id"`
+	DieSize     int       `json:"die_size"`
+}
+
+// BardicInspirationCondition represents a Bardic Inspiration die held by an ally.
+// The die is rolled and added to the holder's next attack roll, ability check or
+// saving throw as it flows through its chain, before the d20 result is final.
+// Using it ends the condition.
+type BardicInspirationCondition struct {
+	CharacterID     string // ID of the creature holding the die
+	SourceID        string // ID of the bard who granted it
+	DieSize         int    // Size of the inspiration die (6, 8, 10 or 12)
+	subscriptionIDs []string
+	bus             events.EventBus
+	roller          dice.Roller
+}
+
+// Ensure BardicInspirationCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*BardicInspirationCondition)(nil)
+
+// BardicInspirationInput provides configuration for creating a bardic inspiration condition
+type BardicInspirationInput struct {
+	CharacterID string      // ID of the creature receiving the die
+	SourceID    string      // ID of the bard
+	DieSize     int         // Size of the inspiration die
+	Roller      dice.Roller // Dice roller for the inspiration die
+}
+
+// NewBardicInspirationCondition creates a bardic inspiration condition from input
+func NewBardicInspirationCondition(input BardicInspirationInput) *BardicInspirationCondition {
+	return &BardicInspirationCondition{
+		CharacterID: input.CharacterID,
+		SourceID:    input.SourceID,
+		DieSize:     input.DieSize,
+		roller:      input.Roller,
+	}
+}
+
+// IsApplied returns true if this condition is currently applied
+func (b *BardicInspirationCondition) IsApplied() bool {
+	return b.bus != nil
+}
+
+// Apply subscribes this condition to the attack, saving throw and ability check chains
+func (b *BardicInspirationCondition) Apply(ctx context.Context, bus events.EventBus) error {
+	if b.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "bardic inspiration condition already applied")
+	}
+	b.bus = bus
+
+	attackChain := dnd5eEvents.AttackChain.On(bus)
+	subID1, err := attackChain.SubscribeWithChain(ctx, b.onAttackChain)
+	if err != nil {
+		b.bus = nil
+		return rpgerr.Wrap(err, "failed to subscribe to attack chain")
+	}
+	b.subscriptionIDs = append(b.subscriptionIDs, subID1)
+
+	saveChain := dnd5eEvents.SavingThrowChain.On(bus)
+	subID2, err := saveChain.SubscribeWithChain(ctx, b.onSavingThrowChain)
+	if err != nil {
+		_ = b.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to saving throw chain")
+	}
+	b.subscriptionIDs = append(b.subscriptionIDs, subID2)
+
+	checkChain := dnd5eEvents.AbilityCheckChain.On(bus)
+	subID3, err := checkChain.SubscribeWithChain(ctx, b.onAbilityCheckChain)
+	if err != nil {
+		_ = b.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to ability check chain")
+	}
+	b.subscriptionIDs = append(b.subscriptionIDs, subID3)
+
+	return nil
+}
+
+// Remove unsubscribes this condition from events
+func (b *BardicInspirationCondition) Remove(ctx context.Context, bus events.EventBus) error {
+	if b.bus == nil {
+		return nil
+	}
+
+	total := len(b.subscriptionIDs)
+	var errs []error
+	for _, subID := range b.subscriptionIDs {
+		if err := bus.Unsubscribe(ctx, subID); err != nil {
+			errs = append(errs, fmt.Errorf("unsubscribe %s: %w", subID, err))
+		}
+	}
+
+	b.subscriptionIDs = nil
+	b.bus = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unsubscribe %d/%d subscriptions: %w", len(errs), total, errors.Join(errs...))
+	}
+	return nil
+}
+
+// ToJSON converts the condition to JSON for persistence
+func (b *BardicInspirationCondition) ToJSON() (json.RawMessage, error) {
+	data := BardicInspirationData{
+		Ref:         refs.Conditions.BardicInspiration(),
+		CharacterID: b.CharacterID,
+		SourceID:    b.SourceID,
+		DieSize:     b.DieSize,
+	}
+
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to marshal bardic inspiration data")
+	}
+
+	return bytes, nil
+}
+
+// loadJSON loads the condition from JSON
+func (b *BardicInspirationCondition) loadJSON(data json.RawMessage) error {
+	var inspirationData BardicInspirationData
+	if err := json.Unmarshal(data, &inspirationData); err != nil {
+		return rpgerr.Wrap(err, "failed to unmarshal bardic inspiration data")
+	}
+
+	b.CharacterID = inspirationData.CharacterID
+	b.SourceID = inspirationData.SourceID
+	b.DieSize = inspirationData.DieSize
+	return nil
+}
+
+// onAttackChain adds the inspiration die to the holder's attack roll
+func (b *BardicInspirationCondition) onAttackChain(
+	ctx context.Context,
+	event dnd5eEvents.AttackChainEvent,
+	c chain.Chain[dnd5eEvents.AttackChainEvent],
+) (chain.Chain[dnd5eEvents.AttackChainEvent], error) {
+	if event.AttackerID != b.CharacterID {
+		return c, nil
+	}
+
+	roll, err := b.rollDie(ctx)
+	if err != nil {
+		return c, err
+	}
+
+	modifyAttack := func(_ context.Context, e dnd5eEvents.AttackChainEvent) (dnd5eEvents.AttackChainEvent, error) {
+		e.AttackBonus += roll
+		return e, nil
+	}
+
+	if err := c.Add(combat.StageFeatures, "bardic_inspiration", modifyAttack); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to apply bardic inspiration for character %s", b.CharacterID)
+	}
+
+	return c, b.end(ctx, "used")
+}
+
+// onSavingThrowChain adds the inspiration die to the holder's saving throw
+func (b *BardicInspirationCondition) onSavingThrowChain(
+	ctx context.Context,
+	event *dnd5eEvents.SavingThrowChainEvent,
+	c chain.Chain[*dnd5eEvents.SavingThrowChainEvent],
+) (chain.Chain[*dnd5eEvents.SavingThrowChainEvent], error) {
+	if event.SaverID != b.CharacterID {
+		return c, nil
+	}
+
+	roll, err := b.rollDie(ctx)
+	if err != nil {
+		return c, err
+	}
+
+	modifySave := func(
+		_ context.Context,
+		e *dnd5eEvents.SavingThrowChainEvent,
+	) (*dnd5eEvents.SavingThrowChainEvent, error) {
+		e.BonusSources = append(e.BonusSources, dnd5eEvents.SaveBonusSource{
+			SaveModifierSource: dnd5eEvents.SaveModifierSource{
+				Name:       "Bardic Inspiration",
+				SourceType: "feature",
+				SourceRef:  refs.Conditions.BardicInspiration(),
+				EntityID:   b.SourceID,
+			},
+			Bonus: roll,
+		})
+		return e, nil
+	}
+
+	if err := c.Add(combat.StageFeatures, "bardic_inspiration", modifySave); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to apply bardic inspiration for character %s", b.CharacterID)
+	}
+
+	return c, b.end(ctx, "used")
+}
+
+// onAbilityCheckChain adds the inspiration die to the holder's ability check
+func (b *BardicInspirationCondition) onAbilityCheckChain(
+	ctx context.Context,
+	event *dnd5eEvents.AbilityCheckChainEvent,
+	c chain.Chain[*dnd5eEvents.AbilityCheckChainEvent],
+) (chain.Chain[*dnd5eEvents.AbilityCheckChainEvent], error) {
+	if event.CheckerID != b.CharacterID {
+		return c, nil
+	}
+
+	roll, err := b.rollDie(ctx)
+	if err != nil {
+		return c, err
+	}
+
+	modifyCheck := func(
+		_ context.Context,
+		e *dnd5eEvents.AbilityCheckChainEvent,
+	) (*dnd5eEvents.AbilityCheckChainEvent, error) {
+		e.BonusSources = append(e.BonusSources, dnd5eEvents.CheckBonusSource{
+			CheckModifierSource: dnd5eEvents.CheckModifierSource{
+				Name:       "Bardic Inspiration",
+				SourceType: "feature",
+				SourceRef:  refs.Conditions.BardicInspiration(),
+				EntityID:   b.SourceID,
+			},
+			Bonus: roll,
+		})
+		return e, nil
+	}
+
+	if err := c.Add(combat.StageFeatures, "bardic_inspiration", modifyCheck); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to apply bardic inspiration for character %s", b.CharacterID)
+	}
+
+	return c, b.end(ctx, "used")
+}
+
+// rollDie rolls the inspiration die
+func (b *BardicInspirationCondition) rollDie(ctx context.Context) (int, error) {
+	roller := b.roller
+	if roller == nil {
+		roller = dice.NewRoller()
+	}
+
+	roll, err := roller.Roll(ctx, b.DieSize)
+	if err != nil {
+		return 0, rpgerr.Wrap(err, "failed to roll bardic inspiration die")
+	}
+	return roll, nil
+}
+
+// end publishes the removal event and unsubscribes from all events
+func (b *BardicInspirationCondition) end(ctx context.Context, reason string) error {
+	if b.bus == nil {
+		return nil
+	}
+
+	removals := dnd5eEvents.ConditionRemovedTopic.On(b.bus)
+	err := removals.Publish(ctx, dnd5eEvents.ConditionRemovedEvent{
+		CharacterID:  b.CharacterID,
+		ConditionRef: refs.Conditions.BardicInspiration().String(),
+		Reason:       reason,
+	})
+	if err != nil {
+		return rpgerr.Wrapf(err, "error publishing bardic inspiration removal for %s", b.CharacterID)
+	}
+
+	return b.Remove(ctx, b.bus)
+}
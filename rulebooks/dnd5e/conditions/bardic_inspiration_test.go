@@ -0,0 +1,155 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/checks"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/saves"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
+)
+
+type BardicInspirationTestSuite struct {
+	suite.Suite
+	ctrl        *gomock.Controller
+	ctx         context.Context
+	bus         events.EventBus
+	roller      *mock_dice.MockRoller
+	inspiration *BardicInspirationCondition
+	removals    []dnd5eEvents.ConditionRemovedEvent
+}
+
+func TestBardicInspirationSuite(t *testing.T) {
+	suite.Run(t, new(BardicInspirationTestSuite))
+}
+
+// SetupTest gives fighter-1 a d8 inspiration die from bard-1
+func (s *BardicInspirationTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.roller = mock_dice.NewMockRoller(s.ctrl)
+	s.removals = nil
+
+	s.inspiration = NewBardicInspirationCondition(BardicInspirationInput{
+		CharacterID: "fighter-1",
+		SourceID:    "bard-1",
+		DieSize:     8,
+		Roller:      s.roller,
+	})
+	s.Require().NoError(s.inspiration.Apply(s.ctx, s.bus))
+
+	_, err := dnd5eEvents.ConditionRemovedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, event dnd5eEvents.ConditionRemovedEvent) error {
+			s.removals = append(s.removals, event)
+			return nil
+		})
+	s.Require().NoError(err)
+}
+
+func (s *BardicInspirationTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// attack runs the attack chain for an attacker and returns the final attack bonus
+func (s *BardicInspirationTestSuite) attack(attackerID string) int {
+	event := dnd5eEvents.AttackChainEvent{
+		AttackerID:  attackerID,
+		TargetID:    "goblin-1",
+		AttackBonus: 5,
+	}
+	attackChain := events.NewStagedChain[dnd5eEvents.AttackChainEvent](combat.ModifierStages)
+	attacks := dnd5eEvents.AttackChain.On(s.bus)
+	modifiedChain, err := attacks.PublishWithChain(s.ctx, event, attackChain)
+	s.Require().NoError(err)
+
+	final, err := modifiedChain.Execute(s.ctx, event)
+	s.Require().NoError(err)
+	return final.AttackBonus
+}
+
+func (s *BardicInspirationTestSuite) TestAddsDieToAttackRoll() {
+	s.roller.EXPECT().Roll(gomock.Any(), 8).Return(6, nil)
+
+	s.Equal(11, s.attack("fighter-1"), "5 + 6 inspiration")
+
+	s.False(s.inspiration.IsApplied())
+	s.Require().Len(s.removals, 1)
+	s.Equal("fighter-1", s.removals[0].CharacterID)
+	s.Equal(refs.Conditions.BardicInspiration().String(), s.removals[0].ConditionRef)
+	s.Equal("used", s.removals[0].Reason)
+
+	s.Equal(5, s.attack("fighter-1"), "the die is spent")
+}
+
+func (s *BardicInspirationTestSuite) TestIgnoresOtherCreatures() {
+	s.Equal(5, s.attack("bard-1"))
+	s.True(s.inspiration.IsApplied())
+	s.Empty(s.removals)
+}
+
+func (s *BardicInspirationTestSuite) TestAddsDieToSavingThrow() {
+	s.roller.EXPECT().Roll(gomock.Any(), 20).Return(9, nil)
+	s.roller.EXPECT().Roll(gomock.Any(), 8).Return(4, nil)
+
+	result, err := saves.MakeSavingThrow(s.ctx, &saves.SavingThrowInput{
+		Roller:   s.roller,
+		EventBus: s.bus,
+		SaverID:  "fighter-1",
+		Ability:  abilities.WIS,
+		DC:       15,
+		Modifier: 2,
+	})
+	s.Require().NoError(err)
+
+	s.Equal(15, result.Total, "9 + 2 WIS + 4 inspiration")
+	s.True(result.Success, "the die turned a failure into a success")
+	s.False(s.inspiration.IsApplied())
+}
+
+func (s *BardicInspirationTestSuite) TestAddsDieToAbilityCheck() {
+	s.roller.EXPECT().Roll(gomock.Any(), 20).Return(10, nil)
+	s.roller.EXPECT().Roll(gomock.Any(), 8).Return(3, nil)
+
+	result, err := checks.MakeAbilityCheck(s.ctx, &checks.AbilityCheckInput{
+		Roller:    s.roller,
+		EventBus:  s.bus,
+		CheckerID: "fighter-1",
+		Skill:     skills.Athletics,
+		DC:        15,
+		Modifier:  3,
+	})
+	s.Require().NoError(err)
+
+	s.Equal(16, result.Total, "10 + 3 STR + 3 inspiration")
+	s.Require().Len(result.BonusSources, 1)
+	s.Equal(refs.Conditions.BardicInspiration(), result.BonusSources[0].SourceRef)
+	s.Equal("bard-1", result.BonusSources[0].EntityID)
+	s.False(s.inspiration.IsApplied())
+}
+
+func (s *BardicInspirationTestSuite) TestRoundTrip() {
+	data, err := s.inspiration.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := LoadJSON(data)
+	s.Require().NoError(err)
+
+	inspiration, ok := loaded.(*BardicInspirationCondition)
+	s.Require().True(ok)
+	s.Equal("fighter-1", inspiration.CharacterID)
+	s.Equal("bard-1", inspiration.SourceID)
+	s.Equal(8, inspiration.DieSize)
+}
@@ -0,0 +1,33 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// BlindedCondition represents a creature that can't see.
+//
+// While applied:
+//   - Ability checks that require sight fail automatically
+//   - Attacks against the creature have advantage
+//   - The creature's attacks have disadvantage
+type BlindedCondition struct {
+	standardCondition
+}
+
+// Ensure BlindedCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*BlindedCondition)(nil)
+
+// NewBlindedCondition creates a blinded condition from input
+func NewBlindedCondition(input StandardConditionInput) *BlindedCondition {
+	return &BlindedCondition{newStandardCondition(input, standardEffects{
+		name:                  "Blinded",
+		ref:                   refs.Conditions.Blinded(),
+		failsSightChecks:      true,
+		attackedWithAdvantage: true,
+		attackDisadvantage:    true,
+	})}
+}
@@ -156,7 +156,7 @@ func (b *BrutalCriticalCondition) onDamageChain(
 	// 1. We're the attacker
 	// 2. This is a critical hit
 	// 3. We have extra dice to add (level 9+)
-	if event.AttackerID != b.CharacterID || !event.IsCritical || b.ExtraDice == 0 {
+	if event.AttackerID != b.CharacterID || !event.IsCritical || b.ExtraDice == 0 || isSpellRef(event.WeaponRef) {
 		return c, nil
 	}
 
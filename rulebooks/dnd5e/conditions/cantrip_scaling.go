@@ -0,0 +1,201 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/dice"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/gamectx"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/spells"
+)
+
+// CantripScalingData is the JSON structure for persisting cantrip scaling condition state
+type CantripScalingData struct {
+	Ref         *core.Ref `json:"ref"`
+	CharacterID string    `json:"character_id"`
+}
+
+// CantripScalingCondition scales a caster's damage cantrips with character level.
+// At 5th, 11th, and 17th level each cantrip rolls its base damage dice one more
+// time (see spells.CantripTier). The level is read from the character registry
+// when the damage is dealt, so the condition doesn't need updating on level up.
+//
+// Cantrip damage is recognized by a WeaponRef naming a cantrip on the damage
+// chain, with its dice in WeaponDamage (as ResolveSpellAttack provides).
+// Cantrips that scale by beam (Eldritch Blast) are skipped; callers fire
+// spells.CantripBeams beams instead.
+type CantripScalingCondition struct {
+	CharacterID     string
+	subscriptionIDs []string
+	bus             events.EventBus
+	roller          dice.Roller
+}
+
+// Ensure CantripScalingCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*CantripScalingCondition)(nil)
+
+// CantripScalingInput provides configuration for creating a cantrip scaling condition
+type CantripScalingInput struct {
+	CharacterID string      // ID of the caster
+	Roller      dice.Roller // Dice roller for the extra damage dice
+}
+
+// NewCantripScalingCondition creates a cantrip scaling condition from input
+func NewCantripScalingCondition(input CantripScalingInput) *CantripScalingCondition {
+	return &CantripScalingCondition{
+		CharacterID: input.CharacterID,
+		roller:      input.Roller,
+	}
+}
+
+// IsApplied returns true if this condition is currently applied
+func (c *CantripScalingCondition) IsApplied() bool {
+	return c.bus != nil
+}
+
+// Apply subscribes this condition to damage chain events
+func (c *CantripScalingCondition) Apply(ctx context.Context, bus events.EventBus) error {
+	if c.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "cantrip scaling already applied")
+	}
+	c.bus = bus
+
+	subID, err := dnd5eEvents.DamageChain.On(bus).SubscribeWithChain(ctx, c.onDamageChain)
+	if err != nil {
+		c.bus = nil
+		return rpgerr.Wrap(err, "failed to subscribe to damage chain")
+	}
+	c.subscriptionIDs = append(c.subscriptionIDs, subID)
+
+	return nil
+}
+
+// Remove unsubscribes this condition from events
+func (c *CantripScalingCondition) Remove(ctx context.Context, bus events.EventBus) error {
+	if c.bus == nil {
+		return nil // Not applied, nothing to remove
+	}
+
+	total := len(c.subscriptionIDs)
+	var errs []error
+	for _, subID := range c.subscriptionIDs {
+		if err := bus.Unsubscribe(ctx, subID); err != nil {
+			errs = append(errs, fmt.Errorf("unsubscribe %s: %w", subID, err))
+		}
+	}
+
+	c.subscriptionIDs = nil
+	c.bus = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unsubscribe %d/%d subscriptions: %w", len(errs), total, errors.Join(errs...))
+	}
+	return nil
+}
+
+// ToJSON converts the condition to JSON for persistence
+func (c *CantripScalingCondition) ToJSON() (json.RawMessage, error) {
+	data := CantripScalingData{
+		Ref:         refs.Conditions.CantripScaling(),
+		CharacterID: c.CharacterID,
+	}
+	return json.Marshal(data)
+}
+
+// loadJSON loads cantrip scaling condition state from JSON
+func (c *CantripScalingCondition) loadJSON(data json.RawMessage) error {
+	var scalingData CantripScalingData
+	if err := json.Unmarshal(data, &scalingData); err != nil {
+		return rpgerr.Wrap(err, "failed to unmarshal cantrip scaling data")
+	}
+
+	c.CharacterID = scalingData.CharacterID
+	return nil
+}
+
+// onDamageChain adds the extra cantrip damage dice for the caster's level
+func (c *CantripScalingCondition) onDamageChain(
+	ctx context.Context,
+	event *dnd5eEvents.DamageChainEvent,
+	ch chain.Chain[*dnd5eEvents.DamageChainEvent],
+) (chain.Chain[*dnd5eEvents.DamageChainEvent], error) {
+	if event.AttackerID != c.CharacterID || !isSpellRef(event.WeaponRef) {
+		return ch, nil
+	}
+	data := spells.GetData(spells.Spell(event.WeaponRef.ID))
+	if data == nil || data.Level != 0 || data.ScalesByBeam {
+		return ch, nil
+	}
+
+	// Without a character registry the caster's level is unknown: no scaling
+	registry, ok := gamectx.Characters(ctx)
+	if !ok {
+		return ch, nil
+	}
+	tier := spells.CantripTier(registry.GetCharacterLevel(c.CharacterID))
+	if tier == 1 {
+		return ch, nil
+	}
+
+	matches := diceNotationRegex.FindStringSubmatch(event.WeaponDamage)
+	if len(matches) < 3 {
+		return ch, rpgerr.Newf(rpgerr.CodeInvalidArgument, "invalid cantrip damage notation: %s", event.WeaponDamage)
+	}
+	count := 1
+	if matches[1] != "" {
+		count, _ = strconv.Atoi(matches[1])
+	}
+	dieSize, _ := strconv.Atoi(matches[2])
+
+	extraDice := count * (tier - 1)
+	if event.IsCritical {
+		extraDice *= 2
+	}
+
+	modifyDamage := func(modCtx context.Context, e *dnd5eEvents.DamageChainEvent) (*dnd5eEvents.DamageChainEvent, error) {
+		roller := c.roller
+		if roller == nil {
+			roller = dice.NewRoller()
+		}
+
+		extraRolls, rollErr := roller.RollN(modCtx, extraDice, dieSize)
+		if rollErr != nil {
+			return e, rpgerr.Wrap(rollErr, "failed to roll cantrip scaling dice")
+		}
+
+		e.Components = append(e.Components, dnd5eEvents.DamageComponent{
+			Source:            dnd5eEvents.DamageSourceSpell,
+			SourceRef:         refs.Conditions.CantripScaling(),
+			OriginalDiceRolls: extraRolls,
+			FinalDiceRolls:    extraRolls,
+			DamageType:        e.DamageType,
+			IsCritical:        e.IsCritical,
+		})
+		return e, nil
+	}
+
+	if err := ch.Add(combat.StageFeatures, "cantrip_scaling", modifyDamage); err != nil {
+		return ch, rpgerr.Wrapf(err, "failed to apply cantrip scaling for character %s", c.CharacterID)
+	}
+
+	return ch, nil
+}
+
+// isSpellRef returns true if an attack or damage chain's WeaponRef names a
+// spell (spell attacks) rather than a weapon
+func isSpellRef(ref *core.Ref) bool {
+	return ref != nil && ref.Type == refs.TypeSpells
+}
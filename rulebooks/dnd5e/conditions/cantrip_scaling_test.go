@@ -0,0 +1,120 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/gamectx"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+type CantripScalingTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	ctx      context.Context
+	bus      events.EventBus
+	registry *gamectx.BasicCharacterRegistry
+	roller   *mock_dice.MockRoller
+}
+
+func TestCantripScalingSuite(t *testing.T) {
+	suite.Run(t, new(CantripScalingTestSuite))
+}
+
+func (s *CantripScalingTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.bus = events.NewEventBus()
+	s.roller = mock_dice.NewMockRoller(s.ctrl)
+
+	s.registry = gamectx.NewBasicCharacterRegistry()
+	gameCtx := gamectx.NewGameContext(gamectx.GameContextConfig{CharacterRegistry: s.registry})
+	s.ctx = gamectx.WithGameContext(context.Background(), gameCtx)
+
+	condition := NewCantripScalingCondition(CantripScalingInput{CharacterID: "wizard-1", Roller: s.roller})
+	s.Require().NoError(condition.Apply(s.ctx, s.bus))
+}
+
+func (s *CantripScalingTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// hit runs the damage chain for one cantrip hit and returns the final components
+func (s *CantripScalingTestSuite) hit(spell *core.Ref, notation string, critical bool) []dnd5eEvents.DamageComponent {
+	event := &dnd5eEvents.DamageChainEvent{
+		AttackerID: "wizard-1",
+		TargetID:   "goblin-1",
+		Components: []dnd5eEvents.DamageComponent{{
+			Source:            dnd5eEvents.DamageSourceSpell,
+			SourceRef:         spell,
+			OriginalDiceRolls: []int{6},
+			FinalDiceRolls:    []int{6},
+			DamageType:        damage.Fire,
+		}},
+		DamageType:   damage.Fire,
+		IsCritical:   critical,
+		WeaponDamage: notation,
+		WeaponRef:    spell,
+	}
+
+	damageChain := events.NewStagedChain[*dnd5eEvents.DamageChainEvent](combat.ModifierStages)
+	modified, err := dnd5eEvents.DamageChain.On(s.bus).PublishWithChain(s.ctx, event, damageChain)
+	s.Require().NoError(err)
+
+	final, err := modified.Execute(s.ctx, event)
+	s.Require().NoError(err)
+	return final.Components
+}
+
+func (s *CantripScalingTestSuite) TestNoExtraDiceBelowFifthLevel() {
+	s.registry.AddLevel("wizard-1", 4)
+	s.Len(s.hit(refs.Spells.FireBolt(), "1d10", false), 1)
+}
+
+func (s *CantripScalingTestSuite) TestAddsDiceAtEachTier() {
+	s.registry.AddLevel("wizard-1", 11)
+	s.roller.EXPECT().RollN(gomock.Any(), 2, 10).Return([]int{4, 9}, nil)
+
+	components := s.hit(refs.Spells.FireBolt(), "1d10", false)
+	s.Require().Len(components, 2)
+	s.Equal(refs.Conditions.CantripScaling(), components[1].SourceRef)
+	s.Equal([]int{4, 9}, components[1].FinalDiceRolls)
+	s.Equal(damage.Fire, components[1].DamageType)
+}
+
+func (s *CantripScalingTestSuite) TestCriticalDoublesExtraDice() {
+	s.registry.AddLevel("wizard-1", 5)
+	s.roller.EXPECT().RollN(gomock.Any(), 2, 10).Return([]int{1, 2}, nil)
+
+	s.Len(s.hit(refs.Spells.FireBolt(), "1d10", true), 2)
+}
+
+func (s *CantripScalingTestSuite) TestSkipsBeamCantripsAndLeveledSpells() {
+	s.registry.AddLevel("wizard-1", 17)
+	s.Len(s.hit(refs.Spells.EldritchBlast(), "1d10", false), 1)
+	s.Len(s.hit(refs.Spells.ScorchingRay(), "2d6", false), 1)
+	s.Len(s.hit(refs.Weapons.Longsword(), "1d8", false), 1)
+}
+
+func (s *CantripScalingTestSuite) TestRoundTrip() {
+	condition := NewCantripScalingCondition(CantripScalingInput{CharacterID: "wizard-1"})
+	data, err := condition.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := LoadJSON(data)
+	s.Require().NoError(err)
+	scaling, ok := loaded.(*CantripScalingCondition)
+	s.Require().True(ok)
+	s.Equal("wizard-1", scaling.CharacterID)
+}
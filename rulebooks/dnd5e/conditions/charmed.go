@@ -0,0 +1,32 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// CharmedCondition represents a creature charmed by another (SourceID).
+//
+// While applied:
+//   - The creature can't attack the charmer: such attacks are cancelled
+//
+// The charmer's advantage on social checks against the creature isn't modeled:
+// ability checks don't carry a target.
+type CharmedCondition struct {
+	standardCondition
+}
+
+// Ensure CharmedCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*CharmedCondition)(nil)
+
+// NewCharmedCondition creates a charmed condition from input
+func NewCharmedCondition(input StandardConditionInput) *CharmedCondition {
+	return &CharmedCondition{newStandardCondition(input, standardEffects{
+		name:             "Charmed",
+		ref:              refs.Conditions.Charmed(),
+		cantAttackSource: true,
+	})}
+}
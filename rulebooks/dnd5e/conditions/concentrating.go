@@ -0,0 +1,234 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/dice"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/saves"
+)
+
+// Reasons a caster's concentration can end
+const (
+	ConcentrationEndedFailedSave = "failed_save"
+	ConcentrationEndedDied       = "died"
+	ConcentrationEndedVoluntary  = "ended"
+)
+
+// minConcentrationDC is the lowest concentration save DC
+const minConcentrationDC = 10
+
+// ConcentratingData is the JSON structure for persisting concentrating condition state
+type ConcentratingData struct {
+	Ref          *core.Ref `json:"ref"`
+	CharacterID  string    `json:"character_id"`
+	Spell        string    `json:"spell"`
+	SaveModifier int       `json:"save_modifier"`
+}
+
+// ConcentratingCondition represents a caster maintaining concentration on a
+// spell. Anything tied to the spell, such as summoned creatures, listens for
+// the ConcentrationEndedEvent it publishes when it ends.
+//
+// Concentration ends when:
+//   - The caster fails a CON save after taking damage (DC 10 or half the damage)
+//   - The caster is incapacitated (including dropping unconscious) or dies
+//   - The caster ends it (End), e.g. to cast another concentration spell
+type ConcentratingCondition struct {
+	CharacterID     string
+	Spell           string
+	SaveModifier    int         // Caster's CON saving throw modifier
+	Roller          dice.Roller // Optional roller for concentration saves
+	bus             events.EventBus
+	subscriptionIDs []string
+}
+
+// Ensure ConcentratingCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*ConcentratingCondition)(nil)
+
+// NewConcentratingCondition creates a concentrating condition for a caster.
+// saveModifier is the caster's CON saving throw modifier.
+func NewConcentratingCondition(characterID, spell string, saveModifier int) *ConcentratingCondition {
+	return &ConcentratingCondition{
+		CharacterID:  characterID,
+		Spell:        spell,
+		SaveModifier: saveModifier,
+	}
+}
+
+// IsApplied returns true if this condition is currently applied
+func (c *ConcentratingCondition) IsApplied() bool {
+	return c.bus != nil
+}
+
+// Apply subscribes this condition to damage, condition, and death events
+func (c *ConcentratingCondition) Apply(ctx context.Context, bus events.EventBus) error {
+	if c.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "concentrating condition already applied")
+	}
+	c.bus = bus
+
+	damageSubID, err := dnd5eEvents.DamageReceivedTopic.On(bus).Subscribe(ctx, c.onDamageReceived)
+	if err != nil {
+		c.bus = nil
+		return rpgerr.Wrap(err, "failed to subscribe to damage received")
+	}
+	c.subscriptionIDs = append(c.subscriptionIDs, damageSubID)
+
+	conditionSubID, err := dnd5eEvents.ConditionAppliedTopic.On(bus).Subscribe(ctx, c.onConditionApplied)
+	if err != nil {
+		_ = c.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to condition applied")
+	}
+	c.subscriptionIDs = append(c.subscriptionIDs, conditionSubID)
+
+	diedSubID, err := dnd5eEvents.CharacterDiedTopic.On(bus).Subscribe(ctx, c.onCharacterDied)
+	if err != nil {
+		_ = c.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to character died")
+	}
+	c.subscriptionIDs = append(c.subscriptionIDs, diedSubID)
+
+	return nil
+}
+
+// Remove unsubscribes this condition from events without ending the spell.
+// Use End to break concentration.
+func (c *ConcentratingCondition) Remove(ctx context.Context, bus events.EventBus) error {
+	if c.bus == nil {
+		return nil // Not applied, nothing to remove
+	}
+
+	total := len(c.subscriptionIDs)
+	var errs []error
+	for _, subID := range c.subscriptionIDs {
+		if err := bus.Unsubscribe(ctx, subID); err != nil {
+			errs = append(errs, fmt.Errorf("unsubscribe %s: %w", subID, err))
+		}
+	}
+
+	c.subscriptionIDs = nil
+	c.bus = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unsubscribe %d/%d subscriptions: %w", len(errs), total, errors.Join(errs...))
+	}
+	return nil
+}
+
+// End breaks concentration: it publishes the ConcentrationEndedEvent and
+// ConditionRemovedEvent, then unsubscribes. Game servers call this when the
+// caster ends the spell, casts another concentration spell, or (for monster
+// casters) drops to 0 hit points.
+func (c *ConcentratingCondition) End(ctx context.Context, reason string) error {
+	if c.bus == nil {
+		return nil
+	}
+	bus := c.bus
+
+	err := dnd5eEvents.ConcentrationEndedTopic.On(bus).Publish(ctx, dnd5eEvents.ConcentrationEndedEvent{
+		CasterID: c.CharacterID,
+		SpellID:  c.Spell,
+		Reason:   reason,
+	})
+	if err != nil {
+		return rpgerr.Wrapf(err, "failed to publish concentration ended for %s", c.CharacterID)
+	}
+
+	err = dnd5eEvents.ConditionRemovedTopic.On(bus).Publish(ctx, dnd5eEvents.ConditionRemovedEvent{
+		CharacterID:  c.CharacterID,
+		ConditionRef: refs.Conditions.Concentrating().String(),
+		Reason:       reason,
+	})
+	if err != nil {
+		return rpgerr.Wrapf(err, "failed to publish condition removed for %s", c.CharacterID)
+	}
+
+	return c.Remove(ctx, bus)
+}
+
+// ToJSON converts the condition to JSON for persistence
+func (c *ConcentratingCondition) ToJSON() (json.RawMessage, error) {
+	data := ConcentratingData{
+		Ref:          refs.Conditions.Concentrating(),
+		CharacterID:  c.CharacterID,
+		Spell:        c.Spell,
+		SaveModifier: c.SaveModifier,
+	}
+	return json.Marshal(data)
+}
+
+// loadJSON loads concentrating condition state from JSON
+func (c *ConcentratingCondition) loadJSON(data json.RawMessage) error {
+	var cd ConcentratingData
+	if err := json.Unmarshal(data, &cd); err != nil {
+		return rpgerr.Wrap(err, "failed to unmarshal concentrating data")
+	}
+
+	c.CharacterID = cd.CharacterID
+	c.Spell = cd.Spell
+	c.SaveModifier = cd.SaveModifier
+	return nil
+}
+
+// onDamageReceived makes a concentration save when the caster takes damage
+func (c *ConcentratingCondition) onDamageReceived(ctx context.Context, event dnd5eEvents.DamageReceivedEvent) error {
+	if event.TargetID != c.CharacterID || event.Amount <= 0 {
+		return nil
+	}
+
+	result, err := saves.MakeSavingThrow(ctx, &saves.SavingThrowInput{
+		Roller:   c.Roller,
+		EventBus: c.bus,
+		SaverID:  c.CharacterID,
+		Cause: dnd5eEvents.SaveCause{
+			Trigger:      dnd5eEvents.SaveTriggerConcentration,
+			EffectRef:    refs.Conditions.Concentrating(),
+			InstigatorID: event.SourceID,
+		},
+		Ability:  abilities.CON,
+		DC:       max(minConcentrationDC, event.Amount/2),
+		Modifier: c.SaveModifier,
+	})
+	if err != nil {
+		return rpgerr.Wrapf(err, "failed to make concentration save for %s", c.CharacterID)
+	}
+
+	if result.Success {
+		return nil
+	}
+	return c.End(ctx, ConcentrationEndedFailedSave)
+}
+
+// onConditionApplied ends concentration when the caster is incapacitated
+func (c *ConcentratingCondition) onConditionApplied(ctx context.Context, event dnd5eEvents.ConditionAppliedEvent) error {
+	if event.Target == nil || event.Target.GetID() != c.CharacterID {
+		return nil
+	}
+
+	switch event.Type {
+	case dnd5eEvents.ConditionIncapacitated, dnd5eEvents.ConditionParalyzed, dnd5eEvents.ConditionPetrified,
+		dnd5eEvents.ConditionStunned, dnd5eEvents.ConditionUnconscious:
+		return c.End(ctx, string(event.Type))
+	}
+	return nil
+}
+
+// onCharacterDied ends concentration when the caster dies
+func (c *ConcentratingCondition) onCharacterDied(ctx context.Context, event dnd5eEvents.CharacterDiedEvent) error {
+	if event.CharacterID != c.CharacterID {
+		return nil
+	}
+	return c.End(ctx, ConcentrationEndedDied)
+}
@@ -0,0 +1,157 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+type ConcentratingConditionTestSuite struct {
+	suite.Suite
+	ctrl       *gomock.Controller
+	ctx        context.Context
+	bus        events.EventBus
+	mockRoller *mock_dice.MockRoller
+	ended      []dnd5eEvents.ConcentrationEndedEvent
+	removed    []dnd5eEvents.ConditionRemovedEvent
+}
+
+func TestConcentratingConditionSuite(t *testing.T) {
+	suite.Run(t, new(ConcentratingConditionTestSuite))
+}
+
+func (s *ConcentratingConditionTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.mockRoller = mock_dice.NewMockRoller(s.ctrl)
+	s.ended = nil
+	s.removed = nil
+
+	_, err := dnd5eEvents.ConcentrationEndedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, event dnd5eEvents.ConcentrationEndedEvent) error {
+			s.ended = append(s.ended, event)
+			return nil
+		})
+	s.Require().NoError(err)
+
+	_, err = dnd5eEvents.ConditionRemovedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, event dnd5eEvents.ConditionRemovedEvent) error {
+			s.removed = append(s.removed, event)
+			return nil
+		})
+	s.Require().NoError(err)
+}
+
+func (s *ConcentratingConditionTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *ConcentratingConditionTestSuite) applyCondition() *ConcentratingCondition {
+	c := NewConcentratingCondition("druid-1", "conjure-animals", 2)
+	c.Roller = s.mockRoller
+	s.Require().NoError(c.Apply(s.ctx, s.bus))
+	return c
+}
+
+func (s *ConcentratingConditionTestSuite) damage(targetID string, amount int) {
+	err := dnd5eEvents.DamageReceivedTopic.On(s.bus).Publish(s.ctx, dnd5eEvents.DamageReceivedEvent{
+		TargetID: targetID,
+		SourceID: "orc-1",
+		Amount:   amount,
+	})
+	s.Require().NoError(err)
+}
+
+func (s *ConcentratingConditionTestSuite) TestDamageSave() {
+	s.Run("passing the save keeps concentration", func() {
+		s.SetupTest()
+		c := s.applyCondition()
+
+		// DC 10 (minimum): 8 + 2 = 10
+		s.mockRoller.EXPECT().Roll(gomock.Any(), 20).Return(8, nil)
+		s.damage("druid-1", 7)
+
+		s.True(c.IsApplied())
+		s.Empty(s.ended)
+	})
+
+	s.Run("DC is half the damage when higher", func() {
+		s.SetupTest()
+		c := s.applyCondition()
+
+		// DC 15: 12 + 2 = 14 fails
+		s.mockRoller.EXPECT().Roll(gomock.Any(), 20).Return(12, nil)
+		s.damage("druid-1", 30)
+
+		s.False(c.IsApplied())
+		s.Equal([]dnd5eEvents.ConcentrationEndedEvent{
+			{CasterID: "druid-1", SpellID: "conjure-animals", Reason: ConcentrationEndedFailedSave},
+		}, s.ended)
+		s.Require().Len(s.removed, 1)
+		s.Equal(refs.Conditions.Concentrating().String(), s.removed[0].ConditionRef)
+	})
+
+	s.Run("damage to others is ignored", func() {
+		s.SetupTest()
+		c := s.applyCondition()
+
+		s.damage("wolf-1", 30)
+		s.True(c.IsApplied())
+	})
+}
+
+func (s *ConcentratingConditionTestSuite) TestEndsWhenCasterDrops() {
+	c := s.applyCondition()
+
+	err := dnd5eEvents.ConditionAppliedTopic.On(s.bus).Publish(s.ctx, dnd5eEvents.ConditionAppliedEvent{
+		Target: &mockEntity{id: "druid-1", entityType: "character"},
+		Type:   dnd5eEvents.ConditionUnconscious,
+	})
+	s.Require().NoError(err)
+
+	s.False(c.IsApplied())
+	s.Require().Len(s.ended, 1)
+	s.Equal("unconscious", s.ended[0].Reason)
+}
+
+func (s *ConcentratingConditionTestSuite) TestEndsWhenCasterDies() {
+	c := s.applyCondition()
+
+	err := dnd5eEvents.CharacterDiedTopic.On(s.bus).Publish(s.ctx, dnd5eEvents.CharacterDiedEvent{CharacterID: "druid-1"})
+	s.Require().NoError(err)
+
+	s.False(c.IsApplied())
+	s.Require().Len(s.ended, 1)
+	s.Equal(ConcentrationEndedDied, s.ended[0].Reason)
+
+	// Ending again is a no-op
+	s.NoError(c.End(s.ctx, ConcentrationEndedVoluntary))
+	s.Len(s.ended, 1)
+}
+
+func (s *ConcentratingConditionTestSuite) TestJSONRoundTrip() {
+	c := NewConcentratingCondition("druid-1", "conjure-animals", 2)
+
+	data, err := c.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := LoadJSON(data)
+	s.Require().NoError(err)
+
+	concentrating, ok := loaded.(*ConcentratingCondition)
+	s.Require().True(ok)
+	s.Equal("druid-1", concentrating.CharacterID)
+	s.Equal("conjure-animals", concentrating.Spell)
+	s.Equal(2, concentrating.SaveModifier)
+}
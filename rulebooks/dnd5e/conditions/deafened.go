@@ -0,0 +1,29 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// DeafenedCondition represents a creature that can't hear.
+//
+// While applied:
+//   - Ability checks that require hearing fail automatically
+type DeafenedCondition struct {
+	standardCondition
+}
+
+// Ensure DeafenedCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*DeafenedCondition)(nil)
+
+// NewDeafenedCondition creates a deafened condition from input
+func NewDeafenedCondition(input StandardConditionInput) *DeafenedCondition {
+	return &DeafenedCondition{newStandardCondition(input, standardEffects{
+		name:         "Deafened",
+		ref:          refs.Conditions.Deafened(),
+		failsHearing: true,
+	})}
+}
@@ -0,0 +1,143 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// DevilsSightData is the JSON structure for persisting devil's sight condition state
+type DevilsSightData struct {
+	Ref         *core.Ref `json:"ref"`
+	CharacterID string    `json:"character_id"`
+}
+
+// DevilsSightCondition lets a warlock see normally in darkness, magical or not.
+// It strips the darkness modifiers combat.EnvironmentalModifiers adds for creatures
+// that can't see: the warlock's attacks into darkness lose their disadvantage, and
+// attackers hidden by darkness lose their advantage against the warlock.
+type DevilsSightCondition struct {
+	CharacterID     string
+	subscriptionIDs []string
+	bus             events.EventBus
+}
+
+// Ensure DevilsSightCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*DevilsSightCondition)(nil)
+
+// NewDevilsSightCondition creates a new Devil's Sight invocation condition.
+func NewDevilsSightCondition(characterID string) *DevilsSightCondition {
+	return &DevilsSightCondition{
+		CharacterID: characterID,
+	}
+}
+
+// IsApplied returns true if this condition is currently applied.
+func (d *DevilsSightCondition) IsApplied() bool {
+	return d.bus != nil
+}
+
+// Apply subscribes this condition to attack chain events.
+func (d *DevilsSightCondition) Apply(ctx context.Context, bus events.EventBus) error {
+	if d.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "devil's sight already applied")
+	}
+	d.bus = bus
+
+	attackChain := dnd5eEvents.AttackChain.On(bus)
+	subID, err := attackChain.SubscribeWithChain(ctx, d.onAttackChain)
+	if err != nil {
+		return rpgerr.Wrap(err, "failed to subscribe to attack chain")
+	}
+	d.subscriptionIDs = append(d.subscriptionIDs, subID)
+
+	return nil
+}
+
+// Remove unsubscribes this condition from events.
+func (d *DevilsSightCondition) Remove(ctx context.Context, bus events.EventBus) error {
+	if d.bus == nil {
+		return nil
+	}
+
+	total := len(d.subscriptionIDs)
+	var errs []error
+	for _, subID := range d.subscriptionIDs {
+		if err := bus.Unsubscribe(ctx, subID); err != nil {
+			errs = append(errs, fmt.Errorf("unsubscribe %s: %w", subID, err))
+		}
+	}
+
+	d.subscriptionIDs = nil
+	d.bus = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unsubscribe %d/%d subscriptions: %w", len(errs), total, errors.Join(errs...))
+	}
+	return nil
+}
+
+// ToJSON converts the condition to JSON for persistence.
+func (d *DevilsSightCondition) ToJSON() (json.RawMessage, error) {
+	data := DevilsSightData{
+		Ref:         refs.Conditions.DevilsSight(),
+		CharacterID: d.CharacterID,
+	}
+	return json.Marshal(data)
+}
+
+// loadJSON loads devil's sight condition state from JSON.
+func (d *DevilsSightCondition) loadJSON(data json.RawMessage) error {
+	var sightData DevilsSightData
+	if err := json.Unmarshal(data, &sightData); err != nil {
+		return rpgerr.Wrap(err, "failed to unmarshal devil's sight data")
+	}
+
+	d.CharacterID = sightData.CharacterID
+	return nil
+}
+
+// onAttackChain removes darkness modifiers that assume this character can't see.
+// It runs at StageFinal, after the environment adds them at StageConditions.
+func (d *DevilsSightCondition) onAttackChain(
+	_ context.Context,
+	event dnd5eEvents.AttackChainEvent,
+	c chain.Chain[dnd5eEvents.AttackChainEvent],
+) (chain.Chain[dnd5eEvents.AttackChainEvent], error) {
+	attacking := event.AttackerID == d.CharacterID
+	if !attacking && event.TargetID != d.CharacterID {
+		return c, nil
+	}
+
+	isDarkness := func(source dnd5eEvents.AttackModifierSource) bool {
+		return source.SourceRef == refs.Conditions.Darkness()
+	}
+
+	modifyAttack := func(_ context.Context, e dnd5eEvents.AttackChainEvent) (dnd5eEvents.AttackChainEvent, error) {
+		if attacking {
+			e.DisadvantageSources = slices.DeleteFunc(slices.Clone(e.DisadvantageSources), isDarkness)
+		} else {
+			e.AdvantageSources = slices.DeleteFunc(slices.Clone(e.AdvantageSources), isDarkness)
+		}
+		return e, nil
+	}
+
+	if err := c.Add(combat.StageFinal, "devils_sight", modifyAttack); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to apply devil's sight for character %s", d.CharacterID)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+type DevilsSightTestSuite struct {
+	suite.Suite
+	ctx   context.Context
+	bus   events.EventBus
+	sight *DevilsSightCondition
+}
+
+func TestDevilsSightSuite(t *testing.T) {
+	suite.Run(t, new(DevilsSightTestSuite))
+}
+
+// SetupTest puts everyone in a dark room with a warlock who has Devil's Sight
+func (s *DevilsSightTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+
+	environment, err := combat.NewEnvironmentalModifiers(&combat.EnvironmentalModifiersConfig{
+		Provider: &combat.RoomEnvironment{Tags: []combat.EnvironmentTag{combat.EnvironmentDarkness}},
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(environment.Apply(s.ctx, s.bus))
+
+	s.sight = NewDevilsSightCondition("warlock-1")
+	s.Require().NoError(s.sight.Apply(s.ctx, s.bus))
+}
+
+// attack runs the attack chain and returns the final event
+func (s *DevilsSightTestSuite) attack(attackerID, targetID string) dnd5eEvents.AttackChainEvent {
+	event := dnd5eEvents.AttackChainEvent{AttackerID: attackerID, TargetID: targetID}
+
+	attackChain := events.NewStagedChain[dnd5eEvents.AttackChainEvent](combat.ModifierStages)
+	modified, err := dnd5eEvents.AttackChain.On(s.bus).PublishWithChain(s.ctx, event, attackChain)
+	s.Require().NoError(err)
+
+	final, err := modified.Execute(s.ctx, event)
+	s.Require().NoError(err)
+	return final
+}
+
+func (s *DevilsSightTestSuite) TestWarlockSeesTargetsInDarkness() {
+	result := s.attack("warlock-1", "goblin-1")
+	s.Empty(result.DisadvantageSources)
+	s.Require().Len(result.AdvantageSources, 1, "the goblin still can't see the warlock")
+	s.Equal(refs.Conditions.Darkness(), result.AdvantageSources[0].SourceRef)
+}
+
+func (s *DevilsSightTestSuite) TestAttackersGetNoAdvantageFromDarkness() {
+	result := s.attack("goblin-1", "warlock-1")
+	s.Empty(result.AdvantageSources)
+	s.Len(result.DisadvantageSources, 1, "the goblin still can't see the warlock")
+}
+
+func (s *DevilsSightTestSuite) TestOtherCreaturesUnaffected() {
+	result := s.attack("goblin-1", "fighter-1")
+	s.Len(result.AdvantageSources, 1)
+	s.Len(result.DisadvantageSources, 1)
+
+	s.Require().NoError(s.sight.Remove(s.ctx, s.bus))
+	result = s.attack("warlock-1", "goblin-1")
+	s.Len(result.DisadvantageSources, 1)
+}
+
+func (s *DevilsSightTestSuite) TestRoundTrip() {
+	data, err := s.sight.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := LoadJSON(data)
+	s.Require().NoError(err)
+	s.Equal(NewDevilsSightCondition("warlock-1"), loaded)
+}
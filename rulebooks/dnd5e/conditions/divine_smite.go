@@ -0,0 +1,241 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/dice"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/weapons"
+)
+
+// DivineSmiteData is the JSON structure for persisting a pending divine smite.
+// The smite is declared between ResolveAttackHit and ApplyAttackOutcome, which
+// run in separate RPC calls, so it must survive the reload in between.
+type DivineSmiteData struct {
+	Ref         *core.Ref `json:"ref"`
+	CharacterID string    `json:"character_id"`
+	TargetID    string    `json:"target_id"`
+	SlotLevel   int       `json:"slot_level"`
+	DamageDice  int       `json:"damage_dice"`
+}
+
+// DivineSmiteCondition represents a paladin's Divine Smite declared after a hit.
+// The spell slot is already spent; the condition adds radiant damage dice to the
+// paladin's melee weapon damage against the target, then ends. An unused smite
+// lapses at the end of the paladin's turn.
+type DivineSmiteCondition struct {
+	CharacterID     string
+	TargetID        string
+	SlotLevel       int // Level of the spell slot expended
+	DamageDice      int // Number of d8s to roll (doubled on a critical hit)
+	subscriptionIDs []string
+	bus             events.EventBus
+	roller          dice.Roller
+}
+
+// Ensure DivineSmiteCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*DivineSmiteCondition)(nil)
+
+// DivineSmiteInput provides configuration for creating a divine smite condition
+type DivineSmiteInput struct {
+	CharacterID string      // ID of the paladin
+	TargetID    string      // ID of the creature hit
+	SlotLevel   int         // Level of the spell slot expended
+	DamageDice  int         // Number of d8s to roll
+	Roller      dice.Roller // Dice roller for the radiant damage
+}
+
+// NewDivineSmiteCondition creates a divine smite condition from input
+func NewDivineSmiteCondition(input DivineSmiteInput) *DivineSmiteCondition {
+	return &DivineSmiteCondition{
+		CharacterID: input.CharacterID,
+		TargetID:    input.TargetID,
+		SlotLevel:   input.SlotLevel,
+		DamageDice:  input.DamageDice,
+		roller:      input.Roller,
+	}
+}
+
+// IsApplied returns true if this condition is currently applied
+func (d *DivineSmiteCondition) IsApplied() bool {
+	return d.bus != nil
+}
+
+// Apply subscribes this condition to the damage chain and turn end events
+func (d *DivineSmiteCondition) Apply(ctx context.Context, bus events.EventBus) error {
+	if d.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "divine smite condition already applied")
+	}
+	d.bus = bus
+
+	damageChain := dnd5eEvents.DamageChain.On(bus)
+	subID1, err := damageChain.SubscribeWithChain(ctx, d.onDamageChain)
+	if err != nil {
+		d.bus = nil
+		return rpgerr.Wrap(err, "failed to subscribe to damage chain")
+	}
+	d.subscriptionIDs = append(d.subscriptionIDs, subID1)
+
+	turnEnds := dnd5eEvents.TurnEndTopic.On(bus)
+	subID2, err := turnEnds.Subscribe(ctx, d.onTurnEnd)
+	if err != nil {
+		_ = d.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to turn end")
+	}
+	d.subscriptionIDs = append(d.subscriptionIDs, subID2)
+
+	return nil
+}
+
+// Remove unsubscribes this condition from events
+func (d *DivineSmiteCondition) Remove(ctx context.Context, bus events.EventBus) error {
+	if d.bus == nil {
+		return nil
+	}
+
+	total := len(d.subscriptionIDs)
+	var errs []error
+	for _, subID := range d.subscriptionIDs {
+		if err := bus.Unsubscribe(ctx, subID); err != nil {
+			errs = append(errs, fmt.Errorf("unsubscribe %s: %w", subID, err))
+		}
+	}
+
+	d.subscriptionIDs = nil
+	d.bus = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unsubscribe %d/%d subscriptions: %w", len(errs), total, errors.Join(errs...))
+	}
+	return nil
+}
+
+// ToJSON converts the condition to JSON for persistence
+func (d *DivineSmiteCondition) ToJSON() (json.RawMessage, error) {
+	data := DivineSmiteData{
+		Ref:         refs.Conditions.DivineSmite(),
+		CharacterID: d.CharacterID,
+		TargetID:    d.TargetID,
+		SlotLevel:   d.SlotLevel,
+		DamageDice:  d.DamageDice,
+	}
+
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to marshal divine smite data")
+	}
+
+	return bytes, nil
+}
+
+// loadJSON loads the condition from JSON
+func (d *DivineSmiteCondition) loadJSON(data json.RawMessage) error {
+	var smiteData DivineSmiteData
+	if err := json.Unmarshal(data, &smiteData); err != nil {
+		return rpgerr.Wrap(err, "failed to unmarshal divine smite data")
+	}
+
+	d.CharacterID = smiteData.CharacterID
+	d.TargetID = smiteData.TargetID
+	d.SlotLevel = smiteData.SlotLevel
+	d.DamageDice = smiteData.DamageDice
+	return nil
+}
+
+// onDamageChain adds the radiant smite dice to the paladin's melee weapon damage
+func (d *DivineSmiteCondition) onDamageChain(
+	ctx context.Context,
+	event *dnd5eEvents.DamageChainEvent,
+	c chain.Chain[*dnd5eEvents.DamageChainEvent],
+) (chain.Chain[*dnd5eEvents.DamageChainEvent], error) {
+	if event.AttackerID != d.CharacterID || event.TargetID != d.TargetID || !isMeleeWeaponAttack(event) {
+		return c, nil
+	}
+
+	roller := d.roller
+	if roller == nil {
+		roller = dice.NewRoller()
+	}
+
+	// Smite dice are doubled on a critical hit like any other attack damage dice
+	count := d.DamageDice
+	if event.IsCritical {
+		count *= 2
+	}
+	smiteDice, err := roller.RollN(ctx, count, 8)
+	if err != nil {
+		return c, rpgerr.Wrap(err, "failed to roll divine smite dice")
+	}
+
+	modifyDamage := func(_ context.Context, e *dnd5eEvents.DamageChainEvent) (*dnd5eEvents.DamageChainEvent, error) {
+		e.Components = append(e.Components, dnd5eEvents.DamageComponent{
+			Source:            dnd5eEvents.DamageSourceFeature,
+			SourceRef:         refs.Features.DivineSmite(),
+			OriginalDiceRolls: smiteDice,
+			FinalDiceRolls:    smiteDice,
+			DamageType:        damage.Radiant,
+			IsCritical:        e.IsCritical,
+		})
+		return e, nil
+	}
+
+	if err := c.Add(combat.StageFeatures, "divine_smite", modifyDamage); err != nil {
+		return c, rpgerr.Wrap(err, "failed to add divine smite modifier")
+	}
+
+	// The smite is spent on this hit
+	return c, d.end(ctx, "smite_delivered")
+}
+
+// onTurnEnd lapses an undelivered smite at the end of the paladin's turn
+func (d *DivineSmiteCondition) onTurnEnd(ctx context.Context, event dnd5eEvents.TurnEndEvent) error {
+	if event.CharacterID != d.CharacterID {
+		return nil
+	}
+	return d.end(ctx, "turn_ended")
+}
+
+// end publishes the removal event and unsubscribes from all events
+func (d *DivineSmiteCondition) end(ctx context.Context, reason string) error {
+	if d.bus == nil {
+		return nil
+	}
+
+	removals := dnd5eEvents.ConditionRemovedTopic.On(d.bus)
+	err := removals.Publish(ctx, dnd5eEvents.ConditionRemovedEvent{
+		CharacterID:  d.CharacterID,
+		ConditionRef: refs.Conditions.DivineSmite().String(),
+		Reason:       reason,
+	})
+	if err != nil {
+		return rpgerr.Wrapf(err, "error publishing divine smite removal for %s", d.CharacterID)
+	}
+
+	return d.Remove(ctx, d.bus)
+}
+
+// isMeleeWeaponAttack returns true when the damage comes from a melee weapon
+// (including unarmed strikes)
+func isMeleeWeaponAttack(event *dnd5eEvents.DamageChainEvent) bool {
+	if event.WeaponRef == nil {
+		return false
+	}
+	weapon, err := weapons.GetByID(event.WeaponRef.ID)
+	if err != nil {
+		return false
+	}
+	return weapon.IsMelee()
+}
@@ -0,0 +1,157 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+type DivineSmiteTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	ctx      context.Context
+	bus      events.EventBus
+	roller   *mock_dice.MockRoller
+	smite    *DivineSmiteCondition
+	removals []dnd5eEvents.ConditionRemovedEvent
+}
+
+func TestDivineSmiteSuite(t *testing.T) {
+	suite.Run(t, new(DivineSmiteTestSuite))
+}
+
+// SetupTest applies a 1st-level smite (2d8) against goblin-1
+func (s *DivineSmiteTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.roller = mock_dice.NewMockRoller(s.ctrl)
+	s.removals = nil
+
+	s.smite = NewDivineSmiteCondition(DivineSmiteInput{
+		CharacterID: "paladin-1",
+		TargetID:    "goblin-1",
+		SlotLevel:   1,
+		DamageDice:  2,
+		Roller:      s.roller,
+	})
+	s.Require().NoError(s.smite.Apply(s.ctx, s.bus))
+
+	_, err := dnd5eEvents.ConditionRemovedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, event dnd5eEvents.ConditionRemovedEvent) error {
+			s.removals = append(s.removals, event)
+			return nil
+		})
+	s.Require().NoError(err)
+}
+
+func (s *DivineSmiteTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// hit runs the damage chain for a weapon hit and returns the final components
+func (s *DivineSmiteTestSuite) hit(targetID string, weapon *core.Ref, critical bool) []dnd5eEvents.DamageComponent {
+	event := &dnd5eEvents.DamageChainEvent{
+		AttackerID: "paladin-1",
+		TargetID:   targetID,
+		Components: []dnd5eEvents.DamageComponent{{
+			Source:            dnd5eEvents.DamageSourceWeapon,
+			OriginalDiceRolls: []int{6},
+			FinalDiceRolls:    []int{6},
+			DamageType:        damage.Slashing,
+			IsCritical:        critical,
+		}},
+		DamageType: damage.Slashing,
+		IsCritical: critical,
+		WeaponRef:  weapon,
+	}
+
+	damageChain := events.NewStagedChain[*dnd5eEvents.DamageChainEvent](combat.ModifierStages)
+	modified, err := dnd5eEvents.DamageChain.On(s.bus).PublishWithChain(s.ctx, event, damageChain)
+	s.Require().NoError(err)
+
+	final, err := modified.Execute(s.ctx, event)
+	s.Require().NoError(err)
+	return final.Components
+}
+
+func (s *DivineSmiteTestSuite) TestAddsRadiantDiceOnce() {
+	s.roller.EXPECT().RollN(gomock.Any(), 2, 8).Return([]int{5, 7}, nil)
+
+	components := s.hit("goblin-1", refs.Weapons.Longsword(), false)
+	s.Require().Len(components, 2)
+	s.Equal(dnd5eEvents.DamageComponent{
+		Source:            dnd5eEvents.DamageSourceFeature,
+		SourceRef:         refs.Features.DivineSmite(),
+		OriginalDiceRolls: []int{5, 7},
+		FinalDiceRolls:    []int{5, 7},
+		DamageType:        damage.Radiant,
+	}, components[1])
+
+	s.False(s.smite.IsApplied())
+	s.Equal([]dnd5eEvents.ConditionRemovedEvent{{
+		CharacterID:  "paladin-1",
+		ConditionRef: refs.Conditions.DivineSmite().String(),
+		Reason:       "smite_delivered",
+	}}, s.removals)
+
+	s.Len(s.hit("goblin-1", refs.Weapons.Longsword(), false), 1, "the smite is spent")
+}
+
+func (s *DivineSmiteTestSuite) TestCriticalHitDoublesDice() {
+	s.roller.EXPECT().RollN(gomock.Any(), 4, 8).Return([]int{1, 2, 3, 4}, nil)
+
+	components := s.hit("goblin-1", refs.Weapons.UnarmedStrike(), true)
+	s.Require().Len(components, 2)
+	s.Equal(10, components[1].Total())
+	s.True(components[1].IsCritical)
+}
+
+func (s *DivineSmiteTestSuite) TestIgnoresOtherAttacks() {
+	s.Len(s.hit("goblin-2", refs.Weapons.Longsword(), false), 1, "other target")
+	s.Len(s.hit("goblin-1", refs.Weapons.Longbow(), false), 1, "ranged weapon")
+	s.Len(s.hit("goblin-1", nil, false), 1, "not a weapon attack")
+	s.True(s.smite.IsApplied())
+}
+
+func (s *DivineSmiteTestSuite) TestLapsesAtTurnEnd() {
+	err := dnd5eEvents.TurnEndTopic.On(s.bus).Publish(s.ctx, dnd5eEvents.TurnEndEvent{CharacterID: "goblin-1"})
+	s.Require().NoError(err)
+	s.True(s.smite.IsApplied())
+
+	err = dnd5eEvents.TurnEndTopic.On(s.bus).Publish(s.ctx, dnd5eEvents.TurnEndEvent{CharacterID: "paladin-1"})
+	s.Require().NoError(err)
+	s.False(s.smite.IsApplied())
+	s.Require().Len(s.removals, 1)
+	s.Equal("turn_ended", s.removals[0].Reason)
+}
+
+func (s *DivineSmiteTestSuite) TestRoundTrip() {
+	data, err := s.smite.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := LoadJSON(data)
+	s.Require().NoError(err)
+
+	smite, ok := loaded.(*DivineSmiteCondition)
+	s.Require().True(ok)
+	s.Equal(&DivineSmiteCondition{
+		CharacterID: "paladin-1",
+		TargetID:    "goblin-1",
+		SlotLevel:   1,
+		DamageDice:  2,
+	}, smite)
+}
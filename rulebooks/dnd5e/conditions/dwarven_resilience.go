@@ -0,0 +1,215 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// DwarvenResilienceData is the JSON structure for persisting dwarven resilience condition state
+type DwarvenResilienceData struct {
+	Ref         *core.Ref `json:"ref"`
+	CharacterID string    `json:"character_id"`
+}
+
+// DwarvenResilienceCondition represents the dwarf's Dwarven Resilience trait.
+// The dwarf has advantage on saving throws against poison and resistance to poison damage.
+type DwarvenResilienceCondition struct {
+	CharacterID     string
+	bus             events.EventBus
+	subscriptionIDs []string
+}
+
+// Ensure DwarvenResilienceCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*DwarvenResilienceCondition)(nil)
+
+// DwarvenResilienceInput provides configuration for creating a dwarven resilience condition
+type DwarvenResilienceInput struct {
+	CharacterID string // ID of the dwarf
+}
+
+// NewDwarvenResilienceCondition creates a dwarven resilience condition from input
+func NewDwarvenResilienceCondition(input DwarvenResilienceInput) *DwarvenResilienceCondition {
+	return &DwarvenResilienceCondition{
+		CharacterID: input.CharacterID,
+	}
+}
+
+// IsApplied returns true if this condition is currently applied
+func (d *DwarvenResilienceCondition) IsApplied() bool {
+	return d.bus != nil
+}
+
+// Apply subscribes to SavingThrowChain for advantage against poison and to
+// DamageChain for poison resistance
+func (d *DwarvenResilienceCondition) Apply(ctx context.Context, bus events.EventBus) error {
+	if d.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "dwarven resilience condition already applied")
+	}
+	d.bus = bus
+
+	saveChain := dnd5eEvents.SavingThrowChain.On(bus)
+	subID1, err := saveChain.SubscribeWithChain(ctx, d.onSavingThrowChain)
+	if err != nil {
+		d.bus = nil
+		return rpgerr.Wrap(err, "failed to subscribe to saving throw chain")
+	}
+	d.subscriptionIDs = append(d.subscriptionIDs, subID1)
+
+	damageChain := dnd5eEvents.DamageChain.On(bus)
+	subID2, err := damageChain.SubscribeWithChain(ctx, d.onDamageChain)
+	if err != nil {
+		_ = d.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to damage chain")
+	}
+	d.subscriptionIDs = append(d.subscriptionIDs, subID2)
+
+	return nil
+}
+
+// Remove unsubscribes this condition from all events
+func (d *DwarvenResilienceCondition) Remove(ctx context.Context, bus events.EventBus) error {
+	if d.bus == nil {
+		return nil
+	}
+
+	total := len(d.subscriptionIDs)
+	var errs []error
+	for _, subID := range d.subscriptionIDs {
+		if err := bus.Unsubscribe(ctx, subID); err != nil {
+			errs = append(errs, fmt.Errorf("unsubscribe %s: %w", subID, err))
+		}
+	}
+
+	d.subscriptionIDs = nil
+	d.bus = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unsubscribe %d/%d subscriptions: %w", len(errs), total, errors.Join(errs...))
+	}
+	return nil
+}
+
+// ToJSON converts the condition to JSON for persistence
+func (d *DwarvenResilienceCondition) ToJSON() (json.RawMessage, error) {
+	data := DwarvenResilienceData{
+		Ref:         refs.Conditions.DwarvenResilience(),
+		CharacterID: d.CharacterID,
+	}
+
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to marshal dwarven resilience data")
+	}
+
+	return bytes, nil
+}
+
+// loadJSON loads the condition from JSON
+func (d *DwarvenResilienceCondition) loadJSON(data json.RawMessage) error {
+	var resilienceData DwarvenResilienceData
+	if err := json.Unmarshal(data, &resilienceData); err != nil {
+		return rpgerr.Wrap(err, "failed to unmarshal dwarven resilience data")
+	}
+
+	d.CharacterID = resilienceData.CharacterID
+	return nil
+}
+
+// onSavingThrowChain grants advantage on saves against poison
+func (d *DwarvenResilienceCondition) onSavingThrowChain(
+	_ context.Context,
+	event *dnd5eEvents.SavingThrowChainEvent,
+	c chain.Chain[*dnd5eEvents.SavingThrowChainEvent],
+) (chain.Chain[*dnd5eEvents.SavingThrowChainEvent], error) {
+	if event.SaverID != d.CharacterID || !isPoisonSave(event.Cause) {
+		return c, nil
+	}
+
+	modifySave := func(_ context.Context, e *dnd5eEvents.SavingThrowChainEvent) (*dnd5eEvents.SavingThrowChainEvent, error) {
+		e.AdvantageSources = append(e.AdvantageSources, dnd5eEvents.SaveModifierSource{
+			Name:       "Dwarven Resilience",
+			SourceType: "condition",
+			SourceRef:  refs.Conditions.DwarvenResilience(),
+			EntityID:   d.CharacterID,
+		})
+		return e, nil
+	}
+
+	if err := c.Add(combat.StageConditions, "dwarven_resilience_advantage", modifySave); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to add dwarven resilience advantage for character %s", d.CharacterID)
+	}
+
+	return c, nil
+}
+
+// onDamageChain halves poison damage dealt to the dwarf
+func (d *DwarvenResilienceCondition) onDamageChain(
+	_ context.Context,
+	event *dnd5eEvents.DamageChainEvent,
+	c chain.Chain[*dnd5eEvents.DamageChainEvent],
+) (chain.Chain[*dnd5eEvents.DamageChainEvent], error) {
+	if event.TargetID != d.CharacterID {
+		return c, nil
+	}
+
+	applyResistance := func(_ context.Context, e *dnd5eEvents.DamageChainEvent) (*dnd5eEvents.DamageChainEvent, error) {
+		if !hasDamageType(e, damage.Poison) {
+			return e, nil
+		}
+		e.Components = append(e.Components, dnd5eEvents.DamageComponent{
+			Source:     dnd5eEvents.DamageSourceCondition,
+			SourceRef:  refs.Conditions.DwarvenResilience(),
+			DamageType: damage.Poison,
+			Multiplier: 0.5, // Resistance halves damage
+		})
+		return e, nil
+	}
+
+	if err := c.Add(combat.StageFinal, "dwarven_resilience_resistance", applyResistance); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to add dwarven resilience resistance for character %s", d.CharacterID)
+	}
+
+	return c, nil
+}
+
+// isPoisonSave returns true if the save is against poison damage or the poisoned condition
+func isPoisonSave(cause dnd5eEvents.SaveCause) bool {
+	return cause.DamageType == damage.Poison || causeImposes(cause, refs.Conditions.Poisoned())
+}
+
+// causeImposes returns true if a failed save would impose the given condition
+func causeImposes(cause dnd5eEvents.SaveCause, condition *core.Ref) bool {
+	for _, ref := range cause.Conditions {
+		if ref.Equals(condition) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasDamageType returns true if the damage event deals damage of the given type
+func hasDamageType(event *dnd5eEvents.DamageChainEvent, damageType damage.Type) bool {
+	if event.DamageType == damageType {
+		return true
+	}
+	for _, component := range event.Components {
+		if component.DamageType == damageType && component.Multiplier == 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/saves"
+)
+
+type DwarvenResilienceTestSuite struct {
+	suite.Suite
+	ctrl   *gomock.Controller
+	ctx    context.Context
+	bus    events.EventBus
+	roller *mock_dice.MockRoller
+}
+
+func TestDwarvenResilienceSuite(t *testing.T) {
+	suite.Run(t, new(DwarvenResilienceTestSuite))
+}
+
+func (s *DwarvenResilienceTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.roller = mock_dice.NewMockRoller(s.ctrl)
+}
+
+func (s *DwarvenResilienceTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *DwarvenResilienceTestSuite) save(saverID string, cause dnd5eEvents.SaveCause) *saves.SavingThrowResult {
+	result, err := saves.MakeSavingThrow(s.ctx, &saves.SavingThrowInput{
+		Roller:   s.roller,
+		EventBus: s.bus,
+		SaverID:  saverID,
+		Cause:    cause,
+		Ability:  abilities.CON,
+		DC:       12,
+	})
+	s.Require().NoError(err)
+	return result
+}
+
+func (s *DwarvenResilienceTestSuite) TestAdvantageOnSavesAgainstPoison() {
+	resilience := NewDwarvenResilienceCondition(DwarvenResilienceInput{CharacterID: "dwarf-1"})
+	s.Require().NoError(resilience.Apply(s.ctx, s.bus))
+
+	s.Run("poison damage", func() {
+		s.roller.EXPECT().RollN(gomock.Any(), 2, 20).Return([]int{4, 15}, nil)
+		result := s.save("dwarf-1", dnd5eEvents.SaveCause{DamageType: damage.Poison})
+		s.Equal(15, result.Roll)
+		s.Require().Len(result.AdvantageSources, 1)
+		s.Equal(refs.Conditions.DwarvenResilience(), result.AdvantageSources[0].SourceRef)
+	})
+
+	s.Run("poisoned condition", func() {
+		s.roller.EXPECT().RollN(gomock.Any(), 2, 20).Return([]int{4, 15}, nil)
+		result := s.save("dwarf-1", dnd5eEvents.SaveCause{Conditions: []*core.Ref{refs.Conditions.Poisoned()}})
+		s.Len(result.AdvantageSources, 1)
+	})
+
+	s.Run("not poison", func() {
+		s.roller.EXPECT().Roll(gomock.Any(), 20).Return(4, nil)
+		result := s.save("dwarf-1", dnd5eEvents.SaveCause{DamageType: damage.Fire})
+		s.Empty(result.AdvantageSources)
+	})
+
+	s.Run("another character", func() {
+		s.roller.EXPECT().Roll(gomock.Any(), 20).Return(4, nil)
+		result := s.save("elf-1", dnd5eEvents.SaveCause{DamageType: damage.Poison})
+		s.Empty(result.AdvantageSources)
+	})
+}
+
+func (s *DwarvenResilienceTestSuite) TestResistsPoisonDamage() {
+	resilience := NewDwarvenResilienceCondition(DwarvenResilienceInput{CharacterID: "dwarf-1"})
+	s.Require().NoError(resilience.Apply(s.ctx, s.bus))
+
+	resolve := func(damageType damage.Type) int {
+		output, err := combat.ResolveDamage(s.ctx, &combat.ResolveDamageInput{
+			AttackerID: "goblin-1",
+			TargetID:   "dwarf-1",
+			Components: []dnd5eEvents.DamageComponent{
+				{Source: dnd5eEvents.DamageSourceWeapon, FlatBonus: 10, DamageType: damageType},
+			},
+			EventBus: s.bus,
+		})
+		s.Require().NoError(err)
+		return output.TotalDamage
+	}
+
+	s.Equal(5, resolve(damage.Poison))
+	s.Equal(10, resolve(damage.Slashing))
+
+	s.Require().NoError(resilience.Remove(s.ctx, s.bus))
+	s.Equal(10, resolve(damage.Poison), "removed")
+}
+
+func (s *DwarvenResilienceTestSuite) TestFactoryAndRoundTrip() {
+	output, err := CreateFromRef(&CreateFromRefInput{
+		Ref:         refs.Conditions.DwarvenResilience().String(),
+		CharacterID: "dwarf-1",
+	})
+	s.Require().NoError(err)
+
+	data, err := output.Condition.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := LoadJSON(data)
+	s.Require().NoError(err)
+
+	resilience, ok := loaded.(*DwarvenResilienceCondition)
+	s.Require().True(ok)
+	s.Equal("dwarf-1", resilience.CharacterID)
+}
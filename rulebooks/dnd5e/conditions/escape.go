@@ -0,0 +1,306 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"slices"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/dice"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/checks"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/saves"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
+)
+
+// EscapeConfig describes how a creature escapes a condition such as
+// grappled or restrained. It is registered with the condition and persisted
+// alongside it.
+type EscapeConfig struct {
+	// Method is how the escape is resolved: a contest, a check, or a save
+	Method dnd5eEvents.EscapeMethod `json:"method"`
+
+	// Skills the escaping creature may choose from (contest and check).
+	// Empty means Athletics or Acrobatics.
+	Skills []skills.Skill `json:"skills,omitempty"`
+
+	// Ability is the ability for a save, or for a check made without a skill
+	Ability abilities.Ability `json:"ability,omitempty"`
+
+	// DC is the Difficulty Class to meet (check and save)
+	DC int `json:"dc,omitempty"`
+
+	// OpposingSkill is the source's skill in a contest. Empty means Athletics.
+	OpposingSkill skills.Skill `json:"opposing_skill,omitempty"`
+
+	// RequiresAction is true if an escape attempt costs the creature its action
+	RequiresAction bool `json:"requires_action,omitempty"`
+}
+
+// GrappleEscape returns the standard escape from a grapple: the creature uses
+// its action for an Athletics or Acrobatics check contested by the grappler's
+// Athletics.
+func GrappleEscape() EscapeConfig {
+	return EscapeConfig{
+		Method:         dnd5eEvents.EscapeMethodContest,
+		RequiresAction: true,
+	}
+}
+
+// Validate validates the config.
+func (c *EscapeConfig) Validate() error {
+	if c == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "EscapeConfig is nil")
+	}
+
+	switch c.Method {
+	case dnd5eEvents.EscapeMethodContest:
+		return nil
+	case dnd5eEvents.EscapeMethodCheck:
+		if c.DC <= 0 {
+			return rpgerr.New(rpgerr.CodeInvalidArgument, "DC is required for an escape check")
+		}
+		if len(c.Skills) == 0 && c.Ability == "" {
+			return rpgerr.New(rpgerr.CodeInvalidArgument, "Skills or Ability is required for an escape check")
+		}
+		return nil
+	case dnd5eEvents.EscapeMethodSave:
+		if c.DC <= 0 {
+			return rpgerr.New(rpgerr.CodeInvalidArgument, "DC is required for an escape save")
+		}
+		if c.Ability == "" {
+			return rpgerr.New(rpgerr.CodeInvalidArgument, "Ability is required for an escape save")
+		}
+		return nil
+	default:
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument, "unknown escape method: %s", c.Method)
+	}
+}
+
+// allowedSkills returns the skills the escaping creature may use.
+func (c *EscapeConfig) allowedSkills() []skills.Skill {
+	if len(c.Skills) == 0 && c.Method == dnd5eEvents.EscapeMethodContest {
+		return []skills.Skill{skills.Athletics, skills.Acrobatics}
+	}
+	return c.Skills
+}
+
+// opposingSkill returns the source's skill in a contest.
+func (c *EscapeConfig) opposingSkill() skills.Skill {
+	if c.OpposingSkill == "" {
+		return skills.Athletics
+	}
+	return c.OpposingSkill
+}
+
+// EscapeAttemptInput provides the parameters for an escape attempt.
+type EscapeAttemptInput struct {
+	// Round is the current round. A creature gets one escape attempt per turn.
+	Round int
+
+	// Skill is the skill the creature uses (contest and check).
+	// Must be one of the config's skills; may be empty for an ability-only check.
+	Skill skills.Skill
+
+	// Modifier is the creature's bonus for the chosen skill, ability check, or save
+	Modifier int
+
+	// SourceModifier is the source's bonus for its opposing check (contest only)
+	SourceModifier int
+
+	// Roller is the dice roller to use. If nil, defaults to dice.NewRoller().
+	Roller dice.Roller
+
+	// EventBus is used for the check chains and escape events
+	EventBus events.EventBus
+
+	// Economy is the creature's action economy. Required when the escape requires an action.
+	Economy *combat.ActionEconomy
+}
+
+// Validate validates the input.
+func (e *EscapeAttemptInput) Validate() error {
+	if e == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "EscapeAttemptInput is nil")
+	}
+	if e.Round < 1 {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "Round must be at least 1")
+	}
+	if e.EventBus == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "EventBus is required")
+	}
+	return nil
+}
+
+// EscapeResult contains the outcome of an escape attempt.
+type EscapeResult struct {
+	// Success is true if the creature escaped and the condition was removed
+	Success bool
+
+	// Total is the creature's check or save total
+	Total int
+
+	// OpposedTotal is the source's check total (contest only)
+	OpposedTotal int
+
+	// DC is the Difficulty Class that was tested against (check and save only)
+	DC int
+}
+
+// escapeAttempt carries what a condition knows about an escape attempt.
+type escapeAttempt struct {
+	characterID      string
+	sourceID         string
+	conditionRef     *core.Ref
+	config           EscapeConfig
+	lastAttemptRound int
+}
+
+// resolve validates the attempt, spends the action if required, and rolls the
+// configured contest, check, or save through the matching chain. It publishes
+// an EscapeAttemptedEvent but leaves removing the condition to the caller.
+func (a *escapeAttempt) resolve(ctx context.Context, input *EscapeAttemptInput) (*EscapeResult, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+	if err := a.config.Validate(); err != nil {
+		return nil, err
+	}
+	if a.lastAttemptRound >= input.Round {
+		return nil, rpgerr.Newf(rpgerr.CodeResourceExhausted,
+			"%s already tried to escape %s in round %d", a.characterID, a.conditionRef.ID, a.lastAttemptRound)
+	}
+
+	allowed := a.config.allowedSkills()
+	if a.config.Method != dnd5eEvents.EscapeMethodSave {
+		if input.Skill == "" && a.config.Ability == "" {
+			return nil, rpgerr.Newf(rpgerr.CodeInvalidArgument, "a skill is required to escape %s", a.conditionRef.ID)
+		}
+		if input.Skill != "" && !slices.Contains(allowed, input.Skill) {
+			return nil, rpgerr.Newf(rpgerr.CodeNotAllowed,
+				"%s can't be used to escape %s", skills.Display(input.Skill), a.conditionRef.ID)
+		}
+	}
+
+	if a.config.RequiresAction {
+		if input.Economy == nil {
+			return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "Economy is required when escaping costs an action")
+		}
+		if err := input.Economy.UseAction(); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := a.roll(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	a.lastAttemptRound = input.Round
+
+	err = dnd5eEvents.EscapeAttemptedTopic.On(input.EventBus).Publish(ctx, dnd5eEvents.EscapeAttemptedEvent{
+		CharacterID:  a.characterID,
+		SourceID:     a.sourceID,
+		ConditionRef: a.conditionRef.String(),
+		Method:       a.config.Method,
+		Total:        result.Total,
+		OpposedTotal: result.OpposedTotal,
+		DC:           result.DC,
+		Success:      result.Success,
+		Round:        input.Round,
+	})
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to publish escape attempted event")
+	}
+
+	return result, nil
+}
+
+// roll makes the contest, check, or save for the attempt.
+func (a *escapeAttempt) roll(ctx context.Context, input *EscapeAttemptInput) (*EscapeResult, error) {
+	cause := dnd5eEvents.CheckCause{EffectRef: a.conditionRef, InstigatorID: a.sourceID}
+
+	switch a.config.Method {
+	case dnd5eEvents.EscapeMethodContest:
+		contest, err := checks.MakeContest(ctx, &checks.ContestInput{
+			Initiator: &checks.AbilityCheckInput{
+				Roller:    input.Roller,
+				EventBus:  input.EventBus,
+				CheckerID: a.characterID,
+				Cause:     cause,
+				Ability:   a.config.Ability,
+				Skill:     input.Skill,
+				Modifier:  input.Modifier,
+			},
+			Defender: &checks.AbilityCheckInput{
+				Roller:    input.Roller,
+				EventBus:  input.EventBus,
+				CheckerID: a.sourceID,
+				Cause:     cause,
+				Skill:     a.config.opposingSkill(),
+				Modifier:  input.SourceModifier,
+			},
+		})
+		if err != nil {
+			return nil, rpgerr.Wrapf(err, "failed to resolve escape contest for %s", a.characterID)
+		}
+		return &EscapeResult{
+			Success:      contest.InitiatorWins,
+			Total:        contest.Initiator.Total,
+			OpposedTotal: contest.Defender.Total,
+		}, nil
+
+	case dnd5eEvents.EscapeMethodCheck:
+		check, err := checks.MakeAbilityCheck(ctx, &checks.AbilityCheckInput{
+			Roller:    input.Roller,
+			EventBus:  input.EventBus,
+			CheckerID: a.characterID,
+			Cause:     cause,
+			Ability:   a.config.Ability,
+			Skill:     input.Skill,
+			DC:        a.config.DC,
+			Modifier:  input.Modifier,
+		})
+		if err != nil {
+			return nil, rpgerr.Wrapf(err, "failed to resolve escape check for %s", a.characterID)
+		}
+		return &EscapeResult{Success: check.Success, Total: check.Total, DC: check.DC}, nil
+
+	default:
+		save, err := saves.MakeSavingThrow(ctx, &saves.SavingThrowInput{
+			Roller:   input.Roller,
+			EventBus: input.EventBus,
+			SaverID:  a.characterID,
+			Cause: dnd5eEvents.SaveCause{
+				Trigger:      dnd5eEvents.SaveTriggerCondition,
+				EffectRef:    a.conditionRef,
+				InstigatorID: a.sourceID,
+			},
+			Ability:  a.config.Ability,
+			DC:       a.config.DC,
+			Modifier: input.Modifier,
+		})
+		if err != nil {
+			return nil, rpgerr.Wrapf(err, "failed to resolve escape save for %s", a.characterID)
+		}
+		return &EscapeResult{Success: save.Success, Total: save.Total, DC: save.DC}, nil
+	}
+}
+
+// publishEscaped publishes the ConditionRemovedEvent for a successful escape.
+func publishEscaped(ctx context.Context, bus events.EventBus, characterID string, ref *core.Ref) error {
+	err := dnd5eEvents.ConditionRemovedTopic.On(bus).Publish(ctx, dnd5eEvents.ConditionRemovedEvent{
+		CharacterID:  characterID,
+		ConditionRef: ref.String(),
+		Reason:       "escaped",
+	})
+	if err != nil {
+		return rpgerr.Wrapf(err, "failed to publish %s removal for character %s", ref.ID, characterID)
+	}
+	return nil
+}
@@ -0,0 +1,369 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	coreResources "github.com/KirkDiggler/rpg-toolkit/core/resources"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// ExhaustionMaxLevel is the exhaustion level at which a creature dies
+const ExhaustionMaxLevel = 6
+
+// Reasons published on ExhaustionChangedEvent and ConditionRemovedEvent
+const (
+	// ExhaustionReasonLongRest is a level lost to a long rest
+	ExhaustionReasonLongRest = "long_rest"
+	// ExhaustionReasonRecovered ends the condition once the last level is gone
+	ExhaustionReasonRecovered = "recovered"
+)
+
+// ExhaustionData is the JSON structure for persisting exhaustion condition state
+type ExhaustionData struct {
+	Ref         *core.Ref `json:"ref"`
+	CharacterID string    `json:"character_id"`
+	Level       int       `json:"level"`
+}
+
+// ExhaustionCondition tracks a creature's exhaustion level. Its effects are
+// cumulative:
+//
+//	1: disadvantage on ability checks
+//	2: speed halved
+//	3: disadvantage on attack rolls and saving throws
+//	4: hit point maximum halved
+//	5: speed reduced to 0
+//	6: death
+//
+// Checks, attacks and saves are modified through their chains. Speed and hit
+// point maximum aren't chained values, so the creature applies them through
+// Speed and MaxHitPoints. Level changes publish an ExhaustionChangedEvent, and
+// each long rest removes one level.
+type ExhaustionCondition struct {
+	CharacterID     string
+	Level           int
+	subscriptionIDs []string
+	bus             events.EventBus
+}
+
+// Ensure ExhaustionCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*ExhaustionCondition)(nil)
+
+// ExhaustionInput provides configuration for creating an exhaustion condition
+type ExhaustionInput struct {
+	CharacterID string // ID of the exhausted creature
+	Level       int    // Starting exhaustion level (1-6, defaults to 1)
+}
+
+// NewExhaustionCondition creates an exhaustion condition from input
+func NewExhaustionCondition(input ExhaustionInput) *ExhaustionCondition {
+	return &ExhaustionCondition{
+		CharacterID: input.CharacterID,
+		Level:       clampExhaustion(input.Level),
+	}
+}
+
+// clampExhaustion keeps a starting level within 1-6
+func clampExhaustion(level int) int {
+	switch {
+	case level < 1:
+		return 1
+	case level > ExhaustionMaxLevel:
+		return ExhaustionMaxLevel
+	}
+	return level
+}
+
+// IsApplied returns true if this condition is currently applied
+func (e *ExhaustionCondition) IsApplied() bool {
+	return e.bus != nil
+}
+
+// Apply subscribes this condition to the check, attack and save chains and to rest events
+func (e *ExhaustionCondition) Apply(ctx context.Context, bus events.EventBus) error {
+	if e.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "exhaustion condition already applied")
+	}
+	e.bus = bus
+
+	checkSubID, err := dnd5eEvents.AbilityCheckChain.On(bus).SubscribeWithChain(ctx, e.onAbilityCheckChain)
+	if err != nil {
+		e.bus = nil
+		return rpgerr.Wrap(err, "failed to subscribe to ability check chain")
+	}
+	e.subscriptionIDs = append(e.subscriptionIDs, checkSubID)
+
+	attackSubID, err := dnd5eEvents.AttackChain.On(bus).SubscribeWithChain(ctx, e.onAttackChain)
+	if err != nil {
+		_ = e.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to attack chain")
+	}
+	e.subscriptionIDs = append(e.subscriptionIDs, attackSubID)
+
+	saveSubID, err := dnd5eEvents.SavingThrowChain.On(bus).SubscribeWithChain(ctx, e.onSavingThrowChain)
+	if err != nil {
+		_ = e.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to saving throw chain")
+	}
+	e.subscriptionIDs = append(e.subscriptionIDs, saveSubID)
+
+	restSubID, err := dnd5eEvents.RestTopic.On(bus).Subscribe(ctx, e.onRest)
+	if err != nil {
+		_ = e.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to rest events")
+	}
+	e.subscriptionIDs = append(e.subscriptionIDs, restSubID)
+
+	return nil
+}
+
+// Remove unsubscribes this condition from events
+func (e *ExhaustionCondition) Remove(ctx context.Context, bus events.EventBus) error {
+	if e.bus == nil {
+		return nil // Not applied, nothing to remove
+	}
+
+	total := len(e.subscriptionIDs)
+	var errs []error
+	for _, subID := range e.subscriptionIDs {
+		if err := bus.Unsubscribe(ctx, subID); err != nil {
+			errs = append(errs, fmt.Errorf("unsubscribe %s: %w", subID, err))
+		}
+	}
+
+	e.subscriptionIDs = nil
+	e.bus = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unsubscribe %d/%d subscriptions: %w", len(errs), total, errors.Join(errs...))
+	}
+	return nil
+}
+
+// Speed returns the creature's speed after exhaustion: halved from level 2
+// and 0 from level 5.
+func (e *ExhaustionCondition) Speed(base int) int {
+	switch {
+	case e.Level >= 5:
+		return 0
+	case e.Level >= 2:
+		return base / 2
+	}
+	return base
+}
+
+// MaxHitPoints returns the creature's hit point maximum after exhaustion,
+// halved from level 4.
+func (e *ExhaustionCondition) MaxHitPoints(base int) int {
+	if e.Level >= 4 {
+		return base / 2
+	}
+	return base
+}
+
+// IsDead returns true once the creature has reached the final exhaustion level
+func (e *ExhaustionCondition) IsDead() bool {
+	return e.Level >= ExhaustionMaxLevel
+}
+
+// AddLevels increases the exhaustion level, capped at 6.
+//
+// Publishes an ExhaustionChangedEvent, and a CharacterDiedEvent when the
+// creature reaches level 6. Returns CodeInvalidState if the condition isn't
+// applied and CodeInvalidArgument for a non-positive number of levels.
+func (e *ExhaustionCondition) AddLevels(ctx context.Context, levels int, reason string) error {
+	if levels <= 0 {
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument, "exhaustion levels to add must be positive, got %d", levels)
+	}
+	if !e.IsApplied() {
+		return rpgerr.New(rpgerr.CodeInvalidState, "exhaustion condition is not applied")
+	}
+
+	wasDead := e.IsDead()
+	if err := e.setLevel(ctx, min(e.Level+levels, ExhaustionMaxLevel), reason); err != nil {
+		return err
+	}
+	if wasDead || !e.IsDead() {
+		return nil
+	}
+
+	err := dnd5eEvents.CharacterDiedTopic.On(e.bus).Publish(ctx, dnd5eEvents.CharacterDiedEvent{
+		CharacterID: e.CharacterID,
+	})
+	if err != nil {
+		return rpgerr.Wrapf(err, "error publishing exhaustion death for %s", e.CharacterID)
+	}
+	return nil
+}
+
+// ReduceLevels lowers the exhaustion level. Reaching level 0 publishes a
+// ConditionRemovedEvent with reason "recovered" and removes the condition.
+//
+// Returns CodeInvalidState if the condition isn't applied and
+// CodeInvalidArgument for a non-positive number of levels.
+func (e *ExhaustionCondition) ReduceLevels(ctx context.Context, levels int, reason string) error {
+	if levels <= 0 {
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument, "exhaustion levels to reduce must be positive, got %d", levels)
+	}
+	if !e.IsApplied() {
+		return rpgerr.New(rpgerr.CodeInvalidState, "exhaustion condition is not applied")
+	}
+
+	if err := e.setLevel(ctx, max(e.Level-levels, 0), reason); err != nil {
+		return err
+	}
+	if e.Level > 0 {
+		return nil
+	}
+
+	bus := e.bus
+	err := dnd5eEvents.ConditionRemovedTopic.On(bus).Publish(ctx, dnd5eEvents.ConditionRemovedEvent{
+		CharacterID:  e.CharacterID,
+		ConditionRef: refs.Conditions.Exhaustion().String(),
+		Reason:       ExhaustionReasonRecovered,
+	})
+	if err != nil {
+		return rpgerr.Wrapf(err, "error publishing exhaustion removal for %s", e.CharacterID)
+	}
+
+	return e.Remove(ctx, bus)
+}
+
+// setLevel records the new level and publishes the change
+func (e *ExhaustionCondition) setLevel(ctx context.Context, level int, reason string) error {
+	previous := e.Level
+	e.Level = level
+
+	err := dnd5eEvents.ExhaustionChangedTopic.On(e.bus).Publish(ctx, dnd5eEvents.ExhaustionChangedEvent{
+		CharacterID:   e.CharacterID,
+		PreviousLevel: previous,
+		Level:         level,
+		Reason:        reason,
+	})
+	if err != nil {
+		return rpgerr.Wrapf(err, "error publishing exhaustion change for %s", e.CharacterID)
+	}
+	return nil
+}
+
+// ToJSON converts the condition to JSON for persistence
+func (e *ExhaustionCondition) ToJSON() (json.RawMessage, error) {
+	data := ExhaustionData{
+		Ref:         refs.Conditions.Exhaustion(),
+		CharacterID: e.CharacterID,
+		Level:       e.Level,
+	}
+	return json.Marshal(data)
+}
+
+// loadJSON loads exhaustion condition state from JSON
+func (e *ExhaustionCondition) loadJSON(data json.RawMessage) error {
+	var exhaustionData ExhaustionData
+	if err := json.Unmarshal(data, &exhaustionData); err != nil {
+		return rpgerr.Wrap(err, "failed to unmarshal exhaustion data")
+	}
+
+	e.CharacterID = exhaustionData.CharacterID
+	e.Level = clampExhaustion(exhaustionData.Level)
+	return nil
+}
+
+// onRest removes one level of exhaustion after a long rest
+func (e *ExhaustionCondition) onRest(ctx context.Context, event dnd5eEvents.RestEvent) error {
+	if event.CharacterID != e.CharacterID || event.RestType != coreResources.ResetLongRest {
+		return nil
+	}
+	return e.ReduceLevels(ctx, 1, ExhaustionReasonLongRest)
+}
+
+// onAbilityCheckChain imposes disadvantage on ability checks from level 1
+func (e *ExhaustionCondition) onAbilityCheckChain(
+	_ context.Context,
+	event *dnd5eEvents.AbilityCheckChainEvent,
+	c chain.Chain[*dnd5eEvents.AbilityCheckChainEvent],
+) (chain.Chain[*dnd5eEvents.AbilityCheckChainEvent], error) {
+	if event.CheckerID != e.CharacterID {
+		return c, nil
+	}
+
+	modifyCheck := func(_ context.Context, ev *dnd5eEvents.AbilityCheckChainEvent) (*dnd5eEvents.AbilityCheckChainEvent, error) {
+		ev.DisadvantageSources = append(ev.DisadvantageSources, dnd5eEvents.CheckModifierSource{
+			Name:       "Exhaustion",
+			SourceType: "condition",
+			SourceRef:  refs.Conditions.Exhaustion(),
+			EntityID:   e.CharacterID,
+		})
+		return ev, nil
+	}
+
+	if err := c.Add(combat.StageConditions, "exhaustion_check_disadvantage", modifyCheck); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to add exhaustion check disadvantage for character %s", e.CharacterID)
+	}
+
+	return c, nil
+}
+
+// onAttackChain imposes disadvantage on the creature's attacks from level 3
+func (e *ExhaustionCondition) onAttackChain(
+	_ context.Context,
+	event dnd5eEvents.AttackChainEvent,
+	c chain.Chain[dnd5eEvents.AttackChainEvent],
+) (chain.Chain[dnd5eEvents.AttackChainEvent], error) {
+	if event.AttackerID != e.CharacterID || e.Level < 3 {
+		return c, nil
+	}
+
+	modifyAttack := func(_ context.Context, ev dnd5eEvents.AttackChainEvent) (dnd5eEvents.AttackChainEvent, error) {
+		ev.DisadvantageSources = append(ev.DisadvantageSources, dnd5eEvents.AttackModifierSource{
+			SourceRef: refs.Conditions.Exhaustion(),
+			SourceID:  e.CharacterID,
+			Reason:    "Exhaustion",
+		})
+		return ev, nil
+	}
+
+	if err := c.Add(combat.StageConditions, "exhaustion_attack_disadvantage", modifyAttack); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to add exhaustion attack disadvantage for character %s", e.CharacterID)
+	}
+
+	return c, nil
+}
+
+// onSavingThrowChain imposes disadvantage on the creature's saves from level 3
+func (e *ExhaustionCondition) onSavingThrowChain(
+	_ context.Context,
+	event *dnd5eEvents.SavingThrowChainEvent,
+	c chain.Chain[*dnd5eEvents.SavingThrowChainEvent],
+) (chain.Chain[*dnd5eEvents.SavingThrowChainEvent], error) {
+	if event.SaverID != e.CharacterID || e.Level < 3 {
+		return c, nil
+	}
+
+	modifySave := func(_ context.Context, ev *dnd5eEvents.SavingThrowChainEvent) (*dnd5eEvents.SavingThrowChainEvent, error) {
+		ev.DisadvantageSources = append(ev.DisadvantageSources, dnd5eEvents.SaveModifierSource{
+			Name:       "Exhaustion",
+			SourceType: "condition",
+			SourceRef:  refs.Conditions.Exhaustion(),
+			EntityID:   e.CharacterID,
+		})
+		return ev, nil
+	}
+
+	if err := c.Add(combat.StageConditions, "exhaustion_save_disadvantage", modifySave); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to add exhaustion save disadvantage for character %s", e.CharacterID)
+	}
+
+	return c, nil
+}
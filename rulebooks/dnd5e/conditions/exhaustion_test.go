@@ -0,0 +1,210 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	coreResources "github.com/KirkDiggler/rpg-toolkit/core/resources"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+type ExhaustionConditionTestSuite struct {
+	suite.Suite
+	ctx       context.Context
+	bus       events.EventBus
+	condition *ExhaustionCondition
+	changes   []dnd5eEvents.ExhaustionChangedEvent
+	removed   []dnd5eEvents.ConditionRemovedEvent
+	died      []dnd5eEvents.CharacterDiedEvent
+}
+
+func TestExhaustionConditionSuite(t *testing.T) {
+	suite.Run(t, new(ExhaustionConditionTestSuite))
+}
+
+func (s *ExhaustionConditionTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.condition = NewExhaustionCondition(ExhaustionInput{CharacterID: "ranger", Level: 1})
+	s.Require().NoError(s.condition.Apply(s.ctx, s.bus))
+
+	s.changes = nil
+	s.removed = nil
+	s.died = nil
+	_, err := dnd5eEvents.ExhaustionChangedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, e dnd5eEvents.ExhaustionChangedEvent) error {
+			s.changes = append(s.changes, e)
+			return nil
+		})
+	s.Require().NoError(err)
+	_, err = dnd5eEvents.ConditionRemovedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, e dnd5eEvents.ConditionRemovedEvent) error {
+			s.removed = append(s.removed, e)
+			return nil
+		})
+	s.Require().NoError(err)
+	_, err = dnd5eEvents.CharacterDiedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, e dnd5eEvents.CharacterDiedEvent) error {
+			s.died = append(s.died, e)
+			return nil
+		})
+	s.Require().NoError(err)
+}
+
+func (s *ExhaustionConditionTestSuite) check(checkerID string) *dnd5eEvents.AbilityCheckChainEvent {
+	event := &dnd5eEvents.AbilityCheckChainEvent{CheckerID: checkerID, Ability: abilities.STR, DC: 10}
+	checkChain := events.NewStagedChain[*dnd5eEvents.AbilityCheckChainEvent](combat.ModifierStages)
+	modifiedChain, err := dnd5eEvents.AbilityCheckChain.On(s.bus).PublishWithChain(s.ctx, event, checkChain)
+	s.Require().NoError(err)
+	final, err := modifiedChain.Execute(s.ctx, event)
+	s.Require().NoError(err)
+	return final
+}
+
+func (s *ExhaustionConditionTestSuite) attack() dnd5eEvents.AttackChainEvent {
+	event := dnd5eEvents.AttackChainEvent{AttackerID: "ranger", TargetID: "goblin", CriticalThreshold: 20}
+	attackChain := events.NewStagedChain[dnd5eEvents.AttackChainEvent](combat.ModifierStages)
+	modifiedChain, err := dnd5eEvents.AttackChain.On(s.bus).PublishWithChain(s.ctx, event, attackChain)
+	s.Require().NoError(err)
+	final, err := modifiedChain.Execute(s.ctx, event)
+	s.Require().NoError(err)
+	return final
+}
+
+func (s *ExhaustionConditionTestSuite) save() *dnd5eEvents.SavingThrowChainEvent {
+	event := &dnd5eEvents.SavingThrowChainEvent{SaverID: "ranger", Ability: abilities.CON, DC: 12}
+	saveChain := events.NewStagedChain[*dnd5eEvents.SavingThrowChainEvent](combat.ModifierStages)
+	modifiedChain, err := dnd5eEvents.SavingThrowChain.On(s.bus).PublishWithChain(s.ctx, event, saveChain)
+	s.Require().NoError(err)
+	final, err := modifiedChain.Execute(s.ctx, event)
+	s.Require().NoError(err)
+	return final
+}
+
+func (s *ExhaustionConditionTestSuite) TestLevelOneDisadvantageOnChecksOnly() {
+	final := s.check("ranger")
+	s.Require().Len(final.DisadvantageSources, 1)
+	s.Equal(refs.Conditions.Exhaustion(), final.DisadvantageSources[0].SourceRef)
+	s.False(s.check("goblin").HasDisadvantage())
+
+	s.Empty(s.attack().DisadvantageSources)
+	s.False(s.save().HasDisadvantage())
+}
+
+func (s *ExhaustionConditionTestSuite) TestLevelThreeDisadvantageOnAttacksAndSaves() {
+	s.Require().NoError(s.condition.AddLevels(s.ctx, 2, "forced_march"))
+
+	s.Len(s.attack().DisadvantageSources, 1)
+	s.True(s.save().HasDisadvantage())
+	s.True(s.check("ranger").HasDisadvantage())
+}
+
+func (s *ExhaustionConditionTestSuite) TestCumulativeSpeedAndHitPoints() {
+	s.Equal(30, s.condition.Speed(30))
+	s.Equal(40, s.condition.MaxHitPoints(40))
+
+	s.condition.Level = 2
+	s.Equal(15, s.condition.Speed(30))
+	s.condition.Level = 4
+	s.Equal(15, s.condition.Speed(30))
+	s.Equal(20, s.condition.MaxHitPoints(40))
+	s.condition.Level = 5
+	s.Equal(0, s.condition.Speed(30))
+}
+
+func (s *ExhaustionConditionTestSuite) TestAddLevelsPublishesChange() {
+	s.Require().NoError(s.condition.AddLevels(s.ctx, 2, "forced_march"))
+	s.Equal(3, s.condition.Level)
+	s.Require().Len(s.changes, 1)
+	s.Equal(dnd5eEvents.ExhaustionChangedEvent{
+		CharacterID:   "ranger",
+		PreviousLevel: 1,
+		Level:         3,
+		Reason:        "forced_march",
+	}, s.changes[0])
+	s.Empty(s.died)
+}
+
+func (s *ExhaustionConditionTestSuite) TestLevelSixKills() {
+	s.Require().NoError(s.condition.AddLevels(s.ctx, 10, "starvation"))
+	s.Equal(ExhaustionMaxLevel, s.condition.Level)
+	s.True(s.condition.IsDead())
+	s.Require().Len(s.died, 1)
+	s.Equal("ranger", s.died[0].CharacterID)
+
+	// Already dead: no second death
+	s.Require().NoError(s.condition.AddLevels(s.ctx, 1, "starvation"))
+	s.Len(s.died, 1)
+}
+
+func (s *ExhaustionConditionTestSuite) TestLongRestReducesOneLevel() {
+	s.Require().NoError(s.condition.AddLevels(s.ctx, 1, "forced_march"))
+	rests := dnd5eEvents.RestTopic.On(s.bus)
+
+	s.Require().NoError(rests.Publish(s.ctx, dnd5eEvents.RestEvent{
+		RestType:    coreResources.ResetShortRest,
+		CharacterID: "ranger",
+	}))
+	s.Equal(2, s.condition.Level)
+
+	s.Require().NoError(rests.Publish(s.ctx, dnd5eEvents.RestEvent{
+		RestType:    coreResources.ResetLongRest,
+		CharacterID: "ranger",
+	}))
+	s.Equal(1, s.condition.Level)
+	s.Equal(ExhaustionReasonLongRest, s.changes[len(s.changes)-1].Reason)
+	s.True(s.condition.IsApplied())
+
+	s.Require().NoError(rests.Publish(s.ctx, dnd5eEvents.RestEvent{
+		RestType:    coreResources.ResetLongRest,
+		CharacterID: "ranger",
+	}))
+	s.False(s.condition.IsApplied())
+	s.Require().Len(s.removed, 1)
+	s.Equal(ExhaustionReasonRecovered, s.removed[0].Reason)
+	s.Equal(refs.Conditions.Exhaustion().String(), s.removed[0].ConditionRef)
+}
+
+func (s *ExhaustionConditionTestSuite) TestInvalidLevelChanges() {
+	err := s.condition.AddLevels(s.ctx, 0, "none")
+	s.Equal(rpgerr.CodeInvalidArgument, rpgerr.GetCode(err))
+
+	s.Require().NoError(s.condition.Remove(s.ctx, s.bus))
+	err = s.condition.ReduceLevels(s.ctx, 1, "none")
+	s.Equal(rpgerr.CodeInvalidState, rpgerr.GetCode(err))
+}
+
+func (s *ExhaustionConditionTestSuite) TestRoundTrip() {
+	s.condition.Level = 4
+	data, err := s.condition.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := LoadJSON(data)
+	s.Require().NoError(err)
+	exhaustion, ok := loaded.(*ExhaustionCondition)
+	s.Require().True(ok)
+	s.Equal("ranger", exhaustion.CharacterID)
+	s.Equal(4, exhaustion.Level)
+}
+
+func (s *ExhaustionConditionTestSuite) TestCreateFromRef() {
+	output, err := CreateFromRef(&CreateFromRefInput{
+		Ref:         refs.Conditions.Exhaustion().String(),
+		Config:      []byte(`{"level": 2}`),
+		CharacterID: "ranger",
+	})
+	s.Require().NoError(err)
+	exhaustion, ok := output.Condition.(*ExhaustionCondition)
+	s.Require().True(ok)
+	s.Equal(2, exhaustion.Level)
+}
@@ -9,7 +9,9 @@ import (
 	"github.com/KirkDiggler/rpg-toolkit/core"
 	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
 	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/terrains"
 )
 
 // CreateFromRefInput provides input for creating a condition from a ref string
@@ -64,44 +66,13 @@ func CreateFromRef(input *CreateFromRefInput) (*CreateFromRefOutput, error) {
 			"unsupported type: %s (expected '%s')", ref.Type, refs.TypeConditions)
 	}
 
-	// Create the condition based on the ID
-	var condition dnd5eEvents.ConditionBehavior
-
-	switch ref.ID {
-	case refs.Conditions.UnarmoredDefense().ID:
-		condition, err = createUnarmoredDefense(input.Config, input.CharacterID, input.SourceRef)
-	case refs.Conditions.Raging().ID:
-		condition, err = createRaging(input.Config, input.CharacterID, input.SourceRef)
-	case refs.Conditions.BrutalCritical().ID:
-		condition, err = createBrutalCritical(input.Config, input.CharacterID)
-	case refs.Conditions.FightingStyleArchery().ID:
-		condition = NewFightingStyleArcheryCondition(input.CharacterID)
-	case refs.Conditions.FightingStyleDefense().ID:
-		condition = NewFightingStyleDefenseCondition(input.CharacterID)
-	case refs.Conditions.FightingStyleDueling().ID:
-		condition = NewFightingStyleDuelingCondition(input.CharacterID)
-	case refs.Conditions.FightingStyleGreatWeaponFighting().ID:
-		condition = NewFightingStyleGreatWeaponFightingCondition(input.CharacterID, nil)
-	case refs.Conditions.FightingStyleProtection().ID:
-		condition = NewFightingStyleProtectionCondition(input.CharacterID)
-	case refs.Conditions.FightingStyleTwoWeaponFighting().ID:
-		condition = NewFightingStyleTwoWeaponFightingCondition(input.CharacterID)
-	case refs.Conditions.ImprovedCritical().ID:
-		condition, err = createImprovedCritical(input.Config, input.CharacterID)
-	case refs.Conditions.MartialArts().ID:
-		condition, err = createMartialArts(input.Config, input.CharacterID)
-	case refs.Conditions.UnarmoredMovement().ID:
-		condition, err = createUnarmoredMovement(input.Config, input.CharacterID)
-	case refs.Conditions.SneakAttack().ID:
-		condition, err = createSneakAttack(input.Config, input.CharacterID)
-	case refs.Conditions.Disengaging().ID:
-		condition = NewDisengagingCondition(input.CharacterID)
-	case refs.Conditions.Dodging().ID:
-		condition = NewDodgingCondition(input.CharacterID)
-	default:
+	// Create the condition from its registered factory
+	reg, ok := lookupID(ref.ID)
+	if !ok || reg.Create == nil {
 		return nil, rpgerr.Newf(rpgerr.CodeInvalidArgument, "unknown condition: %s", ref.ID)
 	}
 
+	condition, err := reg.Create(input)
 	if err != nil {
 		return nil, rpgerr.Wrapf(err, "failed to create condition: %s", ref.ID)
 	}
@@ -300,3 +271,183 @@ func createSneakAttack(config json.RawMessage, characterID string) (*SneakAttack
 		// Roller is nil - will use default roller when needed
 	}), nil
 }
+
+// jackOfAllTradesConfig is the config structure for jack of all trades
+type jackOfAllTradesConfig struct {
+	ProficiencyBonus int `json:"proficiency_bonus"`
+}
+
+// createJackOfAllTrades creates a jack of all trades condition from config
+func createJackOfAllTrades(config json.RawMessage, characterID string) (*JackOfAllTradesCondition, error) {
+	var cfg jackOfAllTradesConfig
+	if len(config) > 0 {
+		if err := json.Unmarshal(config, &cfg); err != nil {
+			return nil, rpgerr.Wrap(err, "failed to parse jack of all trades config")
+		}
+	}
+
+	// Bards gain Jack of All Trades at level 2, when the proficiency bonus is +2
+	proficiencyBonus := cfg.ProficiencyBonus
+	if proficiencyBonus == 0 {
+		proficiencyBonus = 2
+	}
+
+	return NewJackOfAllTradesCondition(JackOfAllTradesInput{
+		CharacterID:      characterID,
+		ProficiencyBonus: proficiencyBonus,
+	}), nil
+}
+
+// favoredEnemyConfig is the config structure for favored enemy
+type favoredEnemyConfig struct {
+	Enemies []monster.CreatureType `json:"enemies"`
+}
+
+// createFavoredEnemy creates a favored enemy condition from config
+func createFavoredEnemy(config json.RawMessage, characterID string) (*FavoredEnemyCondition, error) {
+	var cfg favoredEnemyConfig
+	if len(config) > 0 {
+		if err := json.Unmarshal(config, &cfg); err != nil {
+			return nil, rpgerr.Wrap(err, "failed to parse favored enemy config")
+		}
+	}
+
+	if len(cfg.Enemies) == 0 {
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "favored enemy requires at least one creature type")
+	}
+
+	return NewFavoredEnemyCondition(FavoredEnemyInput{
+		CharacterID: characterID,
+		Enemies:     cfg.Enemies,
+	}), nil
+}
+
+// naturalExplorerConfig is the config structure for natural explorer
+type naturalExplorerConfig struct {
+	Terrains []terrains.Terrain `json:"terrains"`
+}
+
+// createNaturalExplorer creates a natural explorer condition from config
+func createNaturalExplorer(config json.RawMessage, characterID string) (*NaturalExplorerCondition, error) {
+	var cfg naturalExplorerConfig
+	if len(config) > 0 {
+		if err := json.Unmarshal(config, &cfg); err != nil {
+			return nil, rpgerr.Wrap(err, "failed to parse natural explorer config")
+		}
+	}
+
+	if len(cfg.Terrains) == 0 {
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "natural explorer requires at least one terrain")
+	}
+
+	return NewNaturalExplorerCondition(NaturalExplorerInput{
+		CharacterID: characterID,
+		Terrains:    cfg.Terrains,
+	}), nil
+}
+
+// turnedConfig is the config structure for the turned condition
+type turnedConfig struct {
+	SourceID string `json:"source_id"`
+}
+
+// createTurned creates a turned condition from config
+func createTurned(config json.RawMessage, characterID string) (*TurnedCondition, error) {
+	var cfg turnedConfig
+	if len(config) > 0 {
+		if err := json.Unmarshal(config, &cfg); err != nil {
+			return nil, rpgerr.Wrap(err, "failed to parse turned config")
+		}
+	}
+
+	return NewTurnedCondition(TurnedInput{
+		CharacterID: characterID,
+		SourceID:    cfg.SourceID,
+	}), nil
+}
+
+// exhaustionConfig is the config structure for exhaustion
+type exhaustionConfig struct {
+	Level int `json:"level"`
+}
+
+// createExhaustion creates an exhaustion condition from config
+func createExhaustion(config json.RawMessage, characterID string) (*ExhaustionCondition, error) {
+	var cfg exhaustionConfig
+	if len(config) > 0 {
+		if err := json.Unmarshal(config, &cfg); err != nil {
+			return nil, rpgerr.Wrap(err, "failed to parse exhaustion config")
+		}
+	}
+
+	return NewExhaustionCondition(ExhaustionInput{
+		CharacterID: characterID,
+		Level:       cfg.Level,
+	}), nil
+}
+
+// grappledConfig is the config structure for the grappled condition
+type grappledConfig struct {
+	GrapplerID string       `json:"grappler_id"`
+	Escape     EscapeConfig `json:"escape"`
+}
+
+// createGrappled creates a grappled condition from config
+func createGrappled(config json.RawMessage, characterID string) (*GrappledCondition, error) {
+	var cfg grappledConfig
+	if len(config) > 0 {
+		if err := json.Unmarshal(config, &cfg); err != nil {
+			return nil, rpgerr.Wrap(err, "failed to parse grappled config")
+		}
+	}
+
+	return NewGrappledCondition(GrappledConditionConfig{
+		CharacterID: characterID,
+		GrapplerID:  cfg.GrapplerID,
+		Escape:      cfg.Escape,
+	}), nil
+}
+
+// restrainedConfig is the config structure for the restrained condition
+type restrainedConfig struct {
+	SourceID  string       `json:"source_id"`
+	SourceRef *core.Ref    `json:"source_ref"`
+	Escape    EscapeConfig `json:"escape"`
+}
+
+// createRestrained creates a restrained condition from config
+func createRestrained(config json.RawMessage, characterID string) (*RestrainedCondition, error) {
+	var cfg restrainedConfig
+	if len(config) > 0 {
+		if err := json.Unmarshal(config, &cfg); err != nil {
+			return nil, rpgerr.Wrap(err, "failed to parse restrained config")
+		}
+	}
+
+	return NewRestrainedCondition(&RestrainedConditionConfig{
+		CharacterID: characterID,
+		SourceID:    cfg.SourceID,
+		SourceRef:   cfg.SourceRef,
+		Escape:      cfg.Escape,
+	})
+}
+
+// standardConfig is the config structure for the standard PHB conditions
+type standardConfig struct {
+	SourceID string `json:"source_id"`
+}
+
+// createStandard creates a standard condition (Blinded, Charmed, etc.) from config
+func createStandard(ref *core.Ref, config json.RawMessage, characterID string) (dnd5eEvents.ConditionBehavior, error) {
+	var cfg standardConfig
+	if len(config) > 0 {
+		if err := json.Unmarshal(config, &cfg); err != nil {
+			return nil, rpgerr.Wrapf(err, "failed to parse %s config", ref.ID)
+		}
+	}
+
+	return NewStandardCondition(ref, StandardConditionInput{
+		CharacterID: characterID,
+		SourceID:    cfg.SourceID,
+	})
+}
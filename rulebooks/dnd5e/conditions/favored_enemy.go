@@ -0,0 +1,106 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// FavoredEnemyData is the JSON structure for persisting favored enemy condition state
+type FavoredEnemyData struct {
+	Ref         *core.Ref              `json:"ref"`
+	CharacterID string                 `json:"character_id"`
+	Enemies     []monster.CreatureType `json:"enemies"`
+}
+
+// FavoredEnemyCondition represents the ranger's Favored Enemy feature (ranger level 1).
+// The ranger has advantage on Wisdom (Survival) checks to track their favored enemies
+// and on Intelligence checks to recall information about them.
+//
+// Ability checks don't carry the creature they concern, so the condition subscribes
+// to nothing; callers ask IsFavoredEnemy when setting up a tracking or recall check.
+type FavoredEnemyCondition struct {
+	CharacterID string
+	Enemies     []monster.CreatureType
+	bus         events.EventBus
+}
+
+// Ensure FavoredEnemyCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*FavoredEnemyCondition)(nil)
+
+// FavoredEnemyInput provides configuration for creating a favored enemy condition
+type FavoredEnemyInput struct {
+	CharacterID string                 // ID of the ranger
+	Enemies     []monster.CreatureType // Chosen favored enemy types
+}
+
+// NewFavoredEnemyCondition creates a favored enemy condition from input
+func NewFavoredEnemyCondition(input FavoredEnemyInput) *FavoredEnemyCondition {
+	return &FavoredEnemyCondition{
+		CharacterID: input.CharacterID,
+		Enemies:     input.Enemies,
+	}
+}
+
+// IsFavoredEnemy returns true if the creature type is one of the ranger's favored enemies
+func (f *FavoredEnemyCondition) IsFavoredEnemy(creatureType monster.CreatureType) bool {
+	return slices.Contains(f.Enemies, creatureType)
+}
+
+// IsApplied returns true if this condition is currently applied
+func (f *FavoredEnemyCondition) IsApplied() bool {
+	return f.bus != nil
+}
+
+// Apply marks the condition as applied. It has no event subscriptions.
+func (f *FavoredEnemyCondition) Apply(_ context.Context, bus events.EventBus) error {
+	if f.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "favored enemy condition already applied")
+	}
+	f.bus = bus
+	return nil
+}
+
+// Remove marks the condition as no longer applied
+func (f *FavoredEnemyCondition) Remove(_ context.Context, _ events.EventBus) error {
+	f.bus = nil
+	return nil
+}
+
+// ToJSON converts the condition to JSON for persistence
+func (f *FavoredEnemyCondition) ToJSON() (json.RawMessage, error) {
+	data := FavoredEnemyData{
+		Ref:         refs.Conditions.FavoredEnemy(),
+		CharacterID: f.CharacterID,
+		Enemies:     f.Enemies,
+	}
+
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to marshal favored enemy data")
+	}
+
+	return bytes, nil
+}
+
+// loadJSON loads the condition from JSON
+func (f *FavoredEnemyCondition) loadJSON(data json.RawMessage) error {
+	var enemyData FavoredEnemyData
+	if err := json.Unmarshal(data, &enemyData); err != nil {
+		return rpgerr.Wrap(err, "failed to unmarshal favored enemy data")
+	}
+
+	f.CharacterID = enemyData.CharacterID
+	f.Enemies = enemyData.Enemies
+	return nil
+}
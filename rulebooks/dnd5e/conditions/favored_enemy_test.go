@@ -0,0 +1,85 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+type FavoredEnemyTestSuite struct {
+	suite.Suite
+	ctx context.Context
+	bus events.EventBus
+}
+
+func TestFavoredEnemySuite(t *testing.T) {
+	suite.Run(t, new(FavoredEnemyTestSuite))
+}
+
+func (s *FavoredEnemyTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+}
+
+func (s *FavoredEnemyTestSuite) TestIsFavoredEnemy() {
+	favored := NewFavoredEnemyCondition(FavoredEnemyInput{
+		CharacterID: "ranger-1",
+		Enemies:     []monster.CreatureType{monster.CreatureTypeUndead, monster.CreatureTypeFiend},
+	})
+
+	s.True(favored.IsFavoredEnemy(monster.CreatureTypeUndead))
+	s.True(favored.IsFavoredEnemy(monster.CreatureTypeFiend))
+	s.False(favored.IsFavoredEnemy(monster.CreatureTypeBeast))
+}
+
+func (s *FavoredEnemyTestSuite) TestApplyAndRemove() {
+	favored := NewFavoredEnemyCondition(FavoredEnemyInput{
+		CharacterID: "ranger-1",
+		Enemies:     []monster.CreatureType{monster.CreatureTypeUndead},
+	})
+
+	s.Require().NoError(favored.Apply(s.ctx, s.bus))
+	s.True(favored.IsApplied())
+	s.Equal(rpgerr.CodeAlreadyExists, rpgerr.GetCode(favored.Apply(s.ctx, s.bus)))
+
+	s.Require().NoError(favored.Remove(s.ctx, s.bus))
+	s.False(favored.IsApplied())
+}
+
+func (s *FavoredEnemyTestSuite) TestFactoryAndRoundTrip() {
+	output, err := CreateFromRef(&CreateFromRefInput{
+		Ref:         refs.Conditions.FavoredEnemy().String(),
+		Config:      json.RawMessage(`{"enemies": ["undead", "giant"]}`),
+		CharacterID: "ranger-1",
+	})
+	s.Require().NoError(err)
+
+	data, err := output.Condition.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := LoadJSON(data)
+	s.Require().NoError(err)
+
+	favored, ok := loaded.(*FavoredEnemyCondition)
+	s.Require().True(ok)
+	s.Equal("ranger-1", favored.CharacterID)
+	s.Equal([]monster.CreatureType{monster.CreatureTypeUndead, monster.CreatureTypeGiant}, favored.Enemies)
+}
+
+func (s *FavoredEnemyTestSuite) TestFactoryRequiresEnemies() {
+	_, err := CreateFromRef(&CreateFromRefInput{
+		Ref:         refs.Conditions.FavoredEnemy().String(),
+		CharacterID: "ranger-1",
+	})
+	s.Error(err)
+}
@@ -0,0 +1,149 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// FeyAncestryData is the JSON structure for persisting fey ancestry condition state
+type FeyAncestryData struct {
+	Ref         *core.Ref `json:"ref"`
+	CharacterID string    `json:"character_id"`
+}
+
+// FeyAncestryCondition represents the elf and half-elf Fey Ancestry trait.
+// The character has advantage on saving throws against being charmed.
+// Magic can't put them to sleep; sleep effects don't use saving throws, so
+// callers check for this condition when resolving them.
+type FeyAncestryCondition struct {
+	CharacterID     string
+	bus             events.EventBus
+	subscriptionIDs []string
+}
+
+// Ensure FeyAncestryCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*FeyAncestryCondition)(nil)
+
+// FeyAncestryInput provides configuration for creating a fey ancestry condition
+type FeyAncestryInput struct {
+	CharacterID string // ID of the elf or half-elf
+}
+
+// NewFeyAncestryCondition creates a fey ancestry condition from input
+func NewFeyAncestryCondition(input FeyAncestryInput) *FeyAncestryCondition {
+	return &FeyAncestryCondition{
+		CharacterID: input.CharacterID,
+	}
+}
+
+// IsApplied returns true if this condition is currently applied
+func (f *FeyAncestryCondition) IsApplied() bool {
+	return f.bus != nil
+}
+
+// Apply subscribes to SavingThrowChain to grant advantage against being charmed
+func (f *FeyAncestryCondition) Apply(ctx context.Context, bus events.EventBus) error {
+	if f.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "fey ancestry condition already applied")
+	}
+	f.bus = bus
+
+	saveChain := dnd5eEvents.SavingThrowChain.On(bus)
+	subID, err := saveChain.SubscribeWithChain(ctx, f.onSavingThrowChain)
+	if err != nil {
+		f.bus = nil
+		return rpgerr.Wrap(err, "failed to subscribe to saving throw chain")
+	}
+	f.subscriptionIDs = append(f.subscriptionIDs, subID)
+
+	return nil
+}
+
+// Remove unsubscribes this condition from events
+func (f *FeyAncestryCondition) Remove(ctx context.Context, bus events.EventBus) error {
+	if f.bus == nil {
+		return nil
+	}
+
+	total := len(f.subscriptionIDs)
+	var errs []error
+	for _, subID := range f.subscriptionIDs {
+		if err := bus.Unsubscribe(ctx, subID); err != nil {
+			errs = append(errs, fmt.Errorf("unsubscribe %s: %w", subID, err))
+		}
+	}
+
+	f.subscriptionIDs = nil
+	f.bus = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unsubscribe %d/%d subscriptions: %w", len(errs), total, errors.Join(errs...))
+	}
+	return nil
+}
+
+// ToJSON converts the condition to JSON for persistence
+func (f *FeyAncestryCondition) ToJSON() (json.RawMessage, error) {
+	data := FeyAncestryData{
+		Ref:         refs.Conditions.FeyAncestry(),
+		CharacterID: f.CharacterID,
+	}
+
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to marshal fey ancestry data")
+	}
+
+	return bytes, nil
+}
+
+// loadJSON loads the condition from JSON
+func (f *FeyAncestryCondition) loadJSON(data json.RawMessage) error {
+	var ancestryData FeyAncestryData
+	if err := json.Unmarshal(data, &ancestryData); err != nil {
+		return rpgerr.Wrap(err, "failed to unmarshal fey ancestry data")
+	}
+
+	f.CharacterID = ancestryData.CharacterID
+	return nil
+}
+
+// onSavingThrowChain grants advantage on saves against being charmed
+func (f *FeyAncestryCondition) onSavingThrowChain(
+	_ context.Context,
+	event *dnd5eEvents.SavingThrowChainEvent,
+	c chain.Chain[*dnd5eEvents.SavingThrowChainEvent],
+) (chain.Chain[*dnd5eEvents.SavingThrowChainEvent], error) {
+	if event.SaverID != f.CharacterID || !causeImposes(event.Cause, refs.Conditions.Charmed()) {
+		return c, nil
+	}
+
+	modifySave := func(_ context.Context, e *dnd5eEvents.SavingThrowChainEvent) (*dnd5eEvents.SavingThrowChainEvent, error) {
+		e.AdvantageSources = append(e.AdvantageSources, dnd5eEvents.SaveModifierSource{
+			Name:       "Fey Ancestry",
+			SourceType: "condition",
+			SourceRef:  refs.Conditions.FeyAncestry(),
+			EntityID:   f.CharacterID,
+		})
+		return e, nil
+	}
+
+	if err := c.Add(combat.StageConditions, "fey_ancestry_advantage", modifySave); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to add fey ancestry advantage for character %s", f.CharacterID)
+	}
+
+	return c, nil
+}
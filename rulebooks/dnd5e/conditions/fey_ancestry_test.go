@@ -0,0 +1,95 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/saves"
+)
+
+type FeyAncestryTestSuite struct {
+	suite.Suite
+	ctrl   *gomock.Controller
+	ctx    context.Context
+	bus    events.EventBus
+	roller *mock_dice.MockRoller
+}
+
+func TestFeyAncestrySuite(t *testing.T) {
+	suite.Run(t, new(FeyAncestryTestSuite))
+}
+
+func (s *FeyAncestryTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.roller = mock_dice.NewMockRoller(s.ctrl)
+}
+
+func (s *FeyAncestryTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *FeyAncestryTestSuite) save(imposes ...*core.Ref) *saves.SavingThrowResult {
+	result, err := saves.MakeSavingThrow(s.ctx, &saves.SavingThrowInput{
+		Roller:   s.roller,
+		EventBus: s.bus,
+		SaverID:  "elf-1",
+		Cause: dnd5eEvents.SaveCause{
+			Trigger:    dnd5eEvents.SaveTriggerSpell,
+			Conditions: imposes,
+		},
+		Ability: abilities.WIS,
+		DC:      13,
+	})
+	s.Require().NoError(err)
+	return result
+}
+
+func (s *FeyAncestryTestSuite) TestAdvantageAgainstBeingCharmed() {
+	fey := NewFeyAncestryCondition(FeyAncestryInput{CharacterID: "elf-1"})
+	s.Require().NoError(fey.Apply(s.ctx, s.bus))
+
+	s.roller.EXPECT().RollN(gomock.Any(), 2, 20).Return([]int{3, 17}, nil)
+	result := s.save(refs.Conditions.Charmed())
+	s.Equal(17, result.Roll)
+	s.Require().Len(result.AdvantageSources, 1)
+	s.Equal(refs.Conditions.FeyAncestry(), result.AdvantageSources[0].SourceRef)
+
+	s.roller.EXPECT().Roll(gomock.Any(), 20).Return(3, nil)
+	s.Empty(s.save(refs.Conditions.Frightened()).AdvantageSources, "frightened is not charmed")
+
+	s.Require().NoError(fey.Remove(s.ctx, s.bus))
+	s.roller.EXPECT().Roll(gomock.Any(), 20).Return(3, nil)
+	s.Empty(s.save(refs.Conditions.Charmed()).AdvantageSources, "removed")
+}
+
+func (s *FeyAncestryTestSuite) TestFactoryAndRoundTrip() {
+	output, err := CreateFromRef(&CreateFromRefInput{
+		Ref:         refs.Conditions.FeyAncestry().String(),
+		CharacterID: "elf-1",
+	})
+	s.Require().NoError(err)
+
+	data, err := output.Condition.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := LoadJSON(data)
+	s.Require().NoError(err)
+
+	fey, ok := loaded.(*FeyAncestryCondition)
+	s.Require().True(ok)
+	s.Equal("elf-1", fey.CharacterID)
+}
@@ -125,8 +125,9 @@ func (f *FightingStyleTwoWeaponFightingCondition) onDamageChain(
 		return c, nil
 	}
 
-	// Check if there's an ability modifier to add
-	if event.AbilityModifier == 0 {
+	// Only a positive modifier is added; a negative one already applies
+	// to every off-hand attack
+	if event.AbilityModifier <= 0 {
 		return c, nil
 	}
 
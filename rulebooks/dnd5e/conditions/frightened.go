@@ -0,0 +1,35 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// FrightenedCondition represents a creature frightened of another (SourceID).
+//
+// While applied:
+//   - The creature's ability checks and attacks have disadvantage
+//   - It can't willingly move closer to the source of its fear
+//
+// The rules apply the disadvantage only while the source is within line of
+// sight. The toolkit has no line of sight, so it always applies.
+type FrightenedCondition struct {
+	standardCondition
+}
+
+// Ensure FrightenedCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*FrightenedCondition)(nil)
+
+// NewFrightenedCondition creates a frightened condition from input
+func NewFrightenedCondition(input StandardConditionInput) *FrightenedCondition {
+	return &FrightenedCondition{newStandardCondition(input, standardEffects{
+		name:               "Frightened",
+		ref:                refs.Conditions.Frightened(),
+		checkDisadvantage:  true,
+		attackDisadvantage: true,
+		cantApproach:       true,
+	})}
+}
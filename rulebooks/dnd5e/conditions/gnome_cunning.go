@@ -0,0 +1,155 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// GnomeCunningData is the JSON structure for persisting gnome cunning condition state
+type GnomeCunningData struct {
+	Ref         *core.Ref `json:"ref"`
+	CharacterID string    `json:"character_id"`
+}
+
+// GnomeCunningCondition represents the gnome's Gnome Cunning trait.
+// The gnome has advantage on all Intelligence, Wisdom, and Charisma saving
+// throws against magic.
+type GnomeCunningCondition struct {
+	CharacterID     string
+	bus             events.EventBus
+	subscriptionIDs []string
+}
+
+// Ensure GnomeCunningCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*GnomeCunningCondition)(nil)
+
+// GnomeCunningInput provides configuration for creating a gnome cunning condition
+type GnomeCunningInput struct {
+	CharacterID string // ID of the gnome
+}
+
+// NewGnomeCunningCondition creates a gnome cunning condition from input
+func NewGnomeCunningCondition(input GnomeCunningInput) *GnomeCunningCondition {
+	return &GnomeCunningCondition{
+		CharacterID: input.CharacterID,
+	}
+}
+
+// IsApplied returns true if this condition is currently applied
+func (g *GnomeCunningCondition) IsApplied() bool {
+	return g.bus != nil
+}
+
+// Apply subscribes to SavingThrowChain to grant advantage on mental saves against magic
+func (g *GnomeCunningCondition) Apply(ctx context.Context, bus events.EventBus) error {
+	if g.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "gnome cunning condition already applied")
+	}
+	g.bus = bus
+
+	saveChain := dnd5eEvents.SavingThrowChain.On(bus)
+	subID, err := saveChain.SubscribeWithChain(ctx, g.onSavingThrowChain)
+	if err != nil {
+		g.bus = nil
+		return rpgerr.Wrap(err, "failed to subscribe to saving throw chain")
+	}
+	g.subscriptionIDs = append(g.subscriptionIDs, subID)
+
+	return nil
+}
+
+// Remove unsubscribes this condition from events
+func (g *GnomeCunningCondition) Remove(ctx context.Context, bus events.EventBus) error {
+	if g.bus == nil {
+		return nil
+	}
+
+	total := len(g.subscriptionIDs)
+	var errs []error
+	for _, subID := range g.subscriptionIDs {
+		if err := bus.Unsubscribe(ctx, subID); err != nil {
+			errs = append(errs, fmt.Errorf("unsubscribe %s: %w", subID, err))
+		}
+	}
+
+	g.subscriptionIDs = nil
+	g.bus = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unsubscribe %d/%d subscriptions: %w", len(errs), total, errors.Join(errs...))
+	}
+	return nil
+}
+
+// ToJSON converts the condition to JSON for persistence
+func (g *GnomeCunningCondition) ToJSON() (json.RawMessage, error) {
+	data := GnomeCunningData{
+		Ref:         refs.Conditions.GnomeCunning(),
+		CharacterID: g.CharacterID,
+	}
+
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to marshal gnome cunning data")
+	}
+
+	return bytes, nil
+}
+
+// loadJSON loads the condition from JSON
+func (g *GnomeCunningCondition) loadJSON(data json.RawMessage) error {
+	var cunningData GnomeCunningData
+	if err := json.Unmarshal(data, &cunningData); err != nil {
+		return rpgerr.Wrap(err, "failed to unmarshal gnome cunning data")
+	}
+
+	g.CharacterID = cunningData.CharacterID
+	return nil
+}
+
+// onSavingThrowChain grants advantage on INT, WIS, and CHA saves against spells
+func (g *GnomeCunningCondition) onSavingThrowChain(
+	_ context.Context,
+	event *dnd5eEvents.SavingThrowChainEvent,
+	c chain.Chain[*dnd5eEvents.SavingThrowChainEvent],
+) (chain.Chain[*dnd5eEvents.SavingThrowChainEvent], error) {
+	if event.SaverID != g.CharacterID || event.Cause.Trigger != dnd5eEvents.SaveTriggerSpell {
+		return c, nil
+	}
+
+	switch event.Ability {
+	case abilities.INT, abilities.WIS, abilities.CHA:
+	default:
+		return c, nil
+	}
+
+	modifySave := func(_ context.Context, e *dnd5eEvents.SavingThrowChainEvent) (*dnd5eEvents.SavingThrowChainEvent, error) {
+		e.AdvantageSources = append(e.AdvantageSources, dnd5eEvents.SaveModifierSource{
+			Name:       "Gnome Cunning",
+			SourceType: "condition",
+			SourceRef:  refs.Conditions.GnomeCunning(),
+			EntityID:   g.CharacterID,
+		})
+		return e, nil
+	}
+
+	if err := c.Add(combat.StageConditions, "gnome_cunning_advantage", modifySave); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to add gnome cunning advantage for character %s", g.CharacterID)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/saves"
+)
+
+type GnomeCunningTestSuite struct {
+	suite.Suite
+	ctrl   *gomock.Controller
+	ctx    context.Context
+	bus    events.EventBus
+	roller *mock_dice.MockRoller
+}
+
+func TestGnomeCunningSuite(t *testing.T) {
+	suite.Run(t, new(GnomeCunningTestSuite))
+}
+
+func (s *GnomeCunningTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.roller = mock_dice.NewMockRoller(s.ctrl)
+}
+
+func (s *GnomeCunningTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *GnomeCunningTestSuite) save(ability abilities.Ability, trigger dnd5eEvents.SaveTrigger) *saves.SavingThrowResult {
+	result, err := saves.MakeSavingThrow(s.ctx, &saves.SavingThrowInput{
+		Roller:   s.roller,
+		EventBus: s.bus,
+		SaverID:  "gnome-1",
+		Cause:    dnd5eEvents.SaveCause{Trigger: trigger},
+		Ability:  ability,
+		DC:       14,
+	})
+	s.Require().NoError(err)
+	return result
+}
+
+func (s *GnomeCunningTestSuite) TestAdvantageOnMentalSavesAgainstSpells() {
+	cunning := NewGnomeCunningCondition(GnomeCunningInput{CharacterID: "gnome-1"})
+	s.Require().NoError(cunning.Apply(s.ctx, s.bus))
+
+	for _, ability := range []abilities.Ability{abilities.INT, abilities.WIS, abilities.CHA} {
+		s.Run(string(ability), func() {
+			s.roller.EXPECT().RollN(gomock.Any(), 2, 20).Return([]int{2, 12}, nil)
+			result := s.save(ability, dnd5eEvents.SaveTriggerSpell)
+			s.Equal(12, result.Roll)
+			s.Require().Len(result.AdvantageSources, 1)
+			s.Equal(refs.Conditions.GnomeCunning(), result.AdvantageSources[0].SourceRef)
+		})
+	}
+
+	s.Run("physical save", func() {
+		s.roller.EXPECT().Roll(gomock.Any(), 20).Return(2, nil)
+		s.Empty(s.save(abilities.DEX, dnd5eEvents.SaveTriggerSpell).AdvantageSources)
+	})
+
+	s.Run("not magic", func() {
+		s.roller.EXPECT().Roll(gomock.Any(), 20).Return(2, nil)
+		s.Empty(s.save(abilities.WIS, dnd5eEvents.SaveTriggerTrap).AdvantageSources)
+	})
+}
+
+func (s *GnomeCunningTestSuite) TestFactoryAndRoundTrip() {
+	output, err := CreateFromRef(&CreateFromRefInput{
+		Ref:         refs.Conditions.GnomeCunning().String(),
+		CharacterID: "gnome-1",
+	})
+	s.Require().NoError(err)
+
+	data, err := output.Condition.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := LoadJSON(data)
+	s.Require().NoError(err)
+
+	cunning, ok := loaded.(*GnomeCunningCondition)
+	s.Require().True(ok)
+	s.Equal("gnome-1", cunning.CharacterID)
+}
@@ -0,0 +1,148 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// GrappledConditionData is the serializable form of the grappled condition.
+// This is stored by the game server as an opaque JSON blob.
+type GrappledConditionData struct {
+	Ref             *core.Ref    `json:"ref"`
+	CharacterID     string       `json:"character_id"`
+	GrapplerID      string       `json:"grappler_id"`
+	Escape          EscapeConfig `json:"escape"`
+	LastEscapeRound int          `json:"last_escape_round,omitempty"`
+}
+
+// GrappledConditionConfig configures a new grappled condition.
+type GrappledConditionConfig struct {
+	// CharacterID is the grappled creature
+	CharacterID string
+
+	// GrapplerID is the creature holding the grapple
+	GrapplerID string
+
+	// Escape is how the creature escapes. The zero value means GrappleEscape().
+	Escape EscapeConfig
+}
+
+// GrappledCondition represents a creature held by a grappler.
+//
+// A grappled creature's speed is 0; the action economy enforces that through
+// the grappled condition ref. The condition itself owns the escape: each turn
+// the creature may call AttemptEscape, and on success the condition publishes
+// a ConditionRemovedEvent and removes itself.
+type GrappledCondition struct {
+	CharacterID     string
+	GrapplerID      string
+	Escape          EscapeConfig
+	LastEscapeRound int
+	bus             events.EventBus
+}
+
+// Ensure GrappledCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*GrappledCondition)(nil)
+
+// NewGrappledCondition creates a grappled condition from the config.
+func NewGrappledCondition(config GrappledConditionConfig) *GrappledCondition {
+	escape := config.Escape
+	if escape.Method == "" {
+		escape = GrappleEscape()
+	}
+
+	return &GrappledCondition{
+		CharacterID: config.CharacterID,
+		GrapplerID:  config.GrapplerID,
+		Escape:      escape,
+	}
+}
+
+// IsApplied returns true if this condition is currently applied.
+func (g *GrappledCondition) IsApplied() bool {
+	return g.bus != nil
+}
+
+// Apply records the bus used to publish escape events.
+// Grappled has no chain modifiers of its own.
+func (g *GrappledCondition) Apply(_ context.Context, bus events.EventBus) error {
+	if g.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "grappled condition already applied")
+	}
+	g.bus = bus
+	return nil
+}
+
+// Remove releases the condition.
+func (g *GrappledCondition) Remove(_ context.Context, _ events.EventBus) error {
+	g.bus = nil
+	return nil
+}
+
+// AttemptEscape makes the creature's escape attempt for this turn.
+//
+// Returns CodeInvalidState if the condition isn't applied, CodeResourceExhausted
+// if the creature already tried this round, and the economy's error if the
+// escape costs an action the creature doesn't have. On success the condition
+// publishes a ConditionRemovedEvent with reason "escaped" and removes itself.
+func (g *GrappledCondition) AttemptEscape(ctx context.Context, input *EscapeAttemptInput) (*EscapeResult, error) {
+	if !g.IsApplied() {
+		return nil, rpgerr.New(rpgerr.CodeInvalidState, "grappled condition is not applied")
+	}
+
+	attempt := &escapeAttempt{
+		characterID:      g.CharacterID,
+		sourceID:         g.GrapplerID,
+		conditionRef:     refs.Conditions.Grappled(),
+		config:           g.Escape,
+		lastAttemptRound: g.LastEscapeRound,
+	}
+	result, err := attempt.resolve(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	g.LastEscapeRound = attempt.lastAttemptRound
+
+	if !result.Success {
+		return result, nil
+	}
+	if err := publishEscaped(ctx, g.bus, g.CharacterID, refs.Conditions.Grappled()); err != nil {
+		return nil, err
+	}
+	return result, g.Remove(ctx, g.bus)
+}
+
+// ToJSON converts the condition to JSON for persistence.
+func (g *GrappledCondition) ToJSON() (json.RawMessage, error) {
+	data := GrappledConditionData{
+		Ref:             refs.Conditions.Grappled(),
+		CharacterID:     g.CharacterID,
+		GrapplerID:      g.GrapplerID,
+		Escape:          g.Escape,
+		LastEscapeRound: g.LastEscapeRound,
+	}
+	return json.Marshal(data)
+}
+
+// loadJSON loads grappled condition state from JSON.
+func (g *GrappledCondition) loadJSON(data json.RawMessage) error {
+	var grappledData GrappledConditionData
+	if err := json.Unmarshal(data, &grappledData); err != nil {
+		return rpgerr.Wrap(err, "failed to unmarshal grappled data")
+	}
+
+	g.CharacterID = grappledData.CharacterID
+	g.GrapplerID = grappledData.GrapplerID
+	g.Escape = grappledData.Escape
+	g.LastEscapeRound = grappledData.LastEscapeRound
+	return nil
+}
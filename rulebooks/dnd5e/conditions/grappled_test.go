@@ -0,0 +1,213 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
+)
+
+type GrappledConditionTestSuite struct {
+	suite.Suite
+	ctx       context.Context
+	ctrl      *gomock.Controller
+	roller    *mock_dice.MockRoller
+	bus       events.EventBus
+	economy   *combat.ActionEconomy
+	condition *GrappledCondition
+	attempts  []dnd5eEvents.EscapeAttemptedEvent
+	removals  []dnd5eEvents.ConditionRemovedEvent
+}
+
+func TestGrappledConditionSuite(t *testing.T) {
+	suite.Run(t, new(GrappledConditionTestSuite))
+}
+
+func (s *GrappledConditionTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.ctrl = gomock.NewController(s.T())
+	s.roller = mock_dice.NewMockRoller(s.ctrl)
+	s.bus = events.NewEventBus()
+	s.economy = combat.NewActionEconomy()
+
+	s.condition = NewGrappledCondition(GrappledConditionConfig{
+		CharacterID: "rogue",
+		GrapplerID:  "ogre",
+	})
+	s.Require().NoError(s.condition.Apply(s.ctx, s.bus))
+
+	s.attempts = nil
+	s.removals = nil
+	_, err := dnd5eEvents.EscapeAttemptedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, e dnd5eEvents.EscapeAttemptedEvent) error {
+			s.attempts = append(s.attempts, e)
+			return nil
+		})
+	s.Require().NoError(err)
+	_, err = dnd5eEvents.ConditionRemovedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, e dnd5eEvents.ConditionRemovedEvent) error {
+			s.removals = append(s.removals, e)
+			return nil
+		})
+	s.Require().NoError(err)
+}
+
+func (s *GrappledConditionTestSuite) SetupSubTest() {
+	s.SetupTest()
+}
+
+func (s *GrappledConditionTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// escape attempts an Acrobatics escape (+4) against the grappler's Athletics (+5).
+func (s *GrappledConditionTestSuite) escape(round int) (*EscapeResult, error) {
+	return s.condition.AttemptEscape(s.ctx, &EscapeAttemptInput{
+		Round:          round,
+		Skill:          skills.Acrobatics,
+		Modifier:       4,
+		SourceModifier: 5,
+		Roller:         s.roller,
+		EventBus:       s.bus,
+		Economy:        s.economy,
+	})
+}
+
+func (s *GrappledConditionTestSuite) expectContest(escaperRoll, grapplerRoll int) {
+	gomock.InOrder(
+		s.roller.EXPECT().Roll(s.ctx, 20).Return(escaperRoll, nil),
+		s.roller.EXPECT().Roll(s.ctx, 20).Return(grapplerRoll, nil),
+	)
+}
+
+func (s *GrappledConditionTestSuite) TestDefaultsToGrappleEscape() {
+	s.Equal(GrappleEscape(), s.condition.Escape)
+	s.True(s.condition.Escape.RequiresAction)
+}
+
+func (s *GrappledConditionTestSuite) TestEscapeSucceeds() {
+	s.expectContest(15, 8)
+
+	result, err := s.escape(1)
+	s.Require().NoError(err)
+	s.True(result.Success)
+	s.Equal(19, result.Total)
+	s.Equal(13, result.OpposedTotal)
+
+	s.False(s.condition.IsApplied())
+	s.Equal(0, s.economy.ActionsRemaining)
+
+	s.Require().Len(s.attempts, 1)
+	s.Equal(dnd5eEvents.EscapeAttemptedEvent{
+		CharacterID:  "rogue",
+		SourceID:     "ogre",
+		ConditionRef: refs.Conditions.Grappled().String(),
+		Method:       dnd5eEvents.EscapeMethodContest,
+		Total:        19,
+		OpposedTotal: 13,
+		Success:      true,
+		Round:        1,
+	}, s.attempts[0])
+
+	s.Require().Len(s.removals, 1)
+	s.Equal("escaped", s.removals[0].Reason)
+	s.Equal(refs.Conditions.Grappled().String(), s.removals[0].ConditionRef)
+}
+
+func (s *GrappledConditionTestSuite) TestEscapeFails() {
+	s.Run("lower total", func() {
+		s.expectContest(5, 10)
+
+		result, err := s.escape(1)
+		s.Require().NoError(err)
+		s.False(result.Success)
+		s.True(s.condition.IsApplied())
+		s.Empty(s.removals)
+		s.Len(s.attempts, 1)
+	})
+
+	s.Run("tie goes to the grappler", func() {
+		s.expectContest(10, 9)
+
+		result, err := s.escape(1)
+		s.Require().NoError(err)
+		s.Equal(result.Total, result.OpposedTotal)
+		s.False(result.Success)
+		s.True(s.condition.IsApplied())
+	})
+}
+
+func (s *GrappledConditionTestSuite) TestOneAttemptPerTurn() {
+	s.expectContest(5, 10)
+	_, err := s.escape(1)
+	s.Require().NoError(err)
+
+	s.economy.GrantExtraAction()
+	_, err = s.escape(1)
+	s.Equal(rpgerr.CodeResourceExhausted, rpgerr.GetCode(err))
+	s.Equal(1, s.condition.LastEscapeRound)
+
+	// Next round the creature may try again
+	s.economy.Reset()
+	s.expectContest(18, 2)
+	result, err := s.escape(2)
+	s.Require().NoError(err)
+	s.True(result.Success)
+}
+
+func (s *GrappledConditionTestSuite) TestRejections() {
+	s.Run("no action available", func() {
+		s.Require().NoError(s.economy.UseAction())
+		_, err := s.escape(1)
+		s.Equal(rpgerr.CodeResourceExhausted, rpgerr.GetCode(err))
+		s.Equal(0, s.condition.LastEscapeRound)
+		s.Empty(s.attempts)
+	})
+
+	s.Run("skill not allowed", func() {
+		_, err := s.condition.AttemptEscape(s.ctx, &EscapeAttemptInput{
+			Round:    1,
+			Skill:    skills.Persuasion,
+			Roller:   s.roller,
+			EventBus: s.bus,
+			Economy:  s.economy,
+		})
+		s.Equal(rpgerr.CodeNotAllowed, rpgerr.GetCode(err))
+		s.Equal(1, s.economy.ActionsRemaining)
+	})
+
+	s.Run("not applied", func() {
+		s.Require().NoError(s.condition.Remove(s.ctx, s.bus))
+		_, err := s.escape(1)
+		s.Equal(rpgerr.CodeInvalidState, rpgerr.GetCode(err))
+	})
+}
+
+func (s *GrappledConditionTestSuite) TestToJSONRoundTrip() {
+	s.condition.LastEscapeRound = 3
+
+	data, err := s.condition.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := LoadJSON(data)
+	s.Require().NoError(err)
+
+	grappled, ok := loaded.(*GrappledCondition)
+	s.Require().True(ok)
+	s.Equal("rogue", grappled.CharacterID)
+	s.Equal("ogre", grappled.GrapplerID)
+	s.Equal(GrappleEscape(), grappled.Escape)
+	s.Equal(3, grappled.LastEscapeRound)
+}
@@ -0,0 +1,218 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// HalflingLuckyData is the JSON structure for persisting halfling lucky condition state
+type HalflingLuckyData struct {
+	Ref         *core.Ref `json:"ref"`
+	CharacterID string    `json:"character_id"`
+}
+
+// HalflingLuckyCondition represents the halfling's Lucky trait.
+// When the halfling rolls a 1 on the d20 for an attack roll, ability check, or
+// saving throw, they can reroll the die and must use the new roll.
+type HalflingLuckyCondition struct {
+	CharacterID     string
+	bus             events.EventBus
+	subscriptionIDs []string
+}
+
+// Ensure HalflingLuckyCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*HalflingLuckyCondition)(nil)
+
+// HalflingLuckyInput provides configuration for creating a halfling lucky condition
+type HalflingLuckyInput struct {
+	CharacterID string // ID of the halfling
+}
+
+// NewHalflingLuckyCondition creates a halfling lucky condition from input
+func NewHalflingLuckyCondition(input HalflingLuckyInput) *HalflingLuckyCondition {
+	return &HalflingLuckyCondition{
+		CharacterID: input.CharacterID,
+	}
+}
+
+// IsApplied returns true if this condition is currently applied
+func (h *HalflingLuckyCondition) IsApplied() bool {
+	return h.bus != nil
+}
+
+// Apply subscribes to AttackChain, AbilityCheckChain, and SavingThrowChain to
+// let the halfling reroll natural 1s
+func (h *HalflingLuckyCondition) Apply(ctx context.Context, bus events.EventBus) error {
+	if h.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "halfling lucky condition already applied")
+	}
+	h.bus = bus
+
+	attackChain := dnd5eEvents.AttackChain.On(bus)
+	subID1, err := attackChain.SubscribeWithChain(ctx, h.onAttackChain)
+	if err != nil {
+		h.bus = nil
+		return rpgerr.Wrap(err, "failed to subscribe to attack chain")
+	}
+	h.subscriptionIDs = append(h.subscriptionIDs, subID1)
+
+	checkChain := dnd5eEvents.AbilityCheckChain.On(bus)
+	subID2, err := checkChain.SubscribeWithChain(ctx, h.onAbilityCheckChain)
+	if err != nil {
+		_ = h.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to ability check chain")
+	}
+	h.subscriptionIDs = append(h.subscriptionIDs, subID2)
+
+	saveChain := dnd5eEvents.SavingThrowChain.On(bus)
+	subID3, err := saveChain.SubscribeWithChain(ctx, h.onSavingThrowChain)
+	if err != nil {
+		_ = h.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to saving throw chain")
+	}
+	h.subscriptionIDs = append(h.subscriptionIDs, subID3)
+
+	return nil
+}
+
+// Remove unsubscribes this condition from all events
+func (h *HalflingLuckyCondition) Remove(ctx context.Context, bus events.EventBus) error {
+	if h.bus == nil {
+		return nil
+	}
+
+	total := len(h.subscriptionIDs)
+	var errs []error
+	for _, subID := range h.subscriptionIDs {
+		if err := bus.Unsubscribe(ctx, subID); err != nil {
+			errs = append(errs, fmt.Errorf("unsubscribe %s: %w", subID, err))
+		}
+	}
+
+	h.subscriptionIDs = nil
+	h.bus = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unsubscribe %d/%d subscriptions: %w", len(errs), total, errors.Join(errs...))
+	}
+	return nil
+}
+
+// ToJSON converts the condition to JSON for persistence
+func (h *HalflingLuckyCondition) ToJSON() (json.RawMessage, error) {
+	data := HalflingLuckyData{
+		Ref:         refs.Conditions.HalflingLucky(),
+		CharacterID: h.CharacterID,
+	}
+
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to marshal halfling lucky data")
+	}
+
+	return bytes, nil
+}
+
+// loadJSON loads the condition from JSON
+func (h *HalflingLuckyCondition) loadJSON(data json.RawMessage) error {
+	var luckyData HalflingLuckyData
+	if err := json.Unmarshal(data, &luckyData); err != nil {
+		return rpgerr.Wrap(err, "failed to unmarshal halfling lucky data")
+	}
+
+	h.CharacterID = luckyData.CharacterID
+	return nil
+}
+
+// onAttackChain lets the halfling reroll a natural 1 on their attack rolls
+func (h *HalflingLuckyCondition) onAttackChain(
+	_ context.Context,
+	event dnd5eEvents.AttackChainEvent,
+	c chain.Chain[dnd5eEvents.AttackChainEvent],
+) (chain.Chain[dnd5eEvents.AttackChainEvent], error) {
+	if event.AttackerID != h.CharacterID {
+		return c, nil
+	}
+
+	modifyAttack := func(_ context.Context, e dnd5eEvents.AttackChainEvent) (dnd5eEvents.AttackChainEvent, error) {
+		e.RerollOneSources = append(e.RerollOneSources, dnd5eEvents.AttackModifierSource{
+			SourceRef: refs.Conditions.HalflingLucky(),
+			SourceID:  h.CharacterID,
+			Reason:    "Lucky",
+		})
+		return e, nil
+	}
+
+	if err := c.Add(combat.StageConditions, "halfling_lucky_attack", modifyAttack); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to add lucky attack modifier for character %s", h.CharacterID)
+	}
+
+	return c, nil
+}
+
+// onAbilityCheckChain lets the halfling reroll a natural 1 on their ability checks
+func (h *HalflingLuckyCondition) onAbilityCheckChain(
+	_ context.Context,
+	event *dnd5eEvents.AbilityCheckChainEvent,
+	c chain.Chain[*dnd5eEvents.AbilityCheckChainEvent],
+) (chain.Chain[*dnd5eEvents.AbilityCheckChainEvent], error) {
+	if event.CheckerID != h.CharacterID {
+		return c, nil
+	}
+
+	modifyCheck := func(_ context.Context, e *dnd5eEvents.AbilityCheckChainEvent) (*dnd5eEvents.AbilityCheckChainEvent, error) {
+		e.RerollOneSources = append(e.RerollOneSources, dnd5eEvents.CheckModifierSource{
+			Name:       "Lucky",
+			SourceType: "condition",
+			SourceRef:  refs.Conditions.HalflingLucky(),
+			EntityID:   h.CharacterID,
+		})
+		return e, nil
+	}
+
+	if err := c.Add(combat.StageConditions, "halfling_lucky_check", modifyCheck); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to add lucky check modifier for character %s", h.CharacterID)
+	}
+
+	return c, nil
+}
+
+// onSavingThrowChain lets the halfling reroll a natural 1 on their saving throws
+func (h *HalflingLuckyCondition) onSavingThrowChain(
+	_ context.Context,
+	event *dnd5eEvents.SavingThrowChainEvent,
+	c chain.Chain[*dnd5eEvents.SavingThrowChainEvent],
+) (chain.Chain[*dnd5eEvents.SavingThrowChainEvent], error) {
+	if event.SaverID != h.CharacterID {
+		return c, nil
+	}
+
+	modifySave := func(_ context.Context, e *dnd5eEvents.SavingThrowChainEvent) (*dnd5eEvents.SavingThrowChainEvent, error) {
+		e.RerollOneSources = append(e.RerollOneSources, dnd5eEvents.SaveModifierSource{
+			Name:       "Lucky",
+			SourceType: "condition",
+			SourceRef:  refs.Conditions.HalflingLucky(),
+			EntityID:   h.CharacterID,
+		})
+		return e, nil
+	}
+
+	if err := c.Add(combat.StageConditions, "halfling_lucky_save", modifySave); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to add lucky save modifier for character %s", h.CharacterID)
+	}
+
+	return c, nil
+}
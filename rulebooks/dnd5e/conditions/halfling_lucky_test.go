@@ -0,0 +1,136 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/checks"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/saves"
+)
+
+type HalflingLuckyTestSuite struct {
+	suite.Suite
+	ctrl   *gomock.Controller
+	ctx    context.Context
+	bus    events.EventBus
+	roller *mock_dice.MockRoller
+}
+
+func TestHalflingLuckySuite(t *testing.T) {
+	suite.Run(t, new(HalflingLuckyTestSuite))
+}
+
+func (s *HalflingLuckyTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.roller = mock_dice.NewMockRoller(s.ctrl)
+}
+
+func (s *HalflingLuckyTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *HalflingLuckyTestSuite) TestRerollsNaturalOneOnSaves() {
+	lucky := NewHalflingLuckyCondition(HalflingLuckyInput{CharacterID: "halfling-1"})
+	s.Require().NoError(lucky.Apply(s.ctx, s.bus))
+
+	save := func(saverID string) *saves.SavingThrowResult {
+		result, err := saves.MakeSavingThrow(s.ctx, &saves.SavingThrowInput{
+			Roller:   s.roller,
+			EventBus: s.bus,
+			SaverID:  saverID,
+			Ability:  abilities.DEX,
+			DC:       10,
+		})
+		s.Require().NoError(err)
+		return result
+	}
+
+	gomock.InOrder(
+		s.roller.EXPECT().Roll(gomock.Any(), 20).Return(1, nil),
+		s.roller.EXPECT().Roll(gomock.Any(), 20).Return(14, nil),
+	)
+	result := save("halfling-1")
+	s.Equal(14, result.Roll)
+	s.True(result.Success)
+
+	s.roller.EXPECT().Roll(gomock.Any(), 20).Return(1, nil)
+	s.Equal(1, save("human-1").Roll, "other characters keep their 1")
+
+	s.Require().NoError(lucky.Remove(s.ctx, s.bus))
+	s.roller.EXPECT().Roll(gomock.Any(), 20).Return(1, nil)
+	s.Equal(1, save("halfling-1").Roll, "removed")
+}
+
+func (s *HalflingLuckyTestSuite) TestRerollsNaturalOneOnChecks() {
+	lucky := NewHalflingLuckyCondition(HalflingLuckyInput{CharacterID: "halfling-1"})
+	s.Require().NoError(lucky.Apply(s.ctx, s.bus))
+
+	// With disadvantage only the 1 is rerolled, and the lower die is still used
+	gomock.InOrder(
+		s.roller.EXPECT().RollN(gomock.Any(), 2, 20).Return([]int{1, 12}, nil),
+		s.roller.EXPECT().Roll(gomock.Any(), 20).Return(18, nil),
+	)
+	result, err := checks.MakeAbilityCheck(s.ctx, &checks.AbilityCheckInput{
+		Roller:          s.roller,
+		EventBus:        s.bus,
+		CheckerID:       "halfling-1",
+		Ability:         abilities.DEX,
+		DC:              10,
+		HasDisadvantage: true,
+	})
+	s.Require().NoError(err)
+	s.Equal(12, result.Roll)
+}
+
+func (s *HalflingLuckyTestSuite) TestAddsRerollSourceToOwnAttacks() {
+	lucky := NewHalflingLuckyCondition(HalflingLuckyInput{CharacterID: "halfling-1"})
+	s.Require().NoError(lucky.Apply(s.ctx, s.bus))
+
+	attack := func(attackerID string) dnd5eEvents.AttackChainEvent {
+		event := dnd5eEvents.AttackChainEvent{AttackerID: attackerID, TargetID: "goblin-1"}
+		attackChain := events.NewStagedChain[dnd5eEvents.AttackChainEvent](combat.ModifierStages)
+		modifiedChain, err := dnd5eEvents.AttackChain.On(s.bus).PublishWithChain(s.ctx, event, attackChain)
+		s.Require().NoError(err)
+		final, err := modifiedChain.Execute(s.ctx, event)
+		s.Require().NoError(err)
+		return final
+	}
+
+	final := attack("halfling-1")
+	s.Require().Len(final.RerollOneSources, 1)
+	s.Equal(refs.Conditions.HalflingLucky(), final.RerollOneSources[0].SourceRef)
+
+	s.Empty(attack("goblin-1").RerollOneSources)
+}
+
+func (s *HalflingLuckyTestSuite) TestFactoryAndRoundTrip() {
+	output, err := CreateFromRef(&CreateFromRefInput{
+		Ref:         refs.Conditions.HalflingLucky().String(),
+		CharacterID: "halfling-1",
+	})
+	s.Require().NoError(err)
+
+	data, err := output.Condition.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := LoadJSON(data)
+	s.Require().NoError(err)
+
+	lucky, ok := loaded.(*HalflingLuckyCondition)
+	s.Require().True(ok)
+	s.Equal("halfling-1", lucky.CharacterID)
+}
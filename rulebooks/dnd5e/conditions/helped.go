@@ -0,0 +1,228 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// HelpedConditionData is the serializable form of the helped condition.
+type HelpedConditionData struct {
+	Ref         *core.Ref `json:"ref"`
+	CharacterID string    `json:"character_id"`
+	HelperID    string    `json:"helper_id"`
+	AgainstID   string    `json:"against_id,omitempty"`
+}
+
+// HelpedCondition represents an ally aided by the Help action (PHB p.192).
+// Helping with a task gives advantage on the ally's next ability check;
+// helping against a foe gives advantage on the ally's next attack roll against
+// that foe. Either way the help is used up by the first roll it applies to,
+// and is lost at the start of the helper's next turn.
+type HelpedCondition struct {
+	CharacterID     string // ID of the ally being helped
+	HelperID        string // ID of the creature that took the Help action
+	AgainstID       string // ID of the foe the attack is helped against; empty for a task
+	bus             events.EventBus
+	subscriptionIDs []string
+}
+
+// Ensure HelpedCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*HelpedCondition)(nil)
+
+// HelpedInput provides configuration for creating a helped condition
+type HelpedInput struct {
+	CharacterID string // ID of the ally being helped
+	HelperID    string // ID of the helper
+	AgainstID   string // ID of the foe to help against; empty to help with a task
+}
+
+// NewHelpedCondition creates a helped condition from input
+func NewHelpedCondition(input HelpedInput) *HelpedCondition {
+	return &HelpedCondition{
+		CharacterID: input.CharacterID,
+		HelperID:    input.HelperID,
+		AgainstID:   input.AgainstID,
+	}
+}
+
+// IsApplied returns true if this condition is currently applied.
+func (h *HelpedCondition) IsApplied() bool {
+	return h.bus != nil
+}
+
+// Apply subscribes this condition to AttackChain, AbilityCheckChain, and TurnStart events.
+func (h *HelpedCondition) Apply(ctx context.Context, bus events.EventBus) error {
+	if h.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "helped condition already applied")
+	}
+	h.bus = bus
+
+	attackChain := dnd5eEvents.AttackChain.On(bus)
+	subID1, err := attackChain.SubscribeWithChain(ctx, h.onAttackChain)
+	if err != nil {
+		h.bus = nil
+		return rpgerr.Wrap(err, "failed to subscribe to attack chain")
+	}
+	h.subscriptionIDs = append(h.subscriptionIDs, subID1)
+
+	checkChain := dnd5eEvents.AbilityCheckChain.On(bus)
+	subID2, err := checkChain.SubscribeWithChain(ctx, h.onAbilityCheckChain)
+	if err != nil {
+		_ = h.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to ability check chain")
+	}
+	h.subscriptionIDs = append(h.subscriptionIDs, subID2)
+
+	turnStartTopic := dnd5eEvents.TurnStartTopic.On(bus)
+	subID3, err := turnStartTopic.Subscribe(ctx, h.onTurnStart)
+	if err != nil {
+		_ = h.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to turn start topic")
+	}
+	h.subscriptionIDs = append(h.subscriptionIDs, subID3)
+
+	return nil
+}
+
+// Remove unsubscribes this condition from all events.
+func (h *HelpedCondition) Remove(ctx context.Context, bus events.EventBus) error {
+	if h.bus == nil {
+		return nil // Not applied, nothing to remove
+	}
+
+	total := len(h.subscriptionIDs)
+	var errs []error
+	for _, subID := range h.subscriptionIDs {
+		if err := bus.Unsubscribe(ctx, subID); err != nil {
+			errs = append(errs, fmt.Errorf("unsubscribe %s: %w", subID, err))
+		}
+	}
+
+	h.subscriptionIDs = nil
+	h.bus = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unsubscribe %d/%d subscriptions: %w", len(errs), total, errors.Join(errs...))
+	}
+	return nil
+}
+
+// ToJSON converts the condition to JSON for persistence.
+func (h *HelpedCondition) ToJSON() (json.RawMessage, error) {
+	data := HelpedConditionData{
+		Ref:         refs.Conditions.Helped(),
+		CharacterID: h.CharacterID,
+		HelperID:    h.HelperID,
+		AgainstID:   h.AgainstID,
+	}
+	return json.Marshal(data)
+}
+
+// loadJSON loads helped condition state from JSON.
+func (h *HelpedCondition) loadJSON(data json.RawMessage) error {
+	var helpedData HelpedConditionData
+	if err := json.Unmarshal(data, &helpedData); err != nil {
+		return rpgerr.Wrap(err, "failed to unmarshal helped data")
+	}
+
+	h.CharacterID = helpedData.CharacterID
+	h.HelperID = helpedData.HelperID
+	h.AgainstID = helpedData.AgainstID
+	return nil
+}
+
+// onAttackChain grants advantage on the ally's attack against the helped-against foe.
+func (h *HelpedCondition) onAttackChain(
+	ctx context.Context,
+	event dnd5eEvents.AttackChainEvent,
+	c chain.Chain[dnd5eEvents.AttackChainEvent],
+) (chain.Chain[dnd5eEvents.AttackChainEvent], error) {
+	if h.AgainstID == "" || event.AttackerID != h.CharacterID || event.TargetID != h.AgainstID {
+		return c, nil
+	}
+
+	modifyAttack := func(_ context.Context, e dnd5eEvents.AttackChainEvent) (dnd5eEvents.AttackChainEvent, error) {
+		e.AdvantageSources = append(e.AdvantageSources, dnd5eEvents.AttackModifierSource{
+			SourceRef: refs.Conditions.Helped(),
+			SourceID:  h.HelperID,
+			Reason:    "Helped",
+		})
+		return e, nil
+	}
+
+	if err := c.Add(combat.StageConditions, "helped_advantage", modifyAttack); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to add helped advantage for character %s", h.CharacterID)
+	}
+
+	return c, h.end(ctx, "used")
+}
+
+// onAbilityCheckChain grants advantage on the ally's next ability check when helping with a task.
+func (h *HelpedCondition) onAbilityCheckChain(
+	ctx context.Context,
+	event *dnd5eEvents.AbilityCheckChainEvent,
+	c chain.Chain[*dnd5eEvents.AbilityCheckChainEvent],
+) (chain.Chain[*dnd5eEvents.AbilityCheckChainEvent], error) {
+	if h.AgainstID != "" || event.CheckerID != h.CharacterID {
+		return c, nil
+	}
+
+	modifyCheck := func(
+		_ context.Context,
+		e *dnd5eEvents.AbilityCheckChainEvent,
+	) (*dnd5eEvents.AbilityCheckChainEvent, error) {
+		e.AdvantageSources = append(e.AdvantageSources, dnd5eEvents.CheckModifierSource{
+			Name:       "Helped",
+			SourceType: "condition",
+			SourceRef:  refs.Conditions.Helped(),
+			EntityID:   h.HelperID,
+		})
+		return e, nil
+	}
+
+	if err := c.Add(combat.StageConditions, "helped_advantage", modifyCheck); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to add helped advantage for character %s", h.CharacterID)
+	}
+
+	return c, h.end(ctx, "used")
+}
+
+// onTurnStart ends the help, unused, at the start of the helper's next turn.
+func (h *HelpedCondition) onTurnStart(ctx context.Context, event dnd5eEvents.TurnStartEvent) error {
+	if event.CharacterID != h.HelperID {
+		return nil
+	}
+	return h.end(ctx, "turn_start")
+}
+
+// end publishes the removal event and unsubscribes from all events
+func (h *HelpedCondition) end(ctx context.Context, reason string) error {
+	if h.bus == nil {
+		return nil
+	}
+
+	removals := dnd5eEvents.ConditionRemovedTopic.On(h.bus)
+	err := removals.Publish(ctx, dnd5eEvents.ConditionRemovedEvent{
+		CharacterID:  h.CharacterID,
+		ConditionRef: refs.Conditions.Helped().String(),
+		Reason:       reason,
+	})
+	if err != nil {
+		return rpgerr.Wrapf(err, "failed to publish helped removal for character %s", h.CharacterID)
+	}
+
+	return h.Remove(ctx, h.bus)
+}
@@ -0,0 +1,136 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
+	"github.com/stretchr/testify/suite"
+)
+
+type HelpedConditionTestSuite struct {
+	suite.Suite
+	ctx context.Context
+	bus events.EventBus
+}
+
+func TestHelpedConditionSuite(t *testing.T) {
+	suite.Run(t, new(HelpedConditionTestSuite))
+}
+
+func (s *HelpedConditionTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+}
+
+func (s *HelpedConditionTestSuite) SetupSubTest() {
+	s.bus = events.NewEventBus()
+}
+
+func (s *HelpedConditionTestSuite) attack(attackerID, targetID string) dnd5eEvents.AttackChainEvent {
+	attackEvent := dnd5eEvents.AttackChainEvent{AttackerID: attackerID, TargetID: targetID, IsMelee: true}
+	attackChain := events.NewStagedChain[dnd5eEvents.AttackChainEvent](combat.ModifierStages)
+	modifiedChain, err := dnd5eEvents.AttackChain.On(s.bus).PublishWithChain(s.ctx, attackEvent, attackChain)
+	s.Require().NoError(err)
+	finalEvent, err := modifiedChain.Execute(s.ctx, attackEvent)
+	s.Require().NoError(err)
+	return finalEvent
+}
+
+func (s *HelpedConditionTestSuite) check(checkerID string) *dnd5eEvents.AbilityCheckChainEvent {
+	checkEvent := &dnd5eEvents.AbilityCheckChainEvent{CheckerID: checkerID, Skill: string(skills.Athletics)}
+	checkChain := events.NewStagedChain[*dnd5eEvents.AbilityCheckChainEvent](combat.ModifierStages)
+	modifiedChain, err := dnd5eEvents.AbilityCheckChain.On(s.bus).PublishWithChain(s.ctx, checkEvent, checkChain)
+	s.Require().NoError(err)
+	finalEvent, err := modifiedChain.Execute(s.ctx, checkEvent)
+	s.Require().NoError(err)
+	return finalEvent
+}
+
+func (s *HelpedConditionTestSuite) TestAttackHelp() {
+	s.Run("grants advantage on the first attack against the foe", func() {
+		condition := NewHelpedCondition(HelpedInput{CharacterID: "ally", HelperID: "helper", AgainstID: "orc"})
+		s.Require().NoError(condition.Apply(s.ctx, s.bus))
+
+		first := s.attack("ally", "orc")
+		s.Require().Len(first.AdvantageSources, 1)
+		s.Equal(refs.Conditions.Helped(), first.AdvantageSources[0].SourceRef)
+		s.Equal("helper", first.AdvantageSources[0].SourceID)
+		s.False(condition.IsApplied(), "the help is used up")
+
+		second := s.attack("ally", "orc")
+		s.Empty(second.AdvantageSources)
+	})
+
+	s.Run("ignores attacks against other creatures and other attackers", func() {
+		condition := NewHelpedCondition(HelpedInput{CharacterID: "ally", HelperID: "helper", AgainstID: "orc"})
+		s.Require().NoError(condition.Apply(s.ctx, s.bus))
+
+		s.Empty(s.attack("ally", "goblin").AdvantageSources)
+		s.Empty(s.attack("stranger", "orc").AdvantageSources)
+		s.Empty(s.check("ally").AdvantageSources, "attack help doesn't aid checks")
+		s.True(condition.IsApplied())
+	})
+}
+
+func (s *HelpedConditionTestSuite) TestTaskHelp() {
+	s.Run("grants advantage on the next ability check", func() {
+		condition := NewHelpedCondition(HelpedInput{CharacterID: "ally", HelperID: "helper"})
+		s.Require().NoError(condition.Apply(s.ctx, s.bus))
+
+		s.Empty(s.attack("ally", "orc").AdvantageSources, "task help doesn't aid attacks")
+
+		first := s.check("ally")
+		s.Require().Len(first.AdvantageSources, 1)
+		s.Equal("Helped", first.AdvantageSources[0].Name)
+		s.False(condition.IsApplied())
+
+		s.Empty(s.check("ally").AdvantageSources)
+	})
+}
+
+func (s *HelpedConditionTestSuite) TestExpiresAtHelpersTurnStart() {
+	s.Run("lost unused at the start of the helper's turn", func() {
+		condition := NewHelpedCondition(HelpedInput{CharacterID: "ally", HelperID: "helper", AgainstID: "orc"})
+		s.Require().NoError(condition.Apply(s.ctx, s.bus))
+
+		var removed []dnd5eEvents.ConditionRemovedEvent
+		_, err := dnd5eEvents.ConditionRemovedTopic.On(s.bus).Subscribe(s.ctx,
+			func(_ context.Context, e dnd5eEvents.ConditionRemovedEvent) error {
+				removed = append(removed, e)
+				return nil
+			})
+		s.Require().NoError(err)
+
+		turns := dnd5eEvents.TurnStartTopic.On(s.bus)
+		s.Require().NoError(turns.Publish(s.ctx, dnd5eEvents.TurnStartEvent{CharacterID: "ally"}))
+		s.True(condition.IsApplied(), "the ally's own turn doesn't end the help")
+
+		s.Require().NoError(turns.Publish(s.ctx, dnd5eEvents.TurnStartEvent{CharacterID: "helper"}))
+		s.False(condition.IsApplied())
+		s.Require().Len(removed, 1)
+		s.Equal("ally", removed[0].CharacterID)
+		s.Equal("turn_start", removed[0].Reason)
+	})
+}
+
+func (s *HelpedConditionTestSuite) TestPersistence() {
+	condition := NewHelpedCondition(HelpedInput{CharacterID: "ally", HelperID: "helper", AgainstID: "orc"})
+	data, err := condition.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := LoadJSON(data)
+	s.Require().NoError(err)
+	helped, ok := loaded.(*HelpedCondition)
+	s.Require().True(ok)
+	s.Equal("ally", helped.CharacterID)
+	s.Equal("helper", helped.HelperID)
+	s.Equal("orc", helped.AgainstID)
+}
@@ -0,0 +1,299 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/dice"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/saves"
+)
+
+// HoldPersonDurationTurns is how long Hold Person lasts: 1 minute (10 rounds)
+const HoldPersonDurationTurns = 10
+
+// Reasons Hold Person can end besides its duration
+const (
+	HoldPersonEndedSaved         = "saved"
+	HoldPersonEndedConcentration = "concentration_ended"
+)
+
+// HoldPersonData is the JSON structure for persisting hold person condition state
+type HoldPersonData struct {
+	Ref          *core.Ref `json:"ref"`
+	CharacterID  string    `json:"character_id"`
+	CasterID     string    `json:"caster_id"`
+	DC           int       `json:"dc"`
+	SaveModifier int       `json:"save_modifier"`
+	TurnsActive  int       `json:"turns_active"`
+}
+
+// HoldPersonCondition represents a humanoid paralyzed by the Hold Person spell.
+//
+// While applied, attacks against the creature have advantage, and any hit from
+// within 5 feet is a critical hit. The creature automatically fails STR and DEX
+// saves and can't act, react, or move (see combat.ConditionEconomyRestriction).
+// At the end of each of its turns it repeats the WIS save, ending the condition
+// on a success. It also ends when the caster's concentration on the spell ends,
+// or after 1 minute.
+type HoldPersonCondition struct {
+	CharacterID     string      // The paralyzed creature
+	CasterID        string      // The creature concentrating on Hold Person
+	DC              int         // Caster's spell save DC
+	SaveModifier    int         // Creature's WIS saving throw modifier
+	TurnsActive     int         // Turns the creature has ended while held
+	Roller          dice.Roller // Optional roller for the repeated saves
+	bus             events.EventBus
+	subscriptionIDs []string
+}
+
+// Ensure HoldPersonCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*HoldPersonCondition)(nil)
+
+// HoldPersonInput provides configuration for creating a hold person condition
+type HoldPersonInput struct {
+	CharacterID  string      // ID of the paralyzed creature
+	CasterID     string      // ID of the caster
+	DC           int         // Caster's spell save DC
+	SaveModifier int         // Creature's WIS saving throw modifier
+	Roller       dice.Roller // Optional roller for the repeated saves
+}
+
+// NewHoldPersonCondition creates a hold person condition from input
+func NewHoldPersonCondition(input HoldPersonInput) *HoldPersonCondition {
+	return &HoldPersonCondition{
+		CharacterID:  input.CharacterID,
+		CasterID:     input.CasterID,
+		DC:           input.DC,
+		SaveModifier: input.SaveModifier,
+		Roller:       input.Roller,
+	}
+}
+
+// IsApplied returns true if this condition is currently applied
+func (h *HoldPersonCondition) IsApplied() bool {
+	return h.bus != nil
+}
+
+// Apply subscribes this condition to the attack and saving throw chains, turn
+// end, and concentration ended events
+func (h *HoldPersonCondition) Apply(ctx context.Context, bus events.EventBus) error {
+	if h.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "hold person condition already applied")
+	}
+	h.bus = bus
+
+	attackSubID, err := dnd5eEvents.AttackChain.On(bus).SubscribeWithChain(ctx, h.onAttackChain)
+	if err != nil {
+		h.bus = nil
+		return rpgerr.Wrap(err, "failed to subscribe to attack chain")
+	}
+	h.subscriptionIDs = append(h.subscriptionIDs, attackSubID)
+
+	saveSubID, err := dnd5eEvents.SavingThrowChain.On(bus).SubscribeWithChain(ctx, h.onSavingThrowChain)
+	if err != nil {
+		_ = h.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to saving throw chain")
+	}
+	h.subscriptionIDs = append(h.subscriptionIDs, saveSubID)
+
+	turnSubID, err := dnd5eEvents.TurnEndTopic.On(bus).Subscribe(ctx, h.onTurnEnd)
+	if err != nil {
+		_ = h.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to turn end")
+	}
+	h.subscriptionIDs = append(h.subscriptionIDs, turnSubID)
+
+	concentrationSubID, err := dnd5eEvents.ConcentrationEndedTopic.On(bus).Subscribe(ctx, h.onConcentrationEnded)
+	if err != nil {
+		_ = h.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to concentration ended")
+	}
+	h.subscriptionIDs = append(h.subscriptionIDs, concentrationSubID)
+
+	return nil
+}
+
+// Remove unsubscribes this condition from all events
+func (h *HoldPersonCondition) Remove(ctx context.Context, bus events.EventBus) error {
+	if h.bus == nil {
+		return nil // Not applied, nothing to remove
+	}
+
+	total := len(h.subscriptionIDs)
+	var errs []error
+	for _, subID := range h.subscriptionIDs {
+		if err := bus.Unsubscribe(ctx, subID); err != nil {
+			errs = append(errs, fmt.Errorf("unsubscribe %s: %w", subID, err))
+		}
+	}
+
+	h.subscriptionIDs = nil
+	h.bus = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unsubscribe %d/%d subscriptions: %w", len(errs), total, errors.Join(errs...))
+	}
+	return nil
+}
+
+// ToJSON converts the condition to JSON for persistence
+func (h *HoldPersonCondition) ToJSON() (json.RawMessage, error) {
+	data := HoldPersonData{
+		Ref:          refs.Conditions.HoldPerson(),
+		CharacterID:  h.CharacterID,
+		CasterID:     h.CasterID,
+		DC:           h.DC,
+		SaveModifier: h.SaveModifier,
+		TurnsActive:  h.TurnsActive,
+	}
+	return json.Marshal(data)
+}
+
+// loadJSON loads hold person condition state from JSON
+func (h *HoldPersonCondition) loadJSON(data json.RawMessage) error {
+	var holdData HoldPersonData
+	if err := json.Unmarshal(data, &holdData); err != nil {
+		return rpgerr.Wrap(err, "failed to unmarshal hold person data")
+	}
+
+	h.CharacterID = holdData.CharacterID
+	h.CasterID = holdData.CasterID
+	h.DC = holdData.DC
+	h.SaveModifier = holdData.SaveModifier
+	h.TurnsActive = holdData.TurnsActive
+	return nil
+}
+
+// onAttackChain grants advantage on attacks against the paralyzed creature and
+// makes hits from within 5 feet critical
+func (h *HoldPersonCondition) onAttackChain(
+	ctx context.Context,
+	event dnd5eEvents.AttackChainEvent,
+	c chain.Chain[dnd5eEvents.AttackChainEvent],
+) (chain.Chain[dnd5eEvents.AttackChainEvent], error) {
+	if event.TargetID != h.CharacterID {
+		return c, nil
+	}
+
+	// Without positions, a melee attack is assumed to be within 5 feet
+	withinFive := event.IsMelee
+	if distance, ok := combat.DistanceFeet(ctx, event.AttackerID, event.TargetID); ok {
+		withinFive = distance <= 5
+	}
+
+	modifyAttack := func(_ context.Context, e dnd5eEvents.AttackChainEvent) (dnd5eEvents.AttackChainEvent, error) {
+		e.AdvantageSources = append(e.AdvantageSources, dnd5eEvents.AttackModifierSource{
+			SourceRef: refs.Conditions.HoldPerson(),
+			SourceID:  h.CharacterID,
+			Reason:    "Paralyzed",
+		})
+		if withinFive {
+			e.CriticalThreshold = 1
+		}
+		return e, nil
+	}
+	if err := c.Add(combat.StageConditions, "hold_person_target", modifyAttack); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to add hold person modifier for character %s", h.CharacterID)
+	}
+
+	return c, nil
+}
+
+// onSavingThrowChain makes the paralyzed creature fail STR and DEX saves
+func (h *HoldPersonCondition) onSavingThrowChain(
+	_ context.Context,
+	event *dnd5eEvents.SavingThrowChainEvent,
+	c chain.Chain[*dnd5eEvents.SavingThrowChainEvent],
+) (chain.Chain[*dnd5eEvents.SavingThrowChainEvent], error) {
+	if event.SaverID != h.CharacterID || (event.Ability != abilities.STR && event.Ability != abilities.DEX) {
+		return c, nil
+	}
+
+	modifySave := func(_ context.Context, e *dnd5eEvents.SavingThrowChainEvent) (*dnd5eEvents.SavingThrowChainEvent, error) {
+		e.AutoFailSources = append(e.AutoFailSources, dnd5eEvents.SaveModifierSource{
+			Name:       "Paralyzed",
+			SourceType: "condition",
+			SourceRef:  refs.Conditions.HoldPerson(),
+			EntityID:   h.CharacterID,
+		})
+		return e, nil
+	}
+	if err := c.Add(combat.StageConditions, "hold_person_auto_fail", modifySave); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to add hold person save modifier for character %s", h.CharacterID)
+	}
+
+	return c, nil
+}
+
+// onTurnEnd repeats the WIS save at the end of each of the creature's turns
+func (h *HoldPersonCondition) onTurnEnd(ctx context.Context, event dnd5eEvents.TurnEndEvent) error {
+	if event.CharacterID != h.CharacterID {
+		return nil
+	}
+
+	result, err := saves.MakeSavingThrow(ctx, &saves.SavingThrowInput{
+		Roller:   h.Roller,
+		EventBus: h.bus,
+		SaverID:  h.CharacterID,
+		Cause: dnd5eEvents.SaveCause{
+			Trigger:      dnd5eEvents.SaveTriggerSpell,
+			EffectRef:    refs.Spells.HoldPerson(),
+			InstigatorID: h.CasterID,
+			Conditions:   []*core.Ref{refs.Conditions.Paralyzed()},
+		},
+		Ability:  abilities.WIS,
+		DC:       h.DC,
+		Modifier: h.SaveModifier,
+	})
+	if err != nil {
+		return rpgerr.Wrapf(err, "failed to repeat hold person save for %s", h.CharacterID)
+	}
+	if result.Success {
+		return h.end(ctx, HoldPersonEndedSaved)
+	}
+
+	h.TurnsActive++
+	if h.TurnsActive >= HoldPersonDurationTurns {
+		return h.end(ctx, "duration_expired")
+	}
+	return nil
+}
+
+// onConcentrationEnded ends the condition when the caster stops concentrating on the spell
+func (h *HoldPersonCondition) onConcentrationEnded(ctx context.Context, event dnd5eEvents.ConcentrationEndedEvent) error {
+	if event.CasterID != h.CasterID || event.SpellID != refs.Spells.HoldPerson().ID {
+		return nil
+	}
+	return h.end(ctx, HoldPersonEndedConcentration)
+}
+
+// end publishes the removal event and unsubscribes from all events
+func (h *HoldPersonCondition) end(ctx context.Context, reason string) error {
+	if h.bus == nil {
+		return nil
+	}
+
+	err := dnd5eEvents.ConditionRemovedTopic.On(h.bus).Publish(ctx, dnd5eEvents.ConditionRemovedEvent{
+		CharacterID:  h.CharacterID,
+		ConditionRef: refs.Conditions.HoldPerson().String(),
+		Reason:       reason,
+	})
+	if err != nil {
+		return rpgerr.Wrapf(err, "error publishing hold person removal for %s", h.CharacterID)
+	}
+
+	return h.Remove(ctx, h.bus)
+}
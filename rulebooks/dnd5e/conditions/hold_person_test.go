@@ -0,0 +1,156 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+type HoldPersonConditionTestSuite struct {
+	suite.Suite
+	ctrl      *gomock.Controller
+	ctx       context.Context
+	bus       events.EventBus
+	roller    *mock_dice.MockRoller
+	condition *HoldPersonCondition
+	removed   []dnd5eEvents.ConditionRemovedEvent
+}
+
+func TestHoldPersonConditionSuite(t *testing.T) {
+	suite.Run(t, new(HoldPersonConditionTestSuite))
+}
+
+func (s *HoldPersonConditionTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.roller = mock_dice.NewMockRoller(s.ctrl)
+	s.condition = NewHoldPersonCondition(HoldPersonInput{
+		CharacterID:  "bandit",
+		CasterID:     "cleric",
+		DC:           13,
+		SaveModifier: 0,
+		Roller:       s.roller,
+	})
+	s.Require().NoError(s.condition.Apply(s.ctx, s.bus))
+
+	s.removed = nil
+	_, err := dnd5eEvents.ConditionRemovedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, e dnd5eEvents.ConditionRemovedEvent) error {
+			s.removed = append(s.removed, e)
+			return nil
+		})
+	s.Require().NoError(err)
+}
+
+func (s *HoldPersonConditionTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *HoldPersonConditionTestSuite) attack(targetID string, melee bool) dnd5eEvents.AttackChainEvent {
+	event := dnd5eEvents.AttackChainEvent{
+		AttackerID:        "fighter",
+		TargetID:          targetID,
+		IsMelee:           melee,
+		CriticalThreshold: 20,
+	}
+	attackChain := events.NewStagedChain[dnd5eEvents.AttackChainEvent](combat.ModifierStages)
+	modifiedChain, err := dnd5eEvents.AttackChain.On(s.bus).PublishWithChain(s.ctx, event, attackChain)
+	s.Require().NoError(err)
+	final, err := modifiedChain.Execute(s.ctx, event)
+	s.Require().NoError(err)
+	return final
+}
+
+func (s *HoldPersonConditionTestSuite) save(ability abilities.Ability) *dnd5eEvents.SavingThrowChainEvent {
+	event := &dnd5eEvents.SavingThrowChainEvent{SaverID: "bandit", Ability: ability, DC: 12}
+	saveChain := events.NewStagedChain[*dnd5eEvents.SavingThrowChainEvent](combat.ModifierStages)
+	modifiedChain, err := dnd5eEvents.SavingThrowChain.On(s.bus).PublishWithChain(s.ctx, event, saveChain)
+	s.Require().NoError(err)
+	final, err := modifiedChain.Execute(s.ctx, event)
+	s.Require().NoError(err)
+	return final
+}
+
+func (s *HoldPersonConditionTestSuite) TestMeleeAttacksHaveAdvantageAndCrit() {
+	final := s.attack("bandit", true)
+	s.Require().Len(final.AdvantageSources, 1)
+	s.Equal(refs.Conditions.HoldPerson(), final.AdvantageSources[0].SourceRef)
+	s.Equal(1, final.CriticalThreshold)
+}
+
+func (s *HoldPersonConditionTestSuite) TestRangedAttacksHaveAdvantageOnly() {
+	final := s.attack("bandit", false)
+	s.Len(final.AdvantageSources, 1)
+	s.Equal(20, final.CriticalThreshold)
+}
+
+func (s *HoldPersonConditionTestSuite) TestOtherTargetsUnaffected() {
+	final := s.attack("someone-else", true)
+	s.Empty(final.AdvantageSources)
+	s.Equal(20, final.CriticalThreshold)
+}
+
+func (s *HoldPersonConditionTestSuite) TestStrengthAndDexteritySavesAutoFail() {
+	s.True(s.save(abilities.STR).AutoFails())
+	s.True(s.save(abilities.DEX).AutoFails())
+	s.False(s.save(abilities.WIS).AutoFails())
+}
+
+func (s *HoldPersonConditionTestSuite) TestRepeatSaveEndsOnSuccess() {
+	s.roller.EXPECT().Roll(gomock.Any(), 20).Return(8, nil)
+	s.Require().NoError(dnd5eEvents.TurnEndTopic.On(s.bus).Publish(s.ctx, dnd5eEvents.TurnEndEvent{CharacterID: "bandit"}))
+	s.True(s.condition.IsApplied())
+	s.Equal(1, s.condition.TurnsActive)
+
+	s.roller.EXPECT().Roll(gomock.Any(), 20).Return(13, nil)
+	s.Require().NoError(dnd5eEvents.TurnEndTopic.On(s.bus).Publish(s.ctx, dnd5eEvents.TurnEndEvent{CharacterID: "bandit"}))
+	s.False(s.condition.IsApplied())
+	s.Require().Len(s.removed, 1)
+	s.Equal(HoldPersonEndedSaved, s.removed[0].Reason)
+}
+
+func (s *HoldPersonConditionTestSuite) TestEndsWithCastersConcentration() {
+	err := dnd5eEvents.ConcentrationEndedTopic.On(s.bus).Publish(s.ctx, dnd5eEvents.ConcentrationEndedEvent{
+		CasterID: "other-caster",
+		SpellID:  refs.Spells.HoldPerson().ID,
+	})
+	s.Require().NoError(err)
+	s.True(s.condition.IsApplied())
+
+	err = dnd5eEvents.ConcentrationEndedTopic.On(s.bus).Publish(s.ctx, dnd5eEvents.ConcentrationEndedEvent{
+		CasterID: "cleric",
+		SpellID:  refs.Spells.HoldPerson().ID,
+	})
+	s.Require().NoError(err)
+	s.False(s.condition.IsApplied())
+	s.Require().Len(s.removed, 1)
+	s.Equal(HoldPersonEndedConcentration, s.removed[0].Reason)
+}
+
+func (s *HoldPersonConditionTestSuite) TestRoundTrip() {
+	s.condition.TurnsActive = 4
+	data, err := s.condition.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := LoadJSON(data)
+	s.Require().NoError(err)
+	held, ok := loaded.(*HoldPersonCondition)
+	s.Require().True(ok)
+	s.Equal("bandit", held.CharacterID)
+	s.Equal("cleric", held.CasterID)
+	s.Equal(13, held.DC)
+	s.Equal(4, held.TurnsActive)
+}
@@ -0,0 +1,28 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// IncapacitatedCondition represents a creature that can't take actions or reactions.
+//
+// While applied:
+//   - The action economy blocks its actions and reactions through the incapacitated ref
+type IncapacitatedCondition struct {
+	standardCondition
+}
+
+// Ensure IncapacitatedCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*IncapacitatedCondition)(nil)
+
+// NewIncapacitatedCondition creates a incapacitated condition from input
+func NewIncapacitatedCondition(input StandardConditionInput) *IncapacitatedCondition {
+	return &IncapacitatedCondition{newStandardCondition(input, standardEffects{
+		name: "Incapacitated",
+		ref:  refs.Conditions.Incapacitated(),
+	})}
+}
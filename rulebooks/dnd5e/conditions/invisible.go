@@ -0,0 +1,31 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// InvisibleCondition represents a creature that can't be seen without magic.
+//
+// While applied:
+//   - Attacks against the creature have disadvantage
+//   - The creature's attacks have advantage
+type InvisibleCondition struct {
+	standardCondition
+}
+
+// Ensure InvisibleCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*InvisibleCondition)(nil)
+
+// NewInvisibleCondition creates a invisible condition from input
+func NewInvisibleCondition(input StandardConditionInput) *InvisibleCondition {
+	return &InvisibleCondition{newStandardCondition(input, standardEffects{
+		name:                     "Invisible",
+		ref:                      refs.Conditions.Invisible(),
+		attackedWithDisadvantage: true,
+		attackAdvantage:          true,
+	})}
+}
@@ -0,0 +1,26 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/invocations"
+)
+
+// NewInvocationCondition creates the passive condition an Eldritch Invocation grants.
+// Each implemented invocation maps to its own dedicated condition type, applied
+// like a fighting style (ConditionAppliedEvent with dnd5eEvents.ConditionInvocation).
+func NewInvocationCondition(
+	invocation invocations.Invocation, characterID string,
+) (dnd5eEvents.ConditionBehavior, error) {
+	switch invocation {
+	case invocations.AgonizingBlast:
+		return NewAgonizingBlastCondition(characterID), nil
+	case invocations.DevilsSight:
+		return NewDevilsSightCondition(characterID), nil
+	default:
+		return nil, rpgerr.Newf(rpgerr.CodeInvalidArgument, "invocation %s has no condition", invocation)
+	}
+}
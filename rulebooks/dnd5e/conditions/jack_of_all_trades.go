@@ -0,0 +1,168 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// JackOfAllTradesData is the JSON structure for persisting jack of all trades condition state
+type JackOfAllTradesData struct {
+	Ref              *core.Ref `json:"ref"`
+	CharacterID      string    `json:"character_id"`
+	ProficiencyBonus int       `json:"proficiency_bonus"`
+}
+
+// JackOfAllTradesCondition represents the bard's Jack of All Trades feature (bard level 2).
+// It adds half the character's proficiency bonus, rounded down, to ability checks that
+// don't already include their proficiency bonus.
+//
+// A check counts as non-proficient when the caller reports a ProficiencyBonus of 0
+// on checks.AbilityCheckInput.
+type JackOfAllTradesCondition struct {
+	CharacterID      string
+	ProficiencyBonus int // The character's full proficiency bonus
+	subscriptionIDs  []string
+	bus              events.EventBus
+}
+
+// Ensure JackOfAllTradesCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*JackOfAllTradesCondition)(nil)
+
+// JackOfAllTradesInput provides configuration for creating a jack of all trades condition
+type JackOfAllTradesInput struct {
+	CharacterID      string // ID of the bard
+	ProficiencyBonus int    // The bard's proficiency bonus
+}
+
+// NewJackOfAllTradesCondition creates a jack of all trades condition from input
+func NewJackOfAllTradesCondition(input JackOfAllTradesInput) *JackOfAllTradesCondition {
+	return &JackOfAllTradesCondition{
+		CharacterID:      input.CharacterID,
+		ProficiencyBonus: input.ProficiencyBonus,
+	}
+}
+
+// IsApplied returns true if this condition is currently applied
+func (j *JackOfAllTradesCondition) IsApplied() bool {
+	return j.bus != nil
+}
+
+// Apply subscribes this condition to ability check chain events
+func (j *JackOfAllTradesCondition) Apply(ctx context.Context, bus events.EventBus) error {
+	if j.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "jack of all trades condition already applied")
+	}
+	j.bus = bus
+
+	checkChain := dnd5eEvents.AbilityCheckChain.On(bus)
+	subID, err := checkChain.SubscribeWithChain(ctx, j.onAbilityCheckChain)
+	if err != nil {
+		return rpgerr.Wrap(err, "failed to subscribe to ability check chain")
+	}
+	j.subscriptionIDs = append(j.subscriptionIDs, subID)
+
+	return nil
+}
+
+// Remove unsubscribes this condition from events
+func (j *JackOfAllTradesCondition) Remove(ctx context.Context, bus events.EventBus) error {
+	if j.bus == nil {
+		return nil
+	}
+
+	total := len(j.subscriptionIDs)
+	var errs []error
+	for _, subID := range j.subscriptionIDs {
+		if err := bus.Unsubscribe(ctx, subID); err != nil {
+			errs = append(errs, fmt.Errorf("unsubscribe %s: %w", subID, err))
+		}
+	}
+
+	j.subscriptionIDs = nil
+	j.bus = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unsubscribe %d/%d subscriptions: %w", len(errs), total, errors.Join(errs...))
+	}
+	return nil
+}
+
+// ToJSON converts the condition to JSON for persistence
+func (j *JackOfAllTradesCondition) ToJSON() (json.RawMessage, error) {
+	data := JackOfAllTradesData{
+		Ref:              refs.Conditions.JackOfAllTrades(),
+		CharacterID:      j.CharacterID,
+		ProficiencyBonus: j.ProficiencyBonus,
+	}
+
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to marshal jack of all trades data")
+	}
+
+	return bytes, nil
+}
+
+// loadJSON loads the condition from JSON
+func (j *JackOfAllTradesCondition) loadJSON(data json.RawMessage) error {
+	var jackData JackOfAllTradesData
+	if err := json.Unmarshal(data, &jackData); err != nil {
+		return rpgerr.Wrap(err, "failed to unmarshal jack of all trades data")
+	}
+
+	j.CharacterID = jackData.CharacterID
+	j.ProficiencyBonus = jackData.ProficiencyBonus
+	return nil
+}
+
+// onAbilityCheckChain adds half proficiency to this character's non-proficient checks
+func (j *JackOfAllTradesCondition) onAbilityCheckChain(
+	_ context.Context,
+	event *dnd5eEvents.AbilityCheckChainEvent,
+	c chain.Chain[*dnd5eEvents.AbilityCheckChainEvent],
+) (chain.Chain[*dnd5eEvents.AbilityCheckChainEvent], error) {
+	// Only modify this character's checks that don't already include proficiency
+	if event.CheckerID != j.CharacterID || event.ProficiencyBonus > 0 {
+		return c, nil
+	}
+
+	bonus := j.ProficiencyBonus / 2
+	if bonus <= 0 {
+		return c, nil
+	}
+
+	modifyCheck := func(
+		_ context.Context,
+		ev *dnd5eEvents.AbilityCheckChainEvent,
+	) (*dnd5eEvents.AbilityCheckChainEvent, error) {
+		ev.BonusSources = append(ev.BonusSources, dnd5eEvents.CheckBonusSource{
+			CheckModifierSource: dnd5eEvents.CheckModifierSource{
+				Name:       "Jack of All Trades",
+				SourceType: "feature",
+				SourceRef:  refs.Conditions.JackOfAllTrades(),
+				EntityID:   j.CharacterID,
+			},
+			Bonus: bonus,
+		})
+		return ev, nil
+	}
+
+	if err := c.Add(combat.StageFeatures, "jack_of_all_trades", modifyCheck); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to apply jack of all trades for character %s", j.CharacterID)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/checks"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
+)
+
+type JackOfAllTradesTestSuite struct {
+	suite.Suite
+	ctrl   *gomock.Controller
+	ctx    context.Context
+	bus    events.EventBus
+	roller *mock_dice.MockRoller
+}
+
+func TestJackOfAllTradesSuite(t *testing.T) {
+	suite.Run(t, new(JackOfAllTradesTestSuite))
+}
+
+func (s *JackOfAllTradesTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.roller = mock_dice.NewMockRoller(s.ctrl)
+}
+
+func (s *JackOfAllTradesTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// check makes a skill check with a +1 ability modifier plus any proficiency
+func (s *JackOfAllTradesTestSuite) check(checkerID string, skill skills.Skill, proficiencyBonus int) *checks.AbilityCheckResult {
+	s.roller.EXPECT().Roll(gomock.Any(), 20).Return(10, nil)
+
+	result, err := checks.MakeAbilityCheck(s.ctx, &checks.AbilityCheckInput{
+		Roller:           s.roller,
+		EventBus:         s.bus,
+		CheckerID:        checkerID,
+		Skill:            skill,
+		DC:               15,
+		Modifier:         1 + proficiencyBonus,
+		ProficiencyBonus: proficiencyBonus,
+	})
+	s.Require().NoError(err)
+	return result
+}
+
+func (s *JackOfAllTradesTestSuite) TestAddsHalfProficiencyToNonProficientChecks() {
+	jack := NewJackOfAllTradesCondition(JackOfAllTradesInput{
+		CharacterID:      "bard-1",
+		ProficiencyBonus: 3,
+	})
+	s.Require().NoError(jack.Apply(s.ctx, s.bus))
+
+	result := s.check("bard-1", skills.Athletics, 0)
+	s.Equal(12, result.Total, "10 + 1 STR + 1 (half of +3, rounded down)")
+	s.Require().Len(result.BonusSources, 1)
+	s.Equal(refs.Conditions.JackOfAllTrades(), result.BonusSources[0].SourceRef)
+
+	s.Equal(14, s.check("bard-1", skills.Performance, 3).Total, "proficient checks are unchanged")
+	s.Equal(11, s.check("rogue-1", skills.Athletics, 0).Total, "other characters' checks")
+
+	s.Require().NoError(jack.Remove(s.ctx, s.bus))
+	s.Equal(11, s.check("bard-1", skills.Athletics, 0).Total, "removed")
+}
+
+func (s *JackOfAllTradesTestSuite) TestFactoryAndRoundTrip() {
+	output, err := CreateFromRef(&CreateFromRefInput{
+		Ref:         refs.Conditions.JackOfAllTrades().String(),
+		Config:      json.RawMessage(`{"proficiency_bonus": 4}`),
+		CharacterID: "bard-1",
+	})
+	s.Require().NoError(err)
+
+	data, err := output.Condition.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := LoadJSON(data)
+	s.Require().NoError(err)
+
+	jack, ok := loaded.(*JackOfAllTradesCondition)
+	s.Require().True(ok)
+	s.Equal("bard-1", jack.CharacterID)
+	s.Equal(4, jack.ProficiencyBonus)
+}
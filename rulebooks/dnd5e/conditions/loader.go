@@ -7,15 +7,34 @@ import (
 	"encoding/json"
 
 	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/schema"
 	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
 	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
-	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
 )
 
+// DataKind identifies condition JSON in a schema.Envelope
+const DataKind = "dnd5e.condition"
+
+// DataVersion is the current version of condition JSON. Bump it and register a
+// schema migration from the previous version when the shape changes.
+const DataVersion = 1
+
+func init() {
+	schema.MustRegister(DataKind, DataVersion)
+}
+
 // LoadJSON loads a condition from its JSON representation.
 // The game server stores conditions as opaque JSON blobs;
-// this function deserializes them into strongly-typed structs.
+// this function deserializes them into strongly-typed structs using the
+// loader registered for the condition's ref (see Register).
+// data may be the condition's ToJSON output or a schema.Envelope of DataKind,
+// which is migrated to DataVersion first.
 func LoadJSON(data json.RawMessage) (dnd5eEvents.ConditionBehavior, error) {
+	data, err := migrateData(data)
+	if err != nil {
+		return nil, err
+	}
+
 	// Peek at the ref to determine condition type
 	var peek struct {
 		Ref core.Ref `json:"ref"`
@@ -26,141 +45,29 @@ func LoadJSON(data json.RawMessage) (dnd5eEvents.ConditionBehavior, error) {
 	}
 
 	// Route based on ref ID
-	switch peek.Ref.ID {
-	case refs.Conditions.Raging().ID:
-		raging := &RagingCondition{}
-		if err := raging.loadJSON(data); err != nil {
-			return nil, rpgerr.Wrap(err, "failed to load raging condition")
-		}
-		return raging, nil
-
-	case refs.Conditions.BrutalCritical().ID:
-		brutal := &BrutalCriticalCondition{}
-		if err := brutal.loadJSON(data); err != nil {
-			return nil, rpgerr.Wrap(err, "failed to load brutal critical condition")
-		}
-		return brutal, nil
-
-	case refs.Conditions.UnarmoredDefense().ID:
-		unarmored := &UnarmoredDefenseCondition{}
-		if err := unarmored.loadJSON(data); err != nil {
-			return nil, rpgerr.Wrap(err, "failed to load unarmored defense condition")
-		}
-		return unarmored, nil
-
-	case refs.Conditions.FightingStyleArchery().ID:
-		archery := NewFightingStyleArcheryCondition("")
-		if err := archery.loadJSON(data); err != nil {
-			return nil, rpgerr.Wrap(err, "failed to load archery fighting style condition")
-		}
-		return archery, nil
-
-	case refs.Conditions.FightingStyleDefense().ID:
-		defense := NewFightingStyleDefenseCondition("")
-		if err := defense.loadJSON(data); err != nil {
-			return nil, rpgerr.Wrap(err, "failed to load defense fighting style condition")
-		}
-		return defense, nil
-
-	case refs.Conditions.FightingStyleDueling().ID:
-		dueling := NewFightingStyleDuelingCondition("")
-		if err := dueling.loadJSON(data); err != nil {
-			return nil, rpgerr.Wrap(err, "failed to load dueling fighting style condition")
-		}
-		return dueling, nil
-
-	case refs.Conditions.FightingStyleGreatWeaponFighting().ID:
-		gwf := NewFightingStyleGreatWeaponFightingCondition("", nil)
-		if err := gwf.loadJSON(data); err != nil {
-			return nil, rpgerr.Wrap(err, "failed to load great weapon fighting style condition")
-		}
-		return gwf, nil
-
-	case refs.Conditions.FightingStyleProtection().ID:
-		protection := NewFightingStyleProtectionCondition("")
-		if err := protection.loadJSON(data); err != nil {
-			return nil, rpgerr.Wrap(err, "failed to load protection fighting style condition")
-		}
-		return protection, nil
-
-	case refs.Conditions.FightingStyleTwoWeaponFighting().ID:
-		twf := NewFightingStyleTwoWeaponFightingCondition("")
-		if err := twf.loadJSON(data); err != nil {
-			return nil, rpgerr.Wrap(err, "failed to load two-weapon fighting style condition")
-		}
-		return twf, nil
-
-	case refs.Conditions.ImprovedCritical().ID:
-		ic := &ImprovedCriticalCondition{}
-		if err := ic.loadJSON(data); err != nil {
-			return nil, rpgerr.Wrap(err, "failed to load improved critical condition")
-		}
-		return ic, nil
-
-	case refs.Conditions.RecklessAttack().ID:
-		ra := &RecklessAttackCondition{}
-		if err := ra.loadJSON(data); err != nil {
-			return nil, rpgerr.Wrap(err, "failed to load reckless attack condition")
-		}
-		return ra, nil
-
-	case refs.Conditions.MartialArts().ID:
-		ma := &MartialArtsCondition{}
-		if err := ma.loadJSON(data); err != nil {
-			return nil, rpgerr.Wrap(err, "failed to load martial arts condition")
-		}
-		return ma, nil
-
-	case refs.Conditions.UnarmoredMovement().ID:
-		um := &UnarmoredMovementCondition{}
-		if err := um.loadJSON(data); err != nil {
-			return nil, rpgerr.Wrap(err, "failed to load unarmored movement condition")
-		}
-		return um, nil
-
-	case refs.Features.SneakAttack().ID:
-		sneak := &SneakAttackCondition{}
-		if err := sneak.loadJSON(data); err != nil {
-			return nil, rpgerr.Wrap(err, "failed to load sneak attack condition")
-		}
-		return sneak, nil
-
-	case refs.Conditions.Disengaging().ID:
-		disengaging := &DisengagingCondition{}
-		if err := disengaging.loadJSON(data); err != nil {
-			return nil, rpgerr.Wrap(err, "failed to load disengaging condition")
-		}
-		return disengaging, nil
-
-	case refs.Conditions.Dodging().ID:
-		dodging := &DodgingCondition{}
-		if err := dodging.loadJSON(data); err != nil {
-			return nil, rpgerr.Wrap(err, "failed to load dodging condition")
-		}
-		return dodging, nil
-
-	case refs.Conditions.Unconscious().ID:
-		uc := &UnconsciousCondition{}
-		if err := uc.loadJSON(data); err != nil {
-			return nil, rpgerr.Wrap(err, "failed to load unconscious condition")
-		}
-		return uc, nil
+	reg, ok := lookupID(peek.Ref.ID)
+	if !ok || reg.Load == nil {
+		return nil, rpgerr.Newf(rpgerr.CodeInvalidArgument, "unknown condition ref: %s", peek.Ref.ID)
+	}
 
-	case refs.Conditions.OpportunityAttack().ID:
-		oa := &OpportunityAttackCondition{}
-		if err := oa.loadJSON(data); err != nil {
-			return nil, rpgerr.Wrap(err, "failed to load opportunity attack condition")
-		}
-		return oa, nil
+	return reg.Load(data)
+}
 
-	case refs.Spells.Shield().ID:
-		sh := &ShieldSpellCondition{}
-		if err := sh.loadJSON(data); err != nil {
-			return nil, rpgerr.Wrap(err, "failed to load shield spell condition")
-		}
-		return sh, nil
+// migrateData unwraps a condition envelope and migrates it to DataVersion.
+// Bare condition JSON is returned as is.
+func migrateData(data json.RawMessage) (json.RawMessage, error) {
+	envelope, err := schema.Decode(data, DataKind)
+	if err != nil {
+		// Not JSON; peeking at the ref reports it
+		return data, nil
+	}
+	if envelope.Kind != DataKind {
+		return nil, rpgerr.Newf(rpgerr.CodeInvalidArgument, "not condition data: %s", envelope.Kind)
+	}
 
-	default:
-		return nil, rpgerr.Newf(rpgerr.CodeInvalidArgument, "unknown condition ref: %s", peek.Ref.ID)
+	envelope, err = schema.Migrate(envelope)
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to migrate condition data")
 	}
+	return envelope.Data, nil
 }
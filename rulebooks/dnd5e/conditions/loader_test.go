@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/suite"
 
+	"github.com/KirkDiggler/rpg-toolkit/core/schema"
 	"github.com/KirkDiggler/rpg-toolkit/events"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
@@ -183,6 +184,31 @@ func (s *LoaderTestSuite) TestLoadUnknownCondition() {
 	s.Contains(err.Error(), "unknown condition ref")
 }
 
+func (s *LoaderTestSuite) TestLoadVersionedEnvelope() {
+	original := NewBrutalCriticalCondition(BrutalCriticalInput{
+		CharacterID: "barbarian-1",
+		Level:       13,
+	})
+	jsonData, err := original.ToJSON()
+	s.Require().NoError(err)
+
+	enveloped, err := schema.Marshal(DataKind, jsonData)
+	s.Require().NoError(err)
+
+	loaded, err := LoadJSON(enveloped)
+	s.Require().NoError(err)
+	brutal, ok := loaded.(*BrutalCriticalCondition)
+	s.Require().True(ok, "Expected *BrutalCriticalCondition")
+	s.Equal(13, brutal.Level)
+
+	_, err = LoadJSON([]byte(`{"kind":"dnd5e.character","version":1,"data":{}}`))
+	s.Require().Error(err)
+	s.Contains(err.Error(), "not condition data")
+
+	_, err = LoadJSON([]byte(`{"kind":"dnd5e.condition","version":99,"data":{}}`))
+	s.Require().ErrorIs(err, schema.ErrNewerVersion)
+}
+
 func (s *LoaderTestSuite) TestLoadInvalidJSON() {
 	// Test loading invalid JSON
 	jsonData := []byte(`{invalid json}`)
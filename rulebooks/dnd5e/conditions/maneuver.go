@@ -0,0 +1,362 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/dice"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/maneuvers"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/saves"
+)
+
+// ManeuverData is the JSON structure for persisting a pending maneuver.
+// Trip Attack and Riposte are declared between ResolveAttackHit and
+// ApplyAttackOutcome, so they must survive the reload in between.
+type ManeuverData struct {
+	Ref         *core.Ref          `json:"ref"`
+	CharacterID string             `json:"character_id"`
+	TargetID    string             `json:"target_id,omitempty"`
+	Maneuver    maneuvers.Maneuver `json:"maneuver"`
+	DieSize     int                `json:"die_size"`
+	SaveDC      int                `json:"save_dc,omitempty"`
+}
+
+// ManeuverCondition represents a Battle Master maneuver declared for the next attack.
+// The superiority die is already spent; the condition triggers on the fighter's next
+// matching attack, then ends:
+//   - Precision Attack adds the die to the attack roll
+//   - Riposte adds the die to melee weapon damage against the target
+//   - Trip Attack adds the die to weapon damage against the target, and the target
+//     makes a Strength saving throw or is knocked prone
+//
+// An unused maneuver lapses when the turn ends.
+type ManeuverCondition struct {
+	CharacterID     string
+	TargetID        string             // Creature the maneuver is aimed at (optional for Precision Attack)
+	Maneuver        maneuvers.Maneuver // Which maneuver was declared
+	DieSize         int                // Size of the superiority die
+	SaveDC          int                // Maneuver save DC (8 + proficiency + STR or DEX modifier)
+	subscriptionIDs []string
+	bus             events.EventBus
+	roller          dice.Roller
+}
+
+// Ensure ManeuverCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*ManeuverCondition)(nil)
+
+// ManeuverInput provides configuration for creating a maneuver condition
+type ManeuverInput struct {
+	CharacterID string             // ID of the Battle Master
+	TargetID    string             // ID of the creature the maneuver is aimed at
+	Maneuver    maneuvers.Maneuver // Which maneuver was declared
+	DieSize     int                // Size of the superiority die
+	SaveDC      int                // Maneuver save DC for maneuvers that force a save
+	Roller      dice.Roller        // Dice roller for the superiority die and saves
+}
+
+// NewManeuverCondition creates a maneuver condition from input
+func NewManeuverCondition(input ManeuverInput) *ManeuverCondition {
+	return &ManeuverCondition{
+		CharacterID: input.CharacterID,
+		TargetID:    input.TargetID,
+		Maneuver:    input.Maneuver,
+		DieSize:     input.DieSize,
+		SaveDC:      input.SaveDC,
+		roller:      input.Roller,
+	}
+}
+
+// IsApplied returns true if this condition is currently applied
+func (m *ManeuverCondition) IsApplied() bool {
+	return m.bus != nil
+}
+
+// Apply subscribes this condition to the attack or damage chain and turn end events
+func (m *ManeuverCondition) Apply(ctx context.Context, bus events.EventBus) error {
+	if m.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "maneuver condition already applied")
+	}
+	m.bus = bus
+
+	var subID1 string
+	var err error
+	switch m.Maneuver {
+	case maneuvers.PrecisionAttack:
+		subID1, err = dnd5eEvents.AttackChain.On(bus).SubscribeWithChain(ctx, m.onAttackChain)
+	case maneuvers.Riposte, maneuvers.TripAttack:
+		subID1, err = dnd5eEvents.DamageChain.On(bus).SubscribeWithChain(ctx, m.onDamageChain)
+	default:
+		m.bus = nil
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument, "unknown maneuver: %s", m.Maneuver)
+	}
+	if err != nil {
+		m.bus = nil
+		return rpgerr.Wrapf(err, "failed to subscribe %s", m.Maneuver)
+	}
+	m.subscriptionIDs = append(m.subscriptionIDs, subID1)
+
+	turnEnds := dnd5eEvents.TurnEndTopic.On(bus)
+	subID2, err := turnEnds.Subscribe(ctx, m.onTurnEnd)
+	if err != nil {
+		_ = m.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to turn end")
+	}
+	m.subscriptionIDs = append(m.subscriptionIDs, subID2)
+
+	return nil
+}
+
+// Remove unsubscribes this condition from events
+func (m *ManeuverCondition) Remove(ctx context.Context, bus events.EventBus) error {
+	if m.bus == nil {
+		return nil
+	}
+
+	total := len(m.subscriptionIDs)
+	var errs []error
+	for _, subID := range m.subscriptionIDs {
+		if err := bus.Unsubscribe(ctx, subID); err != nil {
+			errs = append(errs, fmt.Errorf("unsubscribe %s: %w", subID, err))
+		}
+	}
+
+	m.subscriptionIDs = nil
+	m.bus = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unsubscribe %d/%d subscriptions: %w", len(errs), total, errors.Join(errs...))
+	}
+	return nil
+}
+
+// ToJSON converts the condition to JSON for persistence
+func (m *ManeuverCondition) ToJSON() (json.RawMessage, error) {
+	data := ManeuverData{
+		Ref:         refs.Conditions.Maneuver(),
+		CharacterID: m.CharacterID,
+		TargetID:    m.TargetID,
+		Maneuver:    m.Maneuver,
+		DieSize:     m.DieSize,
+		SaveDC:      m.SaveDC,
+	}
+
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to marshal maneuver data")
+	}
+
+	return bytes, nil
+}
+
+// loadJSON loads the condition from JSON
+func (m *ManeuverCondition) loadJSON(data json.RawMessage) error {
+	var maneuverData ManeuverData
+	if err := json.Unmarshal(data, &maneuverData); err != nil {
+		return rpgerr.Wrap(err, "failed to unmarshal maneuver data")
+	}
+
+	m.CharacterID = maneuverData.CharacterID
+	m.TargetID = maneuverData.TargetID
+	m.Maneuver = maneuverData.Maneuver
+	m.DieSize = maneuverData.DieSize
+	m.SaveDC = maneuverData.SaveDC
+	return nil
+}
+
+// onAttackChain adds the superiority die to the fighter's weapon attack roll (Precision Attack)
+func (m *ManeuverCondition) onAttackChain(
+	ctx context.Context,
+	event dnd5eEvents.AttackChainEvent,
+	c chain.Chain[dnd5eEvents.AttackChainEvent],
+) (chain.Chain[dnd5eEvents.AttackChainEvent], error) {
+	if event.AttackerID != m.CharacterID || event.WeaponRef == nil || isSpellRef(event.WeaponRef) {
+		return c, nil
+	}
+	if m.TargetID != "" && event.TargetID != m.TargetID {
+		return c, nil
+	}
+
+	rolls, err := m.rollDice(ctx, 1)
+	if err != nil {
+		return c, err
+	}
+
+	modifyAttack := func(_ context.Context, e dnd5eEvents.AttackChainEvent) (dnd5eEvents.AttackChainEvent, error) {
+		e.AttackBonus += rolls[0]
+		return e, nil
+	}
+
+	if err := c.Add(combat.StageFeatures, "precision_attack", modifyAttack); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to apply precision attack for character %s", m.CharacterID)
+	}
+
+	return c, m.end(ctx, "used")
+}
+
+// onDamageChain adds the superiority die to the fighter's weapon damage against the target
+// (Riposte and Trip Attack). A tripped target then saves or falls prone.
+func (m *ManeuverCondition) onDamageChain(
+	ctx context.Context,
+	event *dnd5eEvents.DamageChainEvent,
+	c chain.Chain[*dnd5eEvents.DamageChainEvent],
+) (chain.Chain[*dnd5eEvents.DamageChainEvent], error) {
+	if event.AttackerID != m.CharacterID || event.TargetID != m.TargetID || event.WeaponRef == nil ||
+		isSpellRef(event.WeaponRef) {
+		return c, nil
+	}
+	if m.Maneuver == maneuvers.Riposte && !isMeleeWeaponAttack(event) {
+		return c, nil
+	}
+
+	// The superiority die is doubled on a critical hit like any other damage die
+	count := 1
+	if event.IsCritical {
+		count = 2
+	}
+	rolls, err := m.rollDice(ctx, count)
+	if err != nil {
+		return c, err
+	}
+
+	modifyDamage := func(_ context.Context, e *dnd5eEvents.DamageChainEvent) (*dnd5eEvents.DamageChainEvent, error) {
+		e.Components = append(e.Components, dnd5eEvents.DamageComponent{
+			Source:            dnd5eEvents.DamageSourceFeature,
+			SourceRef:         refs.Features.CombatSuperiority(),
+			OriginalDiceRolls: rolls,
+			FinalDiceRolls:    rolls,
+			DamageType:        e.DamageType,
+			IsCritical:        e.IsCritical,
+		})
+		return e, nil
+	}
+
+	if err := c.Add(combat.StageFeatures, m.Maneuver, modifyDamage); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to apply %s for character %s", m.Maneuver, m.CharacterID)
+	}
+
+	if m.Maneuver == maneuvers.TripAttack {
+		if err := m.trip(ctx); err != nil {
+			return c, err
+		}
+	}
+
+	return c, m.end(ctx, "used")
+}
+
+// trip has the target make a Strength saving throw, knocking it prone on a failure.
+// Creature size isn't tracked, so the Large-or-smaller limit isn't enforced.
+func (m *ManeuverCondition) trip(ctx context.Context) error {
+	target, err := combat.GetCombatantFromContext(ctx, m.TargetID)
+	if err != nil {
+		return rpgerr.Wrapf(err, "failed to find trip attack target %s", m.TargetID)
+	}
+
+	modifier := target.AbilityScores().Modifier(abilities.STR)
+	if provider, ok := target.(combat.SaveModifierProvider); ok {
+		modifier = provider.GetSavingThrowModifier(abilities.STR)
+	}
+
+	result, err := saves.MakeSavingThrow(ctx, &saves.SavingThrowInput{
+		Roller:   m.roller,
+		EventBus: m.bus,
+		SaverID:  m.TargetID,
+		Cause: dnd5eEvents.SaveCause{
+			Trigger:      dnd5eEvents.SaveTriggerFeature,
+			EffectRef:    refs.Features.CombatSuperiority(),
+			InstigatorID: m.CharacterID,
+		},
+		Ability:  abilities.STR,
+		DC:       m.SaveDC,
+		Modifier: modifier,
+	})
+	if err != nil {
+		return rpgerr.Wrapf(err, "failed to roll trip attack save for %s", m.TargetID)
+	}
+	if result.Success {
+		return nil
+	}
+
+	return m.knockProne(ctx, target)
+}
+
+// knockProne applies the Prone condition to the tripped target.
+// Monsters hold their own conditions; characters pick it up from the applied event.
+func (m *ManeuverCondition) knockProne(ctx context.Context, target combat.Combatant) error {
+	prone := NewProneCondition(m.TargetID)
+
+	if holder, ok := target.(interface {
+		AddCondition(condition dnd5eEvents.ConditionBehavior)
+	}); ok {
+		if err := prone.Apply(ctx, m.bus); err != nil {
+			return rpgerr.Wrapf(err, "failed to knock %s prone", m.TargetID)
+		}
+		holder.AddCondition(prone)
+		return nil
+	}
+
+	entity, ok := target.(core.Entity)
+	if !ok {
+		return rpgerr.Newf(rpgerr.CodeInvalidTarget, "target %s can't hold conditions", m.TargetID)
+	}
+	err := dnd5eEvents.ConditionAppliedTopic.On(m.bus).Publish(ctx, dnd5eEvents.ConditionAppliedEvent{
+		Target:    entity,
+		Type:      dnd5eEvents.ConditionProne,
+		Source:    dnd5eEvents.ConditionSourceFeature,
+		Condition: prone,
+	})
+	if err != nil {
+		return rpgerr.Wrapf(err, "failed to knock %s prone", m.TargetID)
+	}
+	return nil
+}
+
+// rollDice rolls the superiority die count times
+func (m *ManeuverCondition) rollDice(ctx context.Context, count int) ([]int, error) {
+	roller := m.roller
+	if roller == nil {
+		roller = dice.NewRoller()
+	}
+
+	rolls, err := roller.RollN(ctx, count, m.DieSize)
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to roll superiority die")
+	}
+	return rolls, nil
+}
+
+// onTurnEnd lapses an unused maneuver when the turn ends
+func (m *ManeuverCondition) onTurnEnd(ctx context.Context, _ dnd5eEvents.TurnEndEvent) error {
+	return m.end(ctx, "turn_ended")
+}
+
+// end publishes the removal event and unsubscribes from all events
+func (m *ManeuverCondition) end(ctx context.Context, reason string) error {
+	if m.bus == nil {
+		return nil
+	}
+
+	removals := dnd5eEvents.ConditionRemovedTopic.On(m.bus)
+	err := removals.Publish(ctx, dnd5eEvents.ConditionRemovedEvent{
+		CharacterID:  m.CharacterID,
+		ConditionRef: refs.Conditions.Maneuver().String(),
+		Reason:       reason,
+	})
+	if err != nil {
+		return rpgerr.Wrapf(err, "error publishing maneuver removal for %s", m.CharacterID)
+	}
+
+	return m.Remove(ctx, m.bus)
+}
@@ -0,0 +1,220 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/maneuvers"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+)
+
+// maneuverTargets resolves the monsters a Battle Master fights in these tests
+type maneuverTargets map[string]*monster.Monster
+
+func (m maneuverTargets) Get(id string) (combat.Combatant, error) {
+	if found, ok := m[id]; ok {
+		return found, nil
+	}
+	return nil, rpgerr.Newf(rpgerr.CodeNotFound, "combatant %s not found", id)
+}
+
+type ManeuverTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	ctx      context.Context
+	bus      events.EventBus
+	roller   *mock_dice.MockRoller
+	ogre     *monster.Monster
+	removals []dnd5eEvents.ConditionRemovedEvent
+}
+
+func TestManeuverSuite(t *testing.T) {
+	suite.Run(t, new(ManeuverTestSuite))
+}
+
+// SetupTest places an ogre with STR 14 (+2) for the fighter to maneuver against
+func (s *ManeuverTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.bus = events.NewEventBus()
+	s.roller = mock_dice.NewMockRoller(s.ctrl)
+	s.removals = nil
+
+	s.ogre = monster.New(monster.Config{
+		ID:   "ogre-1",
+		Name: "Ogre",
+		HP:   59,
+		AC:   11,
+		AbilityScores: shared.AbilityScores{
+			abilities.STR: 14, abilities.DEX: 8, abilities.CON: 16,
+			abilities.INT: 5, abilities.WIS: 7, abilities.CHA: 7,
+		},
+	})
+	s.ctx = combat.WithCombatantLookup(context.Background(), maneuverTargets{"ogre-1": s.ogre})
+
+	_, err := dnd5eEvents.ConditionRemovedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, event dnd5eEvents.ConditionRemovedEvent) error {
+			s.removals = append(s.removals, event)
+			return nil
+		})
+	s.Require().NoError(err)
+}
+
+func (s *ManeuverTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// declare applies a d8 maneuver for fighter-1 against the ogre
+func (s *ManeuverTestSuite) declare(maneuver maneuvers.Maneuver) *ManeuverCondition {
+	condition := NewManeuverCondition(ManeuverInput{
+		CharacterID: "fighter-1",
+		TargetID:    "ogre-1",
+		Maneuver:    maneuver,
+		DieSize:     8,
+		SaveDC:      13,
+		Roller:      s.roller,
+	})
+	s.Require().NoError(condition.Apply(s.ctx, s.bus))
+	return condition
+}
+
+// attack runs the attack chain for a longsword attack and returns the final attack bonus
+func (s *ManeuverTestSuite) attack() int {
+	event := dnd5eEvents.AttackChainEvent{
+		AttackerID:  "fighter-1",
+		TargetID:    "ogre-1",
+		WeaponRef:   refs.Weapons.Longsword(),
+		IsMelee:     true,
+		AttackBonus: 5,
+	}
+	attackChain := events.NewStagedChain[dnd5eEvents.AttackChainEvent](combat.ModifierStages)
+	modified, err := dnd5eEvents.AttackChain.On(s.bus).PublishWithChain(s.ctx, event, attackChain)
+	s.Require().NoError(err)
+
+	final, err := modified.Execute(s.ctx, event)
+	s.Require().NoError(err)
+	return final.AttackBonus
+}
+
+// hit runs the damage chain for a weapon hit on the ogre and returns the final components
+func (s *ManeuverTestSuite) hit(weapon *core.Ref, critical bool) []dnd5eEvents.DamageComponent {
+	event := &dnd5eEvents.DamageChainEvent{
+		AttackerID: "fighter-1",
+		TargetID:   "ogre-1",
+		Components: []dnd5eEvents.DamageComponent{{
+			Source:            dnd5eEvents.DamageSourceWeapon,
+			OriginalDiceRolls: []int{6},
+			FinalDiceRolls:    []int{6},
+			DamageType:        damage.Slashing,
+		}},
+		DamageType: damage.Slashing,
+		IsCritical: critical,
+		WeaponRef:  weapon,
+	}
+
+	damageChain := events.NewStagedChain[*dnd5eEvents.DamageChainEvent](combat.ModifierStages)
+	modified, err := dnd5eEvents.DamageChain.On(s.bus).PublishWithChain(s.ctx, event, damageChain)
+	s.Require().NoError(err)
+
+	final, err := modified.Execute(s.ctx, event)
+	s.Require().NoError(err)
+	return final.Components
+}
+
+func (s *ManeuverTestSuite) TestPrecisionAttackAddsDieToAttackRoll() {
+	precision := s.declare(maneuvers.PrecisionAttack)
+	s.roller.EXPECT().RollN(gomock.Any(), 1, 8).Return([]int{7}, nil)
+
+	s.Equal(12, s.attack(), "5 + 7 superiority die")
+	s.False(precision.IsApplied())
+	s.Require().Len(s.removals, 1)
+	s.Equal("used", s.removals[0].Reason)
+
+	s.Equal(5, s.attack(), "the die is spent")
+}
+
+func (s *ManeuverTestSuite) TestTripAttackKnocksProneOnFailedSave() {
+	trip := s.declare(maneuvers.TripAttack)
+	s.roller.EXPECT().RollN(gomock.Any(), 1, 8).Return([]int{4}, nil)
+	s.roller.EXPECT().Roll(gomock.Any(), 20).Return(8, nil) // 8 + 2 STR = 10 vs DC 13
+
+	components := s.hit(refs.Weapons.Longsword(), false)
+	s.Require().Len(components, 2)
+	s.Equal(refs.Features.CombatSuperiority(), components[1].SourceRef)
+	s.Equal(4, components[1].Total())
+	s.Equal(damage.Slashing, components[1].DamageType, "the die deals the weapon's damage type")
+
+	s.False(trip.IsApplied())
+	s.Require().Len(s.ogre.GetConditions(), 1)
+	prone, ok := s.ogre.GetConditions()[0].(*ProneCondition)
+	s.Require().True(ok)
+	s.True(prone.IsApplied())
+}
+
+func (s *ManeuverTestSuite) TestTripAttackSavedAgainst() {
+	s.declare(maneuvers.TripAttack)
+	s.roller.EXPECT().RollN(gomock.Any(), 1, 8).Return([]int{4}, nil)
+	s.roller.EXPECT().Roll(gomock.Any(), 20).Return(11, nil) // 11 + 2 STR = 13 vs DC 13
+
+	s.Len(s.hit(refs.Weapons.Longsword(), false), 2, "the die still adds damage")
+	s.Empty(s.ogre.GetConditions())
+}
+
+func (s *ManeuverTestSuite) TestRiposteDoublesDieOnCritical() {
+	s.declare(maneuvers.Riposte)
+	s.roller.EXPECT().RollN(gomock.Any(), 2, 8).Return([]int{3, 5}, nil)
+
+	components := s.hit(refs.Weapons.Longsword(), true)
+	s.Require().Len(components, 2)
+	s.Equal(8, components[1].Total())
+	s.Empty(s.ogre.GetConditions(), "riposte forces no save")
+}
+
+func (s *ManeuverTestSuite) TestRiposteRequiresMeleeWeapon() {
+	riposte := s.declare(maneuvers.Riposte)
+
+	s.Len(s.hit(refs.Weapons.Longbow(), false), 1)
+	s.True(riposte.IsApplied())
+}
+
+func (s *ManeuverTestSuite) TestLapsesAtTurnEnd() {
+	trip := s.declare(maneuvers.TripAttack)
+
+	err := dnd5eEvents.TurnEndTopic.On(s.bus).Publish(s.ctx, dnd5eEvents.TurnEndEvent{CharacterID: "fighter-1"})
+	s.Require().NoError(err)
+
+	s.False(trip.IsApplied())
+	s.Require().Len(s.removals, 1)
+	s.Equal("turn_ended", s.removals[0].Reason)
+}
+
+func (s *ManeuverTestSuite) TestRoundTrip() {
+	data, err := s.declare(maneuvers.TripAttack).ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := LoadJSON(data)
+	s.Require().NoError(err)
+
+	maneuver, ok := loaded.(*ManeuverCondition)
+	s.Require().True(ok)
+	s.Equal("fighter-1", maneuver.CharacterID)
+	s.Equal("ogre-1", maneuver.TargetID)
+	s.Equal(maneuvers.TripAttack, maneuver.Maneuver)
+	s.Equal(8, maneuver.DieSize)
+	s.Equal(13, maneuver.SaveDC)
+}
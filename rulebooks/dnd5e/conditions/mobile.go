@@ -0,0 +1,202 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// MobileData is the JSON structure for persisting mobile condition state
+type MobileData struct {
+	Ref             *core.Ref `json:"ref"`
+	CharacterID     string    `json:"character_id"`
+	AttackedTargets []string  `json:"attacked_targets,omitempty"`
+}
+
+// MobileCondition represents the Mobile feat. When the character makes a
+// melee attack against a creature, that creature can't make opportunity
+// attacks against the character for the rest of the turn.
+//
+// Melee attacks are recorded from the attack chain, whether they hit or not,
+// and forgotten when the character's turn ends. The feat's speed increase and
+// Dash over difficult terrain aren't modeled here.
+type MobileCondition struct {
+	CharacterID     string
+	AttackedTargets []string // Creatures attacked in melee this turn
+	bus             events.EventBus
+	subscriptionIDs []string
+}
+
+// Ensure MobileCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*MobileCondition)(nil)
+
+// MobileInput provides configuration for creating a mobile condition
+type MobileInput struct {
+	CharacterID string // ID of the character with the feat
+}
+
+// NewMobileCondition creates a mobile condition from input
+func NewMobileCondition(input MobileInput) *MobileCondition {
+	return &MobileCondition{
+		CharacterID: input.CharacterID,
+	}
+}
+
+// IsApplied returns true if this condition is currently applied
+func (m *MobileCondition) IsApplied() bool {
+	return m.bus != nil
+}
+
+// Apply subscribes to attack and movement chains, and to turn end events
+func (m *MobileCondition) Apply(ctx context.Context, bus events.EventBus) error {
+	if m.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "mobile condition already applied")
+	}
+	m.bus = bus
+
+	attackSubID, err := dnd5eEvents.AttackChain.On(bus).SubscribeWithChain(ctx, m.onAttackChain)
+	if err != nil {
+		m.bus = nil
+		return rpgerr.Wrap(err, "failed to subscribe to attack chain")
+	}
+	m.subscriptionIDs = append(m.subscriptionIDs, attackSubID)
+
+	moveSubID, err := dnd5eEvents.MovementChain.On(bus).SubscribeWithChain(ctx, m.onMovementChain)
+	if err != nil {
+		_ = m.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to movement chain")
+	}
+	m.subscriptionIDs = append(m.subscriptionIDs, moveSubID)
+
+	turnEndSubID, err := dnd5eEvents.TurnEndTopic.On(bus).Subscribe(ctx, m.onTurnEnd)
+	if err != nil {
+		_ = m.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to turn end topic")
+	}
+	m.subscriptionIDs = append(m.subscriptionIDs, turnEndSubID)
+
+	return nil
+}
+
+// Remove unsubscribes this condition from events
+func (m *MobileCondition) Remove(ctx context.Context, bus events.EventBus) error {
+	if m.bus == nil {
+		return nil // Not applied, nothing to remove
+	}
+
+	total := len(m.subscriptionIDs)
+	var errs []error
+	for _, subID := range m.subscriptionIDs {
+		if err := bus.Unsubscribe(ctx, subID); err != nil {
+			errs = append(errs, fmt.Errorf("unsubscribe %s: %w", subID, err))
+		}
+	}
+
+	m.subscriptionIDs = nil
+	m.bus = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unsubscribe %d/%d subscriptions: %w", len(errs), total, errors.Join(errs...))
+	}
+	return nil
+}
+
+// ToJSON converts the condition to JSON for persistence
+func (m *MobileCondition) ToJSON() (json.RawMessage, error) {
+	data := MobileData{
+		Ref:             refs.Conditions.Mobile(),
+		CharacterID:     m.CharacterID,
+		AttackedTargets: m.AttackedTargets,
+	}
+	return json.Marshal(data)
+}
+
+// loadJSON loads mobile condition state from JSON
+func (m *MobileCondition) loadJSON(data json.RawMessage) error {
+	var mobileData MobileData
+	if err := json.Unmarshal(data, &mobileData); err != nil {
+		return rpgerr.Wrap(err, "failed to unmarshal mobile data")
+	}
+
+	m.CharacterID = mobileData.CharacterID
+	m.AttackedTargets = mobileData.AttackedTargets
+	return nil
+}
+
+// onAttackChain records the targets of the character's melee attacks
+func (m *MobileCondition) onAttackChain(
+	_ context.Context,
+	event dnd5eEvents.AttackChainEvent,
+	c chain.Chain[dnd5eEvents.AttackChainEvent],
+) (chain.Chain[dnd5eEvents.AttackChainEvent], error) {
+	if event.AttackerID != m.CharacterID || !event.IsMelee || event.TargetID == "" {
+		return c, nil
+	}
+	if !slices.Contains(m.AttackedTargets, event.TargetID) {
+		m.AttackedTargets = append(m.AttackedTargets, event.TargetID)
+	}
+	return c, nil
+}
+
+// onMovementChain exempts the character from OAs by creatures it attacked this turn
+func (m *MobileCondition) onMovementChain(
+	_ context.Context,
+	event *dnd5eEvents.MovementChainEvent,
+	c chain.Chain[*dnd5eEvents.MovementChainEvent],
+) (chain.Chain[*dnd5eEvents.MovementChainEvent], error) {
+	if event.EntityID != m.CharacterID {
+		return c, nil
+	}
+
+	var exempt []string
+	for _, threatenerID := range event.ThreateningEntities {
+		if slices.Contains(m.AttackedTargets, threatenerID) {
+			exempt = append(exempt, threatenerID)
+		}
+	}
+	if len(exempt) == 0 {
+		return c, nil
+	}
+
+	modifyMovement := func(_ context.Context, e *dnd5eEvents.MovementChainEvent) (*dnd5eEvents.MovementChainEvent, error) {
+		for _, threatenerID := range exempt {
+			e.OAExemptions = append(e.OAExemptions, dnd5eEvents.OAExemption{
+				MovementModifierSource: dnd5eEvents.MovementModifierSource{
+					Name:       "Mobile",
+					SourceType: "feat",
+					SourceRef:  refs.Conditions.Mobile(),
+					EntityID:   m.CharacterID,
+				},
+				ThreatenerID: threatenerID,
+			})
+		}
+		return e, nil
+	}
+
+	if err := c.Add(combat.StageFeatures, "mobile", modifyMovement); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to add mobile modifier for character %s", m.CharacterID)
+	}
+
+	return c, nil
+}
+
+// onTurnEnd forgets this turn's melee targets when the character's turn ends
+func (m *MobileCondition) onTurnEnd(_ context.Context, event dnd5eEvents.TurnEndEvent) error {
+	if event.CharacterID == m.CharacterID {
+		m.AttackedTargets = nil
+	}
+	return nil
+}
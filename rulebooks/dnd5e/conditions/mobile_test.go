@@ -0,0 +1,91 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+)
+
+type MobileConditionTestSuite struct {
+	suite.Suite
+	ctx       context.Context
+	bus       events.EventBus
+	condition *MobileCondition
+}
+
+func TestMobileConditionSuite(t *testing.T) {
+	suite.Run(t, new(MobileConditionTestSuite))
+}
+
+func (s *MobileConditionTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.condition = NewMobileCondition(MobileInput{CharacterID: "monk"})
+	s.Require().NoError(s.condition.Apply(s.ctx, s.bus))
+}
+
+func (s *MobileConditionTestSuite) attack(targetID string, melee bool) {
+	event := dnd5eEvents.AttackChainEvent{AttackerID: "monk", TargetID: targetID, IsMelee: melee}
+	attackChain := events.NewStagedChain[dnd5eEvents.AttackChainEvent](combat.ModifierStages)
+	_, err := dnd5eEvents.AttackChain.On(s.bus).PublishWithChain(s.ctx, event, attackChain)
+	s.Require().NoError(err)
+}
+
+func (s *MobileConditionTestSuite) move(threatening ...string) *dnd5eEvents.MovementChainEvent {
+	event := &dnd5eEvents.MovementChainEvent{EntityID: "monk", ThreateningEntities: threatening}
+	moveChain := events.NewStagedChain[*dnd5eEvents.MovementChainEvent](combat.ModifierStages)
+	modifiedChain, err := dnd5eEvents.MovementChain.On(s.bus).PublishWithChain(s.ctx, event, moveChain)
+	s.Require().NoError(err)
+	final, err := modifiedChain.Execute(s.ctx, event)
+	s.Require().NoError(err)
+	return final
+}
+
+func (s *MobileConditionTestSuite) TestMeleeTargetsCantMakeOAs() {
+	s.attack("orc", true)
+
+	final := s.move("orc", "goblin")
+	s.True(final.IsOAPreventedFrom("orc"))
+	s.False(final.IsOAPreventedFrom("goblin"), "the goblin wasn't attacked")
+	s.False(final.IsOAPrevented(), "OAs aren't prevented wholesale")
+}
+
+func (s *MobileConditionTestSuite) TestRangedAttacksDontCount() {
+	s.attack("orc", false)
+	s.False(s.move("orc").IsOAPreventedFrom("orc"))
+}
+
+func (s *MobileConditionTestSuite) TestTurnEndClearsTargets() {
+	s.attack("orc", true)
+	s.attack("orc", true)
+	s.Equal([]string{"orc"}, s.condition.AttackedTargets)
+
+	s.Require().NoError(dnd5eEvents.TurnEndTopic.On(s.bus).Publish(s.ctx, dnd5eEvents.TurnEndEvent{CharacterID: "orc"}))
+	s.Len(s.condition.AttackedTargets, 1, "other creatures' turns don't matter")
+
+	s.Require().NoError(dnd5eEvents.TurnEndTopic.On(s.bus).Publish(s.ctx, dnd5eEvents.TurnEndEvent{CharacterID: "monk"}))
+	s.Empty(s.condition.AttackedTargets)
+	s.False(s.move("orc").IsOAPreventedFrom("orc"))
+}
+
+func (s *MobileConditionTestSuite) TestJSONRoundTrip() {
+	s.attack("orc", true)
+
+	data, err := s.condition.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := LoadJSON(data)
+	s.Require().NoError(err)
+	mobile, ok := loaded.(*MobileCondition)
+	s.Require().True(ok)
+	s.Equal("monk", mobile.CharacterID)
+	s.Equal([]string{"orc"}, mobile.AttackedTargets)
+}
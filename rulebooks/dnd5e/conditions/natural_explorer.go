@@ -0,0 +1,107 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/terrains"
+)
+
+// NaturalExplorerData is the JSON structure for persisting natural explorer condition state
+type NaturalExplorerData struct {
+	Ref         *core.Ref          `json:"ref"`
+	CharacterID string             `json:"character_id"`
+	Terrains    []terrains.Terrain `json:"terrains"`
+}
+
+// NaturalExplorerCondition represents the ranger's Natural Explorer feature (ranger level 1).
+// In a favored terrain the ranger doubles their proficiency bonus on Intelligence and
+// Wisdom checks about the terrain, isn't slowed by difficult terrain while traveling,
+// and can't become lost except by magical means.
+//
+// Travel and exploration happen outside the event bus, so the condition subscribes
+// to nothing; callers ask IsFavoredTerrain for the terrain the party is in.
+type NaturalExplorerCondition struct {
+	CharacterID string
+	Terrains    []terrains.Terrain
+	bus         events.EventBus
+}
+
+// Ensure NaturalExplorerCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*NaturalExplorerCondition)(nil)
+
+// NaturalExplorerInput provides configuration for creating a natural explorer condition
+type NaturalExplorerInput struct {
+	CharacterID string             // ID of the ranger
+	Terrains    []terrains.Terrain // Chosen favored terrains
+}
+
+// NewNaturalExplorerCondition creates a natural explorer condition from input
+func NewNaturalExplorerCondition(input NaturalExplorerInput) *NaturalExplorerCondition {
+	return &NaturalExplorerCondition{
+		CharacterID: input.CharacterID,
+		Terrains:    input.Terrains,
+	}
+}
+
+// IsFavoredTerrain returns true if the terrain is one of the ranger's favored terrains
+func (n *NaturalExplorerCondition) IsFavoredTerrain(terrain terrains.Terrain) bool {
+	return slices.Contains(n.Terrains, terrain)
+}
+
+// IsApplied returns true if this condition is currently applied
+func (n *NaturalExplorerCondition) IsApplied() bool {
+	return n.bus != nil
+}
+
+// Apply marks the condition as applied. It has no event subscriptions.
+func (n *NaturalExplorerCondition) Apply(_ context.Context, bus events.EventBus) error {
+	if n.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "natural explorer condition already applied")
+	}
+	n.bus = bus
+	return nil
+}
+
+// Remove marks the condition as no longer applied
+func (n *NaturalExplorerCondition) Remove(_ context.Context, _ events.EventBus) error {
+	n.bus = nil
+	return nil
+}
+
+// ToJSON converts the condition to JSON for persistence
+func (n *NaturalExplorerCondition) ToJSON() (json.RawMessage, error) {
+	data := NaturalExplorerData{
+		Ref:         refs.Conditions.NaturalExplorer(),
+		CharacterID: n.CharacterID,
+		Terrains:    n.Terrains,
+	}
+
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to marshal natural explorer data")
+	}
+
+	return bytes, nil
+}
+
+// loadJSON loads the condition from JSON
+func (n *NaturalExplorerCondition) loadJSON(data json.RawMessage) error {
+	var explorerData NaturalExplorerData
+	if err := json.Unmarshal(data, &explorerData); err != nil {
+		return rpgerr.Wrap(err, "failed to unmarshal natural explorer data")
+	}
+
+	n.CharacterID = explorerData.CharacterID
+	n.Terrains = explorerData.Terrains
+	return nil
+}
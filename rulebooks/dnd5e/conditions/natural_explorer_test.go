@@ -0,0 +1,65 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/terrains"
+)
+
+type NaturalExplorerTestSuite struct {
+	suite.Suite
+	ctx context.Context
+	bus events.EventBus
+}
+
+func TestNaturalExplorerSuite(t *testing.T) {
+	suite.Run(t, new(NaturalExplorerTestSuite))
+}
+
+func (s *NaturalExplorerTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+}
+
+func (s *NaturalExplorerTestSuite) TestIsFavoredTerrain() {
+	explorer := NewNaturalExplorerCondition(NaturalExplorerInput{
+		CharacterID: "ranger-1",
+		Terrains:    []terrains.Terrain{terrains.Forest},
+	})
+	s.Require().NoError(explorer.Apply(s.ctx, s.bus))
+
+	s.True(explorer.IsFavoredTerrain(terrains.Forest))
+	s.False(explorer.IsFavoredTerrain(terrains.Underdark))
+
+	s.Require().NoError(explorer.Remove(s.ctx, s.bus))
+	s.False(explorer.IsApplied())
+}
+
+func (s *NaturalExplorerTestSuite) TestFactoryAndRoundTrip() {
+	output, err := CreateFromRef(&CreateFromRefInput{
+		Ref:         refs.Conditions.NaturalExplorer().String(),
+		Config:      json.RawMessage(`{"terrains": ["swamp", "coast"]}`),
+		CharacterID: "ranger-1",
+	})
+	s.Require().NoError(err)
+
+	data, err := output.Condition.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := LoadJSON(data)
+	s.Require().NoError(err)
+
+	explorer, ok := loaded.(*NaturalExplorerCondition)
+	s.Require().True(ok)
+	s.Equal("ranger-1", explorer.CharacterID)
+	s.Equal([]terrains.Terrain{terrains.Swamp, terrains.Coast}, explorer.Terrains)
+}
@@ -153,8 +153,9 @@ func (o *OpportunityAttackCondition) onMovementChain(
 		return c, nil
 	}
 
-	// Disengaging (or any other source) prevented OAs for this step.
-	if event.IsOAPrevented() {
+	// Disengaging (or any other source) prevented OAs for this step, or
+	// Mobile exempted the mover from this holder's OAs.
+	if event.IsOAPreventedFrom(o.CharacterID) {
 		return c, nil
 	}
 
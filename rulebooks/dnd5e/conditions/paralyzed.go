@@ -0,0 +1,34 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// ParalyzedCondition represents a creature that can't move or act.
+//
+// While applied:
+//   - The creature is incapacitated and can't move (through the action economy)
+//   - It fails STR and DEX saves automatically
+//   - Attacks against it have advantage
+//   - Hits from within 5 feet are critical
+type ParalyzedCondition struct {
+	standardCondition
+}
+
+// Ensure ParalyzedCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*ParalyzedCondition)(nil)
+
+// NewParalyzedCondition creates a paralyzed condition from input
+func NewParalyzedCondition(input StandardConditionInput) *ParalyzedCondition {
+	return &ParalyzedCondition{newStandardCondition(input, standardEffects{
+		name:                  "Paralyzed",
+		ref:                   refs.Conditions.Paralyzed(),
+		failsStrDexSaves:      true,
+		attackedWithAdvantage: true,
+		critWithinFive:        true,
+	})}
+}
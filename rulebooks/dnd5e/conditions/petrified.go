@@ -0,0 +1,36 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// PetrifiedCondition represents a creature turned to stone.
+//
+// While applied:
+//   - The creature is incapacitated and can't move (through the action economy)
+//   - It fails STR and DEX saves automatically
+//   - Attacks against it have advantage
+//   - It has resistance to all damage
+//
+// Immunity to poison and disease isn't modeled.
+type PetrifiedCondition struct {
+	standardCondition
+}
+
+// Ensure PetrifiedCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*PetrifiedCondition)(nil)
+
+// NewPetrifiedCondition creates a petrified condition from input
+func NewPetrifiedCondition(input StandardConditionInput) *PetrifiedCondition {
+	return &PetrifiedCondition{newStandardCondition(input, standardEffects{
+		name:                  "Petrified",
+		ref:                   refs.Conditions.Petrified(),
+		failsStrDexSaves:      true,
+		attackedWithAdvantage: true,
+		resistsAllDamage:      true,
+	})}
+}
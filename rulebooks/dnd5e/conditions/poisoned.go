@@ -0,0 +1,30 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// PoisonedCondition represents a poisoned creature.
+//
+// While applied:
+//   - The creature's attacks and ability checks have disadvantage
+type PoisonedCondition struct {
+	standardCondition
+}
+
+// Ensure PoisonedCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*PoisonedCondition)(nil)
+
+// NewPoisonedCondition creates a poisoned condition from input
+func NewPoisonedCondition(input StandardConditionInput) *PoisonedCondition {
+	return &PoisonedCondition{newStandardCondition(input, standardEffects{
+		name:               "Poisoned",
+		ref:                refs.Conditions.Poisoned(),
+		attackDisadvantage: true,
+		checkDisadvantage:  true,
+	})}
+}
@@ -0,0 +1,260 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// proneAdjacentFeet is the distance within which attacks against a prone creature have advantage
+const proneAdjacentFeet = 5
+
+// ProneConditionData is the serializable form of the prone condition.
+// This is stored by the game server as an opaque JSON blob.
+type ProneConditionData struct {
+	Ref         *core.Ref `json:"ref"`
+	CharacterID string    `json:"character_id"`
+}
+
+// ProneCondition represents a creature lying on the ground.
+//
+// While applied:
+//   - The creature's attack rolls have disadvantage
+//   - Attacks against it have advantage if the attacker is within 5 feet,
+//     otherwise disadvantage (when no room is available, melee attacks are
+//     treated as within 5 feet)
+//   - Moving means crawling, which costs 1 extra foot per foot moved
+//
+// StandUp ends the condition by spending half the creature's speed.
+type ProneCondition struct {
+	CharacterID     string
+	bus             events.EventBus
+	subscriptionIDs []string
+}
+
+// Ensure ProneCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*ProneCondition)(nil)
+
+// NewProneCondition creates a prone condition for the specified character.
+func NewProneCondition(characterID string) *ProneCondition {
+	return &ProneCondition{
+		CharacterID: characterID,
+	}
+}
+
+// IsApplied returns true if this condition is currently applied.
+func (p *ProneCondition) IsApplied() bool {
+	return p.bus != nil
+}
+
+// Apply subscribes this condition to AttackChain and MovementChain.
+func (p *ProneCondition) Apply(ctx context.Context, bus events.EventBus) error {
+	if p.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "prone condition already applied")
+	}
+	p.bus = bus
+
+	attackSubID, err := dnd5eEvents.AttackChain.On(bus).SubscribeWithChain(ctx, p.onAttackChain)
+	if err != nil {
+		p.bus = nil
+		return rpgerr.Wrap(err, "failed to subscribe to attack chain")
+	}
+	p.subscriptionIDs = append(p.subscriptionIDs, attackSubID)
+
+	movementSubID, err := dnd5eEvents.MovementChain.On(bus).SubscribeWithChain(ctx, p.onMovementChain)
+	if err != nil {
+		_ = p.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to movement chain")
+	}
+	p.subscriptionIDs = append(p.subscriptionIDs, movementSubID)
+
+	return nil
+}
+
+// Remove unsubscribes this condition from all events.
+func (p *ProneCondition) Remove(ctx context.Context, bus events.EventBus) error {
+	if p.bus == nil {
+		return nil // Not applied, nothing to remove
+	}
+
+	total := len(p.subscriptionIDs)
+	var errs []error
+	for _, subID := range p.subscriptionIDs {
+		if err := bus.Unsubscribe(ctx, subID); err != nil {
+			errs = append(errs, fmt.Errorf("unsubscribe %s: %w", subID, err))
+		}
+	}
+
+	p.subscriptionIDs = nil
+	p.bus = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unsubscribe %d/%d subscriptions: %w", len(errs), total, errors.Join(errs...))
+	}
+	return nil
+}
+
+// StandUpInput provides the parameters for standing up from prone.
+type StandUpInput struct {
+	// Economy is the creature's action economy; half its speed is deducted from movement
+	Economy *combat.ActionEconomy
+
+	// Speed is the creature's walking speed in feet
+	Speed int
+}
+
+// Validate validates the input.
+func (s *StandUpInput) Validate() error {
+	if s == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "StandUpInput is nil")
+	}
+	if s.Economy == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "Economy is required")
+	}
+	if s.Speed <= 0 {
+		return rpgerr.New(rpgerr.CodeNotAllowed, "a creature with 0 speed can't stand up")
+	}
+	return nil
+}
+
+// StandUp spends half the creature's speed to end the prone condition.
+// It publishes a ConditionRemovedEvent with reason "stood_up" and removes itself.
+//
+// Returns CodeInvalidState if the condition isn't applied, CodeNotAllowed if
+// the creature's speed is 0, and the economy's error if not enough movement remains.
+func (p *ProneCondition) StandUp(ctx context.Context, input *StandUpInput) error {
+	if !p.IsApplied() {
+		return rpgerr.New(rpgerr.CodeInvalidState, "prone condition is not applied")
+	}
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	if err := input.Economy.UseMovement(input.Speed / 2); err != nil {
+		return rpgerr.Wrapf(err, "%s can't stand up", p.CharacterID)
+	}
+
+	err := dnd5eEvents.ConditionRemovedTopic.On(p.bus).Publish(ctx, dnd5eEvents.ConditionRemovedEvent{
+		CharacterID:  p.CharacterID,
+		ConditionRef: refs.Conditions.Prone().String(),
+		Reason:       "stood_up",
+	})
+	if err != nil {
+		return rpgerr.Wrapf(err, "failed to publish prone removal for character %s", p.CharacterID)
+	}
+
+	return p.Remove(ctx, p.bus)
+}
+
+// ToJSON converts the condition to JSON for persistence.
+func (p *ProneCondition) ToJSON() (json.RawMessage, error) {
+	data := ProneConditionData{
+		Ref:         refs.Conditions.Prone(),
+		CharacterID: p.CharacterID,
+	}
+	return json.Marshal(data)
+}
+
+// loadJSON loads prone condition state from JSON.
+func (p *ProneCondition) loadJSON(data json.RawMessage) error {
+	var proneData ProneConditionData
+	if err := json.Unmarshal(data, &proneData); err != nil {
+		return rpgerr.Wrap(err, "failed to unmarshal prone data")
+	}
+
+	p.CharacterID = proneData.CharacterID
+	return nil
+}
+
+// attackerAdjacent reports whether the attacker is within 5 feet of the prone creature.
+func (p *ProneCondition) attackerAdjacent(ctx context.Context, event dnd5eEvents.AttackChainEvent) bool {
+	if distance, ok := combat.DistanceFeet(ctx, event.AttackerID, p.CharacterID); ok {
+		return distance <= proneAdjacentFeet
+	}
+	return event.IsMelee
+}
+
+// onAttackChain imposes disadvantage on the prone creature's attacks and
+// grants advantage or disadvantage on attacks against it by distance.
+func (p *ProneCondition) onAttackChain(
+	ctx context.Context,
+	event dnd5eEvents.AttackChainEvent,
+	c chain.Chain[dnd5eEvents.AttackChainEvent],
+) (chain.Chain[dnd5eEvents.AttackChainEvent], error) {
+	source := dnd5eEvents.AttackModifierSource{
+		SourceRef: refs.Conditions.Prone(),
+		SourceID:  p.CharacterID,
+		Reason:    "Prone",
+	}
+
+	var modifyAttack func(context.Context, dnd5eEvents.AttackChainEvent) (dnd5eEvents.AttackChainEvent, error)
+	stepID := "prone_target"
+	switch {
+	case event.AttackerID == p.CharacterID:
+		stepID = "prone_attacker"
+		modifyAttack = func(_ context.Context, e dnd5eEvents.AttackChainEvent) (dnd5eEvents.AttackChainEvent, error) {
+			e.DisadvantageSources = append(e.DisadvantageSources, source)
+			return e, nil
+		}
+	case event.TargetID == p.CharacterID && p.attackerAdjacent(ctx, event):
+		modifyAttack = func(_ context.Context, e dnd5eEvents.AttackChainEvent) (dnd5eEvents.AttackChainEvent, error) {
+			e.AdvantageSources = append(e.AdvantageSources, source)
+			return e, nil
+		}
+	case event.TargetID == p.CharacterID:
+		modifyAttack = func(_ context.Context, e dnd5eEvents.AttackChainEvent) (dnd5eEvents.AttackChainEvent, error) {
+			e.DisadvantageSources = append(e.DisadvantageSources, source)
+			return e, nil
+		}
+	default:
+		return c, nil
+	}
+
+	if err := c.Add(combat.StageConditions, stepID, modifyAttack); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to add prone modifier for character %s", p.CharacterID)
+	}
+
+	return c, nil
+}
+
+// onMovementChain adds the crawling cost to the prone creature's movement.
+func (p *ProneCondition) onMovementChain(
+	_ context.Context,
+	event *dnd5eEvents.MovementChainEvent,
+	c chain.Chain[*dnd5eEvents.MovementChainEvent],
+) (chain.Chain[*dnd5eEvents.MovementChainEvent], error) {
+	if event.EntityID != p.CharacterID {
+		return c, nil
+	}
+
+	addCost := func(_ context.Context, e *dnd5eEvents.MovementChainEvent) (*dnd5eEvents.MovementChainEvent, error) {
+		e.ExtraCostSources = append(e.ExtraCostSources, dnd5eEvents.MovementCostSource{
+			MovementModifierSource: dnd5eEvents.MovementModifierSource{
+				Name:       "Crawling",
+				SourceType: "condition",
+				SourceRef:  refs.Conditions.Prone(),
+				EntityID:   p.CharacterID,
+			},
+			ExtraFeetPerFoot: 1,
+		})
+		return e, nil
+	}
+
+	if err := c.Add(combat.StageConditions, "prone_crawling", addCost); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to add crawling cost for character %s", p.CharacterID)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,178 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
+)
+
+type ProneConditionTestSuite struct {
+	suite.Suite
+	ctx       context.Context
+	bus       events.EventBus
+	condition *ProneCondition
+}
+
+func TestProneConditionSuite(t *testing.T) {
+	suite.Run(t, new(ProneConditionTestSuite))
+}
+
+func (s *ProneConditionTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.condition = NewProneCondition("fighter")
+	s.Require().NoError(s.condition.Apply(s.ctx, s.bus))
+}
+
+func (s *ProneConditionTestSuite) SetupSubTest() {
+	s.SetupTest()
+}
+
+func (s *ProneConditionTestSuite) attack(ctx context.Context, event dnd5eEvents.AttackChainEvent) dnd5eEvents.AttackChainEvent {
+	attackChain := events.NewStagedChain[dnd5eEvents.AttackChainEvent](combat.ModifierStages)
+	modified, err := dnd5eEvents.AttackChain.On(s.bus).PublishWithChain(ctx, event, attackChain)
+	s.Require().NoError(err)
+	result, err := modified.Execute(ctx, event)
+	s.Require().NoError(err)
+	return result
+}
+
+func (s *ProneConditionTestSuite) TestApplyTwice() {
+	s.Error(s.condition.Apply(s.ctx, s.bus))
+}
+
+func (s *ProneConditionTestSuite) TestAttackModifiers() {
+	s.Run("prone attacker has disadvantage", func() {
+		result := s.attack(s.ctx, dnd5eEvents.AttackChainEvent{AttackerID: "fighter", TargetID: "goblin", IsMelee: true})
+		s.Require().Len(result.DisadvantageSources, 1)
+		s.Equal(refs.Conditions.Prone(), result.DisadvantageSources[0].SourceRef)
+		s.Empty(result.AdvantageSources)
+	})
+
+	s.Run("melee against prone has advantage without a room", func() {
+		result := s.attack(s.ctx, dnd5eEvents.AttackChainEvent{AttackerID: "goblin", TargetID: "fighter", IsMelee: true})
+		s.Len(result.AdvantageSources, 1)
+		s.Empty(result.DisadvantageSources)
+	})
+
+	s.Run("ranged against prone has disadvantage without a room", func() {
+		result := s.attack(s.ctx, dnd5eEvents.AttackChainEvent{AttackerID: "goblin", TargetID: "fighter"})
+		s.Empty(result.AdvantageSources)
+		s.Len(result.DisadvantageSources, 1)
+	})
+
+	s.Run("distance decides with a room", func() {
+		room := spatial.NewBasicRoom(spatial.BasicRoomConfig{
+			ID:   "test-room",
+			Type: "dungeon",
+			Grid: spatial.NewSquareGrid(spatial.SquareGridConfig{Width: 20, Height: 20}),
+		})
+		s.Require().NoError(room.PlaceEntity(&mockEntity{id: "fighter", entityType: "character"},
+			spatial.Position{X: 5, Y: 5}))
+		s.Require().NoError(room.PlaceEntity(&mockEntity{id: "archer", entityType: "monster"},
+			spatial.Position{X: 6, Y: 5}))
+		s.Require().NoError(room.PlaceEntity(&mockEntity{id: "sniper", entityType: "monster"},
+			spatial.Position{X: 12, Y: 5}))
+		ctx := combat.WithRoom(s.ctx, room)
+
+		// An adjacent ranged attacker still gets advantage
+		adjacent := s.attack(ctx, dnd5eEvents.AttackChainEvent{AttackerID: "archer", TargetID: "fighter"})
+		s.Len(adjacent.AdvantageSources, 1)
+
+		distant := s.attack(ctx, dnd5eEvents.AttackChainEvent{AttackerID: "sniper", TargetID: "fighter"})
+		s.Len(distant.DisadvantageSources, 1)
+	})
+
+	s.Run("unrelated attacks are unaffected", func() {
+		result := s.attack(s.ctx, dnd5eEvents.AttackChainEvent{AttackerID: "goblin", TargetID: "rogue", IsMelee: true})
+		s.Empty(result.AdvantageSources)
+		s.Empty(result.DisadvantageSources)
+	})
+}
+
+func (s *ProneConditionTestSuite) TestCrawlingCost() {
+	event := &dnd5eEvents.MovementChainEvent{EntityID: "fighter"}
+	movementChain := events.NewStagedChain[*dnd5eEvents.MovementChainEvent](combat.ModifierStages)
+	modified, err := dnd5eEvents.MovementChain.On(s.bus).PublishWithChain(s.ctx, event, movementChain)
+	s.Require().NoError(err)
+	result, err := modified.Execute(s.ctx, event)
+	s.Require().NoError(err)
+
+	s.Equal(2, result.CostMultiplier())
+	s.Require().Len(result.ExtraCostSources, 1)
+	s.Equal(refs.Conditions.Prone(), result.ExtraCostSources[0].SourceRef)
+}
+
+func (s *ProneConditionTestSuite) TestStandUp() {
+	s.Run("spends half speed and removes the condition", func() {
+		var removed []dnd5eEvents.ConditionRemovedEvent
+		_, err := dnd5eEvents.ConditionRemovedTopic.On(s.bus).Subscribe(s.ctx,
+			func(_ context.Context, e dnd5eEvents.ConditionRemovedEvent) error {
+				removed = append(removed, e)
+				return nil
+			})
+		s.Require().NoError(err)
+
+		economy := combat.NewActionEconomy()
+		economy.SetMovement(30)
+
+		s.Require().NoError(s.condition.StandUp(s.ctx, &StandUpInput{Economy: economy, Speed: 30}))
+		s.Equal(15, economy.MovementRemaining)
+		s.False(s.condition.IsApplied())
+
+		s.Require().Len(removed, 1)
+		s.Equal("fighter", removed[0].CharacterID)
+		s.Equal(refs.Conditions.Prone().String(), removed[0].ConditionRef)
+		s.Equal("stood_up", removed[0].Reason)
+
+		result := s.attack(s.ctx, dnd5eEvents.AttackChainEvent{AttackerID: "fighter", TargetID: "goblin"})
+		s.Empty(result.DisadvantageSources)
+	})
+
+	s.Run("insufficient movement", func() {
+		economy := combat.NewActionEconomy()
+		economy.SetMovement(10)
+
+		err := s.condition.StandUp(s.ctx, &StandUpInput{Economy: economy, Speed: 30})
+		s.Require().Error(err)
+		s.Equal(rpgerr.CodeResourceExhausted, rpgerr.GetCode(err))
+		s.True(s.condition.IsApplied())
+		s.Equal(10, economy.MovementRemaining)
+	})
+
+	s.Run("zero speed can't stand", func() {
+		err := s.condition.StandUp(s.ctx, &StandUpInput{Economy: combat.NewActionEconomy(), Speed: 0})
+		s.Require().Error(err)
+		s.Equal(rpgerr.CodeNotAllowed, rpgerr.GetCode(err))
+	})
+
+	s.Run("not applied", func() {
+		s.Require().NoError(s.condition.Remove(s.ctx, s.bus))
+		err := s.condition.StandUp(s.ctx, &StandUpInput{Economy: combat.NewActionEconomy(), Speed: 30})
+		s.Equal(rpgerr.CodeInvalidState, rpgerr.GetCode(err))
+	})
+}
+
+func (s *ProneConditionTestSuite) TestToJSONRoundTrip() {
+	data, err := s.condition.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := LoadJSON(data)
+	s.Require().NoError(err)
+
+	prone, ok := loaded.(*ProneCondition)
+	s.Require().True(ok)
+	s.Equal("fighter", prone.CharacterID)
+	s.False(prone.IsApplied())
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/KirkDiggler/rpg-toolkit/core/chain"
 	"github.com/KirkDiggler/rpg-toolkit/events"
 	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
 	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
@@ -31,6 +32,10 @@ type RagingData struct {
 }
 
 // RagingCondition represents the barbarian rage state.
+// While raging the barbarian deals extra melee damage, resists bludgeoning,
+// piercing, and slashing damage, and has advantage on Strength checks and
+// saving throws. Rage ends early if the barbarian neither attacked a hostile
+// creature nor took damage since their last turn.
 // It implements the Condition interface.
 type RagingCondition struct {
 	CharacterID       string
@@ -107,6 +112,32 @@ func (r *RagingCondition) Apply(ctx context.Context, bus events.EventBus) error
 	}
 	r.subscriptionIDs = append(r.subscriptionIDs, subID5)
 
+	// Subscribe to attack chain to track attacks that miss (they still keep rage going)
+	attackChain := dnd5eEvents.AttackChain.On(bus)
+	subID6, err := attackChain.SubscribeWithChain(ctx, r.onAttackChain)
+	if err != nil {
+		_ = r.Remove(ctx, bus)
+		return err
+	}
+	r.subscriptionIDs = append(r.subscriptionIDs, subID6)
+
+	// Subscribe to saving throw and ability check chains for advantage on STR
+	saveChain := dnd5eEvents.SavingThrowChain.On(bus)
+	subID7, err := saveChain.SubscribeWithChain(ctx, r.onSavingThrowChain)
+	if err != nil {
+		_ = r.Remove(ctx, bus)
+		return err
+	}
+	r.subscriptionIDs = append(r.subscriptionIDs, subID7)
+
+	checkChain := dnd5eEvents.AbilityCheckChain.On(bus)
+	subID8, err := checkChain.SubscribeWithChain(ctx, r.onAbilityCheckChain)
+	if err != nil {
+		_ = r.Remove(ctx, bus)
+		return err
+	}
+	r.subscriptionIDs = append(r.subscriptionIDs, subID8)
+
 	return nil
 }
 
@@ -295,3 +326,69 @@ func (r *RagingCondition) onDamageChain(
 
 	return c, nil
 }
+
+// onAttackChain records that the raging character attacked this turn, hit or miss
+func (r *RagingCondition) onAttackChain(
+	_ context.Context,
+	event dnd5eEvents.AttackChainEvent,
+	c chain.Chain[dnd5eEvents.AttackChainEvent],
+) (chain.Chain[dnd5eEvents.AttackChainEvent], error) {
+	if event.AttackerID == r.CharacterID {
+		r.DidAttackThisTurn = true
+	}
+	return c, nil
+}
+
+// onSavingThrowChain grants advantage on Strength saving throws
+func (r *RagingCondition) onSavingThrowChain(
+	_ context.Context,
+	event *dnd5eEvents.SavingThrowChainEvent,
+	c chain.Chain[*dnd5eEvents.SavingThrowChainEvent],
+) (chain.Chain[*dnd5eEvents.SavingThrowChainEvent], error) {
+	if event.SaverID != r.CharacterID || event.Ability != abilities.STR {
+		return c, nil
+	}
+
+	modifySave := func(_ context.Context, e *dnd5eEvents.SavingThrowChainEvent) (*dnd5eEvents.SavingThrowChainEvent, error) {
+		e.AdvantageSources = append(e.AdvantageSources, dnd5eEvents.SaveModifierSource{
+			Name:       "Rage",
+			SourceType: "condition",
+			SourceRef:  refs.Conditions.Raging(),
+			EntityID:   r.CharacterID,
+		})
+		return e, nil
+	}
+
+	if err := c.Add(combat.StageConditions, "rage_str_save_advantage", modifySave); err != nil {
+		return c, rpgerr.Wrapf(err, "error applying rage save advantage for character id %s", r.CharacterID)
+	}
+
+	return c, nil
+}
+
+// onAbilityCheckChain grants advantage on Strength checks
+func (r *RagingCondition) onAbilityCheckChain(
+	_ context.Context,
+	event *dnd5eEvents.AbilityCheckChainEvent,
+	c chain.Chain[*dnd5eEvents.AbilityCheckChainEvent],
+) (chain.Chain[*dnd5eEvents.AbilityCheckChainEvent], error) {
+	if event.CheckerID != r.CharacterID || event.Ability != abilities.STR {
+		return c, nil
+	}
+
+	modifyCheck := func(_ context.Context, e *dnd5eEvents.AbilityCheckChainEvent) (*dnd5eEvents.AbilityCheckChainEvent, error) {
+		e.AdvantageSources = append(e.AdvantageSources, dnd5eEvents.CheckModifierSource{
+			Name:       "Rage",
+			SourceType: "condition",
+			SourceRef:  refs.Conditions.Raging(),
+			EntityID:   r.CharacterID,
+		})
+		return e, nil
+	}
+
+	if err := c.Add(combat.StageConditions, "rage_str_check_advantage", modifyCheck); err != nil {
+		return c, rpgerr.Wrapf(err, "error applying rage check advantage for character id %s", r.CharacterID)
+	}
+
+	return c, nil
+}
@@ -613,7 +613,7 @@ func (s *RagingConditionTestSuite) TestRagingConditionResistanceOnlyAffectsOwnCh
 }
 
 func (s *RagingConditionTestSuite) TestRemoveContinuesOnStaleSubscription() {
-	// Apply a raging condition (creates 5 subscriptions)
+	// Apply a raging condition (creates 8 subscriptions)
 	raging := newRagingCondition(ragingConditionInput{
 		CharacterID: "barbarian-1",
 		DamageBonus: 2,
@@ -623,7 +623,7 @@ func (s *RagingConditionTestSuite) TestRemoveContinuesOnStaleSubscription() {
 
 	err := raging.Apply(s.ctx, s.bus)
 	s.Require().NoError(err)
-	s.Require().Len(raging.subscriptionIDs, 5)
+	s.Require().Len(raging.subscriptionIDs, 8)
 
 	// Wrap the bus so that the first subscription ID fails on unsubscribe
 	failBus := &errorOnUnsubscribeBus{
@@ -634,10 +634,75 @@ func (s *RagingConditionTestSuite) TestRemoveContinuesOnStaleSubscription() {
 	// Remove should return an error but still clean up all other subscriptions
 	err = raging.Remove(s.ctx, failBus)
 	s.Require().Error(err, "Remove should report the failed unsubscribe")
-	s.Contains(err.Error(), "1/5", "error should report count of failures vs total")
+	s.Contains(err.Error(), "1/8", "error should report count of failures vs total")
 
 	// Condition should be fully cleaned up despite the error
 	s.Nil(raging.subscriptionIDs, "subscriptionIDs should be nil after Remove")
 	s.Nil(raging.bus, "bus should be nil after Remove")
 	s.False(raging.IsApplied(), "condition should no longer be applied")
 }
+
+func (s *RagingConditionTestSuite) TestRagingConditionContinuesAfterMissedAttack() {
+	raging := newRagingCondition(ragingConditionInput{
+		CharacterID: "barbarian-1",
+		DamageBonus: 2,
+		Level:       5,
+		Source:      "dnd5e:features:rage",
+	})
+	s.Require().NoError(raging.Apply(s.ctx, s.bus))
+
+	// The attack chain fires for every attack, hit or miss
+	attack := dnd5eEvents.AttackChainEvent{AttackerID: "barbarian-1", TargetID: "goblin-1", IsMelee: true}
+	attackChain := events.NewStagedChain[dnd5eEvents.AttackChainEvent](combat.ModifierStages)
+	modifiedChain, err := dnd5eEvents.AttackChain.On(s.bus).PublishWithChain(s.ctx, attack, attackChain)
+	s.Require().NoError(err)
+	_, err = modifiedChain.Execute(s.ctx, attack)
+	s.Require().NoError(err)
+
+	err = dnd5eEvents.TurnEndTopic.On(s.bus).Publish(s.ctx, dnd5eEvents.TurnEndEvent{
+		CharacterID: "barbarian-1",
+		Round:       1,
+	})
+	s.Require().NoError(err)
+
+	s.True(raging.IsApplied(), "a missed attack keeps rage going")
+}
+
+func (s *RagingConditionTestSuite) TestRagingConditionGrantsStrengthAdvantage() {
+	raging := newRagingCondition(ragingConditionInput{
+		CharacterID: "barbarian-1",
+		DamageBonus: 2,
+		Level:       5,
+		Source:      "dnd5e:features:rage",
+	})
+	s.Require().NoError(raging.Apply(s.ctx, s.bus))
+
+	save := func(saverID string, ability abilities.Ability) bool {
+		event := &dnd5eEvents.SavingThrowChainEvent{SaverID: saverID, Ability: ability, DC: 15}
+		saveChain := events.NewStagedChain[*dnd5eEvents.SavingThrowChainEvent](combat.ModifierStages)
+		modifiedChain, err := dnd5eEvents.SavingThrowChain.On(s.bus).PublishWithChain(s.ctx, event, saveChain)
+		s.Require().NoError(err)
+		result, err := modifiedChain.Execute(s.ctx, event)
+		s.Require().NoError(err)
+		return result.HasAdvantage()
+	}
+
+	check := func(checkerID string, ability abilities.Ability) bool {
+		event := &dnd5eEvents.AbilityCheckChainEvent{CheckerID: checkerID, Ability: ability, DC: 15}
+		checkChain := events.NewStagedChain[*dnd5eEvents.AbilityCheckChainEvent](combat.ModifierStages)
+		modifiedChain, err := dnd5eEvents.AbilityCheckChain.On(s.bus).PublishWithChain(s.ctx, event, checkChain)
+		s.Require().NoError(err)
+		result, err := modifiedChain.Execute(s.ctx, event)
+		s.Require().NoError(err)
+		return result.HasAdvantage()
+	}
+
+	s.True(save("barbarian-1", abilities.STR))
+	s.True(check("barbarian-1", abilities.STR))
+	s.False(save("barbarian-1", abilities.DEX))
+	s.False(check("barbarian-1", abilities.CON))
+	s.False(save("goblin-1", abilities.STR), "only the raging character")
+
+	s.Require().NoError(raging.Remove(s.ctx, s.bus))
+	s.False(check("barbarian-1", abilities.STR), "advantage ends with rage")
+}
@@ -0,0 +1,288 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/gamectx"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// ReadiedActionConditionData is the serializable form of a readied action.
+// This is stored by the game server as an opaque JSON blob so a held action
+// survives between the holder's turn and the moment its trigger occurs.
+type ReadiedActionConditionData struct {
+	Ref         *core.Ref                `json:"ref"`
+	CharacterID string                   `json:"character_id"`
+	ActionRef   *core.Ref                `json:"action_ref"`
+	TargetID    string                   `json:"target_id,omitempty"`
+	Trigger     dnd5eEvents.ReadyTrigger `json:"trigger"`
+}
+
+// ReadiedActionConfig configures a new readied action condition.
+type ReadiedActionConfig struct {
+	// CharacterID is the character holding the action.
+	CharacterID string
+
+	// ActionRef identifies the held action (e.g. refs.Actions.Strike()).
+	ActionRef *core.Ref
+
+	// TargetID is the intended target of the held action, if any.
+	TargetID string
+
+	// Trigger is the circumstance that releases the action.
+	Trigger dnd5eEvents.ReadyTrigger
+}
+
+// ReadiedActionCondition holds an action prepared with the Ready ability.
+//
+// While applied it watches the bus for the declared trigger. When the trigger
+// occurs and the holder's reaction is ready (gamectx.IsReactionReady), it
+// consumes the reaction (ReactionUsedEvent), publishes a
+// ReactionTriggerEvent carrying a ReadiedActionTriggeredEvent so the
+// orchestrator can resolve the held action, and removes itself. If the holder's
+// next turn starts first, the held action is lost and the condition expires.
+// A trigger that arrives after the reaction was spent this round is ignored;
+// the action stays readied for a later trigger, or expires.
+//
+// The condition does not resolve the held action itself - like the opportunity
+// attack condition, it signals the orchestrator instead of re-entering combat.
+type ReadiedActionCondition struct {
+	CharacterID     string
+	ActionRef       *core.Ref
+	TargetID        string
+	Trigger         dnd5eEvents.ReadyTrigger
+	bus             events.EventBus
+	subscriptionIDs []string
+}
+
+// Ensure ReadiedActionCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*ReadiedActionCondition)(nil)
+
+// NewReadiedActionCondition creates a readied action condition from the config.
+func NewReadiedActionCondition(config ReadiedActionConfig) *ReadiedActionCondition {
+	return &ReadiedActionCondition{
+		CharacterID: config.CharacterID,
+		ActionRef:   config.ActionRef,
+		TargetID:    config.TargetID,
+		Trigger:     config.Trigger,
+	}
+}
+
+// IsApplied returns true if this condition is currently applied.
+func (r *ReadiedActionCondition) IsApplied() bool {
+	return r.bus != nil
+}
+
+// Apply subscribes to the topic matching the trigger kind, plus TurnStart so
+// the held action expires at the start of the holder's next turn.
+func (r *ReadiedActionCondition) Apply(ctx context.Context, bus events.EventBus) error {
+	if r.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "readied action condition already applied")
+	}
+	r.bus = bus
+
+	var subID string
+	var err error
+	switch r.Trigger.Kind {
+	case dnd5eEvents.ReadyTriggerCreatureMoves:
+		subID, err = dnd5eEvents.MovementChain.On(bus).SubscribeWithChain(ctx, r.onMovementChain)
+	case dnd5eEvents.ReadyTriggerCreatureAttacks:
+		subID, err = dnd5eEvents.AttackChain.On(bus).SubscribeWithChain(ctx, r.onAttackChain)
+	case dnd5eEvents.ReadyTriggerCreatureDamaged:
+		subID, err = dnd5eEvents.DamageReceivedTopic.On(bus).Subscribe(ctx, r.onDamageReceived)
+	case dnd5eEvents.ReadyTriggerTurnStart:
+		// Handled by the TurnStart subscription below
+	default:
+		r.bus = nil
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument, "unknown ready trigger kind: %s", r.Trigger.Kind)
+	}
+	if err != nil {
+		r.bus = nil
+		return rpgerr.Wrapf(err, "failed to subscribe readied action trigger %s", r.Trigger.Kind)
+	}
+	if subID != "" {
+		r.subscriptionIDs = append(r.subscriptionIDs, subID)
+	}
+
+	turnStartID, err := dnd5eEvents.TurnStartTopic.On(bus).Subscribe(ctx, r.onTurnStart)
+	if err != nil {
+		_ = r.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to turn start topic")
+	}
+	r.subscriptionIDs = append(r.subscriptionIDs, turnStartID)
+
+	return nil
+}
+
+// Remove unsubscribes this condition from all events.
+func (r *ReadiedActionCondition) Remove(ctx context.Context, bus events.EventBus) error {
+	if r.bus == nil {
+		return nil
+	}
+
+	total := len(r.subscriptionIDs)
+	var errs []error
+	for _, subID := range r.subscriptionIDs {
+		if err := bus.Unsubscribe(ctx, subID); err != nil {
+			errs = append(errs, fmt.Errorf("unsubscribe %s: %w", subID, err))
+		}
+	}
+
+	r.subscriptionIDs = nil
+	r.bus = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unsubscribe %d/%d subscriptions: %w", len(errs), total, errors.Join(errs...))
+	}
+	return nil
+}
+
+// ToJSON converts the condition to JSON for persistence.
+func (r *ReadiedActionCondition) ToJSON() (json.RawMessage, error) {
+	data := ReadiedActionConditionData{
+		Ref:         refs.Conditions.ReadiedAction(),
+		CharacterID: r.CharacterID,
+		ActionRef:   r.ActionRef,
+		TargetID:    r.TargetID,
+		Trigger:     r.Trigger,
+	}
+	return json.Marshal(data)
+}
+
+// loadJSON loads readied action state from JSON.
+func (r *ReadiedActionCondition) loadJSON(data json.RawMessage) error {
+	var readiedData ReadiedActionConditionData
+	if err := json.Unmarshal(data, &readiedData); err != nil {
+		return rpgerr.Wrap(err, "failed to unmarshal readied action data")
+	}
+
+	r.CharacterID = readiedData.CharacterID
+	r.ActionRef = readiedData.ActionRef
+	r.TargetID = readiedData.TargetID
+	r.Trigger = readiedData.Trigger
+	return nil
+}
+
+// matchesCreature returns true if activity by the given creature satisfies the trigger.
+// The holder's own activity never triggers their readied action.
+func (r *ReadiedActionCondition) matchesCreature(creatureID string) bool {
+	if creatureID == r.CharacterID {
+		return false
+	}
+	return r.Trigger.CreatureID == "" || r.Trigger.CreatureID == creatureID
+}
+
+// onMovementChain releases the action when the watched creature moves.
+func (r *ReadiedActionCondition) onMovementChain(
+	ctx context.Context,
+	event *dnd5eEvents.MovementChainEvent,
+	c chain.Chain[*dnd5eEvents.MovementChainEvent],
+) (chain.Chain[*dnd5eEvents.MovementChainEvent], error) {
+	if !r.matchesCreature(event.EntityID) {
+		return c, nil
+	}
+	return c, r.fire(ctx, event.EntityID)
+}
+
+// onAttackChain releases the action when the watched creature attacks.
+func (r *ReadiedActionCondition) onAttackChain(
+	ctx context.Context,
+	event dnd5eEvents.AttackChainEvent,
+	c chain.Chain[dnd5eEvents.AttackChainEvent],
+) (chain.Chain[dnd5eEvents.AttackChainEvent], error) {
+	if !r.matchesCreature(event.AttackerID) {
+		return c, nil
+	}
+	return c, r.fire(ctx, event.AttackerID)
+}
+
+// onDamageReceived releases the action when the watched creature takes damage.
+func (r *ReadiedActionCondition) onDamageReceived(ctx context.Context, event dnd5eEvents.DamageReceivedEvent) error {
+	if !r.matchesCreature(event.TargetID) {
+		return nil
+	}
+	return r.fire(ctx, event.TargetID)
+}
+
+// onTurnStart expires the action on the holder's turn, or releases it when the
+// watched creature's turn starts for ReadyTriggerTurnStart.
+func (r *ReadiedActionCondition) onTurnStart(ctx context.Context, event dnd5eEvents.TurnStartEvent) error {
+	if r.bus == nil {
+		return nil
+	}
+
+	if event.CharacterID == r.CharacterID {
+		return r.end(ctx, "turn_start")
+	}
+
+	if r.Trigger.Kind == dnd5eEvents.ReadyTriggerTurnStart && r.matchesCreature(event.CharacterID) {
+		return r.fire(ctx, event.CharacterID)
+	}
+
+	return nil
+}
+
+// fire consumes the holder's reaction, signals the orchestrator to resolve the
+// held action, and removes the condition. A readied action fires at most once,
+// and not at all while the holder's reaction is spent.
+func (r *ReadiedActionCondition) fire(ctx context.Context, triggeringID string) error {
+	bus := r.bus
+	if bus == nil {
+		return nil
+	}
+
+	if !gamectx.IsReactionReady(ctx, r.CharacterID, refs.Conditions.ReadiedAction().String()) {
+		return nil
+	}
+
+	if err := dnd5eEvents.ReactionUsedTopic.On(bus).Publish(ctx, dnd5eEvents.ReactionUsedEvent{
+		CharacterID: r.CharacterID,
+		FeatureRef:  refs.Conditions.ReadiedAction(),
+		Reason:      "Readied action triggered",
+	}); err != nil {
+		return rpgerr.Wrapf(err, "failed to publish reaction used for character %s", r.CharacterID)
+	}
+
+	if err := dnd5eEvents.ReactionTriggerTopic.On(bus).Publish(ctx, dnd5eEvents.ReactionTriggerEvent{
+		ReactorID:    r.CharacterID,
+		ConditionRef: refs.Conditions.ReadiedAction().String(),
+		TriggerKind:  dnd5eEvents.TriggerKindReadiedAction,
+		SourceEntity: triggeringID,
+		Payload: dnd5eEvents.ReadiedActionTriggeredEvent{
+			CharacterID:  r.CharacterID,
+			ActionRef:    r.ActionRef,
+			TargetID:     r.TargetID,
+			Trigger:      r.Trigger,
+			TriggeringID: triggeringID,
+		},
+	}); err != nil {
+		return rpgerr.Wrapf(err, "failed to publish readied action trigger for character %s", r.CharacterID)
+	}
+
+	return r.end(ctx, "triggered")
+}
+
+// end publishes the removal event and unsubscribes the condition.
+func (r *ReadiedActionCondition) end(ctx context.Context, reason string) error {
+	bus := r.bus
+	if err := dnd5eEvents.ConditionRemovedTopic.On(bus).Publish(ctx, dnd5eEvents.ConditionRemovedEvent{
+		CharacterID:  r.CharacterID,
+		ConditionRef: refs.Conditions.ReadiedAction().String(),
+		Reason:       reason,
+	}); err != nil {
+		return rpgerr.Wrapf(err, "failed to publish readied action removal for character %s", r.CharacterID)
+	}
+
+	return r.Remove(ctx, bus)
+}
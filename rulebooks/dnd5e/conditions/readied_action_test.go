@@ -0,0 +1,248 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/gamectx"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReadiedActionConditionTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	bus      events.EventBus
+	triggers []dnd5eEvents.ReactionTriggerEvent
+	used     []dnd5eEvents.ReactionUsedEvent
+	removed  []dnd5eEvents.ConditionRemovedEvent
+}
+
+func TestReadiedActionConditionSuite(t *testing.T) {
+	suite.Run(t, new(ReadiedActionConditionTestSuite))
+}
+
+func (s *ReadiedActionConditionTestSuite) SetupTest() {
+	s.ctx = gamectx.WithReactionReadiness(context.Background(), gamectx.ReactionReadinessMap{
+		"fighter": {refs.Conditions.ReadiedAction().String(): true},
+	})
+	s.bus = events.NewEventBus()
+	s.triggers = nil
+	s.used = nil
+	s.removed = nil
+
+	_, err := dnd5eEvents.ReactionTriggerTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, e dnd5eEvents.ReactionTriggerEvent) error {
+			s.triggers = append(s.triggers, e)
+			return nil
+		})
+	s.Require().NoError(err)
+
+	_, err = dnd5eEvents.ReactionUsedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, e dnd5eEvents.ReactionUsedEvent) error {
+			s.used = append(s.used, e)
+			return nil
+		})
+	s.Require().NoError(err)
+
+	_, err = dnd5eEvents.ConditionRemovedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, e dnd5eEvents.ConditionRemovedEvent) error {
+			s.removed = append(s.removed, e)
+			return nil
+		})
+	s.Require().NoError(err)
+}
+
+func (s *ReadiedActionConditionTestSuite) newCondition(trigger dnd5eEvents.ReadyTrigger) *ReadiedActionCondition {
+	return NewReadiedActionCondition(ReadiedActionConfig{
+		CharacterID: "fighter",
+		ActionRef:   refs.Actions.Strike(),
+		TargetID:    "goblin",
+		Trigger:     trigger,
+	})
+}
+
+func (s *ReadiedActionConditionTestSuite) move(entityID string) {
+	event := &dnd5eEvents.MovementChainEvent{EntityID: entityID}
+	movementChain := events.NewStagedChain[*dnd5eEvents.MovementChainEvent](combat.ModifierStages)
+	_, err := dnd5eEvents.MovementChain.On(s.bus).PublishWithChain(s.ctx, event, movementChain)
+	s.Require().NoError(err)
+}
+
+func (s *ReadiedActionConditionTestSuite) TestFiresWhenWatchedCreatureMoves() {
+	condition := s.newCondition(dnd5eEvents.ReadyTrigger{
+		Kind:        dnd5eEvents.ReadyTriggerCreatureMoves,
+		CreatureID:  "goblin",
+		Description: "when the goblin steps through the door",
+	})
+	s.Require().NoError(condition.Apply(s.ctx, s.bus))
+
+	s.move("goblin")
+
+	s.Require().Len(s.triggers, 1)
+	trigger := s.triggers[0]
+	s.Equal("fighter", trigger.ReactorID)
+	s.Equal(dnd5eEvents.TriggerKindReadiedAction, trigger.TriggerKind)
+	s.Equal("goblin", trigger.SourceEntity)
+
+	payload, ok := trigger.Payload.(dnd5eEvents.ReadiedActionTriggeredEvent)
+	s.Require().True(ok)
+	s.Equal(refs.Actions.Strike(), payload.ActionRef)
+	s.Equal("goblin", payload.TargetID)
+	s.Equal("when the goblin steps through the door", payload.Trigger.Description)
+
+	s.Require().Len(s.used, 1, "firing consumes the holder's reaction")
+	s.Equal("fighter", s.used[0].CharacterID)
+
+	s.Require().Len(s.removed, 1)
+	s.Equal("triggered", s.removed[0].Reason)
+	s.False(condition.IsApplied())
+}
+
+func (s *ReadiedActionConditionTestSuite) TestFiresOnlyOnce() {
+	condition := s.newCondition(dnd5eEvents.ReadyTrigger{Kind: dnd5eEvents.ReadyTriggerCreatureMoves})
+	s.Require().NoError(condition.Apply(s.ctx, s.bus))
+
+	s.move("goblin")
+	s.move("orc")
+
+	s.Len(s.triggers, 1)
+	s.Len(s.used, 1)
+}
+
+func (s *ReadiedActionConditionTestSuite) TestHoldsWhenReactionAlreadySpent() {
+	condition := s.newCondition(dnd5eEvents.ReadyTrigger{
+		Kind:       dnd5eEvents.ReadyTriggerCreatureMoves,
+		CreatureID: "goblin",
+	})
+	s.Require().NoError(condition.Apply(s.ctx, s.bus))
+
+	// The fighter spent their reaction earlier this round
+	ready := s.ctx
+	s.ctx = gamectx.WithReactionReadiness(context.Background(), gamectx.ReactionReadinessMap{
+		"fighter": {refs.Conditions.ReadiedAction().String(): false},
+	})
+	s.move("goblin")
+
+	s.Empty(s.triggers)
+	s.Empty(s.used, "a spent reaction is not used again")
+	s.Empty(s.removed)
+	s.True(condition.IsApplied(), "the action stays readied")
+
+	// Once the reaction is back, the next trigger releases it
+	s.ctx = ready
+	s.move("goblin")
+	s.Len(s.triggers, 1)
+	s.Len(s.used, 1)
+}
+
+func (s *ReadiedActionConditionTestSuite) TestIgnoresOtherCreaturesAndHolder() {
+	condition := s.newCondition(dnd5eEvents.ReadyTrigger{
+		Kind:       dnd5eEvents.ReadyTriggerCreatureMoves,
+		CreatureID: "goblin",
+	})
+	s.Require().NoError(condition.Apply(s.ctx, s.bus))
+
+	s.move("orc")
+	s.move("fighter")
+
+	s.Empty(s.triggers)
+	s.True(condition.IsApplied())
+}
+
+func (s *ReadiedActionConditionTestSuite) TestFiresWhenWatchedCreatureAttacks() {
+	condition := s.newCondition(dnd5eEvents.ReadyTrigger{
+		Kind:       dnd5eEvents.ReadyTriggerCreatureAttacks,
+		CreatureID: "goblin",
+	})
+	s.Require().NoError(condition.Apply(s.ctx, s.bus))
+
+	attackChain := events.NewStagedChain[dnd5eEvents.AttackChainEvent](combat.ModifierStages)
+	_, err := dnd5eEvents.AttackChain.On(s.bus).PublishWithChain(s.ctx, dnd5eEvents.AttackChainEvent{
+		AttackerID: "goblin",
+		TargetID:   "wizard",
+	}, attackChain)
+	s.Require().NoError(err)
+
+	s.Len(s.triggers, 1)
+}
+
+func (s *ReadiedActionConditionTestSuite) TestFiresWhenWatchedCreatureTakesDamage() {
+	condition := s.newCondition(dnd5eEvents.ReadyTrigger{
+		Kind:       dnd5eEvents.ReadyTriggerCreatureDamaged,
+		CreatureID: "goblin",
+	})
+	s.Require().NoError(condition.Apply(s.ctx, s.bus))
+
+	err := dnd5eEvents.DamageReceivedTopic.On(s.bus).Publish(s.ctx, dnd5eEvents.DamageReceivedEvent{
+		TargetID: "goblin",
+		Amount:   4,
+	})
+	s.Require().NoError(err)
+
+	s.Len(s.triggers, 1)
+}
+
+func (s *ReadiedActionConditionTestSuite) TestFiresOnWatchedCreatureTurnStart() {
+	condition := s.newCondition(dnd5eEvents.ReadyTrigger{
+		Kind:       dnd5eEvents.ReadyTriggerTurnStart,
+		CreatureID: "goblin",
+	})
+	s.Require().NoError(condition.Apply(s.ctx, s.bus))
+
+	err := dnd5eEvents.TurnStartTopic.On(s.bus).Publish(s.ctx, dnd5eEvents.TurnStartEvent{CharacterID: "goblin"})
+	s.Require().NoError(err)
+
+	s.Len(s.triggers, 1)
+}
+
+func (s *ReadiedActionConditionTestSuite) TestExpiresAtHolderTurnStart() {
+	condition := s.newCondition(dnd5eEvents.ReadyTrigger{Kind: dnd5eEvents.ReadyTriggerCreatureMoves})
+	s.Require().NoError(condition.Apply(s.ctx, s.bus))
+
+	err := dnd5eEvents.TurnStartTopic.On(s.bus).Publish(s.ctx, dnd5eEvents.TurnStartEvent{CharacterID: "fighter"})
+	s.Require().NoError(err)
+
+	s.False(condition.IsApplied())
+	s.Require().Len(s.removed, 1)
+	s.Equal("turn_start", s.removed[0].Reason)
+
+	s.move("goblin")
+	s.Empty(s.triggers, "an expired readied action never fires")
+	s.Empty(s.used, "an expired readied action doesn't consume the reaction")
+}
+
+func (s *ReadiedActionConditionTestSuite) TestApplyRejectsUnknownTrigger() {
+	condition := s.newCondition(dnd5eEvents.ReadyTrigger{Kind: "sneezes"})
+
+	err := condition.Apply(s.ctx, s.bus)
+	s.Require().Error(err)
+	s.False(condition.IsApplied())
+}
+
+func (s *ReadiedActionConditionTestSuite) TestJSONRoundTrip() {
+	condition := s.newCondition(dnd5eEvents.ReadyTrigger{
+		Kind:        dnd5eEvents.ReadyTriggerCreatureMoves,
+		CreatureID:  "goblin",
+		Description: "when it moves",
+	})
+
+	data, err := condition.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := LoadJSON(data)
+	s.Require().NoError(err)
+
+	readied, ok := loaded.(*ReadiedActionCondition)
+	s.Require().True(ok)
+	s.Equal("fighter", readied.CharacterID)
+	s.Equal("goblin", readied.TargetID)
+	s.Equal(refs.Actions.Strike().ID, readied.ActionRef.ID)
+	s.Equal(condition.Trigger, readied.Trigger)
+}
@@ -0,0 +1,395 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// Factory creates a new condition from a CreateFromRefInput. The input has
+// already been validated and its ref parsed by CreateFromRef.
+type Factory func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error)
+
+// Loader restores a condition from the JSON produced by its ToJSON.
+type Loader func(data json.RawMessage) (dnd5eEvents.ConditionBehavior, error)
+
+// Registration describes how a condition is built from data.
+// Create is used by CreateFromRef and Load by LoadJSON; either may be nil
+// when the condition is only ever created in code or never persisted.
+type Registration struct {
+	Create Factory
+	Load   Loader
+}
+
+// registry maps condition ref IDs to their registrations.
+// It is keyed by ID because persisted conditions may carry a non-condition
+// ref type (sneak attack stores a feature ref, shield a spell ref).
+var registry = struct {
+	sync.RWMutex
+	entries map[string]registryEntry
+}{entries: make(map[string]registryEntry)}
+
+// registryEntry keeps the ref a registration was made under
+type registryEntry struct {
+	ref *core.Ref
+	Registration
+}
+
+// Register makes a condition available to CreateFromRef and LoadJSON by ref.
+// Content outside this package (monster abilities, spells, items) uses it to
+// add conditions that can then be applied from data. Returns
+// CodeInvalidArgument for a nil ref, a non-dnd5e module or an empty
+// registration, and CodeAlreadyExists if the ref ID is already registered.
+func Register(ref *core.Ref, reg Registration) error {
+	if ref == nil || ref.ID == "" {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "ref is required")
+	}
+	if ref.Module != refs.Module {
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument, "unsupported module: %s", ref.Module)
+	}
+	if reg.Create == nil && reg.Load == nil {
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument, "registration for %s has no factory or loader", ref.ID)
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	if _, exists := registry.entries[ref.ID]; exists {
+		return rpgerr.Newf(rpgerr.CodeAlreadyExists, "condition already registered: %s", ref.ID)
+	}
+	registry.entries[ref.ID] = registryEntry{ref: ref, Registration: reg}
+	return nil
+}
+
+// Lookup returns the registration for a condition ref, matching on ref ID.
+func Lookup(ref *core.Ref) (Registration, bool) {
+	if ref == nil {
+		return Registration{}, false
+	}
+	return lookupID(ref.ID)
+}
+
+// Registered returns the refs of all registered conditions, sorted by ID.
+func Registered() []*core.Ref {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	result := make([]*core.Ref, 0, len(registry.entries))
+	for _, entry := range registry.entries {
+		result = append(result, entry.ref)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// lookupID returns the registration for a ref ID
+func lookupID(id string) (Registration, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	entry, ok := registry.entries[id]
+	return entry.Registration, ok
+}
+
+// mustRegister registers a built-in condition, panicking on a duplicate
+func mustRegister(ref *core.Ref, reg Registration) {
+	if err := Register(ref, reg); err != nil {
+		panic(err)
+	}
+}
+
+// loadableCondition is a condition that can restore itself from JSON
+type loadableCondition interface {
+	dnd5eEvents.ConditionBehavior
+	loadJSON(data json.RawMessage) error
+}
+
+// loaderFor builds a Loader that restores into the condition returned by newCondition
+func loaderFor[T loadableCondition](name string, newCondition func() T) Loader {
+	return func(data json.RawMessage) (dnd5eEvents.ConditionBehavior, error) {
+		condition := newCondition()
+		if err := condition.loadJSON(data); err != nil {
+			return nil, rpgerr.Wrapf(err, "failed to load %s condition", name)
+		}
+		return condition, nil
+	}
+}
+
+func init() {
+	mustRegister(refs.Conditions.UnarmoredDefense(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return createUnarmoredDefense(input.Config, input.CharacterID, input.SourceRef)
+		},
+		Load: loaderFor("unarmored defense", func() *UnarmoredDefenseCondition { return &UnarmoredDefenseCondition{} }),
+	})
+	mustRegister(refs.Conditions.Raging(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return createRaging(input.Config, input.CharacterID, input.SourceRef)
+		},
+		Load: loaderFor("raging", func() *RagingCondition { return &RagingCondition{} }),
+	})
+	mustRegister(refs.Conditions.BrutalCritical(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return createBrutalCritical(input.Config, input.CharacterID)
+		},
+		Load: loaderFor("brutal critical", func() *BrutalCriticalCondition { return &BrutalCriticalCondition{} }),
+	})
+	mustRegister(refs.Conditions.FightingStyleArchery(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return NewFightingStyleArcheryCondition(input.CharacterID), nil
+		},
+		Load: loaderFor("archery fighting style", func() *FightingStyleArcheryCondition {
+			return NewFightingStyleArcheryCondition("")
+		}),
+	})
+	mustRegister(refs.Conditions.FightingStyleDefense(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return NewFightingStyleDefenseCondition(input.CharacterID), nil
+		},
+		Load: loaderFor("defense fighting style", func() *FightingStyleDefenseCondition {
+			return NewFightingStyleDefenseCondition("")
+		}),
+	})
+	mustRegister(refs.Conditions.FightingStyleDueling(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return NewFightingStyleDuelingCondition(input.CharacterID), nil
+		},
+		Load: loaderFor("dueling fighting style", func() *FightingStyleDuelingCondition {
+			return NewFightingStyleDuelingCondition("")
+		}),
+	})
+	mustRegister(refs.Conditions.FightingStyleGreatWeaponFighting(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return NewFightingStyleGreatWeaponFightingCondition(input.CharacterID, nil), nil
+		},
+		Load: loaderFor("great weapon fighting style", func() *FightingStyleGreatWeaponFightingCondition {
+			return NewFightingStyleGreatWeaponFightingCondition("", nil)
+		}),
+	})
+	mustRegister(refs.Conditions.FightingStyleProtection(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return NewFightingStyleProtectionCondition(input.CharacterID), nil
+		},
+		Load: loaderFor("protection fighting style", func() *FightingStyleProtectionCondition {
+			return NewFightingStyleProtectionCondition("")
+		}),
+	})
+	mustRegister(refs.Conditions.FightingStyleTwoWeaponFighting(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return NewFightingStyleTwoWeaponFightingCondition(input.CharacterID), nil
+		},
+		Load: loaderFor("two-weapon fighting style", func() *FightingStyleTwoWeaponFightingCondition {
+			return NewFightingStyleTwoWeaponFightingCondition("")
+		}),
+	})
+	mustRegister(refs.Conditions.AgonizingBlast(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return NewAgonizingBlastCondition(input.CharacterID), nil
+		},
+		Load: loaderFor("agonizing blast", func() *AgonizingBlastCondition { return NewAgonizingBlastCondition("") }),
+	})
+	mustRegister(refs.Conditions.DevilsSight(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return NewDevilsSightCondition(input.CharacterID), nil
+		},
+		Load: loaderFor("devil's sight", func() *DevilsSightCondition { return NewDevilsSightCondition("") }),
+	})
+	mustRegister(refs.Conditions.CantripScaling(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return NewCantripScalingCondition(CantripScalingInput{CharacterID: input.CharacterID}), nil
+		},
+		Load: loaderFor("cantrip scaling", func() *CantripScalingCondition {
+			return NewCantripScalingCondition(CantripScalingInput{})
+		}),
+	})
+	mustRegister(refs.Conditions.ImprovedCritical(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return createImprovedCritical(input.Config, input.CharacterID)
+		},
+		Load: loaderFor("improved critical", func() *ImprovedCriticalCondition { return &ImprovedCriticalCondition{} }),
+	})
+	mustRegister(refs.Conditions.RecklessAttack(), Registration{
+		Load: loaderFor("reckless attack", func() *RecklessAttackCondition { return &RecklessAttackCondition{} }),
+	})
+	mustRegister(refs.Conditions.MartialArts(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return createMartialArts(input.Config, input.CharacterID)
+		},
+		Load: loaderFor("martial arts", func() *MartialArtsCondition { return &MartialArtsCondition{} }),
+	})
+	mustRegister(refs.Conditions.UnarmoredMovement(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return createUnarmoredMovement(input.Config, input.CharacterID)
+		},
+		Load: loaderFor("unarmored movement", func() *UnarmoredMovementCondition { return &UnarmoredMovementCondition{} }),
+	})
+	// Sneak attack is created by its condition ref but persists its feature ref;
+	// both share the same ID.
+	mustRegister(refs.Conditions.SneakAttack(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return createSneakAttack(input.Config, input.CharacterID)
+		},
+		Load: loaderFor("sneak attack", func() *SneakAttackCondition { return &SneakAttackCondition{} }),
+	})
+	mustRegister(refs.Conditions.JackOfAllTrades(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return createJackOfAllTrades(input.Config, input.CharacterID)
+		},
+		Load: loaderFor("jack of all trades", func() *JackOfAllTradesCondition { return &JackOfAllTradesCondition{} }),
+	})
+	mustRegister(refs.Conditions.Disengaging(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return NewDisengagingCondition(input.CharacterID), nil
+		},
+		Load: loaderFor("disengaging", func() *DisengagingCondition { return &DisengagingCondition{} }),
+	})
+	mustRegister(refs.Conditions.Dodging(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return NewDodgingCondition(input.CharacterID), nil
+		},
+		Load: loaderFor("dodging", func() *DodgingCondition { return &DodgingCondition{} }),
+	})
+	mustRegister(refs.Conditions.Helped(), Registration{
+		Load: loaderFor("helped", func() *HelpedCondition { return &HelpedCondition{} }),
+	})
+	mustRegister(refs.Conditions.Turned(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return createTurned(input.Config, input.CharacterID)
+		},
+		Load: loaderFor("turned", func() *TurnedCondition { return &TurnedCondition{} }),
+	})
+	mustRegister(refs.Conditions.DivineSmite(), Registration{
+		Load: loaderFor("divine smite", func() *DivineSmiteCondition { return &DivineSmiteCondition{} }),
+	})
+	mustRegister(refs.Conditions.WildShaped(), Registration{
+		Load: loaderFor("wild shaped", func() *WildShapedCondition { return &WildShapedCondition{} }),
+	})
+	mustRegister(refs.Conditions.BardicInspiration(), Registration{
+		Load: loaderFor("bardic inspiration", func() *BardicInspirationCondition { return &BardicInspirationCondition{} }),
+	})
+	mustRegister(refs.Conditions.Maneuver(), Registration{
+		Load: loaderFor("maneuver", func() *ManeuverCondition { return &ManeuverCondition{} }),
+	})
+	mustRegister(refs.Conditions.FavoredEnemy(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return createFavoredEnemy(input.Config, input.CharacterID)
+		},
+		Load: loaderFor("favored enemy", func() *FavoredEnemyCondition { return &FavoredEnemyCondition{} }),
+	})
+	mustRegister(refs.Conditions.NaturalExplorer(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return createNaturalExplorer(input.Config, input.CharacterID)
+		},
+		Load: loaderFor("natural explorer", func() *NaturalExplorerCondition { return &NaturalExplorerCondition{} }),
+	})
+	mustRegister(refs.Conditions.DwarvenResilience(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return NewDwarvenResilienceCondition(DwarvenResilienceInput{CharacterID: input.CharacterID}), nil
+		},
+		Load: loaderFor("dwarven resilience", func() *DwarvenResilienceCondition { return &DwarvenResilienceCondition{} }),
+	})
+	mustRegister(refs.Conditions.FeyAncestry(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return NewFeyAncestryCondition(FeyAncestryInput{CharacterID: input.CharacterID}), nil
+		},
+		Load: loaderFor("fey ancestry", func() *FeyAncestryCondition { return &FeyAncestryCondition{} }),
+	})
+	mustRegister(refs.Conditions.HalflingLucky(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return NewHalflingLuckyCondition(HalflingLuckyInput{CharacterID: input.CharacterID}), nil
+		},
+		Load: loaderFor("halfling lucky", func() *HalflingLuckyCondition { return &HalflingLuckyCondition{} }),
+	})
+	mustRegister(refs.Conditions.GnomeCunning(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return NewGnomeCunningCondition(GnomeCunningInput{CharacterID: input.CharacterID}), nil
+		},
+		Load: loaderFor("gnome cunning", func() *GnomeCunningCondition { return &GnomeCunningCondition{} }),
+	})
+	mustRegister(refs.Conditions.ReadiedAction(), Registration{
+		Load: loaderFor("readied action", func() *ReadiedActionCondition { return &ReadiedActionCondition{} }),
+	})
+	mustRegister(refs.Conditions.Unconscious(), Registration{
+		Load: loaderFor("unconscious", func() *UnconsciousCondition { return &UnconsciousCondition{} }),
+	})
+	mustRegister(refs.Conditions.OpportunityAttack(), Registration{
+		Load: loaderFor("opportunity attack", func() *OpportunityAttackCondition { return &OpportunityAttackCondition{} }),
+	})
+	mustRegister(refs.Conditions.Sentinel(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return NewSentinelCondition(SentinelInput{CharacterID: input.CharacterID}), nil
+		},
+		Load: loaderFor("sentinel", func() *SentinelCondition { return &SentinelCondition{} }),
+	})
+	mustRegister(refs.Conditions.Mobile(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return NewMobileCondition(MobileInput{CharacterID: input.CharacterID}), nil
+		},
+		Load: loaderFor("mobile", func() *MobileCondition { return &MobileCondition{} }),
+	})
+	mustRegister(refs.Conditions.Grappled(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return createGrappled(input.Config, input.CharacterID)
+		},
+		Load: loaderFor("grappled", func() *GrappledCondition { return &GrappledCondition{} }),
+	})
+	mustRegister(refs.Conditions.Restrained(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return createRestrained(input.Config, input.CharacterID)
+		},
+		Load: loaderFor("restrained", func() *RestrainedCondition { return &RestrainedCondition{} }),
+	})
+	mustRegister(refs.Conditions.Prone(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return NewProneCondition(input.CharacterID), nil
+		},
+		Load: loaderFor("prone", func() *ProneCondition { return &ProneCondition{} }),
+	})
+	mustRegister(refs.Conditions.Squeezing(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return NewSqueezingCondition(input.CharacterID), nil
+		},
+		Load: loaderFor("squeezing", func() *SqueezingCondition { return &SqueezingCondition{} }),
+	})
+	mustRegister(refs.Conditions.Concentrating(), Registration{
+		Load: loaderFor("concentrating", func() *ConcentratingCondition { return &ConcentratingCondition{} }),
+	})
+	mustRegister(refs.Conditions.HoldPerson(), Registration{
+		Load: loaderFor("hold person", func() *HoldPersonCondition { return &HoldPersonCondition{} }),
+	})
+	mustRegister(refs.Conditions.SpiritualWeapon(), Registration{
+		Load: loaderFor("spiritual weapon", func() *SpiritualWeaponCondition { return &SpiritualWeaponCondition{} }),
+	})
+	mustRegister(refs.Conditions.Exhaustion(), Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			return createExhaustion(input.Config, input.CharacterID)
+		},
+		Load: loaderFor("exhaustion", func() *ExhaustionCondition { return &ExhaustionCondition{} }),
+	})
+	mustRegister(refs.Spells.Shield(), Registration{
+		Load: loaderFor("shield spell", func() *ShieldSpellCondition { return &ShieldSpellCondition{} }),
+	})
+
+	for id := range standardConditions {
+		ref := &core.Ref{Module: refs.Module, Type: refs.TypeConditions, ID: id}
+		mustRegister(ref, Registration{
+			Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+				return createStandard(ref, input.Config, input.CharacterID)
+			},
+			Load: func(data json.RawMessage) (dnd5eEvents.ConditionBehavior, error) {
+				condition, _, err := loadStandardCondition(ref.ID, data)
+				return condition, err
+			},
+		})
+	}
+}
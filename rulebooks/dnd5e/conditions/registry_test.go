@@ -0,0 +1,221 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// webbedCondition is a minimal data-defined condition used to exercise the registry
+type webbedCondition struct {
+	Ref         *core.Ref `json:"ref"`
+	CharacterID string    `json:"character_id"`
+	DC          int       `json:"dc"`
+	applied     bool
+}
+
+func (w *webbedCondition) IsApplied() bool { return w.applied }
+
+func (w *webbedCondition) Apply(_ context.Context, _ events.EventBus) error {
+	w.applied = true
+	return nil
+}
+
+func (w *webbedCondition) Remove(_ context.Context, _ events.EventBus) error {
+	w.applied = false
+	return nil
+}
+
+func (w *webbedCondition) ToJSON() (json.RawMessage, error) {
+	return json.Marshal(w)
+}
+
+type RegistryTestSuite struct {
+	suite.Suite
+	webbedRef *core.Ref
+}
+
+func TestRegistryTestSuite(t *testing.T) {
+	suite.Run(t, new(RegistryTestSuite))
+}
+
+func (s *RegistryTestSuite) SetupTest() {
+	s.webbedRef = &core.Ref{Module: refs.Module, Type: refs.TypeConditions, ID: "test_webbed"}
+}
+
+func (s *RegistryTestSuite) TearDownTest() {
+	registry.Lock()
+	delete(registry.entries, s.webbedRef.ID)
+	registry.Unlock()
+}
+
+func (s *RegistryTestSuite) registerWebbed() {
+	err := Register(s.webbedRef, Registration{
+		Create: func(input *CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+			webbed := &webbedCondition{Ref: s.webbedRef, CharacterID: input.CharacterID}
+			if len(input.Config) > 0 {
+				if err := json.Unmarshal(input.Config, webbed); err != nil {
+					return nil, err
+				}
+			}
+			return webbed, nil
+		},
+		Load: func(data json.RawMessage) (dnd5eEvents.ConditionBehavior, error) {
+			webbed := &webbedCondition{}
+			if err := json.Unmarshal(data, webbed); err != nil {
+				return nil, err
+			}
+			return webbed, nil
+		},
+	})
+	s.Require().NoError(err)
+}
+
+func (s *RegistryTestSuite) TestRegisteredConditionCreatesAndLoadsByRef() {
+	s.registerWebbed()
+
+	output, err := CreateFromRef(&CreateFromRefInput{
+		Ref:         s.webbedRef.String(),
+		Config:      json.RawMessage(`{"dc": 12}`),
+		CharacterID: "hero-1",
+	})
+	s.Require().NoError(err)
+
+	webbed, ok := output.Condition.(*webbedCondition)
+	s.Require().True(ok)
+	s.Equal("hero-1", webbed.CharacterID)
+	s.Equal(12, webbed.DC)
+
+	data, err := webbed.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := LoadJSON(data)
+	s.Require().NoError(err)
+	s.Equal(webbed, loaded)
+}
+
+func (s *RegistryTestSuite) TestRegisterRejectsDuplicate() {
+	s.registerWebbed()
+
+	err := Register(s.webbedRef, Registration{Load: func(json.RawMessage) (dnd5eEvents.ConditionBehavior, error) {
+		return nil, nil
+	}})
+	s.Require().Error(err)
+	s.Equal(rpgerr.CodeAlreadyExists, rpgerr.GetCode(err))
+
+	err = Register(refs.Conditions.Prone(), Registration{Create: func(*CreateFromRefInput) (dnd5eEvents.ConditionBehavior, error) {
+		return nil, nil
+	}})
+	s.Require().Error(err)
+	s.Equal(rpgerr.CodeAlreadyExists, rpgerr.GetCode(err))
+}
+
+func (s *RegistryTestSuite) TestRegisterRejectsInvalid() {
+	testCases := []struct {
+		name string
+		ref  *core.Ref
+		reg  Registration
+	}{
+		{name: "nil ref", ref: nil, reg: Registration{Load: LoadJSON}},
+		{
+			name: "foreign module",
+			ref:  &core.Ref{Module: "homebrew", Type: refs.TypeConditions, ID: "test_webbed"},
+			reg:  Registration{Load: LoadJSON},
+		},
+		{name: "empty registration", ref: s.webbedRef, reg: Registration{}},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			err := Register(tc.ref, tc.reg)
+			s.Require().Error(err)
+			s.Equal(rpgerr.CodeInvalidArgument, rpgerr.GetCode(err))
+		})
+	}
+}
+
+func (s *RegistryTestSuite) TestBuiltinsAreRegistered() {
+	for _, ref := range []*core.Ref{
+		refs.Conditions.Raging(),
+		refs.Conditions.Prone(),
+		refs.Conditions.Grappled(),
+		refs.Conditions.Blinded(),
+		refs.Spells.Shield(),
+	} {
+		reg, ok := Lookup(ref)
+		s.True(ok, ref.ID)
+		s.NotNil(reg.Load, ref.ID)
+	}
+
+	registered := Registered()
+	s.NotEmpty(registered)
+	for i := 1; i < len(registered); i++ {
+		s.Less(registered[i-1].ID, registered[i].ID)
+	}
+}
+
+func (s *RegistryTestSuite) TestCreateFromRef_Prone() {
+	output, err := CreateFromRef(&CreateFromRefInput{
+		Ref:         refs.Conditions.Prone().String(),
+		CharacterID: "goblin-1",
+	})
+	s.Require().NoError(err)
+
+	prone, ok := output.Condition.(*ProneCondition)
+	s.Require().True(ok)
+	s.Equal("goblin-1", prone.CharacterID)
+}
+
+func (s *RegistryTestSuite) TestCreateFromRef_Grappled() {
+	output, err := CreateFromRef(&CreateFromRefInput{
+		Ref:         refs.Conditions.Grappled().String(),
+		Config:      json.RawMessage(`{"grappler_id": "ogre-1"}`),
+		CharacterID: "hero-1",
+	})
+	s.Require().NoError(err)
+
+	grappled, ok := output.Condition.(*GrappledCondition)
+	s.Require().True(ok)
+	s.Equal("hero-1", grappled.CharacterID)
+	s.Equal("ogre-1", grappled.GrapplerID)
+	s.Equal(GrappleEscape(), grappled.Escape)
+}
+
+func (s *RegistryTestSuite) TestCreateFromRef_RestrainedRequiresEscape() {
+	_, err := CreateFromRef(&CreateFromRefInput{
+		Ref:         refs.Conditions.Restrained().String(),
+		CharacterID: "hero-1",
+	})
+	s.Require().Error(err)
+
+	output, err := CreateFromRef(&CreateFromRefInput{
+		Ref:         refs.Conditions.Restrained().String(),
+		Config:      json.RawMessage(`{"escape": {"method": "check", "ability": "str", "dc": 10}}`),
+		CharacterID: "hero-1",
+	})
+	s.Require().NoError(err)
+
+	restrained, ok := output.Condition.(*RestrainedCondition)
+	s.Require().True(ok)
+	s.Equal(10, restrained.Escape.DC)
+}
+
+func (s *RegistryTestSuite) TestCreateFromRef_LoadOnlyConditionIsUnknown() {
+	_, err := CreateFromRef(&CreateFromRefInput{
+		Ref:         refs.Conditions.Helped().String(),
+		CharacterID: "hero-1",
+	})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "unknown condition")
+}
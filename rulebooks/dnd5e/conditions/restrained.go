@@ -0,0 +1,269 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// RestrainedConditionData is the serializable form of the restrained condition.
+// This is stored by the game server as an opaque JSON blob.
+type RestrainedConditionData struct {
+	Ref             *core.Ref    `json:"ref"`
+	CharacterID     string       `json:"character_id"`
+	SourceID        string       `json:"source_id,omitempty"`
+	SourceRef       *core.Ref    `json:"source_ref,omitempty"`
+	Escape          EscapeConfig `json:"escape"`
+	LastEscapeRound int          `json:"last_escape_round,omitempty"`
+}
+
+// RestrainedConditionConfig configures a new restrained condition.
+type RestrainedConditionConfig struct {
+	// CharacterID is the restrained creature
+	CharacterID string
+
+	// SourceID is the creature holding the restraint (for a contest), if any
+	SourceID string
+
+	// SourceRef is the effect causing the restraint (e.g. a net or the Web spell)
+	SourceRef *core.Ref
+
+	// Escape is how the creature escapes (e.g. a DC 10 Strength check for a net)
+	Escape EscapeConfig
+}
+
+// Validate validates the config.
+func (c *RestrainedConditionConfig) Validate() error {
+	if c == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "RestrainedConditionConfig is nil")
+	}
+	if c.CharacterID == "" {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "CharacterID is required")
+	}
+	if c.Escape.Method == dnd5eEvents.EscapeMethodContest && c.SourceID == "" {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "SourceID is required for a contested escape")
+	}
+	return c.Escape.Validate()
+}
+
+// RestrainedCondition represents a creature held fast by a net, web, or grab.
+//
+// While applied, attacks against the creature have advantage, the creature's
+// own attacks have disadvantage, and it has disadvantage on DEX saves. Its speed
+// of 0 is enforced by the action economy through the restrained condition ref.
+// The creature may call AttemptEscape each turn; on success the condition
+// publishes a ConditionRemovedEvent and removes itself.
+type RestrainedCondition struct {
+	CharacterID     string
+	SourceID        string
+	SourceRef       *core.Ref
+	Escape          EscapeConfig
+	LastEscapeRound int
+	bus             events.EventBus
+	subscriptionIDs []string
+}
+
+// Ensure RestrainedCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*RestrainedCondition)(nil)
+
+// NewRestrainedCondition creates a restrained condition from the config.
+func NewRestrainedCondition(config *RestrainedConditionConfig) (*RestrainedCondition, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &RestrainedCondition{
+		CharacterID: config.CharacterID,
+		SourceID:    config.SourceID,
+		SourceRef:   config.SourceRef,
+		Escape:      config.Escape,
+	}, nil
+}
+
+// IsApplied returns true if this condition is currently applied.
+func (r *RestrainedCondition) IsApplied() bool {
+	return r.bus != nil
+}
+
+// Apply subscribes this condition to AttackChain and SavingThrowChain.
+func (r *RestrainedCondition) Apply(ctx context.Context, bus events.EventBus) error {
+	if r.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "restrained condition already applied")
+	}
+	r.bus = bus
+
+	attackSubID, err := dnd5eEvents.AttackChain.On(bus).SubscribeWithChain(ctx, r.onAttackChain)
+	if err != nil {
+		r.bus = nil
+		return rpgerr.Wrap(err, "failed to subscribe to attack chain")
+	}
+	r.subscriptionIDs = append(r.subscriptionIDs, attackSubID)
+
+	saveSubID, err := dnd5eEvents.SavingThrowChain.On(bus).SubscribeWithChain(ctx, r.onSavingThrowChain)
+	if err != nil {
+		_ = r.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to saving throw chain")
+	}
+	r.subscriptionIDs = append(r.subscriptionIDs, saveSubID)
+
+	return nil
+}
+
+// Remove unsubscribes this condition from all events.
+func (r *RestrainedCondition) Remove(ctx context.Context, bus events.EventBus) error {
+	if r.bus == nil {
+		return nil // Not applied, nothing to remove
+	}
+
+	total := len(r.subscriptionIDs)
+	var errs []error
+	for _, subID := range r.subscriptionIDs {
+		if err := bus.Unsubscribe(ctx, subID); err != nil {
+			errs = append(errs, fmt.Errorf("unsubscribe %s: %w", subID, err))
+		}
+	}
+
+	r.subscriptionIDs = nil
+	r.bus = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unsubscribe %d/%d subscriptions: %w", len(errs), total, errors.Join(errs...))
+	}
+	return nil
+}
+
+// AttemptEscape makes the creature's escape attempt for this turn.
+//
+// Returns CodeInvalidState if the condition isn't applied, CodeResourceExhausted
+// if the creature already tried this round, and the economy's error if the
+// escape costs an action the creature doesn't have. On success the condition
+// publishes a ConditionRemovedEvent with reason "escaped" and removes itself.
+func (r *RestrainedCondition) AttemptEscape(ctx context.Context, input *EscapeAttemptInput) (*EscapeResult, error) {
+	if !r.IsApplied() {
+		return nil, rpgerr.New(rpgerr.CodeInvalidState, "restrained condition is not applied")
+	}
+
+	attempt := &escapeAttempt{
+		characterID:      r.CharacterID,
+		sourceID:         r.SourceID,
+		conditionRef:     refs.Conditions.Restrained(),
+		config:           r.Escape,
+		lastAttemptRound: r.LastEscapeRound,
+	}
+	result, err := attempt.resolve(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	r.LastEscapeRound = attempt.lastAttemptRound
+
+	if !result.Success {
+		return result, nil
+	}
+	if err := publishEscaped(ctx, r.bus, r.CharacterID, refs.Conditions.Restrained()); err != nil {
+		return nil, err
+	}
+	return result, r.Remove(ctx, r.bus)
+}
+
+// ToJSON converts the condition to JSON for persistence.
+func (r *RestrainedCondition) ToJSON() (json.RawMessage, error) {
+	data := RestrainedConditionData{
+		Ref:             refs.Conditions.Restrained(),
+		CharacterID:     r.CharacterID,
+		SourceID:        r.SourceID,
+		SourceRef:       r.SourceRef,
+		Escape:          r.Escape,
+		LastEscapeRound: r.LastEscapeRound,
+	}
+	return json.Marshal(data)
+}
+
+// loadJSON loads restrained condition state from JSON.
+func (r *RestrainedCondition) loadJSON(data json.RawMessage) error {
+	var restrainedData RestrainedConditionData
+	if err := json.Unmarshal(data, &restrainedData); err != nil {
+		return rpgerr.Wrap(err, "failed to unmarshal restrained data")
+	}
+
+	r.CharacterID = restrainedData.CharacterID
+	r.SourceID = restrainedData.SourceID
+	r.SourceRef = restrainedData.SourceRef
+	r.Escape = restrainedData.Escape
+	r.LastEscapeRound = restrainedData.LastEscapeRound
+	return nil
+}
+
+// onAttackChain grants advantage on attacks against the restrained creature
+// and imposes disadvantage on its own attacks.
+func (r *RestrainedCondition) onAttackChain(
+	_ context.Context,
+	event dnd5eEvents.AttackChainEvent,
+	c chain.Chain[dnd5eEvents.AttackChainEvent],
+) (chain.Chain[dnd5eEvents.AttackChainEvent], error) {
+	source := dnd5eEvents.AttackModifierSource{
+		SourceRef: refs.Conditions.Restrained(),
+		SourceID:  r.CharacterID,
+		Reason:    "Restrained",
+	}
+
+	switch r.CharacterID {
+	case event.TargetID:
+		modifyAttack := func(_ context.Context, e dnd5eEvents.AttackChainEvent) (dnd5eEvents.AttackChainEvent, error) {
+			e.AdvantageSources = append(e.AdvantageSources, source)
+			return e, nil
+		}
+		if err := c.Add(combat.StageConditions, "restrained_target_advantage", modifyAttack); err != nil {
+			return c, rpgerr.Wrapf(err, "failed to add restrained advantage modifier for character %s", r.CharacterID)
+		}
+	case event.AttackerID:
+		modifyAttack := func(_ context.Context, e dnd5eEvents.AttackChainEvent) (dnd5eEvents.AttackChainEvent, error) {
+			e.DisadvantageSources = append(e.DisadvantageSources, source)
+			return e, nil
+		}
+		if err := c.Add(combat.StageConditions, "restrained_attacker_disadvantage", modifyAttack); err != nil {
+			return c, rpgerr.Wrapf(err, "failed to add restrained disadvantage modifier for character %s", r.CharacterID)
+		}
+	}
+
+	return c, nil
+}
+
+// onSavingThrowChain imposes disadvantage on the restrained creature's DEX saves.
+func (r *RestrainedCondition) onSavingThrowChain(
+	_ context.Context,
+	event *dnd5eEvents.SavingThrowChainEvent,
+	c chain.Chain[*dnd5eEvents.SavingThrowChainEvent],
+) (chain.Chain[*dnd5eEvents.SavingThrowChainEvent], error) {
+	if event.SaverID != r.CharacterID || event.Ability != abilities.DEX {
+		return c, nil
+	}
+
+	modifySave := func(_ context.Context, e *dnd5eEvents.SavingThrowChainEvent) (*dnd5eEvents.SavingThrowChainEvent, error) {
+		e.DisadvantageSources = append(e.DisadvantageSources, dnd5eEvents.SaveModifierSource{
+			Name:       "Restrained",
+			SourceType: "condition",
+			SourceRef:  refs.Conditions.Restrained(),
+			EntityID:   r.CharacterID,
+		})
+		return e, nil
+	}
+
+	if err := c.Add(combat.StageConditions, "restrained_dex_disadvantage", modifySave); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to add restrained DEX disadvantage modifier for character %s", r.CharacterID)
+	}
+
+	return c, nil
+}
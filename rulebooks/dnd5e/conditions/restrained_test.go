@@ -0,0 +1,251 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+type RestrainedConditionTestSuite struct {
+	suite.Suite
+	ctx    context.Context
+	ctrl   *gomock.Controller
+	roller *mock_dice.MockRoller
+	bus    events.EventBus
+}
+
+func TestRestrainedConditionSuite(t *testing.T) {
+	suite.Run(t, new(RestrainedConditionTestSuite))
+}
+
+func (s *RestrainedConditionTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.ctrl = gomock.NewController(s.T())
+	s.roller = mock_dice.NewMockRoller(s.ctrl)
+	s.bus = events.NewEventBus()
+}
+
+func (s *RestrainedConditionTestSuite) SetupSubTest() {
+	s.SetupTest()
+}
+
+func (s *RestrainedConditionTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// netCondition returns an applied restraint escaped with a DC 10 Strength check.
+func (s *RestrainedConditionTestSuite) netCondition() *RestrainedCondition {
+	condition, err := NewRestrainedCondition(&RestrainedConditionConfig{
+		CharacterID: "fighter",
+		Escape: EscapeConfig{
+			Method:         dnd5eEvents.EscapeMethodCheck,
+			Ability:        abilities.STR,
+			DC:             10,
+			RequiresAction: true,
+		},
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(condition.Apply(s.ctx, s.bus))
+	return condition
+}
+
+func (s *RestrainedConditionTestSuite) TestConfigValidation() {
+	s.Run("missing character", func() {
+		_, err := NewRestrainedCondition(&RestrainedConditionConfig{
+			Escape: EscapeConfig{Method: dnd5eEvents.EscapeMethodSave, Ability: abilities.STR, DC: 12},
+		})
+		s.Error(err)
+	})
+
+	s.Run("contest needs a source", func() {
+		_, err := NewRestrainedCondition(&RestrainedConditionConfig{
+			CharacterID: "fighter",
+			Escape:      EscapeConfig{Method: dnd5eEvents.EscapeMethodContest},
+		})
+		s.Error(err)
+	})
+
+	s.Run("save needs a DC", func() {
+		_, err := NewRestrainedCondition(&RestrainedConditionConfig{
+			CharacterID: "fighter",
+			Escape:      EscapeConfig{Method: dnd5eEvents.EscapeMethodSave, Ability: abilities.STR},
+		})
+		s.Error(err)
+	})
+
+	s.Run("unknown method", func() {
+		_, err := NewRestrainedCondition(&RestrainedConditionConfig{CharacterID: "fighter"})
+		s.Error(err)
+	})
+}
+
+func (s *RestrainedConditionTestSuite) TestEscapeWithCheck() {
+	s.Run("check meets DC", func() {
+		condition := s.netCondition()
+		economy := combat.NewActionEconomy()
+
+		var checked *dnd5eEvents.AbilityCheckChainEvent
+		_, err := dnd5eEvents.AbilityCheckChain.On(s.bus).SubscribeWithChain(s.ctx,
+			func(
+				_ context.Context,
+				e *dnd5eEvents.AbilityCheckChainEvent,
+				c chain.Chain[*dnd5eEvents.AbilityCheckChainEvent],
+			) (chain.Chain[*dnd5eEvents.AbilityCheckChainEvent], error) {
+				checked = e
+				return c, nil
+			})
+		s.Require().NoError(err)
+
+		s.roller.EXPECT().Roll(s.ctx, 20).Return(8, nil)
+		result, err := condition.AttemptEscape(s.ctx, &EscapeAttemptInput{
+			Round:    1,
+			Modifier: 2,
+			Roller:   s.roller,
+			EventBus: s.bus,
+			Economy:  economy,
+		})
+		s.Require().NoError(err)
+		s.True(result.Success)
+		s.Equal(10, result.DC)
+		s.False(condition.IsApplied())
+		s.Equal(0, economy.ActionsRemaining)
+
+		s.Require().NotNil(checked)
+		s.Equal("fighter", checked.CheckerID)
+		s.Equal(abilities.STR, checked.Ability)
+		s.Equal(refs.Conditions.Restrained(), checked.Cause.EffectRef)
+	})
+
+	s.Run("check below DC", func() {
+		condition := s.netCondition()
+
+		s.roller.EXPECT().Roll(s.ctx, 20).Return(7, nil)
+		result, err := condition.AttemptEscape(s.ctx, &EscapeAttemptInput{
+			Round:    1,
+			Modifier: 2,
+			Roller:   s.roller,
+			EventBus: s.bus,
+			Economy:  combat.NewActionEconomy(),
+		})
+		s.Require().NoError(err)
+		s.False(result.Success)
+		s.True(condition.IsApplied())
+	})
+}
+
+func (s *RestrainedConditionTestSuite) TestEscapeWithSave() {
+	condition, err := NewRestrainedCondition(&RestrainedConditionConfig{
+		CharacterID: "fighter",
+		SourceID:    "roper",
+		Escape:      EscapeConfig{Method: dnd5eEvents.EscapeMethodSave, Ability: abilities.STR, DC: 15},
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(condition.Apply(s.ctx, s.bus))
+
+	var cause dnd5eEvents.SaveCause
+	_, err = dnd5eEvents.SavingThrowChain.On(s.bus).SubscribeWithChain(s.ctx,
+		func(
+			_ context.Context,
+			e *dnd5eEvents.SavingThrowChainEvent,
+			c chain.Chain[*dnd5eEvents.SavingThrowChainEvent],
+		) (chain.Chain[*dnd5eEvents.SavingThrowChainEvent], error) {
+			cause = e.Cause
+			return c, nil
+		})
+	s.Require().NoError(err)
+
+	var attempts []dnd5eEvents.EscapeAttemptedEvent
+	_, err = dnd5eEvents.EscapeAttemptedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, e dnd5eEvents.EscapeAttemptedEvent) error {
+			attempts = append(attempts, e)
+			return nil
+		})
+	s.Require().NoError(err)
+
+	// No action required, so no economy is needed
+	s.roller.EXPECT().Roll(s.ctx, 20).Return(12, nil)
+	result, err := condition.AttemptEscape(s.ctx, &EscapeAttemptInput{
+		Round:    2,
+		Modifier: 3,
+		Roller:   s.roller,
+		EventBus: s.bus,
+	})
+	s.Require().NoError(err)
+	s.True(result.Success)
+	s.Equal(dnd5eEvents.SaveTriggerCondition, cause.Trigger)
+	s.Equal("roper", cause.InstigatorID)
+
+	s.Require().Len(attempts, 1)
+	s.Equal(dnd5eEvents.EscapeMethodSave, attempts[0].Method)
+	s.Equal(15, attempts[0].Total)
+	s.Equal(15, attempts[0].DC)
+	s.Equal(2, attempts[0].Round)
+}
+
+func (s *RestrainedConditionTestSuite) TestAttackAndSaveModifiers() {
+	condition := s.netCondition()
+
+	attack := func(attackerID, targetID string) dnd5eEvents.AttackChainEvent {
+		event := dnd5eEvents.AttackChainEvent{AttackerID: attackerID, TargetID: targetID}
+		attackChain := events.NewStagedChain[dnd5eEvents.AttackChainEvent](combat.ModifierStages)
+		modified, err := dnd5eEvents.AttackChain.On(s.bus).PublishWithChain(s.ctx, event, attackChain)
+		s.Require().NoError(err)
+		result, err := modified.Execute(s.ctx, event)
+		s.Require().NoError(err)
+		return result
+	}
+
+	against := attack("goblin", "fighter")
+	s.Len(against.AdvantageSources, 1)
+	s.Empty(against.DisadvantageSources)
+
+	by := attack("fighter", "goblin")
+	s.Empty(by.AdvantageSources)
+	s.Len(by.DisadvantageSources, 1)
+
+	s.Empty(attack("goblin", "rogue").AdvantageSources)
+
+	save := &dnd5eEvents.SavingThrowChainEvent{SaverID: "fighter", Ability: abilities.DEX}
+	saveChain := events.NewStagedChain[*dnd5eEvents.SavingThrowChainEvent](combat.ModifierStages)
+	modified, err := dnd5eEvents.SavingThrowChain.On(s.bus).PublishWithChain(s.ctx, save, saveChain)
+	s.Require().NoError(err)
+	saveResult, err := modified.Execute(s.ctx, save)
+	s.Require().NoError(err)
+	s.True(saveResult.HasDisadvantage())
+
+	// Escaping removes the modifiers
+	s.Require().NoError(condition.Remove(s.ctx, s.bus))
+	s.Empty(attack("goblin", "fighter").AdvantageSources)
+}
+
+func (s *RestrainedConditionTestSuite) TestToJSONRoundTrip() {
+	condition := s.netCondition()
+	condition.SourceRef = refs.Weapons.Net()
+	condition.LastEscapeRound = 2
+
+	data, err := condition.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := LoadJSON(data)
+	s.Require().NoError(err)
+
+	restrained, ok := loaded.(*RestrainedCondition)
+	s.Require().True(ok)
+	s.Equal("fighter", restrained.CharacterID)
+	s.Equal(condition.Escape, restrained.Escape)
+	s.Equal(2, restrained.LastEscapeRound)
+	s.Equal(refs.Weapons.Net().ID, restrained.SourceRef.ID)
+}
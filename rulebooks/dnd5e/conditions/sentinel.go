@@ -0,0 +1,146 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// SentinelData is the JSON structure for persisting sentinel condition state
+type SentinelData struct {
+	Ref         *core.Ref `json:"ref"`
+	CharacterID string    `json:"character_id"`
+}
+
+// SentinelCondition represents the Sentinel feat. When the character hits a
+// creature with an opportunity attack, the creature's speed becomes 0 for the
+// rest of the turn, which stops its movement (see combat.MoveEntity).
+//
+// The feat's other benefits (opportunity attacks despite Disengage, and the
+// reaction attack when a nearby creature attacks someone else) aren't modeled.
+type SentinelCondition struct {
+	CharacterID     string
+	bus             events.EventBus
+	subscriptionIDs []string
+}
+
+// Ensure SentinelCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*SentinelCondition)(nil)
+
+// SentinelInput provides configuration for creating a sentinel condition
+type SentinelInput struct {
+	CharacterID string // ID of the character with the feat
+}
+
+// NewSentinelCondition creates a sentinel condition from input
+func NewSentinelCondition(input SentinelInput) *SentinelCondition {
+	return &SentinelCondition{
+		CharacterID: input.CharacterID,
+	}
+}
+
+// IsApplied returns true if this condition is currently applied
+func (s *SentinelCondition) IsApplied() bool {
+	return s.bus != nil
+}
+
+// Apply subscribes to OpportunityAttackChain to stop creatures the character hits
+func (s *SentinelCondition) Apply(ctx context.Context, bus events.EventBus) error {
+	if s.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "sentinel condition already applied")
+	}
+	s.bus = bus
+
+	subID, err := dnd5eEvents.OpportunityAttackChain.On(bus).SubscribeWithChain(ctx, s.onOpportunityAttackChain)
+	if err != nil {
+		s.bus = nil
+		return rpgerr.Wrap(err, "failed to subscribe to opportunity attack chain")
+	}
+	s.subscriptionIDs = append(s.subscriptionIDs, subID)
+
+	return nil
+}
+
+// Remove unsubscribes this condition from events
+func (s *SentinelCondition) Remove(ctx context.Context, bus events.EventBus) error {
+	if s.bus == nil {
+		return nil // Not applied, nothing to remove
+	}
+
+	total := len(s.subscriptionIDs)
+	var errs []error
+	for _, subID := range s.subscriptionIDs {
+		if err := bus.Unsubscribe(ctx, subID); err != nil {
+			errs = append(errs, fmt.Errorf("unsubscribe %s: %w", subID, err))
+		}
+	}
+
+	s.subscriptionIDs = nil
+	s.bus = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unsubscribe %d/%d subscriptions: %w", len(errs), total, errors.Join(errs...))
+	}
+	return nil
+}
+
+// ToJSON converts the condition to JSON for persistence
+func (s *SentinelCondition) ToJSON() (json.RawMessage, error) {
+	data := SentinelData{
+		Ref:         refs.Conditions.Sentinel(),
+		CharacterID: s.CharacterID,
+	}
+	return json.Marshal(data)
+}
+
+// loadJSON loads sentinel condition state from JSON
+func (s *SentinelCondition) loadJSON(data json.RawMessage) error {
+	var sentinelData SentinelData
+	if err := json.Unmarshal(data, &sentinelData); err != nil {
+		return rpgerr.Wrap(err, "failed to unmarshal sentinel data")
+	}
+
+	s.CharacterID = sentinelData.CharacterID
+	return nil
+}
+
+// onOpportunityAttackChain reduces the target's speed to 0 when the character's OA hits
+func (s *SentinelCondition) onOpportunityAttackChain(
+	_ context.Context,
+	event *dnd5eEvents.OpportunityAttackChainEvent,
+	c chain.Chain[*dnd5eEvents.OpportunityAttackChainEvent],
+) (chain.Chain[*dnd5eEvents.OpportunityAttackChainEvent], error) {
+	if event.AttackerID != s.CharacterID || !event.Hit {
+		return c, nil
+	}
+
+	stopTarget := func(
+		_ context.Context, e *dnd5eEvents.OpportunityAttackChainEvent,
+	) (*dnd5eEvents.OpportunityAttackChainEvent, error) {
+		e.SpeedZeroSources = append(e.SpeedZeroSources, dnd5eEvents.MovementModifierSource{
+			Name:       "Sentinel",
+			SourceType: "feat",
+			SourceRef:  refs.Conditions.Sentinel(),
+			EntityID:   s.CharacterID,
+		})
+		return e, nil
+	}
+
+	if err := c.Add(combat.StageFeatures, "sentinel", stopTarget); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to add sentinel modifier for character %s", s.CharacterID)
+	}
+
+	return c, nil
+}
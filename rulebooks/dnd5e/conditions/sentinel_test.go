@@ -0,0 +1,76 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+type SentinelConditionTestSuite struct {
+	suite.Suite
+	ctx       context.Context
+	bus       events.EventBus
+	condition *SentinelCondition
+}
+
+func TestSentinelConditionSuite(t *testing.T) {
+	suite.Run(t, new(SentinelConditionTestSuite))
+}
+
+func (s *SentinelConditionTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.condition = NewSentinelCondition(SentinelInput{CharacterID: "fighter"})
+	s.Require().NoError(s.condition.Apply(s.ctx, s.bus))
+}
+
+func (s *SentinelConditionTestSuite) opportunityAttack(attackerID string, hit bool) *dnd5eEvents.OpportunityAttackChainEvent {
+	event := &dnd5eEvents.OpportunityAttackChainEvent{AttackerID: attackerID, TargetID: "goblin", Hit: hit}
+	oaChain := events.NewStagedChain[*dnd5eEvents.OpportunityAttackChainEvent](combat.ModifierStages)
+	modifiedChain, err := dnd5eEvents.OpportunityAttackChain.On(s.bus).PublishWithChain(s.ctx, event, oaChain)
+	s.Require().NoError(err)
+	final, err := modifiedChain.Execute(s.ctx, event)
+	s.Require().NoError(err)
+	return final
+}
+
+func (s *SentinelConditionTestSuite) TestHitStopsTarget() {
+	final := s.opportunityAttack("fighter", true)
+	s.True(final.StopsMovement())
+	s.Require().Len(final.SpeedZeroSources, 1)
+	s.Equal(refs.Conditions.Sentinel(), final.SpeedZeroSources[0].SourceRef)
+}
+
+func (s *SentinelConditionTestSuite) TestMissDoesNothing() {
+	s.False(s.opportunityAttack("fighter", false).StopsMovement())
+}
+
+func (s *SentinelConditionTestSuite) TestOtherAttackersIgnored() {
+	s.False(s.opportunityAttack("rogue", true).StopsMovement())
+}
+
+func (s *SentinelConditionTestSuite) TestRemove() {
+	s.Require().NoError(s.condition.Remove(s.ctx, s.bus))
+	s.False(s.condition.IsApplied())
+	s.False(s.opportunityAttack("fighter", true).StopsMovement())
+}
+
+func (s *SentinelConditionTestSuite) TestJSONRoundTrip() {
+	data, err := s.condition.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := LoadJSON(data)
+	s.Require().NoError(err)
+	sentinel, ok := loaded.(*SentinelCondition)
+	s.Require().True(ok)
+	s.Equal("fighter", sentinel.CharacterID)
+}
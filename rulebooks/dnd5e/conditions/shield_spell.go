@@ -188,6 +188,7 @@ func (s *ShieldSpellCondition) onPostAttackRoll(
 		TriggerKind:  dnd5eEvents.TriggerKindPostHit,
 		SourceEntity: event.AttackerID,
 		Payload:      *event,
+		ACBonus:      ShieldACBonus,
 	}); pubErr != nil {
 		return c, rpgerr.Wrap(pubErr, "failed to publish shield reaction trigger event")
 	}
@@ -0,0 +1,225 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/dice"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// SpiritualWeaponDurationTurns is how long the weapon lasts: 1 minute (10 rounds)
+const SpiritualWeaponDurationTurns = 10
+
+// SpiritualWeaponData is the JSON structure for persisting spiritual weapon condition state
+type SpiritualWeaponData struct {
+	Ref            *core.Ref `json:"ref"`
+	CharacterID    string    `json:"character_id"`
+	SlotLevel      int       `json:"slot_level"`
+	AttackBonus    int       `json:"attack_bonus"`
+	DamageModifier int       `json:"damage_modifier"`
+	TurnsActive    int       `json:"turns_active"`
+}
+
+// SpiritualWeaponCondition represents the floating spectral weapon created by
+// a cleric's Spiritual Weapon. The caster attacks with it immediately when
+// casting the spell and again as a bonus action on later turns (Attack).
+// Each attack is a melee spell attack dealing 1d8 + the spellcasting ability
+// modifier force damage, plus 1d8 for every two slot levels above 2nd.
+// The weapon vanishes after 1 minute.
+type SpiritualWeaponCondition struct {
+	CharacterID     string // The caster controlling the weapon
+	SlotLevel       int    // Slot the spell was cast with
+	AttackBonus     int    // Caster's spell attack bonus
+	DamageModifier  int    // Caster's spellcasting ability modifier
+	TurnsActive     int
+	roller          dice.Roller
+	bus             events.EventBus
+	subscriptionIDs []string
+}
+
+// Ensure SpiritualWeaponCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*SpiritualWeaponCondition)(nil)
+
+// SpiritualWeaponInput provides configuration for creating a spiritual weapon condition
+type SpiritualWeaponInput struct {
+	CharacterID    string      // ID of the caster
+	SlotLevel      int         // Slot the spell was cast with (2 or higher)
+	AttackBonus    int         // Caster's spell attack bonus
+	DamageModifier int         // Caster's spellcasting ability modifier
+	Roller         dice.Roller // Dice roller for attacks
+}
+
+// NewSpiritualWeaponCondition creates a spiritual weapon condition from input
+func NewSpiritualWeaponCondition(input SpiritualWeaponInput) *SpiritualWeaponCondition {
+	return &SpiritualWeaponCondition{
+		CharacterID:    input.CharacterID,
+		SlotLevel:      input.SlotLevel,
+		AttackBonus:    input.AttackBonus,
+		DamageModifier: input.DamageModifier,
+		roller:         input.Roller,
+	}
+}
+
+// SpiritualWeaponDice returns the weapon's damage dice for a slot level:
+// 1d8, plus 1d8 for every two slot levels above 2nd.
+func SpiritualWeaponDice(slotLevel int) string {
+	return fmt.Sprintf("%dd8", 1+max(slotLevel-2, 0)/2)
+}
+
+// IsApplied returns true if this condition is currently applied
+func (s *SpiritualWeaponCondition) IsApplied() bool {
+	return s.bus != nil
+}
+
+// Apply subscribes this condition to turn end events for its duration
+func (s *SpiritualWeaponCondition) Apply(ctx context.Context, bus events.EventBus) error {
+	if s.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "spiritual weapon condition already applied")
+	}
+	s.bus = bus
+
+	subID, err := dnd5eEvents.TurnEndTopic.On(bus).Subscribe(ctx, s.onTurnEnd)
+	if err != nil {
+		s.bus = nil
+		return rpgerr.Wrap(err, "failed to subscribe to turn end")
+	}
+	s.subscriptionIDs = append(s.subscriptionIDs, subID)
+
+	return nil
+}
+
+// Remove unsubscribes this condition from all events
+func (s *SpiritualWeaponCondition) Remove(ctx context.Context, bus events.EventBus) error {
+	if s.bus == nil {
+		return nil // Not applied, nothing to remove
+	}
+
+	total := len(s.subscriptionIDs)
+	var errs []error
+	for _, subID := range s.subscriptionIDs {
+		if err := bus.Unsubscribe(ctx, subID); err != nil {
+			errs = append(errs, fmt.Errorf("unsubscribe %s: %w", subID, err))
+		}
+	}
+
+	s.subscriptionIDs = nil
+	s.bus = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unsubscribe %d/%d subscriptions: %w", len(errs), total, errors.Join(errs...))
+	}
+	return nil
+}
+
+// SpiritualWeaponAttackInput provides the target of a spiritual weapon attack
+type SpiritualWeaponAttackInput struct {
+	// TargetID is the creature the weapon attacks. The caller moves the
+	// weapon within 5 feet of it first (up to 20 feet per turn).
+	TargetID string
+
+	// Economy is the caster's action economy. When set, the attack spends its
+	// bonus action; the attack made while casting the spell leaves it nil.
+	Economy *combat.ActionEconomy
+}
+
+// Attack makes a melee spell attack with the weapon.
+// Returns CodeInvalidState if the weapon has vanished.
+func (s *SpiritualWeaponCondition) Attack(
+	ctx context.Context, input *SpiritualWeaponAttackInput,
+) (*combat.AttackResult, error) {
+	if !s.IsApplied() {
+		return nil, rpgerr.New(rpgerr.CodeInvalidState, "spiritual weapon is not active")
+	}
+	if input == nil || input.TargetID == "" {
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "TargetID is required")
+	}
+	if input.Economy != nil {
+		if err := input.Economy.CheckBonusAction(); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := combat.ResolveSpellAttack(ctx, &combat.SpellAttackInput{
+		AttackerID:  s.CharacterID,
+		TargetID:    input.TargetID,
+		SpellRef:    refs.Spells.SpiritualWeapon(),
+		AttackBonus: s.AttackBonus,
+		Damage:      SpiritualWeaponDice(s.SlotLevel),
+		DamageBonus: s.DamageModifier,
+		DamageType:  damage.Force,
+		EventBus:    s.bus,
+		Roller:      s.roller,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if input.Economy != nil {
+		if err := input.Economy.UseBonusAction(); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// ToJSON converts the condition to JSON for persistence
+func (s *SpiritualWeaponCondition) ToJSON() (json.RawMessage, error) {
+	data := SpiritualWeaponData{
+		Ref:            refs.Conditions.SpiritualWeapon(),
+		CharacterID:    s.CharacterID,
+		SlotLevel:      s.SlotLevel,
+		AttackBonus:    s.AttackBonus,
+		DamageModifier: s.DamageModifier,
+		TurnsActive:    s.TurnsActive,
+	}
+	return json.Marshal(data)
+}
+
+// loadJSON loads spiritual weapon condition state from JSON
+func (s *SpiritualWeaponCondition) loadJSON(data json.RawMessage) error {
+	var weaponData SpiritualWeaponData
+	if err := json.Unmarshal(data, &weaponData); err != nil {
+		return rpgerr.Wrap(err, "failed to unmarshal spiritual weapon data")
+	}
+
+	s.CharacterID = weaponData.CharacterID
+	s.SlotLevel = weaponData.SlotLevel
+	s.AttackBonus = weaponData.AttackBonus
+	s.DamageModifier = weaponData.DamageModifier
+	s.TurnsActive = weaponData.TurnsActive
+	return nil
+}
+
+// onTurnEnd makes the weapon vanish after 1 minute
+func (s *SpiritualWeaponCondition) onTurnEnd(ctx context.Context, event dnd5eEvents.TurnEndEvent) error {
+	if event.CharacterID != s.CharacterID {
+		return nil
+	}
+
+	s.TurnsActive++
+	if s.TurnsActive < SpiritualWeaponDurationTurns {
+		return nil
+	}
+
+	err := dnd5eEvents.ConditionRemovedTopic.On(s.bus).Publish(ctx, dnd5eEvents.ConditionRemovedEvent{
+		CharacterID:  s.CharacterID,
+		ConditionRef: refs.Conditions.SpiritualWeapon().String(),
+		Reason:       "duration_expired",
+	})
+	if err != nil {
+		return rpgerr.Wrapf(err, "error publishing spiritual weapon removal for %s", s.CharacterID)
+	}
+
+	return s.Remove(ctx, s.bus)
+}
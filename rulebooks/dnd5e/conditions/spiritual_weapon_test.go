@@ -0,0 +1,102 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+)
+
+type SpiritualWeaponConditionTestSuite struct {
+	suite.Suite
+	ctx       context.Context
+	bus       events.EventBus
+	condition *SpiritualWeaponCondition
+}
+
+func TestSpiritualWeaponConditionSuite(t *testing.T) {
+	suite.Run(t, new(SpiritualWeaponConditionTestSuite))
+}
+
+func (s *SpiritualWeaponConditionTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.condition = NewSpiritualWeaponCondition(SpiritualWeaponInput{
+		CharacterID:    "cleric",
+		SlotLevel:      4,
+		AttackBonus:    5,
+		DamageModifier: 3,
+	})
+}
+
+func (s *SpiritualWeaponConditionTestSuite) TestDiceScaleEveryTwoSlotLevels() {
+	s.Equal("1d8", SpiritualWeaponDice(2))
+	s.Equal("1d8", SpiritualWeaponDice(3))
+	s.Equal("2d8", SpiritualWeaponDice(4))
+	s.Equal("3d8", SpiritualWeaponDice(6))
+}
+
+func (s *SpiritualWeaponConditionTestSuite) TestVanishesAfterOneMinute() {
+	s.Require().NoError(s.condition.Apply(s.ctx, s.bus))
+
+	var removed *dnd5eEvents.ConditionRemovedEvent
+	_, err := dnd5eEvents.ConditionRemovedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, e dnd5eEvents.ConditionRemovedEvent) error {
+			removed = &e
+			return nil
+		})
+	s.Require().NoError(err)
+
+	turnEnd := dnd5eEvents.TurnEndTopic.On(s.bus)
+	s.Require().NoError(turnEnd.Publish(s.ctx, dnd5eEvents.TurnEndEvent{CharacterID: "someone-else"}))
+	for i := 0; i < SpiritualWeaponDurationTurns-1; i++ {
+		s.Require().NoError(turnEnd.Publish(s.ctx, dnd5eEvents.TurnEndEvent{CharacterID: "cleric"}))
+	}
+	s.True(s.condition.IsApplied())
+	s.Nil(removed)
+
+	s.Require().NoError(turnEnd.Publish(s.ctx, dnd5eEvents.TurnEndEvent{CharacterID: "cleric"}))
+	s.False(s.condition.IsApplied())
+	s.Require().NotNil(removed)
+	s.Equal("duration_expired", removed.Reason)
+}
+
+func (s *SpiritualWeaponConditionTestSuite) TestAttackRequiresActiveWeapon() {
+	_, err := s.condition.Attack(s.ctx, &SpiritualWeaponAttackInput{TargetID: "goblin"})
+	s.Require().Error(err)
+	s.Equal(rpgerr.CodeInvalidState, rpgerr.GetCode(err))
+}
+
+func (s *SpiritualWeaponConditionTestSuite) TestAttackNeedsBonusAction() {
+	s.Require().NoError(s.condition.Apply(s.ctx, s.bus))
+
+	economy := combat.NewActionEconomy()
+	s.Require().NoError(economy.UseBonusAction())
+
+	_, err := s.condition.Attack(s.ctx, &SpiritualWeaponAttackInput{TargetID: "goblin", Economy: economy})
+	s.Require().Error(err)
+}
+
+func (s *SpiritualWeaponConditionTestSuite) TestRoundTrip() {
+	s.condition.TurnsActive = 2
+	data, err := s.condition.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := LoadJSON(data)
+	s.Require().NoError(err)
+	weapon, ok := loaded.(*SpiritualWeaponCondition)
+	s.Require().True(ok)
+	s.Equal("cleric", weapon.CharacterID)
+	s.Equal(4, weapon.SlotLevel)
+	s.Equal(5, weapon.AttackBonus)
+	s.Equal(3, weapon.DamageModifier)
+	s.Equal(2, weapon.TurnsActive)
+}
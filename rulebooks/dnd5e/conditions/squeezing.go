@@ -0,0 +1,226 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// SqueezingConditionData is the serializable form of the squeezing condition.
+// This is stored by the game server as an opaque JSON blob.
+type SqueezingConditionData struct {
+	Ref         *core.Ref `json:"ref"`
+	CharacterID string    `json:"character_id"`
+}
+
+// SqueezingCondition represents a creature squeezing through a space sized
+// for a creature one size smaller.
+//
+// While applied:
+//   - Moving costs 1 extra foot per foot moved
+//   - The creature has disadvantage on attack rolls and DEX saving throws
+//   - Attacks against it have advantage
+//
+// The game server removes the condition once the creature reaches a space it fits.
+type SqueezingCondition struct {
+	CharacterID     string
+	bus             events.EventBus
+	subscriptionIDs []string
+}
+
+// Ensure SqueezingCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*SqueezingCondition)(nil)
+
+// NewSqueezingCondition creates a squeezing condition for the specified character.
+func NewSqueezingCondition(characterID string) *SqueezingCondition {
+	return &SqueezingCondition{
+		CharacterID: characterID,
+	}
+}
+
+// IsApplied returns true if this condition is currently applied.
+func (s *SqueezingCondition) IsApplied() bool {
+	return s.bus != nil
+}
+
+// Apply subscribes this condition to AttackChain, SavingThrowChain, and MovementChain.
+func (s *SqueezingCondition) Apply(ctx context.Context, bus events.EventBus) error {
+	if s.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "squeezing condition already applied")
+	}
+	s.bus = bus
+
+	attackSubID, err := dnd5eEvents.AttackChain.On(bus).SubscribeWithChain(ctx, s.onAttackChain)
+	if err != nil {
+		s.bus = nil
+		return rpgerr.Wrap(err, "failed to subscribe to attack chain")
+	}
+	s.subscriptionIDs = append(s.subscriptionIDs, attackSubID)
+
+	saveSubID, err := dnd5eEvents.SavingThrowChain.On(bus).SubscribeWithChain(ctx, s.onSavingThrowChain)
+	if err != nil {
+		_ = s.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to saving throw chain")
+	}
+	s.subscriptionIDs = append(s.subscriptionIDs, saveSubID)
+
+	movementSubID, err := dnd5eEvents.MovementChain.On(bus).SubscribeWithChain(ctx, s.onMovementChain)
+	if err != nil {
+		_ = s.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to movement chain")
+	}
+	s.subscriptionIDs = append(s.subscriptionIDs, movementSubID)
+
+	return nil
+}
+
+// Remove unsubscribes this condition from all events.
+func (s *SqueezingCondition) Remove(ctx context.Context, bus events.EventBus) error {
+	if s.bus == nil {
+		return nil // Not applied, nothing to remove
+	}
+
+	total := len(s.subscriptionIDs)
+	var errs []error
+	for _, subID := range s.subscriptionIDs {
+		if err := bus.Unsubscribe(ctx, subID); err != nil {
+			errs = append(errs, fmt.Errorf("unsubscribe %s: %w", subID, err))
+		}
+	}
+
+	s.subscriptionIDs = nil
+	s.bus = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unsubscribe %d/%d subscriptions: %w", len(errs), total, errors.Join(errs...))
+	}
+	return nil
+}
+
+// ToJSON converts the condition to JSON for persistence.
+func (s *SqueezingCondition) ToJSON() (json.RawMessage, error) {
+	data := SqueezingConditionData{
+		Ref:         refs.Conditions.Squeezing(),
+		CharacterID: s.CharacterID,
+	}
+	return json.Marshal(data)
+}
+
+// loadJSON loads squeezing condition state from JSON.
+func (s *SqueezingCondition) loadJSON(data json.RawMessage) error {
+	var squeezingData SqueezingConditionData
+	if err := json.Unmarshal(data, &squeezingData); err != nil {
+		return rpgerr.Wrap(err, "failed to unmarshal squeezing data")
+	}
+
+	s.CharacterID = squeezingData.CharacterID
+	return nil
+}
+
+// onAttackChain imposes disadvantage on the squeezing creature's attacks and
+// grants advantage on attacks against it.
+func (s *SqueezingCondition) onAttackChain(
+	_ context.Context,
+	event dnd5eEvents.AttackChainEvent,
+	c chain.Chain[dnd5eEvents.AttackChainEvent],
+) (chain.Chain[dnd5eEvents.AttackChainEvent], error) {
+	source := dnd5eEvents.AttackModifierSource{
+		SourceRef: refs.Conditions.Squeezing(),
+		SourceID:  s.CharacterID,
+		Reason:    "Squeezing",
+	}
+
+	var modifyAttack func(context.Context, dnd5eEvents.AttackChainEvent) (dnd5eEvents.AttackChainEvent, error)
+	stepID := "squeezing_target"
+	switch s.CharacterID {
+	case event.AttackerID:
+		stepID = "squeezing_attacker"
+		modifyAttack = func(_ context.Context, e dnd5eEvents.AttackChainEvent) (dnd5eEvents.AttackChainEvent, error) {
+			e.DisadvantageSources = append(e.DisadvantageSources, source)
+			return e, nil
+		}
+	case event.TargetID:
+		modifyAttack = func(_ context.Context, e dnd5eEvents.AttackChainEvent) (dnd5eEvents.AttackChainEvent, error) {
+			e.AdvantageSources = append(e.AdvantageSources, source)
+			return e, nil
+		}
+	default:
+		return c, nil
+	}
+
+	if err := c.Add(combat.StageConditions, stepID, modifyAttack); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to add squeezing modifier for character %s", s.CharacterID)
+	}
+
+	return c, nil
+}
+
+// onSavingThrowChain imposes disadvantage on the squeezing creature's DEX saves.
+func (s *SqueezingCondition) onSavingThrowChain(
+	_ context.Context,
+	event *dnd5eEvents.SavingThrowChainEvent,
+	c chain.Chain[*dnd5eEvents.SavingThrowChainEvent],
+) (chain.Chain[*dnd5eEvents.SavingThrowChainEvent], error) {
+	if event.SaverID != s.CharacterID || event.Ability != abilities.DEX {
+		return c, nil
+	}
+
+	modifySave := func(_ context.Context, e *dnd5eEvents.SavingThrowChainEvent) (*dnd5eEvents.SavingThrowChainEvent, error) {
+		e.DisadvantageSources = append(e.DisadvantageSources, dnd5eEvents.SaveModifierSource{
+			Name:       "Squeezing",
+			SourceType: "condition",
+			SourceRef:  refs.Conditions.Squeezing(),
+			EntityID:   s.CharacterID,
+		})
+		return e, nil
+	}
+
+	if err := c.Add(combat.StageConditions, "squeezing_dex_disadvantage", modifySave); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to add squeezing DEX disadvantage for character %s", s.CharacterID)
+	}
+
+	return c, nil
+}
+
+// onMovementChain adds the squeezing cost to the creature's movement.
+func (s *SqueezingCondition) onMovementChain(
+	_ context.Context,
+	event *dnd5eEvents.MovementChainEvent,
+	c chain.Chain[*dnd5eEvents.MovementChainEvent],
+) (chain.Chain[*dnd5eEvents.MovementChainEvent], error) {
+	if event.EntityID != s.CharacterID {
+		return c, nil
+	}
+
+	addCost := func(_ context.Context, e *dnd5eEvents.MovementChainEvent) (*dnd5eEvents.MovementChainEvent, error) {
+		e.ExtraCostSources = append(e.ExtraCostSources, dnd5eEvents.MovementCostSource{
+			MovementModifierSource: dnd5eEvents.MovementModifierSource{
+				Name:       "Squeezing",
+				SourceType: "condition",
+				SourceRef:  refs.Conditions.Squeezing(),
+				EntityID:   s.CharacterID,
+			},
+			ExtraFeetPerFoot: 1,
+		})
+		return e, nil
+	}
+
+	if err := c.Add(combat.StageConditions, "squeezing_cost", addCost); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to add squeezing cost for character %s", s.CharacterID)
+	}
+
+	return c, nil
+}
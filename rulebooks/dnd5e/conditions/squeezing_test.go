@@ -0,0 +1,101 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+type SqueezingConditionTestSuite struct {
+	suite.Suite
+	ctx       context.Context
+	bus       events.EventBus
+	condition *SqueezingCondition
+}
+
+func TestSqueezingConditionSuite(t *testing.T) {
+	suite.Run(t, new(SqueezingConditionTestSuite))
+}
+
+func (s *SqueezingConditionTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.condition = NewSqueezingCondition("halfling")
+	s.Require().NoError(s.condition.Apply(s.ctx, s.bus))
+}
+
+func (s *SqueezingConditionTestSuite) attack(attackerID, targetID string) dnd5eEvents.AttackChainEvent {
+	event := dnd5eEvents.AttackChainEvent{AttackerID: attackerID, TargetID: targetID}
+	attackChain := events.NewStagedChain[dnd5eEvents.AttackChainEvent](combat.ModifierStages)
+	modified, err := dnd5eEvents.AttackChain.On(s.bus).PublishWithChain(s.ctx, event, attackChain)
+	s.Require().NoError(err)
+	result, err := modified.Execute(s.ctx, event)
+	s.Require().NoError(err)
+	return result
+}
+
+func (s *SqueezingConditionTestSuite) save(ability abilities.Ability) *dnd5eEvents.SavingThrowChainEvent {
+	event := &dnd5eEvents.SavingThrowChainEvent{SaverID: "halfling", Ability: ability}
+	saveChain := events.NewStagedChain[*dnd5eEvents.SavingThrowChainEvent](combat.ModifierStages)
+	modified, err := dnd5eEvents.SavingThrowChain.On(s.bus).PublishWithChain(s.ctx, event, saveChain)
+	s.Require().NoError(err)
+	result, err := modified.Execute(s.ctx, event)
+	s.Require().NoError(err)
+	return result
+}
+
+func (s *SqueezingConditionTestSuite) TestAttackModifiers() {
+	by := s.attack("halfling", "goblin")
+	s.Require().Len(by.DisadvantageSources, 1)
+	s.Equal(refs.Conditions.Squeezing(), by.DisadvantageSources[0].SourceRef)
+	s.Empty(by.AdvantageSources)
+
+	against := s.attack("goblin", "halfling")
+	s.Len(against.AdvantageSources, 1)
+	s.Empty(against.DisadvantageSources)
+
+	s.Empty(s.attack("goblin", "fighter").AdvantageSources)
+}
+
+func (s *SqueezingConditionTestSuite) TestDexSaveDisadvantage() {
+	s.True(s.save(abilities.DEX).HasDisadvantage())
+	s.False(s.save(abilities.STR).HasDisadvantage())
+}
+
+func (s *SqueezingConditionTestSuite) TestMovementCost() {
+	event := &dnd5eEvents.MovementChainEvent{EntityID: "halfling"}
+	movementChain := events.NewStagedChain[*dnd5eEvents.MovementChainEvent](combat.ModifierStages)
+	modified, err := dnd5eEvents.MovementChain.On(s.bus).PublishWithChain(s.ctx, event, movementChain)
+	s.Require().NoError(err)
+	result, err := modified.Execute(s.ctx, event)
+	s.Require().NoError(err)
+	s.Equal(2, result.CostMultiplier())
+}
+
+func (s *SqueezingConditionTestSuite) TestRemove() {
+	s.Require().NoError(s.condition.Remove(s.ctx, s.bus))
+	s.False(s.condition.IsApplied())
+	s.Empty(s.attack("halfling", "goblin").DisadvantageSources)
+}
+
+func (s *SqueezingConditionTestSuite) TestToJSONRoundTrip() {
+	data, err := s.condition.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := LoadJSON(data)
+	s.Require().NoError(err)
+
+	squeezing, ok := loaded.(*SqueezingCondition)
+	s.Require().True(ok)
+	s.Equal("halfling", squeezing.CharacterID)
+}
@@ -0,0 +1,454 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
+)
+
+// standardAdjacentFeet is the distance within which hits on a paralyzed or
+// unconscious creature are critical
+const standardAdjacentFeet = 5
+
+// StandardConditionData is the JSON structure for persisting the standard PHB
+// conditions (Blinded, Charmed, Frightened, etc.)
+type StandardConditionData struct {
+	Ref         *core.Ref `json:"ref"`
+	CharacterID string    `json:"character_id"`
+	SourceID    string    `json:"source_id,omitempty"`
+}
+
+// StandardConditionInput provides configuration for creating a standard condition
+type StandardConditionInput struct {
+	CharacterID string // ID of the affected creature
+	SourceID    string // Creature causing the condition (the charmer or the source of fear)
+}
+
+// standardEffects describes what a standard condition changes on the chains.
+// Action economy effects (incapacitated, speed 0) aren't listed here: the
+// action economy reads them from the condition's ref (see
+// combat.ConditionEconomyRestriction).
+type standardEffects struct {
+	name string
+	ref  *core.Ref
+
+	attackAdvantage    bool // the creature's attacks have advantage
+	attackDisadvantage bool // the creature's attacks have disadvantage
+	cantAttackSource   bool // the creature can't attack SourceID
+
+	attackedWithAdvantage    bool // attacks against the creature have advantage
+	attackedWithDisadvantage bool // attacks against the creature have disadvantage
+	critWithinFive           bool // hits from within 5 feet are critical
+
+	checkDisadvantage bool // the creature's ability checks have disadvantage
+	failsSightChecks  bool // checks that require sight fail
+	failsHearing      bool // checks that require hearing fail
+	failsStrDexSaves  bool // STR and DEX saves fail
+
+	resistsAllDamage bool // resistance to all damage
+	cantApproach     bool // can't willingly move closer to SourceID
+}
+
+// standardCondition implements the chain effects shared by the standard PHB
+// conditions. Each condition embeds it with its own effects.
+type standardCondition struct {
+	CharacterID     string
+	SourceID        string
+	effects         standardEffects
+	bus             events.EventBus
+	subscriptionIDs []string
+}
+
+// standardConditionBehavior is implemented by every standard condition
+type standardConditionBehavior interface {
+	dnd5eEvents.ConditionBehavior
+	loadJSON(data json.RawMessage) error
+}
+
+// standardConditions maps each standard condition's ref ID to its constructor
+var standardConditions = map[string]func(StandardConditionInput) standardConditionBehavior{
+	refs.Conditions.Blinded().ID:       standardConstructor(NewBlindedCondition),
+	refs.Conditions.Charmed().ID:       standardConstructor(NewCharmedCondition),
+	refs.Conditions.Deafened().ID:      standardConstructor(NewDeafenedCondition),
+	refs.Conditions.Frightened().ID:    standardConstructor(NewFrightenedCondition),
+	refs.Conditions.Incapacitated().ID: standardConstructor(NewIncapacitatedCondition),
+	refs.Conditions.Invisible().ID:     standardConstructor(NewInvisibleCondition),
+	refs.Conditions.Paralyzed().ID:     standardConstructor(NewParalyzedCondition),
+	refs.Conditions.Petrified().ID:     standardConstructor(NewPetrifiedCondition),
+	refs.Conditions.Poisoned().ID:      standardConstructor(NewPoisonedCondition),
+	refs.Conditions.Stunned().ID:       standardConstructor(NewStunnedCondition),
+}
+
+// standardConstructor adapts a condition's constructor for standardConditions
+func standardConstructor[T standardConditionBehavior](
+	newCondition func(StandardConditionInput) T,
+) func(StandardConditionInput) standardConditionBehavior {
+	return func(input StandardConditionInput) standardConditionBehavior {
+		return newCondition(input)
+	}
+}
+
+// NewStandardCondition creates the standard condition named by ref (Blinded,
+// Charmed, Deafened, Frightened, Incapacitated, Invisible, Paralyzed,
+// Petrified, Poisoned or Stunned). Returns CodeInvalidArgument for any other ref.
+func NewStandardCondition(ref *core.Ref, input StandardConditionInput) (dnd5eEvents.ConditionBehavior, error) {
+	if ref == nil {
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "ref is required")
+	}
+	newCondition, ok := standardConditions[ref.ID]
+	if !ok {
+		return nil, rpgerr.Newf(rpgerr.CodeInvalidArgument, "not a standard condition: %s", ref.ID)
+	}
+	return newCondition(input), nil
+}
+
+// loadStandardCondition loads a standard condition from JSON, returning false
+// if the ref isn't a standard condition
+func loadStandardCondition(refID string, data json.RawMessage) (dnd5eEvents.ConditionBehavior, bool, error) {
+	newCondition, ok := standardConditions[refID]
+	if !ok {
+		return nil, false, nil
+	}
+	condition := newCondition(StandardConditionInput{})
+	if err := condition.loadJSON(data); err != nil {
+		return nil, true, rpgerr.Wrapf(err, "failed to load %s condition", refID)
+	}
+	return condition, true, nil
+}
+
+// newStandardCondition creates the shared state of a standard condition
+func newStandardCondition(input StandardConditionInput, effects standardEffects) standardCondition {
+	return standardCondition{
+		CharacterID: input.CharacterID,
+		SourceID:    input.SourceID,
+		effects:     effects,
+	}
+}
+
+// IsApplied returns true if this condition is currently applied
+func (s *standardCondition) IsApplied() bool {
+	return s.bus != nil
+}
+
+// Apply subscribes this condition to the chains its effects modify
+func (s *standardCondition) Apply(ctx context.Context, bus events.EventBus) error {
+	if s.IsApplied() {
+		return rpgerr.Newf(rpgerr.CodeAlreadyExists, "%s condition already applied", s.effects.ref.ID)
+	}
+	s.bus = bus
+
+	fx := s.effects
+	subscribe := func(name string, subscribeFn func() (string, error)) error {
+		subID, err := subscribeFn()
+		if err != nil {
+			_ = s.Remove(ctx, bus)
+			return rpgerr.Wrapf(err, "failed to subscribe to %s", name)
+		}
+		s.subscriptionIDs = append(s.subscriptionIDs, subID)
+		return nil
+	}
+
+	if fx.attackAdvantage || fx.attackDisadvantage || fx.cantAttackSource ||
+		fx.attackedWithAdvantage || fx.attackedWithDisadvantage {
+		err := subscribe("attack chain", func() (string, error) {
+			return dnd5eEvents.AttackChain.On(bus).SubscribeWithChain(ctx, s.onAttackChain)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if fx.checkDisadvantage || fx.failsSightChecks || fx.failsHearing {
+		err := subscribe("ability check chain", func() (string, error) {
+			return dnd5eEvents.AbilityCheckChain.On(bus).SubscribeWithChain(ctx, s.onAbilityCheckChain)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if fx.failsStrDexSaves {
+		err := subscribe("saving throw chain", func() (string, error) {
+			return dnd5eEvents.SavingThrowChain.On(bus).SubscribeWithChain(ctx, s.onSavingThrowChain)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if fx.resistsAllDamage {
+		err := subscribe("damage chain", func() (string, error) {
+			return dnd5eEvents.DamageChain.On(bus).SubscribeWithChain(ctx, s.onDamageChain)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if fx.cantApproach {
+		err := subscribe("movement chain", func() (string, error) {
+			return dnd5eEvents.MovementChain.On(bus).SubscribeWithChain(ctx, s.onMovementChain)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Remove unsubscribes this condition from events
+func (s *standardCondition) Remove(ctx context.Context, bus events.EventBus) error {
+	if s.bus == nil {
+		return nil // Not applied, nothing to remove
+	}
+
+	total := len(s.subscriptionIDs)
+	var errs []error
+	for _, subID := range s.subscriptionIDs {
+		if err := bus.Unsubscribe(ctx, subID); err != nil {
+			errs = append(errs, fmt.Errorf("unsubscribe %s: %w", subID, err))
+		}
+	}
+
+	s.subscriptionIDs = nil
+	s.bus = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unsubscribe %d/%d subscriptions: %w", len(errs), total, errors.Join(errs...))
+	}
+	return nil
+}
+
+// ToJSON converts the condition to JSON for persistence
+func (s *standardCondition) ToJSON() (json.RawMessage, error) {
+	data := StandardConditionData{
+		Ref:         s.effects.ref,
+		CharacterID: s.CharacterID,
+		SourceID:    s.SourceID,
+	}
+	return json.Marshal(data)
+}
+
+// loadJSON loads standard condition state from JSON
+func (s *standardCondition) loadJSON(data json.RawMessage) error {
+	var standardData StandardConditionData
+	if err := json.Unmarshal(data, &standardData); err != nil {
+		return rpgerr.Wrapf(err, "failed to unmarshal %s data", s.effects.ref.ID)
+	}
+
+	s.CharacterID = standardData.CharacterID
+	s.SourceID = standardData.SourceID
+	return nil
+}
+
+// onAttackChain applies the condition to the creature's attacks and to attacks against it
+func (s *standardCondition) onAttackChain(
+	ctx context.Context,
+	event dnd5eEvents.AttackChainEvent,
+	c chain.Chain[dnd5eEvents.AttackChainEvent],
+) (chain.Chain[dnd5eEvents.AttackChainEvent], error) {
+	fx := s.effects
+	source := dnd5eEvents.AttackModifierSource{
+		SourceRef: fx.ref,
+		SourceID:  s.CharacterID,
+		Reason:    fx.name,
+	}
+
+	switch s.CharacterID {
+	case event.AttackerID:
+		cancel := fx.cantAttackSource && s.SourceID != "" && event.TargetID == s.SourceID
+		if !fx.attackAdvantage && !fx.attackDisadvantage && !cancel {
+			return c, nil
+		}
+		modifyAttack := func(_ context.Context, e dnd5eEvents.AttackChainEvent) (dnd5eEvents.AttackChainEvent, error) {
+			if fx.attackAdvantage {
+				e.AdvantageSources = append(e.AdvantageSources, source)
+			}
+			if fx.attackDisadvantage {
+				e.DisadvantageSources = append(e.DisadvantageSources, source)
+			}
+			if cancel {
+				e.CancellationSources = append(e.CancellationSources, source)
+			}
+			return e, nil
+		}
+		if err := c.Add(combat.StageConditions, fx.ref.ID+"_attacker", modifyAttack); err != nil {
+			return c, rpgerr.Wrapf(err, "failed to add %s attacker modifier for character %s", fx.ref.ID, s.CharacterID)
+		}
+
+	case event.TargetID:
+		if !fx.attackedWithAdvantage && !fx.attackedWithDisadvantage {
+			return c, nil
+		}
+		crit := fx.critWithinFive && s.attackerAdjacent(ctx, event)
+		modifyAttack := func(_ context.Context, e dnd5eEvents.AttackChainEvent) (dnd5eEvents.AttackChainEvent, error) {
+			if fx.attackedWithAdvantage {
+				e.AdvantageSources = append(e.AdvantageSources, source)
+			}
+			if fx.attackedWithDisadvantage {
+				e.DisadvantageSources = append(e.DisadvantageSources, source)
+			}
+			if crit {
+				e.CriticalThreshold = 1
+			}
+			return e, nil
+		}
+		if err := c.Add(combat.StageConditions, fx.ref.ID+"_target", modifyAttack); err != nil {
+			return c, rpgerr.Wrapf(err, "failed to add %s target modifier for character %s", fx.ref.ID, s.CharacterID)
+		}
+	}
+
+	return c, nil
+}
+
+// attackerAdjacent reports whether the attacker is within 5 feet of the creature.
+// Without positions, a melee attack is assumed to be within 5 feet.
+func (s *standardCondition) attackerAdjacent(ctx context.Context, event dnd5eEvents.AttackChainEvent) bool {
+	if distance, ok := combat.DistanceFeet(ctx, event.AttackerID, s.CharacterID); ok {
+		return distance <= standardAdjacentFeet
+	}
+	return event.IsMelee
+}
+
+// checkSource describes this condition as a check modifier source
+func (s *standardCondition) checkSource() dnd5eEvents.CheckModifierSource {
+	return dnd5eEvents.CheckModifierSource{
+		Name:       s.effects.name,
+		SourceType: "condition",
+		SourceRef:  s.effects.ref,
+		EntityID:   s.CharacterID,
+	}
+}
+
+// onAbilityCheckChain imposes disadvantage on checks, or fails checks the
+// creature's senses can't make
+func (s *standardCondition) onAbilityCheckChain(
+	_ context.Context,
+	event *dnd5eEvents.AbilityCheckChainEvent,
+	c chain.Chain[*dnd5eEvents.AbilityCheckChainEvent],
+) (chain.Chain[*dnd5eEvents.AbilityCheckChainEvent], error) {
+	if event.CheckerID != s.CharacterID {
+		return c, nil
+	}
+
+	fx := s.effects
+	fails := (fx.failsSightChecks && event.RequiresSight) || (fx.failsHearing && event.RequiresHearing)
+	if !fails && !fx.checkDisadvantage {
+		return c, nil
+	}
+
+	modifyCheck := func(_ context.Context, e *dnd5eEvents.AbilityCheckChainEvent) (*dnd5eEvents.AbilityCheckChainEvent, error) {
+		if fails {
+			e.AutoFailSources = append(e.AutoFailSources, s.checkSource())
+		}
+		if fx.checkDisadvantage {
+			e.DisadvantageSources = append(e.DisadvantageSources, s.checkSource())
+		}
+		return e, nil
+	}
+
+	if err := c.Add(combat.StageConditions, fx.ref.ID+"_check", modifyCheck); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to add %s check modifier for character %s", fx.ref.ID, s.CharacterID)
+	}
+
+	return c, nil
+}
+
+// onSavingThrowChain makes the creature fail STR and DEX saves
+func (s *standardCondition) onSavingThrowChain(
+	_ context.Context,
+	event *dnd5eEvents.SavingThrowChainEvent,
+	c chain.Chain[*dnd5eEvents.SavingThrowChainEvent],
+) (chain.Chain[*dnd5eEvents.SavingThrowChainEvent], error) {
+	fx := s.effects
+	if event.SaverID != s.CharacterID || !fx.failsStrDexSaves ||
+		(event.Ability != abilities.STR && event.Ability != abilities.DEX) {
+		return c, nil
+	}
+
+	modifySave := func(_ context.Context, e *dnd5eEvents.SavingThrowChainEvent) (*dnd5eEvents.SavingThrowChainEvent, error) {
+		e.AutoFailSources = append(e.AutoFailSources, dnd5eEvents.SaveModifierSource{
+			Name:       fx.name,
+			SourceType: "condition",
+			SourceRef:  fx.ref,
+			EntityID:   s.CharacterID,
+		})
+		return e, nil
+	}
+
+	if err := c.Add(combat.StageConditions, fx.ref.ID+"_auto_fail", modifySave); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to add %s save modifier for character %s", fx.ref.ID, s.CharacterID)
+	}
+
+	return c, nil
+}
+
+// onDamageChain gives the creature resistance to all damage
+func (s *standardCondition) onDamageChain(
+	_ context.Context,
+	event *dnd5eEvents.DamageChainEvent,
+	c chain.Chain[*dnd5eEvents.DamageChainEvent],
+) (chain.Chain[*dnd5eEvents.DamageChainEvent], error) {
+	if event.TargetID != s.CharacterID {
+		return c, nil
+	}
+
+	applyResistance := func(_ context.Context, e *dnd5eEvents.DamageChainEvent) (*dnd5eEvents.DamageChainEvent, error) {
+		e.Components = append(e.Components, dnd5eEvents.DamageComponent{
+			Source:     dnd5eEvents.DamageSourceCondition,
+			SourceRef:  s.effects.ref,
+			DamageType: e.DamageType,
+			Multiplier: 0.5, // Resistance halves damage
+		})
+		return e, nil
+	}
+
+	if err := c.Add(combat.StageFinal, s.effects.ref.ID+"_resistance", applyResistance); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to add %s resistance for character %s", s.effects.ref.ID, s.CharacterID)
+	}
+
+	return c, nil
+}
+
+// onMovementChain stops the creature from stepping closer to its source.
+// Without positions for the source, movement isn't restricted.
+func (s *standardCondition) onMovementChain(
+	ctx context.Context,
+	event *dnd5eEvents.MovementChainEvent,
+	c chain.Chain[*dnd5eEvents.MovementChainEvent],
+) (chain.Chain[*dnd5eEvents.MovementChainEvent], error) {
+	if event.EntityID != s.CharacterID || s.SourceID == "" {
+		return c, nil
+	}
+
+	from, fromOK := combat.DistanceToPositionFeet(ctx, s.SourceID,
+		spatial.Position{X: event.FromPosition.X, Y: event.FromPosition.Y})
+	to, toOK := combat.DistanceToPositionFeet(ctx, s.SourceID,
+		spatial.Position{X: event.ToPosition.X, Y: event.ToPosition.Y})
+	if !fromOK || !toOK || to >= from {
+		return c, nil
+	}
+
+	preventMove := func(_ context.Context, e *dnd5eEvents.MovementChainEvent) (*dnd5eEvents.MovementChainEvent, error) {
+		e.MovementPrevented = true
+		e.PreventionReason = fmt.Sprintf("%s: can't move closer to %s", s.effects.name, s.SourceID)
+		return e, nil
+	}
+
+	if err := c.Add(combat.StageConditions, s.effects.ref.ID+"_approach", preventMove); err != nil {
+		return c, rpgerr.Wrapf(err, "failed to add %s movement restriction for character %s", s.effects.ref.ID, s.CharacterID)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,279 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
+)
+
+// standardTestEntity implements core.Entity for room placement
+type standardTestEntity struct {
+	id string
+}
+
+func (e *standardTestEntity) GetID() string            { return e.id }
+func (e *standardTestEntity) GetType() core.EntityType { return "character" }
+
+type StandardConditionsTestSuite struct {
+	suite.Suite
+	ctx context.Context
+	bus events.EventBus
+}
+
+func TestStandardConditionsSuite(t *testing.T) {
+	suite.Run(t, new(StandardConditionsTestSuite))
+}
+
+func (s *StandardConditionsTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+}
+
+func (s *StandardConditionsTestSuite) apply(ref *core.Ref, sourceID string) dnd5eEvents.ConditionBehavior {
+	condition, err := NewStandardCondition(ref, StandardConditionInput{CharacterID: "hero", SourceID: sourceID})
+	s.Require().NoError(err)
+	s.Require().NoError(condition.Apply(s.ctx, s.bus))
+	return condition
+}
+
+func (s *StandardConditionsTestSuite) attack(ctx context.Context, attackerID, targetID string) dnd5eEvents.AttackChainEvent {
+	event := dnd5eEvents.AttackChainEvent{
+		AttackerID:        attackerID,
+		TargetID:          targetID,
+		IsMelee:           true,
+		CriticalThreshold: 20,
+	}
+	attackChain := events.NewStagedChain[dnd5eEvents.AttackChainEvent](combat.ModifierStages)
+	modifiedChain, err := dnd5eEvents.AttackChain.On(s.bus).PublishWithChain(ctx, event, attackChain)
+	s.Require().NoError(err)
+	final, err := modifiedChain.Execute(ctx, event)
+	s.Require().NoError(err)
+	return final
+}
+
+func (s *StandardConditionsTestSuite) check(sight, hearing bool) *dnd5eEvents.AbilityCheckChainEvent {
+	event := &dnd5eEvents.AbilityCheckChainEvent{
+		CheckerID:       "hero",
+		Ability:         abilities.WIS,
+		RequiresSight:   sight,
+		RequiresHearing: hearing,
+	}
+	checkChain := events.NewStagedChain[*dnd5eEvents.AbilityCheckChainEvent](combat.ModifierStages)
+	modifiedChain, err := dnd5eEvents.AbilityCheckChain.On(s.bus).PublishWithChain(s.ctx, event, checkChain)
+	s.Require().NoError(err)
+	final, err := modifiedChain.Execute(s.ctx, event)
+	s.Require().NoError(err)
+	return final
+}
+
+func (s *StandardConditionsTestSuite) save(ability abilities.Ability) *dnd5eEvents.SavingThrowChainEvent {
+	event := &dnd5eEvents.SavingThrowChainEvent{SaverID: "hero", Ability: ability, DC: 12}
+	saveChain := events.NewStagedChain[*dnd5eEvents.SavingThrowChainEvent](combat.ModifierStages)
+	modifiedChain, err := dnd5eEvents.SavingThrowChain.On(s.bus).PublishWithChain(s.ctx, event, saveChain)
+	s.Require().NoError(err)
+	final, err := modifiedChain.Execute(s.ctx, event)
+	s.Require().NoError(err)
+	return final
+}
+
+func (s *StandardConditionsTestSuite) TestBlinded() {
+	s.apply(refs.Conditions.Blinded(), "")
+
+	s.Len(s.check(true, false).AutoFailSources, 1, "checks requiring sight fail")
+	s.Empty(s.check(false, true).AutoFailSources, "other checks are unaffected")
+
+	s.Len(s.attack(s.ctx, "hero", "goblin").DisadvantageSources, 1)
+	s.Len(s.attack(s.ctx, "goblin", "hero").AdvantageSources, 1)
+}
+
+func (s *StandardConditionsTestSuite) TestDeafened() {
+	s.apply(refs.Conditions.Deafened(), "")
+
+	s.Len(s.check(false, true).AutoFailSources, 1, "checks requiring hearing fail")
+	s.Empty(s.check(true, false).AutoFailSources)
+	s.False(s.check(false, false).AutoFails())
+}
+
+func (s *StandardConditionsTestSuite) TestCharmedCantAttackCharmer() {
+	s.apply(refs.Conditions.Charmed(), "vampire")
+
+	s.Len(s.attack(s.ctx, "hero", "vampire").CancellationSources, 1)
+	s.Empty(s.attack(s.ctx, "hero", "goblin").CancellationSources, "other targets are fine")
+}
+
+func (s *StandardConditionsTestSuite) TestFrightened() {
+	s.apply(refs.Conditions.Frightened(), "dragon")
+
+	s.Len(s.check(false, false).DisadvantageSources, 1)
+	s.Len(s.attack(s.ctx, "hero", "dragon").DisadvantageSources, 1)
+	s.Empty(s.attack(s.ctx, "dragon", "hero").AdvantageSources)
+}
+
+func (s *StandardConditionsTestSuite) TestFrightenedCantApproachSource() {
+	s.apply(refs.Conditions.Frightened(), "dragon")
+
+	grid := spatial.NewSquareGrid(spatial.SquareGridConfig{Width: 10, Height: 10})
+	room := spatial.NewBasicRoom(spatial.BasicRoomConfig{ID: "lair", Type: "dungeon", Grid: grid})
+	s.Require().NoError(room.PlaceEntity(&standardTestEntity{id: "hero"}, spatial.Position{X: 2, Y: 2}))
+	s.Require().NoError(room.PlaceEntity(&standardTestEntity{id: "dragon"}, spatial.Position{X: 6, Y: 2}))
+	ctx := combat.WithRoom(s.ctx, room)
+
+	move := func(toX int) *dnd5eEvents.MovementChainEvent {
+		event := &dnd5eEvents.MovementChainEvent{
+			EntityID:     "hero",
+			FromPosition: dnd5eEvents.Position{X: 2, Y: 2},
+			ToPosition:   dnd5eEvents.Position{X: float64(toX), Y: 2},
+		}
+		moveChain := events.NewStagedChain[*dnd5eEvents.MovementChainEvent](combat.ModifierStages)
+		modifiedChain, err := dnd5eEvents.MovementChain.On(s.bus).PublishWithChain(ctx, event, moveChain)
+		s.Require().NoError(err)
+		final, err := modifiedChain.Execute(ctx, event)
+		s.Require().NoError(err)
+		return final
+	}
+
+	s.True(move(3).MovementPrevented, "stepping toward the dragon is prevented")
+	s.False(move(1).MovementPrevented, "stepping away is allowed")
+}
+
+func (s *StandardConditionsTestSuite) TestInvisible() {
+	s.apply(refs.Conditions.Invisible(), "")
+
+	s.Len(s.attack(s.ctx, "hero", "goblin").AdvantageSources, 1)
+	s.Len(s.attack(s.ctx, "goblin", "hero").DisadvantageSources, 1)
+}
+
+func (s *StandardConditionsTestSuite) TestParalyzed() {
+	s.apply(refs.Conditions.Paralyzed(), "")
+
+	final := s.attack(s.ctx, "goblin", "hero")
+	s.Len(final.AdvantageSources, 1)
+	s.Equal(1, final.CriticalThreshold, "melee hits are critical")
+
+	s.Len(s.save(abilities.STR).AutoFailSources, 1)
+	s.Len(s.save(abilities.DEX).AutoFailSources, 1)
+	s.Empty(s.save(abilities.WIS).AutoFailSources)
+}
+
+func (s *StandardConditionsTestSuite) TestParalyzedCritOnlyWithinFiveFeet() {
+	s.apply(refs.Conditions.Paralyzed(), "")
+
+	grid := spatial.NewSquareGrid(spatial.SquareGridConfig{Width: 20, Height: 20})
+	room := spatial.NewBasicRoom(spatial.BasicRoomConfig{ID: "field", Type: "outdoor", Grid: grid})
+	s.Require().NoError(room.PlaceEntity(&standardTestEntity{id: "hero"}, spatial.Position{X: 0, Y: 0}))
+	s.Require().NoError(room.PlaceEntity(&standardTestEntity{id: "archer"}, spatial.Position{X: 10, Y: 0}))
+	s.Require().NoError(room.PlaceEntity(&standardTestEntity{id: "goblin"}, spatial.Position{X: 1, Y: 0}))
+	ctx := combat.WithRoom(s.ctx, room)
+
+	s.Equal(20, s.attack(ctx, "archer", "hero").CriticalThreshold)
+	s.Equal(1, s.attack(ctx, "goblin", "hero").CriticalThreshold)
+}
+
+func (s *StandardConditionsTestSuite) TestPetrifiedResistsDamage() {
+	s.apply(refs.Conditions.Petrified(), "")
+
+	s.Len(s.attack(s.ctx, "goblin", "hero").AdvantageSources, 1)
+	s.Len(s.save(abilities.DEX).AutoFailSources, 1)
+
+	event := &dnd5eEvents.DamageChainEvent{AttackerID: "goblin", TargetID: "hero", DamageType: "slashing"}
+	damageChain := events.NewStagedChain[*dnd5eEvents.DamageChainEvent](combat.ModifierStages)
+	modifiedChain, err := dnd5eEvents.DamageChain.On(s.bus).PublishWithChain(s.ctx, event, damageChain)
+	s.Require().NoError(err)
+	final, err := modifiedChain.Execute(s.ctx, event)
+	s.Require().NoError(err)
+
+	s.Require().Len(final.Components, 1)
+	s.Equal(0.5, final.Components[0].Multiplier)
+}
+
+func (s *StandardConditionsTestSuite) TestPoisoned() {
+	s.apply(refs.Conditions.Poisoned(), "")
+
+	s.Len(s.attack(s.ctx, "hero", "goblin").DisadvantageSources, 1)
+	s.Len(s.check(false, false).DisadvantageSources, 1)
+}
+
+func (s *StandardConditionsTestSuite) TestStunned() {
+	s.apply(refs.Conditions.Stunned(), "")
+
+	s.Len(s.attack(s.ctx, "goblin", "hero").AdvantageSources, 1)
+	s.Len(s.save(abilities.STR).AutoFailSources, 1)
+	s.Equal(20, s.attack(s.ctx, "goblin", "hero").CriticalThreshold, "stunned doesn't grant crits")
+}
+
+func (s *StandardConditionsTestSuite) TestIncapacitatedHasNoChainEffects() {
+	condition, err := NewStandardCondition(refs.Conditions.Incapacitated(), StandardConditionInput{CharacterID: "hero"})
+	s.Require().NoError(err)
+	s.Require().NoError(condition.Apply(s.ctx, s.bus))
+
+	s.True(condition.IsApplied())
+	s.Empty(condition.(*IncapacitatedCondition).subscriptionIDs)
+}
+
+func (s *StandardConditionsTestSuite) TestRemoveStopsEffects() {
+	condition := s.apply(refs.Conditions.Blinded(), "")
+	s.Require().NoError(condition.Remove(s.ctx, s.bus))
+
+	s.False(condition.IsApplied())
+	s.Empty(s.attack(s.ctx, "goblin", "hero").AdvantageSources)
+}
+
+func (s *StandardConditionsTestSuite) TestApplyTwiceFails() {
+	condition := s.apply(refs.Conditions.Poisoned(), "")
+	err := condition.Apply(s.ctx, s.bus)
+	s.Equal(rpgerr.CodeAlreadyExists, rpgerr.GetCode(err))
+}
+
+func (s *StandardConditionsTestSuite) TestNewStandardConditionRejectsOtherRefs() {
+	_, err := NewStandardCondition(refs.Conditions.Raging(), StandardConditionInput{CharacterID: "hero"})
+	s.Equal(rpgerr.CodeInvalidArgument, rpgerr.GetCode(err))
+}
+
+func (s *StandardConditionsTestSuite) TestJSONRoundTrip() {
+	for refID := range standardConditions {
+		s.Run(refID, func() {
+			ref := &core.Ref{Module: refs.Module, Type: refs.TypeConditions, ID: refID}
+			original, err := NewStandardCondition(ref, StandardConditionInput{CharacterID: "hero", SourceID: "lich"})
+			s.Require().NoError(err)
+
+			data, err := original.ToJSON()
+			s.Require().NoError(err)
+
+			loaded, err := LoadJSON(data)
+			s.Require().NoError(err)
+			s.IsType(original, loaded)
+
+			reserialized, err := loaded.ToJSON()
+			s.Require().NoError(err)
+			s.JSONEq(string(data), string(reserialized))
+		})
+	}
+}
+
+func (s *StandardConditionsTestSuite) TestCreateFromRef() {
+	output, err := CreateFromRef(&CreateFromRefInput{
+		Ref:         refs.Conditions.Charmed().String(),
+		Config:      json.RawMessage(`{"source_id":"vampire"}`),
+		CharacterID: "hero",
+	})
+	s.Require().NoError(err)
+
+	charmed, ok := output.Condition.(*CharmedCondition)
+	s.Require().True(ok)
+	s.Equal("hero", charmed.CharacterID)
+	s.Equal("vampire", charmed.SourceID)
+}
@@ -0,0 +1,32 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// StunnedCondition represents a stunned creature.
+//
+// While applied:
+//   - The creature is incapacitated and can't move (through the action economy)
+//   - It fails STR and DEX saves automatically
+//   - Attacks against it have advantage
+type StunnedCondition struct {
+	standardCondition
+}
+
+// Ensure StunnedCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*StunnedCondition)(nil)
+
+// NewStunnedCondition creates a stunned condition from input
+func NewStunnedCondition(input StandardConditionInput) *StunnedCondition {
+	return &StunnedCondition{newStandardCondition(input, standardEffects{
+		name:                  "Stunned",
+		ref:                   refs.Conditions.Stunned(),
+		failsStrDexSaves:      true,
+		attackedWithAdvantage: true,
+	})}
+}
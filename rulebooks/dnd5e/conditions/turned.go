@@ -0,0 +1,181 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// TurnedDurationTurns is how long a creature stays turned: 1 minute (10 rounds)
+const TurnedDurationTurns = 10
+
+// TurnedData is the JSON structure for persisting turned condition state
+type TurnedData struct {
+	Ref         *core.Ref `json:"ref"`
+	CharacterID string    `json:"character_id"`
+	SourceID    string    `json:"source_id,omitempty"`
+	TurnsActive int       `json:"turns_active"`
+}
+
+// TurnedCondition represents an undead creature turned by a cleric's Turn Undead.
+// The creature must spend its turns moving away from the cleric and can't take
+// reactions (see combat.ConditionEconomyRestriction). The condition ends when
+// the creature takes any damage or after 1 minute.
+type TurnedCondition struct {
+	CharacterID     string // The turned creature
+	SourceID        string // The cleric who turned it
+	TurnsActive     int
+	subscriptionIDs []string
+	bus             events.EventBus
+}
+
+// Ensure TurnedCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*TurnedCondition)(nil)
+
+// TurnedInput provides configuration for creating a turned condition
+type TurnedInput struct {
+	CharacterID string // ID of the turned creature
+	SourceID    string // ID of the cleric who turned it
+}
+
+// NewTurnedCondition creates a turned condition from input
+func NewTurnedCondition(input TurnedInput) *TurnedCondition {
+	return &TurnedCondition{
+		CharacterID: input.CharacterID,
+		SourceID:    input.SourceID,
+	}
+}
+
+// IsApplied returns true if this condition is currently applied
+func (t *TurnedCondition) IsApplied() bool {
+	return t.bus != nil
+}
+
+// Apply subscribes this condition to damage and turn end events
+func (t *TurnedCondition) Apply(ctx context.Context, bus events.EventBus) error {
+	if t.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "turned condition already applied")
+	}
+	t.bus = bus
+
+	damages := dnd5eEvents.DamageReceivedTopic.On(bus)
+	subID1, err := damages.Subscribe(ctx, t.onDamageReceived)
+	if err != nil {
+		t.bus = nil
+		return rpgerr.Wrap(err, "failed to subscribe to damage received")
+	}
+	t.subscriptionIDs = append(t.subscriptionIDs, subID1)
+
+	turnEnds := dnd5eEvents.TurnEndTopic.On(bus)
+	subID2, err := turnEnds.Subscribe(ctx, t.onTurnEnd)
+	if err != nil {
+		_ = t.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to turn end")
+	}
+	t.subscriptionIDs = append(t.subscriptionIDs, subID2)
+
+	return nil
+}
+
+// Remove unsubscribes this condition from events
+func (t *TurnedCondition) Remove(ctx context.Context, bus events.EventBus) error {
+	if t.bus == nil {
+		return nil
+	}
+
+	total := len(t.subscriptionIDs)
+	var errs []error
+	for _, subID := range t.subscriptionIDs {
+		if err := bus.Unsubscribe(ctx, subID); err != nil {
+			errs = append(errs, fmt.Errorf("unsubscribe %s: %w", subID, err))
+		}
+	}
+
+	t.subscriptionIDs = nil
+	t.bus = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unsubscribe %d/%d subscriptions: %w", len(errs), total, errors.Join(errs...))
+	}
+	return nil
+}
+
+// ToJSON converts the condition to JSON for persistence
+func (t *TurnedCondition) ToJSON() (json.RawMessage, error) {
+	data := TurnedData{
+		Ref:         refs.Conditions.Turned(),
+		CharacterID: t.CharacterID,
+		SourceID:    t.SourceID,
+		TurnsActive: t.TurnsActive,
+	}
+
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to marshal turned data")
+	}
+
+	return bytes, nil
+}
+
+// loadJSON loads the condition from JSON
+func (t *TurnedCondition) loadJSON(data json.RawMessage) error {
+	var turnedData TurnedData
+	if err := json.Unmarshal(data, &turnedData); err != nil {
+		return rpgerr.Wrap(err, "failed to unmarshal turned data")
+	}
+
+	t.CharacterID = turnedData.CharacterID
+	t.SourceID = turnedData.SourceID
+	t.TurnsActive = turnedData.TurnsActive
+	return nil
+}
+
+// onDamageReceived ends the condition when the turned creature takes damage
+func (t *TurnedCondition) onDamageReceived(ctx context.Context, event dnd5eEvents.DamageReceivedEvent) error {
+	if event.TargetID != t.CharacterID || event.Amount <= 0 {
+		return nil
+	}
+	return t.end(ctx, "damaged")
+}
+
+// onTurnEnd ends the condition after 1 minute
+func (t *TurnedCondition) onTurnEnd(ctx context.Context, event dnd5eEvents.TurnEndEvent) error {
+	if event.CharacterID != t.CharacterID {
+		return nil
+	}
+
+	t.TurnsActive++
+	if t.TurnsActive >= TurnedDurationTurns {
+		return t.end(ctx, "duration_expired")
+	}
+	return nil
+}
+
+// end publishes the removal event and unsubscribes from all events
+func (t *TurnedCondition) end(ctx context.Context, reason string) error {
+	if t.bus == nil {
+		return nil
+	}
+
+	removals := dnd5eEvents.ConditionRemovedTopic.On(t.bus)
+	err := removals.Publish(ctx, dnd5eEvents.ConditionRemovedEvent{
+		CharacterID:  t.CharacterID,
+		ConditionRef: refs.Conditions.Turned().String(),
+		Reason:       reason,
+	})
+	if err != nil {
+		return rpgerr.Wrapf(err, "error publishing turned removal for %s", t.CharacterID)
+	}
+
+	return t.Remove(ctx, t.bus)
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+type TurnedTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	bus      events.EventBus
+	turned   *TurnedCondition
+	removals []dnd5eEvents.ConditionRemovedEvent
+}
+
+func TestTurnedSuite(t *testing.T) {
+	suite.Run(t, new(TurnedTestSuite))
+}
+
+func (s *TurnedTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.removals = nil
+
+	s.turned = NewTurnedCondition(TurnedInput{CharacterID: "zombie-1", SourceID: "cleric-1"})
+	s.Require().NoError(s.turned.Apply(s.ctx, s.bus))
+
+	_, err := dnd5eEvents.ConditionRemovedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, event dnd5eEvents.ConditionRemovedEvent) error {
+			s.removals = append(s.removals, event)
+			return nil
+		})
+	s.Require().NoError(err)
+}
+
+func (s *TurnedTestSuite) damage(targetID string, amount int) {
+	err := dnd5eEvents.DamageReceivedTopic.On(s.bus).Publish(s.ctx, dnd5eEvents.DamageReceivedEvent{
+		TargetID: targetID,
+		Amount:   amount,
+	})
+	s.Require().NoError(err)
+}
+
+func (s *TurnedTestSuite) endTurn(characterID string) {
+	err := dnd5eEvents.TurnEndTopic.On(s.bus).Publish(s.ctx, dnd5eEvents.TurnEndEvent{CharacterID: characterID})
+	s.Require().NoError(err)
+}
+
+func (s *TurnedTestSuite) TestEndsWhenDamaged() {
+	s.damage("zombie-2", 5)
+	s.True(s.turned.IsApplied(), "other creatures' damage")
+
+	s.damage("zombie-1", 0)
+	s.True(s.turned.IsApplied(), "no damage taken")
+
+	s.damage("zombie-1", 3)
+	s.False(s.turned.IsApplied())
+	s.Equal([]dnd5eEvents.ConditionRemovedEvent{{
+		CharacterID:  "zombie-1",
+		ConditionRef: refs.Conditions.Turned().String(),
+		Reason:       "damaged",
+	}}, s.removals)
+}
+
+func (s *TurnedTestSuite) TestEndsAfterOneMinute() {
+	for range TurnedDurationTurns - 1 {
+		s.endTurn("zombie-1")
+		s.endTurn("cleric-1")
+	}
+	s.True(s.turned.IsApplied())
+	s.Equal(TurnedDurationTurns-1, s.turned.TurnsActive)
+
+	s.endTurn("zombie-1")
+	s.False(s.turned.IsApplied())
+	s.Require().Len(s.removals, 1)
+	s.Equal("duration_expired", s.removals[0].Reason)
+}
+
+func (s *TurnedTestSuite) TestFactoryAndRoundTrip() {
+	output, err := CreateFromRef(&CreateFromRefInput{
+		Ref:         refs.Conditions.Turned().String(),
+		Config:      json.RawMessage(`{"source_id": "cleric-1"}`),
+		CharacterID: "skeleton-1",
+	})
+	s.Require().NoError(err)
+
+	turned, ok := output.Condition.(*TurnedCondition)
+	s.Require().True(ok)
+	s.Equal("cleric-1", turned.SourceID)
+	turned.TurnsActive = 4
+
+	data, err := turned.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := LoadJSON(data)
+	s.Require().NoError(err)
+	s.Equal(turned, loaded)
+}
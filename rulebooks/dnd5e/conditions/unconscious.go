@@ -29,10 +29,14 @@ type UnconsciousData struct {
 // UnconsciousCondition represents an unconscious character making death saves.
 // It subscribes to turn start, damage, and healing events to automate
 // death saving throws per D&D 5e rules.
+//
+// While applied, the character also fails STR and DEX saves, attacks against
+// it have advantage, and hits from within 5 feet are critical.
 type UnconsciousCondition struct {
 	CharacterID     string
 	Roller          dice.Roller
 	deathSaveState  *saves.DeathSaveState
+	chainEffects    *standardCondition
 	subscriptionIDs []string
 	bus             events.EventBus
 }
@@ -84,6 +88,20 @@ func (c *UnconsciousCondition) Apply(ctx context.Context, bus events.EventBus) e
 	}
 	c.subscriptionIDs = append(c.subscriptionIDs, subID3)
 
+	// Attack and saving throw effects shared with the other standard conditions
+	effects := newStandardCondition(StandardConditionInput{CharacterID: c.CharacterID}, standardEffects{
+		name:                  "Unconscious",
+		ref:                   refs.Conditions.Unconscious(),
+		failsStrDexSaves:      true,
+		attackedWithAdvantage: true,
+		critWithinFive:        true,
+	})
+	if err := effects.Apply(ctx, bus); err != nil {
+		_ = c.Remove(ctx, bus)
+		return err
+	}
+	c.chainEffects = &effects
+
 	return nil
 }
 
@@ -100,6 +118,13 @@ func (c *UnconsciousCondition) Remove(ctx context.Context, bus events.EventBus)
 		}
 	}
 
+	if c.chainEffects != nil {
+		if err := c.chainEffects.Remove(ctx, bus); err != nil {
+			errs = append(errs, err)
+		}
+		c.chainEffects = nil
+	}
+
 	c.subscriptionIDs = nil
 	c.bus = nil
 
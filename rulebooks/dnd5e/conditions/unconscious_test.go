@@ -12,6 +12,8 @@ import (
 
 	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
 	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
 	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/saves"
 )
@@ -25,17 +27,39 @@ type UnconsciousConditionTestSuite struct {
 	mockRoller *mock_dice.MockRoller
 }
 
+// suiteCtxKey marks the suite's context so handler contexts can be traced back to it
+type suiteCtxKey struct{}
+
+// derivedCtxMatcher matches a context derived from one carrying suiteCtxKey
+type derivedCtxMatcher struct {
+	want any
+}
+
+func (m derivedCtxMatcher) Matches(x any) bool {
+	ctx, ok := x.(context.Context)
+	return ok && ctx.Value(suiteCtxKey{}) == m.want
+}
+
+func (m derivedCtxMatcher) String() string {
+	return "is a context derived from the suite context"
+}
+
 func TestUnconsciousConditionTestSuite(t *testing.T) {
 	suite.Run(t, new(UnconsciousConditionTestSuite))
 }
 
 func (s *UnconsciousConditionTestSuite) SetupTest() {
 	s.ctrl = gomock.NewController(s.T())
-	s.ctx = context.Background()
+	s.ctx = context.WithValue(context.Background(), suiteCtxKey{}, s.T().Name())
 	s.bus = events.NewEventBus()
 	s.mockRoller = mock_dice.NewMockRoller(s.ctrl)
 }
 
+// derivedCtx matches the context a handler receives for an event published with s.ctx
+func (s *UnconsciousConditionTestSuite) derivedCtx() gomock.Matcher {
+	return derivedCtxMatcher{want: s.ctx.Value(suiteCtxKey{})}
+}
+
 func (s *UnconsciousConditionTestSuite) TearDownTest() {
 	s.ctrl.Finish()
 }
@@ -98,7 +122,8 @@ func (s *UnconsciousConditionTestSuite) TestOnTurnStart_RollsDeathSave() {
 	s.Require().NoError(err)
 
 	// Mock: roll a 15 (success)
-	s.mockRoller.EXPECT().Roll(s.ctx, 20).Return(15, nil)
+	// The handler's context carries the event's causality on top of s.ctx
+	s.mockRoller.EXPECT().Roll(s.derivedCtx(), 20).Return(15, nil)
 
 	// Publish turn start
 	turnStartTopic := dnd5eEvents.TurnStartTopic.On(s.bus)
@@ -155,7 +180,7 @@ func (s *UnconsciousConditionTestSuite) TestOnTurnStart_CriticalFail_TwoFailures
 	s.Require().NoError(err)
 
 	// Mock: roll a 1 (critical fail)
-	s.mockRoller.EXPECT().Roll(s.ctx, 20).Return(1, nil)
+	s.mockRoller.EXPECT().Roll(s.derivedCtx(), 20).Return(1, nil)
 
 	turnStartTopic := dnd5eEvents.TurnStartTopic.On(s.bus)
 	err = turnStartTopic.Publish(s.ctx, dnd5eEvents.TurnStartEvent{
@@ -202,7 +227,7 @@ func (s *UnconsciousConditionTestSuite) TestOnTurnStart_CriticalSuccess_RegainsC
 	s.Require().NoError(err)
 
 	// Mock: roll a 20 (critical success)
-	s.mockRoller.EXPECT().Roll(s.ctx, 20).Return(20, nil)
+	s.mockRoller.EXPECT().Roll(s.derivedCtx(), 20).Return(20, nil)
 
 	turnStartTopic := dnd5eEvents.TurnStartTopic.On(s.bus)
 	err = turnStartTopic.Publish(s.ctx, dnd5eEvents.TurnStartEvent{
@@ -248,7 +273,7 @@ func (s *UnconsciousConditionTestSuite) TestOnTurnStart_ThreeFailures_Dies() {
 	s.Require().NoError(err)
 
 	// Mock: roll a 5 (failure, bringing total to 3)
-	s.mockRoller.EXPECT().Roll(s.ctx, 20).Return(5, nil)
+	s.mockRoller.EXPECT().Roll(s.derivedCtx(), 20).Return(5, nil)
 
 	turnStartTopic := dnd5eEvents.TurnStartTopic.On(s.bus)
 	err = turnStartTopic.Publish(s.ctx, dnd5eEvents.TurnStartEvent{
@@ -278,7 +303,7 @@ func (s *UnconsciousConditionTestSuite) TestOnTurnStart_ThreeSuccesses_Stabilize
 	s.Require().NoError(err)
 
 	// Mock: roll a 15 (success, bringing total to 3)
-	s.mockRoller.EXPECT().Roll(s.ctx, 20).Return(15, nil)
+	s.mockRoller.EXPECT().Roll(s.derivedCtx(), 20).Return(15, nil)
 
 	turnStartTopic := dnd5eEvents.TurnStartTopic.On(s.bus)
 	err = turnStartTopic.Publish(s.ctx, dnd5eEvents.TurnStartEvent{
@@ -494,3 +519,34 @@ func (s *UnconsciousConditionTestSuite) TestIsApplied_ReflectsBusState() {
 	s.Require().NoError(err)
 	s.False(uc.IsApplied())
 }
+
+func (s *UnconsciousConditionTestSuite) TestAttacksAndSavesAgainstUnconscious() {
+	uc := s.newCondition("char-1")
+	s.Require().NoError(uc.Apply(s.ctx, s.bus))
+
+	attack := dnd5eEvents.AttackChainEvent{AttackerID: "goblin", TargetID: "char-1", IsMelee: true, CriticalThreshold: 20}
+	attackChain := events.NewStagedChain[dnd5eEvents.AttackChainEvent](combat.ModifierStages)
+	modifiedAttack, err := dnd5eEvents.AttackChain.On(s.bus).PublishWithChain(s.ctx, attack, attackChain)
+	s.Require().NoError(err)
+	finalAttack, err := modifiedAttack.Execute(s.ctx, attack)
+	s.Require().NoError(err)
+	s.Len(finalAttack.AdvantageSources, 1)
+	s.Equal(1, finalAttack.CriticalThreshold)
+
+	save := &dnd5eEvents.SavingThrowChainEvent{SaverID: "char-1", Ability: abilities.DEX, DC: 10}
+	saveChain := events.NewStagedChain[*dnd5eEvents.SavingThrowChainEvent](combat.ModifierStages)
+	modifiedSave, err := dnd5eEvents.SavingThrowChain.On(s.bus).PublishWithChain(s.ctx, save, saveChain)
+	s.Require().NoError(err)
+	finalSave, err := modifiedSave.Execute(s.ctx, save)
+	s.Require().NoError(err)
+	s.True(finalSave.AutoFails())
+
+	// Removing the condition removes its chain effects too
+	s.Require().NoError(uc.Remove(s.ctx, s.bus))
+	modifiedAttack, err = dnd5eEvents.AttackChain.On(s.bus).PublishWithChain(s.ctx, attack,
+		events.NewStagedChain[dnd5eEvents.AttackChainEvent](combat.ModifierStages))
+	s.Require().NoError(err)
+	finalAttack, err = modifiedAttack.Execute(s.ctx, attack)
+	s.Require().NoError(err)
+	s.Empty(finalAttack.AdvantageSources)
+}
@@ -0,0 +1,197 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+)
+
+// WildShapedData is the JSON structure for persisting wild shaped condition state
+type WildShapedData struct {
+	Ref         *core.Ref     `json:"ref"`
+	CharacterID string        `json:"character_id"`
+	Form        *monster.Data `json:"form"`
+}
+
+// WildShapedCondition represents a druid transformed into a beast by Wild Shape.
+// The form is the beast's stat block; its HitPoints track the beast's current hit
+// points, layered over the druid's own. The druid keeps their Intelligence, Wisdom
+// and Charisma and can't cast spells (see combat.ConditionEconomyRestriction).
+// The druid reverts when the form drops to 0 hit points, carrying any excess damage
+// over to their normal form, or when they choose to revert.
+type WildShapedCondition struct {
+	CharacterID string
+	Form        *monster.Data
+	bus         events.EventBus
+}
+
+// Ensure WildShapedCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*WildShapedCondition)(nil)
+
+// WildShapedInput provides configuration for creating a wild shaped condition
+type WildShapedInput struct {
+	CharacterID string        // ID of the druid
+	Form        *monster.Data // Stat block of the beast form
+}
+
+// NewWildShapedCondition creates a wild shaped condition from input.
+// The form is copied at full hit points, so the same stat block can be reused.
+func NewWildShapedCondition(input WildShapedInput) *WildShapedCondition {
+	var form *monster.Data
+	if input.Form != nil {
+		copied := *input.Form
+		copied.AbilityScores = maps.Clone(input.Form.AbilityScores)
+		copied.HitPoints = copied.MaxHitPoints
+		form = &copied
+	}
+	return &WildShapedCondition{
+		CharacterID: input.CharacterID,
+		Form:        form,
+	}
+}
+
+// IsApplied returns true if this condition is currently applied
+func (w *WildShapedCondition) IsApplied() bool {
+	return w.bus != nil
+}
+
+// Apply records the bus so the condition can publish its own removal on reverting.
+// The form changes the druid's statistics rather than reacting to events.
+func (w *WildShapedCondition) Apply(_ context.Context, bus events.EventBus) error {
+	if w.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "wild shaped condition already applied")
+	}
+	if w.Form == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "wild shape requires a form")
+	}
+	w.bus = bus
+	return nil
+}
+
+// Remove detaches the condition from the bus
+func (w *WildShapedCondition) Remove(_ context.Context, _ events.EventBus) error {
+	w.bus = nil
+	return nil
+}
+
+// ToJSON converts the condition to JSON for persistence
+func (w *WildShapedCondition) ToJSON() (json.RawMessage, error) {
+	data := WildShapedData{
+		Ref:         refs.Conditions.WildShaped(),
+		CharacterID: w.CharacterID,
+		Form:        w.Form,
+	}
+
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to marshal wild shaped data")
+	}
+
+	return bytes, nil
+}
+
+// loadJSON loads the condition from JSON
+func (w *WildShapedCondition) loadJSON(data json.RawMessage) error {
+	var shapedData WildShapedData
+	if err := json.Unmarshal(data, &shapedData); err != nil {
+		return rpgerr.Wrap(err, "failed to unmarshal wild shaped data")
+	}
+
+	w.CharacterID = shapedData.CharacterID
+	w.Form = shapedData.Form
+	return nil
+}
+
+// HitPoints returns the form's current hit points
+func (w *WildShapedCondition) HitPoints() int {
+	return w.Form.HitPoints
+}
+
+// MaxHitPoints returns the form's maximum hit points
+func (w *WildShapedCondition) MaxHitPoints() int {
+	return w.Form.MaxHitPoints
+}
+
+// ArmorClass returns the form's armor class
+func (w *WildShapedCondition) ArmorClass() int {
+	return w.Form.ArmorClass
+}
+
+// Speed returns the form's walking speed
+func (w *WildShapedCondition) Speed() int {
+	return w.Form.Speed.Walk
+}
+
+// AbilityScores merges the form's physical scores with the druid's mental scores.
+func (w *WildShapedCondition) AbilityScores(base shared.AbilityScores) shared.AbilityScores {
+	merged := maps.Clone(base)
+	if merged == nil {
+		merged = shared.AbilityScores{}
+	}
+	for _, ability := range []abilities.Ability{abilities.STR, abilities.DEX, abilities.CON} {
+		if score, ok := w.Form.AbilityScores[ability]; ok {
+			merged[ability] = score
+		}
+	}
+	return merged
+}
+
+// AbsorbDamage applies damage to the form and returns the damage left over for the
+// druid's normal form. When the form drops to 0 hit points the druid reverts.
+func (w *WildShapedCondition) AbsorbDamage(ctx context.Context, amount int) (int, error) {
+	if amount <= 0 {
+		return 0, nil
+	}
+	if amount < w.Form.HitPoints {
+		w.Form.HitPoints -= amount
+		return 0, nil
+	}
+
+	carryover := amount - w.Form.HitPoints
+	w.Form.HitPoints = 0
+	return carryover, w.end(ctx, "dropped_to_zero")
+}
+
+// Heal restores the form's hit points, up to its maximum
+func (w *WildShapedCondition) Heal(amount int) {
+	if amount <= 0 {
+		return
+	}
+	w.Form.HitPoints = min(w.Form.HitPoints+amount, w.Form.MaxHitPoints)
+}
+
+// Revert ends the wild shape voluntarily (a bonus action on the druid's turn)
+func (w *WildShapedCondition) Revert(ctx context.Context) error {
+	return w.end(ctx, "reverted")
+}
+
+// end publishes the removal event and detaches from the bus
+func (w *WildShapedCondition) end(ctx context.Context, reason string) error {
+	if w.bus == nil {
+		return nil
+	}
+
+	removals := dnd5eEvents.ConditionRemovedTopic.On(w.bus)
+	err := removals.Publish(ctx, dnd5eEvents.ConditionRemovedEvent{
+		CharacterID:  w.CharacterID,
+		ConditionRef: refs.Conditions.WildShaped().String(),
+		Reason:       reason,
+	})
+	if err != nil {
+		return rpgerr.Wrapf(err, "error publishing wild shape removal for %s", w.CharacterID)
+	}
+
+	return w.Remove(ctx, w.bus)
+}
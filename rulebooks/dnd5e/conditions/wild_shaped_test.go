@@ -0,0 +1,177 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package conditions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+)
+
+type WildShapedTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	bus      events.EventBus
+	form     *monster.Data
+	shaped   *WildShapedCondition
+	removals []dnd5eEvents.ConditionRemovedEvent
+}
+
+func TestWildShapedSuite(t *testing.T) {
+	suite.Run(t, new(WildShapedTestSuite))
+}
+
+// SetupTest applies a wolf form (11 HP, AC 13) to druid-1
+func (s *WildShapedTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.removals = nil
+
+	s.form = &monster.Data{
+		ID:           "wolf-form",
+		Name:         "Wolf",
+		Ref:          refs.Monsters.Wolf(),
+		CreatureType: monster.CreatureTypeBeast,
+		HitPoints:    4,
+		MaxHitPoints: 11,
+		ArmorClass:   13,
+		AbilityScores: shared.AbilityScores{
+			abilities.STR: 12,
+			abilities.DEX: 15,
+			abilities.CON: 12,
+			abilities.INT: 3,
+			abilities.WIS: 12,
+			abilities.CHA: 6,
+		},
+		Speed: monster.SpeedData{Walk: 40},
+	}
+
+	s.shaped = NewWildShapedCondition(WildShapedInput{CharacterID: "druid-1", Form: s.form})
+	s.Require().NoError(s.shaped.Apply(s.ctx, s.bus))
+
+	_, err := dnd5eEvents.ConditionRemovedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, event dnd5eEvents.ConditionRemovedEvent) error {
+			s.removals = append(s.removals, event)
+			return nil
+		})
+	s.Require().NoError(err)
+}
+
+func (s *WildShapedTestSuite) TestFormStartsAtFullHitPoints() {
+	s.Equal(11, s.shaped.HitPoints())
+	s.Equal(11, s.shaped.MaxHitPoints())
+	s.Equal(4, s.form.HitPoints, "source stat block is not modified")
+	s.Equal(13, s.shaped.ArmorClass())
+	s.Equal(40, s.shaped.Speed())
+}
+
+func (s *WildShapedTestSuite) TestApplyRequiresForm() {
+	shaped := NewWildShapedCondition(WildShapedInput{CharacterID: "druid-1"})
+	s.Error(shaped.Apply(s.ctx, s.bus))
+	s.False(shaped.IsApplied())
+}
+
+func (s *WildShapedTestSuite) TestAbilityScoresKeepMentalScores() {
+	base := shared.AbilityScores{
+		abilities.STR: 10,
+		abilities.DEX: 12,
+		abilities.CON: 14,
+		abilities.INT: 11,
+		abilities.WIS: 16,
+		abilities.CHA: 9,
+	}
+
+	merged := s.shaped.AbilityScores(base)
+
+	s.Equal(12, merged[abilities.STR])
+	s.Equal(15, merged[abilities.DEX])
+	s.Equal(12, merged[abilities.CON])
+	s.Equal(11, merged[abilities.INT])
+	s.Equal(16, merged[abilities.WIS])
+	s.Equal(9, merged[abilities.CHA])
+	s.Equal(10, base[abilities.STR], "base scores are not modified")
+}
+
+func (s *WildShapedTestSuite) TestAbsorbDamage() {
+	s.Run("form absorbs damage below its hit points", func() {
+		s.SetupTest()
+
+		carryover, err := s.shaped.AbsorbDamage(s.ctx, 7)
+
+		s.Require().NoError(err)
+		s.Equal(0, carryover)
+		s.Equal(4, s.shaped.HitPoints())
+		s.True(s.shaped.IsApplied())
+		s.Empty(s.removals)
+	})
+
+	s.Run("dropping to 0 reverts and carries over excess damage", func() {
+		s.SetupTest()
+
+		carryover, err := s.shaped.AbsorbDamage(s.ctx, 15)
+
+		s.Require().NoError(err)
+		s.Equal(4, carryover)
+		s.Equal(0, s.shaped.HitPoints())
+		s.False(s.shaped.IsApplied())
+		s.Require().Len(s.removals, 1)
+		s.Equal("druid-1", s.removals[0].CharacterID)
+		s.Equal(refs.Conditions.WildShaped().String(), s.removals[0].ConditionRef)
+		s.Equal("dropped_to_zero", s.removals[0].Reason)
+	})
+
+	s.Run("exact damage reverts with no carryover", func() {
+		s.SetupTest()
+
+		carryover, err := s.shaped.AbsorbDamage(s.ctx, 11)
+
+		s.Require().NoError(err)
+		s.Equal(0, carryover)
+		s.False(s.shaped.IsApplied())
+	})
+}
+
+func (s *WildShapedTestSuite) TestHealCapsAtFormMaximum() {
+	_, err := s.shaped.AbsorbDamage(s.ctx, 8)
+	s.Require().NoError(err)
+
+	s.shaped.Heal(20)
+
+	s.Equal(11, s.shaped.HitPoints())
+}
+
+func (s *WildShapedTestSuite) TestRevert() {
+	s.Require().NoError(s.shaped.Revert(s.ctx))
+
+	s.False(s.shaped.IsApplied())
+	s.Require().Len(s.removals, 1)
+	s.Equal("reverted", s.removals[0].Reason)
+}
+
+func (s *WildShapedTestSuite) TestRoundTrip() {
+	_, err := s.shaped.AbsorbDamage(s.ctx, 5)
+	s.Require().NoError(err)
+
+	data, err := s.shaped.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := LoadJSON(data)
+	s.Require().NoError(err)
+
+	shaped, ok := loaded.(*WildShapedCondition)
+	s.Require().True(ok)
+	s.Equal("druid-1", shaped.CharacterID)
+	s.Equal(6, shaped.HitPoints())
+	s.Equal(11, shaped.MaxHitPoints())
+	s.Equal(15, shaped.Form.AbilityScores[abilities.DEX])
+	s.Equal(refs.Monsters.Wolf(), shaped.Form.Ref)
+}
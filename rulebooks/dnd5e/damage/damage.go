@@ -138,3 +138,19 @@ func (t Type) Display() string {
 		return string(t)
 	}
 }
+
+// Annotation is a secondary property of damage that some resistances and
+// immunities check, e.g. "resistance to bludgeoning from nonmagical attacks"
+type Annotation string
+
+// Damage annotation constants
+const (
+	// AnnotationMagical marks damage from a magical weapon or attack
+	AnnotationMagical Annotation = "magical"
+
+	// AnnotationSilvered marks damage from a silvered weapon
+	AnnotationSilvered Annotation = "silvered"
+
+	// AnnotationAdamantine marks damage from an adamantine weapon
+	AnnotationAdamantine Annotation = "adamantine"
+)
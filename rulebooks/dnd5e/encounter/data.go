@@ -0,0 +1,45 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package encounter
+
+import (
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/character"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/initiative"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
+	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
+)
+
+// EncounterData is the persistent state of an in-progress fight. It is pure
+// JSON: conditions, features and traits travel inside the character and
+// monster data, and are re-applied to a new bus by LoadFromData.
+//
+//nolint:revive // EncounterData matches dungeon.DungeonData
+type EncounterData struct {
+	// ID identifies the encounter
+	ID string `json:"id"`
+
+	// Initiative is the turn order, whose turn it is and the round
+	Initiative initiative.TrackerData `json:"initiative"`
+
+	// Positions are where the combatants stand in the room
+	Positions []PositionData `json:"positions,omitempty"`
+
+	// Economies are the combatants' action economies, keyed by combatant ID.
+	// The acting combatant's holds what is left of the current turn; the
+	// others' hold their reactions.
+	Economies map[string]combat.ActionEconomyData `json:"economies,omitempty"`
+
+	// Characters are the player characters, with their HP and conditions
+	Characters []*character.Data `json:"characters,omitempty"`
+
+	// Monsters are the monsters, with their HP, conditions and traits
+	Monsters []*monster.Data `json:"monsters,omitempty"`
+}
+
+// PositionData is a combatant's position in the room
+type PositionData struct {
+	EntityID string           `json:"entity_id"`
+	Position spatial.Position `json:"position"`
+}
@@ -0,0 +1,330 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package encounter saves and restores an in-progress D&D 5e fight: the
+// initiative order, where everyone stands, each combatant's action economy,
+// and the characters and monsters themselves with their HP and conditions.
+package encounter
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/dice"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/character"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/initiative"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster/actions"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monstertraits"
+	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
+)
+
+// Encounter is a fight in progress. It implements combat.CombatantLookup over
+// its characters and monsters, so it can be put in the context for attacks.
+type Encounter struct {
+	id         string
+	bus        events.EventBus
+	room       spatial.Room
+	tracker    *initiative.Tracker
+	characters []*character.Character
+	monsters   []*monster.Monster
+	economies  map[string]*combat.ActionEconomy
+}
+
+// Ensure Encounter implements combat.CombatantLookup
+var _ combat.CombatantLookup = (*Encounter)(nil)
+
+// NewEncounterInput provides the combatants and state of a new fight.
+type NewEncounterInput struct {
+	// ID identifies the encounter.
+	ID string
+
+	// EventBus is the bus the combatants are wired to.
+	EventBus events.EventBus
+
+	// Room holds the combatants' positions.
+	Room spatial.Room
+
+	// Tracker is the initiative order.
+	Tracker *initiative.Tracker
+
+	// Characters are the player characters, loaded on EventBus.
+	Characters []*character.Character
+
+	// Monsters are the monsters, loaded on EventBus.
+	Monsters []*monster.Monster
+}
+
+// NewEncounter creates an encounter from combatants already wired to the bus.
+func NewEncounter(input *NewEncounterInput) (*Encounter, error) {
+	if input == nil {
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "NewEncounterInput is nil")
+	}
+	if input.ID == "" {
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "ID is required")
+	}
+	if input.EventBus == nil {
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "EventBus is required")
+	}
+	if input.Room == nil {
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "Room is required")
+	}
+	if input.Tracker == nil {
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "Tracker is required")
+	}
+
+	e := &Encounter{
+		id:         input.ID,
+		bus:        input.EventBus,
+		room:       input.Room,
+		tracker:    input.Tracker,
+		characters: input.Characters,
+		monsters:   input.Monsters,
+		economies:  make(map[string]*combat.ActionEconomy),
+	}
+	seen := make(map[string]bool)
+	for _, combatant := range e.combatants() {
+		if seen[combatant.GetID()] {
+			return nil, rpgerr.Newf(rpgerr.CodeInvalidArgument, "combatant %s is in the encounter twice", combatant.GetID())
+		}
+		seen[combatant.GetID()] = true
+	}
+	return e, nil
+}
+
+// ID returns the encounter's ID
+func (e *Encounter) ID() string {
+	return e.id
+}
+
+// EventBus returns the bus the combatants are wired to
+func (e *Encounter) EventBus() events.EventBus {
+	return e.bus
+}
+
+// Room returns the room holding the combatants' positions
+func (e *Encounter) Room() spatial.Room {
+	return e.room
+}
+
+// Tracker returns the initiative order
+func (e *Encounter) Tracker() *initiative.Tracker {
+	return e.tracker
+}
+
+// Characters returns the player characters
+func (e *Encounter) Characters() []*character.Character {
+	return e.characters
+}
+
+// Monsters returns the monsters
+func (e *Encounter) Monsters() []*monster.Monster {
+	return e.monsters
+}
+
+// Character returns a player character by ID, or nil
+func (e *Encounter) Character(id string) *character.Character {
+	for _, c := range e.characters {
+		if c.GetID() == id {
+			return c
+		}
+	}
+	return nil
+}
+
+// Monster returns a monster by ID, or nil
+func (e *Encounter) Monster(id string) *monster.Monster {
+	for _, m := range e.monsters {
+		if m.GetID() == id {
+			return m
+		}
+	}
+	return nil
+}
+
+// Get returns a character or monster by ID
+func (e *Encounter) Get(id string) (combat.Combatant, error) {
+	c, err := e.find(id)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// find returns a character or monster by ID
+func (e *Encounter) find(id string) (combatant, error) {
+	for _, c := range e.combatants() {
+		if c.GetID() == id {
+			return c, nil
+		}
+	}
+	return nil, rpgerr.Newf(rpgerr.CodeNotFound, "combatant %s not found in encounter %s", id, e.id)
+}
+
+// Economy returns a combatant's action economy, creating a fresh one the
+// first time. Pass the acting combatant's economy to the TurnManager so
+// what they spend is saved with the encounter.
+func (e *Encounter) Economy(id string) *combat.ActionEconomy {
+	economy, ok := e.economies[id]
+	if !ok {
+		economy = combat.NewActionEconomy()
+		e.economies[id] = economy
+	}
+	return economy
+}
+
+// combatants returns the characters then the monsters
+func (e *Encounter) combatants() []combatant {
+	all := make([]combatant, 0, len(e.characters)+len(e.monsters))
+	for _, c := range e.characters {
+		all = append(all, c)
+	}
+	for _, m := range e.monsters {
+		all = append(all, m)
+	}
+	return all
+}
+
+// combatant is a character or monster in the encounter
+type combatant interface {
+	core.Entity
+	combat.Combatant
+}
+
+// ToData converts the encounter to its persistent form
+func (e *Encounter) ToData() *EncounterData {
+	data := &EncounterData{
+		ID:         e.id,
+		Initiative: e.tracker.ToData(),
+		Characters: make([]*character.Data, 0, len(e.characters)),
+		Monsters:   make([]*monster.Data, 0, len(e.monsters)),
+	}
+
+	for _, c := range e.combatants() {
+		if pos, ok := e.room.GetEntityPosition(c.GetID()); ok {
+			data.Positions = append(data.Positions, PositionData{EntityID: c.GetID(), Position: pos})
+		}
+	}
+
+	if len(e.economies) > 0 {
+		data.Economies = make(map[string]combat.ActionEconomyData, len(e.economies))
+		for id, economy := range e.economies {
+			data.Economies[id] = economy.ToData()
+		}
+	}
+
+	for _, c := range e.characters {
+		data.Characters = append(data.Characters, c.ToData())
+	}
+	for _, m := range e.monsters {
+		data.Monsters = append(data.Monsters, m.ToData())
+	}
+	return data
+}
+
+// LoadFromDataInput contains parameters for restoring an encounter.
+type LoadFromDataInput struct {
+	// Data is the saved encounter.
+	Data *EncounterData
+
+	// EventBus is the bus to wire the combatants and their conditions to.
+	EventBus events.EventBus
+
+	// Room is the encounter's room without its combatants; they are placed
+	// at their saved positions. Room geometry is map data, not fight state,
+	// so it isn't part of EncounterData.
+	Room spatial.Room
+
+	// Roller is used by traits that roll, such as Undead Fortitude.
+	// If nil, a default roller is used.
+	Roller dice.Roller
+}
+
+// LoadFromData restores a saved encounter: it loads the characters and
+// monsters on the bus, re-applying their conditions and traits, places them
+// in the room and restores the initiative order and action economies.
+func LoadFromData(ctx context.Context, input *LoadFromDataInput) (*Encounter, error) {
+	if input == nil || input.Data == nil {
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "encounter data is required")
+	}
+	if input.EventBus == nil {
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "EventBus is required")
+	}
+	if input.Room == nil {
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "Room is required")
+	}
+	roller := input.Roller
+	if roller == nil {
+		roller = dice.NewRoller()
+	}
+	data := input.Data
+
+	characters := make([]*character.Character, 0, len(data.Characters))
+	for _, charData := range data.Characters {
+		c, err := character.LoadFromData(ctx, charData, input.EventBus)
+		if err != nil {
+			return nil, rpgerr.Wrapf(err, "failed to load character %s", charData.ID)
+		}
+		characters = append(characters, c)
+	}
+
+	monsters := make([]*monster.Monster, 0, len(data.Monsters))
+	for _, monsterData := range data.Monsters {
+		m, err := loadMonster(ctx, monsterData, input.EventBus, roller)
+		if err != nil {
+			return nil, err
+		}
+		monsters = append(monsters, m)
+	}
+
+	e, err := NewEncounter(&NewEncounterInput{
+		ID:         data.ID,
+		EventBus:   input.EventBus,
+		Room:       input.Room,
+		Tracker:    initiative.LoadFromData(data.Initiative),
+		Characters: characters,
+		Monsters:   monsters,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pos := range data.Positions {
+		entity, err := e.find(pos.EntityID)
+		if err != nil {
+			return nil, rpgerr.Wrap(err, "failed to place combatant")
+		}
+		if err := input.Room.PlaceEntity(entity, pos.Position); err != nil {
+			return nil, rpgerr.Wrapf(err, "failed to place %s at %s", pos.EntityID, pos.Position)
+		}
+	}
+
+	for id, economyData := range data.Economies {
+		if _, err := e.find(id); err != nil {
+			return nil, rpgerr.Wrap(err, "failed to restore action economy")
+		}
+		e.economies[id] = combat.LoadActionEconomyData(economyData)
+	}
+
+	return e, nil
+}
+
+// loadMonster loads a monster with its actions and conditions on the bus
+func loadMonster(ctx context.Context, data *monster.Data, bus events.EventBus, roller dice.Roller) (*monster.Monster, error) {
+	m, err := monster.LoadFromData(ctx, data, bus)
+	if err != nil {
+		return nil, rpgerr.Wrapf(err, "failed to load monster %s", data.ID)
+	}
+	if err := actions.LoadMonsterActions(m, data.Actions); err != nil {
+		_ = m.Cleanup(ctx)
+		return nil, rpgerr.Wrapf(err, "failed to load actions for monster %s", data.ID)
+	}
+	if err := monstertraits.LoadMonsterConditions(ctx, m, data.Conditions, bus, roller); err != nil {
+		_ = m.Cleanup(ctx)
+		return nil, rpgerr.Wrapf(err, "failed to load conditions for monster %s", data.ID)
+	}
+	return m, nil
+}
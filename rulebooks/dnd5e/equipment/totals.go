@@ -0,0 +1,69 @@
+package equipment
+
+import (
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/packs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+)
+
+// Stack is a quantity of one piece of equipment, e.g. 10 torches or a
+// shopping cart line
+type Stack struct {
+	ID       shared.EquipmentID `json:"id"`
+	Quantity int                `json:"quantity"`
+}
+
+// TotalWeight returns the weight in pounds of the stacks. A pack weighs what
+// its contents weigh.
+func TotalWeight(stacks []Stack) (float64, error) {
+	total := 0.0
+	for _, stack := range stacks {
+		item, err := lookupStack(stack)
+		if err != nil {
+			return 0, err
+		}
+		total += float64(item.EquipmentWeight()) * float64(stack.Quantity)
+	}
+	return total, nil
+}
+
+// TotalValue returns the value in copper pieces of the stacks, e.g. the
+// price of a shopping list. Use shared.FormatCost to display it.
+func TotalValue(stacks []Stack) (int, error) {
+	total := 0
+	for _, stack := range stacks {
+		item, err := lookupStack(stack)
+		if err != nil {
+			return 0, err
+		}
+		total += item.EquipmentValue() * stack.Quantity
+	}
+	return total, nil
+}
+
+// PackContents returns a pack's contents as stacks, to weigh or price them
+// on their own
+func PackContents(id packs.PackID) ([]Stack, error) {
+	pack, err := packs.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	stacks := make([]Stack, len(pack.Contents))
+	for i, item := range pack.Contents {
+		stacks[i] = Stack{ID: item.ItemID, Quantity: item.Quantity}
+	}
+	return stacks, nil
+}
+
+// lookupStack finds a stack's equipment
+func lookupStack(stack Stack) (Equipment, error) {
+	if stack.Quantity < 0 {
+		return nil, rpgerr.Newf(rpgerr.CodeInvalidArgument, "negative quantity %d of %s", stack.Quantity, stack.ID)
+	}
+	item, err := GetByID(stack.ID)
+	if err != nil {
+		return nil, rpgerr.Wrapf(err, "failed to find equipment %s", stack.ID)
+	}
+	return item, nil
+}
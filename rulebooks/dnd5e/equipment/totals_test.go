@@ -0,0 +1,90 @@
+package equipment_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/ammunition"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/armor"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/equipment"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/items"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/packs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/weapons"
+)
+
+// TotalsTestSuite tests weighing and pricing equipment
+type TotalsTestSuite struct {
+	suite.Suite
+}
+
+func TestTotalsSuite(t *testing.T) {
+	suite.Run(t, new(TotalsTestSuite))
+}
+
+func (s *TotalsTestSuite) TestFighterKit() {
+	kit := []equipment.Stack{
+		{ID: armor.ChainMail, Quantity: 1},
+		{ID: weapons.Longsword, Quantity: 1},
+		{ID: armor.Shield, Quantity: 1},
+		{ID: weapons.Handaxe, Quantity: 2},
+		{ID: ammunition.Arrows20, Quantity: 1},
+	}
+
+	weight, err := equipment.TotalWeight(kit)
+	s.Require().NoError(err)
+	s.Equal(55.0+3+6+2*2+1, weight)
+
+	value, err := equipment.TotalValue(kit)
+	s.Require().NoError(err)
+	s.Equal(7500+1500+1000+2*500+100, value)
+	s.Equal("111 gp", shared.FormatCost(value))
+}
+
+func (s *TotalsTestSuite) TestPackContentsMatchPack() {
+	for _, id := range []packs.PackID{packs.ExplorerPack, packs.DungeoneerPack} {
+		contents, err := equipment.PackContents(id)
+		s.Require().NoError(err)
+
+		weight, err := equipment.TotalWeight(contents)
+		s.Require().NoError(err)
+		s.Equal(float64(packs.All[id].Weight), weight, id)
+
+		packWeight, err := equipment.TotalWeight([]equipment.Stack{{ID: id, Quantity: 1}})
+		s.Require().NoError(err)
+		s.Equal(weight, packWeight, id)
+	}
+}
+
+func (s *TotalsTestSuite) TestEveryPackItemHasData() {
+	for id, pack := range packs.All {
+		contents, err := equipment.PackContents(id)
+		s.Require().NoError(err)
+		_, err = equipment.TotalValue(contents)
+		s.NoError(err, "pack %s", pack.Name)
+	}
+}
+
+func (s *TotalsTestSuite) TestGearPricedPerSaleUnit() {
+	value, err := equipment.TotalValue([]equipment.Stack{
+		{ID: items.Torch, Quantity: 10},
+		{ID: items.HempenRope, Quantity: 1},
+		{ID: items.Piton, Quantity: 10},
+	})
+	s.Require().NoError(err)
+	s.Equal(10+100+50, value)
+	s.Equal("1 gp 6 sp", shared.FormatCost(value))
+}
+
+func (s *TotalsTestSuite) TestErrors() {
+	_, err := equipment.TotalWeight([]equipment.Stack{{ID: "bag-of-holding", Quantity: 1}})
+	s.Equal(rpgerr.CodeNotFound, rpgerr.GetCode(err))
+
+	_, err = equipment.TotalValue([]equipment.Stack{{ID: items.Torch, Quantity: -1}})
+	s.Equal(rpgerr.CodeInvalidArgument, rpgerr.GetCode(err))
+
+	_, err = equipment.PackContents("wizard-pack")
+	s.Error(err)
+}
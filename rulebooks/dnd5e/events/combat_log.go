@@ -0,0 +1,436 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package events
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/resources"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+)
+
+// CombatLogVersion is the version of the combat log schema. It changes only
+// when a field is renamed, removed or changes meaning; new optional fields
+// keep the version.
+const CombatLogVersion = 1
+
+// CombatLogKind identifies what a combat log entry records
+type CombatLogKind string
+
+const (
+	// CombatLogAttackResolved records an attack roll and its outcome
+	CombatLogAttackResolved CombatLogKind = "attack_resolved"
+	// CombatLogSaveResolved records a saving throw and its outcome
+	CombatLogSaveResolved CombatLogKind = "save_resolved"
+	// CombatLogDamageApplied records damage a creature took
+	CombatLogDamageApplied CombatLogKind = "damage_applied"
+	// CombatLogConditionApplied records a condition a creature gained
+	CombatLogConditionApplied CombatLogKind = "condition_applied"
+	// CombatLogResourceSpent records a resource a creature used
+	CombatLogResourceSpent CombatLogKind = "resource_spent"
+)
+
+// CombatLogEvent is one entry in the canonical combat log stream. API servers
+// and VTT clients consume CombatLogTopic (or its JSON) instead of the many
+// rule-level topics, and render entries with Text.
+//
+// Exactly one payload field is set, matching Kind.
+type CombatLogEvent struct {
+	Version  int           `json:"version"`
+	Kind     CombatLogKind `json:"kind"`
+	ActorID  string        `json:"actor_id,omitempty"`  // Who acted (attacker, damage source, resource user)
+	TargetID string        `json:"target_id,omitempty"` // Who was acted on
+
+	Attack    *AttackResolvedLog   `json:"attack,omitempty"`
+	Save      *SaveResolvedLog     `json:"save,omitempty"`
+	Damage    *DamageAppliedLog    `json:"damage,omitempty"`
+	Condition *ConditionAppliedLog `json:"condition,omitempty"`
+	Resource  *ResourceSpentLog    `json:"resource,omitempty"`
+}
+
+// AttackResolvedLog is the payload of an attack_resolved entry
+type AttackResolvedLog struct {
+	WeaponRef    *core.Ref   `json:"weapon_ref,omitempty"`
+	Rolls        []int       `json:"rolls"` // Every d20 rolled
+	Roll         int         `json:"roll"`  // The d20 kept
+	Total        int         `json:"total"`
+	TargetAC     int         `json:"target_ac"`
+	Hit          bool        `json:"hit"`
+	Critical     bool        `json:"critical,omitempty"`
+	Advantage    bool        `json:"advantage,omitempty"`
+	Disadvantage bool        `json:"disadvantage,omitempty"`
+	Damage       int         `json:"damage,omitempty"` // 0 on a miss
+	DamageType   damage.Type `json:"damage_type,omitempty"`
+
+	// Breakdown from the attack trace, in chain order
+	Sources       []CombatLogSource `json:"sources,omitempty"`        // Attack bonuses and roll mode changes
+	ACSources     []CombatLogSource `json:"ac_sources,omitempty"`     // Cover and reaction changes to the AC
+	DamageSources []CombatLogSource `json:"damage_sources,omitempty"` // Damage components
+}
+
+// CombatLogSource is one labelled contribution to a logged roll, taken from a
+// chain trace step or a damage component
+type CombatLogSource struct {
+	Kind       ChainTraceKind `json:"kind"`
+	Label      string         `json:"label"` // "DEX", "Proficiency", "Archery", "Longbow"
+	Value      int            `json:"value,omitempty"`
+	DamageType damage.Type    `json:"damage_type,omitempty"`
+	Multiplier float64        `json:"multiplier,omitempty"`
+	Detail     string         `json:"detail,omitempty"` // Rerolls, e.g. "rerolled 1 to 4, Great Weapon Fighting"
+	SourceRef  *core.Ref      `json:"source_ref,omitempty"`
+}
+
+// SaveResolvedLog is the payload of a save_resolved entry
+type SaveResolvedLog struct {
+	Ability abilities.Ability `json:"ability"`
+	DC      int               `json:"dc"`
+	Roll    int               `json:"roll"`
+	Total   int               `json:"total"`
+	Success bool              `json:"success"`
+	Cause   *core.Ref         `json:"cause,omitempty"` // The spell or feature that forced the save
+}
+
+// DamageAppliedLog is the payload of a damage_applied entry
+type DamageAppliedLog struct {
+	Amount     int         `json:"amount"`
+	DamageType damage.Type `json:"damage_type,omitempty"`
+	SourceRef  *core.Ref   `json:"source_ref,omitempty"`
+	Critical   bool        `json:"critical,omitempty"`
+}
+
+// ConditionAppliedLog is the payload of a condition_applied entry
+type ConditionAppliedLog struct {
+	Condition ConditionType   `json:"condition"`
+	Source    ConditionSource `json:"source,omitempty"`
+}
+
+// ResourceSpentLog is the payload of a resource_spent entry
+type ResourceSpentLog struct {
+	Resource  resources.ResourceKey `json:"resource"`
+	Amount    int                   `json:"amount"`
+	Remaining int                   `json:"remaining"`
+}
+
+// CombatLogTopic carries the canonical combat log stream
+var CombatLogTopic = events.DefineTypedTopic[CombatLogEvent]("dnd5e.combat.log")
+
+// CombatLogSourcesFromTrace converts chain trace steps to combat log sources
+func CombatLogSourcesFromTrace(steps []ChainTraceStep) []CombatLogSource {
+	if len(steps) == 0 {
+		return nil
+	}
+	sources := make([]CombatLogSource, 0, len(steps))
+	for _, step := range steps {
+		sources = append(sources, CombatLogSource{
+			Kind:       step.Kind,
+			Label:      traceStepLabel(step),
+			Value:      step.Value,
+			DamageType: step.DamageType,
+			Multiplier: step.Multiplier,
+			SourceRef:  step.SourceRef,
+		})
+	}
+	return sources
+}
+
+// CombatLogSourcesFromDamage converts final damage components to combat log
+// sources: dice as damage, flat modifiers as bonuses, and multipliers
+func CombatLogSourcesFromDamage(components []DamageComponent) []CombatLogSource {
+	if len(components) == 0 {
+		return nil
+	}
+	sources := make([]CombatLogSource, 0, len(components))
+	for i := range components {
+		component := &components[i]
+		source := CombatLogSource{
+			Label:      componentLabel(component),
+			DamageType: component.DamageType,
+			SourceRef:  component.SourceRef,
+		}
+		switch {
+		case component.Multiplier != 0:
+			source.Kind = ChainTraceMultiplier
+			source.Multiplier = component.Multiplier
+		case len(component.FinalDiceRolls) == 0:
+			source.Kind = ChainTraceBonus
+			source.Value = component.FlatBonus
+		default:
+			source.Kind = ChainTraceDamage
+			source.Value = component.Total()
+			rerolls := make([]string, 0, len(component.Rerolls))
+			for _, reroll := range component.Rerolls {
+				rerolls = append(rerolls, fmt.Sprintf("rerolled %d to %d, %s",
+					reroll.Before, reroll.After, humanizeID(reroll.Reason)))
+			}
+			source.Detail = strings.Join(rerolls, "; ")
+		}
+		sources = append(sources, source)
+	}
+	return sources
+}
+
+// DamageAppliedLogFrom converts a DamageReceivedEvent to a combat log entry
+func DamageAppliedLogFrom(e DamageReceivedEvent) CombatLogEvent {
+	return CombatLogEvent{
+		Version:  CombatLogVersion,
+		Kind:     CombatLogDamageApplied,
+		ActorID:  e.SourceID,
+		TargetID: e.TargetID,
+		Damage: &DamageAppliedLog{
+			Amount:     e.Amount,
+			DamageType: e.DamageType,
+			SourceRef:  e.SourceRef,
+			Critical:   e.IsCritical,
+		},
+	}
+}
+
+// ConditionAppliedLogFrom converts a ConditionAppliedEvent to a combat log entry
+func ConditionAppliedLogFrom(e ConditionAppliedEvent) CombatLogEvent {
+	entry := CombatLogEvent{
+		Version: CombatLogVersion,
+		Kind:    CombatLogConditionApplied,
+		Condition: &ConditionAppliedLog{
+			Condition: e.Type,
+			Source:    e.Source,
+		},
+	}
+	if e.Target != nil {
+		entry.TargetID = e.Target.GetID()
+	}
+	return entry
+}
+
+// ResourceSpentLogFrom converts a ResourceConsumedEvent to a combat log entry
+func ResourceSpentLogFrom(e ResourceConsumedEvent) CombatLogEvent {
+	return CombatLogEvent{
+		Version: CombatLogVersion,
+		Kind:    CombatLogResourceSpent,
+		ActorID: e.CharacterID,
+		Resource: &ResourceSpentLog{
+			Resource:  e.ResourceKey,
+			Amount:    e.Amount,
+			Remaining: e.Remaining,
+		},
+	}
+}
+
+// Validate checks the entry's version and that its payload matches its kind
+func (e *CombatLogEvent) Validate() error {
+	if e.Version < 1 || e.Version > CombatLogVersion {
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument, "unsupported combat log version %d", e.Version)
+	}
+
+	payloads := map[CombatLogKind]bool{
+		CombatLogAttackResolved:   e.Attack != nil,
+		CombatLogSaveResolved:     e.Save != nil,
+		CombatLogDamageApplied:    e.Damage != nil,
+		CombatLogConditionApplied: e.Condition != nil,
+		CombatLogResourceSpent:    e.Resource != nil,
+	}
+	set, known := payloads[e.Kind]
+	if !known {
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument, "unknown combat log kind %q", e.Kind)
+	}
+	if !set {
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument, "combat log %s entry has no %s payload", e.Kind, e.Kind)
+	}
+	for kind, other := range payloads {
+		if kind != e.Kind && other {
+			return rpgerr.Newf(rpgerr.CodeInvalidArgument, "combat log %s entry also has a %s payload", e.Kind, kind)
+		}
+	}
+	return nil
+}
+
+// Text renders the entry as one line of human-readable combat log, e.g.
+//
+//	fighter attacks goblin with Longsword: 17 (d20 12, +3 STR, +2 Proficiency) vs AC 15: hit for 9 damage (Longsword 6 slashing, +3 STR)
+//	goblin fails a DEX save (8 vs DC 13)
+//	goblin takes 9 slashing damage from fighter
+//	goblin is frightened
+//	fighter spends 1 Second Wind (0 left)
+//
+// IDs are rendered as given; callers that want display names map them first.
+func (e *CombatLogEvent) Text() string {
+	switch {
+	case e.Kind == CombatLogAttackResolved && e.Attack != nil:
+		return e.attackText()
+	case e.Kind == CombatLogSaveResolved && e.Save != nil:
+		result := "fails"
+		if e.Save.Success {
+			result = "succeeds on"
+		}
+		return fmt.Sprintf("%s %s a %s save (%d vs DC %d)",
+			e.TargetID, result, strings.ToUpper(string(e.Save.Ability)), e.Save.Total, e.Save.DC)
+	case e.Kind == CombatLogDamageApplied && e.Damage != nil:
+		text := fmt.Sprintf("%s takes %s", e.TargetID, DamageLogText(e.Damage.Amount, e.Damage.DamageType, nil))
+		if e.ActorID != "" {
+			text += " from " + e.ActorID
+		}
+		if e.Damage.Critical {
+			text += " (critical)"
+		}
+		return text
+	case e.Kind == CombatLogConditionApplied && e.Condition != nil:
+		return fmt.Sprintf("%s is %s", e.TargetID, strings.ReplaceAll(string(e.Condition.Condition), "_", " "))
+	case e.Kind == CombatLogResourceSpent && e.Resource != nil:
+		return fmt.Sprintf("%s spends %d %s (%d left)",
+			e.ActorID, e.Resource.Amount, humanizeID(string(e.Resource.Resource)), e.Resource.Remaining)
+	default:
+		return fmt.Sprintf("%s: %s -> %s", e.Kind, e.ActorID, e.TargetID)
+	}
+}
+
+// attackText renders an attack_resolved entry. Without an actor or target it
+// renders just the roll and outcome, which is how combat.FormatAttackLog
+// shows a bare attack trace.
+func (e *CombatLogEvent) attackText() string {
+	a := e.Attack
+
+	var b strings.Builder
+	if e.ActorID != "" || e.TargetID != "" {
+		fmt.Fprintf(&b, "%s attacks %s", e.ActorID, e.TargetID)
+		if a.WeaponRef != nil {
+			fmt.Fprintf(&b, " with %s", humanizeID(a.WeaponRef.ID))
+		}
+		b.WriteString(": ")
+	}
+
+	fmt.Fprintf(&b, "%d (%s) vs AC %d", a.Total, strings.Join(a.rollParts(), ", "), a.TargetAC)
+	if parts := sourceParts(a.ACSources); len(parts) > 0 {
+		fmt.Fprintf(&b, " (%s)", strings.Join(parts, ", "))
+	}
+
+	switch {
+	case !a.Hit:
+		b.WriteString(": miss")
+		return b.String()
+	case a.Critical:
+		b.WriteString(": critical hit")
+	default:
+		b.WriteString(": hit")
+	}
+
+	if a.Damage != 0 || len(a.DamageSources) > 0 {
+		fmt.Fprintf(&b, " for %s", DamageLogText(a.Damage, a.DamageType, a.DamageSources))
+	}
+	return b.String()
+}
+
+// rollParts lists the d20, each attack source, and the roll mode when no
+// source explains it.
+func (a *AttackResolvedLog) rollParts() []string {
+	roll := fmt.Sprintf("d20 %d", a.Roll)
+	if len(a.Rolls) > 1 {
+		rolls := make([]string, 0, len(a.Rolls))
+		for _, r := range a.Rolls {
+			rolls = append(rolls, strconv.Itoa(r))
+		}
+		roll = fmt.Sprintf("%s of %s", roll, strings.Join(rolls, "/"))
+	}
+	parts := append([]string{roll}, sourceParts(a.Sources)...)
+
+	explained := slices.ContainsFunc(a.Sources, func(source CombatLogSource) bool {
+		return source.Kind == ChainTraceAdvantage || source.Kind == ChainTraceDisadvantage
+	})
+	switch {
+	case explained:
+	case a.Advantage && !a.Disadvantage:
+		parts = append(parts, "advantage")
+	case a.Disadvantage && !a.Advantage:
+		parts = append(parts, "disadvantage")
+	}
+	return parts
+}
+
+// DamageLogText renders a damage total, with its sources when there are any,
+// e.g. "9 slashing damage" or "12 damage (Greatsword 9 slashing (rerolled 1
+// to 4, Great Weapon Fighting), +3 STR)"
+func DamageLogText(amount int, damageType damage.Type, sources []CombatLogSource) string {
+	if parts := sourceParts(sources); len(parts) > 0 {
+		return fmt.Sprintf("%d damage (%s)", amount, strings.Join(parts, ", "))
+	}
+	if damageType == "" {
+		return fmt.Sprintf("%d damage", amount)
+	}
+	return fmt.Sprintf("%d %s damage", amount, damageType)
+}
+
+// sourceParts renders each source that shows in a log line
+func sourceParts(sources []CombatLogSource) []string {
+	parts := make([]string, 0, len(sources))
+	for _, source := range sources {
+		switch source.Kind {
+		case ChainTraceBase, ChainTraceBonus, ChainTraceCover, ChainTraceReaction:
+			if source.Value != 0 {
+				parts = append(parts, fmt.Sprintf("%+d %s", source.Value, source.Label))
+			}
+		case ChainTraceAdvantage:
+			parts = append(parts, "advantage from "+source.Label)
+		case ChainTraceDisadvantage:
+			parts = append(parts, "disadvantage from "+source.Label)
+		case ChainTraceCancellation:
+			parts = append(parts, "cancelled by "+source.Label)
+		case ChainTraceCriticalThreshold:
+			parts = append(parts, fmt.Sprintf("critical on %d+ from %s", source.Value, source.Label))
+		case ChainTraceMultiplier:
+			parts = append(parts, fmt.Sprintf("%s x%s %s", source.Label,
+				strconv.FormatFloat(source.Multiplier, 'f', -1, 64), source.DamageType))
+		case ChainTraceDamage:
+			part := fmt.Sprintf("%s %d %s", source.Label, source.Value, source.DamageType)
+			if source.Detail != "" {
+				part = fmt.Sprintf("%s (%s)", part, source.Detail)
+			}
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// traceStepLabel names the source of a trace step, preferring the ability,
+// then the explicit reason, then the source ref, then the chain modifier ID.
+func traceStepLabel(step ChainTraceStep) string {
+	switch {
+	case step.Ability != "":
+		return strings.ToUpper(string(step.Ability))
+	case step.Reason != "":
+		return humanizeID(step.Reason)
+	case step.SourceRef != nil:
+		return humanizeID(step.SourceRef.ID)
+	default:
+		return humanizeID(step.ModifierID)
+	}
+}
+
+// componentLabel names the source of a damage component.
+func componentLabel(component *DamageComponent) string {
+	if component.Source == DamageSourceAbility && component.SourceRef != nil {
+		return strings.ToUpper(component.SourceRef.ID)
+	}
+	if component.SourceRef != nil {
+		return humanizeID(component.SourceRef.ID)
+	}
+	return humanizeID(string(component.Source))
+}
+
+// humanizeID turns an identifier like "great_weapon_fighting" into "Great Weapon Fighting".
+// Text that already contains spaces or capitals is returned unchanged.
+func humanizeID(id string) string {
+	if strings.ContainsAny(id, " ABCDEFGHIJKLMNOPQRSTUVWXYZ") {
+		return id
+	}
+	words := strings.FieldsFunc(id, func(r rune) bool { return r == '_' || r == '-' })
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
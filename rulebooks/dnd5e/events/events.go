@@ -7,8 +7,10 @@ package events
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
 	"github.com/KirkDiggler/rpg-toolkit/core/resources"
 	"github.com/KirkDiggler/rpg-toolkit/events"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
@@ -65,9 +67,26 @@ const (
 	ConditionRaging ConditionType = "raging"
 	// ConditionRecklessAttack is a class-specific condition for barbarians using Reckless Attack
 	ConditionRecklessAttack ConditionType = "reckless_attack"
+	// ConditionDivineSmite is a class-specific condition for a paladin's pending Divine Smite
+	ConditionDivineSmite ConditionType = "divine_smite"
+	// ConditionWildShaped is a class-specific condition for a druid transformed by Wild Shape
+	ConditionWildShaped ConditionType = "wild_shaped"
+	// ConditionBardicInspiration is a class-specific condition for an ally holding a bard's inspiration die
+	ConditionBardicInspiration ConditionType = "bardic_inspiration"
+	// ConditionManeuver is a class-specific condition for a Battle Master's pending maneuver
+	ConditionManeuver ConditionType = "maneuver"
 
 	// ConditionFightingStyle represents an active fighting style
 	ConditionFightingStyle ConditionType = "fighting_style"
+
+	// ConditionInvocation represents a warlock's passive Eldritch Invocation
+	ConditionInvocation ConditionType = "invocation"
+
+	// ConditionRacialTrait represents a passive racial trait (e.g., Dwarven Resilience)
+	ConditionRacialTrait ConditionType = "racial_trait"
+
+	// ConditionSpiritualWeapon represents a caster's floating Spiritual Weapon
+	ConditionSpiritualWeapon ConditionType = "spiritual_weapon"
 )
 
 // ConditionSource identifies where a condition originated
@@ -78,6 +97,12 @@ const (
 	ConditionSourceClass ConditionSource = "class"
 	// ConditionSourceFeature indicates condition from feature activation (e.g., rage)
 	ConditionSourceFeature ConditionSource = "feature"
+	// ConditionSourceRace indicates condition from a racial trait (e.g., fey ancestry)
+	ConditionSourceRace ConditionSource = "race"
+	// ConditionSourceSpell indicates condition from a spell (e.g., hold person)
+	ConditionSourceSpell ConditionSource = "spell"
+	// ConditionSourceAction indicates condition from a combat action (e.g., grapple, shove)
+	ConditionSourceAction ConditionSource = "action"
 )
 
 // ConditionBehavior represents the behavior of an active condition.
@@ -154,6 +179,20 @@ type DamageComponent struct {
 	// When non-zero, this component represents a multiplier to apply to other
 	// components of the same damage type, not additional damage itself.
 	Multiplier float64
+	// Annotations are secondary properties of the damage, copied from the
+	// weapon (magical, silvered). Qualified resistances such as "from
+	// nonmagical attacks" check them.
+	Annotations []damage.Annotation
+}
+
+// HasAnnotation returns true if the component carries the annotation
+func (dc *DamageComponent) HasAnnotation(annotation damage.Annotation) bool {
+	for _, a := range dc.Annotations {
+		if a == annotation {
+			return true
+		}
+	}
+	return false
 }
 
 // Total returns the total damage for this component
@@ -219,6 +258,7 @@ type AttackChainEvent struct {
 	// Advantage/Disadvantage (inputs to the roll)
 	AdvantageSources    []AttackModifierSource // Sources granting advantage
 	DisadvantageSources []AttackModifierSource // Sources imposing disadvantage
+	RerollOneSources    []AttackModifierSource // Sources letting a natural 1 be rerolled (Halfling Lucky)
 
 	// Cancellation (attack can be cancelled by conditions like Disengaging)
 	CancellationSources []AttackModifierSource // Sources that cancelled this attack
@@ -230,6 +270,9 @@ type AttackChainEvent struct {
 
 	// Side effects (processed after chain execution)
 	ReactionsConsumed []ReactionConsumption // Reactions used during this attack
+
+	// Trace records each change chain modifiers made, in execution order
+	Trace []ChainTraceStep
 }
 
 // IsCancelled returns true if this attack has been cancelled.
@@ -252,6 +295,52 @@ type DamageChainEvent struct {
 	WeaponRef       *core.Ref         // Reference to the weapon used (for off-hand detection, etc.)
 	IsOffHandAttack bool              // True for bonus action off-hand attacks (two-weapon fighting)
 	AbilityModifier int               // The ability modifier (STR/DEX) for this attack
+	Trace           []ChainTraceStep  // Each change chain modifiers made, in execution order
+}
+
+// ChainTraceKind identifies what a chain modifier changed
+type ChainTraceKind string
+
+const (
+	// ChainTraceBase is a base value applied before the chain (ability modifier, proficiency)
+	ChainTraceBase ChainTraceKind = "base"
+	// ChainTraceBonus is a flat bonus or penalty to a roll or damage component
+	ChainTraceBonus ChainTraceKind = "bonus"
+	// ChainTraceAdvantage is a source granting advantage
+	ChainTraceAdvantage ChainTraceKind = "advantage"
+	// ChainTraceDisadvantage is a source imposing disadvantage
+	ChainTraceDisadvantage ChainTraceKind = "disadvantage"
+	// ChainTraceCancellation is a source cancelling the attack
+	ChainTraceCancellation ChainTraceKind = "cancellation"
+	// ChainTraceCriticalThreshold is a change to the critical hit threshold
+	ChainTraceCriticalThreshold ChainTraceKind = "critical_threshold"
+	// ChainTraceDamage is a damage component added by a modifier
+	ChainTraceDamage ChainTraceKind = "damage"
+	// ChainTraceMultiplier is a resistance, vulnerability or immunity multiplier
+	ChainTraceMultiplier ChainTraceKind = "multiplier"
+	// ChainTraceReroll is a damage die rerolled by a modifier
+	ChainTraceReroll ChainTraceKind = "reroll"
+	// ChainTraceAbility is a change to the ability used for the attack
+	ChainTraceAbility ChainTraceKind = "ability"
+	// ChainTraceCover is the AC bonus the target gets from cover
+	ChainTraceCover ChainTraceKind = "cover"
+	// ChainTraceReaction is an AC bonus from a reaction taken between attack phases
+	ChainTraceReaction ChainTraceKind = "reaction"
+)
+
+// ChainTraceStep records one change made to a roll, so clients can display
+// how a result was reached without re-deriving it.
+type ChainTraceStep struct {
+	Stage      chain.Stage       // Chain stage the change ran in (empty outside the chain)
+	ModifierID string            // ID the modifier registered with the chain
+	Kind       ChainTraceKind    // What changed
+	SourceRef  *core.Ref         // Feature, condition or item responsible (nil if unknown)
+	SourceID   string            // Entity that provided the change
+	Reason     string            // Human-readable explanation
+	Value      int               // Bonus, reroll delta, new threshold or damage total
+	Multiplier float64           // Damage multiplier for ChainTraceMultiplier
+	DamageType damage.Type       // Damage type for damage, reroll and multiplier steps
+	Ability    abilities.Ability // New ability for ChainTraceAbility
 }
 
 // =============================================================================
@@ -272,6 +361,8 @@ const (
 	SaveTriggerFeature SaveTrigger = "feature"
 	// SaveTriggerEnvironment indicates the saving throw was caused by environmental effects
 	SaveTriggerEnvironment SaveTrigger = "environment"
+	// SaveTriggerCondition indicates the saving throw was made to end a condition (e.g. escaping a grab)
+	SaveTriggerCondition SaveTrigger = "condition"
 )
 
 // SaveCause provides context about what caused the saving throw
@@ -280,6 +371,13 @@ type SaveCause struct {
 	EffectRef      *core.Ref   // Reference to the spell/trap/feature causing the save
 	InstigatorID   string      // ID of entity that caused the save (caster, trap placer, etc)
 	InstigatorType string      // Type of instigator ("character", "monster", "trap", etc)
+
+	// DamageType is the damage a failed save deals, if any (e.g., poison for a
+	// poison spray). Lets traits like Dwarven Resilience recognize the effect.
+	DamageType damage.Type
+
+	// Conditions lists the conditions a failed save imposes (e.g., charmed).
+	Conditions []*core.Ref
 }
 
 // SaveModifierSource tracks the source of a saving throw modifier
@@ -307,6 +405,8 @@ type SavingThrowChainEvent struct {
 	AdvantageSources    []SaveModifierSource // Sources granting advantage
 	DisadvantageSources []SaveModifierSource // Sources imposing disadvantage
 	BonusSources        []SaveBonusSource    // Sources adding bonuses to the roll
+	RerollOneSources    []SaveModifierSource // Sources letting a natural 1 be rerolled (Halfling Lucky)
+	AutoFailSources     []SaveModifierSource // Sources making the save fail automatically (Hold Person)
 }
 
 // HasAdvantage returns true if any advantage sources have been added to this event
@@ -319,6 +419,11 @@ func (e *SavingThrowChainEvent) HasDisadvantage() bool {
 	return len(e.DisadvantageSources) > 0
 }
 
+// AutoFails returns true if any source makes this save fail automatically
+func (e *SavingThrowChainEvent) AutoFails() bool {
+	return len(e.AutoFailSources) > 0
+}
+
 // TotalBonus returns the sum of all bonus sources
 func (e *SavingThrowChainEvent) TotalBonus() int {
 	total := 0
@@ -328,6 +433,118 @@ func (e *SavingThrowChainEvent) TotalBonus() int {
 	return total
 }
 
+// =============================================================================
+// Spellcasting Chain Types
+// =============================================================================
+
+// SpellcastingBonusSource is a bonus to a caster's spell attack rolls and
+// spell save DC, such as a Rod of the Pact Keeper's +1 to both.
+type SpellcastingBonusSource struct {
+	SourceRef   *core.Ref // Reference to the item or feature granting the bonus
+	AttackBonus int       // Added to the spell attack bonus
+	SaveDCBonus int       // Added to the spell save DC
+}
+
+// SpellcastingChainEvent represents a caster's spell attack bonus and spell
+// save DC flowing through the modifier chain. The base values come from the
+// spellcasting ability and proficiency; items and features add BonusSources.
+type SpellcastingChainEvent struct {
+	CasterID        string            // ID of the caster
+	Ability         abilities.Ability // Spellcasting ability
+	BaseAttackBonus int               // Ability modifier + proficiency bonus
+	BaseSaveDC      int               // 8 + ability modifier + proficiency bonus
+	BonusSources    []SpellcastingBonusSource
+}
+
+// AttackBonus returns the spell attack bonus including every bonus source
+func (e *SpellcastingChainEvent) AttackBonus() int {
+	total := e.BaseAttackBonus
+	for _, source := range e.BonusSources {
+		total += source.AttackBonus
+	}
+	return total
+}
+
+// SaveDC returns the spell save DC including every bonus source
+func (e *SpellcastingChainEvent) SaveDC() int {
+	total := e.BaseSaveDC
+	for _, source := range e.BonusSources {
+		total += source.SaveDCBonus
+	}
+	return total
+}
+
+// =============================================================================
+// Ability Check Chain Types
+// =============================================================================
+
+// CheckCause provides context about what caused the ability check
+type CheckCause struct {
+	EffectRef    *core.Ref // Reference to the condition/spell/feature prompting the check
+	InstigatorID string    // ID of entity that caused the check (grappler, caster, etc)
+}
+
+// CheckModifierSource tracks the source of an ability check modifier
+type CheckModifierSource struct {
+	Name       string    // Display name (e.g., "Guidance", "Restrained")
+	SourceType string    // Type of source ("condition", "feature", "spell", etc)
+	SourceRef  *core.Ref // Reference to the source
+	EntityID   string    // ID of entity providing the modifier
+}
+
+// CheckBonusSource tracks a bonus to the ability check
+type CheckBonusSource struct {
+	CheckModifierSource     // Embedded modifier source
+	Bonus               int // The bonus amount
+}
+
+// AbilityCheckChainEvent represents an ability check flowing through the modifier chain.
+// This event fires BEFORE the d20 roll to allow advantage/disadvantage/bonuses to be collected.
+type AbilityCheckChainEvent struct {
+	CheckerID string            // ID of the entity making the check
+	Ability   abilities.Ability // The ability being used (STR, DEX, etc)
+	Skill     string            // Skill applied to the check, if any (e.g., "athletics")
+	DC        int               // Difficulty class (0 for a contest)
+	Cause     CheckCause        // What caused this ability check
+
+	// ProficiencyBonus is the proficiency bonus already included in the check's
+	// modifier (0 if not proficient, doubled when the skill has expertise).
+	ProficiencyBonus int
+
+	RequiresSight   bool // The check relies on sight (a blinded creature fails it)
+	RequiresHearing bool // The check relies on hearing (a deafened creature fails it)
+
+	AdvantageSources    []CheckModifierSource // Sources granting advantage
+	DisadvantageSources []CheckModifierSource // Sources imposing disadvantage
+	BonusSources        []CheckBonusSource    // Sources adding bonuses to the roll
+	RerollOneSources    []CheckModifierSource // Sources letting a natural 1 be rerolled (Halfling Lucky)
+	AutoFailSources     []CheckModifierSource // Sources making the check fail automatically (Blinded)
+}
+
+// HasAdvantage returns true if any advantage sources have been added to this event
+func (e *AbilityCheckChainEvent) HasAdvantage() bool {
+	return len(e.AdvantageSources) > 0
+}
+
+// HasDisadvantage returns true if any disadvantage sources have been added to this event
+func (e *AbilityCheckChainEvent) HasDisadvantage() bool {
+	return len(e.DisadvantageSources) > 0
+}
+
+// AutoFails returns true if any source makes this check fail automatically
+func (e *AbilityCheckChainEvent) AutoFails() bool {
+	return len(e.AutoFailSources) > 0
+}
+
+// TotalBonus returns the sum of all bonus sources
+func (e *AbilityCheckChainEvent) TotalBonus() int {
+	total := 0
+	for _, source := range e.BonusSources {
+		total += source.Bonus
+	}
+	return total
+}
+
 // =============================================================================
 // Movement Chain Types
 // =============================================================================
@@ -342,6 +559,14 @@ type MovementModifierSource struct {
 	EntityID   string    // ID of entity providing the modifier
 }
 
+// MovementCostSource adds extra movement cost to a step.
+// Per D&D 5e, crawling and squeezing each cost 1 extra foot for every foot moved,
+// and extra costs stack (crawling while squeezing costs 3 feet per foot).
+type MovementCostSource struct {
+	MovementModifierSource     // Embedded modifier source
+	ExtraFeetPerFoot       int // Extra feet spent per foot moved
+}
+
 // MovementChainEvent represents movement flowing through the modifier chain.
 // This event fires BEFORE movement completes to allow OA prevention and other
 // movement-related effects to be processed.
@@ -360,11 +585,27 @@ type MovementChainEvent struct {
 	// OA Prevention - conditions can add sources here to prevent OA
 	OAPreventionSources []MovementModifierSource
 
+	// OA exemptions - prevent OAs from specific threateners only (Mobile)
+	OAExemptions []OAExemption
+
+	// Movement cost - stances and terrain can add extra cost per foot moved
+	ExtraCostSources []MovementCostSource
+
 	// Movement control - can stop movement entirely
 	MovementPrevented bool   // If true, movement is blocked
 	PreventionReason  string // Why movement was prevented
 }
 
+// CostMultiplier returns how many feet of movement each foot of this step costs.
+// Normal movement costs 1; each extra cost source adds its ExtraFeetPerFoot.
+func (e *MovementChainEvent) CostMultiplier() int {
+	multiplier := 1
+	for _, source := range e.ExtraCostSources {
+		multiplier += source.ExtraFeetPerFoot
+	}
+	return multiplier
+}
+
 // IsOAPrevented returns true if opportunity attacks are prevented for this movement.
 // A condition like Disengaging adds itself to OAPreventionSources to indicate
 // that the moving entity should not provoke opportunity attacks.
@@ -372,6 +613,47 @@ func (e *MovementChainEvent) IsOAPrevented() bool {
 	return len(e.OAPreventionSources) > 0
 }
 
+// IsOAPreventedFrom returns true if the threatener can't make an opportunity
+// attack against this movement, because all OAs are prevented or because an
+// exemption names it.
+func (e *MovementChainEvent) IsOAPreventedFrom(threatenerID string) bool {
+	if e.IsOAPrevented() {
+		return true
+	}
+	for _, exemption := range e.OAExemptions {
+		if exemption.ThreatenerID == threatenerID {
+			return true
+		}
+	}
+	return false
+}
+
+// OAExemption stops one threatener's opportunity attacks against a movement.
+// The Mobile feat adds one for each creature its holder attacked in melee this turn.
+type OAExemption struct {
+	MovementModifierSource        // What grants the exemption
+	ThreatenerID           string // The creature that can't make the opportunity attack
+}
+
+// OpportunityAttackChainEvent flows through the chain after an opportunity
+// attack made during movement resolves. Features that react to the outcome add
+// themselves here; Sentinel adds a SpeedZeroSource on a hit to stop the mover.
+type OpportunityAttackChainEvent struct {
+	AttackerID string // The creature that made the opportunity attack
+	TargetID   string // The moving creature
+	Hit        bool   // Whether the attack hit
+	Damage     int    // Damage dealt
+	Critical   bool   // Whether the attack was a critical hit
+
+	// SpeedZeroSources reduce the target's speed to 0 for the rest of the turn
+	SpeedZeroSources []MovementModifierSource
+}
+
+// StopsMovement returns true if a source reduced the target's speed to 0
+func (e *OpportunityAttackChainEvent) StopsMovement() bool {
+	return len(e.SpeedZeroSources) > 0
+}
+
 // Position represents a 2D grid position for movement tracking.
 // This mirrors spatial.Position but avoids import cycles.
 // Note: This uses float64 for compatibility with spatial.Position, but grid-based
@@ -439,6 +721,15 @@ type ConditionRemovedEvent struct {
 	Reason       string
 }
 
+// ExhaustionChangedEvent is published when a creature's exhaustion level changes.
+// Level 0 means the exhaustion has ended.
+type ExhaustionChangedEvent struct {
+	CharacterID   string // ID of the exhausted creature
+	PreviousLevel int    // Exhaustion level before the change
+	Level         int    // Exhaustion level after the change
+	Reason        string // What changed the level (e.g., "long_rest")
+}
+
 // AttackEvent is published when a character makes an attack (before rolls)
 type AttackEvent struct {
 	AttackerID string // ID of the attacking character
@@ -519,6 +810,14 @@ const (
 	// TriggerKindPostDamage is published after damage has been applied —
 	// the Hellish Rebuke window.
 	TriggerKindPostDamage TriggerKind = "post_damage"
+
+	// TriggerKindReadiedAction is published when the trigger declared with
+	// the Ready action occurs — the holder may now release the held action.
+	TriggerKindReadiedAction TriggerKind = "readied_action"
+
+	// TriggerKindSpellCast is published when a creature casts a spell within
+	// sight of the reactor — the Counterspell window.
+	TriggerKindSpellCast TriggerKind = "spell_cast"
 )
 
 // ReactionTriggerEvent is published by condition handlers when their predicate
@@ -555,7 +854,32 @@ type ReactionTriggerEvent struct {
 	//     attacker+target)
 	//   - TriggerKindMovementOA: MovementChainEvent (read-only copy of the
 	//     move event so the orchestrator knows mover, from/to positions)
+	//   - TriggerKindReadiedAction: ReadiedActionTriggeredEvent (the held
+	//     action, its declared trigger, and the creature that tripped it)
 	Payload any
+
+	// ACBonus is the AC increase taking the reaction grants the reactor
+	// (Shield: +5). Zero for reactions that don't change AC.
+	ACBonus int
+}
+
+// ReactionPrompt asks a reactor whether to take the reaction a trigger
+// offers. combat.ReactionPrompter hands it to the reactor's ReactionDecider
+// (a player client or an AI) and waits for the ReactionResponse before the
+// flow that raised the trigger resolves.
+type ReactionPrompt struct {
+	// Trigger is the reaction opportunity being offered.
+	Trigger ReactionTriggerEvent
+
+	// Deadline is when an unanswered prompt is declined. Zero means the
+	// prompt waits as long as the context allows.
+	Deadline time.Time
+}
+
+// ReactionResponse answers a ReactionPrompt.
+type ReactionResponse struct {
+	// Take is true when the reactor spends their reaction on the trigger.
+	Take bool
 }
 
 // PostAttackRollEvent is published by ResolveAttackHit AFTER the d20 has been
@@ -648,7 +972,8 @@ type FlurryStrikeActivatedEvent struct {
 }
 
 // OffHandStrikeRequestedEvent is published when an OffHandStrike action is activated.
-// The game server should resolve an off-hand weapon attack from attacker to target.
+// combat.ResolveOffHandStrike turns it into an off-hand weapon attack from attacker
+// to target; a TurnManager does so automatically during its character's turn.
 // Note: Off-hand attacks don't add ability modifier to damage unless the character
 // has the Two-Weapon Fighting fighting style.
 type OffHandStrikeRequestedEvent struct {
@@ -658,6 +983,19 @@ type OffHandStrikeRequestedEvent struct {
 	ActionID   string // ID of the OffHandStrike action (for tracking)
 }
 
+// OffHandStrikeResolvedEvent is published after a requested off-hand strike has
+// been resolved into an attack.
+type OffHandStrikeResolvedEvent struct {
+	AttackerID  string      // ID of the character who made the off-hand attack
+	TargetID    string      // ID of the target
+	WeaponID    string      // ID of the off-hand weapon used
+	ActionID    string      // ID of the OffHandStrike action that requested the attack
+	Hit         bool        // Whether the attack hit
+	Critical    bool        // Whether the attack was a critical hit
+	TotalDamage int         // Damage dealt (0 on a miss)
+	DamageType  damage.Type // Type of damage dealt
+}
+
 // OffHandStrikeActivatedEvent is published after an OffHandStrike action is successfully used.
 // This is a notification event for UI/logging.
 type OffHandStrikeActivatedEvent struct {
@@ -681,6 +1019,13 @@ type StepOfTheWindActivatedEvent struct {
 	Source      string // Feature that triggered this (refs.Features.StepOfTheWind().ID)
 }
 
+// CunningActionActivatedEvent is published when a rogue uses Cunning Action
+type CunningActionActivatedEvent struct {
+	CharacterID string // ID of the rogue activating the feature
+	Action      string // Action taken: "dash", "disengage", or "hide"
+	Source      string // Feature that triggered this (refs.Features.CunningAction().ID)
+}
+
 // DeflectMissilesTriggerEvent is published when a monk deflects a ranged weapon attack
 type DeflectMissilesTriggerEvent struct {
 	CharacterID      string // ID of the monk deflecting
@@ -711,6 +1056,41 @@ type StrikeExecutedEvent struct {
 	ActionID   string // ID of the Strike action (for tracking)
 }
 
+// GrappleResolvedEvent is published when a Grapple action is resolved.
+// On success the target has the Grappled condition, held by the attacker.
+type GrappleResolvedEvent struct {
+	AttackerID    string // ID of the creature attempting the grapple
+	TargetID      string // ID of the creature being grappled
+	AttackerTotal int    // Total of the attacker's Strength (Athletics) check
+	TargetTotal   int    // Total of the target's Athletics or Acrobatics check
+	Success       bool   // True if the target is now grappled
+	ActionID      string // ID of the Grapple action (for tracking)
+}
+
+// ShoveEffect is what a successful shove does to its target.
+type ShoveEffect string
+
+const (
+	// ShoveProne knocks the target prone
+	ShoveProne ShoveEffect = "prone"
+	// ShovePush pushes the target 5 feet away from the attacker
+	ShovePush ShoveEffect = "push"
+)
+
+// ShoveResolvedEvent is published when a Shove action is resolved.
+// On a successful ShoveProne the target is already prone; on a successful
+// ShovePush the game moves the target PushFeet away from the attacker.
+type ShoveResolvedEvent struct {
+	AttackerID    string      // ID of the creature shoving
+	TargetID      string      // ID of the creature being shoved
+	Effect        ShoveEffect // Whether the shove knocks prone or pushes
+	AttackerTotal int         // Total of the attacker's Strength (Athletics) check
+	TargetTotal   int         // Total of the target's Athletics or Acrobatics check
+	Success       bool        // True if the shove took effect
+	PushFeet      int         // Distance to push the target (successful pushes only)
+	ActionID      string      // ID of the Shove action (for tracking)
+}
+
 // MoveExecutedEvent is published when a Move action is activated.
 // The game server should update the entity's position and handle any
 // opportunity attacks or other movement-triggered effects.
@@ -744,20 +1124,255 @@ type DisengageActivatedEvent struct {
 
 // HelpActivatedEvent is published when a character uses the Help action.
 // The helper aids an ally: the next ability check or attack roll the ally makes
-// (against the helped target, for attacks) gains advantage. The mechanical
-// effect (granting advantage) is applied by a subscriber in a later beat; this
-// event is the activation signal.
+// (against the helped target, for attacks) gains advantage. When the ally is
+// known, the Helped condition has already been applied to them.
 type HelpActivatedEvent struct {
 	CharacterID string // ID of the character taking the Help action
 	AllyID      string // ID of the ally being helped (the action's target)
+	TargetID    string // ID of the creature the ally's attack is helped against, if any
 }
 
 // HideActivatedEvent is published when a character uses the Hide action.
-// The character attempts to become hidden (a Stealth check vs observers' passive
-// Perception). The check + the resulting Hidden condition are resolved by a
-// subscriber in a later beat; this event is the activation signal.
+// The character has made its Dexterity (Stealth) check: the game compares
+// StealthTotal with each observer's passive Perception and tracks who is
+// hidden from whom (see combat.VisionModifiersConfig.IsHidden).
 type HideActivatedEvent struct {
-	CharacterID string // ID of the character taking the Hide action
+	CharacterID  string // ID of the character taking the Hide action
+	StealthTotal int    // Total of the Dexterity (Stealth) check
+}
+
+// SearchActivatedEvent is published when a character uses the Search action.
+// The game compares PerceptionTotal with hidden creatures' Stealth totals, or
+// with the DC of whatever is being looked for.
+type SearchActivatedEvent struct {
+	CharacterID     string // ID of the character taking the Search action
+	PerceptionTotal int    // Total of the Wisdom (Perception) check
+}
+
+// UseObjectActivatedEvent is published when a character uses the Use Object
+// action on an object that needs an action (a potion, a lever, a door stuck shut).
+// The game resolves what the object does.
+type UseObjectActivatedEvent struct {
+	CharacterID string // ID of the character taking the Use Object action
+	ObjectID    string // ID of the object being used
+}
+
+// ReadyTriggerKind identifies which bus event a readied action watches for.
+type ReadyTriggerKind string
+
+const (
+	// ReadyTriggerCreatureMoves fires when the watched creature moves.
+	ReadyTriggerCreatureMoves ReadyTriggerKind = "creature_moves"
+	// ReadyTriggerCreatureAttacks fires when the watched creature makes an attack.
+	ReadyTriggerCreatureAttacks ReadyTriggerKind = "creature_attacks"
+	// ReadyTriggerCreatureDamaged fires when the watched creature takes damage.
+	ReadyTriggerCreatureDamaged ReadyTriggerKind = "creature_damaged"
+	// ReadyTriggerTurnStart fires when the watched creature starts its turn.
+	ReadyTriggerTurnStart ReadyTriggerKind = "turn_start"
+)
+
+// ReadyTrigger is the perceivable circumstance declared when readying an action
+// ("when the goblin steps through the door, I attack it").
+type ReadyTrigger struct {
+	// Kind selects the bus event that can release the action.
+	Kind ReadyTriggerKind `json:"kind"`
+
+	// CreatureID is the creature being watched. Empty matches any creature
+	// other than the holder.
+	CreatureID string `json:"creature_id,omitempty"`
+
+	// Description is the player's own wording, kept for display and logs.
+	Description string `json:"description,omitempty"`
+}
+
+// ReadyActivatedEvent is published when a character uses the Ready action.
+// The held action is released with the character's reaction when the trigger
+// occurs before the start of their next turn.
+type ReadyActivatedEvent struct {
+	CharacterID string       // ID of the character readying an action
+	ActionRef   *core.Ref    // The action being held (e.g. Strike)
+	TargetID    string       // Intended target of the held action, if any
+	Trigger     ReadyTrigger // The circumstance that releases the action
+}
+
+// ReadiedActionTriggeredEvent describes a readied action whose trigger has
+// occurred. It is the Payload of the ReactionTriggerEvent the readied action
+// publishes; the orchestrator resolves the held action against it.
+type ReadiedActionTriggeredEvent struct {
+	CharacterID  string       // ID of the character holding the action
+	ActionRef    *core.Ref    // The held action to resolve now
+	TargetID     string       // Intended target of the held action, if any
+	Trigger      ReadyTrigger // The trigger that was declared
+	TriggeringID string       // The creature whose activity matched the trigger
+}
+
+// =============================================================================
+// Legendary and Lair Action Events
+// =============================================================================
+
+// LegendaryActionTakenEvent is published when a legendary creature takes a legendary action
+type LegendaryActionTakenEvent struct {
+	OwnerID     string // ID of the legendary creature
+	ActionID    string // Legendary action taken (e.g. "tail_attack")
+	Cost        int    // Legendary actions spent
+	Remaining   int    // Legendary actions left this round
+	TurnEndedID string // ID of the creature whose turn just ended
+	Round       int    // Current round number
+}
+
+// LairActionTakenEvent is published when a creature takes a lair action on initiative count 20
+type LairActionTakenEvent struct {
+	OwnerID  string // ID of the creature whose lair it is
+	ActionID string // Lair action taken
+	Round    int    // Current round number
+}
+
+// RechargeRolledEvent is published when a monster rolls to recharge a spent
+// "Recharge X–6" action at the start of its turn
+type RechargeRolledEvent struct {
+	OwnerID    string // ID of the monster
+	ActionID   string // Action being recharged (e.g. "fire-breath")
+	Roll       int    // The d6 result
+	RechargeOn int    // Lowest roll that recharges the action
+	Recharged  bool   // True if the action can be used again
+}
+
+// =============================================================================
+// Spellcasting Events
+// =============================================================================
+
+// SpellCastEvent is published when a creature casts a spell through spells.Cast.
+// The spell's effect is resolved by the caller.
+type SpellCastEvent struct {
+	CasterID   string   // ID of the caster
+	SpellID    string   // Spell cast (e.g. "fireball")
+	SpellLevel int      // Spell's base level (0 for cantrips)
+	SlotLevel  int      // Slot level spent (0 for cantrips and innate casting)
+	Innate     bool     // True if cast with an innate use instead of a slot
+	Metamagic  string   // Sorcerer Metamagic option used, if any (e.g. "subtle")
+	TargetIDs  []string // Targets chosen by the caster
+}
+
+// SpellCopiedEvent is published when a wizard copies a spell from a scroll or
+// another spellbook into their spellbook through spells.CopySpell. The game
+// deducts the gold and time; the spell is already in the spellbook.
+type SpellCopiedEvent struct {
+	CasterID string // ID of the wizard
+	SpellID  string // Spell copied (e.g. "sleep")
+	Source   string // Where it was copied from (e.g. "scroll")
+	GoldCost int    // Gold pieces spent on inks (50 gp per spell level)
+	Hours    int    // Hours spent copying (2 per spell level)
+}
+
+// SlotsRecoveredEvent is published when expended spell slots are recovered
+// outside a long rest (e.g. Arcane Recovery).
+type SlotsRecoveredEvent struct {
+	CasterID   string // ID of the caster
+	SlotLevels []int  // Level of each recovered slot
+	Source     string // Ref string of what recovered the slots
+}
+
+// ConcentrationEndedEvent is published when a caster stops concentrating on a
+// spell, ending the spell and anything tied to it (such as summoned creatures).
+type ConcentrationEndedEvent struct {
+	CasterID string // ID of the caster that was concentrating
+	SpellID  string // Spell that ended (e.g. "conjure-animals")
+	Reason   string // Why it ended ("failed_save", "unconscious", "died", "ended")
+}
+
+// SummonDismissedEvent is published when a summoned creature leaves combat
+// because its summoning ended or it was dismissed.
+type SummonDismissedEvent struct {
+	SummonID   string // ID of the summoned creature
+	SummonerID string // ID of the creature that summoned it
+	Reason     string // Why it was dismissed (the concentration end reason, or "dismissed")
+}
+
+// =============================================================================
+// Group Damage Events
+// =============================================================================
+
+// GroupDamageTargetOutcome summarizes the damage one creature took from group damage
+type GroupDamageTargetOutcome struct {
+	TargetID      string // ID of the creature that took damage
+	Damage        int    // Damage applied after halving, modifiers, and resistance
+	Halved        bool   // True if the damage was halved before resistance (e.g. successful save)
+	CurrentHP     int    // The creature's HP after damage
+	DroppedToZero bool   // True if this damage reduced the creature to 0 HP
+}
+
+// GroupDamageAppliedEvent is published once after one set of damage has been applied to several creatures
+type GroupDamageAppliedEvent struct {
+	SourceID    string                     // ID of the entity dealing damage, if any
+	SourceRef   *core.Ref                  // What caused the damage (spell, feature, hazard)
+	DamageType  damage.Type                // Primary damage type
+	Targets     []GroupDamageTargetOutcome // Per-creature outcomes in application order
+	TotalDamage int                        // Sum of damage applied to all creatures
+}
+
+// =============================================================================
+// Area Effect Events
+// =============================================================================
+
+// AreaEffectTargetOutcome summarizes how one creature fared against an area effect
+type AreaEffectTargetOutcome struct {
+	TargetID string // ID of the affected creature
+	Cover    string // Cover the creature had from the effect's origin ("none", "half", ...)
+	Saved    bool   // True if the creature succeeded on its saving throw
+	Hit      bool   // True if the creature was hit by the effect's attack roll
+	Critical bool   // True if the attack roll was a critical hit
+	Damage   int    // Damage dealt to the creature after modifiers
+}
+
+// AreaEffectResolvedEvent is published once after every creature in an area effect has been resolved
+type AreaEffectResolvedEvent struct {
+	SourceID    string                    // ID of the caster or attacker, if any
+	EffectRef   *core.Ref                 // Reference to the spell/feature/trap creating the area
+	Shape       string                    // Template shape ("sphere", "cube", "cone", "line")
+	Targets     []AreaEffectTargetOutcome // Per-creature outcomes in resolution order
+	TotalDamage int                       // Sum of damage dealt to all creatures
+}
+
+// =============================================================================
+// Ongoing Damage Events
+// =============================================================================
+
+// OngoingDamageEndedEvent is published when a creature stops taking ongoing damage
+type OngoingDamageEndedEvent struct {
+	EffectID  string    // ID of the ongoing damage effect
+	TargetID  string    // ID of the creature that was taking the damage
+	SourceID  string    // ID of the creature that caused it, if any
+	EffectRef *core.Ref // Reference to the spell/condition causing the damage
+	Reason    string    // Why it ended ("saved", "condition_removed", "concentration_ended", "duration_expired", "removed")
+}
+
+// =============================================================================
+// Escape Events
+// =============================================================================
+
+// EscapeMethod identifies how a creature tries to escape a condition
+type EscapeMethod string
+
+const (
+	// EscapeMethodContest is a contested check against the source's check (e.g. a grapple)
+	EscapeMethodContest EscapeMethod = "contest"
+	// EscapeMethodCheck is an ability check against a fixed DC (e.g. a net)
+	EscapeMethodCheck EscapeMethod = "check"
+	// EscapeMethodSave is a saving throw against a fixed DC (e.g. a monster's grab)
+	EscapeMethodSave EscapeMethod = "save"
+)
+
+// EscapeAttemptedEvent is published when a creature tries to escape a condition
+type EscapeAttemptedEvent struct {
+	CharacterID  string       // ID of the creature trying to escape
+	SourceID     string       // ID of the creature or effect holding it (grappler, caster, etc)
+	ConditionRef string       // Ref string of the condition being escaped
+	Method       EscapeMethod // Contest, check, or save
+	Total        int          // The escaping creature's check or save total
+	OpposedTotal int          // The source's check total (contest only)
+	DC           int          // DC to beat (check and save only)
+	Success      bool         // True if the creature escaped
+	Round        int          // Round of the attempt
 }
 
 // =============================================================================
@@ -775,6 +1390,9 @@ var (
 	// DamageReceivedTopic provides typed pub/sub for damage received events
 	DamageReceivedTopic = events.DefineTypedTopic[DamageReceivedEvent]("dnd5e.combat.damage.received")
 
+	// GroupDamageAppliedTopic provides typed pub/sub for group damage applied events
+	GroupDamageAppliedTopic = events.DefineTypedTopic[GroupDamageAppliedEvent]("dnd5e.combat.damage.group_applied")
+
 	// HealingReceivedTopic provides typed pub/sub for healing received events
 	HealingReceivedTopic = events.DefineTypedTopic[HealingReceivedEvent]("dnd5e.combat.healing.received")
 
@@ -784,6 +1402,12 @@ var (
 	// ConditionRemovedTopic provides typed pub/sub for condition removed events
 	ConditionRemovedTopic = events.DefineTypedTopic[ConditionRemovedEvent]("dnd5e.condition.removed")
 
+	// ExhaustionChangedTopic provides typed pub/sub for exhaustion level changes
+	ExhaustionChangedTopic = events.DefineTypedTopic[ExhaustionChangedEvent]("dnd5e.condition.exhaustion.changed")
+
+	// EscapeAttemptedTopic provides typed pub/sub for escape attempt events
+	EscapeAttemptedTopic = events.DefineTypedTopic[EscapeAttemptedEvent]("dnd5e.condition.escape_attempted")
+
 	// AttackTopic provides typed pub/sub for attack events
 	AttackTopic = events.DefineTypedTopic[AttackEvent]("dnd5e.combat.attack")
 
@@ -813,6 +1437,10 @@ var (
 	OffHandStrikeRequestedTopic = events.DefineTypedTopic[OffHandStrikeRequestedEvent](
 		"dnd5e.action.off_hand_strike.requested")
 
+	// OffHandStrikeResolvedTopic provides typed pub/sub for resolved off-hand strikes
+	OffHandStrikeResolvedTopic = events.DefineTypedTopic[OffHandStrikeResolvedEvent](
+		"dnd5e.action.off_hand_strike.resolved")
+
 	// OffHandStrikeActivatedTopic provides typed pub/sub for off-hand strike completion notifications
 	OffHandStrikeActivatedTopic = events.DefineTypedTopic[OffHandStrikeActivatedEvent](
 		"dnd5e.action.off_hand_strike.activated")
@@ -833,6 +1461,10 @@ var (
 	StepOfTheWindActivatedTopic = events.DefineTypedTopic[StepOfTheWindActivatedEvent](
 		"dnd5e.feature.step_of_the_wind.activated")
 
+	// CunningActionActivatedTopic provides typed pub/sub for cunning action activation events
+	CunningActionActivatedTopic = events.DefineTypedTopic[CunningActionActivatedEvent](
+		"dnd5e.feature.cunning_action.activated")
+
 	// DeflectMissilesTriggerTopic provides typed pub/sub for deflect missiles trigger events
 	DeflectMissilesTriggerTopic = events.DefineTypedTopic[DeflectMissilesTriggerEvent](
 		"dnd5e.feature.deflect_missiles.triggered")
@@ -852,9 +1484,55 @@ var (
 	// HideActivatedTopic provides typed pub/sub for Hide ability activation
 	HideActivatedTopic = events.DefineTypedTopic[HideActivatedEvent]("dnd5e.ability.hide.activated")
 
+	// SearchActivatedTopic provides typed pub/sub for Search ability activation
+	SearchActivatedTopic = events.DefineTypedTopic[SearchActivatedEvent]("dnd5e.ability.search.activated")
+
+	// UseObjectActivatedTopic provides typed pub/sub for Use Object ability activation
+	UseObjectActivatedTopic = events.DefineTypedTopic[UseObjectActivatedEvent]("dnd5e.ability.use_object.activated")
+
+	// ReadyActivatedTopic provides typed pub/sub for Ready ability activation
+	ReadyActivatedTopic = events.DefineTypedTopic[ReadyActivatedEvent]("dnd5e.ability.ready.activated")
+
+	// LegendaryActionTakenTopic provides typed pub/sub for legendary action taken events
+	LegendaryActionTakenTopic = events.DefineTypedTopic[LegendaryActionTakenEvent](
+		"dnd5e.combat.legendary_action.taken")
+
+	// LairActionTakenTopic provides typed pub/sub for lair action taken events
+	LairActionTakenTopic = events.DefineTypedTopic[LairActionTakenEvent]("dnd5e.combat.lair_action.taken")
+
+	// SpellCastTopic provides typed pub/sub for spell cast events
+	SpellCastTopic = events.DefineTypedTopic[SpellCastEvent]("dnd5e.spell.cast")
+
+	// SpellCopiedTopic provides typed pub/sub for spells copied into a spellbook
+	SpellCopiedTopic = events.DefineTypedTopic[SpellCopiedEvent]("dnd5e.spell.copied")
+
+	// SlotsRecoveredTopic provides typed pub/sub for spell slots recovered outside a long rest
+	SlotsRecoveredTopic = events.DefineTypedTopic[SlotsRecoveredEvent]("dnd5e.spell.slots.recovered")
+
+	// ConcentrationEndedTopic provides typed pub/sub for concentration ending
+	ConcentrationEndedTopic = events.DefineTypedTopic[ConcentrationEndedEvent]("dnd5e.spell.concentration.ended")
+
+	// SummonDismissedTopic provides typed pub/sub for summoned creatures leaving combat
+	SummonDismissedTopic = events.DefineTypedTopic[SummonDismissedEvent]("dnd5e.monster.summon.dismissed")
+
+	// RechargeRolledTopic provides typed pub/sub for monster recharge rolls
+	RechargeRolledTopic = events.DefineTypedTopic[RechargeRolledEvent]("dnd5e.combat.recharge.rolled")
+
+	// AreaEffectResolvedTopic provides typed pub/sub for resolved area effects
+	AreaEffectResolvedTopic = events.DefineTypedTopic[AreaEffectResolvedEvent]("dnd5e.combat.area_effect.resolved")
+
+	// OngoingDamageEndedTopic provides typed pub/sub for ended ongoing damage effects
+	OngoingDamageEndedTopic = events.DefineTypedTopic[OngoingDamageEndedEvent]("dnd5e.combat.ongoing_damage.ended")
+
 	// StrikeExecutedTopic provides typed pub/sub for Strike action execution
 	StrikeExecutedTopic = events.DefineTypedTopic[StrikeExecutedEvent]("dnd5e.action.strike.executed")
 
+	// GrappleResolvedTopic provides typed pub/sub for resolved Grapple actions
+	GrappleResolvedTopic = events.DefineTypedTopic[GrappleResolvedEvent]("dnd5e.action.grapple.resolved")
+
+	// ShoveResolvedTopic provides typed pub/sub for resolved Shove actions
+	ShoveResolvedTopic = events.DefineTypedTopic[ShoveResolvedEvent]("dnd5e.action.shove.resolved")
+
 	// MoveExecutedTopic provides typed pub/sub for Move action execution
 	MoveExecutedTopic = events.DefineTypedTopic[MoveExecutedEvent]("dnd5e.action.move.executed")
 
@@ -902,9 +1580,21 @@ var (
 	// SavingThrowChain provides typed chained topic for saving throw modifiers
 	SavingThrowChain = events.DefineChainedTopic[*SavingThrowChainEvent]("dnd5e.saves.chain")
 
+	// AbilityCheckChain provides typed chained topic for ability check modifiers
+	AbilityCheckChain = events.DefineChainedTopic[*AbilityCheckChainEvent]("dnd5e.checks.chain")
+
+	// SpellcastingChain provides typed chained topic for spell attack bonus and
+	// spell save DC modifiers
+	SpellcastingChain = events.DefineChainedTopic[*SpellcastingChainEvent]("dnd5e.spells.spellcasting.chain")
+
 	// MovementChain provides typed chained topic for movement modifiers.
 	// This chain fires BEFORE each step of movement to allow conditions like
 	// Disengaging to prevent opportunity attacks, or features like Sentinel
 	// to stop movement entirely.
 	MovementChain = events.DefineChainedTopic[*MovementChainEvent]("dnd5e.combat.movement.chain")
+
+	// OpportunityAttackChain provides typed chained topic for reacting to an
+	// opportunity attack's outcome during movement (Sentinel stops the mover).
+	OpportunityAttackChain = events.DefineChainedTopic[*OpportunityAttackChainEvent](
+		"dnd5e.combat.opportunity_attack.chain")
 )
@@ -0,0 +1,297 @@
+// Package features provides D&D 5e class features implementation
+package features
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/combat"
+	coreResources "github.com/KirkDiggler/rpg-toolkit/core/resources"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	dnd5eCombat "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/spells"
+)
+
+// ArcaneRecoveryMaxSlotLevel is the highest slot level Arcane Recovery can recover
+const ArcaneRecoveryMaxSlotLevel = 5
+
+// ArcaneRecovery represents the wizard's Arcane Recovery feature (wizard level 1).
+// It implements core.Action[FeatureInput] for activation and events.BusEffect for resource management.
+// Once per day, when the wizard finishes a short rest, they recover expended spell
+// slots with a combined level of up to half their wizard level (rounded up).
+// None of the slots can be 6th level or higher.
+//
+// The owner must implement spellcaster to have slots to recover.
+type ArcaneRecovery struct {
+	id              string
+	name            string
+	level           int                              // Wizard level for the recovery budget
+	characterID     string                           // Character this feature belongs to
+	resource        *dnd5eCombat.RecoverableResource // 1 use per long rest
+	shortRestTaken  bool                             // True after a short rest until used or the next turn starts
+	subscriptionIDs []string
+	bus             events.EventBus
+}
+
+// ArcaneRecoveryData is the JSON structure for persisting Arcane Recovery state
+type ArcaneRecoveryData struct {
+	Ref            *core.Ref `json:"ref"`
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	Level          int       `json:"level"`
+	CharacterID    string    `json:"character_id"`
+	Uses           int       `json:"uses"`
+	MaxUses        int       `json:"max_uses"`
+	ShortRestTaken bool      `json:"short_rest_taken,omitempty"`
+}
+
+// spellcaster is implemented by owners with spell slots (monsters, and
+// characters once they carry spellcasting)
+type spellcaster interface {
+	Spellcasting() *spells.Spellcasting
+}
+
+// Ref returns the unique ref for the Arcane Recovery feature.
+func (a *ArcaneRecovery) Ref() *core.Ref { return refs.Features.ArcaneRecovery() }
+
+// Name returns the display name for the Arcane Recovery feature.
+func (a *ArcaneRecovery) Name() string { return a.name }
+
+// GetID implements core.Entity
+func (a *ArcaneRecovery) GetID() string {
+	return a.id
+}
+
+// GetType implements core.Entity
+func (a *ArcaneRecovery) GetType() core.EntityType {
+	return EntityTypeFeature
+}
+
+// RecoveryBudget returns the combined slot levels Arcane Recovery can recover:
+// half the wizard level, rounded up.
+func (a *ArcaneRecovery) RecoveryBudget() int {
+	return (a.level + 1) / 2
+}
+
+// Apply subscribes the recoverable resource and the short rest tracking to the event bus.
+// This should be called when the feature is granted to a character.
+func (a *ArcaneRecovery) Apply(ctx context.Context, bus events.EventBus) error {
+	if a.bus != nil {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "arcane recovery already applied")
+	}
+
+	if err := a.resource.Apply(ctx, bus); err != nil {
+		return err
+	}
+	a.bus = bus
+
+	restID, err := dnd5eEvents.RestTopic.On(bus).Subscribe(ctx, a.onRest)
+	if err != nil {
+		_ = a.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to rest events")
+	}
+	a.subscriptionIDs = append(a.subscriptionIDs, restID)
+
+	turnID, err := dnd5eEvents.TurnStartTopic.On(bus).Subscribe(ctx, a.onTurnStart)
+	if err != nil {
+		_ = a.Remove(ctx, bus)
+		return rpgerr.Wrap(err, "failed to subscribe to turn start")
+	}
+	a.subscriptionIDs = append(a.subscriptionIDs, turnID)
+
+	return nil
+}
+
+// Remove unsubscribes the feature from the event bus.
+// This should be called when the feature is removed from a character.
+func (a *ArcaneRecovery) Remove(ctx context.Context, bus events.EventBus) error {
+	if a.bus == nil {
+		return nil
+	}
+
+	var errs []error
+	if err := a.resource.Remove(ctx, bus); err != nil {
+		errs = append(errs, err)
+	}
+	for _, subID := range a.subscriptionIDs {
+		if err := bus.Unsubscribe(ctx, subID); err != nil {
+			errs = append(errs, fmt.Errorf("unsubscribe %s: %w", subID, err))
+		}
+	}
+
+	a.subscriptionIDs = nil
+	a.bus = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to remove arcane recovery: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// CanActivate implements core.Action[FeatureInput]
+func (a *ArcaneRecovery) CanActivate(_ context.Context, owner core.Entity, _ FeatureInput) error {
+	if !a.resource.IsAvailable() {
+		return rpgerr.New(rpgerr.CodeResourceExhausted, "arcane recovery already used today")
+	}
+
+	if !a.shortRestTaken {
+		return rpgerr.New(rpgerr.CodeTimingRestriction, "arcane recovery is used when finishing a short rest")
+	}
+
+	caster, ok := owner.(spellcaster)
+	if !ok || caster.Spellcasting() == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "owner has no spellcasting")
+	}
+
+	return nil
+}
+
+// Activate implements core.Action[FeatureInput]
+func (a *ArcaneRecovery) Activate(ctx context.Context, owner core.Entity, input FeatureInput) error {
+	if err := a.CanActivate(ctx, owner, input); err != nil {
+		return err
+	}
+
+	casting := owner.(spellcaster).Spellcasting()
+
+	levels := input.SlotLevels
+	if len(levels) == 0 {
+		levels = a.highestExpendedSlots(casting)
+	}
+	if len(levels) == 0 {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "no expended spell slots to recover")
+	}
+
+	total := 0
+	for _, level := range levels {
+		if level < 1 || level > ArcaneRecoveryMaxSlotLevel {
+			return rpgerr.Newf(rpgerr.CodeInvalidArgument,
+				"arcane recovery can't recover a level %d slot", level)
+		}
+		total += level
+	}
+	if total > a.RecoveryBudget() {
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument,
+			"slot levels total %d, arcane recovery allows %d", total, a.RecoveryBudget())
+	}
+
+	if err := casting.RecoverSlots(levels); err != nil {
+		return err
+	}
+
+	// Consume the daily use only after the slots are recovered
+	if err := a.resource.Use(1); err != nil {
+		return rpgerr.Wrapf(err, "failed to use arcane recovery")
+	}
+	a.shortRestTaken = false
+
+	if input.Bus != nil {
+		err := dnd5eEvents.SlotsRecoveredTopic.On(input.Bus).Publish(ctx, dnd5eEvents.SlotsRecoveredEvent{
+			CasterID:   owner.GetID(),
+			SlotLevels: levels,
+			Source:     refs.Features.ArcaneRecovery().String(),
+		})
+		if err != nil {
+			return rpgerr.Wrapf(err, "failed to publish slots recovered event")
+		}
+	}
+
+	return nil
+}
+
+// highestExpendedSlots picks the highest expended slots that fit the recovery budget
+func (a *ArcaneRecovery) highestExpendedSlots(casting *spells.Spellcasting) []int {
+	var levels []int
+	remaining := a.RecoveryBudget()
+	for level := min(ArcaneRecoveryMaxSlotLevel, remaining); level >= 1; level-- {
+		for range casting.SlotsExpended(level) {
+			if level > remaining {
+				break
+			}
+			levels = append(levels, level)
+			remaining -= level
+		}
+	}
+	return levels
+}
+
+// onRest tracks when the wizard finishes a short rest
+func (a *ArcaneRecovery) onRest(_ context.Context, event dnd5eEvents.RestEvent) error {
+	if event.CharacterID != a.characterID {
+		return nil
+	}
+	// A long rest restores every slot, so there is nothing left to recover
+	a.shortRestTaken = event.RestType == coreResources.ResetShortRest
+	return nil
+}
+
+// onTurnStart closes the short rest window once the wizard acts again
+func (a *ArcaneRecovery) onTurnStart(_ context.Context, event dnd5eEvents.TurnStartEvent) error {
+	if event.CharacterID == a.characterID {
+		a.shortRestTaken = false
+	}
+	return nil
+}
+
+// loadJSON loads Arcane Recovery state from JSON
+func (a *ArcaneRecovery) loadJSON(data json.RawMessage) error {
+	var recoveryData ArcaneRecoveryData
+	if err := json.Unmarshal(data, &recoveryData); err != nil {
+		return fmt.Errorf("failed to unmarshal arcane recovery data: %w", err)
+	}
+
+	a.id = recoveryData.ID
+	a.name = recoveryData.Name
+	a.level = recoveryData.Level
+	a.characterID = recoveryData.CharacterID
+	a.shortRestTaken = recoveryData.ShortRestTaken
+
+	// Set up recoverable resource with current and max uses
+	a.resource = dnd5eCombat.NewRecoverableResource(dnd5eCombat.RecoverableResourceConfig{
+		ID:          refs.Features.ArcaneRecovery().ID,
+		Maximum:     recoveryData.MaxUses,
+		CharacterID: recoveryData.CharacterID,
+		ResetType:   coreResources.ResetLongRest,
+	})
+	// Restore to the saved state
+	if recoveryData.Uses < recoveryData.MaxUses {
+		if err := a.resource.Use(recoveryData.MaxUses - recoveryData.Uses); err != nil {
+			return fmt.Errorf("failed to set resource uses: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ToJSON converts Arcane Recovery to JSON for persistence
+func (a *ArcaneRecovery) ToJSON() (json.RawMessage, error) {
+	data := ArcaneRecoveryData{
+		Ref:            refs.Features.ArcaneRecovery(),
+		ID:             a.id,
+		Name:           a.name,
+		Level:          a.level,
+		CharacterID:    a.characterID,
+		Uses:           a.resource.Current(),
+		MaxUses:        a.resource.Maximum(),
+		ShortRestTaken: a.shortRestTaken,
+	}
+
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal arcane recovery data: %w", err)
+	}
+
+	return bytes, nil
+}
+
+// ActionType returns the action economy cost to activate arcane recovery.
+// It is used outside combat while finishing a short rest, so it costs nothing.
+func (a *ArcaneRecovery) ActionType() combat.ActionType {
+	return combat.ActionFree
+}
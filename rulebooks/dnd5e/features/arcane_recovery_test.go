@@ -0,0 +1,183 @@
+package features_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	coreResources "github.com/KirkDiggler/rpg-toolkit/core/resources"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/features"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/spells"
+)
+
+// casterOwner is a feature owner with spellcasting (wizard, paladin)
+type casterOwner struct {
+	id      string
+	casting *spells.Spellcasting
+}
+
+func (c *casterOwner) GetID() string                      { return c.id }
+func (c *casterOwner) GetType() core.EntityType           { return "character" }
+func (c *casterOwner) Spellcasting() *spells.Spellcasting { return c.casting }
+
+type ArcaneRecoveryTestSuite struct {
+	suite.Suite
+	ctx       context.Context
+	bus       events.EventBus
+	wizard    *casterOwner
+	feature   *features.ArcaneRecovery
+	recovered []dnd5eEvents.SlotsRecoveredEvent
+}
+
+func TestArcaneRecoveryTestSuite(t *testing.T) {
+	suite.Run(t, new(ArcaneRecoveryTestSuite))
+}
+
+// SetupTest creates a level 5 wizard (recovers up to 3 slot levels) who has
+// spent every slot
+func (s *ArcaneRecoveryTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.recovered = nil
+
+	casting, err := spells.NewSpellcasting(&spells.SpellcastingData{
+		Ability: abilities.INT,
+		SaveDC:  14,
+		Known:   []spells.Spell{spells.MagicMissile, spells.ScorchingRay, spells.Fireball},
+		Slots: map[int]spells.SlotData{
+			1: {Max: 4, Used: 4},
+			2: {Max: 3, Used: 3},
+			3: {Max: 2, Used: 2},
+		},
+	})
+	s.Require().NoError(err)
+	s.wizard = &casterOwner{id: "wizard-1", casting: casting}
+
+	output, err := features.CreateFromRef(&features.CreateFromRefInput{
+		Ref:         refs.Features.ArcaneRecovery().String(),
+		Config:      json.RawMessage(`{"level": 5}`),
+		CharacterID: s.wizard.id,
+	})
+	s.Require().NoError(err)
+	recovery, ok := output.Feature.(*features.ArcaneRecovery)
+	s.Require().True(ok)
+	s.feature = recovery
+	s.Require().NoError(s.feature.Apply(s.ctx, s.bus))
+
+	_, err = dnd5eEvents.SlotsRecoveredTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, event dnd5eEvents.SlotsRecoveredEvent) error {
+			s.recovered = append(s.recovered, event)
+			return nil
+		})
+	s.Require().NoError(err)
+}
+
+func (s *ArcaneRecoveryTestSuite) SetupSubTest() {
+	s.SetupTest()
+}
+
+func (s *ArcaneRecoveryTestSuite) rest(restType coreResources.ResetType) {
+	err := dnd5eEvents.RestTopic.On(s.bus).Publish(s.ctx, dnd5eEvents.RestEvent{
+		RestType:    restType,
+		CharacterID: s.wizard.id,
+	})
+	s.Require().NoError(err)
+}
+
+func (s *ArcaneRecoveryTestSuite) TestRecoversChosenSlots() {
+	s.rest(coreResources.ResetShortRest)
+
+	err := s.feature.Activate(s.ctx, s.wizard, features.FeatureInput{Bus: s.bus, SlotLevels: []int{2, 1}})
+	s.Require().NoError(err)
+
+	s.Equal(1, s.wizard.casting.SlotsRemaining(1))
+	s.Equal(1, s.wizard.casting.SlotsRemaining(2))
+	s.Equal(0, s.wizard.casting.SlotsRemaining(3))
+	s.Equal([]dnd5eEvents.SlotsRecoveredEvent{{
+		CasterID:   "wizard-1",
+		SlotLevels: []int{2, 1},
+		Source:     refs.Features.ArcaneRecovery().String(),
+	}}, s.recovered)
+
+	s.rest(coreResources.ResetShortRest)
+	err = s.feature.Activate(s.ctx, s.wizard, features.FeatureInput{Bus: s.bus, SlotLevels: []int{1}})
+	s.Equal(rpgerr.CodeResourceExhausted, rpgerr.GetCode(err), "once per day")
+
+	s.rest(coreResources.ResetLongRest)
+	err = s.feature.Activate(s.ctx, s.wizard, features.FeatureInput{Bus: s.bus, SlotLevels: []int{1}})
+	s.Equal(rpgerr.CodeTimingRestriction, rpgerr.GetCode(err), "the use is back but needs a short rest")
+
+	s.rest(coreResources.ResetShortRest)
+	s.NoError(s.feature.Activate(s.ctx, s.wizard, features.FeatureInput{Bus: s.bus, SlotLevels: []int{1}}))
+}
+
+func (s *ArcaneRecoveryTestSuite) TestDefaultsToHighestSlots() {
+	s.rest(coreResources.ResetShortRest)
+
+	s.Require().NoError(s.feature.Activate(s.ctx, s.wizard, features.FeatureInput{Bus: s.bus}))
+	s.Equal(1, s.wizard.casting.SlotsRemaining(3))
+	s.Equal(0, s.wizard.casting.SlotsRemaining(2))
+	s.Equal(0, s.wizard.casting.SlotsRemaining(1))
+}
+
+func (s *ArcaneRecoveryTestSuite) TestRequiresShortRest() {
+	err := s.feature.Activate(s.ctx, s.wizard, features.FeatureInput{Bus: s.bus})
+	s.Equal(rpgerr.CodeTimingRestriction, rpgerr.GetCode(err))
+
+	s.rest(coreResources.ResetShortRest)
+	err = dnd5eEvents.TurnStartTopic.On(s.bus).Publish(s.ctx, dnd5eEvents.TurnStartEvent{CharacterID: s.wizard.id})
+	s.Require().NoError(err)
+
+	err = s.feature.Activate(s.ctx, s.wizard, features.FeatureInput{Bus: s.bus})
+	s.Equal(rpgerr.CodeTimingRestriction, rpgerr.GetCode(err), "the window closes when the wizard acts again")
+}
+
+func (s *ArcaneRecoveryTestSuite) TestInvalidSlotLevels() {
+	testCases := []struct {
+		name        string
+		levels      []int
+		allRegained bool
+	}{
+		{name: "over budget", levels: []int{3, 1}},
+		{name: "level out of range", levels: []int{0}},
+		{name: "slot not expended", levels: []int{1}, allRegained: true},
+		{name: "nothing to recover", allRegained: true},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.rest(coreResources.ResetShortRest)
+			if tc.allRegained {
+				s.Require().NoError(s.wizard.casting.RecoverSlots([]int{1, 1, 1, 1, 2, 2, 2, 3, 3}))
+			}
+
+			err := s.feature.Activate(s.ctx, s.wizard, features.FeatureInput{Bus: s.bus, SlotLevels: tc.levels})
+			s.Equal(rpgerr.CodeInvalidArgument, rpgerr.GetCode(err))
+			s.Empty(s.recovered)
+			s.NoError(s.feature.CanActivate(s.ctx, s.wizard, features.FeatureInput{}), "the use isn't spent")
+		})
+	}
+}
+
+func (s *ArcaneRecoveryTestSuite) TestRoundTrip() {
+	s.rest(coreResources.ResetShortRest)
+
+	data, err := s.feature.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := features.LoadJSON(data)
+	s.Require().NoError(err)
+
+	recovery, ok := loaded.(*features.ArcaneRecovery)
+	s.Require().True(ok)
+	s.Equal(3, recovery.RecoveryBudget())
+	s.NoError(recovery.CanActivate(s.ctx, s.wizard, features.FeatureInput{}), "short rest state persists")
+}
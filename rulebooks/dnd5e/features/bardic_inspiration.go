@@ -0,0 +1,195 @@
+// Package features provides D&D 5e class features implementation
+package features
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/combat"
+	coreResources "github.com/KirkDiggler/rpg-toolkit/core/resources"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	dnd5eCombat "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/conditions"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/resources"
+)
+
+// BardicInspirationDie returns the size of the inspiration die at a bard level:
+// d6 at level 1, d8 at 5, d10 at 10 and d12 at 15.
+func BardicInspirationDie(level int) int {
+	switch {
+	case level >= 15:
+		return 12
+	case level >= 10:
+		return 10
+	case level >= 5:
+		return 8
+	default:
+		return 6
+	}
+}
+
+// BardicInspiration represents the bard's Bardic Inspiration feature (bard level 1).
+// It implements core.Action[FeatureInput] for activation.
+// As a bonus action the bard gives another creature an inspiration die. The die is
+// held as a BardicInspirationCondition until the creature adds it to one attack roll,
+// ability check or saving throw. A creature can hold only one inspiration die at a time.
+type BardicInspiration struct {
+	id          string
+	name        string
+	level       int    // Bard level for the die size
+	characterID string // Character this feature belongs to
+}
+
+// BardicInspirationData is the JSON structure for persisting Bardic Inspiration state
+type BardicInspirationData struct {
+	Ref         *core.Ref `json:"ref"`
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Level       int       `json:"level"`
+	CharacterID string    `json:"character_id"`
+}
+
+// Ref returns the unique ref for the Bardic Inspiration feature.
+func (b *BardicInspiration) Ref() *core.Ref { return refs.Features.BardicInspiration() }
+
+// Name returns the display name for the Bardic Inspiration feature.
+func (b *BardicInspiration) Name() string { return b.name }
+
+// GetID implements core.Entity
+func (b *BardicInspiration) GetID() string {
+	return b.id
+}
+
+// GetType implements core.Entity
+func (b *BardicInspiration) GetType() core.EntityType {
+	return EntityTypeFeature
+}
+
+// CanActivate implements core.Action[FeatureInput]
+func (b *BardicInspiration) CanActivate(ctx context.Context, owner core.Entity, input FeatureInput) error {
+	accessor, ok := owner.(coreResources.ResourceAccessor)
+	if !ok {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "owner does not implement ResourceAccessor")
+	}
+
+	if !accessor.IsResourceAvailable(resources.BardicInspiration) {
+		return rpgerr.New(rpgerr.CodeResourceExhausted, "no bardic inspiration uses remaining")
+	}
+
+	if input.Bus == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "bardic inspiration requires an event bus")
+	}
+
+	_, err := b.findTarget(ctx, owner, input.TargetID)
+	return err
+}
+
+// findTarget looks up the creature receiving the die
+func (b *BardicInspiration) findTarget(ctx context.Context, owner core.Entity, targetID string) (core.Entity, error) {
+	if targetID == "" {
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "bardic inspiration requires a target")
+	}
+	if targetID == owner.GetID() {
+		return nil, rpgerr.New(rpgerr.CodeInvalidTarget, "a bard can't inspire themselves")
+	}
+
+	combatant, err := dnd5eCombat.GetCombatantFromContext(ctx, targetID)
+	if err != nil {
+		return nil, rpgerr.Wrapf(err, "failed to find bardic inspiration target %s", targetID)
+	}
+	target, ok := combatant.(core.Entity)
+	if !ok {
+		return nil, rpgerr.Newf(rpgerr.CodeInvalidTarget, "target %s can't hold conditions", targetID)
+	}
+
+	if holder, ok := combatant.(dnd5eCombat.ConditionHolder); ok {
+		for _, ref := range dnd5eCombat.ConditionRefs(holder.GetConditions()) {
+			if ref.Equals(refs.Conditions.BardicInspiration()) {
+				return nil, rpgerr.Newf(rpgerr.CodeAlreadyExists, "%s already holds a bardic inspiration die", targetID)
+			}
+		}
+	}
+
+	return target, nil
+}
+
+// Activate implements core.Action[FeatureInput]
+func (b *BardicInspiration) Activate(ctx context.Context, owner core.Entity, input FeatureInput) error {
+	if err := b.CanActivate(ctx, owner, input); err != nil {
+		return err
+	}
+
+	target, err := b.findTarget(ctx, owner, input.TargetID)
+	if err != nil {
+		return err
+	}
+
+	accessor, ok := owner.(coreResources.ResourceAccessor)
+	if !ok {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "owner does not implement ResourceAccessor")
+	}
+	if err := accessor.UseResource(resources.BardicInspiration, 1); err != nil {
+		return rpgerr.Wrapf(err, "failed to use bardic inspiration")
+	}
+
+	inspiration := conditions.NewBardicInspirationCondition(conditions.BardicInspirationInput{
+		CharacterID: target.GetID(),
+		SourceID:    owner.GetID(),
+		DieSize:     BardicInspirationDie(b.level),
+	})
+
+	topic := dnd5eEvents.ConditionAppliedTopic.On(input.Bus)
+	err = topic.Publish(ctx, dnd5eEvents.ConditionAppliedEvent{
+		Target:    target,
+		Type:      dnd5eEvents.ConditionBardicInspiration,
+		Source:    dnd5eEvents.ConditionSourceFeature,
+		Condition: inspiration,
+	})
+	if err != nil {
+		return rpgerr.Wrapf(err, "failed to publish bardic inspiration condition")
+	}
+
+	return nil
+}
+
+// loadJSON loads Bardic Inspiration state from JSON
+func (b *BardicInspiration) loadJSON(data json.RawMessage) error {
+	var inspirationData BardicInspirationData
+	if err := json.Unmarshal(data, &inspirationData); err != nil {
+		return fmt.Errorf("failed to unmarshal bardic inspiration data: %w", err)
+	}
+
+	b.id = inspirationData.ID
+	b.name = inspirationData.Name
+	b.level = inspirationData.Level
+	b.characterID = inspirationData.CharacterID
+
+	return nil
+}
+
+// ToJSON converts Bardic Inspiration to JSON for persistence
+func (b *BardicInspiration) ToJSON() (json.RawMessage, error) {
+	data := BardicInspirationData{
+		Ref:         refs.Features.BardicInspiration(),
+		ID:          b.id,
+		Name:        b.name,
+		Level:       b.level,
+		CharacterID: b.characterID,
+	}
+
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal bardic inspiration data: %w", err)
+	}
+
+	return bytes, nil
+}
+
+// ActionType returns the action economy cost to activate bardic inspiration (bonus action)
+func (b *BardicInspiration) ActionType() combat.ActionType {
+	return combat.ActionBonus
+}
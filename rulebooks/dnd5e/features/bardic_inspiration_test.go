@@ -0,0 +1,136 @@
+package features_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	coreCombat "github.com/KirkDiggler/rpg-toolkit/core/combat"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/conditions"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/features"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster/monsters"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/resources"
+)
+
+type BardicInspirationTestSuite struct {
+	suite.Suite
+	ctx     context.Context
+	bus     events.EventBus
+	lookup  monsterLookup
+	bard    *mockResourceAccessor
+	feature features.Feature
+	applied []dnd5eEvents.ConditionAppliedEvent
+}
+
+func TestBardicInspirationTestSuite(t *testing.T) {
+	suite.Run(t, new(BardicInspirationTestSuite))
+}
+
+// SetupTest gives a level 5 bard two uses and a wolf companion to inspire
+func (s *BardicInspirationTestSuite) SetupTest() {
+	s.bus = events.NewEventBus()
+	s.lookup = monsterLookup{"wolf-1": monsters.NewWolf("wolf-1")}
+	s.ctx = combat.WithCombatantLookup(context.Background(), s.lookup)
+	s.applied = nil
+
+	s.bard = &mockResourceAccessor{id: "bard-1"}
+	s.bard.AddResource(resources.BardicInspiration, combat.NewRecoverableResource(combat.RecoverableResourceConfig{
+		ID:      string(resources.BardicInspiration),
+		Maximum: 2,
+	}))
+
+	output, err := features.CreateFromRef(&features.CreateFromRefInput{
+		Ref:         refs.Features.BardicInspiration().String(),
+		Config:      json.RawMessage(`{"level": 5}`),
+		CharacterID: s.bard.id,
+	})
+	s.Require().NoError(err)
+	s.feature = output.Feature
+
+	_, err = dnd5eEvents.ConditionAppliedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, event dnd5eEvents.ConditionAppliedEvent) error {
+			s.applied = append(s.applied, event)
+			return nil
+		})
+	s.Require().NoError(err)
+}
+
+func (s *BardicInspirationTestSuite) TestIsBonusAction() {
+	inspiration, ok := s.feature.(*features.BardicInspiration)
+	s.Require().True(ok)
+	s.Equal("Bardic Inspiration", inspiration.Name())
+	s.Equal(coreCombat.ActionBonus, inspiration.ActionType())
+}
+
+func (s *BardicInspirationTestSuite) TestDieSizeByLevel() {
+	s.Equal(6, features.BardicInspirationDie(1))
+	s.Equal(8, features.BardicInspirationDie(5))
+	s.Equal(10, features.BardicInspirationDie(10))
+	s.Equal(12, features.BardicInspirationDie(15))
+}
+
+func (s *BardicInspirationTestSuite) TestGrantsDieToAlly() {
+	err := s.feature.Activate(s.ctx, s.bard, features.FeatureInput{Bus: s.bus, TargetID: "wolf-1"})
+	s.Require().NoError(err)
+
+	s.Equal(1, s.bard.GetResource(resources.BardicInspiration).Current())
+	s.Require().Len(s.applied, 1)
+	s.Equal("wolf-1", s.applied[0].Target.GetID())
+	s.Equal(dnd5eEvents.ConditionBardicInspiration, s.applied[0].Type)
+
+	inspiration, ok := s.applied[0].Condition.(*conditions.BardicInspirationCondition)
+	s.Require().True(ok)
+	s.Equal("wolf-1", inspiration.CharacterID)
+	s.Equal("bard-1", inspiration.SourceID)
+	s.Equal(8, inspiration.DieSize, "d8 at bard level 5")
+}
+
+func (s *BardicInspirationTestSuite) TestCannotInspireSelf() {
+	err := s.feature.CanActivate(s.ctx, s.bard, features.FeatureInput{Bus: s.bus, TargetID: "bard-1"})
+	s.Require().Error(err)
+	s.Equal(rpgerr.CodeInvalidTarget, rpgerr.GetCode(err))
+}
+
+func (s *BardicInspirationTestSuite) TestRequiresTarget() {
+	err := s.feature.CanActivate(s.ctx, s.bard, features.FeatureInput{Bus: s.bus})
+	s.Require().Error(err)
+	s.Equal(rpgerr.CodeInvalidArgument, rpgerr.GetCode(err))
+}
+
+func (s *BardicInspirationTestSuite) TestAllyHoldsOnlyOneDie() {
+	s.lookup["wolf-1"].AddCondition(conditions.NewBardicInspirationCondition(conditions.BardicInspirationInput{
+		CharacterID: "wolf-1",
+		SourceID:    "bard-2",
+		DieSize:     6,
+	}))
+
+	err := s.feature.Activate(s.ctx, s.bard, features.FeatureInput{Bus: s.bus, TargetID: "wolf-1"})
+	s.Require().Error(err)
+	s.Equal(rpgerr.CodeAlreadyExists, rpgerr.GetCode(err))
+	s.Equal(2, s.bard.GetResource(resources.BardicInspiration).Current(), "no use spent")
+}
+
+func (s *BardicInspirationTestSuite) TestExhausted() {
+	s.Require().NoError(s.bard.GetResource(resources.BardicInspiration).Use(2))
+
+	err := s.feature.CanActivate(s.ctx, s.bard, features.FeatureInput{Bus: s.bus, TargetID: "wolf-1"})
+	s.Require().Error(err)
+	s.Equal(rpgerr.CodeResourceExhausted, rpgerr.GetCode(err))
+}
+
+func (s *BardicInspirationTestSuite) TestRoundTrip() {
+	data, err := s.feature.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := features.LoadJSON(data)
+	s.Require().NoError(err)
+	s.Equal(refs.Features.BardicInspiration().ID, loaded.GetID())
+	s.Equal("Bardic Inspiration", loaded.Name())
+}
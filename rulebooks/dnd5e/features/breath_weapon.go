@@ -0,0 +1,231 @@
+// Package features provides D&D 5e class features implementation
+package features
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/combat"
+	coreResources "github.com/KirkDiggler/rpg-toolkit/core/resources"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	dnd5eCombat "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/races"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+const (
+	// BreathWeaponLineLength is the length in feet of a line-shaped breath weapon
+	BreathWeaponLineLength = 30
+	// BreathWeaponConeLength is the length in feet of a cone-shaped breath weapon
+	BreathWeaponConeLength = 15
+)
+
+// BreathWeapon represents the dragonborn's Breath Weapon racial trait.
+// It implements core.Action[FeatureInput] for activation and events.BusEffect for resource management.
+// When activated, the dragonborn exhales a line or cone toward FeatureInput.TargetID.
+// Each creature in the area makes a saving throw (DEX or CON by ancestry) and takes
+// the ancestry's damage type, half on a success. Damage is 2d6, rising to 3d6 at
+// 6th level, 4d6 at 11th, and 5d6 at 16th. One use per short or long rest.
+// Damage is resolved but not applied, as with any area effect.
+// Requires the room and combatant lookup in context.
+type BreathWeapon struct {
+	id          string
+	name        string
+	level       int // Character level for damage scaling
+	characterID string
+	ancestry    races.DraconicAncestry
+	saveDC      int                              // 8 + CON modifier + proficiency bonus
+	resource    *dnd5eCombat.RecoverableResource // 1 use per short/long rest
+}
+
+// BreathWeaponData is the JSON structure for persisting Breath Weapon state
+type BreathWeaponData struct {
+	Ref         *core.Ref              `json:"ref"`
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	Level       int                    `json:"level"`
+	CharacterID string                 `json:"character_id"`
+	Ancestry    races.DraconicAncestry `json:"ancestry"`
+	SaveDC      int                    `json:"save_dc"`
+	Uses        int                    `json:"uses"`
+	MaxUses     int                    `json:"max_uses"`
+}
+
+// BreathWeaponDice returns the breath weapon damage dice for a character level
+func BreathWeaponDice(level int) string {
+	switch {
+	case level >= 16:
+		return "5d6"
+	case level >= 11:
+		return "4d6"
+	case level >= 6:
+		return "3d6"
+	default:
+		return "2d6"
+	}
+}
+
+// Ref returns the unique ref for the Breath Weapon feature.
+func (b *BreathWeapon) Ref() *core.Ref { return refs.Features.BreathWeapon() }
+
+// Name returns the display name for the Breath Weapon feature.
+func (b *BreathWeapon) Name() string { return b.name }
+
+// GetID implements core.Entity
+func (b *BreathWeapon) GetID() string {
+	return b.id
+}
+
+// GetType implements core.Entity
+func (b *BreathWeapon) GetType() core.EntityType {
+	return EntityTypeFeature
+}
+
+// CanActivate implements core.Action[FeatureInput]
+func (b *BreathWeapon) CanActivate(_ context.Context, _ core.Entity, input FeatureInput) error {
+	if !b.resource.IsAvailable() {
+		return rpgerr.New(rpgerr.CodeResourceExhausted, "breath weapon already used")
+	}
+
+	if input.Bus == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "event bus required for breath weapon")
+	}
+
+	if input.TargetID == "" {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "breath weapon requires a target to aim at")
+	}
+
+	return nil
+}
+
+// Apply subscribes the recoverable resource to the event bus for automatic rest recovery.
+func (b *BreathWeapon) Apply(ctx context.Context, bus events.EventBus) error {
+	return b.resource.Apply(ctx, bus)
+}
+
+// Remove unsubscribes the recoverable resource from the event bus.
+func (b *BreathWeapon) Remove(ctx context.Context, bus events.EventBus) error {
+	return b.resource.Remove(ctx, bus)
+}
+
+// Activate implements core.Action[FeatureInput]
+func (b *BreathWeapon) Activate(ctx context.Context, owner core.Entity, input FeatureInput) error {
+	if err := b.CanActivate(ctx, owner, input); err != nil {
+		return err
+	}
+
+	ancestry := races.GetDraconicAncestry(b.ancestry)
+	if ancestry == nil {
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument, "unknown draconic ancestry: %s", b.ancestry)
+	}
+
+	ownerID := owner.GetID()
+	origin, found := dnd5eCombat.EntityPosition(ctx, ownerID)
+	if !found {
+		return rpgerr.Newf(rpgerr.CodeNotFound, "%s is not placed in a room", ownerID)
+	}
+	aim, found := dnd5eCombat.EntityPosition(ctx, input.TargetID)
+	if !found {
+		return rpgerr.Newf(rpgerr.CodeNotFound, "%s is not placed in a room", input.TargetID)
+	}
+
+	template := dnd5eCombat.AreaTemplate{
+		Shape:     dnd5eCombat.AreaShapeCone,
+		Size:      BreathWeaponConeLength,
+		Direction: &aim,
+	}
+	if ancestry.Line {
+		template.Shape = dnd5eCombat.AreaShapeLine
+		template.Size = BreathWeaponLineLength
+	}
+
+	_, err := dnd5eCombat.ResolveAreaEffect(ctx, &dnd5eCombat.AreaInput{
+		SourceID:  ownerID,
+		EffectRef: refs.Features.BreathWeapon(),
+		Template:  template,
+		Origin:    origin,
+		Save: &dnd5eCombat.AreaSave{
+			Ability:       ancestry.SaveAbility,
+			DC:            b.saveDC,
+			HalfOnSuccess: true,
+			Trigger:       dnd5eEvents.SaveTriggerFeature,
+		},
+		Damage: &dnd5eCombat.AreaDamage{
+			Dice: BreathWeaponDice(b.level),
+			Type: ancestry.DamageType,
+		},
+		ExcludeIDs: []string{ownerID},
+		EventBus:   input.Bus,
+		Roller:     input.Roller,
+	})
+	if err != nil {
+		return rpgerr.Wrapf(err, "failed to resolve breath weapon")
+	}
+
+	// Consume the use only after the area resolves
+	if err := b.resource.Use(1); err != nil {
+		return rpgerr.Wrapf(err, "failed to use breath weapon")
+	}
+
+	return nil
+}
+
+// loadJSON loads Breath Weapon state from JSON
+func (b *BreathWeapon) loadJSON(data json.RawMessage) error {
+	var breathData BreathWeaponData
+	if err := json.Unmarshal(data, &breathData); err != nil {
+		return fmt.Errorf("failed to unmarshal breath weapon data: %w", err)
+	}
+
+	b.id = breathData.ID
+	b.name = breathData.Name
+	b.level = breathData.Level
+	b.characterID = breathData.CharacterID
+	b.ancestry = breathData.Ancestry
+	b.saveDC = breathData.SaveDC
+
+	b.resource = dnd5eCombat.NewRecoverableResource(dnd5eCombat.RecoverableResourceConfig{
+		ID:          refs.Features.BreathWeapon().ID,
+		Maximum:     breathData.MaxUses,
+		CharacterID: breathData.CharacterID,
+		ResetType:   coreResources.ResetShortRest,
+	})
+	if breathData.Uses < breathData.MaxUses {
+		if err := b.resource.Use(breathData.MaxUses - breathData.Uses); err != nil {
+			return fmt.Errorf("failed to set resource uses: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ToJSON converts Breath Weapon to JSON for persistence
+func (b *BreathWeapon) ToJSON() (json.RawMessage, error) {
+	data := BreathWeaponData{
+		Ref:         refs.Features.BreathWeapon(),
+		ID:          b.id,
+		Name:        b.name,
+		Level:       b.level,
+		CharacterID: b.characterID,
+		Ancestry:    b.ancestry,
+		SaveDC:      b.saveDC,
+		Uses:        b.resource.Current(),
+		MaxUses:     b.resource.Maximum(),
+	}
+
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal breath weapon data: %w", err)
+	}
+
+	return bytes, nil
+}
+
+// ActionType returns the action economy cost to use the breath weapon (action)
+func (b *BreathWeapon) ActionType() combat.ActionType {
+	return combat.ActionStandard
+}
@@ -0,0 +1,158 @@
+package features_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	coreCombat "github.com/KirkDiggler/rpg-toolkit/core/combat"
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/features"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
+)
+
+type BreathWeaponTestSuite struct {
+	suite.Suite
+	ctrl       *gomock.Controller
+	ctx        context.Context
+	bus        events.EventBus
+	roller     *mock_dice.MockRoller
+	room       *spatial.BasicRoom
+	lookup     monsterLookup
+	dragonborn *mockResourceAccessor
+}
+
+func TestBreathWeaponTestSuite(t *testing.T) {
+	suite.Run(t, new(BreathWeaponTestSuite))
+}
+
+func (s *BreathWeaponTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.bus = events.NewEventBus()
+	s.roller = mock_dice.NewMockRoller(s.ctrl)
+	s.lookup = monsterLookup{}
+
+	s.room = spatial.NewBasicRoom(spatial.BasicRoomConfig{
+		ID:   "cavern",
+		Type: "combat",
+		Grid: spatial.NewSquareGrid(spatial.SquareGridConfig{Width: 12, Height: 12}),
+	})
+	s.ctx = combat.WithRoom(context.Background(), s.room)
+	s.ctx = combat.WithCombatantLookup(s.ctx, s.lookup)
+
+	s.dragonborn = &mockResourceAccessor{id: "dragonborn-1"}
+	s.Require().NoError(s.room.PlaceEntity(s.dragonborn, spatial.Position{X: 0, Y: 0}))
+}
+
+func (s *BreathWeaponTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *BreathWeaponTestSuite) create(config string) features.Feature {
+	output, err := features.CreateFromRef(&features.CreateFromRefInput{
+		Ref:         refs.Features.BreathWeapon().String(),
+		Config:      json.RawMessage(config),
+		CharacterID: s.dragonborn.id,
+	})
+	s.Require().NoError(err)
+	return output.Feature
+}
+
+func (s *BreathWeaponTestSuite) place(m *monster.Monster, pos spatial.Position) {
+	s.lookup[m.GetID()] = m
+	s.Require().NoError(s.room.PlaceEntity(m, pos))
+}
+
+func (s *BreathWeaponTestSuite) TestIsAction() {
+	breath, ok := s.create(`{"ancestry": "red", "save_dc": 12}`).(*features.BreathWeapon)
+	s.Require().True(ok)
+	s.Equal("Breath Weapon", breath.Name())
+	s.Equal(coreCombat.ActionStandard, breath.ActionType())
+}
+
+func (s *BreathWeaponTestSuite) TestDamageScalesWithLevel() {
+	s.Equal("2d6", features.BreathWeaponDice(1))
+	s.Equal("3d6", features.BreathWeaponDice(6))
+	s.Equal("4d6", features.BreathWeaponDice(11))
+	s.Equal("5d6", features.BreathWeaponDice(16))
+}
+
+func (s *BreathWeaponTestSuite) TestLineDamagesCreaturesAlongIt() {
+	feature := s.create(`{"ancestry": "black", "save_dc": 12}`)
+	s.place(monster.NewGoblin("goblin-1"), spatial.Position{X: 3, Y: 0})
+	s.place(monster.NewGoblin("goblin-2"), spatial.Position{X: 0, Y: 3})
+
+	var resolved *dnd5eEvents.AreaEffectResolvedEvent
+	_, err := dnd5eEvents.AreaEffectResolvedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, event dnd5eEvents.AreaEffectResolvedEvent) error {
+			resolved = &event
+			return nil
+		})
+	s.Require().NoError(err)
+
+	// 2d6 acid, then the goblin in the line fails its DEX save (5 + 2 DEX < 12)
+	s.roller.EXPECT().RollN(gomock.Any(), 2, 6).Return([]int{4, 3}, nil)
+	s.roller.EXPECT().Roll(gomock.Any(), 20).Return(5, nil)
+
+	err = feature.Activate(s.ctx, s.dragonborn, features.FeatureInput{
+		Bus:      s.bus,
+		Roller:   s.roller,
+		TargetID: "goblin-1",
+	})
+	s.Require().NoError(err)
+
+	s.Require().NotNil(resolved)
+	s.Equal(refs.Features.BreathWeapon(), resolved.EffectRef)
+	s.Equal("line", resolved.Shape)
+	s.Require().Len(resolved.Targets, 1, "only the goblin in the line")
+	s.Equal("goblin-1", resolved.Targets[0].TargetID)
+	s.False(resolved.Targets[0].Saved)
+	s.Equal(7, resolved.Targets[0].Damage)
+
+	err = feature.Activate(s.ctx, s.dragonborn, features.FeatureInput{
+		Bus:      s.bus,
+		Roller:   s.roller,
+		TargetID: "goblin-1",
+	})
+	s.Equal(rpgerr.CodeResourceExhausted, rpgerr.GetCode(err), "one use per rest")
+}
+
+func (s *BreathWeaponTestSuite) TestRequiresTarget() {
+	feature := s.create(`{"ancestry": "red", "save_dc": 12}`)
+
+	err := feature.Activate(s.ctx, s.dragonborn, features.FeatureInput{Bus: s.bus, Roller: s.roller})
+	s.Equal(rpgerr.CodeInvalidArgument, rpgerr.GetCode(err))
+}
+
+func (s *BreathWeaponTestSuite) TestFactoryAndRoundTrip() {
+	_, err := features.CreateFromRef(&features.CreateFromRefInput{
+		Ref:         refs.Features.BreathWeapon().String(),
+		Config:      json.RawMessage(`{"ancestry": "purple", "save_dc": 12}`),
+		CharacterID: s.dragonborn.id,
+	})
+	s.Error(err, "unknown ancestry")
+
+	_, err = features.CreateFromRef(&features.CreateFromRefInput{
+		Ref:         refs.Features.BreathWeapon().String(),
+		Config:      json.RawMessage(`{"ancestry": "red"}`),
+		CharacterID: s.dragonborn.id,
+	})
+	s.Error(err, "save_dc is required")
+
+	feature := s.create(`{"ancestry": "silver", "save_dc": 13, "level": 6}`)
+	data, err := feature.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := features.LoadJSON(data)
+	s.Require().NoError(err)
+	s.Equal(feature, loaded)
+}
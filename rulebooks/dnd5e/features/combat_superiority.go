@@ -0,0 +1,189 @@
+// Package features provides D&D 5e class features implementation
+package features
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/combat"
+	coreResources "github.com/KirkDiggler/rpg-toolkit/core/resources"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/conditions"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/maneuvers"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/resources"
+)
+
+// CombatSuperiority represents the Battle Master's Combat Superiority feature (fighter level 3).
+// It implements core.Action[FeatureInput] for activation.
+// The fighter spends a superiority die to perform a known maneuver, chosen with
+// FeatureInput.Action. Each maneuver is a ManeuverCondition that triggers on the
+// fighter's next matching attack:
+//   - Precision Attack: activate before the attack roll
+//   - Trip Attack: activate after combat.ResolveAttackHit reports a hit and before
+//     combat.ApplyAttackOutcome, with the hit creature as TargetID
+//   - Riposte: activate when a creature misses the fighter with a melee attack, with
+//     that creature as TargetID, then resolve the reaction attack. The caller spends
+//     the reaction.
+type CombatSuperiority struct {
+	id          string
+	name        string
+	level       int                  // Fighter level for the superiority die size
+	saveDC      int                  // Maneuver save DC (8 + proficiency + STR or DEX modifier)
+	known       []maneuvers.Maneuver // Maneuvers the fighter knows
+	characterID string               // Character this feature belongs to
+}
+
+// CombatSuperiorityData is the JSON structure for persisting Combat Superiority state
+type CombatSuperiorityData struct {
+	Ref         *core.Ref            `json:"ref"`
+	ID          string               `json:"id"`
+	Name        string               `json:"name"`
+	Level       int                  `json:"level"`
+	SaveDC      int                  `json:"save_dc,omitempty"`
+	Maneuvers   []maneuvers.Maneuver `json:"maneuvers"`
+	CharacterID string               `json:"character_id"`
+}
+
+// Ref returns the unique ref for the Combat Superiority feature.
+func (c *CombatSuperiority) Ref() *core.Ref { return refs.Features.CombatSuperiority() }
+
+// Name returns the display name for the Combat Superiority feature.
+func (c *CombatSuperiority) Name() string { return c.name }
+
+// GetID implements core.Entity
+func (c *CombatSuperiority) GetID() string {
+	return c.id
+}
+
+// GetType implements core.Entity
+func (c *CombatSuperiority) GetType() core.EntityType {
+	return EntityTypeFeature
+}
+
+// Maneuvers returns the maneuvers the fighter knows
+func (c *CombatSuperiority) Maneuvers() []maneuvers.Maneuver {
+	return c.known
+}
+
+// CanActivate implements core.Action[FeatureInput]
+func (c *CombatSuperiority) CanActivate(_ context.Context, owner core.Entity, input FeatureInput) error {
+	accessor, ok := owner.(coreResources.ResourceAccessor)
+	if !ok {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "owner does not implement ResourceAccessor")
+	}
+
+	if !accessor.IsResourceAvailable(resources.SuperiorityDice) {
+		return rpgerr.New(rpgerr.CodeResourceExhausted, "no superiority dice remaining")
+	}
+
+	if input.Bus == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "event bus required for combat superiority")
+	}
+
+	maneuver := input.Action
+	if !slices.Contains(c.known, maneuver) {
+		return rpgerr.Newf(rpgerr.CodeNotAllowed, "maneuver %s is not known", maneuver)
+	}
+
+	switch maneuver {
+	case maneuvers.TripAttack:
+		if c.saveDC <= 0 {
+			return rpgerr.New(rpgerr.CodeInvalidArgument, "trip attack requires a maneuver save DC")
+		}
+		if input.TargetID == "" {
+			return rpgerr.New(rpgerr.CodeInvalidArgument, "trip attack requires the target that was hit")
+		}
+	case maneuvers.Riposte:
+		if input.TargetID == "" {
+			return rpgerr.New(rpgerr.CodeInvalidArgument, "riposte requires the creature that missed")
+		}
+	}
+
+	return nil
+}
+
+// Activate implements core.Action[FeatureInput]
+func (c *CombatSuperiority) Activate(ctx context.Context, owner core.Entity, input FeatureInput) error {
+	if err := c.CanActivate(ctx, owner, input); err != nil {
+		return err
+	}
+
+	accessor, ok := owner.(coreResources.ResourceAccessor)
+	if !ok {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "owner does not implement ResourceAccessor")
+	}
+	if err := accessor.UseResource(resources.SuperiorityDice, 1); err != nil {
+		return rpgerr.Wrapf(err, "failed to use superiority die")
+	}
+
+	maneuver := conditions.NewManeuverCondition(conditions.ManeuverInput{
+		CharacterID: owner.GetID(),
+		TargetID:    input.TargetID,
+		Maneuver:    input.Action,
+		DieSize:     maneuvers.DieSize(c.level),
+		SaveDC:      c.saveDC,
+		Roller:      input.Roller,
+	})
+
+	topic := dnd5eEvents.ConditionAppliedTopic.On(input.Bus)
+	err := topic.Publish(ctx, dnd5eEvents.ConditionAppliedEvent{
+		Target:    owner,
+		Type:      dnd5eEvents.ConditionManeuver,
+		Source:    dnd5eEvents.ConditionSourceFeature,
+		Condition: maneuver,
+	})
+	if err != nil {
+		return rpgerr.Wrapf(err, "failed to publish %s maneuver", input.Action)
+	}
+
+	return nil
+}
+
+// loadJSON loads Combat Superiority state from JSON
+func (c *CombatSuperiority) loadJSON(data json.RawMessage) error {
+	var superiorityData CombatSuperiorityData
+	if err := json.Unmarshal(data, &superiorityData); err != nil {
+		return fmt.Errorf("failed to unmarshal combat superiority data: %w", err)
+	}
+
+	c.id = superiorityData.ID
+	c.name = superiorityData.Name
+	c.level = superiorityData.Level
+	c.saveDC = superiorityData.SaveDC
+	c.known = superiorityData.Maneuvers
+	c.characterID = superiorityData.CharacterID
+
+	return nil
+}
+
+// ToJSON converts Combat Superiority to JSON for persistence
+func (c *CombatSuperiority) ToJSON() (json.RawMessage, error) {
+	data := CombatSuperiorityData{
+		Ref:         refs.Features.CombatSuperiority(),
+		ID:          c.id,
+		Name:        c.name,
+		Level:       c.level,
+		SaveDC:      c.saveDC,
+		Maneuvers:   c.known,
+		CharacterID: c.characterID,
+	}
+
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal combat superiority data: %w", err)
+	}
+
+	return bytes, nil
+}
+
+// ActionType returns the action economy cost to activate combat superiority.
+// Maneuvers ride on an attack, so they cost nothing themselves; Riposte's
+// reaction is spent by the caller making the reaction attack.
+func (c *CombatSuperiority) ActionType() combat.ActionType {
+	return combat.ActionFree
+}
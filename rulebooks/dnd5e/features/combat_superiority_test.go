@@ -0,0 +1,141 @@
+package features_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	coreCombat "github.com/KirkDiggler/rpg-toolkit/core/combat"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/conditions"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/features"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/maneuvers"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/resources"
+)
+
+type CombatSuperiorityTestSuite struct {
+	suite.Suite
+	ctx     context.Context
+	bus     events.EventBus
+	fighter *mockResourceAccessor
+	feature features.Feature
+	applied []dnd5eEvents.ConditionAppliedEvent
+}
+
+func TestCombatSuperiorityTestSuite(t *testing.T) {
+	suite.Run(t, new(CombatSuperiorityTestSuite))
+}
+
+// SetupTest gives a level 10 Battle Master four superiority dice and two maneuvers
+func (s *CombatSuperiorityTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.applied = nil
+
+	s.fighter = &mockResourceAccessor{id: "fighter-1"}
+	s.fighter.AddResource(resources.SuperiorityDice, combat.NewRecoverableResource(combat.RecoverableResourceConfig{
+		ID:      string(resources.SuperiorityDice),
+		Maximum: 4,
+	}))
+
+	output, err := features.CreateFromRef(&features.CreateFromRefInput{
+		Ref:         refs.Features.CombatSuperiority().String(),
+		Config:      json.RawMessage(`{"level": 10, "save_dc": 15, "maneuvers": ["trip_attack", "riposte"]}`),
+		CharacterID: s.fighter.id,
+	})
+	s.Require().NoError(err)
+	s.feature = output.Feature
+
+	_, err = dnd5eEvents.ConditionAppliedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, event dnd5eEvents.ConditionAppliedEvent) error {
+			s.applied = append(s.applied, event)
+			return nil
+		})
+	s.Require().NoError(err)
+}
+
+func (s *CombatSuperiorityTestSuite) TestIsFree() {
+	superiority, ok := s.feature.(*features.CombatSuperiority)
+	s.Require().True(ok)
+	s.Equal("Combat Superiority", superiority.Name())
+	s.Equal(coreCombat.ActionFree, superiority.ActionType())
+	s.Equal([]maneuvers.Maneuver{maneuvers.TripAttack, maneuvers.Riposte}, superiority.Maneuvers())
+}
+
+func (s *CombatSuperiorityTestSuite) TestTripAttackDeclaresManeuver() {
+	err := s.feature.Activate(s.ctx, s.fighter, features.FeatureInput{
+		Bus:      s.bus,
+		Action:   maneuvers.TripAttack,
+		TargetID: "ogre-1",
+	})
+	s.Require().NoError(err)
+
+	s.Equal(3, s.fighter.GetResource(resources.SuperiorityDice).Current())
+	s.Require().Len(s.applied, 1)
+	s.Equal("fighter-1", s.applied[0].Target.GetID())
+	s.Equal(dnd5eEvents.ConditionManeuver, s.applied[0].Type)
+
+	maneuver, ok := s.applied[0].Condition.(*conditions.ManeuverCondition)
+	s.Require().True(ok)
+	s.Equal(maneuvers.TripAttack, maneuver.Maneuver)
+	s.Equal("ogre-1", maneuver.TargetID)
+	s.Equal(10, maneuver.DieSize, "d10 at fighter level 10")
+	s.Equal(15, maneuver.SaveDC)
+}
+
+func (s *CombatSuperiorityTestSuite) TestRejectsUnknownManeuver() {
+	err := s.feature.CanActivate(s.ctx, s.fighter, features.FeatureInput{
+		Bus:    s.bus,
+		Action: maneuvers.PrecisionAttack,
+	})
+	s.Require().Error(err)
+	s.Equal(rpgerr.CodeNotAllowed, rpgerr.GetCode(err))
+}
+
+func (s *CombatSuperiorityTestSuite) TestRiposteRequiresTarget() {
+	err := s.feature.CanActivate(s.ctx, s.fighter, features.FeatureInput{
+		Bus:    s.bus,
+		Action: maneuvers.Riposte,
+	})
+	s.Require().Error(err)
+	s.Equal(rpgerr.CodeInvalidArgument, rpgerr.GetCode(err))
+}
+
+func (s *CombatSuperiorityTestSuite) TestExhausted() {
+	s.Require().NoError(s.fighter.GetResource(resources.SuperiorityDice).Use(4))
+
+	err := s.feature.CanActivate(s.ctx, s.fighter, features.FeatureInput{
+		Bus:      s.bus,
+		Action:   maneuvers.Riposte,
+		TargetID: "ogre-1",
+	})
+	s.Require().Error(err)
+	s.Equal(rpgerr.CodeResourceExhausted, rpgerr.GetCode(err))
+}
+
+func (s *CombatSuperiorityTestSuite) TestFactoryLimitsManeuversKnown() {
+	_, err := features.CreateFromRef(&features.CreateFromRefInput{
+		Ref:         refs.Features.CombatSuperiority().String(),
+		Config:      json.RawMessage(`{"level": 3, "maneuvers": ["trip_attack", "riposte", "precision_attack", "menacing"]}`),
+		CharacterID: "fighter-1",
+	})
+	s.Require().Error(err)
+}
+
+func (s *CombatSuperiorityTestSuite) TestRoundTrip() {
+	data, err := s.feature.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := features.LoadJSON(data)
+	s.Require().NoError(err)
+
+	superiority, ok := loaded.(*features.CombatSuperiority)
+	s.Require().True(ok)
+	s.Equal([]maneuvers.Maneuver{maneuvers.TripAttack, maneuvers.Riposte}, superiority.Maneuvers())
+}
@@ -0,0 +1,168 @@
+// Package features provides D&D 5e class features implementation
+package features
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/checks"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/conditions"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
+)
+
+// Cunning Action choices
+const (
+	CunningActionDash      = "dash"
+	CunningActionDisengage = "disengage"
+	CunningActionHide      = "hide"
+)
+
+// CunningAction represents the rogue's Cunning Action feature (rogue level 2).
+// It implements core.Action[FeatureInput] for activation.
+// The rogue takes the Dash, Disengage, or Hide action as a bonus action. It has no resource cost.
+type CunningAction struct {
+	id          string
+	name        string
+	characterID string // Character this feature belongs to
+}
+
+// CunningActionData is the JSON structure for persisting Cunning Action state
+type CunningActionData struct {
+	Ref         *core.Ref `json:"ref"`
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	CharacterID string    `json:"character_id"`
+}
+
+// Ref returns the unique ref for the Cunning Action feature.
+func (c *CunningAction) Ref() *core.Ref { return refs.Features.CunningAction() }
+
+// Name returns the display name for the Cunning Action feature.
+func (c *CunningAction) Name() string { return c.name }
+
+// GetID implements core.Entity
+func (c *CunningAction) GetID() string {
+	return c.id
+}
+
+// GetType implements core.Entity
+func (c *CunningAction) GetType() core.EntityType {
+	return EntityTypeFeature
+}
+
+// CanActivate implements core.Action[FeatureInput]
+func (c *CunningAction) CanActivate(_ context.Context, _ core.Entity, input FeatureInput) error {
+	switch input.Action {
+	case CunningActionDash:
+		if input.ActionEconomy == nil {
+			return rpgerr.New(rpgerr.CodeInvalidArgument, "action economy required to dash")
+		}
+	case CunningActionDisengage, CunningActionHide:
+		if input.Bus == nil {
+			return rpgerr.Newf(rpgerr.CodeInvalidArgument, "event bus required to %s", input.Action)
+		}
+	default:
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument,
+			"invalid action: %s (must be 'dash', 'disengage', or 'hide')", input.Action)
+	}
+
+	return nil
+}
+
+// Activate implements core.Action[FeatureInput]
+func (c *CunningAction) Activate(ctx context.Context, owner core.Entity, input FeatureInput) error {
+	if err := c.CanActivate(ctx, owner, input); err != nil {
+		return err
+	}
+
+	switch input.Action {
+	case CunningActionDash:
+		// Same as the Dash action: gain movement equal to speed
+		input.ActionEconomy.AddMovement(input.Speed)
+	case CunningActionDisengage:
+		condition := conditions.NewDisengagingCondition(owner.GetID())
+		if err := condition.Apply(ctx, input.Bus); err != nil {
+			return rpgerr.Wrapf(err, "failed to apply disengaging condition")
+		}
+	case CunningActionHide:
+		// Same as the Hide action: make the Stealth check and report its total
+		modifier := 0
+		if s, ok := owner.(interface{ GetSkillModifier(skills.Skill) int }); ok {
+			modifier = s.GetSkillModifier(skills.Stealth)
+		}
+		check, err := checks.MakeAbilityCheck(ctx, &checks.AbilityCheckInput{
+			Roller:    input.Roller,
+			EventBus:  input.Bus,
+			CheckerID: owner.GetID(),
+			Cause:     dnd5eEvents.CheckCause{EffectRef: refs.Features.CunningAction()},
+			Skill:     skills.Stealth,
+			Modifier:  modifier,
+		})
+		if err != nil {
+			return rpgerr.Wrapf(err, "failed to make stealth check")
+		}
+		err = dnd5eEvents.HideActivatedTopic.On(input.Bus).Publish(ctx, dnd5eEvents.HideActivatedEvent{
+			CharacterID:  owner.GetID(),
+			StealthTotal: check.Total,
+		})
+		if err != nil {
+			return rpgerr.Wrapf(err, "failed to publish hide activated event")
+		}
+	}
+
+	if input.Bus != nil {
+		topic := dnd5eEvents.CunningActionActivatedTopic.On(input.Bus)
+		err := topic.Publish(ctx, dnd5eEvents.CunningActionActivatedEvent{
+			CharacterID: owner.GetID(),
+			Action:      input.Action,
+			Source:      refs.Features.CunningAction().ID,
+		})
+		if err != nil {
+			return rpgerr.Wrapf(err, "failed to publish cunning action event")
+		}
+	}
+
+	return nil
+}
+
+// loadJSON loads Cunning Action state from JSON
+func (c *CunningAction) loadJSON(data json.RawMessage) error {
+	var cunningData CunningActionData
+	if err := json.Unmarshal(data, &cunningData); err != nil {
+		return fmt.Errorf("failed to unmarshal cunning action data: %w", err)
+	}
+
+	c.id = cunningData.ID
+	c.name = cunningData.Name
+	c.characterID = cunningData.CharacterID
+
+	return nil
+}
+
+// ToJSON converts Cunning Action to JSON for persistence
+func (c *CunningAction) ToJSON() (json.RawMessage, error) {
+	data := CunningActionData{
+		Ref:         refs.Features.CunningAction(),
+		ID:          c.id,
+		Name:        c.name,
+		CharacterID: c.characterID,
+	}
+
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal cunning action data: %w", err)
+	}
+
+	return bytes, nil
+}
+
+// ActionType returns the action economy cost to activate cunning action (bonus action)
+func (c *CunningAction) ActionType() combat.ActionType {
+	return combat.ActionBonus
+}
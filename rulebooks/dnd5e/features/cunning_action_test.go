@@ -0,0 +1,151 @@
+package features_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	coreCombat "github.com/KirkDiggler/rpg-toolkit/core/combat"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/features"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+type CunningActionTestSuite struct {
+	suite.Suite
+	ctx     context.Context
+	bus     events.EventBus
+	rogue   *mockResourceAccessor
+	feature features.Feature
+	events  []dnd5eEvents.CunningActionActivatedEvent
+}
+
+func TestCunningActionTestSuite(t *testing.T) {
+	suite.Run(t, new(CunningActionTestSuite))
+}
+
+func (s *CunningActionTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.rogue = &mockResourceAccessor{id: "test-rogue"}
+	s.events = nil
+
+	output, err := features.CreateFromRef(&features.CreateFromRefInput{
+		Ref:         refs.Features.CunningAction().String(),
+		Config:      json.RawMessage(`{}`),
+		CharacterID: s.rogue.id,
+	})
+	s.Require().NoError(err)
+	s.feature = output.Feature
+
+	_, err = dnd5eEvents.CunningActionActivatedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, event dnd5eEvents.CunningActionActivatedEvent) error {
+			s.events = append(s.events, event)
+			return nil
+		})
+	s.Require().NoError(err)
+}
+
+// isOAPrevented drives a MovementChain for the rogue and reports whether
+// opportunity attacks are prevented
+func (s *CunningActionTestSuite) isOAPrevented() bool {
+	chainBuilder := events.NewStagedChain[*dnd5eEvents.MovementChainEvent](combat.ModifierStages)
+	event := &dnd5eEvents.MovementChainEvent{
+		EntityID:     s.rogue.GetID(),
+		FromPosition: dnd5eEvents.Position{X: 0, Y: 0},
+		ToPosition:   dnd5eEvents.Position{X: 1, Y: 0},
+	}
+
+	modifiedChain, err := dnd5eEvents.MovementChain.On(s.bus).PublishWithChain(s.ctx, event, chainBuilder)
+	s.Require().NoError(err)
+
+	finalEvent, err := modifiedChain.Execute(s.ctx, event)
+	s.Require().NoError(err)
+	return finalEvent.IsOAPrevented()
+}
+
+func (s *CunningActionTestSuite) TestIsBonusAction() {
+	action, ok := s.feature.(*features.CunningAction)
+	s.Require().True(ok)
+	s.Equal("Cunning Action", action.Name())
+	s.Equal(coreCombat.ActionBonus, action.ActionType())
+}
+
+func (s *CunningActionTestSuite) TestDash() {
+	economy := combat.NewActionEconomy()
+	economy.SetMovement(30)
+
+	err := s.feature.Activate(s.ctx, s.rogue, features.FeatureInput{
+		Bus:           s.bus,
+		ActionEconomy: economy,
+		Action:        features.CunningActionDash,
+		Speed:         30,
+	})
+	s.Require().NoError(err)
+
+	s.Equal(60, economy.MovementRemaining)
+	s.False(s.isOAPrevented(), "dash doesn't prevent opportunity attacks")
+	s.Require().Len(s.events, 1)
+	s.Equal(features.CunningActionDash, s.events[0].Action)
+}
+
+func (s *CunningActionTestSuite) TestDisengage() {
+	err := s.feature.Activate(s.ctx, s.rogue, features.FeatureInput{
+		Bus:    s.bus,
+		Action: features.CunningActionDisengage,
+	})
+	s.Require().NoError(err)
+
+	s.True(s.isOAPrevented())
+	s.Require().Len(s.events, 1)
+	s.Equal(dnd5eEvents.CunningActionActivatedEvent{
+		CharacterID: "test-rogue",
+		Action:      features.CunningActionDisengage,
+		Source:      refs.Features.CunningAction().ID,
+	}, s.events[0])
+}
+
+func (s *CunningActionTestSuite) TestHide() {
+	var hidden []string
+	_, err := dnd5eEvents.HideActivatedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, event dnd5eEvents.HideActivatedEvent) error {
+			hidden = append(hidden, event.CharacterID)
+			return nil
+		})
+	s.Require().NoError(err)
+
+	err = s.feature.Activate(s.ctx, s.rogue, features.FeatureInput{
+		Bus:    s.bus,
+		Action: features.CunningActionHide,
+	})
+	s.Require().NoError(err)
+
+	s.Equal([]string{"test-rogue"}, hidden)
+	s.Len(s.events, 1)
+}
+
+func (s *CunningActionTestSuite) TestInvalidInput() {
+	s.Error(s.feature.Activate(s.ctx, s.rogue, features.FeatureInput{Bus: s.bus, Action: "dodge"}))
+	s.Error(s.feature.Activate(s.ctx, s.rogue, features.FeatureInput{Bus: s.bus}), "an action is required")
+	s.Error(s.feature.Activate(s.ctx, s.rogue, features.FeatureInput{
+		Bus:    s.bus,
+		Action: features.CunningActionDash,
+	}), "dash needs the action economy")
+	s.Error(s.feature.Activate(s.ctx, s.rogue, features.FeatureInput{
+		Action: features.CunningActionHide,
+	}), "hide needs the bus")
+	s.Empty(s.events)
+}
+
+func (s *CunningActionTestSuite) TestRoundTrip() {
+	data, err := s.feature.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := features.LoadJSON(data)
+	s.Require().NoError(err)
+	s.Equal(s.feature, loaded)
+}
@@ -0,0 +1,190 @@
+// Package features provides D&D 5e class features implementation
+package features
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	dnd5eCombat "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/conditions"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+const (
+	// DivineSmiteBaseDice is the d8s a 1st-level slot deals
+	DivineSmiteBaseDice = 2
+	// DivineSmiteMaxDice is the most d8s any slot deals before the undead/fiend bonus
+	DivineSmiteMaxDice = 5
+)
+
+// DivineSmite represents the paladin's Divine Smite feature (paladin level 2).
+// It implements core.Action[FeatureInput] for activation.
+// When the paladin hits with a melee weapon attack, they can expend a spell slot
+// to deal extra radiant damage: 2d8 for a 1st-level slot plus 1d8 per slot level
+// above 1st (maximum 5d8), and 1d8 more against an undead or fiend.
+//
+// Activate it after combat.ResolveAttackHit reports a hit and before
+// combat.ApplyAttackOutcome, with the hit creature as TargetID. The slot is spent
+// at once and a DivineSmiteCondition adds the dice to the damage chain.
+type DivineSmite struct {
+	id          string
+	name        string
+	characterID string // Character this feature belongs to
+}
+
+// DivineSmiteData is the JSON structure for persisting Divine Smite state
+type DivineSmiteData struct {
+	Ref         *core.Ref `json:"ref"`
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	CharacterID string    `json:"character_id"`
+}
+
+// DivineSmiteDice returns the d8s a smite with the given slot level deals
+func DivineSmiteDice(slotLevel int, undeadOrFiend bool) int {
+	count := min(DivineSmiteBaseDice+slotLevel-1, DivineSmiteMaxDice)
+	if undeadOrFiend {
+		count++
+	}
+	return count
+}
+
+// Ref returns the unique ref for the Divine Smite feature.
+func (d *DivineSmite) Ref() *core.Ref { return refs.Features.DivineSmite() }
+
+// Name returns the display name for the Divine Smite feature.
+func (d *DivineSmite) Name() string { return d.name }
+
+// GetID implements core.Entity
+func (d *DivineSmite) GetID() string {
+	return d.id
+}
+
+// GetType implements core.Entity
+func (d *DivineSmite) GetType() core.EntityType {
+	return EntityTypeFeature
+}
+
+// CanActivate implements core.Action[FeatureInput]
+func (d *DivineSmite) CanActivate(_ context.Context, owner core.Entity, input FeatureInput) error {
+	if input.TargetID == "" {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "divine smite requires the target that was hit")
+	}
+
+	if input.Bus == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "event bus required for divine smite")
+	}
+
+	caster, ok := owner.(spellcaster)
+	if !ok || caster.Spellcasting() == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "owner has no spellcasting")
+	}
+
+	casting := caster.Spellcasting()
+	level := input.SlotLevel
+	if level == 0 {
+		level = casting.LowestSlot(1)
+	}
+	if level == 0 || casting.SlotsRemaining(level) <= 0 {
+		return rpgerr.New(rpgerr.CodeResourceExhausted, "no spell slot remaining for divine smite")
+	}
+
+	return nil
+}
+
+// Activate implements core.Action[FeatureInput]
+func (d *DivineSmite) Activate(ctx context.Context, owner core.Entity, input FeatureInput) error {
+	if err := d.CanActivate(ctx, owner, input); err != nil {
+		return err
+	}
+
+	casting := owner.(spellcaster).Spellcasting()
+	level := input.SlotLevel
+	if level == 0 {
+		level = casting.LowestSlot(1)
+	}
+
+	if err := casting.ExpendSlot(level); err != nil {
+		return rpgerr.Wrapf(err, "failed to expend spell slot for divine smite")
+	}
+
+	smite := conditions.NewDivineSmiteCondition(conditions.DivineSmiteInput{
+		CharacterID: owner.GetID(),
+		TargetID:    input.TargetID,
+		SlotLevel:   level,
+		DamageDice:  DivineSmiteDice(level, isUndeadOrFiend(ctx, input.TargetID)),
+		Roller:      input.Roller,
+	})
+
+	topic := dnd5eEvents.ConditionAppliedTopic.On(input.Bus)
+	err := topic.Publish(ctx, dnd5eEvents.ConditionAppliedEvent{
+		Target:    owner,
+		Type:      dnd5eEvents.ConditionDivineSmite,
+		Source:    dnd5eEvents.ConditionSourceFeature,
+		Condition: smite,
+	})
+	if err != nil {
+		// Give the slot back when the smite never lands on the damage chain
+		_ = casting.RecoverSlots([]int{level})
+		return rpgerr.Wrapf(err, "failed to publish divine smite condition")
+	}
+
+	return nil
+}
+
+// isUndeadOrFiend returns true when the target is an undead or fiend.
+// Targets that can't be looked up get no bonus die.
+func isUndeadOrFiend(ctx context.Context, targetID string) bool {
+	target, err := dnd5eCombat.GetCombatantFromContext(ctx, targetID)
+	if err != nil {
+		return false
+	}
+	typed, ok := target.(creatureTyper)
+	if !ok {
+		return false
+	}
+	return typed.CreatureType() == monster.CreatureTypeUndead || typed.CreatureType() == monster.CreatureTypeFiend
+}
+
+// loadJSON loads Divine Smite state from JSON
+func (d *DivineSmite) loadJSON(data json.RawMessage) error {
+	var smiteData DivineSmiteData
+	if err := json.Unmarshal(data, &smiteData); err != nil {
+		return fmt.Errorf("failed to unmarshal divine smite data: %w", err)
+	}
+
+	d.id = smiteData.ID
+	d.name = smiteData.Name
+	d.characterID = smiteData.CharacterID
+
+	return nil
+}
+
+// ToJSON converts Divine Smite to JSON for persistence
+func (d *DivineSmite) ToJSON() (json.RawMessage, error) {
+	data := DivineSmiteData{
+		Ref:         refs.Features.DivineSmite(),
+		ID:          d.id,
+		Name:        d.name,
+		CharacterID: d.characterID,
+	}
+
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal divine smite data: %w", err)
+	}
+
+	return bytes, nil
+}
+
+// ActionType returns the action economy cost to activate divine smite.
+// It is part of the attack that hit, so it costs nothing.
+func (d *DivineSmite) ActionType() combat.ActionType {
+	return combat.ActionFree
+}
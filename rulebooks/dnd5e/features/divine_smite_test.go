@@ -0,0 +1,144 @@
+package features_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	coreCombat "github.com/KirkDiggler/rpg-toolkit/core/combat"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/conditions"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/features"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster/monsters"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/spells"
+)
+
+type DivineSmiteTestSuite struct {
+	suite.Suite
+	ctx     context.Context
+	bus     events.EventBus
+	paladin *casterOwner
+	feature features.Feature
+	smites  []*conditions.DivineSmiteCondition
+}
+
+func TestDivineSmiteTestSuite(t *testing.T) {
+	suite.Run(t, new(DivineSmiteTestSuite))
+}
+
+// SetupTest creates a level 5 paladin with two 1st-level slots and one 2nd-level slot
+func (s *DivineSmiteTestSuite) SetupTest() {
+	s.bus = events.NewEventBus()
+	s.smites = nil
+	s.ctx = combat.WithCombatantLookup(context.Background(), monsterLookup{
+		"goblin-1": monster.NewGoblin("goblin-1"),
+		"zombie-1": monsters.NewZombie("zombie-1"),
+	})
+
+	casting, err := spells.NewSpellcasting(&spells.SpellcastingData{
+		Ability: abilities.CHA,
+		SaveDC:  13,
+		Slots:   map[int]spells.SlotData{1: {Max: 2}, 2: {Max: 1}},
+	})
+	s.Require().NoError(err)
+	s.paladin = &casterOwner{id: "paladin-1", casting: casting}
+
+	output, err := features.CreateFromRef(&features.CreateFromRefInput{
+		Ref:         refs.Features.DivineSmite().String(),
+		CharacterID: s.paladin.id,
+	})
+	s.Require().NoError(err)
+	s.feature = output.Feature
+
+	_, err = dnd5eEvents.ConditionAppliedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, event dnd5eEvents.ConditionAppliedEvent) error {
+			if smite, ok := event.Condition.(*conditions.DivineSmiteCondition); ok {
+				s.smites = append(s.smites, smite)
+			}
+			return nil
+		})
+	s.Require().NoError(err)
+}
+
+func (s *DivineSmiteTestSuite) smite(targetID string, slotLevel int) error {
+	return s.feature.Activate(s.ctx, s.paladin, features.FeatureInput{
+		Bus:       s.bus,
+		TargetID:  targetID,
+		SlotLevel: slotLevel,
+	})
+}
+
+func (s *DivineSmiteTestSuite) TestDice() {
+	testCases := []struct {
+		slotLevel     int
+		undeadOrFiend bool
+		expected      int
+	}{
+		{slotLevel: 1, expected: 2},
+		{slotLevel: 2, expected: 3},
+		{slotLevel: 4, expected: 5},
+		{slotLevel: 5, expected: 5},
+		{slotLevel: 1, undeadOrFiend: true, expected: 3},
+		{slotLevel: 9, undeadOrFiend: true, expected: 6},
+	}
+
+	for _, tc := range testCases {
+		s.Equal(tc.expected, features.DivineSmiteDice(tc.slotLevel, tc.undeadOrFiend),
+			"slot level %d, undead or fiend %v", tc.slotLevel, tc.undeadOrFiend)
+	}
+}
+
+func (s *DivineSmiteTestSuite) TestExpendsLowestSlotByDefault() {
+	s.Require().NoError(s.smite("goblin-1", 0))
+
+	s.Equal(1, s.paladin.casting.SlotsRemaining(1))
+	s.Require().Len(s.smites, 1)
+	s.Equal("paladin-1", s.smites[0].CharacterID)
+	s.Equal("goblin-1", s.smites[0].TargetID)
+	s.Equal(1, s.smites[0].SlotLevel)
+	s.Equal(2, s.smites[0].DamageDice)
+}
+
+func (s *DivineSmiteTestSuite) TestUpcastAgainstUndead() {
+	s.Require().NoError(s.smite("zombie-1", 2))
+
+	s.Equal(0, s.paladin.casting.SlotsRemaining(2))
+	s.Require().Len(s.smites, 1)
+	s.Equal(4, s.smites[0].DamageDice, "3d8 for a 2nd-level slot plus 1d8 against undead")
+}
+
+func (s *DivineSmiteTestSuite) TestRequiresSlotAndTarget() {
+	s.Require().NoError(s.smite("goblin-1", 2))
+	err := s.smite("goblin-1", 2)
+	s.Equal(rpgerr.CodeResourceExhausted, rpgerr.GetCode(err))
+
+	err = s.smite("", 1)
+	s.Equal(rpgerr.CodeInvalidArgument, rpgerr.GetCode(err))
+
+	err = s.feature.Activate(s.ctx, &mockResourceAccessor{id: "fighter-1"}, features.FeatureInput{
+		Bus:      s.bus,
+		TargetID: "goblin-1",
+	})
+	s.Equal(rpgerr.CodeInvalidArgument, rpgerr.GetCode(err), "not a spellcaster")
+
+	s.Equal(2, s.paladin.casting.SlotsRemaining(1), "failed smites spend nothing")
+	s.Len(s.smites, 1)
+}
+
+func (s *DivineSmiteTestSuite) TestRoundTrip() {
+	s.Equal(coreCombat.ActionFree, s.feature.ActionType())
+
+	data, err := s.feature.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := features.LoadJSON(data)
+	s.Require().NoError(err)
+	s.Equal(s.feature, loaded)
+}
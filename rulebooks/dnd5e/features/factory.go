@@ -3,11 +3,14 @@ package features
 
 import (
 	"encoding/json"
+	"slices"
 
 	"github.com/KirkDiggler/rpg-toolkit/core"
 	coreResources "github.com/KirkDiggler/rpg-toolkit/core/resources"
 	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/maneuvers"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/races"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
 )
 
@@ -79,6 +82,24 @@ func CreateFromRef(input *CreateFromRefInput) (*CreateFromRefOutput, error) {
 		feature, err = createRecklessAttack(input.Config, input.CharacterID)
 	case refs.Features.DeflectMissiles().ID:
 		feature, err = createDeflectMissiles(input.Config, input.CharacterID)
+	case refs.Features.CunningAction().ID:
+		feature, err = createCunningAction(input.Config, input.CharacterID)
+	case refs.Features.TurnUndead().ID:
+		feature, err = createTurnUndead(input.Config, input.CharacterID)
+	case refs.Features.ArcaneRecovery().ID:
+		feature, err = createArcaneRecovery(input.Config, input.CharacterID)
+	case refs.Features.DivineSmite().ID:
+		feature, err = createDivineSmite(input.Config, input.CharacterID)
+	case refs.Features.LayOnHands().ID:
+		feature, err = createLayOnHands(input.Config, input.CharacterID)
+	case refs.Features.WildShape().ID:
+		feature, err = createWildShape(input.Config, input.CharacterID)
+	case refs.Features.BardicInspiration().ID:
+		feature, err = createBardicInspiration(input.Config, input.CharacterID)
+	case refs.Features.CombatSuperiority().ID:
+		feature, err = createCombatSuperiority(input.Config, input.CharacterID)
+	case refs.Features.BreathWeapon().ID:
+		feature, err = createBreathWeapon(input.Config, input.CharacterID)
 	default:
 		return nil, rpgerr.Newf(rpgerr.CodeInvalidArgument, "unknown feature: %s", ref.ID)
 	}
@@ -321,3 +342,283 @@ func createDeflectMissiles(config json.RawMessage, characterID string) (*Deflect
 		dexModifier: dexModifier,
 	}, nil
 }
+
+// cunningActionConfig is the config structure for cunning action feature
+type cunningActionConfig struct {
+	// Cunning Action has no resource cost or config
+}
+
+// createCunningAction creates a cunning action feature from config
+func createCunningAction(config json.RawMessage, characterID string) (*CunningAction, error) {
+	var cfg cunningActionConfig
+	if len(config) > 0 {
+		if err := json.Unmarshal(config, &cfg); err != nil {
+			return nil, rpgerr.Wrap(err, "failed to parse cunning action config")
+		}
+	}
+
+	return &CunningAction{
+		id:          refs.Features.CunningAction().ID,
+		name:        "Cunning Action",
+		characterID: characterID,
+	}, nil
+}
+
+// turnUndeadConfig is the config structure for turn undead feature
+type turnUndeadConfig struct {
+	SaveDC int `json:"save_dc"` // Cleric spell save DC
+}
+
+// createTurnUndead creates a turn undead feature from config.
+// Note: Channel Divinity uses are registered on the Character, not the feature.
+func createTurnUndead(config json.RawMessage, characterID string) (*TurnUndead, error) {
+	var cfg turnUndeadConfig
+	if len(config) > 0 {
+		if err := json.Unmarshal(config, &cfg); err != nil {
+			return nil, rpgerr.Wrap(err, "failed to parse turn undead config")
+		}
+	}
+
+	if cfg.SaveDC <= 0 {
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "turn undead requires a save_dc")
+	}
+
+	return &TurnUndead{
+		id:          refs.Features.TurnUndead().ID,
+		name:        "Turn Undead",
+		characterID: characterID,
+		saveDC:      cfg.SaveDC,
+	}, nil
+}
+
+// arcaneRecoveryConfig is the config structure for arcane recovery feature
+type arcaneRecoveryConfig struct {
+	Level int `json:"level"` // Wizard level (for the recovery budget)
+}
+
+// createArcaneRecovery creates an arcane recovery feature from config
+func createArcaneRecovery(config json.RawMessage, characterID string) (*ArcaneRecovery, error) {
+	var cfg arcaneRecoveryConfig
+	if len(config) > 0 {
+		if err := json.Unmarshal(config, &cfg); err != nil {
+			return nil, rpgerr.Wrap(err, "failed to parse arcane recovery config")
+		}
+	}
+
+	// Default level to 1 if not specified
+	level := cfg.Level
+	if level == 0 {
+		level = 1
+	}
+
+	// Once per day: restores on long rest
+	resource := combat.NewRecoverableResource(combat.RecoverableResourceConfig{
+		ID:          refs.Features.ArcaneRecovery().ID,
+		Maximum:     1,
+		CharacterID: characterID,
+		ResetType:   coreResources.ResetLongRest,
+	})
+
+	return &ArcaneRecovery{
+		id:          refs.Features.ArcaneRecovery().ID,
+		name:        "Arcane Recovery",
+		level:       level,
+		characterID: characterID,
+		resource:    resource,
+	}, nil
+}
+
+// divineSmiteConfig is the config structure for divine smite feature
+type divineSmiteConfig struct {
+	// Divine Smite doesn't have its own uses - it expends the character's spell slots
+}
+
+// createDivineSmite creates a divine smite feature from config
+func createDivineSmite(config json.RawMessage, characterID string) (*DivineSmite, error) {
+	var cfg divineSmiteConfig
+	if len(config) > 0 {
+		if err := json.Unmarshal(config, &cfg); err != nil {
+			return nil, rpgerr.Wrap(err, "failed to parse divine smite config")
+		}
+	}
+
+	return &DivineSmite{
+		id:          refs.Features.DivineSmite().ID,
+		name:        "Divine Smite",
+		characterID: characterID,
+	}, nil
+}
+
+// layOnHandsConfig is the config structure for lay on hands feature
+type layOnHandsConfig struct {
+	// Lay on Hands doesn't have its own uses - it spends the character's lay_on_hands pool
+}
+
+// createLayOnHands creates a lay on hands feature from config.
+// Note: The healing pool (lay_on_hands) is registered on the Character, not the feature.
+func createLayOnHands(config json.RawMessage, characterID string) (*LayOnHands, error) {
+	var cfg layOnHandsConfig
+	if len(config) > 0 {
+		if err := json.Unmarshal(config, &cfg); err != nil {
+			return nil, rpgerr.Wrap(err, "failed to parse lay on hands config")
+		}
+	}
+
+	return &LayOnHands{
+		id:          refs.Features.LayOnHands().ID,
+		name:        "Lay on Hands",
+		characterID: characterID,
+	}, nil
+}
+
+// wildShapeConfig is the config structure for wild shape feature
+type wildShapeConfig struct {
+	Level int `json:"level"` // Druid level (for the swim and fly form limits)
+}
+
+// createWildShape creates a wild shape feature from config.
+// Note: The uses (wild_shape) are registered on the Character, not the feature.
+func createWildShape(config json.RawMessage, characterID string) (*WildShape, error) {
+	var cfg wildShapeConfig
+	if len(config) > 0 {
+		if err := json.Unmarshal(config, &cfg); err != nil {
+			return nil, rpgerr.Wrap(err, "failed to parse wild shape config")
+		}
+	}
+
+	// Wild Shape is gained at druid level 2
+	level := cfg.Level
+	if level == 0 {
+		level = 2
+	}
+
+	return &WildShape{
+		id:          refs.Features.WildShape().ID,
+		name:        "Wild Shape",
+		level:       level,
+		characterID: characterID,
+	}, nil
+}
+
+// bardicInspirationConfig is the config structure for bardic inspiration feature
+type bardicInspirationConfig struct {
+	Level int `json:"level"` // Bard level (for the inspiration die size)
+}
+
+// createBardicInspiration creates a bardic inspiration feature from config.
+// Note: The uses (bardic_inspiration) are registered on the Character, not the feature.
+func createBardicInspiration(config json.RawMessage, characterID string) (*BardicInspiration, error) {
+	var cfg bardicInspirationConfig
+	if len(config) > 0 {
+		if err := json.Unmarshal(config, &cfg); err != nil {
+			return nil, rpgerr.Wrap(err, "failed to parse bardic inspiration config")
+		}
+	}
+
+	// Default level to 1 if not specified
+	level := cfg.Level
+	if level == 0 {
+		level = 1
+	}
+
+	return &BardicInspiration{
+		id:          refs.Features.BardicInspiration().ID,
+		name:        "Bardic Inspiration",
+		level:       level,
+		characterID: characterID,
+	}, nil
+}
+
+// combatSuperiorityConfig is the config structure for combat superiority feature
+type combatSuperiorityConfig struct {
+	Level     int                  `json:"level"`     // Fighter level (for the superiority die size)
+	SaveDC    int                  `json:"save_dc"`   // Maneuver save DC (required for Trip Attack)
+	Maneuvers []maneuvers.Maneuver `json:"maneuvers"` // Known maneuvers (default: all)
+}
+
+// createCombatSuperiority creates a combat superiority feature from config.
+// Note: The superiority dice (superiority_dice) are registered on the Character, not the feature.
+func createCombatSuperiority(config json.RawMessage, characterID string) (*CombatSuperiority, error) {
+	var cfg combatSuperiorityConfig
+	if len(config) > 0 {
+		if err := json.Unmarshal(config, &cfg); err != nil {
+			return nil, rpgerr.Wrap(err, "failed to parse combat superiority config")
+		}
+	}
+
+	// Combat Superiority is gained at fighter level 3
+	level := cfg.Level
+	if level == 0 {
+		level = 3
+	}
+
+	known := cfg.Maneuvers
+	if len(known) == 0 {
+		known = maneuvers.All()
+	}
+	if len(known) > maneuvers.KnownCount(level) {
+		return nil, rpgerr.Newf(rpgerr.CodeInvalidArgument,
+			"a level %d battle master knows %d maneuvers, got %d", level, maneuvers.KnownCount(level), len(known))
+	}
+	for _, maneuver := range known {
+		if !slices.Contains(maneuvers.All(), maneuver) {
+			return nil, rpgerr.Newf(rpgerr.CodeInvalidArgument, "unknown maneuver: %s", maneuver)
+		}
+	}
+
+	return &CombatSuperiority{
+		id:          refs.Features.CombatSuperiority().ID,
+		name:        "Combat Superiority",
+		level:       level,
+		saveDC:      cfg.SaveDC,
+		known:       known,
+		characterID: characterID,
+	}, nil
+}
+
+// breathWeaponConfig is the config structure for breath weapon feature
+type breathWeaponConfig struct {
+	Ancestry races.DraconicAncestry `json:"ancestry"` // Dragonborn's draconic ancestry
+	SaveDC   int                    `json:"save_dc"`  // 8 + CON modifier + proficiency bonus
+	Level    int                    `json:"level"`    // Character level (for damage dice)
+}
+
+// createBreathWeapon creates a breath weapon feature from config
+func createBreathWeapon(config json.RawMessage, characterID string) (*BreathWeapon, error) {
+	var cfg breathWeaponConfig
+	if len(config) > 0 {
+		if err := json.Unmarshal(config, &cfg); err != nil {
+			return nil, rpgerr.Wrap(err, "failed to parse breath weapon config")
+		}
+	}
+
+	if races.GetDraconicAncestry(cfg.Ancestry) == nil {
+		return nil, rpgerr.Newf(rpgerr.CodeInvalidArgument, "breath weapon requires a draconic ancestry, got %q", cfg.Ancestry)
+	}
+	if cfg.SaveDC <= 0 {
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "breath weapon requires a save_dc")
+	}
+
+	level := cfg.Level
+	if level == 0 {
+		level = 1
+	}
+
+	// One use, restored on a short or long rest
+	resource := combat.NewRecoverableResource(combat.RecoverableResourceConfig{
+		ID:          refs.Features.BreathWeapon().ID,
+		Maximum:     1,
+		CharacterID: characterID,
+		ResetType:   coreResources.ResetShortRest,
+	})
+
+	return &BreathWeapon{
+		id:          refs.Features.BreathWeapon().ID,
+		name:        "Breath Weapon",
+		level:       level,
+		characterID: characterID,
+		ancestry:    cfg.Ancestry,
+		saveDC:      cfg.SaveDC,
+		resource:    resource,
+	}, nil
+}
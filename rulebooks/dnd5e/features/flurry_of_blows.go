@@ -137,6 +137,11 @@ func (f *FlurryOfBlows) Activate(ctx context.Context, owner core.Entity, input F
 		return rpgerr.Wrapf(err, "failed to use ki for flurry of blows")
 	}
 
+	// Grant the two strikes as action economy capacity when the caller tracks it
+	if input.ActionEconomy != nil {
+		input.ActionEconomy.SetFlurryStrikes(2)
+	}
+
 	return nil
 }
 
@@ -235,6 +235,18 @@ func (s *FlurryOfBlowsTestSuite) TestActivate_GrantsFlurryStrikeActions() {
 	s.Assert().Equal("test-monk-flurry-strike-2", grantedActions[1].GetID())
 }
 
+func (s *FlurryOfBlowsTestSuite) TestActivate_SetsFlurryStrikesOnActionEconomy() {
+	economy := combat.NewActionEconomy()
+
+	err := s.feature.Activate(s.ctx, s.character, features.FeatureInput{
+		Bus:           s.bus,
+		ActionEconomy: economy,
+	})
+
+	s.Require().NoError(err)
+	s.Equal(2, economy.FlurryStrikesRemaining)
+}
+
 func (s *FlurryOfBlowsTestSuite) TestActivate_FailsWhenNoKi() {
 	// Arrange - consume all Ki
 	ki := s.character.GetResource(resources.Ki)
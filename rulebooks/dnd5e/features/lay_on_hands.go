@@ -0,0 +1,199 @@
+// Package features provides D&D 5e class features implementation
+package features
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/combat"
+	coreResources "github.com/KirkDiggler/rpg-toolkit/core/resources"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	dnd5eCombat "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/resources"
+)
+
+// Lay on Hands options, passed as FeatureInput.Action
+const (
+	LayOnHandsHeal        = "heal"         // Restore hit points (the default)
+	LayOnHandsCureDisease = "cure_disease" // End one disease
+	LayOnHandsCurePoison  = "cure_poison"  // Neutralize one poison
+)
+
+// LayOnHandsCureCost is the pool points spent to cure a disease or poison
+const LayOnHandsCureCost = 5
+
+// LayOnHands represents the paladin's Lay on Hands feature (paladin level 1).
+// It implements core.Action[FeatureInput] for activation.
+// The paladin touches a creature and spends points from a healing pool of
+// 5 x paladin level to restore that many hit points, or spends 5 points to cure
+// one disease or neutralize one poison. It has no effect on undead or constructs.
+type LayOnHands struct {
+	id          string
+	name        string
+	characterID string // Character this feature belongs to
+}
+
+// LayOnHandsData is the JSON structure for persisting Lay on Hands state
+type LayOnHandsData struct {
+	Ref         *core.Ref `json:"ref"`
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	CharacterID string    `json:"character_id"`
+}
+
+// Ref returns the unique ref for the Lay on Hands feature.
+func (l *LayOnHands) Ref() *core.Ref { return refs.Features.LayOnHands() }
+
+// Name returns the display name for the Lay on Hands feature.
+func (l *LayOnHands) Name() string { return l.name }
+
+// GetID implements core.Entity
+func (l *LayOnHands) GetID() string {
+	return l.id
+}
+
+// GetType implements core.Entity
+func (l *LayOnHands) GetType() core.EntityType {
+	return EntityTypeFeature
+}
+
+// CanActivate implements core.Action[FeatureInput]
+func (l *LayOnHands) CanActivate(ctx context.Context, owner core.Entity, input FeatureInput) error {
+	accessor, ok := owner.(coreResources.ResourceAccessor)
+	if !ok {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "owner does not implement ResourceAccessor")
+	}
+
+	if !accessor.IsResourceAvailable(resources.LayOnHands) {
+		return rpgerr.New(rpgerr.CodeResourceExhausted, "no lay on hands points remaining")
+	}
+
+	switch input.Action {
+	case "", LayOnHandsHeal:
+		if input.Points <= 0 {
+			return rpgerr.New(rpgerr.CodeInvalidArgument, "lay on hands requires points to spend on healing")
+		}
+	case LayOnHandsCureDisease, LayOnHandsCurePoison:
+	default:
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument, "unknown lay on hands option: %s", input.Action)
+	}
+
+	if input.TargetID != "" && isUndeadOrConstruct(ctx, input.TargetID) {
+		return rpgerr.New(rpgerr.CodeInvalidTarget, "lay on hands has no effect on undead or constructs")
+	}
+
+	return nil
+}
+
+// Activate implements core.Action[FeatureInput]
+func (l *LayOnHands) Activate(ctx context.Context, owner core.Entity, input FeatureInput) error {
+	if err := l.CanActivate(ctx, owner, input); err != nil {
+		return err
+	}
+
+	targetID := input.TargetID
+	if targetID == "" {
+		targetID = owner.GetID()
+	}
+
+	cost := LayOnHandsCureCost
+	if input.Action == "" || input.Action == LayOnHandsHeal {
+		cost = input.Points
+	}
+
+	accessor, ok := owner.(coreResources.ResourceAccessor)
+	if !ok {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "owner does not implement ResourceAccessor")
+	}
+	if err := accessor.UseResource(resources.LayOnHands, cost); err != nil {
+		return rpgerr.Wrapf(err, "not enough lay on hands points for %d", cost)
+	}
+
+	if input.Bus == nil {
+		return nil
+	}
+
+	switch input.Action {
+	case LayOnHandsCureDisease, LayOnHandsCurePoison:
+		conditionRef := refs.Conditions.Poisoned()
+		if input.Action == LayOnHandsCureDisease {
+			conditionRef = refs.Conditions.Diseased()
+		}
+		topic := dnd5eEvents.ConditionRemovedTopic.On(input.Bus)
+		err := topic.Publish(ctx, dnd5eEvents.ConditionRemovedEvent{
+			CharacterID:  targetID,
+			ConditionRef: conditionRef.String(),
+			Reason:       refs.Features.LayOnHands().ID,
+		})
+		if err != nil {
+			return rpgerr.Wrapf(err, "failed to publish lay on hands cure")
+		}
+	default:
+		topic := dnd5eEvents.HealingReceivedTopic.On(input.Bus)
+		err := topic.Publish(ctx, dnd5eEvents.HealingReceivedEvent{
+			TargetID: targetID,
+			Amount:   cost,
+			Source:   refs.Features.LayOnHands().ID,
+		})
+		if err != nil {
+			return rpgerr.Wrapf(err, "failed to publish healing event")
+		}
+	}
+
+	return nil
+}
+
+// isUndeadOrConstruct returns true when the target is an undead or construct.
+// Targets that can't be looked up are assumed to be living creatures.
+func isUndeadOrConstruct(ctx context.Context, targetID string) bool {
+	target, err := dnd5eCombat.GetCombatantFromContext(ctx, targetID)
+	if err != nil {
+		return false
+	}
+	typed, ok := target.(creatureTyper)
+	if !ok {
+		return false
+	}
+	return typed.CreatureType() == monster.CreatureTypeUndead || typed.CreatureType() == monster.CreatureTypeConstruct
+}
+
+// loadJSON loads Lay on Hands state from JSON
+func (l *LayOnHands) loadJSON(data json.RawMessage) error {
+	var layOnHandsData LayOnHandsData
+	if err := json.Unmarshal(data, &layOnHandsData); err != nil {
+		return fmt.Errorf("failed to unmarshal lay on hands data: %w", err)
+	}
+
+	l.id = layOnHandsData.ID
+	l.name = layOnHandsData.Name
+	l.characterID = layOnHandsData.CharacterID
+
+	return nil
+}
+
+// ToJSON converts Lay on Hands to JSON for persistence
+func (l *LayOnHands) ToJSON() (json.RawMessage, error) {
+	data := LayOnHandsData{
+		Ref:         refs.Features.LayOnHands(),
+		ID:          l.id,
+		Name:        l.name,
+		CharacterID: l.characterID,
+	}
+
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal lay on hands data: %w", err)
+	}
+
+	return bytes, nil
+}
+
+// ActionType returns the action economy cost to activate lay on hands (action)
+func (l *LayOnHands) ActionType() combat.ActionType {
+	return combat.ActionStandard
+}
@@ -0,0 +1,140 @@
+package features_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	coreCombat "github.com/KirkDiggler/rpg-toolkit/core/combat"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/features"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster/monsters"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/resources"
+)
+
+type LayOnHandsTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	bus      events.EventBus
+	paladin  *mockResourceAccessor
+	feature  features.Feature
+	healing  []dnd5eEvents.HealingReceivedEvent
+	removals []dnd5eEvents.ConditionRemovedEvent
+}
+
+func TestLayOnHandsTestSuite(t *testing.T) {
+	suite.Run(t, new(LayOnHandsTestSuite))
+}
+
+// SetupTest creates a level 2 paladin with a 10 point pool
+func (s *LayOnHandsTestSuite) SetupTest() {
+	s.bus = events.NewEventBus()
+	s.healing = nil
+	s.removals = nil
+	s.ctx = combat.WithCombatantLookup(context.Background(), monsterLookup{
+		"zombie-1": monsters.NewZombie("zombie-1"),
+	})
+
+	s.paladin = &mockResourceAccessor{id: "paladin-1"}
+	s.paladin.AddResource(resources.LayOnHands, combat.NewRecoverableResource(combat.RecoverableResourceConfig{
+		ID:      string(resources.LayOnHands),
+		Maximum: 10,
+	}))
+
+	output, err := features.CreateFromRef(&features.CreateFromRefInput{
+		Ref:         refs.Features.LayOnHands().String(),
+		CharacterID: s.paladin.id,
+	})
+	s.Require().NoError(err)
+	s.feature = output.Feature
+
+	_, err = dnd5eEvents.HealingReceivedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, event dnd5eEvents.HealingReceivedEvent) error {
+			s.healing = append(s.healing, event)
+			return nil
+		})
+	s.Require().NoError(err)
+
+	_, err = dnd5eEvents.ConditionRemovedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, event dnd5eEvents.ConditionRemovedEvent) error {
+			s.removals = append(s.removals, event)
+			return nil
+		})
+	s.Require().NoError(err)
+}
+
+func (s *LayOnHandsTestSuite) activate(input features.FeatureInput) error {
+	input.Bus = s.bus
+	return s.feature.Activate(s.ctx, s.paladin, input)
+}
+
+func (s *LayOnHandsTestSuite) TestHealsFromPool() {
+	s.Require().NoError(s.activate(features.FeatureInput{Points: 7, TargetID: "fighter-1"}))
+	s.Require().NoError(s.activate(features.FeatureInput{Action: features.LayOnHandsHeal, Points: 2}))
+
+	s.Equal([]dnd5eEvents.HealingReceivedEvent{
+		{TargetID: "fighter-1", Amount: 7, Source: refs.Features.LayOnHands().ID},
+		{TargetID: "paladin-1", Amount: 2, Source: refs.Features.LayOnHands().ID},
+	}, s.healing)
+	s.Equal(1, s.paladin.GetResource(resources.LayOnHands).Current())
+
+	err := s.activate(features.FeatureInput{Points: 2})
+	s.Error(err, "only 1 point left")
+	s.Equal(1, s.paladin.GetResource(resources.LayOnHands).Current())
+	s.Len(s.healing, 2)
+}
+
+func (s *LayOnHandsTestSuite) TestCures() {
+	s.Require().NoError(s.activate(features.FeatureInput{Action: features.LayOnHandsCurePoison, TargetID: "rogue-1"}))
+	s.Require().NoError(s.activate(features.FeatureInput{Action: features.LayOnHandsCureDisease}))
+
+	s.Equal([]dnd5eEvents.ConditionRemovedEvent{
+		{CharacterID: "rogue-1", ConditionRef: refs.Conditions.Poisoned().String(), Reason: "lay_on_hands"},
+		{CharacterID: "paladin-1", ConditionRef: refs.Conditions.Diseased().String(), Reason: "lay_on_hands"},
+	}, s.removals)
+	s.False(s.paladin.IsResourceAvailable(resources.LayOnHands), "each cure costs 5 points")
+	s.Empty(s.healing)
+
+	err := s.activate(features.FeatureInput{Action: features.LayOnHandsCurePoison})
+	s.Equal(rpgerr.CodeResourceExhausted, rpgerr.GetCode(err))
+}
+
+func (s *LayOnHandsTestSuite) TestInvalidUses() {
+	testCases := []struct {
+		name     string
+		input    features.FeatureInput
+		expected rpgerr.Code
+	}{
+		{name: "no points", input: features.FeatureInput{}, expected: rpgerr.CodeInvalidArgument},
+		{name: "unknown option", input: features.FeatureInput{Action: "raise_dead"}, expected: rpgerr.CodeInvalidArgument},
+		{
+			name:     "undead target",
+			input:    features.FeatureInput{Points: 5, TargetID: "zombie-1"},
+			expected: rpgerr.CodeInvalidTarget,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			err := s.activate(tc.input)
+			s.Equal(tc.expected, rpgerr.GetCode(err))
+		})
+	}
+	s.Equal(10, s.paladin.GetResource(resources.LayOnHands).Current())
+}
+
+func (s *LayOnHandsTestSuite) TestRoundTrip() {
+	s.Equal(coreCombat.ActionStandard, s.feature.ActionType())
+
+	data, err := s.feature.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := features.LoadJSON(data)
+	s.Require().NoError(err)
+	s.Equal(s.feature, loaded)
+}
@@ -86,6 +86,69 @@ func LoadJSON(data json.RawMessage) (Feature, error) {
 		}
 
 		return deflectMissiles, nil
+	case refs.Features.CunningAction().ID:
+		cunningAction := &CunningAction{}
+		if err := cunningAction.loadJSON(data); err != nil {
+			return nil, fmt.Errorf("failed to load cunning action: %w", err)
+		}
+
+		return cunningAction, nil
+	case refs.Features.TurnUndead().ID:
+		turnUndead := &TurnUndead{}
+		if err := turnUndead.loadJSON(data); err != nil {
+			return nil, fmt.Errorf("failed to load turn undead: %w", err)
+		}
+
+		return turnUndead, nil
+	case refs.Features.ArcaneRecovery().ID:
+		arcaneRecovery := &ArcaneRecovery{}
+		if err := arcaneRecovery.loadJSON(data); err != nil {
+			return nil, fmt.Errorf("failed to load arcane recovery: %w", err)
+		}
+
+		return arcaneRecovery, nil
+	case refs.Features.DivineSmite().ID:
+		divineSmite := &DivineSmite{}
+		if err := divineSmite.loadJSON(data); err != nil {
+			return nil, fmt.Errorf("failed to load divine smite: %w", err)
+		}
+
+		return divineSmite, nil
+	case refs.Features.LayOnHands().ID:
+		layOnHands := &LayOnHands{}
+		if err := layOnHands.loadJSON(data); err != nil {
+			return nil, fmt.Errorf("failed to load lay on hands: %w", err)
+		}
+
+		return layOnHands, nil
+	case refs.Features.WildShape().ID:
+		wildShape := &WildShape{}
+		if err := wildShape.loadJSON(data); err != nil {
+			return nil, fmt.Errorf("failed to load wild shape: %w", err)
+		}
+
+		return wildShape, nil
+	case refs.Features.BardicInspiration().ID:
+		bardicInspiration := &BardicInspiration{}
+		if err := bardicInspiration.loadJSON(data); err != nil {
+			return nil, fmt.Errorf("failed to load bardic inspiration: %w", err)
+		}
+
+		return bardicInspiration, nil
+	case refs.Features.CombatSuperiority().ID:
+		combatSuperiority := &CombatSuperiority{}
+		if err := combatSuperiority.loadJSON(data); err != nil {
+			return nil, fmt.Errorf("failed to load combat superiority: %w", err)
+		}
+
+		return combatSuperiority, nil
+	case refs.Features.BreathWeapon().ID:
+		breathWeapon := &BreathWeapon{}
+		if err := breathWeapon.loadJSON(data); err != nil {
+			return nil, fmt.Errorf("failed to load breath weapon: %w", err)
+		}
+
+		return breathWeapon, nil
 	default:
 		return nil, fmt.Errorf("unknown feature type: %s", metadata.Ref.ID)
 	}
@@ -10,6 +10,7 @@ import (
 	"github.com/KirkDiggler/rpg-toolkit/core/combat"
 	coreResources "github.com/KirkDiggler/rpg-toolkit/core/resources"
 	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/conditions"
 	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/resources"
@@ -82,9 +83,14 @@ func (p *PatientDefense) Activate(ctx context.Context, owner core.Entity, input
 		return rpgerr.Wrapf(err, "failed to use ki for patient defense")
 	}
 
-	// Publish event granting Dodge effect (attackers have disadvantage)
-	// The game server is responsible for applying and tracking the Dodge condition
+	// Apply the Dodging condition (attackers have disadvantage, advantage on DEX saves)
+	// and publish the activation for the game server
 	if input.Bus != nil {
+		condition := conditions.NewDodgingCondition(owner.GetID())
+		if err := condition.Apply(ctx, input.Bus); err != nil {
+			return rpgerr.Wrapf(err, "failed to apply dodging condition")
+		}
+
 		topic := dnd5eEvents.PatientDefenseActivatedTopic.On(input.Bus)
 		err := topic.Publish(ctx, dnd5eEvents.PatientDefenseActivatedEvent{
 			CharacterID: owner.GetID(),
@@ -121,6 +121,22 @@ func (s *PatientDefenseTestSuite) TestActivate_PublishesEvent() {
 	s.Assert().Equal(refs.Features.PatientDefense().ID, receivedEvent.Source)
 }
 
+func (s *PatientDefenseTestSuite) TestActivate_AppliesDodgingCondition() {
+	err := s.feature.Activate(s.ctx, s.accessor, features.FeatureInput{Bus: s.bus})
+	s.Require().NoError(err)
+
+	// Attacks against the monk now have disadvantage
+	attack := dnd5eEvents.AttackChainEvent{AttackerID: "goblin-1", TargetID: s.accessor.GetID(), IsMelee: true}
+	attackChain := events.NewStagedChain[dnd5eEvents.AttackChainEvent](combat.ModifierStages)
+	modifiedChain, err := dnd5eEvents.AttackChain.On(s.bus).PublishWithChain(s.ctx, attack, attackChain)
+	s.Require().NoError(err)
+
+	result, err := modifiedChain.Execute(s.ctx, attack)
+	s.Require().NoError(err)
+	s.Require().Len(result.DisadvantageSources, 1)
+	s.Equal(refs.Conditions.Dodging(), result.DisadvantageSources[0].SourceRef)
+}
+
 func (s *PatientDefenseTestSuite) TestActivate_FailsWhenNoKi() {
 	// Arrange - consume all Ki
 	ki := s.accessor.GetResource(resources.Ki)
@@ -94,14 +94,19 @@ func (s *StepOfTheWind) Activate(ctx context.Context, owner core.Entity, input F
 		return rpgerr.Newf(rpgerr.CodeInvalidArgument, "invalid action: %s (must be 'disengage' or 'dash')", action)
 	}
 
+	// The dash branch adds the monk's speed to their movement, like the Dash
+	// action, when the caller tracks the action economy.
+	if action == "dash" && input.ActionEconomy != nil {
+		input.ActionEconomy.AddMovement(input.Speed)
+	}
+
 	if input.Bus != nil {
 		// Wave 2.11e (#666 Q1=(a)): toolkit-side rule application for the
 		// disengage branch. Applying DisengagingCondition here lets the
 		// monk's bonus-action Disengage suppress OAs end-to-end without
 		// rpg-api needing to know "Step of the Wind activated" means
-		// "apply DisengagingCondition" (boundary smell). The "dash"
-		// branch stays event-only; Dash itself doesn't suppress OAs in
-		// 5e, and the Dash action is not part of Wave 2.11e's scope.
+		// "apply DisengagingCondition" (boundary smell). Dash itself
+		// doesn't suppress OAs in 5e, so the "dash" branch applies no condition.
 		if action == "disengage" {
 			condition := conditions.NewDisengagingCondition(owner.GetID())
 			if err := condition.Apply(ctx, input.Bus); err != nil {
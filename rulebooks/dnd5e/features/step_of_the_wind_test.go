@@ -280,6 +280,20 @@ func (s *StepOfTheWindTestSuite) TestActivate_DashBranch_DoesNotApplyDisengaging
 	s.False(finalEvent.IsOAPrevented())
 }
 
+func (s *StepOfTheWindTestSuite) TestActivate_DashBranch_AddsMovement() {
+	economy := combat.NewActionEconomy()
+	economy.SetMovement(30)
+
+	err := s.feature.Activate(s.ctx, s.accessor, features.FeatureInput{
+		Bus:           s.bus,
+		ActionEconomy: economy,
+		Action:        "dash",
+		Speed:         40,
+	})
+	s.Require().NoError(err)
+	s.Equal(70, economy.MovementRemaining)
+}
+
 func (s *StepOfTheWindTestSuite) TestToJSON() {
 	// Act
 	jsonData, err := s.feature.ToJSON()
@@ -0,0 +1,206 @@
+// Package features provides D&D 5e class features implementation
+package features
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/combat"
+	coreResources "github.com/KirkDiggler/rpg-toolkit/core/resources"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	dnd5eCombat "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/conditions"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/resources"
+)
+
+// TurnUndeadRadius is the radius in feet of Turn Undead around the cleric
+const TurnUndeadRadius = 30
+
+// TurnUndead represents the cleric's Channel Divinity: Turn Undead feature (cleric level 2).
+// It implements core.Action[FeatureInput] for activation.
+// When activated, consumes 1 Channel Divinity use and each undead within 30 feet
+// makes a WIS saving throw against the cleric's spell save DC. Undead that fail
+// are Turned. Requires the room and combatant lookup in context.
+type TurnUndead struct {
+	id          string
+	name        string
+	characterID string // Character this feature belongs to
+	saveDC      int    // Cleric spell save DC (8 + proficiency + WIS modifier)
+}
+
+// TurnUndeadData is the JSON structure for persisting Turn Undead state
+type TurnUndeadData struct {
+	Ref         *core.Ref `json:"ref"`
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	CharacterID string    `json:"character_id"`
+	SaveDC      int       `json:"save_dc"`
+}
+
+// creatureTyper is implemented by combatants with a creature type (monsters)
+type creatureTyper interface {
+	CreatureType() monster.CreatureType
+}
+
+// conditionHolder is implemented by combatants that track their own conditions (monsters)
+type conditionHolder interface {
+	AddCondition(condition dnd5eEvents.ConditionBehavior)
+}
+
+// Ref returns the unique ref for the Turn Undead feature.
+func (t *TurnUndead) Ref() *core.Ref { return refs.Features.TurnUndead() }
+
+// Name returns the display name for the Turn Undead feature.
+func (t *TurnUndead) Name() string { return t.name }
+
+// GetID implements core.Entity
+func (t *TurnUndead) GetID() string {
+	return t.id
+}
+
+// GetType implements core.Entity
+func (t *TurnUndead) GetType() core.EntityType {
+	return EntityTypeFeature
+}
+
+// CanActivate implements core.Action[FeatureInput]
+func (t *TurnUndead) CanActivate(_ context.Context, owner core.Entity, input FeatureInput) error {
+	accessor, ok := owner.(coreResources.ResourceAccessor)
+	if !ok {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "owner does not implement ResourceAccessor")
+	}
+
+	if !accessor.IsResourceAvailable(resources.ChannelDivinity) {
+		return rpgerr.New(rpgerr.CodeResourceExhausted, "no channel divinity uses remaining")
+	}
+
+	if input.Bus == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "event bus required for turn undead")
+	}
+
+	return nil
+}
+
+// Activate implements core.Action[FeatureInput]
+func (t *TurnUndead) Activate(ctx context.Context, owner core.Entity, input FeatureInput) error {
+	if err := t.CanActivate(ctx, owner, input); err != nil {
+		return err
+	}
+
+	ownerID := owner.GetID()
+	origin, found := dnd5eCombat.EntityPosition(ctx, ownerID)
+	if !found {
+		return rpgerr.Newf(rpgerr.CodeNotFound, "%s is not placed in a room", ownerID)
+	}
+
+	result, err := dnd5eCombat.ResolveAreaEffect(ctx, &dnd5eCombat.AreaInput{
+		SourceID:  ownerID,
+		EffectRef: refs.Features.TurnUndead(),
+		Template:  dnd5eCombat.AreaTemplate{Shape: dnd5eCombat.AreaShapeSphere, Size: TurnUndeadRadius},
+		Origin:    origin,
+		Save: &dnd5eCombat.AreaSave{
+			Ability: abilities.WIS,
+			DC:      t.saveDC,
+			Trigger: dnd5eEvents.SaveTriggerFeature,
+		},
+		ExcludeIDs: []string{ownerID},
+		Filter:     isUndead,
+		EventBus:   input.Bus,
+		Roller:     input.Roller,
+	})
+	if err != nil {
+		return rpgerr.Wrapf(err, "failed to resolve turn undead")
+	}
+
+	// Consume the Channel Divinity use only after the saves resolve
+	accessor, ok := owner.(coreResources.ResourceAccessor)
+	if !ok {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "owner does not implement ResourceAccessor")
+	}
+	if err := accessor.UseResource(resources.ChannelDivinity, 1); err != nil {
+		return rpgerr.Wrapf(err, "failed to use channel divinity for turn undead")
+	}
+
+	for _, target := range result.Targets {
+		if target.Save.Success {
+			continue
+		}
+		if err := t.turn(ctx, input, target.TargetID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// turn applies the Turned condition to an undead creature that failed its save
+func (t *TurnUndead) turn(ctx context.Context, input FeatureInput, targetID string) error {
+	turned := conditions.NewTurnedCondition(conditions.TurnedInput{
+		CharacterID: targetID,
+		SourceID:    t.characterID,
+	})
+	if err := turned.Apply(ctx, input.Bus); err != nil {
+		return rpgerr.Wrapf(err, "failed to turn %s", targetID)
+	}
+
+	// Monsters hold their own conditions so they persist with the monster
+	target, err := dnd5eCombat.GetCombatantFromContext(ctx, targetID)
+	if err != nil {
+		return nil
+	}
+	if holder, ok := target.(conditionHolder); ok {
+		holder.AddCondition(turned)
+	}
+
+	return nil
+}
+
+// isUndead returns true for combatants whose creature type is undead
+func isUndead(combatant dnd5eCombat.Combatant) bool {
+	typed, ok := combatant.(creatureTyper)
+	return ok && typed.CreatureType() == monster.CreatureTypeUndead
+}
+
+// loadJSON loads Turn Undead state from JSON
+func (t *TurnUndead) loadJSON(data json.RawMessage) error {
+	var turnData TurnUndeadData
+	if err := json.Unmarshal(data, &turnData); err != nil {
+		return fmt.Errorf("failed to unmarshal turn undead data: %w", err)
+	}
+
+	t.id = turnData.ID
+	t.name = turnData.Name
+	t.characterID = turnData.CharacterID
+	t.saveDC = turnData.SaveDC
+
+	return nil
+}
+
+// ToJSON converts Turn Undead to JSON for persistence
+func (t *TurnUndead) ToJSON() (json.RawMessage, error) {
+	data := TurnUndeadData{
+		Ref:         refs.Features.TurnUndead(),
+		ID:          t.id,
+		Name:        t.name,
+		CharacterID: t.characterID,
+		SaveDC:      t.saveDC,
+	}
+
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal turn undead data: %w", err)
+	}
+
+	return bytes, nil
+}
+
+// ActionType returns the action economy cost to activate turn undead (action)
+func (t *TurnUndead) ActionType() combat.ActionType {
+	return combat.ActionStandard
+}
@@ -0,0 +1,159 @@
+package features_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	coreCombat "github.com/KirkDiggler/rpg-toolkit/core/combat"
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/conditions"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/features"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster/monsters"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/resources"
+	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
+)
+
+// monsterLookup resolves monsters placed in the test room
+type monsterLookup map[string]*monster.Monster
+
+func (m monsterLookup) Get(id string) (combat.Combatant, error) {
+	if found, ok := m[id]; ok {
+		return found, nil
+	}
+	return nil, rpgerr.Newf(rpgerr.CodeNotFound, "combatant %s not found", id)
+}
+
+type TurnUndeadTestSuite struct {
+	suite.Suite
+	ctrl    *gomock.Controller
+	ctx     context.Context
+	bus     events.EventBus
+	roller  *mock_dice.MockRoller
+	room    *spatial.BasicRoom
+	lookup  monsterLookup
+	cleric  *mockResourceAccessor
+	feature features.Feature
+}
+
+func TestTurnUndeadTestSuite(t *testing.T) {
+	suite.Run(t, new(TurnUndeadTestSuite))
+}
+
+func (s *TurnUndeadTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.bus = events.NewEventBus()
+	s.roller = mock_dice.NewMockRoller(s.ctrl)
+	s.lookup = monsterLookup{}
+
+	s.room = spatial.NewBasicRoom(spatial.BasicRoomConfig{
+		ID:   "crypt",
+		Type: "combat",
+		Grid: spatial.NewSquareGrid(spatial.SquareGridConfig{Width: 12, Height: 12}),
+	})
+	s.ctx = combat.WithRoom(context.Background(), s.room)
+	s.ctx = combat.WithCombatantLookup(s.ctx, s.lookup)
+
+	s.cleric = &mockResourceAccessor{id: "cleric-1"}
+	s.cleric.AddResource(resources.ChannelDivinity, combat.NewRecoverableResource(combat.RecoverableResourceConfig{
+		ID:      string(resources.ChannelDivinity),
+		Maximum: 1,
+	}))
+	s.Require().NoError(s.room.PlaceEntity(s.cleric, spatial.Position{X: 0, Y: 0}))
+
+	output, err := features.CreateFromRef(&features.CreateFromRefInput{
+		Ref:         refs.Features.TurnUndead().String(),
+		Config:      json.RawMessage(`{"save_dc": 13}`),
+		CharacterID: s.cleric.id,
+	})
+	s.Require().NoError(err)
+	s.feature = output.Feature
+}
+
+func (s *TurnUndeadTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *TurnUndeadTestSuite) place(m *monster.Monster, pos spatial.Position) *monster.Monster {
+	s.lookup[m.GetID()] = m
+	s.Require().NoError(s.room.PlaceEntity(m, pos))
+	return m
+}
+
+// turnedConditions returns the Turned conditions a monster holds
+func (s *TurnUndeadTestSuite) turnedConditions(m *monster.Monster) []*conditions.TurnedCondition {
+	var turned []*conditions.TurnedCondition
+	for _, condition := range m.GetConditions() {
+		if t, ok := condition.(*conditions.TurnedCondition); ok {
+			turned = append(turned, t)
+		}
+	}
+	return turned
+}
+
+func (s *TurnUndeadTestSuite) TestIsAction() {
+	turnUndead, ok := s.feature.(*features.TurnUndead)
+	s.Require().True(ok)
+	s.Equal("Turn Undead", turnUndead.Name())
+	s.Equal(coreCombat.ActionStandard, turnUndead.ActionType())
+}
+
+func (s *TurnUndeadTestSuite) TestTurnsUndeadThatFailTheirSave() {
+	zombie := s.place(monsters.NewZombie("zombie-1"), spatial.Position{X: 2, Y: 0})
+	skeleton := s.place(monsters.NewSkeleton("skeleton-1"), spatial.Position{X: 0, Y: 3})
+	goblin := s.place(monster.NewGoblin("goblin-1"), spatial.Position{X: 1, Y: 1})
+	distant := s.place(monsters.NewZombie("zombie-2"), spatial.Position{X: 11, Y: 11})
+
+	// Only the two undead within 30 feet save: 14 - 2 WIS fails DC 13, 14 - 1 WIS succeeds
+	s.roller.EXPECT().Roll(gomock.Any(), 20).Return(14, nil).Times(2)
+
+	err := s.feature.Activate(s.ctx, s.cleric, features.FeatureInput{Bus: s.bus, Roller: s.roller})
+	s.Require().NoError(err)
+
+	turned := s.turnedConditions(zombie)
+	s.Require().Len(turned, 1)
+	s.Equal("cleric-1", turned[0].SourceID)
+	s.True(turned[0].IsApplied())
+
+	s.Empty(s.turnedConditions(skeleton), "saved")
+	s.Empty(s.turnedConditions(goblin), "not undead")
+	s.Empty(s.turnedConditions(distant), "out of range")
+	s.False(s.cleric.IsResourceAvailable(resources.ChannelDivinity))
+}
+
+func (s *TurnUndeadTestSuite) TestRequiresChannelDivinity() {
+	s.place(monsters.NewZombie("zombie-1"), spatial.Position{X: 2, Y: 0})
+	s.Require().NoError(s.cleric.UseResource(resources.ChannelDivinity, 1))
+
+	err := s.feature.Activate(s.ctx, s.cleric, features.FeatureInput{Bus: s.bus, Roller: s.roller})
+	s.Equal(rpgerr.CodeResourceExhausted, rpgerr.GetCode(err))
+}
+
+func (s *TurnUndeadTestSuite) TestRequiresPlacement() {
+	err := s.feature.Activate(context.Background(), s.cleric, features.FeatureInput{Bus: s.bus, Roller: s.roller})
+	s.Equal(rpgerr.CodeNotFound, rpgerr.GetCode(err))
+	s.True(s.cleric.IsResourceAvailable(resources.ChannelDivinity), "nothing spent")
+}
+
+func (s *TurnUndeadTestSuite) TestFactoryAndRoundTrip() {
+	_, err := features.CreateFromRef(&features.CreateFromRefInput{
+		Ref:         refs.Features.TurnUndead().String(),
+		CharacterID: s.cleric.id,
+	})
+	s.Error(err, "save_dc is required")
+
+	data, err := s.feature.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := features.LoadJSON(data)
+	s.Require().NoError(err)
+	s.Equal(s.feature, loaded)
+}
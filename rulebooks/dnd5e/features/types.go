@@ -3,8 +3,10 @@ package features
 
 import (
 	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/dice"
 	"github.com/KirkDiggler/rpg-toolkit/events"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
 )
 
 // Type is the content type for features within the dnd5e module
@@ -26,9 +28,37 @@ type FeatureInput struct {
 	// Bus is provided by the character/owner during activation
 	Bus events.EventBus `json:"-"`
 
-	// ActionEconomy is provided for features that grant extra actions (e.g., Action Surge)
+	// ActionEconomy is provided for features that grant extra actions or capacity
+	// (e.g., Action Surge, Flurry of Blows strikes, Dash movement)
 	ActionEconomy *combat.ActionEconomy `json:"-"`
 
 	// Action is provided for features with action choices (e.g., Step of the Wind: "disengage" or "dash")
 	Action string `json:"action,omitempty"`
+
+	// Speed is the character's speed in feet, added to movement by features that Dash
+	// (e.g., Cunning Action, Step of the Wind). Requires ActionEconomy.
+	Speed int `json:"-"`
+
+	// SlotLevels is provided for features that recover spell slots (e.g., Arcane Recovery).
+	// Empty recovers the highest expended slots allowed.
+	SlotLevels []int `json:"slot_levels,omitempty"`
+
+	// SlotLevel is provided for features that expend a spell slot (e.g., Divine Smite).
+	// Zero expends the lowest available slot.
+	SlotLevel int `json:"slot_level,omitempty"`
+
+	// TargetID is provided for features aimed at another creature (e.g., Divine Smite's
+	// target, Lay on Hands' patient). Lay on Hands defaults to the owner when empty.
+	TargetID string `json:"target_id,omitempty"`
+
+	// Points is provided for features spending from a point pool (e.g., Lay on Hands healing)
+	Points int `json:"points,omitempty"`
+
+	// Form is the beast stat block for features that transform the owner (e.g., Wild Shape).
+	// Build it from a monster factory's ToData() or a loaded stat block.
+	Form *monster.Data `json:"-"`
+
+	// Roller rolls dice for features that need rolls (e.g., Turn Undead saves).
+	// If nil, a default roller is used.
+	Roller dice.Roller `json:"-"`
 }
@@ -0,0 +1,175 @@
+// Package features provides D&D 5e class features implementation
+package features
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/combat"
+	coreResources "github.com/KirkDiggler/rpg-toolkit/core/resources"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/conditions"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/resources"
+)
+
+// Wild Shape form limits by druid level
+const (
+	// WildShapeSwimLevel is the druid level at which forms may have a swimming speed
+	WildShapeSwimLevel = 4
+
+	// WildShapeFlyLevel is the druid level at which forms may have a flying speed
+	WildShapeFlyLevel = 8
+)
+
+// WildShape represents the druid's Wild Shape feature (druid level 2).
+// It implements core.Action[FeatureInput] for activation.
+// The druid spends a use (2 per short rest) to transform into a beast, given as a
+// monster stat block in FeatureInput.Form. The transformation is a WildShapedCondition:
+// the form's hit points absorb damage first, the druid keeps their mental ability
+// scores, and they can't cast spells until they revert.
+//
+// Challenge rating limits aren't enforced; stat blocks don't carry a CR.
+type WildShape struct {
+	id          string
+	name        string
+	level       int    // Druid level for the swim and fly limits
+	characterID string // Character this feature belongs to
+}
+
+// WildShapeData is the JSON structure for persisting Wild Shape state
+type WildShapeData struct {
+	Ref         *core.Ref `json:"ref"`
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Level       int       `json:"level"`
+	CharacterID string    `json:"character_id"`
+}
+
+// Ref returns the unique ref for the Wild Shape feature.
+func (w *WildShape) Ref() *core.Ref { return refs.Features.WildShape() }
+
+// Name returns the display name for the Wild Shape feature.
+func (w *WildShape) Name() string { return w.name }
+
+// GetID implements core.Entity
+func (w *WildShape) GetID() string {
+	return w.id
+}
+
+// GetType implements core.Entity
+func (w *WildShape) GetType() core.EntityType {
+	return EntityTypeFeature
+}
+
+// CanActivate implements core.Action[FeatureInput]
+func (w *WildShape) CanActivate(_ context.Context, owner core.Entity, input FeatureInput) error {
+	accessor, ok := owner.(coreResources.ResourceAccessor)
+	if !ok {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "owner does not implement ResourceAccessor")
+	}
+
+	if !accessor.IsResourceAvailable(resources.WildShape) {
+		return rpgerr.New(rpgerr.CodeResourceExhausted, "no wild shape uses remaining")
+	}
+
+	if input.Bus == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "wild shape requires an event bus")
+	}
+
+	return w.checkForm(input.Form)
+}
+
+// checkForm returns why the druid can't take the given form
+func (w *WildShape) checkForm(form *monster.Data) error {
+	if form == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "wild shape requires a form")
+	}
+	if form.CreatureType != monster.CreatureTypeBeast {
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument, "%s is not a beast", form.Name)
+	}
+	if form.Speed.Swim > 0 && w.level < WildShapeSwimLevel {
+		return rpgerr.Newf(rpgerr.CodeNotAllowed,
+			"forms with a swimming speed require druid level %d", WildShapeSwimLevel)
+	}
+	if form.Speed.Fly > 0 && w.level < WildShapeFlyLevel {
+		return rpgerr.Newf(rpgerr.CodeNotAllowed,
+			"forms with a flying speed require druid level %d", WildShapeFlyLevel)
+	}
+	return nil
+}
+
+// Activate implements core.Action[FeatureInput]
+func (w *WildShape) Activate(ctx context.Context, owner core.Entity, input FeatureInput) error {
+	if err := w.CanActivate(ctx, owner, input); err != nil {
+		return err
+	}
+
+	accessor, ok := owner.(coreResources.ResourceAccessor)
+	if !ok {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "owner does not implement ResourceAccessor")
+	}
+	if err := accessor.UseResource(resources.WildShape, 1); err != nil {
+		return rpgerr.Wrapf(err, "failed to use wild shape")
+	}
+
+	shaped := conditions.NewWildShapedCondition(conditions.WildShapedInput{
+		CharacterID: owner.GetID(),
+		Form:        input.Form,
+	})
+
+	topic := dnd5eEvents.ConditionAppliedTopic.On(input.Bus)
+	err := topic.Publish(ctx, dnd5eEvents.ConditionAppliedEvent{
+		Target:    owner,
+		Type:      dnd5eEvents.ConditionWildShaped,
+		Source:    dnd5eEvents.ConditionSourceFeature,
+		Condition: shaped,
+	})
+	if err != nil {
+		return rpgerr.Wrapf(err, "failed to publish wild shaped condition")
+	}
+
+	return nil
+}
+
+// loadJSON loads Wild Shape state from JSON
+func (w *WildShape) loadJSON(data json.RawMessage) error {
+	var wildShapeData WildShapeData
+	if err := json.Unmarshal(data, &wildShapeData); err != nil {
+		return fmt.Errorf("failed to unmarshal wild shape data: %w", err)
+	}
+
+	w.id = wildShapeData.ID
+	w.name = wildShapeData.Name
+	w.level = wildShapeData.Level
+	w.characterID = wildShapeData.CharacterID
+
+	return nil
+}
+
+// ToJSON converts Wild Shape to JSON for persistence
+func (w *WildShape) ToJSON() (json.RawMessage, error) {
+	data := WildShapeData{
+		Ref:         refs.Features.WildShape(),
+		ID:          w.id,
+		Name:        w.name,
+		Level:       w.level,
+		CharacterID: w.characterID,
+	}
+
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal wild shape data: %w", err)
+	}
+
+	return bytes, nil
+}
+
+// ActionType returns the action economy cost to activate wild shape (action)
+func (w *WildShape) ActionType() combat.ActionType {
+	return combat.ActionStandard
+}
@@ -0,0 +1,185 @@
+package features_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	coreCombat "github.com/KirkDiggler/rpg-toolkit/core/combat"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/conditions"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/features"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster/monsters"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/resources"
+)
+
+// apiGiantEagleJSON is a flying beast stat block in D&D 5e API format
+const apiGiantEagleJSON = `{
+	"index": "giant-eagle",
+	"name": "Giant Eagle",
+	"type": "beast",
+	"armor_class": 13,
+	"hit_points": 26,
+	"speed": {"walk": "10 ft.", "fly": "80 ft."},
+	"strength": 16, "dexterity": 17, "constitution": 13,
+	"intelligence": 8, "wisdom": 14, "charisma": 10,
+	"proficiency_bonus": 2,
+	"actions": []
+}`
+
+type WildShapeTestSuite struct {
+	suite.Suite
+	ctx     context.Context
+	bus     events.EventBus
+	druid   *mockResourceAccessor
+	applied []dnd5eEvents.ConditionAppliedEvent
+}
+
+func TestWildShapeTestSuite(t *testing.T) {
+	suite.Run(t, new(WildShapeTestSuite))
+}
+
+// SetupTest creates a druid with 2 Wild Shape uses
+func (s *WildShapeTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.applied = nil
+
+	s.druid = &mockResourceAccessor{id: "druid-1"}
+	s.druid.AddResource(resources.WildShape, combat.NewRecoverableResource(combat.RecoverableResourceConfig{
+		ID:      string(resources.WildShape),
+		Maximum: 2,
+	}))
+
+	_, err := dnd5eEvents.ConditionAppliedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, event dnd5eEvents.ConditionAppliedEvent) error {
+			s.applied = append(s.applied, event)
+			return nil
+		})
+	s.Require().NoError(err)
+}
+
+// wildShape creates the feature for a druid of the given level
+func (s *WildShapeTestSuite) wildShape(level int) features.Feature {
+	output, err := features.CreateFromRef(&features.CreateFromRefInput{
+		Ref:         refs.Features.WildShape().String(),
+		Config:      json.RawMessage(fmt.Sprintf(`{"level": %d}`, level)),
+		CharacterID: s.druid.id,
+	})
+	s.Require().NoError(err)
+	return output.Feature
+}
+
+func (s *WildShapeTestSuite) TestMetadata() {
+	feature := s.wildShape(2)
+
+	s.Equal(refs.Features.WildShape(), feature.Ref())
+	s.Equal("Wild Shape", feature.Name())
+	s.Equal(coreCombat.ActionStandard, feature.ActionType())
+}
+
+func (s *WildShapeTestSuite) TestActivatePublishesWildShapedCondition() {
+	feature := s.wildShape(2)
+	wolf := monsters.NewWolf("wolf-form").ToData()
+
+	err := feature.Activate(s.ctx, s.druid, features.FeatureInput{Bus: s.bus, Form: wolf})
+	s.Require().NoError(err)
+
+	s.Equal(1, s.druid.GetResource(resources.WildShape).Current())
+	s.Require().Len(s.applied, 1)
+	s.Equal(dnd5eEvents.ConditionWildShaped, s.applied[0].Type)
+	s.Equal(dnd5eEvents.ConditionSourceFeature, s.applied[0].Source)
+
+	shaped, ok := s.applied[0].Condition.(*conditions.WildShapedCondition)
+	s.Require().True(ok)
+	s.Equal("druid-1", shaped.CharacterID)
+	s.Equal(wolf.MaxHitPoints, shaped.HitPoints())
+	s.Equal(wolf.ArmorClass, shaped.ArmorClass())
+}
+
+func (s *WildShapeTestSuite) TestUsesPerRest() {
+	feature := s.wildShape(2)
+	input := features.FeatureInput{Bus: s.bus, Form: monsters.NewWolf("wolf-form").ToData()}
+
+	s.Require().NoError(feature.Activate(s.ctx, s.druid, input))
+	s.Require().NoError(feature.Activate(s.ctx, s.druid, input))
+
+	err := feature.Activate(s.ctx, s.druid, input)
+	s.Require().Error(err)
+	s.Equal(rpgerr.CodeResourceExhausted, rpgerr.GetCode(err))
+
+	s.druid.GetResource(resources.WildShape).RestoreToFull()
+	s.NoError(feature.CanActivate(s.ctx, s.druid, input))
+}
+
+func (s *WildShapeTestSuite) TestFormRestrictions() {
+	s.Run("requires a form", func() {
+		err := s.wildShape(2).CanActivate(s.ctx, s.druid, features.FeatureInput{Bus: s.bus})
+		s.Require().Error(err)
+		s.Equal(rpgerr.CodeInvalidArgument, rpgerr.GetCode(err))
+	})
+
+	s.Run("form must be a beast", func() {
+		zombie := monsters.NewZombie("zombie-form").ToData()
+		err := s.wildShape(8).CanActivate(s.ctx, s.druid, features.FeatureInput{Bus: s.bus, Form: zombie})
+		s.Require().Error(err)
+		s.Equal(rpgerr.CodeInvalidArgument, rpgerr.GetCode(err))
+	})
+
+	s.Run("swimming forms require druid level 4", func() {
+		shark := monsters.NewGiantRat("shark-form").ToData()
+		shark.Speed = monster.SpeedData{Swim: 40}
+
+		err := s.wildShape(3).CanActivate(s.ctx, s.druid, features.FeatureInput{Bus: s.bus, Form: shark})
+		s.Require().Error(err)
+		s.Equal(rpgerr.CodeNotAllowed, rpgerr.GetCode(err))
+
+		s.NoError(s.wildShape(4).CanActivate(s.ctx, s.druid, features.FeatureInput{Bus: s.bus, Form: shark}))
+	})
+
+	s.Run("flying forms require druid level 8", func() {
+		eagle, err := monsters.FromAPIJSON("eagle-form", []byte(apiGiantEagleJSON))
+		s.Require().NoError(err)
+		input := features.FeatureInput{Bus: s.bus, Form: eagle.ToData()}
+
+		err = s.wildShape(7).CanActivate(s.ctx, s.druid, input)
+		s.Require().Error(err)
+		s.Equal(rpgerr.CodeNotAllowed, rpgerr.GetCode(err))
+
+		s.NoError(s.wildShape(8).CanActivate(s.ctx, s.druid, input))
+	})
+
+	s.Equal(2, s.druid.GetResource(resources.WildShape).Current(), "failed checks spend nothing")
+}
+
+func (s *WildShapeTestSuite) TestRequiresBus() {
+	input := features.FeatureInput{Form: monsters.NewWolf("wolf-form").ToData()}
+
+	err := s.wildShape(2).Activate(s.ctx, s.druid, input)
+	s.Require().Error(err)
+	s.Equal(2, s.druid.GetResource(resources.WildShape).Current())
+}
+
+func (s *WildShapeTestSuite) TestRoundTrip() {
+	feature := s.wildShape(4)
+
+	data, err := feature.ToJSON()
+	s.Require().NoError(err)
+
+	loaded, err := features.LoadJSON(data)
+	s.Require().NoError(err)
+	s.Equal(refs.Features.WildShape(), loaded.Ref())
+
+	shark := monsters.NewGiantRat("shark-form").ToData()
+	shark.Speed = monster.SpeedData{Swim: 40}
+	s.NoError(loaded.CanActivate(s.ctx, s.druid, features.FeatureInput{Bus: s.bus, Form: shark}),
+		"druid level survives the round trip")
+}
@@ -116,6 +116,7 @@ type BasicCharacterRegistry struct {
 	characters      map[string]*CharacterWeapons
 	abilityScores   map[string]*AbilityScores
 	actionEconomies map[string]*combat.ActionEconomy
+	levels          map[string]int
 }
 
 // NewBasicCharacterRegistry creates a new BasicCharacterRegistry.
@@ -124,6 +125,7 @@ func NewBasicCharacterRegistry() *BasicCharacterRegistry {
 		characters:      make(map[string]*CharacterWeapons),
 		abilityScores:   make(map[string]*AbilityScores),
 		actionEconomies: make(map[string]*combat.ActionEconomy),
+		levels:          make(map[string]int),
 	}
 }
 
@@ -172,3 +174,16 @@ func (r *BasicCharacterRegistry) AddActionEconomy(characterID string, economy *c
 func (r *BasicCharacterRegistry) GetCharacterActionEconomy(id string) *combat.ActionEconomy {
 	return r.actionEconomies[id]
 }
+
+// AddLevel registers a character's total character level.
+// If the character already has a level, it is replaced.
+func (r *BasicCharacterRegistry) AddLevel(characterID string, level int) {
+	r.levels[characterID] = level
+}
+
+// GetCharacterLevel retrieves a character's total character level by ID.
+// Returns 0 if the character is not found.
+// Purpose: Allows cantrip damage to scale with the caster's level.
+func (r *BasicCharacterRegistry) GetCharacterLevel(id string) int {
+	return r.levels[id]
+}
@@ -75,6 +75,14 @@ func (s *CharacterRegistryTestSuite) TestGetCharacterWeaponsNotFound() {
 	s.Nil(retrieved)
 }
 
+func (s *CharacterRegistryTestSuite) TestCharacterLevel() {
+	s.registry.AddLevel("hero-1", 5)
+	s.Equal(5, s.registry.GetCharacterLevel("hero-1"))
+
+	// Unknown characters have no level
+	s.Equal(0, s.registry.GetCharacterLevel("nonexistent"))
+}
+
 func (s *CharacterRegistryTestSuite) TestReplaceCharacterWeapons() {
 	// Add initial weapons
 	longsword := &gamectx.EquippedWeapon{
@@ -24,6 +24,11 @@ type CharacterRegistry interface {
 	// Returns nil if character is not found.
 	// Purpose: Allows features like Protection to check reaction availability.
 	GetCharacterActionEconomy(id string) *combat.ActionEconomy
+
+	// GetCharacterLevel retrieves a character's total character level by ID.
+	// Returns 0 if character is not found.
+	// Purpose: Allows cantrip damage to scale with the caster's level.
+	GetCharacterLevel(id string) int
 }
 
 // GameContext carries game state through context.Context for use during event processing.
@@ -80,3 +85,8 @@ func (e *emptyCharacterRegistry) GetCharacterAbilityScores(_ string) *AbilitySco
 func (e *emptyCharacterRegistry) GetCharacterActionEconomy(_ string) *combat.ActionEconomy {
 	return nil
 }
+
+// GetCharacterLevel always returns 0 for the empty registry.
+func (e *emptyCharacterRegistry) GetCharacterLevel(_ string) int {
+	return 0
+}
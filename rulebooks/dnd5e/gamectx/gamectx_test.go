@@ -41,6 +41,10 @@ func (m *mockCharacterRegistry) GetCharacterActionEconomy(_ string) *combat.Acti
 	return nil
 }
 
+func (m *mockCharacterRegistry) GetCharacterLevel(_ string) int {
+	return 0
+}
+
 func (m *mockCharacterRegistry) addCharacter(id string, weapons *gamectx.CharacterWeapons) {
 	m.characters[id] = weapons
 }
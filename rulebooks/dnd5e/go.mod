@@ -3,13 +3,13 @@ module github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e
 go 1.24.1
 
 require (
-	github.com/KirkDiggler/rpg-toolkit/core v0.10.0
+	github.com/KirkDiggler/rpg-toolkit/core v0.10.1-0.20261016192714-ce0d00a23e54
 	github.com/KirkDiggler/rpg-toolkit/dice v0.3.2
-	github.com/KirkDiggler/rpg-toolkit/events v0.6.2
+	github.com/KirkDiggler/rpg-toolkit/events v0.6.3-0.20261016195916-54fdabf55465
 	github.com/KirkDiggler/rpg-toolkit/mechanics/resources v0.3.1
 	github.com/KirkDiggler/rpg-toolkit/rpgerr v0.1.1
 	github.com/KirkDiggler/rpg-toolkit/tools/environments v0.4.0
-	github.com/KirkDiggler/rpg-toolkit/tools/spatial v0.4.0
+	github.com/KirkDiggler/rpg-toolkit/tools/spatial v0.4.1-0.20261016200339-fbcb37c69115
 	github.com/stretchr/testify v1.10.0
 	go.uber.org/mock v0.5.2
 )
@@ -0,0 +1,37 @@
+package initiative
+
+import "github.com/KirkDiggler/rpg-toolkit/core"
+
+// LairInitiativeCount is the initiative count on which lair actions are taken
+const LairInitiativeCount = 20
+
+// LairEntityType identifies a lair's turn in the initiative order
+const LairEntityType core.EntityType = "lair"
+
+// OrderWithLair returns the entities from rolls in turn order with a lair turn
+// inserted on initiative count 20. The lair loses all ties, so it goes after
+// every entity whose total is 20 or higher.
+// Rolls must already be sorted highest first (as returned by RollForOrder).
+func OrderWithLair(rolls []Roll, lairID string) []core.Entity {
+	order := make([]core.Entity, 0, len(rolls)+1)
+	lair := NewParticipant(lairID, LairEntityType)
+	placed := false
+
+	for _, roll := range rolls {
+		if !placed && roll.Total < LairInitiativeCount {
+			order = append(order, lair)
+			placed = true
+		}
+		order = append(order, roll.Entity)
+	}
+	if !placed {
+		order = append(order, lair)
+	}
+
+	return order
+}
+
+// IsLairTurn reports whether the entity is a lair turn placed by OrderWithLair
+func IsLairTurn(entity core.Entity) bool {
+	return entity != nil && entity.GetType() == LairEntityType
+}
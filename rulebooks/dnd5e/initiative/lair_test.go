@@ -0,0 +1,75 @@
+package initiative_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/initiative"
+)
+
+func ids(order []core.Entity) []string {
+	result := make([]string, len(order))
+	for i, entity := range order {
+		result[i] = entity.GetID()
+	}
+	return result
+}
+
+func TestOrderWithLair(t *testing.T) {
+	dragon := initiative.NewParticipant("dragon", dnd5e.EntityTypeMonster)
+	rogue := initiative.NewParticipant("rogue", dnd5e.EntityTypeCharacter)
+	fighter := initiative.NewParticipant("fighter", dnd5e.EntityTypeCharacter)
+
+	t.Run("lair loses ties on count 20", func(t *testing.T) {
+		order := initiative.OrderWithLair([]initiative.Roll{
+			{Entity: rogue, Total: 23},
+			{Entity: dragon, Total: 20},
+			{Entity: fighter, Total: 12},
+		}, "dragon-lair")
+
+		assert.Equal(t, []string{"rogue", "dragon", "dragon-lair", "fighter"}, ids(order))
+		assert.True(t, initiative.IsLairTurn(order[2]))
+		assert.False(t, initiative.IsLairTurn(order[1]))
+	})
+
+	t.Run("lair goes first when everyone rolled below 20", func(t *testing.T) {
+		order := initiative.OrderWithLair([]initiative.Roll{
+			{Entity: dragon, Total: 15},
+			{Entity: fighter, Total: 8},
+		}, "dragon-lair")
+
+		assert.Equal(t, []string{"dragon-lair", "dragon", "fighter"}, ids(order))
+	})
+
+	t.Run("lair goes last when everyone rolled 20 or higher", func(t *testing.T) {
+		order := initiative.OrderWithLair([]initiative.Roll{
+			{Entity: rogue, Total: 24},
+			{Entity: dragon, Total: 21},
+		}, "dragon-lair")
+
+		assert.Equal(t, []string{"rogue", "dragon", "dragon-lair"}, ids(order))
+	})
+
+	t.Run("tracker visits the lair once per round", func(t *testing.T) {
+		tracker := initiative.New(initiative.OrderWithLair([]initiative.Roll{
+			{Entity: rogue, Total: 22},
+			{Entity: dragon, Total: 14},
+		}, "dragon-lair"))
+
+		lairTurns := 0
+		for tracker.Round() <= 3 {
+			if initiative.IsLairTurn(tracker.Current()) {
+				lairTurns++
+			}
+			tracker.Next()
+		}
+		assert.Equal(t, 3, lairTurns)
+	})
+
+	t.Run("nil entity is not a lair turn", func(t *testing.T) {
+		assert.False(t, initiative.IsLairTurn(nil))
+	})
+}
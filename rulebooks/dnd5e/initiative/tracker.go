@@ -54,6 +54,36 @@ func (t *Tracker) Round() int {
 	return t.round
 }
 
+// InsertAfter adds entities to the turn order directly after an existing
+// entity, e.g. summoned creatures that act right after their summoner.
+// The current turn is unchanged; if the summoner is acting now, the new
+// entities act next.
+func (t *Tracker) InsertAfter(afterID string, entities ...core.Entity) error {
+	index := -1
+	for i, entity := range t.order {
+		if entity.GetID() == afterID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return fmt.Errorf("entity %s not found", afterID)
+	}
+
+	order := make([]core.Entity, 0, len(t.order)+len(entities))
+	order = append(order, t.order[:index+1]...)
+	order = append(order, entities...)
+	order = append(order, t.order[index+1:]...)
+	t.order = order
+
+	// Keep pointing at the same entity if it moved
+	if t.current > index {
+		t.current += len(entities)
+	}
+
+	return nil
+}
+
 // Remove takes someone out of the turn order
 func (t *Tracker) Remove(entityID string) error {
 	newOrder := make([]core.Entity, 0)
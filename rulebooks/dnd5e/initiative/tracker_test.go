@@ -0,0 +1,66 @@
+package initiative_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/initiative"
+)
+
+func orderIDs(tracker *initiative.Tracker) []string {
+	data := tracker.ToData()
+	result := make([]string, len(data.Order))
+	for i, entity := range data.Order {
+		result[i] = entity.ID
+	}
+	return result
+}
+
+func TestTrackerInsertAfter(t *testing.T) {
+	newTracker := func() *initiative.Tracker {
+		return initiative.New([]core.Entity{
+			initiative.NewParticipant("rogue", dnd5e.EntityTypeCharacter),
+			initiative.NewParticipant("druid", dnd5e.EntityTypeCharacter),
+			initiative.NewParticipant("orc", dnd5e.EntityTypeMonster),
+		})
+	}
+	wolves := []core.Entity{
+		initiative.NewParticipant("wolf-1", dnd5e.EntityTypeMonster),
+		initiative.NewParticipant("wolf-2", dnd5e.EntityTypeMonster),
+	}
+
+	t.Run("summons act right after the summoner", func(t *testing.T) {
+		tracker := newTracker()
+		tracker.Next() // Druid's turn, casting Conjure Animals
+
+		require.NoError(t, tracker.InsertAfter("druid", wolves...))
+		assert.Equal(t, []string{"rogue", "druid", "wolf-1", "wolf-2", "orc"}, orderIDs(tracker))
+
+		assert.Equal(t, "druid", tracker.Current().GetID())
+		assert.Equal(t, "wolf-1", tracker.Next().GetID())
+		assert.Equal(t, "wolf-2", tracker.Next().GetID())
+		assert.Equal(t, "orc", tracker.Next().GetID())
+		assert.Equal(t, 1, tracker.Round())
+	})
+
+	t.Run("current turn is kept when inserting earlier in the order", func(t *testing.T) {
+		tracker := newTracker()
+		tracker.Next()
+		tracker.Next() // Orc's turn
+
+		require.NoError(t, tracker.InsertAfter("rogue", wolves...))
+		assert.Equal(t, "orc", tracker.Current().GetID())
+		assert.Equal(t, "rogue", tracker.Next().GetID())
+		assert.Equal(t, 2, tracker.Round())
+	})
+
+	t.Run("unknown summoner", func(t *testing.T) {
+		tracker := newTracker()
+		assert.Error(t, tracker.InsertAfter("lich", wolves...))
+		assert.Equal(t, []string{"rogue", "druid", "orc"}, orderIDs(tracker))
+	})
+}
@@ -0,0 +1,249 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/character"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/classes"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/conditions"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/encounter"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/initiative"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster/monsters"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/races"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/weapons"
+	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
+)
+
+// ============================================================================
+// ENCOUNTER PERSISTENCE TEST SUITE
+// A rogue, a fighter, a skeleton and a wolf fight. Partway through the
+// fighter's turn in round 1 the encounter is saved to JSON and loaded on a
+// fresh bus and room; the rest of the turn then plays out the same on the
+// original and the restored encounter.
+// ============================================================================
+
+type EncounterPersistenceSuite struct {
+	suite.Suite
+	ctrl       *gomock.Controller
+	ctx        context.Context
+	mockRoller *mock_dice.MockRoller
+	mace       weapons.Weapon
+}
+
+func TestEncounterPersistenceSuite(t *testing.T) {
+	suite.Run(t, new(EncounterPersistenceSuite))
+}
+
+func (s *EncounterPersistenceSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.ctx = context.Background()
+	s.mockRoller = mock_dice.NewMockRoller(s.ctrl)
+
+	mace, err := weapons.GetByID(weapons.Mace)
+	s.Require().NoError(err)
+	s.mace = mace
+}
+
+func (s *EncounterPersistenceSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// newRoom creates the empty 10x10 room the fight takes place in
+func newRoom() spatial.Room {
+	grid := spatial.NewSquareGrid(spatial.SquareGridConfig{Width: 10, Height: 10})
+	return spatial.NewBasicRoom(spatial.BasicRoomConfig{ID: "crypt", Type: "combat", Grid: grid})
+}
+
+// characterData builds a level 1 human of a class
+func characterData(id string, class classes.Class, hp, ac int) *character.Data {
+	return &character.Data{
+		ID: id, Name: id, Level: 1, ProficiencyBonus: 2,
+		RaceID: races.Human, ClassID: class,
+		AbilityScores: shared.AbilityScores{
+			abilities.STR: 16, abilities.DEX: 14, abilities.CON: 14,
+			abilities.INT: 10, abilities.WIS: 12, abilities.CHA: 10,
+		},
+		HitPoints: hp, MaxHitPoints: hp, ArmorClass: ac,
+	}
+}
+
+// startEncounter loads the fight at the start of round 1
+func (s *EncounterPersistenceSuite) startEncounter() *encounter.Encounter {
+	rogue := characterData("rogue-1", classes.Rogue, 10, 14)
+	fighter := characterData("fighter-1", classes.Fighter, 12, 16)
+
+	order := []initiative.EntityData{
+		{ID: "rogue-1", Type: "character"},
+		{ID: "fighter-1", Type: "character"},
+		{ID: "skeleton-1", Type: "monster"},
+		{ID: "wolf-1", Type: "monster"},
+	}
+
+	e, err := encounter.LoadFromData(s.ctx, &encounter.LoadFromDataInput{
+		Data: &encounter.EncounterData{
+			ID:         "crypt-fight",
+			Initiative: initiative.TrackerData{Order: order, Round: 1},
+			Positions: []encounter.PositionData{
+				{EntityID: "rogue-1", Position: spatial.Position{X: 2, Y: 3}},
+				{EntityID: "fighter-1", Position: spatial.Position{X: 1, Y: 1}},
+				{EntityID: "skeleton-1", Position: spatial.Position{X: 4, Y: 1}},
+				{EntityID: "wolf-1", Position: spatial.Position{X: 3, Y: 4}},
+			},
+			Characters: []*character.Data{rogue, fighter},
+			Monsters:   []*monster.Data{monsters.NewSkeleton("skeleton-1").ToData(), monsters.NewWolf("wolf-1").ToData()},
+		},
+		EventBus: events.NewEventBus(),
+		Room:     newRoom(),
+		Roller:   s.mockRoller,
+	})
+	s.Require().NoError(err)
+	return e
+}
+
+// turnManager manages the fighter's turn on an encounter, spending from the
+// encounter's saved economy
+func (s *EncounterPersistenceSuite) turnManager(e *encounter.Encounter) *combat.TurnManager {
+	tm, err := combat.NewTurnManager(&combat.NewTurnManagerInput{
+		Character:  e.Character("fighter-1"),
+		Combatants: e,
+		Room:       e.Room(),
+		EventBus:   e.EventBus(),
+		Roller:     s.mockRoller,
+		Economy:    e.Economy("fighter-1"),
+	})
+	s.Require().NoError(err)
+	return tm
+}
+
+// save marshals the encounter to JSON. Character ToData stamps UpdatedAt with
+// the current time, so it is cleared to compare saves.
+func (s *EncounterPersistenceSuite) save(e *encounter.Encounter) []byte {
+	encounterData := e.ToData()
+	for _, c := range encounterData.Characters {
+		c.UpdatedAt = time.Time{}
+	}
+	data, err := json.Marshal(encounterData)
+	s.Require().NoError(err)
+	return data
+}
+
+// load restores an encounter from JSON on a new bus and room
+func (s *EncounterPersistenceSuite) load(saved []byte) *encounter.Encounter {
+	var data encounter.EncounterData
+	s.Require().NoError(json.Unmarshal(saved, &data))
+
+	e, err := encounter.LoadFromData(s.ctx, &encounter.LoadFromDataInput{
+		Data:     &data,
+		EventBus: events.NewEventBus(),
+		Room:     newRoom(),
+		Roller:   s.mockRoller,
+	})
+	s.Require().NoError(err)
+	return e
+}
+
+// playFirstHalf runs round 1 up to the middle of the fighter's turn: the
+// rogue dodged and wounded the wolf, then the fighter takes the Attack action
+// and moves next to the skeleton without striking yet.
+func (s *EncounterPersistenceSuite) playFirstHalf(e *encounter.Encounter) *combat.TurnManager {
+	err := dnd5eEvents.ConditionAppliedTopic.On(e.EventBus()).Publish(s.ctx, dnd5eEvents.ConditionAppliedEvent{
+		Target:    e.Character("rogue-1"),
+		Condition: conditions.NewDodgingCondition("rogue-1"),
+	})
+	s.Require().NoError(err)
+	e.Monster("wolf-1").ApplyDamage(s.ctx, &combat.ApplyDamageInput{
+		Instances: []combat.DamageInstance{{Amount: 4, Type: string(damage.Piercing)}},
+	})
+	s.Equal(1, e.Economy("rogue-1").ReactionsRemaining)
+
+	s.Equal("fighter-1", e.Tracker().Next().GetID())
+	tm := s.turnManager(e)
+	_, err = tm.StartTurn(s.ctx)
+	s.Require().NoError(err)
+	_, err = tm.UseAbility(s.ctx, &combat.UseAbilityInput{AbilityRef: refs.CombatAbilities.Attack()})
+	s.Require().NoError(err)
+	_, err = tm.Move(s.ctx, &combat.MoveInput{Path: []spatial.Position{{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 3, Y: 1}}})
+	s.Require().NoError(err)
+	return tm
+}
+
+// playSecondHalf finishes the fighter's turn: a mace strike on the skeleton,
+// which is vulnerable to bludgeoning, then a step away
+func (s *EncounterPersistenceSuite) playSecondHalf(tm *combat.TurnManager) *combat.AttackResult {
+	// d20(15) + STR(3) + proficiency(2) = 20 vs AC 13; 1d6(2) + STR(3) = 5, doubled
+	s.mockRoller.EXPECT().Roll(gomock.Any(), 20).Return(15, nil)
+	s.mockRoller.EXPECT().RollN(gomock.Any(), 1, 6).Return([]int{2}, nil)
+
+	result, err := tm.Strike(s.ctx, &combat.StrikeInput{TargetID: "skeleton-1", Weapon: &s.mace})
+	s.Require().NoError(err)
+	_, err = tm.Move(s.ctx, &combat.MoveInput{Path: []spatial.Position{{X: 3, Y: 1}, {X: 3, Y: 2}}})
+	s.Require().NoError(err)
+	_, err = tm.EndTurn(s.ctx)
+	s.Require().NoError(err)
+	return result
+}
+
+func (s *EncounterPersistenceSuite) TestMidRoundSaveLoadResumesIdentically() {
+	original := s.startEncounter()
+	originalTurn := s.playFirstHalf(original)
+
+	saved := s.save(original)
+	restored := s.load(saved)
+
+	s.Run("the restored encounter saves the same state", func() {
+		s.JSONEq(string(saved), string(s.save(restored)))
+	})
+
+	s.Run("initiative, positions and HP are restored", func() {
+		s.Equal("fighter-1", restored.Tracker().Current().GetID())
+		s.Equal(1, restored.Tracker().Round())
+		pos, ok := restored.Room().GetEntityPosition("fighter-1")
+		s.Require().True(ok)
+		s.Equal(spatial.Position{X: 3, Y: 1}, pos)
+		s.Equal(7, restored.Monster("wolf-1").GetHitPoints())
+		s.Equal(13, restored.Monster("skeleton-1").GetHitPoints())
+	})
+
+	s.Run("conditions are re-applied to the new bus", func() {
+		rogueConditions := restored.Character("rogue-1").GetConditions()
+		s.Require().Len(rogueConditions, 1)
+		s.True(rogueConditions[0].IsApplied())
+	})
+
+	s.Run("the turn resumes where it stopped", func() {
+		restoredTurn := s.turnManager(restored)
+		start, err := restoredTurn.ResumeTurn(s.ctx)
+		s.Require().NoError(err)
+		s.Equal(20, start.Economy.MovementRemaining, "10 of 30 feet already moved")
+		s.Equal(0, start.Economy.ActionsRemaining)
+		s.Equal(1, start.Economy.AttacksRemaining)
+
+		fromOriginal := s.playSecondHalf(originalTurn)
+		fromRestored := s.playSecondHalf(restoredTurn)
+		s.True(fromRestored.Hit)
+		s.Equal(10, fromRestored.TotalDamage, "the skeleton's vulnerability survived the reload")
+		s.Equal(fromOriginal.TotalDamage, fromRestored.TotalDamage)
+
+		s.JSONEq(string(s.save(original)), string(s.save(restored)))
+		s.Equal(3, restored.Monster("skeleton-1").GetHitPoints())
+		s.Equal(15, restored.Economy("fighter-1").MovementRemaining)
+	})
+}
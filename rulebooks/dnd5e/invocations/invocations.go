@@ -0,0 +1,123 @@
+// Package invocations provides D&D 5e Eldritch Invocation definitions
+package invocations
+
+import "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+
+// Invocation represents an Eldritch Invocation a warlock can learn
+type Invocation = shared.SelectionID
+
+// Invocation constants
+const (
+	// AgonizingBlast adds the warlock's Charisma modifier to Eldritch Blast damage
+	AgonizingBlast Invocation = "agonizing_blast"
+
+	// ArmorOfShadows lets the warlock cast mage armor on themselves at will
+	ArmorOfShadows Invocation = "armor_of_shadows"
+
+	// DevilsSight lets the warlock see normally in darkness out to 120 feet
+	DevilsSight Invocation = "devils_sight"
+
+	// EldritchSight lets the warlock cast detect magic at will
+	EldritchSight Invocation = "eldritch_sight"
+
+	// MaskOfManyFaces lets the warlock cast disguise self at will
+	MaskOfManyFaces Invocation = "mask_of_many_faces"
+
+	// RepellingBlast pushes Eldritch Blast targets 10 feet away
+	RepellingBlast Invocation = "repelling_blast"
+)
+
+// Name returns the display name of the invocation
+func Name(i Invocation) string {
+	switch i {
+	case AgonizingBlast:
+		return "Agonizing Blast"
+	case ArmorOfShadows:
+		return "Armor of Shadows"
+	case DevilsSight:
+		return "Devil's Sight"
+	case EldritchSight:
+		return "Eldritch Sight"
+	case MaskOfManyFaces:
+		return "Mask of Many Faces"
+	case RepellingBlast:
+		return "Repelling Blast"
+	default:
+		return i
+	}
+}
+
+// Description returns the mechanical description of the invocation
+func Description(i Invocation) string {
+	switch i {
+	case AgonizingBlast:
+		return "When you cast eldritch blast, add your Charisma modifier to the damage it deals on a hit."
+	case ArmorOfShadows:
+		return "You can cast mage armor on yourself at will, without expending a spell slot or material components."
+	case DevilsSight:
+		return "You can see normally in darkness, both magical and nonmagical, to a distance of 120 feet."
+	case EldritchSight:
+		return "You can cast detect magic at will, without expending a spell slot."
+	case MaskOfManyFaces:
+		return "You can cast disguise self at will, without expending a spell slot."
+	case RepellingBlast:
+		return "When you hit a creature with eldritch blast, you can push the creature up to 10 feet away from you in a straight line." //nolint:lll
+	default:
+		return ""
+	}
+}
+
+// All returns all available invocations
+func All() []Invocation {
+	return []Invocation{
+		AgonizingBlast,
+		ArmorOfShadows,
+		DevilsSight,
+		EldritchSight,
+		MaskOfManyFaces,
+		RepellingBlast,
+	}
+}
+
+// RequiresEldritchBlast returns true if the invocation has the eldritch blast
+// cantrip as a prerequisite
+func RequiresEldritchBlast(i Invocation) bool {
+	switch i {
+	case AgonizingBlast, RepellingBlast:
+		return true
+	default:
+		return false
+	}
+}
+
+// KnownCount returns how many invocations a warlock of the given level knows
+func KnownCount(warlockLevel int) int {
+	switch {
+	case warlockLevel < 2:
+		return 0
+	case warlockLevel < 5:
+		return 2
+	case warlockLevel < 7:
+		return 3
+	case warlockLevel < 9:
+		return 4
+	case warlockLevel < 12:
+		return 5
+	case warlockLevel < 15:
+		return 6
+	case warlockLevel < 18:
+		return 7
+	default:
+		return 8
+	}
+}
+
+// IsImplemented returns true if the invocation has been implemented
+func IsImplemented(i Invocation) bool {
+	switch i {
+	case AgonizingBlast, DevilsSight:
+		return true
+	default:
+		return false
+	}
+}
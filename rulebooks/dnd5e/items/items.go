@@ -19,28 +19,54 @@ const (
 
 // Adventuring gear
 const (
-	Backpack   ItemID = "backpack"
-	Bedroll    ItemID = "bedroll"
-	Blanket    ItemID = "blanket"
-	Crowbar    ItemID = "crowbar"
-	Hammer     ItemID = "hammer"
-	HempenRope ItemID = "hempen-rope"
-	Lantern    ItemID = "lantern"
-	Mess       ItemID = "mess-kit"
-	Oil        ItemID = "oil"
-	Piton      ItemID = "piton"
-	Rations    ItemID = "rations"
-	Tinderbox  ItemID = "tinderbox"
-	Torch      ItemID = "torch"
-	Waterskin  ItemID = "waterskin"
+	AlmsBox        ItemID = "alms-box"
+	Backpack       ItemID = "backpack"
+	BagOfSand      ItemID = "bag-sand"
+	BallBearings   ItemID = "ball-bearings"
+	Bedroll        ItemID = "bedroll"
+	Bell           ItemID = "bell"
+	Blanket        ItemID = "blanket"
+	BookOfLore     ItemID = "book-lore"
+	Candle         ItemID = "candle"
+	Censer         ItemID = "censer"
+	Chest          ItemID = "chest"
+	CostumeClothes ItemID = "costume"
+	Crowbar        ItemID = "crowbar"
+	FineClothes    ItemID = "fine-clothes"
+	Hammer         ItemID = "hammer"
+	HempenRope     ItemID = "hempen-rope"
+	HoodedLantern  ItemID = "hooded-lantern"
+	Incense        ItemID = "incense"
+	Ink            ItemID = "ink"
+	InkPen         ItemID = "ink-pen"
+	Lamp           ItemID = "lamp"
+	Lantern        ItemID = "lantern"
+	MapCase        ItemID = "case-map"
+	Mess           ItemID = "mess-kit"
+	Oil            ItemID = "oil"
+	Paper          ItemID = "paper"
+	Parchment      ItemID = "parchment"
+	Perfume        ItemID = "perfume"
+	Piton          ItemID = "piton"
+	Rations        ItemID = "rations"
+	SealingWax     ItemID = "sealing-wax"
+	SmallKnife     ItemID = "small-knife"
+	Soap           ItemID = "soap"
+	String         ItemID = "string"
+	Tinderbox      ItemID = "tinderbox"
+	Torch          ItemID = "torch"
+	Vestments      ItemID = "vestments"
+	Waterskin      ItemID = "waterskin"
 )
 
-// Item represents a miscellaneous item with basic stats.
+// Item represents a miscellaneous item with basic stats. Weight and Cost are
+// for the unit the SRD sells, e.g. 50 feet of rope or a bag of 1,000 ball
+// bearings. Items that only come in packs have no listed cost.
 type Item struct {
 	ID     ItemID
 	Name   string
-	Weight float64
-	Cost   string
+	Weight float64 // in pounds
+	Cost   string  // e.g., "5 sp"; empty when not sold separately
 }
 
 // EquipmentID returns the unique identifier for this item.
@@ -65,8 +91,8 @@ func (i *Item) EquipmentWeight() float32 {
 
 // EquipmentValue returns the value in copper pieces.
 func (i *Item) EquipmentValue() int {
-	// TODO: Parse cost string and convert to copper
-	return 0
+	value, _ := shared.ParseCost(i.Cost)
+	return value
 }
 
 // EquipmentDescription returns a description of the item.
@@ -81,4 +107,45 @@ var All = map[ItemID]Item{
 	DruidicFocus:   {ID: DruidicFocus, Name: "Druidic Focus", Weight: 0, Cost: "1 gp"},
 	HolySymbol:     {ID: HolySymbol, Name: "Holy Symbol", Weight: 0, Cost: "5 gp"},
 	Spellbook:      {ID: Spellbook, Name: "Spellbook", Weight: 3, Cost: "50 gp"},
+
+	Backpack:       {ID: Backpack, Name: "Backpack", Weight: 5, Cost: "2 gp"},
+	BallBearings:   {ID: BallBearings, Name: "Ball Bearings (bag of 1,000)", Weight: 2, Cost: "1 gp"},
+	Bedroll:        {ID: Bedroll, Name: "Bedroll", Weight: 7, Cost: "1 gp"},
+	Bell:           {ID: Bell, Name: "Bell", Weight: 0, Cost: "1 gp"},
+	Blanket:        {ID: Blanket, Name: "Blanket", Weight: 3, Cost: "5 sp"},
+	BookOfLore:     {ID: BookOfLore, Name: "Book of Lore", Weight: 5, Cost: "25 gp"},
+	Candle:         {ID: Candle, Name: "Candle", Weight: 0, Cost: "1 cp"},
+	Chest:          {ID: Chest, Name: "Chest", Weight: 25, Cost: "5 gp"},
+	CostumeClothes: {ID: CostumeClothes, Name: "Costume Clothes", Weight: 4, Cost: "5 gp"},
+	Crowbar:        {ID: Crowbar, Name: "Crowbar", Weight: 5, Cost: "2 gp"},
+	FineClothes:    {ID: FineClothes, Name: "Fine Clothes", Weight: 6, Cost: "15 gp"},
+	Hammer:         {ID: Hammer, Name: "Hammer", Weight: 3, Cost: "1 gp"},
+	HempenRope:     {ID: HempenRope, Name: "Hempen Rope (50 feet)", Weight: 10, Cost: "1 gp"},
+	HoodedLantern:  {ID: HoodedLantern, Name: "Hooded Lantern", Weight: 2, Cost: "5 gp"},
+	Ink:            {ID: Ink, Name: "Ink (1 ounce bottle)", Weight: 0, Cost: "10 gp"},
+	InkPen:         {ID: InkPen, Name: "Ink Pen", Weight: 0, Cost: "2 cp"},
+	Lamp:           {ID: Lamp, Name: "Lamp", Weight: 1, Cost: "5 sp"},
+	Lantern:        {ID: Lantern, Name: "Bullseye Lantern", Weight: 2, Cost: "10 gp"},
+	MapCase:        {ID: MapCase, Name: "Map or Scroll Case", Weight: 1, Cost: "1 gp"},
+	Mess:           {ID: Mess, Name: "Mess Kit", Weight: 1, Cost: "2 sp"},
+	Oil:            {ID: Oil, Name: "Oil (flask)", Weight: 1, Cost: "1 sp"},
+	Paper:          {ID: Paper, Name: "Paper (one sheet)", Weight: 0, Cost: "2 sp"},
+	Parchment:      {ID: Parchment, Name: "Parchment (one sheet)", Weight: 0, Cost: "1 sp"},
+	Perfume:        {ID: Perfume, Name: "Perfume (vial)", Weight: 0, Cost: "5 gp"},
+	Piton:          {ID: Piton, Name: "Piton", Weight: 0.25, Cost: "5 cp"},
+	Rations:        {ID: Rations, Name: "Rations (1 day)", Weight: 2, Cost: "5 sp"},
+	SealingWax:     {ID: SealingWax, Name: "Sealing Wax", Weight: 0, Cost: "5 sp"},
+	Soap:           {ID: Soap, Name: "Soap", Weight: 0, Cost: "2 cp"},
+	Tinderbox:      {ID: Tinderbox, Name: "Tinderbox", Weight: 1, Cost: "5 sp"},
+	Torch:          {ID: Torch, Name: "Torch", Weight: 1, Cost: "1 cp"},
+	Waterskin:      {ID: Waterskin, Name: "Waterskin", Weight: 5, Cost: "2 sp"},
+
+	// Only found in packs
+	AlmsBox:    {ID: AlmsBox, Name: "Alms Box"},
+	BagOfSand:  {ID: BagOfSand, Name: "Little Bag of Sand"},
+	Censer:     {ID: Censer, Name: "Censer"},
+	Incense:    {ID: Incense, Name: "Block of Incense"},
+	SmallKnife: {ID: SmallKnife, Name: "Small Knife"},
+	String:     {ID: String, Name: "String (10 feet)"},
+	Vestments:  {ID: Vestments, Name: "Vestments"},
 }
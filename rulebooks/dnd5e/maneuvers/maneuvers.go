@@ -0,0 +1,99 @@
+// Package maneuvers provides D&D 5e Battle Master maneuver definitions
+package maneuvers
+
+import "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+
+// Maneuver represents a Battle Master maneuver fueled by superiority dice
+type Maneuver = shared.SelectionID
+
+// Maneuver constants
+const (
+	// PrecisionAttack adds a superiority die to a weapon attack roll
+	PrecisionAttack Maneuver = "precision_attack"
+
+	// Riposte makes a reaction melee attack against a creature that missed, adding the die to damage
+	Riposte Maneuver = "riposte"
+
+	// TripAttack adds the die to damage and knocks the target prone on a failed Strength save
+	TripAttack Maneuver = "trip_attack"
+)
+
+// Name returns the display name of the maneuver
+func Name(m Maneuver) string {
+	switch m {
+	case PrecisionAttack:
+		return "Precision Attack"
+	case Riposte:
+		return "Riposte"
+	case TripAttack:
+		return "Trip Attack"
+	default:
+		return m
+	}
+}
+
+// Description returns the mechanical description of the maneuver
+func Description(m Maneuver) string {
+	switch m {
+	case PrecisionAttack:
+		return "When you make a weapon attack roll against a creature, you can expend one superiority die to add it to the roll." //nolint:lll
+	case Riposte:
+		return "When a creature misses you with a melee attack, you can use your reaction and expend one superiority die to make a melee weapon attack against the creature. If you hit, you add the superiority die to the attack's damage roll." //nolint:lll
+	case TripAttack:
+		return "When you hit a creature with a weapon attack, you can expend one superiority die to add it to the damage roll. If the target is Large or smaller, it must make a Strength saving throw. On a failed save, you knock the target prone." //nolint:lll
+	default:
+		return ""
+	}
+}
+
+// All returns all available maneuvers
+func All() []Maneuver {
+	return []Maneuver{
+		PrecisionAttack,
+		Riposte,
+		TripAttack,
+	}
+}
+
+// KnownCount returns how many maneuvers a Battle Master of the given fighter level knows
+func KnownCount(fighterLevel int) int {
+	switch {
+	case fighterLevel < 3:
+		return 0
+	case fighterLevel < 7:
+		return 3
+	case fighterLevel < 10:
+		return 5
+	case fighterLevel < 15:
+		return 7
+	default:
+		return 9
+	}
+}
+
+// DieSize returns the size of a Battle Master's superiority dice at a fighter level:
+// d8 at level 3, d10 at 10 and d12 at 18.
+func DieSize(fighterLevel int) int {
+	switch {
+	case fighterLevel >= 18:
+		return 12
+	case fighterLevel >= 10:
+		return 10
+	default:
+		return 8
+	}
+}
+
+// DiceCount returns how many superiority dice a Battle Master of the given fighter level has
+func DiceCount(fighterLevel int) int {
+	switch {
+	case fighterLevel < 3:
+		return 0
+	case fighterLevel < 7:
+		return 4
+	case fighterLevel < 15:
+		return 5
+	default:
+		return 6
+	}
+}
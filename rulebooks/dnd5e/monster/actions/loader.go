@@ -31,6 +31,10 @@ func LoadAction(data monster.ActionData) (monster.MonsterAction, error) {
 		return loadMultiattackAction(data)
 	case "bite":
 		return loadBiteAction(data)
+	case "recharge":
+		return loadRechargeAction(data)
+	case "spell":
+		return loadSpellAction(data)
 	default:
 		return nil, rpgerr.New(rpgerr.CodeNotFound, "unknown action: "+data.Ref.ID)
 	}
@@ -101,6 +105,35 @@ func loadBiteAction(data monster.ActionData) (monster.MonsterAction, error) {
 	return NewBiteAction(config), nil
 }
 
+// loadSpellAction creates a SpellAction from config
+func loadSpellAction(data monster.ActionData) (monster.MonsterAction, error) {
+	var config SpellConfig
+	if err := json.Unmarshal(data.Config, &config); err != nil {
+		return nil, rpgerr.Wrap(err, "failed to unmarshal spell config")
+	}
+	return NewSpellAction(config), nil
+}
+
+// loadRechargeAction creates a RechargeAction and its wrapped action from config
+func loadRechargeAction(data monster.ActionData) (monster.MonsterAction, error) {
+	var config rechargeData
+	if err := json.Unmarshal(data.Config, &config); err != nil {
+		return nil, rpgerr.Wrap(err, "failed to unmarshal recharge config")
+	}
+
+	action, err := LoadAction(config.Action)
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to load recharge action")
+	}
+
+	recharge, err := NewRechargeAction(RechargeConfig{Action: action, RechargeOn: config.RechargeOn})
+	if err != nil {
+		return nil, err
+	}
+	recharge.SetCharged(config.Charged)
+	return recharge, nil
+}
+
 // LoadMonsterActions is a helper function that loads actions from ActionData
 // and adds them to a monster. This is needed because the monster package
 // cannot import the actions package directly (import cycle).
@@ -18,8 +18,8 @@ type MultiattackConfig struct {
 	Attacks []string `json:"attacks"` // Names of actions to chain, e.g., ["bite", "claw", "claw"]
 }
 
-// MultiattackAction executes multiple attacks in sequence.
-// This is typically used for boss monsters.
+// MultiattackAction executes multiple attacks in sequence for the cost of one action.
+// Most monsters of CR 1 and above have one.
 type MultiattackAction struct {
 	attacks []string
 }
@@ -67,8 +67,10 @@ func (m *MultiattackAction) Score(_ *monster.Monster, perception *monster.Percep
 	return baseScore
 }
 
-// CanActivate checks if the action can be used
-func (m *MultiattackAction) CanActivate(_ context.Context, owner core.Entity, input monster.MonsterActionInput) error {
+// CanActivate checks if the action can be used.
+// The monster needs an action available, a primary target, every named
+// sub-action, and at least one sub-action that can reach some enemy.
+func (m *MultiattackAction) CanActivate(ctx context.Context, owner core.Entity, input monster.MonsterActionInput) error {
 	// Owner must be a Monster so we can access its actions
 	monsterOwner, ok := owner.(*monster.Monster)
 	if !ok {
@@ -80,25 +82,35 @@ func (m *MultiattackAction) CanActivate(_ context.Context, owner core.Entity, in
 		return rpgerr.New(rpgerr.CodeInvalidArgument, "no target for multiattack")
 	}
 
-	// Verify all sub-actions exist
-	monsterActions := monsterOwner.Actions()
-	for _, attackName := range m.attacks {
-		found := false
-		for _, action := range monsterActions {
-			if action.GetID() == attackName {
-				found = true
-				break
-			}
+	// Multiattack is a single action, even though it makes several attacks
+	if input.ActionEconomy != nil {
+		if err := input.ActionEconomy.CheckAction(); err != nil {
+			return err
 		}
-		if !found {
-			return rpgerr.New(rpgerr.CodeNotFound, "sub-action not found: "+attackName)
+	}
+
+	// Verify all sub-actions exist
+	subActions, err := m.resolveSubActions(monsterOwner)
+	if err != nil {
+		return err
+	}
+
+	for _, subAction := range subActions {
+		if m.selectStrikeTarget(ctx, owner, subAction, input) != nil {
+			return nil
 		}
 	}
 
-	return nil
+	return rpgerr.New(rpgerr.CodeOutOfRange, "no target in range for any multiattack sub-action")
 }
 
-// Activate executes the multiattack
+// Activate executes the multiattack.
+//
+// Each attack picks its own target: the primary target when the sub-action
+// can reach it, otherwise the closest perceived enemy it can reach. Attacks
+// with no reachable target are skipped. When an ActionEconomy is provided,
+// the multiattack grants one attack per sub-action and each attack made
+// consumes one. The action itself is consumed by the caller (TakeTurn).
 func (m *MultiattackAction) Activate(ctx context.Context, owner core.Entity, input monster.MonsterActionInput) error {
 	// Validate we can activate
 	if err := m.CanActivate(ctx, owner, input); err != nil {
@@ -111,10 +123,43 @@ func (m *MultiattackAction) Activate(ctx context.Context, owner core.Entity, inp
 		return rpgerr.New(rpgerr.CodeInvalidArgument, "owner must be a monster")
 	}
 
+	subActions, err := m.resolveSubActions(monsterOwner)
+	if err != nil {
+		return err
+	}
+
+	if input.ActionEconomy != nil {
+		input.ActionEconomy.SetAttacks(len(subActions))
+	}
+
 	// Execute each attack in sequence
-	monsterActions := monsterOwner.Actions()
+	for _, subAction := range subActions {
+		target := m.selectStrikeTarget(ctx, owner, subAction, input)
+		if target == nil {
+			continue
+		}
+
+		if input.ActionEconomy != nil {
+			if err := input.ActionEconomy.UseAttack(); err != nil {
+				return err
+			}
+		}
+
+		subInput := input
+		subInput.Target = target
+
+		// Note: If one attack fails, we continue with the rest.
+		_ = subAction.Activate(ctx, owner, subInput)
+	}
+
+	return nil
+}
+
+// resolveSubActions looks up the monster's action for each named attack, in order.
+func (m *MultiattackAction) resolveSubActions(owner *monster.Monster) ([]monster.MonsterAction, error) {
+	monsterActions := owner.Actions()
+	subActions := make([]monster.MonsterAction, 0, len(m.attacks))
 	for _, attackName := range m.attacks {
-		// Find the action
 		var subAction monster.MonsterAction
 		for _, action := range monsterActions {
 			if action.GetID() == attackName {
@@ -122,18 +167,39 @@ func (m *MultiattackAction) Activate(ctx context.Context, owner core.Entity, inp
 				break
 			}
 		}
-
 		if subAction == nil {
-			// Skip missing actions (shouldn't happen after CanActivate)
-			continue
+			return nil, rpgerr.New(rpgerr.CodeNotFound, "sub-action not found: "+attackName)
 		}
+		subActions = append(subActions, subAction)
+	}
+	return subActions, nil
+}
 
-		// Execute the sub-action
-		// Note: We don't check CanActivate for each sub-action since multiattack
-		// is a single action that chains attacks. If one fails, we continue with the rest.
-		_ = subAction.Activate(ctx, owner, input)
+// selectStrikeTarget picks the target for one attack of the multiattack.
+// Prefers the primary target, then the closest perceived enemy the sub-action
+// can reach. Returns nil if there is none.
+func (m *MultiattackAction) selectStrikeTarget(
+	ctx context.Context,
+	owner core.Entity,
+	subAction monster.MonsterAction,
+	input monster.MonsterActionInput,
+) core.Entity {
+	if subAction.CanActivate(ctx, owner, input) == nil {
+		return input.Target
+	}
+	if input.Perception == nil {
+		return nil
 	}
 
+	for _, enemy := range input.Perception.Enemies {
+		if enemy.Entity == nil || enemy.Entity.GetID() == input.Target.GetID() {
+			continue
+		}
+		input.Target = enemy.Entity
+		if subAction.CanActivate(ctx, owner, input) == nil {
+			return enemy.Entity
+		}
+	}
 	return nil
 }
 
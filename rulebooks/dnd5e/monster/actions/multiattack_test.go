@@ -11,6 +11,7 @@ import (
 
 	"github.com/KirkDiggler/rpg-toolkit/dice"
 	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
 	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
@@ -255,3 +256,134 @@ func (s *MultiattackActionTestSuite) TestToData() {
 	s.Assert().Contains(string(data.Config), "bite")
 	s.Assert().Contains(string(data.Config), "claw")
 }
+
+func (s *MultiattackActionTestSuite) TestActivate_PicksTargetPerAttack() {
+	// Arrange - the primary target is out of claw reach but within spit range
+	action := NewMultiattackAction(MultiattackConfig{
+		Attacks: []string{"spit", "claw", "claw"},
+	})
+
+	m := monster.New(monster.Config{ID: "boss-1", Name: "Boss", HP: 50, AC: 16})
+	m.AddAction(NewRangedAction(RangedConfig{
+		Name:        "spit",
+		AttackBonus: 4,
+		DamageDice:  "1d6",
+		RangeNormal: 6,
+		RangeLong:   12,
+		DamageType:  damage.Acid,
+	}))
+	m.AddAction(NewMeleeAction(MeleeConfig{
+		Name:        "claw",
+		AttackBonus: 5,
+		DamageDice:  "1d6+3",
+		Reach:       1,
+		DamageType:  damage.Slashing,
+	}))
+
+	far := &mockEntity{id: "wizard"}
+	near := &mockEntity{id: "fighter"}
+	perception := &monster.PerceptionData{
+		MyPosition: hexAt(0),
+		Enemies: []monster.PerceivedEntity{
+			{Entity: near, Position: hexAt(1), Distance: 1, Adjacent: true},
+			{Entity: far, Position: hexAt(4), Distance: 4},
+		},
+	}
+
+	var targets []string
+	_, err := dnd5eEvents.AttackTopic.On(s.bus).Subscribe(context.Background(),
+		func(_ context.Context, event dnd5eEvents.AttackEvent) error {
+			targets = append(targets, event.WeaponRef+":"+event.TargetID)
+			return nil
+		})
+	s.Require().NoError(err)
+
+	economy := combat.NewActionEconomy()
+	input := monster.MonsterActionInput{
+		Bus:           s.bus,
+		Target:        far,
+		Perception:    perception,
+		ActionEconomy: economy,
+		Roller:        s.roller,
+	}
+
+	// Act
+	err = action.Activate(context.Background(), m, input)
+
+	// Assert - claws fall back to the adjacent enemy
+	s.Require().NoError(err)
+	s.Equal([]string{"spit:wizard", "claw:fighter", "claw:fighter"}, targets)
+	s.Equal(0, economy.AttacksRemaining)
+	s.Equal(1, economy.ActionsRemaining, "the caller consumes the action")
+}
+
+func (s *MultiattackActionTestSuite) TestActivate_SkipsUnreachableAttacks() {
+	// Arrange - only the ranged attack can reach anyone
+	action := NewMultiattackAction(MultiattackConfig{
+		Attacks: []string{"spit", "claw"},
+	})
+
+	m := monster.New(monster.Config{ID: "boss-1", Name: "Boss", HP: 50, AC: 16})
+	m.AddAction(NewRangedAction(RangedConfig{
+		Name: "spit", AttackBonus: 4, DamageDice: "1d6", RangeNormal: 6, RangeLong: 12, DamageType: damage.Acid,
+	}))
+	m.AddAction(NewMeleeAction(MeleeConfig{
+		Name: "claw", AttackBonus: 5, DamageDice: "1d6+3", Reach: 1, DamageType: damage.Slashing,
+	}))
+
+	far := &mockEntity{id: "wizard"}
+	economy := combat.NewActionEconomy()
+	input := monster.MonsterActionInput{
+		Bus:    s.bus,
+		Target: far,
+		Perception: &monster.PerceptionData{
+			Enemies: []monster.PerceivedEntity{{Entity: far, Position: hexAt(4), Distance: 4}},
+		},
+		ActionEconomy: economy,
+		Roller:        s.roller,
+	}
+
+	// Act
+	err := action.Activate(context.Background(), m, input)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(1, economy.AttacksRemaining, "the claw had no target")
+}
+
+func (s *MultiattackActionTestSuite) TestCanActivate_RequiresActionAndReachableTarget() {
+	action := NewMultiattackAction(MultiattackConfig{Attacks: []string{"claw", "claw"}})
+
+	m := monster.New(monster.Config{ID: "boss-1", Name: "Boss", HP: 50, AC: 16})
+	m.AddAction(NewMeleeAction(MeleeConfig{
+		Name: "claw", AttackBonus: 5, DamageDice: "1d6+3", Reach: 1, DamageType: damage.Slashing,
+	}))
+
+	target := &mockEntity{id: "hero-1"}
+	input := monster.MonsterActionInput{
+		Target: target,
+		Perception: &monster.PerceptionData{
+			Enemies: []monster.PerceivedEntity{{Entity: target, Position: hexAt(3), Distance: 3}},
+		},
+	}
+
+	s.Run("out of reach", func() {
+		err := action.CanActivate(context.Background(), m, input)
+		s.Require().Error(err)
+		s.Equal(rpgerr.CodeOutOfRange, rpgerr.GetCode(err))
+	})
+
+	s.Run("no action left", func() {
+		economy := combat.NewActionEconomy()
+		s.Require().NoError(economy.UseAction())
+
+		exhausted := input
+		exhausted.ActionEconomy = economy
+		exhausted.Perception = &monster.PerceptionData{
+			Enemies: []monster.PerceivedEntity{{Entity: target, Position: hexAt(1), Distance: 1, Adjacent: true}},
+		}
+		err := action.CanActivate(context.Background(), m, exhausted)
+		s.Require().Error(err)
+		s.Equal(rpgerr.CodeResourceExhausted, rpgerr.GetCode(err))
+	})
+}
@@ -0,0 +1,138 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package actions
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// RechargeConfig holds configuration for creating a recharge action
+type RechargeConfig struct {
+	// Action is the wrapped action, e.g. a breath weapon
+	Action monster.MonsterAction
+
+	// RechargeOn is the lowest d6 roll that recharges the action (5 for "Recharge 5–6")
+	RechargeOn int
+}
+
+// rechargeData is the serializable form of a recharge action
+type rechargeData struct {
+	RechargeOn int                `json:"recharge_on"`
+	Charged    bool               `json:"charged"`
+	Action     monster.ActionData `json:"action"`
+}
+
+// RechargeAction wraps an action with a "Recharge X–6" limit.
+// It starts charged, locks after a successful activation, and is recharged by
+// Monster.RollRecharges at the start of the monster's turn.
+type RechargeAction struct {
+	action     monster.MonsterAction
+	rechargeOn int
+	charged    bool
+}
+
+// Ensure RechargeAction implements RechargeableAction
+var _ monster.RechargeableAction = (*RechargeAction)(nil)
+
+// NewRechargeAction creates a charged recharge action with the given config
+func NewRechargeAction(config RechargeConfig) (*RechargeAction, error) {
+	if config.Action == nil {
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "recharge action requires an action")
+	}
+	if config.RechargeOn < 1 || config.RechargeOn > 6 {
+		return nil, rpgerr.Newf(rpgerr.CodeInvalidArgument, "recharge roll must be 1-6, got %d", config.RechargeOn)
+	}
+
+	return &RechargeAction{
+		action:     config.Action,
+		rechargeOn: config.RechargeOn,
+		charged:    true,
+	}, nil
+}
+
+// GetID implements core.Entity (the wrapped action's ID)
+func (r *RechargeAction) GetID() string {
+	return r.action.GetID()
+}
+
+// GetType implements core.Entity
+func (r *RechargeAction) GetType() core.EntityType {
+	return monsterActionEntityType
+}
+
+// Cost returns the wrapped action's cost
+func (r *RechargeAction) Cost() monster.ActionCost {
+	return r.action.Cost()
+}
+
+// ActionType returns the wrapped action's type
+func (r *RechargeAction) ActionType() monster.ActionType {
+	return r.action.ActionType()
+}
+
+// Score returns the wrapped action's score, or -1000 while the action is
+// spent so TakeTurn never selects it.
+func (r *RechargeAction) Score(m *monster.Monster, perception *monster.PerceptionData) int {
+	if !r.charged {
+		return -1000
+	}
+	return r.action.Score(m, perception)
+}
+
+// RechargeOn returns the lowest d6 roll that recharges the action
+func (r *RechargeAction) RechargeOn() int {
+	return r.rechargeOn
+}
+
+// IsCharged returns true if the action can be used
+func (r *RechargeAction) IsCharged() bool {
+	return r.charged
+}
+
+// SetCharged sets whether the action can be used
+func (r *RechargeAction) SetCharged(charged bool) {
+	r.charged = charged
+}
+
+// CanActivate checks if the action is charged and the wrapped action can be used
+func (r *RechargeAction) CanActivate(ctx context.Context, owner core.Entity, input monster.MonsterActionInput) error {
+	if !r.charged {
+		return rpgerr.Newf(rpgerr.CodeCooldownActive, "%s has not recharged", r.action.GetID())
+	}
+	return r.action.CanActivate(ctx, owner, input)
+}
+
+// Activate executes the wrapped action and spends the charge
+func (r *RechargeAction) Activate(ctx context.Context, owner core.Entity, input monster.MonsterActionInput) error {
+	if err := r.CanActivate(ctx, owner, input); err != nil {
+		return err
+	}
+
+	if err := r.action.Activate(ctx, owner, input); err != nil {
+		return err
+	}
+
+	r.charged = false
+	return nil
+}
+
+// ToData converts the action to its serializable form
+func (r *RechargeAction) ToData() monster.ActionData {
+	configJSON, _ := json.Marshal(rechargeData{
+		RechargeOn: r.rechargeOn,
+		Charged:    r.charged,
+		Action:     r.action.ToData(),
+	})
+
+	return monster.ActionData{
+		Ref:    *refs.MonsterActions.Recharge(),
+		Config: configJSON,
+	}
+}
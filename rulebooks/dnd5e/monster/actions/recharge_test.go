@@ -0,0 +1,163 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
+)
+
+type RechargeActionTestSuite struct {
+	suite.Suite
+	ctrl   *gomock.Controller
+	ctx    context.Context
+	bus    events.EventBus
+	roller *mock_dice.MockRoller
+	target *mockEntity
+}
+
+func TestRechargeActionSuite(t *testing.T) {
+	suite.Run(t, new(RechargeActionTestSuite))
+}
+
+func (s *RechargeActionTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.roller = mock_dice.NewMockRoller(s.ctrl)
+	s.target = &mockEntity{id: "hero-1"}
+}
+
+func (s *RechargeActionTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// newBreath creates a recharge 5-6 ranged "fire-breath" action
+func (s *RechargeActionTestSuite) newBreath() *RechargeAction {
+	breath, err := NewRechargeAction(RechargeConfig{
+		Action: NewRangedAction(RangedConfig{
+			Name:        "fire-breath",
+			AttackBonus: 5,
+			DamageDice:  "7d6",
+			RangeNormal: 3,
+			RangeLong:   3,
+			DamageType:  damage.Fire,
+		}),
+		RechargeOn: 5,
+	})
+	s.Require().NoError(err)
+	return breath
+}
+
+func (s *RechargeActionTestSuite) input() monster.MonsterActionInput {
+	return monster.MonsterActionInput{
+		Bus:    s.bus,
+		Target: s.target,
+		Perception: &monster.PerceptionData{
+			Enemies: []monster.PerceivedEntity{{Entity: s.target, Position: hexAt(2), Distance: 2}},
+		},
+		ActionEconomy: combat.NewActionEconomy(),
+		Roller:        s.roller,
+	}
+}
+
+func (s *RechargeActionTestSuite) TestNewRechargeAction_Validation() {
+	_, err := NewRechargeAction(RechargeConfig{RechargeOn: 5})
+	s.Error(err)
+
+	_, err = NewRechargeAction(RechargeConfig{Action: NewMultiattackAction(MultiattackConfig{}), RechargeOn: 7})
+	s.Error(err)
+}
+
+func (s *RechargeActionTestSuite) TestActivateLocksAction() {
+	breath := s.newBreath()
+	s.Equal("fire-breath", breath.GetID())
+	s.True(breath.IsCharged())
+
+	s.Require().NoError(breath.Activate(s.ctx, &mockEntity{id: "dragon"}, s.input()))
+	s.False(breath.IsCharged())
+
+	err := breath.CanActivate(s.ctx, &mockEntity{id: "dragon"}, s.input())
+	s.Require().Error(err)
+	s.Equal(rpgerr.CodeCooldownActive, rpgerr.GetCode(err))
+	s.Equal(-1000, breath.Score(nil, s.input().Perception))
+}
+
+func (s *RechargeActionTestSuite) TestRollRecharges() {
+	var rolled []dnd5eEvents.RechargeRolledEvent
+	_, err := dnd5eEvents.RechargeRolledTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, event dnd5eEvents.RechargeRolledEvent) error {
+			rolled = append(rolled, event)
+			return nil
+		})
+	s.Require().NoError(err)
+
+	m := monster.New(monster.Config{ID: "dragon", Name: "Dragon", HP: 50, AC: 17})
+	breath := s.newBreath()
+	m.AddAction(breath)
+
+	// Charged actions aren't rolled
+	s.Require().NoError(m.RollRecharges(s.ctx, s.bus, s.roller))
+	s.Empty(rolled)
+
+	breath.SetCharged(false)
+	s.roller.EXPECT().Roll(gomock.Any(), 6).Return(4, nil)
+	s.Require().NoError(m.RollRecharges(s.ctx, s.bus, s.roller))
+	s.False(breath.IsCharged())
+
+	s.roller.EXPECT().Roll(gomock.Any(), 6).Return(5, nil)
+	s.Require().NoError(m.RollRecharges(s.ctx, s.bus, s.roller))
+	s.True(breath.IsCharged())
+
+	s.Equal([]dnd5eEvents.RechargeRolledEvent{
+		{OwnerID: "dragon", ActionID: "fire-breath", Roll: 4, RechargeOn: 5, Recharged: false},
+		{OwnerID: "dragon", ActionID: "fire-breath", Roll: 5, RechargeOn: 5, Recharged: true},
+	}, rolled)
+}
+
+func (s *RechargeActionTestSuite) TestTakeTurnRollsRechargeFirst() {
+	m := monster.New(monster.Config{ID: "dragon", Name: "Dragon", HP: 50, AC: 17})
+	breath := s.newBreath()
+	breath.SetCharged(false)
+	m.AddAction(breath)
+
+	s.roller.EXPECT().Roll(gomock.Any(), 6).Return(6, nil)
+
+	result, err := m.TakeTurn(s.ctx, &monster.TurnInput{
+		Bus:           s.bus,
+		ActionEconomy: combat.NewActionEconomy(),
+		Perception:    s.input().Perception,
+		Roller:        s.roller,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(result.Actions, 1)
+	s.Equal("fire-breath", result.Actions[0].ActionID)
+	s.False(breath.IsCharged())
+}
+
+func (s *RechargeActionTestSuite) TestToDataRoundTrip() {
+	breath := s.newBreath()
+	breath.SetCharged(false)
+
+	loaded, err := LoadAction(breath.ToData())
+	s.Require().NoError(err)
+
+	recharge, ok := loaded.(*RechargeAction)
+	s.Require().True(ok)
+	s.Equal("fire-breath", recharge.GetID())
+	s.Equal(5, recharge.RechargeOn())
+	s.False(recharge.IsCharged())
+	s.Equal(monster.TypeRangedAttack, recharge.ActionType())
+}
@@ -0,0 +1,180 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package actions
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	coreCombat "github.com/KirkDiggler/rpg-toolkit/core/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/spells"
+)
+
+// SpellConfig holds configuration for creating a spell action
+type SpellConfig struct {
+	Spell       spells.Spell       `json:"spell"`                 // e.g., spells.Fireball
+	SlotLevel   int                `json:"slot_level,omitempty"`  // 0 = lowest available slot
+	Innate      bool               `json:"innate,omitempty"`      // Cast with innate uses instead of slots
+	Range       int                `json:"range,omitempty"`       // in hexes, 0 = no range check (self/touch handled by caller)
+	ActionType  monster.ActionType `json:"action_type,omitempty"` // For target selection, default ranged attack
+	BonusAction bool               `json:"bonus_action,omitempty"`
+}
+
+// SpellAction casts one of the monster's spells through spells.Cast.
+// The monster's spellcasting (set with Monster.SetSpellcasting) supplies the
+// slots or innate uses. Like the attack actions, it publishes the cast and
+// leaves the effect to the combat system.
+type SpellAction struct {
+	config SpellConfig
+}
+
+// Ensure SpellAction implements MonsterAction
+var _ monster.MonsterAction = (*SpellAction)(nil)
+
+// NewSpellAction creates a spell action with the given config
+func NewSpellAction(config SpellConfig) *SpellAction {
+	if config.ActionType == "" {
+		config.ActionType = monster.TypeRangedAttack
+	}
+	return &SpellAction{config: config}
+}
+
+// GetID implements core.Entity (the spell's ID)
+func (s *SpellAction) GetID() string {
+	return s.config.Spell
+}
+
+// GetType implements core.Entity
+func (s *SpellAction) GetType() core.EntityType {
+	return monsterActionEntityType
+}
+
+// Cost returns the action economy cost (an action, or a bonus action)
+func (s *SpellAction) Cost() monster.ActionCost {
+	if s.config.BonusAction {
+		return monster.CostBonusAction
+	}
+	return monster.CostAction
+}
+
+// Spell returns the spell this action casts
+func (s *SpellAction) Spell() spells.Spell {
+	return s.config.Spell
+}
+
+// ActionType returns the type of action for target selection
+func (s *SpellAction) ActionType() monster.ActionType {
+	return s.config.ActionType
+}
+
+// Score returns how desirable this action is in the current situation.
+// Leveled spells outscore weapon attacks while the monster can pay for them.
+func (s *SpellAction) Score(m *monster.Monster, _ *monster.PerceptionData) int {
+	if m == nil || !s.canPay(m.Spellcasting()) {
+		return -1000
+	}
+
+	baseScore := 55
+	if data := spells.GetData(s.config.Spell); data != nil {
+		baseScore += 5 * data.Level
+	}
+	return baseScore
+}
+
+// canPay checks whether the spellcasting has the slot or innate use this action needs
+func (s *SpellAction) canPay(spellcasting *spells.Spellcasting) bool {
+	if spellcasting == nil {
+		return false
+	}
+	if s.config.Innate {
+		return spellcasting.InnateRemaining(s.config.Spell) != 0
+	}
+	if !spellcasting.Knows(s.config.Spell) {
+		return false
+	}
+
+	data := spells.GetData(s.config.Spell)
+	if data == nil || data.Level == 0 {
+		return true
+	}
+	if s.config.SlotLevel > 0 {
+		return spellcasting.SlotsRemaining(s.config.SlotLevel) > 0
+	}
+	return spellcasting.LowestSlot(data.Level) > 0
+}
+
+// CanActivate checks if the action can be used
+func (s *SpellAction) CanActivate(_ context.Context, owner core.Entity, input monster.MonsterActionInput) error {
+	monsterOwner, ok := owner.(*monster.Monster)
+	if !ok {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "owner must be a monster")
+	}
+	if !s.canPay(monsterOwner.Spellcasting()) {
+		return rpgerr.Newf(rpgerr.CodeResourceExhausted, "%s can't cast %s", owner.GetID(), s.config.Spell)
+	}
+
+	if s.config.Range == 0 || input.Target == nil || input.Target.GetID() == owner.GetID() {
+		return nil
+	}
+	if input.Perception == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "no perception data")
+	}
+	for _, enemy := range input.Perception.Enemies {
+		if enemy.Entity.GetID() == input.Target.GetID() && enemy.Distance <= s.config.Range {
+			return nil
+		}
+	}
+	return rpgerr.New(rpgerr.CodeOutOfRange, "target out of spell range")
+}
+
+// Activate casts the spell. The action economy is spent by the caller
+// (TakeTurn), so the cast itself only spends the slot or innate use.
+func (s *SpellAction) Activate(ctx context.Context, owner core.Entity, input monster.MonsterActionInput) error {
+	if err := s.CanActivate(ctx, owner, input); err != nil {
+		return err
+	}
+	monsterOwner, ok := owner.(*monster.Monster)
+	if !ok {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "owner must be a monster")
+	}
+
+	var targetIDs []string
+	if input.Target != nil {
+		targetIDs = []string{input.Target.GetID()}
+	}
+
+	castingTime := coreCombat.ActionStandard
+	if s.config.BonusAction {
+		castingTime = coreCombat.ActionBonus
+	}
+
+	_, err := spells.Cast(ctx, &spells.CastInput{
+		CasterID:     owner.GetID(),
+		Spellcasting: monsterOwner.Spellcasting(),
+		Spell:        s.config.Spell,
+		SlotLevel:    s.config.SlotLevel,
+		Innate:       s.config.Innate,
+		TargetIDs:    targetIDs,
+		CastingTime:  castingTime,
+		EventBus:     input.Bus,
+	})
+	if err != nil {
+		return rpgerr.Wrapf(err, "failed to cast %s", s.config.Spell)
+	}
+	return nil
+}
+
+// ToData converts the action to its serializable form
+func (s *SpellAction) ToData() monster.ActionData {
+	configJSON, _ := json.Marshal(s.config)
+
+	return monster.ActionData{
+		Ref:    *refs.MonsterActions.Spell(),
+		Config: configJSON,
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/spells"
+)
+
+type SpellActionTestSuite struct {
+	suite.Suite
+	ctx    context.Context
+	bus    events.EventBus
+	mage   *monster.Monster
+	target *mockEntity
+	casts  []dnd5eEvents.SpellCastEvent
+}
+
+func TestSpellActionSuite(t *testing.T) {
+	suite.Run(t, new(SpellActionTestSuite))
+}
+
+func (s *SpellActionTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.target = &mockEntity{id: "hero-1"}
+	s.casts = nil
+
+	s.mage = monster.New(monster.Config{ID: "mage-1", Name: "Mage", HP: 40, AC: 12})
+	spellcasting, err := spells.NewSpellcasting(&spells.SpellcastingData{
+		Ability:     abilities.INT,
+		SaveDC:      14,
+		AttackBonus: 6,
+		Known:       []spells.Spell{spells.FireBolt, spells.Fireball},
+		Slots:       map[int]spells.SlotData{3: {Max: 1}},
+		Innate:      map[spells.Spell]spells.InnateData{spells.MistyStep: {PerDay: 1}},
+	})
+	s.Require().NoError(err)
+	s.mage.SetSpellcasting(spellcasting)
+
+	_, err = dnd5eEvents.SpellCastTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, event dnd5eEvents.SpellCastEvent) error {
+			s.casts = append(s.casts, event)
+			return nil
+		})
+	s.Require().NoError(err)
+}
+
+func (s *SpellActionTestSuite) input(distance int) monster.MonsterActionInput {
+	return monster.MonsterActionInput{
+		Bus:    s.bus,
+		Target: s.target,
+		Perception: &monster.PerceptionData{
+			Enemies: []monster.PerceivedEntity{{Entity: s.target, Position: hexAt(distance), Distance: distance}},
+		},
+		ActionEconomy: combat.NewActionEconomy(),
+	}
+}
+
+func (s *SpellActionTestSuite) TestCastSpendsSlot() {
+	fireball := NewSpellAction(SpellConfig{Spell: spells.Fireball, Range: 30})
+
+	s.Greater(fireball.Score(s.mage, nil), 60)
+	s.Require().NoError(fireball.Activate(s.ctx, s.mage, s.input(5)))
+
+	s.Equal([]dnd5eEvents.SpellCastEvent{
+		{CasterID: "mage-1", SpellID: spells.Fireball, SpellLevel: 3, SlotLevel: 3, TargetIDs: []string{"hero-1"}},
+	}, s.casts)
+	s.Equal(0, s.mage.Spellcasting().SlotsRemaining(3))
+
+	// Out of slots
+	s.Equal(-1000, fireball.Score(s.mage, nil))
+	err := fireball.CanActivate(s.ctx, s.mage, s.input(5))
+	s.Equal(rpgerr.CodeResourceExhausted, rpgerr.GetCode(err))
+
+	// Cantrips are still available
+	fireBolt := NewSpellAction(SpellConfig{Spell: spells.FireBolt, Range: 24})
+	s.Require().NoError(fireBolt.Activate(s.ctx, s.mage, s.input(5)))
+	s.Len(s.casts, 2)
+}
+
+func (s *SpellActionTestSuite) TestInnatePerDay() {
+	mistyStep := NewSpellAction(SpellConfig{
+		Spell:       spells.MistyStep,
+		Innate:      true,
+		BonusAction: true,
+		ActionType:  monster.TypeMovement,
+	})
+	s.Equal(monster.CostBonusAction, mistyStep.Cost())
+
+	input := s.input(1)
+	input.Target = s.mage
+	s.Require().NoError(mistyStep.Activate(s.ctx, s.mage, input))
+	s.Require().Len(s.casts, 1)
+	s.True(s.casts[0].Innate)
+
+	err := mistyStep.Activate(s.ctx, s.mage, input)
+	s.Equal(rpgerr.CodeResourceExhausted, rpgerr.GetCode(err))
+
+	s.mage.Spellcasting().LongRest()
+	s.NoError(mistyStep.CanActivate(s.ctx, s.mage, input))
+}
+
+func (s *SpellActionTestSuite) TestCanActivate() {
+	fireBolt := NewSpellAction(SpellConfig{Spell: spells.FireBolt, Range: 24})
+
+	s.Run("out of range", func() {
+		err := fireBolt.CanActivate(s.ctx, s.mage, s.input(25))
+		s.Equal(rpgerr.CodeOutOfRange, rpgerr.GetCode(err))
+	})
+
+	s.Run("unknown spell", func() {
+		err := NewSpellAction(SpellConfig{Spell: spells.Sleep}).CanActivate(s.ctx, s.mage, s.input(1))
+		s.Equal(rpgerr.CodeResourceExhausted, rpgerr.GetCode(err))
+	})
+
+	s.Run("monster without spellcasting", func() {
+		goblin := monster.New(monster.Config{ID: "goblin-1", Name: "Goblin", HP: 7, AC: 15})
+		s.Error(fireBolt.CanActivate(s.ctx, goblin, s.input(1)))
+		s.Equal(-1000, fireBolt.Score(goblin, nil))
+	})
+}
+
+func (s *SpellActionTestSuite) TestDataRoundTrip() {
+	s.mage.AddAction(NewSpellAction(SpellConfig{Spell: spells.Fireball, SlotLevel: 3, Range: 30}))
+	s.Require().NoError(s.mage.Actions()[0].Activate(s.ctx, s.mage, s.input(5)))
+
+	data := s.mage.ToData()
+	s.Require().NotNil(data.Spellcasting)
+
+	loaded, err := monster.LoadFromData(s.ctx, data, s.bus)
+	s.Require().NoError(err)
+	s.Require().NoError(LoadMonsterActions(loaded, data.Actions))
+
+	s.Require().NotNil(loaded.Spellcasting())
+	s.Equal(0, loaded.Spellcasting().SlotsRemaining(3))
+	s.Equal(1, loaded.Spellcasting().InnateRemaining(spells.MistyStep))
+	s.Require().Len(loaded.Actions(), 1)
+	s.Equal(data.Actions, loaded.ToData().Actions)
+}
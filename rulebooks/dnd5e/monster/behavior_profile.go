@@ -0,0 +1,158 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package monster
+
+import (
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/spells"
+)
+
+// BehaviorProfile names a monster combat archetype
+type BehaviorProfile string
+
+// Behavior profile constants
+const (
+	// BehaviorBrute wades into melee with the closest enemy
+	BehaviorBrute BehaviorProfile = "brute"
+	// BehaviorSkirmisher closes in on wounded enemies to finish them off
+	BehaviorSkirmisher BehaviorProfile = "skirmisher"
+	// BehaviorArtillery keeps its distance and shoots the easiest target
+	BehaviorArtillery BehaviorProfile = "artillery"
+	// BehaviorController hangs back and leads with spells
+	BehaviorController BehaviorProfile = "controller"
+)
+
+// Behavior tunes how TakeTurn picks actions, targets, and movement.
+// Profiles provide sensible defaults; games can adjust any field.
+type Behavior struct {
+	// Profile is the archetype this behavior was built from
+	Profile BehaviorProfile `json:"profile"`
+
+	// Targeting picks among enemies for attacks
+	Targeting TargetingStrategy `json:"targeting"`
+
+	// ActionBias is added to the score of usable actions of each type,
+	// so a profile favors its kind of action without ignoring the rest.
+	// Spell actions use the TypeSpell bias whatever their targeting type.
+	ActionBias map[ActionType]int `json:"action_bias,omitempty"`
+
+	// HoldRange is how many hexes from the closest enemy the monster stops
+	// when it has a ranged attack or spell. 0 always closes to melee.
+	HoldRange int `json:"hold_range,omitempty"`
+}
+
+// NewBehavior returns the default behavior for a profile.
+// Unknown profiles get the brute behavior.
+func NewBehavior(profile BehaviorProfile) *Behavior {
+	switch profile {
+	case BehaviorSkirmisher:
+		return &Behavior{
+			Profile:    BehaviorSkirmisher,
+			Targeting:  TargetLowestHP,
+			ActionBias: map[ActionType]int{TypeMeleeAttack: 5},
+		}
+	case BehaviorArtillery:
+		return &Behavior{
+			Profile:    BehaviorArtillery,
+			Targeting:  TargetLowestAC,
+			ActionBias: map[ActionType]int{TypeRangedAttack: 15, TypeSpell: 10},
+			HoldRange:  6, // 30 feet
+		}
+	case BehaviorController:
+		return &Behavior{
+			Profile:    BehaviorController,
+			Targeting:  TargetClosest,
+			ActionBias: map[ActionType]int{TypeSpell: 20},
+			HoldRange:  4, // 20 feet
+		}
+	default:
+		return &Behavior{
+			Profile:    BehaviorBrute,
+			Targeting:  TargetClosest,
+			ActionBias: map[ActionType]int{TypeMeleeAttack: 10},
+		}
+	}
+}
+
+// DefaultBehavior picks a behavior profile from the monster's actions and
+// abilities: spellcasters are controllers, monsters with more ranged than
+// melee attacks are artillery, nimble monsters (DEX above STR) are
+// skirmishers, and everything else is a brute.
+//
+// The result is not applied; use SetBehavior to opt in:
+//
+//	m.SetBehavior(m.DefaultBehavior())
+func (m *Monster) DefaultBehavior() *Behavior {
+	var melee, ranged, casting int
+	for _, action := range m.actions {
+		switch behaviorType(action) {
+		case TypeMeleeAttack:
+			melee++
+		case TypeRangedAttack:
+			ranged++
+		case TypeSpell:
+			casting++
+		}
+	}
+
+	switch {
+	case casting > 0:
+		return NewBehavior(BehaviorController)
+	case ranged > melee:
+		return NewBehavior(BehaviorArtillery)
+	case m.abilityScores.Modifier(abilities.DEX) > m.abilityScores.Modifier(abilities.STR):
+		return NewBehavior(BehaviorSkirmisher)
+	default:
+		return NewBehavior(BehaviorBrute)
+	}
+}
+
+// SetBehavior sets the monster's behavior and its targeting strategy.
+// Pass nil to return to the plain TakeTurn defaults.
+func (m *Monster) SetBehavior(behavior *Behavior) {
+	m.behavior = behavior
+	if behavior != nil {
+		m.targeting = behavior.Targeting
+	}
+}
+
+// Behavior returns the monster's behavior (nil if none is set)
+func (m *Monster) Behavior() *Behavior {
+	return m.behavior
+}
+
+// actionBias returns the behavior's score bias for an action
+func (m *Monster) actionBias(action MonsterAction) int {
+	if m.behavior == nil {
+		return 0
+	}
+	return m.behavior.ActionBias[behaviorType(action)]
+}
+
+// spellAction is implemented by actions that cast a spell (actions.SpellAction)
+type spellAction interface {
+	Spell() spells.Spell
+}
+
+// behaviorType returns TypeSpell for spell actions and the action's own
+// type otherwise (spell actions report their targeting type, e.g. ranged)
+func behaviorType(action MonsterAction) ActionType {
+	if _, ok := action.(spellAction); ok {
+		return TypeSpell
+	}
+	return action.ActionType()
+}
+
+// stopDistance returns how close the monster moves to its closest enemy
+func (m *Monster) stopDistance() int {
+	if m.behavior == nil || m.behavior.HoldRange <= 1 {
+		return 1
+	}
+	for _, action := range m.actions {
+		if actionType := behaviorType(action); actionType == TypeRangedAttack || actionType == TypeSpell {
+			return m.behavior.HoldRange
+		}
+	}
+	return 1
+}
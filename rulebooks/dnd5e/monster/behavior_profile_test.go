@@ -0,0 +1,174 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package monster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/spells"
+)
+
+// stubAction is a MonsterAction with a fixed type and score
+type stubAction struct {
+	id         string
+	actionType ActionType
+	score      int
+}
+
+func (a *stubAction) GetID() string            { return a.id }
+func (a *stubAction) GetType() core.EntityType { return "monster_action" }
+func (a *stubAction) Cost() ActionCost         { return CostAction }
+func (a *stubAction) ActionType() ActionType   { return a.actionType }
+func (a *stubAction) ToData() ActionData       { return ActionData{} }
+
+func (a *stubAction) Score(_ *Monster, _ *PerceptionData) int { return a.score }
+
+func (a *stubAction) CanActivate(_ context.Context, _ core.Entity, _ MonsterActionInput) error {
+	return nil
+}
+
+func (a *stubAction) Activate(_ context.Context, _ core.Entity, _ MonsterActionInput) error {
+	return nil
+}
+
+// stubSpellAction is a stubAction that casts a spell
+type stubSpellAction struct {
+	stubAction
+}
+
+func (a *stubSpellAction) Spell() spells.Spell { return a.id }
+
+type BehaviorProfileTestSuite struct {
+	suite.Suite
+}
+
+func TestBehaviorProfileSuite(t *testing.T) {
+	suite.Run(t, new(BehaviorProfileTestSuite))
+}
+
+// newMonster creates a monster with the given STR and DEX and actions
+func (s *BehaviorProfileTestSuite) newMonster(str, dex int, actions ...MonsterAction) *Monster {
+	m := New(Config{
+		ID:            "monster-1",
+		Name:          "Monster",
+		HP:            20,
+		AC:            12,
+		AbilityScores: shared.AbilityScores{abilities.STR: str, abilities.DEX: dex},
+	})
+	m.SetSpeed(SpeedData{Walk: 30})
+	for _, action := range actions {
+		m.AddAction(action)
+	}
+	return m
+}
+
+func (s *BehaviorProfileTestSuite) TestNewBehavior() {
+	s.Equal(TargetLowestHP, NewBehavior(BehaviorSkirmisher).Targeting)
+	s.Equal(TargetLowestAC, NewBehavior(BehaviorArtillery).Targeting)
+	s.Equal(4, NewBehavior(BehaviorController).HoldRange)
+	s.Equal(BehaviorBrute, NewBehavior("berserker").Profile, "unknown profiles are brutes")
+}
+
+func (s *BehaviorProfileTestSuite) TestDefaultBehavior() {
+	claw := &stubAction{id: "claw", actionType: TypeMeleeAttack, score: 50}
+	bow := &stubAction{id: "longbow", actionType: TypeRangedAttack, score: 50}
+	crossbow := &stubAction{id: "crossbow", actionType: TypeRangedAttack, score: 50}
+	fireBolt := &stubSpellAction{stubAction{id: "fire-bolt", actionType: TypeRangedAttack, score: 55}}
+
+	testCases := []struct {
+		name     string
+		monster  *Monster
+		expected BehaviorProfile
+	}{
+		{"strong melee monster", s.newMonster(16, 10, claw), BehaviorBrute},
+		{"nimble melee monster", NewGoblin("goblin-1"), BehaviorSkirmisher},
+		{"mostly ranged", s.newMonster(16, 10, claw, bow, crossbow), BehaviorArtillery},
+		{"spellcaster", s.newMonster(8, 12, claw, fireBolt), BehaviorController},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.Equal(tc.expected, tc.monster.DefaultBehavior().Profile)
+			s.Nil(tc.monster.Behavior(), "default behavior is not applied")
+		})
+	}
+}
+
+func (s *BehaviorProfileTestSuite) TestActionBias() {
+	claw := &stubAction{id: "claw", actionType: TypeMeleeAttack, score: 60}
+	bow := &stubAction{id: "longbow", actionType: TypeRangedAttack, score: 50}
+	m := s.newMonster(10, 16, claw, bow)
+	economy := combat.NewActionEconomy()
+
+	s.Equal("claw", m.selectBestAction(economy, &PerceptionData{}).GetID(), "no behavior: highest score")
+
+	m.SetBehavior(NewBehavior(BehaviorArtillery))
+	s.Equal(TargetLowestAC, m.Targeting())
+	s.Equal("longbow", m.selectBestAction(economy, &PerceptionData{}).GetID(), "artillery favors ranged")
+
+	bow.score = -1000
+	s.Equal("claw", m.selectBestAction(economy, &PerceptionData{}).GetID(), "bias doesn't revive unusable actions")
+}
+
+func (s *BehaviorProfileTestSuite) TestHoldRange() {
+	bow := &stubAction{id: "longbow", actionType: TypeRangedAttack, score: 50}
+
+	enemyAt := func(x int) *PerceptionData {
+		return &PerceptionData{
+			MyPosition: hexAt(0),
+			Enemies: []PerceivedEntity{
+				{Entity: &mockTarget{id: "fighter-1"}, Position: hexAt(x), Distance: x},
+			},
+		}
+	}
+
+	s.Run("artillery stops at hold range", func() {
+		m := s.newMonster(10, 16, bow)
+		m.SetBehavior(NewBehavior(BehaviorArtillery))
+
+		result := &TurnResult{}
+		input := &TurnInput{Perception: enemyAt(10)}
+		m.moveTowardEnemy(input, result)
+
+		s.Require().NotEmpty(result.Movement)
+		s.Equal(6, input.Perception.Enemies[0].Distance)
+	})
+
+	s.Run("artillery within hold range stays put", func() {
+		m := s.newMonster(10, 16, bow)
+		m.SetBehavior(NewBehavior(BehaviorArtillery))
+
+		result := &TurnResult{}
+		m.moveTowardEnemy(&TurnInput{Perception: enemyAt(5)}, result)
+		s.Empty(result.Movement)
+	})
+
+	s.Run("without a ranged action it closes to melee", func() {
+		m := s.newMonster(10, 16, &stubAction{id: "claw", actionType: TypeMeleeAttack, score: 50})
+		m.SetBehavior(NewBehavior(BehaviorArtillery))
+
+		result := &TurnResult{}
+		input := &TurnInput{Perception: enemyAt(5)}
+		m.moveTowardEnemy(input, result)
+		s.Equal(1, input.Perception.Enemies[0].Distance)
+	})
+}
+
+func (s *BehaviorProfileTestSuite) TestDataRoundTrip() {
+	goblin := NewGoblin("goblin-1")
+	goblin.SetBehavior(goblin.DefaultBehavior())
+
+	loaded, err := LoadFromData(context.Background(), goblin.ToData(), events.NewEventBus())
+	s.Require().NoError(err)
+	s.Equal(goblin.Behavior(), loaded.Behavior())
+	s.Equal(TargetLowestHP, loaded.Targeting())
+}
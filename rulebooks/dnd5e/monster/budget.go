@@ -0,0 +1,270 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package monster
+
+import (
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+)
+
+// Difficulty is an encounter difficulty tier from the Dungeon Master's Guide
+type Difficulty string
+
+// Encounter difficulty tiers, easiest first
+const (
+	DifficultyTrivial Difficulty = "trivial" // Below the easy threshold
+	DifficultyEasy    Difficulty = "easy"
+	DifficultyMedium  Difficulty = "medium"
+	DifficultyHard    Difficulty = "hard"
+	DifficultyDeadly  Difficulty = "deadly"
+)
+
+// maxCharacterLevel is the highest character level with XP thresholds
+const maxCharacterLevel = 20
+
+// xpByCR is the experience value of a monster by challenge rating
+var xpByCR = map[float64]int{
+	0: 10, 0.125: 25, 0.25: 50, 0.5: 100,
+	1: 200, 2: 450, 3: 700, 4: 1100, 5: 1800,
+	6: 2300, 7: 2900, 8: 3900, 9: 5000, 10: 5900,
+	11: 7200, 12: 8400, 13: 10000, 14: 11500, 15: 13000,
+	16: 15000, 17: 18000, 18: 20000, 19: 22000, 20: 25000,
+	21: 33000, 22: 41000, 23: 50000, 24: 62000, 25: 75000,
+	26: 90000, 27: 105000, 28: 120000, 29: 135000, 30: 155000,
+}
+
+// thresholdsByLevel is each character's XP threshold by level (index 0 = level 1)
+var thresholdsByLevel = [maxCharacterLevel]XPThresholds{
+	{Easy: 25, Medium: 50, Hard: 75, Deadly: 100},
+	{Easy: 50, Medium: 100, Hard: 150, Deadly: 200},
+	{Easy: 75, Medium: 150, Hard: 225, Deadly: 400},
+	{Easy: 125, Medium: 250, Hard: 375, Deadly: 500},
+	{Easy: 250, Medium: 500, Hard: 750, Deadly: 1100},
+	{Easy: 300, Medium: 600, Hard: 900, Deadly: 1400},
+	{Easy: 350, Medium: 750, Hard: 1100, Deadly: 1700},
+	{Easy: 450, Medium: 900, Hard: 1400, Deadly: 2100},
+	{Easy: 550, Medium: 1100, Hard: 1600, Deadly: 2400},
+	{Easy: 600, Medium: 1200, Hard: 1900, Deadly: 2800},
+	{Easy: 800, Medium: 1600, Hard: 2400, Deadly: 3600},
+	{Easy: 1000, Medium: 2000, Hard: 3000, Deadly: 4500},
+	{Easy: 1100, Medium: 2200, Hard: 3400, Deadly: 5100},
+	{Easy: 1250, Medium: 2500, Hard: 3800, Deadly: 5700},
+	{Easy: 1400, Medium: 2800, Hard: 4300, Deadly: 6400},
+	{Easy: 1600, Medium: 3200, Hard: 4800, Deadly: 7200},
+	{Easy: 2000, Medium: 3900, Hard: 5900, Deadly: 8800},
+	{Easy: 2100, Medium: 4200, Hard: 6300, Deadly: 9500},
+	{Easy: 2400, Medium: 4900, Hard: 7300, Deadly: 10900},
+	{Easy: 2800, Medium: 5700, Hard: 8500, Deadly: 12700},
+}
+
+// encounterMultipliers are the XP multipliers for monster count, including
+// the extra steps used to adjust for small and large parties
+var encounterMultipliers = []float64{0.5, 1, 1.5, 2, 2.5, 3, 4, 5}
+
+// XPForCR returns the experience value of a monster with the given challenge
+// rating (use 0.125, 0.25, and 0.5 for CR 1/8, 1/4, and 1/2)
+func XPForCR(cr float64) (int, error) {
+	xp, ok := xpByCR[cr]
+	if !ok {
+		return 0, rpgerr.Newf(rpgerr.CodeInvalidArgument, "invalid challenge rating %v", cr)
+	}
+	return xp, nil
+}
+
+// XPThresholds are the adjusted XP totals at which an encounter becomes each
+// difficulty tier
+type XPThresholds struct {
+	Easy   int `json:"easy"`
+	Medium int `json:"medium"`
+	Hard   int `json:"hard"`
+	Deadly int `json:"deadly"`
+}
+
+// Budget returns the threshold for a difficulty tier (0 for trivial)
+func (t XPThresholds) Budget(difficulty Difficulty) int {
+	switch difficulty {
+	case DifficultyEasy:
+		return t.Easy
+	case DifficultyMedium:
+		return t.Medium
+	case DifficultyHard:
+		return t.Hard
+	case DifficultyDeadly:
+		return t.Deadly
+	default:
+		return 0
+	}
+}
+
+// Rate returns the difficulty tier of an encounter with the given adjusted XP
+func (t XPThresholds) Rate(adjustedXP int) Difficulty {
+	switch {
+	case adjustedXP >= t.Deadly:
+		return DifficultyDeadly
+	case adjustedXP >= t.Hard:
+		return DifficultyHard
+	case adjustedXP >= t.Medium:
+		return DifficultyMedium
+	case adjustedXP >= t.Easy:
+		return DifficultyEasy
+	default:
+		return DifficultyTrivial
+	}
+}
+
+// PartyThresholds sums each character's XP thresholds for a party, given
+// the characters' levels
+func PartyThresholds(levels []int) (XPThresholds, error) {
+	var total XPThresholds
+	if len(levels) == 0 {
+		return total, rpgerr.New(rpgerr.CodeInvalidArgument, "party must have at least one character")
+	}
+
+	for _, level := range levels {
+		if level < 1 || level > maxCharacterLevel {
+			return XPThresholds{}, rpgerr.Newf(rpgerr.CodeInvalidArgument, "invalid character level %d", level)
+		}
+		threshold := thresholdsByLevel[level-1]
+		total.Easy += threshold.Easy
+		total.Medium += threshold.Medium
+		total.Hard += threshold.Hard
+		total.Deadly += threshold.Deadly
+	}
+	return total, nil
+}
+
+// EncounterMultiplier returns the XP multiplier for fighting several monsters
+// at once. Parties of fewer than three characters use the next higher
+// multiplier; parties of six or more use the next lower one.
+func EncounterMultiplier(monsterCount, partySize int) float64 {
+	var step int
+	switch {
+	case monsterCount <= 1:
+		step = 1
+	case monsterCount == 2:
+		step = 2
+	case monsterCount <= 6:
+		step = 3
+	case monsterCount <= 10:
+		step = 4
+	case monsterCount <= 14:
+		step = 5
+	default:
+		step = 6
+	}
+
+	switch {
+	case partySize < 3:
+		step++
+	case partySize >= 6:
+		step--
+	}
+	return encounterMultipliers[step]
+}
+
+// EncounterBudgetInput describes a party and a proposed set of monsters
+type EncounterBudgetInput struct {
+	// PartyLevels holds each character's level
+	PartyLevels []int
+
+	// MonsterCRs holds each monster's challenge rating
+	MonsterCRs []float64
+
+	// Target is the desired difficulty (optional). When set, the result
+	// reports whether the monsters land on it and how much budget remains.
+	Target Difficulty
+}
+
+// Validate validates the input
+func (i *EncounterBudgetInput) Validate() error {
+	if i == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "EncounterBudgetInput is nil")
+	}
+	switch i.Target {
+	case "", DifficultyEasy, DifficultyMedium, DifficultyHard, DifficultyDeadly:
+	default:
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument, "invalid target difficulty %s", i.Target)
+	}
+	return nil
+}
+
+// EncounterBudgetResult is the difficulty rating of a proposed encounter
+type EncounterBudgetResult struct {
+	// Thresholds are the party's XP thresholds
+	Thresholds XPThresholds
+
+	// BaseXP is the sum of the monsters' XP (the XP the party earns)
+	BaseXP int
+
+	// Multiplier is the encounter multiplier for the number of monsters
+	Multiplier float64
+
+	// AdjustedXP is BaseXP times Multiplier, compared against the thresholds
+	AdjustedXP int
+
+	// Difficulty is the tier the encounter falls in
+	Difficulty Difficulty
+
+	// MeetsTarget is true when Difficulty equals the input's Target
+	MeetsTarget bool
+
+	// Remaining is the adjusted XP left before the encounter exceeds the
+	// target tier (the next tier's threshold minus one, minus AdjustedXP).
+	// Negative when the encounter is already harder than the target.
+	// Deadly has no upper limit, so it is 0 for a deadly target.
+	Remaining int
+}
+
+// EvaluateEncounter rates a proposed set of monsters against a party using
+// the Dungeon Master's Guide encounter building rules. Encounter generators
+// can call it with each candidate monster added to stay within a target tier.
+func EvaluateEncounter(input *EncounterBudgetInput) (*EncounterBudgetResult, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	thresholds, err := PartyThresholds(input.PartyLevels)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &EncounterBudgetResult{
+		Thresholds: thresholds,
+		Multiplier: EncounterMultiplier(len(input.MonsterCRs), len(input.PartyLevels)),
+	}
+	for _, cr := range input.MonsterCRs {
+		xp, err := XPForCR(cr)
+		if err != nil {
+			return nil, err
+		}
+		result.BaseXP += xp
+	}
+	if len(input.MonsterCRs) > 0 {
+		result.AdjustedXP = int(float64(result.BaseXP) * result.Multiplier)
+	}
+	result.Difficulty = thresholds.Rate(result.AdjustedXP)
+
+	if input.Target != "" {
+		result.MeetsTarget = result.Difficulty == input.Target
+		if ceiling := nextThreshold(thresholds, input.Target); ceiling > 0 {
+			result.Remaining = ceiling - 1 - result.AdjustedXP
+		}
+	}
+
+	return result, nil
+}
+
+// nextThreshold returns the threshold of the tier above the difficulty,
+// or 0 for deadly
+func nextThreshold(thresholds XPThresholds, difficulty Difficulty) int {
+	switch difficulty {
+	case DifficultyEasy:
+		return thresholds.Medium
+	case DifficultyMedium:
+		return thresholds.Hard
+	case DifficultyHard:
+		return thresholds.Deadly
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package monster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type BudgetTestSuite struct {
+	suite.Suite
+}
+
+func TestBudgetSuite(t *testing.T) {
+	suite.Run(t, new(BudgetTestSuite))
+}
+
+func (s *BudgetTestSuite) TestXPForCR() {
+	xp, err := XPForCR(0.25)
+	s.Require().NoError(err)
+	s.Equal(50, xp)
+
+	xp, err = XPForCR(30)
+	s.Require().NoError(err)
+	s.Equal(155000, xp)
+
+	_, err = XPForCR(0.3)
+	s.Error(err)
+}
+
+func (s *BudgetTestSuite) TestPartyThresholds() {
+	thresholds, err := PartyThresholds([]int{3, 3, 2, 2})
+	s.Require().NoError(err)
+	s.Equal(XPThresholds{Easy: 250, Medium: 500, Hard: 750, Deadly: 1200}, thresholds)
+
+	_, err = PartyThresholds(nil)
+	s.Error(err)
+
+	_, err = PartyThresholds([]int{3, 21})
+	s.Error(err)
+}
+
+func (s *BudgetTestSuite) TestEncounterMultiplier() {
+	testCases := []struct {
+		name      string
+		monsters  int
+		partySize int
+		expected  float64
+	}{
+		{"single monster", 1, 4, 1},
+		{"pair", 2, 4, 1.5},
+		{"group of 6", 6, 4, 2},
+		{"horde of 15", 15, 4, 4},
+		{"small party", 1, 2, 1.5},
+		{"small party horde", 15, 2, 5},
+		{"large party", 1, 6, 0.5},
+		{"large party pair", 2, 7, 1},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.Equal(tc.expected, EncounterMultiplier(tc.monsters, tc.partySize))
+		})
+	}
+}
+
+func (s *BudgetTestSuite) TestEvaluateEncounter() {
+	// DMG example: four 3rd-level characters against a bugbear and three hobgoblins
+	result, err := EvaluateEncounter(&EncounterBudgetInput{
+		PartyLevels: []int{3, 3, 3, 3},
+		MonsterCRs:  []float64{1, 0.5, 0.5, 0.5},
+		Target:      DifficultyHard,
+	})
+	s.Require().NoError(err)
+
+	s.Equal(XPThresholds{Easy: 300, Medium: 600, Hard: 900, Deadly: 1600}, result.Thresholds)
+	s.Equal(500, result.BaseXP)
+	s.Equal(2.0, result.Multiplier)
+	s.Equal(1000, result.AdjustedXP)
+	s.Equal(DifficultyHard, result.Difficulty)
+	s.True(result.MeetsTarget)
+	s.Equal(599, result.Remaining)
+}
+
+func (s *BudgetTestSuite) TestEvaluateEncounter_Tiers() {
+	party := []int{1, 1, 1, 1}
+
+	testCases := []struct {
+		name     string
+		crs      []float64
+		expected Difficulty
+	}{
+		{"no monsters", nil, DifficultyTrivial},
+		{"one goblin", []float64{0.25}, DifficultyTrivial},
+		{"one orc", []float64{0.5}, DifficultyEasy},
+		{"one bugbear", []float64{1}, DifficultyMedium},
+		{"three goblins", []float64{0.25, 0.25, 0.25}, DifficultyHard},
+		{"two bugbears", []float64{1, 1}, DifficultyDeadly},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			result, err := EvaluateEncounter(&EncounterBudgetInput{PartyLevels: party, MonsterCRs: tc.crs})
+			s.Require().NoError(err)
+			s.Equal(tc.expected, result.Difficulty)
+			s.False(result.MeetsTarget, "no target")
+		})
+	}
+}
+
+func (s *BudgetTestSuite) TestEvaluateEncounter_OverTarget() {
+	result, err := EvaluateEncounter(&EncounterBudgetInput{
+		PartyLevels: []int{1, 1, 1, 1},
+		MonsterCRs:  []float64{1},
+		Target:      DifficultyEasy,
+	})
+	s.Require().NoError(err)
+	s.Equal(DifficultyMedium, result.Difficulty)
+	s.False(result.MeetsTarget)
+	s.Equal(-1, result.Remaining)
+}
+
+func (s *BudgetTestSuite) TestEvaluateEncounter_InvalidInput() {
+	_, err := EvaluateEncounter(nil)
+	s.Error(err)
+
+	_, err = EvaluateEncounter(&EncounterBudgetInput{PartyLevels: []int{1}, Target: "impossible"})
+	s.Error(err)
+
+	_, err = EvaluateEncounter(&EncounterBudgetInput{PartyLevels: []int{1}, MonsterCRs: []float64{31}})
+	s.Error(err)
+}
@@ -0,0 +1,26 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package monster
+
+// CreatureType is a monster's creature type. Spells and features key off it
+// (e.g., Turn Undead, Hunter's Mark favored enemies).
+type CreatureType string
+
+// Creature types from the D&D 5e SRD
+const (
+	CreatureTypeAberration  CreatureType = "aberration"
+	CreatureTypeBeast       CreatureType = "beast"
+	CreatureTypeCelestial   CreatureType = "celestial"
+	CreatureTypeConstruct   CreatureType = "construct"
+	CreatureTypeDragon      CreatureType = "dragon"
+	CreatureTypeElemental   CreatureType = "elemental"
+	CreatureTypeFey         CreatureType = "fey"
+	CreatureTypeFiend       CreatureType = "fiend"
+	CreatureTypeGiant       CreatureType = "giant"
+	CreatureTypeHumanoid    CreatureType = "humanoid"
+	CreatureTypeMonstrosity CreatureType = "monstrosity"
+	CreatureTypeOoze        CreatureType = "ooze"
+	CreatureTypePlant       CreatureType = "plant"
+	CreatureTypeUndead      CreatureType = "undead"
+)
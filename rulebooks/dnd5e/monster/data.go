@@ -7,7 +7,9 @@ import (
 	"encoding/json"
 
 	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/spells"
 )
 
 // Data represents the serializable form of a monster.
@@ -18,6 +20,12 @@ type Data struct {
 	Name string    `json:"name"`
 	Ref  *core.Ref `json:"ref,omitempty"` // Type reference (e.g., refs.Monsters.Skeleton())
 
+	// CreatureType is the monster's creature type (e.g., undead)
+	CreatureType CreatureType `json:"creature_type,omitempty"`
+
+	// SummonerID is the creature controlling a summoned monster
+	SummonerID string `json:"summoner_id,omitempty"`
+
 	// Core stats
 	HitPoints        int                  `json:"hit_points"`
 	MaxHitPoints     int                  `json:"max_hit_points"`
@@ -46,8 +54,18 @@ type Data struct {
 	// Proficiencies (for skill checks like Stealth)
 	Proficiencies []ProficiencyData `json:"proficiencies,omitempty"`
 
+	// SavingThrows are the proficient saves from the stat block (e.g., WIS +4)
+	SavingThrows []SavingThrowData `json:"saving_throws,omitempty"`
+
+	// Spellcasting (slots, known and innate spells) for monsters that cast
+	Spellcasting *spells.SpellcastingData `json:"spellcasting,omitempty"`
+
+	// Lair actions and regional effects for monsters with a lair
+	Lair *LairData `json:"lair,omitempty"`
+
 	// AI behavior
 	Targeting TargetingStrategy `json:"targeting,omitempty"`
+	Behavior  *Behavior         `json:"behavior,omitempty"`
 }
 
 // SpeedData represents monster movement speeds in feet
@@ -88,6 +106,12 @@ type ProficiencyData struct {
 	Bonus int    `json:"bonus"`
 }
 
+// SavingThrowData represents a serializable saving throw proficiency
+type SavingThrowData struct {
+	Ability abilities.Ability `json:"ability"`
+	Bonus   int               `json:"bonus"`
+}
+
 // ActionCost represents the action economy cost of an action
 type ActionCost int
 
@@ -0,0 +1,162 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package monster
+
+import (
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+)
+
+// LairData describes a monster's lair: the lair actions it takes on
+// initiative count 20 and the regional effects that warp the land around it
+type LairData struct {
+	// Actions are the lair actions the monster can choose from
+	Actions []LairActionData `json:"actions,omitempty"`
+
+	// RegionalEffects are the lair's effects on the surrounding region
+	RegionalEffects []RegionalEffectData `json:"regional_effects,omitempty"`
+
+	// RegionRadiusMiles is how far the regional effects reach (e.g., 6 miles)
+	RegionRadiusMiles int `json:"region_radius_miles,omitempty"`
+}
+
+// LairActionData defines one lair action. The effect is resolved by the
+// caller (e.g., via combat.ResolveAreaEffect with the save and damage below).
+type LairActionData struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+
+	// Condition applied to creatures that fail the save (optional),
+	// e.g., refs.Conditions.Restrained()
+	Condition *core.Ref `json:"condition,omitempty"`
+
+	// Save creatures make against the action (optional)
+	SaveAbility abilities.Ability `json:"save_ability,omitempty"`
+	SaveDC      int               `json:"save_dc,omitempty"`
+
+	// Damage dealt on a failed save (optional), e.g., "3d6"
+	Damage     string      `json:"damage,omitempty"`
+	DamageType damage.Type `json:"damage_type,omitempty"`
+
+	// Radius of the affected area in hexes (0 = single target)
+	Radius int `json:"radius,omitempty"`
+}
+
+// RegionalEffectData defines one regional effect. Environment generation
+// uses these to add flavor (fog, twisted plants, foul water) to the region.
+type RegionalEffectData struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+
+	// Effect is the condition or effect the region imposes (optional),
+	// e.g., refs.Conditions.Frightened() for creatures sleeping nearby
+	Effect *core.Ref `json:"effect,omitempty"`
+
+	// EndsOnDeath is true when the effect fades after the monster dies
+	EndsOnDeath bool `json:"ends_on_death,omitempty"`
+}
+
+// Validate validates the lair data
+func (l *LairData) Validate() error {
+	if l == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "LairData is nil")
+	}
+	if l.RegionRadiusMiles < 0 {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "RegionRadiusMiles cannot be negative")
+	}
+
+	seen := make(map[string]bool, len(l.Actions))
+	for _, action := range l.Actions {
+		if action.ID == "" {
+			return rpgerr.New(rpgerr.CodeInvalidArgument, "lair action ID is required")
+		}
+		if seen[action.ID] {
+			return rpgerr.Newf(rpgerr.CodeInvalidArgument, "duplicate lair action %s", action.ID)
+		}
+		seen[action.ID] = true
+	}
+
+	seen = make(map[string]bool, len(l.RegionalEffects))
+	for _, effect := range l.RegionalEffects {
+		if effect.ID == "" {
+			return rpgerr.New(rpgerr.CodeInvalidArgument, "regional effect ID is required")
+		}
+		if seen[effect.ID] {
+			return rpgerr.Newf(rpgerr.CodeInvalidArgument, "duplicate regional effect %s", effect.ID)
+		}
+		seen[effect.ID] = true
+	}
+	return nil
+}
+
+// clone returns a copy of the lair data that shares no slices
+func (l *LairData) clone() *LairData {
+	if l == nil {
+		return nil
+	}
+	return &LairData{
+		Actions:           append([]LairActionData(nil), l.Actions...),
+		RegionalEffects:   append([]RegionalEffectData(nil), l.RegionalEffects...),
+		RegionRadiusMiles: l.RegionRadiusMiles,
+	}
+}
+
+// SetLair sets the monster's lair data
+func (m *Monster) SetLair(lair *LairData) error {
+	if lair != nil {
+		if err := lair.Validate(); err != nil {
+			return err
+		}
+	}
+	m.lair = lair.clone()
+	return nil
+}
+
+// Lair returns the monster's lair data (nil if it has no lair)
+func (m *Monster) Lair() *LairData {
+	return m.lair
+}
+
+// LairAction returns the lair action with the given ID
+func (m *Monster) LairAction(actionID string) (*LairActionData, bool) {
+	if m.lair == nil {
+		return nil, false
+	}
+	for i := range m.lair.Actions {
+		if m.lair.Actions[i].ID == actionID {
+			return &m.lair.Actions[i], true
+		}
+	}
+	return nil, false
+}
+
+// RegionalEffects returns the lair's regional effects (nil if it has no lair)
+func (m *Monster) RegionalEffects() []RegionalEffectData {
+	if m.lair == nil {
+		return nil
+	}
+	return m.lair.RegionalEffects
+}
+
+// NewLairActions creates the combat tracker for the monster's lair actions.
+// Returns CodeNotFound if the monster has no lair actions.
+func (m *Monster) NewLairActions() (*combat.LairActions, error) {
+	if m.lair == nil || len(m.lair.Actions) == 0 {
+		return nil, rpgerr.Newf(rpgerr.CodeNotFound, "%s has no lair actions", m.id)
+	}
+
+	actions := make([]combat.LairAction, 0, len(m.lair.Actions))
+	for _, action := range m.lair.Actions {
+		actions = append(actions, combat.LairAction{ID: action.ID, Name: action.Name})
+	}
+	return combat.NewLairActions(&combat.LairActionsConfig{
+		OwnerID: m.id,
+		Actions: actions,
+	})
+}
@@ -0,0 +1,129 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package monster
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+type LairTestSuite struct {
+	suite.Suite
+}
+
+func TestLairSuite(t *testing.T) {
+	suite.Run(t, new(LairTestSuite))
+}
+
+// hagLair returns lair data modeled on a swamp hag's lair
+func (s *LairTestSuite) hagLair() *LairData {
+	return &LairData{
+		Actions: []LairActionData{
+			{
+				ID:          "grasping_roots",
+				Name:        "Grasping Roots",
+				Condition:   refs.Conditions.Restrained(),
+				SaveAbility: abilities.STR,
+				SaveDC:      13,
+				Radius:      4,
+			},
+			{
+				ID:          "choking_fog",
+				Name:        "Choking Fog",
+				SaveAbility: abilities.CON,
+				SaveDC:      13,
+				Damage:      "2d6",
+				DamageType:  damage.Poison,
+				Radius:      2,
+			},
+		},
+		RegionalEffects: []RegionalEffectData{
+			{ID: "foul_water", Name: "Foul Water", Effect: refs.Conditions.Poisoned(), EndsOnDeath: true},
+			{ID: "nightmares", Name: "Nightmares", Effect: refs.Conditions.Frightened()},
+		},
+		RegionRadiusMiles: 1,
+	}
+}
+
+func (s *LairTestSuite) TestSetLair() {
+	hag := NewGoblin("hag-1")
+	s.Nil(hag.Lair())
+	s.Nil(hag.RegionalEffects())
+
+	lair := s.hagLair()
+	s.Require().NoError(hag.SetLair(lair))
+
+	// The monster keeps its own copy
+	lair.Actions[0].Name = "Changed"
+	action, found := hag.LairAction("grasping_roots")
+	s.Require().True(found)
+	s.Equal("Grasping Roots", action.Name)
+	s.Equal(refs.Conditions.Restrained(), action.Condition)
+
+	_, found = hag.LairAction("meteor_swarm")
+	s.False(found)
+
+	s.Require().Len(hag.RegionalEffects(), 2)
+	s.Equal(refs.Conditions.Poisoned(), hag.RegionalEffects()[0].Effect)
+}
+
+func (s *LairTestSuite) TestValidation() {
+	hag := NewGoblin("hag-1")
+
+	lair := s.hagLair()
+	lair.Actions[1].ID = "grasping_roots"
+	s.Error(hag.SetLair(lair), "duplicate lair action")
+
+	lair = s.hagLair()
+	lair.RegionalEffects[0].ID = ""
+	s.Error(hag.SetLair(lair), "regional effect ID is required")
+
+	s.Nil(hag.Lair(), "invalid lairs are not set")
+}
+
+func (s *LairTestSuite) TestNewLairActions() {
+	hag := NewGoblin("hag-1")
+
+	_, err := hag.NewLairActions()
+	s.Equal(rpgerr.CodeNotFound, rpgerr.GetCode(err))
+
+	s.Require().NoError(hag.SetLair(s.hagLair()))
+	lairActions, err := hag.NewLairActions()
+	s.Require().NoError(err)
+
+	s.Equal("hag-1", lairActions.OwnerID)
+	s.Equal([]combat.LairAction{
+		{ID: "grasping_roots", Name: "Grasping Roots"},
+		{ID: "choking_fog", Name: "Choking Fog"},
+	}, lairActions.Available(1))
+}
+
+func (s *LairTestSuite) TestDataRoundTrip() {
+	hag := NewGoblin("hag-1")
+	s.Require().NoError(hag.SetLair(s.hagLair()))
+
+	raw, err := json.Marshal(hag.ToData())
+	s.Require().NoError(err)
+
+	var data Data
+	s.Require().NoError(json.Unmarshal(raw, &data))
+
+	loaded, err := LoadFromData(context.Background(), &data, events.NewEventBus())
+	s.Require().NoError(err)
+	s.Equal(s.hagLair(), loaded.Lair())
+
+	data.Lair.Actions[0].ID = ""
+	_, err = LoadFromData(context.Background(), &data, events.NewEventBus())
+	s.Error(err, "invalid lair data is rejected")
+}
@@ -15,6 +15,7 @@ import (
 	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/spells"
 	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
 )
 
@@ -26,6 +27,12 @@ type Monster struct {
 	name string
 	ref  *core.Ref // Type reference (e.g., refs.Monsters.Skeleton())
 
+	// Creature type (beast, undead, ...)
+	creatureType CreatureType
+
+	// Summoner controls this monster (empty for monsters that weren't summoned)
+	summonerID string
+
 	// Stats
 	hp            int
 	maxHP         int
@@ -46,11 +53,19 @@ type Monster struct {
 	traitData []json.RawMessage
 
 	// Proficiencies
-	proficiencyBonus int            // Base proficiency bonus (CR-based)
-	proficiencies    map[string]int // skill -> bonus
+	proficiencyBonus int                       // Base proficiency bonus (CR-based)
+	proficiencies    map[string]int            // skill -> bonus
+	savingThrows     map[abilities.Ability]int // ability -> bonus (proficient saves only)
+
+	// Spellcasting (nil for monsters that don't cast)
+	spellcasting *spells.Spellcasting
+
+	// Lair actions and regional effects (nil for monsters without a lair)
+	lair *LairData
 
 	// AI behavior
 	targeting TargetingStrategy
+	behavior  *Behavior // nil uses the plain TakeTurn defaults
 
 	// Event bus wiring
 	bus             events.EventBus
@@ -65,6 +80,7 @@ type Config struct {
 	ID               string
 	Name             string
 	Ref              *core.Ref // Type reference (e.g., refs.Monsters.Skeleton())
+	Type             CreatureType
 	HP               int
 	AC               int
 	AbilityScores    shared.AbilityScores
@@ -81,6 +97,7 @@ func New(config Config) *Monster {
 		id:               config.ID,
 		name:             config.Name,
 		ref:              config.Ref,
+		creatureType:     config.Type,
 		hp:               config.HP,
 		maxHP:            config.HP,
 		ac:               config.AC,
@@ -109,6 +126,11 @@ func (m *Monster) Ref() *core.Ref {
 	return m.ref
 }
 
+// CreatureType returns the monster's creature type (empty if unknown)
+func (m *Monster) CreatureType() CreatureType {
+	return m.creatureType
+}
+
 // HP returns current hit points
 func (m *Monster) HP() int {
 	return m.hp
@@ -223,6 +245,7 @@ func NewGoblin(id string) *Monster {
 		ID:   id,
 		Name: "Goblin",
 		Ref:  refs.Monsters.Goblin(),
+		Type: CreatureTypeHumanoid,
 		HP:   7,  // 2d6 average
 		AC:   15, // Leather armor + DEX
 		AbilityScores: shared.AbilityScores{
@@ -270,6 +293,40 @@ func (m *Monster) Senses() SensesData {
 	return m.senses
 }
 
+// SetSenses sets the monster's sensory capabilities
+func (m *Monster) SetSenses(senses SensesData) {
+	m.senses = senses
+}
+
+// SetProficiency sets the monster's total bonus for a skill (e.g., "stealth": 6)
+func (m *Monster) SetProficiency(skill string, bonus int) {
+	if m.proficiencies == nil {
+		m.proficiencies = make(map[string]int)
+	}
+	m.proficiencies[skill] = bonus
+}
+
+// SummonerID returns the ID of the creature that summoned this monster,
+// or empty if it wasn't summoned
+func (m *Monster) SummonerID() string {
+	return m.summonerID
+}
+
+// SetSummoner marks the monster as summoned and controlled by the summoner
+func (m *Monster) SetSummoner(summonerID string) {
+	m.summonerID = summonerID
+}
+
+// Spellcasting returns the monster's spellcasting, or nil if it doesn't cast
+func (m *Monster) Spellcasting() *spells.Spellcasting {
+	return m.spellcasting
+}
+
+// SetSpellcasting sets the monster's spellcasting (slots, known and innate spells)
+func (m *Monster) SetSpellcasting(spellcasting *spells.Spellcasting) {
+	m.spellcasting = spellcasting
+}
+
 // GetConditions returns all active conditions
 func (m *Monster) GetConditions() []dnd5eEvents.ConditionBehavior {
 	return m.conditions
@@ -317,6 +374,8 @@ func LoadFromData(ctx context.Context, d *Data, bus events.EventBus) (*Monster,
 		id:               d.ID,
 		name:             d.Name,
 		ref:              d.Ref,
+		creatureType:     d.CreatureType,
+		summonerID:       d.SummonerID,
 		hp:               d.HitPoints,
 		maxHP:            d.MaxHitPoints,
 		ac:               d.ArmorClass,
@@ -325,10 +384,12 @@ func LoadFromData(ctx context.Context, d *Data, bus events.EventBus) (*Monster,
 		speed:            d.Speed,
 		senses:           d.Senses,
 		targeting:        d.Targeting,
+		behavior:         d.Behavior,
 		bus:              bus,
 		subscriptionIDs:  make([]string, 0),
 		actions:          make([]MonsterAction, 0, len(d.Actions)),
 		proficiencies:    make(map[string]int),
+		savingThrows:     make(map[abilities.Ability]int),
 	}
 
 	// Actions must be loaded by the caller to avoid import cycles.
@@ -345,6 +406,28 @@ func LoadFromData(ctx context.Context, d *Data, bus events.EventBus) (*Monster,
 		m.proficiencies[prof.Skill] = prof.Bonus
 	}
 
+	// Load saving throw proficiencies
+	for _, save := range d.SavingThrows {
+		m.savingThrows[save.Ability] = save.Bonus
+	}
+
+	// Load lair
+	if d.Lair != nil {
+		if err := d.Lair.Validate(); err != nil {
+			return nil, rpgerr.Wrapf(err, "invalid lair for monster %s", d.ID)
+		}
+		m.lair = d.Lair.clone()
+	}
+
+	// Load spellcasting
+	if d.Spellcasting != nil {
+		spellcasting, err := spells.NewSpellcasting(d.Spellcasting)
+		if err != nil {
+			return nil, rpgerr.Wrapf(err, "failed to load spellcasting for monster %s", d.ID)
+		}
+		m.spellcasting = spellcasting
+	}
+
 	// Conditions must be loaded by the caller to avoid import cycles.
 	// The monster package cannot import monstertraits because traits need monster types.
 	// Use LoadMonsterConditions helper to load conditions after creating the monster.
@@ -419,6 +502,13 @@ func (m *Monster) TakeTurn(ctx context.Context, input *TurnInput) (*TurnResult,
 		Movement:  make([]spatial.CubeCoordinate, 0),
 	}
 
+	// Roll to recharge spent "Recharge X–6" actions
+	if input.Roller != nil {
+		if err := m.RollRecharges(ctx, input.Bus, input.Roller); err != nil {
+			return nil, err
+		}
+	}
+
 	// Move toward closest enemy if not adjacent
 	m.moveTowardEnemy(input, result)
 
@@ -501,8 +591,11 @@ func (m *Monster) selectBestAction(economy *combat.ActionEconomy, perception *Pe
 			continue
 		}
 
-		// Score it
+		// Score it, with the behavior's bias (-1000 marks an unusable action)
 		score := action.Score(m, perception)
+		if score > -1000 {
+			score += m.actionBias(action)
+		}
 		if score > bestScore {
 			bestScore = score
 			best = action
@@ -607,6 +700,12 @@ func (m *Monster) moveTowardEnemy(input *TurnInput, result *TurnResult) {
 		return
 	}
 
+	// Ranged profiles hold position once the closest enemy is near enough
+	stopDistance := m.stopDistance()
+	if closest.Distance <= stopDistance {
+		return
+	}
+
 	// Calculate how far we can move (use input speed, fall back to monster's speed)
 	// input.Speed is already in hexes, but m.speed.Walk is in feet (5 feet per hex)
 	speed := input.Speed
@@ -631,8 +730,8 @@ func (m *Monster) moveTowardEnemy(input *TurnInput, result *TurnResult) {
 		return // No valid path - stay put
 	}
 
-	// Calculate how many hexes to move (stop 1 hex short to stay adjacent)
-	hexesToMove := len(path) - 1 // Stop adjacent to target
+	// Calculate how many hexes to move (stop short to stay adjacent, or at hold range)
+	hexesToMove := len(path) - stopDistance
 	if hexesToMove <= 0 {
 		return // Already close enough
 	}
@@ -675,6 +774,8 @@ func (m *Monster) ToData() *Data {
 		ID:               m.id,
 		Name:             m.name,
 		Ref:              m.ref,
+		CreatureType:     m.creatureType,
+		SummonerID:       m.summonerID,
 		HitPoints:        m.hp,
 		MaxHitPoints:     m.maxHP,
 		ArmorClass:       m.ac,
@@ -683,6 +784,7 @@ func (m *Monster) ToData() *Data {
 		Speed:            m.speed,
 		Senses:           m.senses,
 		Targeting:        m.targeting,
+		Behavior:         m.behavior,
 		Actions:          make([]ActionData, 0, len(m.actions)),
 		Proficiencies:    make([]ProficiencyData, 0, len(m.proficiencies)),
 	}
@@ -705,6 +807,23 @@ func (m *Monster) ToData() *Data {
 		return data.Proficiencies[i].Skill < data.Proficiencies[j].Skill
 	})
 
+	// Convert saving throws, sorted for deterministic output
+	for ability, bonus := range m.savingThrows {
+		data.SavingThrows = append(data.SavingThrows, SavingThrowData{
+			Ability: ability,
+			Bonus:   bonus,
+		})
+	}
+	sort.Slice(data.SavingThrows, func(i, j int) bool {
+		return data.SavingThrows[i].Ability < data.SavingThrows[j].Ability
+	})
+
+	if m.spellcasting != nil {
+		data.Spellcasting = m.spellcasting.ToData()
+	}
+
+	data.Lair = m.lair.clone()
+
 	// Convert conditions to persisted JSON
 	// Include both applied conditions and unapplied trait data
 	totalConditions := len(m.conditions) + len(m.traitData)
@@ -0,0 +1,355 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package monsters
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster/actions"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monstertraits"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+)
+
+var (
+	feetPattern  = regexp.MustCompile(`(\d+) ft`)
+	reachPattern = regexp.MustCompile(`reach (\d+) ft`)
+	rangePattern = regexp.MustCompile(`range (\d+)(?:/(\d+))? ft`)
+)
+
+// apiMonster is the subset of a dnd5eapi.co monster document the loader reads
+type apiMonster struct {
+	Index                 string           `json:"index"`
+	Name                  string           `json:"name"`
+	Type                  string           `json:"type"`
+	ArmorClass            apiArmorClass    `json:"armor_class"`
+	HitPoints             int              `json:"hit_points"`
+	Speed                 map[string]any   `json:"speed"`
+	Strength              int              `json:"strength"`
+	Dexterity             int              `json:"dexterity"`
+	Constitution          int              `json:"constitution"`
+	Intelligence          int              `json:"intelligence"`
+	Wisdom                int              `json:"wisdom"`
+	Charisma              int              `json:"charisma"`
+	ProficiencyBonus      int              `json:"proficiency_bonus"`
+	Proficiencies         []apiProficiency `json:"proficiencies"`
+	DamageVulnerabilities []string         `json:"damage_vulnerabilities"`
+	DamageResistances     []string         `json:"damage_resistances"`
+	DamageImmunities      []string         `json:"damage_immunities"`
+	Senses                map[string]any   `json:"senses"`
+	SpecialAbilities      []apiNamed       `json:"special_abilities"`
+	Actions               []apiAction      `json:"actions"`
+}
+
+type apiNamed struct {
+	Name string `json:"name"`
+}
+
+type apiProficiency struct {
+	Value       int `json:"value"`
+	Proficiency struct {
+		Index string `json:"index"`
+	} `json:"proficiency"`
+}
+
+type apiAction struct {
+	Name            string           `json:"name"`
+	Desc            string           `json:"desc"`
+	AttackBonus     *int             `json:"attack_bonus"`
+	Damage          []apiDamage      `json:"damage"`
+	MultiattackType string           `json:"multiattack_type"`
+	Actions         []apiActionCount `json:"actions"`
+}
+
+type apiDamage struct {
+	DamageType struct {
+		Index string `json:"index"`
+	} `json:"damage_type"`
+	DamageDice string `json:"damage_dice"`
+}
+
+type apiActionCount struct {
+	ActionName string     `json:"action_name"`
+	Count      apiFlexInt `json:"count"`
+}
+
+// apiArmorClass accepts both the legacy integer form and the current list of
+// {type, value} entries. The first entry wins.
+type apiArmorClass int
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (a *apiArmorClass) UnmarshalJSON(data []byte) error {
+	var value int
+	if err := json.Unmarshal(data, &value); err == nil {
+		*a = apiArmorClass(value)
+		return nil
+	}
+
+	var entries []struct {
+		Value int `json:"value"`
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	if len(entries) > 0 {
+		*a = apiArmorClass(entries[0].Value)
+	}
+	return nil
+}
+
+// apiFlexInt accepts a number or a numeric string. The API uses both for
+// multiattack counts.
+type apiFlexInt int
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (f *apiFlexInt) UnmarshalJSON(data []byte) error {
+	var value int
+	if err := json.Unmarshal(data, &value); err == nil {
+		*f = apiFlexInt(value)
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	value, err := strconv.Atoi(strings.TrimSpace(text))
+	if err != nil {
+		return err
+	}
+	*f = apiFlexInt(value)
+	return nil
+}
+
+// FromAPIJSON creates a monster from a dnd5eapi.co monster document.
+//
+// The loader maps ability scores, AC, HP, speeds, senses, skill and saving
+// throw proficiencies, damage vulnerabilities/resistances/immunities, and
+// attack actions. Special abilities are mapped only when the toolkit
+// implements them (Pack Tactics, Undead Fortitude).
+//
+// Limitations:
+//   - Actions without an attack bonus (breath weapons, spellcasting) are skipped
+//   - Attacks use their first damage entry; riders such as extra fire damage are dropped
+//
+// Known monsters get their refs.Monsters ref; others get a ref from their index.
+func FromAPIJSON(id string, data []byte) (*monster.Monster, error) {
+	if id == "" {
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "monster id is required")
+	}
+
+	var doc apiMonster
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, rpgerr.WrapWithCode(err, rpgerr.CodeInvalidArgument, "failed to unmarshal monster JSON")
+	}
+	if doc.Index == "" {
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "monster JSON is missing index")
+	}
+
+	ref := refs.Monsters.ByID(doc.Index)
+	if ref == nil {
+		ref = &core.Ref{Module: refs.Module, Type: refs.TypeMonsters, ID: doc.Index}
+	}
+
+	m := monster.New(monster.Config{
+		ID:   id,
+		Name: doc.Name,
+		Ref:  ref,
+		Type: monster.CreatureType(doc.Type),
+		HP:   doc.HitPoints,
+		AC:   int(doc.ArmorClass),
+		AbilityScores: shared.AbilityScores{
+			abilities.STR: doc.Strength,
+			abilities.DEX: doc.Dexterity,
+			abilities.CON: doc.Constitution,
+			abilities.INT: doc.Intelligence,
+			abilities.WIS: doc.Wisdom,
+			abilities.CHA: doc.Charisma,
+		},
+		ProficiencyBonus: doc.ProficiencyBonus,
+	})
+
+	m.SetSpeed(monster.SpeedData{
+		Walk:   apiFeet(doc.Speed["walk"]),
+		Fly:    apiFeet(doc.Speed["fly"]),
+		Swim:   apiFeet(doc.Speed["swim"]),
+		Climb:  apiFeet(doc.Speed["climb"]),
+		Burrow: apiFeet(doc.Speed["burrow"]),
+	})
+
+	m.SetSenses(monster.SensesData{
+		Darkvision:        apiFeet(doc.Senses["darkvision"]),
+		Blindsight:        apiFeet(doc.Senses["blindsight"]),
+		Tremorsense:       apiFeet(doc.Senses["tremorsense"]),
+		Truesight:         apiFeet(doc.Senses["truesight"]),
+		PassivePerception: apiFeet(doc.Senses["passive_perception"]),
+	})
+
+	for _, prof := range doc.Proficiencies {
+		if skill, ok := strings.CutPrefix(prof.Proficiency.Index, "skill-"); ok {
+			m.SetProficiency(skill, prof.Value)
+		}
+		if ability, ok := strings.CutPrefix(prof.Proficiency.Index, "saving-throw-"); ok {
+			m.SetSavingThrow(abilities.Ability(ability), prof.Value)
+		}
+	}
+
+	for _, trait := range apiDamageTraits(doc.DamageVulnerabilities) {
+		m.AddTraitData(monstertraits.MustVulnerabilityJSON(id, trait.damageType))
+	}
+	for _, trait := range apiDamageTraits(doc.DamageResistances) {
+		m.AddTraitData(monstertraits.MustResistanceJSON(id, trait.damageType, trait.bypassedBy...))
+	}
+	for _, trait := range apiDamageTraits(doc.DamageImmunities) {
+		m.AddTraitData(monstertraits.MustImmunityJSON(id, trait.damageType, trait.bypassedBy...))
+	}
+
+	conMod := m.AbilityScores().Modifier(abilities.CON)
+	for _, ability := range doc.SpecialAbilities {
+		var traitJSON json.RawMessage
+		var err error
+		switch ability.Name {
+		case "Pack Tactics":
+			traitJSON, err = monstertraits.PackTactics(id).ToJSON()
+		case "Undead Fortitude":
+			traitJSON, err = monstertraits.UndeadFortitude(id, conMod, nil).ToJSON()
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, rpgerr.Wrapf(err, "failed to convert %s trait", ability.Name)
+		}
+		m.AddTraitData(traitJSON)
+	}
+
+	for _, action := range doc.Actions {
+		if monsterAction := apiToAction(action); monsterAction != nil {
+			m.AddAction(monsterAction)
+		}
+	}
+
+	return m, nil
+}
+
+// apiToAction converts an API action, or returns nil when the toolkit has no
+// equivalent.
+func apiToAction(action apiAction) monster.MonsterAction {
+	if action.MultiattackType == "actions" && len(action.Actions) > 0 {
+		var attacks []string
+		for _, sub := range action.Actions {
+			name := strings.ReplaceAll(strings.ToLower(sub.ActionName), " ", "-")
+			for i := 0; i < int(sub.Count); i++ {
+				attacks = append(attacks, name)
+			}
+		}
+		return actions.NewMultiattackAction(actions.MultiattackConfig{Attacks: attacks})
+	}
+
+	if action.AttackBonus == nil || len(action.Damage) == 0 {
+		return nil
+	}
+
+	name := strings.ReplaceAll(strings.ToLower(action.Name), " ", "-")
+	damageType, err := damage.GetByID(action.Damage[0].DamageType.Index)
+	if err != nil {
+		return nil
+	}
+
+	if strings.Contains(action.Desc, "Ranged Weapon Attack") || strings.Contains(action.Desc, "Ranged Spell Attack") {
+		rangeNormal, rangeLong := 0, 0
+		if match := rangePattern.FindStringSubmatch(action.Desc); match != nil {
+			rangeNormal, _ = strconv.Atoi(match[1])
+			rangeLong = rangeNormal
+			if match[2] != "" {
+				rangeLong, _ = strconv.Atoi(match[2])
+			}
+		}
+		return actions.NewRangedAction(actions.RangedConfig{
+			Name:        name,
+			AttackBonus: *action.AttackBonus,
+			DamageDice:  action.Damage[0].DamageDice,
+			RangeNormal: rangeNormal,
+			RangeLong:   rangeLong,
+			DamageType:  damageType,
+		})
+	}
+
+	reach := 5
+	if match := reachPattern.FindStringSubmatch(action.Desc); match != nil {
+		reach, _ = strconv.Atoi(match[1])
+	}
+	return actions.NewMeleeAction(actions.MeleeConfig{
+		Name:        name,
+		AttackBonus: *action.AttackBonus,
+		DamageDice:  action.Damage[0].DamageDice,
+		Reach:       reach,
+		DamageType:  damageType,
+	})
+}
+
+// apiFeet reads an API distance such as "30 ft." or a bare number.
+// Returns 0 for anything else, including the fly speed's "hover" flag.
+func apiFeet(value any) int {
+	switch v := value.(type) {
+	case float64:
+		return int(v)
+	case string:
+		if match := feetPattern.FindStringSubmatch(v); match != nil {
+			feet, _ := strconv.Atoi(match[1])
+			return feet
+		}
+	}
+	return 0
+}
+
+// apiDamageTrait is one damage type from an API trait entry, with the
+// annotations that bypass it
+type apiDamageTrait struct {
+	damageType damage.Type
+	bypassedBy []damage.Annotation
+}
+
+// apiDamageTraits pulls the known damage types out of API trait entries such
+// as "poison" or "bludgeoning, piercing, and slashing from nonmagical attacks".
+// A "nonmagical" qualifier is bypassed by magical damage, and "that aren't
+// silvered" or "that aren't adamantine" by that material too.
+func apiDamageTraits(entries []string) []apiDamageTrait {
+	seen := make(map[damage.Type]bool)
+	var traits []apiDamageTrait
+	for _, entry := range entries {
+		words := strings.FieldsFunc(strings.ToLower(entry), func(r rune) bool {
+			return r < 'a' || r > 'z'
+		})
+
+		var bypassedBy []damage.Annotation
+		if slices.Contains(words, "nonmagical") {
+			bypassedBy = append(bypassedBy, damage.AnnotationMagical)
+			if slices.Contains(words, "silvered") {
+				bypassedBy = append(bypassedBy, damage.AnnotationSilvered)
+			}
+			if slices.Contains(words, "adamantine") {
+				bypassedBy = append(bypassedBy, damage.AnnotationAdamantine)
+			}
+		}
+
+		for _, word := range words {
+			damageType, ok := damage.All[word]
+			if !ok || damageType == damage.None || seen[damageType] {
+				continue
+			}
+			seen[damageType] = true
+			traits = append(traits, apiDamageTrait{damageType: damageType, bypassedBy: bypassedBy})
+		}
+	}
+	return traits
+}
@@ -0,0 +1,252 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package monsters
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster/actions"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monstertraits"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+const apiGoblinJSON = `{
+	"index": "goblin",
+	"name": "Goblin",
+	"armor_class": [{"type": "armor", "value": 15}],
+	"hit_points": 7,
+	"speed": {"walk": "30 ft."},
+	"strength": 8, "dexterity": 14, "constitution": 10,
+	"intelligence": 10, "wisdom": 8, "charisma": 8,
+	"proficiency_bonus": 2,
+	"proficiencies": [
+		{"value": 6, "proficiency": {"index": "skill-stealth", "name": "Skill: Stealth"}}
+	],
+	"damage_vulnerabilities": [], "damage_resistances": [], "damage_immunities": [],
+	"senses": {"darkvision": "60 ft.", "passive_perception": 9},
+	"special_abilities": [{"name": "Nimble Escape", "desc": "The goblin can take the Disengage or Hide action..."}],
+	"actions": [
+		{
+			"name": "Scimitar",
+			"desc": "Melee Weapon Attack: +4 to hit, reach 5 ft., one target. Hit: 5 (1d6 + 2) slashing damage.",
+			"attack_bonus": 4,
+			"damage": [{"damage_type": {"index": "slashing"}, "damage_dice": "1d6+2"}]
+		},
+		{
+			"name": "Shortbow",
+			"desc": "Ranged Weapon Attack: +4 to hit, range 80/320 ft., one target. Hit: 5 (1d6 + 2) piercing damage.",
+			"attack_bonus": 4,
+			"damage": [{"damage_type": {"index": "piercing"}, "damage_dice": "1d6+2"}]
+		}
+	]
+}`
+
+const apiBrownBearJSON = `{
+	"index": "brown-bear",
+	"name": "Brown Bear",
+	"armor_class": 11,
+	"hit_points": 34,
+	"speed": {"walk": "40 ft.", "climb": "30 ft."},
+	"strength": 19, "dexterity": 10, "constitution": 16,
+	"intelligence": 2, "wisdom": 13, "charisma": 7,
+	"proficiency_bonus": 2,
+	"proficiencies": [
+		{"value": 3, "proficiency": {"index": "skill-perception"}}
+	],
+	"senses": {"passive_perception": 13},
+	"actions": [
+		{
+			"name": "Multiattack",
+			"multiattack_type": "actions",
+			"desc": "The bear makes two attacks: one with its bite and one with its claws.",
+			"actions": [
+				{"action_name": "Bite", "count": 1, "type": "melee"},
+				{"action_name": "Claw", "count": "1", "type": "melee"}
+			]
+		},
+		{
+			"name": "Bite",
+			"desc": "Melee Weapon Attack: +6 to hit, reach 5 ft., one target.",
+			"attack_bonus": 6,
+			"damage": [{"damage_type": {"index": "piercing"}, "damage_dice": "1d8+4"}]
+		},
+		{
+			"name": "Claw",
+			"desc": "Melee Weapon Attack: +6 to hit, reach 5 ft., one target.",
+			"attack_bonus": 6,
+			"damage": [{"damage_type": {"index": "slashing"}, "damage_dice": "2d6+4"}]
+		}
+	]
+}`
+
+const apiWightJSON = `{
+	"index": "wight",
+	"name": "Wight",
+	"type": "undead",
+	"armor_class": [{"type": "armor", "value": 14}],
+	"hit_points": 45,
+	"speed": {"walk": "30 ft."},
+	"strength": 15, "dexterity": 14, "constitution": 16,
+	"intelligence": 10, "wisdom": 13, "charisma": 15,
+	"proficiency_bonus": 2,
+	"damage_vulnerabilities": [],
+	"damage_resistances": ["necrotic", "bludgeoning, piercing, and slashing from nonmagical weapons that aren't silvered"],
+	"damage_immunities": ["poison"],
+	"senses": {"darkvision": "60 ft.", "passive_perception": 13},
+	"actions": [
+		{
+			"name": "Life Drain",
+			"desc": "Melee Weapon Attack: +4 to hit, reach 5 ft., one creature.",
+			"attack_bonus": 4,
+			"damage": [{"damage_type": {"index": "necrotic"}, "damage_dice": "1d6+2"}]
+		},
+		{
+			"name": "Longbow",
+			"desc": "Ranged Weapon Attack: +4 to hit, range 150/600 ft., one target.",
+			"attack_bonus": 4,
+			"damage": [{"damage_type": {"index": "piercing"}, "damage_dice": "1d8+2"}]
+		}
+	]
+}`
+
+type APILoaderTestSuite struct {
+	suite.Suite
+}
+
+func TestAPILoaderSuite(t *testing.T) {
+	suite.Run(t, new(APILoaderTestSuite))
+}
+
+// traitRefs returns the ref IDs of the monster's trait data in order
+func (s *APILoaderTestSuite) traitRefs(m *monster.Monster) []string {
+	var ids []string
+	for _, data := range m.ToData().Conditions {
+		var peek struct {
+			Ref        *core.Ref `json:"ref"`
+			DamageType string    `json:"damage_type"`
+		}
+		s.Require().NoError(json.Unmarshal(data, &peek))
+		id := peek.Ref.ID
+		if peek.DamageType != "" {
+			id += ":" + peek.DamageType
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (s *APILoaderTestSuite) TestGoblin() {
+	goblin, err := FromAPIJSON("goblin-1", []byte(apiGoblinJSON))
+	s.Require().NoError(err)
+
+	s.Equal("goblin-1", goblin.GetID())
+	s.Equal("Goblin", goblin.Name())
+	s.True(goblin.Ref().Equals(refs.Monsters.Goblin()))
+	s.Equal(7, goblin.MaxHP())
+	s.Equal(15, goblin.AC())
+	s.Equal(14, goblin.AbilityScores()[abilities.DEX])
+	s.Equal(30, goblin.Speed().Walk)
+	s.Equal(60, goblin.Senses().Darkvision)
+	s.Equal(9, goblin.Senses().PassivePerception)
+
+	data := goblin.ToData()
+	s.Equal([]monster.ProficiencyData{{Skill: "stealth", Bonus: 6}}, data.Proficiencies)
+
+	// Nimble Escape isn't implemented as a trait, so nothing is added
+	s.Empty(data.Conditions)
+
+	s.Require().Len(goblin.Actions(), 2)
+	scimitar, ok := goblin.Actions()[0].(*actions.MeleeAction)
+	s.Require().True(ok)
+	s.Equal("scimitar", scimitar.GetID())
+
+	shortbow, ok := goblin.Actions()[1].(*actions.RangedAction)
+	s.Require().True(ok)
+	s.Equal("shortbow", shortbow.GetID())
+}
+
+func (s *APILoaderTestSuite) TestBrownBearMultiattack() {
+	bear, err := FromAPIJSON("bear-1", []byte(apiBrownBearJSON))
+	s.Require().NoError(err)
+
+	s.True(bear.Ref().Equals(refs.Monsters.BrownBear()))
+	s.Equal(11, bear.AC())
+	s.Equal(monster.SpeedData{Walk: 40, Climb: 30}, bear.Speed())
+
+	s.Require().Len(bear.Actions(), 3)
+	s.Equal(multiattackActionID, bear.Actions()[0].GetID())
+
+	var config actions.MultiattackConfig
+	s.Require().NoError(json.Unmarshal(bear.Actions()[0].ToData().Config, &config))
+	s.Equal([]string{"bite", "claw"}, config.Attacks)
+	s.Equal("bite", bear.Actions()[1].GetID())
+	s.Equal("claw", bear.Actions()[2].GetID())
+}
+
+func (s *APILoaderTestSuite) TestDamageTraits() {
+	wight, err := FromAPIJSON("wight-1", []byte(apiWightJSON))
+	s.Require().NoError(err)
+
+	// Not in refs.Monsters, so the ref comes from the index
+	s.Equal("wight", wight.Ref().ID)
+	s.Equal(refs.TypeMonsters, wight.Ref().Type)
+	s.Equal(monster.CreatureTypeUndead, wight.CreatureType())
+
+	s.Equal([]string{
+		"resistance:necrotic",
+		"resistance:bludgeoning",
+		"resistance:piercing",
+		"resistance:slashing",
+		"immunity:poison",
+	}, s.traitRefs(wight))
+
+	// "from nonmagical weapons that aren't silvered" is bypassed by both
+	var slashing monstertraits.ResistanceData
+	s.Require().NoError(json.Unmarshal(wight.ToData().Conditions[3], &slashing))
+	s.Equal(damage.Slashing, slashing.DamageType)
+	s.Equal([]damage.Annotation{damage.AnnotationMagical, damage.AnnotationSilvered}, slashing.BypassedBy)
+	var necrotic monstertraits.ResistanceData
+	s.Require().NoError(json.Unmarshal(wight.ToData().Conditions[0], &necrotic))
+	s.Empty(necrotic.BypassedBy)
+
+	s.Require().Len(wight.Actions(), 2)
+	s.Equal("life-drain", wight.Actions()[0].GetID())
+}
+
+func (s *APILoaderTestSuite) TestUndeadFortitude() {
+	zombie, err := FromAPIJSON("zombie-1", []byte(`{
+		"index": "zombie",
+		"name": "Zombie",
+		"armor_class": 8,
+		"hit_points": 22,
+		"constitution": 16, "wisdom": 6,
+		"proficiencies": [{"value": 0, "proficiency": {"index": "saving-throw-wis"}}],
+		"damage_immunities": ["poison"],
+		"special_abilities": [{"name": "Undead Fortitude"}]
+	}`))
+	s.Require().NoError(err)
+	s.Equal([]string{"immunity:poison", "undead_fortitude"}, s.traitRefs(zombie))
+
+	// Saving Throws Wis +0, against a -2 ability modifier
+	s.Equal(0, zombie.GetSavingThrowModifier(abilities.WIS))
+	s.Equal(3, zombie.GetSavingThrowModifier(abilities.CON))
+}
+
+func (s *APILoaderTestSuite) TestInvalidInput() {
+	_, err := FromAPIJSON("", []byte(apiGoblinJSON))
+	s.Error(err)
+
+	_, err = FromAPIJSON("goblin-1", []byte(`not json`))
+	s.Error(err)
+
+	_, err = FromAPIJSON("goblin-1", []byte(`{"name": "Goblin"}`))
+	s.Error(err)
+}
@@ -19,6 +19,7 @@ func NewBanditMelee(id string) *monster.Monster {
 		ID:   id,
 		Name: "Bandit",
 		Ref:  refs.Monsters.Bandit(),
+		Type: monster.CreatureTypeHumanoid,
 		HP:   11, // 2d8+2
 		AC:   12, // Leather armor
 		AbilityScores: shared.AbilityScores{
@@ -18,6 +18,7 @@ func NewBrownBear(id string) *monster.Monster {
 		ID:   id,
 		Name: "Brown Bear",
 		Ref:  refs.Monsters.BrownBear(),
+		Type: monster.CreatureTypeBeast,
 		HP:   34, // 4d10+12
 		AC:   11, // Natural armor
 		AbilityScores: shared.AbilityScores{
@@ -18,6 +18,7 @@ func NewGhoul(id string) *monster.Monster {
 		ID:   id,
 		Name: "Ghoul",
 		Ref:  refs.Monsters.Ghoul(),
+		Type: monster.CreatureTypeUndead,
 		HP:   22, // 5d8
 		AC:   12, // Natural armor
 		AbilityScores: shared.AbilityScores{
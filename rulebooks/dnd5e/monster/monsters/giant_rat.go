@@ -18,6 +18,7 @@ func NewGiantRat(id string) *monster.Monster {
 		ID:   id,
 		Name: "Giant Rat",
 		Ref:  refs.Monsters.GiantRat(),
+		Type: monster.CreatureTypeBeast,
 		HP:   7,  // 2d6
 		AC:   12, // Natural armor
 		AbilityScores: shared.AbilityScores{
@@ -19,6 +19,7 @@ func NewSkeleton(id string) *monster.Monster {
 		ID:   id,
 		Name: "Skeleton",
 		Ref:  refs.Monsters.Skeleton(),
+		Type: monster.CreatureTypeUndead,
 		HP:   13, // 2d8+4
 		AC:   13, // Armor scraps
 		AbilityScores: shared.AbilityScores{
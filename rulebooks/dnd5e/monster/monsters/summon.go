@@ -0,0 +1,226 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package monsters
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/dice"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/initiative"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster/actions"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monstertraits"
+)
+
+// SummonDismissed is the reason used when summons are dismissed directly
+const SummonDismissed = "dismissed"
+
+// Factory creates a monster with the given ID (e.g., NewWolf, NewSkeleton)
+type Factory func(id string) *monster.Monster
+
+// SummonInput configures summoning creatures into combat
+type SummonInput struct {
+	// SummonerID is the creature that controls the summons
+	SummonerID string
+
+	// Factory creates each summoned creature
+	Factory Factory
+
+	// Count is how many creatures to summon
+	Count int
+
+	// IDPrefix names the summons "<IDPrefix>-1", "<IDPrefix>-2", ...
+	IDPrefix string
+
+	// Spell ties the summons to the summoner's concentration on this spell
+	// (e.g., Conjure Animals). They are dismissed when that concentration ends.
+	// Leave empty for summons that last on their own (e.g., Animate Dead).
+	Spell string
+
+	// Tracker, if set, gets the summons inserted directly after the summoner,
+	// so they act right after the summoner's turn
+	Tracker *initiative.Tracker
+
+	// EventBus wires the summons into combat
+	EventBus events.EventBus
+
+	// Roller is used by the summons' traits (optional)
+	Roller dice.Roller
+}
+
+// Validate validates the input
+func (i *SummonInput) Validate() error {
+	if i == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "SummonInput is nil")
+	}
+	if i.SummonerID == "" {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "SummonerID is required")
+	}
+	if i.Factory == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "Factory is required")
+	}
+	if i.Count < 1 {
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument, "Count must be at least 1, got %d", i.Count)
+	}
+	if i.IDPrefix == "" {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "IDPrefix is required")
+	}
+	if i.EventBus == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "EventBus is required")
+	}
+	return nil
+}
+
+// Summons is a group of creatures summoned together. It removes them from
+// combat when their summoning ends.
+type Summons struct {
+	summonerID     string
+	spell          string
+	monsters       []*monster.Monster
+	tracker        *initiative.Tracker
+	bus            events.EventBus
+	subscriptionID string
+	dismissed      bool
+}
+
+// Summon creates the summoned creatures, wires them to the event bus with
+// their actions and traits, and registers them in initiative after the
+// summoner. Summons tied to a spell are dismissed automatically when the
+// summoner's concentration on it ends (see conditions.ConcentratingCondition).
+func Summon(ctx context.Context, input *SummonInput) (*Summons, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	summons := &Summons{
+		summonerID: input.SummonerID,
+		spell:      input.Spell,
+		tracker:    input.Tracker,
+		bus:        input.EventBus,
+		monsters:   make([]*monster.Monster, 0, input.Count),
+	}
+
+	for n := 1; n <= input.Count; n++ {
+		m, err := loadSummon(ctx, input, fmt.Sprintf("%s-%d", input.IDPrefix, n))
+		if err != nil {
+			_ = summons.Dismiss(ctx, SummonDismissed)
+			return nil, err
+		}
+		summons.monsters = append(summons.monsters, m)
+	}
+
+	if input.Tracker != nil {
+		entities := make([]core.Entity, len(summons.monsters))
+		for i, m := range summons.monsters {
+			entities[i] = m
+		}
+		if err := input.Tracker.InsertAfter(input.SummonerID, entities...); err != nil {
+			_ = summons.Dismiss(ctx, SummonDismissed)
+			return nil, rpgerr.WrapWithCode(err, rpgerr.CodeNotFound, "failed to add summons to initiative")
+		}
+	}
+
+	if input.Spell != "" {
+		subID, err := dnd5eEvents.ConcentrationEndedTopic.On(input.EventBus).Subscribe(ctx, summons.onConcentrationEnded)
+		if err != nil {
+			_ = summons.Dismiss(ctx, SummonDismissed)
+			return nil, rpgerr.Wrap(err, "failed to subscribe to concentration ended")
+		}
+		summons.subscriptionID = subID
+	}
+
+	return summons, nil
+}
+
+// loadSummon creates one summoned creature and wires it to the bus
+func loadSummon(ctx context.Context, input *SummonInput, id string) (*monster.Monster, error) {
+	data := input.Factory(id).ToData()
+	data.SummonerID = input.SummonerID
+
+	m, err := monster.LoadFromData(ctx, data, input.EventBus)
+	if err != nil {
+		return nil, rpgerr.Wrapf(err, "failed to load summon %s", id)
+	}
+	if err := actions.LoadMonsterActions(m, data.Actions); err != nil {
+		_ = m.Cleanup(ctx)
+		return nil, rpgerr.Wrapf(err, "failed to load actions for summon %s", id)
+	}
+	if err := monstertraits.LoadMonsterConditions(ctx, m, data.Conditions, input.EventBus, input.Roller); err != nil {
+		_ = m.Cleanup(ctx)
+		return nil, rpgerr.Wrapf(err, "failed to load traits for summon %s", id)
+	}
+	return m, nil
+}
+
+// Monsters returns the summoned creatures
+func (s *Summons) Monsters() []*monster.Monster {
+	return s.monsters
+}
+
+// SummonerID returns the creature that controls the summons
+func (s *Summons) SummonerID() string {
+	return s.summonerID
+}
+
+// IsDismissed returns true once the summons have left combat
+func (s *Summons) IsDismissed() bool {
+	return s.dismissed
+}
+
+// Dismiss removes the summons from combat: their traits and subscriptions are
+// removed, they leave the initiative order, and a SummonDismissedEvent is
+// published for each. Dismissing twice is a no-op.
+func (s *Summons) Dismiss(ctx context.Context, reason string) error {
+	if s.dismissed {
+		return nil
+	}
+	s.dismissed = true
+
+	if s.subscriptionID != "" {
+		if err := s.bus.Unsubscribe(ctx, s.subscriptionID); err != nil {
+			return rpgerr.Wrap(err, "failed to unsubscribe from concentration ended")
+		}
+		s.subscriptionID = ""
+	}
+
+	for _, m := range s.monsters {
+		for _, condition := range m.GetConditions() {
+			if err := condition.Remove(ctx, s.bus); err != nil {
+				return rpgerr.Wrapf(err, "failed to remove condition from summon %s", m.GetID())
+			}
+		}
+		if err := m.Cleanup(ctx); err != nil {
+			return rpgerr.Wrapf(err, "failed to clean up summon %s", m.GetID())
+		}
+
+		// A summon may already have left initiative (e.g. it was destroyed)
+		if s.tracker != nil {
+			_ = s.tracker.Remove(m.GetID())
+		}
+
+		err := dnd5eEvents.SummonDismissedTopic.On(s.bus).Publish(ctx, dnd5eEvents.SummonDismissedEvent{
+			SummonID:   m.GetID(),
+			SummonerID: s.summonerID,
+			Reason:     reason,
+		})
+		if err != nil {
+			return rpgerr.Wrapf(err, "failed to publish summon dismissed for %s", m.GetID())
+		}
+	}
+
+	return nil
+}
+
+// onConcentrationEnded dismisses the summons when their spell ends
+func (s *Summons) onConcentrationEnded(ctx context.Context, event dnd5eEvents.ConcentrationEndedEvent) error {
+	if event.CasterID != s.summonerID || event.SpellID != s.spell {
+		return nil
+	}
+	return s.Dismiss(ctx, event.Reason)
+}
@@ -0,0 +1,137 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package monsters
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/conditions"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/initiative"
+)
+
+type SummonTestSuite struct {
+	suite.Suite
+	ctx       context.Context
+	bus       events.EventBus
+	tracker   *initiative.Tracker
+	dismissed []dnd5eEvents.SummonDismissedEvent
+}
+
+func TestSummonSuite(t *testing.T) {
+	suite.Run(t, new(SummonTestSuite))
+}
+
+func (s *SummonTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.dismissed = nil
+	s.tracker = initiative.New([]core.Entity{
+		initiative.NewParticipant("druid-1", dnd5e.EntityTypeCharacter),
+		initiative.NewParticipant("orc-1", dnd5e.EntityTypeMonster),
+	})
+
+	_, err := dnd5eEvents.SummonDismissedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, event dnd5eEvents.SummonDismissedEvent) error {
+			s.dismissed = append(s.dismissed, event)
+			return nil
+		})
+	s.Require().NoError(err)
+}
+
+func (s *SummonTestSuite) turnOrder() []string {
+	var ids []string
+	for _, entity := range s.tracker.ToData().Order {
+		ids = append(ids, entity.ID)
+	}
+	return ids
+}
+
+func (s *SummonTestSuite) TestConjureAnimals() {
+	concentration := conditions.NewConcentratingCondition("druid-1", "conjure-animals", 2)
+	s.Require().NoError(concentration.Apply(s.ctx, s.bus))
+
+	summons, err := Summon(s.ctx, &SummonInput{
+		SummonerID: "druid-1",
+		Factory:    NewWolf,
+		Count:      2,
+		IDPrefix:   "wolf",
+		Spell:      "conjure-animals",
+		Tracker:    s.tracker,
+		EventBus:   s.bus,
+	})
+	s.Require().NoError(err)
+
+	s.Require().Len(summons.Monsters(), 2)
+	wolf := summons.Monsters()[0]
+	s.Equal("wolf-1", wolf.GetID())
+	s.Equal("druid-1", wolf.SummonerID())
+	s.NotEmpty(wolf.Actions(), "actions are loaded")
+	s.Equal([]string{"druid-1", "wolf-1", "wolf-2", "orc-1"}, s.turnOrder())
+
+	// Summons take damage through the bus
+	s.Require().NoError(dnd5eEvents.DamageReceivedTopic.On(s.bus).Publish(s.ctx,
+		dnd5eEvents.DamageReceivedEvent{TargetID: "wolf-1", Amount: 3}))
+	s.Equal(8, wolf.HP())
+
+	// The druid drops: concentration ends and the wolves vanish
+	s.Require().NoError(dnd5eEvents.ConditionAppliedTopic.On(s.bus).Publish(s.ctx,
+		dnd5eEvents.ConditionAppliedEvent{
+			Target: initiative.NewParticipant("druid-1", dnd5e.EntityTypeCharacter),
+			Type:   dnd5eEvents.ConditionUnconscious,
+		}))
+
+	s.True(summons.IsDismissed())
+	s.Equal([]string{"druid-1", "orc-1"}, s.turnOrder())
+	s.Equal([]dnd5eEvents.SummonDismissedEvent{
+		{SummonID: "wolf-1", SummonerID: "druid-1", Reason: "unconscious"},
+		{SummonID: "wolf-2", SummonerID: "druid-1", Reason: "unconscious"},
+	}, s.dismissed)
+
+	// Dismissed summons no longer react to the bus
+	s.Require().NoError(dnd5eEvents.DamageReceivedTopic.On(s.bus).Publish(s.ctx,
+		dnd5eEvents.DamageReceivedEvent{TargetID: "wolf-1", Amount: 3}))
+	s.Equal(8, wolf.HP())
+}
+
+func (s *SummonTestSuite) TestAnimateDeadOutlastsConcentration() {
+	summons, err := Summon(s.ctx, &SummonInput{
+		SummonerID: "druid-1",
+		Factory:    NewSkeleton,
+		Count:      1,
+		IDPrefix:   "skeleton",
+		Tracker:    s.tracker,
+		EventBus:   s.bus,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(summons.Monsters(), 1)
+	s.Len(summons.Monsters()[0].GetConditions(), 2, "vulnerability and immunity traits are applied")
+
+	s.Require().NoError(dnd5eEvents.ConcentrationEndedTopic.On(s.bus).Publish(s.ctx,
+		dnd5eEvents.ConcentrationEndedEvent{CasterID: "druid-1", SpellID: "bless", Reason: "ended"}))
+	s.False(summons.IsDismissed())
+	s.Equal([]string{"druid-1", "skeleton-1", "orc-1"}, s.turnOrder())
+
+	s.Require().NoError(summons.Dismiss(s.ctx, SummonDismissed))
+	s.Require().NoError(summons.Dismiss(s.ctx, SummonDismissed))
+	s.Len(s.dismissed, 1)
+	s.Equal([]string{"druid-1", "orc-1"}, s.turnOrder())
+}
+
+func (s *SummonTestSuite) TestValidation() {
+	_, err := Summon(s.ctx, &SummonInput{SummonerID: "druid-1", Factory: NewWolf, IDPrefix: "wolf", EventBus: s.bus})
+	s.Error(err, "count is required")
+
+	_, err = Summon(s.ctx, &SummonInput{
+		SummonerID: "lich-1", Factory: NewWolf, Count: 1, IDPrefix: "wolf", Tracker: s.tracker, EventBus: s.bus,
+	})
+	s.Error(err, "summoner must be in initiative")
+	s.Len(s.dismissed, 1, "partially created summons are cleaned up")
+}
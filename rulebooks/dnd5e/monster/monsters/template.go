@@ -0,0 +1,246 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package monsters
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster/actions"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monstertraits"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/spells"
+)
+
+// Ability scores stay within the stat block limits
+const (
+	minAbilityScore = 1
+	maxAbilityScore = 30
+)
+
+// Template transforms a base stat block into a themed variant
+// (e.g., a zombie ogre or a skeleton wolf) without a new factory
+type Template struct {
+	// NamePrefix is prepended to the base name ("Zombie" + "Wolf" = "Zombie Wolf")
+	NamePrefix string
+
+	// CreatureType replaces the base creature type (optional)
+	CreatureType monster.CreatureType
+
+	// AbilityScores replace base scores (e.g., a zombie's INT 3)
+	AbilityScores map[abilities.Ability]int
+
+	// AbilityAdjustments are added to base scores after replacements
+	AbilityAdjustments map[abilities.Ability]int
+
+	// ACAdjustment is added to the base armor class
+	ACAdjustment int
+
+	// Darkvision raises the base darkvision to at least this many feet
+	Darkvision int
+
+	// Damage traits added to the base traits
+	Vulnerabilities []damage.Type
+	Resistances     []damage.Type
+	Immunities      []damage.Type
+
+	// UndeadFortitude adds the Undead Fortitude trait
+	UndeadFortitude bool
+
+	// DropSpellcasting removes the base creature's spells
+	DropSpellcasting bool
+}
+
+// Validate validates the template
+func (t *Template) Validate() error {
+	if t == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "template is nil")
+	}
+	if t.NamePrefix == "" {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "NamePrefix is required")
+	}
+	return nil
+}
+
+// ZombieTemplate returns the template for a zombie version of a creature:
+// slower-witted and clumsier but tougher, immune to poison, with Undead Fortitude
+func ZombieTemplate() *Template {
+	return &Template{
+		NamePrefix:   "Zombie",
+		CreatureType: monster.CreatureTypeUndead,
+		AbilityScores: map[abilities.Ability]int{
+			abilities.INT: 3,
+			abilities.WIS: 6,
+			abilities.CHA: 5,
+		},
+		AbilityAdjustments: map[abilities.Ability]int{
+			abilities.DEX: -2,
+			abilities.CON: 2,
+		},
+		Darkvision:       60,
+		Immunities:       []damage.Type{damage.Poison},
+		UndeadFortitude:  true,
+		DropSpellcasting: true,
+	}
+}
+
+// SkeletonTemplate returns the template for a skeleton version of a creature:
+// mindless bones vulnerable to bludgeoning and immune to poison
+func SkeletonTemplate() *Template {
+	return &Template{
+		NamePrefix:   "Skeleton",
+		CreatureType: monster.CreatureTypeUndead,
+		AbilityScores: map[abilities.Ability]int{
+			abilities.INT: 6,
+			abilities.WIS: 8,
+			abilities.CHA: 5,
+		},
+		Darkvision:       60,
+		Vulnerabilities:  []damage.Type{damage.Bludgeoning},
+		Immunities:       []damage.Type{damage.Poison},
+		DropSpellcasting: true,
+	}
+}
+
+// ApplyTemplate creates a new monster from a base stat block with the template
+// applied. The base monster is not modified. The derived monster is at full
+// hit points and keeps the base ID, ref, actions, traits, and lair.
+//
+// Actions keep their base attack bonuses and damage; ability changes affect
+// saves, checks, and traits such as Undead Fortitude.
+func ApplyTemplate(base *monster.Monster, template *Template) (*monster.Monster, error) {
+	if base == nil {
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "base monster is nil")
+	}
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+
+	data := base.ToData()
+
+	scores := make(map[abilities.Ability]int, len(data.AbilityScores))
+	for ability, score := range data.AbilityScores {
+		scores[ability] = score
+	}
+	for ability, score := range template.AbilityScores {
+		scores[ability] = score
+	}
+	for ability, adjustment := range template.AbilityAdjustments {
+		scores[ability] = min(max(scores[ability]+adjustment, minAbilityScore), maxAbilityScore)
+	}
+
+	creatureType := data.CreatureType
+	if template.CreatureType != "" {
+		creatureType = template.CreatureType
+	}
+
+	derived := monster.New(monster.Config{
+		ID:               data.ID,
+		Name:             template.NamePrefix + " " + data.Name,
+		Ref:              data.Ref,
+		Type:             creatureType,
+		HP:               data.MaxHitPoints,
+		AC:               data.ArmorClass + template.ACAdjustment,
+		AbilityScores:    scores,
+		ProficiencyBonus: data.ProficiencyBonus,
+	})
+
+	derived.SetSpeed(data.Speed)
+	senses := data.Senses
+	senses.Darkvision = max(senses.Darkvision, template.Darkvision)
+	derived.SetSenses(senses)
+	derived.SetTargeting(data.Targeting)
+
+	for _, prof := range data.Proficiencies {
+		derived.SetProficiency(prof.Skill, prof.Bonus)
+	}
+	for _, save := range data.SavingThrows {
+		derived.SetSavingThrow(save.Ability, save.Bonus)
+	}
+
+	if err := derived.SetLair(data.Lair); err != nil {
+		return nil, rpgerr.Wrapf(err, "failed to copy lair for %s", data.ID)
+	}
+
+	if err := actions.LoadMonsterActions(derived, data.Actions); err != nil {
+		return nil, rpgerr.Wrapf(err, "failed to copy actions for %s", data.ID)
+	}
+
+	if data.Spellcasting != nil && !template.DropSpellcasting {
+		spellcasting, err := spells.NewSpellcasting(data.Spellcasting)
+		if err != nil {
+			return nil, rpgerr.Wrapf(err, "failed to copy spellcasting for %s", data.ID)
+		}
+		derived.SetSpellcasting(spellcasting)
+	}
+
+	traits, err := templateTraits(data.ID, template, derived)
+	if err != nil {
+		return nil, err
+	}
+	added := make([]json.RawMessage, 0, len(data.Conditions)+len(traits))
+	for _, trait := range append(data.Conditions, traits...) {
+		if !containsTrait(added, trait) {
+			derived.AddTraitData(trait)
+			added = append(added, trait)
+		}
+	}
+
+	return derived, nil
+}
+
+// WithTemplate wraps a factory so every monster it creates has the template
+// applied (e.g., WithTemplate(NewWolf, ZombieTemplate()) for Animate Dead).
+// It panics if the template cannot be applied, which indicates a programming
+// error in the template or factory.
+func WithTemplate(factory Factory, template *Template) Factory {
+	return func(id string) *monster.Monster {
+		m, err := ApplyTemplate(factory(id), template)
+		if err != nil {
+			panic("monsters: failed to apply template: " + err.Error())
+		}
+		return m
+	}
+}
+
+// templateTraits builds the trait data the template adds to the monster
+func templateTraits(id string, template *Template, derived *monster.Monster) ([]json.RawMessage, error) {
+	traits := make([]json.RawMessage, 0,
+		len(template.Vulnerabilities)+len(template.Resistances)+len(template.Immunities)+1)
+
+	for _, damageType := range template.Vulnerabilities {
+		traits = append(traits, monstertraits.MustVulnerabilityJSON(id, damageType))
+	}
+	for _, damageType := range template.Resistances {
+		traits = append(traits, monstertraits.MustResistanceJSON(id, damageType))
+	}
+	for _, damageType := range template.Immunities {
+		traits = append(traits, monstertraits.MustImmunityJSON(id, damageType))
+	}
+
+	if template.UndeadFortitude {
+		conMod := derived.AbilityScores().Modifier(abilities.CON)
+		traitJSON, err := monstertraits.UndeadFortitude(id, conMod, nil).ToJSON()
+		if err != nil {
+			return nil, rpgerr.Wrapf(err, "failed to convert undead fortitude for %s", id)
+		}
+		traits = append(traits, traitJSON)
+	}
+
+	return traits, nil
+}
+
+// containsTrait reports whether the trait data is already present, so
+// templating a skeleton as a skeleton doesn't stack its traits
+func containsTrait(traits []json.RawMessage, trait json.RawMessage) bool {
+	for _, existing := range traits {
+		if bytes.Equal(existing, trait) {
+			return true
+		}
+	}
+	return false
+}
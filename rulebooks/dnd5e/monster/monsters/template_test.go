@@ -0,0 +1,133 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package monsters
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+type TemplateTestSuite struct {
+	suite.Suite
+}
+
+func TestTemplateSuite(t *testing.T) {
+	suite.Run(t, new(TemplateTestSuite))
+}
+
+// traitRefs returns the ref IDs of the monster's trait data in order
+func (s *TemplateTestSuite) traitRefs(m *monster.Monster) []string {
+	var ids []string
+	for _, data := range m.ToData().Conditions {
+		var peek struct {
+			Ref *core.Ref `json:"ref"`
+		}
+		s.Require().NoError(json.Unmarshal(data, &peek))
+		ids = append(ids, peek.Ref.ID)
+	}
+	return ids
+}
+
+func (s *TemplateTestSuite) TestZombieWolf() {
+	wolf := NewWolf("wolf-1")
+
+	zombie, err := ApplyTemplate(wolf, ZombieTemplate())
+	s.Require().NoError(err)
+
+	s.Equal("wolf-1", zombie.GetID())
+	s.Equal("Zombie Wolf", zombie.Name())
+	s.Equal(refs.Monsters.Wolf(), zombie.Ref())
+	s.Equal(monster.CreatureTypeUndead, zombie.CreatureType())
+	s.Equal(wolf.MaxHP(), zombie.HP())
+	s.Equal(wolf.AC(), zombie.AC())
+
+	scores := zombie.AbilityScores()
+	s.Equal(12, scores[abilities.STR], "unchanged")
+	s.Equal(13, scores[abilities.DEX], "15 - 2")
+	s.Equal(14, scores[abilities.CON], "12 + 2")
+	s.Equal(3, scores[abilities.INT])
+	s.Equal(6, scores[abilities.WIS])
+	s.Equal(5, scores[abilities.CHA])
+
+	s.Equal(60, zombie.Senses().Darkvision)
+	s.Equal(wolf.Speed(), zombie.Speed())
+	s.Len(zombie.Actions(), len(wolf.Actions()))
+	s.Equal([]string{
+		refs.MonsterTraits.Immunity().ID,
+		refs.MonsterTraits.UndeadFortitude().ID,
+	}, s.traitRefs(zombie))
+
+	// The base stat block is untouched
+	s.Equal("Wolf", wolf.Name())
+	s.Equal(monster.CreatureTypeBeast, wolf.CreatureType())
+	s.Equal(3, wolf.AbilityScores()[abilities.INT])
+	s.Empty(wolf.ToData().Conditions)
+}
+
+func (s *TemplateTestSuite) TestSkeletonDoesNotStackTraits() {
+	skeleton, err := ApplyTemplate(NewSkeleton("skeleton-1"), SkeletonTemplate())
+	s.Require().NoError(err)
+
+	s.Equal("Skeleton Skeleton", skeleton.Name())
+	s.Equal([]string{
+		refs.MonsterTraits.Vulnerability().ID,
+		refs.MonsterTraits.Immunity().ID,
+	}, s.traitRefs(skeleton))
+}
+
+func (s *TemplateTestSuite) TestCustomTemplate() {
+	template := &Template{
+		NamePrefix:         "Giant",
+		AbilityAdjustments: map[abilities.Ability]int{abilities.STR: 25},
+		ACAdjustment:       2,
+	}
+
+	giant, err := ApplyTemplate(NewGiantRat("rat-1"), template)
+	s.Require().NoError(err)
+
+	s.Equal("Giant Giant Rat", giant.Name())
+	s.Equal(monster.CreatureTypeBeast, giant.CreatureType(), "type is kept when the template has none")
+	s.Equal(NewGiantRat("rat-1").AC()+2, giant.AC())
+	s.Equal(30, giant.AbilityScores()[abilities.STR], "scores are capped at 30")
+}
+
+func (s *TemplateTestSuite) TestWithTemplateSummons() {
+	ctx := context.Background()
+	bus := events.NewEventBus()
+
+	summons, err := Summon(ctx, &SummonInput{
+		SummonerID: "necromancer-1",
+		Factory:    WithTemplate(NewWolf, SkeletonTemplate()),
+		Count:      2,
+		IDPrefix:   "skeleton-wolf",
+		EventBus:   bus,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(summons.Monsters(), 2)
+
+	wolf := summons.Monsters()[1]
+	s.Equal("skeleton-wolf-2", wolf.GetID())
+	s.Equal("Skeleton Wolf", wolf.Name())
+	s.Len(wolf.GetConditions(), 2, "template traits are applied in combat")
+}
+
+func (s *TemplateTestSuite) TestInvalidInput() {
+	_, err := ApplyTemplate(nil, ZombieTemplate())
+	s.Error(err)
+
+	_, err = ApplyTemplate(NewWolf("wolf-1"), nil)
+	s.Error(err)
+
+	_, err = ApplyTemplate(NewWolf("wolf-1"), &Template{})
+	s.Error(err, "name prefix is required")
+}
@@ -18,6 +18,7 @@ func NewThug(id string) *monster.Monster {
 		ID:   id,
 		Name: "Thug",
 		Ref:  refs.Monsters.Thug(),
+		Type: monster.CreatureTypeHumanoid,
 		HP:   32, // 5d8+10
 		AC:   11, // Leather armor
 		AbilityScores: shared.AbilityScores{
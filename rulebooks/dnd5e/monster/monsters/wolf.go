@@ -18,6 +18,7 @@ func NewWolf(id string) *monster.Monster {
 		ID:   id,
 		Name: "Wolf",
 		Ref:  refs.Monsters.Wolf(),
+		Type: monster.CreatureTypeBeast,
 		HP:   11, // 2d8+2
 		AC:   13, // Natural armor
 		AbilityScores: shared.AbilityScores{
@@ -19,6 +19,7 @@ func NewZombie(id string) *monster.Monster {
 		ID:   id,
 		Name: "Zombie",
 		Ref:  refs.Monsters.Zombie(),
+		Type: monster.CreatureTypeUndead,
 		HP:   22, // 3d8+9
 		AC:   8,  // No armor
 		AbilityScores: shared.AbilityScores{
@@ -0,0 +1,73 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package monster
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/rpg-toolkit/dice"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+)
+
+// RechargeableAction is a monster action with a "Recharge X–6" limit, such as
+// a dragon's breath weapon. After it is used, it can't be used again until the
+// monster rolls X or higher on a d6 at the start of one of its turns.
+type RechargeableAction interface {
+	MonsterAction
+
+	// RechargeOn returns the lowest d6 roll that recharges the action
+	RechargeOn() int
+
+	// IsCharged returns true if the action can be used
+	IsCharged() bool
+
+	// SetCharged sets whether the action can be used
+	SetCharged(charged bool)
+}
+
+// RollRecharges rolls a d6 for each spent rechargeable action and recharges
+// those that roll high enough. Called at the start of the monster's turn;
+// TakeTurn does this automatically when given a roller.
+//
+// Publishes a RechargeRolledEvent per roll when bus is not nil.
+func (m *Monster) RollRecharges(ctx context.Context, bus events.EventBus, roller dice.Roller) error {
+	if roller == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "roller is required")
+	}
+
+	for _, action := range m.actions {
+		rechargeable, ok := action.(RechargeableAction)
+		if !ok || rechargeable.IsCharged() {
+			continue
+		}
+
+		roll, err := roller.Roll(ctx, 6)
+		if err != nil {
+			return rpgerr.Wrapf(err, "failed to roll recharge for %s", action.GetID())
+		}
+
+		recharged := roll >= rechargeable.RechargeOn()
+		if recharged {
+			rechargeable.SetCharged(true)
+		}
+
+		if bus == nil {
+			continue
+		}
+		err = dnd5eEvents.RechargeRolledTopic.On(bus).Publish(ctx, dnd5eEvents.RechargeRolledEvent{
+			OwnerID:    m.id,
+			ActionID:   action.GetID(),
+			Roll:       roll,
+			RechargeOn: rechargeable.RechargeOn(),
+			Recharged:  recharged,
+		})
+		if err != nil {
+			return rpgerr.Wrap(err, "failed to publish recharge rolled event")
+		}
+	}
+
+	return nil
+}
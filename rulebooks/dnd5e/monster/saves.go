@@ -0,0 +1,147 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package monster
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/rpg-toolkit/dice"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/checks"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/saves"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
+)
+
+// SetSavingThrow sets the monster's total bonus for a saving throw
+// (e.g., a Mage's "Saving Throws Int +6, Wis +4")
+func (m *Monster) SetSavingThrow(ability abilities.Ability, bonus int) {
+	if m.savingThrows == nil {
+		m.savingThrows = make(map[abilities.Ability]int)
+	}
+	m.savingThrows[ability] = bonus
+}
+
+// GetSavingThrowModifier returns the total modifier for a saving throw.
+// Stat blocks list the full bonus for proficient saves; all others use the
+// ability modifier.
+func (m *Monster) GetSavingThrowModifier(ability abilities.Ability) int {
+	if bonus, ok := m.savingThrows[ability]; ok {
+		return bonus
+	}
+	return m.abilityScores.Modifier(ability)
+}
+
+// GetSkillModifier returns the total modifier for a skill check.
+// Stat blocks list the full bonus for proficient skills; all others use the
+// skill's ability modifier.
+func (m *Monster) GetSkillModifier(skill skills.Skill) int {
+	if bonus, ok := m.proficiencies[skill]; ok {
+		return bonus
+	}
+	return m.abilityScores.Modifier(skills.Ability(skill))
+}
+
+// RollSaveInput contains parameters for a monster saving throw
+type RollSaveInput struct {
+	// Roller is the dice roller to use. If nil, defaults to dice.NewRoller().
+	Roller dice.Roller
+
+	// EventBus fires the SavingThrowChain. If nil, the monster's own bus is used.
+	EventBus events.EventBus
+
+	// Cause provides context about what triggered this saving throw
+	Cause dnd5eEvents.SaveCause
+
+	// Ability is the ability score being tested (STR, DEX, CON, INT, WIS, CHA)
+	Ability abilities.Ability
+
+	// DC is the Difficulty Class that must be met or exceeded
+	DC int
+
+	// HasAdvantage indicates the monster has advantage on this save
+	HasAdvantage bool
+
+	// HasDisadvantage indicates the monster has disadvantage on this save
+	HasDisadvantage bool
+}
+
+// RollSave makes a saving throw for this monster.
+// The monster's save bonus is applied, and conditions and traits modify the
+// roll through the SavingThrowChain, the same as for characters.
+func (m *Monster) RollSave(ctx context.Context, input *RollSaveInput) (*saves.SavingThrowResult, error) {
+	bus := input.EventBus
+	if bus == nil {
+		bus = m.bus
+	}
+
+	return saves.MakeSavingThrow(ctx, &saves.SavingThrowInput{
+		Roller:          input.Roller,
+		EventBus:        bus,
+		SaverID:         m.id,
+		Cause:           input.Cause,
+		Ability:         input.Ability,
+		DC:              input.DC,
+		Modifier:        m.GetSavingThrowModifier(input.Ability),
+		HasAdvantage:    input.HasAdvantage,
+		HasDisadvantage: input.HasDisadvantage,
+	})
+}
+
+// RollCheckInput contains parameters for a monster ability check
+type RollCheckInput struct {
+	// Roller is the dice roller to use. If nil, defaults to dice.NewRoller().
+	Roller dice.Roller
+
+	// EventBus fires the AbilityCheckChain. If nil, the monster's own bus is used.
+	EventBus events.EventBus
+
+	// Cause provides context about what prompted this check
+	Cause dnd5eEvents.CheckCause
+
+	// Ability is the ability score being tested.
+	// If empty and Skill is set, the skill's ability is used.
+	Ability abilities.Ability
+
+	// Skill is the skill applied to the check, if any (e.g. skills.Stealth)
+	Skill skills.Skill
+
+	// DC is the Difficulty Class to meet. Leave 0 for a contest.
+	DC int
+
+	// HasAdvantage indicates the monster has advantage on this check
+	HasAdvantage bool
+
+	// HasDisadvantage indicates the monster has disadvantage on this check
+	HasDisadvantage bool
+}
+
+// RollCheck makes an ability check for this monster.
+// With a skill, the monster's skill bonus is applied; otherwise the ability
+// modifier. The roll goes through the AbilityCheckChain like a character's.
+func (m *Monster) RollCheck(ctx context.Context, input *RollCheckInput) (*checks.AbilityCheckResult, error) {
+	bus := input.EventBus
+	if bus == nil {
+		bus = m.bus
+	}
+
+	modifier := m.abilityScores.Modifier(input.Ability)
+	if input.Skill != "" && (input.Ability == "" || input.Ability == skills.Ability(input.Skill)) {
+		modifier = m.GetSkillModifier(input.Skill)
+	}
+
+	return checks.MakeAbilityCheck(ctx, &checks.AbilityCheckInput{
+		Roller:          input.Roller,
+		EventBus:        bus,
+		CheckerID:       m.id,
+		Cause:           input.Cause,
+		Ability:         input.Ability,
+		Skill:           input.Skill,
+		DC:              input.DC,
+		Modifier:        modifier,
+		HasAdvantage:    input.HasAdvantage,
+		HasDisadvantage: input.HasDisadvantage,
+	})
+}
@@ -0,0 +1,142 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package monster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
+)
+
+type SavesTestSuite struct {
+	suite.Suite
+	ctrl   *gomock.Controller
+	ctx    context.Context
+	bus    events.EventBus
+	roller *mock_dice.MockRoller
+	mage   *Monster
+}
+
+func TestSavesSuite(t *testing.T) {
+	suite.Run(t, new(SavesTestSuite))
+}
+
+func (s *SavesTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.roller = mock_dice.NewMockRoller(s.ctrl)
+
+	// Mage: Saving Throws Int +6, Wis +4; Skills Arcana +6, History +6
+	s.mage = New(Config{
+		ID:   "mage-1",
+		Name: "Mage",
+		HP:   40,
+		AC:   12,
+		AbilityScores: shared.AbilityScores{
+			abilities.STR: 9,
+			abilities.DEX: 14,
+			abilities.CON: 11,
+			abilities.INT: 17,
+			abilities.WIS: 12,
+			abilities.CHA: 11,
+		},
+		ProficiencyBonus: 3,
+	})
+	s.mage.SetSavingThrow(abilities.INT, 6)
+	s.mage.SetSavingThrow(abilities.WIS, 4)
+	s.mage.SetProficiency(skills.Arcana, 6)
+	s.mage.SetProficiency(skills.History, 6)
+}
+
+func (s *SavesTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *SavesTestSuite) TestModifiers() {
+	s.Equal(4, s.mage.GetSavingThrowModifier(abilities.WIS))
+	s.Equal(2, s.mage.GetSavingThrowModifier(abilities.DEX), "not proficient, ability modifier")
+
+	s.Equal(6, s.mage.GetSkillModifier(skills.Arcana))
+	s.Equal(2, s.mage.GetSkillModifier(skills.Stealth), "not proficient, ability modifier")
+}
+
+func (s *SavesTestSuite) TestRollSaveUsesSaveBonusAndChain() {
+	// Something on the bus imposes disadvantage on the mage's saves
+	_, err := dnd5eEvents.SavingThrowChain.On(s.bus).SubscribeWithChain(s.ctx,
+		func(
+			_ context.Context, event *dnd5eEvents.SavingThrowChainEvent, c chain.Chain[*dnd5eEvents.SavingThrowChainEvent],
+		) (chain.Chain[*dnd5eEvents.SavingThrowChainEvent], error) {
+			if event.SaverID != "mage-1" {
+				return c, nil
+			}
+			return c, c.Add(combat.StageConditions, "bane",
+				func(_ context.Context, e *dnd5eEvents.SavingThrowChainEvent) (*dnd5eEvents.SavingThrowChainEvent, error) {
+					e.DisadvantageSources = append(e.DisadvantageSources, dnd5eEvents.SaveModifierSource{Name: "Bane"})
+					return e, nil
+				})
+		})
+	s.Require().NoError(err)
+
+	s.roller.EXPECT().RollN(gomock.Any(), 2, 20).Return([]int{15, 9}, nil)
+
+	// Hold Person: DC 13 WIS save
+	result, err := s.mage.RollSave(s.ctx, &RollSaveInput{
+		Roller:   s.roller,
+		EventBus: s.bus,
+		Ability:  abilities.WIS,
+		DC:       13,
+	})
+	s.Require().NoError(err)
+	s.Equal(9, result.Roll)
+	s.Equal(13, result.Total, "9 + WIS save +4")
+	s.True(result.Success)
+	s.Len(result.DisadvantageSources, 1)
+}
+
+func (s *SavesTestSuite) TestRollCheck() {
+	s.Run("skill bonus", func() {
+		s.roller.EXPECT().Roll(gomock.Any(), 20).Return(8, nil)
+
+		result, err := s.mage.RollCheck(s.ctx, &RollCheckInput{
+			Roller: s.roller,
+			Skill:  skills.Arcana,
+			DC:     15,
+		})
+		s.Require().NoError(err)
+		s.Equal(14, result.Total)
+		s.False(result.Success)
+	})
+
+	s.Run("raw ability", func() {
+		s.roller.EXPECT().Roll(gomock.Any(), 20).Return(8, nil)
+
+		result, err := s.mage.RollCheck(s.ctx, &RollCheckInput{
+			Roller:  s.roller,
+			Ability: abilities.DEX,
+		})
+		s.Require().NoError(err)
+		s.Equal(10, result.Total, "8 + DEX +2")
+	})
+}
+
+func (s *SavesTestSuite) TestDataRoundTrip() {
+	loaded, err := LoadFromData(s.ctx, s.mage.ToData(), s.bus)
+	s.Require().NoError(err)
+
+	s.Equal(6, loaded.GetSavingThrowModifier(abilities.INT))
+	s.Equal(4, loaded.GetSavingThrowModifier(abilities.WIS))
+	s.Equal(s.mage.ToData().SavingThrows, loaded.ToData().SavingThrows)
+}
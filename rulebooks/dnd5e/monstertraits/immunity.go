@@ -23,6 +23,9 @@ type ImmunityData struct {
 	Ref        *core.Ref   `json:"ref"`
 	OwnerID    string      `json:"owner_id"`
 	DamageType damage.Type `json:"damage_type"`
+	// BypassedBy lists annotations that get past the immunity, e.g. magical
+	// for "from nonmagical attacks". Empty means it always applies.
+	BypassedBy []damage.Annotation `json:"bypassed_by,omitempty"`
 }
 
 // immunityCondition represents a monster's immunity to a specific damage type.
@@ -30,6 +33,7 @@ type ImmunityData struct {
 type immunityCondition struct {
 	ownerID    string
 	damageType damage.Type
+	bypassedBy []damage.Annotation
 	bus        events.EventBus
 	subID      string
 }
@@ -37,21 +41,28 @@ type immunityCondition struct {
 // Ensure immunityCondition implements dnd5eEvents.ConditionBehavior
 var _ dnd5eEvents.ConditionBehavior = (*immunityCondition)(nil)
 
-// Immunity creates a new immunity trait that reduces damage of the specified type to 0
-func Immunity(ownerID string, damageType damage.Type) dnd5eEvents.ConditionBehavior {
+// Immunity creates a new immunity trait that reduces damage of the specified type to 0.
+// Damage carrying any bypassedBy annotation isn't negated.
+func Immunity(
+	ownerID string,
+	damageType damage.Type,
+	bypassedBy ...damage.Annotation,
+) dnd5eEvents.ConditionBehavior {
 	return &immunityCondition{
 		ownerID:    ownerID,
 		damageType: damageType,
+		bypassedBy: bypassedBy,
 	}
 }
 
 // ImmunityJSON creates the JSON representation of an immunity trait.
 // This is used by factory functions to add trait data before a bus is available.
-func ImmunityJSON(ownerID string, damageType damage.Type) (json.RawMessage, error) {
+func ImmunityJSON(ownerID string, damageType damage.Type, bypassedBy ...damage.Annotation) (json.RawMessage, error) {
 	data := ImmunityData{
 		Ref:        refs.MonsterTraits.Immunity(),
 		OwnerID:    ownerID,
 		DamageType: damageType,
+		BypassedBy: bypassedBy,
 	}
 	return json.Marshal(data)
 }
@@ -59,8 +70,8 @@ func ImmunityJSON(ownerID string, damageType damage.Type) (json.RawMessage, erro
 // MustImmunityJSON creates the JSON representation of an immunity trait.
 // It panics if JSON marshaling fails (which should never happen with valid inputs).
 // Use this in factory functions where errors indicate programming bugs, not runtime issues.
-func MustImmunityJSON(ownerID string, damageType damage.Type) json.RawMessage {
-	data, err := ImmunityJSON(ownerID, damageType)
+func MustImmunityJSON(ownerID string, damageType damage.Type, bypassedBy ...damage.Annotation) json.RawMessage {
+	data, err := ImmunityJSON(ownerID, damageType, bypassedBy...)
 	if err != nil {
 		panic("monstertraits: failed to marshal immunity JSON: " + err.Error())
 	}
@@ -114,6 +125,7 @@ func (i *immunityCondition) ToJSON() (json.RawMessage, error) {
 		Ref:        refs.MonsterTraits.Immunity(),
 		OwnerID:    i.ownerID,
 		DamageType: i.damageType,
+		BypassedBy: i.bypassedBy,
 	}
 	return json.Marshal(data)
 }
@@ -127,6 +139,7 @@ func (i *immunityCondition) loadJSON(data json.RawMessage) error {
 
 	i.ownerID = immunityData.OwnerID
 	i.damageType = immunityData.DamageType
+	i.bypassedBy = immunityData.BypassedBy
 
 	return nil
 }
@@ -142,16 +155,9 @@ func (i *immunityCondition) onDamageChain(
 		return c, nil
 	}
 
-	// Check if any component has our immune damage type
-	hasImmuneDamage := false
-	for idx := range event.Components {
-		if event.Components[idx].DamageType == i.damageType {
-			hasImmuneDamage = true
-			break
-		}
-	}
-
-	if !hasImmuneDamage {
+	// Check if any component has our immune damage type, and whether the
+	// attack gets past a qualified immunity
+	if !matchesDamage(event.Components, i.damageType, i.bypassedBy) {
 		return c, nil
 	}
 
@@ -49,6 +49,13 @@ func LoadJSON(data json.RawMessage, roller dice.Roller) (dnd5eEvents.ConditionBe
 		}
 		return trait, nil
 
+	case refs.MonsterTraits.Resistance().ID:
+		trait := &resistanceCondition{}
+		if err := trait.loadJSON(data); err != nil {
+			return nil, rpgerr.Wrap(err, "failed to load resistance trait")
+		}
+		return trait, nil
+
 	case refs.MonsterTraits.PackTactics().ID:
 		trait := &packTacticsCondition{}
 		if err := trait.loadJSON(data); err != nil {
@@ -0,0 +1,208 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//nolint:dupl // Resistance, Resistance, and Immunity implement same interface with similar structure but different behavior
+package monstertraits
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+)
+
+// ResistanceData is the JSON structure for persisting resistance trait state
+type ResistanceData struct {
+	Ref        *core.Ref   `json:"ref"`
+	OwnerID    string      `json:"owner_id"`
+	DamageType damage.Type `json:"damage_type"`
+	// BypassedBy lists annotations that get past the resistance, e.g. magical
+	// for "from nonmagical attacks". Empty means it always applies.
+	BypassedBy []damage.Annotation `json:"bypassed_by,omitempty"`
+}
+
+// resistanceCondition represents a monster's resistance to a specific damage type.
+// It implements the ConditionBehavior interface.
+type resistanceCondition struct {
+	ownerID    string
+	damageType damage.Type
+	bypassedBy []damage.Annotation
+	bus        events.EventBus
+	subID      string
+}
+
+// Ensure resistanceCondition implements dnd5eEvents.ConditionBehavior
+var _ dnd5eEvents.ConditionBehavior = (*resistanceCondition)(nil)
+
+// Resistance creates a new resistance trait that halves damage of the specified type.
+// Damage carrying any bypassedBy annotation isn't resisted, so
+// Resistance(id, damage.Slashing, damage.AnnotationMagical) resists slashing
+// from nonmagical attacks.
+func Resistance(
+	ownerID string,
+	damageType damage.Type,
+	bypassedBy ...damage.Annotation,
+) dnd5eEvents.ConditionBehavior {
+	return &resistanceCondition{
+		ownerID:    ownerID,
+		damageType: damageType,
+		bypassedBy: bypassedBy,
+	}
+}
+
+// ResistanceJSON creates the JSON representation of a resistance trait.
+// This is used by factory functions to add trait data before a bus is available.
+func ResistanceJSON(ownerID string, damageType damage.Type, bypassedBy ...damage.Annotation) (json.RawMessage, error) {
+	data := ResistanceData{
+		Ref:        refs.MonsterTraits.Resistance(),
+		OwnerID:    ownerID,
+		DamageType: damageType,
+		BypassedBy: bypassedBy,
+	}
+	return json.Marshal(data)
+}
+
+// MustResistanceJSON creates the JSON representation of a resistance trait.
+// It panics if JSON marshaling fails (which should never happen with valid inputs).
+// Use this in factory functions where errors indicate programming bugs, not runtime issues.
+func MustResistanceJSON(ownerID string, damageType damage.Type, bypassedBy ...damage.Annotation) json.RawMessage {
+	data, err := ResistanceJSON(ownerID, damageType, bypassedBy...)
+	if err != nil {
+		panic("monstertraits: failed to marshal resistance JSON: " + err.Error())
+	}
+	return data
+}
+
+// IsApplied returns true if this condition is currently applied
+func (r *resistanceCondition) IsApplied() bool {
+	return r.bus != nil
+}
+
+// Apply subscribes this condition to relevant combat events
+func (r *resistanceCondition) Apply(ctx context.Context, bus events.EventBus) error {
+	if r.IsApplied() {
+		return rpgerr.New(rpgerr.CodeAlreadyExists, "resistance condition already applied")
+	}
+	r.bus = bus
+
+	// Subscribe to damage chain to halve resisted damage
+	damageChain := dnd5eEvents.DamageChain.On(bus)
+	subID, err := damageChain.SubscribeWithChain(ctx, r.onDamageChain)
+	if err != nil {
+		return err
+	}
+	r.subID = subID
+
+	return nil
+}
+
+// Remove unsubscribes this condition from events
+func (r *resistanceCondition) Remove(ctx context.Context, bus events.EventBus) error {
+	if r.bus == nil {
+		return nil // Not applied, nothing to remove
+	}
+
+	if r.subID != "" {
+		err := bus.Unsubscribe(ctx, r.subID)
+		if err != nil {
+			return err
+		}
+	}
+
+	r.subID = ""
+	r.bus = nil
+	return nil
+}
+
+// ToJSON converts the condition to JSON for persistence
+func (r *resistanceCondition) ToJSON() (json.RawMessage, error) {
+	data := ResistanceData{
+		Ref:        refs.MonsterTraits.Resistance(),
+		OwnerID:    r.ownerID,
+		DamageType: r.damageType,
+		BypassedBy: r.bypassedBy,
+	}
+	return json.Marshal(data)
+}
+
+// loadJSON loads resistance condition state from JSON
+func (r *resistanceCondition) loadJSON(data json.RawMessage) error {
+	var resistanceData ResistanceData
+	if err := json.Unmarshal(data, &resistanceData); err != nil {
+		return rpgerr.Wrap(err, "failed to unmarshal resistance data")
+	}
+
+	r.ownerID = resistanceData.OwnerID
+	r.damageType = resistanceData.DamageType
+	r.bypassedBy = resistanceData.BypassedBy
+
+	return nil
+}
+
+// onDamageChain adds a resistance multiplier component if damage type matches
+func (r *resistanceCondition) onDamageChain(
+	_ context.Context,
+	event *dnd5eEvents.DamageChainEvent,
+	c chain.Chain[*dnd5eEvents.DamageChainEvent],
+) (chain.Chain[*dnd5eEvents.DamageChainEvent], error) {
+	// Only process if we're the target
+	if event.TargetID != r.ownerID {
+		return c, nil
+	}
+
+	// Check if any component has our resisted damage type, and whether the
+	// attack gets past a qualified resistance
+	if !matchesDamage(event.Components, r.damageType, r.bypassedBy) {
+		return c, nil
+	}
+
+	// Add resistance multiplier component
+	addMultiplier := func(_ context.Context, e *dnd5eEvents.DamageChainEvent) (*dnd5eEvents.DamageChainEvent, error) {
+		e.Components = append(e.Components, dnd5eEvents.DamageComponent{
+			Source:     dnd5eEvents.DamageSourceMonsterTrait,
+			SourceRef:  refs.MonsterTraits.Resistance(),
+			DamageType: r.damageType,
+			Multiplier: 0.5,
+		})
+		return e, nil
+	}
+
+	// Add to chain - process in final stage (for resistance/resistance/immunity)
+	err := c.Add(combat.StageFinal, "resistance", addMultiplier)
+	if err != nil {
+		return c, rpgerr.Wrapf(err, "error applying resistance for owner %s", r.ownerID)
+	}
+
+	return c, nil
+}
+
+// matchesDamage returns true if any component deals damageType and no
+// component of that type carries an annotation in bypassedBy. Qualifiers such
+// as "from nonmagical attacks" apply to the whole attack, so one magical
+// component of the type bypasses the trait for every component of the type.
+func matchesDamage(
+	components []dnd5eEvents.DamageComponent,
+	damageType damage.Type,
+	bypassedBy []damage.Annotation,
+) bool {
+	matched := false
+	for idx := range components {
+		if components[idx].DamageType != damageType {
+			continue
+		}
+		for _, annotation := range bypassedBy {
+			if components[idx].HasAnnotation(annotation) {
+				return false
+			}
+		}
+		matched = true
+	}
+	return matched
+}
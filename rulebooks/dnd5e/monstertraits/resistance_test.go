@@ -0,0 +1,107 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package monstertraits
+
+import (
+	"context"
+	"testing"
+
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/stretchr/testify/suite"
+)
+
+type ResistanceTestSuite struct {
+	suite.Suite
+	bus events.EventBus
+	ctx context.Context
+}
+
+func TestResistanceTestSuite(t *testing.T) {
+	suite.Run(t, new(ResistanceTestSuite))
+}
+
+func (s *ResistanceTestSuite) SetupTest() {
+	s.bus = events.NewEventBus()
+	s.ctx = context.Background()
+}
+
+func (s *ResistanceTestSuite) damageChain(
+	targetID string,
+	damageType damage.Type,
+	annotations ...damage.Annotation,
+) *dnd5eEvents.DamageChainEvent {
+	event := &dnd5eEvents.DamageChainEvent{
+		AttackerID: "pc-1",
+		TargetID:   targetID,
+		Components: []dnd5eEvents.DamageComponent{
+			{
+				Source:         dnd5eEvents.DamageSourceWeapon,
+				FinalDiceRolls: []int{5, 3},
+				DamageType:     damageType,
+				Annotations:    annotations,
+			},
+		},
+		DamageType: damageType,
+	}
+
+	chain := events.NewStagedChain[*dnd5eEvents.DamageChainEvent](combat.ModifierStages)
+	modifiedChain, err := dnd5eEvents.DamageChain.On(s.bus).PublishWithChain(s.ctx, event, chain)
+	s.Require().NoError(err)
+
+	result, err := modifiedChain.Execute(s.ctx, event)
+	s.Require().NoError(err)
+	return result
+}
+
+func (s *ResistanceTestSuite) TestResistanceAddsHalfMultiplier() {
+	resistance := Resistance("monster-1", damage.Cold)
+	s.Require().NoError(resistance.Apply(s.ctx, s.bus))
+
+	result := s.damageChain("monster-1", damage.Cold)
+	s.Require().Len(result.Components, 2)
+	s.Equal(dnd5eEvents.DamageSourceMonsterTrait, result.Components[1].Source)
+	s.Equal(refs.MonsterTraits.Resistance(), result.Components[1].SourceRef)
+	s.Equal(0.5, result.Components[1].Multiplier)
+
+	s.Len(s.damageChain("monster-1", damage.Fire).Components, 1)
+	s.Len(s.damageChain("monster-2", damage.Cold).Components, 1)
+}
+
+func (s *ResistanceTestSuite) TestResistanceFromNonmagicalAttacks() {
+	resistance := Resistance("werewolf-1", damage.Slashing, damage.AnnotationMagical, damage.AnnotationSilvered)
+	s.Require().NoError(resistance.Apply(s.ctx, s.bus))
+
+	s.Len(s.damageChain("werewolf-1", damage.Slashing).Components, 2, "mundane weapons are resisted")
+	s.Len(s.damageChain("werewolf-1", damage.Slashing, damage.AnnotationMagical).Components, 1)
+	s.Len(s.damageChain("werewolf-1", damage.Slashing, damage.AnnotationSilvered).Components, 1)
+	s.Len(s.damageChain("werewolf-1", damage.Slashing, damage.AnnotationAdamantine).Components, 2)
+}
+
+func (s *ResistanceTestSuite) TestResistanceCanBeRemoved() {
+	resistance := Resistance("monster-1", damage.Cold)
+	s.Require().NoError(resistance.Apply(s.ctx, s.bus))
+	s.Require().NoError(resistance.Remove(s.ctx, s.bus))
+	s.False(resistance.IsApplied())
+
+	s.Len(s.damageChain("monster-1", damage.Cold).Components, 1)
+}
+
+func (s *ResistanceTestSuite) TestResistanceRoundTrip() {
+	loaded, err := LoadJSON(MustResistanceJSON("monster-1", damage.Necrotic), nil)
+	s.Require().NoError(err)
+
+	resistance, ok := loaded.(*resistanceCondition)
+	s.Require().True(ok)
+	s.Equal("monster-1", resistance.ownerID)
+	s.Equal(damage.Necrotic, resistance.damageType)
+	s.Empty(resistance.bypassedBy)
+
+	loaded, err = LoadJSON(MustResistanceJSON("monster-1", damage.Piercing, damage.AnnotationMagical), nil)
+	s.Require().NoError(err)
+	s.Equal([]damage.Annotation{damage.AnnotationMagical}, loaded.(*resistanceCondition).bypassedBy)
+}
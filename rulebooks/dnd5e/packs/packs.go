@@ -25,7 +25,7 @@ const (
 // PackItem represents an item contained in a pack
 type PackItem struct {
 	ItemID   string // The equipment ID
-	Quantity int    // How many of this item
+	Quantity int    // How many of this item, in the units it's sold in (50 feet of rope is 1)
 }
 
 // Pack represents an equipment pack
@@ -60,9 +60,8 @@ func (p *Pack) EquipmentWeight() float32 {
 
 // EquipmentValue returns the value in copper pieces
 func (p *Pack) EquipmentValue() int {
-	// TODO: Parse cost string (e.g., "12 gp") and convert to copper
-	// For now, return a placeholder
-	return 0
+	value, _ := shared.ParseCost(p.Cost)
+	return value
 }
 
 // EquipmentDescription returns a description of the pack
@@ -83,8 +82,8 @@ var All = map[PackID]Pack{
 		Weight: 46.5,
 		Contents: []PackItem{
 			{ItemID: "backpack", Quantity: 1},
-			{ItemID: "ball-bearings", Quantity: 1000},
-			{ItemID: "string", Quantity: 10}, // 10 feet
+			{ItemID: "ball-bearings", Quantity: 1}, // bag of 1,000
+			{ItemID: "string", Quantity: 1},        // 10 feet
 			{ItemID: "bell", Quantity: 1},
 			{ItemID: "candle", Quantity: 5},
 			{ItemID: "crowbar", Quantity: 1},
@@ -95,7 +94,7 @@ var All = map[PackID]Pack{
 			{ItemID: "rations", Quantity: 5},
 			{ItemID: "tinderbox", Quantity: 1},
 			{ItemID: "waterskin", Quantity: 1},
-			{ItemID: "hempen-rope", Quantity: 50}, // 50 feet
+			{ItemID: "hempen-rope", Quantity: 1}, // 50 feet
 		},
 		Description: "Equipment for breaking and entering",
 	},
@@ -133,7 +132,7 @@ var All = map[PackID]Pack{
 			{ItemID: "tinderbox", Quantity: 1},
 			{ItemID: "rations", Quantity: 10},
 			{ItemID: "waterskin", Quantity: 1},
-			{ItemID: "hempen-rope", Quantity: 50}, // 50 feet
+			{ItemID: "hempen-rope", Quantity: 1}, // 50 feet
 		},
 		Description: "Equipment for dungeon exploration",
 	},
@@ -166,7 +165,7 @@ var All = map[PackID]Pack{
 			{ItemID: "torch", Quantity: 10},
 			{ItemID: "rations", Quantity: 10},
 			{ItemID: "waterskin", Quantity: 1},
-			{ItemID: "hempen-rope", Quantity: 50}, // 50 feet
+			{ItemID: "hempen-rope", Quantity: 1}, // 50 feet
 		},
 		Description: "Equipment for wilderness exploration",
 	},
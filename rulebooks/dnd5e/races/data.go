@@ -26,6 +26,7 @@ type Data struct {
 	LanguageChoice *Choice
 	ToolChoice     *Choice
 	AbilityChoice  *Choice // For Half-Elf's +1 to two abilities
+	FeatChoice     *Choice // For Variant Human and Custom Lineage
 
 	// Subraces if applicable
 	Subraces map[Subrace]*SubraceData
@@ -49,7 +50,7 @@ type Trait struct {
 
 // Choice represents a choice the player must make
 type Choice struct {
-	Type        string   // "skill", "language", "tool", "ability"
+	Type        string   // "skill", "language", "tool", "ability", "feat"
 	Count       int      // Number to choose
 	Options     []string // Available options (empty means "any")
 	Description string
@@ -481,6 +482,67 @@ var RaceData = map[Race]*Data{
 			languages.Infernal,
 		},
 	},
+
+	VariantHuman: {
+		ID:    VariantHuman,
+		Speed: 30,
+		Size:  "Medium",
+		// No fixed increases: AbilityChoice replaces the human's +1 to every ability
+		Languages: []languages.Language{
+			languages.Common,
+		},
+		LanguageChoice: &Choice{
+			Type:        "language",
+			Count:       1,
+			Options:     []string{},
+			Description: "You can speak, read, and write one extra language of your choice",
+		},
+		SkillChoice: &Choice{
+			Type:        "skill",
+			Count:       1,
+			Options:     []string{}, // Any skill
+			Description: "You gain proficiency in one skill of your choice",
+		},
+		AbilityChoice: &Choice{
+			Type:        "ability",
+			Count:       2,
+			Options:     []string{},
+			Description: "Two different ability scores of your choice increase by 1",
+		},
+		FeatChoice: &Choice{
+			Type:        "feat",
+			Count:       1,
+			Options:     []string{},
+			Description: "You gain one feat of your choice",
+		},
+	},
+
+	CustomLineage: {
+		ID:    CustomLineage,
+		Speed: 30,
+		Size:  "Medium", // Or Small, chosen by the player
+		Languages: []languages.Language{
+			languages.Common,
+		},
+		LanguageChoice: &Choice{
+			Type:        "language",
+			Count:       1,
+			Options:     []string{},
+			Description: "You can speak, read, and write one other language that you and your DM agree is appropriate",
+		},
+		AbilityChoice: &Choice{
+			Type:        "ability",
+			Count:       1,
+			Options:     []string{},
+			Description: "One ability score of your choice increases by 2",
+		},
+		FeatChoice: &Choice{
+			Type:        "feat",
+			Count:       1,
+			Options:     []string{},
+			Description: "You gain one feat of your choice for which you qualify",
+		},
+	},
 }
 
 // GetData returns the race data for a given race ID
@@ -0,0 +1,57 @@
+package races
+
+import (
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+)
+
+// DraconicAncestry is the dragon type a dragonborn descends from
+type DraconicAncestry string
+
+// Draconic ancestries from the Player's Handbook
+const (
+	AncestryBlack  DraconicAncestry = "black"
+	AncestryBlue   DraconicAncestry = "blue"
+	AncestryBrass  DraconicAncestry = "brass"
+	AncestryBronze DraconicAncestry = "bronze"
+	AncestryCopper DraconicAncestry = "copper"
+	AncestryGold   DraconicAncestry = "gold"
+	AncestryGreen  DraconicAncestry = "green"
+	AncestryRed    DraconicAncestry = "red"
+	AncestrySilver DraconicAncestry = "silver"
+	AncestryWhite  DraconicAncestry = "white"
+)
+
+// DraconicAncestryData describes the breath weapon and resistance an ancestry grants
+type DraconicAncestryData struct {
+	Ancestry    DraconicAncestry
+	DamageType  damage.Type
+	Line        bool              // 5 by 30 ft. line; otherwise a 15 ft. cone
+	SaveAbility abilities.Ability // Ability used to save against the breath weapon
+}
+
+var draconicAncestries = map[DraconicAncestry]*DraconicAncestryData{
+	AncestryBlack:  {Ancestry: AncestryBlack, DamageType: damage.Acid, Line: true, SaveAbility: abilities.DEX},
+	AncestryBlue:   {Ancestry: AncestryBlue, DamageType: damage.Lightning, Line: true, SaveAbility: abilities.DEX},
+	AncestryBrass:  {Ancestry: AncestryBrass, DamageType: damage.Fire, Line: true, SaveAbility: abilities.DEX},
+	AncestryBronze: {Ancestry: AncestryBronze, DamageType: damage.Lightning, Line: true, SaveAbility: abilities.DEX},
+	AncestryCopper: {Ancestry: AncestryCopper, DamageType: damage.Acid, Line: true, SaveAbility: abilities.DEX},
+	AncestryGold:   {Ancestry: AncestryGold, DamageType: damage.Fire, SaveAbility: abilities.DEX},
+	AncestryGreen:  {Ancestry: AncestryGreen, DamageType: damage.Poison, SaveAbility: abilities.CON},
+	AncestryRed:    {Ancestry: AncestryRed, DamageType: damage.Fire, SaveAbility: abilities.DEX},
+	AncestrySilver: {Ancestry: AncestrySilver, DamageType: damage.Cold, SaveAbility: abilities.CON},
+	AncestryWhite:  {Ancestry: AncestryWhite, DamageType: damage.Cold, SaveAbility: abilities.CON},
+}
+
+// GetDraconicAncestry returns the data for an ancestry, or nil if unknown
+func GetDraconicAncestry(ancestry DraconicAncestry) *DraconicAncestryData {
+	return draconicAncestries[ancestry]
+}
+
+// DraconicAncestries returns all draconic ancestries
+func DraconicAncestries() []DraconicAncestry {
+	return []DraconicAncestry{
+		AncestryBlack, AncestryBlue, AncestryBrass, AncestryBronze, AncestryCopper,
+		AncestryGold, AncestryGreen, AncestryRed, AncestrySilver, AncestryWhite,
+	}
+}
@@ -1,8 +1,11 @@
 package races
 
 import (
+	"encoding/json"
+
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/languages"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/proficiencies"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
 )
 
@@ -18,9 +21,30 @@ type Grant struct {
 	// Languages
 	Languages []languages.Language
 
-	// Future: conditions and features when racial traits are implemented
-	// Conditions []ConditionRef  // e.g., Darkvision, poison resistance
-	// Features   []FeatureRef    // e.g., Breath Weapon, Relentless Endurance
+	// Ref-based grants for racial traits (e.g., Dwarven Resilience).
+	// Traits that depend on a choice, like the Dragonborn's Breath Weapon,
+	// are built from that choice instead.
+	Conditions []ConditionRef
+	Features   []FeatureRef
+}
+
+// ConditionRef references a condition with optional configuration.
+// The config is parsed by the condition itself via its factory.
+type ConditionRef struct {
+	// Ref is the condition reference in "module:type:value" format
+	// e.g., "dnd5e:conditions:dwarven_resilience"
+	Ref string `json:"ref"`
+	// Config is condition-specific configuration parsed by the condition factory
+	Config json.RawMessage `json:"config,omitempty"`
+}
+
+// FeatureRef references a feature with optional configuration.
+// The config is parsed by the feature itself via its factory.
+type FeatureRef struct {
+	// Ref is the feature reference in "module:type:value" format
+	Ref string `json:"ref"`
+	// Config is feature-specific configuration parsed by the feature factory
+	Config json.RawMessage `json:"config,omitempty"`
 }
 
 // GetGrants returns what a race grants at character creation (not choices).
@@ -40,6 +64,9 @@ func GetGrants(race Race) *Grant {
 				languages.Common,
 				languages.Dwarvish,
 			},
+			Conditions: []ConditionRef{
+				{Ref: refs.Conditions.DwarvenResilience().String()},
+			},
 		}
 
 	case Elf, HighElf, WoodElf:
@@ -48,6 +75,9 @@ func GetGrants(race Race) *Grant {
 				languages.Common,
 				languages.Elvish,
 			},
+			Conditions: []ConditionRef{
+				{Ref: refs.Conditions.FeyAncestry().String()},
+			},
 		}
 
 	case Gnome, ForestGnome, RockGnome:
@@ -56,6 +86,9 @@ func GetGrants(race Race) *Grant {
 				languages.Common,
 				languages.Gnomish,
 			},
+			Conditions: []ConditionRef{
+				{Ref: refs.Conditions.GnomeCunning().String()},
+			},
 		}
 
 	case HalfElf:
@@ -65,6 +98,9 @@ func GetGrants(race Race) *Grant {
 				languages.Elvish,
 				// Note: Half-Elf gets one additional language of choice - that's handled in choices
 			},
+			Conditions: []ConditionRef{
+				{Ref: refs.Conditions.FeyAncestry().String()},
+			},
 		}
 
 	case Halfling, LightfootHalfling, StoutHalfling:
@@ -73,6 +109,9 @@ func GetGrants(race Race) *Grant {
 				languages.Common,
 				languages.Halfling,
 			},
+			Conditions: []ConditionRef{
+				{Ref: refs.Conditions.HalflingLucky().String()},
+			},
 		}
 
 	case HalfOrc:
@@ -25,6 +25,12 @@ const (
 	Tiefling   Race = "tiefling"
 )
 
+// Optional race variants
+const (
+	VariantHuman  Race = "variant-human"  // Player's Handbook variant human traits
+	CustomLineage Race = "custom-lineage" // Tasha's Cauldron of Everything custom lineage
+)
+
 // Subrace constants
 const (
 	SubraceNone Race = "none" // No subrace (for races without subraces)
@@ -68,6 +74,9 @@ var All = map[string]Race{
 	"half-elf":   HalfElf,
 	"half-orc":   HalfOrc,
 	"tiefling":   Tiefling,
+	// Variants
+	"variant-human":  VariantHuman,
+	"custom-lineage": CustomLineage,
 }
 
 // Subraces provides map lookup for subraces only
@@ -94,6 +103,9 @@ var AllIncludingSubraces = map[string]Race{
 	"half-elf":   HalfElf,
 	"half-orc":   HalfOrc,
 	"tiefling":   Tiefling,
+	// Variants
+	"variant-human":  VariantHuman,
+	"custom-lineage": CustomLineage,
 	// Subraces
 	"high-elf":           HighElf,
 	"wood-elf":           WoodElf,
@@ -142,6 +154,10 @@ func (r Race) Name() string {
 		return "Half-Orc"
 	case Tiefling:
 		return "Tiefling"
+	case VariantHuman:
+		return "Variant Human"
+	case CustomLineage:
+		return "Custom Lineage"
 	default:
 		return "Unknown"
 	}
@@ -168,6 +184,10 @@ func (r Race) Description() string {
 		return "Half-Orcs are a powerful race with a love of magic and a deep connection to the earth."
 	case Tiefling:
 		return "Tieflings are a powerful race with a love of magic and a deep connection to the earth."
+	case VariantHuman:
+		return "Humans who trade their broad talents for a skill, a feat and two focused ability increases."
+	case CustomLineage:
+		return "A character of your own devising, shaped by circumstance or magic rather than a traditional race."
 	default:
 		return "Unknown race"
 	}
@@ -11,6 +11,8 @@ var (
 	actionOffHandStrike = &core.Ref{Module: Module, Type: TypeActions, ID: "off_hand_strike"}
 	actionFlurryStrike  = &core.Ref{Module: Module, Type: TypeActions, ID: "flurry_strike"}
 	actionUnarmedStrike = &core.Ref{Module: Module, Type: TypeActions, ID: "unarmed_strike"}
+	actionGrapple       = &core.Ref{Module: Module, Type: TypeActions, ID: "grapple"}
+	actionShove         = &core.Ref{Module: Module, Type: TypeActions, ID: "shove"}
 )
 
 // Actions provides type-safe, discoverable references to D&D 5e combat actions.
@@ -40,3 +42,11 @@ func (n actionsNS) FlurryStrike() *core.Ref { return actionFlurryStrike }
 // UnarmedStrike returns the ref for the UnarmedStrike action.
 // UnarmedStrike is an attack made without a weapon.
 func (n actionsNS) UnarmedStrike() *core.Ref { return actionUnarmedStrike }
+
+// Grapple returns the ref for the Grapple action.
+// Grapple replaces one attack from AttacksRemaining with an Athletics contest.
+func (n actionsNS) Grapple() *core.Ref { return actionGrapple }
+
+// Shove returns the ref for the Shove action.
+// Shove replaces one attack from AttacksRemaining to knock prone or push a target.
+func (n actionsNS) Shove() *core.Ref { return actionShove }
@@ -12,6 +12,8 @@ var (
 	combatAbilityDisengage     = &core.Ref{Module: Module, Type: TypeCombatAbilities, ID: "disengage"}
 	combatAbilityHelp          = &core.Ref{Module: Module, Type: TypeCombatAbilities, ID: "help"}
 	combatAbilityHide          = &core.Ref{Module: Module, Type: TypeCombatAbilities, ID: "hide"}
+	combatAbilitySearch        = &core.Ref{Module: Module, Type: TypeCombatAbilities, ID: "search"}
+	combatAbilityUseObject     = &core.Ref{Module: Module, Type: TypeCombatAbilities, ID: "use_object"}
 	combatAbilityReady         = &core.Ref{Module: Module, Type: TypeCombatAbilities, ID: "ready"}
 	combatAbilityOffHandAttack = &core.Ref{Module: Module, Type: TypeCombatAbilities, ID: "off_hand_attack"}
 )
@@ -48,6 +50,14 @@ func (n combatAbilitiesNS) Help() *core.Ref { return combatAbilityHelp }
 // Hide consumes 1 action to attempt a stealth check.
 func (n combatAbilitiesNS) Hide() *core.Ref { return combatAbilityHide }
 
+// Search returns the ref for the Search combat ability.
+// Search consumes 1 action to make a Wisdom (Perception) check.
+func (n combatAbilitiesNS) Search() *core.Ref { return combatAbilitySearch }
+
+// UseObject returns the ref for the Use Object combat ability.
+// Use Object consumes 1 action to interact with an object that needs one.
+func (n combatAbilitiesNS) UseObject() *core.Ref { return combatAbilityUseObject }
+
 // Ready returns the ref for the Ready combat ability.
 // Ready consumes 1 action to prepare an action for a specified trigger.
 func (n combatAbilitiesNS) Ready() *core.Ref { return combatAbilityReady }
@@ -14,6 +14,19 @@ var (
 	conditionMartialArts       = &core.Ref{Module: Module, Type: TypeConditions, ID: "martial_arts"}
 	conditionUnarmoredMovement = &core.Ref{Module: Module, Type: TypeConditions, ID: "unarmored_movement"}
 	conditionSneakAttack       = &core.Ref{Module: Module, Type: TypeConditions, ID: "sneak_attack"}
+	conditionDivineSmite       = &core.Ref{Module: Module, Type: TypeConditions, ID: "divine_smite"}
+	conditionWildShaped        = &core.Ref{Module: Module, Type: TypeConditions, ID: "wild_shaped"}
+	conditionBardicInspiration = &core.Ref{Module: Module, Type: TypeConditions, ID: "bardic_inspiration"}
+	conditionJackOfAllTrades   = &core.Ref{Module: Module, Type: TypeConditions, ID: "jack_of_all_trades"}
+	conditionManeuver          = &core.Ref{Module: Module, Type: TypeConditions, ID: "maneuver"}
+	conditionFavoredEnemy      = &core.Ref{Module: Module, Type: TypeConditions, ID: "favored_enemy"}
+	conditionNaturalExplorer   = &core.Ref{Module: Module, Type: TypeConditions, ID: "natural_explorer"}
+
+	// Racial trait conditions
+	conditionDwarvenResilience = &core.Ref{Module: Module, Type: TypeConditions, ID: "dwarven_resilience"}
+	conditionFeyAncestry       = &core.Ref{Module: Module, Type: TypeConditions, ID: "fey_ancestry"}
+	conditionHalflingLucky     = &core.Ref{Module: Module, Type: TypeConditions, ID: "halfling_lucky"}
+	conditionGnomeCunning      = &core.Ref{Module: Module, Type: TypeConditions, ID: "gnome_cunning"}
 
 	// Fighting style conditions
 	conditionFightingStyleArchery = &core.Ref{
@@ -35,15 +48,35 @@ var (
 		Module: Module, Type: TypeConditions, ID: "fighting_style_two_weapon_fighting",
 	}
 
+	// Eldritch invocation conditions
+	conditionAgonizingBlast = &core.Ref{Module: Module, Type: TypeConditions, ID: "agonizing_blast"}
+	conditionDevilsSight    = &core.Ref{Module: Module, Type: TypeConditions, ID: "devils_sight"}
+
 	// Turn-based conditions (from actions, last until start of next turn)
 	conditionDodging     = &core.Ref{Module: Module, Type: TypeConditions, ID: "dodging"}
 	conditionDisengaging = &core.Ref{Module: Module, Type: TypeConditions, ID: "disengaging"}
+	conditionHelped      = &core.Ref{Module: Module, Type: TypeConditions, ID: "helped"}
+	conditionReadied     = &core.Ref{Module: Module, Type: TypeConditions, ID: "readied_action"}
+	conditionSqueezing   = &core.Ref{Module: Module, Type: TypeConditions, ID: "squeezing"}
+
+	// Spell conditions
+	conditionConcentrating   = &core.Ref{Module: Module, Type: TypeConditions, ID: "concentrating"}
+	conditionHoldPerson      = &core.Ref{Module: Module, Type: TypeConditions, ID: "hold_person"}
+	conditionSpiritualWeapon = &core.Ref{Module: Module, Type: TypeConditions, ID: "spiritual_weapon"}
+	conditionCantripScaling  = &core.Ref{Module: Module, Type: TypeConditions, ID: "cantrip_scaling"}
+
+	// Channel Divinity conditions
+	conditionTurned = &core.Ref{Module: Module, Type: TypeConditions, ID: "turned"}
 
 	// Reaction conditions (Wave 2.11d) — universal-by-default reactions that
 	// subscribe to the appropriate chain and publish ReactionTriggerEvents
 	// when their predicate matches AND gamectx.IsReactionReady returns true.
 	conditionOpportunityAttack = &core.Ref{Module: Module, Type: TypeConditions, ID: "opportunity_attack"}
 
+	// Feat conditions
+	conditionSentinel = &core.Ref{Module: Module, Type: TypeConditions, ID: "sentinel"}
+	conditionMobile   = &core.Ref{Module: Module, Type: TypeConditions, ID: "mobile"}
+
 	// Standard D&D 5e Conditions
 	conditionBlinded       = &core.Ref{Module: Module, Type: TypeConditions, ID: "blinded"}
 	conditionCharmed       = &core.Ref{Module: Module, Type: TypeConditions, ID: "charmed"}
@@ -60,6 +93,29 @@ var (
 	conditionStunned       = &core.Ref{Module: Module, Type: TypeConditions, ID: "stunned"}
 	conditionUnconscious   = &core.Ref{Module: Module, Type: TypeConditions, ID: "unconscious"}
 	conditionExhaustion    = &core.Ref{Module: Module, Type: TypeConditions, ID: "exhaustion"}
+
+	// Afflictions (ended by curing magic such as Lay on Hands)
+	conditionDiseased = &core.Ref{Module: Module, Type: TypeConditions, ID: "diseased"}
+
+	// Cover (derived from spatial state, not applied to a character)
+	conditionHalfCover          = &core.Ref{Module: Module, Type: TypeConditions, ID: "half_cover"}
+	conditionThreeQuartersCover = &core.Ref{Module: Module, Type: TypeConditions, ID: "three_quarters_cover"}
+	conditionFullCover          = &core.Ref{Module: Module, Type: TypeConditions, ID: "full_cover"}
+
+	// Ranged attack penalties (derived from spatial state, not applied to a character)
+	conditionLongRange       = &core.Ref{Module: Module, Type: TypeConditions, ID: "long_range"}
+	conditionHostileAdjacent = &core.Ref{Module: Module, Type: TypeConditions, ID: "hostile_adjacent"}
+
+	// Flanking (optional rule derived from spatial state, not applied to a character)
+	conditionFlanking = &core.Ref{Module: Module, Type: TypeConditions, ID: "flanking"}
+
+	// Environmental modifiers (derived from room/zone tags, not applied to a character)
+	conditionUnderwater       = &core.Ref{Module: Module, Type: TypeConditions, ID: "underwater"}
+	conditionDarkness         = &core.Ref{Module: Module, Type: TypeConditions, ID: "darkness"}
+	conditionDifficultTerrain = &core.Ref{Module: Module, Type: TypeConditions, ID: "difficult_terrain"}
+
+	// Vision (derived from light, senses and stealth, not applied to a character)
+	conditionHidden = &core.Ref{Module: Module, Type: TypeConditions, ID: "hidden"}
 )
 
 // Conditions provides type-safe, discoverable references to D&D 5e conditions.
@@ -78,6 +134,19 @@ func (n conditionsNS) ImprovedCritical() *core.Ref  { return conditionImprovedCr
 func (n conditionsNS) MartialArts() *core.Ref       { return conditionMartialArts }
 func (n conditionsNS) UnarmoredMovement() *core.Ref { return conditionUnarmoredMovement }
 func (n conditionsNS) SneakAttack() *core.Ref       { return conditionSneakAttack }
+func (n conditionsNS) DivineSmite() *core.Ref       { return conditionDivineSmite }
+func (n conditionsNS) WildShaped() *core.Ref        { return conditionWildShaped }
+func (n conditionsNS) BardicInspiration() *core.Ref { return conditionBardicInspiration }
+func (n conditionsNS) JackOfAllTrades() *core.Ref   { return conditionJackOfAllTrades }
+func (n conditionsNS) Maneuver() *core.Ref          { return conditionManeuver }
+func (n conditionsNS) FavoredEnemy() *core.Ref      { return conditionFavoredEnemy }
+func (n conditionsNS) NaturalExplorer() *core.Ref   { return conditionNaturalExplorer }
+
+// Racial trait conditions
+func (n conditionsNS) DwarvenResilience() *core.Ref { return conditionDwarvenResilience }
+func (n conditionsNS) FeyAncestry() *core.Ref       { return conditionFeyAncestry }
+func (n conditionsNS) HalflingLucky() *core.Ref     { return conditionHalflingLucky }
+func (n conditionsNS) GnomeCunning() *core.Ref      { return conditionGnomeCunning }
 
 // Fighting style conditions
 func (n conditionsNS) FightingStyleArchery() *core.Ref { return conditionFightingStyleArchery }
@@ -91,16 +160,60 @@ func (n conditionsNS) FightingStyleTwoWeaponFighting() *core.Ref {
 	return conditionFightingStyleTwoWeaponFighting
 }
 
+// Eldritch invocation conditions
+func (n conditionsNS) AgonizingBlast() *core.Ref { return conditionAgonizingBlast }
+func (n conditionsNS) DevilsSight() *core.Ref    { return conditionDevilsSight }
+
 // Turn-based conditions (from actions)
 func (n conditionsNS) Dodging() *core.Ref     { return conditionDodging }
 func (n conditionsNS) Disengaging() *core.Ref { return conditionDisengaging }
 
+// Helped returns the ref for an ally aided by the Help action (advantage on
+// their next ability check, or next attack against the helped-against foe).
+func (n conditionsNS) Helped() *core.Ref { return conditionHelped }
+
+// ReadiedAction returns the ref for the condition holding an action prepared
+// with the Ready ability until its trigger occurs or the holder's next turn.
+func (n conditionsNS) ReadiedAction() *core.Ref { return conditionReadied }
+
+// Squeezing returns the ref for a creature squeezing through a space sized for
+// a creature one size smaller (extra movement cost, attack and DEX save penalties).
+func (n conditionsNS) Squeezing() *core.Ref { return conditionSqueezing }
+
+// Concentrating returns the ref for a caster maintaining concentration on a
+// spell (broken by failed CON saves on damage, or by dropping unconscious).
+func (n conditionsNS) Concentrating() *core.Ref { return conditionConcentrating }
+
+// HoldPerson returns the ref for a humanoid paralyzed by Hold Person (repeats
+// its WIS save at the end of each of its turns; ends with the caster's concentration).
+func (n conditionsNS) HoldPerson() *core.Ref { return conditionHoldPerson }
+
+// SpiritualWeapon returns the ref for a caster's Spiritual Weapon (a floating
+// weapon that makes melee spell attacks as a bonus action for 1 minute).
+func (n conditionsNS) SpiritualWeapon() *core.Ref { return conditionSpiritualWeapon }
+
+// CantripScaling returns the ref for a caster whose damage cantrips gain dice
+// at 5th, 11th, and 17th character level.
+func (n conditionsNS) CantripScaling() *core.Ref { return conditionCantripScaling }
+
+// Turned returns the ref for an undead creature turned by a cleric's Turn Undead
+// (must move away, can't take reactions; ends when it takes damage or after 1 minute).
+func (n conditionsNS) Turned() *core.Ref { return conditionTurned }
+
 // OpportunityAttack returns the ref for the OpportunityAttackCondition
 // applied by default to every melee combatant. The condition subscribes to
 // MovementChain and publishes a ReactionTriggerEvent when an enemy leaves
 // the holder's threatened reach AND the holder has the OA reaction readied.
 func (n conditionsNS) OpportunityAttack() *core.Ref { return conditionOpportunityAttack }
 
+// Sentinel returns the ref for the Sentinel feat (opportunity attack hits
+// reduce the target's speed to 0 for the rest of the turn).
+func (n conditionsNS) Sentinel() *core.Ref { return conditionSentinel }
+
+// Mobile returns the ref for the Mobile feat (creatures the holder attacked in
+// melee this turn can't make opportunity attacks against it).
+func (n conditionsNS) Mobile() *core.Ref { return conditionMobile }
+
 // Standard D&D 5e Conditions
 func (n conditionsNS) Blinded() *core.Ref       { return conditionBlinded }
 func (n conditionsNS) Charmed() *core.Ref       { return conditionCharmed }
@@ -117,3 +230,35 @@ func (n conditionsNS) Restrained() *core.Ref    { return conditionRestrained }
 func (n conditionsNS) Stunned() *core.Ref       { return conditionStunned }
 func (n conditionsNS) Unconscious() *core.Ref   { return conditionUnconscious }
 func (n conditionsNS) Exhaustion() *core.Ref    { return conditionExhaustion }
+
+// Diseased returns the ref for a creature suffering a disease, which curing
+// magic (Lay on Hands, Lesser Restoration) ends.
+func (n conditionsNS) Diseased() *core.Ref { return conditionDiseased }
+
+// Cover - computed per attack or save from room geometry by combat.CalculateCover.
+// These refs attribute cover bonuses in AC and saving throw breakdowns.
+func (n conditionsNS) HalfCover() *core.Ref          { return conditionHalfCover }
+func (n conditionsNS) ThreeQuartersCover() *core.Ref { return conditionThreeQuartersCover }
+func (n conditionsNS) FullCover() *core.Ref          { return conditionFullCover }
+
+// Ranged attack penalties - computed per attack from room geometry by combat.ResolveAttackHit.
+// These refs attribute the disadvantage on ranged attacks at long range or
+// with a hostile creature within 5 feet.
+func (n conditionsNS) LongRange() *core.Ref       { return conditionLongRange }
+func (n conditionsNS) HostileAdjacent() *core.Ref { return conditionHostileAdjacent }
+
+// Flanking - computed per melee attack by combat.ResolveAttackHit when the
+// CombatRules flanking option is enabled. Attributes the flanking advantage.
+func (n conditionsNS) Flanking() *core.Ref { return conditionFlanking }
+
+// Environmental modifiers - applied by combat.EnvironmentalModifiers from room/zone tags.
+// These refs attribute underwater, darkness and difficult terrain effects in
+// attack, damage and movement cost breakdowns.
+func (n conditionsNS) Underwater() *core.Ref       { return conditionUnderwater }
+func (n conditionsNS) Darkness() *core.Ref         { return conditionDarkness }
+func (n conditionsNS) DifficultTerrain() *core.Ref { return conditionDifficultTerrain }
+
+// Vision - applied by combat.VisionModifiers when a combatant can't see the other.
+// Hidden attributes advantage and disadvantage from a hidden creature; creatures
+// unseen in darkness are attributed to Darkness.
+func (n conditionsNS) Hidden() *core.Ref { return conditionHidden }
@@ -11,8 +11,9 @@ var (
 	featureRecklessAttack = &core.Ref{Module: Module, Type: TypeFeatures, ID: "reckless_attack"}
 
 	// Fighter
-	featureSecondWind  = &core.Ref{Module: Module, Type: TypeFeatures, ID: "second_wind"}
-	featureActionSurge = &core.Ref{Module: Module, Type: TypeFeatures, ID: "action_surge"}
+	featureSecondWind        = &core.Ref{Module: Module, Type: TypeFeatures, ID: "second_wind"}
+	featureActionSurge       = &core.Ref{Module: Module, Type: TypeFeatures, ID: "action_surge"}
+	featureCombatSuperiority = &core.Ref{Module: Module, Type: TypeFeatures, ID: "combat_superiority"}
 
 	// Monk
 	featureFlurryOfBlows   = &core.Ref{Module: Module, Type: TypeFeatures, ID: "flurry_of_blows"}
@@ -21,10 +22,27 @@ var (
 	featureDeflectMissiles = &core.Ref{Module: Module, Type: TypeFeatures, ID: "deflect_missiles"}
 
 	// Rogue
-	featureSneakAttack = &core.Ref{Module: Module, Type: TypeFeatures, ID: "sneak_attack"}
+	featureSneakAttack   = &core.Ref{Module: Module, Type: TypeFeatures, ID: "sneak_attack"}
+	featureCunningAction = &core.Ref{Module: Module, Type: TypeFeatures, ID: "cunning_action"}
+
+	// Cleric
+	featureTurnUndead = &core.Ref{Module: Module, Type: TypeFeatures, ID: "turn_undead"}
+
+	// Wizard
+	featureArcaneRecovery = &core.Ref{Module: Module, Type: TypeFeatures, ID: "arcane_recovery"}
 
 	// Paladin
 	featureDivineSmite = &core.Ref{Module: Module, Type: TypeFeatures, ID: "divine_smite"}
+	featureLayOnHands  = &core.Ref{Module: Module, Type: TypeFeatures, ID: "lay_on_hands"}
+
+	// Druid
+	featureWildShape = &core.Ref{Module: Module, Type: TypeFeatures, ID: "wild_shape"}
+
+	// Bard
+	featureBardicInspiration = &core.Ref{Module: Module, Type: TypeFeatures, ID: "bardic_inspiration"}
+
+	// Racial
+	featureBreathWeapon = &core.Ref{Module: Module, Type: TypeFeatures, ID: "breath_weapon"}
 )
 
 // Features provides type-safe, discoverable references to D&D 5e features.
@@ -40,8 +58,9 @@ func (n featuresNS) BrutalCritical() *core.Ref { return featureBrutalCritical }
 func (n featuresNS) RecklessAttack() *core.Ref { return featureRecklessAttack }
 
 // Fighter
-func (n featuresNS) SecondWind() *core.Ref  { return featureSecondWind }
-func (n featuresNS) ActionSurge() *core.Ref { return featureActionSurge }
+func (n featuresNS) SecondWind() *core.Ref        { return featureSecondWind }
+func (n featuresNS) ActionSurge() *core.Ref       { return featureActionSurge }
+func (n featuresNS) CombatSuperiority() *core.Ref { return featureCombatSuperiority }
 
 // Monk
 func (n featuresNS) FlurryOfBlows() *core.Ref   { return featureFlurryOfBlows }
@@ -50,7 +69,24 @@ func (n featuresNS) StepOfTheWind() *core.Ref   { return featureStepOfTheWind }
 func (n featuresNS) DeflectMissiles() *core.Ref { return featureDeflectMissiles }
 
 // Rogue
-func (n featuresNS) SneakAttack() *core.Ref { return featureSneakAttack }
+func (n featuresNS) SneakAttack() *core.Ref   { return featureSneakAttack }
+func (n featuresNS) CunningAction() *core.Ref { return featureCunningAction }
+
+// Cleric
+func (n featuresNS) TurnUndead() *core.Ref { return featureTurnUndead }
+
+// Wizard
+func (n featuresNS) ArcaneRecovery() *core.Ref { return featureArcaneRecovery }
 
 // Paladin
 func (n featuresNS) DivineSmite() *core.Ref { return featureDivineSmite }
+func (n featuresNS) LayOnHands() *core.Ref  { return featureLayOnHands }
+
+// Druid
+func (n featuresNS) WildShape() *core.Ref { return featureWildShape }
+
+// Bard
+func (n featuresNS) BardicInspiration() *core.Ref { return featureBardicInspiration }
+
+// Racial
+func (n featuresNS) BreathWeapon() *core.Ref { return featureBreathWeapon }
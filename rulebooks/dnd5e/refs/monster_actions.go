@@ -19,6 +19,8 @@ var (
 	monsterActionRanged      = &core.Ref{Module: Module, Type: TypeMonsterActions, ID: "ranged"}
 	monsterActionMultiattack = &core.Ref{Module: Module, Type: TypeMonsterActions, ID: "multiattack"}
 	monsterActionBite        = &core.Ref{Module: Module, Type: TypeMonsterActions, ID: "bite"}
+	monsterActionRecharge    = &core.Ref{Module: Module, Type: TypeMonsterActions, ID: "recharge"}
+	monsterActionSpell       = &core.Ref{Module: Module, Type: TypeMonsterActions, ID: "spell"}
 )
 
 // MonsterActions provides type-safe, discoverable references to D&D 5e monster actions.
@@ -53,3 +55,9 @@ func (n monsterActionsNS) Multiattack() *core.Ref { return monsterActionMultiatt
 
 // Bite returns the ref for a bite attack with knockdown
 func (n monsterActionsNS) Bite() *core.Ref { return monsterActionBite }
+
+// Recharge returns the ref for an action that recharges on a d6 roll
+func (n monsterActionsNS) Recharge() *core.Ref { return monsterActionRecharge }
+
+// Spell returns the ref for casting one of the monster's spells
+func (n monsterActionsNS) Spell() *core.Ref { return monsterActionSpell }
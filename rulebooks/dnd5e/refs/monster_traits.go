@@ -7,6 +7,7 @@ import "github.com/KirkDiggler/rpg-toolkit/core"
 var (
 	monsterTraitImmunity        = &core.Ref{Module: Module, Type: TypeMonsterTraits, ID: "immunity"}
 	monsterTraitVulnerability   = &core.Ref{Module: Module, Type: TypeMonsterTraits, ID: "vulnerability"}
+	monsterTraitResistance      = &core.Ref{Module: Module, Type: TypeMonsterTraits, ID: "resistance"}
 	monsterTraitPackTactics     = &core.Ref{Module: Module, Type: TypeMonsterTraits, ID: "pack_tactics"}
 	monsterTraitUndeadFortitude = &core.Ref{Module: Module, Type: TypeMonsterTraits, ID: "undead_fortitude"}
 )
@@ -24,6 +25,9 @@ func (n monsterTraitsNS) Immunity() *core.Ref { return monsterTraitImmunity }
 // Vulnerability returns the ref for damage vulnerability trait
 func (n monsterTraitsNS) Vulnerability() *core.Ref { return monsterTraitVulnerability }
 
+// Resistance returns the ref for damage resistance trait
+func (n monsterTraitsNS) Resistance() *core.Ref { return monsterTraitResistance }
+
 // PackTactics returns the ref for pack tactics trait
 func (n monsterTraitsNS) PackTactics() *core.Ref { return monsterTraitPackTactics }
 
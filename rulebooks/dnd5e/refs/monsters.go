@@ -54,3 +54,28 @@ func (n monstersNS) BanditArcher() *core.Ref  { return monsterBanditArcher }
 func (n monstersNS) BanditCaptain() *core.Ref { return monsterBanditCaptain }
 func (n monstersNS) Thug() *core.Ref          { return monsterThug }
 func (n monstersNS) Goblin() *core.Ref        { return monsterGoblin }
+
+// monsterByID maps monster IDs to their singleton refs
+var monsterByID = map[string]*core.Ref{
+	"skeleton":          monsterSkeleton,
+	"zombie":            monsterZombie,
+	"skeleton-archer":   monsterSkeletonArcher,
+	"skeleton-captain":  monsterSkeletonCaptain,
+	"ghoul":             monsterGhoul,
+	"giant-rat":         monsterGiantRat,
+	"giant-spider":      monsterGiantSpider,
+	"giant-wolf-spider": monsterGiantWolfSpider,
+	"wolf":              monsterWolf,
+	"brown-bear":        monsterBrownBear,
+	"bandit":            monsterBandit,
+	"bandit-archer":     monsterBanditArcher,
+	"bandit-captain":    monsterBanditCaptain,
+	"thug":              monsterThug,
+	"goblin":            monsterGoblin,
+}
+
+// ByID returns the singleton ref for the given monster ID, or nil if not found.
+// Monster IDs match the dnd5eapi.co monster index (e.g., "brown-bear").
+func (n monstersNS) ByID(id string) *core.Ref {
+	return monsterByID[id]
+}
@@ -378,6 +378,20 @@ func TestExpandedConditionsNamespace(t *testing.T) {
 		{"Stunned", refs.Conditions.Stunned, "stunned"},
 		{"Unconscious", refs.Conditions.Unconscious, "unconscious"},
 		{"Exhaustion", refs.Conditions.Exhaustion, "exhaustion"},
+		{"ReadiedAction", refs.Conditions.ReadiedAction, "readied_action"},
+		{"Squeezing", refs.Conditions.Squeezing, "squeezing"},
+		{"HalfCover", refs.Conditions.HalfCover, "half_cover"},
+		{"ThreeQuartersCover", refs.Conditions.ThreeQuartersCover, "three_quarters_cover"},
+		{"FullCover", refs.Conditions.FullCover, "full_cover"},
+		{"LongRange", refs.Conditions.LongRange, "long_range"},
+		{"HostileAdjacent", refs.Conditions.HostileAdjacent, "hostile_adjacent"},
+		{"Flanking", refs.Conditions.Flanking, "flanking"},
+		{"Underwater", refs.Conditions.Underwater, "underwater"},
+		{"Darkness", refs.Conditions.Darkness, "darkness"},
+		{"DifficultTerrain", refs.Conditions.DifficultTerrain, "difficult_terrain"},
+		{"Hidden", refs.Conditions.Hidden, "hidden"},
+		{"Sentinel", refs.Conditions.Sentinel, "sentinel"},
+		{"Mobile", refs.Conditions.Mobile, "mobile"},
 	}
 
 	for _, tc := range tests {
@@ -495,3 +509,10 @@ func TestWeaponsByID(t *testing.T) {
 		assert.True(t, matched, "ByID ref should match singleton in switch")
 	})
 }
+
+// TestMonstersByID verifies the ByID lookup returns singleton refs
+func TestMonstersByID(t *testing.T) {
+	assert.Same(t, refs.Monsters.BrownBear(), refs.Monsters.ByID("brown-bear"))
+	assert.Same(t, refs.Monsters.Goblin(), refs.Monsters.ByID("goblin"))
+	assert.Nil(t, refs.Monsters.ByID("tarrasque"))
+}
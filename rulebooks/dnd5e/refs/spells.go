@@ -124,9 +124,11 @@ var (
 	spellAugury            = &core.Ref{Module: Module, Type: TypeSpells, ID: "augury"}
 	spellBarkskin          = &core.Ref{Module: Module, Type: TypeSpells, ID: "barkskin"}
 	spellBlindnessDeafness = &core.Ref{Module: Module, Type: TypeSpells, ID: "blindness-deafness"}
+	spellHoldPerson        = &core.Ref{Module: Module, Type: TypeSpells, ID: "hold-person"}
 	spellLesserRestoration = &core.Ref{Module: Module, Type: TypeSpells, ID: "lesser-restoration"}
 	spellMagicWeapon       = &core.Ref{Module: Module, Type: TypeSpells, ID: "magic-weapon"}
 	spellMirrorImage       = &core.Ref{Module: Module, Type: TypeSpells, ID: "mirror-image"}
+	spellMistyStep         = &core.Ref{Module: Module, Type: TypeSpells, ID: "misty-step"}
 	spellPassWithoutTrace  = &core.Ref{Module: Module, Type: TypeSpells, ID: "pass-without-trace"}
 	spellSpikeGrowth       = &core.Ref{Module: Module, Type: TypeSpells, ID: "spike-growth"}
 	spellSuggestion        = &core.Ref{Module: Module, Type: TypeSpells, ID: "suggestion"}
@@ -310,9 +312,11 @@ func (n spellsNS) RayOfEnfeeblement() *core.Ref  { return spellRayOfEnfeeblement
 func (n spellsNS) Augury() *core.Ref            { return spellAugury }
 func (n spellsNS) Barkskin() *core.Ref          { return spellBarkskin }
 func (n spellsNS) BlindnessDeafness() *core.Ref { return spellBlindnessDeafness }
+func (n spellsNS) HoldPerson() *core.Ref        { return spellHoldPerson }
 func (n spellsNS) LesserRestoration() *core.Ref { return spellLesserRestoration }
 func (n spellsNS) MagicWeapon() *core.Ref       { return spellMagicWeapon }
 func (n spellsNS) MirrorImage() *core.Ref       { return spellMirrorImage }
+func (n spellsNS) MistyStep() *core.Ref         { return spellMistyStep }
 func (n spellsNS) PassWithoutTrace() *core.Ref  { return spellPassWithoutTrace }
 func (n spellsNS) SpikeGrowth() *core.Ref       { return spellSpikeGrowth }
 func (n spellsNS) Suggestion() *core.Ref        { return spellSuggestion }
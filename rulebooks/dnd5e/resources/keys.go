@@ -22,6 +22,39 @@ const (
 	// Used by: Flurry of Blows, Patient Defense, Step of the Wind, etc.
 	Ki coreResources.ResourceKey = "ki"
 
+	// ChannelDivinity is the cleric's Channel Divinity uses per rest.
+	// Maximum depends on cleric level: 1 at level 2-5, 2 at 6-17, 3 at 18+.
+	// Recovered on short or long rest.
+	// Used by: Turn Undead, domain Channel Divinity options
+	ChannelDivinity coreResources.ResourceKey = "channel_divinity"
+
+	// LayOnHands is the paladin's healing pool, equal to 5 x paladin level.
+	// Recovered on long rest.
+	// Used by: Lay on Hands (1 point per hit point healed, 5 points per disease or poison cured)
+	LayOnHands coreResources.ResourceKey = "lay_on_hands"
+
+	// SorceryPoints is the sorcerer's pool, equal to sorcerer level from level 2.
+	// Recovered on long rest.
+	// Converted to and from spell slots with a SlotConverter (Flexible Casting).
+	// Used by: Metamagic (spells.CastInput.SorceryPoints)
+	SorceryPoints coreResources.ResourceKey = "sorcery_points"
+
+	// WildShape is the druid's Wild Shape uses, 2 from druid level 2.
+	// Recovered on short or long rest.
+	// Used by: Wild Shape
+	WildShape coreResources.ResourceKey = "wild_shape"
+
+	// BardicInspiration is the bard's Bardic Inspiration uses, equal to their Charisma
+	// modifier (minimum 1). Recovered on long rest, or short rest from bard level 5
+	// (Font of Inspiration).
+	// Used by: Bardic Inspiration
+	BardicInspiration coreResources.ResourceKey = "bardic_inspiration"
+
+	// SuperiorityDice is the Battle Master's superiority dice, 4 from fighter level 3
+	// (5 at level 7, 6 at level 15). Recovered on short or long rest.
+	// Used by: Combat Superiority maneuvers
+	SuperiorityDice coreResources.ResourceKey = "superiority_dice"
+
 	// HitDice is the character's pool of hit dice for short rest healing.
 	// Maximum equals character level (sum of all class levels for multiclass).
 	// Die size is determined by class (d6 for wizard, d12 for barbarian, etc.).
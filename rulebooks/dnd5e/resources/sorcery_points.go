@@ -0,0 +1,129 @@
+package resources
+
+import (
+	coreResources "github.com/KirkDiggler/rpg-toolkit/core/resources"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/spells"
+)
+
+// MaxCreatedSlotLevel is the highest spell slot Flexible Casting can create
+const MaxCreatedSlotLevel = 5
+
+// slotCreationCosts are the sorcery points to create a slot, indexed by slot level
+var slotCreationCosts = []int{0, 2, 3, 5, 6, 7}
+
+// SlotCreationCost returns the sorcery points needed to create a spell slot of
+// the given level, or 0 if Flexible Casting can't create it.
+func SlotCreationCost(level int) int {
+	if level < 1 || level > MaxCreatedSlotLevel {
+		return 0
+	}
+	return slotCreationCosts[level]
+}
+
+// SorceryPointsResourceConfig contains configuration for creating a sorcery points resource
+type SorceryPointsResourceConfig struct {
+	// CharacterID is the ID of the character this resource belongs to
+	CharacterID string
+
+	// Level is the character's sorcerer level (the pool's maximum)
+	Level int
+}
+
+// NewSorceryPointsResource creates a RecoverableResource configured for sorcery points.
+// Sorcerers gain sorcery points at level 2, so level 1 sorcerers get an empty pool.
+func NewSorceryPointsResource(config SorceryPointsResourceConfig) *combat.RecoverableResource {
+	maximum := config.Level
+	if maximum < 2 {
+		maximum = 0
+	}
+	return combat.NewRecoverableResource(combat.RecoverableResourceConfig{
+		ID:          string(SorceryPoints),
+		Maximum:     maximum,
+		CharacterID: config.CharacterID,
+		ResetType:   coreResources.ResetLongRest,
+	})
+}
+
+// SlotConverterConfig contains configuration for creating a SlotConverter
+type SlotConverterConfig struct {
+	// SorceryPoints is the sorcerer's sorcery point pool
+	SorceryPoints *combat.RecoverableResource
+
+	// Spellcasting holds the spell slots to convert
+	Spellcasting *spells.Spellcasting
+}
+
+// Validate validates the config.
+func (c *SlotConverterConfig) Validate() error {
+	if c == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "SlotConverterConfig is nil")
+	}
+	if c.SorceryPoints == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "SorceryPoints is required")
+	}
+	if c.Spellcasting == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "Spellcasting is required")
+	}
+	return nil
+}
+
+// SlotConverter converts between sorcery points and spell slots (the sorcerer's
+// Flexible Casting). Conversions check everything before spending, so a failed
+// conversion costs nothing.
+type SlotConverter struct {
+	points  *combat.RecoverableResource
+	casting *spells.Spellcasting
+}
+
+// NewSlotConverter creates a converter for a sorcerer's points and slots.
+func NewSlotConverter(config *SlotConverterConfig) (*SlotConverter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &SlotConverter{
+		points:  config.SorceryPoints,
+		casting: config.Spellcasting,
+	}, nil
+}
+
+// SlotToPoints expends a spell slot to gain sorcery points equal to its level.
+// The points can't exceed the pool's maximum.
+func (c *SlotConverter) SlotToPoints(level int) error {
+	if c.casting.SlotsRemaining(level) <= 0 {
+		return rpgerr.ResourceExhaustedf("no level %d spell slot to convert", level)
+	}
+	if c.points.Current()+level > c.points.Maximum() {
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument,
+			"converting a level %d slot would exceed the sorcery point maximum of %d", level, c.points.Maximum())
+	}
+
+	if err := c.casting.ExpendSlot(level); err != nil {
+		return rpgerr.Wrapf(err, "failed to expend level %d spell slot", level)
+	}
+	c.points.Restore(level)
+	return nil
+}
+
+// PointsToSlot spends sorcery points to create a spell slot of up to 5th level.
+// A created slot above the normal maximum vanishes on a long rest.
+func (c *SlotConverter) PointsToSlot(level int) error {
+	cost := SlotCreationCost(level)
+	if cost == 0 {
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument, "can't create a level %d spell slot", level)
+	}
+	if c.points.Current() < cost {
+		return rpgerr.ResourceExhaustedf("a level %d spell slot needs %d sorcery points, %d remaining",
+			level, cost, c.points.Current())
+	}
+
+	if err := c.points.Use(cost); err != nil {
+		return rpgerr.Wrap(err, "failed to spend sorcery points")
+	}
+	if err := c.casting.CreateSlot(level); err != nil {
+		c.points.Restore(cost)
+		return rpgerr.Wrapf(err, "failed to create level %d spell slot", level)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/spells"
+)
+
+type SlotConverterTestSuite struct {
+	suite.Suite
+	points    *combat.RecoverableResource
+	casting   *spells.Spellcasting
+	converter *SlotConverter
+}
+
+func TestSlotConverterSuite(t *testing.T) {
+	suite.Run(t, new(SlotConverterTestSuite))
+}
+
+// SetupTest creates a level 5 sorcerer: 5 sorcery points, 4/3/2 slots
+func (s *SlotConverterTestSuite) SetupTest() {
+	s.points = NewSorceryPointsResource(SorceryPointsResourceConfig{CharacterID: "sorcerer-1", Level: 5})
+
+	var err error
+	s.casting, err = spells.NewSpellcasting(&spells.SpellcastingData{
+		Ability: abilities.CHA,
+		Slots:   map[int]spells.SlotData{1: {Max: 4}, 2: {Max: 3}, 3: {Max: 2}},
+	})
+	s.Require().NoError(err)
+
+	s.converter, err = NewSlotConverter(&SlotConverterConfig{SorceryPoints: s.points, Spellcasting: s.casting})
+	s.Require().NoError(err)
+}
+
+func (s *SlotConverterTestSuite) SetupSubTest() {
+	s.SetupTest()
+}
+
+func (s *SlotConverterTestSuite) TestPool() {
+	s.Equal(0, NewSorceryPointsResource(SorceryPointsResourceConfig{Level: 1}).Maximum())
+	s.Equal(2, NewSorceryPointsResource(SorceryPointsResourceConfig{Level: 2}).Maximum())
+	s.Equal(5, s.points.Maximum())
+}
+
+func (s *SlotConverterTestSuite) TestSlotCreationCost() {
+	s.Equal([]int{0, 2, 3, 5, 6, 7, 0}, []int{
+		SlotCreationCost(0), SlotCreationCost(1), SlotCreationCost(2), SlotCreationCost(3),
+		SlotCreationCost(4), SlotCreationCost(5), SlotCreationCost(6),
+	})
+}
+
+func (s *SlotConverterTestSuite) TestPointsToSlot() {
+	s.Run("regains an expended slot", func() {
+		s.Require().NoError(s.casting.ExpendSlot(2))
+		s.Require().NoError(s.converter.PointsToSlot(2))
+		s.Equal(3, s.casting.SlotsRemaining(2))
+		s.Equal(2, s.points.Current())
+	})
+
+	s.Run("creates a slot above the maximum until a long rest", func() {
+		s.Require().NoError(s.converter.PointsToSlot(3))
+		s.Equal(3, s.casting.SlotsRemaining(3))
+		s.Equal(0, s.points.Current())
+
+		s.casting.LongRest()
+		s.Equal(2, s.casting.SlotsRemaining(3))
+		s.Equal(spells.SlotData{Max: 2}, s.casting.ToData().Slots[3])
+	})
+
+	s.Run("fails without spending", func() {
+		err := s.converter.PointsToSlot(6)
+		s.Equal(rpgerr.CodeInvalidArgument, rpgerr.GetCode(err))
+
+		s.Require().NoError(s.points.Use(3))
+		err = s.converter.PointsToSlot(3)
+		s.Equal(rpgerr.CodeResourceExhausted, rpgerr.GetCode(err))
+		s.Equal(2, s.points.Current())
+		s.Equal(2, s.casting.SlotsRemaining(3))
+	})
+}
+
+func (s *SlotConverterTestSuite) TestSlotToPoints() {
+	s.Require().NoError(s.points.Use(5))
+
+	s.Require().NoError(s.converter.SlotToPoints(3))
+	s.Equal(3, s.points.Current())
+	s.Equal(1, s.casting.SlotsRemaining(3))
+
+	err := s.converter.SlotToPoints(3)
+	s.Equal(rpgerr.CodeInvalidArgument, rpgerr.GetCode(err), "would exceed the maximum")
+	s.Equal(1, s.casting.SlotsRemaining(3))
+
+	err = s.converter.SlotToPoints(4)
+	s.Equal(rpgerr.CodeResourceExhausted, rpgerr.GetCode(err))
+}
+
+func (s *SlotConverterTestSuite) TestConfigValidation() {
+	_, err := NewSlotConverter(&SlotConverterConfig{SorceryPoints: s.points})
+	s.Equal(rpgerr.CodeInvalidArgument, rpgerr.GetCode(err))
+
+	_, err = NewSlotConverter(nil)
+	s.Equal(rpgerr.CodeInvalidArgument, rpgerr.GetCode(err))
+}
@@ -79,6 +79,10 @@ type SavingThrowResult struct {
 
 	// BonusSources contains the sources that added bonuses to this save
 	BonusSources []dnd5eEvents.SaveBonusSource
+
+	// AutoFailSources contains the sources that made this save fail automatically.
+	// The d20 is still rolled, but Success is false regardless of the total.
+	AutoFailSources []dnd5eEvents.SaveModifierSource
 }
 
 // MakeSavingThrow executes a saving throw using the input parameters
@@ -89,10 +93,13 @@ type SavingThrowResult struct {
 //   - Disadvantage (roll 2d20, take lower)
 //   - Advantage + Disadvantage cancellation (single d20)
 //   - Natural 1 and natural 20 detection
+//   - Rerolling a natural 1 when a chain source allows it (Halfling Lucky)
+//   - Automatic failure when a chain source imposes it (paralyzed by Hold Person)
 //   - Chain event modifiers (advantage, disadvantage, bonuses from conditions/features)
 //
 // If input.Roller is nil, a default CryptoRoller is used.
-// If input.EventBus is provided, the SavingThrowChain is fired to collect modifiers.
+// If input.EventBus is provided, the SavingThrowChain is fired to collect modifiers
+// and the result is published to CombatLogTopic as a save_resolved entry.
 // Returns an error if the dice roller fails or chain execution fails.
 func MakeSavingThrow(ctx context.Context, input *SavingThrowInput) (*SavingThrowResult, error) {
 	if input == nil {
@@ -108,9 +115,11 @@ func MakeSavingThrow(ctx context.Context, input *SavingThrowInput) (*SavingThrow
 	hasAdvantage := input.HasAdvantage
 	hasDisadvantage := input.HasDisadvantage
 	bonusFromChain := 0
+	rerollOnes := false
 	var advantageSources []dnd5eEvents.SaveModifierSource
 	var disadvantageSources []dnd5eEvents.SaveModifierSource
 	var bonusSources []dnd5eEvents.SaveBonusSource
+	var autoFailSources []dnd5eEvents.SaveModifierSource
 
 	// Track input-provided advantage/disadvantage as sources for auditability
 	if input.HasAdvantage {
@@ -161,48 +170,51 @@ func MakeSavingThrow(ctx context.Context, input *SavingThrowInput) (*SavingThrow
 		}
 		bonusFromChain = result.TotalBonus()
 		bonusSources = append(bonusSources, result.BonusSources...)
+		rerollOnes = len(result.RerollOneSources) > 0
+		autoFailSources = result.AutoFailSources
 	}
 
-	var roll int
-	var err error
-
 	// D&D 5e Rule: Advantage and Disadvantage cancel each other out
-	effectiveAdvantage := hasAdvantage && !hasDisadvantage
-	effectiveDisadvantage := hasDisadvantage && !hasAdvantage
-
-	switch {
-	case effectiveAdvantage:
-		// Roll with advantage: 2d20, take higher
-		rolls, rollErr := roller.RollN(ctx, 2, 20)
-		if rollErr != nil {
-			return nil, rollErr
-		}
-		roll = max(rolls[0], rolls[1])
-	case effectiveDisadvantage:
-		// Roll with disadvantage: 2d20, take lower
-		rolls, rollErr := roller.RollN(ctx, 2, 20)
-		if rollErr != nil {
-			return nil, rollErr
-		}
-		roll = min(rolls[0], rolls[1])
-	default:
-		// Normal roll: 1d20
-		roll, err = roller.Roll(ctx, 20)
-		if err != nil {
-			return nil, err
-		}
+	d20, err := combat.RollD20(ctx, roller, combat.D20Input{
+		Advantage:    hasAdvantage,
+		Disadvantage: hasDisadvantage,
+		RerollOnes:   rerollOnes,
+	})
+	if err != nil {
+		return nil, err
 	}
+	roll := d20.Roll
 
 	// Calculate total (base modifier + chain bonuses)
 	total := roll + input.Modifier + bonusFromChain
 
 	// Determine success
-	success := total >= input.DC
+	success := total >= input.DC && len(autoFailSources) == 0
 
 	// Detect natural 1 and natural 20
 	isNat1 := roll == 1
 	isNat20 := roll == 20
 
+	if input.EventBus != nil {
+		err := dnd5eEvents.CombatLogTopic.On(input.EventBus).Publish(ctx, dnd5eEvents.CombatLogEvent{
+			Version:  dnd5eEvents.CombatLogVersion,
+			Kind:     dnd5eEvents.CombatLogSaveResolved,
+			ActorID:  input.Cause.InstigatorID,
+			TargetID: input.SaverID,
+			Save: &dnd5eEvents.SaveResolvedLog{
+				Ability: input.Ability,
+				DC:      input.DC,
+				Roll:    roll,
+				Total:   total,
+				Success: success,
+				Cause:   input.Cause.EffectRef,
+			},
+		})
+		if err != nil {
+			return nil, rpgerr.Wrap(err, "failed to publish save combat log")
+		}
+	}
+
 	return &SavingThrowResult{
 		Roll:                roll,
 		Total:               total,
@@ -213,5 +225,6 @@ func MakeSavingThrow(ctx context.Context, input *SavingThrowInput) (*SavingThrow
 		AdvantageSources:    advantageSources,
 		DisadvantageSources: disadvantageSources,
 		BonusSources:        bonusSources,
+		AutoFailSources:     autoFailSources,
 	}, nil
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
 	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
 	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
 )
 
 type SavingThrowTestSuite struct {
@@ -521,3 +522,76 @@ func (s *SavingThrowTestSuite) TestNoEventBusStillWorks() {
 	s.Empty(result.DisadvantageSources, "should have no disadvantage sources")
 	s.Empty(result.BonusSources, "should have no bonus sources")
 }
+
+// TestChainAutoFail tests that a chain subscriber can make a save fail regardless of the roll
+func (s *SavingThrowTestSuite) TestChainAutoFail() {
+	s.mockRoller.EXPECT().Roll(s.ctx, 20).Return(20, nil)
+
+	bus := events.NewEventBus()
+	_, err := dnd5eEvents.SavingThrowChain.On(bus).SubscribeWithChain(s.ctx,
+		func(_ context.Context, _ *dnd5eEvents.SavingThrowChainEvent, c chain.Chain[*dnd5eEvents.SavingThrowChainEvent]) (chain.Chain[*dnd5eEvents.SavingThrowChainEvent], error) {
+			return c, c.Add(combat.StageConditions, "paralyzed", func(_ context.Context, e *dnd5eEvents.SavingThrowChainEvent) (*dnd5eEvents.SavingThrowChainEvent, error) {
+				e.AutoFailSources = append(e.AutoFailSources, dnd5eEvents.SaveModifierSource{
+					Name:       "Paralyzed",
+					SourceType: "condition",
+					EntityID:   "hero",
+				})
+				return e, nil
+			})
+		})
+	s.Require().NoError(err)
+
+	result, err := MakeSavingThrow(s.ctx, &SavingThrowInput{
+		Roller:   s.mockRoller,
+		EventBus: bus,
+		SaverID:  "hero",
+		Ability:  abilities.DEX,
+		DC:       10,
+		Modifier: 2,
+	})
+	s.Require().NoError(err)
+
+	s.Equal(22, result.Total)
+	s.False(result.Success, "an auto-failed save fails even on a natural 20")
+	s.Len(result.AutoFailSources, 1)
+}
+
+// TestPublishesCombatLog tests that a save made with an event bus is logged
+func (s *SavingThrowTestSuite) TestPublishesCombatLog() {
+	bus := events.NewEventBus()
+	var entries []dnd5eEvents.CombatLogEvent
+	_, err := dnd5eEvents.CombatLogTopic.On(bus).Subscribe(s.ctx, func(_ context.Context, e dnd5eEvents.CombatLogEvent) error {
+		entries = append(entries, e)
+		return nil
+	})
+	s.Require().NoError(err)
+
+	s.mockRoller.EXPECT().Roll(gomock.Any(), 20).Return(6, nil)
+
+	_, err = MakeSavingThrow(s.ctx, &SavingThrowInput{
+		Roller:   s.mockRoller,
+		EventBus: bus,
+		SaverID:  "goblin",
+		Cause: dnd5eEvents.SaveCause{
+			EffectRef:    refs.Spells.HoldPerson(),
+			InstigatorID: "cleric",
+		},
+		Ability:  abilities.WIS,
+		DC:       13,
+		Modifier: -1,
+	})
+	s.Require().NoError(err)
+
+	s.Require().Len(entries, 1)
+	s.Require().NoError(entries[0].Validate())
+	s.Equal("cleric", entries[0].ActorID)
+	s.Equal("goblin", entries[0].TargetID)
+	s.Equal(&dnd5eEvents.SaveResolvedLog{
+		Ability: abilities.WIS,
+		DC:      13,
+		Roll:    6,
+		Total:   5,
+		Cause:   refs.Spells.HoldPerson(),
+	}, entries[0].Save)
+	s.Equal("goblin fails a WIS save (5 vs DC 13)", entries[0].Text())
+}
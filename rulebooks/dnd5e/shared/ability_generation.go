@@ -0,0 +1,188 @@
+package shared
+
+import (
+	"context"
+	"slices"
+
+	"github.com/KirkDiggler/rpg-toolkit/dice"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+)
+
+// AbilityScoreMethod is how a character's base ability scores were generated
+type AbilityScoreMethod string
+
+const (
+	// AbilityScoreMethodStandardArray assigns 15, 14, 13, 12, 10 and 8
+	AbilityScoreMethodStandardArray AbilityScoreMethod = "standard"
+
+	// AbilityScoreMethodPointBuy buys scores of 8-15 with PointBuyBudget points
+	AbilityScoreMethodPointBuy AbilityScoreMethod = "point_buy"
+
+	// AbilityScoreMethodRolled rolls 4d6 and drops the lowest die for each score
+	AbilityScoreMethodRolled AbilityScoreMethod = "rolled"
+)
+
+// Point buy rules
+const (
+	// PointBuyBudget is the number of points to spend
+	PointBuyBudget = 27
+
+	// PointBuyMinScore is the lowest score, which costs nothing
+	PointBuyMinScore = 8
+
+	// PointBuyMaxScore is the highest score that can be bought
+	PointBuyMaxScore = 15
+)
+
+// Rolled score range
+const (
+	// RolledMinScore is the lowest score 4d6 drop lowest rolls
+	RolledMinScore = 3
+
+	// RolledMaxScore is the highest score 4d6 drop lowest rolls
+	RolledMaxScore = 18
+)
+
+// pointBuyCosts maps a score to its point buy cost
+var pointBuyCosts = map[int]int{8: 0, 9: 1, 10: 2, 11: 3, 12: 4, 13: 5, 14: 7, 15: 9}
+
+// standardArray is the standard array, highest first
+var standardArray = []int{15, 14, 13, 12, 10, 8}
+
+// StandardArray returns the standard array scores, highest first, to assign
+// one per ability
+func StandardArray() []int {
+	return slices.Clone(standardArray)
+}
+
+// PointBuyCost returns the point buy cost of one score
+func PointBuyCost(score int) (int, error) {
+	cost, ok := pointBuyCosts[score]
+	if !ok {
+		return 0, rpgerr.Newf(rpgerr.CodeInvalidArgument,
+			"point buy score %d is outside %d-%d", score, PointBuyMinScore, PointBuyMaxScore)
+	}
+	return cost, nil
+}
+
+// PointBuyTotal returns the points all six scores cost
+func PointBuyTotal(scores AbilityScores) (int, error) {
+	total := 0
+	for _, ability := range abilities.List() {
+		cost, ok := pointBuyCosts[scores[ability]]
+		if !ok {
+			return 0, rpgerr.Newf(rpgerr.CodeInvalidArgument, "point buy %s %d is outside %d-%d",
+				ability.Display(), scores[ability], PointBuyMinScore, PointBuyMaxScore)
+		}
+		total += cost
+	}
+	return total, nil
+}
+
+// ValidatePointBuy checks the scores can be bought within PointBuyBudget.
+// Spending less than the budget is legal.
+func ValidatePointBuy(scores AbilityScores) error {
+	if err := requireAllAbilities(scores); err != nil {
+		return err
+	}
+	total, err := PointBuyTotal(scores)
+	if err != nil {
+		return err
+	}
+	if total > PointBuyBudget {
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument,
+			"point buy costs %d points, budget is %d", total, PointBuyBudget)
+	}
+	return nil
+}
+
+// ValidateStandardArray checks the scores are the standard array in any order
+func ValidateStandardArray(scores AbilityScores) error {
+	if err := requireAllAbilities(scores); err != nil {
+		return err
+	}
+	values := make([]int, 0, len(standardArray))
+	for _, ability := range abilities.List() {
+		values = append(values, scores[ability])
+	}
+	slices.Sort(values)
+	slices.Reverse(values)
+	if !slices.Equal(values, standardArray) {
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument,
+			"scores %v aren't the standard array %v", values, standardArray)
+	}
+	return nil
+}
+
+// ValidateRolled checks every score is one 4d6 drop lowest can roll
+func ValidateRolled(scores AbilityScores) error {
+	if err := requireAllAbilities(scores); err != nil {
+		return err
+	}
+	for _, ability := range abilities.List() {
+		if score := scores[ability]; score < RolledMinScore || score > RolledMaxScore {
+			return rpgerr.Newf(rpgerr.CodeInvalidArgument, "rolled %s %d is outside %d-%d",
+				ability.Display(), score, RolledMinScore, RolledMaxScore)
+		}
+	}
+	return nil
+}
+
+// ValidateAbilityScoreMethod checks base scores are legal for a method
+func ValidateAbilityScoreMethod(method AbilityScoreMethod, scores AbilityScores) error {
+	switch method {
+	case AbilityScoreMethodStandardArray:
+		return ValidateStandardArray(scores)
+	case AbilityScoreMethodPointBuy:
+		return ValidatePointBuy(scores)
+	case AbilityScoreMethodRolled:
+		return ValidateRolled(scores)
+	default:
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument, "unknown ability score method %q", method)
+	}
+}
+
+// requireAllAbilities checks all six abilities have a score
+func requireAllAbilities(scores AbilityScores) error {
+	for _, ability := range abilities.List() {
+		if _, ok := scores[ability]; !ok {
+			return rpgerr.Newf(rpgerr.CodeInvalidArgument, "missing %s score", ability.Display())
+		}
+	}
+	return nil
+}
+
+// RollAbilityScore rolls one ability score: 4d6, dropping the lowest die
+func RollAbilityScore(ctx context.Context, roller dice.Roller) (int, error) {
+	if roller == nil {
+		return 0, rpgerr.New(rpgerr.CodeInvalidArgument, "roller is required")
+	}
+	rolls, err := roller.RollN(ctx, 4, 6)
+	if err != nil {
+		return 0, rpgerr.Wrap(err, "failed to roll ability score")
+	}
+	if len(rolls) != 4 {
+		return 0, rpgerr.Newf(rpgerr.CodeInternal, "rolled %d dice for an ability score, expected 4", len(rolls))
+	}
+
+	total := 0
+	for _, roll := range rolls {
+		total += roll
+	}
+	return total - slices.Min(rolls), nil
+}
+
+// RollAbilityScores rolls six ability scores, in the order rolled, for the
+// player to assign
+func RollAbilityScores(ctx context.Context, roller dice.Roller) ([]int, error) {
+	scores := make([]int, 0, len(standardArray))
+	for range len(standardArray) {
+		score, err := RollAbilityScore(ctx, roller)
+		if err != nil {
+			return nil, err
+		}
+		scores = append(scores, score)
+	}
+	return scores, nil
+}
@@ -0,0 +1,111 @@
+package shared_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+)
+
+// AbilityGenerationTestSuite tests the ability score generation methods
+type AbilityGenerationTestSuite struct {
+	suite.Suite
+	ctrl   *gomock.Controller
+	roller *mock_dice.MockRoller
+	ctx    context.Context
+}
+
+func TestAbilityGenerationSuite(t *testing.T) {
+	suite.Run(t, new(AbilityGenerationTestSuite))
+}
+
+func (s *AbilityGenerationTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.roller = mock_dice.NewMockRoller(s.ctrl)
+	s.ctx = context.Background()
+}
+
+func (s *AbilityGenerationTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// scores builds ability scores in STR, DEX, CON, INT, WIS, CHA order
+func scores(values ...int) shared.AbilityScores {
+	result := make(shared.AbilityScores)
+	for i, ability := range abilities.List() {
+		result[ability] = values[i]
+	}
+	return result
+}
+
+func (s *AbilityGenerationTestSuite) TestPointBuy() {
+	total, err := shared.PointBuyTotal(scores(15, 15, 15, 8, 8, 8))
+	s.Require().NoError(err)
+	s.Equal(27, total)
+
+	cost, err := shared.PointBuyCost(14)
+	s.Require().NoError(err)
+	s.Equal(7, cost)
+
+	s.NoError(shared.ValidatePointBuy(scores(15, 15, 15, 8, 8, 8)))
+	s.NoError(shared.ValidatePointBuy(scores(8, 8, 8, 8, 8, 8)), "spending less than the budget is legal")
+	s.ErrorContains(shared.ValidatePointBuy(scores(15, 15, 15, 9, 8, 8)), "costs 28 points, budget is 27")
+	s.ErrorContains(shared.ValidatePointBuy(scores(16, 8, 8, 8, 8, 8)), "point buy Strength 16 is outside 8-15")
+
+	_, err = shared.PointBuyCost(7)
+	s.Error(err)
+}
+
+func (s *AbilityGenerationTestSuite) TestStandardArray() {
+	s.Equal([]int{15, 14, 13, 12, 10, 8}, shared.StandardArray())
+	s.NoError(shared.ValidateStandardArray(scores(8, 10, 12, 13, 14, 15)))
+	s.Error(shared.ValidateStandardArray(scores(15, 15, 13, 12, 10, 8)))
+
+	array := shared.StandardArray()
+	array[0] = 18
+	s.Equal(15, shared.StandardArray()[0], "callers get a copy")
+}
+
+func (s *AbilityGenerationTestSuite) TestValidateMethod() {
+	s.NoError(shared.ValidateAbilityScoreMethod(shared.AbilityScoreMethodRolled, scores(18, 3, 10, 10, 10, 10)))
+	s.ErrorContains(shared.ValidateAbilityScoreMethod(shared.AbilityScoreMethodRolled, scores(19, 3, 10, 10, 10, 10)),
+		"rolled Strength 19 is outside 3-18")
+	s.ErrorContains(shared.ValidateAbilityScoreMethod(shared.AbilityScoreMethodPointBuy, shared.AbilityScores{}),
+		"missing Strength score")
+	s.Error(shared.ValidateAbilityScoreMethod("manual", scores(10, 10, 10, 10, 10, 10)))
+}
+
+func (s *AbilityGenerationTestSuite) TestRollDropsLowest() {
+	s.roller.EXPECT().RollN(s.ctx, 4, 6).Return([]int{6, 1, 5, 3}, nil)
+
+	score, err := shared.RollAbilityScore(s.ctx, s.roller)
+	s.Require().NoError(err)
+	s.Equal(14, score)
+}
+
+func (s *AbilityGenerationTestSuite) TestRollSixScores() {
+	gomock.InOrder(
+		s.roller.EXPECT().RollN(s.ctx, 4, 6).Return([]int{6, 6, 6, 6}, nil),
+		s.roller.EXPECT().RollN(s.ctx, 4, 6).Return([]int{1, 1, 1, 1}, nil).Times(4),
+		s.roller.EXPECT().RollN(s.ctx, 4, 6).Return([]int{2, 4, 4, 3}, nil),
+	)
+
+	rolled, err := shared.RollAbilityScores(s.ctx, s.roller)
+	s.Require().NoError(err)
+	s.Equal([]int{18, 3, 3, 3, 3, 11}, rolled)
+}
+
+func (s *AbilityGenerationTestSuite) TestRollErrors() {
+	s.roller.EXPECT().RollN(s.ctx, 4, 6).Return(nil, errors.New("dice fell off the table"))
+	_, err := shared.RollAbilityScores(s.ctx, s.roller)
+	s.ErrorContains(err, "dice fell off the table")
+
+	_, err = shared.RollAbilityScore(s.ctx, nil)
+	s.Error(err)
+}
@@ -0,0 +1,79 @@
+package shared
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+)
+
+// Coin values in copper pieces
+const (
+	// CopperPiece is worth 1 cp
+	CopperPiece = 1
+
+	// SilverPiece is worth 10 cp
+	SilverPiece = 10
+
+	// ElectrumPiece is worth 50 cp
+	ElectrumPiece = 50
+
+	// GoldPiece is worth 100 cp
+	GoldPiece = 100
+
+	// PlatinumPiece is worth 1,000 cp
+	PlatinumPiece = 1000
+)
+
+// coinValues maps a coin abbreviation to its value in copper pieces
+var coinValues = map[string]int{
+	"cp": CopperPiece,
+	"sp": SilverPiece,
+	"ep": ElectrumPiece,
+	"gp": GoldPiece,
+	"pp": PlatinumPiece,
+}
+
+// ParseCost converts a cost such as "5 gp" or "2 gp 5 sp" to copper pieces.
+// An empty cost is free.
+func ParseCost(cost string) (int, error) {
+	fields := strings.Fields(cost)
+	if len(fields)%2 != 0 {
+		return 0, rpgerr.Newf(rpgerr.CodeInvalidArgument, "cost %q isn't amount and coin pairs", cost)
+	}
+
+	total := 0
+	for i := 0; i < len(fields); i += 2 {
+		amount, err := strconv.Atoi(strings.ReplaceAll(fields[i], ",", ""))
+		if err != nil || amount < 0 {
+			return 0, rpgerr.Newf(rpgerr.CodeInvalidArgument, "cost %q has invalid amount %q", cost, fields[i])
+		}
+		value, ok := coinValues[strings.ToLower(fields[i+1])]
+		if !ok {
+			return 0, rpgerr.Newf(rpgerr.CodeInvalidArgument, "cost %q has unknown coin %q", cost, fields[i+1])
+		}
+		total += amount * value
+	}
+	return total, nil
+}
+
+// FormatCost renders copper pieces in gold, silver and copper, largest first,
+// e.g. 250 is "2 gp 5 sp". Zero is "0 gp".
+func FormatCost(copper int) string {
+	if copper == 0 {
+		return "0 gp"
+	}
+
+	var parts []string
+	for _, coin := range []struct {
+		name  string
+		value int
+	}{{"gp", GoldPiece}, {"sp", SilverPiece}, {"cp", CopperPiece}} {
+		if amount := copper / coin.value; amount != 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", amount, coin.name))
+			copper -= amount * coin.value
+		}
+	}
+	return strings.Join(parts, " ")
+}
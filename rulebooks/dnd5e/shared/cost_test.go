@@ -0,0 +1,50 @@
+package shared_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+)
+
+// CostTestSuite tests parsing and formatting costs
+type CostTestSuite struct {
+	suite.Suite
+}
+
+func TestCostSuite(t *testing.T) {
+	suite.Run(t, new(CostTestSuite))
+}
+
+func (s *CostTestSuite) TestParseCost() {
+	for cost, want := range map[string]int{
+		"5 gp":      500,
+		"2 gp 5 sp": 250,
+		"1 cp":      1,
+		"1 ep":      50,
+		"1 PP":      1000,
+		"1,500 gp":  150000,
+		"0 gp":      0,
+		"":          0,
+	} {
+		copper, err := shared.ParseCost(cost)
+		s.Require().NoError(err, cost)
+		s.Equal(want, copper, cost)
+	}
+}
+
+func (s *CostTestSuite) TestParseCostErrors() {
+	for _, cost := range []string{"5", "five gp", "5 dp", "-1 gp", "1 gp 2"} {
+		_, err := shared.ParseCost(cost)
+		s.Error(err, cost)
+	}
+}
+
+func (s *CostTestSuite) TestFormatCost() {
+	s.Equal("0 gp", shared.FormatCost(0))
+	s.Equal("2 gp 5 sp", shared.FormatCost(250))
+	s.Equal("1 cp", shared.FormatCost(1))
+	s.Equal("1500 gp", shared.FormatCost(150000))
+	s.Equal("3 sp 4 cp", shared.FormatCost(34))
+}
@@ -189,8 +189,14 @@ const (
 	ChoiceToolProficiency ChoiceCategory = "tool_proficiency"
 	// ChoiceExpertise represents expertise selection (for rogues and bards)
 	ChoiceExpertise ChoiceCategory = "expertise"
+	// ChoiceFavoredEnemy represents a ranger's favored enemy selection
+	ChoiceFavoredEnemy ChoiceCategory = "favored_enemy"
+	// ChoiceFavoredTerrain represents a ranger's favored terrain selection (Natural Explorer)
+	ChoiceFavoredTerrain ChoiceCategory = "favored_terrain"
 	// ChoiceTraits represents racial trait selection (e.g., draconic ancestry)
 	ChoiceTraits ChoiceCategory = "traits"
+	// ChoiceFeats represents feat selection (e.g., variant human)
+	ChoiceFeats ChoiceCategory = "feats"
 )
 
 // ChoiceSource represents where a choice or grant comes from
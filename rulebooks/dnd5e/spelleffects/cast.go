@@ -0,0 +1,246 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package spelleffects resolves the effects of SRD combat spells.
+//
+// spells.Cast spends a spell's resources; Cast here builds on it and then
+// resolves what the spell does using the combat systems: spell attacks, area
+// effects with saving throws, healing, and conditions for ongoing effects.
+package spelleffects
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	coreCombat "github.com/KirkDiggler/rpg-toolkit/core/combat"
+	"github.com/KirkDiggler/rpg-toolkit/dice"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/saves"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/spells"
+	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
+)
+
+// spellEffect checks a casting's targets before anything is spent, then
+// resolves the spell once it has been cast.
+type spellEffect struct {
+	castingTime coreCombat.ActionType
+	validate    func(ctx context.Context, input *CastInput) error
+	resolve     func(ctx context.Context, input *CastInput, cast *spells.CastResult) (*CastResult, error)
+}
+
+// effects holds the spells Cast can resolve
+var effects = map[spells.Spell]spellEffect{
+	spells.MagicMissile:    {validate: requireTargets, resolve: resolveMagicMissile},
+	spells.CureWounds:      {validate: validateCureWounds, resolve: resolveCureWounds},
+	spells.BurningHands:    {validate: requirePoint, resolve: resolveBurningHands},
+	spells.HoldPerson:      {validate: validateHoldPerson, resolve: resolveHoldPerson},
+	spells.ScorchingRay:    {validate: requireTargets, resolve: resolveScorchingRay},
+	spells.MistyStep:       {castingTime: coreCombat.ActionBonus, validate: validateMistyStep, resolve: resolveMistyStep},
+	spells.SpiritualWeapon: {castingTime: coreCombat.ActionBonus, resolve: resolveSpiritualWeapon},
+}
+
+// Supported returns true if Cast can resolve the spell's effect.
+func Supported(spell spells.Spell) bool {
+	_, ok := effects[spell]
+	return ok
+}
+
+// CastInput provides the parameters for casting a spell and resolving its effect.
+type CastInput struct {
+	// CasterID is the creature casting the spell. Spells that use the
+	// caster's position or ability scores look it up in the context.
+	CasterID string
+
+	// Spellcasting is the caster's spellcasting state.
+	Spellcasting *spells.Spellcasting
+
+	// Spell is the spell to cast (see Supported).
+	Spell spells.Spell
+
+	// SlotLevel is the slot to spend. 0 spends the lowest available slot.
+	// Higher slots strengthen the effect.
+	SlotLevel int
+
+	// Innate casts the spell with an innate use instead of a spell slot.
+	Innate bool
+
+	// TargetIDs are the creatures the spell targets. Magic Missile darts and
+	// Scorching Ray rays are spread across them in order, repeating as needed.
+	TargetIDs []string
+
+	// Point is the point the spell is aimed at: the direction of Burning
+	// Hands' cone, or Misty Step's destination.
+	Point *spatial.Position
+
+	// Economy is the caster's action economy (optional, see spells.CastInput).
+	Economy *combat.ActionEconomy
+
+	// EventBus is required for publishing cast, damage, and condition events.
+	EventBus events.EventBus
+
+	// Roller is the dice roller. If nil, a default roller is used.
+	Roller dice.Roller
+}
+
+// Validate validates the input.
+func (c *CastInput) Validate() error {
+	if c == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "CastInput is nil")
+	}
+	if c.CasterID == "" {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "CasterID is required")
+	}
+	if c.Spellcasting == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "Spellcasting is required")
+	}
+	if c.EventBus == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "EventBus is required")
+	}
+	return nil
+}
+
+// TargetOutcome is the effect of a spell on one creature. Spells with several
+// darts or rays report one outcome per dart or ray.
+type TargetOutcome struct {
+	// TargetID is the affected creature.
+	TargetID string
+
+	// Attack is the spell attack against the creature (spell attacks only).
+	Attack *combat.AttackResult
+
+	// Save is the creature's saving throw (save-based spells only).
+	Save *saves.SavingThrowResult
+
+	// Damage is the damage dealt after the damage chain.
+	Damage int
+
+	// Healing is the hit points restored.
+	Healing int
+}
+
+// CastResult describes a cast spell and what it did.
+type CastResult struct {
+	// Cast is the resources spent and the caster's DC and attack bonus.
+	Cast *spells.CastResult
+
+	// Targets holds the outcome for each creature affected, in order.
+	Targets []*TargetOutcome
+
+	// Area is the area effect resolution (area spells only).
+	Area *combat.AreaResult
+
+	// Conditions are the conditions the spell created, such as Hold Person's
+	// paralysis or the caster's Spiritual Weapon.
+	Conditions []dnd5eEvents.ConditionBehavior
+}
+
+// Cast casts a spell through spells.Cast and resolves its effect.
+//
+// Targets are checked before anything is spent, so a casting with missing or
+// invalid targets costs nothing. Damage runs through the DamageChain and is
+// published as a DamageReceivedEvent per creature, as weapon hits are; healing
+// is published as a HealingReceivedEvent. Concentration is left to the caller:
+// Hold Person ends when a ConcentrationEndedEvent for it is published.
+func Cast(ctx context.Context, input *CastInput) (*CastResult, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+	effect, ok := effects[input.Spell]
+	if !ok {
+		return nil, rpgerr.Newf(rpgerr.CodeNotFound, "%s has no spell effect", input.Spell)
+	}
+	if effect.validate != nil {
+		if err := effect.validate(ctx, input); err != nil {
+			return nil, err
+		}
+	}
+
+	cast, err := spells.Cast(ctx, &spells.CastInput{
+		CasterID:     input.CasterID,
+		Spellcasting: input.Spellcasting,
+		Spell:        input.Spell,
+		SlotLevel:    input.SlotLevel,
+		Innate:       input.Innate,
+		TargetIDs:    input.TargetIDs,
+		CastingTime:  effect.castingTime,
+		Economy:      input.Economy,
+		EventBus:     input.EventBus,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := effect.resolve(ctx, input, cast)
+	if err != nil {
+		return nil, rpgerr.Wrapf(err, "failed to resolve %s", input.Spell)
+	}
+	result.Cast = cast
+	return result, nil
+}
+
+// requireTargets checks that the spell has at least one target
+func requireTargets(_ context.Context, input *CastInput) error {
+	if len(input.TargetIDs) == 0 {
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument, "%s requires a target", input.Spell)
+	}
+	return nil
+}
+
+// requirePoint checks that the spell is aimed at a point
+func requirePoint(_ context.Context, input *CastInput) error {
+	if input.Point == nil {
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument, "%s requires a Point", input.Spell)
+	}
+	return nil
+}
+
+// upcast returns how many slot levels above the spell's level it was cast with
+func upcast(cast *spells.CastResult) int {
+	if cast.SlotLevel <= cast.SpellLevel {
+		return 0
+	}
+	return cast.SlotLevel - cast.SpellLevel
+}
+
+// spellcastingModifier returns the caster's spellcasting ability modifier
+func spellcastingModifier(ctx context.Context, input *CastInput) (int, error) {
+	caster, err := combat.GetCombatantFromContext(ctx, input.CasterID)
+	if err != nil {
+		return 0, rpgerr.Wrapf(err, "failed to look up caster %s", input.CasterID)
+	}
+	return caster.AbilityScores().Modifier(input.Spellcasting.Ability()), nil
+}
+
+// applyCondition applies a condition to a creature. Monsters hold their own
+// conditions; characters pick it up from the applied event.
+func applyCondition(
+	ctx context.Context,
+	bus events.EventBus,
+	target combat.Combatant,
+	conditionType dnd5eEvents.ConditionType,
+	condition dnd5eEvents.ConditionBehavior,
+) error {
+	if holder, ok := target.(interface {
+		AddCondition(condition dnd5eEvents.ConditionBehavior)
+	}); ok {
+		if err := condition.Apply(ctx, bus); err != nil {
+			return err
+		}
+		holder.AddCondition(condition)
+		return nil
+	}
+
+	entity, ok := target.(core.Entity)
+	if !ok {
+		return rpgerr.Newf(rpgerr.CodeInvalidTarget, "%s can't hold conditions", target.GetID())
+	}
+	return dnd5eEvents.ConditionAppliedTopic.On(bus).Publish(ctx, dnd5eEvents.ConditionAppliedEvent{
+		Target:    entity,
+		Type:      conditionType,
+		Source:    dnd5eEvents.ConditionSourceSpell,
+		Condition: condition,
+	})
+}
@@ -0,0 +1,331 @@
+package spelleffects_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	mock_dice "github.com/KirkDiggler/rpg-toolkit/dice/mock"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/conditions"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/spelleffects"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/spells"
+	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
+)
+
+// combatantMap resolves combatants by ID
+type combatantMap map[string]combat.Combatant
+
+func (m combatantMap) Get(id string) (combat.Combatant, error) {
+	if c, ok := m[id]; ok {
+		return c, nil
+	}
+	return nil, rpgerr.Newf(rpgerr.CodeNotFound, "combatant %s not found", id)
+}
+
+type CastTestSuite struct {
+	suite.Suite
+	ctrl         *gomock.Controller
+	ctx          context.Context
+	eventBus     events.EventBus
+	lookup       combatantMap
+	roller       *mock_dice.MockRoller
+	room         *spatial.BasicRoom
+	caster       *monster.Monster
+	spellcasting *spells.Spellcasting
+	damage       map[string]int
+	healing      map[string]int
+}
+
+func TestCastSuite(t *testing.T) {
+	suite.Run(t, new(CastTestSuite))
+}
+
+func (s *CastTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.eventBus = events.NewEventBus()
+	s.lookup = combatantMap{}
+	s.roller = mock_dice.NewMockRoller(s.ctrl)
+	s.room = spatial.NewBasicRoom(spatial.BasicRoomConfig{
+		ID:   "spell-room",
+		Type: "combat",
+		Grid: spatial.NewSquareGrid(spatial.SquareGridConfig{Width: 30, Height: 30}),
+	})
+	s.ctx = combat.WithRoom(context.Background(), s.room)
+	s.ctx = combat.WithCombatantLookup(s.ctx, s.lookup)
+
+	// A WIS 16 (+3) cleric-like caster with spell save DC 13 and +5 to hit
+	s.caster = monster.New(monster.Config{
+		ID:   "caster",
+		Name: "Acolyte",
+		Type: monster.CreatureTypeHumanoid,
+		HP:   20,
+		AC:   12,
+		AbilityScores: shared.AbilityScores{
+			abilities.STR: 10,
+			abilities.DEX: 10,
+			abilities.CON: 10,
+			abilities.INT: 10,
+			abilities.WIS: 16,
+			abilities.CHA: 10,
+		},
+	})
+	s.place(s.caster, spatial.Position{X: 5, Y: 5})
+
+	var err error
+	s.spellcasting, err = spells.NewSpellcasting(&spells.SpellcastingData{
+		Ability:     abilities.WIS,
+		SaveDC:      13,
+		AttackBonus: 5,
+		Known: []spells.Spell{
+			spells.MagicMissile, spells.CureWounds, spells.BurningHands, spells.HoldPerson,
+			spells.ScorchingRay, spells.MistyStep, spells.SpiritualWeapon,
+		},
+		Slots: map[int]spells.SlotData{1: {Max: 2}, 2: {Max: 2}, 3: {Max: 1}},
+	})
+	s.Require().NoError(err)
+
+	s.damage = map[string]int{}
+	_, err = dnd5eEvents.DamageReceivedTopic.On(s.eventBus).Subscribe(s.ctx,
+		func(_ context.Context, e dnd5eEvents.DamageReceivedEvent) error {
+			s.damage[e.TargetID] += e.Amount
+			return nil
+		})
+	s.Require().NoError(err)
+
+	s.healing = map[string]int{}
+	_, err = dnd5eEvents.HealingReceivedTopic.On(s.eventBus).Subscribe(s.ctx,
+		func(_ context.Context, e dnd5eEvents.HealingReceivedEvent) error {
+			s.healing[e.TargetID] += e.Amount
+			return nil
+		})
+	s.Require().NoError(err)
+}
+
+func (s *CastTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// place adds a monster to the room and the combatant lookup
+func (s *CastTestSuite) place(m *monster.Monster, pos spatial.Position) {
+	s.lookup[m.GetID()] = m
+	s.Require().NoError(s.room.PlaceEntity(m, pos))
+}
+
+func (s *CastTestSuite) cast(spell spells.Spell, slotLevel int, targetIDs ...string) *spelleffects.CastInput {
+	return &spelleffects.CastInput{
+		CasterID:     s.caster.GetID(),
+		Spellcasting: s.spellcasting,
+		Spell:        spell,
+		SlotLevel:    slotLevel,
+		TargetIDs:    targetIDs,
+		EventBus:     s.eventBus,
+		Roller:       s.roller,
+	}
+}
+
+func (s *CastTestSuite) TestMagicMissileUpcastAddsDarts() {
+	s.place(monster.NewGoblin("goblin-1"), spatial.Position{X: 8, Y: 5})
+	s.place(monster.NewGoblin("goblin-2"), spatial.Position{X: 8, Y: 6})
+
+	s.roller.EXPECT().Roll(gomock.Any(), 4).Return(2, nil).Times(4)
+
+	result, err := spelleffects.Cast(s.ctx, s.cast(spells.MagicMissile, 2, "goblin-1", "goblin-2"))
+	s.Require().NoError(err)
+
+	s.Require().Len(result.Targets, 4, "3 darts plus 1 for the 2nd-level slot")
+	s.Equal("goblin-1", result.Targets[2].TargetID, "darts cycle through the targets")
+	s.Equal(3, result.Targets[0].Damage)
+	s.Equal(6, s.damage["goblin-1"])
+	s.Equal(6, s.damage["goblin-2"])
+	s.Equal(1, s.spellcasting.SlotsRemaining(2))
+}
+
+func (s *CastTestSuite) TestCureWoundsHealsSlotDiceAndModifier() {
+	s.place(monster.NewGoblin("ally"), spatial.Position{X: 6, Y: 5})
+
+	s.roller.EXPECT().RollN(gomock.Any(), 2, 8).Return([]int{4, 5}, nil)
+
+	result, err := spelleffects.Cast(s.ctx, s.cast(spells.CureWounds, 2, "ally"))
+	s.Require().NoError(err)
+
+	s.Equal(12, result.Targets[0].Healing, "2d8 (9) + WIS 3")
+	s.Equal(12, s.healing["ally"])
+}
+
+func (s *CastTestSuite) TestCureWoundsRejectsUndeadWithoutSpending() {
+	s.place(monster.New(monster.Config{
+		ID:   "zombie",
+		Type: monster.CreatureTypeUndead,
+		HP:   22,
+		AC:   8,
+	}), spatial.Position{X: 6, Y: 5})
+
+	_, err := spelleffects.Cast(s.ctx, s.cast(spells.CureWounds, 1, "zombie"))
+	s.Require().Error(err)
+	s.Equal(rpgerr.CodeInvalidTarget, rpgerr.GetCode(err))
+	s.Equal(2, s.spellcasting.SlotsRemaining(1), "a rejected cast spends nothing")
+}
+
+func (s *CastTestSuite) TestBurningHandsHalvesDamageOnSave() {
+	s.place(monster.NewGoblin("goblin-1"), spatial.Position{X: 6, Y: 5})
+	s.place(monster.NewGoblin("goblin-2"), spatial.Position{X: 7, Y: 5})
+	s.place(monster.NewGoblin("behind"), spatial.Position{X: 3, Y: 5})
+
+	gomock.InOrder(
+		s.roller.EXPECT().RollN(gomock.Any(), 3, 6).Return([]int{4, 4, 4}, nil),
+		s.roller.EXPECT().Roll(gomock.Any(), 20).Return(3, nil),  // goblin-1 fails
+		s.roller.EXPECT().Roll(gomock.Any(), 20).Return(15, nil), // goblin-2 saves
+	)
+
+	point := spatial.Position{X: 8, Y: 5}
+	input := s.cast(spells.BurningHands, 1)
+	input.Point = &point
+
+	result, err := spelleffects.Cast(s.ctx, input)
+	s.Require().NoError(err)
+
+	s.Require().Len(result.Targets, 2, "the cone points away from the creature behind the caster")
+	s.Equal(12, s.damage["goblin-1"])
+	s.Equal(6, s.damage["goblin-2"])
+	s.Zero(s.damage["caster"])
+}
+
+func (s *CastTestSuite) TestHoldPersonParalyzesOnFailedSave() {
+	goblin := monster.NewGoblin("goblin-1")
+	s.place(goblin, spatial.Position{X: 7, Y: 5})
+
+	s.roller.EXPECT().Roll(gomock.Any(), 20).Return(5, nil)
+
+	result, err := spelleffects.Cast(s.ctx, s.cast(spells.HoldPerson, 2, "goblin-1"))
+	s.Require().NoError(err)
+
+	s.Require().Len(result.Conditions, 1)
+	s.False(result.Targets[0].Save.Success)
+	s.Require().Len(goblin.GetConditions(), 1)
+	held, ok := goblin.GetConditions()[0].(*conditions.HoldPersonCondition)
+	s.Require().True(ok)
+	s.True(held.IsApplied())
+	s.Equal(13, held.DC)
+}
+
+func (s *CastTestSuite) TestHoldPersonRejectsNonHumanoid() {
+	s.place(monster.New(monster.Config{
+		ID:   "wolf",
+		Type: monster.CreatureTypeBeast,
+		HP:   11,
+		AC:   13,
+	}), spatial.Position{X: 7, Y: 5})
+
+	_, err := spelleffects.Cast(s.ctx, s.cast(spells.HoldPerson, 2, "wolf"))
+	s.Require().Error(err)
+	s.Equal(rpgerr.CodeInvalidTarget, rpgerr.GetCode(err))
+	s.Equal(2, s.spellcasting.SlotsRemaining(2))
+}
+
+func (s *CastTestSuite) TestHoldPersonLimitsTargetsBySlot() {
+	s.place(monster.NewGoblin("goblin-1"), spatial.Position{X: 7, Y: 5})
+	s.place(monster.NewGoblin("goblin-2"), spatial.Position{X: 7, Y: 6})
+
+	_, err := spelleffects.Cast(s.ctx, s.cast(spells.HoldPerson, 2, "goblin-1", "goblin-2"))
+	s.Require().Error(err)
+	s.Equal(rpgerr.CodeInvalidArgument, rpgerr.GetCode(err))
+}
+
+func (s *CastTestSuite) TestScorchingRayMakesOneAttackPerRay() {
+	s.place(monster.NewGoblin("goblin-1"), spatial.Position{X: 10, Y: 5})
+
+	gomock.InOrder(
+		s.roller.EXPECT().Roll(gomock.Any(), 20).Return(15, nil), // hit
+		s.roller.EXPECT().RollN(gomock.Any(), 2, 6).Return([]int{3, 3}, nil),
+		s.roller.EXPECT().Roll(gomock.Any(), 20).Return(2, nil),  // miss
+		s.roller.EXPECT().Roll(gomock.Any(), 20).Return(12, nil), // hit
+		s.roller.EXPECT().RollN(gomock.Any(), 2, 6).Return([]int{1, 2}, nil),
+	)
+
+	result, err := spelleffects.Cast(s.ctx, s.cast(spells.ScorchingRay, 2, "goblin-1"))
+	s.Require().NoError(err)
+
+	s.Require().Len(result.Targets, 3)
+	s.False(result.Targets[1].Attack.Hit)
+	s.Equal(9, s.damage["goblin-1"])
+}
+
+func (s *CastTestSuite) TestMistyStepTeleportsCaster() {
+	point := spatial.Position{X: 10, Y: 5}
+	input := s.cast(spells.MistyStep, 2)
+	input.Point = &point
+	input.Economy = combat.NewActionEconomy()
+
+	_, err := spelleffects.Cast(s.ctx, input)
+	s.Require().NoError(err)
+
+	pos, found := s.room.GetEntityPosition("caster")
+	s.Require().True(found)
+	s.Equal(point, pos)
+	s.Equal(0, input.Economy.BonusActionsRemaining, "misty step is a bonus action")
+}
+
+func (s *CastTestSuite) TestMistyStepBeyondRangeSpendsNothing() {
+	point := spatial.Position{X: 20, Y: 5}
+	input := s.cast(spells.MistyStep, 2)
+	input.Point = &point
+
+	_, err := spelleffects.Cast(s.ctx, input)
+	s.Require().Error(err)
+	s.Equal(rpgerr.CodeOutOfRange, rpgerr.GetCode(err))
+	s.Equal(2, s.spellcasting.SlotsRemaining(2))
+}
+
+func (s *CastTestSuite) TestSpiritualWeaponAttacksWhenCast() {
+	s.place(monster.NewGoblin("goblin-1"), spatial.Position{X: 12, Y: 5})
+
+	gomock.InOrder(
+		s.roller.EXPECT().Roll(gomock.Any(), 20).Return(14, nil),
+		s.roller.EXPECT().RollN(gomock.Any(), 1, 8).Return([]int{5}, nil),
+	)
+
+	result, err := spelleffects.Cast(s.ctx, s.cast(spells.SpiritualWeapon, 2, "goblin-1"))
+	s.Require().NoError(err)
+
+	s.Require().Len(s.caster.GetConditions(), 1)
+	weapon, ok := s.caster.GetConditions()[0].(*conditions.SpiritualWeaponCondition)
+	s.Require().True(ok)
+	s.True(weapon.IsApplied())
+	s.Equal(8, result.Targets[0].Damage, "1d8 (5) + WIS 3")
+	s.Equal(8, s.damage["goblin-1"])
+}
+
+func (s *CastTestSuite) TestUnsupportedSpell() {
+	_, err := spelleffects.Cast(s.ctx, s.cast(spells.Fireball, 3, "goblin-1"))
+	s.Require().Error(err)
+	s.Equal(rpgerr.CodeNotFound, rpgerr.GetCode(err))
+	s.False(spelleffects.Supported(spells.Fireball))
+	s.True(spelleffects.Supported(spells.HoldPerson))
+}
+
+func (s *CastTestSuite) TestHoldPersonEndsOnConcentration() {
+	goblin := monster.NewGoblin("goblin-1")
+	s.place(goblin, spatial.Position{X: 7, Y: 5})
+	s.roller.EXPECT().Roll(gomock.Any(), 20).Return(1, nil)
+
+	result, err := spelleffects.Cast(s.ctx, s.cast(spells.HoldPerson, 2, "goblin-1"))
+	s.Require().NoError(err)
+
+	err = dnd5eEvents.ConcentrationEndedTopic.On(s.eventBus).Publish(s.ctx, dnd5eEvents.ConcentrationEndedEvent{
+		CasterID: "caster",
+		SpellID:  refs.Spells.HoldPerson().ID,
+		Reason:   "failed_save",
+	})
+	s.Require().NoError(err)
+	s.False(result.Conditions[0].IsApplied())
+}
@@ -0,0 +1,385 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package spelleffects
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/dice"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/conditions"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/refs"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/saves"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/spells"
+)
+
+const (
+	// burningHandsSize is the length of Burning Hands' cone in feet
+	burningHandsSize = 15
+
+	// mistyStepRange is how far Misty Step teleports the caster in feet
+	mistyStepRange = 30
+
+	// scorchingRayRange is Scorching Ray's range in feet
+	scorchingRayRange = 120
+)
+
+// creatureTyper is implemented by combatants with a creature type (monsters)
+type creatureTyper interface {
+	CreatureType() monster.CreatureType
+}
+
+// rollerOrDefault returns the input's roller, or a default roller
+func rollerOrDefault(input *CastInput) dice.Roller {
+	if input.Roller == nil {
+		return dice.NewRoller()
+	}
+	return input.Roller
+}
+
+// targetAt spreads darts and rays across the targets in order
+func targetAt(input *CastInput, i int) string {
+	return input.TargetIDs[i%len(input.TargetIDs)]
+}
+
+// resolveMagicMissile fires three darts, plus one per slot level above 1st.
+// Each dart hits automatically for 1d4 + 1 force damage.
+func resolveMagicMissile(ctx context.Context, input *CastInput, cast *spells.CastResult) (*CastResult, error) {
+	roller := rollerOrDefault(input)
+	result := &CastResult{}
+
+	for i := 0; i < 3+upcast(cast); i++ {
+		targetID := targetAt(input, i)
+		roll, err := roller.Roll(ctx, 4)
+		if err != nil {
+			return nil, rpgerr.Wrap(err, "failed to roll magic missile damage")
+		}
+		amount, err := dealSpellDamage(ctx, input, targetID, refs.Spells.MagicMissile(), dnd5eEvents.DamageComponent{
+			Source:            dnd5eEvents.DamageSourceSpell,
+			SourceRef:         refs.Spells.MagicMissile(),
+			OriginalDiceRolls: []int{roll},
+			FinalDiceRolls:    []int{roll},
+			FlatBonus:         1,
+			DamageType:        damage.Force,
+		})
+		if err != nil {
+			return nil, err
+		}
+		result.Targets = append(result.Targets, &TargetOutcome{TargetID: targetID, Damage: amount})
+	}
+
+	return result, nil
+}
+
+// dealSpellDamage resolves damage through the DamageChain and publishes it
+func dealSpellDamage(
+	ctx context.Context,
+	input *CastInput,
+	targetID string,
+	spellRef *core.Ref,
+	component dnd5eEvents.DamageComponent,
+) (int, error) {
+	resolved, err := combat.ResolveDamage(ctx, &combat.ResolveDamageInput{
+		AttackerID: input.CasterID,
+		TargetID:   targetID,
+		Components: []dnd5eEvents.DamageComponent{component},
+		EventBus:   input.EventBus,
+	})
+	if err != nil {
+		return 0, err
+	}
+	amount := max(resolved.TotalDamage, 0)
+
+	err = dnd5eEvents.DamageReceivedTopic.On(input.EventBus).Publish(ctx, dnd5eEvents.DamageReceivedEvent{
+		TargetID:   targetID,
+		SourceID:   input.CasterID,
+		SourceRef:  spellRef,
+		Amount:     amount,
+		DamageType: component.DamageType,
+	})
+	if err != nil {
+		return 0, rpgerr.Wrap(err, "failed to publish damage received event")
+	}
+	return amount, nil
+}
+
+// validateCureWounds requires one living target: undead and constructs can't be healed
+func validateCureWounds(ctx context.Context, input *CastInput) error {
+	if len(input.TargetIDs) != 1 {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "cure wounds requires exactly one target")
+	}
+	target, err := combat.GetCombatantFromContext(ctx, input.TargetIDs[0])
+	if err != nil {
+		return rpgerr.Wrapf(err, "failed to look up target %s", input.TargetIDs[0])
+	}
+	if typed, ok := target.(creatureTyper); ok {
+		switch typed.CreatureType() {
+		case monster.CreatureTypeUndead, monster.CreatureTypeConstruct:
+			return rpgerr.Newf(rpgerr.CodeInvalidTarget,
+				"cure wounds has no effect on %s (%s)", input.TargetIDs[0], typed.CreatureType())
+		}
+	}
+	return nil
+}
+
+// resolveCureWounds heals 1d8 per slot level + the spellcasting ability modifier
+func resolveCureWounds(ctx context.Context, input *CastInput, cast *spells.CastResult) (*CastResult, error) {
+	modifier, err := spellcastingModifier(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	rolls, err := rollerOrDefault(input).RollN(ctx, cast.SlotLevel, 8)
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to roll cure wounds healing")
+	}
+	roll := 0
+	for _, r := range rolls {
+		roll += r
+	}
+	amount := max(roll+modifier, 0)
+
+	targetID := input.TargetIDs[0]
+	err = dnd5eEvents.HealingReceivedTopic.On(input.EventBus).Publish(ctx, dnd5eEvents.HealingReceivedEvent{
+		TargetID: targetID,
+		Amount:   amount,
+		Roll:     roll,
+		Modifier: modifier,
+		Source:   refs.Spells.CureWounds().String(),
+	})
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to publish healing received event")
+	}
+
+	return &CastResult{Targets: []*TargetOutcome{{TargetID: targetID, Healing: amount}}}, nil
+}
+
+// resolveBurningHands sweeps a 15-foot cone from the caster toward Point.
+// Creatures in it take 3d6 fire damage, plus 1d6 per slot level above 1st,
+// halved on a successful DEX save.
+func resolveBurningHands(ctx context.Context, input *CastInput, cast *spells.CastResult) (*CastResult, error) {
+	origin, found := combat.EntityPosition(ctx, input.CasterID)
+	if !found {
+		return nil, rpgerr.Newf(rpgerr.CodeNotFound, "caster %s is not in the room", input.CasterID)
+	}
+
+	area, err := combat.ResolveAreaEffect(ctx, &combat.AreaInput{
+		SourceID:  input.CasterID,
+		EffectRef: refs.Spells.BurningHands(),
+		Template: combat.AreaTemplate{
+			Shape:     combat.AreaShapeCone,
+			Size:      burningHandsSize,
+			Direction: input.Point,
+		},
+		Origin: origin,
+		Save: &combat.AreaSave{
+			Ability:       abilities.DEX,
+			DC:            cast.SaveDC,
+			HalfOnSuccess: true,
+			Trigger:       dnd5eEvents.SaveTriggerSpell,
+		},
+		Damage: &combat.AreaDamage{
+			Dice: fmt.Sprintf("%dd6", 3+upcast(cast)),
+			Type: damage.Fire,
+		},
+		ExcludeIDs: []string{input.CasterID},
+		EventBus:   input.EventBus,
+		Roller:     input.Roller,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CastResult{Area: area}
+	for _, target := range area.Targets {
+		result.Targets = append(result.Targets, &TargetOutcome{TargetID: target.TargetID, Damage: target.Damage})
+		if target.Damage == 0 {
+			continue
+		}
+		err := dnd5eEvents.DamageReceivedTopic.On(input.EventBus).Publish(ctx, dnd5eEvents.DamageReceivedEvent{
+			TargetID:   target.TargetID,
+			SourceID:   input.CasterID,
+			SourceRef:  refs.Spells.BurningHands(),
+			Amount:     target.Damage,
+			DamageType: damage.Fire,
+		})
+		if err != nil {
+			return nil, rpgerr.Wrap(err, "failed to publish damage received event")
+		}
+	}
+
+	return result, nil
+}
+
+// holdPersonTargets returns how many creatures Hold Person can target: one,
+// plus one per slot level above 2nd. An unspecified slot is assumed 2nd level.
+func holdPersonTargets(slotLevel int) int {
+	return 1 + max(slotLevel-2, 0)
+}
+
+// validateHoldPerson checks the target count and that every target is a humanoid.
+// Combatants without a creature type (characters) are humanoids.
+func validateHoldPerson(ctx context.Context, input *CastInput) error {
+	if len(input.TargetIDs) == 0 {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "hold person requires a target")
+	}
+	if limit := holdPersonTargets(input.SlotLevel); len(input.TargetIDs) > limit {
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument,
+			"hold person can target %d creatures at slot level %d, got %d", limit, input.SlotLevel, len(input.TargetIDs))
+	}
+	for _, targetID := range input.TargetIDs {
+		target, err := combat.GetCombatantFromContext(ctx, targetID)
+		if err != nil {
+			return rpgerr.Wrapf(err, "failed to look up target %s", targetID)
+		}
+		if typed, ok := target.(creatureTyper); ok && typed.CreatureType() != monster.CreatureTypeHumanoid {
+			return rpgerr.Newf(rpgerr.CodeInvalidTarget, "hold person only affects humanoids, %s is %s",
+				targetID, typed.CreatureType())
+		}
+	}
+	return nil
+}
+
+// resolveHoldPerson has each target make a WIS save, paralyzing those that fail
+func resolveHoldPerson(ctx context.Context, input *CastInput, cast *spells.CastResult) (*CastResult, error) {
+	result := &CastResult{}
+
+	for _, targetID := range input.TargetIDs {
+		target, err := combat.GetCombatantFromContext(ctx, targetID)
+		if err != nil {
+			return nil, rpgerr.Wrapf(err, "failed to look up target %s", targetID)
+		}
+		modifier := target.AbilityScores().Modifier(abilities.WIS)
+		if provider, ok := target.(combat.SaveModifierProvider); ok {
+			modifier = provider.GetSavingThrowModifier(abilities.WIS)
+		}
+
+		save, err := saves.MakeSavingThrow(ctx, &saves.SavingThrowInput{
+			Roller:   input.Roller,
+			EventBus: input.EventBus,
+			SaverID:  targetID,
+			Cause: dnd5eEvents.SaveCause{
+				Trigger:      dnd5eEvents.SaveTriggerSpell,
+				EffectRef:    refs.Spells.HoldPerson(),
+				InstigatorID: input.CasterID,
+				Conditions:   []*core.Ref{refs.Conditions.Paralyzed()},
+			},
+			Ability:  abilities.WIS,
+			DC:       cast.SaveDC,
+			Modifier: modifier,
+		})
+		if err != nil {
+			return nil, rpgerr.Wrapf(err, "failed to roll hold person save for %s", targetID)
+		}
+		result.Targets = append(result.Targets, &TargetOutcome{TargetID: targetID, Save: save})
+		if save.Success {
+			continue
+		}
+
+		held := conditions.NewHoldPersonCondition(conditions.HoldPersonInput{
+			CharacterID:  targetID,
+			CasterID:     input.CasterID,
+			DC:           cast.SaveDC,
+			SaveModifier: modifier,
+			Roller:       input.Roller,
+		})
+		if err := applyCondition(ctx, input.EventBus, target, dnd5eEvents.ConditionParalyzed, held); err != nil {
+			return nil, rpgerr.Wrapf(err, "failed to paralyze %s", targetID)
+		}
+		result.Conditions = append(result.Conditions, held)
+	}
+
+	return result, nil
+}
+
+// validateMistyStep checks that the caster can teleport to Point
+func validateMistyStep(ctx context.Context, input *CastInput) error {
+	if err := requirePoint(ctx, input); err != nil {
+		return err
+	}
+	return combat.CheckTeleport(ctx, input.CasterID, *input.Point, mistyStepRange)
+}
+
+// resolveMistyStep teleports the caster up to 30 feet to Point
+func resolveMistyStep(ctx context.Context, input *CastInput, _ *spells.CastResult) (*CastResult, error) {
+	if err := combat.TeleportEntity(ctx, input.CasterID, *input.Point, mistyStepRange); err != nil {
+		return nil, err
+	}
+	return &CastResult{}, nil
+}
+
+// resolveScorchingRay fires three rays, plus one per slot level above 2nd.
+// Each ray is a ranged spell attack dealing 2d6 fire damage.
+func resolveScorchingRay(ctx context.Context, input *CastInput, cast *spells.CastResult) (*CastResult, error) {
+	result := &CastResult{}
+
+	for i := 0; i < 3+upcast(cast); i++ {
+		targetID := targetAt(input, i)
+		attack, err := combat.ResolveSpellAttack(ctx, &combat.SpellAttackInput{
+			AttackerID:  input.CasterID,
+			TargetID:    targetID,
+			SpellRef:    refs.Spells.ScorchingRay(),
+			AttackBonus: cast.AttackBonus,
+			Ranged:      true,
+			Range:       scorchingRayRange,
+			Damage:      "2d6",
+			DamageType:  damage.Fire,
+			EventBus:    input.EventBus,
+			Roller:      input.Roller,
+		})
+		if err != nil {
+			return nil, rpgerr.Wrapf(err, "failed to resolve scorching ray against %s", targetID)
+		}
+		result.Targets = append(result.Targets, &TargetOutcome{
+			TargetID: targetID,
+			Attack:   attack,
+			Damage:   attack.TotalDamage,
+		})
+	}
+
+	return result, nil
+}
+
+// resolveSpiritualWeapon creates the weapon on the caster. With a target, the
+// weapon attacks it as part of the casting.
+func resolveSpiritualWeapon(ctx context.Context, input *CastInput, cast *spells.CastResult) (*CastResult, error) {
+	caster, err := combat.GetCombatantFromContext(ctx, input.CasterID)
+	if err != nil {
+		return nil, rpgerr.Wrapf(err, "failed to look up caster %s", input.CasterID)
+	}
+	modifier := caster.AbilityScores().Modifier(input.Spellcasting.Ability())
+
+	weapon := conditions.NewSpiritualWeaponCondition(conditions.SpiritualWeaponInput{
+		CharacterID:    input.CasterID,
+		SlotLevel:      cast.SlotLevel,
+		AttackBonus:    cast.AttackBonus,
+		DamageModifier: modifier,
+		Roller:         input.Roller,
+	})
+	err = applyCondition(ctx, input.EventBus, caster, dnd5eEvents.ConditionSpiritualWeapon, weapon)
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to create spiritual weapon")
+	}
+	result := &CastResult{Conditions: []dnd5eEvents.ConditionBehavior{weapon}}
+
+	if len(input.TargetIDs) == 0 {
+		return result, nil
+	}
+	attack, err := weapon.Attack(ctx, &conditions.SpiritualWeaponAttackInput{TargetID: input.TargetIDs[0]})
+	if err != nil {
+		return nil, err
+	}
+	result.Targets = append(result.Targets, &TargetOutcome{
+		TargetID: input.TargetIDs[0],
+		Attack:   attack,
+		Damage:   attack.TotalDamage,
+	})
+	return result, nil
+}
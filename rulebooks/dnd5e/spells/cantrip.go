@@ -0,0 +1,31 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package spells
+
+// cantripTierLevels are the character levels at which cantrips grow stronger
+var cantripTierLevels = []int{5, 11, 17}
+
+// CantripTier returns how strong a cantrip is for a caster's character level:
+// 1 below 5th level, then 2 at 5th, 3 at 11th, and 4 at 17th.
+// Damage cantrips roll this many times their base damage dice; cantrips that
+// scale by beam (Eldritch Blast) fire this many beams instead.
+func CantripTier(characterLevel int) int {
+	tier := 1
+	for _, level := range cantripTierLevels {
+		if characterLevel >= level {
+			tier++
+		}
+	}
+	return tier
+}
+
+// CantripBeams returns how many beams a cantrip fires for a caster's character
+// level. Cantrips that don't scale by beam always fire one.
+func CantripBeams(spell Spell, characterLevel int) int {
+	data := GetData(spell)
+	if data == nil || data.Level != 0 || !data.ScalesByBeam {
+		return 1
+	}
+	return CantripTier(characterLevel)
+}
@@ -0,0 +1,27 @@
+package spells
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CantripScalingTestSuite struct {
+	suite.Suite
+}
+
+func TestCantripScalingSuite(t *testing.T) {
+	suite.Run(t, new(CantripScalingTestSuite))
+}
+
+func (s *CantripScalingTestSuite) TestTierRisesAtFifthEleventhAndSeventeenth() {
+	for level, tier := range map[int]int{1: 1, 4: 1, 5: 2, 10: 2, 11: 3, 16: 3, 17: 4, 20: 4} {
+		s.Equal(tier, CantripTier(level), "level %d", level)
+	}
+}
+
+func (s *CantripScalingTestSuite) TestBeamsOnlyForBeamCantrips() {
+	s.Equal(3, CantripBeams(EldritchBlast, 11))
+	s.Equal(1, CantripBeams(FireBolt, 11), "fire bolt scales by dice, not beams")
+	s.Equal(1, CantripBeams(ScorchingRay, 11))
+}
@@ -0,0 +1,290 @@
+package spells
+
+import (
+	"context"
+
+	coreCombat "github.com/KirkDiggler/rpg-toolkit/core/combat"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+)
+
+// CastInput provides the parameters for casting a spell.
+type CastInput struct {
+	// CasterID is the creature casting the spell
+	CasterID string
+
+	// Spellcasting is the caster's spellcasting state. Slots and innate uses
+	// are spent from it.
+	Spellcasting *Spellcasting
+
+	// Spell is the spell to cast
+	Spell Spell
+
+	// SlotLevel is the slot to spend. 0 spends the lowest available slot of
+	// at least the spell's level. Ignored for cantrips and innate casting.
+	SlotLevel int
+
+	// Innate casts the spell with an innate use instead of a spell slot
+	Innate bool
+
+	// PactSlot casts the spell with a pact magic slot, at the pact slot level.
+	// Without it, a pact slot is still used when the caster has no spell slot
+	// of the spell's level or higher.
+	PactSlot bool
+
+	// TargetIDs are the creatures the caster targets
+	TargetIDs []string
+
+	// Metamagic is the sorcerer's Metamagic option for this casting (optional).
+	// Quickened turns a 1 action casting time into a bonus action, and Twinned
+	// needs a single-target spell with both targets in TargetIDs.
+	Metamagic Metamagic
+
+	// SorceryPoints pays for Metamagic. Required when Metamagic is set.
+	SorceryPoints *combat.RecoverableResource
+
+	// CastingTime is the action economy cost. Empty means an action.
+	CastingTime coreCombat.ActionType
+
+	// Economy is the caster's action economy. Optional: callers that manage
+	// the economy themselves (such as Monster.TakeTurn) leave it nil.
+	Economy *combat.ActionEconomy
+
+	// EventBus is used to publish the SpellCastEvent
+	EventBus events.EventBus
+}
+
+// Validate validates the input.
+func (c *CastInput) Validate() error {
+	if c == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "CastInput is nil")
+	}
+	if c.CasterID == "" {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "CasterID is required")
+	}
+	if c.Spellcasting == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "Spellcasting is required")
+	}
+	if c.Spell == "" {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "Spell is required")
+	}
+	if c.SlotLevel < 0 || c.SlotLevel > MaxSpellLevel {
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument, "invalid slot level %d", c.SlotLevel)
+	}
+	switch c.CastingTime {
+	case "", coreCombat.ActionStandard, coreCombat.ActionBonus, coreCombat.ActionReaction:
+	default:
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument, "unsupported casting time %s", c.CastingTime)
+	}
+	if c.EventBus == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "EventBus is required")
+	}
+	return nil
+}
+
+// CastResult describes a spell that was cast.
+type CastResult struct {
+	Spell       Spell
+	SpellLevel  int // Spell's base level (0 for cantrips)
+	SlotLevel   int // Slot spent (0 for cantrips and innate casting)
+	Innate      bool
+	PactSlot    bool // SlotLevel is a pact magic slot
+	Metamagic   Metamagic
+	SaveDC      int // Caster's spell save DC after the SpellcastingChain, for save-based effects
+	AttackBonus int // Caster's spell attack bonus after the SpellcastingChain, for spell attacks
+}
+
+// Cast spends the resources for a spell and publishes a SpellCastEvent.
+// This is the one casting entry point for characters and monsters.
+//
+// The spell must be known (cast with a slot, or at will for cantrips) or
+// innate. Metamagic is paid in sorcery points. Cast checks everything before
+// spending anything, so a failed cast costs nothing. The spell's effect is
+// resolved by the caller (an attack, an area save, a condition) using the
+// result's DC and attack bonus.
+func Cast(ctx context.Context, input *CastInput) (*CastResult, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+	caster := input.Spellcasting
+
+	stats, err := ResolveStats(ctx, &StatsInput{
+		CasterID:    input.CasterID,
+		Ability:     caster.Ability(),
+		AttackBonus: caster.AttackBonus(),
+		SaveDC:      caster.SaveDC(),
+		EventBus:    input.EventBus,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CastResult{
+		Spell:       input.Spell,
+		Innate:      input.Innate,
+		SaveDC:      stats.SaveDC(),
+		AttackBonus: stats.AttackBonus(),
+	}
+	data := GetData(input.Spell)
+	if data != nil {
+		result.SpellLevel = data.Level
+	}
+
+	// Work out the resource before touching anything
+	switch {
+	case input.Innate:
+		if remaining := caster.InnateRemaining(input.Spell); remaining == 0 {
+			if !caster.HasInnate(input.Spell) {
+				return nil, rpgerr.Newf(rpgerr.CodeNotFound, "%s can't cast %s innately", input.CasterID, input.Spell)
+			}
+			return nil, rpgerr.ResourceExhausted(input.Spell + " innate uses")
+		}
+	case !caster.Knows(input.Spell):
+		return nil, rpgerr.Newf(rpgerr.CodeNotFound, "%s doesn't know %s", input.CasterID, input.Spell)
+	case result.SpellLevel > 0 && usesPactSlot(input, result.SpellLevel):
+		slotLevel, err := pactSlotLevel(input, result.SpellLevel)
+		if err != nil {
+			return nil, err
+		}
+		result.SlotLevel = slotLevel
+		result.PactSlot = true
+	case result.SpellLevel > 0:
+		slotLevel := input.SlotLevel
+		if slotLevel == 0 {
+			slotLevel = caster.LowestSlot(result.SpellLevel)
+			if slotLevel == 0 {
+				return nil, rpgerr.ResourceExhaustedf("%s has no spell slot of level %d or higher",
+					input.CasterID, result.SpellLevel)
+			}
+		}
+		if slotLevel < result.SpellLevel {
+			return nil, rpgerr.Newf(rpgerr.CodeInvalidArgument, "%s needs a slot of level %d or higher, got %d",
+				input.Spell, result.SpellLevel, slotLevel)
+		}
+		if caster.SlotsRemaining(slotLevel) <= 0 {
+			return nil, rpgerr.ResourceExhaustedf("%s has no level %d spell slots", input.CasterID, slotLevel)
+		}
+		result.SlotLevel = slotLevel
+	}
+
+	castingTime := input.CastingTime
+	var metamagicCost int
+	if input.Metamagic != "" {
+		cost, err := checkMetamagic(input, data)
+		if err != nil {
+			return nil, err
+		}
+		metamagicCost = cost
+		result.Metamagic = input.Metamagic
+		if input.Metamagic == MetamagicQuickened {
+			castingTime = coreCombat.ActionBonus
+		}
+	}
+
+	if input.Economy != nil {
+		if err := checkCastingTime(input.Economy, castingTime); err != nil {
+			return nil, err
+		}
+	}
+
+	// Spend
+	switch {
+	case input.Innate:
+		if err := caster.useInnate(input.Spell); err != nil {
+			return nil, err
+		}
+	case result.PactSlot:
+		if err := caster.usePactSlot(); err != nil {
+			return nil, err
+		}
+	case result.SlotLevel > 0:
+		if err := caster.useSlot(result.SlotLevel); err != nil {
+			return nil, err
+		}
+	}
+	if metamagicCost > 0 {
+		if err := input.SorceryPoints.Use(metamagicCost); err != nil {
+			return nil, rpgerr.Wrap(err, "failed to spend sorcery points")
+		}
+	}
+	if input.Economy != nil {
+		if err := useCastingTime(input.Economy, castingTime); err != nil {
+			return nil, err
+		}
+	}
+
+	err = dnd5eEvents.SpellCastTopic.On(input.EventBus).Publish(ctx, dnd5eEvents.SpellCastEvent{
+		CasterID:   input.CasterID,
+		SpellID:    input.Spell,
+		SpellLevel: result.SpellLevel,
+		SlotLevel:  result.SlotLevel,
+		Innate:     result.Innate,
+		Metamagic:  string(result.Metamagic),
+		TargetIDs:  input.TargetIDs,
+	})
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to publish spell cast event")
+	}
+
+	return result, nil
+}
+
+// usesPactSlot reports whether a leveled spell is cast with a pact magic slot:
+// asked for, or the only slot that can cast it.
+func usesPactSlot(input *CastInput, spellLevel int) bool {
+	if input.PactSlot {
+		return true
+	}
+	caster := input.Spellcasting
+	return input.SlotLevel == 0 && caster.LowestSlot(spellLevel) == 0 &&
+		caster.PactSlotsRemaining() > 0 && caster.PactSlotLevel() >= spellLevel
+}
+
+// pactSlotLevel returns the pact slot level a spell is cast at, or why it can't be.
+func pactSlotLevel(input *CastInput, spellLevel int) (int, error) {
+	caster := input.Spellcasting
+	if !caster.HasPactMagic() {
+		return 0, rpgerr.Newf(rpgerr.CodeInvalidArgument, "%s has no pact magic", input.CasterID)
+	}
+	slotLevel := caster.PactSlotLevel()
+	if input.SlotLevel != 0 && input.SlotLevel != slotLevel {
+		return 0, rpgerr.Newf(rpgerr.CodeInvalidArgument, "pact slots are level %d, got %d", slotLevel, input.SlotLevel)
+	}
+	if slotLevel < spellLevel {
+		return 0, rpgerr.Newf(rpgerr.CodeInvalidArgument, "%s needs a slot of level %d or higher, pact slots are level %d",
+			input.Spell, spellLevel, slotLevel)
+	}
+	if caster.PactSlotsRemaining() <= 0 {
+		return 0, rpgerr.ResourceExhaustedf("%s has no pact magic slots", input.CasterID)
+	}
+	return slotLevel, nil
+}
+
+// checkCastingTime returns why the economy can't pay the casting time.
+func checkCastingTime(economy *combat.ActionEconomy, castingTime coreCombat.ActionType) error {
+	if err := economy.CheckSpellcasting(); err != nil {
+		return err
+	}
+	switch castingTime {
+	case coreCombat.ActionBonus:
+		return economy.CheckBonusAction()
+	case coreCombat.ActionReaction:
+		return economy.CheckReaction()
+	default:
+		return economy.CheckAction()
+	}
+}
+
+// useCastingTime spends the casting time from the economy.
+func useCastingTime(economy *combat.ActionEconomy, castingTime coreCombat.ActionType) error {
+	switch castingTime {
+	case coreCombat.ActionBonus:
+		return economy.UseBonusAction()
+	case coreCombat.ActionReaction:
+		return economy.UseReaction()
+	default:
+		return economy.UseAction()
+	}
+}
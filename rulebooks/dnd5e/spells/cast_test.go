@@ -0,0 +1,204 @@
+package spells
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	coreCombat "github.com/KirkDiggler/rpg-toolkit/core/combat"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+)
+
+// invisibility isn't in SpellData; innate spells don't need to be
+const invisibility Spell = "invisibility"
+
+type CastTestSuite struct {
+	suite.Suite
+	ctx     context.Context
+	bus     events.EventBus
+	mage    *Spellcasting
+	casts   []dnd5eEvents.SpellCastEvent
+	economy *combat.ActionEconomy
+}
+
+func TestCastSuite(t *testing.T) {
+	suite.Run(t, new(CastTestSuite))
+}
+
+func (s *CastTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.economy = combat.NewActionEconomy()
+	s.casts = nil
+
+	var err error
+	s.mage, err = NewSpellcasting(&SpellcastingData{
+		Ability:     abilities.INT,
+		SaveDC:      14,
+		AttackBonus: 6,
+		Known:       []Spell{FireBolt, MagicMissile, Shield, Fireball},
+		Slots: map[int]SlotData{
+			1: {Max: 2},
+			3: {Max: 1},
+		},
+		Innate: map[Spell]InnateData{
+			MageHand:     {},
+			invisibility: {PerDay: 1},
+		},
+	})
+	s.Require().NoError(err)
+
+	_, err = dnd5eEvents.SpellCastTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, event dnd5eEvents.SpellCastEvent) error {
+			s.casts = append(s.casts, event)
+			return nil
+		})
+	s.Require().NoError(err)
+}
+
+func (s *CastTestSuite) SetupSubTest() {
+	s.SetupTest()
+}
+
+func (s *CastTestSuite) cast(input CastInput) (*CastResult, error) {
+	input.CasterID = "mage-1"
+	input.Spellcasting = s.mage
+	input.EventBus = s.bus
+	return Cast(s.ctx, &input)
+}
+
+func (s *CastTestSuite) TestCantripIsFree() {
+	result, err := s.cast(CastInput{Spell: FireBolt, TargetIDs: []string{"hero-1"}, Economy: s.economy})
+	s.Require().NoError(err)
+	s.Equal(0, result.SlotLevel)
+	s.Equal(6, result.AttackBonus)
+	s.Equal(14, result.SaveDC)
+	s.Equal(2, s.mage.SlotsRemaining(1))
+	s.Equal(0, s.economy.ActionsRemaining)
+
+	s.Equal([]dnd5eEvents.SpellCastEvent{
+		{CasterID: "mage-1", SpellID: FireBolt, TargetIDs: []string{"hero-1"}},
+	}, s.casts)
+}
+
+func (s *CastTestSuite) TestSlotSelection() {
+	s.Run("lowest slot", func() {
+		result, err := s.cast(CastInput{Spell: MagicMissile})
+		s.Require().NoError(err)
+		s.Equal(1, result.SpellLevel)
+		s.Equal(1, result.SlotLevel)
+		s.Equal(1, s.mage.SlotsRemaining(1))
+	})
+
+	s.Run("upcast", func() {
+		result, err := s.cast(CastInput{Spell: MagicMissile, SlotLevel: 3})
+		s.Require().NoError(err)
+		s.Equal(3, result.SlotLevel)
+		s.Equal(0, s.mage.SlotsRemaining(3))
+		s.Equal(3, s.casts[0].SlotLevel)
+	})
+
+	s.Run("falls back to a higher slot", func() {
+		_, err := s.cast(CastInput{Spell: MagicMissile})
+		s.Require().NoError(err)
+		_, err = s.cast(CastInput{Spell: MagicMissile})
+		s.Require().NoError(err)
+
+		result, err := s.cast(CastInput{Spell: MagicMissile})
+		s.Require().NoError(err)
+		s.Equal(3, result.SlotLevel)
+
+		_, err = s.cast(CastInput{Spell: MagicMissile})
+		s.Equal(rpgerr.CodeResourceExhausted, rpgerr.GetCode(err))
+	})
+
+	s.Run("slot below spell level", func() {
+		_, err := s.cast(CastInput{Spell: Fireball, SlotLevel: 1})
+		s.Equal(rpgerr.CodeInvalidArgument, rpgerr.GetCode(err))
+		s.Equal(2, s.mage.SlotsRemaining(1))
+	})
+}
+
+func (s *CastTestSuite) TestInnate() {
+	s.Run("at will", func() {
+		for range 3 {
+			_, err := s.cast(CastInput{Spell: MageHand, Innate: true})
+			s.Require().NoError(err)
+		}
+		s.Equal(-1, s.mage.InnateRemaining(MageHand))
+	})
+
+	s.Run("per day", func() {
+		result, err := s.cast(CastInput{Spell: invisibility, Innate: true})
+		s.Require().NoError(err)
+		s.True(result.Innate)
+		s.Equal(0, result.SlotLevel)
+		s.Equal(0, s.mage.InnateRemaining(invisibility))
+
+		_, err = s.cast(CastInput{Spell: invisibility, Innate: true})
+		s.Equal(rpgerr.CodeResourceExhausted, rpgerr.GetCode(err))
+
+		s.mage.LongRest()
+		s.Equal(1, s.mage.InnateRemaining(invisibility))
+	})
+
+	s.Run("not innate", func() {
+		_, err := s.cast(CastInput{Spell: FireBolt, Innate: true})
+		s.Equal(rpgerr.CodeNotFound, rpgerr.GetCode(err))
+	})
+}
+
+func (s *CastTestSuite) TestFailedCastCostsNothing() {
+	s.Require().NoError(s.economy.UseAction())
+
+	_, err := s.cast(CastInput{Spell: MagicMissile, Economy: s.economy})
+	s.Equal(rpgerr.CodeResourceExhausted, rpgerr.GetCode(err))
+	s.Equal(2, s.mage.SlotsRemaining(1))
+	s.Empty(s.casts)
+
+	// Shield is a reaction
+	_, err = s.cast(CastInput{Spell: Shield, CastingTime: coreCombat.ActionReaction, Economy: s.economy})
+	s.Require().NoError(err)
+	s.Equal(0, s.economy.ReactionsRemaining)
+
+	_, err = s.cast(CastInput{Spell: Sleep})
+	s.Equal(rpgerr.CodeNotFound, rpgerr.GetCode(err))
+}
+
+func (s *CastTestSuite) TestDataRoundTrip() {
+	_, err := s.cast(CastInput{Spell: MagicMissile})
+	s.Require().NoError(err)
+
+	raw, err := json.Marshal(s.mage.ToData())
+	s.Require().NoError(err)
+
+	var data SpellcastingData
+	s.Require().NoError(json.Unmarshal(raw, &data))
+	loaded, err := NewSpellcasting(&data)
+	s.Require().NoError(err)
+
+	s.Equal(s.mage.ToData(), loaded.ToData())
+	s.Equal(1, loaded.SlotsRemaining(1))
+	s.True(loaded.Knows(Fireball))
+	s.True(loaded.HasInnate(invisibility))
+}
+
+func (s *CastTestSuite) TestValidate() {
+	_, err := NewSpellcasting(&SpellcastingData{Known: []Spell{"wish-upon-a-star"}})
+	s.Error(err)
+
+	_, err = NewSpellcasting(&SpellcastingData{Slots: map[int]SlotData{10: {Max: 1}}})
+	s.Error(err)
+
+	_, err = NewSpellcasting(&SpellcastingData{Slots: map[int]SlotData{1: {Max: 1, Used: 2}}})
+	s.Error(err)
+
+	_, err = Cast(s.ctx, &CastInput{CasterID: "mage-1", Spellcasting: s.mage, Spell: FireBolt})
+	s.Error(err, "event bus is required")
+}
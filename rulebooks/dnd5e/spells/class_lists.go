@@ -0,0 +1,238 @@
+package spells
+
+import (
+	"slices"
+
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/classes"
+)
+
+// classSpellLists maps each spellcasting class to its spell list by spell
+// level, with cantrips at level 0. Only spells with constants are listed.
+var classSpellLists = map[classes.Class]map[int][]Spell{
+	classes.Bard: {
+		0: {
+			BladeWard, DancingLights, Friends, Light, MageHand, Mending, Message,
+			MinorIllusion, Prestidigitation, TrueStrike, ViciousMockery,
+		},
+		1: {
+			AnimalFriendship, Bane, CharmPerson, ComprehendLanguages, CureWounds, DetectMagic,
+			DisguiseSelf, DistortValue, EarthTremor, FaerieFire, FeatherFall, HealingWord,
+			Heroism, HideousLaughter, Identify, IllusoryScript, Longstrider, SilentImage,
+			Sleep, SpeakWithAnimals, Thunderwave, UnseenServant,
+		},
+		2: {
+			BlindnessDeafness, CloudOfDaggers, HoldPerson, LesserRestoration, Shatter, Suggestion,
+		},
+		3: {DispelMagic, Nondetection, PlantGrowth, SpeakWithDead},
+		4: {Confusion, DimensionDoor, FreedomOfMovement, Polymorph},
+		5: {DominatePerson, HoldMonster, LegendLore, MassCureWounds, ModifyMemory, RaiseDead, Scrying},
+	},
+	classes.Cleric: {
+		0: {Guidance, Light, Resistance, SacredFlame, SpareTheDying, Thaumaturgy, TollTheDead, WordOfRadiance},
+		1: {
+			Bane, Bless, Command, CureWounds, DetectMagic, GuidingBolt, HealingWord,
+			InflictWounds, ProtectionEvil, PurifyFood, ShieldOfFaith,
+		},
+		2: {Augury, BlindnessDeafness, HoldPerson, LesserRestoration, SpiritualWeapon},
+		3: {AnimateDead, BeaconOfHope, Daylight, DispelMagic, Revivify, SpeakWithDead, SpiritGuardians},
+		4: {ControlWater, DeathWard, FreedomOfMovement, GuardianOfFaith},
+		5: {FlameStrike, InsectPlague, LegendLore, MassCureWounds, RaiseDead, Scrying},
+	},
+	classes.Druid: {
+		0: {
+			ControlFlames, CreateBonfire, Druidcraft, Frostbite, Guidance, GustWind, Infestation,
+			MagicStone, MoldEarth, PoisonSpray, PrimalSavagery, Resistance, ShapeWater, Thornwhip,
+		},
+		1: {
+			AbsorbElements, AnimalFriendship, BeastBond, CharmPerson, CureWounds, DetectMagic,
+			EarthTremor, Entangle, FaerieFire, FogCloud, GoodBerry, HealingWord, IceKnife,
+			JumpSpell, Longstrider, PurifyFood, SpeakWithAnimals, Thunderwave,
+		},
+		2: {
+			Barkskin, FlamingSphere, GustOfWind, HoldPerson, LesserRestoration, Moonbeam,
+			PassWithoutTrace, SpikeGrowth,
+		},
+		3: {CallLightning, Daylight, DispelMagic, PlantGrowth, SleetStorm, WindWall},
+		4: {
+			Blight, Confusion, ControlWater, DominateBeast, FreedomOfMovement, GraspingVine,
+			IceStorm, Polymorph, Stoneskin, WallOfFire,
+		},
+		5: {AntiLifeShell, InsectPlague, MassCureWounds, Scrying, TreeStride},
+	},
+	classes.Paladin: {
+		1: {
+			Bless, Command, CureWounds, DetectMagic, DivineFavor, ProtectionEvil, PurifyFood,
+			SearingSmite, ShieldOfFaith, ThunderousSmite, WrathfulSmite,
+		},
+		2: {LesserRestoration, MagicWeapon},
+		3: {CrusadersMantle, Daylight, DispelMagic, Revivify},
+		4: {DeathWard},
+		5: {DestructiveWave, RaiseDead},
+	},
+	classes.Ranger: {
+		1: {
+			AbsorbElements, AnimalFriendship, BeastBond, CureWounds, DetectMagic, EnsnaringStrike,
+			FogCloud, GoodBerry, HailOfThorns, JumpSpell, Longstrider, SpeakWithAnimals,
+		},
+		2: {Barkskin, LesserRestoration, PassWithoutTrace, SpikeGrowth},
+		3: {Daylight, Nondetection, PlantGrowth, WindWall},
+		4: {FreedomOfMovement, GraspingVine, Stoneskin},
+		5: {TreeStride},
+	},
+	classes.Sorcerer: {
+		0: {
+			AcidSplash, BladeWard, BoomingBlade, ChillTouch, ControlFlames, CreateBonfire,
+			DancingLights, FireBolt, Friends, Frostbite, GreenFlameBlade, GustWind, Infestation,
+			Light, MageHand, Mending, Message, MinorIllusion, MoldEarth, PoisonSpray,
+			Prestidigitation, RayOfFrost, ShapeWater, ShockingGrasp, SwordBurst, TrueStrike,
+		},
+		1: {
+			AbsorbElements, BurningHands, CatapultSpell, CauseFear, CharmPerson, ChromaticOrb,
+			ColorSpray, ComprehendLanguages, DetectMagic, DisguiseSelf, DistortValue, EarthTremor,
+			ExpeditiousRetreat, FalseLife, FeatherFall, FogCloud, IceKnife, JumpSpell,
+			MagicMissile, RayOfSickness, Shield, SilentImage, Sleep, Thunderwave, WitchBolt,
+		},
+		2: {
+			AganazzarsScorcher, BlindnessDeafness, CloudOfDaggers, GustOfWind, HoldPerson,
+			MirrorImage, MistyStep, ScorchingRay, Shatter, Suggestion,
+		},
+		3: {Blink, Daylight, DispelMagic, Fireball, LightningBolt, SleetStorm},
+		4: {Blight, Confusion, DimensionDoor, DominateBeast, IceStorm, Polymorph, Stoneskin, WallOfFire},
+		5: {Cloudkill, DominatePerson, HoldMonster, InsectPlague},
+	},
+	classes.Warlock: {
+		0: {
+			BladeWard, BoomingBlade, ChillTouch, CreateBonfire, EldritchBlast, Friends, Frostbite,
+			GreenFlameBlade, Infestation, MageHand, MagicStone, MinorIllusion, PoisonSpray,
+			Prestidigitation, SwordBurst, TollTheDead, TrueStrike,
+		},
+		1: {
+			ArmsOfHadar, CauseFear, CharmPerson, ComprehendLanguages, DistortValue,
+			ExpeditiousRetreat, HellishRebuke, Hex, IllusoryScript, ProtectionEvil,
+			UnseenServant, WitchBolt,
+		},
+		2: {CloudOfDaggers, HoldPerson, MirrorImage, MistyStep, RayOfEnfeeblement, Shatter, Suggestion},
+		3: {DispelMagic, VampiricTouch},
+		4: {Blight, DimensionDoor},
+		5: {HoldMonster, Scrying},
+	},
+	classes.Wizard: {
+		0: {
+			AcidSplash, BladeWard, BoomingBlade, ChillTouch, ControlFlames, CreateBonfire,
+			DancingLights, FireBolt, Friends, Frostbite, GreenFlameBlade, GustWind, Infestation,
+			Light, MageHand, Mending, Message, MinorIllusion, MoldEarth, PoisonSpray,
+			Prestidigitation, RayOfFrost, ShapeWater, ShockingGrasp, SwordBurst, TollTheDead,
+			TrueStrike,
+		},
+		1: {
+			AbsorbElements, BurningHands, CatapultSpell, CauseFear, CharmPerson, ChromaticOrb,
+			ColorSpray, ComprehendLanguages, DetectMagic, DisguiseSelf, DistortValue, EarthTremor,
+			ExpeditiousRetreat, FalseLife, FeatherFall, FogCloud, HideousLaughter, IceKnife,
+			Identify, IllusoryScript, JumpSpell, Longstrider, MagicMissile, ProtectionEvil,
+			RayOfSickness, Shield, SilentImage, Sleep, Thunderwave, UnseenServant, WitchBolt,
+		},
+		2: {
+			AganazzarsScorcher, BlindnessDeafness, CloudOfDaggers, FlamingSphere, GustOfWind,
+			HoldPerson, MagicWeapon, MelfsAcidArrow, MirrorImage, MistyStep, RayOfEnfeeblement,
+			ScorchingRay, Shatter, Suggestion,
+		},
+		3: {AnimateDead, Blink, DispelMagic, Fireball, LightningBolt, Nondetection, SleetStorm, VampiricTouch},
+		4: {
+			ArcaneEye, Blight, Confusion, ControlWater, DimensionDoor, IceStorm, Polymorph,
+			Stoneskin, WallOfFire,
+		},
+		5: {Cloudkill, DominatePerson, HoldMonster, LegendLore, ModifyMemory, Scrying},
+	},
+}
+
+// patronSpells maps each warlock patron to its expanded spell list by spell
+// level. Only spells with constants are listed.
+var patronSpells = map[classes.Subclass]map[int][]Spell{
+	classes.Archfey: {
+		1: {FaerieFire, Sleep},
+		3: {Blink, PlantGrowth},
+		4: {DominateBeast},
+		5: {DominatePerson},
+	},
+	classes.Fiend: {
+		1: {BurningHands, Command},
+		2: {BlindnessDeafness, ScorchingRay},
+		3: {Fireball},
+		4: {WallOfFire},
+		5: {FlameStrike},
+	},
+	classes.GreatOldOne: {
+		1: {HideousLaughter},
+		4: {DominateBeast},
+		5: {DominatePerson},
+	},
+}
+
+// ClassSpells returns the spells of a level on a class's spell list. Level 0
+// returns the class's cantrips. Returns nil for classes without spellcasting.
+func ClassSpells(classID classes.Class, level int) []Spell {
+	return classSpellLists[classID][level]
+}
+
+// PatronSpells returns the spells a warlock patron adds to the warlock spell
+// list, up to the given spell level. Unlike domain spells they aren't known
+// automatically; the warlock may choose them when learning spells.
+func PatronSpells(patron classes.Subclass, maxSpellLevel int) []Spell {
+	byLevel := patronSpells[patron]
+
+	var result []Spell
+	for level := 1; level <= maxSpellLevel; level++ {
+		result = append(result, byLevel[level]...)
+	}
+	return result
+}
+
+// OnSpellList returns true if a spell is on a class's spell list, including
+// the spells its subclass adds: a warlock patron's expanded spells or a
+// cleric domain's spells. Pass an empty subclass to check the class list only.
+func OnSpellList(classID classes.Class, subclass classes.Subclass, spell Spell) bool {
+	for _, list := range classSpellLists[classID] {
+		if slices.Contains(list, spell) {
+			return true
+		}
+	}
+
+	switch classID {
+	case classes.Warlock:
+		return slices.Contains(PatronSpells(subclass, MaxSpellLevel), spell)
+	case classes.Cleric:
+		return slices.Contains(DomainSpells(subclass, 20), spell)
+	default:
+		return false
+	}
+}
+
+// Level returns the level of a spell, 0 for cantrips. Returns false for
+// spells with neither spell data nor a place on a spell list.
+func Level(spell Spell) (int, bool) {
+	if data := GetData(spell); data != nil {
+		return data.Level, true
+	}
+
+	for _, byLevel := range classSpellLists {
+		if level, ok := listLevel(byLevel, spell); ok {
+			return level, true
+		}
+	}
+	for _, byLevel := range patronSpells {
+		if level, ok := listLevel(byLevel, spell); ok {
+			return level, true
+		}
+	}
+	return 0, false
+}
+
+// listLevel returns the level a spell is listed at in a list by spell level
+func listLevel(byLevel map[int][]Spell, spell Spell) (int, bool) {
+	for level, list := range byLevel {
+		if slices.Contains(list, spell) {
+			return level, true
+		}
+	}
+	return 0, false
+}
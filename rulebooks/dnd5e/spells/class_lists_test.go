@@ -0,0 +1,79 @@
+package spells
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/classes"
+)
+
+// ClassListsTestSuite tests class spell lists and their subclass additions
+type ClassListsTestSuite struct {
+	suite.Suite
+}
+
+func TestClassListsSuite(t *testing.T) {
+	suite.Run(t, new(ClassListsTestSuite))
+}
+
+func (s *ClassListsTestSuite) TestOnSpellList() {
+	testCases := []struct {
+		name     string
+		class    classes.Class
+		subclass classes.Subclass
+		spell    Spell
+		onList   bool
+	}{
+		{"wizard fireball", classes.Wizard, "", Fireball, true},
+		{"wizard cure wounds", classes.Wizard, "", CureWounds, false},
+		{"cleric cure wounds", classes.Cleric, "", CureWounds, true},
+		{"warlock burning hands", classes.Warlock, "", BurningHands, false},
+		{"fiend warlock burning hands", classes.Warlock, classes.Fiend, BurningHands, true},
+		{"archfey warlock burning hands", classes.Warlock, classes.Archfey, BurningHands, false},
+		{"cleric burning hands", classes.Cleric, "", BurningHands, false},
+		{"light cleric burning hands", classes.Cleric, classes.LightDomain, BurningHands, true},
+		{"fighter", classes.Fighter, "", MagicMissile, false},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.Equal(tc.onList, OnSpellList(tc.class, tc.subclass, tc.spell))
+		})
+	}
+}
+
+func (s *ClassListsTestSuite) TestPatronSpellsByLevel() {
+	s.Equal([]Spell{BurningHands, Command}, PatronSpells(classes.Fiend, 1))
+	s.Equal([]Spell{BurningHands, Command, BlindnessDeafness, ScorchingRay, Fireball}, PatronSpells(classes.Fiend, 3))
+	s.Empty(PatronSpells(classes.LifeDomain, 5))
+}
+
+func (s *ClassListsTestSuite) TestLevel() {
+	level, ok := Level(Fireball)
+	s.True(ok)
+	s.Equal(3, level)
+
+	level, ok = Level(Suggestion)
+	s.True(ok, "spells without data are found on the class lists")
+	s.Equal(2, level)
+
+	level, ok = Level(Druidcraft)
+	s.True(ok)
+	s.Equal(0, level)
+
+	_, ok = Level("wish")
+	s.False(ok)
+}
+
+func (s *ClassListsTestSuite) TestListsAgreeWithSpellData() {
+	for classID, byLevel := range classSpellLists {
+		for level, list := range byLevel {
+			for _, spell := range list {
+				if data := GetData(spell); data != nil {
+					s.Equal(data.Level, level, "%s lists %s at level %d", classID, spell, level)
+				}
+			}
+		}
+	}
+}
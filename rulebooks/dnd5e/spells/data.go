@@ -2,10 +2,12 @@ package spells
 
 // Data contains all the game mechanics data for a spell
 type Data struct {
-	ID          Spell  // The spell this data represents
-	Level       int    // 0 for cantrips, 1-9 for leveled spells
-	Name        string // Display name
-	Description string // Brief description of the spell's effect
+	ID           Spell  // The spell this data represents
+	Level        int    // 0 for cantrips, 1-9 for leveled spells
+	SingleTarget bool   // Targets one creature other than the caster (Twinned Spell)
+	ScalesByBeam bool   // Cantrip gains beams rather than damage dice as the caster levels (Eldritch Blast)
+	Name         string // Display name
+	Description  string // Brief description of the spell's effect
 }
 
 // SpellData is the lookup map for all spell data
@@ -13,22 +15,25 @@ type Data struct {
 var SpellData = map[Spell]*Data{
 	// Cantrips (Level 0)
 	FireBolt: {
-		ID:          FireBolt,
-		Level:       0,
-		Name:        "Fire Bolt",
-		Description: "Hurl a mote of fire at a creature or object (1d10 fire damage)",
+		ID:           FireBolt,
+		Level:        0,
+		SingleTarget: true,
+		Name:         "Fire Bolt",
+		Description:  "Hurl a mote of fire at a creature or object (1d10 fire damage)",
 	},
 	RayOfFrost: {
-		ID:          RayOfFrost,
-		Level:       0,
-		Name:        "Ray of Frost",
-		Description: "A frigid beam that deals 1d8 cold damage and reduces speed by 10 feet",
+		ID:           RayOfFrost,
+		Level:        0,
+		SingleTarget: true,
+		Name:         "Ray of Frost",
+		Description:  "A frigid beam that deals 1d8 cold damage and reduces speed by 10 feet",
 	},
 	ShockingGrasp: {
-		ID:          ShockingGrasp,
-		Level:       0,
-		Name:        "Shocking Grasp",
-		Description: "Lightning springs from your hand dealing 1d8 lightning damage, advantage vs metal armor",
+		ID:           ShockingGrasp,
+		Level:        0,
+		SingleTarget: true,
+		Name:         "Shocking Grasp",
+		Description:  "Lightning springs from your hand dealing 1d8 lightning damage, advantage vs metal armor",
 	},
 	AcidSplash: {
 		ID:          AcidSplash,
@@ -37,28 +42,32 @@ var SpellData = map[Spell]*Data{
 		Description: "Hurl a bubble of acid at creatures for 1d6 acid damage",
 	},
 	PoisonSpray: {
-		ID:          PoisonSpray,
-		Level:       0,
-		Name:        "Poison Spray",
-		Description: "Project a puff of noxious gas dealing 1d12 poison damage",
+		ID:           PoisonSpray,
+		Level:        0,
+		SingleTarget: true,
+		Name:         "Poison Spray",
+		Description:  "Project a puff of noxious gas dealing 1d12 poison damage",
 	},
 	ChillTouch: {
-		ID:          ChillTouch,
-		Level:       0,
-		Name:        "Chill Touch",
-		Description: "Assail with necrotic energy for 1d8 damage and prevent healing",
+		ID:           ChillTouch,
+		Level:        0,
+		SingleTarget: true,
+		Name:         "Chill Touch",
+		Description:  "Assail with necrotic energy for 1d8 damage and prevent healing",
 	},
 	SacredFlame: {
-		ID:          SacredFlame,
-		Level:       0,
-		Name:        "Sacred Flame",
-		Description: "Flame-like radiance descends for 1d8 radiant damage",
+		ID:           SacredFlame,
+		Level:        0,
+		SingleTarget: true,
+		Name:         "Sacred Flame",
+		Description:  "Flame-like radiance descends for 1d8 radiant damage",
 	},
 	TollTheDead: {
-		ID:          TollTheDead,
-		Level:       0,
-		Name:        "Toll the Dead",
-		Description: "Point at a creature and sound a dolorous bell for 1d8/1d12 necrotic damage",
+		ID:           TollTheDead,
+		Level:        0,
+		SingleTarget: true,
+		Name:         "Toll the Dead",
+		Description:  "Point at a creature and sound a dolorous bell for 1d8/1d12 necrotic damage",
 	},
 	WordOfRadiance: {
 		ID:          WordOfRadiance,
@@ -67,16 +76,18 @@ var SpellData = map[Spell]*Data{
 		Description: "Burning radiance erupts from you for 1d6 radiant damage to nearby enemies",
 	},
 	EldritchBlast: {
-		ID:          EldritchBlast,
-		Level:       0,
-		Name:        "Eldritch Blast",
-		Description: "A beam of crackling energy streaks toward a foe for 1d10 force damage",
+		ID:           EldritchBlast,
+		Level:        0,
+		ScalesByBeam: true,
+		Name:         "Eldritch Blast",
+		Description:  "A beam of crackling energy streaks toward a foe for 1d10 force damage",
 	},
 	Frostbite: {
-		ID:          Frostbite,
-		Level:       0,
-		Name:        "Frostbite",
-		Description: "Cause numbing frost for 1d6 cold damage and disadvantage on next weapon attack",
+		ID:           Frostbite,
+		Level:        0,
+		SingleTarget: true,
+		Name:         "Frostbite",
+		Description:  "Cause numbing frost for 1d6 cold damage and disadvantage on next weapon attack",
 	},
 	PrimalSavagery: {
 		ID:          PrimalSavagery,
@@ -85,10 +96,11 @@ var SpellData = map[Spell]*Data{
 		Description: "Your teeth or nails sharpen for a 1d10 acid damage melee attack",
 	},
 	Thornwhip: {
-		ID:          Thornwhip,
-		Level:       0,
-		Name:        "Thorn Whip",
-		Description: "A vine-like whip deals 1d6 piercing damage and pulls the target closer",
+		ID:           Thornwhip,
+		Level:        0,
+		SingleTarget: true,
+		Name:         "Thorn Whip",
+		Description:  "A vine-like whip deals 1d6 piercing damage and pulls the target closer",
 	},
 	MageHand: {
 		ID:          MageHand,
@@ -115,16 +127,18 @@ var SpellData = map[Spell]*Data{
 		Description: "Touch an object to make it shed bright light",
 	},
 	Guidance: {
-		ID:          Guidance,
-		Level:       0,
-		Name:        "Guidance",
-		Description: "Touch a willing creature to add 1d4 to one ability check",
+		ID:           Guidance,
+		Level:        0,
+		SingleTarget: true,
+		Name:         "Guidance",
+		Description:  "Touch a willing creature to add 1d4 to one ability check",
 	},
 	Resistance: {
-		ID:          Resistance,
-		Level:       0,
-		Name:        "Resistance",
-		Description: "Touch a willing creature to add 1d4 to one saving throw",
+		ID:           Resistance,
+		Level:        0,
+		SingleTarget: true,
+		Name:         "Resistance",
+		Description:  "Touch a willing creature to add 1d4 to one saving throw",
 	},
 	Thaumaturgy: {
 		ID:          Thaumaturgy,
@@ -133,10 +147,11 @@ var SpellData = map[Spell]*Data{
 		Description: "Manifest minor wonders that show supernatural power",
 	},
 	SpareTheDying: {
-		ID:          SpareTheDying,
-		Level:       0,
-		Name:        "Spare the Dying",
-		Description: "Stabilize a dying creature",
+		ID:           SpareTheDying,
+		Level:        0,
+		SingleTarget: true,
+		Name:         "Spare the Dying",
+		Description:  "Stabilize a dying creature",
 	},
 
 	// Level 1 Spells
@@ -153,10 +168,11 @@ var SpellData = map[Spell]*Data{
 		Description: "Cone of fire from your hands deals 3d6 fire damage",
 	},
 	ChromaticOrb: {
-		ID:          ChromaticOrb,
-		Level:       1,
-		Name:        "Chromatic Orb",
-		Description: "Hurl a sphere of energy dealing 3d8 damage of a chosen type",
+		ID:           ChromaticOrb,
+		Level:        1,
+		SingleTarget: true,
+		Name:         "Chromatic Orb",
+		Description:  "Hurl a sphere of energy dealing 3d8 damage of a chosen type",
 	},
 	Thunderwave: {
 		ID:          Thunderwave,
@@ -171,22 +187,25 @@ var SpellData = map[Spell]*Data{
 		Description: "Create a shard of ice that deals 1d10 piercing then explodes for 2d6 cold",
 	},
 	WitchBolt: {
-		ID:          WitchBolt,
-		Level:       1,
-		Name:        "Witch Bolt",
-		Description: "A beam of crackling energy deals 1d12 lightning damage with sustained arc",
+		ID:           WitchBolt,
+		Level:        1,
+		SingleTarget: true,
+		Name:         "Witch Bolt",
+		Description:  "A beam of crackling energy deals 1d12 lightning damage with sustained arc",
 	},
 	GuidingBolt: {
-		ID:          GuidingBolt,
-		Level:       1,
-		Name:        "Guiding Bolt",
-		Description: "A flash of light deals 4d6 radiant damage and grants advantage on next attack",
+		ID:           GuidingBolt,
+		Level:        1,
+		SingleTarget: true,
+		Name:         "Guiding Bolt",
+		Description:  "A flash of light deals 4d6 radiant damage and grants advantage on next attack",
 	},
 	InflictWounds: {
-		ID:          InflictWounds,
-		Level:       1,
-		Name:        "Inflict Wounds",
-		Description: "Touch deals 3d10 necrotic damage to a creature",
+		ID:           InflictWounds,
+		Level:        1,
+		SingleTarget: true,
+		Name:         "Inflict Wounds",
+		Description:  "Touch deals 3d10 necrotic damage to a creature",
 	},
 	HailOfThorns: {
 		ID:          HailOfThorns,
@@ -201,10 +220,11 @@ var SpellData = map[Spell]*Data{
 		Description: "Your next weapon hit entangles the target with thorny vines",
 	},
 	HellishRebuke: {
-		ID:          HellishRebuke,
-		Level:       1,
-		Name:        "Hellish Rebuke",
-		Description: "Reactively engulf attacker in flames for 2d10 fire damage",
+		ID:           HellishRebuke,
+		Level:        1,
+		SingleTarget: true,
+		Name:         "Hellish Rebuke",
+		Description:  "Reactively engulf attacker in flames for 2d10 fire damage",
 	},
 	ArmsOfHadar: {
 		ID:          ArmsOfHadar,
@@ -213,10 +233,11 @@ var SpellData = map[Spell]*Data{
 		Description: "Dark tendrils erupt for 2d6 necrotic damage and prevent reactions",
 	},
 	Hex: {
-		ID:          Hex,
-		Level:       1,
-		Name:        "Hex",
-		Description: "Curse a target for extra 1d6 necrotic damage and disadvantage on ability checks",
+		ID:           Hex,
+		Level:        1,
+		SingleTarget: true,
+		Name:         "Hex",
+		Description:  "Curse a target for extra 1d6 necrotic damage and disadvantage on ability checks",
 	},
 	SearingSmite: {
 		ID:          SearingSmite,
@@ -249,10 +270,11 @@ var SpellData = map[Spell]*Data{
 		Description: "Send creatures into magical slumber (5d8 hit points affected)",
 	},
 	CharmPerson: {
-		ID:          CharmPerson,
-		Level:       1,
-		Name:        "Charm Person",
-		Description: "Charm a humanoid to regard you as a friendly acquaintance",
+		ID:           CharmPerson,
+		Level:        1,
+		SingleTarget: true,
+		Name:         "Charm Person",
+		Description:  "Charm a humanoid to regard you as a friendly acquaintance",
 	},
 	DetectMagic: {
 		ID:          DetectMagic,
@@ -267,16 +289,18 @@ var SpellData = map[Spell]*Data{
 		Description: "Learn the properties of a magic item or spell affecting a creature",
 	},
 	CureWounds: {
-		ID:          CureWounds,
-		Level:       1,
-		Name:        "Cure Wounds",
-		Description: "Touch heals a creature for 1d8+modifier hit points",
+		ID:           CureWounds,
+		Level:        1,
+		SingleTarget: true,
+		Name:         "Cure Wounds",
+		Description:  "Touch heals a creature for 1d8+modifier hit points",
 	},
 	HealingWord: {
-		ID:          HealingWord,
-		Level:       1,
-		Name:        "Healing Word",
-		Description: "Speak a word of healing to restore 1d4+modifier hit points at range",
+		ID:           HealingWord,
+		Level:        1,
+		SingleTarget: true,
+		Name:         "Healing Word",
+		Description:  "Speak a word of healing to restore 1d4+modifier hit points at range",
 	},
 	Bless: {
 		ID:          Bless,
@@ -291,10 +315,68 @@ var SpellData = map[Spell]*Data{
 		Description: "Curse enemies to subtract 1d4 from attack rolls and saves",
 	},
 	ShieldOfFaith: {
-		ID:          ShieldOfFaith,
+		ID:           ShieldOfFaith,
+		Level:        1,
+		SingleTarget: true,
+		Name:         "Shield of Faith",
+		Description:  "Shimmering field grants +2 AC for 10 minutes",
+	},
+	FaerieFire: {
+		ID:          FaerieFire,
+		Level:       1,
+		Name:        "Faerie Fire",
+		Description: "Outline creatures in a 20-foot cube with light; attacks against them have advantage",
+	},
+	AnimalFriendship: {
+		ID:           AnimalFriendship,
+		Level:        1,
+		SingleTarget: true,
+		Name:         "Animal Friendship",
+		Description:  "Convince a beast that you mean it no harm, charming it for 24 hours",
+	},
+	SpeakWithAnimals: {
+		ID:          SpeakWithAnimals,
+		Level:       1,
+		Name:        "Speak with Animals",
+		Description: "Comprehend and verbally communicate with beasts for 10 minutes",
+	},
+	FogCloud: {
+		ID:          FogCloud,
+		Level:       1,
+		Name:        "Fog Cloud",
+		Description: "Create a 20-foot-radius sphere of fog that heavily obscures the area",
+	},
+	DisguiseSelf: {
+		ID:          DisguiseSelf,
 		Level:       1,
-		Name:        "Shield of Faith",
-		Description: "Shimmering field grants +2 AC for 10 minutes",
+		Name:        "Disguise Self",
+		Description: "Make yourself look different until the spell ends or you use an action to dismiss it",
+	},
+	DivineFavor: {
+		ID:          DivineFavor,
+		Level:       1,
+		Name:        "Divine Favor",
+		Description: "Your weapon attacks deal an extra 1d4 radiant damage",
+	},
+	Command: {
+		ID:           Command,
+		Level:        1,
+		SingleTarget: true,
+		Name:         "Command",
+		Description:  "Speak a one-word command that a creature must obey on its next turn",
+	},
+	FalseLife: {
+		ID:          FalseLife,
+		Level:       1,
+		Name:        "False Life",
+		Description: "Gain 1d4+4 temporary hit points for 1 hour",
+	},
+	RayOfSickness: {
+		ID:           RayOfSickness,
+		Level:        1,
+		SingleTarget: true,
+		Name:         "Ray of Sickness",
+		Description:  "A ray of sickening energy deals 2d8 poison damage and may poison the target",
 	},
 
 	// Level 2 Spells
@@ -323,10 +405,11 @@ var SpellData = map[Spell]*Data{
 		Description: "Fill the air with spinning daggers dealing 4d4 slashing damage",
 	},
 	MelfsAcidArrow: {
-		ID:          MelfsAcidArrow,
-		Level:       2,
-		Name:        "Melf's Acid Arrow",
-		Description: "A shimmering arrow deals 4d4 acid damage immediately and 2d4 at end of next turn",
+		ID:           MelfsAcidArrow,
+		Level:        2,
+		SingleTarget: true,
+		Name:         "Melf's Acid Arrow",
+		Description:  "A shimmering arrow deals 4d4 acid damage immediately and 2d4 at end of next turn",
 	},
 	Moonbeam: {
 		ID:          Moonbeam,
@@ -346,6 +429,19 @@ var SpellData = map[Spell]*Data{
 		Name:        "Flaming Sphere",
 		Description: "A 5-foot sphere of fire deals 2d6 damage and can be moved as bonus action",
 	},
+	HoldPerson: {
+		ID:           HoldPerson,
+		Level:        2,
+		SingleTarget: true,
+		Name:         "Hold Person",
+		Description:  "Paralyze a humanoid that fails a Wisdom save",
+	},
+	MistyStep: {
+		ID:          MistyStep,
+		Level:       2,
+		Name:        "Misty Step",
+		Description: "Teleport up to 30 feet to an unoccupied space you can see",
+	},
 
 	// Level 3 Spells
 	Fireball: {
@@ -367,10 +463,11 @@ var SpellData = map[Spell]*Data{
 		Description: "Storm cloud strikes for 3d10 lightning damage, repeatable each turn",
 	},
 	VampiricTouch: {
-		ID:          VampiricTouch,
-		Level:       3,
-		Name:        "Vampiric Touch",
-		Description: "Touch deals 3d6 necrotic damage and you regain half as hit points",
+		ID:           VampiricTouch,
+		Level:        3,
+		SingleTarget: true,
+		Name:         "Vampiric Touch",
+		Description:  "Touch deals 3d6 necrotic damage and you regain half as hit points",
 	},
 }
 
@@ -0,0 +1,29 @@
+package spells
+
+import "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/classes"
+
+// domainSpells maps each cleric domain to its domain spells by the cleric
+// level at which they are gained. Only levels with spell data are listed.
+var domainSpells = map[classes.Subclass]map[int][]Spell{
+	classes.LifeDomain:      {1: {Bless, CureWounds}},
+	classes.LightDomain:     {1: {BurningHands, FaerieFire}},
+	classes.NatureDomain:    {1: {AnimalFriendship, SpeakWithAnimals}},
+	classes.TempestDomain:   {1: {FogCloud, Thunderwave}},
+	classes.TrickeryDomain:  {1: {CharmPerson, DisguiseSelf}},
+	classes.WarDomain:       {1: {DivineFavor, ShieldOfFaith}},
+	classes.KnowledgeDomain: {1: {Command, Identify}},
+	classes.DeathDomain:     {1: {FalseLife, RayOfSickness}},
+}
+
+// DomainSpells returns the domain spells a cleric of the given level has.
+// Domain spells are always prepared and don't count against the number of
+// spells the cleric can prepare. Returns nil for subclasses that aren't domains.
+func DomainSpells(domain classes.Subclass, clericLevel int) []Spell {
+	byLevel := domainSpells[domain]
+
+	var result []Spell
+	for level := 1; level <= clericLevel; level++ {
+		result = append(result, byLevel[level]...)
+	}
+	return result
+}
@@ -0,0 +1,82 @@
+package spells
+
+import (
+	coreCombat "github.com/KirkDiggler/rpg-toolkit/core/combat"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+)
+
+// Metamagic is a sorcerer's option for twisting a spell as it is cast.
+// Only one Metamagic option can be used on a spell.
+type Metamagic string
+
+// Metamagic options
+const (
+	// MetamagicQuickened changes a casting time of 1 action to 1 bonus action
+	MetamagicQuickened Metamagic = "quickened"
+
+	// MetamagicSubtle casts the spell without verbal or somatic components
+	MetamagicSubtle Metamagic = "subtle"
+
+	// MetamagicTwinned targets a second creature with a single-target spell
+	MetamagicTwinned Metamagic = "twinned"
+)
+
+// Metamagic sorcery point costs
+const (
+	// QuickenedCost is the sorcery points Quickened Spell costs
+	QuickenedCost = 2
+
+	// SubtleCost is the sorcery points Subtle Spell costs
+	SubtleCost = 1
+)
+
+// MetamagicCost returns the sorcery points an option costs for a spell of
+// the given level. Twinned Spell costs the spell's level (1 for cantrips).
+func MetamagicCost(option Metamagic, spellLevel int) int {
+	switch option {
+	case MetamagicQuickened:
+		return QuickenedCost
+	case MetamagicSubtle:
+		return SubtleCost
+	case MetamagicTwinned:
+		return max(spellLevel, 1)
+	default:
+		return 0
+	}
+}
+
+// checkMetamagic returns the sorcery point cost of the input's Metamagic, or
+// why the spell isn't eligible for it.
+func checkMetamagic(input *CastInput, data *Data) (int, error) {
+	switch input.Metamagic {
+	case MetamagicQuickened:
+		if input.CastingTime != "" && input.CastingTime != coreCombat.ActionStandard {
+			return 0, rpgerr.Newf(rpgerr.CodeInvalidArgument,
+				"quickened spell needs a casting time of 1 action, got %s", input.CastingTime)
+		}
+	case MetamagicSubtle:
+	case MetamagicTwinned:
+		if data == nil || !data.SingleTarget {
+			return 0, rpgerr.Newf(rpgerr.CodeInvalidArgument, "%s can't be twinned", input.Spell)
+		}
+		if len(input.TargetIDs) != 2 || input.TargetIDs[0] == input.TargetIDs[1] {
+			return 0, rpgerr.New(rpgerr.CodeInvalidArgument, "twinned spell needs two different targets")
+		}
+	default:
+		return 0, rpgerr.Newf(rpgerr.CodeInvalidArgument, "unknown metamagic %s", input.Metamagic)
+	}
+
+	spellLevel := 0
+	if data != nil {
+		spellLevel = data.Level
+	}
+	cost := MetamagicCost(input.Metamagic, spellLevel)
+	if input.SorceryPoints == nil {
+		return 0, rpgerr.New(rpgerr.CodeInvalidArgument, "metamagic requires sorcery points")
+	}
+	if input.SorceryPoints.Current() < cost {
+		return 0, rpgerr.ResourceExhaustedf("%s metamagic needs %d sorcery points, %d remaining",
+			input.Metamagic, cost, input.SorceryPoints.Current())
+	}
+	return cost, nil
+}
@@ -0,0 +1,151 @@
+package spells
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	coreCombat "github.com/KirkDiggler/rpg-toolkit/core/combat"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+)
+
+type MetamagicTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	bus      events.EventBus
+	sorcerer *Spellcasting
+	points   *combat.RecoverableResource
+	economy  *combat.ActionEconomy
+	casts    []dnd5eEvents.SpellCastEvent
+}
+
+func TestMetamagicSuite(t *testing.T) {
+	suite.Run(t, new(MetamagicTestSuite))
+}
+
+// SetupTest creates a sorcerer with 4 sorcery points and two 1st-level slots
+func (s *MetamagicTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+	s.economy = combat.NewActionEconomy()
+	s.casts = nil
+	s.points = combat.NewRecoverableResource(combat.RecoverableResourceConfig{ID: "sorcery_points", Maximum: 4})
+
+	var err error
+	s.sorcerer, err = NewSpellcasting(&SpellcastingData{
+		Ability: abilities.CHA,
+		Known:   []Spell{FireBolt, ChromaticOrb, MagicMissile, Shield},
+		Slots:   map[int]SlotData{1: {Max: 2}},
+	})
+	s.Require().NoError(err)
+
+	_, err = dnd5eEvents.SpellCastTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, event dnd5eEvents.SpellCastEvent) error {
+			s.casts = append(s.casts, event)
+			return nil
+		})
+	s.Require().NoError(err)
+}
+
+func (s *MetamagicTestSuite) SetupSubTest() {
+	s.SetupTest()
+}
+
+func (s *MetamagicTestSuite) cast(input CastInput) (*CastResult, error) {
+	input.CasterID = "sorcerer-1"
+	input.Spellcasting = s.sorcerer
+	input.SorceryPoints = s.points
+	input.Economy = s.economy
+	input.EventBus = s.bus
+	return Cast(s.ctx, &input)
+}
+
+func (s *MetamagicTestSuite) TestQuickenedUsesBonusAction() {
+	result, err := s.cast(CastInput{Spell: FireBolt, Metamagic: MetamagicQuickened})
+	s.Require().NoError(err)
+	s.Equal(MetamagicQuickened, result.Metamagic)
+	s.Equal(2, s.points.Current())
+	s.NoError(s.economy.CheckAction(), "the action is still available")
+	s.Error(s.economy.CheckBonusAction())
+
+	s.Require().Len(s.casts, 1)
+	s.Equal(string(MetamagicQuickened), s.casts[0].Metamagic)
+}
+
+func (s *MetamagicTestSuite) TestTwinnedCostsSpellLevel() {
+	_, err := s.cast(CastInput{Spell: ChromaticOrb, Metamagic: MetamagicTwinned, TargetIDs: []string{"orc-1", "orc-2"}})
+	s.Require().NoError(err)
+	s.Equal(3, s.points.Current())
+
+	s.economy = combat.NewActionEconomy()
+	_, err = s.cast(CastInput{Spell: FireBolt, Metamagic: MetamagicTwinned, TargetIDs: []string{"orc-1", "orc-2"}})
+	s.Require().NoError(err)
+	s.Equal(2, s.points.Current(), "cantrips cost 1 point")
+}
+
+func (s *MetamagicTestSuite) TestSubtle() {
+	_, err := s.cast(CastInput{Spell: Shield, Metamagic: MetamagicSubtle, CastingTime: coreCombat.ActionReaction})
+	s.Require().NoError(err)
+	s.Equal(3, s.points.Current())
+}
+
+func (s *MetamagicTestSuite) TestIneligibleSpells() {
+	testCases := []struct {
+		name     string
+		input    CastInput
+		expected rpgerr.Code
+	}{
+		{
+			name:     "quickened reaction",
+			input:    CastInput{Spell: Shield, Metamagic: MetamagicQuickened, CastingTime: coreCombat.ActionReaction},
+			expected: rpgerr.CodeInvalidArgument,
+		},
+		{
+			name:     "twinned multi-target spell",
+			input:    CastInput{Spell: MagicMissile, Metamagic: MetamagicTwinned, TargetIDs: []string{"orc-1", "orc-2"}},
+			expected: rpgerr.CodeInvalidArgument,
+		},
+		{
+			name:     "twinned without a second target",
+			input:    CastInput{Spell: FireBolt, Metamagic: MetamagicTwinned, TargetIDs: []string{"orc-1", "orc-1"}},
+			expected: rpgerr.CodeInvalidArgument,
+		},
+		{
+			name:     "unknown option",
+			input:    CastInput{Spell: FireBolt, Metamagic: "empowered"},
+			expected: rpgerr.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			_, err := s.cast(tc.input)
+			s.Equal(tc.expected, rpgerr.GetCode(err))
+			s.Equal(4, s.points.Current())
+			s.Equal(2, s.sorcerer.SlotsRemaining(1))
+			s.Empty(s.casts)
+		})
+	}
+}
+
+func (s *MetamagicTestSuite) TestNotEnoughPoints() {
+	s.Require().NoError(s.points.Use(3))
+
+	_, err := s.cast(CastInput{Spell: ChromaticOrb, Metamagic: MetamagicQuickened})
+	s.Equal(rpgerr.CodeResourceExhausted, rpgerr.GetCode(err))
+	s.Equal(2, s.sorcerer.SlotsRemaining(1), "failed casts spend nothing")
+
+	_, err = Cast(s.ctx, &CastInput{
+		CasterID:     "sorcerer-1",
+		Spellcasting: s.sorcerer,
+		Spell:        FireBolt,
+		Metamagic:    MetamagicSubtle,
+		EventBus:     s.bus,
+	})
+	s.Equal(rpgerr.CodeInvalidArgument, rpgerr.GetCode(err), "no sorcery points")
+}
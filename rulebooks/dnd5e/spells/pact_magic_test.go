@@ -0,0 +1,182 @@
+package spells
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+)
+
+type PactMagicTestSuite struct {
+	suite.Suite
+	ctx     context.Context
+	bus     events.EventBus
+	warlock *Spellcasting
+}
+
+func TestPactMagicSuite(t *testing.T) {
+	suite.Run(t, new(PactMagicTestSuite))
+}
+
+// SetupTest creates a level 5 warlock: two 3rd-level pact slots
+func (s *PactMagicTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+
+	pact := PactSlots(5)
+	var err error
+	s.warlock, err = NewSpellcasting(&SpellcastingData{
+		Ability:     abilities.CHA,
+		SaveDC:      14,
+		AttackBonus: 6,
+		Known:       []Spell{EldritchBlast, Hex, HellishRebuke},
+		Pact:        &pact,
+	})
+	s.Require().NoError(err)
+}
+
+func (s *PactMagicTestSuite) SetupSubTest() {
+	s.SetupTest()
+}
+
+func (s *PactMagicTestSuite) cast(input CastInput) (*CastResult, error) {
+	input.CasterID = "warlock-1"
+	input.Spellcasting = s.warlock
+	input.EventBus = s.bus
+	return Cast(s.ctx, &input)
+}
+
+func (s *PactMagicTestSuite) TestPactSlots() {
+	testCases := []struct {
+		warlockLevel int
+		expected     PactSlotData
+	}{
+		{warlockLevel: 0, expected: PactSlotData{}},
+		{warlockLevel: 1, expected: PactSlotData{Level: 1, Max: 1}},
+		{warlockLevel: 2, expected: PactSlotData{Level: 1, Max: 2}},
+		{warlockLevel: 3, expected: PactSlotData{Level: 2, Max: 2}},
+		{warlockLevel: 9, expected: PactSlotData{Level: 5, Max: 2}},
+		{warlockLevel: 11, expected: PactSlotData{Level: 5, Max: 3}},
+		{warlockLevel: 17, expected: PactSlotData{Level: 5, Max: 4}},
+	}
+
+	for _, tc := range testCases {
+		s.Equal(tc.expected, PactSlots(tc.warlockLevel), "warlock level %d", tc.warlockLevel)
+	}
+}
+
+func (s *PactMagicTestSuite) TestCastsAtPactSlotLevel() {
+	result, err := s.cast(CastInput{Spell: Hex})
+	s.Require().NoError(err)
+	s.Equal(1, result.SpellLevel)
+	s.Equal(3, result.SlotLevel, "pact slots always cast at their own level")
+	s.True(result.PactSlot)
+	s.Equal(1, s.warlock.PactSlotsRemaining())
+
+	_, err = s.cast(CastInput{Spell: HellishRebuke, PactSlot: true})
+	s.Require().NoError(err)
+	s.Equal(0, s.warlock.PactSlotsRemaining())
+
+	_, err = s.cast(CastInput{Spell: Hex})
+	s.Equal(rpgerr.CodeResourceExhausted, rpgerr.GetCode(err))
+
+	result, err = s.cast(CastInput{Spell: EldritchBlast})
+	s.Require().NoError(err, "cantrips need no slot")
+	s.False(result.PactSlot)
+}
+
+func (s *PactMagicTestSuite) TestInvalidPactCasts() {
+	testCases := []struct {
+		name     string
+		input    CastInput
+		expected rpgerr.Code
+	}{
+		{name: "wrong slot level", input: CastInput{Spell: Hex, PactSlot: true, SlotLevel: 2}, expected: rpgerr.CodeInvalidArgument},
+		{name: "no standard slots", input: CastInput{Spell: Hex, SlotLevel: 1}, expected: rpgerr.CodeResourceExhausted},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			_, err := s.cast(tc.input)
+			s.Equal(tc.expected, rpgerr.GetCode(err))
+			s.Equal(2, s.warlock.PactSlotsRemaining())
+		})
+	}
+}
+
+func (s *PactMagicTestSuite) TestStandardSlotsComeFirst() {
+	pact := PactSlots(1)
+	multiclass, err := NewSpellcasting(&SpellcastingData{
+		Ability: abilities.CHA,
+		Known:   []Spell{Hex, Shield},
+		Slots:   map[int]SlotData{1: {Max: 1}},
+		Pact:    &pact,
+	})
+	s.Require().NoError(err)
+	s.warlock = multiclass
+
+	result, err := s.cast(CastInput{Spell: Shield})
+	s.Require().NoError(err)
+	s.False(result.PactSlot)
+	s.Equal(0, multiclass.SlotsRemaining(1))
+	s.Equal(1, multiclass.PactSlotsRemaining())
+
+	result, err = s.cast(CastInput{Spell: Hex})
+	s.Require().NoError(err)
+	s.True(result.PactSlot)
+
+	_, err = s.cast(CastInput{Spell: Shield, PactSlot: true})
+	s.Equal(rpgerr.CodeResourceExhausted, rpgerr.GetCode(err))
+}
+
+func (s *PactMagicTestSuite) TestRests() {
+	_, err := s.cast(CastInput{Spell: Hex})
+	s.Require().NoError(err)
+	s.warlock.ShortRest()
+	s.Equal(2, s.warlock.PactSlotsRemaining(), "pact slots return on a short rest")
+
+	_, err = s.cast(CastInput{Spell: Hex})
+	s.Require().NoError(err)
+	s.warlock.LongRest()
+	s.Equal(2, s.warlock.PactSlotsRemaining())
+}
+
+func (s *PactMagicTestSuite) TestDataRoundTrip() {
+	_, err := s.cast(CastInput{Spell: Hex})
+	s.Require().NoError(err)
+
+	data, err := json.Marshal(s.warlock.ToData())
+	s.Require().NoError(err)
+
+	var loaded SpellcastingData
+	s.Require().NoError(json.Unmarshal(data, &loaded))
+	s.Equal(&PactSlotData{Level: 3, Max: 2, Used: 1}, loaded.Pact)
+
+	restored, err := NewSpellcasting(&loaded)
+	s.Require().NoError(err)
+	s.Equal(1, restored.PactSlotsRemaining())
+	s.Equal(3, restored.PactSlotLevel())
+}
+
+func (s *PactMagicTestSuite) TestValidate() {
+	testCases := []struct {
+		name string
+		pact PactSlotData
+	}{
+		{name: "level too high", pact: PactSlotData{Level: 6, Max: 1}},
+		{name: "no level", pact: PactSlotData{Max: 1}},
+		{name: "overspent", pact: PactSlotData{Level: 1, Max: 1, Used: 2}},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			_, err := NewSpellcasting(&SpellcastingData{Ability: abilities.CHA, Pact: &tc.pact})
+			s.Equal(rpgerr.CodeInvalidArgument, rpgerr.GetCode(err))
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package spells
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+)
+
+// WizardSpellsPerLevel is how many wizard spells a wizard adds to their
+// spellbook for free each time they gain a wizard level.
+const WizardSpellsPerLevel = 2
+
+// Costs of copying a spell into a spellbook, per spell level
+const (
+	CopyGoldPerLevel  = 50
+	CopyHoursPerLevel = 2
+)
+
+// Learn adds spells to the caster's known spells (a wizard's spellbook).
+// Leveled spells must be of a level the caster has spell slots for.
+// Nothing is learned unless every spell can be.
+func (s *Spellcasting) Learn(spells ...Spell) error {
+	for _, spell := range spells {
+		data := GetData(spell)
+		if data == nil {
+			return rpgerr.Newf(rpgerr.CodeInvalidArgument, "unknown spell %s", spell)
+		}
+		if s.known[spell] {
+			return rpgerr.Newf(rpgerr.CodeAlreadyExists, "%s is already known", spell)
+		}
+		if data.Level > 0 && s.slots[data.Level].Max == 0 {
+			return rpgerr.Newf(rpgerr.CodeInvalidArgument,
+				"can't learn %s without level %d spell slots", spell, data.Level)
+		}
+	}
+
+	for _, spell := range spells {
+		s.known[spell] = true
+	}
+	return nil
+}
+
+// LearnOnLevelUp adds the free leveled spells a wizard writes into their
+// spellbook when they gain a wizard level.
+func (s *Spellcasting) LearnOnLevelUp(spells []Spell) error {
+	if len(spells) > WizardSpellsPerLevel {
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument,
+			"%d spells chosen, a wizard learns %d per level", len(spells), WizardSpellsPerLevel)
+	}
+	for _, spell := range spells {
+		if data := GetData(spell); data != nil && data.Level == 0 {
+			return rpgerr.Newf(rpgerr.CodeInvalidArgument, "%s is a cantrip", spell)
+		}
+	}
+	return s.Learn(spells...)
+}
+
+// CopySpellInput provides the parameters for copying a spell into a spellbook.
+type CopySpellInput struct {
+	// CasterID is the wizard copying the spell
+	CasterID string
+
+	// Spellcasting is the wizard's spellcasting; the spell is added to its known spells
+	Spellcasting *Spellcasting
+
+	// Spell is the spell being copied
+	Spell Spell
+
+	// Source describes where the spell is copied from (e.g. "scroll")
+	Source string
+
+	// EventBus is used to publish the SpellCopiedEvent
+	EventBus events.EventBus
+}
+
+// Validate validates the input.
+func (c *CopySpellInput) Validate() error {
+	if c == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "CopySpellInput is nil")
+	}
+	if c.CasterID == "" {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "CasterID is required")
+	}
+	if c.Spellcasting == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "Spellcasting is required")
+	}
+	if c.Spell == "" {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "Spell is required")
+	}
+	if c.EventBus == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "EventBus is required")
+	}
+	return nil
+}
+
+// CopySpellResult is the cost of copying a spell.
+type CopySpellResult struct {
+	Spell    Spell
+	GoldCost int
+	Hours    int
+}
+
+// CopySpell copies a leveled spell from a scroll or another spellbook into the
+// wizard's spellbook and publishes a SpellCopiedEvent. The game owns gold and
+// downtime: it deducts the returned cost (and decides whether a scroll is
+// consumed) in response to the event.
+func CopySpell(ctx context.Context, input *CopySpellInput) (*CopySpellResult, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	data := GetData(input.Spell)
+	if data != nil && data.Level == 0 {
+		return nil, rpgerr.Newf(rpgerr.CodeInvalidArgument, "cantrips can't be copied: %s", input.Spell)
+	}
+	if err := input.Spellcasting.Learn(input.Spell); err != nil {
+		return nil, err
+	}
+
+	result := &CopySpellResult{
+		Spell:    input.Spell,
+		GoldCost: data.Level * CopyGoldPerLevel,
+		Hours:    data.Level * CopyHoursPerLevel,
+	}
+
+	err := dnd5eEvents.SpellCopiedTopic.On(input.EventBus).Publish(ctx, dnd5eEvents.SpellCopiedEvent{
+		CasterID: input.CasterID,
+		SpellID:  input.Spell,
+		Source:   input.Source,
+		GoldCost: result.GoldCost,
+		Hours:    result.Hours,
+	})
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to publish spell copied event")
+	}
+
+	return result, nil
+}
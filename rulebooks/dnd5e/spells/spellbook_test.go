@@ -0,0 +1,120 @@
+package spells
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+)
+
+type SpellbookTestSuite struct {
+	suite.Suite
+	ctx    context.Context
+	bus    events.EventBus
+	wizard *Spellcasting
+}
+
+func TestSpellbookSuite(t *testing.T) {
+	suite.Run(t, new(SpellbookTestSuite))
+}
+
+// SetupTest creates a level 3 wizard (INT 16) with level 1 and 2 slots
+func (s *SpellbookTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+
+	var err error
+	s.wizard, err = NewSpellcasting(&SpellcastingData{
+		Ability:     abilities.INT,
+		SaveDC:      13,
+		AttackBonus: 5,
+		Known:       []Spell{FireBolt, MagicMissile, Shield, Sleep},
+		Slots:       map[int]SlotData{1: {Max: 4}, 2: {Max: 2}},
+		MaxPrepared: PreparedCount(3, 3),
+		Prepared:    []Spell{MagicMissile, Shield},
+	})
+	s.Require().NoError(err)
+}
+
+func (s *SpellbookTestSuite) TestLearnOnLevelUp() {
+	s.Require().NoError(s.wizard.LearnOnLevelUp([]Spell{ScorchingRay, Thunderwave}))
+	s.False(s.wizard.Knows(ScorchingRay), "in the spellbook but not prepared")
+
+	s.Require().NoError(s.wizard.Prepare([]Spell{ScorchingRay, MagicMissile}))
+	s.True(s.wizard.Knows(ScorchingRay))
+
+	s.Error(s.wizard.LearnOnLevelUp([]Spell{Identify, DetectMagic, CharmPerson}), "two spells per level")
+	s.Error(s.wizard.LearnOnLevelUp([]Spell{RayOfFrost}), "cantrips aren't learned this way")
+	s.Error(s.wizard.LearnOnLevelUp([]Spell{Fireball}), "no level 3 slots yet")
+
+	err := s.wizard.LearnOnLevelUp([]Spell{Identify, Sleep})
+	s.Equal(rpgerr.CodeAlreadyExists, rpgerr.GetCode(err))
+	s.NotContains(s.wizard.ToData().Known, Identify, "nothing is learned when one spell fails")
+}
+
+func (s *SpellbookTestSuite) TestCopySpell() {
+	var copied []dnd5eEvents.SpellCopiedEvent
+	_, err := dnd5eEvents.SpellCopiedTopic.On(s.bus).Subscribe(s.ctx,
+		func(_ context.Context, event dnd5eEvents.SpellCopiedEvent) error {
+			copied = append(copied, event)
+			return nil
+		})
+	s.Require().NoError(err)
+
+	result, err := CopySpell(s.ctx, &CopySpellInput{
+		CasterID:     "wizard-1",
+		Spellcasting: s.wizard,
+		Spell:        Shatter,
+		Source:       "scroll",
+		EventBus:     s.bus,
+	})
+	s.Require().NoError(err)
+	s.Equal(&CopySpellResult{Spell: Shatter, GoldCost: 100, Hours: 4}, result)
+	s.Contains(s.wizard.ToData().Known, Shatter)
+	s.Equal([]dnd5eEvents.SpellCopiedEvent{{
+		CasterID: "wizard-1",
+		SpellID:  Shatter,
+		Source:   "scroll",
+		GoldCost: 100,
+		Hours:    4,
+	}}, copied)
+
+	_, err = CopySpell(s.ctx, &CopySpellInput{
+		CasterID: "wizard-1", Spellcasting: s.wizard, Spell: Shatter, EventBus: s.bus,
+	})
+	s.Equal(rpgerr.CodeAlreadyExists, rpgerr.GetCode(err))
+
+	_, err = CopySpell(s.ctx, &CopySpellInput{
+		CasterID: "wizard-1", Spellcasting: s.wizard, Spell: Fireball, EventBus: s.bus,
+	})
+	s.Error(err, "no level 3 slots")
+
+	_, err = CopySpell(s.ctx, &CopySpellInput{
+		CasterID: "wizard-1", Spellcasting: s.wizard, Spell: RayOfFrost, EventBus: s.bus,
+	})
+	s.Error(err, "cantrips can't be copied")
+
+	_, err = CopySpell(s.ctx, &CopySpellInput{CasterID: "wizard-1", Spellcasting: s.wizard, Spell: Shield})
+	s.Equal(rpgerr.CodeInvalidArgument, rpgerr.GetCode(err), "event bus required")
+	s.Len(copied, 1)
+}
+
+func (s *SpellbookTestSuite) TestRecoverSlots() {
+	for range 3 {
+		s.Require().NoError(s.wizard.useSlot(1))
+	}
+	s.Require().NoError(s.wizard.useSlot(2))
+
+	s.Error(s.wizard.RecoverSlots([]int{2, 2}), "only one level 2 slot is expended")
+	s.Equal(1, s.wizard.SlotsRemaining(2), "nothing recovered on failure")
+
+	s.Require().NoError(s.wizard.RecoverSlots([]int{2, 1, 1}))
+	s.Equal(2, s.wizard.SlotsRemaining(2))
+	s.Equal(3, s.wizard.SlotsRemaining(1))
+	s.Equal(1, s.wizard.SlotsExpended(1))
+}
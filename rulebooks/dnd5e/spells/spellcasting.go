@@ -0,0 +1,525 @@
+package spells
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+)
+
+// MaxSpellLevel is the highest spell and spell slot level
+const MaxSpellLevel = 9
+
+// SlotData is the serializable state of the spell slots of one level.
+// Created counts slots made by Flexible Casting on top of the normal maximum;
+// they are included in Max and vanish on a long rest.
+type SlotData struct {
+	Max     int `json:"max"`
+	Used    int `json:"used"`
+	Created int `json:"created,omitempty"`
+}
+
+// MaxPactSlotLevel is the highest level pact magic slots reach
+const MaxPactSlotLevel = 5
+
+// PactSlotData is the serializable state of a warlock's pact magic slots.
+// Every pact slot has the same level, and they all return on a short rest.
+type PactSlotData struct {
+	Level int `json:"level"`
+	Max   int `json:"max"`
+	Used  int `json:"used"`
+}
+
+// PactSlots returns the pact magic slots of a warlock of the given level:
+// the number of slots and the level they are all cast at.
+func PactSlots(warlockLevel int) PactSlotData {
+	var count int
+	switch {
+	case warlockLevel < 1:
+		return PactSlotData{}
+	case warlockLevel == 1:
+		count = 1
+	case warlockLevel <= 10:
+		count = 2
+	case warlockLevel <= 16:
+		count = 3
+	default:
+		count = 4
+	}
+	return PactSlotData{
+		Level: min((warlockLevel+1)/2, MaxPactSlotLevel),
+		Max:   count,
+	}
+}
+
+// halfCasterSlots is the Paladin/Ranger spell slot table indexed by class level,
+// listing the slot count for each spell level starting at 1st.
+var halfCasterSlots = [][]int{
+	{}, {}, // levels 0-1: no spellcasting yet
+	{2}, {3}, {3}, {4, 2}, {4, 2}, {4, 3}, {4, 3}, {4, 3, 2}, {4, 3, 2},
+	{4, 3, 3}, {4, 3, 3}, {4, 3, 3, 1}, {4, 3, 3, 1}, {4, 3, 3, 2}, {4, 3, 3, 2},
+	{4, 3, 3, 3, 1}, {4, 3, 3, 3, 1}, {4, 3, 3, 3, 2}, {4, 3, 3, 3, 2},
+}
+
+// HalfCasterSlots returns the spell slots of a paladin or ranger of the given level,
+// keyed by slot level. Half-casters gain their first slots at level 2.
+func HalfCasterSlots(classLevel int) map[int]SlotData {
+	slots := make(map[int]SlotData)
+	if classLevel < 0 {
+		return slots
+	}
+	for i, count := range halfCasterSlots[min(classLevel, 20)] {
+		slots[i+1] = SlotData{Max: count}
+	}
+	return slots
+}
+
+// InnateData is the serializable state of an innate spell
+type InnateData struct {
+	PerDay int `json:"per_day,omitempty"` // 0 means at will
+	Used   int `json:"used,omitempty"`
+}
+
+// SpellcastingData is the serializable form of a creature's spellcasting.
+// Character and monster data embed it as an opaque blob.
+//
+// Warlocks set Pact. Pact slots are separate from Slots (a multiclass caster
+// can have both) and recover on a short rest.
+//
+// Prepared casters (clerics, druids, wizards) set MaxPrepared. Their leveled
+// Known spells are the spells they can prepare, and only Prepared and
+// AlwaysPrepared spells can be cast with slots.
+type SpellcastingData struct {
+	Ability        abilities.Ability    `json:"ability"`
+	SaveDC         int                  `json:"save_dc"`
+	AttackBonus    int                  `json:"attack_bonus"`
+	Known          []Spell              `json:"known,omitempty"`
+	Slots          map[int]SlotData     `json:"slots,omitempty"`
+	Innate         map[Spell]InnateData `json:"innate,omitempty"`
+	MaxPrepared    int                  `json:"max_prepared,omitempty"`
+	Prepared       []Spell              `json:"prepared,omitempty"`
+	AlwaysPrepared []Spell              `json:"always_prepared,omitempty"`
+	Pact           *PactSlotData        `json:"pact,omitempty"`
+}
+
+// Validate validates the spellcasting data.
+// Known spells must be in SpellData so their level is known; innate spells
+// don't use slots and can be anything.
+func (d *SpellcastingData) Validate() error {
+	if d == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "SpellcastingData is nil")
+	}
+	for _, spell := range d.Known {
+		if GetData(spell) == nil {
+			return rpgerr.Newf(rpgerr.CodeInvalidArgument, "unknown spell %s", spell)
+		}
+	}
+	for level, slot := range d.Slots {
+		if level < 1 || level > MaxSpellLevel {
+			return rpgerr.Newf(rpgerr.CodeInvalidArgument, "invalid spell slot level %d", level)
+		}
+		if slot.Max < 0 || slot.Used < 0 || slot.Used > slot.Max {
+			return rpgerr.Newf(rpgerr.CodeInvalidArgument, "invalid level %d slots: %d/%d used", level, slot.Used, slot.Max)
+		}
+		if slot.Created < 0 || slot.Created > slot.Max {
+			return rpgerr.Newf(rpgerr.CodeInvalidArgument, "invalid level %d slots: %d of %d created", level, slot.Created, slot.Max)
+		}
+	}
+	if pact := d.Pact; pact != nil {
+		if pact.Level < 1 || pact.Level > MaxPactSlotLevel {
+			return rpgerr.Newf(rpgerr.CodeInvalidArgument, "invalid pact slot level %d", pact.Level)
+		}
+		if pact.Max < 0 || pact.Used < 0 || pact.Used > pact.Max {
+			return rpgerr.Newf(rpgerr.CodeInvalidArgument, "invalid pact slots: %d/%d used", pact.Used, pact.Max)
+		}
+	}
+	for spell, innate := range d.Innate {
+		if innate.PerDay < 0 || innate.Used < 0 || (innate.PerDay > 0 && innate.Used > innate.PerDay) {
+			return rpgerr.Newf(rpgerr.CodeInvalidArgument, "invalid innate uses for %s", spell)
+		}
+	}
+	if d.MaxPrepared < 0 {
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument, "invalid max prepared %d", d.MaxPrepared)
+	}
+	if d.MaxPrepared == 0 && (len(d.Prepared) > 0 || len(d.AlwaysPrepared) > 0) {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "prepared spells require MaxPrepared")
+	}
+	if err := validatePrepared(d.Known, d.Prepared, d.MaxPrepared); err != nil {
+		return err
+	}
+	for _, spell := range d.AlwaysPrepared {
+		data := GetData(spell)
+		if data == nil || data.Level == 0 {
+			return rpgerr.Newf(rpgerr.CodeInvalidArgument, "%s can't be always prepared", spell)
+		}
+	}
+	return nil
+}
+
+// validatePrepared checks that every prepared spell is a known leveled spell
+// and that no more than maxPrepared are prepared.
+func validatePrepared(known, prepared []Spell, maxPrepared int) error {
+	if len(prepared) > maxPrepared {
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument,
+			"%d spells prepared, maximum is %d", len(prepared), maxPrepared)
+	}
+	for _, spell := range prepared {
+		data := GetData(spell)
+		if data == nil || data.Level == 0 || !slices.Contains(known, spell) {
+			return rpgerr.Newf(rpgerr.CodeInvalidArgument, "%s can't be prepared", spell)
+		}
+	}
+	return nil
+}
+
+// PreparedCount returns how many spells a prepared caster can prepare:
+// their spellcasting ability modifier plus their class level (minimum 1).
+func PreparedCount(abilityModifier, classLevel int) int {
+	return max(1, abilityModifier+classLevel)
+}
+
+// Spellcasting tracks a creature's spells and the resources that fuel them:
+// known spells cast with spell slots, and innate spells cast at will or a
+// number of times per day.
+//
+// Characters and monsters share it so both cast through Cast.
+type Spellcasting struct {
+	ability        abilities.Ability
+	saveDC         int
+	attackBonus    int
+	known          map[Spell]bool
+	slots          map[int]SlotData
+	innate         map[Spell]InnateData
+	maxPrepared    int
+	prepared       map[Spell]bool
+	alwaysPrepared map[Spell]bool
+	pact           *PactSlotData
+}
+
+// NewSpellcasting creates spellcasting from its data.
+func NewSpellcasting(data *SpellcastingData) (*Spellcasting, error) {
+	if err := data.Validate(); err != nil {
+		return nil, err
+	}
+
+	s := &Spellcasting{
+		ability:     data.Ability,
+		saveDC:      data.SaveDC,
+		attackBonus: data.AttackBonus,
+		known:       make(map[Spell]bool, len(data.Known)),
+		slots:       make(map[int]SlotData, len(data.Slots)),
+		innate:      make(map[Spell]InnateData, len(data.Innate)),
+		maxPrepared: data.MaxPrepared,
+	}
+	for _, spell := range data.Known {
+		s.known[spell] = true
+	}
+	s.prepared = spellSet(data.Prepared)
+	s.alwaysPrepared = spellSet(data.AlwaysPrepared)
+	for level, slot := range data.Slots {
+		s.slots[level] = slot
+	}
+	for spell, innate := range data.Innate {
+		s.innate[spell] = innate
+	}
+	if data.Pact != nil {
+		pact := *data.Pact
+		s.pact = &pact
+	}
+	return s, nil
+}
+
+// Ability returns the spellcasting ability.
+func (s *Spellcasting) Ability() abilities.Ability {
+	return s.ability
+}
+
+// SaveDC returns the base spell save DC. Cast runs it through the
+// SpellcastingChain, so item bonuses aren't included here.
+func (s *Spellcasting) SaveDC() int {
+	return s.saveDC
+}
+
+// AttackBonus returns the base spell attack bonus. Cast runs it through the
+// SpellcastingChain, so item bonuses aren't included here.
+func (s *Spellcasting) AttackBonus() int {
+	return s.attackBonus
+}
+
+// Knows returns true if the spell can be cast with spell slots (or at will, for cantrips).
+// Prepared casters know their cantrips and the leveled spells they have prepared.
+func (s *Spellcasting) Knows(spell Spell) bool {
+	if !s.PreparesSpells() {
+		return s.known[spell]
+	}
+	if s.prepared[spell] || s.alwaysPrepared[spell] {
+		return true
+	}
+	data := GetData(spell)
+	return s.known[spell] && data != nil && data.Level == 0
+}
+
+// PreparesSpells returns true for prepared casters.
+func (s *Spellcasting) PreparesSpells() bool {
+	return s.maxPrepared > 0
+}
+
+// MaxPrepared returns how many spells can be prepared, not counting
+// always-prepared spells.
+func (s *Spellcasting) MaxPrepared() int {
+	return s.maxPrepared
+}
+
+// IsPrepared returns true if the spell is prepared or always prepared.
+func (s *Spellcasting) IsPrepared(spell Spell) bool {
+	return s.prepared[spell] || s.alwaysPrepared[spell]
+}
+
+// Prepare replaces the prepared spells, as after a long rest. Each spell must
+// be a known leveled spell. Always-prepared spells don't count against the maximum.
+func (s *Spellcasting) Prepare(spells []Spell) error {
+	if !s.PreparesSpells() {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "spellcaster doesn't prepare spells")
+	}
+
+	// Always-prepared spells are already prepared for free
+	var toPrepare []Spell
+	for _, spell := range spells {
+		if !s.alwaysPrepared[spell] && !slices.Contains(toPrepare, spell) {
+			toPrepare = append(toPrepare, spell)
+		}
+	}
+
+	known := make([]Spell, 0, len(s.known))
+	for spell := range s.known {
+		known = append(known, spell)
+	}
+	if err := validatePrepared(known, toPrepare, s.maxPrepared); err != nil {
+		return err
+	}
+
+	s.prepared = spellSet(toPrepare)
+	return nil
+}
+
+// HasInnate returns true if the spell can be cast innately.
+func (s *Spellcasting) HasInnate(spell Spell) bool {
+	_, ok := s.innate[spell]
+	return ok
+}
+
+// SlotsRemaining returns the unused spell slots of the given level.
+func (s *Spellcasting) SlotsRemaining(level int) int {
+	slot := s.slots[level]
+	return slot.Max - slot.Used
+}
+
+// HasPactMagic returns true if the caster has pact magic slots.
+func (s *Spellcasting) HasPactMagic() bool {
+	return s.pact != nil
+}
+
+// PactSlotLevel returns the level every pact slot is cast at, or 0 without pact magic.
+func (s *Spellcasting) PactSlotLevel() int {
+	if s.pact == nil {
+		return 0
+	}
+	return s.pact.Level
+}
+
+// PactSlotsRemaining returns the unused pact magic slots.
+func (s *Spellcasting) PactSlotsRemaining() int {
+	if s.pact == nil {
+		return 0
+	}
+	return s.pact.Max - s.pact.Used
+}
+
+// usePactSlot expends one pact magic slot.
+func (s *Spellcasting) usePactSlot() error {
+	if s.PactSlotsRemaining() <= 0 {
+		return rpgerr.ResourceExhausted("pact magic slot")
+	}
+	s.pact.Used++
+	return nil
+}
+
+// InnateRemaining returns the innate uses left for the spell today,
+// or -1 if it can be cast at will.
+func (s *Spellcasting) InnateRemaining(spell Spell) int {
+	innate, ok := s.innate[spell]
+	if !ok {
+		return 0
+	}
+	if innate.PerDay == 0 {
+		return -1
+	}
+	return innate.PerDay - innate.Used
+}
+
+// LowestSlot returns the lowest slot level at or above minLevel with a slot
+// remaining, or 0 if there is none.
+func (s *Spellcasting) LowestSlot(minLevel int) int {
+	for level := max(minLevel, 1); level <= MaxSpellLevel; level++ {
+		if s.SlotsRemaining(level) > 0 {
+			return level
+		}
+	}
+	return 0
+}
+
+// useSlot expends one spell slot of the given level.
+func (s *Spellcasting) useSlot(level int) error {
+	if s.SlotsRemaining(level) <= 0 {
+		return rpgerr.ResourceExhausted(fmt.Sprintf("level %d spell slot", level))
+	}
+	slot := s.slots[level]
+	slot.Used++
+	s.slots[level] = slot
+	return nil
+}
+
+// ExpendSlot expends one spell slot of the given level for a feature that
+// spends slots without casting a spell (Divine Smite).
+func (s *Spellcasting) ExpendSlot(level int) error {
+	if level < 1 || level > MaxSpellLevel {
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument, "invalid spell slot level %d", level)
+	}
+	return s.useSlot(level)
+}
+
+// useInnate expends one innate use of the spell. At-will spells are free.
+func (s *Spellcasting) useInnate(spell Spell) error {
+	innate, ok := s.innate[spell]
+	if !ok {
+		return rpgerr.Newf(rpgerr.CodeNotFound, "%s is not an innate spell", spell)
+	}
+	if innate.PerDay == 0 {
+		return nil
+	}
+	if innate.Used >= innate.PerDay {
+		return rpgerr.ResourceExhausted(string(spell) + " innate uses")
+	}
+	innate.Used++
+	s.innate[spell] = innate
+	return nil
+}
+
+// SlotsExpended returns the used spell slots of the given level.
+func (s *Spellcasting) SlotsExpended(level int) int {
+	return s.slots[level].Used
+}
+
+// RecoverSlots regains one expended slot for each level listed (Arcane Recovery,
+// Natural Recovery). Nothing is recovered unless every slot can be.
+func (s *Spellcasting) RecoverSlots(levels []int) error {
+	expended := make(map[int]int, len(levels))
+	for _, level := range levels {
+		expended[level]++
+		if expended[level] > s.SlotsExpended(level) {
+			return rpgerr.Newf(rpgerr.CodeInvalidArgument, "no expended level %d spell slot to recover", level)
+		}
+	}
+
+	for level, count := range expended {
+		slot := s.slots[level]
+		slot.Used -= count
+		s.slots[level] = slot
+	}
+	return nil
+}
+
+// ShortRest restores pact magic slots. Other slots and innate uses wait for a long rest.
+func (s *Spellcasting) ShortRest() {
+	if s.pact != nil {
+		s.pact.Used = 0
+	}
+}
+
+// CreateSlot gains a spell slot of the given level (Flexible Casting). An
+// expended slot is regained first; otherwise the slot is added on top of the
+// maximum until the next long rest.
+func (s *Spellcasting) CreateSlot(level int) error {
+	if level < 1 || level > MaxSpellLevel {
+		return rpgerr.Newf(rpgerr.CodeInvalidArgument, "invalid spell slot level %d", level)
+	}
+	slot := s.slots[level]
+	if slot.Used > 0 {
+		slot.Used--
+	} else {
+		slot.Max++
+		slot.Created++
+	}
+	s.slots[level] = slot
+	return nil
+}
+
+// LongRest restores all spell slots, pact magic slots and innate uses.
+// Slots created by Flexible Casting vanish.
+func (s *Spellcasting) LongRest() {
+	s.ShortRest()
+	for level, slot := range s.slots {
+		slot.Max -= slot.Created
+		slot.Created = 0
+		slot.Used = 0
+		s.slots[level] = slot
+	}
+	for spell, innate := range s.innate {
+		innate.Used = 0
+		s.innate[spell] = innate
+	}
+}
+
+// ToData converts the spellcasting to its serializable form.
+func (s *Spellcasting) ToData() *SpellcastingData {
+	data := &SpellcastingData{
+		Ability:        s.ability,
+		SaveDC:         s.saveDC,
+		AttackBonus:    s.attackBonus,
+		Known:          sortedSpells(s.known),
+		MaxPrepared:    s.maxPrepared,
+		Prepared:       sortedSpells(s.prepared),
+		AlwaysPrepared: sortedSpells(s.alwaysPrepared),
+	}
+
+	if len(s.slots) > 0 {
+		data.Slots = make(map[int]SlotData, len(s.slots))
+		for level, slot := range s.slots {
+			data.Slots[level] = slot
+		}
+	}
+	if s.pact != nil {
+		pact := *s.pact
+		data.Pact = &pact
+	}
+	if len(s.innate) > 0 {
+		data.Innate = make(map[Spell]InnateData, len(s.innate))
+		for spell, innate := range s.innate {
+			data.Innate[spell] = innate
+		}
+	}
+	return data
+}
+
+// spellSet converts a spell list to a set.
+func spellSet(spells []Spell) map[Spell]bool {
+	set := make(map[Spell]bool, len(spells))
+	for _, spell := range spells {
+		set[spell] = true
+	}
+	return set
+}
+
+// sortedSpells returns the spells in a set, sorted for deterministic output.
+func sortedSpells(set map[Spell]bool) []Spell {
+	var spells []Spell
+	for spell := range set {
+		spells = append(spells, spell)
+	}
+	sort.Slice(spells, func(i, j int) bool {
+		return spells[i] < spells[j]
+	})
+	return spells
+}
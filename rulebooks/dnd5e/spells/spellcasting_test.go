@@ -0,0 +1,126 @@
+package spells
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/classes"
+)
+
+type PreparedCastingTestSuite struct {
+	suite.Suite
+	cleric *Spellcasting
+}
+
+func TestPreparedCastingSuite(t *testing.T) {
+	suite.Run(t, new(PreparedCastingTestSuite))
+}
+
+// SetupTest creates a level 1 Life cleric with WIS 16: 3 + 1 = 4 spells prepared
+func (s *PreparedCastingTestSuite) SetupTest() {
+	var err error
+	s.cleric, err = NewSpellcasting(&SpellcastingData{
+		Ability:        abilities.WIS,
+		SaveDC:         13,
+		AttackBonus:    5,
+		Known:          []Spell{SacredFlame, GuidingBolt, InflictWounds, HealingWord, Bane, ShieldOfFaith, Command},
+		Slots:          map[int]SlotData{1: {Max: 2}},
+		MaxPrepared:    PreparedCount(3, 1),
+		Prepared:       []Spell{GuidingBolt, HealingWord},
+		AlwaysPrepared: DomainSpells(classes.LifeDomain, 1),
+	})
+	s.Require().NoError(err)
+}
+
+func (s *PreparedCastingTestSuite) TestPreparedCount() {
+	s.Equal(4, PreparedCount(3, 1))
+	s.Equal(1, PreparedCount(-1, 1), "minimum of one")
+	s.Equal(9, PreparedCount(4, 5))
+}
+
+func (s *PreparedCastingTestSuite) TestDomainSpells() {
+	s.Equal([]Spell{Bless, CureWounds}, DomainSpells(classes.LifeDomain, 1))
+	s.Equal([]Spell{Bless, CureWounds}, DomainSpells(classes.LifeDomain, 5))
+	s.Empty(DomainSpells(classes.LifeDomain, 0))
+	s.Empty(DomainSpells(classes.Champion, 1), "not a domain")
+
+	for domain := range domainSpells {
+		for _, spell := range DomainSpells(domain, 1) {
+			s.NotNil(GetData(spell), "%s domain spell %s needs spell data", domain, spell)
+		}
+	}
+}
+
+func (s *PreparedCastingTestSuite) TestKnows() {
+	s.True(s.cleric.PreparesSpells())
+	s.True(s.cleric.Knows(SacredFlame), "cantrips are always available")
+	s.True(s.cleric.Knows(GuidingBolt), "prepared")
+	s.True(s.cleric.Knows(Bless), "domain spells are always prepared")
+	s.False(s.cleric.Knows(InflictWounds), "known but not prepared")
+	s.False(s.cleric.Knows(MagicMissile))
+}
+
+func (s *PreparedCastingTestSuite) TestPrepare() {
+	s.Require().NoError(s.cleric.Prepare([]Spell{InflictWounds, Bane, ShieldOfFaith, Command, Bless, CureWounds}))
+	s.True(s.cleric.Knows(InflictWounds))
+	s.False(s.cleric.Knows(GuidingBolt), "no longer prepared")
+	s.True(s.cleric.IsPrepared(CureWounds), "domain spells stay prepared")
+
+	err := s.cleric.Prepare([]Spell{GuidingBolt, InflictWounds, HealingWord, Bane, ShieldOfFaith})
+	s.Equal(rpgerr.CodeInvalidArgument, rpgerr.GetCode(err), "five spells, maximum is four")
+
+	s.Error(s.cleric.Prepare([]Spell{MagicMissile}), "not on the cleric's list")
+	s.Error(s.cleric.Prepare([]Spell{SacredFlame}), "cantrips aren't prepared")
+	s.True(s.cleric.Knows(InflictWounds), "failed preparation keeps the old spells")
+}
+
+func (s *PreparedCastingTestSuite) TestCastRequiresPreparation() {
+	ctx := context.Background()
+	bus := events.NewEventBus()
+
+	_, err := Cast(ctx, &CastInput{CasterID: "cleric-1", Spell: CureWounds, Spellcasting: s.cleric, EventBus: bus})
+	s.Require().NoError(err)
+
+	_, err = Cast(ctx, &CastInput{CasterID: "cleric-1", Spell: InflictWounds, Spellcasting: s.cleric, EventBus: bus})
+	s.Error(err)
+	s.Equal(1, s.cleric.SlotsRemaining(1))
+}
+
+func (s *PreparedCastingTestSuite) TestDataRoundTrip() {
+	data := s.cleric.ToData()
+	s.Equal(4, data.MaxPrepared)
+	s.Equal([]Spell{GuidingBolt, HealingWord}, data.Prepared)
+	s.Equal([]Spell{Bless, CureWounds}, data.AlwaysPrepared)
+
+	loaded, err := NewSpellcasting(data)
+	s.Require().NoError(err)
+	s.Equal(s.cleric, loaded)
+}
+
+func (s *PreparedCastingTestSuite) TestValidate() {
+	data := s.cleric.ToData()
+	data.Prepared = append(data.Prepared, InflictWounds, Bane, Command)
+	s.Error(data.Validate(), "too many prepared")
+
+	data = s.cleric.ToData()
+	data.MaxPrepared = 0
+	s.Error(data.Validate(), "prepared spells without a maximum")
+
+	data = s.cleric.ToData()
+	data.AlwaysPrepared = []Spell{SacredFlame}
+	s.Error(data.Validate(), "cantrips can't be always prepared")
+}
+
+func (s *PreparedCastingTestSuite) TestHalfCasterSlots() {
+	s.Empty(HalfCasterSlots(1), "half-casters have no slots at level 1")
+	s.Equal(map[int]SlotData{1: {Max: 2}}, HalfCasterSlots(2))
+	s.Equal(map[int]SlotData{1: {Max: 4}, 2: {Max: 2}}, HalfCasterSlots(5))
+	s.Equal(map[int]SlotData{1: {Max: 4}, 2: {Max: 3}, 3: {Max: 3}, 4: {Max: 1}}, HalfCasterSlots(13))
+	s.Equal(HalfCasterSlots(20), HalfCasterSlots(25), "levels past 20 use the level 20 table")
+	s.Len(HalfCasterSlots(20), 5)
+}
@@ -0,0 +1,74 @@
+package spells
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+)
+
+// BaseAttackBonus returns the spell attack bonus before items and features:
+// the spellcasting ability modifier plus the proficiency bonus.
+func BaseAttackBonus(abilityModifier, proficiencyBonus int) int {
+	return abilityModifier + proficiencyBonus
+}
+
+// BaseSaveDC returns the spell save DC before items and features:
+// 8 + the spellcasting ability modifier + the proficiency bonus.
+func BaseSaveDC(abilityModifier, proficiencyBonus int) int {
+	return 8 + abilityModifier + proficiencyBonus
+}
+
+// StatsInput provides the parameters for resolving a caster's spell attack
+// bonus and spell save DC.
+type StatsInput struct {
+	CasterID    string
+	Ability     abilities.Ability
+	AttackBonus int // Base spell attack bonus (see BaseAttackBonus)
+	SaveDC      int // Base spell save DC (see BaseSaveDC)
+	EventBus    events.EventBus
+}
+
+// Validate validates the input.
+func (s *StatsInput) Validate() error {
+	if s == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "StatsInput is nil")
+	}
+	if s.CasterID == "" {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "CasterID is required")
+	}
+	if s.EventBus == nil {
+		return rpgerr.New(rpgerr.CodeInvalidArgument, "EventBus is required")
+	}
+	return nil
+}
+
+// ResolveStats runs the caster's base spell attack bonus and save DC through
+// the SpellcastingChain so items such as a Rod of the Pact Keeper can add to
+// them. The returned event's AttackBonus and SaveDC are the final values.
+func ResolveStats(ctx context.Context, input *StatsInput) (*dnd5eEvents.SpellcastingChainEvent, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	event := &dnd5eEvents.SpellcastingChainEvent{
+		CasterID:        input.CasterID,
+		Ability:         input.Ability,
+		BaseAttackBonus: input.AttackBonus,
+		BaseSaveDC:      input.SaveDC,
+	}
+
+	statsChain := events.NewStagedChain[*dnd5eEvents.SpellcastingChainEvent](combat.ModifierStages)
+	modified, err := dnd5eEvents.SpellcastingChain.On(input.EventBus).PublishWithChain(ctx, event, statsChain)
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to publish spellcasting chain")
+	}
+	final, err := modified.Execute(ctx, event)
+	if err != nil {
+		return nil, rpgerr.Wrap(err, "failed to execute spellcasting chain")
+	}
+	return final, nil
+}
@@ -0,0 +1,113 @@
+package spells
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/chain"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/combat"
+	dnd5eEvents "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/events"
+)
+
+type StatsTestSuite struct {
+	suite.Suite
+	ctx context.Context
+	bus events.EventBus
+}
+
+func TestStatsSuite(t *testing.T) {
+	suite.Run(t, new(StatsTestSuite))
+}
+
+func (s *StatsTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = events.NewEventBus()
+}
+
+func (s *StatsTestSuite) SetupSubTest() {
+	s.SetupTest()
+}
+
+// equipRod subscribes a Rod of the Pact Keeper +1 for the caster
+func (s *StatsTestSuite) equipRod(casterID string) {
+	_, err := dnd5eEvents.SpellcastingChain.On(s.bus).SubscribeWithChain(s.ctx, func(
+		_ context.Context,
+		event *dnd5eEvents.SpellcastingChainEvent,
+		c chain.Chain[*dnd5eEvents.SpellcastingChainEvent],
+	) (chain.Chain[*dnd5eEvents.SpellcastingChainEvent], error) {
+		if event.CasterID != casterID {
+			return c, nil
+		}
+		rod := func(_ context.Context, e *dnd5eEvents.SpellcastingChainEvent) (*dnd5eEvents.SpellcastingChainEvent, error) {
+			e.BonusSources = append(e.BonusSources, dnd5eEvents.SpellcastingBonusSource{
+				SourceRef:   &core.Ref{Module: "dnd5e", Type: "items", ID: "rod_of_the_pact_keeper_1"},
+				AttackBonus: 1,
+				SaveDCBonus: 1,
+			})
+			return e, nil
+		}
+		return c, c.Add(combat.StageEquipment, "rod_of_the_pact_keeper", rod)
+	})
+	s.Require().NoError(err)
+}
+
+func (s *StatsTestSuite) TestBaseStats() {
+	// CHA 16 (+3), proficiency +2
+	s.Equal(5, BaseAttackBonus(3, 2))
+	s.Equal(13, BaseSaveDC(3, 2))
+}
+
+func (s *StatsTestSuite) TestResolveStats() {
+	s.Run("no bonuses keeps the base values", func() {
+		stats, err := ResolveStats(s.ctx, &StatsInput{
+			CasterID: "warlock-1", Ability: abilities.CHA, AttackBonus: 5, SaveDC: 13, EventBus: s.bus,
+		})
+		s.Require().NoError(err)
+		s.Equal(5, stats.AttackBonus())
+		s.Equal(13, stats.SaveDC())
+	})
+
+	s.Run("items add through the chain", func() {
+		s.equipRod("warlock-1")
+
+		stats, err := ResolveStats(s.ctx, &StatsInput{
+			CasterID: "warlock-1", Ability: abilities.CHA, AttackBonus: 5, SaveDC: 13, EventBus: s.bus,
+		})
+		s.Require().NoError(err)
+		s.Equal(6, stats.AttackBonus())
+		s.Equal(14, stats.SaveDC())
+		s.Require().Len(stats.BonusSources, 1)
+		s.Equal("rod_of_the_pact_keeper_1", stats.BonusSources[0].SourceRef.ID)
+	})
+
+	s.Run("requires an event bus", func() {
+		_, err := ResolveStats(s.ctx, &StatsInput{CasterID: "warlock-1"})
+		s.Error(err)
+	})
+}
+
+func (s *StatsTestSuite) TestCastUsesChainedStats() {
+	s.equipRod("warlock-1")
+	warlock, err := NewSpellcasting(&SpellcastingData{
+		Ability:     abilities.CHA,
+		SaveDC:      13,
+		AttackBonus: 5,
+		Known:       []Spell{FireBolt},
+	})
+	s.Require().NoError(err)
+
+	result, err := Cast(s.ctx, &CastInput{
+		CasterID:     "warlock-1",
+		Spellcasting: warlock,
+		Spell:        FireBolt,
+		EventBus:     s.bus,
+	})
+	s.Require().NoError(err)
+	s.Equal(6, result.AttackBonus)
+	s.Equal(14, result.SaveDC)
+}
@@ -164,9 +164,11 @@ const (
 	Augury            Spell = "augury"
 	Barkskin          Spell = "barkskin"
 	BlindnessDeafness Spell = "blindness-deafness"
+	HoldPerson        Spell = "hold-person"
 	LesserRestoration Spell = "lesser-restoration"
 	MagicWeapon       Spell = "magic-weapon"
 	MirrorImage       Spell = "mirror-image"
+	MistyStep         Spell = "misty-step"
 	PassWithoutTrace  Spell = "pass-without-trace"
 	SpikeGrowth       Spell = "spike-growth"
 	Suggestion        Spell = "suggestion"
@@ -293,6 +295,9 @@ var spellName = map[Spell]string{
 	Moonbeam:           "Moonbeam",
 	SpiritualWeapon:    "Spiritual Weapon",
 	FlamingSphere:      "Flaming Sphere",
+	// Level 2 Utility
+	HoldPerson: "Hold Person",
+	MistyStep:  "Misty Step",
 	// Level 3 Damage
 	Fireball:      "Fireball",
 	LightningBolt: "Lightning Bolt",
@@ -405,6 +410,8 @@ var spellSlotDescription = map[Spell]string{
 	Bless:         "Bless up to three creatures, adding 1d4 to attack rolls and saves",
 	Bane:          "Curse enemies to subtract 1d4 from attack rolls and saves",
 	ShieldOfFaith: "Shimmering field grants +2 AC for 10 minutes",
+	HoldPerson:    "Paralyze a humanoid that fails a Wisdom save",
+	MistyStep:     "Teleport up to 30 feet to an unoccupied space you can see",
 }
 
 // Description returns a brief description of the spell's effect
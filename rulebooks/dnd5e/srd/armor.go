@@ -0,0 +1,56 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package srd
+
+import (
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/armor"
+)
+
+// Armor is an SRD armor or shield
+type Armor struct {
+	Index               armor.ArmorID       `json:"index"`
+	Name                string              `json:"name"`
+	Category            armor.ArmorCategory `json:"category"`
+	AC                  int                 `json:"ac"`                      // base AC, or the bonus for a shield
+	MaxDexBonus         *int                `json:"max_dex_bonus,omitempty"` // nil means unlimited
+	Strength            int                 `json:"strength,omitempty"`      // minimum Strength score
+	StealthDisadvantage bool                `json:"stealth_disadvantage,omitempty"`
+	Weight              int                 `json:"weight"`
+	Cost                string              `json:"cost"`
+}
+
+var armorTable = &table[Armor]{
+	file:     "data/armor.json",
+	index:    func(a *Armor) string { return a.Index },
+	coverage: "every SRD armor and the shield",
+}
+
+// AllArmor returns every SRD armor, light to heavy, then the shield
+func AllArmor() ([]Armor, error) {
+	return armorTable.all()
+}
+
+// GetArmor returns an SRD armor by index, e.g. armor.ChainMail
+func GetArmor(index armor.ArmorID) (Armor, error) {
+	return armorTable.get(index)
+}
+
+// ToArmor converts the record to the rulebook's armor
+func (a Armor) ToArmor() armor.Armor {
+	result := armor.Armor{
+		ID:                  a.Index,
+		Name:                a.Name,
+		Category:            a.Category,
+		AC:                  a.AC,
+		Strength:            a.Strength,
+		StealthDisadvantage: a.StealthDisadvantage,
+		Weight:              a.Weight,
+		Cost:                a.Cost,
+	}
+	if a.MaxDexBonus != nil {
+		maxDex := *a.MaxDexBonus
+		result.MaxDexBonus = &maxDex
+	}
+	return result
+}
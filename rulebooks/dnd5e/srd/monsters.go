@@ -0,0 +1,105 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package srd
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster/monsters"
+)
+
+// Monster is the summary of an SRD monster. The full stat block is the
+// dnd5eapi.co document returned by JSON.
+type Monster struct {
+	Index           string
+	Name            string
+	Size            string // "Small", "Medium", "Large", ...
+	Type            string // "undead", "beast", "humanoid", ...
+	Alignment       string
+	ArmorClass      int
+	HitPoints       int
+	HitDice         string
+	ChallengeRating float64
+	XP              int
+
+	document json.RawMessage
+}
+
+// UnmarshalJSON reads the summary from a dnd5eapi.co monster document and
+// keeps the document.
+func (m *Monster) UnmarshalJSON(data []byte) error {
+	var doc struct {
+		Index      string `json:"index"`
+		Name       string `json:"name"`
+		Size       string `json:"size"`
+		Type       string `json:"type"`
+		Alignment  string `json:"alignment"`
+		ArmorClass []struct {
+			Value int `json:"value"`
+		} `json:"armor_class"`
+		HitPoints       int     `json:"hit_points"`
+		HitDice         string  `json:"hit_dice"`
+		ChallengeRating float64 `json:"challenge_rating"`
+		XP              int     `json:"xp"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+
+	*m = Monster{
+		Index:           doc.Index,
+		Name:            doc.Name,
+		Size:            doc.Size,
+		Type:            doc.Type,
+		Alignment:       doc.Alignment,
+		HitPoints:       doc.HitPoints,
+		HitDice:         doc.HitDice,
+		ChallengeRating: doc.ChallengeRating,
+		XP:              doc.XP,
+		document:        slices.Clone(data),
+	}
+	if len(doc.ArmorClass) > 0 {
+		m.ArmorClass = doc.ArmorClass[0].Value
+	}
+	return nil
+}
+
+// JSON returns the monster's dnd5eapi.co document
+func (m Monster) JSON() json.RawMessage {
+	return slices.Clone(m.document)
+}
+
+var monsterTable = &table[Monster]{
+	file:     "data/monsters.json",
+	index:    func(m *Monster) string { return m.Index },
+	coverage: "a subset of low-CR monsters",
+}
+
+// Monsters returns every embedded SRD monster, by name
+func Monsters() ([]Monster, error) {
+	return monsterTable.all()
+}
+
+// GetMonster returns an SRD monster by index, e.g. "goblin". Monsters
+// outside the embedded subset return a CodeNotFound error.
+func GetMonster(index string) (Monster, error) {
+	return monsterTable.get(index)
+}
+
+// NewMonster creates a monster with an ID from an SRD stat block, through
+// monsters.FromAPIJSON
+func NewMonster(id, index string) (*monster.Monster, error) {
+	m, err := monsterTable.get(index)
+	if err != nil {
+		return nil, err
+	}
+	created, err := monsters.FromAPIJSON(id, m.document)
+	if err != nil {
+		return nil, rpgerr.Wrapf(err, "failed to create SRD monster %s", index)
+	}
+	return created, nil
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package srd
+
+import (
+	"slices"
+
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/classes"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/spells"
+)
+
+// Spell is an SRD spell's reference data
+type Spell struct {
+	Index         spells.Spell      `json:"index"`
+	Name          string            `json:"name"`
+	Level         int               `json:"level"` // 0 for cantrips
+	School        string            `json:"school"`
+	CastingTime   string            `json:"casting_time"` // "1 action", "1 bonus action", "1 reaction", "1 minute"
+	Range         string            `json:"range"`        // "120 feet", "Touch", "Self (15-foot cone)"
+	Components    []string          `json:"components"`   // "V", "S", "M"
+	Material      string            `json:"material,omitempty"`
+	Duration      string            `json:"duration"`
+	Concentration bool              `json:"concentration,omitempty"`
+	Ritual        bool              `json:"ritual,omitempty"`
+	Classes       []classes.Class   `json:"classes"`
+	Damage        *SpellDamage      `json:"damage,omitempty"`    // base damage at the spell's level
+	HealDice      string            `json:"heal_dice,omitempty"` // base healing, before the caster's modifier
+	Save          abilities.Ability `json:"save,omitempty"`      // saving throw the targets make
+	Attack        string            `json:"attack,omitempty"`    // "melee" or "ranged" for a spell attack
+	Area          *SpellArea        `json:"area,omitempty"`
+	Desc          string            `json:"desc"`
+}
+
+// SpellDamage is a spell's base damage
+type SpellDamage struct {
+	Dice string      `json:"dice"`
+	Type damage.Type `json:"type"`
+}
+
+// SpellArea is a spell's area of effect
+type SpellArea struct {
+	Type string `json:"type"` // "cone", "cube", "cylinder", "line", "sphere"
+	Size int    `json:"size"` // in feet
+}
+
+var spellTable = &table[Spell]{
+	file:     "data/spells.json",
+	index:    func(s *Spell) string { return s.Index },
+	coverage: "a subset of cantrips through 3rd-level spells",
+}
+
+// Spells returns every embedded SRD spell, by level
+func Spells() ([]Spell, error) {
+	return spellTable.all()
+}
+
+// GetSpell returns an SRD spell by index, e.g. spells.Fireball. Spells
+// outside the embedded subset return a CodeNotFound error.
+func GetSpell(index spells.Spell) (Spell, error) {
+	return spellTable.get(index)
+}
+
+// SpellsForClass returns the embedded SRD spells on a class's spell list, by
+// level
+func SpellsForClass(class classes.Class) ([]Spell, error) {
+	all, err := spellTable.all()
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(all, func(s Spell) bool {
+		return !slices.Contains(s.Classes, class)
+	}), nil
+}
+
+// IsCantrip returns true for a level 0 spell
+func (s Spell) IsCantrip() bool {
+	return s.Level == 0
+}
+
+// HasComponent returns true if casting the spell needs a component: "V",
+// "S" or "M"
+func (s Spell) HasComponent(component string) bool {
+	return slices.Contains(s.Components, component)
+}
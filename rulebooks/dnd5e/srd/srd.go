@@ -0,0 +1,100 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package srd embeds D&D 5e System Reference Document data offline. Weapons
+// and armor are complete. Spells and monsters are a starter subset, not the
+// full SRD: 52 spells from cantrips through 3rd level, and 10 low-CR
+// monsters. Anything outside the subset still has to come from dnd5eapi.co,
+// and looking it up here returns a CodeNotFound error that names the gap.
+//
+// Each data file is parsed the first time it is used, once, and shared after
+// that. Records convert to the rulebook's own types where one exists:
+//
+//	longsword, err := srd.GetWeapon(weapons.Longsword)
+//	w := longsword.ToWeapon()
+//
+//	goblin, err := srd.NewMonster("goblin-1", "goblin")
+//
+// Monsters are stored as dnd5eapi.co documents and load through
+// monsters.FromAPIJSON, so the embedded data and a document fetched from the
+// API behave the same.
+package srd
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+)
+
+//go:embed data/*.json
+var files embed.FS
+
+// table lazily parses one embedded data file of records
+type table[T any] struct {
+	file     string
+	index    func(*T) string
+	coverage string // what the file holds, for not-found errors
+
+	once    sync.Once
+	records []T
+	byIndex map[string]int
+	err     error
+}
+
+// load parses the file on first use
+func (t *table[T]) load() error {
+	t.once.Do(func() {
+		data, err := files.ReadFile(t.file)
+		if err != nil {
+			t.err = rpgerr.Wrapf(err, "failed to read %s", t.file)
+			return
+		}
+		var records []T
+		if err := json.Unmarshal(data, &records); err != nil {
+			t.err = rpgerr.Wrapf(err, "failed to parse %s", t.file)
+			return
+		}
+
+		byIndex := make(map[string]int, len(records))
+		for i := range records {
+			index := t.index(&records[i])
+			if _, ok := byIndex[index]; ok {
+				t.err = rpgerr.Newf(rpgerr.CodeInternal, "%s lists %s twice", t.file, index)
+				return
+			}
+			byIndex[index] = i
+		}
+		t.records = records
+		t.byIndex = byIndex
+	})
+	return t.err
+}
+
+// all returns a copy of every record, in file order
+func (t *table[T]) all() ([]T, error) {
+	if err := t.load(); err != nil {
+		return nil, err
+	}
+	records := make([]T, len(t.records))
+	copy(records, t.records)
+	return records, nil
+}
+
+// get returns the record with an index
+func (t *table[T]) get(index string) (T, error) {
+	var zero T
+	if err := t.load(); err != nil {
+		return zero, err
+	}
+	i, ok := t.byIndex[index]
+	if !ok {
+		msg := fmt.Sprintf("%s is not in the embedded SRD data, which holds %s", index, t.coverage)
+		return zero, rpgerr.New(rpgerr.CodeNotFound, msg,
+			rpgerr.WithMeta("file", t.file),
+			rpgerr.WithMeta("index", index))
+	}
+	return t.records[i], nil
+}
@@ -0,0 +1,171 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package srd_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/armor"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/classes"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/monster/monsters"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/spells"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/srd"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/weapons"
+)
+
+type SRDTestSuite struct {
+	suite.Suite
+}
+
+func TestSRDSuite(t *testing.T) {
+	suite.Run(t, new(SRDTestSuite))
+}
+
+func (s *SRDTestSuite) TestWeaponsMatchRulebook() {
+	all, err := srd.Weapons()
+	s.Require().NoError(err)
+	s.Len(all, len(weapons.All)-1, "every weapon but the unarmed strike")
+
+	for _, record := range all {
+		expected, err := weapons.GetByID(record.Index)
+		s.Require().NoError(err, record.Index)
+		s.Equal(expected, record.ToWeapon(), record.Index)
+	}
+
+	longsword, err := srd.GetWeapon(weapons.Longsword)
+	s.Require().NoError(err)
+	s.Equal("1d10", longsword.VersatileDamage)
+}
+
+func (s *SRDTestSuite) TestArmorMatchesRulebook() {
+	all, err := srd.AllArmor()
+	s.Require().NoError(err)
+	s.Len(all, len(armor.All))
+
+	for _, record := range all {
+		expected, ok := armor.All[record.Index]
+		s.Require().True(ok, record.Index)
+		s.Equal(expected, record.ToArmor(), record.Index)
+	}
+
+	plate, err := srd.GetArmor(armor.Plate)
+	s.Require().NoError(err)
+	s.Equal(18, plate.AC)
+	s.Equal(15, plate.Strength)
+	s.True(plate.StealthDisadvantage)
+}
+
+func (s *SRDTestSuite) TestSpells() {
+	all, err := srd.Spells()
+	s.Require().NoError(err)
+	s.NotEmpty(all)
+
+	for _, spell := range all {
+		s.NotEmpty(spell.Name, spell.Index)
+		s.NotEmpty(spell.Classes, spell.Index)
+		s.Equal(spell.Material != "", spell.HasComponent("M"), "%s material", spell.Index)
+		if data, ok := spells.SpellData[spell.Index]; ok {
+			s.Equal(data.Level, spell.Level, spell.Index)
+		}
+	}
+
+	fireball, err := srd.GetSpell(spells.Fireball)
+	s.Require().NoError(err)
+	s.Equal(3, fireball.Level)
+	s.Equal(abilities.DEX, fireball.Save)
+	s.Equal(&srd.SpellDamage{Dice: "8d6", Type: damage.Fire}, fireball.Damage)
+	s.Equal(&srd.SpellArea{Type: "sphere", Size: 20}, fireball.Area)
+
+	detectMagic, err := srd.GetSpell(spells.DetectMagic)
+	s.Require().NoError(err)
+	s.True(detectMagic.Ritual)
+	s.True(detectMagic.Concentration)
+}
+
+func (s *SRDTestSuite) TestSpellsForClass() {
+	clericSpells, err := srd.SpellsForClass(classes.Cleric)
+	s.Require().NoError(err)
+
+	var indexes []spells.Spell
+	for _, spell := range clericSpells {
+		indexes = append(indexes, spell.Index)
+	}
+	s.Contains(indexes, spells.SacredFlame)
+	s.Contains(indexes, spells.SpiritGuardians)
+	s.NotContains(indexes, spells.FireBolt)
+	s.True(clericSpells[0].IsCantrip())
+
+	all, err := srd.Spells()
+	s.Require().NoError(err)
+	s.Greater(len(all), len(clericSpells), "filtering doesn't change the shared data")
+}
+
+func (s *SRDTestSuite) TestMonstersLoad() {
+	all, err := srd.Monsters()
+	s.Require().NoError(err)
+	s.NotEmpty(all)
+
+	for _, record := range all {
+		m, err := srd.NewMonster(record.Index+"-1", record.Index)
+		s.Require().NoError(err, record.Index)
+		s.Equal(record.Name, m.Name())
+		s.Equal(record.HitPoints, m.GetHitPoints())
+		s.Equal(record.ArmorClass, m.AC())
+		s.Positive(record.XP, record.Index)
+		s.True(json.Valid(record.JSON()), record.Index)
+	}
+}
+
+func (s *SRDTestSuite) TestMonstersMatchRulebook() {
+	constructors := map[string]func(string) *monster.Monster{
+		"bandit":     monsters.NewBanditMelee,
+		"brown-bear": monsters.NewBrownBear,
+		"ghoul":      monsters.NewGhoul,
+		"giant-rat":  monsters.NewGiantRat,
+		"skeleton":   monsters.NewSkeleton,
+		"thug":       monsters.NewThug,
+		"wolf":       monsters.NewWolf,
+		"zombie":     monsters.NewZombie,
+	}
+	for index, constructor := range constructors {
+		expected := constructor(index + "-1")
+		m, err := srd.NewMonster(index+"-1", index)
+		s.Require().NoError(err, index)
+		s.Equal(expected.GetHitPoints(), m.GetHitPoints(), index)
+		s.Equal(expected.AC(), m.AC(), index)
+		s.Equal(expected.AbilityScores(), m.AbilityScores(), index)
+		s.Equal(expected.Speed(), m.Speed(), index)
+	}
+
+	goblin, err := srd.GetMonster("goblin")
+	s.Require().NoError(err)
+	s.Equal("Small", goblin.Size)
+	s.Equal(0.25, goblin.ChallengeRating)
+	s.Equal(50, goblin.XP)
+	s.Equal("2d6", goblin.HitDice)
+}
+
+func (s *SRDTestSuite) TestNotFound() {
+	_, err := srd.GetWeapon("lightsaber")
+	s.Equal(rpgerr.CodeNotFound, rpgerr.GetCode(err))
+
+	_, err = srd.NewMonster("tarrasque-1", "tarrasque")
+	s.Equal(rpgerr.CodeNotFound, rpgerr.GetCode(err))
+}
+
+func (s *SRDTestSuite) TestNotFoundNamesTheSubset() {
+	// An SRD spell that the embedded subset leaves out
+	_, err := srd.GetSpell(spells.AnimateDead)
+	s.Require().Error(err)
+	s.Equal(rpgerr.CodeNotFound, rpgerr.GetCode(err))
+	s.Contains(err.Error(), "animate-dead")
+	s.Contains(err.Error(), "subset")
+}
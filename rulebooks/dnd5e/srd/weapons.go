@@ -0,0 +1,60 @@
+// Copyright (C) 2024 Kirk Diggler
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package srd
+
+import (
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/ammunition"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/damage"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/weapons"
+)
+
+// Weapon is an SRD weapon
+type Weapon struct {
+	Index           weapons.WeaponID         `json:"index"`
+	Name            string                   `json:"name"`
+	Category        weapons.WeaponCategory   `json:"category"`
+	Cost            string                   `json:"cost"`
+	Damage          string                   `json:"damage"`
+	VersatileDamage string                   `json:"versatile_damage,omitempty"` // two-handed damage of a versatile weapon
+	DamageType      damage.Type              `json:"damage_type"`
+	Weight          float64                  `json:"weight"`
+	Properties      []weapons.WeaponProperty `json:"properties,omitempty"`
+	Range           *weapons.Range           `json:"range,omitempty"`
+	Ammunition      ammunition.Type          `json:"ammunition,omitempty"`
+}
+
+var weaponTable = &table[Weapon]{
+	file:     "data/weapons.json",
+	index:    func(w *Weapon) string { return w.Index },
+	coverage: "every SRD simple and martial weapon",
+}
+
+// Weapons returns every SRD weapon, simple then martial, melee then ranged
+func Weapons() ([]Weapon, error) {
+	return weaponTable.all()
+}
+
+// GetWeapon returns an SRD weapon by index, e.g. weapons.Longsword
+func GetWeapon(index weapons.WeaponID) (Weapon, error) {
+	return weaponTable.get(index)
+}
+
+// ToWeapon converts the record to the rulebook's weapon
+func (w Weapon) ToWeapon() weapons.Weapon {
+	weapon := weapons.Weapon{
+		ID:             w.Index,
+		Name:           w.Name,
+		Category:       w.Category,
+		Cost:           w.Cost,
+		Damage:         w.Damage,
+		DamageType:     w.DamageType,
+		Weight:         w.Weight,
+		Properties:     append([]weapons.WeaponProperty{}, w.Properties...),
+		AmmunitionType: w.Ammunition,
+	}
+	if w.Range != nil {
+		weapon.Range = &weapons.Range{Normal: w.Range.Normal, Long: w.Range.Long}
+	}
+	return weapon
+}
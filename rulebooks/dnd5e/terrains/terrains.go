@@ -0,0 +1,57 @@
+// Package terrains provides D&D 5e favored terrain definitions
+package terrains
+
+import "github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+
+// Terrain represents a favored terrain a ranger can choose with Natural Explorer
+type Terrain = shared.SelectionID
+
+// Terrain constants
+const (
+	Arctic    Terrain = "arctic"
+	Coast     Terrain = "coast"
+	Desert    Terrain = "desert"
+	Forest    Terrain = "forest"
+	Grassland Terrain = "grassland"
+	Mountain  Terrain = "mountain"
+	Swamp     Terrain = "swamp"
+	Underdark Terrain = "underdark"
+)
+
+// Name returns the display name of the terrain
+func Name(t Terrain) string {
+	switch t {
+	case Arctic:
+		return "Arctic"
+	case Coast:
+		return "Coast"
+	case Desert:
+		return "Desert"
+	case Forest:
+		return "Forest"
+	case Grassland:
+		return "Grassland"
+	case Mountain:
+		return "Mountain"
+	case Swamp:
+		return "Swamp"
+	case Underdark:
+		return "Underdark"
+	default:
+		return t
+	}
+}
+
+// All returns all available favored terrains
+func All() []Terrain {
+	return []Terrain{
+		Arctic,
+		Coast,
+		Desert,
+		Forest,
+		Grassland,
+		Mountain,
+		Swamp,
+		Underdark,
+	}
+}
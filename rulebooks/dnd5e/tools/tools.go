@@ -112,9 +112,8 @@ func (t *Tool) EquipmentWeight() float32 {
 
 // EquipmentValue returns the value in copper pieces
 func (t *Tool) EquipmentValue() int {
-	// TODO: Parse cost string (e.g., "25 gp") and convert to copper
-	// For now, return a placeholder
-	return 0
+	value, _ := shared.ParseCost(t.Cost)
+	return value
 }
 
 // EquipmentDescription returns a description of the tool
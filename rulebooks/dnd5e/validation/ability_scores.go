@@ -0,0 +1,127 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/character"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/race"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+)
+
+// AbilityScoreMethod is how ability scores were generated
+type AbilityScoreMethod = shared.AbilityScoreMethod
+
+const (
+	// MethodAny accepts scores from any method
+	MethodAny AbilityScoreMethod = ""
+
+	// MethodStandardArray assigns 15, 14, 13, 12, 10 and 8
+	MethodStandardArray = shared.AbilityScoreMethodStandardArray
+
+	// MethodPointBuy buys scores of 8-15 with 27 points
+	MethodPointBuy = shared.AbilityScoreMethodPointBuy
+
+	// MethodRolled rolls each score (4d6, drop the lowest: 3-18)
+	MethodRolled = shared.AbilityScoreMethodRolled
+)
+
+// ValidateAbilityScores checks the character's ability scores are legal: 1-20
+// after racial increases, and, at levels 1-3, base scores the method could
+// produce. Base scores are the final scores less the race's and subrace's
+// increases; increases a player chooses (half-elf, variant human) aren't in
+// the race data, so with MethodAny a mismatch is only a warning. From level 4
+// Ability Score Improvements change the scores and the method isn't checked.
+func ValidateAbilityScores(data *character.Data, raceData *race.Data, method AbilityScoreMethod) []Issue {
+	var issues []Issue
+	add := func(severity Severity, format string, args ...any) {
+		issues = append(issues, issue(severity, SourceAbilityScores, "ability_scores", format, args...))
+	}
+
+	for _, ability := range abilities.List() {
+		score, ok := data.AbilityScores[ability]
+		switch {
+		case !ok:
+			add(SeverityError, "missing %s score", ability.Display())
+		case score < 1 || score > 20:
+			add(SeverityError, "%s %d is outside 1-20", ability.Display(), score)
+		}
+	}
+	if len(issues) > 0 || data.Level > 3 {
+		return issues
+	}
+
+	base := baseScores(data, raceData)
+	matched := matchingMethods(base)
+	if method == MethodAny {
+		switch {
+		case len(matched) > 0:
+			add(SeverityInfo, "base scores %s match %s", formatScores(base), matched[0])
+		default:
+			add(SeverityWarning, "base scores %s match no ability score method", formatScores(base))
+		}
+		return issues
+	}
+
+	if err := shared.ValidateAbilityScoreMethod(method, base); err != nil {
+		add(SeverityError, "base scores %s aren't legal for %s: %v", formatScores(base), method, err)
+		return issues
+	}
+	if method == MethodPointBuy {
+		if spent, _ := shared.PointBuyTotal(base); spent < shared.PointBuyBudget {
+			add(SeverityWarning, "point buy spends %d of %d points", spent, shared.PointBuyBudget)
+		}
+	}
+	return issues
+}
+
+// baseScores returns the scores before the race's and subrace's increases
+func baseScores(data *character.Data, raceData *race.Data) shared.AbilityScores {
+	base := make(shared.AbilityScores, len(data.AbilityScores))
+	for ability, score := range data.AbilityScores {
+		base[ability] = score
+	}
+	if raceData == nil {
+		return base
+	}
+
+	for ability, bonus := range raceData.AbilityScoreIncreases {
+		base[ability] -= bonus
+	}
+	if subrace := findSubrace(raceData, data); subrace != nil {
+		for ability, bonus := range subrace.AbilityScoreIncreases {
+			base[ability] -= bonus
+		}
+	}
+	return base
+}
+
+// matchingMethods returns the methods that could produce base scores, most
+// restrictive first
+func matchingMethods(base shared.AbilityScores) []AbilityScoreMethod {
+	var methods []AbilityScoreMethod
+	for _, method := range []AbilityScoreMethod{MethodStandardArray, MethodPointBuy, MethodRolled} {
+		if shared.ValidateAbilityScoreMethod(method, base) == nil {
+			methods = append(methods, method)
+		}
+	}
+	return methods
+}
+
+// formatScores renders scores in standard order, e.g. "STR 15, DEX 14, ..."
+func formatScores(scores shared.AbilityScores) string {
+	parts := make([]string, 0, len(scores))
+	for _, ability := range abilities.List() {
+		parts = append(parts, fmt.Sprintf("%s %d", strings.ToUpper(string(ability)), scores[ability]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// modifier returns the ability modifier for a score, rounding down
+func modifier(score int) int {
+	if score < 10 {
+		return (score - 11) / 2
+	}
+	return (score - 10) / 2
+}
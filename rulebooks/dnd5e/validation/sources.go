@@ -0,0 +1,291 @@
+package validation
+
+import (
+	"slices"
+
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/backgrounds"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/character"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/class"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/classes"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/languages"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/race"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
+)
+
+// ValidateClass checks a character has what its class grants: saving
+// throws, armor and weapon proficiencies, its class skill picks, a legal
+// subclass, and hit points the class's hit die allows
+func ValidateClass(data *character.Data, classData *class.Data) []Issue {
+	var issues []Issue
+	add := func(severity Severity, field, format string, args ...any) {
+		issues = append(issues, issue(severity, SourceClass, field, format, args...))
+	}
+
+	if data.ClassID != classData.ID {
+		add(SeverityError, "class_id", "character is a %s, validated against %s", data.ClassID, classData.ID)
+		return issues
+	}
+	if data.Level < 1 || data.Level > 20 {
+		add(SeverityError, "level", "level %d is outside 1-20", data.Level)
+	}
+
+	for _, ability := range classData.SavingThrows {
+		if data.SavingThrows[ability] < shared.Proficient {
+			add(SeverityError, "saving_throws", "missing %s saving throw proficiency", ability.Display())
+		}
+	}
+	for _, ability := range abilities.List() {
+		if data.SavingThrows[ability] >= shared.Proficient && !slices.Contains(classData.SavingThrows, ability) {
+			add(SeverityWarning, "saving_throws", "%s saving throw proficiency isn't from the class", ability.Display())
+		}
+	}
+
+	for _, armor := range classData.ArmorProficiencies {
+		if !slices.Contains(data.ArmorProficiencies, armor) {
+			add(SeverityError, "armor_proficiencies", "missing %s armor proficiency", armor)
+		}
+	}
+	for _, weapon := range classData.WeaponProficiencies {
+		if !slices.Contains(data.WeaponProficiencies, weapon) {
+			add(SeverityError, "weapon_proficiencies", "missing %s weapon proficiency", weapon)
+		}
+	}
+
+	if chosen := proficientIn(data, classData.SkillOptions); len(chosen) < classData.SkillProficiencyCount {
+		add(SeverityError, "skills", "class grants %d skills from its list, character has %d",
+			classData.SkillProficiencyCount, len(chosen))
+	}
+
+	issues = append(issues, validateSubclass(data, classData)...)
+	issues = append(issues, validateHitPoints(data, classData)...)
+	return issues
+}
+
+// validateSubclass checks the subclass is one of the class's, and chosen once
+// the class grants it
+func validateSubclass(data *character.Data, classData *class.Data) []Issue {
+	if data.SubclassID == "" {
+		if classData.SubclassLevel > 0 && data.Level >= classData.SubclassLevel {
+			return []Issue{issue(SeverityWarning, SourceClass, "subclass_id",
+				"no subclass chosen, %s grants one at level %d", classes.Name(classData.ID), classData.SubclassLevel)}
+		}
+		return nil
+	}
+
+	if classData.SubclassLevel > 0 && data.Level < classData.SubclassLevel {
+		return []Issue{issue(SeverityError, SourceClass, "subclass_id",
+			"subclass %s chosen before level %d", data.SubclassID, classData.SubclassLevel)}
+	}
+	if len(classData.Subclasses) > 0 && !slices.ContainsFunc(classData.Subclasses, func(sub class.SubclassData) bool {
+		return sub.ID == data.SubclassID
+	}) {
+		return []Issue{issue(SeverityError, SourceClass, "subclass_id",
+			"%s is not a %s subclass", data.SubclassID, classes.Name(classData.ID))}
+	}
+	return nil
+}
+
+// validateHitPoints checks maximum hit points are within what the hit die
+// and Constitution allow: the full hit die at level 1, at least 1 per level,
+// and at most the maximum roll every level. Traits such as Dwarven Toughness
+// add hit points, so too many is only a warning.
+func validateHitPoints(data *character.Data, classData *class.Data) []Issue {
+	if classData.HitDice == 0 || data.Level < 1 {
+		return nil
+	}
+
+	conModifier := modifier(data.AbilityScores[abilities.CON])
+	highest := data.Level * (classData.HitDice + conModifier)
+	lowest := max(data.Level, data.Level*(1+conModifier))
+	if data.Level == 1 {
+		lowest = max(1, highest)
+	}
+	switch {
+	case data.MaxHitPoints > highest:
+		return []Issue{issue(SeverityWarning, SourceClass, "max_hit_points",
+			"%d maximum hit points exceeds the d%d hit die's %d at level %d",
+			data.MaxHitPoints, classData.HitDice, highest, data.Level)}
+	case data.MaxHitPoints < lowest:
+		return []Issue{issue(SeverityError, SourceClass, "max_hit_points",
+			"%d maximum hit points is below the minimum %d at level %d", data.MaxHitPoints, lowest, data.Level)}
+	}
+	return nil
+}
+
+// ValidateRace checks a character has what its race and subrace grant: a
+// legal subrace, languages, and skill and weapon proficiencies
+func ValidateRace(data *character.Data, raceData *race.Data) []Issue {
+	var issues []Issue
+	add := func(severity Severity, field, format string, args ...any) {
+		issues = append(issues, issue(severity, SourceRace, field, format, args...))
+	}
+
+	if data.RaceID != raceData.ID {
+		add(SeverityError, "race_id", "character is a %s, validated against %s", data.RaceID, raceData.ID)
+		return issues
+	}
+
+	subrace := findSubrace(raceData, data)
+	switch {
+	case data.SubraceID != "" && subrace == nil:
+		add(SeverityError, "subrace_id", "%s is not a %s subrace", data.SubraceID, raceData.Name)
+	case data.SubraceID == "" && len(raceData.Subraces) > 0:
+		add(SeverityWarning, "subrace_id", "no subrace chosen for %s", raceData.Name)
+	}
+
+	for _, language := range raceData.Languages {
+		if !slices.Contains(data.Languages, language) {
+			add(SeverityError, "languages", "missing %s, granted by the race", languages.Display(language))
+		}
+	}
+	for _, skill := range raceData.SkillProficiencies {
+		if data.Skills[skill] < shared.Proficient {
+			add(SeverityError, "skills", "missing %s, granted by the race", skills.Display(skill))
+		}
+	}
+
+	weapons := raceData.WeaponProficiencies
+	if subrace != nil {
+		weapons = append(slices.Clone(weapons), subrace.WeaponProficiencies...)
+		for _, armor := range subrace.ArmorProficiencies {
+			if !slices.Contains(data.ArmorProficiencies, armor) {
+				add(SeverityError, "armor_proficiencies", "missing %s armor proficiency, granted by the subrace", armor)
+			}
+		}
+	}
+	for _, weapon := range weapons {
+		if !slices.Contains(data.WeaponProficiencies, weapon) {
+			add(SeverityError, "weapon_proficiencies", "missing %s weapon proficiency, granted by the race", weapon)
+		}
+	}
+
+	if raceData.LanguageChoice != nil {
+		want := len(raceData.Languages) + raceData.LanguageChoice.Choose
+		if len(data.Languages) < want {
+			add(SeverityError, "languages", "race grants %d languages, character knows %d", want, len(data.Languages))
+		}
+	}
+	return issues
+}
+
+// findSubrace returns the character's subrace data, or nil
+func findSubrace(raceData *race.Data, data *character.Data) *race.SubraceData {
+	for i := range raceData.Subraces {
+		if raceData.Subraces[i].ID == data.SubraceID {
+			return &raceData.Subraces[i]
+		}
+	}
+	return nil
+}
+
+// ValidateBackground checks a character has the skills its background grants.
+// The languages it grants are counted with the race's (see ValidateCharacter).
+func ValidateBackground(data *character.Data, backgroundData *backgrounds.Data) []Issue {
+	var issues []Issue
+	add := func(severity Severity, field, format string, args ...any) {
+		issues = append(issues, issue(severity, SourceBackground, field, format, args...))
+	}
+
+	if data.BackgroundID != backgroundData.ID {
+		add(SeverityError, "background_id", "character has the %s background, validated against %s",
+			data.BackgroundID, backgroundData.ID)
+		return issues
+	}
+
+	// A background listing exactly as many skills as it grants grants them all;
+	// a longer list is a pick (customized backgrounds)
+	if len(backgroundData.Skills) == backgroundData.SkillCount {
+		for _, skill := range backgroundData.Skills {
+			if data.Skills[skill] < shared.Proficient {
+				add(SeverityError, "skills", "missing %s, granted by the background", skills.Display(skill))
+			}
+		}
+	} else if chosen := proficientIn(data, backgroundData.Skills); len(chosen) < backgroundData.SkillCount {
+		add(SeverityError, "skills", "background grants %d skills from its list, character has %d",
+			backgroundData.SkillCount, len(chosen))
+	}
+	return issues
+}
+
+// validateCrossSource checks the sources against each other. In 5e a
+// proficiency granted twice is a wasted grant: the player should have picked
+// a replacement, so duplicates are warnings.
+func validateCrossSource(
+	data *character.Data,
+	raceData *race.Data,
+	classData *class.Data,
+	backgroundData *backgrounds.Data,
+) []Issue {
+	var issues []Issue
+	add := func(severity Severity, field, format string, args ...any) {
+		issues = append(issues, issue(severity, SourceCrossSource, field, format, args...))
+	}
+
+	// Fixed skill grants, by source
+	grants := make(map[skills.Skill][]Source)
+	if raceData != nil {
+		for _, skill := range raceData.SkillProficiencies {
+			grants[skill] = append(grants[skill], SourceRace)
+		}
+	}
+	if backgroundData != nil && len(backgroundData.Skills) == backgroundData.SkillCount {
+		for _, skill := range backgroundData.Skills {
+			grants[skill] = append(grants[skill], SourceBackground)
+		}
+	}
+	for _, skill := range skills.List() {
+		if sources := grants[skill]; len(sources) > 1 {
+			add(SeverityWarning, "skills", "%s is granted by both %s and %s; choose a replacement skill",
+				skills.Display(skill), sources[0], sources[1])
+		}
+	}
+
+	// With the background's skills fixed, class skill picks should avoid them
+	if classData != nil && backgroundData != nil && len(backgroundData.Skills) == backgroundData.SkillCount {
+		available := slices.DeleteFunc(slices.Clone(classData.SkillOptions), func(skill skills.Skill) bool {
+			return slices.Contains(backgroundData.Skills, skill)
+		})
+		if chosen := proficientIn(data, available); len(chosen) < classData.SkillProficiencyCount {
+			add(SeverityWarning, "skills",
+				"class skill picks overlap the background's skills: %d of %d picks are distinct",
+				len(chosen), classData.SkillProficiencyCount)
+		}
+	}
+
+	// Languages chosen from the race and background add up
+	if raceData != nil && backgroundData != nil {
+		want := len(raceData.Languages) + backgroundData.LanguageCount
+		if raceData.LanguageChoice != nil {
+			want += raceData.LanguageChoice.Choose
+		}
+		if len(data.Languages) < want {
+			add(SeverityError, "languages", "race and background grant %d languages, character knows %d",
+				want, len(data.Languages))
+		}
+	}
+
+	for i, language := range data.Languages {
+		if slices.Contains(data.Languages[:i], language) {
+			add(SeverityWarning, "languages", "%s is listed more than once", languages.Display(language))
+		}
+	}
+	for i, tool := range data.ToolProficiencies {
+		if slices.Contains(data.ToolProficiencies[:i], tool) {
+			add(SeverityWarning, "tool_proficiencies", "%s is listed more than once", tool)
+		}
+	}
+	return issues
+}
+
+// proficientIn returns the skills from a list the character is proficient in
+func proficientIn(data *character.Data, list []skills.Skill) []skills.Skill {
+	var proficient []skills.Skill
+	for _, skill := range list {
+		if data.Skills[skill] >= shared.Proficient && !slices.Contains(proficient, skill) {
+			proficient = append(proficient, skill)
+		}
+	}
+	return proficient
+}
@@ -0,0 +1,166 @@
+// Package validation checks a finished D&D 5e character against the race,
+// class and background it was built from. Each source is validated on its
+// own, then the sources are checked against each other: proficiencies granted
+// twice, and ability scores no generation method could produce.
+//
+// Issues come in three severities. Errors make the character illegal,
+// warnings flag something legal but likely a mistake, and infos note what
+// the validator noticed without judging it.
+package validation
+
+import (
+	"fmt"
+
+	"github.com/KirkDiggler/rpg-toolkit/rpgerr"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/backgrounds"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/character"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/class"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/race"
+)
+
+// Severity is how serious an issue is
+type Severity string
+
+const (
+	// SeverityError means the character breaks the rules
+	SeverityError Severity = "error"
+
+	// SeverityWarning means the character is legal but likely has a mistake,
+	// e.g. a proficiency granted twice that should have been replaced
+	SeverityWarning Severity = "warning"
+
+	// SeverityInfo is a note, e.g. which ability score method the scores match
+	SeverityInfo Severity = "info"
+)
+
+// Source is where the rule an issue checks comes from
+type Source string
+
+const (
+	// SourceClass issues check the class's grants
+	SourceClass Source = "class"
+
+	// SourceRace issues check the race's and subrace's grants
+	SourceRace Source = "race"
+
+	// SourceBackground issues check the background's grants
+	SourceBackground Source = "background"
+
+	// SourceAbilityScores issues check how the ability scores were generated
+	SourceAbilityScores Source = "ability_scores"
+
+	// SourceCrossSource issues involve more than one source
+	SourceCrossSource Source = "cross_source"
+)
+
+// Issue is one problem or note found in a character
+type Issue struct {
+	Severity Severity `json:"severity"`
+	Source   Source   `json:"source"`
+	Field    string   `json:"field"` // e.g. "skills", "saving_throws", "ability_scores"
+	Message  string   `json:"message"`
+}
+
+// String renders the issue as "error [class] skills: message"
+func (i Issue) String() string {
+	return fmt.Sprintf("%s [%s] %s: %s", i.Severity, i.Source, i.Field, i.Message)
+}
+
+// Result holds every issue found in a character
+type Result struct {
+	Issues []Issue `json:"issues"`
+}
+
+// Valid reports whether the character has no errors. Warnings and infos
+// don't make a character invalid.
+func (r *Result) Valid() bool {
+	return len(r.Errors()) == 0
+}
+
+// Errors returns the issues that make the character illegal
+func (r *Result) Errors() []Issue {
+	return r.BySeverity(SeverityError)
+}
+
+// Warnings returns the issues that are legal but likely mistakes
+func (r *Result) Warnings() []Issue {
+	return r.BySeverity(SeverityWarning)
+}
+
+// Infos returns the notes
+func (r *Result) Infos() []Issue {
+	return r.BySeverity(SeverityInfo)
+}
+
+// BySeverity returns the issues of a severity, in the order they were found
+func (r *Result) BySeverity(severity Severity) []Issue {
+	var issues []Issue
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// Config configures a Validator
+type Config struct {
+	// Method is the ability score method the table uses. The zero value
+	// accepts any method and only notes which one the scores match.
+	Method AbilityScoreMethod
+}
+
+// Validator validates characters against their sources
+type Validator struct {
+	method AbilityScoreMethod
+}
+
+// NewValidator creates a validator
+func NewValidator(config Config) *Validator {
+	return &Validator{method: config.Method}
+}
+
+// ValidateCharacter validates a character with the default configuration,
+// accepting ability scores from any method
+func ValidateCharacter(
+	data *character.Data,
+	raceData *race.Data,
+	classData *class.Data,
+	backgroundData *backgrounds.Data,
+) (*Result, error) {
+	return NewValidator(Config{}).ValidateCharacter(data, raceData, classData, backgroundData)
+}
+
+// ValidateCharacter runs the class, race and background validators together,
+// then checks for proficiencies granted by more than one source and for
+// ability scores the configured method couldn't produce. A nil source is
+// skipped.
+func (v *Validator) ValidateCharacter(
+	data *character.Data,
+	raceData *race.Data,
+	classData *class.Data,
+	backgroundData *backgrounds.Data,
+) (*Result, error) {
+	if data == nil {
+		return nil, rpgerr.New(rpgerr.CodeInvalidArgument, "character data is required")
+	}
+
+	result := &Result{}
+	if classData != nil {
+		result.Issues = append(result.Issues, ValidateClass(data, classData)...)
+	}
+	if raceData != nil {
+		result.Issues = append(result.Issues, ValidateRace(data, raceData)...)
+	}
+	if backgroundData != nil {
+		result.Issues = append(result.Issues, ValidateBackground(data, backgroundData)...)
+	}
+	result.Issues = append(result.Issues, validateCrossSource(data, raceData, classData, backgroundData)...)
+	result.Issues = append(result.Issues, ValidateAbilityScores(data, raceData, v.method)...)
+	return result, nil
+}
+
+// issue builds an issue
+func issue(severity Severity, source Source, field, format string, args ...any) Issue {
+	return Issue{Severity: severity, Source: source, Field: field, Message: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,226 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/abilities"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/backgrounds"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/character"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/class"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/classes"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/languages"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/proficiencies"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/race"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/races"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/shared"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/skills"
+	"github.com/KirkDiggler/rpg-toolkit/rulebooks/dnd5e/validation"
+)
+
+// ValidationTestSuite tests validating a character against its sources
+type ValidationTestSuite struct {
+	suite.Suite
+	data           *character.Data
+	raceData       *race.Data
+	classData      *class.Data
+	backgroundData *backgrounds.Data
+}
+
+func TestValidationSuite(t *testing.T) {
+	suite.Run(t, new(ValidationTestSuite))
+}
+
+// SetupTest builds a legal level 1 human fighter soldier with the standard array
+func (s *ValidationTestSuite) SetupTest() {
+	s.raceData = &race.Data{
+		ID:   races.Human,
+		Name: "Human",
+		AbilityScoreIncreases: map[abilities.Ability]int{
+			abilities.STR: 1, abilities.DEX: 1, abilities.CON: 1,
+			abilities.INT: 1, abilities.WIS: 1, abilities.CHA: 1,
+		},
+		Languages:      []languages.Language{languages.Common},
+		LanguageChoice: &race.ChoiceData{ID: "human-language", Type: "language", Choose: 1},
+	}
+	s.classData = &class.Data{
+		ID:                    classes.Fighter,
+		Name:                  "Fighter",
+		HitDice:               10,
+		ArmorProficiencies:    []proficiencies.Armor{proficiencies.ArmorLight, proficiencies.ArmorHeavy},
+		WeaponProficiencies:   []proficiencies.Weapon{proficiencies.WeaponMartial},
+		SavingThrows:          []abilities.Ability{abilities.STR, abilities.CON},
+		SkillProficiencyCount: 2,
+		SkillOptions: []skills.Skill{
+			skills.Acrobatics, skills.Athletics, skills.Intimidation, skills.Perception, skills.Survival,
+		},
+		SubclassLevel: 3,
+		Subclasses:    []class.SubclassData{{ID: classes.Champion, Name: "Champion"}},
+	}
+	s.backgroundData = backgrounds.BackgroundData[backgrounds.Soldier]
+
+	s.data = &character.Data{
+		Level:        1,
+		RaceID:       races.Human,
+		ClassID:      classes.Fighter,
+		BackgroundID: backgrounds.Soldier,
+		AbilityScores: shared.AbilityScores{
+			abilities.STR: 16, abilities.DEX: 15, abilities.CON: 14,
+			abilities.INT: 9, abilities.WIS: 13, abilities.CHA: 11,
+		},
+		MaxHitPoints: 12,
+		Skills: map[skills.Skill]shared.ProficiencyLevel{
+			skills.Athletics:    shared.Proficient,
+			skills.Intimidation: shared.Proficient,
+			skills.Perception:   shared.Proficient,
+			skills.Survival:     shared.Proficient,
+		},
+		SavingThrows: map[abilities.Ability]shared.ProficiencyLevel{
+			abilities.STR: shared.Proficient,
+			abilities.CON: shared.Proficient,
+		},
+		Languages:           []languages.Language{languages.Common, languages.Dwarvish},
+		ArmorProficiencies:  []proficiencies.Armor{proficiencies.ArmorLight, proficiencies.ArmorHeavy},
+		WeaponProficiencies: []proficiencies.Weapon{proficiencies.WeaponMartial},
+	}
+}
+
+// validate runs the validator with a method
+func (s *ValidationTestSuite) validate(method validation.AbilityScoreMethod) *validation.Result {
+	validator := validation.NewValidator(validation.Config{Method: method})
+	result, err := validator.ValidateCharacter(s.data, s.raceData, s.classData, s.backgroundData)
+	s.Require().NoError(err)
+	return result
+}
+
+// messages returns the messages of issues
+func messages(issues []validation.Issue) []string {
+	var messages []string
+	for _, issue := range issues {
+		messages = append(messages, issue.Message)
+	}
+	return messages
+}
+
+func (s *ValidationTestSuite) TestLegalCharacter() {
+	result, err := validation.ValidateCharacter(s.data, s.raceData, s.classData, s.backgroundData)
+	s.Require().NoError(err)
+
+	s.True(result.Valid(), "errors: %v", result.Errors())
+	s.Empty(result.Warnings())
+	s.Equal([]string{"base scores STR 15, DEX 14, CON 13, INT 8, WIS 12, CHA 10 match standard"},
+		messages(result.Infos()))
+}
+
+func (s *ValidationTestSuite) TestMissingClassGrants() {
+	delete(s.data.SavingThrows, abilities.CON)
+	s.data.SavingThrows[abilities.DEX] = shared.Proficient
+	s.data.WeaponProficiencies = nil
+	s.data.MaxHitPoints = 9
+
+	result := s.validate(validation.MethodAny)
+	s.False(result.Valid())
+	s.Equal([]string{
+		"missing Constitution saving throw proficiency",
+		"missing martial weapon proficiency",
+		"9 maximum hit points is below the minimum 12 at level 1",
+	}, messages(result.Errors()))
+	s.Equal([]string{"Dexterity saving throw proficiency isn't from the class"}, messages(result.Warnings()))
+	s.Equal(validation.SourceClass, result.Errors()[0].Source)
+}
+
+func (s *ValidationTestSuite) TestSubclass() {
+	s.data.SubclassID = classes.Champion
+	s.Equal([]string{"subclass champion chosen before level 3"}, messages(s.validate(validation.MethodAny).Errors()))
+
+	s.data.Level = 3
+	s.data.MaxHitPoints = 28
+	s.data.SubclassID = classes.BattleMaster
+	s.Equal([]string{"battle-master is not a Fighter subclass"}, messages(s.validate(validation.MethodAny).Errors()))
+
+	s.data.SubclassID = ""
+	s.Equal([]string{"no subclass chosen, Fighter grants one at level 3"},
+		messages(s.validate(validation.MethodAny).Warnings()))
+}
+
+func (s *ValidationTestSuite) TestCrossSourceDuplicates() {
+	s.raceData.SkillProficiencies = []skills.Skill{skills.Intimidation}
+	s.data.Languages = append(s.data.Languages, languages.Common)
+
+	result := s.validate(validation.MethodAny)
+	s.True(result.Valid(), "errors: %v", result.Errors())
+	s.Equal([]string{
+		"Intimidation is granted by both race and background; choose a replacement skill",
+		"Common is listed more than once",
+	}, messages(result.Warnings()))
+	s.Equal(validation.SourceCrossSource, result.Warnings()[0].Source)
+}
+
+func (s *ValidationTestSuite) TestClassPicksOverlapBackground() {
+	delete(s.data.Skills, skills.Survival)
+
+	result := s.validate(validation.MethodAny)
+	s.True(result.Valid(), "athletics still counts as a class pick")
+	s.Equal([]string{"class skill picks overlap the background's skills: 1 of 2 picks are distinct"},
+		messages(result.Warnings()))
+}
+
+func (s *ValidationTestSuite) TestLanguagesFromRaceAndBackground() {
+	s.backgroundData = backgrounds.BackgroundData[backgrounds.Sage]
+	s.data.BackgroundID = backgrounds.Sage
+	s.data.Skills[skills.Arcana] = shared.Proficient
+	s.data.Skills[skills.History] = shared.Proficient
+
+	result := s.validate(validation.MethodAny)
+	s.Equal([]string{"race and background grant 4 languages, character knows 2"}, messages(result.Errors()))
+}
+
+func (s *ValidationTestSuite) TestAbilityScoreMethods() {
+	s.Run("standard array is also a 27 point buy", func() {
+		s.True(s.validate(validation.MethodStandardArray).Valid())
+		s.True(s.validate(validation.MethodPointBuy).Valid())
+	})
+
+	s.Run("underspent point buy", func() {
+		s.data.AbilityScores[abilities.STR] = 15
+		result := s.validate(validation.MethodPointBuy)
+		s.True(result.Valid())
+		s.Equal([]string{"point buy spends 25 of 27 points"}, messages(result.Warnings()))
+		s.False(s.validate(validation.MethodStandardArray).Valid())
+	})
+
+	s.Run("rolled scores", func() {
+		s.data.AbilityScores[abilities.STR] = 18
+		s.True(s.validate(validation.MethodRolled).Valid())
+		s.Equal([]string{
+			"base scores STR 17, DEX 14, CON 13, INT 8, WIS 12, CHA 10 aren't legal for point_buy: point buy Strength 17 is outside 8-15",
+		}, messages(s.validate(validation.MethodPointBuy).Errors()))
+		s.Equal([]string{"base scores STR 17, DEX 14, CON 13, INT 8, WIS 12, CHA 10 match rolled"},
+			messages(s.validate(validation.MethodAny).Infos()))
+	})
+
+	s.Run("out of range", func() {
+		s.data.AbilityScores[abilities.STR] = 21
+		s.Equal([]string{"Strength 21 is outside 1-20"}, messages(s.validate(validation.MethodAny).Errors()))
+	})
+
+	s.Run("not checked after level 3", func() {
+		s.data.AbilityScores[abilities.STR] = 20
+		s.data.Level = 4
+		s.data.MaxHitPoints = 36
+		result := s.validate(validation.MethodStandardArray)
+		s.True(result.Valid(), "errors: %v", result.Errors())
+		s.Empty(result.Infos())
+	})
+}
+
+func (s *ValidationTestSuite) TestSourceMismatchAndNilSources() {
+	s.data.ClassID = classes.Wizard
+	result, err := validation.ValidateCharacter(s.data, nil, s.classData, nil)
+	s.Require().NoError(err)
+	s.Equal([]string{"character is a wizard, validated against fighter"}, messages(result.Errors()))
+
+	_, err = validation.ValidateCharacter(nil, s.raceData, s.classData, s.backgroundData)
+	s.Error(err)
+}
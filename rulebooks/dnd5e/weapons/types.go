@@ -59,6 +59,10 @@ type Weapon struct {
 	Properties     []WeaponProperty
 	Range          *Range          // nil for melee-only weapons
 	AmmunitionType ammunition.Type // Type of ammunition this weapon uses
+
+	// Annotations are carried onto the weapon's damage, e.g. magical for a +1
+	// longsword or silvered for a silvered dagger. Nil for mundane weapons.
+	Annotations []damage.Annotation
 }
 
 // EquipmentID returns the unique identifier for this weapon
@@ -83,9 +87,8 @@ func (w *Weapon) EquipmentWeight() float32 {
 
 // EquipmentValue returns the value in copper pieces
 func (w *Weapon) EquipmentValue() int {
-	// TODO: Parse cost string (e.g., "5 gp") and convert to copper
-	// For now, return a placeholder
-	return 0
+	value, _ := shared.ParseCost(w.Cost)
+	return value
 }
 
 // EquipmentDescription returns a description of the weapon
@@ -141,6 +144,46 @@ func (w Weapon) HasProperty(prop WeaponProperty) bool {
 	return false
 }
 
+// HasAnnotation returns true if the weapon's damage carries the annotation
+func (w Weapon) HasAnnotation(annotation damage.Annotation) bool {
+	return containsAnnotation(w.Annotations, annotation)
+}
+
+// IsMagical returns true if the weapon deals magical damage
+func (w Weapon) IsMagical() bool {
+	return w.HasAnnotation(damage.AnnotationMagical)
+}
+
+// IsSilvered returns true if the weapon is silvered
+func (w Weapon) IsSilvered() bool {
+	return w.HasAnnotation(damage.AnnotationSilvered)
+}
+
+// WithAnnotations returns a copy of the weapon with the annotations added,
+// e.g. weapon.WithAnnotations(damage.AnnotationMagical) for a +1 weapon.
+// The weapon data in All is shared, so annotate a copy rather than editing it.
+func (w Weapon) WithAnnotations(annotations ...damage.Annotation) Weapon {
+	merged := make([]damage.Annotation, 0, len(w.Annotations)+len(annotations))
+	merged = append(merged, w.Annotations...)
+	for _, annotation := range annotations {
+		if !containsAnnotation(merged, annotation) {
+			merged = append(merged, annotation)
+		}
+	}
+	w.Annotations = merged
+	return w
+}
+
+// containsAnnotation returns true if annotations contains annotation
+func containsAnnotation(annotations []damage.Annotation, annotation damage.Annotation) bool {
+	for _, a := range annotations {
+		if a == annotation {
+			return true
+		}
+	}
+	return false
+}
+
 // RequiresAmmunition returns true if this weapon needs ammunition to fire
 func (w Weapon) RequiresAmmunition() bool {
 	return w.HasProperty(PropertyAmmunition)
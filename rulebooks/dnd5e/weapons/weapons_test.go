@@ -186,3 +186,19 @@ func TestWeaponRanges(t *testing.T) {
 		assert.Nil(t, greatsword.Range)
 	})
 }
+
+func TestWeaponAnnotations(t *testing.T) {
+	longsword, err := weapons.GetByID("longsword")
+	require.NoError(t, err)
+	assert.False(t, longsword.IsMagical(), "weapon data is mundane")
+
+	plusOne := longsword.WithAnnotations(damage.AnnotationMagical, damage.AnnotationMagical)
+	assert.True(t, plusOne.IsMagical())
+	assert.False(t, plusOne.IsSilvered())
+	assert.Equal(t, []damage.Annotation{damage.AnnotationMagical}, plusOne.Annotations)
+
+	silvered := plusOne.WithAnnotations(damage.AnnotationSilvered)
+	assert.True(t, silvered.IsSilvered())
+	assert.Len(t, plusOne.Annotations, 1, "annotating returns a copy")
+	assert.Nil(t, weapons.All[weapons.Longsword].Annotations)
+}
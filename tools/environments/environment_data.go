@@ -1,6 +1,7 @@
 package environments
 
 import (
+	"github.com/KirkDiggler/rpg-toolkit/core/schema"
 	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
 )
 
@@ -51,6 +52,18 @@ const (
 // These structures enable full round-trip fidelity for BasicEnvironment.
 // ToData() followed by LoadFromData() produces a functionally equivalent environment.
 
+// EnvironmentDataKind identifies EnvironmentData in a schema.Envelope
+const EnvironmentDataKind = "environments.environment"
+
+// EnvironmentDataVersion is the current version of EnvironmentData. Bump it and
+// register a schema migration from the previous version when the shape changes.
+// This is independent of EnvironmentMetadata.Version, which games set freely.
+const EnvironmentDataVersion = 1
+
+func init() {
+	schema.MustRegister(EnvironmentDataKind, EnvironmentDataVersion)
+}
+
 // EnvironmentData contains all information needed to persist and reconstruct
 // a multi-zone environment with absolute coordinates.
 //
@@ -5,7 +5,7 @@ go 1.24
 toolchain go1.24.5
 
 require (
-	github.com/KirkDiggler/rpg-toolkit/core v0.9.6
+	github.com/KirkDiggler/rpg-toolkit/core v0.10.1-0.20261016192714-ce0d00a23e54
 	github.com/KirkDiggler/rpg-toolkit/events v0.6.2
 	github.com/KirkDiggler/rpg-toolkit/tools/selectables v0.1.2
 	github.com/KirkDiggler/rpg-toolkit/tools/spatial v0.4.0
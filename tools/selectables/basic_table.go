@@ -15,7 +15,8 @@ import (
 // BasicTable implements the SelectionTable interface with simple weighted selection
 // Purpose: Provides a straightforward implementation of weighted random selection
 // that supports all standard selection modes and integrates with the RPG toolkit's
-// event system for debugging and analytics.
+// event system for debugging and analytics. It also implements every optional
+// table interface, from MutableTable to SerializableTable.
 type BasicTable[T comparable] struct {
 	// Core table identity
 	id     string
@@ -25,6 +26,21 @@ type BasicTable[T comparable] struct {
 	items map[T]int
 	mutex sync.RWMutex
 
+	// Pity state for items with a PityPolicy, guarded by mutex
+	pity map[T]*pityState
+
+	// Quantity expressions for items that yield more than one, guarded by mutex
+	quantities map[T]Quantity
+
+	// Context conditions for items that are only sometimes eligible, guarded by mutex
+	conditions map[T]map[string]interface{}
+
+	// Weight functions for items whose weight depends on the context, guarded by mutex
+	weightFuncs map[T]WeightFunc
+
+	// Nothing, roll-again and nested table entries resolved by Roll, guarded by mutex
+	special []specialEntry[T]
+
 	// Connected typed topics for event publishing
 	connectedTopics struct {
 		tableCreated       events.TypedTopic[SelectionTableCreatedEvent]
@@ -39,11 +55,24 @@ type BasicTable[T comparable] struct {
 	}
 
 	// Weight calculation caching for performance
-	cachedWeights    map[string]map[T]int // keyed by context hash
+	cachedWeights    map[string]map[T]int       // keyed by context hash
+	indexes          map[string]*weightIndex[T] // keyed by context hash, for IndexedSelection
 	weightCacheMutex sync.RWMutex
 	lastModification time.Time
+	version          uint64 // counts changes to the items, guarded by mutex
 }
 
+var (
+	_ SelectionTable[string]    = (*BasicTable[string])(nil)
+	_ MutableTable[string]      = (*BasicTable[string])(nil)
+	_ PityTable[string]         = (*BasicTable[string])(nil)
+	_ QuantityTable[string]     = (*BasicTable[string])(nil)
+	_ ConditionalTable[string]  = (*BasicTable[string])(nil)
+	_ ShuffleTable[string]      = (*BasicTable[string])(nil)
+	_ RollTable[string]         = (*BasicTable[string])(nil)
+	_ SerializableTable[string] = (*BasicTable[string])(nil)
+)
+
 // BasicTableConfig provides configuration options for BasicTable creation
 // Purpose: Follows the toolkit's config pattern for clean dependency injection
 type BasicTableConfig struct {
@@ -74,7 +103,12 @@ func NewBasicTable[T comparable](config BasicTableConfig) SelectionTable[T] {
 		id:               config.ID,
 		config:           tableConfig,
 		items:            make(map[T]int),
+		pity:             make(map[T]*pityState),
+		quantities:       make(map[T]Quantity),
+		conditions:       make(map[T]map[string]interface{}),
+		weightFuncs:      make(map[T]WeightFunc),
 		cachedWeights:    make(map[string]map[T]int),
+		indexes:          make(map[string]*weightIndex[T]),
 		lastModification: time.Now(),
 	}
 
@@ -111,24 +145,15 @@ func (t *BasicTable[T]) ConnectToEventBus(bus events.EventBus) {
 // Add includes an item in the selection table with the specified weight
 // Higher weights increase the probability of selection
 func (t *BasicTable[T]) Add(item T, weight int) SelectionTable[T] {
-	if weight < t.config.MinWeight {
-		weight = t.config.MinWeight
-	}
-	if weight > t.config.MaxWeight {
-		weight = t.config.MaxWeight
-	}
+	weight = t.clampWeight(weight)
 
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
 
 	previousWeight, existed := t.items[item]
 	t.items[item] = weight
-	t.lastModification = time.Now()
-
-	// Clear weight cache since table changed
-	if t.config.CacheWeights {
-		t.clearWeightCache()
-	}
+	delete(t.weightFuncs, item)
+	t.markModified()
 
 	// Publish item added event
 	if t.config.EnableEvents {
@@ -159,13 +184,16 @@ func (t *BasicTable[T]) Add(item T, weight int) SelectionTable[T] {
 
 // AddTable includes another selection table as a nested option with the specified weight
 // This enables hierarchical selection patterns (e.g., roll category, then roll item from category)
-// Note: For BasicTable, this converts the nested table to individual items
+// Note: For BasicTable, this converts the nested table to individual items, unless
+// it has nothing or roll-again entries; then Roll rolls on it whole and Select ignores it
 func (t *BasicTable[T]) AddTable(_ string, table SelectionTable[T], weight int) SelectionTable[T] {
-	if weight < t.config.MinWeight {
-		weight = t.config.MinWeight
-	}
-	if weight > t.config.MaxWeight {
-		weight = t.config.MaxWeight
+	weight = t.clampWeight(weight)
+
+	if special, ok := withSpecialEntries(table); ok {
+		t.mutex.Lock()
+		defer t.mutex.Unlock()
+		t.special = append(t.special, specialEntry[T]{weight: weight, table: special})
+		return t
 	}
 
 	// For basic tables, we flatten nested tables by adding their items
@@ -190,6 +218,88 @@ func (t *BasicTable[T]) AddTable(_ string, table SelectionTable[T], weight int)
 	return t
 }
 
+// Remove deletes an item from the table
+// Returns true if the item was in the table
+func (t *BasicTable[T]) Remove(item T) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if _, exists := t.items[item]; !exists {
+		return false
+	}
+	delete(t.items, item)
+	delete(t.pity, item)
+	delete(t.quantities, item)
+	delete(t.conditions, item)
+	delete(t.weightFuncs, item)
+	t.markModified()
+
+	t.publishItemRemoved(item, "removed")
+	return true
+}
+
+// SetWeight changes the weight of an item already in the table, clamped like Add
+// Returns ErrInvalidWeight if weight is less than 1
+// Returns ErrItemNotFound if the item is not in the table
+func (t *BasicTable[T]) SetWeight(item T, weight int) error {
+	if weight < 1 {
+		return NewSelectionError("set_weight", t.id, nil, ErrInvalidWeight).
+			AddDetail("weight", weight)
+	}
+	weight = t.clampWeight(weight)
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	previousWeight, exists := t.items[item]
+	if !exists {
+		return NewSelectionError("set_weight", t.id, nil, ErrItemNotFound).
+			AddDetail("item", fmt.Sprintf("%v", item))
+	}
+	_, dynamic := t.weightFuncs[item]
+	if previousWeight == weight && !dynamic {
+		return nil
+	}
+	t.items[item] = weight
+	delete(t.weightFuncs, item)
+	t.markModified()
+
+	if t.config.EnableEvents && t.connectedTopics.weightChanged != nil {
+		event := WeightChangedEvent{
+			TableID:   t.id,
+			ItemID:    fmt.Sprintf("%v", item),
+			OldWeight: previousWeight,
+			NewWeight: weight,
+			ChangedAt: time.Now(),
+		}
+		_ = t.connectedTopics.weightChanged.Publish(context.Background(), event)
+	}
+
+	return nil
+}
+
+// Clear removes every item from the table
+func (t *BasicTable[T]) Clear() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if len(t.items) == 0 && len(t.special) == 0 {
+		return
+	}
+	removed := t.items
+	t.items = make(map[T]int)
+	t.pity = make(map[T]*pityState)
+	t.quantities = make(map[T]Quantity)
+	t.conditions = make(map[T]map[string]interface{})
+	t.weightFuncs = make(map[T]WeightFunc)
+	t.special = nil
+	t.markModified()
+
+	for item := range removed {
+		t.publishItemRemoved(item, "cleared")
+	}
+}
+
 // Select performs a single weighted random selection from the table
 // Returns ErrEmptyTable if the table contains no items
 func (t *BasicTable[T]) Select(ctx SelectionContext) (T, error) {
@@ -245,6 +355,10 @@ func (t *BasicTable[T]) Select(ctx SelectionContext) (T, error) {
 		return zeroValue, err
 	}
 
+	if index := t.weightIndexFor(ctx); index != nil {
+		return t.selectIndexed(ctx, index, startTime)
+	}
+
 	// Get effective weights (potentially modified by context)
 	effectiveWeights, err := t.getEffectiveWeights(ctx)
 	if err != nil {
@@ -262,6 +376,7 @@ func (t *BasicTable[T]) Select(ctx SelectionContext) (T, error) {
 		}
 		return zeroValue, selectionErr
 	}
+	effectiveWeights = t.applyPity(effectiveWeights)
 
 	// Calculate total weight
 	totalWeight := 0
@@ -321,6 +436,7 @@ func (t *BasicTable[T]) Select(ctx SelectionContext) (T, error) {
 				}
 				_ = t.connectedTopics.selectionCompleted.Publish(context.Background(), event)
 			}
+			t.recordPull(item)
 			return item, nil
 		}
 	}
@@ -485,6 +601,7 @@ func (t *BasicTable[T]) SelectUnique(ctx SelectionContext, count int) ([]T, erro
 			}
 			return nil, selectionErr
 		}
+		effectiveWeights = t.applyPity(effectiveWeights)
 
 		// Calculate total weight
 		totalWeight := 0
@@ -521,6 +638,7 @@ func (t *BasicTable[T]) SelectUnique(ctx SelectionContext, count int) ([]T, erro
 			if rollValue <= currentWeight && !used[item] {
 				results = append(results, item)
 				used[item] = true
+				t.recordPull(item)
 				break
 			}
 		}
@@ -667,23 +785,45 @@ func (t *BasicTable[T]) getEffectiveWeights(ctx SelectionContext) (map[T]int, er
 		t.weightCacheMutex.RUnlock()
 	}
 
+	// Hold the read lock until the result is cached so a concurrent
+	// Remove, SetWeight or Clear can't have its cache reset overwritten
 	t.mutex.RLock()
+
 	result := make(map[T]int)
+	dynamic := make(map[T]WeightFunc)
 	for item, baseWeight := range t.items {
+		// Items whose conditions the context doesn't meet are not eligible
+		if !conditionsMatch(t.conditions[item], ctx) {
+			continue
+		}
 		result[item] = baseWeight
+		if weightFunc, ok := t.weightFuncs[item]; ok {
+			dynamic[item] = weightFunc
+		}
 	}
-	t.mutex.RUnlock()
-
-	// Apply context modifications (in future iterations)
-	// For now, just return base weights
 
-	// Cache the result if caching is enabled
-	if t.config.CacheWeights {
+	// Cache the result if caching is enabled. Weight functions may depend on
+	// more than the context's values, so their results are never cached.
+	if t.config.CacheWeights && len(t.weightFuncs) == 0 {
 		contextHash := t.hashContext(ctx)
 		t.weightCacheMutex.Lock()
 		t.cachedWeights[contextHash] = result
 		t.weightCacheMutex.Unlock()
 	}
+	t.mutex.RUnlock()
+
+	// Weight functions run without the lock so they may read the table
+	for item, weightFunc := range dynamic {
+		weight := weightFunc(ctx)
+		if weight <= 0 {
+			delete(result, item)
+			continue
+		}
+		if weight > t.config.MaxWeight {
+			weight = t.config.MaxWeight
+		}
+		result[item] = weight
+	}
 
 	return result, nil
 }
@@ -724,11 +864,49 @@ func (t *BasicTable[T]) hashContext(ctx SelectionContext) string {
 	return hash
 }
 
+// clampWeight limits a weight to the configured minimum and maximum
+func (t *BasicTable[T]) clampWeight(weight int) int {
+	if weight < t.config.MinWeight {
+		weight = t.config.MinWeight
+	}
+	if weight > t.config.MaxWeight {
+		weight = t.config.MaxWeight
+	}
+	return weight
+}
+
+// markModified records a change to the items and invalidates cached weights.
+// Callers must hold the items mutex.
+func (t *BasicTable[T]) markModified() {
+	t.lastModification = time.Now()
+	t.version++
+
+	// Clear weight cache since table changed
+	if t.config.CacheWeights || t.config.IndexedSelection {
+		t.clearWeightCache()
+	}
+}
+
+// publishItemRemoved publishes an item removed event if events are enabled
+func (t *BasicTable[T]) publishItemRemoved(item T, reason string) {
+	if !t.config.EnableEvents || t.connectedTopics.itemRemoved == nil {
+		return
+	}
+	event := ItemRemovedEvent{
+		TableID:   t.id,
+		ItemID:    fmt.Sprintf("%v", item),
+		Reason:    reason,
+		RemovedAt: time.Now(),
+	}
+	_ = t.connectedTopics.itemRemoved.Publish(context.Background(), event)
+}
+
 // clearWeightCache clears the weight calculation cache
 func (t *BasicTable[T]) clearWeightCache() {
 	t.weightCacheMutex.Lock()
 	defer t.weightCacheMutex.Unlock()
 	t.cachedWeights = make(map[string]map[T]int)
+	t.indexes = make(map[string]*weightIndex[T])
 }
 
 // parseDiceExpression parses and rolls a simple dice expression
@@ -816,15 +994,8 @@ func toString(value interface{}) string {
 	switch v := value.(type) {
 	case string:
 		return v
-	case int:
-		return string(rune(v)) // Simplified conversion
-	case bool:
-		if v {
-			return "true"
-		}
-		return "false"
 	default:
-		return "unknown"
+		return fmt.Sprintf("%v", v)
 	}
 }
 
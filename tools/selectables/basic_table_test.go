@@ -1,6 +1,8 @@
 package selectables
 
 import (
+	"context"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -535,3 +537,122 @@ func (s *BasicTableTestSuite) TestTableIntrospection() {
 		s.Assert().Equal(2, s.table.Size()) // Size shouldn't change
 	})
 }
+
+// mutable returns the suite's table as a MutableTable, as callers holding a
+// SelectionTable detect it
+func (s *BasicTableTestSuite) mutable() MutableTable[string] {
+	mutable, ok := s.table.(MutableTable[string])
+	s.Require().True(ok, "BasicTable is a MutableTable")
+	return mutable
+}
+
+func (s *BasicTableTestSuite) TestMutation() {
+	s.Run("Remove deletes an item", func() {
+		s.table.Add("heal", 10).Add("attack", 30)
+
+		s.Assert().True(s.mutable().Remove("heal"))
+		s.Assert().Equal(1, s.table.Size())
+		s.Assert().NotContains(s.table.GetItems(), "heal")
+
+		s.Assert().False(s.mutable().Remove("heal"), "removing a missing item reports false")
+	})
+
+	s.Run("removed item is never selected", func() {
+		s.table.Add("heal", 10).Add("attack", 30)
+		// Prime the weight cache before removing
+		_, err := s.table.Select(s.ctx)
+		s.Require().NoError(err)
+
+		s.mutable().Remove("heal")
+		for i := 0; i < 20; i++ {
+			selected, err := s.table.Select(s.ctx)
+			s.Require().NoError(err)
+			s.Assert().Equal("attack", selected)
+		}
+	})
+
+	s.Run("SetWeight changes an existing item", func() {
+		s.table.Add("heal", 10)
+
+		s.Require().NoError(s.mutable().SetWeight("heal", 40))
+		s.Assert().Equal(40, s.table.GetItems()["heal"])
+
+		s.Require().NoError(s.mutable().SetWeight("heal", 5000))
+		s.Assert().Equal(1000, s.table.GetItems()["heal"], "clamped to MaxWeight")
+	})
+
+	s.Run("SetWeight rejects missing items and invalid weights", func() {
+		s.table.Add("heal", 10)
+
+		err := s.mutable().SetWeight("flee", 10)
+		s.Assert().ErrorIs(err, ErrItemNotFound)
+		s.Assert().Equal(1, s.table.Size(), "SetWeight must not add items")
+
+		err = s.mutable().SetWeight("heal", 0)
+		s.Assert().ErrorIs(err, ErrInvalidWeight)
+		s.Assert().Equal(10, s.table.GetItems()["heal"])
+	})
+
+	s.Run("Clear removes every item", func() {
+		s.table.Add("heal", 10).Add("attack", 30)
+
+		s.mutable().Clear()
+		s.Assert().True(s.table.IsEmpty())
+
+		_, err := s.table.Select(s.ctx)
+		s.Assert().ErrorIs(err, ErrEmptyTable)
+	})
+
+	s.Run("publishes removal and weight events", func() {
+		var removed []ItemRemovedEvent
+		var changed []WeightChangedEvent
+		_, err := ItemRemovedTopic.On(s.eventBus).Subscribe(context.Background(),
+			func(_ context.Context, e ItemRemovedEvent) error {
+				removed = append(removed, e)
+				return nil
+			})
+		s.Require().NoError(err)
+		_, err = WeightChangedTopic.On(s.eventBus).Subscribe(context.Background(),
+			func(_ context.Context, e WeightChangedEvent) error {
+				changed = append(changed, e)
+				return nil
+			})
+		s.Require().NoError(err)
+
+		s.table.Add("heal", 10).Add("attack", 30)
+		s.Require().NoError(s.mutable().SetWeight("attack", 50))
+		s.mutable().Remove("heal")
+		s.mutable().Clear()
+
+		s.Require().Len(changed, 1)
+		s.Assert().Equal(30, changed[0].OldWeight)
+		s.Assert().Equal(50, changed[0].NewWeight)
+
+		s.Require().Len(removed, 2)
+		s.Assert().Equal("heal", removed[0].ItemID)
+		s.Assert().Equal("removed", removed[0].Reason)
+		s.Assert().Equal("attack", removed[1].ItemID)
+		s.Assert().Equal("cleared", removed[1].Reason)
+	})
+
+	s.Run("is safe for concurrent use", func() {
+		mutable := s.mutable()
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ctx := NewSelectionContextWithRoller(NewTestRoller([]int{1}))
+				for j := 0; j < 50; j++ {
+					s.table.Add("heal", 10)
+					_ = mutable.SetWeight("heal", 20)
+					_, _ = s.table.Select(ctx)
+					mutable.Remove("heal")
+					mutable.Clear()
+				}
+			}()
+		}
+		wg.Wait()
+		s.Assert().True(s.table.IsEmpty())
+	})
+}
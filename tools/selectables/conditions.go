@@ -0,0 +1,71 @@
+package selectables
+
+import "fmt"
+
+// SetConditions makes an item already in the table eligible only when the
+// selection context matches every condition. Each condition maps a context key
+// to the value it must have, or to a list of acceptable values. Values are
+// compared by their printed form, so a condition of 5 loaded from JSON matches
+// a context value of int 5. Nil or empty conditions make the item always eligible.
+// Returns ErrItemNotFound if the item is not in the table
+func (t *BasicTable[T]) SetConditions(item T, conditions map[string]interface{}) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if _, exists := t.items[item]; !exists {
+		return NewSelectionError("set_conditions", t.id, nil, ErrItemNotFound).
+			AddDetail("item", fmt.Sprintf("%v", item))
+	}
+
+	if len(conditions) == 0 {
+		delete(t.conditions, item)
+	} else {
+		copied := make(map[string]interface{}, len(conditions))
+		for key, value := range conditions {
+			copied[key] = value
+		}
+		t.conditions[item] = copied
+	}
+	t.markModified()
+	return nil
+}
+
+// conditionsMatch reports whether the context satisfies every condition
+func conditionsMatch(conditions map[string]interface{}, ctx SelectionContext) bool {
+	if len(conditions) == 0 {
+		return true
+	}
+	if ctx == nil {
+		return false
+	}
+
+	for key, want := range conditions {
+		got, ok := ctx.Get(key)
+		if !ok || !conditionValueMatches(want, got) {
+			return false
+		}
+	}
+	return true
+}
+
+// conditionValueMatches compares a condition value, or any value of a list, to a context value
+func conditionValueMatches(want, got interface{}) bool {
+	switch values := want.(type) {
+	case []interface{}:
+		for _, value := range values {
+			if conditionValueMatches(value, got) {
+				return true
+			}
+		}
+		return false
+	case []string:
+		for _, value := range values {
+			if conditionValueMatches(value, got) {
+				return true
+			}
+		}
+		return false
+	default:
+		return fmt.Sprintf("%v", want) == fmt.Sprintf("%v", got)
+	}
+}
@@ -0,0 +1,74 @@
+package selectables
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ConditionsTestSuite struct {
+	suite.Suite
+	table *BasicTable[string]
+}
+
+func (s *ConditionsTestSuite) SetupTest() {
+	s.table = NewBasicTable[string](BasicTableConfig{
+		ID:            "encounters",
+		Configuration: TableConfiguration{CacheWeights: true},
+	}).(*BasicTable[string])
+	s.table.Add("wolves", 10).Add("bandits", 10).Add("troll", 10)
+	s.Require().NoError(s.table.SetConditions("wolves", map[string]interface{}{"terrain": []interface{}{"forest", "hills"}}))
+	s.Require().NoError(s.table.SetConditions("troll", map[string]interface{}{"terrain": "hills", "level": 5.0}))
+}
+
+func TestConditionsTestSuite(t *testing.T) {
+	suite.Run(t, new(ConditionsTestSuite))
+}
+
+func (s *ConditionsTestSuite) TestOnlyMatchingItemsAreSelectable() {
+	testCases := []struct {
+		name    string
+		context map[string]interface{}
+		want    []string
+	}{
+		{name: "no context values", want: []string{"bandits"}},
+		{name: "any of a list", context: map[string]interface{}{"terrain": "forest"}, want: []string{"bandits", "wolves"}},
+		{
+			name:    "every condition must match",
+			context: map[string]interface{}{"terrain": "hills", "level": 3},
+			want:    []string{"bandits", "wolves"},
+		},
+		{
+			name:    "numbers match across types",
+			context: map[string]interface{}{"terrain": "hills", "level": 5},
+			want:    []string{"bandits", "troll", "wolves"},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			builder := NewContextBuilderWithRoller(NewTestRoller([]int{1}))
+			for key, value := range tc.context {
+				builder.Set(key, value)
+			}
+
+			selected, err := s.table.SelectUnique(builder.Build(), len(tc.want))
+			s.Require().NoError(err)
+			s.ElementsMatch(tc.want, selected)
+
+			_, err = s.table.SelectUnique(builder.Build(), len(tc.want)+1)
+			s.ErrorIs(err, ErrInsufficientItems)
+		})
+	}
+}
+
+func (s *ConditionsTestSuite) TestClearingConditions() {
+	s.Require().NoError(s.table.SetConditions("troll", nil))
+
+	ctx := NewSelectionContextWithRoller(NewTestRoller([]int{1}))
+	selected, err := s.table.SelectUnique(ctx, 2)
+	s.Require().NoError(err)
+	s.ElementsMatch([]string{"bandits", "troll"}, selected)
+
+	s.ErrorIs(s.table.SetConditions("dragon", map[string]interface{}{"terrain": "mountain"}), ErrItemNotFound)
+}
@@ -1,6 +1,8 @@
 package selectables
 
 import (
+	"context"
+
 	"github.com/KirkDiggler/rpg-toolkit/dice"
 )
 
@@ -25,6 +27,16 @@ func NewBasicSelectionContext() SelectionContext {
 	}
 }
 
+// NewSelectionContextFromContext creates a new selection context whose dice roller
+// comes from ctx, so a roller seeded with dice.WithRoller also drives selections
+// Purpose: Lets a test make a whole call tree deterministic, table rolls included
+func NewSelectionContextFromContext(ctx context.Context) SelectionContext {
+	return &BasicSelectionContext{
+		values:     make(map[string]interface{}),
+		diceRoller: dice.FromContext(ctx),
+	}
+}
+
 // NewSelectionContextWithRoller creates a new selection context with a specific dice roller
 // Purpose: Allows customization of randomization behavior for testing or specific game needs
 func NewSelectionContextWithRoller(roller dice.Roller) SelectionContext {
@@ -1,6 +1,7 @@
 package selectables
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -47,6 +48,26 @@ func (s *SelectionContextTestSuite) TestBasicContextCreation() {
 		s.Assert().Equal(testRoller, ctx.GetDiceRoller())
 		s.Assert().Empty(ctx.Keys())
 	})
+
+	s.Run("takes the roller seeded through the context", func() {
+		testRoller := NewTestRoller([]int{4})
+		ctx := NewSelectionContextFromContext(dice.WithRoller(context.Background(), testRoller))
+
+		s.Assert().Equal(testRoller, ctx.GetDiceRoller())
+
+		table := NewBasicTable[string](BasicTableConfig{ID: "seeded"}).Add("gold", 1).(QuantityTable[string])
+		s.Require().NoError(table.SetQuantity("gold", "1d6"))
+		item, count, err := table.SelectWithQuantity(ctx)
+		s.Require().NoError(err)
+		s.Assert().Equal("gold", item)
+		s.Assert().Equal(4, count)
+	})
+
+	s.Run("falls back to a crypto roller without a seeded one", func() {
+		ctx := NewSelectionContextFromContext(context.Background())
+
+		s.Assert().IsType(&dice.CryptoRoller{}, ctx.GetDiceRoller())
+	})
 }
 
 func (s *SelectionContextTestSuite) TestContextValueOperations() {
@@ -0,0 +1,288 @@
+package selectables
+
+import (
+	"fmt"
+	"sort"
+)
+
+// TableData is the serializable form of a selection table
+// Purpose: Lets games define encounter and treasure tables in JSON config files
+// and load them at startup with TableFromData or a TableRegistry.
+type TableData[T comparable] struct {
+	// ID identifies the table, and is the key other tables nest it by
+	ID string `json:"id"`
+
+	// MinWeight and MaxWeight clamp entry weights like TableConfiguration's
+	MinWeight int `json:"min_weight,omitempty"`
+	MaxWeight int `json:"max_weight,omitempty"`
+
+	// Entries are the table's items and nested tables
+	Entries []EntryData[T] `json:"entries"`
+}
+
+// EntryData is one entry of a TableData: an item, a nested table referenced by
+// ID or defined inline, or a nothing or roll-again entry. Nested tables are
+// flattened into the table's items when loaded, keeping each nested item's
+// quantity, pity and conditions, unless they have nothing or roll-again entries.
+type EntryData[T comparable] struct {
+	// Item is the entry's item, for entries that are not nested tables
+	Item T `json:"item,omitempty"`
+
+	// Weight is the entry's selection weight, shared among a nested table's items
+	Weight int `json:"weight"`
+
+	// Table is the ID of a registered table to nest
+	Table string `json:"table,omitempty"`
+
+	// Nested is an inline table to nest
+	Nested *TableData[T] `json:"nested,omitempty"`
+
+	// Quantity is the item's quantity expression, e.g. "3d6x10"
+	Quantity string `json:"quantity,omitempty"`
+
+	// Pity is the item's pity policy
+	Pity *PityPolicy `json:"pity,omitempty"`
+
+	// Conditions are the context values the entry requires, see SetConditions.
+	// On a nested table they apply to each of its items.
+	Conditions map[string]interface{} `json:"conditions,omitempty"`
+
+	// Nothing marks an entry that rolls no result, see AddNothing
+	Nothing bool `json:"nothing,omitempty"`
+
+	// RollAgain, if greater than 0, marks an entry that rolls this many more results, see AddRollAgain
+	RollAgain int `json:"roll_again,omitempty"`
+
+	// Duplicates is a roll-again entry's duplicate policy
+	Duplicates DuplicatePolicy `json:"duplicates,omitempty"`
+}
+
+// TableDataConfig provides options for TableFromData
+type TableDataConfig[T comparable] struct {
+	// Configuration customizes the loaded table's behavior.
+	// The data's ID and weight limits take precedence over Configuration's.
+	Configuration TableConfiguration
+
+	// Tables resolves entries that nest a table by ID
+	Tables *TableRegistry[T]
+}
+
+// ToData returns the table's serializable form, with entries ordered by item.
+// Nested tables were flattened when added, so their items are written as plain entries.
+// Items added with AddDynamic are written with their stored minimum weight.
+func (t *BasicTable[T]) ToData() TableData[T] {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	data := TableData[T]{
+		ID:        t.id,
+		MinWeight: t.config.MinWeight,
+		MaxWeight: t.config.MaxWeight,
+		Entries:   make([]EntryData[T], 0, len(t.items)),
+	}
+
+	for item, weight := range t.items {
+		entry := EntryData[T]{Item: item, Weight: weight}
+		if quantity, ok := t.quantities[item]; ok {
+			entry.Quantity = quantity.String()
+		}
+		if state, ok := t.pity[item]; ok {
+			policy := state.policy
+			entry.Pity = &policy
+		}
+		if conditions, ok := t.conditions[item]; ok {
+			entry.Conditions = make(map[string]interface{}, len(conditions))
+			for key, value := range conditions {
+				entry.Conditions[key] = value
+			}
+		}
+		data.Entries = append(data.Entries, entry)
+	}
+
+	sort.Slice(data.Entries, func(i, j int) bool {
+		return fmt.Sprintf("%v", data.Entries[i].Item) < fmt.Sprintf("%v", data.Entries[j].Item)
+	})
+
+	// Special entries follow the items in the order they were added
+	for _, special := range t.special {
+		entry := EntryData[T]{Weight: special.weight, Nothing: special.nothing}
+		if special.rollAgain > 0 {
+			entry.RollAgain = special.rollAgain
+			entry.Duplicates = special.duplicates
+		}
+		if special.table != nil {
+			nested := special.table.ToData()
+			entry.Nested = &nested
+		}
+		if len(special.conditions) > 0 {
+			entry.Conditions = make(map[string]interface{}, len(special.conditions))
+			for key, value := range special.conditions {
+				entry.Conditions[key] = value
+			}
+		}
+		data.Entries = append(data.Entries, entry)
+	}
+	return data
+}
+
+// TableFromData creates a table from its serializable form
+// Returns ErrTableNotFound if an entry nests a table config.Tables doesn't have
+// Returns ErrInvalidWeight, ErrInvalidDiceExpression or ErrInvalidConfiguration for invalid entries
+func TableFromData[T comparable](data TableData[T], config TableDataConfig[T]) (SelectionTable[T], error) {
+	if data.MinWeight < 0 || data.MaxWeight < 0 || (data.MaxWeight > 0 && data.MinWeight > data.MaxWeight) {
+		return nil, NewSelectionError("from_data", data.ID, nil, ErrInvalidConfiguration).
+			AddDetail("min_weight", data.MinWeight).
+			AddDetail("max_weight", data.MaxWeight)
+	}
+
+	tableConfig := config.Configuration
+	tableConfig.ID = data.ID
+	if data.MinWeight > 0 {
+		tableConfig.MinWeight = data.MinWeight
+	}
+	if data.MaxWeight > 0 {
+		tableConfig.MaxWeight = data.MaxWeight
+	}
+
+	table := NewBasicTable[T](BasicTableConfig{ID: data.ID, Configuration: tableConfig}).(*BasicTable[T])
+	for i, entry := range data.Entries {
+		if err := table.loadEntry(entry, config.Tables); err != nil {
+			return nil, NewSelectionError("from_data", table.id, nil, err).
+				AddDetail("entry", i)
+		}
+	}
+	return table, nil
+}
+
+// loadEntry adds one entry of a TableData to the table
+func (t *BasicTable[T]) loadEntry(entry EntryData[T], tables *TableRegistry[T]) error {
+	if entry.Weight < 1 {
+		return fmt.Errorf("%w: %d", ErrInvalidWeight, entry.Weight)
+	}
+
+	if entry.Nothing || entry.RollAgain != 0 {
+		return t.loadSpecialEntry(entry)
+	}
+
+	if entry.Table == "" && entry.Nested == nil {
+		t.Add(entry.Item, entry.Weight)
+		if entry.Quantity != "" {
+			if err := t.SetQuantity(entry.Item, entry.Quantity); err != nil {
+				return err
+			}
+		}
+		if entry.Pity != nil {
+			if err := t.SetPity(entry.Item, *entry.Pity); err != nil {
+				return err
+			}
+		}
+		return t.SetConditions(entry.Item, entry.Conditions)
+	}
+
+	var zero T
+	switch {
+	case entry.Table != "" && entry.Nested != nil:
+		return fmt.Errorf("%w: entry nests both table %q and an inline table", ErrInvalidConfiguration, entry.Table)
+	case entry.Item != zero:
+		return fmt.Errorf("%w: entry has both an item and a nested table", ErrInvalidConfiguration)
+	case entry.Quantity != "" || entry.Pity != nil:
+		return fmt.Errorf("%w: nested table entries can't have a quantity or pity, set them on its items", ErrInvalidConfiguration)
+	}
+
+	var nested SelectionTable[T]
+	if entry.Nested != nil {
+		var err error
+		nested, err = TableFromData(*entry.Nested, TableDataConfig[T]{Tables: tables})
+		if err != nil {
+			return err
+		}
+	} else {
+		if tables == nil {
+			return fmt.Errorf("%w: %s", ErrTableNotFound, entry.Table)
+		}
+		var err error
+		nested, err = tables.Get(entry.Table)
+		if err != nil {
+			return err
+		}
+	}
+
+	return t.addNested(nested, entry.Weight, entry.Conditions)
+}
+
+// loadSpecialEntry adds a nothing or roll-again entry of a TableData to the table
+func (t *BasicTable[T]) loadSpecialEntry(entry EntryData[T]) error {
+	var zero T
+	switch {
+	case entry.Nothing && entry.RollAgain != 0:
+		return fmt.Errorf("%w: entry is both nothing and roll again", ErrInvalidConfiguration)
+	case entry.RollAgain < 0:
+		return fmt.Errorf("%w: roll again count must not be negative: %d", ErrInvalidConfiguration, entry.RollAgain)
+	case entry.Item != zero || entry.Table != "" || entry.Nested != nil:
+		return fmt.Errorf("%w: nothing and roll again entries can't have an item or nested table", ErrInvalidConfiguration)
+	case entry.Quantity != "" || entry.Pity != nil || len(entry.Conditions) > 0:
+		return fmt.Errorf("%w: nothing and roll again entries can't have a quantity, pity or conditions", ErrInvalidConfiguration)
+	}
+	if err := entry.Duplicates.Validate(); err != nil {
+		return err
+	}
+
+	if entry.Nothing {
+		t.AddNothing(entry.Weight)
+		return nil
+	}
+	t.AddRollAgain(entry.Weight, entry.RollAgain, entry.Duplicates)
+	return nil
+}
+
+// addNested flattens a nested table into the table like AddTable, keeping its
+// items' quantities, pity and conditions. conditions apply to every nested item,
+// over the item's own conditions for the same keys. A nested table with nothing
+// or roll-again entries is kept whole, with conditions on its entry.
+func (t *BasicTable[T]) addNested(nested SelectionTable[T], weight int, conditions map[string]interface{}) error {
+	if special, ok := withSpecialEntries(nested); ok {
+		entry := specialEntry[T]{weight: t.clampWeight(weight), table: special}
+		if len(conditions) > 0 {
+			entry.conditions = make(map[string]interface{}, len(conditions))
+			for key, value := range conditions {
+				entry.conditions[key] = value
+			}
+		}
+		t.mutex.Lock()
+		defer t.mutex.Unlock()
+		t.special = append(t.special, entry)
+		return nil
+	}
+
+	t.AddTable("", nested, weight)
+
+	// Tables that can't be serialized contribute their items and weights only
+	serializable, ok := nested.(SerializableTable[T])
+	if !ok {
+		return nil
+	}
+	for _, entry := range serializable.ToData().Entries {
+		if entry.Quantity != "" {
+			if err := t.SetQuantity(entry.Item, entry.Quantity); err != nil {
+				return err
+			}
+		}
+		if entry.Pity != nil {
+			if err := t.SetPity(entry.Item, *entry.Pity); err != nil {
+				return err
+			}
+		}
+
+		merged := make(map[string]interface{}, len(entry.Conditions)+len(conditions))
+		for key, value := range entry.Conditions {
+			merged[key] = value
+		}
+		for key, value := range conditions {
+			merged[key] = value
+		}
+		if err := t.SetConditions(entry.Item, merged); err != nil {
+			return err
+		}
+	}
+	return nil
+}
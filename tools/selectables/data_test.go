@@ -0,0 +1,108 @@
+package selectables
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TableDataTestSuite struct {
+	suite.Suite
+}
+
+func TestTableDataTestSuite(t *testing.T) {
+	suite.Run(t, new(TableDataTestSuite))
+}
+
+func (s *TableDataTestSuite) TestRoundTrip() {
+	table := NewBasicTable[string](BasicTableConfig{ID: "hoard", Configuration: TableConfiguration{MaxWeight: 500}}).(*BasicTable[string])
+	table.Add("gold", 60).Add("gem", 30).Add("dragon_egg", 1)
+	s.Require().NoError(table.SetQuantity("gold", "3d6x10"))
+	s.Require().NoError(table.SetPity("dragon_egg", PityPolicy{Increment: 2, GuaranteeWithin: 50}))
+	s.Require().NoError(table.SetConditions("gem", map[string]interface{}{"terrain": "cave"}))
+
+	encoded, err := json.Marshal(table.ToData())
+	s.Require().NoError(err)
+	s.JSONEq(`{
+		"id": "hoard",
+		"min_weight": 1,
+		"max_weight": 500,
+		"entries": [
+			{"item": "dragon_egg", "weight": 1, "pity": {"increment": 2, "guarantee_within": 50}},
+			{"item": "gem", "weight": 30, "conditions": {"terrain": "cave"}},
+			{"item": "gold", "weight": 60, "quantity": "3d6x10"}
+		]
+	}`, string(encoded))
+
+	var data TableData[string]
+	s.Require().NoError(json.Unmarshal(encoded, &data))
+	loaded, err := TableFromData(data, TableDataConfig[string]{})
+	s.Require().NoError(err)
+	s.Equal(table.ToData(), loaded.(SerializableTable[string]).ToData())
+}
+
+func (s *TableDataTestSuite) TestNestedTablesAreFlattened() {
+	tables := NewTableRegistry[string]()
+	gems := NewBasicTable[string](BasicTableConfig{ID: "gems"}).(*BasicTable[string])
+	gems.Add("ruby", 1).Add("opal", 3)
+	s.Require().NoError(gems.SetConditions("ruby", map[string]interface{}{"level": 5}))
+	s.Require().NoError(tables.Register("gems", gems))
+
+	loaded, err := TableFromData(TableData[string]{
+		ID: "treasure",
+		Entries: []EntryData[string]{
+			{Item: "gold", Weight: 50},
+			{Table: "gems", Weight: 40, Conditions: map[string]interface{}{"terrain": "cave"}},
+			{Nested: &TableData[string]{
+				ID:      "coins",
+				Entries: []EntryData[string]{{Item: "silver", Weight: 1, Quantity: "2d6"}},
+			}, Weight: 10},
+		},
+	}, TableDataConfig[string]{Tables: tables})
+	s.Require().NoError(err)
+
+	s.Equal(map[string]int{"gold": 50, "ruby": 10, "opal": 30, "silver": 10}, loaded.GetItems())
+
+	byItem := make(map[string]EntryData[string])
+	for _, entry := range loaded.(SerializableTable[string]).ToData().Entries {
+		byItem[entry.Item] = entry
+	}
+	s.Equal(map[string]interface{}{"level": 5, "terrain": "cave"}, byItem["ruby"].Conditions)
+	s.Equal(map[string]interface{}{"terrain": "cave"}, byItem["opal"].Conditions)
+	s.Equal("2d6", byItem["silver"].Quantity)
+}
+
+func (s *TableDataTestSuite) TestInvalidEntries() {
+	testCases := []struct {
+		name  string
+		entry EntryData[string]
+		want  error
+	}{
+		{name: "zero weight", entry: EntryData[string]{Item: "gold"}, want: ErrInvalidWeight},
+		{name: "bad quantity", entry: EntryData[string]{Item: "gold", Weight: 1, Quantity: "lots"}, want: ErrInvalidDiceExpression},
+		{
+			name:  "negative pity",
+			entry: EntryData[string]{Item: "gold", Weight: 1, Pity: &PityPolicy{Increment: -1}},
+			want:  ErrInvalidConfiguration,
+		},
+		{name: "unknown table", entry: EntryData[string]{Table: "gems", Weight: 1}, want: ErrTableNotFound},
+		{name: "item and table", entry: EntryData[string]{Item: "gold", Table: "gems", Weight: 1}, want: ErrInvalidConfiguration},
+		{name: "quantity on table", entry: EntryData[string]{Table: "gems", Weight: 1, Quantity: "2"}, want: ErrInvalidConfiguration},
+		{
+			name:  "reference and inline table",
+			entry: EntryData[string]{Table: "gems", Nested: &TableData[string]{}, Weight: 1},
+			want:  ErrInvalidConfiguration,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			_, err := TableFromData(TableData[string]{ID: "broken", Entries: []EntryData[string]{tc.entry}}, TableDataConfig[string]{})
+			s.ErrorIs(err, tc.want)
+		})
+	}
+
+	_, err := TableFromData(TableData[string]{ID: "broken", MinWeight: 10, MaxWeight: 5}, TableDataConfig[string]{})
+	s.ErrorIs(err, ErrInvalidConfiguration)
+}
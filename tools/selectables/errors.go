@@ -22,6 +22,10 @@ var (
 	// Used by SelectVariable when the provided expression is malformed
 	ErrInvalidDiceExpression = errors.New("invalid dice expression")
 
+	// ErrItemNotFound indicates an operation on an item that is not in the table
+	// Used by SetWeight, which only changes existing items
+	ErrItemNotFound = errors.New("item not found in table")
+
 	// ErrInsufficientItems indicates not enough unique items for SelectUnique operation
 	// Occurs when requesting more unique items than are available in the table
 	ErrInsufficientItems = errors.New("insufficient unique items available for selection")
@@ -30,6 +34,10 @@ var (
 	// Used by hierarchical tables when a named sub-table is missing
 	ErrTableNotFound = errors.New("referenced selection table not found")
 
+	// ErrDuplicateTable indicates a table ID that is already registered
+	// Used by TableRegistry, which keys tables by ID
+	ErrDuplicateTable = errors.New("selection table already registered")
+
 	// ErrCircularReference indicates a circular dependency in nested tables
 	// Prevents infinite loops in hierarchical table structures
 	ErrCircularReference = errors.New("circular reference detected in nested tables")
@@ -6,7 +6,7 @@ toolchain go1.24.5
 
 require (
 	github.com/KirkDiggler/rpg-toolkit/core v0.9.6
-	github.com/KirkDiggler/rpg-toolkit/dice v0.3.2
+	github.com/KirkDiggler/rpg-toolkit/dice v0.3.3-0.20261016182459-75025840da58
 	github.com/KirkDiggler/rpg-toolkit/events v0.6.2
 	github.com/stretchr/testify v1.10.0
 )
@@ -0,0 +1,141 @@
+package selectables
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// weightIndex maps a roll to an item by binary search over cumulative weights.
+// Building it is O(n log n); each pick is O(log n).
+type weightIndex[T comparable] struct {
+	items      []T
+	cumulative []int
+	total      int
+}
+
+// newWeightIndex builds an index over the weights. Items are ordered by their
+// string form so the same roll always picks the same item.
+func newWeightIndex[T comparable](weights map[T]int) *weightIndex[T] {
+	type keyed struct {
+		item T
+		key  string
+	}
+	entries := make([]keyed, 0, len(weights))
+	for item := range weights {
+		entries = append(entries, keyed{item: item, key: fmt.Sprintf("%v", item)})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+
+	index := &weightIndex[T]{
+		items:      make([]T, len(entries)),
+		cumulative: make([]int, len(entries)),
+	}
+	for i, entry := range entries {
+		index.total += weights[entry.item]
+		index.items[i] = entry.item
+		index.cumulative[i] = index.total
+	}
+	return index
+}
+
+// pick returns the item a roll in [1, total] lands on.
+// Returns false if the roll is out of range.
+func (w *weightIndex[T]) pick(roll int) (T, bool) {
+	var zeroValue T
+	if roll < 1 || roll > w.total {
+		return zeroValue, false
+	}
+	return w.items[sort.SearchInts(w.cumulative, roll)], true
+}
+
+// weightIndexFor returns the cached index for the context, building it if needed.
+// Returns nil when IndexedSelection is off, when pity or weight functions make
+// weights change between selections, or when no item is eligible.
+func (t *BasicTable[T]) weightIndexFor(ctx SelectionContext) *weightIndex[T] {
+	if !t.config.IndexedSelection {
+		return nil
+	}
+
+	t.mutex.RLock()
+	volatile := len(t.pity) > 0 || len(t.weightFuncs) > 0
+	version := t.version
+	t.mutex.RUnlock()
+	if volatile {
+		return nil
+	}
+
+	contextHash := t.hashContext(ctx)
+	t.weightCacheMutex.RLock()
+	index, exists := t.indexes[contextHash]
+	t.weightCacheMutex.RUnlock()
+	if exists {
+		return index
+	}
+
+	weights, err := t.getEffectiveWeights(ctx)
+	if err != nil || len(weights) == 0 {
+		return nil
+	}
+	index = newWeightIndex(weights)
+
+	// Only cache the index if the table hasn't changed since the weights were read;
+	// holding the read lock keeps a change from clearing the cache before the store
+	t.mutex.RLock()
+	if t.version == version {
+		t.weightCacheMutex.Lock()
+		t.indexes[contextHash] = index
+		t.weightCacheMutex.Unlock()
+	}
+	t.mutex.RUnlock()
+
+	return index
+}
+
+// selectIndexed performs Select's weighted roll with a weight index
+func (t *BasicTable[T]) selectIndexed(ctx SelectionContext, index *weightIndex[T], startTime time.Time) (T, error) {
+	var zeroValue T
+
+	var selectionErr *SelectionError
+	rollValue, err := ctx.GetDiceRoller().Roll(context.Background(), index.total)
+	item, ok := index.pick(rollValue)
+	switch {
+	case err != nil:
+		selectionErr = NewSelectionError("select", t.id, ctx, err)
+	case !ok:
+		// This should never happen, but handle it like the linear scan does
+		selectionErr = NewSelectionError("select", t.id, ctx, ErrEmptyTable).
+			AddDetail("reason", "selection algorithm failed").
+			AddDetail("roll_value", rollValue).
+			AddDetail("total_weight", index.total)
+	}
+	if selectionErr != nil {
+		if t.config.EnableEvents && t.connectedTopics.selectionFailed != nil {
+			event := SelectionFailedEvent{
+				TableID:       t.id,
+				Operation:     "select",
+				RequestCount:  1,
+				SelectionMode: "standard",
+				Error:         selectionErr.Error(),
+				FailedAt:      time.Now(),
+			}
+			_ = t.connectedTopics.selectionFailed.Publish(context.Background(), event)
+		}
+		return zeroValue, selectionErr
+	}
+
+	if t.config.EnableEvents && t.connectedTopics.selectionCompleted != nil {
+		event := SelectionCompletedEvent{
+			TableID:       t.id,
+			Operation:     "select",
+			RequestCount:  1,
+			ActualCount:   1,
+			SelectionMode: "standard",
+			DurationMs:    int(time.Since(startTime).Milliseconds()),
+			CompletedAt:   time.Now(),
+		}
+		_ = t.connectedTopics.selectionCompleted.Publish(context.Background(), event)
+	}
+	return item, nil
+}
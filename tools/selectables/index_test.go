@@ -0,0 +1,122 @@
+package selectables
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type IndexTestSuite struct {
+	suite.Suite
+	table *BasicTable[string]
+}
+
+func (s *IndexTestSuite) SetupTest() {
+	s.table = NewBasicTable[string](BasicTableConfig{
+		ID:            "spawns",
+		Configuration: TableConfiguration{IndexedSelection: true},
+	}).(*BasicTable[string])
+	s.table.Add("goblin", 30).Add("kobold", 10).Add("orc", 20)
+}
+
+func TestIndexTestSuite(t *testing.T) {
+	suite.Run(t, new(IndexTestSuite))
+}
+
+func (s *IndexTestSuite) selectWith(rolls ...int) string {
+	item, err := s.table.Select(NewSelectionContextWithRoller(NewTestRoller(rolls)))
+	s.Require().NoError(err)
+	return item
+}
+
+func (s *IndexTestSuite) TestRollsMapToItemsInNameOrder() {
+	// Items are indexed in name order: goblin 1-30, kobold 31-40, orc 41-60
+	s.Equal("goblin", s.selectWith(1))
+	s.Equal("goblin", s.selectWith(30))
+	s.Equal("kobold", s.selectWith(31))
+	s.Equal("kobold", s.selectWith(40))
+	s.Equal("orc", s.selectWith(41))
+	s.Equal("orc", s.selectWith(60))
+}
+
+func (s *IndexTestSuite) TestChangesRebuildTheIndex() {
+	s.Equal("goblin", s.selectWith(1))
+
+	s.table.Remove("goblin")
+	s.Equal("kobold", s.selectWith(1))
+
+	s.table.Add("bugbear", 5)
+	s.Equal("bugbear", s.selectWith(1))
+
+	s.Require().NoError(s.table.SetWeight("bugbear", 50))
+	s.Equal("bugbear", s.selectWith(50))
+}
+
+func (s *IndexTestSuite) TestIndexesPerContext() {
+	s.Require().NoError(s.table.SetConditions("goblin", map[string]interface{}{"biome": "forest"}))
+
+	cave := NewSelectionContextWithRoller(NewTestRoller([]int{1})).Set("biome", "cave")
+	item, err := s.table.Select(cave)
+	s.Require().NoError(err)
+	s.Equal("kobold", item)
+
+	forest := NewSelectionContextWithRoller(NewTestRoller([]int{1})).Set("biome", "forest")
+	item, err = s.table.Select(forest)
+	s.Require().NoError(err)
+	s.Equal("goblin", item)
+}
+
+func (s *IndexTestSuite) TestSelectionFollowsWeights() {
+	const samples = 6000
+	counts := make(map[string]int)
+	items, err := s.table.SelectMany(NewBasicSelectionContext(), samples)
+	s.Require().NoError(err)
+	for _, item := range items {
+		counts[item]++
+	}
+
+	s.InDelta(0.5, float64(counts["goblin"])/samples, 0.03)
+	s.InDelta(1.0/3, float64(counts["orc"])/samples, 0.03)
+	s.InDelta(1.0/6, float64(counts["kobold"])/samples, 0.03)
+}
+
+func (s *IndexTestSuite) TestPityFallsBackToLinearScan() {
+	s.Equal("goblin", s.selectWith(1))
+
+	// Guaranteed within 1 pull, the kobold is selected every time
+	s.Require().NoError(s.table.SetPity("kobold", PityPolicy{GuaranteeWithin: 1}))
+	s.Equal("kobold", s.selectWith(1))
+}
+
+func (s *IndexTestSuite) TestEmptyTable() {
+	s.table.Clear()
+	_, err := s.table.Select(NewBasicSelectionContext())
+	s.ErrorIs(err, ErrEmptyTable)
+}
+
+// spawnTable builds a table the size of a large spawn table
+func spawnTable(indexed bool) SelectionTable[string] {
+	table := NewBasicTable[string](BasicTableConfig{
+		ID:            "spawns",
+		Configuration: TableConfiguration{CacheWeights: true, IndexedSelection: indexed},
+	})
+	for i := 0; i < 5000; i++ {
+		table.Add(fmt.Sprintf("monster-%04d", i), 1+i%100)
+	}
+	return table
+}
+
+func BenchmarkSelect(b *testing.B) {
+	for _, indexed := range []bool{false, true} {
+		b.Run(fmt.Sprintf("5000-items/indexed=%t", indexed), func(b *testing.B) {
+			table := spawnTable(indexed)
+			ctx := NewBasicSelectionContext()
+			for b.Loop() {
+				if _, err := table.Select(ctx); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -53,6 +53,95 @@ type SelectionTable[T comparable] interface {
 	Size() int
 }
 
+// The interfaces below are optional table features. BasicTable implements all
+// of them; code holding a SelectionTable detects a feature with a type
+// assertion, e.g. table.(RollTable[T]), so other table types only implement
+// what they support.
+
+// MutableTable is a table whose items can be removed or reweighted after they are added
+type MutableTable[T comparable] interface {
+	// Remove deletes an item from the table
+	// Returns true if the item was in the table
+	Remove(item T) bool
+
+	// SetWeight changes the weight of an item already in the table, clamped like Add
+	// Returns ErrInvalidWeight if weight is less than 1
+	// Returns ErrItemNotFound if the item is not in the table
+	SetWeight(item T, weight int) error
+
+	// Clear removes every item from the table
+	Clear()
+}
+
+// PityTable is a table whose items can guarantee a selection after a run of misses
+type PityTable[T comparable] interface {
+	// SetPity attaches a pity policy to an item already in the table
+	// A zero policy removes pity from the item
+	// Returns ErrItemNotFound if the item is not in the table
+	SetPity(item T, policy PityPolicy) error
+
+	// PityCount returns how many consecutive pulls have not selected the item
+	PityCount(item T) int
+}
+
+// QuantityTable is a table whose items yield a rolled quantity, like "3d6x10" gold
+type QuantityTable[T comparable] interface {
+	// SetQuantity attaches a quantity expression such as "3d6x10" to an item already in the table
+	// An empty expression removes the quantity, so the item yields 1
+	// Returns ErrInvalidDiceExpression if the expression cannot be parsed
+	// Returns ErrItemNotFound if the item is not in the table
+	SetQuantity(item T, expression string) error
+
+	// SelectWithQuantity selects one item and rolls its quantity
+	// Items without a quantity expression yield 1
+	SelectWithQuantity(ctx SelectionContext) (T, int, error)
+}
+
+// ConditionalTable is a table whose item weights depend on the selection context
+type ConditionalTable[T comparable] interface {
+	// SetConditions makes an item already in the table eligible only when the context matches every condition
+	// Nil or empty conditions make the item always eligible
+	// Returns ErrItemNotFound if the item is not in the table
+	SetConditions(item T, conditions map[string]interface{}) error
+
+	// AddDynamic includes an item whose weight is computed from the context on every selection
+	// A computed weight less than 1 makes the item ineligible for that selection
+	AddDynamic(item T, weight WeightFunc) SelectionTable[T]
+
+	// EffectiveWeights returns the weight each eligible item has in the context,
+	// after conditions and weight functions are applied
+	EffectiveWeights(ctx SelectionContext) (map[T]int, error)
+}
+
+// ShuffleTable is a table that can order all of its items at once
+type ShuffleTable[T comparable] interface {
+	// Shuffle returns every eligible item in a weight-biased random order, heavier items tending first
+	// Returns ErrEmptyTable if no item is eligible in the context
+	Shuffle(ctx SelectionContext) ([]T, error)
+}
+
+// RollTable is a table with rows beyond items: rolling nothing, or rolling again
+type RollTable[T comparable] interface {
+	// AddNothing sets the weight of rolling no result; a weight less than 1 removes it
+	// Only Roll can roll nothing
+	AddNothing(weight int) SelectionTable[T]
+
+	// AddRollAgain adds an entry that selects count more results from the table when rolled
+	// Only Roll resolves roll-again entries
+	AddRollAgain(weight, count int, duplicates DuplicatePolicy) SelectionTable[T]
+
+	// Roll resolves one roll on the table, including nothing and roll-again entries
+	// Returns no items if nothing is rolled, and several if a roll-again entry is
+	// Returns ErrEmptyTable if the table has no items or special entries
+	Roll(ctx SelectionContext) ([]T, error)
+}
+
+// SerializableTable is a table that can be saved and restored, see TableFromData
+type SerializableTable[T comparable] interface {
+	// ToData returns the table's serializable form
+	ToData() TableData[T]
+}
+
 // SelectionContext provides conditional selection parameters and game state
 // Purpose: Allows selection tables to modify weights based on game conditions
 // like player level, environment, time of day, etc. Context values are used
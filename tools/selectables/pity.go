@@ -0,0 +1,136 @@
+package selectables
+
+import "fmt"
+
+// PityPolicy raises an item's chance of selection each time it is passed over
+// Purpose: Supports loot "streak breakers" such as a guaranteed rare within N pulls,
+// while selection still runs through the table's weighted roll and events.
+// The count of consecutive pulls without the item resets when it is selected.
+type PityPolicy struct {
+	// Increment is added to the item's effective weight for each consecutive
+	// pull that did not select it (soft pity)
+	Increment int `json:"increment,omitempty"`
+
+	// GuaranteeWithin, if greater than 0, makes the item certain to be selected
+	// no later than this many pulls after it was last selected (hard pity)
+	GuaranteeWithin int `json:"guarantee_within,omitempty"`
+}
+
+// Validate checks the policy for negative values
+func (p PityPolicy) Validate() error {
+	if p.Increment < 0 {
+		return fmt.Errorf("%w: pity increment must not be negative", ErrInvalidConfiguration)
+	}
+	if p.GuaranteeWithin < 0 {
+		return fmt.Errorf("%w: pity guarantee must not be negative", ErrInvalidConfiguration)
+	}
+	return nil
+}
+
+// isZero reports whether the policy has no effect
+func (p PityPolicy) isZero() bool {
+	return p.Increment == 0 && p.GuaranteeWithin == 0
+}
+
+// pityState tracks one item's policy and its consecutive misses
+type pityState struct {
+	policy PityPolicy
+	misses int
+}
+
+// guaranteed reports whether the next pull must select the item
+func (s *pityState) guaranteed() bool {
+	return s.policy.GuaranteeWithin > 0 && s.misses >= s.policy.GuaranteeWithin-1
+}
+
+// SetPity attaches a pity policy to an item already in the table
+// A zero policy removes pity from the item. Changing the policy resets its miss count.
+// Returns ErrItemNotFound if the item is not in the table
+func (t *BasicTable[T]) SetPity(item T, policy PityPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return NewSelectionError("set_pity", t.id, nil, err)
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if _, exists := t.items[item]; !exists {
+		return NewSelectionError("set_pity", t.id, nil, ErrItemNotFound).
+			AddDetail("item", fmt.Sprintf("%v", item))
+	}
+
+	if policy.isZero() {
+		delete(t.pity, item)
+		return nil
+	}
+	t.pity[item] = &pityState{policy: policy}
+	return nil
+}
+
+// PityCount returns how many consecutive pulls have not selected the item
+// Returns 0 for items without a pity policy
+func (t *BasicTable[T]) PityCount(item T) int {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	if state, ok := t.pity[item]; ok {
+		return state.misses
+	}
+	return 0
+}
+
+// applyPity returns weights adjusted by each item's pity state.
+// If any item is guaranteed, only guaranteed items remain selectable.
+// The input map is never modified.
+func (t *BasicTable[T]) applyPity(weights map[T]int) map[T]int {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	if len(t.pity) == 0 {
+		return weights
+	}
+
+	result := make(map[T]int, len(weights))
+	guaranteed := make(map[T]int)
+	for item, weight := range weights {
+		state, ok := t.pity[item]
+		if !ok {
+			result[item] = weight
+			continue
+		}
+		weight += state.misses * state.policy.Increment
+		result[item] = weight
+		if state.guaranteed() {
+			guaranteed[item] = weight
+		}
+	}
+
+	if len(guaranteed) > 0 {
+		return guaranteed
+	}
+	return result
+}
+
+// recordPull resets the selected item's pity and counts a miss for every other item
+func (t *BasicTable[T]) recordPull(selected T) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for item, state := range t.pity {
+		if item == selected {
+			state.misses = 0
+		} else {
+			state.misses++
+		}
+	}
+}
+
+// recordMiss counts a miss for every item, for pulls that selected no item
+func (t *BasicTable[T]) recordMiss() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for _, state := range t.pity {
+		state.misses++
+	}
+}
@@ -0,0 +1,110 @@
+package selectables
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/dice"
+)
+
+type PityTestSuite struct {
+	suite.Suite
+	table *BasicTable[string]
+	ctx   SelectionContext
+}
+
+func (s *PityTestSuite) SetupTest() {
+	s.table = NewBasicTable[string](BasicTableConfig{
+		ID:            "gacha",
+		Configuration: TableConfiguration{CacheWeights: true},
+	}).(*BasicTable[string])
+	s.table.Add("common", 99).Add("rare", 1)
+	s.ctx = NewSelectionContextWithRoller(dice.NewRoller())
+}
+
+func TestPityTestSuite(t *testing.T) {
+	suite.Run(t, new(PityTestSuite))
+}
+
+func (s *PityTestSuite) TestGuaranteeWithinNPulls() {
+	s.Require().NoError(s.table.SetPity("rare", PityPolicy{GuaranteeWithin: 10}))
+
+	sinceRare := 0
+	rares := 0
+	for i := 0; i < 500; i++ {
+		item, err := s.table.Select(s.ctx)
+		s.Require().NoError(err)
+
+		sinceRare++
+		if item == "rare" {
+			rares++
+			sinceRare = 0
+		}
+		s.Require().Less(sinceRare, 10, "rare must appear within 10 pulls")
+		s.Equal(sinceRare, s.table.PityCount("rare"))
+	}
+	s.GreaterOrEqual(rares, 50)
+}
+
+func (s *PityTestSuite) TestSelectManyCountsEachPull() {
+	s.Require().NoError(s.table.SetPity("rare", PityPolicy{GuaranteeWithin: 3}))
+
+	results, err := s.table.SelectMany(s.ctx, 30)
+	s.Require().NoError(err)
+
+	sinceRare := 0
+	for _, item := range results {
+		sinceRare++
+		if item == "rare" {
+			sinceRare = 0
+		}
+		s.Require().Less(sinceRare, 3)
+	}
+}
+
+func (s *PityTestSuite) TestSoftPityRaisesWeight() {
+	s.Require().NoError(s.table.SetPity("rare", PityPolicy{Increment: 5}))
+	weights := map[string]int{"common": 99, "rare": 1}
+
+	s.Equal(1, s.table.applyPity(weights)["rare"])
+
+	s.table.recordPull("common")
+	s.table.recordPull("common")
+	s.Equal(2, s.table.PityCount("rare"))
+	s.Equal(11, s.table.applyPity(weights)["rare"])
+	s.Equal(99, s.table.applyPity(weights)["common"])
+	s.Equal(1, weights["rare"], "input weights must not be modified")
+
+	s.table.recordPull("rare")
+	s.Equal(0, s.table.PityCount("rare"))
+	s.Equal(1, s.table.applyPity(weights)["rare"])
+}
+
+func (s *PityTestSuite) TestSetPity() {
+	s.Run("rejects missing items", func() {
+		err := s.table.SetPity("legendary", PityPolicy{Increment: 1})
+		s.ErrorIs(err, ErrItemNotFound)
+	})
+
+	s.Run("rejects negative values", func() {
+		err := s.table.SetPity("rare", PityPolicy{Increment: -1})
+		s.ErrorIs(err, ErrInvalidConfiguration)
+		err = s.table.SetPity("rare", PityPolicy{GuaranteeWithin: -1})
+		s.ErrorIs(err, ErrInvalidConfiguration)
+	})
+
+	s.Run("zero policy and removal clear pity", func() {
+		s.Require().NoError(s.table.SetPity("rare", PityPolicy{Increment: 1}))
+		s.table.recordPull("common")
+		s.Equal(1, s.table.PityCount("rare"))
+
+		s.Require().NoError(s.table.SetPity("rare", PityPolicy{}))
+		s.Equal(0, s.table.PityCount("rare"))
+
+		s.Require().NoError(s.table.SetPity("rare", PityPolicy{Increment: 1}))
+		s.table.recordPull("common")
+		s.table.Remove("rare")
+		s.Equal(0, s.table.PityCount("rare"))
+	})
+}
@@ -0,0 +1,136 @@
+package selectables
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/KirkDiggler/rpg-toolkit/dice"
+)
+
+// quantityMultiplierRegex splits a trailing multiplier ("x10", "*100" or "×10") from a quantity expression
+var quantityMultiplierRegex = regexp.MustCompile(`^(.+?)[x*×](\d+)$`)
+
+// Quantity is a dice expression for how many of an item a selection yields
+// Purpose: Lets treasure table entries carry their own amount ("gold" with "3d6x10")
+// so the game doesn't maintain a parallel map of items to dice.
+type Quantity struct {
+	expression string
+	pool       *dice.Pool // nil for a constant
+	constant   int
+	multiplier int
+}
+
+// ParseQuantity parses a quantity expression: a constant ("5") or dice
+// notation as dice.ParseNotation reads it ("2d6", "1d4+1", "2d6+1d4"), with an
+// optional multiplier ("3d6x10"). The multiplier may be written as x, * or ×,
+// and applies after the modifier.
+// Returns ErrInvalidDiceExpression if the expression cannot be parsed
+func ParseQuantity(expression string) (Quantity, error) {
+	normalized := strings.ToLower(strings.ReplaceAll(expression, " ", ""))
+
+	q := Quantity{expression: expression, multiplier: 1}
+	if matches := quantityMultiplierRegex.FindStringSubmatch(normalized); matches != nil {
+		normalized = matches[1]
+		q.multiplier, _ = strconv.Atoi(matches[2])
+	}
+
+	if constant, err := strconv.Atoi(normalized); err == nil && constant >= 0 {
+		q.constant = constant
+		return q, nil
+	}
+
+	pool, err := dice.ParseNotation(normalized)
+	if err != nil {
+		return Quantity{}, fmt.Errorf("%w: %q: %w", ErrInvalidDiceExpression, expression, err)
+	}
+	// The pool's notation leaves out dice groups with no dice, as in "0d6"
+	if !strings.Contains(pool.Notation(), "d") {
+		return Quantity{}, fmt.Errorf("%w: %q needs at least one die", ErrInvalidDiceExpression, expression)
+	}
+	q.pool = pool
+
+	return q, nil
+}
+
+// String returns the expression the quantity was parsed from
+func (q Quantity) String() string {
+	return q.expression
+}
+
+// Roll rolls the quantity. The result is never negative.
+func (q Quantity) Roll(ctx context.Context, roller dice.Roller) (int, error) {
+	total := q.constant
+	if q.pool != nil {
+		if roller == nil {
+			return 0, ErrDiceRollerRequired
+		}
+		result := q.pool.RollContext(ctx, roller)
+		if err := result.Error(); err != nil {
+			return 0, err
+		}
+		total = result.Total()
+	}
+
+	total *= q.multiplier
+	if total < 0 {
+		total = 0
+	}
+	return total, nil
+}
+
+// SetQuantity attaches a quantity expression to an item already in the table
+// An empty expression removes the quantity, so the item yields 1
+// Returns ErrInvalidDiceExpression if the expression cannot be parsed
+// Returns ErrItemNotFound if the item is not in the table
+func (t *BasicTable[T]) SetQuantity(item T, expression string) error {
+	var quantity Quantity
+	if expression != "" {
+		var err error
+		quantity, err = ParseQuantity(expression)
+		if err != nil {
+			return NewSelectionError("set_quantity", t.id, nil, err).
+				AddDetail("dice_expression", expression)
+		}
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if _, exists := t.items[item]; !exists {
+		return NewSelectionError("set_quantity", t.id, nil, ErrItemNotFound).
+			AddDetail("item", fmt.Sprintf("%v", item))
+	}
+
+	if expression == "" {
+		delete(t.quantities, item)
+		return nil
+	}
+	t.quantities[item] = quantity
+	return nil
+}
+
+// SelectWithQuantity selects one item and rolls its quantity
+// Items without a quantity expression yield 1
+func (t *BasicTable[T]) SelectWithQuantity(ctx SelectionContext) (T, int, error) {
+	item, err := t.Select(ctx)
+	if err != nil {
+		return item, 0, err
+	}
+
+	t.mutex.RLock()
+	quantity, ok := t.quantities[item]
+	t.mutex.RUnlock()
+	if !ok {
+		return item, 1, nil
+	}
+
+	amount, err := quantity.Roll(context.Background(), ctx.GetDiceRoller())
+	if err != nil {
+		return item, 0, NewSelectionError("select_with_quantity", t.id, ctx, err).
+			AddDetail("dice_expression", quantity.String())
+	}
+	return item, amount, nil
+}
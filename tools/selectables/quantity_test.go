@@ -0,0 +1,108 @@
+package selectables
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type QuantityTestSuite struct {
+	suite.Suite
+	table *BasicTable[string]
+}
+
+func (s *QuantityTestSuite) SetupTest() {
+	s.table = NewBasicTable[string](BasicTableConfig{ID: "treasure"}).(*BasicTable[string])
+	s.table.Add("gold", 10)
+}
+
+func TestQuantityTestSuite(t *testing.T) {
+	suite.Run(t, new(QuantityTestSuite))
+}
+
+func (s *QuantityTestSuite) TestParseAndRoll() {
+	testCases := []struct {
+		expression string
+		rolls      []int
+		want       int
+	}{
+		{expression: "5", want: 5},
+		{expression: "d6", rolls: []int{4}, want: 4},
+		{expression: "2d6", rolls: []int{3, 5}, want: 8},
+		{expression: "1d4+1", rolls: []int{2}, want: 3},
+		{expression: "1d4-3", rolls: []int{1}, want: 0},
+		{expression: "3d6x10", rolls: []int{1, 2, 3}, want: 60},
+		{expression: "3d6×10", rolls: []int{6, 6, 6}, want: 180},
+		{expression: "2D4 * 100", rolls: []int{1, 1}, want: 200},
+		{expression: "1d6+1x10", rolls: []int{1}, want: 20},
+		{expression: "2d6+1d4x2", rolls: []int{3, 5, 2}, want: 20},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.expression, func() {
+			quantity, err := ParseQuantity(tc.expression)
+			s.Require().NoError(err)
+			s.Equal(tc.expression, quantity.String())
+
+			amount, err := quantity.Roll(context.Background(), NewTestRoller(tc.rolls))
+			s.Require().NoError(err)
+			s.Equal(tc.want, amount)
+		})
+	}
+}
+
+func (s *QuantityTestSuite) TestParseRejectsInvalid() {
+	for _, expression := range []string{"", "gold", "2d", "0d6", "2d0", "3d6x", "1d6/2"} {
+		s.Run(expression, func() {
+			_, err := ParseQuantity(expression)
+			s.ErrorIs(err, ErrInvalidDiceExpression)
+		})
+	}
+}
+
+func (s *QuantityTestSuite) TestSelectWithQuantity() {
+	s.Require().NoError(s.table.SetQuantity("gold", "3d6x10"))
+	ctx := NewSelectionContextWithRoller(NewTestRoller([]int{4}))
+
+	item, amount, err := s.table.SelectWithQuantity(ctx)
+	s.Require().NoError(err)
+	s.Equal("gold", item)
+	s.Equal(120, amount)
+}
+
+func (s *QuantityTestSuite) TestItemWithoutQuantityYieldsOne() {
+	s.table = NewBasicTable[string](BasicTableConfig{ID: "treasure"}).(*BasicTable[string])
+	s.table.Add("crown", 1)
+	ctx := NewSelectionContextWithRoller(NewTestRoller([]int{1}))
+
+	item, amount, err := s.table.SelectWithQuantity(ctx)
+	s.Require().NoError(err)
+	s.Equal("crown", item)
+	s.Equal(1, amount)
+}
+
+func (s *QuantityTestSuite) TestSetQuantity() {
+	s.ErrorIs(s.table.SetQuantity("silver", "2d6"), ErrItemNotFound)
+	s.ErrorIs(s.table.SetQuantity("gold", "lots"), ErrInvalidDiceExpression)
+
+	ctx := NewSelectionContextWithRoller(NewTestRoller([]int{6}))
+	s.Require().NoError(s.table.SetQuantity("gold", "2d6"))
+	s.Require().NoError(s.table.SetQuantity("gold", ""))
+	_, amount, err := s.table.SelectWithQuantity(ctx)
+	s.Require().NoError(err)
+	s.Equal(1, amount, "empty expression removes the quantity")
+
+	s.Require().NoError(s.table.SetQuantity("gold", "2d6"))
+	s.table.Remove("gold")
+	s.table.Add("gold", 10)
+	_, amount, err = s.table.SelectWithQuantity(ctx)
+	s.Require().NoError(err)
+	s.Equal(1, amount, "removing an item drops its quantity")
+}
+
+func (s *QuantityTestSuite) TestSelectWithQuantityEmptyTable() {
+	s.table.Clear()
+	_, _, err := s.table.SelectWithQuantity(NewSelectionContextWithRoller(NewTestRoller(nil)))
+	s.ErrorIs(err, ErrEmptyTable)
+}
@@ -0,0 +1,185 @@
+package selectables
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// TableRegistry holds selection tables keyed by ID
+// Purpose: Loads a game's encounter and treasure tables from config at startup
+// and lets tables nest each other by ID.
+type TableRegistry[T comparable] struct {
+	mu     sync.RWMutex
+	tables map[string]SelectionTable[T]
+}
+
+// NewTableRegistry creates an empty table registry
+func NewTableRegistry[T comparable]() *TableRegistry[T] {
+	return &TableRegistry[T]{tables: make(map[string]SelectionTable[T])}
+}
+
+// Register adds a table under an ID
+// Returns ErrInvalidConfiguration if the ID is empty or the table is nil
+// Returns ErrDuplicateTable if a table is already registered under the ID
+func (r *TableRegistry[T]) Register(id string, table SelectionTable[T]) error {
+	if id == "" || table == nil {
+		return fmt.Errorf("%w: a table and an ID are required to register", ErrInvalidConfiguration)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.tables[id]; exists {
+		return fmt.Errorf("%w: %s", ErrDuplicateTable, id)
+	}
+	r.tables[id] = table
+	return nil
+}
+
+// Get returns the table registered under an ID
+// Returns ErrTableNotFound if no table is registered under the ID
+func (r *TableRegistry[T]) Get(id string) (SelectionTable[T], error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	table, ok := r.tables[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTableNotFound, id)
+	}
+	return table, nil
+}
+
+// IDs returns the IDs of all registered tables in sorted order
+func (r *TableRegistry[T]) IDs() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := make([]string, 0, len(r.tables))
+	for id := range r.tables {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Load creates tables from their data and registers them. Tables may nest
+// each other by ID in any order, and may nest tables already registered.
+// Either every table is registered or, on error, none are.
+// Returns ErrCircularReference if tables nest each other in a cycle
+// Returns ErrDuplicateTable if an ID is repeated or already registered
+func (r *TableRegistry[T]) Load(tables ...TableData[T]) error {
+	pending := make(map[string]TableData[T], len(tables))
+	for _, data := range tables {
+		if data.ID == "" {
+			return fmt.Errorf("%w: loaded tables need an ID", ErrInvalidConfiguration)
+		}
+		if _, exists := pending[data.ID]; exists {
+			return fmt.Errorf("%w: %s", ErrDuplicateTable, data.ID)
+		}
+		pending[data.ID] = data
+	}
+
+	// Build into a staging registry so nested lookups see both existing and new tables
+	staged := NewTableRegistry[T]()
+	r.mu.RLock()
+	for id, table := range r.tables {
+		if _, exists := pending[id]; exists {
+			r.mu.RUnlock()
+			return fmt.Errorf("%w: %s", ErrDuplicateTable, id)
+		}
+		staged.tables[id] = table
+	}
+	r.mu.RUnlock()
+
+	loader := tableLoader[T]{pending: pending, staged: staged, visiting: make(map[string]bool)}
+	for _, data := range tables {
+		if err := loader.load(data.ID); err != nil {
+			return err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id := range pending {
+		if _, exists := r.tables[id]; exists {
+			return fmt.Errorf("%w: %s", ErrDuplicateTable, id)
+		}
+	}
+	for id := range pending {
+		r.tables[id] = staged.tables[id]
+	}
+	return nil
+}
+
+// LoadJSON loads a JSON table or array of tables, see Load
+func (r *TableRegistry[T]) LoadJSON(data []byte) error {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var tables []TableData[T]
+		if err := json.Unmarshal(trimmed, &tables); err != nil {
+			return fmt.Errorf("failed to unmarshal tables: %w", err)
+		}
+		return r.Load(tables...)
+	}
+
+	var table TableData[T]
+	if err := json.Unmarshal(trimmed, &table); err != nil {
+		return fmt.Errorf("failed to unmarshal table: %w", err)
+	}
+	return r.Load(table)
+}
+
+// tableLoader builds pending tables after the tables they nest
+type tableLoader[T comparable] struct {
+	pending  map[string]TableData[T]
+	staged   *TableRegistry[T]
+	visiting map[string]bool
+}
+
+// load builds the pending table with the ID, and first every pending table it nests
+func (l *tableLoader[T]) load(id string) error {
+	if _, built := l.staged.tables[id]; built {
+		return nil
+	}
+	if l.visiting[id] {
+		return fmt.Errorf("%w: %s", ErrCircularReference, id)
+	}
+	l.visiting[id] = true
+	defer delete(l.visiting, id)
+
+	data := l.pending[id]
+	for _, ref := range nestedRefs(data) {
+		if _, ok := l.pending[ref]; !ok {
+			// Already registered, or missing and reported by TableFromData
+			continue
+		}
+		if err := l.load(ref); err != nil {
+			return err
+		}
+	}
+
+	table, err := TableFromData(data, TableDataConfig[T]{Tables: l.staged})
+	if err != nil {
+		return err
+	}
+	l.staged.tables[id] = table
+	return nil
+}
+
+// nestedRefs returns the IDs of tables nested by reference, including inside inline tables
+func nestedRefs[T comparable](data TableData[T]) []string {
+	var refs []string
+	for _, entry := range data.Entries {
+		if entry.Table != "" {
+			refs = append(refs, entry.Table)
+		}
+		if entry.Nested != nil {
+			refs = append(refs, nestedRefs(*entry.Nested)...)
+		}
+	}
+	return refs
+}
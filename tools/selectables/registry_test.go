@@ -0,0 +1,104 @@
+package selectables
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TableRegistryTestSuite struct {
+	suite.Suite
+	registry *TableRegistry[string]
+}
+
+func (s *TableRegistryTestSuite) SetupTest() {
+	s.registry = NewTableRegistry[string]()
+}
+
+func TestTableRegistryTestSuite(t *testing.T) {
+	suite.Run(t, new(TableRegistryTestSuite))
+}
+
+func (s *TableRegistryTestSuite) TestLoadJSONResolvesReferencesInAnyOrder() {
+	s.Require().NoError(s.registry.LoadJSON([]byte(`[
+		{"id": "forest", "entries": [
+			{"item": "wolves", "weight": 6},
+			{"table": "undead", "weight": 4, "conditions": {"time": "night"}}
+		]},
+		{"id": "undead", "entries": [
+			{"item": "skeletons", "weight": 3},
+			{"item": "ghoul", "weight": 1}
+		]}
+	]`)))
+
+	s.Equal([]string{"forest", "undead"}, s.registry.IDs())
+
+	forest, err := s.registry.Get("forest")
+	s.Require().NoError(err)
+	s.Equal(map[string]int{"wolves": 6, "skeletons": 3, "ghoul": 1}, forest.GetItems())
+
+	day := NewContextBuilderWithRoller(NewTestRoller([]int{10})).SetString("time", "day").Build()
+	encounter, err := forest.Select(day)
+	s.Require().NoError(err)
+	s.Equal("wolves", encounter, "undead only appear at night")
+
+	// A single table object loads too, and may nest tables already registered
+	s.Require().NoError(s.registry.LoadJSON([]byte(`{"id": "crypt", "entries": [{"table": "undead", "weight": 1}]}`)))
+	_, err = s.registry.Get("crypt")
+	s.NoError(err)
+}
+
+func (s *TableRegistryTestSuite) TestLoadIsAllOrNothing() {
+	testCases := []struct {
+		name   string
+		tables []TableData[string]
+		want   error
+	}{
+		{
+			name: "cycle",
+			tables: []TableData[string]{
+				{ID: "a", Entries: []EntryData[string]{{Table: "b", Weight: 1}}},
+				{ID: "b", Entries: []EntryData[string]{{Nested: &TableData[string]{
+					Entries: []EntryData[string]{{Table: "a", Weight: 1}},
+				}, Weight: 1}}},
+			},
+			want: ErrCircularReference,
+		},
+		{
+			name: "missing reference",
+			tables: []TableData[string]{
+				{ID: "a", Entries: []EntryData[string]{{Item: "gold", Weight: 1}}},
+				{ID: "b", Entries: []EntryData[string]{{Table: "c", Weight: 1}}},
+			},
+			want: ErrTableNotFound,
+		},
+		{
+			name:   "repeated ID",
+			tables: []TableData[string]{{ID: "a"}, {ID: "a"}},
+			want:   ErrDuplicateTable,
+		},
+		{
+			name:   "missing ID",
+			tables: []TableData[string]{{}},
+			want:   ErrInvalidConfiguration,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.ErrorIs(s.registry.Load(tc.tables...), tc.want)
+			s.Empty(s.registry.IDs())
+		})
+	}
+}
+
+func (s *TableRegistryTestSuite) TestRegister() {
+	table := NewBasicTable[string](BasicTableConfig{ID: "loot"})
+	s.Require().NoError(s.registry.Register("loot", table))
+	s.ErrorIs(s.registry.Register("loot", table), ErrDuplicateTable)
+	s.ErrorIs(s.registry.Register("", table), ErrInvalidConfiguration)
+	s.ErrorIs(s.registry.Load(TableData[string]{ID: "loot"}), ErrDuplicateTable)
+
+	_, err := s.registry.Get("missing")
+	s.ErrorIs(err, ErrTableNotFound)
+}
@@ -0,0 +1,251 @@
+package selectables
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// maxRollAgainDepth is how deep roll-again entries may nest. Rolls at this
+// depth leave roll-again entries out, so a run of roll-again results ends.
+const maxRollAgainDepth = 10
+
+// DuplicatePolicy controls what a roll-again entry does with a result it already rolled
+type DuplicatePolicy string
+
+const (
+	// DuplicatesAllow keeps repeated results (the default)
+	DuplicatesAllow DuplicatePolicy = "allow"
+	// DuplicatesReroll rolls again until the result is new, leaving rolled items out of the roll
+	DuplicatesReroll DuplicatePolicy = "reroll"
+	// DuplicatesDiscard drops repeated results, so fewer results may be returned
+	DuplicatesDiscard DuplicatePolicy = "discard"
+)
+
+// Validate checks the policy is a known policy or empty
+func (p DuplicatePolicy) Validate() error {
+	switch p {
+	case "", DuplicatesAllow, DuplicatesReroll, DuplicatesDiscard:
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown duplicate policy %q", ErrInvalidConfiguration, p)
+	}
+}
+
+// specialEntry is a weighted entry that isn't a single item. Roll resolves
+// special entries; Select and the other selection methods ignore them.
+type specialEntry[T comparable] struct {
+	weight int
+
+	// nothing entries yield no result
+	nothing bool
+
+	// rollAgain entries select this many more results from the table
+	rollAgain  int
+	duplicates DuplicatePolicy
+
+	// table entries roll once on a nested table that has special entries of its own
+	table      *BasicTable[T]
+	conditions map[string]interface{}
+}
+
+// AddNothing sets the weight of rolling no result, like a "01-40: nothing" row.
+// Only Roll can roll nothing. A weight less than 1 removes the entry.
+func (t *BasicTable[T]) AddNothing(weight int) SelectionTable[T] {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	entries := t.special[:0]
+	for _, entry := range t.special {
+		if !entry.nothing {
+			entries = append(entries, entry)
+		}
+	}
+	t.special = entries
+	if weight >= 1 {
+		t.special = append(t.special, specialEntry[T]{weight: t.clampWeight(weight), nothing: true})
+	}
+	return t
+}
+
+// AddRollAgain adds an entry that, when rolled, selects count more results from
+// the table, like a "roll twice more" row. Each extra roll may itself roll again,
+// up to a fixed depth. duplicates controls results the entry already rolled.
+// Only Roll resolves roll-again entries. A count less than 1 is treated as 1.
+func (t *BasicTable[T]) AddRollAgain(weight, count int, duplicates DuplicatePolicy) SelectionTable[T] {
+	if count < 1 {
+		count = 1
+	}
+	if duplicates == "" || duplicates.Validate() != nil {
+		duplicates = DuplicatesAllow
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.special = append(t.special, specialEntry[T]{
+		weight:     t.clampWeight(weight),
+		rollAgain:  count,
+		duplicates: duplicates,
+	})
+	return t
+}
+
+// Roll resolves one roll on the table, including nothing and roll-again entries
+// and nested tables that have them. Returns no items if nothing is rolled and
+// several if a roll-again entry is.
+// Returns ErrEmptyTable if the table has no items or special entries
+func (t *BasicTable[T]) Roll(ctx SelectionContext) ([]T, error) {
+	startTime := time.Now()
+
+	if ctx == nil {
+		return nil, NewSelectionError("roll", t.id, ctx, ErrContextRequired)
+	}
+	if ctx.GetDiceRoller() == nil {
+		return nil, NewSelectionError("roll", t.id, ctx, ErrDiceRollerRequired)
+	}
+
+	t.mutex.RLock()
+	empty := len(t.items) == 0 && len(t.special) == 0
+	t.mutex.RUnlock()
+	if empty {
+		return nil, NewSelectionError("roll", t.id, ctx, ErrEmptyTable)
+	}
+
+	results, err := t.roll(ctx, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.config.EnableEvents && t.connectedTopics.selectionCompleted != nil {
+		event := SelectionCompletedEvent{
+			TableID:       t.id,
+			Operation:     "roll",
+			RequestCount:  1,
+			ActualCount:   len(results),
+			SelectionMode: "standard",
+			DurationMs:    int(time.Since(startTime).Milliseconds()),
+			CompletedAt:   time.Now(),
+		}
+		_ = t.connectedTopics.selectionCompleted.Publish(context.Background(), event)
+	}
+	return results, nil
+}
+
+// roll makes one weighted roll over the eligible items and special entries,
+// leaving out excluded items
+func (t *BasicTable[T]) roll(ctx SelectionContext, depth int, excluded map[T]bool) ([]T, error) {
+	weights, err := t.getEffectiveWeightsExcluding(ctx, excluded)
+	if err != nil {
+		return nil, NewSelectionError("roll", t.id, ctx, err)
+	}
+	weights = t.applyPity(weights)
+
+	t.mutex.RLock()
+	special := make([]specialEntry[T], 0, len(t.special))
+	for _, entry := range t.special {
+		if entry.rollAgain > 0 && depth >= maxRollAgainDepth {
+			continue
+		}
+		if !conditionsMatch(entry.conditions, ctx) {
+			continue
+		}
+		special = append(special, entry)
+	}
+	t.mutex.RUnlock()
+
+	totalWeight := 0
+	for _, weight := range weights {
+		totalWeight += weight
+	}
+	for _, entry := range special {
+		totalWeight += entry.weight
+	}
+	if totalWeight <= 0 {
+		if depth == 0 {
+			return nil, NewSelectionError("roll", t.id, ctx, ErrEmptyTable).
+				AddDetail("reason", "no entry is eligible")
+		}
+		// Extra rolls that run out of eligible entries come up empty
+		return nil, nil
+	}
+
+	rollValue, err := ctx.GetDiceRoller().Roll(context.Background(), totalWeight)
+	if err != nil {
+		return nil, NewSelectionError("roll", t.id, ctx, err)
+	}
+
+	currentWeight := 0
+	for item, weight := range weights {
+		currentWeight += weight
+		if rollValue <= currentWeight {
+			t.recordPull(item)
+			return []T{item}, nil
+		}
+	}
+
+	for _, entry := range special {
+		currentWeight += entry.weight
+		if rollValue > currentWeight {
+			continue
+		}
+		t.recordMiss()
+
+		switch {
+		case entry.nothing:
+			return nil, nil
+		case entry.table != nil:
+			return entry.table.Roll(ctx)
+		default:
+			return t.rollAgain(ctx, depth, excluded, entry)
+		}
+	}
+
+	return nil, NewSelectionError("roll", t.id, ctx, ErrEmptyTable).
+		AddDetail("reason", "selection algorithm failed").
+		AddDetail("roll_value", rollValue).
+		AddDetail("total_weight", totalWeight)
+}
+
+// rollAgain makes a roll-again entry's extra rolls, applying its duplicate policy
+func (t *BasicTable[T]) rollAgain(
+	ctx SelectionContext, depth int, excluded map[T]bool, entry specialEntry[T],
+) ([]T, error) {
+	var results []T
+	rolled := make(map[T]bool)
+	for i := 0; i < entry.rollAgain; i++ {
+		rollExcluded := excluded
+		if entry.duplicates == DuplicatesReroll {
+			rollExcluded = make(map[T]bool, len(excluded)+len(rolled))
+			for item := range excluded {
+				rollExcluded[item] = true
+			}
+			for item := range rolled {
+				rollExcluded[item] = true
+			}
+		}
+
+		extra, err := t.roll(ctx, depth+1, rollExcluded)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range extra {
+			if rolled[item] && entry.duplicates != DuplicatesAllow {
+				continue
+			}
+			rolled[item] = true
+			results = append(results, item)
+		}
+	}
+	return results, nil
+}
+
+// withSpecialEntries returns the table as a BasicTable if it has entries only Roll resolves
+func withSpecialEntries[T comparable](table SelectionTable[T]) (*BasicTable[T], bool) {
+	basic, ok := table.(*BasicTable[T])
+	if !ok {
+		return nil, false
+	}
+	basic.mutex.RLock()
+	defer basic.mutex.RUnlock()
+	return basic, len(basic.special) > 0
+}
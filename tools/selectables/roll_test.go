@@ -0,0 +1,168 @@
+package selectables
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RollTestSuite struct {
+	suite.Suite
+	table *BasicTable[string]
+}
+
+func (s *RollTestSuite) SetupTest() {
+	s.table = NewBasicTable[string](BasicTableConfig{ID: "stocking"}).(*BasicTable[string])
+}
+
+func TestRollTestSuite(t *testing.T) {
+	suite.Run(t, new(RollTestSuite))
+}
+
+// roll rolls on the table with a roller returning values in turn
+func (s *RollTestSuite) roll(values ...int) []string {
+	results, err := s.table.Roll(NewSelectionContextWithRoller(NewTestRoller(values)))
+	s.Require().NoError(err)
+	return results
+}
+
+func (s *RollTestSuite) TestNothingAndRollAgain() {
+	// Items roll first, then special entries in the order they were added
+	s.table.Add("gold", 10)
+	s.table.AddNothing(10).(RollTable[string]).AddRollAgain(10, 2, DuplicatesAllow)
+
+	s.Equal([]string{"gold"}, s.roll(5))
+	s.Empty(s.roll(15))
+	s.Equal([]string{"gold", "gold"}, s.roll(25, 5, 5))
+
+	// Select ignores the special entries
+	item, err := s.table.Select(NewSelectionContextWithRoller(NewTestRoller([]int{25})))
+	s.Require().NoError(err)
+	s.Equal("gold", item)
+}
+
+func (s *RollTestSuite) TestDuplicatePolicies() {
+	s.Run("reroll", func() {
+		s.table.Clear()
+		s.table.Add("gold", 10).Add("gem", 10)
+		s.table.AddRollAgain(10, 2, DuplicatesReroll)
+		s.ElementsMatch([]string{"gold", "gem"}, s.roll(30, 1, 1))
+	})
+
+	s.Run("discard", func() {
+		s.table.Clear()
+		s.table.Add("gold", 10)
+		s.table.AddRollAgain(10, 2, DuplicatesDiscard)
+		s.Equal([]string{"gold"}, s.roll(20, 1, 1))
+	})
+}
+
+func (s *RollTestSuite) TestRollAgainDepthIsLimited() {
+	s.table.Add("gold", 1)
+	s.table.AddRollAgain(1000, 1, DuplicatesAllow)
+	s.Equal([]string{"gold"}, s.roll(1000), "the deepest roll leaves roll again out")
+
+	s.table.Remove("gold")
+	s.Empty(s.roll(1), "a table of only roll again ends empty")
+}
+
+func (s *RollTestSuite) TestNestedTablesKeepSpecialEntries() {
+	gems := NewBasicTable[string](BasicTableConfig{ID: "gems"}).(*BasicTable[string])
+	gems.Add("ruby", 1)
+	gems.AddNothing(1)
+	s.table.Add("gold", 1).AddTable("gems", gems, 1)
+
+	s.Equal(map[string]int{"gold": 1}, s.table.GetItems(), "the nested table is not flattened")
+	s.Equal([]string{"ruby"}, s.roll(2, 1))
+	s.Empty(s.roll(2, 2))
+}
+
+func (s *RollTestSuite) TestNothingCountsAsPityMiss() {
+	s.table.Add("gold", 10)
+	s.table.AddNothing(10)
+	s.Require().NoError(s.table.SetPity("gold", PityPolicy{Increment: 1}))
+
+	s.Empty(s.roll(20))
+	s.Equal(1, s.table.PityCount("gold"))
+}
+
+func (s *RollTestSuite) TestRollErrors() {
+	_, err := s.table.Roll(NewBasicSelectionContext())
+	s.ErrorIs(err, ErrEmptyTable)
+
+	_, err = s.table.Roll(nil)
+	s.ErrorIs(err, ErrContextRequired)
+
+	s.table.AddNothing(5)
+	s.Empty(s.roll(1), "a table of only nothing rolls nothing")
+	_, err = s.table.Select(NewBasicSelectionContext())
+	s.ErrorIs(err, ErrEmptyTable)
+
+	s.table.AddNothing(0)
+	_, err = s.table.Roll(NewBasicSelectionContext())
+	s.ErrorIs(err, ErrEmptyTable, "a weight less than 1 removes nothing")
+}
+
+func (s *RollTestSuite) TestDataRoundTrip() {
+	treasure, err := TableFromData(TableData[string]{
+		ID: "treasure",
+		Entries: []EntryData[string]{
+			{Item: "gold", Weight: 50},
+			{Nothing: true, Weight: 40},
+			{RollAgain: 2, Duplicates: DuplicatesReroll, Weight: 5},
+			{Nested: &TableData[string]{
+				ID:      "gems",
+				Entries: []EntryData[string]{{Item: "ruby", Weight: 1}, {Nothing: true, Weight: 3}},
+			}, Weight: 5, Conditions: map[string]interface{}{"terrain": "cave"}},
+		},
+	}, TableDataConfig[string]{})
+	s.Require().NoError(err)
+
+	encoded, err := json.Marshal(treasure.(SerializableTable[string]).ToData())
+	s.Require().NoError(err)
+	s.JSONEq(`{
+		"id": "treasure",
+		"min_weight": 1,
+		"max_weight": 1000000,
+		"entries": [
+			{"item": "gold", "weight": 50},
+			{"nothing": true, "weight": 40},
+			{"roll_again": 2, "duplicates": "reroll", "weight": 5},
+			{"weight": 5, "conditions": {"terrain": "cave"}, "nested": {
+				"id": "gems",
+				"min_weight": 1,
+				"max_weight": 1000000,
+				"entries": [{"item": "ruby", "weight": 1}, {"nothing": true, "weight": 3}]
+			}}
+		]
+	}`, string(encoded))
+
+	var data TableData[string]
+	s.Require().NoError(json.Unmarshal(encoded, &data))
+	loaded, err := TableFromData(data, TableDataConfig[string]{})
+	s.Require().NoError(err)
+	s.Equal(treasure.(SerializableTable[string]).ToData(), loaded.(SerializableTable[string]).ToData())
+}
+
+func (s *RollTestSuite) TestInvalidSpecialEntries() {
+	testCases := []struct {
+		name  string
+		entry EntryData[string]
+	}{
+		{name: "nothing and roll again", entry: EntryData[string]{Nothing: true, RollAgain: 2, Weight: 1}},
+		{name: "negative roll again", entry: EntryData[string]{RollAgain: -1, Weight: 1}},
+		{name: "nothing with an item", entry: EntryData[string]{Item: "gold", Nothing: true, Weight: 1}},
+		{name: "roll again with conditions", entry: EntryData[string]{
+			RollAgain: 1, Weight: 1, Conditions: map[string]interface{}{"level": 1},
+		}},
+		{name: "unknown duplicate policy", entry: EntryData[string]{RollAgain: 1, Duplicates: "merge", Weight: 1}},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			_, err := TableFromData(TableData[string]{ID: "broken", Entries: []EntryData[string]{tc.entry}}, TableDataConfig[string]{})
+			s.ErrorIs(err, ErrInvalidConfiguration)
+		})
+	}
+}
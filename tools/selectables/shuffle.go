@@ -0,0 +1,77 @@
+package selectables
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// shuffleResolution is the die size used to draw each item's random key
+const shuffleResolution = 1 << 20
+
+// Shuffle returns every eligible item in a weight-biased random order. Each
+// position is drawn like a SelectUnique pick from the items not yet placed,
+// so heavier items tend to come first. Runs in O(n log n) with one roll per item,
+// using Efraimidis-Spirakis keys, rather than repeated select-and-remove.
+// Pity is neither applied nor advanced, and special entries are ignored.
+// Returns ErrEmptyTable if no item is eligible in the context
+func (t *BasicTable[T]) Shuffle(ctx SelectionContext) ([]T, error) {
+	startTime := time.Now()
+
+	if ctx == nil {
+		return nil, NewSelectionError("shuffle", t.id, ctx, ErrContextRequired)
+	}
+	roller := ctx.GetDiceRoller()
+	if roller == nil {
+		return nil, NewSelectionError("shuffle", t.id, ctx, ErrDiceRollerRequired)
+	}
+
+	weights, err := t.getEffectiveWeights(ctx)
+	if err != nil {
+		return nil, NewSelectionError("shuffle", t.id, ctx, err)
+	}
+	if len(weights) == 0 {
+		return nil, NewSelectionError("shuffle", t.id, ctx, ErrEmptyTable)
+	}
+
+	// Order the items first so the same rolls always give the same order
+	items := make([]T, 0, len(weights))
+	for item := range weights {
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return fmt.Sprintf("%v", items[i]) < fmt.Sprintf("%v", items[j])
+	})
+
+	rolls, err := roller.RollN(context.Background(), len(items), shuffleResolution)
+	if err != nil {
+		return nil, NewSelectionError("shuffle", t.id, ctx, err)
+	}
+
+	// Each item's key is ln(u)/weight for a uniform u in (0, 1); sorting by key,
+	// largest first, gives the weighted order
+	keys := make(map[T]float64, len(items))
+	for i, item := range items {
+		u := (float64(rolls[i]) - 0.5) / shuffleResolution
+		keys[item] = math.Log(u) / float64(weights[item])
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return keys[items[i]] > keys[items[j]]
+	})
+
+	if t.config.EnableEvents && t.connectedTopics.selectionCompleted != nil {
+		event := SelectionCompletedEvent{
+			TableID:       t.id,
+			Operation:     "shuffle",
+			RequestCount:  len(items),
+			ActualCount:   len(items),
+			SelectionMode: "standard",
+			DurationMs:    int(time.Since(startTime).Milliseconds()),
+			CompletedAt:   time.Now(),
+		}
+		_ = t.connectedTopics.selectionCompleted.Publish(context.Background(), event)
+	}
+	return items, nil
+}
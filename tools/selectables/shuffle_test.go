@@ -0,0 +1,63 @@
+package selectables
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ShuffleTestSuite struct {
+	suite.Suite
+	table *BasicTable[string]
+}
+
+func (s *ShuffleTestSuite) SetupTest() {
+	s.table = NewBasicTable[string](BasicTableConfig{ID: "initiative"}).(*BasicTable[string])
+	s.table.Add("rogue", 30).Add("fighter", 10).Add("wizard", 20)
+}
+
+func TestShuffleTestSuite(t *testing.T) {
+	suite.Run(t, new(ShuffleTestSuite))
+}
+
+func (s *ShuffleTestSuite) TestEqualRollsOrderByWeight() {
+	ctx := NewSelectionContextWithRoller(NewTestRoller([]int{1000}))
+	order, err := s.table.Shuffle(ctx)
+	s.Require().NoError(err)
+	s.Equal([]string{"rogue", "wizard", "fighter"}, order)
+}
+
+func (s *ShuffleTestSuite) TestRollsCanOverturnWeight() {
+	// Items are rolled for in name order: fighter, rogue, wizard
+	ctx := NewSelectionContextWithRoller(NewTestRoller([]int{shuffleResolution, 1, 1000}))
+	order, err := s.table.Shuffle(ctx)
+	s.Require().NoError(err)
+	s.Equal([]string{"fighter", "wizard", "rogue"}, order)
+}
+
+func (s *ShuffleTestSuite) TestFirstPlaceFollowsWeights() {
+	const samples = 6000
+	first := make(map[string]int)
+	for i := 0; i < samples; i++ {
+		order, err := s.table.Shuffle(NewBasicSelectionContext())
+		s.Require().NoError(err)
+		s.Require().Len(order, 3)
+		first[order[0]]++
+	}
+
+	s.InDelta(0.5, float64(first["rogue"])/samples, 0.03)
+	s.InDelta(1.0/3, float64(first["wizard"])/samples, 0.03)
+	s.InDelta(1.0/6, float64(first["fighter"])/samples, 0.03)
+}
+
+func (s *ShuffleTestSuite) TestOnlyEligibleItems() {
+	s.Require().NoError(s.table.SetConditions("wizard", map[string]interface{}{"awake": true}))
+	order, err := s.table.Shuffle(NewBasicSelectionContext())
+	s.Require().NoError(err)
+	s.ElementsMatch([]string{"rogue", "fighter"}, order)
+
+	_, err = NewBasicTable[string](BasicTableConfig{}).(ShuffleTable[string]).Shuffle(NewBasicSelectionContext())
+	s.ErrorIs(err, ErrEmptyTable)
+	_, err = s.table.Shuffle(nil)
+	s.ErrorIs(err, ErrContextRequired)
+}
@@ -115,6 +115,12 @@ type TableConfiguration struct {
 	// when the same context is used repeatedly
 	CacheWeights bool
 
+	// IndexedSelection makes Select and SelectMany pick items by binary search
+	// over cumulative weights, built once per context, instead of a linear scan.
+	// Suits large tables selected many times, like spawn tables with thousands
+	// of entries. Tables with pity or weight functions still scan linearly.
+	IndexedSelection bool
+
 	// MinWeight sets the minimum allowed weight for items (default: 1)
 	MinWeight int
 
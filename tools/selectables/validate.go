@@ -0,0 +1,254 @@
+package selectables
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ItemFrequency compares how often an item was drawn with how often its weight says it should be
+type ItemFrequency[T comparable] struct {
+	// Item is the table item
+	Item T
+
+	// Weight is the item's weight in the table
+	Weight int
+
+	// Count is how many draws selected the item
+	Count int
+
+	// Expected is the item's share of the total weight, between 0 and 1
+	Expected float64
+
+	// Observed is the item's share of the draws, between 0 and 1
+	Observed float64
+}
+
+// Deviation returns the observed frequency minus the expected frequency
+func (f ItemFrequency[T]) Deviation() float64 {
+	return f.Observed - f.Expected
+}
+
+// ValidationReport is the outcome of Validate
+// Purpose: Lets CI catch weight regressions in loot and encounter config by
+// comparing a table's draws with the frequencies its weights promise.
+type ValidationReport[T comparable] struct {
+	// TableID identifies the validated table
+	TableID string
+
+	// Samples is the number of draws made
+	Samples int
+
+	// Tolerance is the largest allowed absolute difference between an item's
+	// observed and expected frequency
+	Tolerance float64
+
+	// Items holds each eligible item's frequencies, ordered by item
+	Items []ItemFrequency[T]
+
+	// ChiSquared is Pearson's chi-squared statistic of the draws against the weights
+	ChiSquared float64
+
+	// DegreesOfFreedom is one less than the number of items
+	DegreesOfFreedom int
+
+	// PValue is the probability of a chi-squared statistic at least this large
+	// if the table selects exactly by its weights. A tiny p-value (e.g. below 0.001)
+	// means the draws don't follow the weights.
+	PValue float64
+}
+
+// Passed returns true if every item's frequency is within the tolerance
+func (r *ValidationReport[T]) Passed() bool {
+	return len(r.Failures()) == 0
+}
+
+// Failures returns the items whose frequency is outside the tolerance
+func (r *ValidationReport[T]) Failures() []ItemFrequency[T] {
+	var failures []ItemFrequency[T]
+	for _, item := range r.Items {
+		if math.Abs(item.Deviation()) > r.Tolerance {
+			failures = append(failures, item)
+		}
+	}
+	return failures
+}
+
+// String returns a table of observed and expected frequencies for test output
+func (r *ValidationReport[T]) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "table %s: %d samples, chi-squared %.2f (df %d, p %.4f)\n",
+		r.TableID, r.Samples, r.ChiSquared, r.DegreesOfFreedom, r.PValue)
+	for _, item := range r.Items {
+		status := "ok"
+		if math.Abs(item.Deviation()) > r.Tolerance {
+			status = "FAIL"
+		}
+		fmt.Fprintf(&b, "  %v: observed %.4f, expected %.4f (%+.4f) %s\n",
+			item.Item, item.Observed, item.Expected, item.Deviation(), status)
+	}
+	return b.String()
+}
+
+// Validate draws from the table samples times with a default context and reports
+// observed vs. expected frequencies. See ValidateWithContext.
+func Validate[T comparable](table SelectionTable[T], samples int, tolerance float64) (*ValidationReport[T], error) {
+	return ValidateWithContext(NewBasicSelectionContext(), table, samples, tolerance)
+}
+
+// ValidateWithContext draws from the table samples times and reports observed vs.
+// expected frequencies. The context's values decide which conditional items are
+// eligible and what weight functions return, and its roller makes the draws.
+//
+// Draws are made from a copy of the table, so the table's pity counters are not
+// advanced and no selection events are published. Items with a pity policy are
+// drawn more often than their weight alone promises. A table that isn't a
+// SerializableTable or ConditionalTable is validated from its GetItems weights.
+// Returns ErrInvalidCount if samples is less than 1
+// Returns ErrEmptyTable if no item is eligible in the context
+func ValidateWithContext[T comparable](
+	ctx SelectionContext, table SelectionTable[T], samples int, tolerance float64,
+) (*ValidationReport[T], error) {
+	data := validationData(table)
+	if samples < 1 {
+		return nil, NewSelectionError("validate", data.ID, ctx, ErrInvalidCount).
+			AddDetail("samples", samples)
+	}
+	if tolerance < 0 {
+		return nil, NewSelectionError("validate", data.ID, ctx, ErrInvalidConfiguration).
+			AddDetail("tolerance", tolerance)
+	}
+
+	weights := table.GetItems()
+	if conditional, ok := table.(ConditionalTable[T]); ok {
+		var err error
+		if weights, err = conditional.EffectiveWeights(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	// The copy is fixed at the weights the context gives, so weight functions
+	// are evaluated once rather than on every draw
+	report := &ValidationReport[T]{TableID: data.ID, Samples: samples, Tolerance: tolerance}
+	index := make(map[T]int)
+	totalWeight := 0
+	eligible := data.Entries[:0:0]
+	for _, entry := range data.Entries {
+		weight, ok := weights[entry.Item]
+		if !ok {
+			continue
+		}
+		entry.Weight = weight
+		eligible = append(eligible, entry)
+		index[entry.Item] = len(report.Items)
+		report.Items = append(report.Items, ItemFrequency[T]{Item: entry.Item, Weight: weight})
+		totalWeight += weight
+	}
+	if totalWeight == 0 {
+		return nil, NewSelectionError("validate", data.ID, ctx, ErrEmptyTable)
+	}
+	data.Entries = eligible
+
+	draws, err := TableFromData(data, TableDataConfig[T]{})
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < samples; i++ {
+		item, err := draws.Select(ctx)
+		if err != nil {
+			return nil, err
+		}
+		report.Items[index[item]].Count++
+	}
+
+	for i := range report.Items {
+		item := &report.Items[i]
+		item.Expected = float64(item.Weight) / float64(totalWeight)
+		item.Observed = float64(item.Count) / float64(samples)
+
+		expectedCount := item.Expected * float64(samples)
+		diff := float64(item.Count) - expectedCount
+		report.ChiSquared += diff * diff / expectedCount
+	}
+	report.DegreesOfFreedom = len(report.Items) - 1
+	report.PValue = chiSquaredPValue(report.ChiSquared, report.DegreesOfFreedom)
+
+	return report, nil
+}
+
+// validationData returns the table's serializable form, or its items and
+// weights for a table that isn't a SerializableTable
+func validationData[T comparable](table SelectionTable[T]) TableData[T] {
+	if serializable, ok := table.(SerializableTable[T]); ok {
+		return serializable.ToData()
+	}
+	items := table.GetItems()
+	data := TableData[T]{Entries: make([]EntryData[T], 0, len(items))}
+	for item, weight := range items {
+		data.Entries = append(data.Entries, EntryData[T]{Item: item, Weight: weight})
+	}
+	return data
+}
+
+// chiSquaredPValue returns P(X >= statistic) for a chi-squared distribution
+func chiSquaredPValue(statistic float64, degreesOfFreedom int) float64 {
+	if degreesOfFreedom < 1 {
+		return 1
+	}
+	return upperIncompleteGamma(float64(degreesOfFreedom)/2, statistic/2)
+}
+
+// upperIncompleteGamma returns the regularized upper incomplete gamma function Q(a, x),
+// by series for x < a+1 and by continued fraction otherwise
+func upperIncompleteGamma(a, x float64) float64 {
+	const (
+		maxIterations = 500
+		epsilon       = 1e-14
+		tiny          = 1e-300
+	)
+
+	if x <= 0 {
+		return 1
+	}
+	lgamma, _ := math.Lgamma(a)
+	prefix := math.Exp(-x + a*math.Log(x) - lgamma)
+
+	if x < a+1 {
+		// Series for the lower function P(a, x)
+		sum := 1 / a
+		term := sum
+		for n := 1; n < maxIterations; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*epsilon {
+				break
+			}
+		}
+		return math.Max(0, 1-sum*prefix)
+	}
+
+	// Lentz's continued fraction for Q(a, x)
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for n := 1; n < maxIterations; n++ {
+		an := -float64(n) * (float64(n) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+	return prefix * h
+}
@@ -0,0 +1,115 @@
+package selectables
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ValidateTestSuite struct {
+	suite.Suite
+	table *BasicTable[string]
+}
+
+func (s *ValidateTestSuite) SetupTest() {
+	s.table = NewBasicTable[string](BasicTableConfig{ID: "loot"}).(*BasicTable[string])
+	s.table.Add("common", 70).Add("uncommon", 25).Add("rare", 5)
+}
+
+func TestValidateTestSuite(t *testing.T) {
+	suite.Run(t, new(ValidateTestSuite))
+}
+
+func (s *ValidateTestSuite) TestFairTablePasses() {
+	report, err := Validate(s.table, 20000, 0.03)
+	s.Require().NoError(err)
+
+	s.True(report.Passed(), report.String())
+	s.Equal("loot", report.TableID)
+	s.Equal(2, report.DegreesOfFreedom)
+	s.Require().Len(report.Items, 3)
+
+	total := 0
+	for _, item := range report.Items {
+		total += item.Count
+	}
+	s.Equal(20000, total)
+
+	common := report.Items[0]
+	s.Equal("common", common.Item)
+	s.InDelta(0.70, common.Expected, 1e-9)
+}
+
+func (s *ValidateTestSuite) TestBiasedDrawsFail() {
+	// A roller stuck on 1 always selects the same item
+	ctx := NewSelectionContextWithRoller(NewTestRoller([]int{1}))
+	report, err := ValidateWithContext(ctx, s.table, 1000, 0.05)
+	s.Require().NoError(err)
+
+	s.False(report.Passed())
+	s.NotEmpty(report.Failures())
+	s.Less(report.PValue, 1e-6)
+	s.Contains(report.String(), "FAIL")
+}
+
+func (s *ValidateTestSuite) TestConditionsAndPity() {
+	s.Require().NoError(s.table.SetConditions("rare", map[string]interface{}{"level": 5}))
+	s.Require().NoError(s.table.SetPity("uncommon", PityPolicy{Increment: 10}))
+
+	ctx := NewContextBuilder().SetInt("level", 1).Build()
+	report, err := ValidateWithContext(ctx, s.table, 100, 1)
+	s.Require().NoError(err)
+
+	s.Require().Len(report.Items, 2, "rare is not eligible at level 1")
+	s.InDelta(70.0/95, report.Items[0].Expected, 1e-9)
+	s.Equal(0, s.table.PityCount("uncommon"), "validation draws from a copy")
+}
+
+// plainTable exposes only SelectionTable, like a table type with no optional features
+type plainTable struct {
+	SelectionTable[string]
+}
+
+func (s *ValidateTestSuite) TestTableWithoutOptionalFeatures() {
+	table := plainTable{s.table}
+	_, ok := SelectionTable[string](table).(SerializableTable[string])
+	s.Require().False(ok)
+
+	report, err := Validate[string](table, 20000, 0.03)
+	s.Require().NoError(err)
+
+	s.True(report.Passed(), report.String())
+	s.Empty(report.TableID, "only a SerializableTable reports its ID")
+	s.Require().Len(report.Items, 3)
+}
+
+func (s *ValidateTestSuite) TestInvalidInput() {
+	_, err := Validate(s.table, 0, 0.01)
+	s.ErrorIs(err, ErrInvalidCount)
+
+	_, err = Validate(s.table, 10, -1)
+	s.ErrorIs(err, ErrInvalidConfiguration)
+
+	_, err = Validate(NewBasicTable[string](BasicTableConfig{}), 10, 0.01)
+	s.ErrorIs(err, ErrEmptyTable)
+}
+
+func (s *ValidateTestSuite) TestChiSquaredPValue() {
+	testCases := []struct {
+		statistic        float64
+		degreesOfFreedom int
+		want             float64
+	}{
+		{statistic: 0, degreesOfFreedom: 3, want: 1},
+		{statistic: 3.841, degreesOfFreedom: 1, want: 0.05},
+		{statistic: 5.991, degreesOfFreedom: 2, want: 0.05},
+		{statistic: 6.635, degreesOfFreedom: 1, want: 0.01},
+		{statistic: 18.307, degreesOfFreedom: 10, want: 0.05},
+		{statistic: 2.558, degreesOfFreedom: 10, want: 0.99},
+	}
+
+	for _, tc := range testCases {
+		s.InDelta(tc.want, chiSquaredPValue(tc.statistic, tc.degreesOfFreedom), 1e-3,
+			"chi-squared %.3f with df %d", tc.statistic, tc.degreesOfFreedom)
+	}
+}
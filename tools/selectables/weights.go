@@ -0,0 +1,42 @@
+package selectables
+
+// WeightFunc computes an item's weight from the selection context
+// Purpose: Lets weights scale with game state, such as favoring a healing action
+// as HP drops or a ranged attack as the target gets farther away, which a fixed
+// weight or a boolean condition can't express.
+// A result less than 1 makes the item ineligible for that selection; larger
+// results are clamped to the table's maximum weight.
+type WeightFunc func(ctx SelectionContext) int
+
+// AddDynamic includes an item whose weight is computed from the selection context
+// on every selection. The item is stored with the table's minimum weight, which
+// GetItems reports and ToData writes, since functions can't be serialized.
+// Calling Add or SetWeight for the item replaces the function with a fixed weight.
+func (t *BasicTable[T]) AddDynamic(item T, weight WeightFunc) SelectionTable[T] {
+	if weight == nil {
+		return t.Add(item, t.config.MinWeight)
+	}
+
+	t.Add(item, t.config.MinWeight)
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.weightFuncs[item] = weight
+	t.markModified()
+	return t
+}
+
+// EffectiveWeights returns the weight each eligible item has in the context, after
+// conditions and weight functions are applied. Pity adjustments are not included.
+func (t *BasicTable[T]) EffectiveWeights(ctx SelectionContext) (map[T]int, error) {
+	weights, err := t.getEffectiveWeights(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[T]int, len(weights))
+	for item, weight := range weights {
+		result[item] = weight
+	}
+	return result, nil
+}
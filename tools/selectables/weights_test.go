@@ -0,0 +1,107 @@
+package selectables
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type WeightFuncTestSuite struct {
+	suite.Suite
+	table *BasicTable[string]
+}
+
+func (s *WeightFuncTestSuite) SetupTest() {
+	s.table = NewBasicTable[string](BasicTableConfig{
+		ID:            "goblin_actions",
+		Configuration: TableConfiguration{CacheWeights: true, MaxWeight: 500},
+	}).(*BasicTable[string])
+	s.table.Add("attack", 50)
+	s.table.AddDynamic("flee", func(ctx SelectionContext) int {
+		// Fleeing grows more likely as HP drops, and is never chosen at full health
+		hp := GetIntValue(ctx, "hp_percent", 100)
+		return 100 - hp
+	})
+}
+
+func TestWeightFuncTestSuite(t *testing.T) {
+	suite.Run(t, new(WeightFuncTestSuite))
+}
+
+func (s *WeightFuncTestSuite) TestWeightScalesWithContext() {
+	testCases := []struct {
+		name      string
+		hpPercent int
+		want      map[string]int
+	}{
+		{name: "full health", hpPercent: 100, want: map[string]int{"attack": 50}},
+		{name: "wounded", hpPercent: 80, want: map[string]int{"attack": 50, "flee": 20}},
+		{name: "nearly dead", hpPercent: 10, want: map[string]int{"attack": 50, "flee": 90}},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			ctx := NewContextBuilder().SetInt("hp_percent", tc.hpPercent).Build()
+			weights, err := s.table.EffectiveWeights(ctx)
+			s.Require().NoError(err)
+			s.Equal(tc.want, weights)
+		})
+	}
+}
+
+func (s *WeightFuncTestSuite) TestIneligibleWhenNotPositive() {
+	ctx := NewContextBuilderWithRoller(NewTestRoller([]int{1})).SetInt("hp_percent", 100).Build()
+	_, err := s.table.SelectUnique(ctx, 2)
+	s.ErrorIs(err, ErrInsufficientItems)
+}
+
+func (s *WeightFuncTestSuite) TestResultsAreNotCached() {
+	calls := 0
+	s.table.AddDynamic("taunt", func(SelectionContext) int {
+		calls++
+		return 1000
+	})
+
+	ctx := NewContextBuilder().SetInt("hp_percent", 50).Build()
+	for i := 0; i < 3; i++ {
+		weights, err := s.table.EffectiveWeights(ctx)
+		s.Require().NoError(err)
+		s.Equal(500, weights["taunt"], "clamped to the maximum weight")
+	}
+	s.Equal(3, calls)
+}
+
+func (s *WeightFuncTestSuite) TestFixedWeightReplacesFunction() {
+	s.Require().NoError(s.table.SetWeight("flee", 5))
+	weights, err := s.table.EffectiveWeights(NewContextBuilder().SetInt("hp_percent", 10).Build())
+	s.Require().NoError(err)
+	s.Equal(map[string]int{"attack": 50, "flee": 5}, weights)
+
+	s.table.AddDynamic("attack", func(SelectionContext) int { return 7 })
+	s.table.Add("attack", 3)
+	weights, err = s.table.EffectiveWeights(NewBasicSelectionContext())
+	s.Require().NoError(err)
+	s.Equal(3, weights["attack"])
+}
+
+func (s *WeightFuncTestSuite) TestStoredWithMinimumWeight() {
+	s.Equal(map[string]int{"attack": 50, "flee": 1}, s.table.GetItems())
+	s.True(s.table.Remove("flee"))
+
+	s.table.Add("flee", 4)
+	weights, err := s.table.EffectiveWeights(NewContextBuilder().SetInt("hp_percent", 100).Build())
+	s.Require().NoError(err)
+	s.Equal(4, weights["flee"], "removing the item dropped its function")
+}
+
+func (s *WeightFuncTestSuite) TestValidateUsesComputedWeights() {
+	ctx := NewContextBuilder().SetInt("hp_percent", 50).Build()
+	report, err := ValidateWithContext(ctx, s.table, 20000, 0.03)
+	s.Require().NoError(err)
+
+	s.True(report.Passed(), report.String())
+	s.Require().Len(report.Items, 2)
+	s.Equal("flee", report.Items[1].Item)
+	s.Equal(50, report.Items[1].Weight)
+	s.InDelta(0.5, report.Items[1].Expected, 1e-9)
+}
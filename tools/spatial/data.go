@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/core/schema"
 	"github.com/KirkDiggler/rpg-toolkit/game"
 )
 
@@ -18,6 +19,17 @@ const (
 	GridTypeGridless = "gridless"
 )
 
+// RoomDataKind identifies RoomData in a schema.Envelope
+const RoomDataKind = "spatial.room"
+
+// RoomDataVersion is the current version of RoomData. Bump it and register a
+// schema migration from the previous version when the shape changes.
+const RoomDataVersion = 1
+
+func init() {
+	schema.MustRegister(RoomDataKind, RoomDataVersion)
+}
+
 // RoomData contains all information needed to persist and reconstruct a room.
 // This follows the established data pattern for serialization and loading.
 type RoomData struct {
@@ -50,6 +62,10 @@ type RoomData struct {
 	// Used for hex grids where cube coordinates are the native format.
 	// Map of entity ID to their position and data.
 	CubeEntities map[string]EntityCubePlacement `json:"cube_entities,omitempty"`
+
+	// Explored maps observer IDs to the cells each has explored, in offset
+	// coordinates for every grid type. See ExplorationData.
+	Explored map[string][]Position `json:"explored,omitempty"`
 }
 
 // EntityPlacement represents an entity's position and spatial properties in a room.
@@ -67,6 +83,9 @@ type EntityPlacement struct {
 	// Size is how many grid spaces the entity occupies (default 1)
 	Size int `json:"size,omitempty"`
 
+	// Radius is the entity's collision radius in gridless rooms (0 is a point)
+	Radius float64 `json:"radius,omitempty"`
+
 	// BlocksMovement indicates if this entity blocks movement through its space
 	BlocksMovement bool `json:"blocks_movement"`
 
@@ -102,6 +121,7 @@ type PlaceableData struct {
 	id                string
 	entityType        string
 	size              int
+	radius            float64
 	blocksMovement    bool
 	blocksLineOfSight bool
 }
@@ -124,6 +144,11 @@ func (p *PlaceableData) GetSize() int {
 	return p.size
 }
 
+// GetRadius returns the entity's collision radius
+func (p *PlaceableData) GetRadius() float64 {
+	return p.radius
+}
+
 // BlocksMovement returns true if the entity blocks movement
 func (p *PlaceableData) BlocksMovement() bool {
 	return p.blocksMovement
@@ -211,12 +236,26 @@ func (r *BasicRoom) ToData() RoomData {
 					placement.BlocksMovement = placeable.BlocksMovement()
 					placement.BlocksLineOfSight = placeable.BlocksLineOfSight()
 				}
+				placement.Radius = radiusOf(entity)
 
 				entities[id] = placement
 			}
 		}
 	}
 
+	var explored map[string][]Position
+	if len(r.explored) > 0 {
+		explored = make(map[string][]Position, len(r.explored))
+		for observerID, cells := range r.explored {
+			positions := make([]Position, 0, len(cells))
+			for cell := range cells {
+				positions = append(positions, cell)
+			}
+			sortCells(positions)
+			explored[observerID] = positions
+		}
+	}
+
 	return RoomData{
 		ID:           r.id,
 		Type:         r.roomType,
@@ -226,6 +265,7 @@ func (r *BasicRoom) ToData() RoomData {
 		HexFlatTop:   hexFlatTop,
 		Entities:     entities,
 		CubeEntities: cubeEntities,
+		Explored:     explored,
 	}
 }
 
@@ -316,6 +356,7 @@ func LoadRoomFromContext(_ context.Context, gameCtx game.Context[RoomData]) (*Ba
 				id:                placement.EntityID,
 				entityType:        placement.EntityType,
 				size:              placement.Size,
+				radius:            placement.Radius,
 				blocksMovement:    placement.BlocksMovement,
 				blocksLineOfSight: placement.BlocksLineOfSight,
 			}
@@ -329,5 +370,11 @@ func LoadRoomFromContext(_ context.Context, gameCtx game.Context[RoomData]) (*Ba
 		}
 	}
 
+	for observerID, cells := range data.Explored {
+		if err := room.LoadExploration(ExplorationData{ObserverID: observerID, Cells: cells}); err != nil {
+			return nil, err
+		}
+	}
+
 	return room, nil
 }
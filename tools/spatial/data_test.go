@@ -387,6 +387,62 @@ func (s *RoomDataTestSuite) TestLoadRoomFromContextGridless() {
 	s.Equal(GridShapeGridless, grid.GetShape())
 }
 
+func (s *RoomDataTestSuite) TestGridlessRoundTripKeepsRadiusAndFractions() {
+	roomData := RoomData{
+		ID:       "clearing",
+		Type:     "outdoor",
+		Width:    60,
+		Height:   60,
+		GridType: GridTypeGridless,
+		Entities: map[string]EntityPlacement{
+			"ogre": {
+				EntityID:       "ogre",
+				EntityType:     "monster",
+				Position:       Position{X: 12.5, Y: 40.25},
+				Radius:         5,
+				BlocksMovement: true,
+			},
+		},
+	}
+
+	gameCtx, err := game.NewContext(s.eventBus, roomData)
+	s.Require().NoError(err)
+	room, err := LoadRoomFromContext(context.Background(), gameCtx)
+	s.Require().NoError(err)
+
+	// The loaded radius still blocks placement nearby
+	s.False(room.CanPlaceEntity(&PlaceableData{id: "goblin"}, Position{X: 15, Y: 40}))
+
+	data := room.ToData()
+	s.Equal(roomData.Entities["ogre"].Position, data.Entities["ogre"].Position)
+	s.Equal(5.0, data.Entities["ogre"].Radius)
+}
+
+func (s *RoomDataTestSuite) TestRoundTripKeepsExploration() {
+	room := NewBasicRoom(BasicRoomConfig{
+		ID:   "crypt",
+		Type: "dungeon",
+		Grid: NewSquareGrid(SquareGridConfig{Width: 5, Height: 5}),
+	})
+	room.MarkExplored("alice", Position{X: 1, Y: 1}, Position{X: 0, Y: 0})
+	room.MarkExplored("bob", Position{X: 4, Y: 4})
+
+	data := room.ToData()
+	s.Equal(map[string][]Position{
+		"alice": {{X: 0, Y: 0}, {X: 1, Y: 1}},
+		"bob":   {{X: 4, Y: 4}},
+	}, data.Explored)
+
+	gameCtx, err := game.NewContext(s.eventBus, data)
+	s.Require().NoError(err)
+	loaded, err := LoadRoomFromContext(context.Background(), gameCtx)
+	s.Require().NoError(err)
+
+	s.Equal([]string{"alice", "bob"}, loaded.GetObservers())
+	s.True(loaded.IsExplored("alice", Position{X: 1, Y: 1}))
+	s.False(loaded.IsExplored("bob", Position{X: 1, Y: 1}))
+}
+
 func (s *RoomDataTestSuite) TestLoadRoomFromContextInvalidGridType() {
 	// Create room data with invalid grid type
 	roomData := RoomData{
@@ -20,7 +20,7 @@
 // Non-Goals:
 //   - Movement rules: Speed, difficult terrain are game-specific
 //   - Line of sight rules: Cover/concealment mechanics belong in games
-//   - Pathfinding algorithms: AI navigation belongs in behavior package
+//   - Pathfinding algorithms: see the pathfind subpackage
 //   - Combat ranges: Weapon/spell ranges are game-specific
 //   - 3D positioning: This is explicitly 2D only
 //   - Movement costs: Action economy is game-specific
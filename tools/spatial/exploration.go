@@ -0,0 +1,243 @@
+package spatial
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// ExplorationData records the cells one observer has explored in a room.
+// Cells are in the room's offset coordinates for every grid type. In gridless
+// rooms a cell is the 1x1 unit square whose corner is the cell position.
+type ExplorationData struct {
+	// RoomID is the room the cells belong to
+	RoomID string `json:"room_id"`
+
+	// ObserverID identifies who explored the cells, such as a player or a party
+	ObserverID string `json:"observer_id"`
+
+	// Cells are the explored cells, ordered by row then column
+	Cells []Position `json:"cells"`
+}
+
+// RevealFrom marks every cell within radius of origin that the observer can see
+// as explored. A cell is seen if the line of sight to it is not blocked, so the
+// cell holding a wall is revealed but the cells behind it are not. In gridless
+// rooms each unit cell is checked at its center.
+// Returns the cells explored for the first time, ordered by row then column.
+func (r *BasicRoom) RevealFrom(observerID string, origin Position, radius float64) ([]Position, error) {
+	if observerID == "" {
+		return nil, fmt.Errorf("observer ID cannot be empty")
+	}
+	if radius < 0 {
+		return nil, fmt.Errorf("reveal radius cannot be negative: %g", radius)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if !r.grid.IsValidPosition(origin) {
+		return nil, fmt.Errorf("position %v is not valid for this room", origin)
+	}
+
+	dims := r.grid.GetDimensions()
+	minX := math.Max(0, math.Floor(origin.X-radius))
+	maxX := math.Min(math.Ceil(dims.Width)-1, math.Floor(origin.X+radius))
+	minY := math.Max(0, math.Floor(origin.Y-radius))
+	maxY := math.Min(math.Ceil(dims.Height)-1, math.Floor(origin.Y+radius))
+
+	// Grid distances never exceed the row or column difference, so the bounding
+	// box around the radius holds every cell in range
+	var seen []Position
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			cell := Position{X: x, Y: y}
+			target := r.cellTargetUnsafe(cell)
+			if !r.grid.IsValidPosition(target) || r.grid.Distance(origin, target) > radius {
+				continue
+			}
+			if r.isLineOfSightBlockedUnsafe(origin, target) {
+				continue
+			}
+			seen = append(seen, cell)
+		}
+	}
+
+	return r.markExploredUnsafe(observerID, seen), nil
+}
+
+// MarkExplored marks cells as explored by the observer without a line of sight
+// check, for reveals such as reading a map or casting a divination.
+// Positions are converted to the cell containing them; invalid positions are ignored.
+// Returns the cells explored for the first time, ordered by row then column.
+func (r *BasicRoom) MarkExplored(observerID string, positions ...Position) []Position {
+	if observerID == "" {
+		return nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	cells := make([]Position, 0, len(positions))
+	for _, pos := range positions {
+		if r.grid.IsValidPosition(pos) {
+			cells = append(cells, cellOf(pos))
+		}
+	}
+	return r.markExploredUnsafe(observerID, cells)
+}
+
+// IsExplored returns true if the observer has explored the cell containing pos
+func (r *BasicRoom) IsExplored(observerID string, pos Position) bool {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.explored[observerID][cellOf(pos)]
+}
+
+// GetExploredCells returns the cells the observer has explored, ordered by row then column
+func (r *BasicRoom) GetExploredCells(observerID string) []Position {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	cells := make([]Position, 0, len(r.explored[observerID]))
+	for cell := range r.explored[observerID] {
+		cells = append(cells, cell)
+	}
+	sortCells(cells)
+	return cells
+}
+
+// GetUnexploredCells returns the cells of the room the observer has not explored,
+// ordered by row then column
+func (r *BasicRoom) GetUnexploredCells(observerID string) []Position {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	explored := r.explored[observerID]
+	dims := r.grid.GetDimensions()
+	var cells []Position
+	for y := 0.0; y < dims.Height; y++ {
+		for x := 0.0; x < dims.Width; x++ {
+			cell := Position{X: x, Y: y}
+			if !explored[cell] {
+				cells = append(cells, cell)
+			}
+		}
+	}
+	return cells
+}
+
+// GetObservers returns the IDs of observers that have explored part of the room, sorted
+func (r *BasicRoom) GetObservers() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	observers := make([]string, 0, len(r.explored))
+	for observerID := range r.explored {
+		observers = append(observers, observerID)
+	}
+	sort.Strings(observers)
+	return observers
+}
+
+// ResetExploration forgets every cell the observer has explored
+func (r *BasicRoom) ResetExploration(observerID string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delete(r.explored, observerID)
+}
+
+// ExplorationToData returns the observer's explored cells for persistence or
+// for sending to that observer's client alone
+func (r *BasicRoom) ExplorationToData(observerID string) ExplorationData {
+	return ExplorationData{
+		RoomID:     r.id,
+		ObserverID: observerID,
+		Cells:      r.GetExploredCells(observerID),
+	}
+}
+
+// LoadExploration adds the explored cells in data to the room.
+// Cells already explored by the observer are kept, and no event is published.
+// Returns an error if data belongs to another room or has no observer
+func (r *BasicRoom) LoadExploration(data ExplorationData) error {
+	if data.RoomID != "" && data.RoomID != r.id {
+		return fmt.Errorf("exploration data for room %s cannot be loaded into room %s", data.RoomID, r.id)
+	}
+	if data.ObserverID == "" {
+		return fmt.Errorf("observer ID cannot be empty")
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	explored := r.exploredByUnsafe(data.ObserverID)
+	for _, pos := range data.Cells {
+		if r.grid.IsValidPosition(pos) {
+			explored[cellOf(pos)] = true
+		}
+	}
+	return nil
+}
+
+// markExploredUnsafe records cells for the observer and publishes the ones that are new
+func (r *BasicRoom) markExploredUnsafe(observerID string, cells []Position) []Position {
+	explored := r.exploredByUnsafe(observerID)
+
+	var revealed []Position
+	for _, cell := range cells {
+		if explored[cell] {
+			continue
+		}
+		explored[cell] = true
+		revealed = append(revealed, cell)
+	}
+	if len(revealed) == 0 {
+		return nil
+	}
+	sortCells(revealed)
+
+	if r.areaExplored != nil {
+		_ = r.areaExplored.Publish(context.Background(), AreaExploredEvent{
+			RoomID:     r.id,
+			ObserverID: observerID,
+			Cells:      revealed,
+		})
+	}
+
+	return revealed
+}
+
+// exploredByUnsafe returns the observer's explored cells, creating the set if needed
+func (r *BasicRoom) exploredByUnsafe(observerID string) map[Position]bool {
+	explored, exists := r.explored[observerID]
+	if !exists {
+		explored = make(map[Position]bool)
+		r.explored[observerID] = explored
+	}
+	return explored
+}
+
+// cellTargetUnsafe returns the point sight lines to a cell are drawn to
+func (r *BasicRoom) cellTargetUnsafe(cell Position) Position {
+	if r.isGridless() {
+		return Position{X: cell.X + 0.5, Y: cell.Y + 0.5}
+	}
+	return cell
+}
+
+// cellOf returns the cell containing pos
+func cellOf(pos Position) Position {
+	return Position{X: math.Floor(pos.X), Y: math.Floor(pos.Y)}
+}
+
+// sortCells orders cells by row then column
+func sortCells(cells []Position) {
+	sort.Slice(cells, func(i, j int) bool {
+		if cells[i].Y != cells[j].Y {
+			return cells[i].Y < cells[j].Y
+		}
+		return cells[i].X < cells[j].X
+	})
+}
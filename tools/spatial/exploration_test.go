@@ -0,0 +1,126 @@
+package spatial_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
+)
+
+// ExplorationTestSuite tests per-observer explored cell tracking
+type ExplorationTestSuite struct {
+	suite.Suite
+	room *spatial.BasicRoom
+}
+
+func (s *ExplorationTestSuite) SetupTest() {
+	s.room = spatial.NewBasicRoom(spatial.BasicRoomConfig{
+		ID:   "corridor",
+		Type: "dungeon",
+		Grid: spatial.NewSquareGrid(spatial.SquareGridConfig{Width: 10, Height: 3}),
+	})
+
+	// A wall across the corridor at x=5
+	wall := NewMockEntity("wall", "wall").WithBlocking(true, true)
+	s.Require().NoError(s.room.PlaceEntity(wall, spatial.Position{X: 5, Y: 1}))
+}
+
+func TestExplorationSuite(t *testing.T) {
+	suite.Run(t, new(ExplorationTestSuite))
+}
+
+func (s *ExplorationTestSuite) TestRevealStopsAtBlockers() {
+	revealed, err := s.room.RevealFrom("party", spatial.Position{X: 2, Y: 1}, 10)
+	s.Require().NoError(err)
+	s.NotEmpty(revealed)
+
+	s.True(s.room.IsExplored("party", spatial.Position{X: 0, Y: 0}))
+	s.True(s.room.IsExplored("party", spatial.Position{X: 5, Y: 1}), "the wall itself is seen")
+	s.False(s.room.IsExplored("party", spatial.Position{X: 8, Y: 1}), "cells behind the wall are not")
+	s.False(s.room.IsExplored("rogue", spatial.Position{X: 0, Y: 0}), "exploration is per observer")
+
+	// Revealing again only reports new cells
+	again, err := s.room.RevealFrom("party", spatial.Position{X: 2, Y: 1}, 10)
+	s.Require().NoError(err)
+	s.Empty(again)
+}
+
+func (s *ExplorationTestSuite) TestRevealRespectsRadius() {
+	revealed, err := s.room.RevealFrom("party", spatial.Position{X: 0, Y: 0}, 1)
+	s.Require().NoError(err)
+	s.Equal([]spatial.Position{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}}, revealed)
+	s.Equal(revealed, s.room.GetExploredCells("party"))
+	s.Len(s.room.GetUnexploredCells("party"), 26)
+
+	_, err = s.room.RevealFrom("party", spatial.Position{X: 20, Y: 0}, 1)
+	s.Error(err)
+	_, err = s.room.RevealFrom("", spatial.Position{X: 0, Y: 0}, 1)
+	s.Error(err)
+}
+
+func (s *ExplorationTestSuite) TestMarkExploredAndReset() {
+	revealed := s.room.MarkExplored("rogue", spatial.Position{X: 9.4, Y: 2.7}, spatial.Position{X: 40, Y: 0})
+	s.Equal([]spatial.Position{{X: 9, Y: 2}}, revealed, "positions map to their cell and invalid ones are ignored")
+	s.Equal([]string{"rogue"}, s.room.GetObservers())
+
+	s.room.ResetExploration("rogue")
+	s.Empty(s.room.GetExploredCells("rogue"))
+	s.Empty(s.room.GetObservers())
+}
+
+func (s *ExplorationTestSuite) TestExplorationDataRoundTrip() {
+	_, err := s.room.RevealFrom("party", spatial.Position{X: 0, Y: 0}, 1)
+	s.Require().NoError(err)
+	data := s.room.ExplorationToData("party")
+	s.Equal("corridor", data.RoomID)
+
+	other := spatial.NewBasicRoom(spatial.BasicRoomConfig{
+		ID:   "corridor",
+		Grid: spatial.NewSquareGrid(spatial.SquareGridConfig{Width: 10, Height: 3}),
+	})
+	s.Require().NoError(other.LoadExploration(data))
+	s.Equal(data.Cells, other.GetExploredCells("party"))
+
+	data.RoomID = "vault"
+	s.Error(other.LoadExploration(data))
+}
+
+func (s *ExplorationTestSuite) TestGridlessRevealUsesCellCenters() {
+	room := spatial.NewBasicRoom(spatial.BasicRoomConfig{
+		ID:   "meadow",
+		Grid: spatial.NewGridlessRoom(spatial.GridlessConfig{Width: 20, Height: 20}),
+	})
+	s.Require().NoError(room.PlaceEntity(NewCollidingEntity("boulder", 1.5), spatial.Position{X: 10, Y: 10}))
+
+	_, err := room.RevealFrom("scout", spatial.Position{X: 5.5, Y: 10}, 8)
+	s.Require().NoError(err)
+
+	s.True(room.IsExplored("scout", spatial.Position{X: 5.9, Y: 10.2}))
+	s.True(room.IsExplored("scout", spatial.Position{X: 9.5, Y: 9.5}), "cells under the boulder are seen")
+	s.False(room.IsExplored("scout", spatial.Position{X: 12.5, Y: 10}), "the boulder hides what is behind it")
+	s.False(room.IsExplored("scout", spatial.Position{X: 5.5, Y: 19}), "out of range")
+}
+
+func (s *ExplorationTestSuite) TestRevealPublishesNewCells() {
+	bus := events.NewEventBus()
+	s.room.ConnectToEventBus(bus)
+
+	var explored []spatial.AreaExploredEvent
+	_, err := spatial.AreaExploredTopic.On(bus).Subscribe(context.Background(),
+		func(_ context.Context, event spatial.AreaExploredEvent) error {
+			explored = append(explored, event)
+			return nil
+		})
+	s.Require().NoError(err)
+
+	s.room.MarkExplored("party", spatial.Position{X: 3, Y: 0})
+	s.room.MarkExplored("party", spatial.Position{X: 3, Y: 0})
+
+	s.Require().Len(explored, 1)
+	s.Equal("corridor", explored[0].RoomID)
+	s.Equal("party", explored[0].ObserverID)
+	s.Equal([]spatial.Position{{X: 3, Y: 0}}, explored[0].Cells)
+}
@@ -326,3 +326,36 @@ func (gr *GridlessRoom) GetNearestPosition(pos Position) Position {
 
 	return Position{X: x, Y: y}
 }
+
+// DistanceToSegment returns the shortest distance from point to the line segment from a to b
+func DistanceToSegment(a, b, point Position) float64 {
+	dx := b.X - a.X
+	dy := b.Y - a.Y
+	lengthSquared := dx*dx + dy*dy
+	if lengthSquared == 0 {
+		return math.Hypot(point.X-a.X, point.Y-a.Y)
+	}
+
+	// Project point onto the segment, clamped to its ends
+	t := ((point.X-a.X)*dx + (point.Y-a.Y)*dy) / lengthSquared
+	t = math.Max(0, math.Min(1, t))
+	return math.Hypot(point.X-(a.X+t*dx), point.Y-(a.Y+t*dy))
+}
+
+// SegmentIntersectsCircle checks if the line segment from a to b passes through
+// the circle. Touching the circle's edge does not count; a zero-radius circle
+// is a point that intersects only segments passing exactly through it.
+func SegmentIntersectsCircle(a, b Position, circle Circle) bool {
+	distance := DistanceToSegment(a, b, circle.Center)
+	if circle.Radius <= 0 {
+		return distance == 0
+	}
+	return distance < circle.Radius
+}
+
+// CirclesOverlap checks if two circles share any area. Circles that only touch
+// do not overlap; zero-radius circles overlap only at the same position.
+func CirclesOverlap(a, b Circle) bool {
+	distance := math.Hypot(b.Center.X-a.Center.X, b.Center.Y-a.Center.Y)
+	return distance == 0 || distance < a.Radius+b.Radius
+}
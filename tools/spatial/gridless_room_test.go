@@ -0,0 +1,166 @@
+package spatial_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/events"
+	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
+)
+
+// CollidingEntity is a MockEntity with a collision radius
+type CollidingEntity struct {
+	*MockEntity
+	radius float64
+}
+
+func (c *CollidingEntity) GetRadius() float64 { return c.radius }
+
+// NewCollidingEntity creates a colliding entity that blocks movement and line of sight
+func NewCollidingEntity(id string, radius float64) *CollidingEntity {
+	return &CollidingEntity{
+		MockEntity: NewMockEntity(id, "creature").WithBlocking(true, true),
+		radius:     radius,
+	}
+}
+
+// GridlessRoomTestSuite tests BasicRoom in continuous gridless space
+type GridlessRoomTestSuite struct {
+	suite.Suite
+	room *spatial.BasicRoom
+	ogre *CollidingEntity
+}
+
+func (s *GridlessRoomTestSuite) SetupTest() {
+	s.room = spatial.NewBasicRoom(spatial.BasicRoomConfig{
+		ID:   "meadow",
+		Type: "outdoor",
+		Grid: spatial.NewGridlessRoom(spatial.GridlessConfig{Width: 100, Height: 100}),
+	})
+	s.ogre = NewCollidingEntity("ogre", 5)
+	s.Require().NoError(s.room.PlaceEntity(s.ogre, spatial.Position{X: 50, Y: 50}))
+}
+
+func TestGridlessRoomSuite_BasicRoom(t *testing.T) {
+	suite.Run(t, new(GridlessRoomTestSuite))
+}
+
+func (s *GridlessRoomTestSuite) TestCollisionRadiiPreventOverlap() {
+	testCases := []struct {
+		name     string
+		entity   core.Entity
+		position spatial.Position
+		expected bool
+	}{
+		{"overlapping radii", NewCollidingEntity("goblin", 2.5), spatial.Position{X: 57, Y: 50}, false},
+		{"touching radii", NewCollidingEntity("goblin", 2.5), spatial.Position{X: 57.5, Y: 50}, true},
+		{"point inside radius", NewMockEntity("rat", "creature").WithBlocking(true, false), spatial.Position{X: 53.2, Y: 51.7}, false},
+		{"point outside radius", NewMockEntity("rat", "creature"), spatial.Position{X: 55.1, Y: 50}, true},
+		{"fractional position", NewCollidingEntity("pixie", 0.25), spatial.Position{X: 10.125, Y: 99.75}, true},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.Equal(tc.expected, s.room.CanPlaceEntity(tc.entity, tc.position))
+		})
+	}
+
+	// Non-blocking entities may share space
+	s.ogre.blocksMovement = false
+	s.True(s.room.CanPlaceEntity(NewCollidingEntity("ghost", 3), spatial.Position{X: 50, Y: 50}))
+}
+
+func (s *GridlessRoomTestSuite) TestPointQueriesUseRadius() {
+	inside := spatial.Position{X: 53, Y: 54}
+	s.True(s.room.IsPositionOccupied(inside))
+	s.Require().Len(s.room.GetEntitiesAt(inside), 1)
+	s.Equal("ogre", s.room.GetEntitiesAt(inside)[0].GetID())
+
+	s.False(s.room.IsPositionOccupied(spatial.Position{X: 54, Y: 54}))
+	s.Empty(s.room.GetEntitiesAt(spatial.Position{X: 54, Y: 54}))
+}
+
+func (s *GridlessRoomTestSuite) TestRangeIsMeasuredToEdge() {
+	archer := spatial.Position{X: 20, Y: 50}
+
+	// The ogre's center is 30 away but its edge is 25 away
+	s.Len(s.room.GetEntitiesInRange(archer, 25), 1)
+	s.Empty(s.room.GetEntitiesInRange(archer, 24.9))
+}
+
+func (s *GridlessRoomTestSuite) TestLineOfSightSegmentIntersection() {
+	testCases := []struct {
+		name     string
+		from     spatial.Position
+		to       spatial.Position
+		expected bool
+	}{
+		{"through the ogre", spatial.Position{X: 10, Y: 52}, spatial.Position{X: 90, Y: 48}, true},
+		{"grazing the edge", spatial.Position{X: 10, Y: 55}, spatial.Position{X: 90, Y: 55}, false},
+		{"stopping short", spatial.Position{X: 10, Y: 50}, spatial.Position{X: 44, Y: 50}, false},
+		{"from inside the ogre", spatial.Position{X: 52, Y: 50}, spatial.Position{X: 90, Y: 50}, false},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.Equal(tc.expected, s.room.IsLineOfSightBlocked(tc.from, tc.to))
+		})
+	}
+}
+
+func (s *GridlessRoomTestSuite) TestValidatePath() {
+	knight := NewCollidingEntity("knight", 2.5)
+	start := spatial.Position{X: 10, Y: 50}
+	s.Require().NoError(s.room.PlaceEntity(knight, start))
+
+	// Straight through the ogre is blocked, even though the end is clear
+	err := s.room.ValidatePath(knight, start, []spatial.Position{{X: 90, Y: 50}})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "blocked by entity ogre")
+
+	// Passing close enough that the radii would overlap is blocked too
+	s.Error(s.room.ValidatePath(knight, start, []spatial.Position{{X: 90, Y: 57}}))
+
+	// Going around works
+	s.NoError(s.room.ValidatePath(knight, start, []spatial.Position{
+		{X: 50, Y: 60},
+		{X: 90, Y: 50},
+	}))
+
+	// Waypoints must be placeable
+	s.Error(s.room.ValidatePath(knight, start, []spatial.Position{{X: 50, Y: 120}}))
+}
+
+func (s *GridlessRoomTestSuite) TestMovementQueryChecksPath() {
+	handler := spatial.NewSpatialQueryHandler()
+	handler.RegisterRoom(s.room)
+	knight := NewCollidingEntity("knight", 2.5)
+
+	result, err := handler.HandleQuery(context.Background(), &spatial.QueryMovementData{
+		Entity: knight,
+		From:   spatial.Position{X: 10, Y: 50},
+		To:     spatial.Position{X: 90, Y: 50},
+		RoomID: "meadow",
+	})
+	s.Require().NoError(err)
+	s.False(result.(*spatial.QueryMovementData).Valid)
+}
+
+func (s *GridlessRoomTestSuite) TestEventsCarryFractionalPositions() {
+	bus := events.NewEventBus()
+	s.room.ConnectToEventBus(bus)
+
+	var moved spatial.EntityMovedEvent
+	_, err := spatial.EntityMovedTopic.On(bus).Subscribe(context.Background(),
+		func(_ context.Context, event spatial.EntityMovedEvent) error {
+			moved = event
+			return nil
+		})
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.room.MoveEntity("ogre", spatial.Position{X: 33.3, Y: 66.6}))
+	s.Equal(spatial.Position{X: 33.3, Y: 66.6}, moved.ToPosition)
+}
@@ -4,6 +4,7 @@ import (
 	"math"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
@@ -325,3 +326,36 @@ func (s *GridlessTestSuite) TestSmallGridlessRoom() {
 func TestGridlessRoomSuite(t *testing.T) {
 	suite.Run(t, new(GridlessTestSuite))
 }
+
+func TestGridlessGeometry(t *testing.T) {
+	a := spatial.Position{X: 0, Y: 0}
+	b := spatial.Position{X: 10, Y: 0}
+
+	testCases := []struct {
+		name       string
+		point      spatial.Position
+		distance   float64
+		intersects bool
+	}{
+		{"beside the segment", spatial.Position{X: 5, Y: 3}, 3, true},
+		{"past the end", spatial.Position{X: 13, Y: 4}, 5, false},
+		{"before the start", spatial.Position{X: -4, Y: 0}, 4, false},
+		{"on the segment", spatial.Position{X: 7.5, Y: 0}, 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.InDelta(t, tc.distance, spatial.DistanceToSegment(a, b, tc.point), 1e-9)
+			assert.Equal(t, tc.intersects, spatial.SegmentIntersectsCircle(a, b, spatial.Circle{Center: tc.point, Radius: 3.5}))
+		})
+	}
+
+	// Zero-radius circles are points
+	assert.True(t, spatial.SegmentIntersectsCircle(a, b, spatial.Circle{Center: spatial.Position{X: 4, Y: 0}}))
+	assert.False(t, spatial.SegmentIntersectsCircle(a, b, spatial.Circle{Center: spatial.Position{X: 4, Y: 0.1}}))
+
+	small := spatial.Circle{Center: a, Radius: 2}
+	assert.True(t, spatial.CirclesOverlap(small, spatial.Circle{Center: spatial.Position{X: 3}, Radius: 1.5}))
+	assert.False(t, spatial.CirclesOverlap(small, spatial.Circle{Center: spatial.Position{X: 3}, Radius: 1}))
+	assert.True(t, spatial.CirclesOverlap(spatial.Circle{Center: b}, spatial.Circle{Center: b}))
+}
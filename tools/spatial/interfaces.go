@@ -102,6 +102,13 @@ type Placeable interface {
 	BlocksLineOfSight() bool
 }
 
+// Collider is implemented by entities that occupy a circle of space in gridless rooms.
+// Entities without it are points. Grid rooms ignore the radius.
+type Collider interface {
+	// GetRadius returns the entity's collision radius in the room's units
+	GetRadius() float64
+}
+
 // QueryHandler defines the interface for spatial query processing
 type QueryHandler interface {
 	// ProcessQuery processes a spatial query and returns results
@@ -0,0 +1,40 @@
+// Package pathfind finds paths for entities through spatial rooms.
+//
+// Purpose:
+// One canonical pathfinder for AI movement and player movement previews, so
+// consumers stop writing their own. It searches square, hex and gridless rooms
+// and asks the room which positions an entity can occupy, so walls, blocking
+// creatures and any other blocking entities are honored.
+//
+// Scope:
+//   - A* over any grid, with pluggable step costs (difficult terrain, hazards)
+//   - Jump point search for uniform-cost square grids
+//   - Reachable positions within a cost budget, for movement previews
+//
+// Non-Goals:
+//   - Movement rules: speeds, difficult terrain and who may pass whom are game-specific
+//     and are supplied through CostFunc and the room's blocking entities
+//   - Multi-room routes: use the spatial orchestrator's FindPath between rooms
+//
+// Grid Rules:
+//   - Square grids step to 8 neighbors; a diagonal step can't cut the corner of a
+//     blocked position, so both positions beside it must be open
+//   - Hex grids step to the 6 neighbors the grid reports
+//   - Gridless rooms step along a unit lattice anchored at the start position, in
+//     8 directions, and step straight to the goal once it is within one step.
+//     Rooms that validate paths, like BasicRoom, have each step swept for blockers.
+//
+// Example:
+//
+//	finder, err := pathfind.NewFinder(pathfind.FinderConfig{
+//	    Algorithm: pathfind.AlgorithmAStar,
+//	    Cost: func(from, to spatial.Position) float64 {
+//	        if rubble[to] {
+//	            return 2 // difficult terrain
+//	        }
+//	        return 1
+//	    },
+//	})
+//	path, err := finder.FindPath(room, goblin, from, to)
+//	// path.Steps excludes from and includes to
+package pathfind
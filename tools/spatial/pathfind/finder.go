@@ -0,0 +1,433 @@
+package pathfind
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
+)
+
+// ErrNoPath is returned when no path reaches the goal
+var ErrNoPath = errors.New("no path found")
+
+// Algorithm selects how a Finder searches
+type Algorithm string
+
+const (
+	// AlgorithmAStar searches with A*, on any grid and with any step costs (the default)
+	AlgorithmAStar Algorithm = "a_star"
+
+	// AlgorithmJPS searches with jump point search, which skips along open runs of
+	// a square grid instead of expanding every position. It needs uniform step
+	// costs, so a Finder with a CostFunc or a room without a square grid uses A*.
+	AlgorithmJPS Algorithm = "jps"
+)
+
+// CostFunc returns the cost of one step between adjacent positions, such as 2 for
+// a step into difficult terrain. A negative or infinite cost forbids the step.
+// Costs no less than the grid distance of the step keep paths the cheapest.
+type CostFunc func(from, to spatial.Position) float64
+
+// FinderConfig holds configuration for creating a finder
+type FinderConfig struct {
+	// Algorithm is the search to use (default: AlgorithmAStar)
+	Algorithm Algorithm
+
+	// Cost prices each step (default: the grid distance of the step)
+	Cost CostFunc
+
+	// MaxCost limits the total cost of a path, such as a creature's speed.
+	// 0 means no limit.
+	MaxCost float64
+}
+
+// Path is a route through a room
+type Path struct {
+	// Steps are the positions moved through, excluding the start and including the goal
+	Steps []spatial.Position
+
+	// Cost is the total cost of the steps
+	Cost float64
+}
+
+// Finder finds paths for entities through rooms
+type Finder struct {
+	algorithm Algorithm
+	cost      CostFunc
+	maxCost   float64
+}
+
+// NewFinder creates a finder from config
+func NewFinder(config FinderConfig) (*Finder, error) {
+	algorithm := config.Algorithm
+	switch algorithm {
+	case "":
+		algorithm = AlgorithmAStar
+	case AlgorithmAStar, AlgorithmJPS:
+	default:
+		return nil, fmt.Errorf("unknown pathfinding algorithm: %s", algorithm)
+	}
+	if config.MaxCost < 0 {
+		return nil, fmt.Errorf("max cost cannot be negative: %g", config.MaxCost)
+	}
+
+	return &Finder{
+		algorithm: algorithm,
+		cost:      config.Cost,
+		maxCost:   config.MaxCost,
+	}, nil
+}
+
+// FindPath returns the cheapest path for the entity from one position to another.
+// A position is open if the room can place the entity there, so blocking
+// entities other than the mover itself are avoided. The entity need not be in the room.
+// Returns an empty path if from equals to.
+// Returns ErrNoPath if the goal is blocked or unreachable within the finder's MaxCost
+func (f *Finder) FindPath(room spatial.Room, entity core.Entity, from, to spatial.Position) (*Path, error) {
+	space, err := newSearchSpace(room, entity, from, f.cost)
+	if err != nil {
+		return nil, err
+	}
+	if !space.grid.IsValidPosition(to) {
+		return nil, fmt.Errorf("goal %v is not valid for room %s", to, room.GetID())
+	}
+	if from.Equals(to) {
+		return &Path{Steps: []spatial.Position{}}, nil
+	}
+	if !space.open(to) {
+		return nil, fmt.Errorf("%w: goal %v is blocked for entity %s", ErrNoPath, to, entity.GetID())
+	}
+	space.goal = &to
+
+	jps := f.algorithm == AlgorithmJPS && f.cost == nil && space.grid.GetShape() == spatial.GridShapeSquare
+	successors := space.neighbors
+	if jps {
+		successors = space.jumpPoints
+	}
+
+	result := space.search(successors, f.maxCost)
+	if _, found := result.cost[to]; !found {
+		return nil, fmt.Errorf("%w: from %v to %v for entity %s", ErrNoPath, from, to, entity.GetID())
+	}
+	return &Path{
+		Steps: result.stepsTo(to, jps),
+		Cost:  result.cost[to],
+	}, nil
+}
+
+// Reachable returns every position the entity can reach from a position for at
+// most budget, with the cost to reach it, for previewing a creature's movement.
+// The start position is included at cost 0.
+func (f *Finder) Reachable(
+	room spatial.Room, entity core.Entity, from spatial.Position, budget float64,
+) (map[spatial.Position]float64, error) {
+	if budget < 0 {
+		return nil, fmt.Errorf("budget cannot be negative: %g", budget)
+	}
+	space, err := newSearchSpace(room, entity, from, f.cost)
+	if err != nil {
+		return nil, err
+	}
+	return space.search(space.neighbors, budget).cost, nil
+}
+
+// pathValidator is implemented by rooms that can check a path for blockers
+type pathValidator interface {
+	ValidatePath(entity core.Entity, from spatial.Position, path []spatial.Position) error
+}
+
+// successor is a position a search can move to and the cost of moving there
+type successor struct {
+	pos  spatial.Position
+	cost float64
+}
+
+// searchSpace is the room as one search sees it
+type searchSpace struct {
+	room      spatial.Room
+	grid      spatial.Grid
+	entity    core.Entity
+	start     spatial.Position
+	goal      *spatial.Position
+	cost      CostFunc
+	validator pathValidator
+
+	// openCache remembers which positions the entity can occupy, so the room is
+	// asked once per position
+	openCache map[spatial.Position]bool
+}
+
+// newSearchSpace validates the search and prepares its view of the room
+func newSearchSpace(room spatial.Room, entity core.Entity, start spatial.Position, cost CostFunc) (*searchSpace, error) {
+	if room == nil {
+		return nil, fmt.Errorf("room cannot be nil")
+	}
+	if entity == nil {
+		return nil, fmt.Errorf("entity cannot be nil")
+	}
+	grid := room.GetGrid()
+	if !grid.IsValidPosition(start) {
+		return nil, fmt.Errorf("start %v is not valid for room %s", start, room.GetID())
+	}
+
+	space := &searchSpace{
+		room:      room,
+		grid:      grid,
+		entity:    entity,
+		start:     start,
+		cost:      cost,
+		openCache: make(map[spatial.Position]bool),
+	}
+	if grid.GetShape() == spatial.GridShapeGridless {
+		space.validator, _ = room.(pathValidator)
+	}
+	return space, nil
+}
+
+// open reports whether the entity can occupy the position
+func (s *searchSpace) open(pos spatial.Position) bool {
+	open, cached := s.openCache[pos]
+	if !cached {
+		open = s.grid.IsValidPosition(pos) && s.room.CanPlaceEntity(s.entity, pos)
+		s.openCache[pos] = open
+	}
+	return open
+}
+
+// step returns the cost of stepping between adjacent positions, or false if the
+// step is blocked
+func (s *searchSpace) step(from, to spatial.Position) (float64, bool) {
+	if !s.open(to) {
+		return 0, false
+	}
+
+	switch s.grid.GetShape() {
+	case spatial.GridShapeSquare:
+		// A diagonal step can't cut the corner of a blocked position
+		if from.X != to.X && from.Y != to.Y &&
+			(!s.open(spatial.Position{X: to.X, Y: from.Y}) || !s.open(spatial.Position{X: from.X, Y: to.Y})) {
+			return 0, false
+		}
+	case spatial.GridShapeGridless:
+		if s.validator != nil && s.validator.ValidatePath(s.entity, from, []spatial.Position{to}) != nil {
+			return 0, false
+		}
+	}
+
+	if s.cost == nil {
+		return s.grid.Distance(from, to), true
+	}
+	cost := s.cost(from, to)
+	if cost < 0 || math.IsInf(cost, 1) || math.IsNaN(cost) {
+		return 0, false
+	}
+	return cost, true
+}
+
+// squareDirections are the 8 steps on a square grid
+var squareDirections = []spatial.Position{
+	{X: -1, Y: -1}, {X: 0, Y: -1}, {X: 1, Y: -1},
+	{X: -1, Y: 0}, {X: 1, Y: 0},
+	{X: -1, Y: 1}, {X: 0, Y: 1}, {X: 1, Y: 1},
+}
+
+// neighbors returns the open steps from a position
+func (s *searchSpace) neighbors(pos spatial.Position, _ *spatial.Position) []successor {
+	var candidates []spatial.Position
+	switch s.grid.GetShape() {
+	case spatial.GridShapeSquare:
+		candidates = make([]spatial.Position, 0, len(squareDirections))
+		for _, dir := range squareDirections {
+			candidates = append(candidates, pos.Add(dir))
+		}
+	case spatial.GridShapeGridless:
+		candidates = s.latticeNeighbors(pos)
+	default:
+		candidates = s.grid.GetNeighbors(pos)
+	}
+
+	successors := make([]successor, 0, len(candidates))
+	for _, candidate := range candidates {
+		if cost, ok := s.step(pos, candidate); ok {
+			successors = append(successors, successor{pos: candidate, cost: cost})
+		}
+	}
+	return successors
+}
+
+// latticeNeighbors returns the gridless lattice positions around pos, plus the
+// goal once it is within one step. Lattice positions are computed from their
+// whole-unit offset to the start so repeated steps don't drift.
+func (s *searchSpace) latticeNeighbors(pos spatial.Position) []spatial.Position {
+	offsetX := math.Round(pos.X - s.start.X)
+	offsetY := math.Round(pos.Y - s.start.Y)
+
+	candidates := make([]spatial.Position, 0, len(squareDirections)+1)
+	for _, dir := range squareDirections {
+		candidates = append(candidates, spatial.Position{
+			X: s.start.X + offsetX + dir.X,
+			Y: s.start.Y + offsetY + dir.Y,
+		})
+	}
+	if s.goal != nil && !pos.Equals(*s.goal) && s.grid.Distance(pos, *s.goal) <= math.Sqrt2 {
+		candidates = append(candidates, *s.goal)
+	}
+	return candidates
+}
+
+// searchResult holds the cheapest known cost to each settled position and how it was reached
+type searchResult struct {
+	cost     map[spatial.Position]float64
+	cameFrom map[spatial.Position]spatial.Position
+}
+
+// search runs A* toward the goal, or Dijkstra over everything within maxCost when
+// there is no goal. maxCost 0 means no limit when there is a goal.
+func (s *searchSpace) search(
+	successors func(pos spatial.Position, parent *spatial.Position) []successor, maxCost float64,
+) searchResult {
+	result := searchResult{
+		cost:     map[spatial.Position]float64{s.start: 0},
+		cameFrom: make(map[spatial.Position]spatial.Position),
+	}
+	limited := maxCost > 0 || s.goal == nil
+	settled := make(map[spatial.Position]bool)
+
+	queue := &nodeQueue{}
+	heap.Push(queue, &node{pos: s.start, priority: s.heuristic(s.start)})
+	for queue.Len() > 0 {
+		current := heap.Pop(queue).(*node)
+		if settled[current.pos] {
+			continue
+		}
+		settled[current.pos] = true
+		if s.goal != nil && current.pos.Equals(*s.goal) {
+			break
+		}
+
+		var parent *spatial.Position
+		if from, ok := result.cameFrom[current.pos]; ok {
+			parent = &from
+		}
+		for _, next := range successors(current.pos, parent) {
+			if settled[next.pos] {
+				continue
+			}
+			cost := result.cost[current.pos] + next.cost
+			if limited && cost > maxCost {
+				continue
+			}
+			if known, ok := result.cost[next.pos]; ok && known <= cost {
+				continue
+			}
+			result.cost[next.pos] = cost
+			result.cameFrom[next.pos] = current.pos
+			heap.Push(queue, &node{pos: next.pos, cost: cost, priority: cost + s.heuristic(next.pos)})
+		}
+	}
+
+	// Positions that were queued but never settled may still have a cheaper path
+	for pos := range result.cost {
+		if !settled[pos] {
+			delete(result.cost, pos)
+			delete(result.cameFrom, pos)
+		}
+	}
+	return result
+}
+
+// heuristic estimates the cost from pos to the goal
+func (s *searchSpace) heuristic(pos spatial.Position) float64 {
+	if s.goal == nil {
+		return 0
+	}
+	return s.grid.Distance(pos, *s.goal)
+}
+
+// stepsTo rebuilds the path to the goal. Jump point searches settle positions
+// several steps apart, so expand fills in the straight or diagonal runs between them.
+func (r searchResult) stepsTo(goal spatial.Position, expand bool) []spatial.Position {
+	// waypoints runs from the goal back to the start
+	waypoints := []spatial.Position{goal}
+	for pos := goal; ; {
+		from, ok := r.cameFrom[pos]
+		if !ok {
+			break
+		}
+		waypoints = append(waypoints, from)
+		pos = from
+	}
+
+	steps := make([]spatial.Position, 0, len(waypoints)-1)
+	for i := len(waypoints) - 1; i > 0; i-- {
+		from, to := waypoints[i], waypoints[i-1]
+		if !expand {
+			steps = append(steps, to)
+			continue
+		}
+		dx, dy := sign(to.X-from.X), sign(to.Y-from.Y)
+		for pos := from; !pos.Equals(to); {
+			pos = spatial.Position{X: pos.X + dx, Y: pos.Y + dy}
+			steps = append(steps, pos)
+		}
+	}
+	return steps
+}
+
+// sign returns -1, 0 or 1 matching the sign of v
+func sign(v float64) float64 {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// node is a queued search position
+type node struct {
+	pos      spatial.Position
+	cost     float64
+	priority float64
+	seq      int
+}
+
+// nodeQueue is a min-heap of nodes by priority, then by cost, then by insertion
+type nodeQueue struct {
+	nodes []*node
+	next  int
+}
+
+func (q *nodeQueue) Len() int { return len(q.nodes) }
+
+func (q *nodeQueue) Less(i, j int) bool {
+	a, b := q.nodes[i], q.nodes[j]
+	if a.priority != b.priority {
+		return a.priority < b.priority
+	}
+	if a.cost != b.cost {
+		// Prefer the node closer to the goal
+		return a.cost > b.cost
+	}
+	return a.seq < b.seq
+}
+
+func (q *nodeQueue) Swap(i, j int) { q.nodes[i], q.nodes[j] = q.nodes[j], q.nodes[i] }
+
+func (q *nodeQueue) Push(x any) {
+	n := x.(*node)
+	n.seq = q.next
+	q.next++
+	q.nodes = append(q.nodes, n)
+}
+
+func (q *nodeQueue) Pop() any {
+	last := q.nodes[len(q.nodes)-1]
+	q.nodes = q.nodes[:len(q.nodes)-1]
+	return last
+}
@@ -0,0 +1,290 @@
+package pathfind_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/core"
+	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
+	"github.com/KirkDiggler/rpg-toolkit/tools/spatial/pathfind"
+)
+
+// testEntity is a placeable entity with an optional collision radius
+type testEntity struct {
+	id       string
+	blocking bool
+	radius   float64
+}
+
+func (e *testEntity) GetID() string            { return e.id }
+func (e *testEntity) GetType() core.EntityType { return "test" }
+func (e *testEntity) GetSize() int             { return 1 }
+func (e *testEntity) BlocksMovement() bool     { return e.blocking }
+func (e *testEntity) BlocksLineOfSight() bool  { return e.blocking }
+func (e *testEntity) GetRadius() float64       { return e.radius }
+
+type FinderTestSuite struct {
+	suite.Suite
+	room   *spatial.BasicRoom
+	goblin *testEntity
+	walls  int
+}
+
+func (s *FinderTestSuite) SetupTest() {
+	s.room = newSquareRoom(10, 10)
+	s.goblin = &testEntity{id: "goblin", blocking: true}
+	s.walls = 0
+}
+
+func TestFinderSuite(t *testing.T) {
+	suite.Run(t, new(FinderTestSuite))
+}
+
+func newSquareRoom(width, height float64) *spatial.BasicRoom {
+	return spatial.NewBasicRoom(spatial.BasicRoomConfig{
+		ID:   "cave",
+		Type: "dungeon",
+		Grid: spatial.NewSquareGrid(spatial.SquareGridConfig{Width: width, Height: height}),
+	})
+}
+
+// wall places blocking entities at the positions
+func (s *FinderTestSuite) wall(positions ...spatial.Position) {
+	for _, pos := range positions {
+		s.walls++
+		wall := &testEntity{id: fmt.Sprintf("wall-%d", s.walls), blocking: true}
+		s.Require().NoError(s.room.PlaceEntity(wall, pos))
+	}
+}
+
+func (s *FinderTestSuite) finder(config pathfind.FinderConfig) *pathfind.Finder {
+	finder, err := pathfind.NewFinder(config)
+	s.Require().NoError(err)
+	return finder
+}
+
+func (s *FinderTestSuite) TestStraightPath() {
+	for _, algorithm := range []pathfind.Algorithm{pathfind.AlgorithmAStar, pathfind.AlgorithmJPS} {
+		path, err := s.finder(pathfind.FinderConfig{Algorithm: algorithm}).
+			FindPath(s.room, s.goblin, spatial.Position{X: 0, Y: 0}, spatial.Position{X: 4, Y: 0})
+		s.Require().NoError(err, algorithm)
+		s.Equal([]spatial.Position{{X: 1, Y: 0}, {X: 2, Y: 0}, {X: 3, Y: 0}, {X: 4, Y: 0}}, path.Steps, algorithm)
+		s.Equal(4.0, path.Cost, algorithm)
+	}
+}
+
+func (s *FinderTestSuite) TestPathAroundWall() {
+	// A wall across x=3 from y=0 to y=4 forces a detour below it
+	s.wall(
+		spatial.Position{X: 3, Y: 0}, spatial.Position{X: 3, Y: 1}, spatial.Position{X: 3, Y: 2},
+		spatial.Position{X: 3, Y: 3}, spatial.Position{X: 3, Y: 4},
+	)
+
+	for _, algorithm := range []pathfind.Algorithm{pathfind.AlgorithmAStar, pathfind.AlgorithmJPS} {
+		path, err := s.finder(pathfind.FinderConfig{Algorithm: algorithm}).
+			FindPath(s.room, s.goblin, spatial.Position{X: 1, Y: 1}, spatial.Position{X: 5, Y: 1})
+		s.Require().NoError(err, algorithm)
+		// The corners of the wall can't be cut, so the path rounds them orthogonally
+		s.Equal(10.0, path.Cost, algorithm)
+		s.Len(path.Steps, 10, algorithm)
+		s.Equal(spatial.Position{X: 5, Y: 1}, path.Steps[len(path.Steps)-1])
+		s.NoError(s.room.ValidatePath(s.goblin, spatial.Position{X: 1, Y: 1}, path.Steps))
+	}
+}
+
+func (s *FinderTestSuite) TestNoCornerCutting() {
+	// Walls at (1,0) and (0,1) leave only the diagonal (0,0) -> (1,1), which cuts both corners
+	s.wall(spatial.Position{X: 1, Y: 0}, spatial.Position{X: 0, Y: 1})
+
+	_, err := s.finder(pathfind.FinderConfig{}).
+		FindPath(s.room, s.goblin, spatial.Position{X: 0, Y: 0}, spatial.Position{X: 1, Y: 1})
+	s.ErrorIs(err, pathfind.ErrNoPath)
+}
+
+func (s *FinderTestSuite) TestOccupancy() {
+	orc := &testEntity{id: "orc", blocking: true}
+	s.Require().NoError(s.room.PlaceEntity(orc, spatial.Position{X: 2, Y: 2}))
+	rat := &testEntity{id: "rat"}
+	s.Require().NoError(s.room.PlaceEntity(rat, spatial.Position{X: 3, Y: 2}))
+
+	s.Run("blocking creatures can't be entered", func() {
+		_, err := s.finder(pathfind.FinderConfig{}).
+			FindPath(s.room, s.goblin, spatial.Position{X: 0, Y: 2}, spatial.Position{X: 2, Y: 2})
+		s.ErrorIs(err, pathfind.ErrNoPath)
+	})
+
+	s.Run("paths go around blocking creatures and through others", func() {
+		path, err := s.finder(pathfind.FinderConfig{}).
+			FindPath(s.room, s.goblin, spatial.Position{X: 1, Y: 2}, spatial.Position{X: 3, Y: 2})
+		s.Require().NoError(err)
+		// Diagonals can't cut past the orc either, so the detour is orthogonal
+		s.Equal(4.0, path.Cost)
+		s.NotContains(path.Steps, spatial.Position{X: 2, Y: 2})
+	})
+
+	s.Run("the mover doesn't block itself", func() {
+		path, err := s.finder(pathfind.FinderConfig{}).
+			FindPath(s.room, orc, spatial.Position{X: 2, Y: 2}, spatial.Position{X: 4, Y: 2})
+		s.Require().NoError(err)
+		s.Equal(2.0, path.Cost)
+	})
+}
+
+func (s *FinderTestSuite) TestCostFunction() {
+	// Difficult terrain across y=1..3 at x=2 costs double; the path goes around it
+	difficult := map[spatial.Position]bool{{X: 2, Y: 1}: true, {X: 2, Y: 2}: true, {X: 2, Y: 3}: true}
+	cost := func(_, to spatial.Position) float64 {
+		if difficult[to] {
+			return 2
+		}
+		return 1
+	}
+
+	path, err := s.finder(pathfind.FinderConfig{Cost: cost}).
+		FindPath(s.room, s.goblin, spatial.Position{X: 0, Y: 2}, spatial.Position{X: 4, Y: 2})
+	s.Require().NoError(err)
+	s.Equal(4.0, path.Cost)
+	for _, step := range path.Steps {
+		s.False(difficult[step], "step %v is difficult terrain", step)
+	}
+
+	s.Run("negative cost forbids the step", func() {
+		forbid := func(_, to spatial.Position) float64 {
+			if to.X == 2 {
+				return -1
+			}
+			return 1
+		}
+		_, err := s.finder(pathfind.FinderConfig{Cost: forbid}).
+			FindPath(s.room, s.goblin, spatial.Position{X: 0, Y: 2}, spatial.Position{X: 4, Y: 2})
+		s.ErrorIs(err, pathfind.ErrNoPath)
+	})
+}
+
+func (s *FinderTestSuite) TestMaxCost() {
+	finder := s.finder(pathfind.FinderConfig{MaxCost: 3})
+
+	_, err := finder.FindPath(s.room, s.goblin, spatial.Position{X: 0, Y: 0}, spatial.Position{X: 3, Y: 3})
+	s.NoError(err)
+
+	_, err = finder.FindPath(s.room, s.goblin, spatial.Position{X: 0, Y: 0}, spatial.Position{X: 4, Y: 0})
+	s.ErrorIs(err, pathfind.ErrNoPath)
+}
+
+func (s *FinderTestSuite) TestReachable() {
+	s.wall(spatial.Position{X: 1, Y: 0})
+
+	reachable, err := s.finder(pathfind.FinderConfig{}).
+		Reachable(s.room, s.goblin, spatial.Position{X: 0, Y: 0}, 2)
+	s.Require().NoError(err)
+
+	s.Equal(map[spatial.Position]float64{
+		{X: 0, Y: 0}: 0,
+		{X: 0, Y: 1}: 1,
+		{X: 0, Y: 2}: 2,
+		{X: 1, Y: 2}: 2,
+		// The wall's corner can't be cut, so (1,1) takes two steps
+		{X: 1, Y: 1}: 2,
+	}, reachable)
+}
+
+func (s *FinderTestSuite) TestInvalidInput() {
+	_, err := pathfind.NewFinder(pathfind.FinderConfig{Algorithm: "dijkstra"})
+	s.Error(err)
+	_, err = pathfind.NewFinder(pathfind.FinderConfig{MaxCost: -1})
+	s.Error(err)
+
+	finder := s.finder(pathfind.FinderConfig{})
+	_, err = finder.FindPath(s.room, s.goblin, spatial.Position{X: -1, Y: 0}, spatial.Position{X: 1, Y: 0})
+	s.Error(err)
+	_, err = finder.FindPath(s.room, s.goblin, spatial.Position{X: 0, Y: 0}, spatial.Position{X: 10, Y: 0})
+	s.Error(err)
+	_, err = finder.FindPath(s.room, nil, spatial.Position{X: 0, Y: 0}, spatial.Position{X: 1, Y: 0})
+	s.Error(err)
+
+	path, err := finder.FindPath(s.room, s.goblin, spatial.Position{X: 2, Y: 2}, spatial.Position{X: 2, Y: 2})
+	s.Require().NoError(err)
+	s.Empty(path.Steps)
+}
+
+func (s *FinderTestSuite) TestHexGrid() {
+	room := spatial.NewBasicRoom(spatial.BasicRoomConfig{
+		ID:   "hive",
+		Type: "dungeon",
+		Grid: spatial.NewHexGrid(spatial.HexGridConfig{Width: 10, Height: 10}),
+	})
+	wall := &testEntity{id: "wall", blocking: true}
+	s.Require().NoError(room.PlaceEntity(wall, spatial.Position{X: 2, Y: 2}))
+
+	from, to := spatial.Position{X: 0, Y: 2}, spatial.Position{X: 4, Y: 2}
+	path, err := s.finder(pathfind.FinderConfig{Algorithm: pathfind.AlgorithmJPS}).FindPath(room, s.goblin, from, to)
+	s.Require().NoError(err)
+	s.Equal(to, path.Steps[len(path.Steps)-1])
+	s.NotContains(path.Steps, spatial.Position{X: 2, Y: 2})
+	s.Equal(float64(len(path.Steps)), path.Cost)
+
+	previous := from
+	for _, step := range path.Steps {
+		s.True(room.GetGrid().IsAdjacent(previous, step), "%v -> %v", previous, step)
+		previous = step
+	}
+}
+
+func (s *FinderTestSuite) TestGridless() {
+	room := spatial.NewBasicRoom(spatial.BasicRoomConfig{
+		ID:   "glade",
+		Type: "outdoor",
+		Grid: spatial.NewGridlessRoom(spatial.GridlessConfig{Width: 12, Height: 12}),
+	})
+	boulder := &testEntity{id: "boulder", blocking: true, radius: 1.5}
+	s.Require().NoError(room.PlaceEntity(boulder, spatial.Position{X: 6, Y: 6}))
+	wolf := &testEntity{id: "wolf", blocking: true, radius: 0.4}
+
+	from, to := spatial.Position{X: 2.5, Y: 6}, spatial.Position{X: 9.7, Y: 6.2}
+	path, err := s.finder(pathfind.FinderConfig{}).FindPath(room, wolf, from, to)
+	s.Require().NoError(err)
+	s.Equal(to, path.Steps[len(path.Steps)-1])
+	s.NoError(room.ValidatePath(wolf, from, path.Steps))
+	s.Greater(path.Cost, room.GetGrid().Distance(from, to))
+}
+
+// TestJPSMatchesAStar compares path costs on random square rooms
+func (s *FinderTestSuite) TestJPSMatchesAStar() {
+	random := rand.New(rand.NewSource(7)) //nolint:gosec // deterministic test rooms
+	astar := s.finder(pathfind.FinderConfig{Algorithm: pathfind.AlgorithmAStar})
+	jps := s.finder(pathfind.FinderConfig{Algorithm: pathfind.AlgorithmJPS})
+
+	for trial := 0; trial < 50; trial++ {
+		room := newSquareRoom(16, 16)
+		for i := 0; i < 70; i++ {
+			wall := &testEntity{id: fmt.Sprintf("wall-%d-%d", trial, i), blocking: true}
+			_ = room.PlaceEntity(wall, spatial.Position{X: float64(random.Intn(16)), Y: float64(random.Intn(16))})
+		}
+		from := spatial.Position{X: float64(random.Intn(16)), Y: float64(random.Intn(16))}
+		to := spatial.Position{X: float64(random.Intn(16)), Y: float64(random.Intn(16))}
+		if !room.CanPlaceEntity(s.goblin, from) {
+			continue
+		}
+
+		expected, expectedErr := astar.FindPath(room, s.goblin, from, to)
+		actual, actualErr := jps.FindPath(room, s.goblin, from, to)
+		if expectedErr != nil {
+			s.Error(actualErr, "trial %d", trial)
+			continue
+		}
+		s.Require().NoError(actualErr, "trial %d", trial)
+		s.Equal(expected.Cost, actual.Cost, "trial %d", trial)
+		s.Equal(int(actual.Cost), len(actual.Steps), "trial %d", trial)
+		s.NoError(room.ValidatePath(s.goblin, from, actual.Steps), "trial %d", trial)
+
+		previous := from
+		for _, step := range actual.Steps {
+			s.LessOrEqual(math.Max(math.Abs(step.X-previous.X), math.Abs(step.Y-previous.Y)), 1.0, "trial %d", trial)
+			previous = step
+		}
+	}
+}
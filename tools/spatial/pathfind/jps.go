@@ -0,0 +1,83 @@
+package pathfind
+
+import (
+	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
+)
+
+// jumpPoints returns the jump points reachable from pos on a square grid.
+// Directions are pruned by the direction pos was reached from: a diagonal
+// continues diagonally and along both of its axes, and a straight move continues
+// forward and may turn aside, since the corner rule can force a turn there.
+// Each successor costs the Chebyshev distance to it.
+func (s *searchSpace) jumpPoints(pos spatial.Position, parent *spatial.Position) []successor {
+	var directions []spatial.Position
+	switch {
+	case parent == nil:
+		directions = squareDirections
+	default:
+		dx, dy := sign(pos.X-parent.X), sign(pos.Y-parent.Y)
+		switch {
+		case dx != 0 && dy != 0:
+			directions = []spatial.Position{{X: dx, Y: dy}, {X: dx, Y: 0}, {X: 0, Y: dy}}
+		case dx != 0:
+			directions = []spatial.Position{
+				{X: dx, Y: 0}, {X: 0, Y: -1}, {X: 0, Y: 1}, {X: dx, Y: -1}, {X: dx, Y: 1},
+			}
+		default:
+			directions = []spatial.Position{
+				{X: 0, Y: dy}, {X: -1, Y: 0}, {X: 1, Y: 0}, {X: -1, Y: dy}, {X: 1, Y: dy},
+			}
+		}
+	}
+
+	successors := make([]successor, 0, len(directions))
+	for _, dir := range directions {
+		if jumpPoint, ok := s.jump(pos, dir.X, dir.Y); ok {
+			successors = append(successors, successor{pos: jumpPoint, cost: s.grid.Distance(pos, jumpPoint)})
+		}
+	}
+	return successors
+}
+
+// jump moves from pos in one direction until it reaches the goal or a position
+// where the path may need to turn. Returns false if it runs into a blocked step.
+func (s *searchSpace) jump(pos spatial.Position, dx, dy float64) (spatial.Position, bool) {
+	for {
+		next := spatial.Position{X: pos.X + dx, Y: pos.Y + dy}
+		if _, ok := s.step(pos, next); !ok {
+			return spatial.Position{}, false
+		}
+		pos = next
+		if pos.Equals(*s.goal) {
+			return pos, true
+		}
+
+		switch {
+		case dx != 0 && dy != 0:
+			// A diagonal stops where a run along either axis finds a jump point
+			if _, ok := s.jump(pos, dx, 0); ok {
+				return pos, true
+			}
+			if _, ok := s.jump(pos, 0, dy); ok {
+				return pos, true
+			}
+		case dx != 0:
+			// A side position that couldn't be reached diagonally from behind forces a turn
+			if s.forced(pos, 0, -1, -dx, 0) || s.forced(pos, 0, 1, -dx, 0) {
+				return pos, true
+			}
+		default:
+			if s.forced(pos, -1, 0, 0, -dy) || s.forced(pos, 1, 0, 0, -dy) {
+				return pos, true
+			}
+		}
+	}
+}
+
+// forced reports whether the side position of pos is open while the position
+// behind it is blocked, so the side position must be reached through pos
+func (s *searchSpace) forced(pos spatial.Position, sideX, sideY, backX, backY float64) bool {
+	side := spatial.Position{X: pos.X + sideX, Y: pos.Y + sideY}
+	behind := spatial.Position{X: side.X + backX, Y: side.Y + backY}
+	return s.open(side) && !s.open(behind)
+}
@@ -122,8 +122,12 @@ func (h *SpatialQueryHandler) handleMovement(_ context.Context, data *QueryMovem
 		return data, nil
 	}
 
-	// Check if the entity can move to the target position
-	data.Valid = room.CanPlaceEntity(data.Entity, data.To)
+	// Check if the entity can move to the target position, and along the way if the room can tell
+	if validator, ok := room.(pathValidator); ok {
+		data.Valid = validator.ValidatePath(data.Entity, data.From, []Position{data.To}) == nil
+	} else {
+		data.Valid = room.CanPlaceEntity(data.Entity, data.To)
+	}
 
 	// Calculate distance using the room's grid
 	data.Distance = room.GetGrid().Distance(data.From, data.To)
@@ -134,6 +138,11 @@ func (h *SpatialQueryHandler) handleMovement(_ context.Context, data *QueryMovem
 	return data, nil
 }
 
+// pathValidator is implemented by rooms that can check movement along a path, like BasicRoom
+type pathValidator interface {
+	ValidatePath(entity core.Entity, from Position, path []Position) error
+}
+
 // handlePlacement handles placement queries
 //
 //nolint:unparam // error is always nil by design - errors are stored in data struct
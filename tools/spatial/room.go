@@ -3,6 +3,7 @@ package spatial
 import (
 	"context"
 	"fmt"
+	"math"
 	"sync"
 	"time"
 
@@ -20,6 +21,7 @@ type BasicRoom struct {
 	entityPlacements events.TypedTopic[EntityPlacedEvent]
 	entityMovements  events.TypedTopic[EntityMovedEvent]
 	entityRemovals   events.TypedTopic[EntityRemovedEvent]
+	areaExplored     events.TypedTopic[AreaExploredEvent]
 	roomCreated      events.TypedTopic[RoomCreatedEvent]
 
 	// Triple entity tracking for efficient lookups
@@ -27,6 +29,9 @@ type BasicRoom struct {
 	positions map[string]Position    // ID -> Position
 	occupancy map[Position][]string  // Position -> []EntityID
 
+	// Cells each observer has explored, keyed by observer ID
+	explored map[string]map[Position]bool
+
 	// Mutex for thread-safe access
 	mutex sync.RWMutex
 }
@@ -49,6 +54,7 @@ func NewBasicRoom(config BasicRoomConfig) *BasicRoom {
 		entities:  make(map[string]core.Entity),
 		positions: make(map[string]Position),
 		occupancy: make(map[Position][]string),
+		explored:  make(map[string]map[Position]bool),
 	}
 
 	return room
@@ -59,6 +65,7 @@ func (r *BasicRoom) ConnectToEventBus(bus events.EventBus) {
 	r.entityPlacements = EntityPlacedTopic.On(bus)
 	r.entityMovements = EntityMovedTopic.On(bus)
 	r.entityRemovals = EntityRemovedTopic.On(bus)
+	r.areaExplored = AreaExploredTopic.On(bus)
 	r.roomCreated = RoomCreatedTopic.On(bus)
 
 	// Now emit room creation event since we're connected
@@ -219,10 +226,15 @@ func (r *BasicRoom) RemoveEntity(entityID string) error {
 }
 
 // GetEntitiesAt returns all entities at a specific position
+// In gridless rooms this includes every entity whose collision radius covers the position
 func (r *BasicRoom) GetEntitiesAt(pos Position) []core.Entity {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
 
+	if r.isGridless() {
+		return r.entitiesCoveringUnsafe(pos)
+	}
+
 	entityIDs, exists := r.occupancy[pos]
 	if !exists {
 		return []core.Entity{}
@@ -275,14 +287,20 @@ func (r *BasicRoom) GetAllEntities() map[string]core.Entity {
 }
 
 // GetEntitiesInRange returns entities within a given range
+// In gridless rooms range is measured to the nearest edge of each entity's collision radius
 func (r *BasicRoom) GetEntitiesInRange(center Position, radius float64) []core.Entity {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
 
 	entities := make([]core.Entity, 0)
+	gridless := r.isGridless()
 
 	for entityID, pos := range r.positions {
-		if r.grid.Distance(center, pos) <= radius {
+		distance := r.grid.Distance(center, pos)
+		if gridless {
+			distance -= radiusOf(r.entities[entityID])
+		}
+		if distance <= radius {
 			if entity, exists := r.entities[entityID]; exists {
 				entities = append(entities, entity)
 			}
@@ -293,10 +311,15 @@ func (r *BasicRoom) GetEntitiesInRange(center Position, radius float64) []core.E
 }
 
 // IsPositionOccupied checks if a position is occupied
+// In gridless rooms a position is occupied if any entity's collision radius covers it
 func (r *BasicRoom) IsPositionOccupied(pos Position) bool {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
 
+	if r.isGridless() {
+		return len(r.entitiesCoveringUnsafe(pos)) > 0
+	}
+
 	entityIDs, exists := r.occupancy[pos]
 	return exists && len(entityIDs) > 0
 }
@@ -333,6 +356,20 @@ func (r *BasicRoom) canPlaceEntityUnsafe(entity core.Entity, pos Position) bool
 		}
 	}
 
+	// In gridless rooms blocking entities can't overlap either
+	if r.isGridless() {
+		placed := Circle{Center: pos, Radius: radiusOf(entity)}
+		for entityID, otherPos := range r.positions {
+			other := r.entities[entityID]
+			if entityID == entity.GetID() || !blocksMovement(other) {
+				continue
+			}
+			if CirclesOverlap(placed, Circle{Center: otherPos, Radius: radiusOf(other)}) {
+				return false
+			}
+		}
+	}
+
 	return true
 }
 
@@ -373,9 +410,32 @@ func (r *BasicRoom) GetLineOfSight(from, to Position) []Position {
 }
 
 // IsLineOfSightBlocked checks if line of sight is blocked by entities
+// In gridless rooms the sight line is blocked if it passes through the collision
+// radius of a blocking entity, other than entities covering either end
 func (r *BasicRoom) IsLineOfSightBlocked(from, to Position) bool {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
+	return r.isLineOfSightBlockedUnsafe(from, to)
+}
+
+// isLineOfSightBlockedUnsafe checks line of sight without acquiring the lock
+func (r *BasicRoom) isLineOfSightBlockedUnsafe(from, to Position) bool {
+	if r.isGridless() {
+		for entityID, pos := range r.positions {
+			entity := r.entities[entityID]
+			if !blocksLineOfSight(entity) {
+				continue
+			}
+			circle := Circle{Center: pos, Radius: radiusOf(entity)}
+			if covers(circle, from) || covers(circle, to) {
+				continue
+			}
+			if SegmentIntersectsCircle(from, to, circle) {
+				return true
+			}
+		}
+		return false
+	}
 
 	losPositions := r.grid.GetLineOfSight(from, to)
 
@@ -398,6 +458,41 @@ func (r *BasicRoom) IsLineOfSightBlocked(from, to Position) bool {
 	return false
 }
 
+// ValidatePath checks that entity can move from along each segment of path in turn.
+// Every waypoint must be a position the entity can be placed at. In gridless rooms
+// each segment is also swept by the entity's collision radius and must not pass
+// through a blocking entity; grid rooms check the waypoints only.
+func (r *BasicRoom) ValidatePath(entity core.Entity, from Position, path []Position) error {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	gridless := r.isGridless()
+	radius := radiusOf(entity)
+	start := from
+
+	for _, waypoint := range path {
+		if !r.canPlaceEntityUnsafe(entity, waypoint) {
+			return fmt.Errorf("entity %s cannot be moved to position %v", entity.GetID(), waypoint)
+		}
+
+		if gridless {
+			for entityID, pos := range r.positions {
+				other := r.entities[entityID]
+				if entityID == entity.GetID() || !blocksMovement(other) {
+					continue
+				}
+				swept := Circle{Center: pos, Radius: radius + radiusOf(other)}
+				if SegmentIntersectsCircle(start, waypoint, swept) {
+					return fmt.Errorf("path from %v to %v is blocked by entity %s", start, waypoint, entityID)
+				}
+			}
+		}
+		start = waypoint
+	}
+
+	return nil
+}
+
 // GetEntityCount returns the number of entities in the room
 func (r *BasicRoom) GetEntityCount() int {
 	r.mutex.RLock()
@@ -419,6 +514,48 @@ func (r *BasicRoom) GetOccupiedPositions() []Position {
 	return positions
 }
 
+// isGridless reports whether the room uses continuous gridless positions
+func (r *BasicRoom) isGridless() bool {
+	return r.grid.GetShape() == GridShapeGridless
+}
+
+// entitiesCoveringUnsafe returns entities whose collision radius covers a position (without locking)
+func (r *BasicRoom) entitiesCoveringUnsafe(pos Position) []core.Entity {
+	entities := make([]core.Entity, 0)
+	for entityID, entityPos := range r.positions {
+		entity := r.entities[entityID]
+		if covers(Circle{Center: entityPos, Radius: radiusOf(entity)}, pos) {
+			entities = append(entities, entity)
+		}
+	}
+	return entities
+}
+
+// covers checks if a position is inside or on the edge of a circle
+func covers(circle Circle, pos Position) bool {
+	return math.Hypot(pos.X-circle.Center.X, pos.Y-circle.Center.Y) <= circle.Radius
+}
+
+// radiusOf returns an entity's collision radius, 0 if it isn't a Collider
+func radiusOf(entity core.Entity) float64 {
+	if collider, ok := entity.(Collider); ok {
+		return math.Max(0, collider.GetRadius())
+	}
+	return 0
+}
+
+// blocksMovement reports whether an entity is Placeable and blocks movement
+func blocksMovement(entity core.Entity) bool {
+	placeable, ok := entity.(Placeable)
+	return ok && placeable.BlocksMovement()
+}
+
+// blocksLineOfSight reports whether an entity is Placeable and blocks line of sight
+func blocksLineOfSight(entity core.Entity) bool {
+	placeable, ok := entity.(Placeable)
+	return ok && placeable.BlocksLineOfSight()
+}
+
 // gridShapeToString converts GridShape to string for events
 func gridShapeToString(shape GridShape) string {
 	switch shape {
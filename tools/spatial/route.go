@@ -0,0 +1,227 @@
+package spatial
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// RouteMode controls which waypoint a route continues to after each arrival
+type RouteMode string
+
+const (
+	// RouteModeLoop returns to the first waypoint after the last
+	RouteModeLoop RouteMode = "loop"
+	// RouteModePingPong walks the waypoints back in reverse after the last
+	RouteModePingPong RouteMode = "ping_pong"
+	// RouteModeRandom picks any other waypoint at random
+	RouteModeRandom RouteMode = "random"
+)
+
+// Waypoint is a stop on a route
+type Waypoint struct {
+	// Position is where the entity stops
+	Position Position `json:"position"`
+
+	// Dwell is how many updates the entity waits at the waypoint before moving on
+	Dwell int `json:"dwell,omitempty"`
+}
+
+// Route is a sequence of waypoints for guards and wandering monsters to follow
+type Route struct {
+	// ID identifies the route
+	ID string `json:"id"`
+
+	// Mode controls the order waypoints are visited in
+	Mode RouteMode `json:"mode"`
+
+	// Waypoints are the route's stops, visited from the first
+	Waypoints []Waypoint `json:"waypoints"`
+}
+
+// Validate checks the route has waypoints, a known mode and no negative dwell
+func (r Route) Validate() error {
+	if len(r.Waypoints) == 0 {
+		return fmt.Errorf("route %s has no waypoints", r.ID)
+	}
+	switch r.Mode {
+	case RouteModeLoop, RouteModePingPong, RouteModeRandom:
+	default:
+		return fmt.Errorf("route %s has unknown mode: %s", r.ID, r.Mode)
+	}
+	for i, waypoint := range r.Waypoints {
+		if waypoint.Dwell < 0 {
+			return fmt.Errorf("route %s waypoint %d has negative dwell: %d", r.ID, i, waypoint.Dwell)
+		}
+	}
+	return nil
+}
+
+// RouteStatus describes what a route follower wants its entity to do
+type RouteStatus string
+
+const (
+	// RouteStatusMoving means the entity should move toward the target waypoint
+	RouteStatusMoving RouteStatus = "moving"
+	// RouteStatusDwelling means the entity should wait where it is
+	RouteStatusDwelling RouteStatus = "dwelling"
+	// RouteStatusBlocked means the way to the target waypoint is blocked
+	RouteStatusBlocked RouteStatus = "blocked"
+)
+
+// RouteStep is the movement a route follower requests for one update
+type RouteStep struct {
+	// Status is what the entity should do
+	Status RouteStatus
+
+	// From is the entity's current position
+	From Position
+
+	// To is the target waypoint's position
+	To Position
+
+	// Waypoint is the index of the target waypoint
+	Waypoint int
+
+	// Reason explains why the step is blocked
+	Reason string
+}
+
+// RouteFollowerConfig holds configuration for creating a route follower
+type RouteFollowerConfig struct {
+	// EntityID is the entity that follows the route
+	EntityID string
+
+	// Room holds the entity. Rooms that can validate paths, like BasicRoom, have
+	// each leg checked for blockers; others only check the waypoint is placeable.
+	Room Room
+
+	// Route is the route to follow
+	Route Route
+
+	// Random returns a number in [0, n) for random routes (default: math/rand)
+	Random func(n int) int
+}
+
+// RouteFollower walks an entity along a route one update at a time
+// Purpose: Provides the waypoint bookkeeping for patrols and wandering so each
+// game's AI only decides when to follow the route and how far to move per update.
+// The follower never moves the entity itself; the game performs the requested
+// movement with its own speed and rules, and the follower notices arrival on the
+// next update.
+type RouteFollower struct {
+	entityID string
+	room     Room
+	route    Route
+	random   func(n int) int
+
+	target    int
+	direction int
+	dwelling  int
+}
+
+// NewRouteFollower creates a follower that starts toward the route's first waypoint
+func NewRouteFollower(config RouteFollowerConfig) (*RouteFollower, error) {
+	if config.EntityID == "" {
+		return nil, fmt.Errorf("entity ID cannot be empty")
+	}
+	if config.Room == nil {
+		return nil, fmt.Errorf("room cannot be nil")
+	}
+	if err := config.Route.Validate(); err != nil {
+		return nil, err
+	}
+
+	random := config.Random
+	if random == nil {
+		random = rand.Intn //nolint:gosec // patrol order doesn't need cryptographic randomness
+	}
+
+	return &RouteFollower{
+		entityID:  config.EntityID,
+		room:      config.Room,
+		route:     config.Route,
+		random:    random,
+		direction: 1,
+	}, nil
+}
+
+// GetRoute returns the route being followed
+func (f *RouteFollower) GetRoute() Route {
+	return f.route
+}
+
+// GetTarget returns the index of the waypoint the entity is heading to or waiting at
+func (f *RouteFollower) GetTarget() int {
+	return f.target
+}
+
+// Update returns the step the entity should take now. Arriving at a waypoint
+// starts its dwell; once the dwell is over the follower targets the next waypoint
+// chosen by the route's mode.
+// Returns an error if the entity is not in the room
+func (f *RouteFollower) Update() (RouteStep, error) {
+	pos, exists := f.room.GetEntityPosition(f.entityID)
+	if !exists {
+		return RouteStep{}, fmt.Errorf("entity %s not found in room %s", f.entityID, f.room.GetID())
+	}
+
+	if f.dwelling == 0 && pos.Equals(f.route.Waypoints[f.target].Position) {
+		f.dwelling = f.route.Waypoints[f.target].Dwell + 1
+	}
+	if f.dwelling > 0 {
+		f.dwelling--
+		if f.dwelling > 0 {
+			return RouteStep{Status: RouteStatusDwelling, From: pos, To: pos, Waypoint: f.target}, nil
+		}
+		f.advance()
+	}
+
+	step := RouteStep{
+		Status:   RouteStatusMoving,
+		From:     pos,
+		To:       f.route.Waypoints[f.target].Position,
+		Waypoint: f.target,
+	}
+	if pos.Equals(step.To) {
+		// A single waypoint route, or a random pick of the same spot, has nowhere to go
+		step.Status = RouteStatusDwelling
+		return step, nil
+	}
+
+	entity := f.room.GetAllEntities()[f.entityID]
+	if validator, ok := f.room.(pathValidator); ok {
+		if err := validator.ValidatePath(entity, pos, []Position{step.To}); err != nil {
+			step.Status = RouteStatusBlocked
+			step.Reason = err.Error()
+		}
+	} else if !f.room.CanPlaceEntity(entity, step.To) {
+		step.Status = RouteStatusBlocked
+		step.Reason = fmt.Sprintf("entity %s cannot be placed at position %v", f.entityID, step.To)
+	}
+
+	return step, nil
+}
+
+// advance targets the next waypoint for the route's mode
+func (f *RouteFollower) advance() {
+	count := len(f.route.Waypoints)
+	if count == 1 {
+		return
+	}
+
+	switch f.route.Mode {
+	case RouteModePingPong:
+		if f.target+f.direction < 0 || f.target+f.direction >= count {
+			f.direction = -f.direction
+		}
+		f.target += f.direction
+	case RouteModeRandom:
+		next := f.random(count - 1)
+		if next >= f.target {
+			next++
+		}
+		f.target = next
+	default:
+		f.target = (f.target + 1) % count
+	}
+}
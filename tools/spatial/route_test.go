@@ -0,0 +1,164 @@
+package spatial_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/KirkDiggler/rpg-toolkit/tools/spatial"
+)
+
+// RouteTestSuite tests waypoint routes and the route follower
+type RouteTestSuite struct {
+	suite.Suite
+	room  *spatial.BasicRoom
+	guard *MockEntity
+}
+
+func (s *RouteTestSuite) SetupTest() {
+	s.room = spatial.NewBasicRoom(spatial.BasicRoomConfig{
+		ID:   "courtyard",
+		Type: "outdoor",
+		Grid: spatial.NewSquareGrid(spatial.SquareGridConfig{Width: 10, Height: 10}),
+	})
+	s.guard = NewMockEntity("guard", "npc").WithBlocking(true, false)
+	s.Require().NoError(s.room.PlaceEntity(s.guard, spatial.Position{X: 0, Y: 0}))
+}
+
+func TestRouteSuite(t *testing.T) {
+	suite.Run(t, new(RouteTestSuite))
+}
+
+func (s *RouteTestSuite) newFollower(mode spatial.RouteMode, waypoints ...spatial.Waypoint) *spatial.RouteFollower {
+	follower, err := spatial.NewRouteFollower(spatial.RouteFollowerConfig{
+		EntityID: "guard",
+		Room:     s.room,
+		Route:    spatial.Route{ID: "patrol", Mode: mode, Waypoints: waypoints},
+		Random:   func(int) int { return 0 },
+	})
+	s.Require().NoError(err)
+	return follower
+}
+
+// walk moves the guard wherever the follower asks and returns the targets visited
+func (s *RouteTestSuite) walk(follower *spatial.RouteFollower, updates int) []int {
+	var targets []int
+	for i := 0; i < updates; i++ {
+		step, err := follower.Update()
+		s.Require().NoError(err)
+		if step.Status == spatial.RouteStatusMoving {
+			targets = append(targets, step.Waypoint)
+			s.Require().NoError(s.room.MoveEntity("guard", step.To))
+		}
+	}
+	return targets
+}
+
+func (s *RouteTestSuite) TestModes() {
+	waypoints := []spatial.Waypoint{
+		{Position: spatial.Position{X: 0, Y: 0}},
+		{Position: spatial.Position{X: 5, Y: 0}},
+		{Position: spatial.Position{X: 5, Y: 5}},
+	}
+
+	testCases := []struct {
+		mode spatial.RouteMode
+		want []int
+	}{
+		{mode: spatial.RouteModeLoop, want: []int{1, 2, 0, 1, 2}},
+		{mode: spatial.RouteModePingPong, want: []int{1, 2, 1, 0, 1}},
+		{mode: spatial.RouteModeRandom, want: []int{1, 0, 1, 0, 1}},
+	}
+
+	for _, tc := range testCases {
+		s.Run(string(tc.mode), func() {
+			s.Require().NoError(s.room.MoveEntity("guard", spatial.Position{X: 0, Y: 0}))
+			s.Equal(tc.want, s.walk(s.newFollower(tc.mode, waypoints...), 5))
+		})
+	}
+}
+
+func (s *RouteTestSuite) TestDwell() {
+	follower := s.newFollower(spatial.RouteModeLoop,
+		spatial.Waypoint{Position: spatial.Position{X: 0, Y: 0}, Dwell: 2},
+		spatial.Waypoint{Position: spatial.Position{X: 3, Y: 0}},
+	)
+
+	var statuses []spatial.RouteStatus
+	for i := 0; i < 3; i++ {
+		step, err := follower.Update()
+		s.Require().NoError(err)
+		statuses = append(statuses, step.Status)
+	}
+	s.Equal([]spatial.RouteStatus{
+		spatial.RouteStatusDwelling,
+		spatial.RouteStatusDwelling,
+		spatial.RouteStatusMoving,
+	}, statuses)
+	s.Equal(1, follower.GetTarget())
+}
+
+func (s *RouteTestSuite) TestPartialMovesKeepTarget() {
+	follower := s.newFollower(spatial.RouteModeLoop,
+		spatial.Waypoint{Position: spatial.Position{X: 0, Y: 0}},
+		spatial.Waypoint{Position: spatial.Position{X: 8, Y: 0}},
+	)
+
+	step, err := follower.Update()
+	s.Require().NoError(err)
+	s.Equal(spatial.Position{X: 8, Y: 0}, step.To)
+
+	// The game only moves the guard part of the way this update
+	s.Require().NoError(s.room.MoveEntity("guard", spatial.Position{X: 3, Y: 0}))
+	step, err = follower.Update()
+	s.Require().NoError(err)
+	s.Equal(spatial.RouteStatusMoving, step.Status)
+	s.Equal(spatial.Position{X: 3, Y: 0}, step.From)
+	s.Equal(1, step.Waypoint)
+}
+
+func (s *RouteTestSuite) TestBlockedWaypoint() {
+	crate := NewMockEntity("crate", "object").WithBlocking(true, false)
+	s.Require().NoError(s.room.PlaceEntity(crate, spatial.Position{X: 4, Y: 4}))
+
+	follower := s.newFollower(spatial.RouteModeLoop,
+		spatial.Waypoint{Position: spatial.Position{X: 0, Y: 0}},
+		spatial.Waypoint{Position: spatial.Position{X: 4, Y: 4}},
+	)
+	step, err := follower.Update()
+	s.Require().NoError(err)
+	s.Equal(spatial.RouteStatusBlocked, step.Status)
+	s.NotEmpty(step.Reason)
+}
+
+func (s *RouteTestSuite) TestInvalidConfig() {
+	testCases := []struct {
+		name   string
+		config spatial.RouteFollowerConfig
+	}{
+		{name: "no waypoints", config: spatial.RouteFollowerConfig{
+			EntityID: "guard", Room: s.room, Route: spatial.Route{Mode: spatial.RouteModeLoop},
+		}},
+		{name: "unknown mode", config: spatial.RouteFollowerConfig{
+			EntityID: "guard", Room: s.room, Route: spatial.Route{Mode: "zigzag", Waypoints: []spatial.Waypoint{{}}},
+		}},
+		{name: "negative dwell", config: spatial.RouteFollowerConfig{
+			EntityID: "guard", Room: s.room, Route: spatial.Route{Mode: spatial.RouteModeLoop, Waypoints: []spatial.Waypoint{{Dwell: -1}}},
+		}},
+		{name: "no room", config: spatial.RouteFollowerConfig{
+			EntityID: "guard", Route: spatial.Route{Mode: spatial.RouteModeLoop, Waypoints: []spatial.Waypoint{{}}},
+		}},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			_, err := spatial.NewRouteFollower(tc.config)
+			s.Error(err)
+		})
+	}
+
+	follower := s.newFollower(spatial.RouteModeLoop, spatial.Waypoint{})
+	s.Require().NoError(s.room.RemoveEntity("guard"))
+	_, err := follower.Update()
+	s.Error(err)
+}
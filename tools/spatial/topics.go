@@ -20,6 +20,8 @@ var (
 
 	// RoomCreatedTopic publishes events when rooms are created
 	RoomCreatedTopic = events.DefineTypedTopic[RoomCreatedEvent]("spatial.room.created")
+	// AreaExploredTopic publishes events when an observer explores new cells of a room
+	AreaExploredTopic = events.DefineTypedTopic[AreaExploredEvent]("spatial.room.explored")
 
 	// RoomAddedTopic publishes events when rooms are added to orchestrators
 	RoomAddedTopic = events.DefineTypedTopic[RoomAddedEvent]("spatial.orchestrator.room_added")
@@ -77,6 +79,13 @@ type RoomCreatedEvent struct {
 	CreationTime time.Time `json:"creation_time"`
 }
 
+// AreaExploredEvent contains the cells an observer explored for the first time
+type AreaExploredEvent struct {
+	RoomID     string     `json:"room_id"`
+	ObserverID string     `json:"observer_id"`
+	Cells      []Position `json:"cells"`
+}
+
 // RoomAddedEvent contains data for room addition to orchestrator events
 type RoomAddedEvent struct {
 	OrchestratorID string    `json:"orchestrator_id"`